@@ -0,0 +1,633 @@
+package terma
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+var agendaNowLine = strings.Repeat("─", 200)
+
+// AgendaEvent is a scheduled block of time shown by Agenda.
+type AgendaEvent struct {
+	ID       string        // Optional identifier for the application's use
+	Title    string        // Shown inside the block
+	Start    time.Time     // When the event begins
+	Duration time.Duration // How long it lasts
+	Color    Color         // Block background (default: theme.Primary)
+}
+
+// End returns when the event finishes.
+func (e AgendaEvent) End() time.Time {
+	return e.Start.Add(e.Duration)
+}
+
+// AgendaView selects how many days an Agenda shows.
+type AgendaView int
+
+const (
+	// AgendaWeek shows the week containing the cursor, starting on the
+	// locale's first weekday.
+	AgendaWeek AgendaView = iota
+	// AgendaDay shows only the cursor's day.
+	AgendaDay
+)
+
+// AgendaState holds the state for an Agenda widget.
+type AgendaState struct {
+	Events   AnySignal[[]AgendaEvent] // Reactive event data
+	Cursor   AnySignal[time.Time]     // Start of the time slot under the cursor
+	Selected Signal[int]              // Index of the selected event in Events (-1 = none)
+}
+
+// NewAgendaState creates a new AgendaState with the given events and the
+// cursor at the start of the current hour.
+func NewAgendaState(events []AgendaEvent) *AgendaState {
+	if events == nil {
+		events = []AgendaEvent{}
+	}
+	return &AgendaState{
+		Events:   NewAnySignal(events),
+		Cursor:   NewAnySignal(Now().Truncate(time.Hour)),
+		Selected: NewSignal(-1),
+	}
+}
+
+// SetEvents replaces all events and clears the event selection.
+func (s *AgendaState) SetEvents(events []AgendaEvent) {
+	if events == nil {
+		events = []AgendaEvent{}
+	}
+	s.Events.Set(events)
+	s.Selected.Set(-1)
+}
+
+// GetEvents returns the current events (without subscribing).
+func (s *AgendaState) GetEvents() []AgendaEvent {
+	return s.Events.Peek()
+}
+
+// SelectedEvent returns the selected event, if there is one.
+func (s *AgendaState) SelectedEvent() (AgendaEvent, bool) {
+	events := s.Events.Peek()
+	idx := s.Selected.Peek()
+	if idx < 0 || idx >= len(events) {
+		return AgendaEvent{}, false
+	}
+	return events[idx], true
+}
+
+// SelectEvent selects the event at index and moves the cursor to its start.
+func (s *AgendaState) SelectEvent(index int) {
+	events := s.Events.Peek()
+	if index < 0 || index >= len(events) {
+		return
+	}
+	s.Selected.Set(index)
+	s.Cursor.Set(events[index].Start)
+}
+
+// SetCursor moves the cursor to t and clears the event selection.
+func (s *AgendaState) SetCursor(t time.Time) {
+	s.Cursor.Set(t)
+	s.Selected.Set(-1)
+}
+
+// Agenda is a day or week calendar: a time axis down the left, one column
+// per day, and events drawn as blocks spanning their time slots.
+// Overlapping events share their day column side by side, and a line
+// marks the current time.
+//
+// The cursor moves between time slots; tab and shift+tab move between
+// events. Enter (or clicking an event) calls OnEventSelect for the selected
+// event, or OnSlotSelect for the slot under the cursor, which is the usual
+// place to start creating an event. Clicking a slot moves the cursor there,
+// and clicking it again selects it.
+//
+// Example:
+//
+//	state := terma.NewAgendaState(events)
+//	terma.Agenda{
+//	    State:        state,
+//	    ScrollState:  scroll,
+//	    StartHour:    8,
+//	    EndHour:      20,
+//	    OnSlotSelect: func(start, end time.Time) { openNewEventDialog(start, end) },
+//	    Style:        terma.Style{Height: terma.Flex(1)},
+//	}
+//
+// Keys: up/down (k/j) move a slot, left/right (h/l) move a day, [ and ]
+// move a week (a day in AgendaDay), t jumps to now.
+type Agenda struct {
+	ID            string                                        // Optional unique identifier
+	DisableFocus  bool                                          // If true, prevent keyboard focus
+	State         *AgendaState                                  // Required - holds events and the cursor
+	ScrollState   *ScrollState                                  // Optional - scrolls the time grid below the day headers
+	View          AgendaView                                    // Week (default) or day
+	SlotDuration  time.Duration                                 // Time covered by each row (default 30 minutes)
+	StartHour     int                                           // First hour shown (default 0)
+	EndHour       int                                           // Hour the grid ends at (default 24)
+	Now           func() time.Time                              // Optional clock for the current-time line (default = terma.Now)
+	OnEventSelect func(event AgendaEvent)                       // Called when an event is activated
+	OnSlotSelect  func(start, end time.Time)                    // Called when an empty slot is activated
+	RenderEvent   func(event AgendaEvent, selected bool) Widget // Optional block renderer
+	Style         Style                                         // Optional styling
+}
+
+// agendaBlock is an event laid out in a day column.
+type agendaBlock struct {
+	index   int // Index in AgendaState.Events
+	event   AgendaEvent
+	row     int // First slot row
+	rows    int // Number of slot rows
+	lane    int // Column within its cluster of overlapping events
+	cluster int
+}
+
+// WidgetID returns the widget's unique identifier.
+// Implements the Identifiable interface.
+func (a Agenda) WidgetID() string {
+	return a.ID
+}
+
+// GetContentDimensions returns the width and height dimension preferences.
+// Implements the Dimensioned interface.
+func (a Agenda) GetContentDimensions() (width, height Dimension) {
+	dims := a.Style.GetDimensions()
+	return dims.Width, dims.Height
+}
+
+// GetStyle returns the style of the agenda.
+// Implements the Styled interface.
+func (a Agenda) GetStyle() Style {
+	return a.Style
+}
+
+// IsFocusable returns true to allow keyboard navigation.
+// Implements the Focusable interface.
+func (a Agenda) IsFocusable() bool {
+	return !a.DisableFocus
+}
+
+// OnKey handles keys not covered by declarative keybindings.
+// Implements the Focusable interface.
+func (a Agenda) OnKey(event KeyEvent) bool {
+	return false
+}
+
+// Keybinds returns the declarative keybindings for this agenda.
+func (a Agenda) Keybinds() []Keybind {
+	if a.State == nil {
+		return nil
+	}
+	period := 7
+	if a.View == AgendaDay {
+		period = 1
+	}
+	return []Keybind{
+		{Key: "enter", Action: a.activate, Hidden: true},
+		{Key: "up", Action: func() { a.moveSlots(-1) }, Hidden: true},
+		{Key: "k", Action: func() { a.moveSlots(-1) }, Hidden: true},
+		{Key: "down", Action: func() { a.moveSlots(1) }, Hidden: true},
+		{Key: "j", Action: func() { a.moveSlots(1) }, Hidden: true},
+		{Key: "left", Action: func() { a.moveDays(-1) }, Hidden: true},
+		{Key: "h", Action: func() { a.moveDays(-1) }, Hidden: true},
+		{Key: "right", Action: func() { a.moveDays(1) }, Hidden: true},
+		{Key: "l", Action: func() { a.moveDays(1) }, Hidden: true},
+		{Key: "[", Action: func() { a.moveDays(-period) }, Hidden: true},
+		{Key: "]", Action: func() { a.moveDays(period) }, Hidden: true},
+		{Key: "tab", Action: func() { a.moveEvent(1) }, Hidden: true},
+		{Key: "shift+tab", Action: func() { a.moveEvent(-1) }, Hidden: true},
+		{Key: "t", Action: a.jumpToNow, Hidden: true},
+	}
+}
+
+// Build returns the day headers above the time axis and day columns.
+func (a Agenda) Build(ctx BuildContext) Widget {
+	if a.State == nil {
+		return Column{}
+	}
+	theme := ctx.Theme()
+	locale := ctx.Locale()
+	focused := ctx.IsFocused(a)
+
+	events := a.State.Events.Get()
+	cursor := a.State.Cursor.Get()
+	selected := a.State.Selected.Get()
+	clockSignal(time.Minute).Get()
+	now := a.now()
+
+	slot, startHour, endHour := a.slotDuration(), a.startHour(), a.endHour()
+	rowCount := int(time.Duration(endHour-startHour) * time.Hour / slot)
+
+	// Time axis
+	axisLabels := make([]string, rowCount)
+	axisWidth := 0
+	for row := range axisLabels {
+		at := time.Date(2000, 1, 1, startHour, 0, 0, 0, time.UTC).Add(time.Duration(row) * slot)
+		if at.Minute() == 0 {
+			axisLabels[row] = locale.FormatTime(at)
+			axisWidth = max(axisWidth, ansi.StringWidth(axisLabels[row]))
+		}
+	}
+	axisWidth++
+
+	days := a.days(cursor)
+	nowRow := -1
+	for _, day := range days {
+		if sameDay(day, now) {
+			if row, ok := a.rowAt(day, now); ok {
+				nowRow = row
+			}
+		}
+	}
+
+	axis := make([]Widget, rowCount)
+	for row, label := range axisLabels {
+		style := Style{ForegroundColor: theme.TextMuted}
+		if row == nowRow {
+			label = locale.FormatTime(now)
+			style.ForegroundColor = theme.Error
+			style.Bold = true
+		}
+		if label == "" {
+			label = " "
+		}
+		axis[row] = Text{Content: label, Style: style}
+	}
+
+	header := []Widget{Spacer{Width: Cells(axisWidth), Height: Cells(1)}}
+	body := []Widget{Column{Style: Style{Width: Cells(axisWidth)}, Children: axis}}
+	for _, day := range days {
+		style := Style{ForegroundColor: theme.TextMuted, Width: Flex(1), Margin: EdgeInsets{Left: 1}}
+		if sameDay(day, now) {
+			style.ForegroundColor = theme.Primary
+			style.Bold = true
+		}
+		header = append(header, Text{
+			Content:   locale.WeekdayAbbrevs[day.Weekday()] + " " + day.Format("2"),
+			TextAlign: TextAlignCenter,
+			Truncate:  TruncateEnd,
+			Style:     style,
+		})
+
+		cursorRow := -1
+		if selected < 0 && sameDay(day, cursor) {
+			if row, ok := a.rowAt(day, cursor); ok {
+				cursorRow = row
+			}
+		}
+		dayNowRow := -1
+		if sameDay(day, now) {
+			dayNowRow = nowRow
+		}
+		body = append(body, a.buildDay(ctx, day, a.layoutDay(events, day), rowCount, cursorRow, dayNowRow, selected, focused))
+	}
+
+	grid := Widget(Row{Children: body})
+	if a.ScrollState != nil {
+		grid = Scrollable{State: a.ScrollState, Style: Style{Height: Flex(1)}, Child: grid}
+	}
+	return Column{
+		ID:         a.ID,
+		Style:      a.Style,
+		CrossAlign: CrossAxisStretch,
+		Children:   []Widget{Row{Children: header}, grid},
+	}
+}
+
+// buildDay returns one day column: clickable slot rows underneath, event
+// blocks in lanes above them, and the current-time line on top.
+func (a Agenda) buildDay(ctx BuildContext, day time.Time, blocks []agendaBlock, rowCount, cursorRow, nowRow, selected int, focused bool) Widget {
+	theme := ctx.Theme()
+	slot := a.slotDuration()
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), a.startHour(), 0, 0, 0, day.Location())
+
+	slots := make([]Widget, rowCount)
+	for row := range slots {
+		style := Style{Width: Flex(1)}
+		if row == cursorRow {
+			style.BackgroundColor = theme.Selection
+			if focused {
+				style.BackgroundColor = theme.ActiveCursor
+			}
+		}
+		start := dayStart.Add(time.Duration(row) * slot)
+		slots[row] = Text{Content: " ", Style: style, Click: func(MouseEvent) { a.clickSlot(start) }}
+	}
+
+	// Clusters of overlapping blocks become Rows of lanes; the gaps between
+	// them are empty so the slot rows show through.
+	var segments []Widget
+	row := 0
+	for i := 0; i < len(blocks); {
+		j := i
+		top, bottom, lanes := blocks[i].row, 0, 0
+		for ; j < len(blocks) && blocks[j].cluster == blocks[i].cluster; j++ {
+			bottom = max(bottom, blocks[j].row+blocks[j].rows)
+			lanes = max(lanes, blocks[j].lane+1)
+		}
+		if top > row {
+			segments = append(segments, Spacer{Width: Flex(1), Height: Cells(top - row)})
+		}
+		laneChildren := make([][]Widget, lanes)
+		laneRows := make([]int, lanes)
+		for _, block := range blocks[i:j] {
+			if gap := block.row - top - laneRows[block.lane]; gap > 0 {
+				laneChildren[block.lane] = append(laneChildren[block.lane], Spacer{Width: Flex(1), Height: Cells(gap)})
+			}
+			laneChildren[block.lane] = append(laneChildren[block.lane], a.buildBlock(ctx, block, block.index == selected, focused))
+			laneRows[block.lane] = block.row + block.rows - top
+		}
+		laneWidgets := make([]Widget, lanes)
+		for lane, children := range laneChildren {
+			laneWidgets[lane] = Column{Style: Style{Width: Flex(1)}, Children: children}
+		}
+		segments = append(segments, Row{Style: Style{Width: Flex(1), Height: Cells(bottom - top)}, Children: laneWidgets})
+		row = bottom
+		i = j
+	}
+
+	children := []Widget{
+		Column{Style: Style{Width: Flex(1)}, Children: slots},
+		Positioned{Top: IntPtr(0), Left: IntPtr(0), Right: IntPtr(0), Child: Column{Children: segments}},
+	}
+	if nowRow >= 0 {
+		children = append(children, Positioned{
+			Top:   IntPtr(nowRow),
+			Left:  IntPtr(0),
+			Right: IntPtr(0),
+			Child: Text{Content: agendaNowLine, Style: Style{ForegroundColor: theme.Error}},
+		})
+	}
+	return Stack{Style: Style{Width: Flex(1), Margin: EdgeInsets{Left: 1}}, Children: children}
+}
+
+// buildBlock renders one event block.
+func (a Agenda) buildBlock(ctx BuildContext, block agendaBlock, selected, focused bool) Widget {
+	var child Widget
+	if a.RenderEvent != nil {
+		child = a.RenderEvent(block.event, selected)
+	} else {
+		theme := ctx.Theme()
+		style := Style{
+			BackgroundColor: block.event.Color,
+			ForegroundColor: theme.TextOnPrimary,
+			Padding:         EdgeInsets{Left: 1},
+		}
+		if !style.BackgroundColor.IsSet() {
+			style.BackgroundColor = theme.Primary
+		}
+		if selected {
+			style.BackgroundColor = theme.Selection
+			style.ForegroundColor = theme.SelectionText
+			if focused {
+				style.BackgroundColor = theme.ActiveCursor
+			}
+		}
+		content := block.event.Title
+		if block.rows > 1 {
+			locale := ctx.Locale()
+			content += "\n" + locale.FormatTime(block.event.Start) + "–" + locale.FormatTime(block.event.End())
+		}
+		child = Text{Content: content, Truncate: TruncateEnd, Style: style}
+	}
+	index := block.index
+	return Stack{
+		Style:    Style{Width: Flex(1), Height: Cells(block.rows), Margin: EdgeInsets{Right: 1}},
+		Children: []Widget{PositionedFill(child)},
+		Click:    func(MouseEvent) { a.clickEvent(index) },
+	}
+}
+
+// layoutDay places the events that fall on day into slot rows and lanes.
+// Blocks are sorted by row; those sharing a row, directly or through a
+// chain of other blocks, form a cluster that splits the column into lanes.
+func (a Agenda) layoutDay(events []AgendaEvent, day time.Time) []agendaBlock {
+	slot := a.slotDuration()
+	visibleStart := time.Date(day.Year(), day.Month(), day.Day(), a.startHour(), 0, 0, 0, day.Location())
+	visibleEnd := visibleStart.Add(time.Duration(a.endHour()-a.startHour()) * time.Hour)
+
+	var blocks []agendaBlock
+	for i, event := range events {
+		start, end := event.Start, event.End()
+		if !start.Before(visibleEnd) || end.Before(visibleStart) || (end.Equal(visibleStart) && event.Duration > 0) {
+			continue
+		}
+		if start.Before(visibleStart) {
+			start = visibleStart
+		}
+		if end.After(visibleEnd) {
+			end = visibleEnd
+		}
+		row := int(start.Sub(visibleStart) / slot)
+		endRow := int((end.Sub(visibleStart) + slot - 1) / slot)
+		blocks = append(blocks, agendaBlock{index: i, event: event, row: row, rows: max(endRow-row, 1)})
+	}
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if blocks[i].row != blocks[j].row {
+			return blocks[i].row < blocks[j].row
+		}
+		return blocks[i].rows > blocks[j].rows
+	})
+
+	cluster, clusterEnd := 0, 0
+	var laneEnds []int
+	for i := range blocks {
+		block := &blocks[i]
+		if block.row >= clusterEnd {
+			cluster++
+			laneEnds = laneEnds[:0]
+		}
+		block.cluster = cluster
+		block.lane = len(laneEnds)
+		for lane, end := range laneEnds {
+			if end <= block.row {
+				block.lane = lane
+				break
+			}
+		}
+		if block.lane == len(laneEnds) {
+			laneEnds = append(laneEnds, 0)
+		}
+		laneEnds[block.lane] = block.row + block.rows
+		clusterEnd = max(clusterEnd, block.row+block.rows)
+	}
+	return blocks
+}
+
+// days returns midnight of each day shown for the cursor.
+func (a Agenda) days(cursor time.Time) []time.Time {
+	first := time.Date(cursor.Year(), cursor.Month(), cursor.Day(), 0, 0, 0, 0, cursor.Location())
+	if a.View == AgendaDay {
+		return []time.Time{first}
+	}
+	back := (int(first.Weekday()) - int(CurrentLocale().FirstWeekday) + 7) % 7
+	first = first.AddDate(0, 0, -back)
+	days := make([]time.Time, 7)
+	for i := range days {
+		days[i] = first.AddDate(0, 0, i)
+	}
+	return days
+}
+
+// rowAt returns the slot row containing t on day.
+func (a Agenda) rowAt(day, t time.Time) (int, bool) {
+	start := time.Date(day.Year(), day.Month(), day.Day(), a.startHour(), 0, 0, 0, day.Location())
+	if t.Before(start) {
+		return 0, false
+	}
+	row := int(t.Sub(start) / a.slotDuration())
+	if row >= int(time.Duration(a.endHour()-a.startHour())*time.Hour/a.slotDuration()) {
+		return 0, false
+	}
+	return row, true
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+func (a Agenda) slotDuration() time.Duration {
+	if a.SlotDuration > 0 {
+		return a.SlotDuration
+	}
+	return 30 * time.Minute
+}
+
+func (a Agenda) startHour() int {
+	return clampInt(a.StartHour, 0, 23)
+}
+
+func (a Agenda) endHour() int {
+	if a.EndHour <= a.startHour() || a.EndHour > 24 {
+		return 24
+	}
+	return a.EndHour
+}
+
+func (a Agenda) now() time.Time {
+	if a.Now != nil {
+		return a.Now()
+	}
+	return Now()
+}
+
+// clampToGrid snaps t to the start of its slot within the visible hours.
+func (a Agenda) clampToGrid(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), a.startHour(), 0, 0, 0, t.Location())
+	last := day.Add(time.Duration(a.endHour()-a.startHour())*time.Hour - a.slotDuration())
+	if t.Before(day) {
+		return day
+	}
+	if t.After(last) {
+		return last
+	}
+	return day.Add(t.Sub(day) / a.slotDuration() * a.slotDuration())
+}
+
+func (a Agenda) moveSlots(delta int) {
+	cursor := a.clampToGrid(a.State.Cursor.Peek())
+	a.State.SetCursor(a.clampToGrid(cursor.Add(time.Duration(delta) * a.slotDuration())))
+	a.scrollIntoView()
+}
+
+func (a Agenda) moveDays(delta int) {
+	a.State.SetCursor(a.clampToGrid(a.State.Cursor.Peek().AddDate(0, 0, delta)))
+	a.scrollIntoView()
+}
+
+// moveEvent selects the next (delta > 0) or previous event in time order
+// from the cursor.
+func (a Agenda) moveEvent(delta int) {
+	events := a.State.Events.Peek()
+	order := make([]int, len(events))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return events[order[i]].Start.Before(events[order[j]].Start) })
+
+	current := a.State.Selected.Peek()
+	cursor := a.State.Cursor.Peek()
+	pos := -1
+	for i, idx := range order {
+		if idx == current {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		// Start from the cursor: the first event at or after it going
+		// forwards, the last one before it going backwards.
+		pos = len(order)
+		for i, idx := range order {
+			if !events[idx].Start.Before(cursor) {
+				pos = i
+				break
+			}
+		}
+		if delta > 0 {
+			pos--
+		}
+	}
+	next := pos + delta
+	if next < 0 || next >= len(order) {
+		return
+	}
+	a.State.SelectEvent(order[next])
+	a.scrollIntoView()
+}
+
+func (a Agenda) jumpToNow() {
+	a.State.SetCursor(a.clampToGrid(a.now()))
+	a.scrollIntoView()
+}
+
+func (a Agenda) activate() {
+	if event, ok := a.State.SelectedEvent(); ok {
+		if a.OnEventSelect != nil {
+			a.OnEventSelect(event)
+		}
+		return
+	}
+	if a.OnSlotSelect != nil {
+		start := a.clampToGrid(a.State.Cursor.Peek())
+		a.OnSlotSelect(start, start.Add(a.slotDuration()))
+	}
+}
+
+func (a Agenda) clickSlot(start time.Time) {
+	if _, ok := a.State.SelectedEvent(); !ok && a.State.Cursor.Peek().Equal(start) {
+		a.activate()
+		return
+	}
+	a.State.SetCursor(start)
+}
+
+func (a Agenda) clickEvent(index int) {
+	a.State.SelectEvent(index)
+	a.activate()
+}
+
+// scrollIntoView scrolls the selected event, or the cursor slot, into view.
+func (a Agenda) scrollIntoView() {
+	if a.ScrollState == nil {
+		return
+	}
+	cursor := a.State.Cursor.Peek()
+	row, ok := a.rowAt(cursor, cursor)
+	if !ok {
+		return
+	}
+	height := 1
+	if event, ok := a.State.SelectedEvent(); ok {
+		for _, block := range a.layoutDay([]AgendaEvent{event}, cursor) {
+			row, height = block.row, block.rows
+		}
+	}
+	a.ScrollState.ScrollToView(row, height)
+}
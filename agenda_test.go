@@ -0,0 +1,98 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var agendaTestDay = time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+
+func agendaTestEvents() []AgendaEvent {
+	at := func(hour, minute int) time.Time {
+		return agendaTestDay.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	return []AgendaEvent{
+		{Title: "Lunch", Start: at(12, 0), Duration: time.Hour},
+		{Title: "Standup", Start: at(9, 0), Duration: 30 * time.Minute},
+		{Title: "Design review", Start: at(9, 15), Duration: time.Hour},
+		{Title: "1:1", Start: at(10, 0), Duration: 30 * time.Minute},
+	}
+}
+
+func TestAgenda_LayoutDayOverlaps(t *testing.T) {
+	agenda := Agenda{StartHour: 8, EndHour: 14}
+	blocks := agenda.layoutDay(agendaTestEvents(), agendaTestDay)
+	require.Len(t, blocks, 4)
+
+	type placed struct {
+		title                    string
+		row, rows, lane, cluster int
+	}
+	var got []placed
+	for _, block := range blocks {
+		got = append(got, placed{block.event.Title, block.row, block.rows, block.lane, block.cluster})
+	}
+	assert.Equal(t, []placed{
+		{"Design review", 2, 3, 0, 1},
+		{"Standup", 2, 1, 1, 1},
+		{"1:1", 4, 1, 1, 1},
+		{"Lunch", 8, 2, 0, 2},
+	}, got, "1:1 reuses the lane Standup has finished with")
+}
+
+func TestAgenda_KeyboardAndCallbacks(t *testing.T) {
+	state := NewAgendaState(agendaTestEvents())
+	state.SetCursor(agendaTestDay.Add(8 * time.Hour))
+	var activated []string
+	agenda := Agenda{
+		State:         state,
+		StartHour:     8,
+		EndHour:       14,
+		OnEventSelect: func(event AgendaEvent) { activated = append(activated, event.Title) },
+		OnSlotSelect: func(start, end time.Time) {
+			activated = append(activated, start.Format("15:04")+"-"+end.Format("15:04"))
+		},
+	}
+
+	agenda.moveEvent(1)
+	agenda.moveEvent(1)
+	agenda.activate()
+	agenda.moveEvent(-1)
+	agenda.activate()
+	agenda.moveSlots(-1)
+	agenda.activate()
+	agenda.moveDays(1)
+	agenda.activate()
+
+	assert.Equal(t, []string{"Design review", "Standup", "08:30-09:00", "08:30-09:00"}, activated)
+	assert.Equal(t, agendaTestDay.AddDate(0, 0, 1).Add(8*time.Hour+30*time.Minute), state.Cursor.Peek())
+
+	agenda.moveSlots(-5)
+	assert.Equal(t, 8, state.Cursor.Peek().Hour(), "the cursor stays within the visible hours")
+}
+
+func TestAgenda_RendersBlocksAndNowLine(t *testing.T) {
+	SetLocale("en-GB")
+	t.Cleanup(func() { SetLocale("en") })
+	state := NewAgendaState(agendaTestEvents())
+	state.SetCursor(agendaTestDay)
+	agenda := Agenda{
+		State:     state,
+		View:      AgendaDay,
+		StartHour: 8,
+		EndHour:   14,
+		Now:       func() time.Time { return agendaTestDay.Add(10*time.Hour + 40*time.Minute) },
+	}
+
+	lines := strings.Split(screenText(agenda, 40, 13), "\n")
+	assert.Contains(t, lines[0], "Wed 4")
+	assert.Regexp(t, `^09:00 +Design review +Standup`, lines[3])
+	assert.Regexp(t, `^ +09:15–10:15`, lines[4])
+	assert.Regexp(t, `^10:00 {20,}1:1`, lines[5], "1:1 shares Standup's lane")
+	assert.Regexp(t, `^10:40 +────`, lines[6])
+	assert.Regexp(t, `^12:00 +Lunch`, lines[9])
+}
@@ -27,10 +27,12 @@ type animationHandle struct {
 type AnimationController struct {
 	mu         sync.Mutex
 	animations map[*animationHandle]struct{}
-	ticker     *time.Ticker
+	clock      Clock // Clock the ticker was started on
+	ticker     Timer
 	tickChan   chan time.Time
 	fps        int
 	stopped    bool
+	lastUpdate time.Time // When animations were last advanced
 }
 
 // NewAnimationController creates a new controller with the given target FPS.
@@ -104,7 +106,19 @@ func (ac *AnimationController) Update() {
 		return
 	}
 
-	dt := time.Duration(float64(time.Second) / float64(ac.fps))
+	// Advance by the time that actually passed on the clock, so dropped
+	// ticks don't slow animations down and a ManualClock drives them exactly.
+	if ac.clock == nil {
+		ac.mu.Unlock()
+		return
+	}
+	now := ac.clock.Now()
+	dt := now.Sub(ac.lastUpdate)
+	ac.lastUpdate = now
+	if dt <= 0 {
+		ac.mu.Unlock()
+		return
+	}
 
 	// Copy handles to iterate outside the lock
 	handles := make([]*animationHandle, 0, len(ac.animations))
@@ -159,19 +173,16 @@ func (ac *AnimationController) startTicker() {
 		return
 	}
 
+	ac.clock = currentClock()
 	interval := time.Duration(float64(time.Second) / float64(ac.fps))
-	ac.ticker = time.NewTicker(interval)
-
-	// Pump ticker events to tickChan
-	go func() {
-		for t := range ac.ticker.C {
-			select {
-			case ac.tickChan <- t:
-			default:
-				// Drop tick if channel is full (avoid blocking)
-			}
+	ac.lastUpdate = ac.clock.Now()
+	ac.ticker = ac.clock.Every(interval, func(t time.Time) {
+		select {
+		case ac.tickChan <- t:
+		default:
+			// Drop tick if channel is full (avoid blocking)
 		}
-	}()
+	})
 }
 
 // stopTicker halts the animation tick loop.
@@ -0,0 +1,171 @@
+package terma
+
+import (
+	"strings"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ParseANSI converts text containing ANSI escape sequences, such as the
+// colored output of a command, into spans. SGR colors and attributes and
+// OSC 8 hyperlinks are kept, and other sequences are dropped. A carriage
+// return not followed by a newline starts its line over, the way progress
+// bars redraw themselves.
+//
+// Example:
+//
+//	out, _ := exec.Command("git", "-c", "color.ui=always", "status").Output()
+//	t.Text{Spans: t.ParseANSI(string(out))}
+func ParseANSI(text string) []Span {
+	var decoder ansiDecoder
+	var lines spanLines
+	lines.append(decoder.decode(text))
+	return lines.spans()
+}
+
+// ansiDecoder turns text with ANSI escape sequences into spans. The style
+// and hyperlink carry over between calls, and an escape sequence cut off at
+// the end of one call is finished by the next, so a stream can be decoded
+// as it arrives.
+type ansiDecoder struct {
+	pen     uv.Style
+	link    uv.Link
+	pending string // Incomplete sequence, or a "\r" that may start a "\r\n"
+}
+
+// decode returns the spans of text. A lone carriage return comes back as
+// a span of its own with the text "\r".
+func (d *ansiDecoder) decode(text string) []Span {
+	text = d.pending + text
+	d.pending = ""
+
+	var spans []Span
+	var run strings.Builder
+	flush := func() {
+		if run.Len() > 0 {
+			spans = append(spans, Span{Text: run.String(), Style: spanStyleFromUV(d.pen, d.link.URL)})
+			run.Reset()
+		}
+	}
+
+	parser := ansi.NewParser()
+	var state byte
+	for len(text) > 0 {
+		seq, width, n, newState := ansi.DecodeSequence(text, state, parser)
+		if newState != ansi.NormalState && n == len(text) {
+			d.pending = seq
+			break
+		}
+		state = newState
+		text = text[n:]
+
+		switch {
+		case width > 0 || seq == "\n" || seq == "\t":
+			run.WriteString(seq)
+		case seq == "\r":
+			if text == "" {
+				d.pending = seq
+			} else if text[0] != '\n' {
+				flush()
+				spans = append(spans, Span{Text: "\r"})
+			}
+		case ansi.HasCsiPrefix(seq) && parser.Command() == 'm':
+			flush()
+			uv.ReadStyle(parser.Params(), &d.pen)
+		case ansi.HasOscPrefix(seq) && parser.Command() == 8:
+			flush()
+			uv.ReadLink(parser.Data(), &d.link)
+		}
+	}
+	flush()
+	return spans
+}
+
+// spanStyleFromUV converts a cell style read from SGR sequences to a
+// SpanStyle.
+func spanStyleFromUV(style uv.Style, link string) SpanStyle {
+	return SpanStyle{
+		Foreground:     FromANSI(style.Fg),
+		Background:     FromANSI(style.Bg),
+		Bold:           style.Attrs&uv.AttrBold != 0,
+		Faint:          style.Attrs&uv.AttrFaint != 0,
+		Italic:         style.Attrs&uv.AttrItalic != 0,
+		Underline:      fromUVUnderline(style.Underline),
+		UnderlineColor: FromANSI(style.UnderlineColor),
+		Blink:          style.Attrs&(uv.AttrBlink|uv.AttrRapidBlink) != 0,
+		Reverse:        style.Attrs&uv.AttrReverse != 0,
+		Conceal:        style.Attrs&uv.AttrConceal != 0,
+		Strikethrough:  style.Attrs&uv.AttrStrikethrough != 0,
+		Link:           link,
+	}
+}
+
+// fromUVUnderline is the inverse of toUVUnderline.
+func fromUVUnderline(u uv.Underline) UnderlineStyle {
+	switch u {
+	case uv.UnderlineSingle:
+		return UnderlineSingle
+	case uv.UnderlineDouble:
+		return UnderlineDouble
+	case uv.UnderlineCurly:
+		return UnderlineCurly
+	case uv.UnderlineDotted:
+		return UnderlineDotted
+	case uv.UnderlineDashed:
+		return UnderlineDashed
+	default:
+		return UnderlineNone
+	}
+}
+
+// spanLines collects decoded spans into lines.
+type spanLines struct {
+	lines [][]Span
+	open  bool // The last line hasn't been ended by a newline
+}
+
+// append adds spans to the lines, starting a new line at each "\n" and
+// emptying the current one at each "\r" span. It returns how many lines
+// were added.
+func (l *spanLines) append(spans []Span) int {
+	before := len(l.lines)
+	for _, span := range spans {
+		if span.Text == "\r" {
+			if l.open {
+				l.lines[len(l.lines)-1] = nil
+			}
+			continue
+		}
+		for i, part := range strings.Split(span.Text, "\n") {
+			if i > 0 {
+				if !l.open {
+					l.lines = append(l.lines, nil)
+				}
+				l.open = false
+			}
+			if part == "" {
+				continue
+			}
+			if !l.open {
+				l.lines = append(l.lines, nil)
+				l.open = true
+			}
+			last := len(l.lines) - 1
+			l.lines[last] = append(l.lines[last], Span{Text: part, Style: span.Style})
+		}
+	}
+	return len(l.lines) - before
+}
+
+// spans joins the lines back into spans, with a "\n" after each ended line.
+func (l *spanLines) spans() []Span {
+	var spans []Span
+	for i, line := range l.lines {
+		spans = append(spans, line...)
+		if i < len(l.lines)-1 || !l.open {
+			spans = append(spans, Span{Text: "\n"})
+		}
+	}
+	return spans
+}
@@ -0,0 +1,60 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func spansText(spans []Span) string {
+	text := ""
+	for _, span := range spans {
+		text += span.Text
+	}
+	return text
+}
+
+func TestParseANSI_ColorsAndAttributes(t *testing.T) {
+	spans := ParseANSI("\x1b[1;31merror\x1b[0m: \x1b[4;38;2;10;20;30mbad\x1b[24m input")
+
+	require.Len(t, spans, 4)
+	assert.Equal(t, "error", spans[0].Text)
+	assert.True(t, spans[0].Style.Bold)
+	assert.True(t, spans[0].Style.Foreground.IsSet())
+	assert.Equal(t, Span{Text: ": "}, spans[1])
+	assert.Equal(t, UnderlineSingle, spans[2].Style.Underline)
+	assert.Equal(t, RGB(10, 20, 30), spans[2].Style.Foreground)
+	assert.Equal(t, UnderlineNone, spans[3].Style.Underline)
+	assert.Equal(t, RGB(10, 20, 30), spans[3].Style.Foreground)
+}
+
+func TestParseANSI_HyperlinksAndDroppedSequences(t *testing.T) {
+	spans := ParseANSI("\x1b[2J\x1b]8;;https://example.com\x07docs\x1b]8;;\x07 here\x1b[K")
+
+	assert.Equal(t, "docs here", spansText(spans))
+	assert.Equal(t, "https://example.com", spans[0].Style.Link)
+	assert.Equal(t, "", spans[1].Style.Link)
+}
+
+func TestParseANSI_CarriageReturnRestartsLine(t *testing.T) {
+	spans := ParseANSI("start\n10%\r50%\r100%\r\ndone\n")
+
+	assert.Equal(t, "start\n100%\ndone\n", spansText(spans))
+}
+
+func TestANSIDecoder_SequenceSplitAcrossWrites(t *testing.T) {
+	var decoder ansiDecoder
+	var lines spanLines
+
+	lines.append(decoder.decode("a\x1b[3"))
+	lines.append(decoder.decode("2mb\r"))
+	lines.append(decoder.decode("\nc"))
+
+	require.Len(t, lines.lines, 2)
+	assert.Equal(t, "ab", spansText(lines.lines[0]))
+	assert.False(t, lines.lines[0][0].Style.Foreground.IsSet())
+	assert.True(t, lines.lines[0][1].Style.Foreground.IsSet())
+	assert.Equal(t, "c", lines.lines[1][0].Text)
+	assert.True(t, lines.lines[1][0].Style.Foreground.IsSet(), "the style carries over")
+}
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"runtime/debug"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	uv "github.com/charmbracelet/ultraviolet"
@@ -29,6 +32,43 @@ var appRenderer *Renderer
 // Buffered with size 1 to avoid blocking signal setters.
 var renderTrigger chan struct{}
 
+// uiQueue holds functions handed to the event loop by background
+// goroutines, for changes to state that widgets read while building.
+var uiQueue struct {
+	sync.Mutex
+	fns    []func()
+	notify chan struct{} // nil unless Run's event loop is running
+}
+
+// runOnUI runs fn on the event loop, or straight away when no app is
+// running, as in tests.
+func runOnUI(fn func()) {
+	uiQueue.Lock()
+	if uiQueue.notify == nil {
+		uiQueue.Unlock()
+		fn()
+		return
+	}
+	uiQueue.fns = append(uiQueue.fns, fn)
+	notify := uiQueue.notify
+	uiQueue.Unlock()
+	select {
+	case notify <- struct{}{}:
+	default:
+	}
+}
+
+// runUIQueue runs the functions queued by runOnUI, in order.
+func runUIQueue() {
+	uiQueue.Lock()
+	fns := uiQueue.fns
+	uiQueue.fns = nil
+	uiQueue.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
+
 const (
 	clickChainTimeout = 500 * time.Millisecond
 	defaultFPS        = 60
@@ -49,6 +89,7 @@ var terminalDisableSequences = []string{
 	ansi.ResetModeMouseButtonEvent,
 	ansi.ResetModeMouseNormal,
 	ansi.ResetModeMouseExtSgr,
+	ansi.ResetModeMouseExtSgrPixel,
 }
 
 func writeTerminalSequences(writeString func(string) (int, error), sequences []string) {
@@ -217,6 +258,35 @@ func buildMouseEvent(m uv.Mouse, entry *WidgetEntry, clickCount int) MouseEvent
 	}
 }
 
+// mouseTarget returns the widget a mouse press or release at (x, y) goes
+// to. Floats come first; outside them a press may dismiss the top float,
+// and modal floats block the widgets underneath. It returns true when the
+// float logic handled the event, leaving no target.
+func mouseTarget(renderer *Renderer, x, y int, allowDismiss bool) (*WidgetEntry, bool) {
+	// Check if click is on a float
+	if renderer.FloatAt(x, y) != nil {
+		return renderer.WidgetAt(x, y), false
+	}
+
+	// Click is outside all floats - check for dismissal or modal blocking
+	if renderer.HasFloats() {
+		if allowDismiss {
+			topFloat := renderer.TopFloat()
+			if topFloat != nil && topFloat.Config.shouldDismissOnClickOutside() && topFloat.Config.OnDismiss != nil {
+				topFloat.Config.OnDismiss()
+				return nil, true
+			}
+		}
+
+		// For modal floats, block the click from reaching underlying widgets
+		if renderer.HasModalFloat() {
+			return nil, true
+		}
+	}
+
+	return renderer.WidgetAt(x, y), false
+}
+
 // Quit exits the running application gracefully.
 // This performs the same teardown as pressing Ctrl+C.
 func Quit() {
@@ -234,11 +304,19 @@ func ScreenText() string {
 	return appRenderer.ScreenText()
 }
 
+// isAppKey reports whether Run's event loop takes a key for the app itself
+// (quitting, exporting the screen, copy mode, the ruler overlay and
+// suspending) rather than passing it to widgets.
+func isAppKey(event KeyEvent) bool {
+	return event.MatchString("ctrl+c", "ctrl+shift+s", "ctrl+shift+y", "ctrl+shift+g", "ctrl+z")
+}
+
 // Run starts the application with the given root widget and blocks until it exits.
 // The root widget can implement KeyHandler to receive key events that bubble up
 // from focused descendants.
 func Run(root Widget) (runErr error) {
 	t := uv.DefaultTerminal()
+	setDitherProfile(t.ColorProfile())
 	origStdinState := snapshotTTYState(os.Stdin)
 	origStdoutState := snapshotTTYState(os.Stdout)
 	restoreOriginalTTY := func() {
@@ -265,6 +343,10 @@ func Run(root Widget) (runErr error) {
 		// state to screen buffers, so doing this before shutdown is more
 		// reliable than only restoring after shutdown.
 		preRestoreDone := false
+		// Write anything queued since the last frame, then undo window
+		// title and progress changes.
+		writeTerminalSequences(t.WriteString, drainTerminalOutput())
+		writeTerminalSequences(t.WriteString, restoreTerminalWindow())
 		disableTerminalInputModes(t.WriteString, enableKittyKeyboard, forceDisableKittyKeyboard, false)
 		if err := t.Flush(); err == nil {
 			preRestoreDone = true
@@ -298,12 +380,33 @@ func Run(root Widget) (runErr error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	appCancel = cancel
 
+	// Quit cleanly, restoring the terminal, when the process is asked to stop.
+	stopSignals := make(chan os.Signal, 1)
+	signal.Notify(stopSignals, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(stopSignals)
+	go func() {
+		select {
+		case <-stopSignals:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// Recent input is kept for crash reports, or a longer recording with
+	// EnableInputRecording.
+	enableInputRecordingFromEnv()
+	inputEvents.CompareAndSwap(nil, newInputHistory(crashReportInputEvents))
+
 	// Create animation controller for this app
 	animController := NewAnimationController(defaultFPS)
 	currentController = animController
 
 	// Create render trigger channel for signal-driven re-renders
 	renderTrigger = make(chan struct{}, 1)
+	uiQueue.Lock()
+	uiQueue.notify = make(chan struct{}, 1)
+	uiCalls := uiQueue.notify
+	uiQueue.Unlock()
 
 	// Track event loop goroutine so we can wait for it during shutdown.
 	eventLoopDone := make(chan struct{})
@@ -328,23 +431,73 @@ func Run(root Widget) (runErr error) {
 			<-eventLoopDone
 		}
 
+		// Panics in the event loop or in goroutines started with Go are
+		// recorded rather than propagated, so check the store as well.
+		crashReportPath := ""
+		if panics := recordedPanics(); len(panics) > 0 {
+			runErr = ErrPanicked
+			if dir := crashReportDirectory(); dir != "" {
+				report := crashReport{Time: time.Now(), Panics: panics, Input: inputEvents.Load().recent()}
+				if appRenderer != nil {
+					report.Width, report.Height = appRenderer.width, appRenderer.height
+					report.Screen = appRenderer.ScreenText()
+				}
+				path, err := writeCrashReport(dir, report)
+				if err != nil {
+					LogError("crash report failed", Field("error", err))
+				}
+				crashReportPath = path
+			}
+		}
+
+		// Under terma-dev, keep hot signals and focus for the next build.
+		focusedID := ""
+		if appRenderer != nil && appRenderer.focusManager != nil {
+			focusedID = appRenderer.focusManager.FocusedID()
+		}
+		saveHotState(focusedID)
+
 		appCancel = nil
 		appRenderer = nil
 		renderTrigger = nil
+		uiQueue.Lock()
+		uiQueue.notify = nil
+		uiQueue.fns = nil
+		uiQueue.Unlock()
 		currentController = nil
 		animController.Stop()
 
 		shutdownTerminal()
 		renderPanics()
+		if crashReportPath != "" {
+			fmt.Fprintf(os.Stderr, "terma: crash report written to %s\n", crashReportPath)
+		}
 	}()
 
 	// Get initial terminal size
 	size := t.Size()
 	width, height := size.Width, size.Height
+
+	// Ask whether the terminal can report mouse positions in pixels.
+	mousePixelMode := newPixelMouse(width, height)
+	mousePixelMode.start(t.WriteString)
+	// Ask for the terminal's background, which Transparent blends with.
+	_, _ = t.WriteString(ansi.RequestBackgroundColor)
 	debugOverlayEnabled := os.Getenv("TERMA_DEBUG_OVERLAY") != ""
 	if debugOverlayEnabled {
 		EnableDebugRenderCause()
 	}
+	if debugOverlayEnabled || os.Getenv("TERMA_DEBUG_IDS") != "" {
+		EnableIDCollisionCheck()
+	}
+	if os.Getenv("TERMA_DEBUG_HOVER") != "" {
+		EnableHoverDebug()
+	}
+	if os.Getenv("TERMA_DEBUG_RULERS") != "" {
+		EnableRulerOverlay()
+	}
+	rulers := newRulerOverlay()
+	copying := &copyMode{}
 
 	// Create focus manager and focused signal
 	focusManager := NewFocusManager()
@@ -359,6 +512,12 @@ func Run(root Widget) (runErr error) {
 	renderer := NewRenderer(t, width, height, focusManager, focusedSignal, hoveredSignal)
 	appRenderer = renderer
 
+	// Routes keys and mouse presses to widgets, as Replay does
+	input := newInputDispatcher(renderer, focusManager, root)
+	hints := input.hints
+	hoverState := input.hover
+	dragState := input.drag
+
 	updateFocusedSignal := func() bool {
 		focusedID := focusManager.FocusedID()
 		if focusedID == lastFocusedID {
@@ -418,15 +577,48 @@ func Run(root Widget) (runErr error) {
 
 	renderInterval := time.Second / time.Duration(defaultFPS)
 	lastModalCount := 0
-	hoverState := &hoverTracker{}
 	var resolveHoverTarget hoverTargetResolver
 
+	// syncPointerState passes the hovered and pressed widgets to the renderer
+	// for HoverStyle and PressedStyle.
+	syncPointerState := func() {
+		pressedID := ""
+		if dragState.isDragging {
+			pressedID = dragState.dragWidgetID
+		}
+		renderer.setPointerState(hoverState.currentID, pressedID)
+	}
+
+	// pointerTarget returns the widget under the pointer at (x, y), or the
+	// widget being dragged, and the pointer shape it suggests.
+	pointerTarget := func(x, y int) (*WidgetEntry, CursorHint) {
+		var entry *WidgetEntry
+		if dragState.isDragging && dragState.dragWidgetID != "" {
+			entry = renderer.WidgetByID(dragState.dragWidgetID)
+		}
+		if entry == nil && resolveHoverTarget != nil {
+			entry = resolveHoverTarget(x, y)
+		}
+		return entry, cursorHintFor(entry, x, y)
+	}
+
+	// Shown instead of the app while a resize settles, under a ResizeStrategy
+	// other than ResizeImmediate
+	var resizing *resizeHold
+
 	// Render and update focusables
 	display := func() {
 		startTime := time.Now()
 		screen.Clear(t)
+		if resizing != nil {
+			// Layout waits for the resize to settle
+			resizing.draw(t, width, height)
+			_ = t.Display()
+			return
+		}
 		// Update the focused signal BEFORE render so widgets can read it
 		updateFocusedSignal()
+		syncPointerState()
 
 		focusables := renderer.Render(root)
 		focusManager.SetFocusables(focusables)
@@ -475,6 +667,7 @@ func Run(root Widget) (runErr error) {
 		// Reconcile hover after render so enter/leave transitions still fire when
 		// layout changes under a stationary pointer.
 		if hoverState.Reconcile(resolveHoverTarget, hoveredSignal) {
+			syncPointerState()
 			renderer.Render(root)
 		}
 		// Position terminal cursor for IME support (emoji picker, input methods)
@@ -489,7 +682,20 @@ func Run(root Widget) (runErr error) {
 			}
 		}
 
+		applyFrameFilters(t, width, height, renderer.ModalCount())
 		drawDebugOverlay()
+		if hoverDebugEnabled.Load() && hoverState.pointerKnown {
+			entry, hint := pointerTarget(hoverState.pointerX, hoverState.pointerY)
+			drawHoverDebug(t, width, height, entry, hint)
+		}
+		if input.showRequestedHints(width, height) {
+			copying.exit()
+		}
+		rulers.draw(t, width, height)
+		copying.draw(t, width, height)
+		hints.draw(t, width, height)
+		// Notifications and bells queued since the last frame.
+		writeTerminalSequences(t.WriteString, drainTerminalOutput())
 		_ = t.Display()
 
 		elapsed := time.Since(startTime)
@@ -497,65 +703,22 @@ func Run(root Widget) (runErr error) {
 		if elapsed > renderInterval {
 			overrunFrames++
 		}
-
-		Log("Render complete in %.3fms, %d widgets registered", float64(elapsed.Microseconds())/1000.0, len(renderer.widgetRegistry.entries))
-	}
-
-	clickTracker := &mouseClickTracker{}
-	dragState := &mouseDragState{}
-
-	resolveMouseTarget := func(x, y int, allowDismiss bool) (*WidgetEntry, bool) {
-		// Check if click is on a float
-		if renderer.FloatAt(x, y) != nil {
-			return renderer.WidgetAt(x, y), false
-		}
-
-		// Click is outside all floats - check for dismissal or modal blocking
-		if renderer.HasFloats() {
-			if allowDismiss {
-				topFloat := renderer.TopFloat()
-				if topFloat != nil && topFloat.Config.shouldDismissOnClickOutside() && topFloat.Config.OnDismiss != nil {
-					topFloat.Config.OnDismiss()
-					return nil, true
-				}
-			}
-
-			// For modal floats, block the click from reaching underlying widgets
-			if renderer.HasModalFloat() {
-				return nil, true
-			}
-		}
-
-		return renderer.WidgetAt(x, y), false
 	}
 
 	resolveHoverTarget = func(x, y int) *WidgetEntry {
-		entry, blocked := resolveMouseTarget(x, y, false)
+		entry, blocked := mouseTarget(renderer, x, y, false)
 		if blocked {
 			return nil
 		}
 		return entry
 	}
 
-	// focusAt finds the innermost focusable widget at (x, y) and focuses it.
-	// This is separate from WidgetAt because the clicked widget (for OnClick)
-	// may be different from the focusable widget (e.g., clicking Text inside a List).
-	focusAt := func(x, y int) {
-		entry := renderer.FocusableAt(x, y)
-		if entry != nil {
-			focusManager.FocusByID(entry.ID)
-		}
-	}
-
-	// Get root's key handling interfaces (if any) for the no-focusables case
-	rootHandler, _ := root.(KeyHandler)
-	rootKeybindProvider, _ := root.(KeybindProvider)
-
 	var (
 		lastRender    time.Time
 		renderPending bool
 		renderTimer   *time.Timer
 		renderTimerCh <-chan time.Time
+		resizeTimerCh <-chan time.Time
 	)
 
 	stopRenderTimer := func() {
@@ -605,6 +768,11 @@ func Run(root Widget) (runErr error) {
 		renderTimerCh = renderTimer.C
 	}
 
+	// Restore focus carried over from the previous build under terma-dev.
+	if focusedID := restoredHotFocus(); focusedID != "" && pendingFocusID == "" {
+		pendingFocusID = focusedID
+	}
+
 	// Initial render
 	renderNow()
 
@@ -621,13 +789,16 @@ func Run(root Widget) (runErr error) {
 				cancel()
 			}
 		}()
-		termEvents := t.Events()
+		termEvents := filterLongPresses(ctx, t.Events())
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case <-renderTrigger:
 				requestRender()
+			case <-uiCalls:
+				runUIQueue()
+				requestRender()
 			case <-animController.Tick():
 				animController.Update()
 				requestRender()
@@ -635,16 +806,49 @@ func Run(root Widget) (runErr error) {
 				if renderPending {
 					renderNow()
 				}
+			case <-resizeTimerCh:
+				// The size has settled: lay out at it
+				resizeTimerCh = nil
+				resizing = nil
+				renderer.Resize(width, height)
+				t.Erase()
+				requestRender()
 			case ev, ok := <-termEvents:
 				if !ok {
 					return
 				}
+				if mousePixelMode.handle(ev, t.WriteString) {
+					continue
+				}
+				var pixels mousePixels
+				ev, pixels = mousePixelMode.translate(ev)
+				if held, ok := ev.(longPressEvent); ok {
+					if dispatchLongPress(focusManager, root, KeyEvent{event: held.key}) {
+						requestRender()
+						continue
+					}
+					ev = held.key // Long presses nothing takes act as a plain press
+				}
 				switch ev := ev.(type) {
+				case uv.BackgroundColorEvent:
+					setTerminalBackground(ev.Color)
+					requestRender()
 				case uv.WindowSizeEvent:
+					if strategy := currentResizeStrategy(); strategy != ResizeImmediate {
+						if resizing == nil {
+							resizing = newResizeHold(strategy, t, width, height)
+						}
+						resizeTimerCh = time.After(currentResizeSettle())
+					}
 					_ = t.Resize(ev.Width, ev.Height)
-					renderer.Resize(ev.Width, ev.Height)
+					if resizing == nil {
+						renderer.Resize(ev.Width, ev.Height)
+					}
 					width = ev.Width
 					height = ev.Height
+					recordInput(ev)
+					copying.exit() // Its snapshot no longer matches the screen
+					hints.exit()
 					t.Erase()
 					requestRender()
 				case uv.KeyPressEvent:
@@ -660,6 +864,33 @@ func Run(root Widget) (runErr error) {
 						continue
 					}
 
+					// Copy mode takes every key while it is on
+					if copying.handleKey(KeyEvent{event: ev}) {
+						requestRender()
+						continue
+					}
+					if ev.MatchString("ctrl+shift+y") {
+						hints.exit()
+						copying.enter(t, width, height)
+						requestRender()
+						continue
+					}
+
+					// Hint mode and keyboard drags take keys before the app does
+					keyEvent := KeyEvent{event: ev}
+					if input.captureKey(keyEvent) {
+						recordInput(ev)
+						requestRender()
+						continue
+					}
+
+					// Ruler overlay toggle
+					if ev.MatchString("ctrl+shift+g") {
+						rulers.toggle()
+						requestRender()
+						continue
+					}
+
 					// Suspend on Ctrl+Z
 					if ev.MatchString("ctrl+z") {
 						// Disable input reporting modes before suspending so
@@ -681,119 +912,48 @@ func Run(root Widget) (runErr error) {
 
 						// Re-enable mouse tracking
 						enableTerminalInputModes(t.WriteString, enableKittyKeyboard, forceDisableKittyKeyboard)
+						mousePixelMode.resume(t.WriteString)
 
 						// Redraw the screen
 						requestRender()
 						continue
 					}
 
-					// Check for Escape to dismiss floats
-					if ev.MatchString("escape") {
-						if topFloat := renderer.TopFloat(); topFloat != nil {
-							if topFloat.Config.shouldDismissOnEsc() && topFloat.Config.OnDismiss != nil {
-								topFloat.Config.OnDismiss()
-								requestRender()
-								continue
-							}
-						}
-					}
-
-					// Route key event through focus manager (bubbles through widget tree)
-					keyEvent := KeyEvent{event: ev}
-					handled := focusManager.HandleKey(keyEvent)
-
-					// If not handled, try root's keybindings and handler directly
-					// (handles case when there are no focusable widgets)
-					if !handled {
-						if rootKeybindProvider != nil {
-							handled = matchKeybind(keyEvent, rootKeybindProvider.Keybinds())
-						}
-						if !handled && rootHandler != nil {
-							rootHandler.OnKey(keyEvent)
-						}
-					}
+					// Only keys that reach the dispatcher are recorded, so
+					// Replay doesn't send the app's own keys to widgets
+					recordInput(ev)
+					input.key(keyEvent)
 
 					// Re-render after key press (for signal updates and focus changes)
 					requestRender()
 
 				case uv.MouseClickEvent:
-					Log("MouseClickEvent at X=%d Y=%d Button=%v", ev.X, ev.Y, ev.Button)
-
-					entry, handled := resolveMouseTarget(ev.X, ev.Y, true)
-					if handled {
-						Log("  Mouse click handled by float logic")
+					// Presses on the ruler overlay's rulers and guides don't reach
+					// widgets, and neither does anything in copy mode
+					if rulers.handlePress(ev.X, ev.Y, height) || copying.handlePress(ev.X, ev.Y) {
+						hints.exit()
 						requestRender()
 						continue
 					}
 
-					if entry != nil {
-						Log("  Found widget: ID=%q Type=%T", entry.ID, entry.EventWidget)
-						focusEntry := renderer.FocusableAt(ev.X, ev.Y)
-						focusAt(ev.X, ev.Y)
-						clickCount := clickTracker.nextClick(entry.ID, ev.Button, ev.X, ev.Y, time.Now())
-						mouseEvent := buildMouseEvent(uv.Mouse(ev), entry, clickCount)
-
-						// Set drag state for mouse move tracking
-						dragState.isDragging = true
-						dragState.dragWidgetID = entry.ID
-						dragState.pressedButton = ev.Button
-
-						if downHandler, ok := entry.EventWidget.(MouseDownHandler); ok {
-							Log("  Widget has OnMouseDown")
-							downHandler.OnMouseDown(mouseEvent)
-						}
-
-						// Also notify the focused widget when a non-focusable child was clicked.
-						// This lets focusable widgets (Tree/TextInput/TextArea, etc.) handle cursor placement.
-						if focusEntry != nil && focusEntry != entry {
-							focusMouseEvent := buildMouseEvent(uv.Mouse(ev), focusEntry, clickCount)
-							if downHandler, ok := focusEntry.EventWidget.(MouseDownHandler); ok {
-								Log("  Focused widget has OnMouseDown")
-								downHandler.OnMouseDown(focusMouseEvent)
-							}
-						}
-
-						if clickable, ok := entry.EventWidget.(Clickable); ok {
-							Log("  Widget is Clickable, calling OnClick")
-							clickable.OnClick(mouseEvent)
-						} else {
-							Log("  Widget is NOT Clickable")
-						}
-					} else {
-						Log("  No widget found at position")
-						LogWidgetRegistry(renderer.widgetRegistry)
-					}
+					recordInput(ev)
+					input.press(uv.Mouse(ev), pixels)
 
 					// Re-render after click
 					requestRender()
 
 				case uv.MouseReleaseEvent:
-					Log("MouseReleaseEvent at X=%d Y=%d Button=%v", ev.X, ev.Y, ev.Button)
-
-					// Clear drag state
-					dragState.isDragging = false
-					dragState.dragWidgetID = ""
-					dragState.pressedButton = uv.MouseNone
-
-					entry, handled := resolveMouseTarget(ev.X, ev.Y, false)
-					if handled {
-						Log("  Mouse release blocked by float logic")
+					if rulers.handleRelease(ev.X, ev.Y, height) || copying.handleRelease(ev.X, ev.Y) {
 						requestRender()
 						continue
 					}
 
-					if entry != nil {
-						Log("  Found widget: ID=%q Type=%T", entry.ID, entry.EventWidget)
-						clickCount := clickTracker.releaseCount(entry.ID, ev.Button)
-						mouseEvent := buildMouseEvent(uv.Mouse(ev), entry, clickCount)
+					recordInput(ev)
+					input.release(uv.Mouse(ev), pixels)
 
-						if upHandler, ok := entry.EventWidget.(MouseUpHandler); ok {
-							Log("  Widget has OnMouseUp")
-							upHandler.OnMouseUp(mouseEvent)
-						}
-					} else {
-						Log("  No widget found at position")
-					}
+					// The drag is over, so the pointer follows what it is over again
+					_, hint := pointerTarget(ev.X, ev.Y)
+					setPointerShape(hint)
 
 					// Re-render after mouse up
 					requestRender()
@@ -801,6 +961,20 @@ func Run(root Widget) (runErr error) {
 				case uv.MouseMotionEvent:
 					// Log("MouseMotionEvent at X=%d Y=%d", ev.X, ev.Y)
 
+					// The ruler overlay's readout follows the pointer
+					if rulers.handleMotion(ev.X, ev.Y) {
+						requestRender()
+					}
+					if copying.handleMotion(ev.X, ev.Y) {
+						requestRender()
+						continue
+					}
+					// Dragging a Draggable moves its ghost rather than reaching widgets
+					if input.drags.handleMotion(renderer.widgetRegistry.Entries(), ev.X, ev.Y) {
+						requestRender()
+						continue
+					}
+
 					// Handle drag - dispatch to the widget that received the mouse down
 					if dragState.isDragging && dragState.dragWidgetID != "" {
 						if dragEntry := renderer.WidgetByID(dragState.dragWidgetID); dragEntry != nil {
@@ -818,18 +992,22 @@ func Run(root Widget) (runErr error) {
 									ClickCount: 1,
 									WidgetID:   dragEntry.ID,
 								}
+								pixels.apply(&mouseEvent)
 								moveHandler.OnMouseMove(mouseEvent)
 								display()
 							}
 						}
 					}
 
-					if hoverState.UpdatePointer(ev.X, ev.Y, ev.Mod, ev.Button, resolveHoverTarget, hoveredSignal) {
+					if hoverState.UpdatePointer(ev.X, ev.Y, ev.Mod, ev.Button, resolveHoverTarget, hoveredSignal) || hoverDebugEnabled.Load() {
 						requestRender()
 					}
+					_, hint := pointerTarget(ev.X, ev.Y)
+					setPointerShape(hint)
 
 				case uv.MouseWheelEvent:
-					dispatchMouseWheel(renderer, ev.X, ev.Y, ev.Button)
+					recordInput(ev)
+					input.wheel(uv.Mouse(ev))
 					requestRender()
 
 				default:
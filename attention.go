@@ -0,0 +1,165 @@
+package terma
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// reducedMotion is set by SetReducedMotion.
+var reducedMotion atomic.Bool
+
+// SetReducedMotion turns movement in the framework's attention animations
+// off, for users who find motion distracting or uncomfortable. Shake
+// flashes the widget in the theme's Error color instead of moving it, and
+// Flash fades out once instead of pulsing. Custom animations can check
+// ReducedMotion to follow suit.
+//
+// Example:
+//
+//	t.SetReducedMotion(os.Getenv("REDUCE_MOTION") != "")
+//	t.Run(app)
+func SetReducedMotion(reduced bool) {
+	reducedMotion.Store(reduced)
+}
+
+// ReducedMotion reports whether SetReducedMotion turned motion off.
+func ReducedMotion() bool {
+	return reducedMotion.Load()
+}
+
+const (
+	flashDuration = 600 * time.Millisecond
+	shakeDuration = 400 * time.Millisecond
+
+	// flashAlpha is how strongly a flash tints the background at its peak.
+	flashAlpha = 0.35
+	// shakeAmplitude is how far, in cells, a shake first moves the widget.
+	shakeAmplitude = 2
+)
+
+// attentionKind is the effect an attention animation has.
+type attentionKind int
+
+const (
+	attentionFlash attentionKind = iota
+	attentionShake
+)
+
+// attention is a running Flash or Shake.
+type attention struct {
+	kind      attentionKind
+	animation *Animation[float64] // Progress from 0 to 1
+	color     func(theme ThemeData) Color
+	reduced   bool // Started under reduced motion
+}
+
+// attentions holds the running attention animations by widget ID.
+var attentions struct {
+	sync.Mutex
+	byID map[string]*attention
+}
+
+// Flash briefly pulses the background of the widget with the given ID in
+// the theme's Accent color, to draw the eye to it, such as a field a
+// shortcut just focused or a row that just changed.
+//
+// Example:
+//
+//	t.Flash("unread-count")
+func Flash(widgetID string) {
+	startAttention(widgetID, attentionFlash, func(theme ThemeData) Color { return theme.Accent })
+}
+
+// Shake moves the widget with the given ID from side to side for a moment,
+// the way a login form rejects a wrong password. Under reduced motion it
+// flashes in the theme's Error color instead.
+//
+// Example:
+//
+//	if !a.checkPassword(password) {
+//	    t.Shake("password")
+//	}
+func Shake(widgetID string) {
+	if ReducedMotion() {
+		startAttention(widgetID, attentionFlash, func(theme ThemeData) Color { return theme.Error })
+		return
+	}
+	startAttention(widgetID, attentionShake, nil)
+}
+
+// startAttention starts an attention animation on a widget, replacing any
+// running one.
+func startAttention(widgetID string, kind attentionKind, color func(theme ThemeData) Color) {
+	duration := flashDuration
+	if kind == attentionShake {
+		duration = shakeDuration
+	}
+	a := &attention{kind: kind, color: color, reduced: ReducedMotion()}
+	a.animation = NewAnimation(AnimationConfig[float64]{
+		From:     0,
+		To:       1,
+		Duration: duration,
+		OnComplete: func() {
+			attentions.Lock()
+			defer attentions.Unlock()
+			if attentions.byID[widgetID] == a {
+				delete(attentions.byID, widgetID)
+			}
+		},
+	})
+
+	attentions.Lock()
+	if attentions.byID == nil {
+		attentions.byID = map[string]*attention{}
+	}
+	previous := attentions.byID[widgetID]
+	attentions.byID[widgetID] = a
+	attentions.Unlock()
+
+	if previous != nil {
+		previous.animation.Stop()
+	}
+	a.animation.Start()
+}
+
+// runningAttention returns the attention animation on a widget, if any.
+func runningAttention(widgetID string) *attention {
+	if widgetID == "" {
+		return nil
+	}
+	attentions.Lock()
+	defer attentions.Unlock()
+	return attentions.byID[widgetID]
+}
+
+// shakeOffset returns how far a shake moves the widget right, in cells.
+// The widget swings three times, less each time.
+func shakeOffset(widgetID string) int {
+	a := runningAttention(widgetID)
+	if a == nil || a.kind != attentionShake {
+		return 0
+	}
+	t := a.animation.Value().Peek()
+	return int(math.Round(shakeAmplitude * math.Sin(6*math.Pi*t) * (1 - t)))
+}
+
+// flashTint returns the color a flash blends over the widget, if it is
+// flashing. The tint pulses twice, or fades out once under reduced motion.
+// Between pulses, when the tint is too faint to see, it isn't blended.
+func flashTint(widgetID string, ctx *RenderContext) (Color, bool) {
+	a := runningAttention(widgetID)
+	if a == nil || a.kind != attentionFlash {
+		return Color{}, false
+	}
+	t := a.animation.Value().Peek()
+	alpha := flashAlpha * math.Abs(math.Sin(2*math.Pi*t))
+	if a.reduced {
+		alpha = flashAlpha * (1 - t)
+	}
+	if alpha < 0.01 {
+		return Color{}, false
+	}
+	return a.color(ctx.buildContext.Theme()).WithAlpha(alpha), true
+}
@@ -0,0 +1,83 @@
+package terma
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func advanceAttention(t *testing.T, widgetID string, dt time.Duration) {
+	t.Helper()
+	a := runningAttention(widgetID)
+	require.NotNil(t, a)
+	a.animation.Advance(dt)
+}
+
+func useReducedMotion(t *testing.T) {
+	t.Helper()
+	SetReducedMotion(true)
+	t.Cleanup(func() { SetReducedMotion(false) })
+}
+
+func attentionRow() Widget {
+	return Row{Style: Style{Width: Flex(1)}, Children: []Widget{
+		Text{ID: "name", Content: "name"},
+		Text{ID: "other", Content: "other"},
+	}}
+}
+
+func TestFlash_PulsesTheWidgetBackground(t *testing.T) {
+	before := renderToBufferWithFocus(attentionRow(), 10, 1, "")
+
+	Flash("name")
+	advanceAttention(t, "name", flashDuration/4) // First peak
+	during := renderToBufferWithFocus(attentionRow(), 10, 1, "")
+
+	assert.Equal(t, "n", during.CellAt(0, 0).Content)
+	assert.NotEqual(t, before.CellAt(0, 0).Style.Bg, during.CellAt(0, 0).Style.Bg)
+	assert.Equal(t, before.CellAt(5, 0).Style.Bg, during.CellAt(5, 0).Style.Bg, "only the flashed widget is tinted")
+
+	advanceAttention(t, "name", flashDuration/4) // Between the pulses
+	between := renderToBufferWithFocus(attentionRow(), 10, 1, "")
+	assert.Equal(t, before.CellAt(0, 0).Style.Bg, between.CellAt(0, 0).Style.Bg)
+
+	advanceAttention(t, "name", flashDuration)
+	assert.Nil(t, runningAttention("name"), "finished flashes are removed")
+}
+
+func TestShake_MovesTheWidgetSideways(t *testing.T) {
+	Shake("other")
+	advanceAttention(t, "other", shakeDuration/12) // First swing to the right
+	buf := renderToBufferWithFocus(attentionRow(), 12, 1, "")
+
+	assert.Equal(t, 2, shakeOffset("other"))
+	assert.Equal(t, "name  other ", bufferLine(buf, 0, 12), "the siblings don't move")
+
+	advanceAttention(t, "other", shakeDuration)
+	assert.Equal(t, 0, shakeOffset("other"))
+}
+
+func TestShake_FlashesInsteadUnderReducedMotion(t *testing.T) {
+	useReducedMotion(t)
+	before := renderToBufferWithFocus(attentionRow(), 10, 1, "")
+
+	Shake("name")
+	buf := renderToBufferWithFocus(attentionRow(), 10, 1, "")
+
+	assert.Equal(t, 0, shakeOffset("name"))
+	assert.Equal(t, "nameother ", bufferLine(buf, 0, 10))
+	assert.NotEqual(t, before.CellAt(0, 0).Style.Bg, buf.CellAt(0, 0).Style.Bg, "a steady tint from the start")
+	advanceAttention(t, "name", shakeDuration)
+}
+
+func TestFlash_RestartReplacesTheRunningFlash(t *testing.T) {
+	Flash("name")
+	first := runningAttention("name")
+	Flash("name")
+
+	assert.NotSame(t, first, runningAttention("name"))
+	assert.True(t, first.animation.IsComplete())
+	advanceAttention(t, "name", flashDuration)
+}
@@ -184,6 +184,7 @@ type Autocomplete struct {
 	MaxVisible int            // Max visible items (default 8)
 	Insert     InsertStrategy // Default: InsertFromTrigger if TriggerChars set, else InsertReplace
 	MatchMode  FilterMode     // FilterContains (default) or FilterFuzzy
+	Unfiltered bool           // Show Suggestions as set, for OnQueryChange handlers that narrow them
 
 	// Dismissal behavior
 	DismissOnBlur    *bool // Dismiss when input loses focus (default: true)
@@ -254,13 +255,13 @@ func (a Autocomplete) anchorContentInsets() EdgeInsets {
 			return EdgeInsets{}
 		}
 	}
-	border := style.Border.Width()
+	border := style.Border.Insets()
 	padding := style.Padding
 	return EdgeInsets{
-		Top:    padding.Top + border,
-		Right:  padding.Right + border,
-		Bottom: padding.Bottom + border,
-		Left:   padding.Left + border,
+		Top:    padding.Top + border.Top,
+		Right:  padding.Right + border.Right,
+		Bottom: padding.Bottom + border.Bottom,
+		Left:   padding.Left + border.Left,
 	}
 }
 
@@ -704,7 +705,11 @@ func (a Autocomplete) filteredSuggestionCount() int {
 	if a.State == nil {
 		return 0
 	}
-	a.State.filterState.Query.Set(a.State.filterQuery.Peek())
+	query := a.State.filterQuery.Peek()
+	if a.Unfiltered {
+		query = ""
+	}
+	a.State.filterState.Query.Set(query)
 	a.State.filterState.Mode.Set(a.matchMode())
 	return a.State.listState.ApplyFilter(a.State.filterState, suggestionMatchItem)
 }
@@ -914,6 +919,7 @@ func (a Autocomplete) buildFloatConfig(anchorID string) FloatConfig {
 	if anchorID != "" {
 		config.AnchorID = anchorID
 		config.Anchor = AnchorBottomLeft
+		config.Flip = true
 	}
 
 	if a.AnchorToInput {
@@ -1030,11 +1036,11 @@ func (a Autocomplete) cursorPopupOffset() (Offset, bool) {
 		}
 		cursorX := child.State.cursorDisplayX() - child.State.scrollOffset
 		padding := child.Style.Padding
-		border := child.Style.Border.Width()
+		border := child.Style.Border.Insets()
 		contentHeight := 1
 		return Offset{
-			X: cursorX + padding.Left + border,
-			Y: 1 - contentHeight - padding.Bottom - border,
+			X: cursorX + padding.Left + border.Left,
+			Y: 1 - contentHeight - padding.Bottom - border.Bottom,
 		}, true
 	case TextArea:
 		if child.State == nil {
@@ -1042,14 +1048,14 @@ func (a Autocomplete) cursorPopupOffset() (Offset, bool) {
 		}
 		cursorX, cursorY := child.State.CursorScreenPosition(0, 0)
 		padding := child.Style.Padding
-		border := child.Style.Border.Width()
+		border := child.Style.Border.Insets()
 		contentHeight := child.State.lastHeight
 		if contentHeight <= 0 {
 			contentHeight = textAreaContentHeight(child)
 		}
 		return Offset{
-			X: cursorX + padding.Left + border,
-			Y: cursorY + 1 - contentHeight - padding.Bottom - border,
+			X: cursorX + padding.Left + border.Left,
+			Y: cursorY + 1 - contentHeight - padding.Bottom - border.Bottom,
 		}, true
 	default:
 		return Offset{}, false
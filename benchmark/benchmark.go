@@ -0,0 +1,307 @@
+// Package benchmark provides canonical terma scenes and helpers that time
+// how long they take to build, lay out and render, so apps and contributors
+// can track performance regressions with go test -bench.
+//
+// terma builds and lays out widgets in a single pass, so Run reports two
+// sub-benchmarks for a scene: "layout" times building and laying out the
+// widget tree, and "frame" times a whole frame, painting included. The
+// difference between them is the cost of painting.
+//
+// Example:
+//
+//	func BenchmarkInbox(b *testing.B) {
+//	    benchmark.Run(b, benchmark.Scene{
+//	        Name:   "inbox",
+//	        Width:  120,
+//	        Height: 40,
+//	        Widget: func() terma.Widget { return newInbox(fixtureMessages) },
+//	    })
+//	}
+//
+// Run the canonical scenes with:
+//
+//	go test ./benchmark -bench . -benchmem
+package benchmark
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/darrenburns/terma"
+	"github.com/darrenburns/terma/layout"
+)
+
+// Scene is a widget to benchmark at a fixed screen size.
+type Scene struct {
+	Name   string              // Sub-benchmark name
+	Width  int                 // Screen width in cells
+	Height int                 // Screen height in cells
+	Widget func() terma.Widget // Returns the root widget; called once per Run, outside the timed loop
+}
+
+// Scenes returns the canonical scenes: a 10,000-item list, a 1,000-row
+// table with wrapping cells, a deep tree, a screen of gradients and a
+// 100,000-line file open in a TextArea.
+func Scenes() []Scene {
+	return []Scene{
+		LargeList(10_000),
+		WrappingTable(1_000),
+		DeepTree(10),
+		GradientScreen(),
+		LargeTextFile(100_000),
+	}
+}
+
+// Run benchmarks a scene as the sub-benchmarks "layout" and "frame".
+func Run(b *testing.B, scene Scene) {
+	b.Run("layout", func(b *testing.B) { Layout(b, scene) })
+	b.Run("frame", func(b *testing.B) { Frame(b, scene) })
+}
+
+// Layout times building and laying out the scene's widget tree.
+func Layout(b *testing.B, scene Scene) {
+	root := scene.Widget()
+	ctx := terma.NewBuildContext(terma.NewFocusManager(), terma.NewAnySignal[terma.Focusable](nil), terma.NewAnySignal[terma.Widget](nil), nil)
+	constraints := layout.Loose(scene.Width, scene.Height)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		terma.BuildRenderTree(root, ctx, constraints, terma.NewFocusCollector())
+	}
+}
+
+// Frame times rendering whole frames of the scene into a screen buffer.
+func Frame(b *testing.B, scene Scene) {
+	root := scene.Widget()
+	renderer := newRenderer(scene.Width, scene.Height)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		renderer.Render(root)
+	}
+}
+
+// FramesPerSecond is the frame rate FrameLoop renders at.
+const FramesPerSecond = 60
+
+// FrameLoop times one second of an animating app: each op renders the scene
+// FramesPerSecond times with the same renderer, so pooled buffers are
+// reused between frames as they are in a running app. Along with the usual
+// allocation counts it reports "gc/op", the garbage collections that second
+// of frames set off, which is where heavy allocation turns into dropped
+// frames.
+func FrameLoop(b *testing.B, scene Scene) {
+	root := scene.Widget()
+	renderer := newRenderer(scene.Width, scene.Height)
+	renderer.Render(root)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for frame := 0; frame < FramesPerSecond; frame++ {
+			renderer.Render(root)
+		}
+	}
+	b.StopTimer()
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(after.NumGC-before.NumGC)/float64(b.N), "gc/op")
+}
+
+// Render renders one frame of the scene and returns its text, for checking
+// that a scene draws what it should.
+func Render(scene Scene) string {
+	renderer := newRenderer(scene.Width, scene.Height)
+	renderer.Render(scene.Widget())
+	return renderer.ScreenText()
+}
+
+func newRenderer(width, height int) *terma.Renderer {
+	return terma.NewRenderer(uv.NewBuffer(width, height), width, height, terma.NewFocusManager(), terma.NewAnySignal[terma.Focusable](nil), terma.NewAnySignal[terma.Widget](nil))
+}
+
+// LargeList is a scrolling List of items, most of them out of view, the
+// way a log viewer or file picker holds far more rows than fit.
+func LargeList(items int) Scene {
+	return Scene{
+		Name:   fmt.Sprintf("list-%d", items),
+		Width:  80,
+		Height: 40,
+		Widget: func() terma.Widget {
+			rows := make([]string, items)
+			for i := range rows {
+				rows[i] = fmt.Sprintf("item %d: %s", i, strings.Repeat("lorem ", i%7))
+			}
+			return terma.Scrollable{
+				State: terma.NewScrollState(),
+				Style: terma.Style{Height: terma.Flex(1)},
+				Child: terma.List[string]{ID: "list", State: terma.NewListState(rows)},
+			}
+		},
+	}
+}
+
+// WrappingTable is a Table whose last column wraps long text onto several
+// lines, so every row has to be measured.
+func WrappingTable(rows int) Scene {
+	type row struct {
+		id          int
+		name        string
+		description string
+	}
+	return Scene{
+		Name:   fmt.Sprintf("table-%d", rows),
+		Width:  100,
+		Height: 40,
+		Widget: func() terma.Widget {
+			data := make([]row, rows)
+			for i := range data {
+				data[i] = row{
+					id:          i,
+					name:        fmt.Sprintf("row %d", i),
+					description: strings.Repeat("a description that wraps ", 1+i%5),
+				}
+			}
+			return terma.Scrollable{
+				State: terma.NewScrollState(),
+				Style: terma.Style{Height: terma.Flex(1)},
+				Child: terma.Table[row]{
+					ID:            "table",
+					State:         terma.NewTableState(data),
+					ColumnSpacing: 1,
+					Columns: []terma.TableColumn{
+						{Width: terma.Cells(6), Header: terma.Text{Content: "ID"}},
+						{Width: terma.Cells(12), Header: terma.Text{Content: "Name"}},
+						{Width: terma.Flex(1), Header: terma.Text{Content: "Description"}},
+					},
+					RenderCell: func(r row, rowIndex, colIndex int, active, selected bool) terma.Widget {
+						switch colIndex {
+						case 0:
+							return terma.Text{Content: fmt.Sprint(r.id)}
+						case 1:
+							return terma.Text{Content: r.name}
+						default:
+							return terma.Text{Content: r.description, Wrap: terma.WrapSoft, Style: terma.Style{Width: terma.Flex(1)}}
+						}
+					},
+				},
+			}
+		},
+	}
+}
+
+// DeepTree is a fully expanded Tree in which every node has two children,
+// depth levels deep.
+func DeepTree(depth int) Scene {
+	var grow func(path string, level int) []terma.TreeNode[string]
+	grow = func(path string, level int) []terma.TreeNode[string] {
+		if level == depth {
+			return nil
+		}
+		nodes := make([]terma.TreeNode[string], 2)
+		for i := range nodes {
+			name := fmt.Sprintf("%s/%d", path, i)
+			nodes[i] = terma.TreeNode[string]{Data: name, Children: grow(name, level+1)}
+		}
+		return nodes
+	}
+	return Scene{
+		Name:   fmt.Sprintf("tree-depth-%d", depth),
+		Width:  80,
+		Height: 40,
+		Widget: func() terma.Widget {
+			return terma.Scrollable{
+				State: terma.NewScrollState(),
+				Style: terma.Style{Height: terma.Flex(1)},
+				Child: terma.Tree[string]{
+					ID:     "tree",
+					State:  terma.NewTreeState(grow("", 0)),
+					NodeID: func(name string) string { return name },
+				},
+			}
+		},
+	}
+}
+
+// GradientScreen fills the screen with a gradient background and a grid of
+// cards with gradient backgrounds, borders and text, so nearly every cell
+// interpolates a color.
+func GradientScreen() Scene {
+	return Scene{
+		Name:   "gradients",
+		Width:  160,
+		Height: 48,
+		Widget: func() terma.Widget {
+			var rows []terma.Widget
+			for r := 0; r < 4; r++ {
+				var cards []terma.Widget
+				for c := 0; c < 6; c++ {
+					angle := float64((r*6 + c) * 15)
+					cards = append(cards, terma.Column{
+						Style: terma.Style{
+							Width:           terma.Flex(1),
+							Height:          terma.Flex(1),
+							Padding:         terma.EdgeInsetsXY(1, 0),
+							BackgroundColor: terma.NewGradient(terma.Hex("#1e1b4b"), terma.Hex("#0f766e")).WithAngle(angle),
+							Border: terma.Border{
+								Style: terma.BorderRounded,
+								Color: terma.NewGradient(terma.Hex("#f472b6"), terma.Hex("#38bdf8")).WithAngle(90),
+							},
+						},
+						Children: []terma.Widget{
+							terma.Text{
+								Content: fmt.Sprintf("Card %d\n%s", r*6+c, strings.Repeat("gradient text ", 4)),
+								Wrap:    terma.WrapSoft,
+								Style: terma.Style{
+									Width:           terma.Flex(1),
+									ForegroundColor: terma.NewGradient(terma.Hex("#fde68a"), terma.Hex("#f97316")).WithAngle(90),
+								},
+							},
+						},
+					})
+				}
+				rows = append(rows, terma.Row{Spacing: 1, Style: terma.Style{Height: terma.Flex(1)}, Children: cards})
+			}
+			return terma.Column{
+				Spacing: 1,
+				Style: terma.Style{
+					Width:           terma.Flex(1),
+					Height:          terma.Flex(1),
+					Padding:         terma.EdgeInsetsXY(2, 1),
+					BackgroundColor: terma.NewGradient(terma.Hex("#020617"), terma.Hex("#312e81"), terma.Hex("#020617")).WithAngle(45),
+				},
+				Children: rows,
+			}
+		},
+	}
+}
+
+// LargeTextFile is a TextArea editing a log of lines lines through a
+// TextBuffer, with the cursor halfway down and soft wrapping on.
+func LargeTextFile(lines int) Scene {
+	return Scene{
+		Name:   fmt.Sprintf("text-%d", lines),
+		Width:  120,
+		Height: 40,
+		Widget: func() terma.Widget {
+			var sb strings.Builder
+			for i := 0; i < lines; i++ {
+				fmt.Fprintf(&sb, "2024-05-01T12:%02d:%02d INFO request %d served in %dms %s\n", i/60%60, i%60, i, i%250, strings.Repeat("detail ", i%20))
+			}
+			state := terma.NewTextAreaStateFromBuffer(terma.NewTextBuffer(sb.String()))
+			state.GoToLine(lines / 2)
+			return terma.TextArea{
+				ID:    "file",
+				State: state,
+				Style: terma.Style{Width: terma.Flex(1), Height: terma.Flex(1)},
+			}
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package benchmark
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScenesRender(t *testing.T) {
+	want := map[string]string{
+		"list-10000":    "item 0:",
+		"table-1000":    "a description that wraps",
+		"tree-depth-10": "/0/0/0",
+		"gradients":     "Card 23",
+		"text-100000":   "request 50000 served",
+	}
+	for _, scene := range Scenes() {
+		t.Run(scene.Name, func(t *testing.T) {
+			screen := Render(scene)
+			assert.Contains(t, screen, want[scene.Name])
+			assert.Len(t, strings.Split(screen, "\n"), scene.Height)
+		})
+	}
+}
+
+func BenchmarkScenes(b *testing.B) {
+	for _, scene := range Scenes() {
+		b.Run(scene.Name, func(b *testing.B) { Run(b, scene) })
+	}
+}
+
+// BenchmarkLargeTableAt60FPS renders a second of frames of the 1,000-row
+// table, the heaviest canonical scene for allocation.
+func BenchmarkLargeTableAt60FPS(b *testing.B) {
+	FrameLoop(b, WrappingTable(1_000))
+}
@@ -0,0 +1,212 @@
+package terma
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/darrenburns/terma/layout"
+)
+
+// BoxPlotGroup is one set of values summarized by a box on a BoxPlot.
+type BoxPlotGroup struct {
+	Name   string    // Shown under the box
+	Values []float64 // The values to summarize; NaN and infinities are skipped
+	Color  Color     // Default: a theme color picked by the group's index
+}
+
+// BoxPlot draws a box for each group, side by side on a shared value axis.
+// Each box spans the middle half of the group's values with a line at the
+// median. Whiskers reach the furthest values within 1.5 interquartile
+// ranges of the box, and values beyond them are drawn as outlier dots.
+//
+// Example:
+//
+//	terma.BoxPlot{
+//	    Groups: []terma.BoxPlotGroup{
+//	        {Name: "v1", Values: a.before},
+//	        {Name: "v2", Values: a.after},
+//	    },
+//	    YFormat: func(ms float64) string { return fmt.Sprintf("%.0fms", ms) },
+//	}
+type BoxPlot struct {
+	ID        string                     // Optional unique identifier
+	Groups    []BoxPlotGroup             // The boxes, left to right
+	MinY      *float64                   // Optional fixed range start (default: fit the data)
+	MaxY      *float64                   // Optional fixed range end (default: fit the data)
+	FullRange bool                       // Whiskers reach the minimum and maximum, without outliers
+	YFormat   func(value float64) string // Formats value labels (default: 4 significant digits)
+	HideAxes  bool                       // Draw the boxes only, without axes and labels
+	Style     Style                      // Optional styling
+}
+
+// Build returns itself as BoxPlot is a leaf widget.
+func (b BoxPlot) Build(ctx BuildContext) Widget {
+	return b
+}
+
+// WidgetID returns the box plot's unique identifier.
+// Implements the Identifiable interface.
+func (b BoxPlot) WidgetID() string {
+	return b.ID
+}
+
+// GetContentDimensions returns the width and height dimension preferences.
+// Width defaults to Flex(1), Height defaults to Cells(10).
+func (b BoxPlot) GetContentDimensions() (width, height Dimension) {
+	dims := b.Style.GetDimensions()
+	width, height = dims.Width, dims.Height
+	if width.IsUnset() {
+		width = Flex(1)
+	}
+	if height.IsUnset() {
+		height = Cells(10)
+	}
+	return width, height
+}
+
+// GetStyle returns the style of the box plot.
+func (b BoxPlot) GetStyle() Style {
+	return b.Style
+}
+
+// BuildLayoutNode builds a layout node for this BoxPlot widget.
+func (b BoxPlot) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return monitorLayoutNode(b, b.Style)
+}
+
+// boxPlotStats summarizes one group's values.
+type boxPlotStats struct {
+	low, q1, median, q3, high float64 // Whisker ends, quartiles and median
+	outliers                  []float64
+}
+
+// boxPlotSummary returns the stats of values, or false if none are finite.
+func boxPlotSummary(values []float64, fullRange bool) (boxPlotStats, bool) {
+	var sorted []float64
+	for _, v := range values {
+		if chartFinite(v) {
+			sorted = append(sorted, v)
+		}
+	}
+	if len(sorted) == 0 {
+		return boxPlotStats{}, false
+	}
+	slices.Sort(sorted)
+	stats := boxPlotStats{
+		low:    sorted[0],
+		q1:     chartQuantile(sorted, 0.25),
+		median: chartQuantile(sorted, 0.5),
+		q3:     chartQuantile(sorted, 0.75),
+		high:   sorted[len(sorted)-1],
+	}
+	if fullRange {
+		return stats, true
+	}
+	fence := 1.5 * (stats.q3 - stats.q1)
+	stats.low, stats.high = math.Inf(1), math.Inf(-1)
+	for _, v := range sorted {
+		if v < stats.q1-fence || v > stats.q3+fence {
+			stats.outliers = append(stats.outliers, v)
+			continue
+		}
+		stats.low, stats.high = math.Min(stats.low, v), math.Max(stats.high, v)
+	}
+	return stats, true
+}
+
+// Render draws the axes, the boxes and the group names.
+func (b BoxPlot) Render(ctx *RenderContext) {
+	if ctx.Width <= 0 || ctx.Height <= 0 || len(b.Groups) == 0 {
+		return
+	}
+	theme := ctx.buildContext.Theme()
+	stats := make([]boxPlotStats, len(b.Groups))
+	present := make([]bool, len(b.Groups))
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for i, group := range b.Groups {
+		stats[i], present[i] = boxPlotSummary(group.Values, b.FullRange)
+		if !present[i] {
+			continue
+		}
+		minY, maxY = math.Min(minY, stats[i].low), math.Max(maxY, stats[i].high)
+		for _, v := range stats[i].outliers {
+			minY, maxY = math.Min(minY, v), math.Max(maxY, v)
+		}
+	}
+	if b.MinY != nil {
+		minY = *b.MinY
+	}
+	if b.MaxY != nil {
+		maxY = *b.MaxY
+	}
+	if minY > maxY {
+		minY, maxY = 0, 1
+	}
+	view := widenChartView(PlotView{MinX: 0, MaxX: 1, MinY: minY, MaxY: maxY})
+	area := chartArea{view: view, width: ctx.Width, height: ctx.Height}
+	if !b.HideAxes {
+		area = drawChartAxes(ctx, theme, view, b.YFormat)
+	}
+	if area.width <= 0 || area.height <= 0 {
+		return
+	}
+
+	row := func(value float64) int {
+		_, y := area.cellOf(PlotPoint{X: view.MinX, Y: value})
+		return y
+	}
+	draw := func(x, y int, text string, style Style) {
+		if y >= 0 && y < area.height {
+			ctx.DrawStyledText(area.left+x, area.top+y, text, style)
+		}
+	}
+
+	// Boxes are the same width in every slot, and an odd width gives the
+	// whiskers a center column
+	boxWidth := max(1, area.width/len(b.Groups)*3/5)
+	if boxWidth%2 == 0 {
+		boxWidth--
+	}
+	for i, group := range b.Groups {
+		start, end := area.width*i/len(b.Groups), area.width*(i+1)/len(b.Groups)
+		slot := end - start
+		if !b.HideAxes && group.Name != "" {
+			name := ansi.Truncate(group.Name, slot, "")
+			ctx.DrawStyledText(area.left+start+(slot-ansi.StringWidth(name))/2, area.top+area.height+1, name, Style{ForegroundColor: theme.TextMuted})
+		}
+		if !present[i] || slot < boxWidth {
+			continue
+		}
+
+		left := start + (slot-boxWidth)/2
+		center := left + boxWidth/2
+		color := chartColor(theme, group.Color, i)
+		lineStyle := Style{ForegroundColor: color}
+		s := stats[i]
+		highRow, q3Row, medianRow, q1Row, lowRow := row(s.high), row(s.q3), row(s.median), row(s.q1), row(s.low)
+
+		for y := highRow + 1; y < q3Row; y++ {
+			draw(center, y, "│", lineStyle)
+		}
+		for y := q1Row + 1; y < lowRow; y++ {
+			draw(center, y, "│", lineStyle)
+		}
+		if highRow < q3Row {
+			draw(left, highRow, strings.Repeat("─", boxWidth), lineStyle)
+			draw(center, highRow, "┬", lineStyle)
+		}
+		if lowRow > q1Row {
+			draw(left, lowRow, strings.Repeat("─", boxWidth), lineStyle)
+			draw(center, lowRow, "┴", lineStyle)
+		}
+		for y := q3Row; y <= q1Row; y++ {
+			draw(left, y, strings.Repeat(" ", boxWidth), Style{BackgroundColor: color})
+		}
+		draw(left, medianRow, strings.Repeat("━", boxWidth), Style{ForegroundColor: color.AutoText(), BackgroundColor: color})
+		for _, v := range s.outliers {
+			draw(center, row(v), "•", lineStyle)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func renderBoxPlot(t *testing.T, boxPlot BoxPlot, width, height int) []string {
+	t.Helper()
+	renderer, buf := portalTestRenderer(width, height)
+	boxPlot.Style.Width = Cells(width)
+	boxPlot.Style.Height = Cells(height)
+	renderer.Render(boxPlot)
+	lines := make([]string, height)
+	for y := range lines {
+		lines[y] = bufferLine(buf, y, width)
+	}
+	return lines
+}
+
+func TestBoxPlotSummary(t *testing.T) {
+	stats, ok := boxPlotSummary([]float64{1, 2, 3, 4, 5, 100}, false)
+	require.True(t, ok)
+
+	assert.Equal(t, 2.25, stats.q1)
+	assert.Equal(t, 3.5, stats.median)
+	assert.Equal(t, 4.75, stats.q3)
+	assert.Equal(t, 1.0, stats.low)
+	assert.Equal(t, 5.0, stats.high)
+	assert.Equal(t, []float64{100}, stats.outliers)
+
+	stats, _ = boxPlotSummary([]float64{1, 2, 3, 4, 5, 100}, true)
+	assert.Equal(t, 100.0, stats.high)
+	assert.Empty(t, stats.outliers)
+
+	_, ok = boxPlotSummary([]float64{}, false)
+	assert.False(t, ok)
+}
+
+func TestBoxPlot_DrawsWhiskersBoxAndOutliers(t *testing.T) {
+	boxPlot := BoxPlot{
+		HideAxes: true,
+		Groups:   []BoxPlotGroup{{Values: []float64{0, 3, 4, 5, 6, 7, 8, 20}}},
+	}
+
+	lines := renderBoxPlot(t, boxPlot, 5, 11)
+
+	assert.Equal(t, "  •  ", lines[0])
+	assert.Equal(t, " ━━━ ", lines[7], "the median")
+	assert.Equal(t, "  │  ", lines[9])
+	assert.Equal(t, " ─┴─ ", lines[10])
+}
+
+func TestBoxPlot_NamesGroupsUnderTheAxis(t *testing.T) {
+	boxPlot := BoxPlot{Groups: []BoxPlotGroup{
+		{Name: "a", Values: []float64{1, 2, 3}},
+		{Name: "b", Values: []float64{2, 3, 4}},
+	}}
+
+	lines := renderBoxPlot(t, boxPlot, 21, 8)
+
+	assert.Equal(t, "  4│           ─┬─   ", lines[0])
+	assert.Equal(t, "  1│  ─┴─            ", lines[5])
+	assert.Equal(t, "       a        b    ", lines[7])
+}
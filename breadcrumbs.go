@@ -1,14 +1,28 @@
 package terma
 
+import (
+	"github.com/charmbracelet/x/ansi"
+	"github.com/darrenburns/terma/layout"
+)
+
 // Breadcrumbs renders a clickable breadcrumb path.
+//
+// When the whole path doesn't fit, segments from the middle are collapsed
+// into an ellipsis, keeping the first segment and as many of the last ones
+// as fit: "home > … > src > main.go".
+//
+// With OnSelect set, each segment but the last (the current location) is
+// a link: clicking it, or focusing it and pressing Enter or Space, calls
+// OnSelect with its index in Path.
 type Breadcrumbs struct {
-	ID        string
-	Path      []string
-	OnSelect  func(index int) // Click to navigate
-	Separator string          // Default: ">"
-	Width     Dimension // Deprecated: use Style.Width
-	Height    Dimension // Deprecated: use Style.Height
-	Style     Style
+	ID           string
+	Path         []string
+	OnSelect     func(index int) // Click or press Enter on a segment to navigate
+	Separator    string          // Default: ">"
+	DisableFocus bool            // If true, segments can be clicked but not focused
+	Width        Dimension       // Deprecated: use Style.Width
+	Height       Dimension       // Deprecated: use Style.Height
+	Style        Style           // Style.Ellipsis sets the marker for collapsed segments (default "…")
 }
 
 // Build renders the breadcrumb path as a row of text segments.
@@ -23,7 +37,15 @@ func (b Breadcrumbs) Build(ctx BuildContext) Widget {
 	}
 	separator = " " + separator + " "
 
-	children := make([]Widget, 0, len(b.Path)*2-1)
+	mutedStyle := b.Style
+	mutedStyle.Width = Dimension{}
+	mutedStyle.Height = Dimension{}
+	if mutedStyle.ForegroundColor == nil || !mutedStyle.ForegroundColor.IsSet() {
+		mutedStyle.ForegroundColor = ctx.Theme().TextMuted
+	}
+
+	segments := make([]Widget, len(b.Path))
+	widths := make([]int, len(b.Path))
 	for i, label := range b.Path {
 		style := b.Style
 		style.Width = Dimension{}
@@ -36,27 +58,20 @@ func (b Breadcrumbs) Build(ctx BuildContext) Widget {
 			}
 		}
 
-		index := i
-		text := Text{
-			Content: label,
-			Style:   style,
+		segment := breadcrumbSegment{
+			ID:        ctx.ScopedID("segment", i),
+			Label:     label,
+			Style:     style,
+			Focusable: !b.DisableFocus && i < len(b.Path)-1,
 		}
 		if b.OnSelect != nil {
-			text.Click = func(MouseEvent) {
+			index := i
+			segment.OnSelect = func() {
 				b.OnSelect(index)
 			}
 		}
-		children = append(children, text)
-
-		if i < len(b.Path)-1 {
-			sepStyle := b.Style
-			sepStyle.Width = Dimension{}
-			sepStyle.Height = Dimension{}
-			if sepStyle.ForegroundColor == nil || !sepStyle.ForegroundColor.IsSet() {
-				sepStyle.ForegroundColor = ctx.Theme().TextMuted
-			}
-			children = append(children, Text{Content: separator, Style: sepStyle})
-		}
+		segments[i] = segment
+		widths[i] = ansi.StringWidth(label)
 	}
 
 	rowStyle := b.Style
@@ -69,10 +84,183 @@ func (b Breadcrumbs) Build(ctx BuildContext) Widget {
 	if rowStyle.Height.IsUnset() {
 		rowStyle.Height = b.Height
 	}
-	return Row{
-		ID:         b.ID,
-		CrossAlign: CrossAxisCenter,
-		Children:   children,
-		Style:      rowStyle,
+	ellipsis := b.Style.EllipsisMarker()
+	return breadcrumbsRow{
+		Row: Row{
+			ID:         b.ID,
+			CrossAlign: CrossAxisCenter,
+			Style:      rowStyle,
+		},
+		segments:       segments,
+		widths:         widths,
+		separator:      Text{Content: separator, Style: mutedStyle},
+		separatorWidth: ansi.StringWidth(separator),
+		ellipsis:       Text{Content: ellipsis, Style: mutedStyle},
+		ellipsisWidth:  ansi.StringWidth(ellipsis),
+		shown:          new([]Widget),
+	}
+}
+
+// breadcrumbSegment is one segment of a Breadcrumbs.
+type breadcrumbSegment struct {
+	ID        string
+	Label     string
+	Style     Style
+	OnSelect  func()
+	Focusable bool
+}
+
+// WidgetID returns the segment's identifier.
+// Implements the Identifiable interface.
+func (s breadcrumbSegment) WidgetID() string {
+	return s.ID
+}
+
+// IsFocusable reports whether the segment is a link that takes focus.
+// Implements the Focusable interface.
+func (s breadcrumbSegment) IsFocusable() bool {
+	return s.Focusable && s.OnSelect != nil
+}
+
+// OnKey handles keys not covered by Keybinds.
+// Implements the Focusable interface.
+func (s breadcrumbSegment) OnKey(event KeyEvent) bool {
+	return false
+}
+
+// Keybinds selects the segment with Enter or Space.
+// Implements the KeybindProvider interface.
+func (s breadcrumbSegment) Keybinds() []Keybind {
+	return []Keybind{
+		{Key: "enter", Name: "Open", Action: s.selectSegment},
+		{Key: " ", Name: "Open", Action: s.selectSegment, Hidden: true},
+	}
+}
+
+func (s breadcrumbSegment) selectSegment() {
+	if s.OnSelect != nil {
+		s.OnSelect()
+	}
+}
+
+// OnClick selects the segment.
+// Implements the Clickable interface.
+func (s breadcrumbSegment) OnClick(event MouseEvent) {
+	s.selectSegment()
+}
+
+// CursorHint suggests the pointing hand over links.
+// Implements the CursorHintProvider interface.
+func (s breadcrumbSegment) CursorHint(event MouseEvent) CursorHint {
+	if s.OnSelect == nil {
+		return CursorDefault
+	}
+	return CursorPointer
+}
+
+// Build renders the label, highlighted while focused.
+func (s breadcrumbSegment) Build(ctx BuildContext) Widget {
+	style := s.Style
+	if s.IsFocusable() && ctx.IsFocused(s) {
+		style.BackgroundColor = ctx.Theme().Primary
+		style.ForegroundColor = ctx.Theme().TextOnPrimary
+	}
+	return Text{Content: s.Label, Style: style}
+}
+
+// breadcrumbsRow lays out a breadcrumb trail in a row, choosing which
+// segments to show once the width available is known.
+type breadcrumbsRow struct {
+	Row
+	segments       []Widget
+	widths         []int // Display width of each segment
+	separator      Widget
+	separatorWidth int
+	ellipsis       Widget
+	ellipsisWidth  int
+	shown          *[]Widget // Children chosen by the last layout
+}
+
+// Build returns itself as breadcrumbsRow manages its own children.
+func (r breadcrumbsRow) Build(ctx BuildContext) Widget {
+	return r
+}
+
+// ChildWidgets returns the children chosen during layout.
+// Implements the ChildProvider interface.
+func (r breadcrumbsRow) ChildWidgets() []Widget {
+	return *r.shown
+}
+
+// BuildLayoutNode builds a node that lays out the segments that fit.
+// Implements the LayoutNodeBuilder interface.
+func (r breadcrumbsRow) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return &breadcrumbsNode{row: r, ctx: ctx}
+}
+
+// breadcrumbsNode chooses the segments that fit the constraints, then lays
+// them out as a Row.
+type breadcrumbsNode struct {
+	row breadcrumbsRow
+	ctx BuildContext
+}
+
+func (n *breadcrumbsNode) ComputeLayout(constraints layout.Constraints) layout.ComputedLayout {
+	r := n.row
+	padding := toLayoutEdgeInsets(r.Style.Padding)
+	border := borderToEdgeInsets(r.Style.Border)
+	_, maxWidth, _, _ := dimensionSetToMinMax(GetWidgetDimensionSet(r.Row), padding, border)
+	available := constraints.MaxWidth - toLayoutEdgeInsets(r.Style.Margin).Horizontal()
+	if maxWidth > 0 && maxWidth < available {
+		available = maxWidth
+	}
+	available -= padding.Horizontal() + border.Horizontal()
+
+	*r.shown = r.arrange(r.keptTail(available))
+	row := r.Row
+	row.Children = *r.shown
+	return row.BuildLayoutNode(n.ctx).ComputeLayout(constraints)
+}
+
+// keptTail returns how many of the last segments to show after the first
+// segment and the ellipsis, or all of them when the whole trail fits.
+func (r breadcrumbsRow) keptTail(available int) int {
+	count := len(r.widths)
+	full := r.separatorWidth * (count - 1)
+	for _, w := range r.widths {
+		full += w
+	}
+	if full <= available || count <= 2 {
+		return count
+	}
+	width := r.widths[0] + r.separatorWidth + r.ellipsisWidth
+	kept := 0
+	for i := count - 1; i > 1; i-- {
+		width += r.separatorWidth + r.widths[i]
+		if width > available {
+			break
+		}
+		kept++
+	}
+	return max(kept, 1)
+}
+
+// arrange returns the children for a trail keeping the last kept segments.
+func (r breadcrumbsRow) arrange(kept int) []Widget {
+	count := len(r.segments)
+	children := make([]Widget, 0, count*2)
+	if kept >= count {
+		for i, segment := range r.segments {
+			if i > 0 {
+				children = append(children, r.separator)
+			}
+			children = append(children, segment)
+		}
+		return children
+	}
+	children = append(children, r.segments[0], r.separator, r.ellipsis)
+	for _, segment := range r.segments[count-kept:] {
+		children = append(children, r.separator, segment)
 	}
+	return children
 }
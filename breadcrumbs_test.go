@@ -1,6 +1,11 @@
 package terma
 
-import "testing"
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
 
 func TestSnapshot_Breadcrumbs_Basic(t *testing.T) {
 	widget := Breadcrumbs{
@@ -9,3 +14,45 @@ func TestSnapshot_Breadcrumbs_Basic(t *testing.T) {
 	}
 	AssertSnapshot(t, widget, 40, 3, "Breadcrumbs with three segments separated by >")
 }
+
+func TestSnapshot_Breadcrumbs_CollapsesMiddle(t *testing.T) {
+	widget := Breadcrumbs{
+		ID:   "breadcrumbs-collapsed",
+		Path: []string{"home", "user", "projects", "terma", "main.go"},
+	}
+	AssertSnapshot(t, widget, 30, 1, "The middle segments collapse into an ellipsis, keeping home and the last two segments")
+}
+
+func TestBreadcrumbs_CollapsesMiddleSegments(t *testing.T) {
+	path := []string{"home", "user", "projects", "terma", "main.go"}
+	cases := []struct {
+		width int
+		want  string
+	}{
+		{42, " home > user > projects > terma > main.go "},
+		{30, " home > … > terma > main.go   "},
+		{20, " home > … > main.go "},
+	}
+	for _, c := range cases {
+		buf := RenderToBuffer(Breadcrumbs{Path: path}, c.width, 1)
+		assert.Equal(t, c.want, bufferLine(buf, 0, c.width), "width %d", c.width)
+	}
+}
+
+func TestBreadcrumbs_SegmentsSelectOnClickAndEnter(t *testing.T) {
+	var selected []int
+	crumbs := Breadcrumbs{
+		Path:     []string{"home", "user", "projects", "terma", "main.go"},
+		OnSelect: func(index int) { selected = append(selected, index) },
+	}
+
+	Replay(crumbs, []InputRecord{
+		{Kind: "click", X: 14, Y: 0, Button: int(uv.MouseLeft)}, // terma
+		{Kind: "key", Code: uv.KeyEnter},
+		{Kind: "click", X: 22, Y: 0, Button: int(uv.MouseLeft)}, // main.go, the current location
+		{Kind: "key", Code: uv.KeyEnter},
+	}, 30, 1)
+
+	// main.go can be clicked but not focused, so Enter still opens terma
+	assert.Equal(t, []int{3, 3, 4, 3}, selected)
+}
@@ -118,6 +118,12 @@ func (b Button) Build(ctx BuildContext) Widget {
 	bg := style.BackgroundColor.ColorAt(1, 1, 0, 0)
 	var bracketColor Color
 
+	// Comfortable density pads the label inside the brackets: [ label ]
+	label := b.Label
+	if ctx.Density() == DensityComfortable {
+		label = " " + label + " "
+	}
+
 	// Handle disabled state
 	if ctx.IsDisabled() {
 		style.ForegroundColor = theme.TextDisabled
@@ -126,7 +132,7 @@ func (b Button) Build(ctx BuildContext) Widget {
 		return Text{
 			Spans: []Span{
 				ColorSpan("[", bracketColor),
-				PlainSpan(b.Label),
+				PlainSpan(label),
 				ColorSpan("]", bracketColor),
 			},
 			Style: style,
@@ -147,7 +153,7 @@ func (b Button) Build(ctx BuildContext) Widget {
 	return Text{
 		Spans: []Span{
 			ColorSpan("[", bracketColor),
-			PlainSpan(b.Label),
+			PlainSpan(label),
 			ColorSpan("]", bracketColor),
 		},
 		Style: style,
@@ -176,6 +182,12 @@ func (b Button) OnClick(event MouseEvent) {
 	}
 }
 
+// CursorHint suggests the pointing hand over the button.
+// Implements the CursorHintProvider interface.
+func (b Button) CursorHint(event MouseEvent) CursorHint {
+	return CursorPointer
+}
+
 // OnMouseDown is called when the mouse is pressed on the widget.
 // Implements the MouseDownHandler interface.
 func (b Button) OnMouseDown(event MouseEvent) {
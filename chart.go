@@ -0,0 +1,185 @@
+package terma
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// This file holds what Plot, LineChart, Histogram and BoxPlot share:
+// mapping data to cells, the axes and the legend.
+
+// chartArea maps data to the cells the data is drawn in.
+type chartArea struct {
+	view          PlotView
+	left, top     int // Offset in the content box
+	width, height int // Size in cells
+}
+
+func (a chartArea) contains(x, y int) bool {
+	return x >= a.left && x < a.left+a.width && y >= a.top && y < a.top+a.height
+}
+
+// dot returns the position of a point in dots, where each cell is 2 dots
+// wide and 4 tall, from the top left.
+func (a chartArea) dot(pt PlotPoint) (float64, float64) {
+	dx := (pt.X - a.view.MinX) / a.view.width() * float64(a.width*2-1)
+	dy := (a.view.MaxY - pt.Y) / a.view.height() * float64(a.height*4-1)
+	return dx, dy
+}
+
+// cellOf returns the cell a point is in, relative to the area.
+func (a chartArea) cellOf(pt PlotPoint) (int, int) {
+	dx, dy := a.dot(pt)
+	return int(math.Floor(math.Round(dx) / 2)), int(math.Floor(math.Round(dy) / 4))
+}
+
+// pointAt returns the data at the middle of a cell, relative to the area.
+func (a chartArea) pointAt(x, y int) PlotPoint {
+	return PlotPoint{
+		X: a.view.MinX + (float64(x*2)+0.5)/float64(max(1, a.width*2-1))*a.view.width(),
+		Y: a.view.MaxY - (float64(y*4)+1.5)/float64(max(1, a.height*4-1))*a.view.height(),
+	}
+}
+
+// widenChartView gives empty ranges some room, so single values are drawn
+// mid-axis.
+func widenChartView(view PlotView) PlotView {
+	widen := func(lo, hi float64) (float64, float64) {
+		if hi > lo {
+			return lo, hi
+		}
+		margin := math.Abs(lo) * 0.1
+		if margin == 0 {
+			margin = 1
+		}
+		return lo - margin, hi + margin
+	}
+	view.MinX, view.MaxX = widen(view.MinX, view.MaxX)
+	view.MinY, view.MaxY = widen(view.MinY, view.MaxY)
+	return view
+}
+
+func chartFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// chartQuantile returns the q quantile (0 to 1) of sorted values,
+// interpolating between the nearest two.
+func chartQuantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	pos := q * float64(len(sorted)-1)
+	i := int(pos)
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (sorted[i+1]-sorted[i])*(pos-float64(i))
+}
+
+// chartFormat returns format, or the default of 4 significant digits.
+func chartFormat(format func(value float64) string) func(value float64) string {
+	if format != nil {
+		return format
+	}
+	return func(value float64) string {
+		return strconv.FormatFloat(value, 'g', 4, 64)
+	}
+}
+
+// chartColors are the default series colors, in order.
+func chartColors(theme ThemeData) []Color {
+	return []Color{theme.Primary, theme.Accent, theme.Success, theme.Warning, theme.Error, theme.Secondary, theme.Info}
+}
+
+// chartColor returns color if set, else the default color for index.
+func chartColor(theme ThemeData, color Color, index int) Color {
+	if color.IsSet() {
+		return color
+	}
+	colors := chartColors(theme)
+	return colors[index%len(colors)]
+}
+
+// drawChartAxes draws the Y labels and axis on the left and the X axis
+// along the bottom, leaving the last row for X labels, and returns the
+// area left for the data.
+func drawChartAxes(ctx *RenderContext, theme ThemeData, view PlotView, yFormat func(value float64) string) chartArea {
+	yFormat = chartFormat(yFormat)
+	area := chartArea{view: view, height: ctx.Height - 2}
+	if area.height <= 0 {
+		return chartArea{}
+	}
+	yLabels := map[int]string{0: yFormat(view.MaxY), area.height - 1: yFormat(view.MinY)}
+	if area.height >= 5 {
+		yLabels[(area.height-1)/2] = yFormat(view.MaxY - view.height()*float64((area.height-1)/2)/float64(area.height-1))
+	}
+	labelWidth := 0
+	for _, label := range yLabels {
+		labelWidth = max(labelWidth, ansi.StringWidth(label))
+	}
+	area.left = labelWidth + 1
+	area.width = ctx.Width - area.left
+	if area.width <= 0 {
+		return chartArea{}
+	}
+
+	labelStyle := Style{ForegroundColor: theme.TextMuted}
+	axisStyle := Style{ForegroundColor: theme.Border}
+	for y := 0; y < area.height; y++ {
+		if label, ok := yLabels[y]; ok {
+			ctx.DrawStyledText(labelWidth-ansi.StringWidth(label), y, label, labelStyle)
+		}
+		ctx.DrawStyledText(labelWidth, y, "│", axisStyle)
+	}
+	ctx.DrawStyledText(labelWidth, area.height, "└"+strings.Repeat("─", area.width), axisStyle)
+	return area
+}
+
+// drawChartXLabels labels the start, middle and end of the X axis, below
+// an area from drawChartAxes.
+func drawChartXLabels(ctx *RenderContext, theme ThemeData, area chartArea, xFormat func(value float64) string) {
+	if area.width <= 0 {
+		return
+	}
+	xFormat = chartFormat(xFormat)
+	view := area.view
+	labelStyle := Style{ForegroundColor: theme.TextMuted}
+	minLabel, maxLabel := xFormat(view.MinX), xFormat(view.MaxX)
+	labelY := area.top + area.height + 1
+	ctx.DrawStyledText(area.left, labelY, minLabel, labelStyle)
+	maxX := area.left + area.width - ansi.StringWidth(maxLabel)
+	if maxX > area.left+ansi.StringWidth(minLabel) {
+		ctx.DrawStyledText(maxX, labelY, maxLabel, labelStyle)
+	}
+	midLabel := xFormat(view.MinX + view.width()/2)
+	midX := area.left + area.width/2 - ansi.StringWidth(midLabel)/2
+	if midX > area.left+ansi.StringWidth(minLabel) && midX+ansi.StringWidth(midLabel) < maxX {
+		ctx.DrawStyledText(midX, labelY, midLabel, labelStyle)
+	}
+}
+
+// chartLegendEntry is one row of a chart's legend.
+type chartLegendEntry struct {
+	name  string
+	color Color
+}
+
+// drawChartLegend lists the named entries in the top right corner of the
+// area.
+func drawChartLegend(ctx *RenderContext, theme ThemeData, area chartArea, entries []chartLegendEntry) {
+	y := area.top
+	for _, entry := range entries {
+		if entry.name == "" || y >= area.top+area.height {
+			continue
+		}
+		x := max(area.left, area.left+area.width-ansi.StringWidth(" ● "+entry.name+" "))
+		ctx.DrawStyledText(x, y, " ", Style{BackgroundColor: theme.Surface})
+		ctx.DrawStyledText(x+1, y, "●", Style{ForegroundColor: entry.color, BackgroundColor: theme.Surface})
+		ctx.DrawStyledText(x+2, y, " "+entry.name+" ", Style{ForegroundColor: theme.Text, BackgroundColor: theme.Surface})
+		y++
+	}
+}
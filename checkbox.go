@@ -167,6 +167,12 @@ func (c *Checkbox) OnClick(event MouseEvent) {
 	}
 }
 
+// CursorHint suggests the pointing hand over the checkbox.
+// Implements the CursorHintProvider interface.
+func (c *Checkbox) CursorHint(event MouseEvent) CursorHint {
+	return CursorPointer
+}
+
 // OnMouseDown is called when the mouse is pressed on the widget.
 // Implements the MouseDownHandler interface.
 func (c *Checkbox) OnMouseDown(event MouseEvent) {
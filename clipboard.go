@@ -0,0 +1,78 @@
+package terma
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// CopyToClipboard puts text on the system clipboard using the OSC 52
+// escape sequence, which works over SSH as long as the terminal allows it.
+// Inside tmux, enable set-clipboard for the sequence to get through.
+func CopyToClipboard(text string) {
+	writeTerminalOutput(ansi.SetSystemClipboard(text))
+}
+
+// Copy puts the text's content on the clipboard, without styling.
+func (t Text) Copy() {
+	CopyToClipboard(t.textContent())
+}
+
+// CopyRows puts the selected rows on the clipboard, or the cursor row when
+// nothing is selected: one line per row, with the visible columns
+// separated by tabs so they paste into spreadsheets. Cell text comes from
+// CopyCell. Focused tables copy with Ctrl+Y.
+func (t Table[T]) CopyRows() {
+	if text := t.copyText(); text != "" {
+		CopyToClipboard(text)
+	}
+}
+
+// copyText returns the text CopyRows copies.
+func (t Table[T]) copyText() string {
+	if t.State == nil {
+		return ""
+	}
+	rows := t.State.Rows.Peek()
+	indices := t.State.SelectedIndices()
+	if len(indices) == 0 || t.selectionMode() == TableSelectionColumn {
+		cursor := t.State.CursorIndex.Peek()
+		if cursor < 0 || cursor >= len(rows) {
+			return ""
+		}
+		indices = []int{cursor}
+	}
+
+	columns := t.visibleColumns(t.State.VisibleColumns.Peek())
+	if columns == nil {
+		columns = indexRange(0, len(t.Columns))
+	}
+	cellText := t.CopyCell
+	if cellText == nil {
+		cellText = defaultTableCopyCell[T]
+	}
+
+	lines := make([]string, 0, len(indices))
+	cells := make([]string, len(columns))
+	for _, rowIdx := range indices {
+		if rowIdx < 0 || rowIdx >= len(rows) {
+			continue
+		}
+		for i, col := range columns {
+			cells[i] = cellText(rows[rowIdx], rowIdx, col)
+		}
+		lines = append(lines, strings.Join(cells, "\t"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func defaultTableCopyCell[T any](row T, rowIndex int, colIndex int) string {
+	if content, ok := tableDefaultCellContent(row, colIndex); ok {
+		return content
+	}
+	if colIndex != 0 {
+		return ""
+	}
+	return fmt.Sprintf("%v", row)
+}
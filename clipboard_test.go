@@ -0,0 +1,75 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyToClipboard(t *testing.T) {
+	buf := captureTerminalOutput(t)
+	CopyToClipboard("hello")
+	assert.Equal(t, "\x1b]52;c;aGVsbG8=\x07", buf.String())
+}
+
+func TestText_Copy(t *testing.T) {
+	buf := captureTerminalOutput(t)
+	Text{Spans: []Span{{Text: "bold", Style: SpanStyle{Bold: true}}, {Text: " plain"}}}.Copy()
+	assert.Equal(t, ansi.SetSystemClipboard("bold plain"), buf.String())
+}
+
+func copyTestTable() Table[[]string] {
+	state := NewTableState([][]string{
+		{"api", "ok", "12"},
+		{"db", "degraded", "340"},
+		{"cache", "ok", "3"},
+	})
+	return Table[[]string]{
+		State:   state,
+		Columns: []TableColumn{{}, {}, {}},
+	}
+}
+
+func TestTable_CopyRowsCopiesCursorRow(t *testing.T) {
+	table := copyTestTable()
+	table.State.CursorIndex.Set(1)
+	assert.Equal(t, "db\tdegraded\t340", table.copyText())
+
+	buf := captureTerminalOutput(t)
+	assert.True(t, pressTableKey(table, "ctrl+y"))
+	assert.Equal(t, ansi.SetSystemClipboard("db\tdegraded\t340"), buf.String())
+}
+
+func TestTable_CopyRowsCopiesSelectedRows(t *testing.T) {
+	table := copyTestTable()
+	table.MultiSelect = true
+	table.State.Select(2)
+	table.State.Select(0)
+	assert.Equal(t, "api\tok\t12\ncache\tok\t3", table.copyText())
+}
+
+func TestTable_CopyRowsUsesVisibleColumnsAndCopyCell(t *testing.T) {
+	table := copyTestTable()
+	table.State.SetVisibleColumns([]int{2, 0})
+	table.CopyCell = func(row []string, rowIndex, colIndex int) string {
+		if colIndex == 2 {
+			return row[2] + "ms"
+		}
+		return row[colIndex]
+	}
+	assert.Equal(t, "12ms\tapi", table.copyText())
+
+	buf := captureTerminalOutput(t)
+	assert.True(t, pressTableKey(table, "ctrl+y"))
+	assert.Equal(t, ansi.SetSystemClipboard("12ms\tapi"), buf.String())
+}
+
+func TestTable_CopyRowsStructRowsDefaultToRowText(t *testing.T) {
+	type service struct{ Name string }
+	table := Table[service]{
+		State:   NewTableState([]service{{Name: "api"}}),
+		Columns: []TableColumn{{}, {}},
+	}
+	assert.Equal(t, "{api}\t", table.copyText())
+}
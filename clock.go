@@ -0,0 +1,244 @@
+package terma
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time for animations, spinners, timers and
+// RelativeTime. The default reads the wall clock; tests and recordings can
+// install a ManualClock with SetClock and advance time explicitly.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// AfterFunc calls f once, d after now.
+	AfterFunc(d time.Duration, f func()) Timer
+	// Every calls f with the tick time every d until the timer is stopped.
+	Every(d time.Duration, f func(time.Time)) Timer
+}
+
+// Timer is a pending AfterFunc or Every callback.
+type Timer interface {
+	// Stop cancels the timer. Returns false if it had already fired
+	// (for AfterFunc) or been stopped.
+	Stop() bool
+}
+
+var (
+	clockSourceMu sync.RWMutex
+	clockSource   Clock = wallClock{}
+)
+
+// SetClock replaces the clock used by terma. Pass nil to restore the wall
+// clock. Call it before starting animations; timers already running keep
+// the clock they were created with.
+func SetClock(clock Clock) {
+	if clock == nil {
+		clock = wallClock{}
+	}
+	clockSourceMu.Lock()
+	clockSource = clock
+	clockSourceMu.Unlock()
+	resetClockSignals()
+}
+
+// currentClock returns the clock installed with SetClock.
+func currentClock() Clock {
+	clockSourceMu.RLock()
+	defer clockSourceMu.RUnlock()
+	return clockSource
+}
+
+// Now returns the current time according to the installed clock.
+// Prefer it over time.Now in widgets so they can be tested deterministically.
+func Now() time.Time {
+	return currentClock().Now()
+}
+
+// wallClock is the default Clock backed by the time package.
+type wallClock struct{}
+
+func (wallClock) Now() time.Time {
+	return time.Now()
+}
+
+func (wallClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+func (wallClock) Every(d time.Duration, f func(time.Time)) Timer {
+	ticker := time.NewTicker(d)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case tick := <-ticker.C:
+				f(tick)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return &wallTicker{ticker: ticker, done: done}
+}
+
+// wallTicker stops a wallClock.Every goroutine.
+type wallTicker struct {
+	ticker *time.Ticker
+	done   chan struct{}
+	once   sync.Once
+}
+
+func (t *wallTicker) Stop() bool {
+	stopped := false
+	t.once.Do(func() {
+		t.ticker.Stop()
+		close(t.done)
+		stopped = true
+	})
+	return stopped
+}
+
+// ManualClock is a Clock that only moves when Advance or Set is called.
+// Callbacks due within the advanced span run synchronously, in time order,
+// on the goroutine calling Advance.
+//
+// Example:
+//
+//	clock := t.NewManualClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+//	t.SetClock(clock)
+//	defer t.SetClock(nil)
+//	clock.Advance(500 * time.Millisecond)
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+	nextID int
+}
+
+// manualTimer is a callback scheduled on a ManualClock.
+type manualTimer struct {
+	clock    *ManualClock
+	id       int
+	when     time.Time
+	interval time.Duration // Zero for AfterFunc
+	fn       func(time.Time)
+	stopped  bool
+}
+
+// NewManualClock creates a ManualClock reading start.
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now returns the clock's current time.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc schedules f to run once the clock has advanced by d.
+func (c *ManualClock) AfterFunc(d time.Duration, f func()) Timer {
+	return c.schedule(d, 0, func(time.Time) { f() })
+}
+
+// Every schedules f to run each time the clock passes a multiple of d.
+func (c *ManualClock) Every(d time.Duration, f func(time.Time)) Timer {
+	if d <= 0 {
+		panic("terma: non-positive interval for ManualClock.Every")
+	}
+	return c.schedule(d, d, f)
+}
+
+func (c *ManualClock) schedule(d, interval time.Duration, f func(time.Time)) *manualTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextID++
+	timer := &manualTimer{clock: c, id: c.nextID, when: c.now.Add(d), interval: interval, fn: f}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+// Advance moves the clock forward by d, running every callback that falls
+// due along the way with the clock set to its due time.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set moves the clock to target, running callbacks that fall due on the way.
+// Moving backwards changes Now without running anything.
+func (c *ManualClock) Set(target time.Time) {
+	for {
+		c.mu.Lock()
+		timer := c.nextDue(target)
+		if timer == nil {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+		c.now = timer.when
+		if timer.interval > 0 {
+			timer.when = timer.when.Add(timer.interval)
+		} else {
+			timer.stopped = true
+			c.remove(timer)
+		}
+		fireAt := c.now
+		c.mu.Unlock()
+
+		// Callbacks may schedule or stop timers, so run them unlocked.
+		timer.fn(fireAt)
+	}
+}
+
+// nextDue returns the earliest live timer due at or before target.
+// Must be called with c.mu held.
+func (c *ManualClock) nextDue(target time.Time) *manualTimer {
+	due := make([]*manualTimer, 0, len(c.timers))
+	for _, timer := range c.timers {
+		if !timer.stopped && !timer.when.After(target) {
+			due = append(due, timer)
+		}
+	}
+	if len(due) == 0 {
+		return nil
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].when.Equal(due[j].when) {
+			return due[i].id < due[j].id
+		}
+		return due[i].when.Before(due[j].when)
+	})
+	return due[0]
+}
+
+// remove drops timer from the schedule. Must be called with c.mu held.
+func (c *ManualClock) remove(timer *manualTimer) {
+	for i, candidate := range c.timers {
+		if candidate == timer {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Pending returns how many timers are scheduled and not yet stopped.
+func (c *ManualClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// Stop cancels the timer.
+func (t *manualTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	if t.stopped {
+		return false
+	}
+	t.stopped = true
+	t.clock.remove(t)
+	return true
+}
@@ -0,0 +1,102 @@
+package terma
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withManualClock installs a ManualClock for the duration of the test.
+func withManualClock(t *testing.T) *ManualClock {
+	t.Helper()
+	clock := NewManualClock(time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC))
+	SetClock(clock)
+	t.Cleanup(func() { SetClock(nil) })
+	return clock
+}
+
+func TestManualClock_RunsCallbacksInOrder(t *testing.T) {
+	clock := withManualClock(t)
+	start := clock.Now()
+
+	var fired []string
+	clock.AfterFunc(250*time.Millisecond, func() { fired = append(fired, "after") })
+	ticker := clock.Every(100*time.Millisecond, func(tick time.Time) {
+		fired = append(fired, tick.Sub(start).String())
+	})
+
+	clock.Advance(350 * time.Millisecond)
+	assert.Equal(t, []string{"100ms", "200ms", "after", "300ms"}, fired)
+	assert.Equal(t, start.Add(350*time.Millisecond), Now())
+
+	assert.True(t, ticker.Stop())
+	assert.False(t, ticker.Stop())
+	clock.Advance(time.Second)
+	assert.Len(t, fired, 4)
+	assert.Equal(t, 0, clock.Pending())
+}
+
+func TestManualClock_StoppedAfterFuncNeverRuns(t *testing.T) {
+	clock := withManualClock(t)
+
+	ran := false
+	timer := clock.AfterFunc(time.Second, func() { ran = true })
+	assert.True(t, timer.Stop())
+	clock.Advance(2 * time.Second)
+	assert.False(t, ran)
+}
+
+func TestManualClock_CallbackCanSchedule(t *testing.T) {
+	clock := withManualClock(t)
+
+	var fired []time.Duration
+	start := clock.Now()
+	clock.AfterFunc(time.Second, func() {
+		fired = append(fired, clock.Now().Sub(start))
+		clock.AfterFunc(time.Second, func() { fired = append(fired, clock.Now().Sub(start)) })
+	})
+
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, fired)
+}
+
+func TestAnimationController_AdvancesByClockTime(t *testing.T) {
+	clock := withManualClock(t)
+	controller := NewAnimationController(60)
+	previous := currentController
+	currentController = controller
+	t.Cleanup(func() {
+		controller.Stop()
+		currentController = previous
+	})
+
+	anim := NewAnimation(AnimationConfig[float64]{From: 0, To: 100, Duration: time.Second})
+	anim.Start()
+	require.NotNil(t, controller.Tick())
+
+	clock.Advance(250 * time.Millisecond)
+	<-controller.Tick()
+	controller.Update()
+	assert.Equal(t, float64(25), anim.Get())
+
+	clock.Advance(time.Second)
+	controller.Update()
+	assert.Equal(t, float64(100), anim.Get())
+	assert.False(t, controller.HasActiveAnimations())
+	assert.Equal(t, 0, clock.Pending(), "ticker stops with the last animation")
+}
+
+func TestRelativeTime_RefreshesFromClock(t *testing.T) {
+	withLocale(t, "en", nil)
+	clock := withManualClock(t)
+	widget := RelativeTime{Time: clock.Now()}
+
+	assert.Contains(t, screenText(widget, 30, 1), "just now")
+
+	tick := clockSignal(time.Second).Peek()
+	clock.Advance(45 * time.Second)
+	assert.NotEqual(t, tick, clockSignal(time.Second).Peek())
+	assert.Contains(t, screenText(widget, 30, 1), "45 seconds ago")
+}
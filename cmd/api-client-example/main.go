@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"time"
 
 	t "github.com/darrenburns/terma"
 )
@@ -12,21 +13,17 @@ type TreeItem struct {
 	Type string // "folder" or "request"
 }
 
-// HeaderRow represents a row in the headers table
-type HeaderRow struct {
-	Key   string
-	Value string
-}
-
 type APIClientDemo struct {
-	mainState            *t.SplitPaneState
-	rightState           *t.SplitPaneState
-	treeState            *t.TreeState[TreeItem]
-	requestTab           t.Signal[string]
-	responseTab          t.Signal[string]
-	headersTable         *t.TableState[HeaderRow]
-	bodyTextArea         *t.TextAreaState
-	responseBodyTextArea *t.TextAreaState
+	mainState    *t.SplitPaneState
+	rightState   *t.SplitPaneState
+	treeState    *t.TreeState[TreeItem]
+	requestTab   t.Signal[string]
+	responseTab  t.Signal[string]
+	headers      *t.KeyValueEditorState
+	query        *t.QueryParamsState
+	bodyTextArea *t.TextAreaState
+	responseBody *t.ResponseBodyState
+	timings      []t.TimingPhase
 }
 
 func NewAPIClientDemo() *APIClientDemo {
@@ -56,30 +53,36 @@ func NewAPIClientDemo() *APIClientDemo {
 	}
 
 	// Sample headers data
-	headers := []HeaderRow{
+	headers := []t.KeyValue{
 		{Key: "Content-Type", Value: "application/json"},
 		{Key: "Authorization", Value: "Bearer token123"},
 		{Key: "Accept", Value: "application/json"},
 		{Key: "User-Agent", Value: "APIClient/1.0"},
 	}
 
+	responseBody := t.NewResponseBodyState()
+	responseBody.SetBody("application/json", []byte(`{"id":1,"name":"John Doe","email":"john@example.com","created_at":"2024-01-15T10:30:00Z"}`))
+
 	return &APIClientDemo{
-		mainState:    t.NewSplitPaneState(0.25),
-		rightState:   t.NewSplitPaneState(0.5),
-		treeState:    t.NewTreeState(treeNodes),
-		requestTab:   t.NewSignal("headers"),
-		responseTab:  t.NewSignal("body"),
-		headersTable: t.NewTableState(headers),
+		mainState:   t.NewSplitPaneState(0.25),
+		rightState:  t.NewSplitPaneState(0.5),
+		treeState:   t.NewTreeState(treeNodes),
+		requestTab:  t.NewSignal("headers"),
+		responseTab: t.NewSignal("body"),
+		headers:     t.NewKeyValueEditorState(headers),
+		query:       t.NewQueryParamsState("https://api.example.com/users?page=1&per_page=20"),
 		bodyTextArea: t.NewTextAreaState(`{
   "name": "John Doe",
   "email": "john@example.com"
 }`),
-		responseBodyTextArea: t.NewTextAreaState(`{
-  "id": 1,
-  "name": "John Doe",
-  "email": "john@example.com",
-  "created_at": "2024-01-15T10:30:00Z"
-}`),
+		responseBody: responseBody,
+		timings: []t.TimingPhase{
+			{Name: "DNS", Duration: 12 * time.Millisecond},
+			{Name: "Connect", Duration: 28 * time.Millisecond},
+			{Name: "TLS", Duration: 41 * time.Millisecond},
+			{Name: "Waiting", Duration: 118 * time.Millisecond},
+			{Name: "Download", Duration: 6 * time.Millisecond},
+		},
 	}
 }
 
@@ -192,15 +195,24 @@ func (d *APIClientDemo) buildRequestPanel(ctx t.BuildContext) t.Widget {
 			Height: t.Flex(1),
 		},
 		Children: []t.Widget{
+			t.URLInput{
+				ID:          "url-input",
+				State:       d.query,
+				Placeholder: "https://",
+				Style: t.Style{
+					BackgroundColor: ctx.Theme().Surface,
+					Padding:         t.EdgeInsetsXY(1, 0),
+				},
+			},
 			d.buildTabBar(ctx, []string{"headers", "body", "query", "auth", "info", "options"},
 				[]string{"Headers", "Body", "Query", "Auth", "Info", "Options"},
 				active, func(key string) { d.requestTab.Set(key) }),
 			t.Switcher{
 				Active: active,
 				Children: map[string]t.Widget{
-					"headers": d.buildHeadersTable(ctx),
+					"headers": t.KeyValueEditor{ID: "headers", State: d.headers, KeyHeader: "Header", Style: t.Style{Padding: t.EdgeInsetsXY(1, 0)}},
 					"body":    d.buildBodyTextArea(ctx),
-					"query":   d.buildPlaceholder(ctx, "Query parameters will appear here"),
+					"query":   t.QueryParamEditor{ID: "query-params", State: d.query, Style: t.Style{Padding: t.EdgeInsetsXY(1, 0)}},
 					"auth":    d.buildPlaceholder(ctx, "Authentication settings will appear here"),
 					"info":    d.buildPlaceholder(ctx, "Request info will appear here"),
 					"options": d.buildPlaceholder(ctx, "Request options will appear here"),
@@ -227,10 +239,10 @@ func (d *APIClientDemo) buildResponsePanel(ctx t.BuildContext) t.Widget {
 			t.Switcher{
 				Active: active,
 				Children: map[string]t.Widget{
-					"body":    d.buildResponseBodyTextArea(ctx),
+					"body":    t.ResponseBodyView{ID: "response-body", State: d.responseBody, Style: t.Style{Padding: t.EdgeInsetsXY(1, 1)}},
 					"headers": d.buildPlaceholder(ctx, "Response headers will appear here"),
 					"cookie":  d.buildPlaceholder(ctx, "Cookies will appear here"),
-					"trace":   d.buildPlaceholder(ctx, "Request trace will appear here"),
+					"trace":   t.TimingWaterfall{Phases: d.timings, Style: t.Style{Padding: t.EdgeInsetsXY(1, 1)}},
 				},
 				Style: t.Style{
 					Height:          t.Flex(1),
@@ -270,36 +282,6 @@ func (d *APIClientDemo) buildTabBar(ctx t.BuildContext, keys []string, labels []
 	}
 }
 
-func (d *APIClientDemo) buildHeadersTable(ctx t.BuildContext) t.Widget {
-	return t.Table[HeaderRow]{
-		ID:    "headers-table",
-		State: d.headersTable,
-		Columns: []t.TableColumn{
-			{Width: t.Flex(1), Header: t.Text{Content: "Header", Style: t.Style{Bold: true, Padding: t.EdgeInsetsXY(1, 0)}}},
-			{Width: t.Flex(2), Header: t.Text{Content: "Value", Style: t.Style{Bold: true, Padding: t.EdgeInsetsXY(1, 0)}}},
-		},
-		RenderCell: func(row HeaderRow, rowIndex int, colIndex int, active bool, selected bool) t.Widget {
-			content := row.Key
-			if colIndex == 1 {
-				content = row.Value
-			}
-			style := t.Style{
-				ForegroundColor: ctx.Theme().Text,
-				Padding:         t.EdgeInsetsXY(1, 0),
-			}
-			if active {
-				style.BackgroundColor = ctx.Theme().ActiveCursor
-				style.ForegroundColor = ctx.Theme().SelectionText
-			}
-			return t.Text{Content: content, Style: style}
-		},
-		SelectionMode: t.TableSelectionRow,
-		Style: t.Style{
-			Height: t.Cells(6),
-		},
-	}
-}
-
 func (d *APIClientDemo) buildBodyTextArea(ctx t.BuildContext) t.Widget {
 	return t.TextArea{
 		ID:    "body-textarea",
@@ -314,20 +296,6 @@ func (d *APIClientDemo) buildBodyTextArea(ctx t.BuildContext) t.Widget {
 	}
 }
 
-func (d *APIClientDemo) buildResponseBodyTextArea(ctx t.BuildContext) t.Widget {
-	return t.TextArea{
-		ID:    "response-body-textarea",
-		State: d.responseBodyTextArea,
-		Style: t.Style{
-			Height:          t.Flex(1),
-			Width:           t.Flex(1),
-			BackgroundColor: ctx.Theme().Surface,
-			ForegroundColor: ctx.Theme().Text,
-			Padding:         t.EdgeInsetsXY(1, 1),
-		},
-	}
-}
-
 func (d *APIClientDemo) buildPlaceholder(ctx t.BuildContext, text string) t.Widget {
 	return t.Text{
 		Content: text,
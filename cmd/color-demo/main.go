@@ -34,7 +34,7 @@ func (d *ColorDemo) Build(ctx t.BuildContext) t.Widget {
 				constructorsSection(),
 				lightnessSection(),
 				saturationSection(),
-				harmoniesSection(),
+				harmoniesSection(ctx),
 				blendingSection(),
 				autoTextSection(),
 				accessibilitySection(),
@@ -166,9 +166,20 @@ func saturationSection() t.Widget {
 	}
 }
 
-func harmoniesSection() t.Widget {
+func harmoniesSection(ctx t.BuildContext) t.Widget {
 	base := t.Hex("#06B6D4") // Cyan
 
+	complement := harmonyLabel("Complement")
+	triadic := harmonyLabel("Triadic")
+	analogous := harmonyLabel("Analogous")
+	splitComp := harmonyLabel("Split-Comp")
+
+	// Size the label column to the longest label, plus a gap
+	labelWidth := t.Cells(t.MaxIntrinsicWidth(ctx, complement, triadic, analogous, splitComp) + 1)
+	for _, label := range []*t.Text{&complement, &triadic, &analogous, &splitComp} {
+		label.Style.Width = labelWidth
+	}
+
 	return t.Column{
 		Children: []t.Widget{
 			sectionHeader("Color Harmonies", t.Hex("#06B6D4")),
@@ -176,7 +187,7 @@ func harmoniesSection() t.Widget {
 			// Complementary
 			t.Row{
 				Children: []t.Widget{
-					harmonyLabel("Complement"),
+					complement,
 					harmonyBlock(base, "base"),
 					harmonyBlock(base.Complement(), "+180°"),
 				},
@@ -185,7 +196,7 @@ func harmoniesSection() t.Widget {
 			// Triadic
 			t.Row{
 				Children: []t.Widget{
-					harmonyLabel("Triadic"),
+					triadic,
 					harmonyBlock(base, "base"),
 					harmonyBlock(base.Rotate(120), "+120°"),
 					harmonyBlock(base.Rotate(240), "+240°"),
@@ -195,7 +206,7 @@ func harmoniesSection() t.Widget {
 			// Analogous
 			t.Row{
 				Children: []t.Widget{
-					harmonyLabel("Analogous"),
+					analogous,
 					harmonyBlock(base.Rotate(-30), "-30°"),
 					harmonyBlock(base, "base"),
 					harmonyBlock(base.Rotate(30), "+30°"),
@@ -205,7 +216,7 @@ func harmoniesSection() t.Widget {
 			// Split-complementary
 			t.Row{
 				Children: []t.Widget{
-					harmonyLabel("Split-Comp"),
+					splitComp,
 					harmonyBlock(base, "base"),
 					harmonyBlock(base.Rotate(150), "+150°"),
 					harmonyBlock(base.Rotate(210), "+210°"),
@@ -215,11 +226,7 @@ func harmoniesSection() t.Widget {
 	}
 }
 
-func harmonyLabel(name string) t.Widget {
-	// Pad to consistent width
-	for len(name) < 12 {
-		name = name + " "
-	}
+func harmonyLabel(name string) t.Text {
 	return t.Text{
 		Content: name,
 		Style:   t.Style{ForegroundColor: t.Hex("#94A3B8")},
@@ -17,6 +17,7 @@ type DirectoryTreeDemo struct {
 	scrollState *t.ScrollState
 	status      t.Signal[string]
 	rootPath    string
+	watcher     *t.DirectoryWatcher
 }
 
 func NewDirectoryTreeDemo() *DirectoryTreeDemo {
@@ -29,7 +30,7 @@ func NewDirectoryTreeDemo() *DirectoryTreeDemo {
 		root = abs
 	}
 
-	return &DirectoryTreeDemo{
+	d := &DirectoryTreeDemo{
 		treeState:   t.NewDirectoryTreeState(root),
 		filterState: t.NewFilterState(),
 		filterInput: t.NewTextInputState(""),
@@ -37,6 +38,12 @@ func NewDirectoryTreeDemo() *DirectoryTreeDemo {
 		status:      t.NewSignal("Ready"),
 		rootPath:    root,
 	}
+	d.watcher = t.DirectoryTree{Tree: t.Tree[t.DirectoryEntry]{State: d.treeState}}.Watch(t.DirectoryWatchOptions{
+		OnChange: func(changes []t.DirectoryChange) {
+			d.status.Set(fmt.Sprintf("%d change(s) on disk", len(changes)))
+		},
+	})
+	return d
 }
 
 func (d *DirectoryTreeDemo) Keybinds() []t.Keybind {
@@ -58,6 +65,7 @@ func (d *DirectoryTreeDemo) Build(ctx t.BuildContext) t.Widget {
 
 	tree := t.DirectoryTree{
 		EagerLoad: true,
+		Watcher:   d.watcher,
 		Tree: t.Tree[t.DirectoryEntry]{
 			ID:          "dir-tree",
 			State:       d.treeState,
@@ -183,6 +191,7 @@ func (d *DirectoryTreeDemo) updateCursorStatus(entry t.DirectoryEntry) {
 
 func main() {
 	app := NewDirectoryTreeDemo()
+	defer app.watcher.Stop()
 	if err := t.Run(app); err != nil {
 		log.Fatal(err)
 	}
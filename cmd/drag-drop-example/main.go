@@ -0,0 +1,143 @@
+package main
+
+import (
+	"log"
+
+	t "github.com/darrenburns/terma"
+)
+
+// DragDropDemo drags groceries from a Tree into a basket List, and between
+// the basket and the pantry.
+type DragDropDemo struct {
+	treeState   *t.TreeState[string]
+	basketState *t.ListState[string]
+	pantryState *t.ListState[string]
+	status      t.Signal[string]
+}
+
+func NewDragDropDemo() *DragDropDemo {
+	leaf := func(name string) t.TreeNode[string] {
+		return t.TreeNode[string]{Data: name, Children: []t.TreeNode[string]{}}
+	}
+	return &DragDropDemo{
+		treeState: t.NewTreeState([]t.TreeNode[string]{
+			{Data: "Fruit", Children: []t.TreeNode[string]{leaf("Apple"), leaf("Banana"), leaf("Cherry")}},
+			{Data: "Vegetables", Children: []t.TreeNode[string]{leaf("Carrot"), leaf("Leek"), leaf("Spinach")}},
+		}),
+		basketState: t.NewListState([]string{"Bread"}),
+		pantryState: t.NewListState([]string{"Rice", "Oats"}),
+		status:      t.NewSignal("Drag items with the mouse, or press m to move the tree's item with the keyboard"),
+	}
+}
+
+func (d *DragDropDemo) Keybinds() []t.Keybind {
+	return []t.Keybind{
+		{Key: "m", Name: "Move", Action: d.moveTreeItem},
+	}
+}
+
+// moveTreeItem starts a keyboard drag of the item under the tree's cursor.
+func (d *DragDropDemo) moveTreeItem() {
+	if item, ok := d.treeState.CursorNode(); ok {
+		d.draggable(item, nil, t.Text{Content: item}).StartKeyboardDrag()
+	}
+}
+
+// draggable wraps an item so it can be dropped on a list. Items dragged out
+// of a list leave it when they land elsewhere.
+func (d *DragDropDemo) draggable(item string, from *t.ListState[string], child t.Widget) t.Draggable[string] {
+	return t.Draggable[string]{
+		Payload: item,
+		Child:   child,
+		Ghost:   t.Text{Content: " " + item + " ", Style: t.Style{Reverse: true}},
+		OnDragEnd: func(item string, dropped bool) {
+			if dropped && from != nil {
+				from.RemoveWhere(func(existing string) bool { return existing == item })
+			}
+		},
+	}
+}
+
+func (d *DragDropDemo) dropList(title string, state *t.ListState[string], theme t.ThemeData) t.Widget {
+	return t.DropTarget[string]{
+		ID: title,
+		Accept: func(item string) bool {
+			for _, existing := range state.GetItems() {
+				if existing == item {
+					return false
+				}
+			}
+			return true
+		},
+		OnDrop: func(item string) {
+			state.Append(item)
+			d.status.Set(item + " moved to " + title)
+		},
+		Style: t.Style{
+			Width:   t.Flex(1),
+			Height:  t.Flex(1),
+			Border:  t.Border{Style: t.BorderRounded, Color: theme.Border, Decorations: []t.BorderDecoration{{Text: title, Position: t.DecorationTopLeft}}},
+			Padding: t.EdgeInsetsXY(1, 0),
+		},
+		Child: t.List[string]{
+			ID:    title + "-list",
+			State: state,
+			RenderItem: func(item string, active bool, selected bool) t.Widget {
+				style := t.Style{ForegroundColor: theme.Text}
+				if active {
+					style = t.Style{ForegroundColor: theme.SelectionText, BackgroundColor: theme.ActiveCursor}
+				}
+				return d.draggable(item, state, t.Text{Content: item, Style: style})
+			},
+		},
+	}
+}
+
+func (d *DragDropDemo) Build(ctx t.BuildContext) t.Widget {
+	theme := ctx.Theme()
+
+	return t.Column{
+		ID:      "drag-drop-root",
+		Spacing: 1,
+		Style: t.Style{
+			Width:           t.Flex(1),
+			Height:          t.Flex(1),
+			BackgroundColor: theme.Background,
+			Padding:         t.EdgeInsetsXY(2, 1),
+		},
+		Children: []t.Widget{
+			t.ParseMarkupToText("[b $Accent]Drag[/] items between panes | [b $Accent]m[/] move with the keyboard, then [b $Accent]Tab[/] and [b $Accent]Enter[/] | [b $Error]Ctrl+C[/] quit", theme),
+			t.Row{
+				Spacing: 2,
+				Style:   t.Style{Height: t.Flex(1)},
+				Children: []t.Widget{
+					t.Tree[string]{
+						ID:    "groceries",
+						Width: t.Cells(24),
+						State: d.treeState,
+						RenderNode: func(item string, node t.TreeNodeContext) t.Widget {
+							style := t.Style{ForegroundColor: theme.Text}
+							if node.Active {
+								style = t.Style{ForegroundColor: theme.SelectionText, BackgroundColor: theme.ActiveCursor}
+							}
+							label := t.Text{Content: item, Style: style}
+							if node.Expandable {
+								return label
+							}
+							return d.draggable(item, nil, label)
+						},
+					},
+					d.dropList("Basket", d.basketState, theme),
+					d.dropList("Pantry", d.pantryState, theme),
+				},
+			},
+			t.Text{Content: d.status.Get(), Style: t.Style{ForegroundColor: theme.TextMuted}},
+		},
+	}
+}
+
+func main() {
+	if err := t.Run(NewDragDropDemo()); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"log"
 	"os"
 	"path/filepath"
@@ -130,7 +129,7 @@ func (a *FileSearchDemo) filesToItems(files []FileInfo) []t.CommandPaletteItem {
 				return a.filesToItems(subFiles)
 			}
 		} else {
-			item.Hint = formatSize(file.Size)
+			item.Hint = t.FormatBytes(file.Size)
 			item.Action = func() {
 				a.selectedFile.Set(file.Path)
 				a.palette.Close(false)
@@ -143,26 +142,6 @@ func (a *FileSearchDemo) filesToItems(files []FileInfo) []t.CommandPaletteItem {
 	return items
 }
 
-// formatSize formats a file size in human-readable form.
-func formatSize(size int64) string {
-	const (
-		KB = 1024
-		MB = KB * 1024
-		GB = MB * 1024
-	)
-
-	switch {
-	case size >= GB:
-		return fmt.Sprintf("%.1fGB", float64(size)/float64(GB))
-	case size >= MB:
-		return fmt.Sprintf("%.1fMB", float64(size)/float64(MB))
-	case size >= KB:
-		return fmt.Sprintf("%.1fKB", float64(size)/float64(KB))
-	default:
-		return fmt.Sprintf("%dB", size)
-	}
-}
-
 func (a *FileSearchDemo) togglePalette() {
 	if a.palette.Visible.Peek() {
 		a.palette.Close(false)
@@ -409,6 +409,8 @@ func headerExample() t.Widget {
 }
 
 func main() {
+	// Smooth out banding on 256-color terminals
+	t.SetGradientDithering(t.DitherOrdered)
 	_ = t.Run(&GradientDemo{
 		scrollState: t.NewScrollState(),
 	})
@@ -116,6 +116,9 @@ func (a *HoverEventsDemo) Build(ctx t.BuildContext) t.Widget {
 								Padding:         t.EdgeInsetsAll(1),
 								BackgroundColor: theme.Surface,
 								ForegroundColor: theme.Text,
+								// Applied by the framework, no event wiring needed
+								HoverStyle:   &t.Style{BackgroundColor: theme.SurfaceHover, Bold: true},
+								PressedStyle: &t.Style{BackgroundColor: theme.Primary, ForegroundColor: theme.TextOnPrimary},
 							},
 						},
 						&t.Checkbox{
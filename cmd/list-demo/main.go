@@ -109,22 +109,17 @@ func (d *ListDemo) Keybinds() []t.Keybind {
 }
 
 func (d *ListDemo) buildSelectionSummary(theme t.ThemeData) t.Widget {
-	// Subscribe to selection changes with .Get()
-	selection := d.listState.Selection.Get()
-	if len(selection) == 0 {
+	// SelectionCount subscribes to selection changes
+	count := d.listState.SelectionCount()
+	if count == 0 {
 		return t.Text{
-			Spans: t.ParseMarkup("[$TextMuted]No items selected[/]", theme),
+			Spans: t.ParseMarkup("[$TextMuted]No items selected[/] | [b $Info]Ctrl+A[/] all, [b $Info]Alt+A[/] none, [b $Info]*[/] invert", theme),
 		}
 	}
 
-	// Get the actual selected items (also subscribe to item changes)
-	items := d.listState.Items.Get()
-	var selected []string
-	for i, item := range items {
-		if _, ok := selection[i]; ok {
-			selected = append(selected, item)
-		}
-	}
+	// Subscribe to item changes too, so the summary follows edits
+	d.listState.Items.Get()
+	selected := d.listState.SelectedItems()
 
 	summary := strings.Join(selected, ", ")
 	if len(summary) > 50 {
@@ -132,7 +127,7 @@ func (d *ListDemo) buildSelectionSummary(theme t.ThemeData) t.Widget {
 	}
 
 	return t.Text{
-		Spans: t.ParseMarkup(fmt.Sprintf("[b $Secondary]Selected (%d): [/]%s", len(selected), summary), theme),
+		Spans: t.ParseMarkup(fmt.Sprintf("[b $Secondary]Selected (%d): [/]%s", count, summary), theme),
 	}
 }
 
@@ -193,7 +188,8 @@ func (d *ListDemo) Build(ctx t.BuildContext) t.Widget {
 							BackgroundColor: theme.Surface,
 							ForegroundColor: theme.Text,
 						},
-						OnChange: func(text string) {
+						// Filter once typing pauses rather than on every keystroke.
+						OnChangeDebounced: func(text string) {
 							d.filterState.Query.Set(text)
 						},
 						OnSubmit: func(text string) {
@@ -232,6 +228,7 @@ func (d *ListDemo) Build(ctx t.BuildContext) t.Widget {
 					ScrollState: d.scrollState,
 					Filter:      d.filterState,
 					MultiSelect: true,
+					Markers:     &t.SelectionMarkers{},
 				},
 			},
 
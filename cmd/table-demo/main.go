@@ -340,7 +340,8 @@ func (d *TableDemo) Build(ctx t.BuildContext) t.Widget {
 							BackgroundColor: theme.Surface,
 							ForegroundColor: theme.Text,
 						},
-						OnChange: func(text string) {
+						// Filter once typing pauses rather than on every keystroke.
+						OnChangeDebounced: func(text string) {
 							d.filterState.Query.Set(text)
 						},
 						OnSubmit: func(text string) {
@@ -0,0 +1,185 @@
+// Command terma-dev runs a terma app and restarts it whenever its Go source
+// changes, carrying HotSignal values and the focused widget across restarts.
+//
+// Usage:
+//
+//	go run ./cmd/terma-dev [-watch dir] [-interval 500ms] ./cmd/my-app [app args...]
+//
+// The app is rebuilt in the background while the old build keeps running,
+// then asked to quit with SIGTERM so it saves its hot state, and the new
+// build is started on the same terminal. Build errors leave the running
+// app untouched and are written to terma-dev.log.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	t "github.com/darrenburns/terma"
+)
+
+func main() {
+	watchDir := flag.String("watch", ".", "directory to watch for .go file changes")
+	interval := flag.Duration("interval", 500*time.Millisecond, "how often to check for changes")
+	flag.Parse()
+	if flag.NArg() < 1 {
+		fmt.Fprintln(os.Stderr, "usage: terma-dev [-watch dir] [-interval 500ms] <package> [args...]")
+		os.Exit(2)
+	}
+
+	workDir, err := os.MkdirTemp("", "terma-dev")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(workDir)
+
+	logFile, err := os.Create("terma-dev.log")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer logFile.Close()
+	logger := log.New(logFile, "", log.Ltime)
+
+	dev := &devServer{
+		pkg:       flag.Arg(0),
+		args:      flag.Args()[1:],
+		binary:    filepath.Join(workDir, "app"),
+		statePath: filepath.Join(workDir, "state.json"),
+		logger:    logger,
+	}
+	os.Exit(dev.run(*watchDir, *interval))
+}
+
+// devServer rebuilds and restarts the app.
+type devServer struct {
+	pkg       string
+	args      []string
+	binary    string
+	statePath string
+	logger    *log.Logger
+}
+
+// run starts the app and restarts it on source changes until the app exits
+// on its own, returning its exit code.
+func (d *devServer) run(watchDir string, interval time.Duration) int {
+	if err := d.build(d.binary); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	lastChange := latestModTime(watchDir)
+	for {
+		cmd, exited := d.start()
+
+		ticker := time.NewTicker(interval)
+		restarted := false
+		for !restarted {
+			select {
+			case err := <-exited:
+				ticker.Stop()
+				var exitErr *exec.ExitError
+				if errors.As(err, &exitErr) {
+					return exitErr.ExitCode()
+				}
+				return 0
+			case <-ticker.C:
+				changed := latestModTime(watchDir)
+				if !changed.After(lastChange) {
+					continue
+				}
+				lastChange = changed
+
+				// Build alongside the running app so a failing build keeps it alive.
+				next := d.binary + ".next"
+				if err := d.build(next); err != nil {
+					d.logger.Printf("build failed, keeping the running app:\n%v", err)
+					continue
+				}
+				d.logger.Printf("rebuilt %s, restarting", d.pkg)
+				d.stop(cmd, exited)
+				if err := os.Rename(next, d.binary); err != nil {
+					d.logger.Printf("replacing binary: %v", err)
+				}
+				ticker.Stop()
+				restarted = true
+			}
+		}
+	}
+}
+
+// build compiles the app package to output.
+func (d *devServer) build(output string) error {
+	out, err := exec.Command("go", "build", "-o", output, d.pkg).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("go build %s: %v\n%s", d.pkg, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// start runs the current binary attached to this terminal.
+func (d *devServer) start() (*exec.Cmd, <-chan error) {
+	cmd := exec.Command(d.binary, d.args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = append(os.Environ(), t.HotStateEnv+"="+d.statePath)
+
+	exited := make(chan error, 1)
+	if err := cmd.Start(); err != nil {
+		exited <- err
+		return cmd, exited
+	}
+	go func() { exited <- cmd.Wait() }()
+	return cmd, exited
+}
+
+// stop asks the app to quit so it restores the terminal and saves its hot
+// state, killing it if it doesn't exit in time.
+func (d *devServer) stop(cmd *exec.Cmd, exited <-chan error) {
+	if cmd.Process == nil {
+		return
+	}
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		_ = cmd.Process.Kill()
+	}
+	select {
+	case <-exited:
+	case <-time.After(3 * time.Second):
+		d.logger.Printf("app did not exit after SIGTERM, killing it")
+		_ = cmd.Process.Kill()
+		<-exited
+	}
+}
+
+// latestModTime returns the newest modification time of the .go files
+// and go.mod/go.sum under dir.
+func latestModTime(dir string) time.Time {
+	var latest time.Time
+	_ = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			if path != dir && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") && name != "go.mod" && name != "go.sum" {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	return latest
+}
@@ -122,41 +122,7 @@ func (d DiffView) OnMouseUp(event t.MouseEvent) {
 }
 
 func (d DiffView) BuildLayoutNode(ctx t.BuildContext) layout.LayoutNode {
-	style := d.Style
-	padding := toLayoutInsets(style.Padding)
-	border := layout.EdgeInsetsAll(style.Border.Width())
-	dims := d.Style.GetDimensions()
-	if dims.Width.IsUnset() {
-		dims.Width = d.Width
-	}
-	if dims.Height.IsUnset() {
-		dims.Height = d.Height
-	}
-
-	minWidth, maxWidth, minHeight, maxHeight := dimensionSetToMinMax(dims, padding, border)
-	expandWidth := dims.Width.IsFlex() || dims.Width.IsPercent()
-	expandHeight := dims.Height.IsFlex() || dims.Height.IsPercent()
-
-	return &layout.BoxNode{
-		MinWidth:     minWidth,
-		MaxWidth:     maxWidth,
-		MinHeight:    minHeight,
-		MaxHeight:    maxHeight,
-		Padding:      padding,
-		Border:       border,
-		Margin:       toLayoutInsets(style.Margin),
-		ExpandWidth:  expandWidth,
-		ExpandHeight: expandHeight,
-		MeasureFunc: func(constraints layout.Constraints) (int, int) {
-			size := d.Layout(ctx, t.Constraints{
-				MinWidth:  constraints.MinWidth,
-				MaxWidth:  constraints.MaxWidth,
-				MinHeight: constraints.MinHeight,
-				MaxHeight: constraints.MaxHeight,
-			})
-			return size.Width, size.Height
-		},
-	}
+	return t.LeafLayoutNode(ctx, d)
 }
 
 func (d DiffView) Layout(ctx t.BuildContext, constraints t.Constraints) t.Size {
@@ -1290,72 +1256,6 @@ func wrappedLineRowCount(line RenderedDiffLine, wrapWidth int) int {
 	return rows
 }
 
-func toLayoutInsets(in t.EdgeInsets) layout.EdgeInsets {
-	return layout.EdgeInsets{
-		Top:    in.Top,
-		Right:  in.Right,
-		Bottom: in.Bottom,
-		Left:   in.Left,
-	}
-}
-
-func dimensionSetToMinMax(ds t.DimensionSet, padding layout.EdgeInsets, border layout.EdgeInsets) (minW int, maxW int, minH int, maxH int) {
-	explicitMinW := dimensionToCells(ds.MinWidth)
-	explicitMaxW := dimensionToCells(ds.MaxWidth)
-	explicitMinH := dimensionToCells(ds.MinHeight)
-	explicitMaxH := dimensionToCells(ds.MaxHeight)
-
-	if ds.Width.IsCells() {
-		width := clampFixedDimension(ds.Width.CellsValue(), explicitMinW, explicitMaxW)
-		minW, maxW = width, width
-	} else {
-		minW, maxW = explicitMinW, explicitMaxW
-	}
-	if ds.Height.IsCells() {
-		height := clampFixedDimension(ds.Height.CellsValue(), explicitMinH, explicitMaxH)
-		minH, maxH = height, height
-	} else {
-		minH, maxH = explicitMinH, explicitMaxH
-	}
-
-	hInset := padding.Horizontal() + border.Horizontal()
-	vInset := padding.Vertical() + border.Vertical()
-
-	if minW > 0 {
-		minW += hInset
-	}
-	if maxW > 0 {
-		maxW += hInset
-	}
-	if minH > 0 {
-		minH += vInset
-	}
-	if maxH > 0 {
-		maxH += vInset
-	}
-	return minW, maxW, minH, maxH
-}
-
-func dimensionToCells(dim t.Dimension) int {
-	if dim.IsCells() {
-		return dim.CellsValue()
-	}
-	return 0
-}
-
-func clampFixedDimension(value int, minValue int, maxValue int) int {
-	if minValue > 0 && maxValue > 0 && maxValue < minValue {
-		return minValue
-	}
-	if minValue > 0 && value < minValue {
-		value = minValue
-	}
-	if maxValue > 0 && value > maxValue {
-		value = maxValue
-	}
-	return value
-}
-
 func lineText(line RenderedDiffLine) string {
 	if len(line.Segments) == 0 {
 		return ""
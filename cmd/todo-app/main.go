@@ -484,26 +484,9 @@ func (a *TodoApp) buildTaskList(ctx t.BuildContext) t.Widget {
 		scrollState = a.filteredScrollState
 	}
 
-	// Show empty state if no items
-	if listState.ItemCount() == 0 {
-		message := "There’s nothing to do."
-		if isFilterMode {
-			message = "Press [b]enter[/] to create this task."
-		}
-		return t.Column{
-			Height:     t.Flex(1),
-			Width:      t.Flex(1),
-			MainAlign:  t.MainAxisCenter,
-			CrossAlign: t.CrossAxisCenter,
-			Children: []t.Widget{
-				t.Text{
-					Spans: t.ParseMarkup(message, theme),
-					Style: t.Style{
-						ForegroundColor: theme.TextMuted.WithAlpha(0.5),
-					},
-				},
-			},
-		}
+	message := "There’s nothing to do."
+	if isFilterMode {
+		message = "Press [b]enter[/] to create this task."
 	}
 
 	// Check if the list is focused
@@ -521,6 +504,19 @@ func (a *TodoApp) buildTaskList(ctx t.BuildContext) t.Widget {
 			RenderItem:  a.renderTaskItem(ctx, listFocused),
 			OnSelect:    a.toggleTask,
 			MultiSelect: true,
+			EmptyState: t.Column{
+				Width:      t.Flex(1),
+				CrossAlign: t.CrossAxisCenter,
+				Style:      t.Style{Padding: t.EdgeInsetsTRBL(1, 0, 0, 0)},
+				Children: []t.Widget{
+					t.Text{
+						Spans: t.ParseMarkup(message, theme),
+						Style: t.Style{
+							ForegroundColor: theme.TextMuted.WithAlpha(0.5),
+						},
+					},
+				},
+			},
 			Blur: func() {
 				// Preserve multi-selection while the move menu is open so menu actions
 				// can operate on the full selected set.
@@ -1215,26 +1211,16 @@ func (a *TodoApp) setTaskCompleted(task Task, completed bool) {
 }
 
 func (a *TodoApp) updateTaskCompletion(listState *t.ListState[Task], id string, completed bool) {
-	listState.Items.Update(func(items []Task) []Task {
-		for i := range items {
-			if items[i].ID == id {
-				items[i].Completed = completed
-				break
-			}
-		}
-		return items
+	listState.UpdateWhere(func(task Task) bool { return task.ID == id }, func(task Task) Task {
+		task.Completed = completed
+		return task
 	})
 }
 
 func (a *TodoApp) updateTaskTitle(listState *t.ListState[Task], id string, title string) {
-	listState.Items.Update(func(items []Task) []Task {
-		for i := range items {
-			if items[i].ID == id {
-				items[i].Title = title
-				break
-			}
-		}
-		return items
+	listState.UpdateWhere(func(task Task) bool { return task.ID == id }, func(task Task) Task {
+		task.Title = title
+		return task
 	})
 }
 
@@ -1340,8 +1326,7 @@ func (a *TodoApp) moveTaskUp() {
 		return
 	}
 
-	tasks[idx], tasks[idx-1] = tasks[idx-1], tasks[idx]
-	listState.SetItems(tasks)
+	listState.Swap(idx, idx-1)
 	a.refreshFilteredTasks()
 	listState.SelectIndex(idx - 1)
 	a.scheduleSave()
@@ -1386,8 +1371,7 @@ func (a *TodoApp) moveTaskDown() {
 		return
 	}
 
-	tasks[idx], tasks[idx+1] = tasks[idx+1], tasks[idx]
-	listState.SetItems(tasks)
+	listState.Swap(idx, idx+1)
 	a.refreshFilteredTasks()
 	listState.SelectIndex(idx + 1)
 	a.scheduleSave()
@@ -0,0 +1,323 @@
+package terma
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/darrenburns/terma/layout"
+)
+
+// CodeTokenKind classifies a CodeToken, deciding the color it is drawn in.
+type CodeTokenKind int
+
+const (
+	CodeTokenPlain       CodeTokenKind = iota // Identifiers, whitespace and anything unclassified
+	CodeTokenKeyword                          // Language keywords
+	CodeTokenType                             // Type, class and builtin names
+	CodeTokenFunction                         // Function names
+	CodeTokenString                           // String and character literals
+	CodeTokenNumber                           // Numeric literals
+	CodeTokenComment                          // Comments
+	CodeTokenPunctuation                      // Operators and punctuation
+)
+
+// CodeToken is a run of source text of one kind.
+type CodeToken struct {
+	Text string
+	Kind CodeTokenKind
+}
+
+// Lexer splits source text into tokens. The tokens' text, joined, should
+// be the source.
+type Lexer interface {
+	Tokenize(source string) []CodeToken
+}
+
+// LexerFunc adapts a function to the Lexer interface.
+type LexerFunc func(source string) []CodeToken
+
+// Tokenize implements the Lexer interface.
+func (f LexerFunc) Tokenize(source string) []CodeToken {
+	return f(source)
+}
+
+// LexerFor returns a Lexer for a language, looked up by name, alias or
+// file name (e.g. "go", "Python", "main.rs"), or nil if it isn't known.
+// The lexers are chroma's, so every language chroma supports is available.
+func LexerFor(language string) Lexer {
+	if language == "" || lexers.Get(language) == nil {
+		return nil
+	}
+	return chromaLexer{language: language}
+}
+
+// chromaLexer tokenizes with the chroma lexer registered for language.
+type chromaLexer struct {
+	language string
+}
+
+// Tokenize implements the Lexer interface. Source chroma fails to lex comes
+// back as a single plain token.
+func (l chromaLexer) Tokenize(source string) []CodeToken {
+	lexer := lexers.Get(l.language)
+	if lexer == nil {
+		return []CodeToken{{Text: source}}
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, source)
+	if err != nil {
+		return []CodeToken{{Text: source}}
+	}
+	var tokens []CodeToken
+	for token := iterator(); token != chroma.EOF; token = iterator() {
+		if token.Value != "" {
+			tokens = append(tokens, CodeToken{Text: token.Value, Kind: codeTokenKindFromChroma(token.Type)})
+		}
+	}
+	return tokens
+}
+
+func codeTokenKindFromChroma(token chroma.TokenType) CodeTokenKind {
+	switch {
+	case token.InCategory(chroma.Comment):
+		return CodeTokenComment
+	case token == chroma.KeywordType || token == chroma.NameClass || token.InSubCategory(chroma.NameBuiltin):
+		return CodeTokenType
+	case token.InCategory(chroma.Keyword):
+		return CodeTokenKeyword
+	case token.InSubCategory(chroma.LiteralString):
+		return CodeTokenString
+	case token.InSubCategory(chroma.LiteralNumber):
+		return CodeTokenNumber
+	case token.InSubCategory(chroma.NameFunction):
+		return CodeTokenFunction
+	case token == chroma.Punctuation || token.InCategory(chroma.Operator):
+		return CodeTokenPunctuation
+	default:
+		return CodeTokenPlain
+	}
+}
+
+// CodeTokenStyle returns the theme's style for a kind of token.
+func CodeTokenStyle(theme ThemeData, kind CodeTokenKind) SpanStyle {
+	switch kind {
+	case CodeTokenKeyword:
+		return SpanStyle{Foreground: theme.Accent, Bold: true}
+	case CodeTokenType:
+		return SpanStyle{Foreground: theme.Primary}
+	case CodeTokenFunction:
+		return SpanStyle{Foreground: theme.Secondary}
+	case CodeTokenString:
+		return SpanStyle{Foreground: theme.Success}
+	case CodeTokenNumber:
+		return SpanStyle{Foreground: theme.Warning}
+	case CodeTokenComment:
+		return SpanStyle{Foreground: theme.TextMuted, Italic: true}
+	default:
+		return SpanStyle{}
+	}
+}
+
+// codeLines is source split into lines of tokens, tabs expanded.
+type codeLines struct {
+	lines [][]CodeToken
+	width int // Widest line, in cells
+}
+
+// maxCachedCode bounds the cache of lexed sources.
+const maxCachedCode = 32
+
+type codeCacheKey struct {
+	language string
+	tabWidth int
+	source   string
+}
+
+// codeCache memoizes lexing by language, so a CodeView rebuilt on every
+// frame only lexes its source the first time.
+var codeCache = struct {
+	sync.Mutex
+	entries map[codeCacheKey]codeLines
+}{entries: map[codeCacheKey]codeLines{}}
+
+// lexCode tokenizes source with lexer and splits it into lines. A final
+// newline doesn't start a line of its own.
+func lexCode(lexer Lexer, source string, tabWidth int) codeLines {
+	tokens := []CodeToken{{Text: source}}
+	if lexer != nil {
+		tokens = lexer.Tokenize(source)
+	}
+	lineCount := strings.Count(source, "\n") + 1
+	if strings.HasSuffix(source, "\n") {
+		lineCount--
+	}
+
+	result := codeLines{lines: make([][]CodeToken, 1, lineCount)}
+	column := 0
+	for _, token := range tokens {
+		for i, part := range strings.Split(token.Text, "\n") {
+			if i > 0 {
+				result.width = max(result.width, column)
+				result.lines = append(result.lines, nil)
+				column = 0
+			}
+			if part == "" {
+				continue
+			}
+			if strings.Contains(part, "\t") {
+				part = expandTabs(part, tabWidth, &column)
+			} else {
+				column += ansi.StringWidth(part)
+			}
+			last := len(result.lines) - 1
+			result.lines[last] = append(result.lines[last], CodeToken{Text: part, Kind: token.Kind})
+		}
+	}
+	result.width = max(result.width, column)
+	if len(result.lines) > lineCount {
+		result.lines = result.lines[:max(1, lineCount)]
+	}
+	return result
+}
+
+// CodeView displays syntax-highlighted source code, optionally with line
+// numbers. Set Language to highlight with a built-in lexer (see LexerFor),
+// or Lexer to supply your own; source in an unknown language is shown
+// plain. Lexing is cached, and only the lines inside the visible area are
+// drawn, so a long file in a Scrollable costs little per frame.
+//
+// Lines are not wrapped; in a Scrollable, long lines scroll horizontally.
+//
+// Example:
+//
+//	t.Scrollable{
+//	    State: a.scroll,
+//	    Child: t.CodeView{
+//	        Source:      string(data),
+//	        Language:    "go",
+//	        LineNumbers: true,
+//	    },
+//	}
+type CodeView struct {
+	ID          string                      // Optional unique identifier
+	Source      string                      // The code to display
+	Language    string                      // Language name, alias or file name used to pick a lexer
+	Lexer       Lexer                       // Optional: tokenizes Source instead of Language's lexer (not cached)
+	LineNumbers bool                        // Show a gutter of line numbers
+	FirstLine   int                         // Number of the first line in the gutter (default = 1)
+	TabWidth    int                         // Columns between tab stops (default = 4)
+	TokenStyles map[CodeTokenKind]SpanStyle // Optional: overrides the theme's style for token kinds
+	Style       Style                       // Optional styling
+}
+
+// Build returns itself as CodeView is a leaf widget.
+func (c CodeView) Build(ctx BuildContext) Widget {
+	return c
+}
+
+// WidgetID returns the code view's unique identifier.
+// Implements the Identifiable interface.
+func (c CodeView) WidgetID() string {
+	return c.ID
+}
+
+// GetStyle returns the style of the code view.
+func (c CodeView) GetStyle() Style {
+	return c.Style
+}
+
+// BuildLayoutNode builds a layout node for this CodeView widget.
+func (c CodeView) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return LeafLayoutNode(ctx, c)
+}
+
+// Layout sizes the code view to its longest line and line count.
+func (c CodeView) Layout(ctx BuildContext, constraints Constraints) Size {
+	code := c.lines()
+	width := c.gutterWidth(len(code.lines)) + code.width
+	return Size{
+		Width:  clampInt(width, constraints.MinWidth, constraints.MaxWidth),
+		Height: clampInt(len(code.lines), constraints.MinHeight, constraints.MaxHeight),
+	}
+}
+
+// Render draws the lines within the clip rect.
+func (c CodeView) Render(ctx *RenderContext) {
+	if ctx.Width <= 0 || ctx.Height <= 0 {
+		return
+	}
+	code := c.lines()
+	theme := ctx.buildContext.Theme()
+	baseStyle := Style{ForegroundColor: c.Style.ForegroundColor}
+	if baseStyle.ForegroundColor == nil || !baseStyle.ForegroundColor.IsSet() {
+		baseStyle.ForegroundColor = theme.Text
+	}
+
+	clip := ctx.ClipBounds()
+	first := max(0, clip.Y-ctx.Y)
+	last := min(ctx.Height, len(code.lines), clip.Y+clip.Height-ctx.Y)
+
+	gutter := c.gutterWidth(len(code.lines))
+	gutterStyle := SpanStyle{Foreground: theme.TextMuted}
+	for row := first; row < last; row++ {
+		if gutter > 0 {
+			number := strconv.Itoa(c.firstLine() + row)
+			ctx.DrawSpan(gutter-1-len(number), row, Span{Text: number, Style: gutterStyle}, baseStyle)
+		}
+		x := gutter
+		for _, token := range code.lines[row] {
+			style, ok := c.TokenStyles[token.Kind]
+			if !ok {
+				style = CodeTokenStyle(theme, token.Kind)
+			}
+			x += ctx.DrawSpan(x, row, Span{Text: token.Text, Style: style}, baseStyle)
+		}
+	}
+}
+
+// lines returns the source lexed and split into lines.
+func (c CodeView) lines() codeLines {
+	tabWidth := c.TabWidth
+	if tabWidth <= 0 {
+		tabWidth = 4
+	}
+	if c.Lexer != nil {
+		return lexCode(c.Lexer, c.Source, tabWidth)
+	}
+
+	key := codeCacheKey{language: c.Language, tabWidth: tabWidth, source: c.Source}
+	codeCache.Lock()
+	code, ok := codeCache.entries[key]
+	codeCache.Unlock()
+	if ok {
+		return code
+	}
+
+	code = lexCode(LexerFor(c.Language), c.Source, tabWidth)
+	codeCache.Lock()
+	if len(codeCache.entries) >= maxCachedCode {
+		clear(codeCache.entries)
+	}
+	codeCache.entries[key] = code
+	codeCache.Unlock()
+	return code
+}
+
+func (c CodeView) firstLine() int {
+	if c.FirstLine == 0 {
+		return 1
+	}
+	return c.FirstLine
+}
+
+// gutterWidth returns the width of the line number gutter: the widest
+// number and a space after it.
+func (c CodeView) gutterWidth(lineCount int) int {
+	if !c.LineNumbers {
+		return 0
+	}
+	return len(strconv.Itoa(c.firstLine()+lineCount-1)) + 1
+}
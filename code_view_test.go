@@ -0,0 +1,89 @@
+package terma
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const codeViewSample = `package main
+
+// main greets.
+func main() {
+	fmt.Println("hi", 42)
+}
+`
+
+func TestLexerFor_ClassifiesGo(t *testing.T) {
+	lexer := LexerFor("go")
+	require.NotNil(t, lexer)
+
+	kinds := map[string]CodeTokenKind{}
+	var joined strings.Builder
+	for _, token := range lexer.Tokenize(codeViewSample) {
+		kinds[strings.TrimSpace(token.Text)] = token.Kind
+		joined.WriteString(token.Text)
+	}
+
+	assert.Equal(t, codeViewSample, joined.String())
+	assert.Equal(t, CodeTokenKeyword, kinds["func"])
+	assert.Equal(t, CodeTokenComment, kinds["// main greets."])
+	assert.Equal(t, CodeTokenString, kinds[`"hi"`])
+	assert.Equal(t, CodeTokenNumber, kinds["42"])
+}
+
+func TestLexerFor_MatchesFileNamesAndRejectsUnknown(t *testing.T) {
+	assert.NotNil(t, LexerFor("main.py"))
+	assert.Nil(t, LexerFor("not-a-language"))
+	assert.Nil(t, LexerFor(""))
+}
+
+func TestLexCode_SplitsLinesAndExpandsTabs(t *testing.T) {
+	lexer := LexerFunc(func(source string) []CodeToken {
+		return []CodeToken{{Text: "if\tx", Kind: CodeTokenKeyword}, {Text: " {\n\ty\n}\n"}}
+	})
+
+	code := lexCode(lexer, "if\tx {\n\ty\n}\n", 4)
+
+	require.Len(t, code.lines, 3, "the final newline doesn't add a line")
+	assert.Equal(t, []CodeToken{{Text: "if  x", Kind: CodeTokenKeyword}, {Text: " {"}}, code.lines[0])
+	assert.Equal(t, []CodeToken{{Text: "    y"}}, code.lines[1])
+	assert.Equal(t, 7, code.width)
+}
+
+func TestCodeView_LineNumbers(t *testing.T) {
+	source := strings.Repeat("x\n", 9) + "last"
+	view := CodeView{Source: source, LineNumbers: true}
+
+	lines := strings.Split(screenText(view, 10, 10), "\n")
+
+	assert.Equal(t, " 1 x", strings.TrimRight(lines[0], " "))
+	assert.Equal(t, "10 last", strings.TrimRight(lines[9], " "))
+}
+
+func TestCodeView_ScrolledShowsLinesInView(t *testing.T) {
+	var source strings.Builder
+	for i := 1; i <= 100; i++ {
+		fmt.Fprintf(&source, "line %d\n", i)
+	}
+	scroll := NewScrollState()
+	scroll.Offset.Set(50)
+
+	screen := screenText(Scrollable{
+		State: scroll,
+		Style: Style{Height: Cells(3)},
+		Child: CodeView{Source: source.String(), Language: "text"},
+	}, 20, 3)
+
+	lines := strings.Split(screen, "\n")
+	assert.Equal(t, "line 51", strings.TrimSpace(strings.Trim(lines[0], "▐█│┃")))
+	assert.Equal(t, "line 53", strings.TrimSpace(strings.Trim(lines[2], "▐█│┃")))
+}
+
+func TestCodeView_Snapshot(t *testing.T) {
+	view := CodeView{Source: codeViewSample, Language: "go", LineNumbers: true}
+	AssertSnapshot(t, view, 30, 6, "Go source with keywords, comment, string and number colored, and a line number gutter")
+}
@@ -29,6 +29,7 @@ type Color struct {
 	r, g, b uint8
 	a       float64 // 0.0 = fully transparent, 1.0 = fully opaque
 	set     bool    // distinguishes "not set" from "transparent black"
+	clear   bool    // the terminal's own background (see Transparent)
 }
 
 // RGB creates a color from red, green, blue components (0-255).
@@ -110,6 +111,13 @@ var (
 	BrightWhite   = RGB(255, 255, 255)
 )
 
+// Transparent leaves cells with no background color, so the terminal's own
+// background (including any transparency or image the user has set) shows
+// through. Unlike the zero Color it counts as set, so a widget using it
+// clears the background its parent painted. Semi-transparent colors over
+// it blend with the terminal's reported background (see TerminalBackground).
+var Transparent = Color{set: true, clear: true}
+
 // --- Inspection Methods ---
 
 // RGB returns the red, green, and blue components (0-255).
@@ -124,7 +132,7 @@ func (c Color) HSL() (h, s, l float64) {
 
 // Hex returns the color as a hex string "#RRGGBB".
 func (c Color) Hex() string {
-	if !c.set {
+	if !c.set || c.clear {
 		return ""
 	}
 	return fmt.Sprintf("#%02X%02X%02X", c.r, c.g, c.b)
@@ -135,6 +143,11 @@ func (c Color) IsSet() bool {
 	return c.set
 }
 
+// IsTransparent returns true for Transparent, the terminal's own background.
+func (c Color) IsTransparent() bool {
+	return c.clear
+}
+
 // ColorAt returns the color unchanged (solid colors are constant across the region).
 // This implements ColorProvider.
 func (c Color) ColorAt(width, height, x, y int) Color {
@@ -274,6 +287,12 @@ func (c Color) Invert() Color {
 // Blend mixes this color with another color.
 // ratio of 0 returns this color, ratio of 1 returns the other color.
 func (c Color) Blend(other Color, ratio float64) Color {
+	if c.clear {
+		c = terminalBackground()
+	}
+	if other.clear {
+		other = terminalBackground()
+	}
 	if !c.set {
 		return other
 	}
@@ -294,11 +313,15 @@ func (c Color) Blend(other Color, ratio float64) Color {
 
 // BlendOver composites this color over a background color using alpha blending.
 // This is the standard "over" operator for alpha compositing.
-// Returns a fully opaque color representing the visual result.
+// Returns a fully opaque color representing the visual result, or
+// Transparent when c is Transparent.
 func (c Color) BlendOver(bg Color) Color {
-	// If background is not set, treat as black
-	if !bg.set {
-		bg = Black
+	if c.clear {
+		return c
+	}
+	// An unset or Transparent background is the terminal's own
+	if !bg.set || bg.clear {
+		bg = terminalBackground()
 	}
 
 	// If foreground is fully opaque, it completely covers background
@@ -491,7 +514,7 @@ func (g Gradient) Steps(n int) []Color {
 
 // toANSI converts to charmbracelet/x/ansi color.Color for rendering.
 func (c Color) toANSI() color.Color {
-	if !c.set {
+	if !c.set || c.clear {
 		return nil // default/transparent
 	}
 	return ansi.RGBColor{R: c.r, G: c.g, B: c.b}
@@ -15,8 +15,17 @@ const (
 
 var commandPaletteDividerLine = strings.Repeat("─", 120)
 
+// commandPaletteInputPadding returns the padding around the palette's
+// input for the current density.
 func commandPaletteInputPadding() EdgeInsets {
-	return EdgeInsetsTRBL(1, 1, 1, 1)
+	switch getDensity() {
+	case DensityCompact:
+		return EdgeInsetsXY(1, 0)
+	case DensityComfortable:
+		return EdgeInsetsXY(2, 1)
+	default:
+		return EdgeInsetsTRBL(1, 1, 1, 1)
+	}
 }
 
 // CommandPaletteItem represents a single entry in the command palette.
@@ -25,7 +34,9 @@ type CommandPaletteItem struct {
 	Hint          string                      // Right-aligned text (e.g., "Ctrl+N")
 	HintWidget    func() Widget               // Custom hint widget (color swatches, etc.)
 	Description   string                      // Optional secondary line below label
-	Action        func()                      // Called on selection (ignored if Children set)
+	Action        func()                      // Called on selection (ignored if Children or Args set)
+	Args          []CommandArg                // Values prompted for in turn on selection, before ArgsAction
+	ArgsAction    func(args CommandArgs)      // Called with the values once every arg is given
 	Children      func() []CommandPaletteItem // Opens nested palette (lazy-loaded)
 	ChildrenTitle string                      // Breadcrumb title for nested level
 	Disabled      bool                        // Grayed out, not selectable
@@ -44,7 +55,7 @@ func (i CommandPaletteItem) IsDivider() bool {
 	if i.Divider != "" {
 		return true
 	}
-	return i.Label == "" && i.Hint == "" && i.Description == "" && i.Action == nil && i.Children == nil && i.HintWidget == nil
+	return i.Label == "" && i.Hint == "" && i.Description == "" && i.Action == nil && i.Children == nil && i.HintWidget == nil && len(i.Args) == 0
 }
 
 // GetFilterText returns the text used for filtering.
@@ -63,6 +74,8 @@ type CommandPaletteLevel struct {
 	ScrollState *ScrollState
 	FilterState *FilterState
 	InputState  *TextInputState
+
+	prompt *commandPalettePrompt // Argument prompt shown instead of commands, if any
 }
 
 // CommandPaletteState holds the stack of palette levels and visibility state.
@@ -129,11 +142,15 @@ func (s *CommandPaletteState) PushLevel(title string, items []CommandPaletteItem
 }
 
 // PopLevel removes the current level, returning true if a level was popped.
+// Returning to an argument prompt asks for its value again.
 func (s *CommandPaletteState) PopLevel() bool {
 	if s == nil || len(s.stack) <= 1 {
 		return false
 	}
 	s.stack = s.stack[:len(s.stack)-1]
+	if prompt := s.CurrentLevel().prompt; prompt != nil {
+		prompt.answer, prompt.answered = "", false
+	}
 	s.depth.Set(len(s.stack))
 	return true
 }
@@ -164,16 +181,7 @@ func (s *CommandPaletteState) CurrentItem() (CommandPaletteItem, bool) {
 
 // BreadcrumbPath returns the current breadcrumb trail.
 func (s *CommandPaletteState) BreadcrumbPath() []string {
-	if s == nil {
-		return nil
-	}
-	path := make([]string, 0, len(s.stack))
-	for _, level := range s.stack {
-		if level == nil || level.Title == "" {
-			continue
-		}
-		path = append(path, level.Title)
-	}
+	path, _ := s.breadcrumbs()
 	return path
 }
 
@@ -282,7 +290,11 @@ func commandPaletteFilteredView(items []CommandPaletteItem, filter *FilterState)
 		return commandPaletteMatchItem(item, query, options)
 	}
 	view := ApplyFilter(items, query, matchItem)
-	if options.Mode == FilterFuzzy {
+	switch {
+	case query == "":
+	case options.SortByScore || options.Mode == FilterScored:
+		sortFilteredViewByScore(&view)
+	case options.Mode == FilterFuzzy:
 		sortFilteredViewByFuzzyRank(&view)
 	}
 	return view
@@ -410,10 +422,11 @@ func (p CommandPalette) buildContent(ctx BuildContext, level *CommandPaletteLeve
 	if path := p.State.BreadcrumbPath(); len(path) > 0 {
 		hasBreadcrumbs = true
 		headerChildren = append(headerChildren, Breadcrumbs{
-			ID:        p.ID + "-breadcrumbs",
-			Path:      path,
-			OnSelect:  p.onBreadcrumbSelect(),
-			Separator: ">",
+			ID:           p.ID + "-breadcrumbs",
+			Path:         path,
+			OnSelect:     p.onBreadcrumbSelect(),
+			Separator:    ">",
+			DisableFocus: true, // Focus stays in the palette's input
 			Style: Style{
 				ForegroundColor: theme.TextMuted,
 				Width:           Flex(1),
@@ -421,13 +434,19 @@ func (p CommandPalette) buildContent(ctx BuildContext, level *CommandPaletteLeve
 		})
 	}
 	headerChildren = append(headerChildren, p.buildInput(level, theme))
+	message := p.promptMessage(level, theme)
+	if message != nil {
+		headerChildren = append(headerChildren, message)
+	}
 
 	children = append(children, Column{
 		CrossAlign: CrossAxisStretch,
 		Spacing:    0,
 		Children:   headerChildren,
 	})
-	children = append(children, p.buildList(ctx, level, theme, containerStyle, hasBreadcrumbs))
+	if level.prompt == nil || level.prompt.arg().Kind != CommandArgText {
+		children = append(children, p.buildList(ctx, level, theme, containerStyle, hasBreadcrumbs, message != nil))
+	}
 
 	return Column{
 		ID:         p.ID + "-content",
@@ -474,9 +493,9 @@ func (p CommandPalette) buildInput(level *CommandPaletteLevel, theme ThemeData)
 	return TextInput{
 		ID:          p.inputID(),
 		State:       level.InputState,
-		Placeholder: p.placeholderText(),
+		Placeholder: p.placeholderText(level),
 		Style: Style{
-			BackgroundColor: theme.Surface,
+			BackgroundColor: theme.Token(TokenSurfaceFloating),
 			ForegroundColor: theme.Text,
 			Padding:         padding,
 			Width:           Flex(1),
@@ -486,7 +505,7 @@ func (p CommandPalette) buildInput(level *CommandPaletteLevel, theme ThemeData)
 	}
 }
 
-func (p CommandPalette) buildList(ctx BuildContext, level *CommandPaletteLevel, theme ThemeData, containerStyle Style, hasBreadcrumbs bool) Widget {
+func (p CommandPalette) buildList(ctx BuildContext, level *CommandPaletteLevel, theme ThemeData, containerStyle Style, hasBreadcrumbs, hasMessage bool) Widget {
 	if level == nil {
 		return EmptyWidget{}
 	}
@@ -523,15 +542,15 @@ func (p CommandPalette) buildList(ctx BuildContext, level *CommandPaletteLevel,
 				p.notifyCursorChange()
 			},
 			Style: Style{
-				BackgroundColor: theme.Surface,
+				BackgroundColor: theme.Token(TokenSurfaceFloating),
 			},
 		}
 	}
 
 	listStyle := Style{
-		BackgroundColor: theme.Surface,
+		BackgroundColor: theme.Token(TokenSurfaceFloating),
 	}
-	if maxHeight, ok := p.listMaxHeight(containerStyle, hasBreadcrumbs); ok {
+	if maxHeight, ok := p.listMaxHeight(containerStyle, hasBreadcrumbs, hasMessage); ok {
 		listStyle.MaxHeight = Cells(maxHeight)
 	} else {
 		listStyle.Height = Flex(1)
@@ -545,13 +564,16 @@ func (p CommandPalette) buildList(ctx BuildContext, level *CommandPaletteLevel,
 	}
 }
 
-func (p CommandPalette) listMaxHeight(containerStyle Style, hasBreadcrumbs bool) (int, bool) {
+func (p CommandPalette) listMaxHeight(containerStyle Style, hasBreadcrumbs, hasMessage bool) (int, bool) {
 	maxHeight, ok := p.paletteContentHeightCells(containerStyle)
 	if !ok {
 		return 0, false
 	}
 
 	available := maxHeight - p.headerHeight(hasBreadcrumbs)
+	if hasMessage {
+		available--
+	}
 	if available < 0 {
 		available = 0
 	}
@@ -682,7 +704,7 @@ func (p CommandPalette) suffixWidgets(item CommandPaletteItem, style Style) []Wi
 	if hintWidget != nil {
 		widgets = append(widgets, hintWidget)
 	}
-	if item.Children != nil {
+	if item.Children != nil || len(item.Args) > 0 {
 		if len(widgets) > 0 {
 			widgets = append(widgets, Spacer{Width: Cells(1)})
 		}
@@ -800,6 +822,11 @@ func (p CommandPalette) selectCurrent() {
 	if p.State == nil {
 		return
 	}
+	if level := p.State.CurrentLevel(); level != nil && level.prompt != nil {
+		p.submitPrompt(level)
+		return
+	}
+
 	item, ok := p.State.CurrentItem()
 	if !ok {
 		return
@@ -821,6 +848,13 @@ func (p CommandPalette) selectCurrent() {
 		return
 	}
 
+	if len(item.Args) > 0 {
+		p.State.PromptArgs(item)
+		p.notifyCursorChange()
+		RequestFocus(p.inputID())
+		return
+	}
+
 	if item.Action != nil {
 		item.Action()
 	}
@@ -872,7 +906,11 @@ func (p CommandPalette) onBreadcrumbSelect() func(index int) {
 		if p.State == nil {
 			return
 		}
-		for len(p.State.stack) > index+1 {
+		_, levels := p.State.breadcrumbs()
+		if index < 0 || index >= len(levels) {
+			return
+		}
+		for len(p.State.stack) > levels[index]+1 {
 			p.State.PopLevel()
 		}
 		p.notifyCursorChange()
@@ -883,7 +921,7 @@ func (p CommandPalette) onBreadcrumbSelect() func(index int) {
 func (p CommandPalette) containerStyle(theme ThemeData) Style {
 	style := p.Style
 	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
-		style.BackgroundColor = theme.Surface
+		style.BackgroundColor = theme.Token(TokenSurfaceFloating)
 	}
 	return style
 }
@@ -931,7 +969,17 @@ func (p CommandPalette) paletteHeight() Dimension {
 	return height
 }
 
-func (p CommandPalette) placeholderText() string {
+func (p CommandPalette) placeholderText(level *CommandPaletteLevel) string {
+	if level != nil && level.prompt != nil {
+		arg := level.prompt.arg()
+		if arg.Prompt != "" {
+			return arg.Prompt
+		}
+		if arg.Kind == CommandArgText {
+			return arg.Name
+		}
+		return defaultCommandPalettePlaceholder
+	}
 	if p.Placeholder == "" {
 		return defaultCommandPalettePlaceholder
 	}
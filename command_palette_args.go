@@ -0,0 +1,238 @@
+package terma
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// CommandArgKind is the kind of input a CommandArg prompts for.
+type CommandArgKind int
+
+const (
+	// CommandArgText prompts for free text.
+	CommandArgText CommandArgKind = iota
+	// CommandArgChoice prompts to pick one of Choices, filtered by typing.
+	CommandArgChoice
+	// CommandArgFile prompts to pick a file, browsing from Dir.
+	CommandArgFile
+)
+
+// CommandArgs holds the values given for a command's Args, by name.
+type CommandArgs map[string]string
+
+// CommandArg is one value a CommandPaletteItem prompts for before it runs.
+type CommandArg struct {
+	Name     string                                     // Key in CommandArgs, and the breadcrumb of its prompt
+	Kind     CommandArgKind                             // Input to prompt with (default: free text)
+	Prompt   string                                     // Input placeholder (default: Name for text, "Type to search..." otherwise)
+	Default  string                                     // Initial text of a text prompt
+	Choices  func(args CommandArgs) []string            // Options of a choice prompt, given the args collected so far
+	Dir      string                                     // Directory a file prompt starts in (default: the working directory)
+	Validate func(value string, args CommandArgs) error // Rejects a value with an error shown below the input
+}
+
+// commandPalettePrompt is the argument prompt a palette level shows.
+type commandPalettePrompt struct {
+	item     CommandPaletteItem
+	index    int    // Index of the arg in item.Args
+	dir      string // Directory listed by a file prompt
+	answer   string // Value given, once the flow has moved past this level
+	answered bool
+	message  Signal[string] // Error shown below the input
+}
+
+// arg returns the arg this prompt asks for.
+func (p *commandPalettePrompt) arg() CommandArg {
+	return p.item.Args[p.index]
+}
+
+// PromptArgs starts the argument prompts of item, as selecting it does:
+// each of item.Args is asked for on its own level, and item.ArgsAction is
+// called once all are given. Use it from an OnSelect handler.
+func (s *CommandPaletteState) PromptArgs(item CommandPaletteItem) {
+	if s == nil || len(item.Args) == 0 {
+		return
+	}
+	s.pushPrompt(item, 0)
+}
+
+// pushPrompt adds a level asking for item.Args[index].
+func (s *CommandPaletteState) pushPrompt(item CommandPaletteItem, index int) {
+	prompt := &commandPalettePrompt{item: item, index: index, message: NewSignal("")}
+	arg := prompt.arg()
+	var items []CommandPaletteItem
+	switch arg.Kind {
+	case CommandArgChoice:
+		if arg.Choices != nil {
+			for _, choice := range arg.Choices(s.collectedArgs()) {
+				items = append(items, CommandPaletteItem{Label: choice})
+			}
+		}
+	case CommandArgFile:
+		prompt.dir = arg.Dir
+		if prompt.dir == "" {
+			prompt.dir = "."
+		}
+		if abs, err := filepath.Abs(prompt.dir); err == nil {
+			prompt.dir = abs
+		}
+		items = prompt.fileItems()
+	}
+	s.PushLevel(arg.Name, items)
+	level := s.CurrentLevel()
+	level.prompt = prompt
+	if arg.Kind == CommandArgText && arg.Default != "" {
+		level.InputState.SetText(arg.Default)
+	}
+}
+
+// fileItems lists the directory of a file prompt: its parent first, then
+// directories and files by name. Hidden entries are left out.
+func (p *commandPalettePrompt) fileItems() []CommandPaletteItem {
+	entries, err := defaultDirectoryReadDir(p.dir)
+	if err != nil {
+		p.message.Set(err.Error())
+	}
+	defaultDirectorySort(entries)
+	items := make([]CommandPaletteItem, 0, len(entries)+1)
+	if parent := filepath.Dir(p.dir); parent != p.dir {
+		items = append(items, CommandPaletteItem{Label: "..", Data: DirectoryEntry{Name: "..", Path: parent, IsDir: true}})
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name, ".") {
+			continue
+		}
+		label := entry.Name
+		if entry.IsDir {
+			label += string(filepath.Separator)
+		}
+		items = append(items, CommandPaletteItem{Label: label, Data: entry})
+	}
+	return items
+}
+
+// collectedArgs returns the values given at the prompt levels below the
+// current one.
+func (s *CommandPaletteState) collectedArgs() CommandArgs {
+	args := CommandArgs{}
+	for _, level := range s.stack {
+		if level.prompt != nil && level.prompt.answered {
+			args[level.prompt.arg().Name] = level.prompt.answer
+		}
+	}
+	return args
+}
+
+// breadcrumbs returns the breadcrumb trail and the index of the level each
+// crumb returns to. A prompt flow shows its command, then the value of each
+// arg given so far, then the name of the arg being asked for.
+func (s *CommandPaletteState) breadcrumbs() (path []string, levels []int) {
+	if s == nil {
+		return nil, nil
+	}
+	for i, level := range s.stack {
+		if level == nil {
+			continue
+		}
+		if prompt := level.prompt; prompt != nil {
+			if prompt.index == 0 {
+				title := prompt.item.ChildrenTitle
+				if title == "" {
+					title = prompt.item.Label
+				}
+				path, levels = append(path, title), append(levels, i)
+			}
+			crumb := prompt.arg().Name
+			if prompt.answered {
+				crumb = prompt.answer
+				if prompt.arg().Kind == CommandArgFile {
+					crumb = filepath.Base(crumb)
+				}
+			}
+			path, levels = append(path, crumb), append(levels, i)
+			continue
+		}
+		if level.Title == "" {
+			continue
+		}
+		path, levels = append(path, level.Title), append(levels, i)
+	}
+	return path, levels
+}
+
+// submitPrompt takes the value of the prompt on level: the text typed, or
+// the choice or file under the cursor. Entering a directory lists it
+// instead. Once the last arg is given, the prompt levels are popped and
+// the command runs with every value.
+func (p CommandPalette) submitPrompt(level *CommandPaletteLevel) {
+	prompt := level.prompt
+	arg := prompt.arg()
+	var value string
+	switch arg.Kind {
+	case CommandArgText:
+		value = level.InputState.GetText()
+	default:
+		item, ok := p.State.CurrentItem()
+		if !ok {
+			return
+		}
+		value = item.Label
+		if entry, ok := item.Data.(DirectoryEntry); ok {
+			if entry.IsDir {
+				prompt.dir = entry.Path
+				prompt.message.Set("")
+				level.InputState.SetText("")
+				level.FilterState.Query.Set("")
+				level.ScrollState.SetOffset(0)
+				level.ListState.CursorIndex.Set(0)
+				p.State.SetItems(prompt.fileItems())
+				p.notifyCursorChange()
+				return
+			}
+			value = entry.Path
+		}
+	}
+
+	args := p.State.collectedArgs()
+	if arg.Validate != nil {
+		if err := arg.Validate(value, args); err != nil {
+			prompt.message.Set(err.Error())
+			return
+		}
+	}
+	prompt.message.Set("")
+	prompt.answer, prompt.answered = value, true
+	args[arg.Name] = value
+
+	if next := prompt.index + 1; next < len(prompt.item.Args) {
+		p.State.pushPrompt(prompt.item, next)
+		p.notifyCursorChange()
+		RequestFocus(p.inputID())
+		return
+	}
+	for p.State.CurrentLevel().prompt != nil {
+		p.State.PopLevel()
+	}
+	if prompt.item.ArgsAction != nil {
+		prompt.item.ArgsAction(args)
+	}
+}
+
+// promptMessage returns the line shown below the input of a prompt level:
+// an error, or the directory a file prompt lists.
+func (p CommandPalette) promptMessage(level *CommandPaletteLevel, theme ThemeData) Widget {
+	prompt := level.prompt
+	if prompt == nil {
+		return nil
+	}
+	style := Style{Padding: EdgeInsetsXY(1, 0), Width: Flex(1)}
+	if message := prompt.message.Get(); message != "" {
+		style.ForegroundColor = theme.Error
+		return Text{Content: message, Style: style}
+	}
+	if prompt.arg().Kind == CommandArgFile {
+		style.ForegroundColor = theme.TextMuted
+		return Text{Content: prompt.dir, Style: style}
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package terma
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -276,3 +278,146 @@ func TestSnapshot_CommandPalette_ScrollOverflow(t *testing.T) {
 
 	AssertSnapshot(t, widget, 60, 16, "Command palette with constrained height and enough items to require scrolling; scrollbar should remain visible within the palette")
 }
+
+func commandPaletteArgsItem(run func(CommandArgs)) CommandPaletteItem {
+	return CommandPaletteItem{
+		Label: "Create Branch",
+		Args: []CommandArg{
+			{Name: "name", Validate: func(value string, args CommandArgs) error {
+				if value == "" {
+					return fmt.Errorf("name is required")
+				}
+				return nil
+			}},
+			{Name: "remote", Kind: CommandArgChoice, Choices: func(args CommandArgs) []string {
+				return []string{"origin", "upstream/" + args["name"]}
+			}},
+		},
+		ArgsAction: run,
+	}
+}
+
+func TestCommandPalette_ArgsPromptInTurn(t *testing.T) {
+	var got CommandArgs
+	state := NewCommandPaletteState("Commands", []CommandPaletteItem{
+		commandPaletteArgsItem(func(args CommandArgs) { got = args }),
+	})
+	palette := CommandPalette{ID: "palette", State: state}
+
+	palette.selectCurrent()
+	if got := state.BreadcrumbPath(); !reflect.DeepEqual(got, []string{"Commands", "Create Branch", "name"}) {
+		t.Fatalf("unexpected breadcrumb path: %#v", got)
+	}
+
+	palette.selectCurrent()
+	if msg := state.CurrentLevel().prompt.message.Peek(); msg != "name is required" {
+		t.Fatalf("expected validation error, got %q", msg)
+	}
+
+	state.CurrentLevel().InputState.SetText("feature")
+	palette.selectCurrent()
+	if got := state.BreadcrumbPath(); !reflect.DeepEqual(got, []string{"Commands", "Create Branch", "feature", "remote"}) {
+		t.Fatalf("unexpected breadcrumb path after first arg: %#v", got)
+	}
+	level := state.CurrentLevel()
+	if len(level.Items) != 2 || level.Items[1].Label != "upstream/feature" {
+		t.Fatalf("expected choices built from earlier args, got %#v", level.Items)
+	}
+
+	level.ListState.SelectIndex(1)
+	palette.selectCurrent()
+	if !reflect.DeepEqual(got, CommandArgs{"name": "feature", "remote": "upstream/feature"}) {
+		t.Fatalf("unexpected args: %#v", got)
+	}
+	if state.IsNested() {
+		t.Fatalf("expected prompts to be popped once the command ran")
+	}
+}
+
+func TestCommandPalette_ArgsGoBackAStep(t *testing.T) {
+	ran := false
+	state := NewCommandPaletteState("Commands", []CommandPaletteItem{
+		commandPaletteArgsItem(func(CommandArgs) { ran = true }),
+	})
+	palette := CommandPalette{ID: "palette", State: state}
+
+	palette.selectCurrent()
+	state.CurrentLevel().InputState.SetText("feature")
+	palette.selectCurrent()
+	palette.handleEscape()
+
+	if got := state.BreadcrumbPath(); !reflect.DeepEqual(got, []string{"Commands", "Create Branch", "name"}) {
+		t.Fatalf("expected the first arg to be asked again, got %#v", got)
+	}
+	if text := state.CurrentLevel().InputState.GetText(); text != "feature" {
+		t.Fatalf("expected the earlier text to be kept, got %q", text)
+	}
+
+	palette.onBreadcrumbSelect()(0)
+	if state.IsNested() || ran {
+		t.Fatalf("expected the breadcrumb to leave the flow without running it")
+	}
+}
+
+func TestCommandPalette_ArgsFilePrompt(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "docs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"docs/guide.md", "main.go", ".hidden"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got CommandArgs
+	state := NewCommandPaletteState("Commands", []CommandPaletteItem{{
+		Label:      "Open",
+		Args:       []CommandArg{{Name: "file", Kind: CommandArgFile, Dir: dir}},
+		ArgsAction: func(args CommandArgs) { got = args },
+	}})
+	palette := CommandPalette{ID: "palette", State: state}
+	palette.selectCurrent()
+
+	labels := func() []string {
+		var labels []string
+		for _, item := range state.CurrentLevel().Items {
+			labels = append(labels, item.Label)
+		}
+		return labels
+	}
+	sep := string(filepath.Separator)
+	if got := labels(); !reflect.DeepEqual(got, []string{"..", "docs" + sep, "main.go"}) {
+		t.Fatalf("unexpected entries: %#v", got)
+	}
+
+	state.CurrentLevel().ListState.SelectIndex(1)
+	palette.selectCurrent()
+	if got := labels(); !reflect.DeepEqual(got, []string{"..", "guide.md"}) {
+		t.Fatalf("unexpected entries after entering docs: %#v", got)
+	}
+
+	state.CurrentLevel().ListState.SelectIndex(1)
+	palette.selectCurrent()
+	if want := filepath.Join(dir, "docs", "guide.md"); got["file"] != want {
+		t.Fatalf("got %q, want %q", got["file"], want)
+	}
+}
+
+func TestSnapshot_CommandPalette_ArgsPrompt(t *testing.T) {
+	state := NewCommandPaletteState("Commands", []CommandPaletteItem{
+		commandPaletteArgsItem(nil),
+	})
+	state.Visible.Set(true)
+	palette := CommandPalette{
+		ID:       "palette-args",
+		State:    state,
+		Position: FloatPositionTopLeft,
+		Offset:   Offset{X: 2, Y: 1},
+	}
+	palette.selectCurrent()
+	state.CurrentLevel().InputState.SetText("feature")
+	palette.selectCurrent()
+
+	AssertSnapshot(t, palette, 80, 20, "Command palette prompting for a second argument: breadcrumbs show the command and the first value, and the list offers the choices")
+}
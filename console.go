@@ -0,0 +1,376 @@
+package terma
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// defaultConsoleMaxLines is how many scrollback lines a ConsoleState keeps
+// unless MaxLines says otherwise.
+const defaultConsoleMaxLines = 10_000
+
+// consoleLineKind is what a scrollback line holds, which decides its style.
+type consoleLineKind int
+
+const (
+	consoleOutput  consoleLineKind = iota // Written by a command or the app
+	consoleCommand                        // A submitted command, drawn after the prompt
+	consoleError                          // The error a command returned
+)
+
+// ConsoleState holds the scrollback, prompt input and running command of a
+// Console. Its Write method makes it an io.Writer, so output can be
+// appended from any goroutine.
+type ConsoleState struct {
+	Input    *TextInputState // The prompt; Input.History records each command
+	Running  Signal[bool]    // True while Execute is running a command
+	MaxLines int             // Most scrollback lines kept, dropping the oldest (0 = 10000)
+
+	updates    Signal[int] // Bumped on every scrollback change, to rebuild
+	scroll     *ScrollState
+	completion *AutocompleteState
+	spinner    *SpinnerState
+
+	mu      sync.Mutex
+	output  spanLines
+	kinds   []consoleLineKind // Parallel to output.lines
+	decoder ansiDecoder
+	cancel  context.CancelFunc
+
+	completedFor string // Query the completion suggestions were made for
+}
+
+// NewConsoleState creates an empty console with an in-memory history.
+// Replace Input.History to persist it.
+func NewConsoleState() *ConsoleState {
+	input := NewTextInputState("")
+	input.History = &InputHistory{}
+	scroll := NewScrollState()
+	scroll.PinToBottom = true
+	return &ConsoleState{
+		Input:      input,
+		Running:    NewSignal(false),
+		updates:    NewSignal(0),
+		scroll:     scroll,
+		completion: NewAutocompleteState(),
+		spinner:    NewSpinnerState(SpinnerDots),
+	}
+}
+
+// Write appends output to the scrollback, decoding ANSI escape sequences
+// (see ParseANSI). Lines are ended by "\n"; text after the last one is
+// continued by the next Write. Safe to call from any goroutine.
+func (s *ConsoleState) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	s.appendOutput(s.decoder.decode(string(p)))
+	s.mu.Unlock()
+	s.changed()
+	return len(p), nil
+}
+
+// Print appends styled output to the scrollback, as Write does for ANSI
+// text. Safe to call from any goroutine.
+//
+// Example:
+//
+//	state.Print(t.ColorSpan("connected", theme.Success), t.PlainSpan(" to db.example.com\n"))
+func (s *ConsoleState) Print(spans ...Span) {
+	s.mu.Lock()
+	s.appendOutput(spans)
+	s.mu.Unlock()
+	s.changed()
+}
+
+// Clear empties the scrollback.
+func (s *ConsoleState) Clear() {
+	s.mu.Lock()
+	s.output = spanLines{}
+	s.kinds = nil
+	s.decoder = ansiDecoder{}
+	s.mu.Unlock()
+	s.changed()
+}
+
+// Text returns the scrollback as plain text, with commands after the
+// prompt they were entered at.
+func (s *ConsoleState) Text(prompt string) string {
+	lines, kinds := s.scrollback()
+	var sb strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		if kinds[i] == consoleCommand {
+			sb.WriteString(prompt)
+		}
+		for _, span := range line {
+			sb.WriteString(span.Text)
+		}
+	}
+	return sb.String()
+}
+
+// Cancel cancels the context of the running command, if any.
+func (s *ConsoleState) Cancel() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// appendOutput adds output spans, then drops the oldest lines beyond
+// MaxLines. Callers hold mu.
+func (s *ConsoleState) appendOutput(spans []Span) {
+	for added := s.output.append(spans); added > 0; added-- {
+		s.kinds = append(s.kinds, consoleOutput)
+	}
+	s.trim()
+}
+
+// appendLine ends any unfinished output line and adds a line of its own.
+// Callers hold mu.
+func (s *ConsoleState) appendLine(kind consoleLineKind, spans ...Span) {
+	s.output.open = false
+	s.output.lines = append(s.output.lines, spans)
+	s.kinds = append(s.kinds, kind)
+	s.trim()
+}
+
+func (s *ConsoleState) trim() {
+	limit := s.MaxLines
+	if limit <= 0 {
+		limit = defaultConsoleMaxLines
+	}
+	if drop := len(s.output.lines) - limit; drop > 0 {
+		s.output.lines = s.output.lines[drop:]
+		s.kinds = s.kinds[drop:]
+	}
+}
+
+func (s *ConsoleState) changed() {
+	s.updates.Update(func(n int) int { return n + 1 })
+}
+
+// scrollback returns a copy of the lines and their kinds.
+func (s *ConsoleState) scrollback() ([][]Span, []consoleLineKind) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]Span(nil), s.output.lines...), append([]consoleLineKind(nil), s.kinds...)
+}
+
+// run echoes command and runs it with execute on a new goroutine, unless a
+// command is already running. The prompt is read-only until it finishes.
+func (s *ConsoleState) run(command string, execute func(ctx context.Context, command string, out io.Writer) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.mu.Unlock()
+		cancel()
+		return
+	}
+	s.appendLine(consoleCommand, PlainSpan(command))
+	if execute == nil || strings.TrimSpace(command) == "" {
+		s.mu.Unlock()
+		cancel()
+		s.changed()
+		return
+	}
+	s.cancel = cancel
+	s.mu.Unlock()
+	s.changed()
+
+	s.Input.ReadOnly.Set(true)
+	s.Running.Set(true)
+	s.spinner.Start()
+	Go(func() {
+		err := execute(ctx, command, s)
+		s.mu.Lock()
+		if err != nil {
+			if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+				err = errors.New("cancelled")
+			}
+			s.appendLine(consoleError, PlainSpan(err.Error()))
+		}
+		s.output.open = false
+		s.cancel = nil
+		s.mu.Unlock()
+		cancel()
+		s.changed()
+		s.spinner.Stop()
+		s.Running.Set(false)
+		s.Input.ReadOnly.Set(false)
+	})
+}
+
+// Console pairs a scrollback of output with a prompt, as a ready-made
+// REPL for database shells and debugging consoles. A submitted command is
+// echoed after the prompt, recorded in the prompt's history (up/down and
+// ctrl+r recall it) and passed to Execute on its own goroutine. Whatever
+// Execute writes to out shows in the scrollback as it arrives, ANSI colors
+// included, and an error it returns is shown in the theme's error color.
+// The prompt is read-only while a command runs; Escape cancels its context.
+// Ctrl+L clears the scrollback and Ctrl+Y copies it.
+//
+// Example:
+//
+//	t.Console{
+//	    ID:     "sql",
+//	    State:  a.console,
+//	    Prompt: "db> ",
+//	    Execute: func(ctx context.Context, query string, out io.Writer) error {
+//	        return a.db.QueryTo(ctx, query, out)
+//	    },
+//	    Complete: func(line string) []t.Suggestion {
+//	        return a.completeSQL(line)
+//	    },
+//	}
+type Console struct {
+	ID          string        // Optional unique identifier; the prompt input's ID is ID + "-input"
+	State       *ConsoleState // Required - holds scrollback, prompt and history
+	Prompt      string        // Shown before the input and each echoed command (default = "> ")
+	Placeholder string        // Shown in the empty input
+	Style       Style         // Optional styling
+
+	// Execute runs a command, writing its output to out. It runs off the
+	// UI goroutine and should return once ctx is cancelled.
+	Execute func(ctx context.Context, command string, out io.Writer) error
+
+	// Complete, when set, offers completions in an Autocomplete popup as
+	// the user types. It is passed the line up to the cursor, and each
+	// suggestion's Value replaces the word at the cursor.
+	Complete func(line string) []Suggestion
+}
+
+// WidgetID returns the console's unique identifier.
+func (c Console) WidgetID() string {
+	return c.ID
+}
+
+// inputID returns the ID of the prompt input.
+func (c Console) inputID() string {
+	if c.ID == "" {
+		return "console-input"
+	}
+	return c.ID + "-input"
+}
+
+func (c Console) prompt() string {
+	if c.Prompt == "" {
+		return "> "
+	}
+	return c.Prompt
+}
+
+// Keybinds returns the clear, copy and cancel bindings, which work while
+// focus is in the prompt.
+func (c Console) Keybinds() []Keybind {
+	if c.State == nil {
+		return nil
+	}
+	keybinds := []Keybind{
+		{Key: "ctrl+l", Name: "Clear", Action: c.State.Clear},
+		{Key: "ctrl+y", Name: "Copy", Action: c.Copy},
+	}
+	// Escape only while running, so otherwise it still reaches ancestors
+	if c.State.Running.Peek() {
+		keybinds = append([]Keybind{{Key: "escape", Name: "Cancel", Action: c.State.Cancel}}, keybinds...)
+	}
+	return keybinds
+}
+
+// Copy puts the scrollback on the clipboard as plain text.
+func (c Console) Copy() {
+	if c.State != nil {
+		CopyToClipboard(c.State.Text(c.prompt()))
+	}
+}
+
+// submit runs the prompt's text as a command.
+func (c Console) submit(command string) {
+	c.State.Input.SetText("")
+	c.State.run(command, c.Execute)
+}
+
+// complete refreshes the suggestions when the text before the cursor changes.
+func (c Console) complete(line string) {
+	if line == c.State.completedFor {
+		return
+	}
+	c.State.completedFor = line
+	c.State.completion.SetSuggestions(c.Complete(line))
+}
+
+// Build returns the scrollback above the prompt row.
+func (c Console) Build(ctx BuildContext) Widget {
+	if c.State == nil {
+		return EmptyWidget{}
+	}
+	theme := ctx.Theme()
+	c.State.updates.Get()
+	prompt := c.prompt()
+	promptStyle := SpanStyle{Foreground: theme.Accent}
+
+	lines, kinds := c.State.scrollback()
+	var spans []Span
+	for i, line := range lines {
+		if i > 0 {
+			spans = append(spans, PlainSpan("\n"))
+		}
+		switch kinds[i] {
+		case consoleCommand:
+			spans = append(spans, Span{Text: prompt, Style: promptStyle})
+			spans = append(spans, line...)
+		case consoleError:
+			for _, span := range line {
+				spans = append(spans, ColorSpan(span.Text, theme.Error))
+			}
+		default:
+			spans = append(spans, line...)
+		}
+	}
+
+	var promptWidget Widget = Text{Spans: []Span{{Text: prompt, Style: promptStyle}}}
+	if c.State.Running.Get() {
+		promptWidget = Text{Spans: []Span{{Text: c.State.spinner.Frame(), Style: promptStyle}}, Style: Style{Width: Cells(max(1, ansi.StringWidth(prompt)))}}
+	}
+	var input Widget = TextInput{
+		ID:          c.inputID(),
+		State:       c.State.Input,
+		Placeholder: c.Placeholder,
+		OnSubmit:    c.submit,
+		Style:       Style{Width: Flex(1)},
+	}
+	if c.Complete != nil {
+		input = Autocomplete{
+			State:                 c.State.completion,
+			Child:                 input,
+			MinChars:              1,
+			DismissWhenEmpty:      true,
+			DisableKeysWhenHidden: true,
+			Unfiltered:            true,
+			Insert:                InsertReplaceWord,
+			OnQueryChange:         c.complete,
+			Style:                 Style{Width: Flex(1)},
+		}
+	}
+
+	return Column{
+		ID:    c.ID,
+		Style: c.Style,
+		Children: []Widget{
+			Scrollable{
+				State: c.State.scroll,
+				Style: Style{Height: Flex(1)},
+				Child: Text{Spans: spans, Wrap: WrapSoft, Style: Style{Width: Flex(1)}},
+			},
+			Row{Children: []Widget{promptWidget, input}},
+		},
+	}
+}
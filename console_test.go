@@ -0,0 +1,114 @@
+package terma
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runConsoleCommand enters command at the prompt and waits for it to finish.
+func runConsoleCommand(t *testing.T, console Console, command string) {
+	t.Helper()
+	console.State.Input.SetText(command)
+	TextInput{State: console.State.Input, OnSubmit: console.submit}.submit()
+	require.Eventually(t, func() bool { return !console.State.Running.Peek() }, time.Second, time.Millisecond)
+}
+
+func TestConsole_RunsCommandsAndShowsOutput(t *testing.T) {
+	state := NewConsoleState()
+	console := Console{State: state, Execute: func(ctx context.Context, command string, out io.Writer) error {
+		fmt.Fprintf(out, "\x1b[32mok\x1b[0m: ran %q\n", command)
+		fmt.Fprint(out, "no newline")
+		return nil
+	}}
+
+	runConsoleCommand(t, console, "select 1")
+	runConsoleCommand(t, console, "select 2")
+
+	assert.Equal(t, "> select 1\nok: ran \"select 1\"\nno newline\n> select 2\nok: ran \"select 2\"\nno newline", state.Text("> "))
+	assert.Equal(t, []string{"select 1", "select 2"}, state.Input.History.Entries())
+	assert.Equal(t, "", state.Input.GetText())
+	assert.False(t, state.Input.ReadOnly.Peek())
+}
+
+func TestConsole_ErrorsAndCancellation(t *testing.T) {
+	state := NewConsoleState()
+	started := make(chan struct{})
+	console := Console{State: state, Execute: func(ctx context.Context, command string, out io.Writer) error {
+		if command == "fail" {
+			return errors.New("syntax error")
+		}
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+
+	runConsoleCommand(t, console, "fail")
+	console.submit("sleep")
+	<-started
+	assert.True(t, state.Running.Peek())
+	assert.True(t, state.Input.ReadOnly.Peek(), "the prompt is read-only while a command runs")
+	state.Cancel()
+	require.Eventually(t, func() bool { return !state.Running.Peek() }, time.Second, time.Millisecond)
+
+	assert.Equal(t, "> fail\nsyntax error\n> sleep\ncancelled", state.Text("> "))
+	_, kinds := state.scrollback()
+	assert.Equal(t, []consoleLineKind{consoleCommand, consoleError, consoleCommand, consoleError}, kinds)
+}
+
+func TestConsoleState_MaxLinesDropsOldest(t *testing.T) {
+	state := NewConsoleState()
+	state.MaxLines = 3
+
+	for i := range 5 {
+		fmt.Fprintf(state, "line %d\n", i)
+	}
+
+	assert.Equal(t, "line 2\nline 3\nline 4", state.Text(""))
+}
+
+func TestConsole_Render(t *testing.T) {
+	state := NewConsoleState()
+	state.Print(PlainSpan("Connected to db\n"))
+	console := Console{State: state, Prompt: "db> ", Style: Style{Height: Cells(4)}}
+	console.submit("\\dt")
+	state.Input.SetText("select")
+
+	screen := screenText(console, 20, 4)
+
+	lines := strings.Split(screen, "\n")
+	assert.Equal(t, "Connected to db", strings.TrimRight(lines[0], " "))
+	assert.Equal(t, "db> \\dt", strings.TrimRight(lines[1], " "))
+	assert.Equal(t, "db> select", strings.TrimRight(lines[3], " "))
+}
+
+func TestConsole_CompletionReplacesWord(t *testing.T) {
+	state := NewConsoleState()
+	var asked []string
+	console := Console{
+		ID:    "sql",
+		State: state,
+		Style: Style{Height: Cells(6)},
+		Complete: func(line string) []Suggestion {
+			asked = append(asked, line)
+			return []Suggestion{{Label: "users"}, {Label: "user_roles"}}
+		},
+	}
+	state.Input.SetText("select * from us")
+	state.Input.CursorEnd()
+
+	screen := screenText(console, 30, 6)
+
+	assert.Contains(t, screen, "user_roles", "the popup flips above the prompt")
+	assert.Equal(t, []string{"select * from us"}, asked)
+	console.State.completion.listState.SelectIndex(1)
+	Autocomplete{State: state.completion, Child: TextInput{ID: "sql-input", State: state.Input}, Insert: InsertReplaceWord}.selectCurrentSuggestion()
+	assert.Equal(t, "select * from user_roles", state.Input.GetText())
+}
@@ -25,12 +25,22 @@ type BuildContext struct {
 	focusedSignal AnySignal[Focusable]
 	// Signal that holds the currently hovered widget (nil if none)
 	hoveredSignal AnySignal[Widget]
+	// hoverPath holds the IDs of the hovered widget and its ancestors, as
+	// they were in the last frame
+	hoverPath map[string]bool
 	// path tracks the current position in the widget tree for auto-ID generation
 	path []int
 	// floatCollector gathers Floating widgets for deferred rendering
 	floatCollector *FloatCollector
 	// disabled is true if within a disabled subtree (set by DisabledWhen wrapper)
 	disabled bool
+	// scope is the ID of the widget being built, used by ScopedID
+	scope string
+	// ids detects duplicate widget IDs (nil unless EnableIDCollisionCheck was called)
+	ids *idTracker
+	// frame is the render pass being built, for state that tracks whether
+	// its widget is still shown
+	frame frameStamp
 }
 
 // NewBuildContext creates a new build context.
@@ -50,6 +60,28 @@ func (ctx BuildContext) AutoID() string {
 	return "_auto:" + ctx.pathString()
 }
 
+// ScopedID returns an ID derived from the widget being built, so IDs
+// generated for repeated children stay unique across the app. The scope is
+// the widget's own ID, or its AutoID when it has none.
+//
+// Example:
+//
+//	for i, item := range items {
+//	    rows = append(rows, Button{ID: ctx.ScopedID("row", i), Label: item})
+//	}
+func (ctx BuildContext) ScopedID(parts ...any) string {
+	var b strings.Builder
+	if ctx.scope != "" {
+		b.WriteString(ctx.scope)
+	} else {
+		b.WriteString(ctx.AutoID())
+	}
+	for _, part := range parts {
+		fmt.Fprintf(&b, "-%v", part)
+	}
+	return b.String()
+}
+
 // pathString converts the path slice to a dot-separated string (e.g., "0.1.3").
 func (ctx BuildContext) pathString() string {
 	if len(ctx.path) == 0 {
@@ -75,9 +107,12 @@ func (ctx BuildContext) PushChild(index int) BuildContext {
 		focusManager:   ctx.focusManager,
 		focusedSignal:  ctx.focusedSignal,
 		hoveredSignal:  ctx.hoveredSignal,
+		hoverPath:      ctx.hoverPath,
 		path:           newPath,
 		floatCollector: ctx.floatCollector,
 		disabled:       ctx.disabled,
+		ids:            ctx.ids,
+		frame:          ctx.frame,
 	}
 }
 
@@ -94,9 +129,13 @@ func (ctx BuildContext) WithDisabled() BuildContext {
 		focusManager:   ctx.focusManager,
 		focusedSignal:  ctx.focusedSignal,
 		hoveredSignal:  ctx.hoveredSignal,
+		hoverPath:      ctx.hoverPath,
 		path:           ctx.path,
 		floatCollector: ctx.floatCollector,
 		disabled:       true,
+		scope:          ctx.scope,
+		ids:            ctx.ids,
+		frame:          ctx.frame,
 	}
 }
 
@@ -122,6 +161,41 @@ func (ctx BuildContext) IsFocused(widget Widget) bool {
 	return ctx.AutoID() == focusedID
 }
 
+// IsFocusedID returns true if the widget with the given ID currently has
+// focus.
+//
+// Example:
+//
+//	if ctx.IsFocusedID("task-list") {
+//	    style.Border = t.RoundedBorder(theme.FocusRing)
+//	}
+func (ctx BuildContext) IsFocusedID(id string) bool {
+	if ctx.focusManager == nil || id == "" {
+		return false
+	}
+	return ctx.focusManager.FocusedID() == id
+}
+
+// FocusWithin returns true if the widget with the given ID, or any widget
+// inside it, currently has focus. Containers use it to style themselves
+// while one of their descendants is focused.
+//
+// Example:
+//
+//	func (p Panel) Build(ctx t.BuildContext) t.Widget {
+//	    border := theme.Border
+//	    if ctx.FocusWithin(p.ID) {
+//	        border = theme.FocusRing
+//	    }
+//	    return t.Column{Style: t.Style{Border: t.RoundedBorder(border)}, Children: p.Children}
+//	}
+func (ctx BuildContext) FocusWithin(id string) bool {
+	if ctx.focusManager == nil {
+		return false
+	}
+	return ctx.focusManager.FocusWithin(id)
+}
+
 // Focused returns the currently focused widget, or nil if none.
 // This is a reactive value - reading it during Build() will cause
 // the widget to rebuild when focus changes.
@@ -191,6 +265,28 @@ func (ctx BuildContext) HoveredID() string {
 	return ""
 }
 
+// HoverWithin returns true if the pointer is over the widget with the given
+// ID or any of its descendants, the way FocusWithin works for focus. Like
+// CSS :hover, a container counts as hovered while any child is.
+// This is a reactive value - reading it during Build() will cause
+// the widget to rebuild when hover changes.
+//
+// Example:
+//
+//	func (c Card) Build(ctx t.BuildContext) t.Widget {
+//	    border := ctx.Theme().Border
+//	    if ctx.HoverWithin(c.ID) {
+//	        border = ctx.Theme().Primary
+//	    }
+//	    return t.Column{ID: c.ID, Style: t.Style{Border: t.RoundedBorder(border)}, Children: c.Children}
+//	}
+func (ctx BuildContext) HoverWithin(id string) bool {
+	if id == "" || ctx.Hovered() == nil {
+		return false
+	}
+	return ctx.hoverPath[id] || ctx.HoveredID() == id
+}
+
 // Theme returns the current theme data.
 // This is a reactive value - reading it during Build() will cause
 // the widget to rebuild when the theme changes.
@@ -211,6 +307,20 @@ func (ctx BuildContext) Theme() ThemeData {
 	return getTheme()
 }
 
+// Density returns the density set by SetDensity. Custom widgets can use it
+// to size their padding like the built-in widgets do. Calling this during
+// Build subscribes the widget to density changes.
+//
+// Example:
+//
+//	func (c Card) Build(ctx BuildContext) Widget {
+//	    pad := ctx.Density().Pick(0, 1, 2)
+//	    return Column{Style: Style{Padding: EdgeInsetsXY(pad*2, pad)}, ...}
+//	}
+func (ctx BuildContext) Density() Density {
+	return getDensity()
+}
+
 // RequestFocus requests that the widget with the given ID receive focus
 // after the current render cycle completes. This is useful for programmatically
 // moving focus, such as when showing inline edit fields.
@@ -0,0 +1,76 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+// focusProbe records the focus queries it answers while building.
+type focusProbe struct {
+	ID      string
+	Query   string
+	Child   Widget
+	focused *bool
+	within  *bool
+}
+
+func (p focusProbe) WidgetID() string { return p.ID }
+
+func (p focusProbe) Build(ctx BuildContext) Widget {
+	*p.focused = ctx.IsFocusedID(p.Query)
+	*p.within = ctx.FocusWithin(p.Query)
+	return p.Child
+}
+
+func TestBuildContext_FocusQueries(t *testing.T) {
+	var focused, within bool
+	widget := Column{
+		Children: []Widget{
+			focusProbe{
+				ID:      "sidebar",
+				Query:   "sidebar",
+				focused: &focused,
+				within:  &within,
+				Child: Column{ID: "nav", Children: []Widget{
+					Button{ID: "home", Label: "Home"},
+				}},
+			},
+			Button{ID: "save", Label: "Save"},
+		},
+	}
+
+	fm := NewFocusManager()
+	renderer := NewRenderer(uv.NewBuffer(20, 3), 20, 3, fm, NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil))
+	fm.SetFocusables(renderer.Render(widget))
+
+	fm.FocusByID("home")
+	renderer.Render(widget)
+	assert.False(t, focused)
+	assert.True(t, within, "descendant focused")
+	assert.True(t, fm.FocusWithin("nav"))
+	assert.True(t, fm.FocusWithin("home"), "a widget contains its own focus")
+
+	fm.FocusByID("save")
+	renderer.Render(widget)
+	assert.False(t, within)
+	assert.False(t, fm.FocusWithin("nav"))
+	assert.False(t, fm.FocusWithin(""))
+}
+
+func TestBuildContext_IsFocusedID(t *testing.T) {
+	fm := NewFocusManager()
+	fm.SetFocusables([]FocusableEntry{
+		{ID: "a", Focusable: newTestFocusable("a")},
+		{ID: "b", Focusable: newTestFocusable("b")},
+	})
+	fm.FocusByID("b")
+	ctx := NewBuildContext(fm, NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil), nil)
+
+	assert.True(t, ctx.IsFocusedID("b"))
+	assert.False(t, ctx.IsFocusedID("a"))
+	assert.False(t, ctx.IsFocusedID(""))
+	assert.False(t, BuildContext{}.IsFocusedID("b"), "no focus manager")
+	assert.False(t, BuildContext{}.FocusWithin("b"))
+}
@@ -0,0 +1,246 @@
+package terma
+
+import (
+	"strings"
+)
+
+// Alpha of the tint copy mode blends into selected cells.
+const copySelectionAlpha = 0.45
+
+// copyMode lets users select text on screen and copy it to the clipboard.
+// Ctrl+Shift+Y enters it, taking a snapshot of the screen to select from;
+// while it is on it takes every key and mouse press:
+//
+//   - arrows or h/j/k/l move the cursor, 0 and $ jump to the line's ends
+//   - v (or space) starts a selection at the cursor, or drops it
+//   - r switches between flowed (like a text editor) and rectangular selection
+//   - enter or y copies the selection, or the cursor's line, and exits
+//   - escape or q exits without copying
+//
+// Dragging the mouse selects too. It is owned by the app's event loop.
+type copyMode struct {
+	active      bool
+	rectangular bool
+	selecting   bool
+	dragging    bool
+	cursorX     int
+	cursorY     int
+	anchorX     int
+	anchorY     int
+	screen      [][]string // Cell contents when copy mode started ("" for wide character continuations)
+}
+
+// enter starts copy mode on a snapshot of the screen, with the cursor in
+// the top-left corner.
+func (m *copyMode) enter(terminal CellBuffer, width, height int) {
+	*m = copyMode{active: true, screen: snapshotScreen(terminal, width, height)}
+}
+
+// exit leaves copy mode.
+func (m *copyMode) exit() {
+	*m = copyMode{}
+}
+
+// handleKey handles a key press while copy mode is on. It returns true
+// when copy mode took the key, which then doesn't reach widgets.
+func (m *copyMode) handleKey(event KeyEvent) bool {
+	if !m.active {
+		return false
+	}
+	switch {
+	case event.MatchString("escape", "q", "ctrl+shift+y"):
+		m.exit()
+	case event.MatchString("enter", "y"):
+		CopyToClipboard(m.selectedText())
+		m.exit()
+	case event.MatchString("up", "k"):
+		m.moveCursor(0, -1)
+	case event.MatchString("down", "j"):
+		m.moveCursor(0, 1)
+	case event.MatchString("left", "h"):
+		m.moveCursor(-1, 0)
+	case event.MatchString("right", "l"):
+		m.moveCursor(1, 0)
+	case event.MatchString("0", "home"):
+		m.moveCursor(-m.cursorX, 0)
+	case event.MatchString("$", "end"):
+		m.moveCursor(m.width()-1-m.cursorX, 0)
+	case event.MatchString("v", "space", " "):
+		m.selecting = !m.selecting
+		m.anchorX, m.anchorY = m.cursorX, m.cursorY
+	case event.MatchString("r"):
+		m.rectangular = !m.rectangular
+	}
+	return true
+}
+
+// handlePress starts a mouse selection at (x, y). It returns true when
+// copy mode took the press.
+func (m *copyMode) handlePress(x, y int) bool {
+	if !m.active {
+		return false
+	}
+	m.cursorX, m.cursorY = 0, 0
+	m.moveCursor(x, y)
+	m.anchorX, m.anchorY = m.cursorX, m.cursorY
+	m.selecting = true
+	m.dragging = true
+	return true
+}
+
+// handleMotion extends a mouse selection to (x, y). It returns true when
+// the overlay needs redrawing.
+func (m *copyMode) handleMotion(x, y int) bool {
+	if !m.active || !m.dragging {
+		return false
+	}
+	m.moveCursor(x-m.cursorX, y-m.cursorY)
+	return true
+}
+
+// handleRelease ends a mouse selection. It returns true when copy mode
+// took the release.
+func (m *copyMode) handleRelease(x, y int) bool {
+	if !m.active {
+		return false
+	}
+	m.handleMotion(x, y)
+	m.dragging = false
+	return true
+}
+
+func (m *copyMode) width() int {
+	if len(m.screen) == 0 {
+		return 0
+	}
+	return len(m.screen[0])
+}
+
+// moveCursor moves the cursor by (dx, dy), keeping it on screen.
+func (m *copyMode) moveCursor(dx, dy int) {
+	m.cursorX = max(0, min(m.width()-1, m.cursorX+dx))
+	m.cursorY = max(0, min(len(m.screen)-1, m.cursorY+dy))
+}
+
+// contains reports whether the cell at (x, y) is selected. Without a
+// selection, the cursor's cell is.
+func (m *copyMode) contains(x, y int) bool {
+	if !m.selecting {
+		return x == m.cursorX && y == m.cursorY
+	}
+	if m.rectangular {
+		left, right := min(m.anchorX, m.cursorX), max(m.anchorX, m.cursorX)
+		top, bottom := min(m.anchorY, m.cursorY), max(m.anchorY, m.cursorY)
+		return x >= left && x <= right && y >= top && y <= bottom
+	}
+	startX, startY, endX, endY := m.flowedRange()
+	if y < startY || y > endY {
+		return false
+	}
+	return (y > startY || x >= startX) && (y < endY || x <= endX)
+}
+
+// flowedRange returns the first and last cells of a flowed selection in
+// reading order.
+func (m *copyMode) flowedRange() (startX, startY, endX, endY int) {
+	if m.anchorY < m.cursorY || (m.anchorY == m.cursorY && m.anchorX <= m.cursorX) {
+		return m.anchorX, m.anchorY, m.cursorX, m.cursorY
+	}
+	return m.cursorX, m.cursorY, m.anchorX, m.anchorY
+}
+
+// selectedText returns the selected text, one line per screen row with
+// trailing spaces removed. Without a selection it is the cursor's line.
+func (m *copyMode) selectedText() string {
+	if len(m.screen) == 0 {
+		return ""
+	}
+	if !m.selecting {
+		return screenLineText(m.screen[m.cursorY], 0, m.width()-1)
+	}
+	var lines []string
+	if m.rectangular {
+		left, right := min(m.anchorX, m.cursorX), max(m.anchorX, m.cursorX)
+		for y := min(m.anchorY, m.cursorY); y <= max(m.anchorY, m.cursorY); y++ {
+			lines = append(lines, screenLineText(m.screen[y], left, right))
+		}
+	} else {
+		startX, startY, endX, endY := m.flowedRange()
+		for y := startY; y <= endY; y++ {
+			from, to := 0, m.width()-1
+			if y == startY {
+				from = startX
+			}
+			if y == endY {
+				to = endX
+			}
+			lines = append(lines, screenLineText(m.screen[y], from, to))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// draw tints the selection, marks the cursor and shows a status line with
+// the keys to use.
+func (m *copyMode) draw(terminal CellBuffer, width, height int) {
+	if !m.active || width <= 0 || height <= 0 {
+		return
+	}
+	theme := getTheme()
+
+	tint := theme.Accent.WithAlpha(copySelectionAlpha)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if m.contains(x, y) {
+				tintCell(terminal, x, y, tint)
+			}
+		}
+	}
+	if cell := terminal.CellAt(m.cursorX, m.cursorY); cell != nil {
+		cursor := *cell
+		cursor.Style.Fg = theme.TextOnPrimary.toANSI()
+		cursor.Style.Bg = theme.Primary.toANSI()
+		terminal.SetCell(m.cursorX, m.cursorY, &cursor)
+	}
+
+	kind := "flowed"
+	if m.rectangular {
+		kind = "rectangle"
+	}
+	status := " COPY " + kind + " │ v select · r shape · enter copy · esc exit "
+	// Keep the status line out of the way of the cursor.
+	y := height - 1
+	if m.cursorY == y {
+		y = 0
+	}
+	ctx := NewRenderContext(terminal, width, height, nil, nil, BuildContext{}, nil)
+	ctx.DrawStyledText(max(0, width-len([]rune(status))), y, status, Style{
+		ForegroundColor: theme.TextOnPrimary,
+		BackgroundColor: theme.Primary,
+	})
+}
+
+// snapshotScreen returns the content of every cell on screen.
+func snapshotScreen(terminal CellBuffer, width, height int) [][]string {
+	screen := make([][]string, height)
+	for y := range screen {
+		screen[y] = make([]string, width)
+		for x := range screen[y] {
+			screen[y][x] = " "
+			if cell := terminal.CellAt(x, y); cell != nil {
+				screen[y][x] = cell.Content
+			}
+		}
+	}
+	return screen
+}
+
+// screenLineText returns the text of cells from..to of a snapshot row,
+// without trailing spaces.
+func screenLineText(line []string, from, to int) string {
+	var sb strings.Builder
+	for x := max(0, from); x <= to && x < len(line); x++ {
+		sb.WriteString(line[x])
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
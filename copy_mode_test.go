@@ -0,0 +1,135 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+func copyModeForTest(lines ...string) *copyMode {
+	buf := uv.NewBuffer(10, len(lines))
+	for y, line := range lines {
+		for x, r := range line {
+			buf.SetCell(x, y, &uv.Cell{Content: string(r), Width: 1})
+		}
+	}
+	mode := &copyMode{}
+	mode.enter(buf, 10, len(lines))
+	return mode
+}
+
+func pressCopyKeys(mode *copyMode, keys ...KeyEvent) {
+	for _, key := range keys {
+		mode.handleKey(key)
+	}
+}
+
+func TestCopyMode_InactiveIgnoresInput(t *testing.T) {
+	mode := &copyMode{}
+	assert.False(t, mode.handleKey(makeCharEvent('j')))
+	assert.False(t, mode.handlePress(1, 1))
+	assert.False(t, mode.handleMotion(2, 2))
+	assert.False(t, mode.handleRelease(2, 2))
+}
+
+func TestCopyMode_FlowedSelection(t *testing.T) {
+	mode := copyModeForTest("alpha one", "beta two", "gamma")
+	pressCopyKeys(mode, makeCharEvent('l'), makeCharEvent('l'), makeCharEvent('v'),
+		makeCharEvent('j'), makeCharEvent('j'), makeCharEvent('l'))
+
+	assert.Equal(t, "pha one\nbeta two\ngamm", mode.selectedText())
+	assert.True(t, mode.contains(9, 1), "whole middle lines are selected")
+	assert.False(t, mode.contains(1, 0))
+	assert.False(t, mode.contains(4, 2), "the last line ends at the cursor")
+}
+
+func TestCopyMode_FlowedSelectionBackwards(t *testing.T) {
+	mode := copyModeForTest("alpha one", "beta two")
+	mode.moveCursor(3, 1)
+	pressCopyKeys(mode, makeCharEvent('v'), makeKeyEvent(uv.KeyUp, 0), makeCharEvent('h'))
+
+	assert.Equal(t, "pha one\nbeta", mode.selectedText())
+}
+
+func TestCopyMode_RectangularSelection(t *testing.T) {
+	mode := copyModeForTest("alpha one", "beta two", "gamma")
+	mode.moveCursor(1, 0)
+	pressCopyKeys(mode, makeCharEvent('r'), makeCharEvent('v'), makeCharEvent('j'),
+		makeCharEvent('j'), makeCharEvent('l'), makeCharEvent('l'))
+
+	assert.Equal(t, "lph\neta\namm", mode.selectedText())
+	assert.False(t, mode.contains(4, 1))
+}
+
+func TestCopyMode_CursorLineWithoutSelection(t *testing.T) {
+	mode := copyModeForTest("alpha", "beta two")
+	pressCopyKeys(mode, makeCharEvent('j'), makeCharEvent('$'))
+
+	assert.Equal(t, 9, mode.cursorX)
+	assert.Equal(t, "beta two", mode.selectedText())
+}
+
+func TestCopyMode_CursorStaysOnScreen(t *testing.T) {
+	mode := copyModeForTest("ab", "cd")
+	pressCopyKeys(mode, makeCharEvent('k'), makeCharEvent('h'))
+	assert.Equal(t, 0, mode.cursorX)
+	assert.Equal(t, 0, mode.cursorY)
+
+	mode.handlePress(40, 40)
+	assert.Equal(t, 9, mode.cursorX)
+	assert.Equal(t, 1, mode.cursorY)
+}
+
+func TestCopyMode_MouseDragSelects(t *testing.T) {
+	mode := copyModeForTest("alpha one", "beta two")
+	assert.True(t, mode.handlePress(6, 0))
+	assert.True(t, mode.handleMotion(2, 1))
+	assert.True(t, mode.handleRelease(3, 1))
+	assert.False(t, mode.handleMotion(8, 1), "motion after release doesn't move the selection")
+
+	assert.Equal(t, "one\nbeta", mode.selectedText())
+}
+
+func TestCopyMode_EnterCopiesAndExits(t *testing.T) {
+	buf := captureTerminalOutput(t)
+	mode := copyModeForTest("alpha", "beta")
+	pressCopyKeys(mode, makeCharEvent('v'), makeCharEvent('l'), makeKeyEvent(uv.KeyEnter, 0))
+
+	assert.Equal(t, ansi.SetSystemClipboard("al"), buf.String())
+	assert.False(t, mode.active)
+}
+
+func TestCopyMode_EscapeExitsWithoutCopying(t *testing.T) {
+	buf := captureTerminalOutput(t)
+	mode := copyModeForTest("alpha")
+	assert.True(t, mode.handleKey(makeKeyEvent(uv.KeyEscape, 0)))
+
+	assert.False(t, mode.active)
+	assert.Empty(t, buf.String())
+}
+
+func TestCopyMode_SnapshotIgnoresLaterFrames(t *testing.T) {
+	buf := uv.NewBuffer(5, 1)
+	buf.SetCell(0, 0, &uv.Cell{Content: "a", Width: 1})
+	mode := &copyMode{}
+	mode.enter(buf, 5, 1)
+	buf.SetCell(0, 0, &uv.Cell{Content: "b", Width: 1})
+
+	assert.Equal(t, "a", mode.selectedText())
+}
+
+func TestCopyMode_Draw(t *testing.T) {
+	mode := copyModeForTest("alpha", "beta", "gamma")
+	pressCopyKeys(mode, makeCharEvent('v'), makeCharEvent('l'))
+
+	buf := uv.NewBuffer(60, 3)
+	mode.draw(buf, 60, 3)
+
+	theme := getTheme()
+	assert.Equal(t, theme.Primary.toANSI(), buf.CellAt(1, 0).Style.Bg, "cursor cell")
+	assert.NotNil(t, buf.CellAt(0, 0).Style.Bg, "selected cell is tinted")
+	assert.Nil(t, buf.CellAt(2, 0).Style.Bg)
+	assert.Contains(t, bufferLine(buf, 2, 60), "COPY flowed")
+}
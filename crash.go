@@ -0,0 +1,165 @@
+package terma
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// crashReportInputEvents is how many recent input events a crash report keeps.
+const crashReportInputEvents = 50
+
+var (
+	crashReportMu  sync.Mutex
+	crashReportDir string
+)
+
+// EnableCrashReports makes Run write a crash report to dir when the app
+// panics. The report contains the panic and stack trace, the most recent
+// input events and the last rendered frame, and its path is printed to
+// stderr after the terminal is restored. The input is also written next to
+// it for Replay; turn on EnableInputRecording to keep more of it, along with
+// signal changes. Setting TERMA_CRASH_REPORT_DIR has the same effect. Pass
+// "" to disable.
+func EnableCrashReports(dir string) {
+	crashReportMu.Lock()
+	defer crashReportMu.Unlock()
+	crashReportDir = dir
+}
+
+// crashReportDirectory returns the directory crash reports are written to,
+// or "" when reports are disabled.
+func crashReportDirectory() string {
+	crashReportMu.Lock()
+	defer crashReportMu.Unlock()
+	if crashReportDir != "" {
+		return crashReportDir
+	}
+	return os.Getenv("TERMA_CRASH_REPORT_DIR")
+}
+
+// inputHistory is a fixed-size ring of recent input events, for crash
+// reports and input recordings.
+type inputHistory struct {
+	mu        sync.Mutex
+	start     time.Time
+	records   []InputRecord
+	next      int
+	recording bool // Started by EnableInputRecording: signal changes are kept too
+}
+
+// inputEvents is the input history Run adds to, nil before the first Run
+// unless EnableInputRecording was called.
+var inputEvents atomic.Pointer[inputHistory]
+
+func newInputHistory(size int) *inputHistory {
+	return &inputHistory{start: Now(), records: make([]InputRecord, 0, size)}
+}
+
+// record adds a record, dropping the oldest once the ring is full.
+func (h *inputHistory) record(record InputRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	record.At = Now().Sub(h.start)
+	if len(h.records) < cap(h.records) {
+		h.records = append(h.records, record)
+		return
+	}
+	h.records[h.next] = record
+	h.next = (h.next + 1) % len(h.records)
+}
+
+// recent returns the records, oldest first. A nil history has none.
+func (h *inputHistory) recent() []InputRecord {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append(append([]InputRecord{}, h.records[h.next:]...), h.records[:h.next]...)
+}
+
+// describeInput formats a record as a line of a crash report.
+func describeInput(record InputRecord) string {
+	switch record.Kind {
+	case "key":
+		return "key " + record.Key
+	case "resize":
+		return fmt.Sprintf("resize %dx%d", record.Width, record.Height)
+	case "signal":
+		return fmt.Sprintf("signal %s at %s", record.Text, record.Source)
+	}
+	return fmt.Sprintf("%s %s at %d,%d", record.Kind, uv.MouseButton(record.Button), record.X, record.Y)
+}
+
+// crashReport is everything written to a crash report file.
+type crashReport struct {
+	Time          time.Time
+	Panics        []Panic
+	Width, Height int
+	Input         []InputRecord
+	Screen        string
+	recordingFile string // Name of the file the input was written to, for Replay
+}
+
+// String formats the report as plain text.
+func (c crashReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "terma crash report\n")
+	fmt.Fprintf(&b, "time:     %s\n", c.Time.Format(time.RFC3339))
+	fmt.Fprintf(&b, "go:       %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "terminal: %dx%d\n", c.Width, c.Height)
+
+	for _, p := range c.Panics {
+		fmt.Fprintf(&b, "\npanic: %s\n\n%s\n", p.Message, strings.TrimSpace(p.StackTrace))
+	}
+
+	fmt.Fprintf(&b, "\nrecent input (oldest first):\n")
+	if len(c.Input) == 0 {
+		fmt.Fprintf(&b, "  (none)\n")
+	}
+	for _, record := range c.Input {
+		fmt.Fprintf(&b, "  %9s %s\n", record.At.Round(time.Millisecond), describeInput(record))
+	}
+	if c.recordingFile != "" {
+		fmt.Fprintf(&b, "\ninput recording: %s (replay with terma.Replay)\n", c.recordingFile)
+	}
+
+	fmt.Fprintf(&b, "\nlast frame:\n%s\n", c.Screen)
+	return b.String()
+}
+
+// writeCrashReport writes the report to a timestamped file in dir and
+// returns its path.
+func writeCrashReport(dir string, report crashReport) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	name := "terma-crash-" + report.Time.Format("20060102-150405")
+	if len(report.Input) > 0 {
+		report.recordingFile = name + "-input.jsonl"
+		f, err := os.Create(filepath.Join(dir, report.recordingFile))
+		if err != nil {
+			return "", err
+		}
+		err = writeInputRecords(f, report.Input)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	path := filepath.Join(dir, name+".txt")
+	if err := os.WriteFile(path, []byte(report.String()), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,84 @@
+package terma
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputHistory_KeepsMostRecent(t *testing.T) {
+	history := newInputHistory(3)
+	for i := range 5 {
+		history.record(InputRecord{Kind: "key", Key: fmt.Sprint(i)})
+	}
+
+	recent := history.recent()
+	require.Len(t, recent, 3)
+	for i, want := range []string{"2", "3", "4"} {
+		assert.Equal(t, want, recent[i].Key)
+	}
+	assert.Nil(t, (*inputHistory)(nil).recent())
+}
+
+func TestWriteCrashReport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "crashes")
+	report := crashReport{
+		Time:   time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+		Panics: []Panic{{Message: "index out of range", StackTrace: "main.render()\n\tmain.go:12\n"}},
+		Width:  20,
+		Height: 2,
+		Input: []InputRecord{
+			{At: 1500 * time.Millisecond, Kind: "key", Key: "j", Code: 'j', Text: "j"},
+			{At: 2 * time.Second, Kind: "click", X: 3, Y: 1, Button: int(uv.MouseLeft)},
+		},
+		Screen: "hello\nworld",
+	}
+
+	path, err := writeCrashReport(dir, report)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "terma-crash-20240301-123000.txt"), path)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	text := string(data)
+	assert.Contains(t, text, "terminal: 20x2")
+	assert.Contains(t, text, "panic: index out of range\n\nmain.render()\n\tmain.go:12\n")
+	assert.Contains(t, text, "recent input (oldest first):\n       1.5s key j\n         2s click left at 3,1\n")
+	assert.Contains(t, text, "input recording: terma-crash-20240301-123000-input.jsonl")
+	assert.Contains(t, text, "last frame:\nhello\nworld\n")
+}
+
+func TestCrashReportDirectory(t *testing.T) {
+	t.Cleanup(func() { EnableCrashReports("") })
+
+	t.Setenv("TERMA_CRASH_REPORT_DIR", "")
+	assert.Equal(t, "", crashReportDirectory())
+
+	t.Setenv("TERMA_CRASH_REPORT_DIR", "/tmp/from-env")
+	assert.Equal(t, "/tmp/from-env", crashReportDirectory())
+
+	EnableCrashReports("/tmp/explicit")
+	assert.Equal(t, "/tmp/explicit", crashReportDirectory())
+}
+
+func TestGo_RecordsPanic(t *testing.T) {
+	t.Cleanup(func() { drainPanics() })
+
+	done := make(chan struct{})
+	Go(func() {
+		defer close(done)
+		panic("worker failed")
+	})
+	<-done
+
+	require.Eventually(t, func() bool { return len(recordedPanics()) == 1 }, time.Second, time.Millisecond)
+	panics := drainPanics()
+	assert.Equal(t, "worker failed", panics[0].Message)
+	assert.Contains(t, panics[0].StackTrace, "TestGo_RecordsPanic")
+}
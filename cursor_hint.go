@@ -0,0 +1,119 @@
+package terma
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// CursorHint is a mouse pointer shape a widget suggests for the pointer
+// over it. Terminals that support the OSC 22 pointer-shape sequence
+// (xterm, kitty, foot, Ghostty) show it; others ignore it.
+type CursorHint int
+
+const (
+	// CursorDefault is the terminal's normal pointer.
+	CursorDefault CursorHint = iota
+	// CursorPointer is the hand shown over clickable things.
+	CursorPointer
+	// CursorText is the I-beam shown over editable text.
+	CursorText
+	// CursorResizeHorizontal shows that dragging resizes left and right.
+	CursorResizeHorizontal
+	// CursorResizeVertical shows that dragging resizes up and down.
+	CursorResizeVertical
+	// CursorMove shows that dragging moves something.
+	CursorMove
+	// CursorNotAllowed shows that the thing under the pointer is disabled.
+	CursorNotAllowed
+	// CursorWait shows that the app is busy.
+	CursorWait
+)
+
+// String returns the CSS cursor name the terminal is sent for the hint.
+func (h CursorHint) String() string {
+	switch h {
+	case CursorPointer:
+		return "pointer"
+	case CursorText:
+		return "text"
+	case CursorResizeHorizontal:
+		return "ew-resize"
+	case CursorResizeVertical:
+		return "ns-resize"
+	case CursorMove:
+		return "move"
+	case CursorNotAllowed:
+		return "not-allowed"
+	case CursorWait:
+		return "wait"
+	default:
+		return "default"
+	}
+}
+
+// CursorHintProvider is implemented by widgets that suggest a pointer
+// shape. The event carries the pointer position, so a widget can vary the
+// hint across its area (for example only over a divider). It is called
+// for the widget under the pointer, or the widget being dragged.
+type CursorHintProvider interface {
+	CursorHint(event MouseEvent) CursorHint
+}
+
+// cursorHintFor returns the hint for the pointer at (x, y) over entry.
+func cursorHintFor(entry *WidgetEntry, x, y int) CursorHint {
+	if entry == nil {
+		return CursorDefault
+	}
+	provider, ok := entry.EventWidget.(CursorHintProvider)
+	if !ok {
+		return CursorDefault
+	}
+	return provider.CursorHint(buildMouseEvent(uv.Mouse{X: x, Y: y}, entry, 0))
+}
+
+// setPointerShape shows hint as the terminal's pointer, if it isn't
+// already showing it. The shape is reset when Run returns.
+func setPointerShape(hint CursorHint) {
+	terminalWindow.mu.Lock()
+	if terminalWindow.pointerShape == hint {
+		terminalWindow.mu.Unlock()
+		return
+	}
+	terminalWindow.pointerShape = hint
+	terminalWindow.mu.Unlock()
+	writeTerminalOutput(ansi.SetPointerShape(hint.String()))
+}
+
+var hoverDebugEnabled atomic.Bool
+
+// EnableHoverDebug outlines the widget under the pointer on every frame,
+// labelled with its ID and cursor hint, to check hit areas of split panes
+// and other mouse targets. Apps enable this automatically when
+// TERMA_DEBUG_HOVER is set.
+func EnableHoverDebug() {
+	hoverDebugEnabled.Store(true)
+}
+
+// drawHoverDebug outlines entry's bounds on top of the frame.
+func drawHoverDebug(terminal CellBuffer, width, height int, entry *WidgetEntry, hint CursorHint) {
+	if entry == nil || entry.Bounds.IsEmpty() {
+		return
+	}
+	theme := getTheme()
+	ctx := NewRenderContext(terminal, width, height, nil, nil, BuildContext{}, nil)
+	bounds := entry.Bounds
+	ctx.DrawBorder(bounds.X, bounds.Y, bounds.Width, bounds.Height, DashedBorder(theme.Warning))
+
+	label := fmt.Sprintf(" %s %s ", entry.ID, hint)
+	labelY := bounds.Y - 1
+	if labelY < 0 {
+		labelY = bounds.Y + bounds.Height
+	}
+	ctx.DrawStyledText(bounds.X, labelY, label, Style{
+		ForegroundColor: theme.Background,
+		BackgroundColor: theme.Warning,
+	})
+}
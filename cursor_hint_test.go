@@ -0,0 +1,39 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetPointerShape_SkipsRepeatsAndRestores(t *testing.T) {
+	buf := captureTerminalOutput(t)
+	resetTerminalWindow(t)
+
+	setPointerShape(CursorDefault)
+	setPointerShape(CursorPointer)
+	setPointerShape(CursorPointer)
+	setPointerShape(CursorResizeHorizontal)
+	assert.Equal(t, "\x1b]22;pointer\x07\x1b]22;ew-resize\x07", buf.String())
+
+	assert.Equal(t, []string{"\x1b]22;default\x07"}, restoreTerminalWindow())
+	assert.Empty(t, restoreTerminalWindow())
+}
+
+func TestCursorHintFor(t *testing.T) {
+	entry := &WidgetEntry{EventWidget: Button{ID: "ok"}, ID: "ok", Bounds: Rect{X: 2, Y: 1, Width: 4, Height: 1}}
+	assert.Equal(t, CursorPointer, cursorHintFor(entry, 3, 1))
+	assert.Equal(t, CursorText, cursorHintFor(&WidgetEntry{EventWidget: TextInput{}}, 0, 0))
+	assert.Equal(t, CursorDefault, cursorHintFor(&WidgetEntry{EventWidget: Text{}}, 0, 0))
+	assert.Equal(t, CursorDefault, cursorHintFor(nil, 0, 0))
+}
+
+func TestDrawHoverDebug(t *testing.T) {
+	buf := uv.NewBuffer(12, 4)
+	entry := &WidgetEntry{ID: "ok", Bounds: Rect{X: 1, Y: 1, Width: 6, Height: 3}}
+	drawHoverDebug(buf, 12, 4, entry, CursorPointer)
+
+	assert.Equal(t, getTheme().Warning.toANSI(), buf.CellAt(1, 1).Style.Fg, "outline")
+	assert.Equal(t, "  ok pointer", bufferLine(buf, 0, 12))
+}
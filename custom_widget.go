@@ -0,0 +1,124 @@
+package terma
+
+import "github.com/darrenburns/terma/layout"
+
+// This file holds the pieces external packages need to write widgets that
+// behave like the built-in ones. The contract is:
+//
+//   - Build returns the widget itself for leaf and container widgets, or a
+//     composed tree for widgets built from others.
+//   - BuildLayoutNode (LayoutNodeBuilder) sizes the widget. Leaf widgets
+//     return LeafLayoutNode; containers build each child with
+//     ChildLayoutNode and arrange the results with a layout package node.
+//   - Render (Renderable) paints the content box through the RenderContext
+//     drawing operations. Background, border and padding are painted by
+//     the framework from GetStyle.
+//   - ChildWidgets (ChildProvider) lists a container's children in the same
+//     order as its layout node's children, so they are built and rendered.
+//   - Identifiable, Focusable, KeybindProvider and the mouse interfaces
+//     register the widget for focus, keys, hover and clicks. Widgets are
+//     registered automatically; there is no registration call.
+//   - Floating overlays are declared by returning a Floating from Build (or
+//     including one among the children); they are collected while the tree
+//     is built.
+
+// WidgetBase can be embedded in a widget struct to provide the common ID
+// and Style fields, implementing Identifiable and Styled.
+//
+// Example:
+//
+//	type Gauge struct {
+//	    t.WidgetBase
+//	    Value float64
+//	}
+//
+//	Gauge{WidgetBase: t.WidgetBase{ID: "cpu", Style: t.Style{Width: t.Cells(20)}}}
+type WidgetBase struct {
+	ID    string // Optional unique identifier for the widget
+	Style Style  // Optional styling
+}
+
+// WidgetID returns the widget's unique identifier.
+// Implements the Identifiable interface.
+func (b WidgetBase) WidgetID() string {
+	return b.ID
+}
+
+// GetStyle returns the widget's style.
+// Implements the Styled interface.
+func (b WidgetBase) GetStyle() Style {
+	return b.Style
+}
+
+// FocusableBase can be embedded alongside WidgetBase to make a widget
+// focusable. It ignores keys by default; declare bindings with Keybinds()
+// or define OnKey on the widget to handle them. A focusable widget needs
+// an ID to keep its focus across rebuilds.
+type FocusableBase struct {
+	Disabled bool // When true, the widget cannot receive focus
+}
+
+// IsFocusable returns true unless the widget is disabled.
+// Implements the Focusable interface.
+func (b FocusableBase) IsFocusable() bool {
+	return !b.Disabled
+}
+
+// OnKey ignores the key, letting it bubble to ancestors.
+// Implements the Focusable interface.
+func (b FocusableBase) OnKey(event KeyEvent) bool {
+	return false
+}
+
+// LeafLayoutNode returns the layout node for a widget that draws its own
+// content. The node takes the widget's padding, border, margin and size
+// from GetStyle and GetContentDimensions, and measures content with the
+// widget's Layout method when it has one (given and returning the
+// content-box size).
+//
+// Example:
+//
+//	func (g Gauge) BuildLayoutNode(ctx t.BuildContext) layout.LayoutNode {
+//	    return t.LeafLayoutNode(ctx, g)
+//	}
+func LeafLayoutNode(ctx BuildContext, widget Widget) layout.LayoutNode {
+	var measure func(layout.Constraints) (int, int)
+	if layoutable, ok := widget.(Layoutable); ok {
+		measure = func(constraints layout.Constraints) (int, int) {
+			size := layoutable.Layout(ctx, Constraints{
+				MinWidth:  constraints.MinWidth,
+				MaxWidth:  constraints.MaxWidth,
+				MinHeight: constraints.MinHeight,
+				MaxHeight: constraints.MaxHeight,
+			})
+			return size.Width, size.Height
+		}
+	}
+	return leafLayoutNode(widget, measure)
+}
+
+// ChildLayoutNode builds child and returns its layout node for a container
+// that lays children out along axis, wrapped so Flex and Percent sizes on
+// that axis share the container's space as they do in Row and Column. Pass
+// ctx.PushChild(i) for the i-th child.
+func ChildLayoutNode(ctx BuildContext, child Widget, axis layout.Axis) layout.LayoutNode {
+	built := child.Build(ctx)
+
+	var node layout.LayoutNode
+	if builder, ok := built.(LayoutNodeBuilder); ok {
+		node = builder.BuildLayoutNode(ctx)
+	} else {
+		// Fallback: create a BoxNode for widgets without LayoutNodeBuilder
+		node = buildFallbackLayoutNode(built, ctx)
+	}
+
+	mainAxisDim := getChildMainAxisDimension(built, axis == layout.Horizontal)
+	node = wrapInPercentIfNeeded(node, mainAxisDim, axis)
+	return wrapInFlexIfNeeded(node, mainAxisDim)
+}
+
+// LayoutInsets converts a style's padding, border and margin to layout
+// insets, for widgets building their own layout nodes.
+func LayoutInsets(style Style) (padding, border, margin layout.EdgeInsets) {
+	return toLayoutEdgeInsets(style.Padding), borderToEdgeInsets(style.Border), toLayoutEdgeInsets(style.Margin)
+}
@@ -0,0 +1,97 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/darrenburns/terma/layout"
+	"github.com/stretchr/testify/assert"
+)
+
+// testGauge is written the way an external package would write a widget.
+type testGauge struct {
+	WidgetBase
+	FocusableBase
+	Filled  int
+	pressed *int
+}
+
+func (g testGauge) Build(ctx BuildContext) Widget { return g }
+
+func (g testGauge) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return LeafLayoutNode(ctx, g)
+}
+
+func (g testGauge) Layout(ctx BuildContext, constraints Constraints) Size {
+	return Size{Width: min(5, constraints.MaxWidth), Height: 1}
+}
+
+func (g testGauge) Render(ctx *RenderContext) {
+	ctx.DrawText(0, 0, strings.Repeat("#", g.Filled)+strings.Repeat(".", ctx.Width-g.Filled))
+}
+
+func (g testGauge) Keybinds() []Keybind {
+	return []Keybind{{Key: "+", Name: "More", Action: func() { *g.pressed++ }}}
+}
+
+// testPair is a custom container stacking its two children.
+type testPair struct {
+	WidgetBase
+	Top, Bottom Widget
+}
+
+func (p testPair) Build(ctx BuildContext) Widget { return p }
+
+func (p testPair) ChildWidgets() []Widget { return []Widget{p.Top, p.Bottom} }
+
+func (p testPair) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	padding, border, margin := LayoutInsets(p.Style)
+	return &layout.ColumnNode{
+		Children: []layout.LayoutNode{
+			ChildLayoutNode(ctx.PushChild(0), p.Top, layout.Vertical),
+			ChildLayoutNode(ctx.PushChild(1), p.Bottom, layout.Vertical),
+		},
+		Padding: padding,
+		Border:  border,
+		Margin:  margin,
+	}
+}
+
+func TestCustomWidget_LayoutRenderAndFocus(t *testing.T) {
+	pressed := 0
+	widget := testPair{
+		WidgetBase: WidgetBase{Style: Style{Padding: EdgeInsetsXY(1, 0)}},
+		Top:        Text{Content: "CPU"},
+		Bottom: Row{Children: []Widget{
+			testGauge{WidgetBase: WidgetBase{ID: "gauge"}, Filled: 2, pressed: &pressed},
+			Text{Content: "!"},
+		}},
+	}
+
+	fm := NewFocusManager()
+	buf := uv.NewBuffer(10, 2)
+	renderer := NewRenderer(buf, 10, 2, fm, NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil))
+	fm.SetFocusables(renderer.Render(widget))
+
+	assert.Equal(t, " CPU      ", rowText(buf, 10, 0))
+	assert.Equal(t, " ##...!   ", rowText(buf, 10, 1), "measured by its Layout method inside a Row")
+
+	fm.FocusByID("gauge")
+	assert.Equal(t, "gauge", fm.FocusedID())
+	assert.True(t, fm.HandleKey(makeCharEvent('+')))
+	assert.Equal(t, 1, pressed)
+
+	disabled := testGauge{WidgetBase: WidgetBase{ID: "gauge"}, FocusableBase: FocusableBase{Disabled: true}}
+	assert.False(t, disabled.IsFocusable())
+}
+
+func TestChildLayoutNode_WrapsFlexOnAxis(t *testing.T) {
+	child := Text{Content: "x", Style: Style{Width: Flex(2)}}
+	flex, ok := layout.IsFlexNode(ChildLayoutNode(BuildContext{}, child, layout.Horizontal))
+	assert.True(t, ok)
+	assert.Equal(t, 2.0, flex.Flex)
+
+	_, ok = layout.IsFlexNode(ChildLayoutNode(BuildContext{}, child, layout.Vertical))
+	assert.False(t, ok, "only the main axis is wrapped")
+}
@@ -0,0 +1,386 @@
+package terma
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// DataSource produces items for a ListState or TableState bound with
+// BindList or BindTable. Run is called once on a background goroutine and
+// should write to sink until ctx is cancelled or the source is exhausted.
+// Returning a non-nil error other than ctx's sets Binding.Err.
+type DataSource[T any] interface {
+	Run(ctx context.Context, sink *DataSink[T]) error
+}
+
+// DataSourceFunc adapts a function to a DataSource.
+type DataSourceFunc[T any] func(ctx context.Context, sink *DataSink[T]) error
+
+// Run calls f(ctx, sink).
+func (f DataSourceFunc[T]) Run(ctx context.Context, sink *DataSink[T]) error {
+	return f(ctx, sink)
+}
+
+// BindOptions configures BindList and BindTable.
+type BindOptions struct {
+	MaxItems      int           // Items kept; the oldest are dropped beyond it (0 = unlimited)
+	Upsert        bool          // Add replaces items with the same key (the state's KeyFor) instead of appending
+	FlushInterval time.Duration // Minimum time between updates of the state while data is arriving (default 50ms)
+}
+
+// Binding connects a DataSource to a ListState or TableState. Writes from
+// the source are batched and applied at most once per FlushInterval, so a
+// fast stream causes a handful of renders rather than one per item. With
+// the state's KeyFor set, the cursor and selection follow their items as
+// the source adds, replaces and removes them.
+//
+// Example - a gRPC stream in a live table:
+//
+//	binding := t.BindTable(ctx, a.table, t.StreamSource(func(ctx context.Context) (func() (*pb.Order, error), error) {
+//		stream, err := client.WatchOrders(ctx, &pb.WatchRequest{})
+//		if err != nil {
+//			return nil, err
+//		}
+//		return stream.Recv, nil
+//	}), t.BindOptions{Upsert: true, MaxItems: 1000})
+//	defer binding.Stop()
+type Binding[T any] struct {
+	Loading Signal[bool]     // True while the source runs, except while it waits for LoadMore
+	Done    Signal[bool]     // True once the source has finished
+	Err     AnySignal[error] // Error the source finished with, or nil
+
+	apply   func(fn func([]T) []T)
+	keyFor  func(T) string
+	options BindOptions
+
+	mu        sync.Mutex
+	pending   []dataOp[T]
+	scheduled bool
+	flushMu   sync.Mutex // Keeps flushes in order
+
+	more   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// dataOpKind identifies a pending change from a DataSink.
+type dataOpKind int
+
+const (
+	dataAdd dataOpKind = iota
+	dataReset
+	dataRemove
+)
+
+// dataOp is a change waiting to be applied to the bound state.
+type dataOp[T any] struct {
+	kind   dataOpKind
+	items  []T
+	remove func(T) bool
+}
+
+// DataSink is how a DataSource writes to its binding. Its methods are safe
+// to call from any goroutine.
+type DataSink[T any] struct {
+	binding *Binding[T]
+	ctx     context.Context
+}
+
+// BindList runs source on a background goroutine, feeding its items into
+// state, until ctx is cancelled, the source finishes or Stop is called.
+// The binding's Loading and Err are the state's, so List.LoadingState and
+// List.ErrorState follow the source.
+func BindList[T any](ctx context.Context, state *ListState[T], source DataSource[T], options BindOptions) *Binding[T] {
+	return bind(ctx, func(fn func([]T) []T) {
+		state.Items.Update(fn)
+		state.resetFilterCache()
+		state.syncKeys()
+		state.clampCursor()
+	}, state.KeyFor, state.Loading, state.Err, source, options)
+}
+
+// BindTable runs source on a background goroutine, feeding its rows into
+// state, until ctx is cancelled, the source finishes or Stop is called.
+// The binding's Loading and Err are the state's, so Table.LoadingState and
+// Table.ErrorState follow the source.
+func BindTable[T any](ctx context.Context, state *TableState[T], source DataSource[T], options BindOptions) *Binding[T] {
+	return bind(ctx, func(fn func([]T) []T) {
+		state.Rows.Update(fn)
+		state.syncKeys()
+		state.clampCursor()
+	}, state.KeyFor, state.Loading, state.Err, source, options)
+}
+
+// bind starts a binding that reports progress through loading and loadErr,
+// creating the signals if they are unset.
+func bind[T any](ctx context.Context, apply func(fn func([]T) []T), keyFor func(T) string, loading Signal[bool], loadErr AnySignal[error], source DataSource[T], options BindOptions) *Binding[T] {
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = 50 * time.Millisecond
+	}
+	if !loading.IsValid() {
+		loading = NewSignal(false)
+	}
+	if !loadErr.IsValid() {
+		loadErr = NewAnySignal[error](nil)
+	}
+	loading.Set(true)
+	loadErr.Set(nil)
+	ctx, cancel := context.WithCancel(ctx)
+	b := &Binding[T]{
+		Loading: loading,
+		Done:    NewSignal(false),
+		Err:     loadErr,
+		apply:   apply,
+		keyFor:  keyFor,
+		options: options,
+		more:    make(chan struct{}, 1),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	sink := &DataSink[T]{binding: b, ctx: ctx}
+	Go(func() {
+		defer close(b.done)
+		err := source.Run(ctx, sink)
+		b.flush()
+		if err != nil && !errors.Is(err, ctx.Err()) && !errors.Is(err, io.EOF) {
+			b.Err.Set(err)
+		}
+		b.Loading.Set(false)
+		b.Done.Set(true)
+	})
+	return b
+}
+
+// Stop cancels the source, applies anything it already wrote, and waits
+// for it to return. It is safe to call more than once. While an app is
+// running, writes are applied on its event loop, so from an event handler
+// they land once the handler returns.
+func (b *Binding[T]) Stop() {
+	b.cancel()
+	<-b.done
+}
+
+// LoadMore asks a paged source for its next page. It does nothing if a
+// request is already waiting.
+func (b *Binding[T]) LoadMore() {
+	select {
+	case b.more <- struct{}{}:
+	default:
+	}
+}
+
+// Add appends items, or with BindOptions.Upsert replaces existing items
+// that have the same key.
+func (s *DataSink[T]) Add(items ...T) {
+	if len(items) == 0 {
+		return
+	}
+	s.binding.enqueue(dataOp[T]{kind: dataAdd, items: items})
+}
+
+// Reset replaces all items.
+func (s *DataSink[T]) Reset(items []T) {
+	s.binding.enqueue(dataOp[T]{kind: dataReset, items: append([]T(nil), items...)})
+}
+
+// RemoveWhere removes all items matching predicate.
+func (s *DataSink[T]) RemoveWhere(predicate func(T) bool) {
+	s.binding.enqueue(dataOp[T]{kind: dataRemove, remove: predicate})
+}
+
+// WaitForLoadMore applies pending writes and blocks until Binding.LoadMore
+// is called. It returns false if the binding was stopped first.
+func (s *DataSink[T]) WaitForLoadMore() bool {
+	b := s.binding
+	b.flush()
+	b.Loading.Set(false)
+	select {
+	case <-s.ctx.Done():
+		return false
+	case <-b.more:
+		b.Loading.Set(true)
+		return true
+	}
+}
+
+// enqueue records op and schedules a flush if one isn't already due.
+func (b *Binding[T]) enqueue(op dataOp[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if n := len(b.pending); op.kind == dataAdd && n > 0 && b.pending[n-1].kind == dataAdd {
+		b.pending[n-1].items = append(b.pending[n-1].items, op.items...)
+	} else {
+		if op.kind == dataAdd {
+			op.items = append([]T(nil), op.items...)
+		}
+		b.pending = append(b.pending, op)
+	}
+	if !b.scheduled {
+		b.scheduled = true
+		currentClock().AfterFunc(b.options.FlushInterval, b.flush)
+	}
+}
+
+// flush applies pending writes to the state in a single update.
+func (b *Binding[T]) flush() {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+	b.mu.Lock()
+	ops := b.pending
+	b.pending = nil
+	b.scheduled = false
+	b.mu.Unlock()
+	if len(ops) == 0 {
+		return
+	}
+	// Widgets read the state's caches while building, so the state is
+	// only changed on the event loop.
+	runOnUI(func() { b.apply(b.applyOps(ops)) })
+}
+
+// applyOps returns a function applying ops to the current items.
+func (b *Binding[T]) applyOps(ops []dataOp[T]) func(items []T) []T {
+	return func(items []T) []T {
+		next := append([]T(nil), items...)
+		for _, op := range ops {
+			switch op.kind {
+			case dataAdd:
+				next = b.add(next, op.items)
+			case dataReset:
+				next = append(next[:0], op.items...)
+			case dataRemove:
+				kept := next[:0]
+				for _, item := range next {
+					if !op.remove(item) {
+						kept = append(kept, item)
+					}
+				}
+				next = kept
+			}
+		}
+		if b.options.MaxItems > 0 && len(next) > b.options.MaxItems {
+			next = next[len(next)-b.options.MaxItems:]
+		}
+		return next
+	}
+}
+
+// add appends added to items, replacing items with the same key when
+// upserting.
+func (b *Binding[T]) add(items, added []T) []T {
+	if !b.options.Upsert || b.keyFor == nil {
+		return append(items, added...)
+	}
+	index := make(map[string]int, len(items))
+	for i, item := range items {
+		index[b.keyFor(item)] = i
+	}
+	for _, item := range added {
+		key := b.keyFor(item)
+		if i, ok := index[key]; ok {
+			items[i] = item
+			continue
+		}
+		index[key] = len(items)
+		items = append(items, item)
+	}
+	return items
+}
+
+// ChannelSource adds each value received from ch until ch is closed.
+func ChannelSource[T any](ch <-chan T) DataSource[T] {
+	return DataSourceFunc[T](func(ctx context.Context, sink *DataSink[T]) error {
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case item, ok := <-ch:
+				if !ok {
+					return nil
+				}
+				sink.Add(item)
+			}
+		}
+	})
+}
+
+// StreamSource adds each value received from a stream until it returns
+// io.EOF or an error. open is called with the binding's context and
+// returns the stream's receive function; the stream should be tied to
+// that context so that stopping the binding unblocks it, as gRPC client
+// streams and most websocket libraries are.
+func StreamSource[T any](open func(ctx context.Context) (recv func() (T, error), err error)) DataSource[T] {
+	return DataSourceFunc[T](func(ctx context.Context, sink *DataSink[T]) error {
+		recv, err := open(ctx)
+		if err != nil {
+			return err
+		}
+		for {
+			item, err := recv()
+			if err != nil {
+				return err
+			}
+			sink.Add(item)
+		}
+	})
+}
+
+// PagedSource loads pages of up to pageSize items from fetch, the first
+// immediately and each following one when Binding.LoadMore is called. A
+// page shorter than pageSize ends the source.
+func PagedSource[T any](pageSize int, fetch func(ctx context.Context, offset, limit int) ([]T, error)) DataSource[T] {
+	return DataSourceFunc[T](func(ctx context.Context, sink *DataSink[T]) error {
+		for offset := 0; ; {
+			page, err := fetch(ctx, offset, pageSize)
+			if err != nil {
+				return err
+			}
+			sink.Add(page...)
+			offset += len(page)
+			if len(page) < pageSize || !sink.WaitForLoadMore() {
+				return nil
+			}
+		}
+	})
+}
+
+// RowsSource reads pages of up to pageSize rows from rows, converting each
+// with scan: the first page immediately and each following one when
+// Binding.LoadMore is called. rows is closed when the source ends.
+//
+// Example:
+//
+//	rows, err := db.QueryContext(ctx, "SELECT id, name FROM users ORDER BY id")
+//	if err != nil {
+//		return err
+//	}
+//	binding := t.BindTable(ctx, a.users, t.RowsSource(rows, 100, func(rows *sql.Rows) (User, error) {
+//		var u User
+//		return u, rows.Scan(&u.ID, &u.Name)
+//	}), t.BindOptions{})
+func RowsSource[T any](rows *sql.Rows, pageSize int, scan func(rows *sql.Rows) (T, error)) DataSource[T] {
+	return DataSourceFunc[T](func(ctx context.Context, sink *DataSink[T]) error {
+		defer rows.Close()
+		for {
+			page := make([]T, 0, pageSize)
+			for len(page) < pageSize && rows.Next() {
+				item, err := scan(rows)
+				if err != nil {
+					return err
+				}
+				page = append(page, item)
+			}
+			sink.Add(page...)
+			if len(page) < pageSize {
+				return rows.Err()
+			}
+			if !sink.WaitForLoadMore() {
+				return nil
+			}
+		}
+	})
+}
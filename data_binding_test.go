@@ -0,0 +1,229 @@
+package terma
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fastBind = BindOptions{FlushInterval: time.Millisecond}
+
+func waitForItems[T any](t *testing.T, get func() []T, want []T) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		return fmt.Sprint(get()) == fmt.Sprint(want)
+	}, 2*time.Second, time.Millisecond, "items: %v", get())
+}
+
+func TestBindList_ChannelSource(t *testing.T) {
+	state := NewListState([]string{"existing"})
+	ch := make(chan string)
+	binding := BindList(context.Background(), state, ChannelSource(ch), fastBind)
+	defer binding.Stop()
+
+	ch <- "a"
+	ch <- "b"
+	waitForItems(t, state.GetItems, []string{"existing", "a", "b"})
+	assert.False(t, binding.Done.Peek())
+
+	close(ch)
+	assert.Eventually(t, binding.Done.Peek, time.Second, time.Millisecond)
+	assert.False(t, binding.Loading.Peek())
+	assert.NoError(t, binding.Err.Peek())
+}
+
+func TestBindList_AppliesOnEventLoop(t *testing.T) {
+	// Stand in for a running app's event loop
+	uiQueue.Lock()
+	uiQueue.notify = make(chan struct{}, 1)
+	uiQueue.Unlock()
+	t.Cleanup(func() {
+		uiQueue.Lock()
+		uiQueue.notify, uiQueue.fns = nil, nil
+		uiQueue.Unlock()
+	})
+
+	state := NewListState([]string{})
+	binding := BindList(context.Background(), state, DataSourceFunc[string](func(ctx context.Context, sink *DataSink[string]) error {
+		sink.Add("a", "b")
+		return nil
+	}), fastBind)
+	binding.Stop()
+	assert.Empty(t, state.GetItems(), "nothing changes off the event loop")
+
+	runUIQueue()
+	assert.Equal(t, []string{"a", "b"}, state.GetItems())
+}
+
+func TestBindTable_UpsertFollowsCursorAndCapsItems(t *testing.T) {
+	type quote struct {
+		Symbol string
+		Price  int
+	}
+	state := NewTableState([]quote{{"AAA", 1}, {"BBB", 2}})
+	state.KeyFor = func(q quote) string { return q.Symbol }
+	state.CursorIndex.Set(1)
+
+	ch := make(chan quote)
+	binding := BindTable(context.Background(), state, ChannelSource(ch), BindOptions{
+		Upsert: true, MaxItems: 3, FlushInterval: time.Millisecond,
+	})
+	defer binding.Stop()
+
+	ch <- quote{"BBB", 3}
+	ch <- quote{"CCC", 4}
+	waitForItems(t, state.GetRows, []quote{{"AAA", 1}, {"BBB", 3}, {"CCC", 4}})
+	assert.Equal(t, 1, state.CursorIndex.Peek())
+
+	ch <- quote{"DDD", 5}
+	waitForItems(t, state.GetRows, []quote{{"BBB", 3}, {"CCC", 4}, {"DDD", 5}})
+	assert.Equal(t, 0, state.CursorIndex.Peek(), "the cursor follows its row when the oldest is dropped")
+}
+
+func TestBindList_SinkResetAndRemove(t *testing.T) {
+	state := NewListState([]int{9})
+	binding := BindList(context.Background(), state, DataSourceFunc[int](func(ctx context.Context, sink *DataSink[int]) error {
+		sink.Reset([]int{1, 2, 3, 4})
+		sink.RemoveWhere(func(n int) bool { return n%2 == 0 })
+		sink.Add(5)
+		return nil
+	}), fastBind)
+	defer binding.Stop()
+
+	assert.Eventually(t, binding.Done.Peek, time.Second, time.Millisecond)
+	assert.Equal(t, []int{1, 3, 5}, state.GetItems())
+}
+
+func TestBindList_StreamSourceErrorAndStop(t *testing.T) {
+	state := NewListState[string](nil)
+	failure := errors.New("connection reset")
+	replies := []string{"a", "b"}
+	binding := BindList(context.Background(), state, StreamSource(func(ctx context.Context) (func() (string, error), error) {
+		return func() (string, error) {
+			if len(replies) == 0 {
+				return "", failure
+			}
+			reply := replies[0]
+			replies = replies[1:]
+			return reply, nil
+		}, nil
+	}), fastBind)
+	defer binding.Stop()
+
+	assert.Eventually(t, binding.Done.Peek, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"a", "b"}, state.GetItems())
+	assert.Equal(t, failure, binding.Err.Peek())
+	assert.Equal(t, failure, state.Err.Peek(), "the binding reports through the state")
+	assert.False(t, state.Loading.Peek())
+
+	blocked := BindList(context.Background(), state, StreamSource(func(ctx context.Context) (func() (string, error), error) {
+		return func() (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}, nil
+	}), fastBind)
+	blocked.Stop()
+	blocked.Stop()
+	assert.True(t, blocked.Done.Peek())
+	assert.NoError(t, blocked.Err.Peek(), "stopping is not an error")
+
+	eof := BindList(context.Background(), state, StreamSource(func(ctx context.Context) (func() (string, error), error) {
+		return func() (string, error) { return "", io.EOF }, nil
+	}), fastBind)
+	eof.Stop()
+	assert.NoError(t, eof.Err.Peek())
+}
+
+func TestBindTable_PagedSource(t *testing.T) {
+	state := NewTableState[int](nil)
+	binding := BindTable(context.Background(), state, PagedSource(2, func(ctx context.Context, offset, limit int) ([]int, error) {
+		var page []int
+		for i := offset; i < min(offset+limit, 5); i++ {
+			page = append(page, i)
+		}
+		return page, nil
+	}), fastBind)
+	defer binding.Stop()
+
+	waitForItems(t, state.GetRows, []int{0, 1})
+	assert.Eventually(t, func() bool { return !binding.Loading.Peek() }, time.Second, time.Millisecond)
+
+	binding.LoadMore()
+	waitForItems(t, state.GetRows, []int{0, 1, 2, 3})
+	binding.LoadMore()
+	waitForItems(t, state.GetRows, []int{0, 1, 2, 3, 4})
+	assert.Eventually(t, binding.Done.Peek, time.Second, time.Millisecond)
+}
+
+func TestBindTable_RowsSource(t *testing.T) {
+	db, err := sql.Open("terma-test", "5")
+	require.NoError(t, err)
+	defer db.Close()
+	rows, err := db.Query("SELECT n")
+	require.NoError(t, err)
+
+	state := NewTableState[int](nil)
+	binding := BindTable(context.Background(), state, RowsSource(rows, 3, func(rows *sql.Rows) (int, error) {
+		var n int
+		return n, rows.Scan(&n)
+	}), fastBind)
+	defer binding.Stop()
+
+	waitForItems(t, state.GetRows, []int{0, 1, 2})
+	binding.LoadMore()
+	waitForItems(t, state.GetRows, []int{0, 1, 2, 3, 4})
+	assert.Eventually(t, binding.Done.Peek, time.Second, time.Millisecond)
+	assert.NoError(t, binding.Err.Peek())
+}
+
+// countingDriver is a database/sql driver whose queries return a single
+// column counting from 0 to the number given as the data source name.
+type countingDriver struct{}
+
+type countingConn struct{ n int }
+
+type countingStmt struct{ n int }
+
+type countingRows struct{ next, n int }
+
+func init() {
+	sql.Register("terma-test", countingDriver{})
+}
+
+func (countingDriver) Open(name string) (driver.Conn, error) {
+	n, err := strconv.Atoi(name)
+	return &countingConn{n: n}, err
+}
+
+func (c *countingConn) Prepare(string) (driver.Stmt, error) { return &countingStmt{n: c.n}, nil }
+func (c *countingConn) Close() error                        { return nil }
+func (c *countingConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (s *countingStmt) Close() error  { return nil }
+func (s *countingStmt) NumInput() int { return 0 }
+func (s *countingStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (s *countingStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &countingRows{n: s.n}, nil
+}
+
+func (r *countingRows) Columns() []string { return []string{"n"} }
+func (r *countingRows) Close() error      { return nil }
+func (r *countingRows) Next(dest []driver.Value) error {
+	if r.next >= r.n {
+		return io.EOF
+	}
+	dest[0] = int64(r.next)
+	r.next++
+	return nil
+}
@@ -0,0 +1,321 @@
+package terma
+
+import (
+	"fmt"
+	"time"
+)
+
+// datePickerWidth is the width of the grid: seven two-cell days with a
+// cell between each.
+const datePickerWidth = 7*2 + 6
+
+// DatePickerState holds the state for a DatePicker widget.
+type DatePickerState struct {
+	Selected AnySignal[time.Time] // The chosen date at midnight (zero = none)
+	Cursor   AnySignal[time.Time] // The day under the cursor at midnight; its month is the one shown
+}
+
+// NewDatePickerState creates a new DatePickerState with selected chosen and
+// the cursor on it. A zero selected leaves nothing chosen and puts the
+// cursor on today.
+func NewDatePickerState(selected time.Time) *DatePickerState {
+	cursor := selected
+	if selected.IsZero() {
+		cursor = Now()
+	} else {
+		selected = startOfDay(selected)
+	}
+	return &DatePickerState{
+		Selected: NewAnySignal(selected),
+		Cursor:   NewAnySignal(startOfDay(cursor)),
+	}
+}
+
+// GetSelected returns the chosen date (without subscribing), and false if
+// nothing is chosen.
+func (s *DatePickerState) GetSelected() (time.Time, bool) {
+	selected := s.Selected.Peek()
+	return selected, !selected.IsZero()
+}
+
+// SetSelected chooses date and moves the cursor to it. A zero date clears
+// the choice and leaves the cursor where it is.
+func (s *DatePickerState) SetSelected(date time.Time) {
+	if date.IsZero() {
+		s.Selected.Set(time.Time{})
+		return
+	}
+	date = startOfDay(date)
+	s.Selected.Set(date)
+	s.Cursor.Set(date)
+}
+
+// SetCursor moves the cursor to date, showing its month.
+func (s *DatePickerState) SetCursor(date time.Time) {
+	s.Cursor.Set(startOfDay(date))
+}
+
+// DatePicker is a month calendar for choosing a date: a header with the
+// month and year between previous and next arrows, a row of weekday names
+// starting on the locale's first weekday, and a grid of days. Today is
+// bold, the chosen date is highlighted, and days outside Min and Max are
+// dimmed and can't be chosen.
+//
+// Enter or space (or clicking a day) chooses the day under the cursor and
+// calls OnChange.
+//
+// Example:
+//
+//	state := terma.NewDatePickerState(time.Time{})
+//	terma.DatePicker{
+//	    ID:       "due",
+//	    State:    state,
+//	    Min:      terma.Now(),
+//	    OnChange: func(date time.Time) { a.setDueDate(date) },
+//	}
+//
+// Keys: left/right (h/l) move a day, up/down (k/j) move a week, pgup/pgdown
+// move a month, shift+pgup/shift+pgdown move a year, home/end move to the
+// first/last day of the month, t jumps to today.
+type DatePicker struct {
+	ID           string               // Optional unique identifier
+	DisableFocus bool                 // If true, prevent keyboard focus
+	State        *DatePickerState     // Required - holds the chosen date and the cursor
+	Min          time.Time            // Optional earliest date that can be chosen (zero = no limit)
+	Max          time.Time            // Optional latest date that can be chosen (zero = no limit)
+	OnChange     func(date time.Time) // Called when a date is chosen
+	Style        Style                // Optional styling
+}
+
+// WidgetID returns the widget's unique identifier.
+// Implements the Identifiable interface.
+func (d DatePicker) WidgetID() string {
+	return d.ID
+}
+
+// GetContentDimensions returns the width and height dimension preferences.
+// Implements the Dimensioned interface.
+func (d DatePicker) GetContentDimensions() (width, height Dimension) {
+	dims := d.Style.GetDimensions()
+	return dims.Width, dims.Height
+}
+
+// GetStyle returns the style of the date picker.
+// Implements the Styled interface.
+func (d DatePicker) GetStyle() Style {
+	return d.Style
+}
+
+// IsFocusable returns true to allow keyboard navigation.
+// Implements the Focusable interface.
+func (d DatePicker) IsFocusable() bool {
+	return !d.DisableFocus
+}
+
+// OnKey handles keys not covered by declarative keybindings.
+// Implements the Focusable interface.
+func (d DatePicker) OnKey(event KeyEvent) bool {
+	return false
+}
+
+// Keybinds returns the declarative keybindings for this date picker.
+func (d DatePicker) Keybinds() []Keybind {
+	if d.State == nil {
+		return nil
+	}
+	return []Keybind{
+		{Key: "enter", Action: d.choose, Hidden: true},
+		{Key: "space", Action: d.choose, Hidden: true},
+		{Key: "left", Action: func() { d.moveDays(-1) }, Hidden: true},
+		{Key: "h", Action: func() { d.moveDays(-1) }, Hidden: true},
+		{Key: "right", Action: func() { d.moveDays(1) }, Hidden: true},
+		{Key: "l", Action: func() { d.moveDays(1) }, Hidden: true},
+		{Key: "up", Action: func() { d.moveDays(-7) }, Hidden: true},
+		{Key: "k", Action: func() { d.moveDays(-7) }, Hidden: true},
+		{Key: "down", Action: func() { d.moveDays(7) }, Hidden: true},
+		{Key: "j", Action: func() { d.moveDays(7) }, Hidden: true},
+		{Key: "pgup", Action: func() { d.moveMonths(-1) }, Hidden: true},
+		{Key: "pgdown", Action: func() { d.moveMonths(1) }, Hidden: true},
+		{Key: "shift+pgup", Action: func() { d.moveMonths(-12) }, Hidden: true},
+		{Key: "shift+pgdown", Action: func() { d.moveMonths(12) }, Hidden: true},
+		{Key: "home", Action: d.moveToMonthStart, Hidden: true},
+		{Key: "end", Action: d.moveToMonthEnd, Hidden: true},
+		{Key: "t", Action: d.jumpToToday, Hidden: true},
+	}
+}
+
+// Build returns the month header above the weekday names and the grid of
+// days, six weeks tall so the picker keeps its size from month to month.
+func (d DatePicker) Build(ctx BuildContext) Widget {
+	if d.State == nil {
+		return Column{}
+	}
+	theme := ctx.Theme()
+	locale := ctx.Locale()
+	focused := ctx.IsFocused(d)
+
+	cursor := d.State.Cursor.Get()
+	selected := d.State.Selected.Get()
+	clockSignal(time.Minute).Get()
+	today := Now()
+
+	monthStart := time.Date(cursor.Year(), cursor.Month(), 1, 0, 0, 0, 0, cursor.Location())
+	back := (int(monthStart.Weekday()) - int(locale.FirstWeekday) + 7) % 7
+	first := monthStart.AddDate(0, 0, -back)
+
+	arrowStyle := Style{ForegroundColor: theme.TextMuted, HoverStyle: &Style{ForegroundColor: theme.Text}}
+	header := Row{
+		Style: Style{Width: Cells(datePickerWidth)},
+		Children: []Widget{
+			Text{Content: "‹", Style: arrowStyle, Click: func(MouseEvent) { d.moveMonths(-1) }},
+			Text{
+				Content:   fmt.Sprintf("%s %d", locale.MonthName(cursor.Month()), cursor.Year()),
+				TextAlign: TextAlignCenter,
+				Style:     Style{ForegroundColor: theme.Text, Bold: true, Width: Flex(1)},
+			},
+			Text{Content: "›", Style: arrowStyle, Click: func(MouseEvent) { d.moveMonths(1) }},
+		},
+	}
+
+	weekdays := make([]Widget, 7)
+	for i := range weekdays {
+		weekday := time.Weekday((int(locale.FirstWeekday) + i) % 7)
+		weekdays[i] = Text{
+			Content:   datePickerWeekdayLabel(locale.WeekdayAbbrevs[weekday]),
+			TextAlign: TextAlignRight,
+			Style:     Style{ForegroundColor: theme.TextMuted, Width: Cells(2)},
+		}
+	}
+
+	rows := []Widget{header, Row{Spacing: 1, Children: weekdays}}
+	for week := 0; week < 6; week++ {
+		days := make([]Widget, 7)
+		for i := range days {
+			day := first.AddDate(0, 0, week*7+i)
+			days[i] = d.buildDay(theme, day, day.Month() == cursor.Month(), sameDay(day, today), sameDay(day, selected), sameDay(day, cursor), focused)
+		}
+		rows = append(rows, Row{Spacing: 1, Children: days})
+	}
+
+	return Column{ID: d.ID, Style: d.Style, Children: rows}
+}
+
+// buildDay renders one day of the grid.
+func (d DatePicker) buildDay(theme ThemeData, day time.Time, inMonth, today, selected, cursor, focused bool) Widget {
+	style := Style{ForegroundColor: theme.Text, Width: Cells(2)}
+	if !inMonth {
+		style.ForegroundColor = theme.TextMuted
+	}
+	if today {
+		style.Bold = true
+		style.ForegroundColor = theme.Primary
+	}
+	allowed := d.allowed(day)
+	if !allowed {
+		style.ForegroundColor = theme.TextDisabled
+	}
+	if selected {
+		style.BackgroundColor = theme.Primary
+		style.ForegroundColor = theme.TextOnPrimary
+	}
+	if cursor {
+		style.BackgroundColor = theme.Selection
+		if focused {
+			style.BackgroundColor = theme.ActiveCursor
+			style.ForegroundColor = theme.SelectionText
+		}
+	}
+
+	text := Text{Content: fmt.Sprintf("%2d", day.Day()), Style: style}
+	if allowed {
+		text.Click = func(MouseEvent) { d.clickDay(day) }
+	}
+	return text
+}
+
+// datePickerWeekdayLabel shortens a weekday abbreviation to the two cells
+// of a day column.
+func datePickerWeekdayLabel(abbrev string) string {
+	runes := []rune(abbrev)
+	if len(runes) > 2 {
+		runes = runes[:2]
+	}
+	return string(runes)
+}
+
+// allowed reports whether day is within Min and Max.
+func (d DatePicker) allowed(day time.Time) bool {
+	if !d.Min.IsZero() && day.Before(startOfDay(d.Min)) {
+		return false
+	}
+	if !d.Max.IsZero() && day.After(startOfDay(d.Max)) {
+		return false
+	}
+	return true
+}
+
+// clamp moves day into the range between Min and Max.
+func (d DatePicker) clamp(day time.Time) time.Time {
+	if !d.Min.IsZero() && day.Before(startOfDay(d.Min)) {
+		return startOfDay(d.Min)
+	}
+	if !d.Max.IsZero() && day.After(startOfDay(d.Max)) {
+		return startOfDay(d.Max)
+	}
+	return day
+}
+
+func (d DatePicker) moveDays(delta int) {
+	d.State.SetCursor(d.clamp(d.State.Cursor.Peek().AddDate(0, 0, delta)))
+}
+
+// moveMonths moves the cursor by delta months, keeping its day of the
+// month where the target month has it and using the month's last day
+// otherwise, so January 31st moves to February 28th rather than March.
+func (d DatePicker) moveMonths(delta int) {
+	cursor := d.State.Cursor.Peek()
+	target := time.Date(cursor.Year(), cursor.Month()+time.Month(delta), 1, 0, 0, 0, 0, cursor.Location())
+	day := min(cursor.Day(), daysIn(target))
+	d.State.SetCursor(d.clamp(target.AddDate(0, 0, day-1)))
+}
+
+func (d DatePicker) moveToMonthStart() {
+	cursor := d.State.Cursor.Peek()
+	d.State.SetCursor(d.clamp(cursor.AddDate(0, 0, 1-cursor.Day())))
+}
+
+func (d DatePicker) moveToMonthEnd() {
+	cursor := d.State.Cursor.Peek()
+	d.State.SetCursor(d.clamp(cursor.AddDate(0, 0, daysIn(cursor)-cursor.Day())))
+}
+
+func (d DatePicker) jumpToToday() {
+	d.State.SetCursor(d.clamp(Now()))
+}
+
+// choose selects the day under the cursor if it is allowed.
+func (d DatePicker) choose() {
+	day := d.State.Cursor.Peek()
+	if !d.allowed(day) {
+		return
+	}
+	d.State.SetSelected(day)
+	if d.OnChange != nil {
+		d.OnChange(day)
+	}
+}
+
+func (d DatePicker) clickDay(day time.Time) {
+	d.State.SetCursor(day)
+	d.choose()
+}
+
+// startOfDay returns midnight at the start of t's day.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// daysIn returns the number of days in t's month.
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+}
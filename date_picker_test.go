@@ -0,0 +1,78 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func datePickerTestDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDatePicker_Navigation(t *testing.T) {
+	state := NewDatePickerState(datePickerTestDate(2026, 1, 31))
+	picker := DatePicker{State: state}
+
+	picker.moveMonths(1)
+	assert.Equal(t, datePickerTestDate(2026, 2, 28), state.Cursor.Peek(), "January 31st moves to the end of February")
+	picker.moveDays(1)
+	assert.Equal(t, datePickerTestDate(2026, 3, 1), state.Cursor.Peek())
+	picker.moveDays(7)
+	assert.Equal(t, datePickerTestDate(2026, 3, 8), state.Cursor.Peek())
+	picker.moveToMonthEnd()
+	assert.Equal(t, datePickerTestDate(2026, 3, 31), state.Cursor.Peek())
+	picker.moveToMonthStart()
+	assert.Equal(t, datePickerTestDate(2026, 3, 1), state.Cursor.Peek())
+	picker.moveMonths(-12)
+	assert.Equal(t, datePickerTestDate(2025, 3, 1), state.Cursor.Peek())
+
+	selected, ok := state.GetSelected()
+	assert.True(t, ok)
+	assert.Equal(t, datePickerTestDate(2026, 1, 31), selected, "moving the cursor doesn't change the chosen date")
+}
+
+func TestDatePicker_MinMax(t *testing.T) {
+	state := NewDatePickerState(time.Time{})
+	state.SetCursor(datePickerTestDate(2026, 3, 10))
+	var changes []time.Time
+	picker := DatePicker{
+		State:    state,
+		Min:      datePickerTestDate(2026, 3, 5).Add(15 * time.Hour),
+		Max:      datePickerTestDate(2026, 3, 20),
+		OnChange: func(date time.Time) { changes = append(changes, date) },
+	}
+
+	picker.moveDays(-7)
+	assert.Equal(t, datePickerTestDate(2026, 3, 5), state.Cursor.Peek(), "the cursor stops at Min")
+	picker.moveMonths(1)
+	assert.Equal(t, datePickerTestDate(2026, 3, 20), state.Cursor.Peek(), "the cursor stops at Max")
+	picker.choose()
+
+	state.SetCursor(datePickerTestDate(2026, 3, 21))
+	picker.choose()
+
+	assert.Equal(t, []time.Time{datePickerTestDate(2026, 3, 20)}, changes, "days after Max can't be chosen")
+	selected, _ := state.GetSelected()
+	assert.Equal(t, datePickerTestDate(2026, 3, 20), selected)
+}
+
+func TestDatePicker_Renders(t *testing.T) {
+	SetLocale("en-GB")
+	t.Cleanup(func() { SetLocale("en") })
+	state := NewDatePickerState(datePickerTestDate(2026, 3, 4))
+
+	lines := strings.Split(screenText(DatePicker{State: state}, 20, 8), "\n")
+	assert.Equal(t, []string{
+		"‹    March 2026    ›",
+		"Mo Tu We Th Fr Sa Su",
+		"23 24 25 26 27 28  1",
+		" 2  3  4  5  6  7  8",
+		" 9 10 11 12 13 14 15",
+		"16 17 18 19 20 21 22",
+		"23 24 25 26 27 28 29",
+		"30 31  1  2  3  4  5",
+	}, lines, "weeks start on the locale's first weekday")
+}
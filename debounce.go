@@ -0,0 +1,156 @@
+package terma
+
+import (
+	"sync"
+	"time"
+)
+
+// Debounce returns a signal that takes source's value once source has stopped
+// changing for wait. Use it to drive expensive work, such as filtering, from
+// a signal that changes on every keystroke.
+//
+// Like NewSignal, create it once and keep it; don't call Debounce in Build.
+//
+// Example:
+//
+//	query := t.NewSignal("")
+//	settledQuery := t.Debounce(query, 150*time.Millisecond)
+func Debounce[T comparable](source Signal[T], wait time.Duration) Signal[T] {
+	out := NewSignal(source.Peek())
+	set := DebounceFunc(wait, out.Set)
+	source.watch(func() { set(source.Peek()) })
+	return out
+}
+
+// Throttle returns a signal that follows source at most once per interval.
+// The first change passes through immediately; later changes within the
+// interval are collapsed into one update with the latest value at its end.
+//
+// Like NewSignal, create it once and keep it; don't call Throttle in Build.
+func Throttle[T comparable](source Signal[T], interval time.Duration) Signal[T] {
+	out := NewSignal(source.Peek())
+	set := ThrottleFunc(interval, out.Set)
+	source.watch(func() { set(source.Peek()) })
+	return out
+}
+
+// DebounceFunc returns a function that calls fn with its latest argument
+// once it has not been called for wait.
+//
+// fn runs on a timer goroutine, so it should only touch thread-safe state
+// such as signals. A panic in fn quits the app like one in Go.
+//
+// Example:
+//
+//	search := t.DebounceFunc(200*time.Millisecond, func(query string) {
+//	    results.Set(runSearch(query))
+//	})
+func DebounceFunc[T any](wait time.Duration, fn func(T)) func(T) {
+	d := &debouncer[T]{}
+	return func(value T) {
+		d.call(wait, value, fn)
+	}
+}
+
+// ThrottleFunc returns a function that calls fn at most once per interval.
+// The first call runs immediately; calls during the interval are collapsed
+// into one trailing call with the latest argument.
+//
+// A trailing call runs on a timer goroutine, so fn should only touch
+// thread-safe state such as signals.
+func ThrottleFunc[T any](interval time.Duration, fn func(T)) func(T) {
+	var (
+		mu       sync.Mutex
+		window   Timer
+		pending  bool
+		latest   T
+		trailing func()
+	)
+	trailing = func() {
+		mu.Lock()
+		if !pending {
+			window = nil
+			mu.Unlock()
+			return
+		}
+		pending = false
+		value := latest
+		window = currentClock().AfterFunc(interval, trailing)
+		mu.Unlock()
+		runGuarded(func() { fn(value) })
+	}
+	return func(value T) {
+		mu.Lock()
+		latest = value
+		if window != nil {
+			pending = true
+			mu.Unlock()
+			return
+		}
+		window = currentClock().AfterFunc(interval, trailing)
+		mu.Unlock()
+		fn(value)
+	}
+}
+
+// debouncer delays a callback until calls stop for a while.
+// The zero value is ready to use.
+type debouncer[T any] struct {
+	mu         sync.Mutex
+	timer      Timer
+	generation int
+	pending    func()
+}
+
+// call schedules fn(value) after wait, replacing any pending call.
+func (d *debouncer[T]) call(wait time.Duration, value T, fn func(T)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.generation++
+	generation := d.generation
+	d.pending = func() { fn(value) }
+	d.timer = currentClock().AfterFunc(wait, func() {
+		// A timer that fired while being replaced must not run stale work.
+		if run := d.take(generation); run != nil {
+			runGuarded(run)
+		}
+	})
+}
+
+// flush runs the pending call now, if any.
+func (d *debouncer[T]) flush() {
+	d.mu.Lock()
+	generation := d.generation
+	d.mu.Unlock()
+	if run := d.take(generation); run != nil {
+		run()
+	}
+}
+
+// cancel drops the pending call, if any.
+func (d *debouncer[T]) cancel() {
+	d.mu.Lock()
+	generation := d.generation
+	d.mu.Unlock()
+	d.take(generation)
+}
+
+// take claims the pending call if it belongs to generation.
+func (d *debouncer[T]) take(generation int) func() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if generation != d.generation || d.pending == nil {
+		return nil
+	}
+	run := d.pending
+	d.pending = nil
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	return run
+}
@@ -0,0 +1,89 @@
+package terma
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebounce_SettlesAfterQuietPeriod(t *testing.T) {
+	clock := withManualClock(t)
+	query := NewSignal("")
+	settled := Debounce(query, 100*time.Millisecond)
+
+	query.Set("a")
+	clock.Advance(60 * time.Millisecond)
+	query.Set("ab")
+	clock.Advance(60 * time.Millisecond)
+	assert.Equal(t, "", settled.Peek(), "still typing")
+
+	clock.Advance(40 * time.Millisecond)
+	assert.Equal(t, "ab", settled.Peek())
+}
+
+func TestThrottle_LeadingAndTrailing(t *testing.T) {
+	clock := withManualClock(t)
+	source := NewSignal(0)
+	throttled := Throttle(source, 100*time.Millisecond)
+
+	source.Set(1)
+	assert.Equal(t, 1, throttled.Peek(), "first change passes immediately")
+
+	source.Set(2)
+	source.Set(3)
+	assert.Equal(t, 1, throttled.Peek())
+
+	clock.Advance(100 * time.Millisecond)
+	assert.Equal(t, 3, throttled.Peek(), "trailing update carries the latest value")
+
+	clock.Advance(100 * time.Millisecond)
+	source.Set(4)
+	assert.Equal(t, 4, throttled.Peek(), "idle again after a quiet interval")
+}
+
+func TestDebounceFunc_CallsOnceWithLatest(t *testing.T) {
+	clock := withManualClock(t)
+	var calls []string
+	search := DebounceFunc(50*time.Millisecond, func(query string) { calls = append(calls, query) })
+
+	search("c")
+	search("ca")
+	search("cat")
+	clock.Advance(time.Second)
+	assert.Equal(t, []string{"cat"}, calls)
+}
+
+func TestTextInput_OnChangeDebounced(t *testing.T) {
+	clock := withManualClock(t)
+	state := NewTextInputState("")
+	var changes, submits []string
+	input := TextInput{
+		State:             state,
+		OnChangeDebounced: func(text string) { changes = append(changes, text) },
+		OnSubmit:          func(text string) { submits = append(submits, text) },
+	}
+
+	for _, r := range "go" {
+		state.Insert(string(r))
+		input.notifyChange()
+		clock.Advance(50 * time.Millisecond)
+	}
+	assert.Empty(t, changes)
+	clock.Advance(200 * time.Millisecond)
+	assert.Equal(t, []string{"go"}, changes)
+
+	state.Insert("!")
+	input.notifyChange()
+	input.submit()
+	assert.Equal(t, []string{"go", "go!"}, changes, "Enter flushes the pending change")
+	assert.Equal(t, []string{"go!"}, submits)
+	clock.Advance(time.Second)
+	assert.Len(t, changes, 2)
+
+	state.Insert("?")
+	input.notifyChange()
+	state.SetText("")
+	clock.Advance(time.Second)
+	assert.Len(t, changes, 2, "SetText discards the pending change")
+}
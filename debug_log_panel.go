@@ -0,0 +1,171 @@
+package terma
+
+import (
+	"fmt"
+	"time"
+)
+
+// debugLogPanelRefresh is how often a visible DebugLogPanel picks up new entries.
+const debugLogPanelRefresh = 250 * time.Millisecond
+
+// DebugLogPanelState holds the visibility and level filter of a DebugLogPanel.
+type DebugLogPanelState struct {
+	Visible  Signal[bool]
+	MinLevel Signal[LogLevel]
+	scroll   *ScrollState
+}
+
+// NewDebugLogPanelState creates a hidden panel state showing all levels.
+func NewDebugLogPanelState() *DebugLogPanelState {
+	scroll := NewScrollState()
+	scroll.PinToBottom = true
+	return &DebugLogPanelState{
+		Visible:  NewSignal(false),
+		MinLevel: NewSignal(LevelDebug),
+		scroll:   scroll,
+	}
+}
+
+// Toggle shows or hides the panel.
+func (s *DebugLogPanelState) Toggle() {
+	s.Visible.Update(func(visible bool) bool { return !visible })
+}
+
+// CycleLevel raises the minimum level shown, wrapping from Error back to Debug.
+func (s *DebugLogPanelState) CycleLevel() {
+	s.MinLevel.Update(func(level LogLevel) LogLevel {
+		if level >= LevelError {
+			return LevelDebug
+		}
+		return level + 1
+	})
+}
+
+// DebugLogPanel wraps an app's UI and docks a panel of recent log entries
+// (see RecentLogs) below it. The toggle key works from anywhere inside
+// Child. Click a level in the panel header, or press the level key, to
+// filter out less severe entries.
+//
+// Example:
+//
+//	func (a *App) Build(ctx t.BuildContext) t.Widget {
+//	    return t.DebugLogPanel{State: a.logs, Child: a.content(ctx)}
+//	}
+type DebugLogPanel struct {
+	ID        string              // Optional unique identifier
+	State     *DebugLogPanelState // Required
+	Child     Widget              // The app content
+	ToggleKey string              // Shows/hides the panel (default = "f12")
+	LevelKey  string              // Cycles the level filter (default = "shift+f12")
+	Height    Dimension           // Panel height (default = Cells(10))
+	Style     Style               // Optional styling for the panel
+}
+
+// WidgetID returns the widget's unique identifier.
+func (p DebugLogPanel) WidgetID() string {
+	return p.ID
+}
+
+// Keybinds returns the toggle and level filter bindings.
+func (p DebugLogPanel) Keybinds() []Keybind {
+	if p.State == nil {
+		return nil
+	}
+	toggleKey := p.ToggleKey
+	if toggleKey == "" {
+		toggleKey = "f12"
+	}
+	levelKey := p.LevelKey
+	if levelKey == "" {
+		levelKey = "shift+f12"
+	}
+	return []Keybind{
+		{Key: toggleKey, Name: "Logs", Action: p.State.Toggle},
+		{Key: levelKey, Name: "Log level", Action: p.State.CycleLevel, Hidden: !p.State.Visible.Peek()},
+	}
+}
+
+// Build returns Child with the log panel docked below it while visible.
+func (p DebugLogPanel) Build(ctx BuildContext) Widget {
+	child := p.Child
+	if child == nil {
+		child = EmptyWidget{}
+	}
+	if p.State == nil || !p.State.Visible.Get() {
+		return Dock{Body: child}
+	}
+
+	clockSignal(debugLogPanelRefresh).Get()
+	theme := ctx.Theme()
+	minLevel := p.State.MinLevel.Get()
+
+	height := p.Height
+	if height.IsUnset() {
+		height = Cells(10)
+	}
+	style := p.Style
+	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
+		style.BackgroundColor = theme.Surface
+	}
+	if style.Border.IsZero() {
+		style.Border = Border{Style: BorderSquare, Color: theme.Border}
+	}
+	style.Height = height
+
+	header := []Widget{Text{Content: "Logs", Style: Style{Bold: true}}}
+	for level := LevelDebug; level <= LevelError; level++ {
+		chip := Style{ForegroundColor: debugLogLevelColor(theme, level), Padding: EdgeInsetsXY(1, 0)}
+		if level == minLevel {
+			chip.Reverse = true
+		}
+		header = append(header, Text{
+			Content: level.String(),
+			Style:   chip,
+			Click:   func(MouseEvent) { p.State.MinLevel.Set(level) },
+		})
+	}
+
+	var spans []Span
+	for i, entry := range RecentLogs(minLevel) {
+		if i > 0 {
+			spans = append(spans, PlainSpan("\n"))
+		}
+		spans = append(spans,
+			ColorSpan(entry.Time.Format("15:04:05.000")+" ", theme.TextMuted),
+			StyledSpan(fmt.Sprintf("%-5s ", entry.Level), SpanStyle{Foreground: debugLogLevelColor(theme, entry.Level), Bold: true}),
+			PlainSpan(entry.Message),
+		)
+		if len(entry.Fields) > 0 {
+			spans = append(spans, ColorSpan(" "+entry.FieldsString(), theme.TextMuted))
+		}
+	}
+	if len(spans) == 0 {
+		spans = []Span{ColorSpan("No log entries", theme.TextMuted)}
+	}
+
+	panel := Column{
+		Style: style,
+		Children: []Widget{
+			Row{Spacing: 1, Children: header},
+			Scrollable{
+				State: p.State.scroll,
+				Style: Style{Height: Flex(1)},
+				Child: Text{Spans: spans, Style: Style{Width: Flex(1)}},
+			},
+		},
+	}
+	return Dock{Body: child, Bottom: []Widget{panel}}
+}
+
+// debugLogLevelColor returns the colour used for a level's label.
+func debugLogLevelColor(theme ThemeData, level LogLevel) Color {
+	switch level {
+	case LevelInfo:
+		return theme.Info
+	case LevelWarn:
+		return theme.Warning
+	case LevelError:
+		return theme.Error
+	}
+	return theme.TextMuted
+}
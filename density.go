@@ -0,0 +1,65 @@
+package terma
+
+// Density is how tightly the built-in widgets pack their content: the
+// padding of dialogs, tabs, menus and palettes, the height of list rows,
+// and the size of buttons.
+type Density int
+
+const (
+	// DensityNormal is the default spacing.
+	DensityNormal Density = iota
+	// DensityCompact trims padding to fit more on screen, for power users.
+	DensityCompact
+	// DensityComfortable adds room around content for readability.
+	DensityComfortable
+)
+
+// activeDensity is the signal holding the current density, so widgets
+// rebuild when it changes.
+var activeDensity = NewSignal(DensityNormal)
+
+// SetDensity sets how tightly the built-in widgets pack their content.
+// Widgets rebuild with the new spacing. Custom widgets can read the
+// density with BuildContext.Density to follow suit.
+//
+// Example:
+//
+//	t.SetDensity(t.DensityCompact)
+//	t.Run(app)
+func SetDensity(d Density) {
+	activeDensity.Set(d)
+}
+
+// getDensity returns the current density. During a build this subscribes
+// the widget to density changes.
+func getDensity() Density {
+	return activeDensity.Get()
+}
+
+// Pick returns the value for this density: compact, normal or comfortable.
+//
+// Example:
+//
+//	padding := t.EdgeInsetsXY(ctx.Density().Pick(1, 2, 3), 0)
+func (d Density) Pick(compact, normal, comfortable int) int {
+	switch d {
+	case DensityCompact:
+		return compact
+	case DensityComfortable:
+		return comfortable
+	default:
+		return normal
+	}
+}
+
+// String returns the density's name.
+func (d Density) String() string {
+	switch d {
+	case DensityCompact:
+		return "compact"
+	case DensityComfortable:
+		return "comfortable"
+	default:
+		return "normal"
+	}
+}
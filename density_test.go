@@ -0,0 +1,80 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// useDensity sets the density for the duration of a test.
+func useDensity(t *testing.T, d Density) {
+	t.Helper()
+	SetDensity(d)
+	t.Cleanup(func() { SetDensity(DensityNormal) })
+}
+
+func TestDensity_Pick(t *testing.T) {
+	assert.Equal(t, 1, DensityCompact.Pick(1, 2, 3))
+	assert.Equal(t, 2, DensityNormal.Pick(1, 2, 3))
+	assert.Equal(t, 3, DensityComfortable.Pick(1, 2, 3))
+}
+
+func TestDensity_String(t *testing.T) {
+	assert.Equal(t, "compact", DensityCompact.String())
+	assert.Equal(t, "normal", DensityNormal.String())
+	assert.Equal(t, "comfortable", DensityComfortable.String())
+}
+
+func TestBuildContext_Density(t *testing.T) {
+	ctx := newTestBuildContext()
+	assert.Equal(t, DensityNormal, ctx.Density())
+
+	useDensity(t, DensityCompact)
+	assert.Equal(t, DensityCompact, ctx.Density())
+}
+
+func TestDensity_Button(t *testing.T) {
+	button := Button{ID: "ok", Label: "OK"}
+
+	buf := renderToBufferWithFocus(button, 10, 1, "")
+	assert.Equal(t, "[OK]", strings.TrimRight(bufferLine(buf, 0, 10), " "))
+
+	useDensity(t, DensityComfortable)
+	buf = renderToBufferWithFocus(button, 10, 1, "")
+	assert.Equal(t, "[ OK ]", strings.TrimRight(bufferLine(buf, 0, 10), " "))
+}
+
+func TestDensity_ListRows(t *testing.T) {
+	list := func() List[string] {
+		return List[string]{ID: "list", State: NewListState([]string{"one", "two"})}
+	}
+
+	buf := renderToBufferWithFocus(list(), 10, 4, "")
+	assert.Equal(t, "one", strings.TrimRight(bufferLine(buf, 0, 10), " "))
+	assert.Equal(t, "two", strings.TrimRight(bufferLine(buf, 1, 10), " "))
+
+	useDensity(t, DensityComfortable)
+	buf = renderToBufferWithFocus(list(), 10, 4, "")
+	assert.Equal(t, " one", strings.TrimRight(bufferLine(buf, 0, 10), " "))
+	assert.Equal(t, "", strings.TrimRight(bufferLine(buf, 1, 10), " "))
+	assert.Equal(t, " two", strings.TrimRight(bufferLine(buf, 2, 10), " "))
+}
+
+func TestDensity_MenuPadding(t *testing.T) {
+	items := []MenuItem{{Label: "Open"}}
+	assert.Equal(t, 1, computeMenuItemLayout(items, Auto).paddingX)
+
+	useDensity(t, DensityCompact)
+	assert.Equal(t, 0, computeMenuItemLayout(items, Auto).paddingX)
+
+	useDensity(t, DensityComfortable)
+	assert.Equal(t, 2, computeMenuItemLayout(items, Auto).paddingX)
+}
+
+func TestDensity_CommandPaletteInputPadding(t *testing.T) {
+	assert.Equal(t, EdgeInsetsTRBL(1, 1, 1, 1), commandPaletteInputPadding())
+
+	useDensity(t, DensityCompact)
+	assert.Equal(t, EdgeInsetsXY(1, 0), commandPaletteInputPadding())
+}
@@ -88,13 +88,14 @@ func (d Dialog) Build(ctx BuildContext) Widget {
 	// Apply default style
 	style := d.Style
 	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
-		style.BackgroundColor = theme.Surface
+		style.BackgroundColor = theme.Token(TokenSurfaceFloating)
 	}
 	if style.ForegroundColor == nil || !style.ForegroundColor.IsSet() {
 		style.ForegroundColor = theme.Text
 	}
 	if style.Padding == (EdgeInsets{}) {
-		style.Padding = EdgeInsetsXY(2, 1)
+		density := ctx.Density()
+		style.Padding = EdgeInsetsXY(density.Pick(1, 2, 3), density.Pick(0, 1, 1))
 	}
 	if style.Border.IsZero() {
 		decorations := []BorderDecoration{}
@@ -29,6 +29,8 @@ type DirectoryTree struct {
 	IncludeHidden bool
 	// Sort controls entry ordering; default sorts directories first by name.
 	Sort func([]DirectoryEntry)
+	// Watcher, if set, highlights entries it has recently seen change.
+	Watcher *DirectoryWatcher
 }
 
 // NewDirectoryTreeState creates a TreeState for a single root path.
@@ -120,6 +122,9 @@ func (d DirectoryTree) defaultRenderNode(ctx BuildContext, tree Tree[DirectoryEn
 	return func(entry DirectoryEntry, nodeCtx TreeNodeContext, match MatchResult) Widget {
 		style := directoryTreeNodeStyle(theme, nodeCtx, widgetFocused, entry.Err != nil)
 		style.Width = Flex(1)
+		if kind, ok := d.Watcher.RecentChange(entry.Path); ok && !(nodeCtx.Active && widgetFocused) && entry.Err == nil {
+			style.ForegroundColor = directoryChangeColor(theme, kind)
+		}
 
 		label := entry.Name
 		if label == "" {
@@ -172,17 +177,25 @@ func directoryTreeNodeStyle(theme ThemeData, nodeCtx TreeNodeContext, widgetFocu
 	return style
 }
 
+// directoryChangeColor is the highlight color for a recently changed entry.
+func directoryChangeColor(theme ThemeData, kind DirectoryChangeKind) Color {
+	if kind == DirectoryModified {
+		return theme.Warning
+	}
+	return theme.Success
+}
+
 func (d DirectoryTree) loadChildren(entry DirectoryEntry, setChildren func([]TreeNode[DirectoryEntry])) {
-	go func(entry DirectoryEntry) {
+	Go(func() {
 		setChildren(d.readChildren(entry))
-	}(entry)
+	})
 }
 
 func (d DirectoryTree) eagerLoad(state *TreeState[DirectoryEntry]) {
 	if state == nil {
 		return
 	}
-	go func() {
+	Go(func() {
 		roots := state.Nodes.Peek()
 		if len(roots) == 0 {
 			return
@@ -218,7 +231,7 @@ func (d DirectoryTree) eagerLoad(state *TreeState[DirectoryEntry]) {
 				})
 			}
 		}
-	}()
+	})
 }
 
 func (d DirectoryTree) readChildren(entry DirectoryEntry) []TreeNode[DirectoryEntry] {
@@ -0,0 +1,413 @@
+package terma
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DirectoryChangeKind identifies what happened to a filesystem entry.
+type DirectoryChangeKind int
+
+const (
+	// DirectoryCreated means the entry appeared.
+	DirectoryCreated DirectoryChangeKind = iota
+	// DirectoryRemoved means the entry disappeared.
+	DirectoryRemoved
+	// DirectoryRenamed means the entry moved to Path from OldPath within
+	// the same directory.
+	DirectoryRenamed
+	// DirectoryModified means a file's size or modification time changed.
+	DirectoryModified
+)
+
+// DirectoryChange describes one change found by a DirectoryWatcher.
+type DirectoryChange struct {
+	Kind    DirectoryChangeKind
+	Path    string
+	OldPath string // Previous path for DirectoryRenamed
+}
+
+// DirectoryWatchOptions configures DirectoryTree.Watch.
+type DirectoryWatchOptions struct {
+	Interval  time.Duration                   // How often loaded directories are rescanned (default 500ms)
+	Debounce  time.Duration                   // Quiet period required before changes are applied (default 100ms)
+	Highlight time.Duration                   // How long changed entries stay highlighted (default 2s; negative disables)
+	OnChange  func(changes []DirectoryChange) // Optional callback with each applied batch; runs off the UI loop
+}
+
+// DirectoryWatcher keeps a DirectoryTree's state in sync with the
+// filesystem. It polls the directories that have been loaded into the
+// tree, so it needs no platform support and never loads directories the
+// user hasn't opened. Changes are applied in one batch once the disk has
+// been quiet for the debounce period, so a burst such as a git checkout
+// produces a single update.
+//
+// Expanded, collapsed and cursor state follow entries by path, including
+// across renames. Pass the watcher to DirectoryTree.Watcher to highlight
+// recently changed entries.
+type DirectoryWatcher struct {
+	tree    DirectoryTree
+	state   *TreeState[DirectoryEntry]
+	options DirectoryWatchOptions
+
+	recent AnySignal[map[string]directoryRecentChange]
+	infos  map[string]os.FileInfo // Last applied file info per path; watcher goroutine only
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// directoryRecentChange is a highlighted change and when it expires.
+type directoryRecentChange struct {
+	kind  DirectoryChangeKind
+	until time.Time
+}
+
+// Watch starts watching the tree's loaded directories for changes and
+// returns the running watcher. Call Stop to end it.
+//
+// Example:
+//
+//	state := terma.NewDirectoryTreeState(".")
+//	tree := terma.DirectoryTree{Tree: terma.Tree[terma.DirectoryEntry]{ID: "files", State: state}}
+//	watcher := tree.Watch(terma.DirectoryWatchOptions{})
+//	defer watcher.Stop()
+//
+//	// In Build:
+//	tree.Watcher = watcher
+func (d DirectoryTree) Watch(options DirectoryWatchOptions) *DirectoryWatcher {
+	w := newDirectoryWatcher(d, options)
+	Go(w.run)
+	return w
+}
+
+func newDirectoryWatcher(d DirectoryTree, options DirectoryWatchOptions) *DirectoryWatcher {
+	if options.Interval <= 0 {
+		options.Interval = 500 * time.Millisecond
+	}
+	if options.Debounce <= 0 {
+		options.Debounce = 100 * time.Millisecond
+	}
+	if options.Highlight == 0 {
+		options.Highlight = 2 * time.Second
+	}
+	w := &DirectoryWatcher{
+		tree:    d,
+		state:   d.resolvedTree().State,
+		options: options,
+		recent:  NewAnySignal(map[string]directoryRecentChange{}),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if w.state != nil {
+		_, w.infos, _ = w.scan(w.state.Nodes.Peek())
+	}
+	return w
+}
+
+// Stop ends watching and waits for the background goroutine to exit. It
+// is safe to call more than once.
+func (w *DirectoryWatcher) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+	<-w.done
+}
+
+// RecentChange reports how the entry at path changed, if it changed within
+// the highlight period. Calling it during Build subscribes to changes.
+func (w *DirectoryWatcher) RecentChange(path string) (DirectoryChangeKind, bool) {
+	if w == nil {
+		return 0, false
+	}
+	change, ok := w.recent.Get()[path]
+	if !ok || !Now().Before(change.until) {
+		return 0, false
+	}
+	return change.kind, true
+}
+
+func (w *DirectoryWatcher) run() {
+	defer close(w.done)
+	if w.state == nil {
+		return
+	}
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-time.After(w.options.Interval):
+		}
+		w.poll()
+	}
+}
+
+// poll rescans and, once the results stop changing for the debounce
+// period, applies them.
+func (w *DirectoryWatcher) poll() {
+	base := w.state.Nodes.Peek()
+	roots, infos, changes := w.scan(base)
+	for attempt := 0; len(changes) > 0 && attempt < 10; attempt++ {
+		select {
+		case <-w.stop:
+			return
+		case <-time.After(w.options.Debounce):
+		}
+		base = w.state.Nodes.Peek()
+		nextRoots, nextInfos, nextChanges := w.scan(base)
+		settled := sameDirectoryChanges(changes, nextChanges)
+		roots, infos, changes = nextRoots, nextInfos, nextChanges
+		if settled {
+			break
+		}
+	}
+	w.apply(base, roots, infos, changes)
+}
+
+// apply publishes a scan of base, unless the tree changed since (for
+// example a directory was expanded and loaded), in which case the next
+// poll picks the changes up again.
+func (w *DirectoryWatcher) apply(base, roots []TreeNode[DirectoryEntry], infos map[string]os.FileInfo, changes []DirectoryChange) {
+	if len(changes) == 0 {
+		w.infos = infos
+		return
+	}
+
+	cursorID := ""
+	if node, ok := w.state.NodeAtPath(w.state.CursorPath.Peek()); ok {
+		cursorID = node.Data.Path
+	}
+
+	applied := false
+	w.state.Nodes.Update(func(current []TreeNode[DirectoryEntry]) []TreeNode[DirectoryEntry] {
+		if !sameNodeSlice(current, base) {
+			return current
+		}
+		applied = true
+		return roots
+	})
+	if !applied {
+		return
+	}
+	w.infos = infos
+
+	// Carry state keyed by path over renames.
+	renames := map[string]string{}
+	for _, change := range changes {
+		if change.Kind == DirectoryRenamed {
+			renames[change.OldPath] = change.Path
+		}
+	}
+	if len(renames) > 0 {
+		w.state.Collapsed.Update(func(collapsed map[string]bool) map[string]bool {
+			next := make(map[string]bool, len(collapsed))
+			for id, v := range collapsed {
+				next[renamedPath(id, renames)] = v
+			}
+			return next
+		})
+		cursorID = renamedPath(cursorID, renames)
+	}
+	if cursorID != "" {
+		if path, ok := directoryNodePath(roots, cursorID); ok {
+			w.state.CursorPath.Set(path)
+		} else if path, ok := directoryNodePath(roots, filepath.Dir(cursorID)); ok {
+			w.state.CursorPath.Set(path)
+		}
+	}
+
+	w.highlight(changes)
+	if w.options.OnChange != nil {
+		w.options.OnChange(changes)
+	}
+}
+
+// highlight records changes for RecentChange and schedules a rebuild when
+// the highlight expires.
+func (w *DirectoryWatcher) highlight(changes []DirectoryChange) {
+	if w.options.Highlight < 0 {
+		return
+	}
+	until := Now().Add(w.options.Highlight)
+	w.recent.Update(func(recent map[string]directoryRecentChange) map[string]directoryRecentChange {
+		now := Now()
+		next := make(map[string]directoryRecentChange, len(recent)+len(changes))
+		for path, change := range recent {
+			if now.Before(change.until) {
+				next[path] = change
+			}
+		}
+		for _, change := range changes {
+			if change.Kind == DirectoryRemoved {
+				// The entry is gone; mark its directory instead.
+				next[filepath.Dir(change.Path)] = directoryRecentChange{kind: DirectoryModified, until: until}
+				continue
+			}
+			next[change.Path] = directoryRecentChange{kind: change.Kind, until: until}
+		}
+		return next
+	})
+	currentClock().AfterFunc(w.options.Highlight, func() {
+		w.recent.Update(func(recent map[string]directoryRecentChange) map[string]directoryRecentChange {
+			return recent
+		})
+	})
+}
+
+// scan rereads every loaded directory under nodes and returns the updated
+// nodes, the file info of every entry seen, and the changes relative to
+// nodes and the last applied file info.
+func (w *DirectoryWatcher) scan(nodes []TreeNode[DirectoryEntry]) ([]TreeNode[DirectoryEntry], map[string]os.FileInfo, []DirectoryChange) {
+	infos := map[string]os.FileInfo{}
+	var changes []DirectoryChange
+	var refresh func(nodes []TreeNode[DirectoryEntry]) ([]TreeNode[DirectoryEntry], bool)
+	refresh = func(nodes []TreeNode[DirectoryEntry]) ([]TreeNode[DirectoryEntry], bool) {
+		changed := false
+		next := make([]TreeNode[DirectoryEntry], len(nodes))
+		for i, node := range nodes {
+			next[i] = node
+			if !node.Data.IsDir || node.Data.Err != nil || node.Children == nil {
+				continue
+			}
+			children, childrenChanged := w.mergeChildren(node.Children, w.tree.readChildren(node.Data), infos, &changes)
+			children, descendantsChanged := refresh(children)
+			if childrenChanged || descendantsChanged {
+				next[i].Children = children
+				changed = true
+			}
+		}
+		if !changed {
+			return nodes, false
+		}
+		return next, true
+	}
+	roots, _ := refresh(nodes)
+	return roots, infos, changes
+}
+
+// mergeChildren reconciles a directory's current child nodes with a fresh
+// listing. Entries that still exist keep their nodes, so loaded
+// subdirectories stay loaded; a removed entry and a new entry that are the
+// same file are treated as a rename.
+func (w *DirectoryWatcher) mergeChildren(old, fresh []TreeNode[DirectoryEntry], infos map[string]os.FileInfo, changes *[]DirectoryChange) ([]TreeNode[DirectoryEntry], bool) {
+	byPath := make(map[string]TreeNode[DirectoryEntry], len(old))
+	for _, node := range old {
+		byPath[node.Data.Path] = node
+	}
+	freshPaths := make(map[string]bool, len(fresh))
+	for _, node := range fresh {
+		freshPaths[node.Data.Path] = true
+	}
+	var removed []TreeNode[DirectoryEntry]
+	for _, node := range old {
+		if !freshPaths[node.Data.Path] {
+			removed = append(removed, node)
+		}
+	}
+
+	changed := len(old) != len(fresh)
+	merged := make([]TreeNode[DirectoryEntry], len(fresh))
+	for i, node := range fresh {
+		path := node.Data.Path
+		info, err := os.Lstat(path)
+		if err == nil {
+			infos[path] = info
+		}
+		if existing, ok := byPath[path]; ok {
+			merged[i] = existing
+			merged[i].Data = node.Data
+			if i >= len(old) || old[i].Data.Path != path {
+				changed = true // Reordered
+			}
+			if prev, ok := w.infos[path]; ok && info != nil && !info.IsDir() &&
+				(prev.Size() != info.Size() || !prev.ModTime().Equal(info.ModTime())) {
+				*changes = append(*changes, DirectoryChange{Kind: DirectoryModified, Path: path})
+				changed = true
+			}
+			continue
+		}
+
+		changed = true
+		merged[i] = node
+		renamedFrom := -1
+		if info != nil {
+			for j, gone := range removed {
+				if prev, ok := w.infos[gone.Data.Path]; ok && os.SameFile(prev, info) {
+					renamedFrom = j
+					break
+				}
+			}
+		}
+		if renamedFrom < 0 {
+			*changes = append(*changes, DirectoryChange{Kind: DirectoryCreated, Path: path})
+			continue
+		}
+		gone := removed[renamedFrom]
+		removed = append(removed[:renamedFrom], removed[renamedFrom+1:]...)
+		merged[i].Children = renameDirectoryNodes(gone.Children, gone.Data.Path, path)
+		*changes = append(*changes, DirectoryChange{Kind: DirectoryRenamed, Path: path, OldPath: gone.Data.Path})
+	}
+	for _, node := range removed {
+		*changes = append(*changes, DirectoryChange{Kind: DirectoryRemoved, Path: node.Data.Path})
+	}
+	if !changed {
+		return old, false
+	}
+	return merged, true
+}
+
+// renameDirectoryNodes rewrites the paths of loaded descendants of a
+// renamed directory.
+func renameDirectoryNodes(nodes []TreeNode[DirectoryEntry], oldPath, newPath string) []TreeNode[DirectoryEntry] {
+	if nodes == nil {
+		return nil
+	}
+	renamed := make([]TreeNode[DirectoryEntry], len(nodes))
+	for i, node := range nodes {
+		node.Data.Path = renamedPath(node.Data.Path, map[string]string{oldPath: newPath})
+		node.Children = renameDirectoryNodes(node.Children, oldPath, newPath)
+		renamed[i] = node
+	}
+	return renamed
+}
+
+// renamedPath applies the first rename whose old path is path or one of
+// its parents.
+func renamedPath(path string, renames map[string]string) string {
+	for oldPath, newPath := range renames {
+		if path == oldPath {
+			return newPath
+		}
+		if strings.HasPrefix(path, oldPath+string(filepath.Separator)) {
+			return newPath + path[len(oldPath):]
+		}
+	}
+	return path
+}
+
+// directoryNodePath returns the tree path of the node for the entry at path.
+func directoryNodePath(nodes []TreeNode[DirectoryEntry], path string) ([]int, bool) {
+	for i, node := range nodes {
+		if node.Data.Path == path {
+			return []int{i}, true
+		}
+		if sub, ok := directoryNodePath(node.Children, path); ok {
+			return append([]int{i}, sub...), true
+		}
+	}
+	return nil, false
+}
+
+func sameDirectoryChanges(a, b []DirectoryChange) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
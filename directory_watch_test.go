@@ -0,0 +1,185 @@
+package terma
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// watchedDirectory returns a DirectoryTree over root with root and the
+// given subdirectories loaded, and a watcher that is polled by hand.
+func watchedDirectory(t *testing.T, root string, options DirectoryWatchOptions, subdirs ...[]int) (DirectoryTree, *DirectoryWatcher) {
+	t.Helper()
+	tree := DirectoryTree{Tree: Tree[DirectoryEntry]{ID: "files", State: NewDirectoryTreeState(root)}}
+	state := tree.resolvedTree().State
+	for _, path := range append([][]int{{0}}, subdirs...) {
+		node, ok := state.NodeAtPath(path)
+		require.True(t, ok)
+		state.SetChildren(path, tree.readChildren(node.Data))
+	}
+	options.Debounce = time.Millisecond
+	return tree, newDirectoryWatcher(tree, options)
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func childNames(t *testing.T, state *TreeState[DirectoryEntry], path []int) []string {
+	t.Helper()
+	node, ok := state.NodeAtPath(path)
+	require.True(t, ok)
+	names := []string{}
+	for _, child := range node.Children {
+		names = append(names, child.Data.Name)
+	}
+	return names
+}
+
+func TestDirectoryWatcher_CreateAndRemove(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "a.txt"), "a")
+	writeTestFile(t, filepath.Join(root, "b.txt"), "b")
+
+	var got []DirectoryChange
+	tree, w := watchedDirectory(t, root, DirectoryWatchOptions{
+		OnChange: func(changes []DirectoryChange) { got = changes },
+	})
+	state := tree.State
+
+	writeTestFile(t, filepath.Join(root, "c.txt"), "c")
+	require.NoError(t, os.Remove(filepath.Join(root, "a.txt")))
+	w.poll()
+
+	assert.Equal(t, []string{"b.txt", "c.txt"}, childNames(t, state, []int{0}))
+	assert.Equal(t, []DirectoryChange{
+		{Kind: DirectoryCreated, Path: filepath.Join(root, "c.txt")},
+		{Kind: DirectoryRemoved, Path: filepath.Join(root, "a.txt")},
+	}, got)
+
+	got = nil
+	w.poll()
+	assert.Nil(t, got, "no changes are reported when the disk is unchanged")
+}
+
+func TestDirectoryWatcher_ModifiedFile(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "a.txt")
+	writeTestFile(t, path, "a")
+
+	var got []DirectoryChange
+	_, w := watchedDirectory(t, root, DirectoryWatchOptions{
+		OnChange: func(changes []DirectoryChange) { got = changes },
+	})
+
+	writeTestFile(t, path, "longer content")
+	w.poll()
+
+	assert.Equal(t, []DirectoryChange{{Kind: DirectoryModified, Path: path}}, got)
+}
+
+func TestDirectoryWatcher_RenameKeepsLoadedChildrenAndState(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "src"), 0o755))
+	writeTestFile(t, filepath.Join(root, "src", "main.go"), "package main")
+	writeTestFile(t, filepath.Join(root, "z.txt"), "z")
+
+	var got []DirectoryChange
+	tree, w := watchedDirectory(t, root, DirectoryWatchOptions{
+		OnChange: func(changes []DirectoryChange) { got = changes },
+	}, []int{0, 0})
+	state := tree.State
+	state.Expand([]int{0, 0})
+	state.CursorPath.Set([]int{0, 0, 0})
+
+	require.NoError(t, os.Rename(filepath.Join(root, "src"), filepath.Join(root, "lib")))
+	w.poll()
+
+	newDir := filepath.Join(root, "lib")
+	assert.Equal(t, []DirectoryChange{
+		{Kind: DirectoryRenamed, Path: newDir, OldPath: filepath.Join(root, "src")},
+	}, got)
+	node, ok := state.NodeAtPath([]int{0, 0})
+	require.True(t, ok)
+	assert.Equal(t, newDir, node.Data.Path)
+	require.Len(t, node.Children, 1, "the renamed directory stays loaded")
+	assert.Equal(t, filepath.Join(newDir, "main.go"), node.Children[0].Data.Path)
+	assert.False(t, state.IsCollapsed([]int{0, 0}), "the renamed directory stays expanded")
+
+	cursor, ok := state.CursorNode()
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join(newDir, "main.go"), cursor.Path)
+}
+
+func TestDirectoryWatcher_CursorFollowsEntry(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "b.txt"), "b")
+	writeTestFile(t, filepath.Join(root, "c.txt"), "c")
+
+	tree, w := watchedDirectory(t, root, DirectoryWatchOptions{})
+	state := tree.State
+	state.CursorPath.Set([]int{0, 1})
+
+	writeTestFile(t, filepath.Join(root, "a.txt"), "a")
+	w.poll()
+	cursor, _ := state.CursorNode()
+	assert.Equal(t, "c.txt", cursor.Name, "the cursor moves with its entry")
+
+	require.NoError(t, os.Remove(filepath.Join(root, "c.txt")))
+	w.poll()
+	cursor, _ = state.CursorNode()
+	assert.Equal(t, root, cursor.Path, "the cursor falls back to the parent of a removed entry")
+}
+
+func TestDirectoryWatcher_HighlightExpires(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	SetClock(clock)
+	t.Cleanup(func() { SetClock(nil) })
+
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "a.txt"), "a")
+	_, w := watchedDirectory(t, root, DirectoryWatchOptions{Highlight: time.Second})
+
+	created := filepath.Join(root, "b.txt")
+	writeTestFile(t, created, "b")
+	require.NoError(t, os.Remove(filepath.Join(root, "a.txt")))
+	w.poll()
+
+	kind, ok := w.RecentChange(created)
+	assert.True(t, ok)
+	assert.Equal(t, DirectoryCreated, kind)
+	kind, ok = w.RecentChange(root)
+	assert.True(t, ok, "a removal highlights its directory")
+	assert.Equal(t, DirectoryModified, kind)
+
+	clock.Advance(time.Second)
+	_, ok = w.RecentChange(created)
+	assert.False(t, ok)
+}
+
+func TestDirectoryWatcher_StopIsIdempotent(t *testing.T) {
+	tree := DirectoryTree{Tree: Tree[DirectoryEntry]{ID: "files", State: NewDirectoryTreeState(t.TempDir())}}
+	w := tree.Watch(DirectoryWatchOptions{Interval: time.Millisecond})
+	w.Stop()
+	w.Stop()
+}
+
+func TestDirectoryTree_HighlightsRecentChanges(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "a.txt"), "a")
+	tree, w := watchedDirectory(t, root, DirectoryWatchOptions{})
+	tree.Watcher = w
+
+	writeTestFile(t, filepath.Join(root, "b.txt"), "b")
+	w.poll()
+
+	buf := RenderToBuffer(tree, 20, 3)
+	theme := newTestBuildContext().Theme()
+	assert.Equal(t, theme.Text.toANSI(), buf.CellAt(4, 1).Style.Fg, "unchanged entries use the normal color")
+	assert.Equal(t, theme.Success.toANSI(), buf.CellAt(4, 2).Style.Fg, "created entries are highlighted")
+}
@@ -0,0 +1,129 @@
+package terma
+
+import (
+	"math"
+	"sync/atomic"
+
+	"github.com/charmbracelet/colorprofile"
+)
+
+// GradientDithering selects how gradient backgrounds are dithered on
+// terminals with fewer colors than the gradient needs, so they show a fine
+// pattern of neighbouring colors instead of wide bands.
+type GradientDithering int
+
+const (
+	// DitherNone leaves gradients to the terminal's nearest-color matching.
+	DitherNone GradientDithering = iota
+	// DitherOrdered uses a 4x4 Bayer matrix: a regular, cross-hatched pattern.
+	DitherOrdered
+	// DitherBlueNoise uses interleaved gradient noise, an irregular pattern
+	// with no visible repetition.
+	DitherBlueNoise
+)
+
+var (
+	gradientDithering atomic.Int32
+	// ditherLevels holds the per-channel levels the terminal can show, or
+	// nil when it shows every color (or none).
+	ditherLevels atomic.Pointer[[]float64]
+)
+
+// Channel levels of the xterm 256-color cube, and a rough approximation of
+// the 16 ANSI colors (whose exact values vary by terminal).
+var (
+	ansi256Levels = []float64{0, 95, 135, 175, 215, 255}
+	ansi16Levels  = []float64{0, 128, 255}
+)
+
+// bayer4 is the 4x4 ordered dithering matrix.
+var bayer4 = [4][4]float64{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// SetGradientDithering sets how gradient backgrounds are dithered. It only
+// has an effect on 256- and 16-color terminals; true-color terminals show
+// gradients exactly. The default is DitherNone.
+//
+// Example:
+//
+//	t.SetGradientDithering(t.DitherOrdered)
+//	t.Run(app)
+func SetGradientDithering(mode GradientDithering) {
+	gradientDithering.Store(int32(mode))
+}
+
+// setDitherProfile records the terminal's color profile, which decides the
+// levels gradients are dithered to.
+func setDitherProfile(profile colorprofile.Profile) {
+	var levels *[]float64
+	switch profile {
+	case colorprofile.ANSI256:
+		levels = &ansi256Levels
+	case colorprofile.ANSI:
+		levels = &ansi16Levels
+	}
+	ditherLevels.Store(levels)
+}
+
+// backgroundAt samples bg at (relX, relY) in a width x height box, for the
+// cell at absolute position (absX, absY). Gradients are dithered when
+// enabled; the pattern follows absolute positions so neighbouring widgets
+// sampling the same gradient agree.
+func backgroundAt(bg ColorProvider, width, height, relX, relY, absX, absY int) Color {
+	c := bg.ColorAt(width, height, relX, relY)
+	if _, ok := bg.(Gradient); !ok {
+		return c
+	}
+	return ditherColor(c, absX, absY)
+}
+
+// ditherColor snaps c to the terminal's color levels, rounding each channel
+// up or down according to the dither pattern at (x, y).
+func ditherColor(c Color, x, y int) Color {
+	levels := ditherLevels.Load()
+	if levels == nil || !c.IsSet() {
+		return c
+	}
+	var threshold float64
+	switch GradientDithering(gradientDithering.Load()) {
+	case DitherOrdered:
+		threshold = (bayer4[y&3][x&3] + 0.5) / 16
+	case DitherBlueNoise:
+		threshold = interleavedGradientNoise(x, y)
+	default:
+		return c
+	}
+	c.r = ditherChannel(c.r, *levels, threshold)
+	c.g = ditherChannel(c.g, *levels, threshold)
+	c.b = ditherChannel(c.b, *levels, threshold)
+	return c
+}
+
+// ditherChannel returns the level below or above v: the one above when
+// v's position between the two exceeds threshold.
+func ditherChannel(v uint8, levels []float64, threshold float64) uint8 {
+	value := float64(v)
+	for i := 1; i < len(levels); i++ {
+		lo, hi := levels[i-1], levels[i]
+		if value > hi {
+			continue
+		}
+		if (value-lo)/(hi-lo) > threshold {
+			return uint8(hi)
+		}
+		return uint8(lo)
+	}
+	return uint8(levels[len(levels)-1])
+}
+
+// interleavedGradientNoise returns a value in [0, 1) for the cell at (x, y)
+// (Jimenez 2014). Neighbouring values differ widely, approximating blue noise.
+func interleavedGradientNoise(x, y int) float64 {
+	_, f := math.Modf(0.06711056*float64(x) + 0.00583715*float64(y))
+	_, f = math.Modf(52.9829189 * f)
+	return f
+}
@@ -0,0 +1,78 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/stretchr/testify/assert"
+)
+
+func useDithering(t *testing.T, mode GradientDithering, profile colorprofile.Profile) {
+	t.Helper()
+	SetGradientDithering(mode)
+	setDitherProfile(profile)
+	t.Cleanup(func() {
+		SetGradientDithering(DitherNone)
+		setDitherProfile(colorprofile.TrueColor)
+	})
+}
+
+func TestDitherChannel(t *testing.T) {
+	assert.Equal(t, uint8(95), ditherChannel(95, ansi256Levels, 0.5), "exact levels are kept")
+	assert.Equal(t, uint8(135), ditherChannel(125, ansi256Levels, 0.5))
+	assert.Equal(t, uint8(95), ditherChannel(105, ansi256Levels, 0.5))
+	assert.Equal(t, uint8(255), ditherChannel(255, ansi256Levels, 0.99))
+	assert.Equal(t, uint8(0), ditherChannel(10, ansi256Levels, 0.5))
+}
+
+func TestDitherColor_MixesNeighbouringLevels(t *testing.T) {
+	useDithering(t, DitherOrdered, colorprofile.ANSI256)
+
+	// Halfway between two cube levels, half of a 4x4 tile rounds each way.
+	c := RGB(155, 155, 155)
+	counts := map[uint8]int{}
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			r, _, _ := ditherColor(c, x, y).RGB()
+			counts[r]++
+		}
+	}
+	assert.Equal(t, map[uint8]int{135: 8, 175: 8}, counts)
+}
+
+func TestDitherColor_OnlyOnLimitedTerminals(t *testing.T) {
+	useDithering(t, DitherBlueNoise, colorprofile.TrueColor)
+	c := RGB(155, 10, 200)
+	assert.Equal(t, c, ditherColor(c, 3, 5))
+
+	setDitherProfile(colorprofile.ANSI)
+	r, g, b := ditherColor(c, 3, 5).RGB()
+	for _, v := range []uint8{r, g, b} {
+		assert.Contains(t, []uint8{0, 128, 255}, v)
+	}
+
+	SetGradientDithering(DitherNone)
+	assert.Equal(t, c, ditherColor(c, 3, 5))
+}
+
+func TestBackgroundAt_DithersGradientsOnly(t *testing.T) {
+	useDithering(t, DitherOrdered, colorprofile.ANSI256)
+
+	solid := RGB(155, 155, 155)
+	assert.Equal(t, solid, backgroundAt(solid, 10, 1, 3, 0, 3, 0))
+
+	gradient := NewGradient(RGB(0, 0, 0), RGB(255, 255, 255)).WithAngle(90)
+	r, _, _ := backgroundAt(gradient, 10, 1, 3, 0, 3, 0).RGB()
+	assert.Contains(t, ansi256Levels, float64(r))
+}
+
+func TestGradientBackground_Dithered(t *testing.T) {
+	useDithering(t, DitherOrdered, colorprofile.ANSI256)
+
+	gradient := NewGradient(RGB(0, 0, 0), RGB(255, 255, 255)).WithAngle(90)
+	buf := RenderToBuffer(Text{Content: "", Style: Style{BackgroundColor: gradient, Width: Cells(20), Height: Cells(2)}}, 20, 2)
+	for x := 0; x < 20; x++ {
+		r, _, _ := FromANSI(buf.CellAt(x, 0).Style.Bg).RGB()
+		assert.Contains(t, ansi256Levels, float64(r), "cell %d", x)
+	}
+}
@@ -0,0 +1,407 @@
+package terma
+
+import (
+	"github.com/darrenburns/terma/layout"
+)
+
+// Alpha of the accent tint a DropTarget shows while an accepted payload is
+// over it.
+const dropTargetAcceptAlpha = 0.2
+
+// dragSession is the drag in progress.
+type dragSession struct {
+	payload  any
+	ghost    Widget
+	start    func()
+	end      func(dropped bool)
+	keyboard bool       // Started with StartKeyboardDrag: keys move between targets
+	x, y     int        // Where the ghost is drawn
+	targetID string     // Drop target the payload is over ("" = none)
+	target   dropTarget // That drop target, as last rendered
+	accepted bool       // Whether that target accepts the payload
+}
+
+// activeDrag is the drag in progress, nil when there is none. Drop targets
+// read it to show whether they accept the payload.
+var activeDrag = NewAnySignal[*dragSession](nil)
+
+// dragSource is implemented by Draggable.
+type dragSource interface {
+	newDragSession() *dragSession
+}
+
+// dropTarget is implemented by DropTarget.
+type dropTarget interface {
+	accepts(payload any) bool
+	drop(payload any)
+}
+
+// Draggable lets its child be dragged with the mouse and dropped on a
+// DropTarget of the same payload type, which receives Payload. A ghost of
+// the child follows the pointer while dragging; Escape cancels.
+//
+// For keyboard users, call StartKeyboardDrag from a keybind: the arrow keys
+// and Tab then move the payload between the targets that accept it, Enter
+// drops it and Escape cancels.
+//
+// Example:
+//
+//	Draggable[Task]{
+//	    Payload: task,
+//	    Child:   Text{Content: task.Title},
+//	}
+type Draggable[T any] struct {
+	ID          string                        // Optional unique identifier
+	Payload     T                             // Value handed to the drop target
+	Child       Widget                        // The widget to drag
+	Ghost       Widget                        // Shown under the pointer while dragging (default: Child)
+	Disabled    bool                          // Leave the child undraggable
+	OnDragStart func(payload T)               // Optional callback when the drag starts
+	OnDragEnd   func(payload T, dropped bool) // Optional callback when the drag ends, with whether a target took the payload
+}
+
+// WidgetID returns the draggable's unique identifier.
+// Implements the Identifiable interface.
+func (d Draggable[T]) WidgetID() string {
+	return d.ID
+}
+
+// Build returns itself; the child is built as its only child.
+func (d Draggable[T]) Build(ctx BuildContext) Widget {
+	return d
+}
+
+// ChildWidgets returns the wrapped child for render tree building.
+func (d Draggable[T]) ChildWidgets() []Widget {
+	if d.Child != nil {
+		return []Widget{d.Child}
+	}
+	return nil
+}
+
+// BuildLayoutNode wraps the child in a container for layout.
+func (d Draggable[T]) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return wrapChildLayoutNode(ctx, d.Child)
+}
+
+// StartKeyboardDrag starts dragging Payload with the keyboard, over the
+// first drop target that accepts it.
+func (d Draggable[T]) StartKeyboardDrag() {
+	session := d.newDragSession()
+	if session == nil {
+		return
+	}
+	session.keyboard = true
+	startDrag(session)
+	moveKeyboardDrag(renderedEntries(), 1)
+}
+
+func (d Draggable[T]) newDragSession() *dragSession {
+	if d.Disabled || d.Child == nil {
+		return nil
+	}
+	ghost := d.Ghost
+	if ghost == nil {
+		ghost = d.Child
+	}
+	payload := d.Payload
+	return &dragSession{
+		payload: payload,
+		ghost:   ghost,
+		start: func() {
+			if d.OnDragStart != nil {
+				d.OnDragStart(payload)
+			}
+		},
+		end: func(dropped bool) {
+			if d.OnDragEnd != nil {
+				d.OnDragEnd(payload, dropped)
+			}
+		},
+	}
+}
+
+// DropTarget receives the payloads of Draggable widgets dropped on it.
+// While an accepted payload is over the target it shows AcceptStyle.
+//
+// Example:
+//
+//	DropTarget[Task]{
+//	    Accept: func(task Task) bool { return !task.Done },
+//	    OnDrop: func(task Task) { a.moveToDone(task) },
+//	    Style:  Style{Width: Flex(1)},
+//	    Child:  doneList,
+//	}
+type DropTarget[T any] struct {
+	ID          string               // Optional unique identifier
+	Child       Widget               // The widget payloads are dropped on
+	Accept      func(payload T) bool // Optional: whether to take a payload (default: every payload of type T)
+	OnDrop      func(payload T)      // Called when an accepted payload is dropped
+	Style       Style                // Style of the container around Child
+	AcceptStyle Style                // Colors and text attributes while an accepted payload is over the target (default: accent-tinted background)
+}
+
+// WidgetID returns the drop target's unique identifier.
+// Implements the Identifiable interface.
+func (t DropTarget[T]) WidgetID() string {
+	return t.ID
+}
+
+// Build wraps the child in a container showing whether a payload being
+// dragged over it would be accepted.
+func (t DropTarget[T]) Build(ctx BuildContext) Widget {
+	style := t.Style
+	if session := activeDrag.Get(); session != nil && session.accepted && session.targetID == t.targetID(ctx) {
+		accept := t.AcceptStyle
+		if accept.IsZero() {
+			accept = Style{BackgroundColor: ctx.Theme().Accent.WithAlpha(dropTargetAcceptAlpha)}
+		}
+		style = style.withFormatStyle(accept)
+	}
+	var children []Widget
+	if t.Child != nil {
+		children = []Widget{t.Child}
+	}
+	return Column{Style: style, Children: children}
+}
+
+// targetID returns the ID the drop target is registered under.
+func (t DropTarget[T]) targetID(ctx BuildContext) string {
+	if t.ID != "" {
+		return t.ID
+	}
+	return ctx.AutoID()
+}
+
+func (t DropTarget[T]) accepts(payload any) bool {
+	value, ok := payload.(T)
+	if !ok {
+		return false
+	}
+	return t.Accept == nil || t.Accept(value)
+}
+
+func (t DropTarget[T]) drop(payload any) {
+	if value, ok := payload.(T); ok && t.OnDrop != nil {
+		t.OnDrop(value)
+	}
+}
+
+// wrapChildLayoutNode lays out child inside a column, so the child gets a
+// render tree of its own.
+func wrapChildLayoutNode(ctx BuildContext, child Widget) layout.LayoutNode {
+	if child == nil {
+		return &layout.BoxNode{}
+	}
+	childCtx := ctx.PushChild(0)
+	built := child.Build(childCtx)
+	var childNode layout.LayoutNode
+	if builder, ok := built.(LayoutNodeBuilder); ok {
+		childNode = builder.BuildLayoutNode(childCtx)
+	} else {
+		childNode = &layout.BoxNode{}
+	}
+	return &layout.ColumnNode{
+		Children: []layout.LayoutNode{childNode},
+	}
+}
+
+// startDrag makes session the drag in progress.
+func startDrag(session *dragSession) {
+	endDrag(false)
+	activeDrag.Set(session)
+	if session.start != nil {
+		session.start()
+	}
+}
+
+// updateDrag replaces the drag in progress with a changed copy, so drop
+// targets rebuild.
+func updateDrag(change func(session *dragSession)) {
+	current := activeDrag.Peek()
+	if current == nil {
+		return
+	}
+	next := *current
+	change(&next)
+	activeDrag.Set(&next)
+}
+
+// endDrag drops the payload on the target it is over when dropped is true
+// and that target accepts it, then ends the drag.
+func endDrag(dropped bool) {
+	session := activeDrag.Peek()
+	if session == nil {
+		return
+	}
+	activeDrag.Set(nil)
+	dropped = dropped && session.accepted && session.target != nil
+	if dropped {
+		session.target.drop(session.payload)
+	}
+	if session.end != nil {
+		session.end(dropped)
+	}
+}
+
+// dragOver moves the ghost to (x, y) and notes the drop target there.
+func dragOver(entries []WidgetEntry, x, y int) {
+	updateDrag(func(session *dragSession) {
+		session.x, session.y = x, y
+		session.targetID, session.target, session.accepted = "", nil, false
+		for i := len(entries) - 1; i >= 0; i-- {
+			entry := entries[i]
+			target, ok := entry.EventWidget.(dropTarget)
+			if !ok || !entry.Bounds.Contains(x, y) {
+				continue
+			}
+			session.targetID, session.target = entry.ID, target
+			session.accepted = target.accepts(session.payload)
+			return
+		}
+	})
+}
+
+// moveKeyboardDrag moves a keyboard drag to the next (step 1) or previous
+// (step -1) drop target that accepts the payload, in screen order.
+func moveKeyboardDrag(entries []WidgetEntry, step int) {
+	session := activeDrag.Peek()
+	if session == nil {
+		return
+	}
+	var targets []WidgetEntry
+	current := -1
+	for _, entry := range entries {
+		target, ok := entry.EventWidget.(dropTarget)
+		if !ok || !target.accepts(session.payload) || widgetEntryByID(targets, entry.ID) != nil {
+			continue
+		}
+		if entry.ID == session.targetID {
+			current = len(targets)
+		}
+		targets = append(targets, entry)
+	}
+	if len(targets) == 0 {
+		return
+	}
+	next := 0
+	if current >= 0 {
+		next = (current + step + len(targets)) % len(targets)
+	}
+	entry := targets[next]
+	updateDrag(func(session *dragSession) {
+		session.targetID, session.target, session.accepted = entry.ID, entry.EventWidget.(dropTarget), true
+		session.x, session.y = entry.Bounds.X+1, entry.Bounds.Y
+	})
+}
+
+// widgetEntryByID returns the first entry with the given ID, or nil.
+func widgetEntryByID(entries []WidgetEntry, id string) *WidgetEntry {
+	for i := range entries {
+		if entries[i].ID == id {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// renderedEntries returns the widgets of the running app's last frame.
+func renderedEntries() []WidgetEntry {
+	if appRenderer == nil {
+		return nil
+	}
+	return appRenderer.widgetRegistry.Entries()
+}
+
+// buildDragGhost adds the ghost of the drag in progress to the overlay
+// layer. It ignores the pointer, so drop targets beneath it are found.
+func buildDragGhost(ctx BuildContext) {
+	session := activeDrag.Peek()
+	if session == nil || session.ghost == nil {
+		return
+	}
+	Portal{
+		Offset:        Offset{X: session.x, Y: session.y},
+		IgnorePointer: true,
+		Child:         session.ghost,
+	}.Build(ctx)
+}
+
+// dragTracker turns mouse presses on a Draggable into drags and handles
+// keys during a drag. It is owned by the app's event loop.
+type dragTracker struct {
+	pending        dragSource // Draggable pressed, dragged once the pointer moves
+	pressX, pressY int
+}
+
+// handlePress notes the Draggable under the pointer, if any. The press
+// still reaches widgets.
+func (d *dragTracker) handlePress(entries []WidgetEntry, x, y int) {
+	d.pending = nil
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if source, ok := entry.EventWidget.(dragSource); ok && entry.Bounds.Contains(x, y) {
+			d.pending, d.pressX, d.pressY = source, x, y
+			return
+		}
+	}
+}
+
+// handleMotion starts dragging the pressed Draggable once the pointer
+// leaves the cell it was pressed on, then moves the drag. It returns true
+// while dragging, when the motion doesn't reach widgets.
+func (d *dragTracker) handleMotion(entries []WidgetEntry, x, y int) bool {
+	if d.pending != nil && (x != d.pressX || y != d.pressY) {
+		source := d.pending
+		d.pending = nil
+		if session := source.newDragSession(); session != nil {
+			startDrag(session)
+		}
+	}
+	session := activeDrag.Peek()
+	if session == nil || session.keyboard {
+		return false
+	}
+	dragOver(entries, x, y)
+	return true
+}
+
+// handleRelease drops a mouse drag on the target under the pointer. It
+// returns true when a drag ended, when the release doesn't reach widgets.
+func (d *dragTracker) handleRelease(entries []WidgetEntry, x, y int) bool {
+	d.pending = nil
+	session := activeDrag.Peek()
+	if session == nil || session.keyboard {
+		return false
+	}
+	dragOver(entries, x, y)
+	endDrag(true)
+	return true
+}
+
+// handleKey handles keys during a drag: Escape cancels, and keyboard drags
+// move between targets and drop. It returns true when it took the key.
+func (d *dragTracker) handleKey(event KeyEvent, entries []WidgetEntry) bool {
+	session := activeDrag.Peek()
+	if session == nil {
+		return false
+	}
+	if event.MatchString("escape") {
+		d.pending = nil
+		endDrag(false)
+		return true
+	}
+	if !session.keyboard {
+		return false
+	}
+	switch {
+	case event.MatchString("down", "right", "tab", "j", "l"):
+		moveKeyboardDrag(entries, 1)
+	case event.MatchString("up", "left", "shift+tab", "k", "h"):
+		moveKeyboardDrag(entries, -1)
+	case event.MatchString("enter", "space", " "):
+		endDrag(true)
+	}
+	// Keyboard drags take every key, so widgets don't move underneath.
+	return true
+}
@@ -0,0 +1,175 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dropLog struct {
+	dropped []string
+	ended   []bool
+	started int
+}
+
+// dndTestWidget has a draggable "task" on row 0 and drop targets "todo" on
+// row 1, "done" on row 2, "locked" on row 3, which refuses everything, and
+// "numbers" on row 4, which takes ints.
+func dndTestWidget(log *dropLog) Widget {
+	target := func(id string, accept func(string) bool) Widget {
+		return DropTarget[string]{
+			ID:     id,
+			Accept: accept,
+			OnDrop: func(payload string) { log.dropped = append(log.dropped, id+":"+payload) },
+			Style:  Style{Width: Cells(10)},
+			Child:  Text{Content: id},
+		}
+	}
+	return Column{
+		Children: []Widget{
+			Draggable[string]{
+				Payload:     "task",
+				Child:       Text{Content: "task"},
+				Ghost:       Text{Content: "ghost"},
+				OnDragStart: func(string) { log.started++ },
+				OnDragEnd:   func(_ string, dropped bool) { log.ended = append(log.ended, dropped) },
+			},
+			target("todo", nil),
+			target("done", nil),
+			target("locked", func(string) bool { return false }),
+			DropTarget[int]{ID: "numbers", Child: Text{Content: "numbers"}},
+		},
+	}
+}
+
+func renderDnDTest(t *testing.T, log *dropLog) (*Renderer, *uv.Buffer) {
+	t.Cleanup(func() { activeDrag.Set(nil) })
+	renderer, buf := portalTestRenderer(20, 8)
+	renderer.Render(dndTestWidget(log))
+	return renderer, buf
+}
+
+func TestDragAndDrop_MouseDropsOnTarget(t *testing.T) {
+	log := &dropLog{}
+	renderer, buf := renderDnDTest(t, log)
+	drags := &dragTracker{}
+	entries := renderer.widgetRegistry.Entries()
+
+	drags.handlePress(entries, 1, 0)
+	assert.Nil(t, activeDrag.Peek(), "pressing doesn't start a drag")
+	assert.True(t, drags.handleMotion(entries, 2, 2))
+	require.NotNil(t, activeDrag.Peek())
+	assert.Equal(t, 1, log.started)
+	assert.Equal(t, "done", activeDrag.Peek().targetID)
+	assert.True(t, activeDrag.Peek().accepted)
+
+	renderer.Render(dndTestWidget(log))
+	assert.Equal(t, "ghost", bufferLine(buf, 2, 20)[2:7], "the ghost follows the pointer")
+	assert.NotNil(t, buf.CellAt(8, 2).Style.Bg, "the target shows it accepts the payload")
+	assert.Nil(t, buf.CellAt(8, 1).Style.Bg)
+
+	assert.True(t, drags.handleRelease(entries, 2, 2))
+	assert.Nil(t, activeDrag.Peek())
+	assert.Equal(t, []string{"done:task"}, log.dropped)
+	assert.Equal(t, []bool{true}, log.ended)
+
+	renderer.Render(dndTestWidget(log))
+	assert.Equal(t, "done", bufferLine(buf, 2, 20)[:4], "the ghost is gone")
+}
+
+func TestDragAndDrop_RefusedPayloads(t *testing.T) {
+	for name, y := range map[string]int{"accept refuses": 3, "other payload type": 4, "no target": 6} {
+		t.Run(name, func(t *testing.T) {
+			log := &dropLog{}
+			renderer, buf := renderDnDTest(t, log)
+			drags := &dragTracker{}
+			entries := renderer.widgetRegistry.Entries()
+
+			drags.handlePress(entries, 0, 0)
+			drags.handleMotion(entries, 2, y)
+			assert.False(t, activeDrag.Peek().accepted)
+			renderer.Render(dndTestWidget(log))
+			assert.Nil(t, buf.CellAt(8, y).Style.Bg, "no accept feedback")
+
+			assert.True(t, drags.handleRelease(entries, 2, y))
+			assert.Empty(t, log.dropped)
+			assert.Equal(t, []bool{false}, log.ended)
+		})
+	}
+}
+
+func TestDragAndDrop_ClickWithoutMotionIsNotADrag(t *testing.T) {
+	log := &dropLog{}
+	renderer, _ := renderDnDTest(t, log)
+	drags := &dragTracker{}
+	entries := renderer.widgetRegistry.Entries()
+
+	drags.handlePress(entries, 0, 0)
+	assert.False(t, drags.handleMotion(entries, 0, 0))
+	assert.False(t, drags.handleRelease(entries, 0, 0))
+	assert.False(t, drags.handleMotion(entries, 0, 2), "the press is over after release")
+	assert.Zero(t, log.started)
+
+	drags.handlePress(entries, 0, 1)
+	assert.False(t, drags.handleMotion(entries, 0, 2), "targets aren't draggable")
+}
+
+func TestDragAndDrop_EscapeCancels(t *testing.T) {
+	log := &dropLog{}
+	renderer, _ := renderDnDTest(t, log)
+	drags := &dragTracker{}
+	entries := renderer.widgetRegistry.Entries()
+
+	assert.False(t, drags.handleKey(makeKeyEvent(uv.KeyEscape, 0), entries), "no drag to cancel")
+	drags.handlePress(entries, 0, 0)
+	drags.handleMotion(entries, 0, 1)
+	assert.False(t, drags.handleKey(makeCharEvent('j'), entries), "mouse drags leave other keys alone")
+	assert.True(t, drags.handleKey(makeKeyEvent(uv.KeyEscape, 0), entries))
+
+	assert.Nil(t, activeDrag.Peek())
+	assert.False(t, drags.handleRelease(entries, 0, 1))
+	assert.Empty(t, log.dropped)
+	assert.Equal(t, []bool{false}, log.ended)
+}
+
+func TestDragAndDrop_Keyboard(t *testing.T) {
+	log := &dropLog{}
+	renderer, buf := renderDnDTest(t, log)
+	previous := appRenderer
+	appRenderer = renderer
+	t.Cleanup(func() { appRenderer = previous })
+	drags := &dragTracker{}
+	entries := renderer.widgetRegistry.Entries()
+
+	Draggable[string]{
+		Payload:   "task",
+		Child:     Text{Content: "task"},
+		OnDragEnd: func(_ string, dropped bool) { log.ended = append(log.ended, dropped) },
+	}.StartKeyboardDrag()
+	require.NotNil(t, activeDrag.Peek())
+	assert.Equal(t, "todo", activeDrag.Peek().targetID, "starts on the first target that accepts the payload")
+
+	renderer.Render(dndTestWidget(log))
+	assert.Equal(t, "ttask", bufferLine(buf, 1, 20)[:5], "the ghost sits on the target")
+
+	assert.True(t, drags.handleKey(makeKeyEvent(uv.KeyTab, 0), entries))
+	assert.Equal(t, "done", activeDrag.Peek().targetID)
+	drags.handleKey(makeKeyEvent(uv.KeyDown, 0), entries)
+	assert.Equal(t, "todo", activeDrag.Peek().targetID, "refusing targets are skipped and the order wraps")
+	drags.handleKey(makeKeyEvent(uv.KeyUp, 0), entries)
+	assert.Equal(t, "done", activeDrag.Peek().targetID)
+	assert.True(t, drags.handleKey(makeCharEvent('x'), entries), "keyboard drags take every key")
+
+	assert.True(t, drags.handleKey(makeKeyEvent(uv.KeyEnter, 0), entries))
+	assert.Nil(t, activeDrag.Peek())
+	assert.Equal(t, []string{"done:task"}, log.dropped)
+	assert.Equal(t, []bool{true}, log.ended)
+}
+
+func TestDragAndDrop_DisabledDraggable(t *testing.T) {
+	t.Cleanup(func() { activeDrag.Set(nil) })
+	Draggable[string]{Payload: "task", Child: Text{Content: "task"}, Disabled: true}.StartKeyboardDrag()
+	assert.Nil(t, activeDrag.Peek())
+}
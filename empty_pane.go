@@ -0,0 +1,163 @@
+package terma
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/darrenburns/terma/layout"
+)
+
+// EmptyPaneAction is a quick action offered by an EmptyPane, shown as its
+// label beside the key that runs it, such as "Open a file  ctrl+p".
+type EmptyPaneAction struct {
+	Label   string // What the action does
+	Key     string // The key that runs it, for display; bind it in the app's keybinds
+	OnPress func() // Optional: makes the action clickable
+}
+
+// EmptyPane fills a pane that has no content, like an editor with no open
+// files: a centered icon, title and message, and a list of quick actions
+// with their keys. Watermark tiles faint text diagonally behind it.
+//
+// Example:
+//
+//	if len(a.openFiles.Get()) == 0 {
+//	    return terma.EmptyPane{
+//	        Icon:    "◇",
+//	        Title:   "No open files",
+//	        Actions: []terma.EmptyPaneAction{
+//	            {Label: "Open a file", Key: "ctrl+p", OnPress: a.showFilePicker},
+//	            {Label: "Show all commands", Key: "ctrl+shift+p", OnPress: a.showCommands},
+//	        },
+//	        Watermark: "my-editor",
+//	    }
+//	}
+type EmptyPane struct {
+	ID        string            // Optional unique identifier
+	Icon      string            // Optional glyph or multi-line art shown above the title
+	Title     string            // Optional heading, shown in bold
+	Message   string            // Optional text shown muted under the title
+	Actions   []EmptyPaneAction // Optional quick actions listed under the message
+	Watermark string            // Optional text tiled diagonally across the pane
+	Style     Style             // Optional styling (default: fills the available space)
+}
+
+// WidgetID returns the empty pane's unique identifier.
+// Implements the Identifiable interface.
+func (p EmptyPane) WidgetID() string {
+	return p.ID
+}
+
+// Build composes the pane from a Stack of the watermark and a centered Column.
+func (p EmptyPane) Build(ctx BuildContext) Widget {
+	theme := ctx.Theme()
+
+	style := p.Style
+	if style.Width.IsUnset() {
+		style.Width = Flex(1)
+	}
+	if style.Height.IsUnset() {
+		style.Height = Flex(1)
+	}
+
+	var content []Widget
+	if p.Icon != "" {
+		content = append(content, Text{Content: p.Icon, TextAlign: TextAlignCenter, Style: Style{ForegroundColor: theme.TextMuted}})
+	}
+	if p.Title != "" {
+		content = append(content, Text{Content: p.Title, Style: Style{ForegroundColor: theme.Text, Bold: true}})
+	}
+	if p.Message != "" {
+		content = append(content, Text{Content: p.Message, TextAlign: TextAlignCenter, Style: Style{ForegroundColor: theme.TextMuted}})
+	}
+	if len(p.Actions) > 0 {
+		content = append(content, p.actions(theme))
+	}
+
+	column := Column{Spacing: 1, CrossAlign: CrossAxisCenter, Children: content}
+	if p.Watermark == "" {
+		return Stack{ID: p.ID, Style: style, Alignment: AlignCenter, Children: []Widget{column}}
+	}
+
+	// Clear the watermark from behind the content so it stays readable
+	var background ColorProvider = theme.Background
+	if style.BackgroundColor != nil && style.BackgroundColor.IsSet() {
+		background = style.BackgroundColor
+	}
+	column.Style = Style{BackgroundColor: background, Padding: EdgeInsetsXY(2, 1)}
+	return Stack{
+		ID:        p.ID,
+		Style:     style,
+		Alignment: AlignCenter,
+		Children: []Widget{
+			PositionedFill(emptyPaneWatermark{text: p.Watermark, color: theme.TextMuted.Blend(theme.Background, 0.6)}),
+			column,
+		},
+	}
+}
+
+// actions lists the quick actions with labels right-aligned against their
+// keys, so the keys line up.
+func (p EmptyPane) actions(theme ThemeData) Widget {
+	labelWidth := 0
+	for _, action := range p.Actions {
+		labelWidth = max(labelWidth, ansi.StringWidth(action.Label))
+	}
+
+	rows := make([]Widget, len(p.Actions))
+	for i, action := range p.Actions {
+		label := Text{
+			Content:   action.Label,
+			TextAlign: TextAlignRight,
+			Style:     Style{ForegroundColor: theme.TextMuted, Width: Cells(labelWidth)},
+		}
+		if action.OnPress != nil {
+			onPress := action.OnPress
+			label.Style.HoverStyle = &Style{ForegroundColor: theme.Text, Underline: UnderlineSingle}
+			label.Click = func(MouseEvent) { onPress() }
+		}
+		rows[i] = Row{Spacing: 2, Children: []Widget{
+			label,
+			Text{Content: action.Key, Style: Style{ForegroundColor: theme.Accent}},
+		}}
+	}
+	return Column{Children: rows}
+}
+
+// emptyPaneWatermark tiles text diagonally across its area.
+type emptyPaneWatermark struct {
+	text  string
+	color Color
+}
+
+// Build returns itself as emptyPaneWatermark is a leaf widget.
+func (w emptyPaneWatermark) Build(ctx BuildContext) Widget {
+	return w
+}
+
+// GetContentDimensions fills the available space.
+func (w emptyPaneWatermark) GetContentDimensions() (width, height Dimension) {
+	return Flex(1), Flex(1)
+}
+
+// BuildLayoutNode builds a layout node for the watermark.
+func (w emptyPaneWatermark) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return monitorLayoutNode(w, Style{})
+}
+
+// Render repeats the text along each row with a gap after each copy, each
+// row starting two cells further right than the one above.
+func (w emptyPaneWatermark) Render(ctx *RenderContext) {
+	textWidth := ansi.StringWidth(w.text)
+	if textWidth == 0 || ctx.Width <= 0 {
+		return
+	}
+	tile := w.text + strings.Repeat(" ", textWidth)
+	period := 2 * textWidth
+	style := Style{ForegroundColor: w.color}
+	for y := 0; y < ctx.Height; y++ {
+		for x := (2*y)%period - period; x < ctx.Width; x += period {
+			ctx.DrawStyledText(x, y, tile, style)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmptyPane_CentersContentAndAlignsActionKeys(t *testing.T) {
+	opened := 0
+	widget := EmptyPane{
+		Icon:    "◇",
+		Title:   "No open files",
+		Message: "Pick one",
+		Actions: []EmptyPaneAction{
+			{Label: "Open a file", Key: "ctrl+p", OnPress: func() { opened++ }},
+			{Label: "Commands", Key: "ctrl+shift+p"},
+		},
+	}
+
+	buf := uv.NewBuffer(30, 12)
+	renderer := NewRenderer(buf, 30, 12, NewFocusManager(), NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil))
+	renderer.Render(widget)
+
+	expected := []string{
+		"                              ",
+		"                              ",
+		"              ◇               ",
+		"                              ",
+		"        No open files         ",
+		"                              ",
+		"          Pick one            ",
+		"                              ",
+		"  Open a file  ctrl+p         ",
+		"     Commands  ctrl+shift+p   ",
+		"                              ",
+		"                              ",
+	}
+	for y, line := range expected {
+		assert.Equal(t, line, bufferLine(buf, y, 30), "line %d", y)
+	}
+
+	entry := renderer.WidgetAt(4, 8)
+	require.NotNil(t, entry)
+	clickable, ok := entry.EventWidget.(Clickable)
+	require.True(t, ok)
+	clickable.OnClick(MouseEvent{X: 4, Y: 8, ClickCount: 1})
+	assert.Equal(t, 1, opened)
+}
+
+func TestEmptyPane_WatermarkTilesDiagonallyBehindContent(t *testing.T) {
+	buf := renderToBufferWithFocus(EmptyPane{Title: "Empty", Watermark: "terma"}, 30, 6, "")
+
+	expected := []string{
+		"terma     terma     terma     ",
+		"  terma               terma   ",
+		"    terma   Empty       terma ",
+		"a     term         ma     term",
+		"rma     terma     terma     te",
+		"terma     terma     terma     ",
+	}
+	for y, line := range expected {
+		assert.Equal(t, line, bufferLine(buf, y, 30), "line %d", y)
+	}
+}
@@ -0,0 +1,250 @@
+package terma
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/darrenburns/terma/layout"
+)
+
+// errorBoundaryErrors holds the panic caught by each ErrorBoundary, keyed by
+// boundary ID. A boundary shows its error panel until a retry clears the entry.
+var errorBoundaryErrors sync.Map
+
+// errorHandler is the global hook called for every caught panic.
+var errorHandler func(boundaryID string, err Panic)
+
+// SetErrorHandler registers a function that is called whenever an
+// ErrorBoundary catches a panic, e.g. to report it to a crash service.
+// Pass nil to remove the handler.
+func SetErrorHandler(handler func(boundaryID string, err Panic)) {
+	errorHandler = handler
+}
+
+// ErrorBoundary isolates a subtree so that a panic while building, laying
+// out or rendering Child shows an error panel in its place instead of
+// tearing down the whole app. The panel shows the panic message and stack
+// trace, and a Retry button that rebuilds Child.
+//
+// Example:
+//
+//	ErrorBoundary{
+//	    ID:    "preview",
+//	    Child: MarkdownPreview{Source: doc},
+//	}
+type ErrorBoundary struct {
+	ID       string                               // Optional unique identifier (keys the caught error)
+	Child    Widget                               // The subtree to protect
+	Fallback func(err Panic, retry func()) Widget // Optional custom error panel
+	OnError  func(err Panic)                      // Optional callback when a panic is caught
+	Style    Style                                // Optional styling for the default error panel
+}
+
+// WidgetID returns the widget's unique identifier.
+func (b ErrorBoundary) WidgetID() string {
+	return b.ID
+}
+
+// boundaryID returns the key the boundary's error is stored under.
+func (b ErrorBoundary) boundaryID(ctx BuildContext) string {
+	if b.ID != "" {
+		return b.ID
+	}
+	return ctx.AutoID()
+}
+
+// caughtError returns the panic currently shown by the boundary, if any.
+func (b ErrorBoundary) caughtError(id string) (Panic, bool) {
+	if value, ok := errorBoundaryErrors.Load(id); ok {
+		return value.(Panic), true
+	}
+	return Panic{}, false
+}
+
+// catch records a recovered panic and reports it to OnError and the
+// global error handler.
+func (b ErrorBoundary) catch(id string, value any) Panic {
+	err := Panic{
+		Message:    fmt.Sprint(value),
+		StackTrace: panicStack(debug.Stack()),
+	}
+	errorBoundaryErrors.Store(id, err)
+	LogError("ErrorBoundary caught panic", Field("boundary", id), Field("panic", err.Message))
+	if b.OnError != nil {
+		b.OnError(err)
+	}
+	if errorHandler != nil {
+		errorHandler(id, err)
+	}
+	return err
+}
+
+// panicStack trims the recovery frames from a stack captured in a deferred
+// recover, so the trace starts at the code that panicked.
+func panicStack(stack []byte) string {
+	trace := string(stack)
+	if i := strings.Index(trace, "\npanic("); i >= 0 {
+		rest := trace[i+1:]
+		// Skip the panic call and its file:line.
+		for range 2 {
+			if j := strings.IndexByte(rest, '\n'); j >= 0 {
+				rest = rest[j+1:]
+			}
+		}
+		return rest
+	}
+	return trace
+}
+
+// RetryErrorBoundary clears the error caught by the boundary with the given
+// ID so its child is built again on the next render.
+func RetryErrorBoundary(id string) {
+	errorBoundaryErrors.Delete(id)
+	scheduleRender()
+}
+
+// fallback returns the widget shown in place of Child after a panic.
+func (b ErrorBoundary) fallback(ctx BuildContext, id string, err Panic) Widget {
+	retry := func() { RetryErrorBoundary(id) }
+	if b.Fallback != nil {
+		return b.Fallback(err, retry)
+	}
+
+	theme := ctx.Theme()
+	style := b.Style
+	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
+		style.BackgroundColor = theme.ErrorBg
+	}
+	if style.ForegroundColor == nil || !style.ForegroundColor.IsSet() {
+		style.ForegroundColor = theme.Text
+	}
+	if style.Padding == (EdgeInsets{}) {
+		style.Padding = EdgeInsetsXY(1, 0)
+	}
+	if style.Border.IsZero() {
+		style.Border = RoundedBorder(theme.Error, BorderTitle("Error"))
+	}
+	if style.Width.IsUnset() {
+		style.Width = Flex(1)
+	}
+	if style.Height.IsUnset() {
+		style.Height = Flex(1)
+	}
+
+	return Column{
+		Spacing: 1,
+		Style:   style,
+		Children: []Widget{
+			Row{
+				Spacing: 2,
+				Children: []Widget{
+					Text{
+						Content: "panic: " + err.Message,
+						Style:   Style{ForegroundColor: theme.ErrorText, Bold: true, Width: Flex(1)},
+					},
+					Button{ID: id + "-retry", Label: "Retry", OnPress: retry},
+				},
+			},
+			Text{
+				Content: strings.TrimSpace(err.StackTrace),
+				Style:   Style{ForegroundColor: theme.TextMuted},
+			},
+		},
+	}
+}
+
+// Build returns Child's built widget, or the error panel once a panic has
+// been caught. ErrorBoundary is handled by BuildRenderTree directly; this
+// method is used when a parent measures the boundary during layout.
+func (b ErrorBoundary) Build(ctx BuildContext) (built Widget) {
+	id := b.boundaryID(ctx)
+	if err, ok := b.caughtError(id); ok {
+		return b.fallback(ctx, id, err)
+	}
+	if b.Child == nil {
+		return EmptyWidget{}
+	}
+	defer func() {
+		if value := recover(); value != nil {
+			built = b.fallback(ctx, id, b.catch(id, value))
+		}
+	}()
+	return errorBoundaryLayout{boundary: b, id: id, child: b.Child.Build(ctx)}
+}
+
+// errorBoundaryLayout guards the layout pass of a boundary's built child,
+// which builds the rest of the subtree.
+type errorBoundaryLayout struct {
+	boundary ErrorBoundary
+	id       string
+	child    Widget
+}
+
+// Build returns itself; the child has already been built.
+func (l errorBoundaryLayout) Build(ctx BuildContext) Widget {
+	return l
+}
+
+// BuildLayoutNode builds the child's layout node, substituting the error
+// panel's node if that panics.
+func (l errorBoundaryLayout) BuildLayoutNode(ctx BuildContext) (node layout.LayoutNode) {
+	defer func() {
+		if value := recover(); value != nil {
+			panel := l.boundary.fallback(ctx, l.id, l.boundary.catch(l.id, value)).Build(ctx)
+			if builder, ok := panel.(LayoutNodeBuilder); ok {
+				node = builder.BuildLayoutNode(ctx)
+			} else {
+				node = buildFallbackLayoutNode(panel, ctx)
+			}
+		}
+	}()
+	if builder, ok := l.child.(LayoutNodeBuilder); ok {
+		return builder.BuildLayoutNode(ctx)
+	}
+	return buildFallbackLayoutNode(l.child, ctx)
+}
+
+// buildErrorBoundary builds the render tree for a boundary. A panic while
+// building Child's tree is recovered and replaced by the error panel, and
+// the returned tree recovers panics from Child's render pass the same way.
+func buildErrorBoundary(b ErrorBoundary, ctx BuildContext, constraints layout.Constraints, fc *FocusCollector) (tree RenderTree) {
+	id := b.boundaryID(ctx)
+	if err, ok := b.caughtError(id); ok || b.Child == nil {
+		if !ok {
+			return BuildRenderTree(EmptyWidget{}, ctx, constraints, fc)
+		}
+		return BuildRenderTree(b.fallback(ctx, id, err), ctx, constraints, fc)
+	}
+
+	// Discard focusables and floats registered by a subtree that panicked.
+	focusables := 0
+	if fc != nil {
+		focusables = fc.Len()
+	}
+	floats := 0
+	if ctx.floatCollector != nil {
+		floats = ctx.floatCollector.Len()
+	}
+	defer func() {
+		if value := recover(); value != nil {
+			if fc != nil {
+				fc.focusables = fc.focusables[:focusables]
+			}
+			if ctx.floatCollector != nil {
+				ctx.floatCollector.entries = ctx.floatCollector.entries[:floats]
+			}
+			err := b.catch(id, value)
+			tree = BuildRenderTree(b.fallback(ctx, id, err), ctx, constraints, fc)
+		}
+	}()
+
+	tree = BuildRenderTree(b.Child, ctx, constraints, fc)
+	tree.recoverRender = func(value any) RenderTree {
+		err := b.catch(id, value)
+		size := layout.Tight(tree.Layout.Box.BorderBoxWidth(), tree.Layout.Box.BorderBoxHeight())
+		return BuildRenderTree(b.fallback(ctx, id, err), ctx, size, nil)
+	}
+	return tree
+}
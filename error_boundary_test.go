@@ -0,0 +1,120 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// panicky panics in Build, or in Render when duringRender is set.
+type panicky struct {
+	duringRender bool
+}
+
+func (p panicky) Build(ctx BuildContext) Widget {
+	if !p.duringRender {
+		panic("build exploded")
+	}
+	return p
+}
+
+func (p panicky) Render(ctx *RenderContext) {
+	panic("render exploded")
+}
+
+// screenText returns the buffer's lines joined with newlines.
+func screenText(widget Widget, width, height int) string {
+	buf := RenderToBuffer(widget, width, height)
+	lines := make([]string, height)
+	for y := range height {
+		lines[y] = bufferLine(buf, y, width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestErrorBoundary_CatchesBuildPanic(t *testing.T) {
+	t.Cleanup(func() { errorBoundaryErrors.Delete("build") })
+
+	var caught []Panic
+	widget := Column{Children: []Widget{
+		Text{Content: "above"},
+		ErrorBoundary{
+			ID:      "build",
+			Child:   panicky{},
+			OnError: func(err Panic) { caught = append(caught, err) },
+		},
+	}}
+
+	screen := screenText(widget, 50, 8)
+	assert.Contains(t, screen, "above")
+	assert.Contains(t, screen, "panic: build exploded")
+	assert.Contains(t, screen, "Retry")
+	require.Len(t, caught, 1, "reported once; later renders show the stored error")
+	assert.True(t, strings.HasPrefix(caught[0].StackTrace, "github.com/darrenburns/terma.panicky.Build"), "recovery frames are trimmed")
+}
+
+func TestErrorBoundary_CatchesNestedAndRenderPanics(t *testing.T) {
+	t.Cleanup(func() {
+		errorBoundaryErrors.Delete("nested")
+		errorBoundaryErrors.Delete("render")
+	})
+
+	nested := ErrorBoundary{ID: "nested", Child: Column{Children: []Widget{
+		Text{Content: "ok"},
+		Row{Children: []Widget{panicky{}}},
+	}}}
+	assert.Contains(t, screenText(nested, 50, 6), "panic: build exploded")
+
+	render := Row{Children: []Widget{
+		Text{Content: "left", Style: Style{Width: Cells(6)}},
+		ErrorBoundary{ID: "render", Child: panicky{duringRender: true}},
+	}}
+	screen := screenText(render, 50, 6)
+	assert.True(t, strings.HasPrefix(screen, "left"))
+	assert.Contains(t, screen, "panic: render exploded")
+}
+
+func TestErrorBoundary_RetryAndGlobalHandler(t *testing.T) {
+	t.Cleanup(func() {
+		errorBoundaryErrors.Delete("retry")
+		SetErrorHandler(nil)
+	})
+
+	var handled []string
+	SetErrorHandler(func(boundaryID string, err Panic) {
+		handled = append(handled, boundaryID+": "+err.Message)
+	})
+
+	fail := true
+	child := func() Widget {
+		if fail {
+			return panicky{}
+		}
+		return Text{Content: "recovered"}
+	}
+
+	assert.Contains(t, screenText(ErrorBoundary{ID: "retry", Child: child()}, 40, 6), "build exploded")
+	assert.Equal(t, []string{"retry: build exploded"}, handled)
+
+	fail = false
+	assert.Contains(t, screenText(ErrorBoundary{ID: "retry", Child: child()}, 40, 6), "build exploded",
+		"the error panel stays until retried")
+
+	RetryErrorBoundary("retry")
+	assert.Contains(t, screenText(ErrorBoundary{ID: "retry", Child: child()}, 40, 6), "recovered")
+}
+
+func TestErrorBoundary_CustomFallback(t *testing.T) {
+	t.Cleanup(func() { errorBoundaryErrors.Delete("custom") })
+
+	widget := ErrorBoundary{
+		ID:    "custom",
+		Child: panicky{},
+		Fallback: func(err Panic, retry func()) Widget {
+			return Text{Content: "sorry: " + err.Message}
+		},
+	}
+	assert.Equal(t, "sorry: build exploded", strings.TrimSpace(bufferLine(RenderToBuffer(widget, 30, 1), 0, 30)))
+}
@@ -0,0 +1,146 @@
+package terma
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Topic is a named stream of events carrying payloads of type T, for parts
+// of an app that don't hold references to one another, such as a sidebar
+// telling an editor and a status bar which file was opened. Declare each
+// topic once, as a package-level variable, and share it:
+//
+//	var FileOpened = t.NewTopic[string]("file-opened")
+//
+//	// In the sidebar:
+//	t.Emit(FileOpened, entry.Path)
+//
+//	// In the status bar's Build:
+//	t.Subscribe(ctx, FileOpened, func(path string) { s.path.Set(path) })
+type Topic[T any] struct {
+	key *topicKey
+}
+
+// topicKey identifies a topic, so that topics are distinct even when
+// their names are the same.
+type topicKey struct {
+	name string
+}
+
+// NewTopic creates a topic. The name is only used to describe it.
+func NewTopic[T any](name string) Topic[T] {
+	return Topic[T]{key: &topicKey{name: name}}
+}
+
+// Name returns the name the topic was created with.
+func (t Topic[T]) Name() string {
+	if t.key == nil {
+		return ""
+	}
+	return t.key.name
+}
+
+// subscription is a handler for one topic.
+type subscription struct {
+	handler func(payload any)
+	seq     uint64     // Order of the first subscription, for delivery
+	frame   frameStamp // Render pass the subscribing widget was last built in; zero for Listen
+}
+
+// eventBus holds the subscriptions of every topic, by the ID of the
+// widget that made them.
+var eventBus struct {
+	sync.Mutex
+	seq    uint64
+	topics map[*topicKey]map[string]*subscription
+}
+
+// Subscribe calls handler with the payload of each event emitted on topic
+// for as long as the widget being built stays in the tree. Call it from
+// Build on every build: the subscription is kept with the latest handler,
+// and dropped once its renderer renders a frame without the widget.
+// Renders elsewhere, such as RenderToBuffer, don't end it. A widget has
+// one subscription per topic.
+//
+// Handlers run on the goroutine that calls Emit, usually the event loop.
+func Subscribe[T any](ctx BuildContext, topic Topic[T], handler func(payload T)) {
+	if topic.key == nil || handler == nil {
+		return
+	}
+	subscribe(topic.key, ctx.ScopedID(), ctx.frame, func(payload any) {
+		handler(payload.(T))
+	})
+}
+
+// Listen calls handler with the payload of each event emitted on topic
+// until the returned function is called. Unlike Subscribe it isn't tied to
+// a widget, so it suits app-level services.
+func Listen[T any](topic Topic[T], handler func(payload T)) (cancel func()) {
+	if topic.key == nil || handler == nil {
+		return func() {}
+	}
+	eventBus.Lock()
+	eventBus.seq++
+	id := fmt.Sprintf("_listen:%d", eventBus.seq)
+	eventBus.Unlock()
+	subscribe(topic.key, id, frameStamp{}, func(payload any) {
+		handler(payload.(T))
+	})
+	return func() {
+		eventBus.Lock()
+		defer eventBus.Unlock()
+		delete(eventBus.topics[topic.key], id)
+	}
+}
+
+// subscribe adds or renews a subscription.
+func subscribe(key *topicKey, id string, frame frameStamp, handler func(payload any)) {
+	eventBus.Lock()
+	defer eventBus.Unlock()
+	if eventBus.topics == nil {
+		eventBus.topics = map[*topicKey]map[string]*subscription{}
+	}
+	subs := eventBus.topics[key]
+	if subs == nil {
+		subs = map[string]*subscription{}
+		eventBus.topics[key] = subs
+	}
+	sub := subs[id]
+	if sub == nil {
+		eventBus.seq++
+		sub = &subscription{seq: eventBus.seq}
+		subs[id] = sub
+	}
+	sub.handler, sub.frame = handler, frame
+}
+
+// Emit calls the handlers subscribed to topic with payload, in the order
+// they first subscribed. Subscriptions of widgets missing from their
+// renderer's last frame are dropped first. Emit from event handlers and
+// callbacks rather than from Build.
+func Emit[T any](topic Topic[T], payload T) {
+	if topic.key == nil {
+		return
+	}
+	eventBus.Lock()
+	subs := eventBus.topics[topic.key]
+	live := make([]*subscription, 0, len(subs))
+	for id, sub := range subs {
+		if sub.frame.counter != nil && !sub.frame.current() {
+			delete(subs, id)
+			continue
+		}
+		live = append(live, sub)
+	}
+	sort.Slice(live, func(i, j int) bool { return live[i].seq < live[j].seq })
+	handlers := make([]func(any), len(live))
+	for i, sub := range live {
+		handlers[i] = sub.handler
+	}
+	eventBus.Unlock()
+
+	for _, handler := range handlers {
+		handler(payload)
+	}
+}
@@ -0,0 +1,78 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// eventsTestWidget shows the last payload it received on a topic.
+type eventsTestWidget struct {
+	topic    Topic[string]
+	received *[]string
+}
+
+func (w eventsTestWidget) Build(ctx BuildContext) Widget {
+	Subscribe(ctx, w.topic, func(payload string) {
+		*w.received = append(*w.received, payload)
+	})
+	return Text{Content: "listening"}
+}
+
+func TestEvents_SubscriptionLastsAsLongAsTheWidget(t *testing.T) {
+	topic := NewTopic[string]("test")
+	var first, second []string
+	tree := Column{Children: []Widget{
+		eventsTestWidget{topic: topic, received: &first},
+		eventsTestWidget{topic: topic, received: &second},
+	}}
+	renderer, _ := portalTestRenderer(20, 2)
+
+	Emit(topic, "before")
+	renderer.Render(tree)
+	Emit(topic, "one")
+	renderer.Render(tree)
+	Emit(topic, "two")
+	assert.Equal(t, []string{"one", "two"}, first, "rebuilding keeps one subscription")
+	assert.Equal(t, []string{"one", "two"}, second)
+
+	renderer.Render(Column{Children: []Widget{eventsTestWidget{topic: topic, received: &first}}})
+	Emit(topic, "three")
+	assert.Equal(t, []string{"one", "two", "three"}, first)
+	assert.Equal(t, []string{"one", "two"}, second, "a widget gone from the tree is unsubscribed")
+
+	Emit(NewTopic[string]("test"), "other")
+	assert.Equal(t, []string{"one", "two", "three"}, first, "topics with the same name are distinct")
+}
+
+func TestEvents_OtherRenderersDontEndSubscriptions(t *testing.T) {
+	topic := NewTopic[string]("test")
+	var received []string
+	renderer, _ := portalTestRenderer(20, 1)
+	renderer.Render(eventsTestWidget{topic: topic, received: &received})
+
+	RenderReportPlain(Text{Content: "a report"}, 40)
+	RenderToBuffer(Text{Content: "a snapshot"}, 20, 1)
+	Emit(topic, "one")
+	assert.Equal(t, []string{"one"}, received)
+
+	renderer.Render(Text{Content: "gone"})
+	Emit(topic, "two")
+	assert.Equal(t, []string{"one"}, received)
+}
+
+func TestEvents_Listen(t *testing.T) {
+	topic := NewTopic[int]("count")
+	var total int
+	cancel := Listen(topic, func(n int) { total += n })
+
+	Emit(topic, 2)
+	RenderToBuffer(Text{Content: "frames don't end listeners"}, 30, 1)
+	Emit(topic, 3)
+	assert.Equal(t, 5, total)
+
+	cancel()
+	Emit(topic, 4)
+	assert.Equal(t, 5, total)
+	assert.Equal(t, "count", topic.Name())
+}
@@ -0,0 +1,579 @@
+package terma
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	defaultFilePickerWidth       = 70
+	defaultFilePickerHeight      = 20
+	defaultFilePickerPlaceholder = "Type to filter..."
+	defaultFilePickerEmptyLabel  = "No files"
+)
+
+// filePickerRowKind identifies what a row in the file list shows.
+type filePickerRowKind int
+
+const (
+	filePickerRowEntry  filePickerRowKind = iota // A file or directory
+	filePickerRowParent                          // ".." leading to the parent directory
+	filePickerRowCreate                          // The path of a new file, named by the query
+)
+
+// filePickerRow is one row of the file list.
+type filePickerRow struct {
+	kind  filePickerRowKind
+	entry DirectoryEntry
+	match MatchResult
+}
+
+// FilePickerState holds the directory, the query and the marked files of a
+// FilePicker. The directory is kept when the picker closes, so it opens
+// where it was left.
+type FilePickerState struct {
+	Visible    Signal[bool]
+	ShowHidden Signal[bool] // List entries whose names start with "."
+
+	dir     Signal[string]
+	input   *TextInputState
+	list    *ListState[filePickerRow]
+	scroll  *ScrollState
+	marked  AnySignal[[]string] // Files marked for a multi-select pick, in the order marked
+	message Signal[string]      // Error shown below the list
+
+	entries  []DirectoryEntry // Listing of dir, read once per directory
+	patterns []string         // Glob patterns of the FilePicker last built
+	newFile  bool             // NewFile of the FilePicker last built
+
+	wasVisible  bool
+	lastFocusID string
+}
+
+// NewFilePickerState creates a picker that opens in dir, or in the working
+// directory if dir is "".
+func NewFilePickerState(dir string) *FilePickerState {
+	s := &FilePickerState{
+		Visible:    NewSignal(false),
+		ShowHidden: NewSignal(false),
+		dir:        NewSignal(""),
+		input:      NewTextInputState(""),
+		list:       NewListState([]filePickerRow{}),
+		scroll:     NewScrollState(),
+		marked:     NewAnySignal([]string(nil)),
+		message:    NewSignal(""),
+	}
+	s.SetDir(dir)
+	return s
+}
+
+// Open shows the picker, listing its directory afresh.
+func (s *FilePickerState) Open() {
+	if s == nil {
+		return
+	}
+	s.SetDir(s.Dir())
+	s.Visible.Set(true)
+}
+
+// Close hides the picker and clears the query and the marked files.
+func (s *FilePickerState) Close() {
+	if s == nil {
+		return
+	}
+	s.Visible.Set(false)
+	s.input.SetText("")
+	s.marked.Set(nil)
+	s.message.Set("")
+	s.refresh()
+}
+
+// Dir returns the directory being listed.
+func (s *FilePickerState) Dir() string {
+	if s == nil {
+		return ""
+	}
+	return s.dir.Peek()
+}
+
+// SetDir lists dir, made absolute, and clears the query.
+func (s *FilePickerState) SetDir(dir string) {
+	if s == nil {
+		return
+	}
+	if dir == "" {
+		dir = "."
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	entries, err := defaultDirectoryReadDir(dir)
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+	defaultDirectorySort(entries)
+	s.entries = entries
+	s.dir.Set(dir)
+	s.message.Set(message)
+	s.input.SetText("")
+	s.refresh()
+}
+
+// ToggleHidden shows or hides entries whose names start with ".".
+func (s *FilePickerState) ToggleHidden() {
+	if s == nil {
+		return
+	}
+	s.ShowHidden.Update(func(show bool) bool { return !show })
+	s.refresh()
+}
+
+// Marked returns the paths of the files marked in multi-select mode.
+func (s *FilePickerState) Marked() []string {
+	if s == nil {
+		return nil
+	}
+	return slices.Clone(s.marked.Peek())
+}
+
+// ToggleMarked marks the file at path for a multi-select pick, or unmarks
+// it.
+func (s *FilePickerState) ToggleMarked(path string) {
+	if s == nil {
+		return
+	}
+	s.marked.Update(func(marked []string) []string {
+		if i := slices.Index(marked, path); i >= 0 {
+			return slices.Delete(slices.Clone(marked), i, i+1)
+		}
+		return append(slices.Clone(marked), path)
+	})
+}
+
+// isMarked reports whether the file at path is marked.
+func (s *FilePickerState) isMarked(path string) bool {
+	return slices.Contains(s.marked.Peek(), path)
+}
+
+// refresh rebuilds the file list from the listing and the query, with the
+// cursor on the first row.
+func (s *FilePickerState) refresh() {
+	query := s.input.GetText()
+	dir := s.dir.Peek()
+	var rows []filePickerRow
+	if query == "" {
+		if parent := filepath.Dir(dir); parent != dir {
+			rows = append(rows, filePickerRow{kind: filePickerRowParent, entry: DirectoryEntry{Name: "..", Path: parent, IsDir: true}})
+		}
+	}
+	if s.newFile && query != "" && !strings.ContainsRune(query, filepath.Separator) &&
+		!slices.ContainsFunc(s.entries, func(entry DirectoryEntry) bool { return entry.Name == query }) {
+		rows = append(rows, filePickerRow{kind: filePickerRowCreate, entry: DirectoryEntry{Name: query, Path: filepath.Join(dir, query)}})
+	}
+	showHidden := s.ShowHidden.Peek()
+	for _, entry := range s.entries {
+		if !showHidden && strings.HasPrefix(entry.Name, ".") {
+			continue
+		}
+		if !entry.IsDir && !filePickerGlobMatch(s.patterns, entry.Name) {
+			continue
+		}
+		row := filePickerRow{entry: entry}
+		if query != "" {
+			if row.match = MatchString(entry.Name, query, FilterOptions{SmartCase: true}); !row.match.Matched {
+				continue
+			}
+		}
+		rows = append(rows, row)
+	}
+	s.list.SetItems(rows)
+	s.list.SelectIndex(0)
+	s.scroll.SetOffset(0)
+}
+
+// filePickerGlobMatch reports whether name matches any of patterns, or
+// whether there are none.
+func filePickerGlobMatch(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// FilePicker is a dialog for choosing files: it lists a directory with
+// directories first, and typing filters the list. Enter on a directory
+// opens it, as does typing its name followed by a separator, and Backspace
+// on an empty query goes up to the parent.
+//
+// Patterns restrict the files listed to those matching a glob, such as
+// "*.go"; directories are always listed so they can be browsed. Alt+H
+// shows or hides entries starting with ".".
+//
+// With MultiSelect, Tab marks the file under the cursor, across
+// directories, and Enter picks the marked files. With NewFile, typing a
+// name that isn't listed offers it as a new file at the top of the list,
+// for a "save as" dialog.
+//
+// Example:
+//
+//	a.picker = t.NewFilePickerState("")
+//
+//	// In Build, with a keybind calling a.picker.Open():
+//	t.FilePicker{
+//	    ID:          "open",
+//	    State:       a.picker,
+//	    Patterns:    []string{"*.md", "*.txt"},
+//	    MultiSelect: true,
+//	    OnSelect:    a.openFiles,
+//	}
+type FilePicker struct {
+	ID            string
+	State         *FilePickerState
+	OnSelect      func(paths []string) // Called with the absolute paths chosen; the picker closes first
+	OnDismiss     func()               // Called when the picker closes without a choice
+	Title         string               // Shown before the directory (default: "Open" or, with NewFile, "Save")
+	Patterns      []string             // Glob patterns files must match to be listed (default: all files)
+	MultiSelect   bool                 // Tab marks files and Enter picks every marked file
+	NewFile       bool                 // Offer the typed name as a new file when no entry has it
+	Placeholder   string               // Default: "Type to filter..."
+	BackdropColor Color                // Optional modal backdrop color override (default: theme.Overlay)
+	Style         Style                // Optional styling (default: Cells(70) wide, at most Cells(20) tall)
+}
+
+// Build renders the picker as a floating modal.
+func (p FilePicker) Build(ctx BuildContext) Widget {
+	if p.State == nil {
+		return EmptyWidget{}
+	}
+	if !slices.Equal(p.State.patterns, p.Patterns) || p.State.newFile != p.NewFile {
+		p.State.patterns, p.State.newFile = slices.Clone(p.Patterns), p.NewFile
+		p.State.refresh()
+	}
+
+	visible := p.State.Visible.Get()
+	if visible && !p.State.wasVisible {
+		if focused := ctx.Focused(); focused != nil {
+			if identifiable, ok := focused.(Identifiable); ok {
+				p.State.lastFocusID = identifiable.WidgetID()
+			}
+		}
+	}
+	if visible {
+		RequestFocus(p.inputID())
+	} else if p.State.wasVisible && p.State.lastFocusID != "" {
+		RequestFocus(p.State.lastFocusID)
+	}
+	p.State.wasVisible = visible
+
+	if !visible {
+		return EmptyWidget{}
+	}
+
+	theme := ctx.Theme()
+	backdropColor := theme.Overlay
+	if p.BackdropColor.IsSet() {
+		backdropColor = p.BackdropColor
+	}
+	float := Floating{
+		Visible: true,
+		Config: FloatConfig{
+			Position:              FloatPositionTopCenter,
+			Offset:                Offset{Y: defaultCommandPaletteTopOffsetY},
+			Modal:                 true,
+			DismissOnEsc:          BoolPtr(false),
+			DismissOnClickOutside: BoolPtr(true),
+			OnDismiss:             p.dismiss,
+			BackdropColor:         backdropColor,
+		},
+		Child: p.buildContent(theme),
+	}
+	return float.Build(ctx)
+}
+
+func (p FilePicker) buildContent(theme ThemeData) Widget {
+	style := p.Style
+	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
+		style.BackgroundColor = theme.Token(TokenSurfaceFloating)
+	}
+	if style.Width.IsUnset() {
+		style.Width = Cells(defaultFilePickerWidth)
+	}
+	if style.Height.IsUnset() {
+		style.Height = Auto
+	}
+	if style.MaxHeight.IsUnset() {
+		style.MaxHeight = Cells(defaultFilePickerHeight)
+	}
+
+	children := []Widget{p.buildHeader(theme), p.buildInput(theme), p.buildList(theme, style)}
+	if message := p.State.message.Get(); message != "" {
+		children = append(children, Text{
+			Content: message,
+			Style:   Style{ForegroundColor: theme.Error, Padding: EdgeInsetsXY(1, 0), Width: Flex(1)},
+		})
+	}
+	return Column{
+		ID:         p.ID + "-content",
+		CrossAlign: CrossAxisStretch,
+		Style:      style,
+		Children:   children,
+	}
+}
+
+// buildHeader shows the title, the directory listed and how many files
+// are marked.
+func (p FilePicker) buildHeader(theme ThemeData) Widget {
+	spans := []Span{
+		BoldSpan(p.titleText()),
+		ColorSpan("  "+p.State.dir.Get(), theme.TextMuted),
+	}
+	if marked := len(p.State.marked.Get()); p.MultiSelect && marked > 0 {
+		spans = append(spans, ColorSpan(fmt.Sprintf("  %d marked", marked), theme.Accent))
+	}
+	return Text{
+		Spans: spans,
+		Style: Style{ForegroundColor: theme.Text, Padding: EdgeInsets{Top: 1, Left: 1, Right: 1}, Width: Flex(1)},
+	}
+}
+
+func (p FilePicker) buildInput(theme ThemeData) Widget {
+	keybinds := []Keybind{
+		{Key: "up", Action: p.State.list.SelectPrevious, Hidden: true},
+		{Key: "down", Action: p.State.list.SelectNext, Hidden: true},
+		{Key: "ctrl+p", Action: p.State.list.SelectPrevious, Hidden: true},
+		{Key: "ctrl+n", Action: p.State.list.SelectNext, Hidden: true},
+		{Key: "enter", Action: p.selectCurrent, Hidden: true},
+		{Key: "backspace", Action: p.backspace, Hidden: true},
+		{Key: "escape", Action: p.dismiss, Hidden: true},
+		{Key: "alt+h", Name: "Hidden files", Action: p.State.ToggleHidden},
+	}
+	if p.MultiSelect {
+		keybinds = append(keybinds, Keybind{Key: "tab", Name: "Mark", Action: p.markCurrent})
+	}
+	return TextInput{
+		ID:          p.inputID(),
+		State:       p.State.input,
+		Placeholder: p.placeholderText(),
+		Style: Style{
+			BackgroundColor: theme.Token(TokenSurfaceFloating),
+			ForegroundColor: theme.Text,
+			Padding:         commandPaletteInputPadding(),
+			Width:           Flex(1),
+		},
+		OnChange:      p.queryChanged,
+		ExtraKeybinds: keybinds,
+	}
+}
+
+func (p FilePicker) buildList(theme ThemeData, containerStyle Style) Widget {
+	rows := p.State.list.Items.Get()
+	p.State.marked.Get()
+
+	var listChild Widget
+	if len(rows) == 0 {
+		listChild = Text{
+			Content:   defaultFilePickerEmptyLabel,
+			TextAlign: TextAlignCenter,
+			Style: Style{
+				ForegroundColor: theme.TextMuted,
+				Padding:         EdgeInsetsXY(1, 0),
+				Width:           Flex(1),
+			},
+		}
+	} else {
+		listChild = List[filePickerRow]{
+			ID:          p.listID(),
+			State:       p.State.list,
+			ScrollState: p.State.scroll,
+			RenderItem: func(row filePickerRow, active bool, selected bool) Widget {
+				return p.renderRow(theme, row, active)
+			},
+			Style: Style{BackgroundColor: theme.Token(TokenSurfaceFloating)},
+		}
+	}
+
+	listStyle := Style{BackgroundColor: theme.Token(TokenSurfaceFloating)}
+	if maxHeight := containerStyle.MaxHeight; maxHeight.IsCells() {
+		// The header and the input
+		used := 2 + 1 + commandPaletteInputPadding().Vertical()
+		listStyle.MaxHeight = Cells(max(0, maxHeight.CellsValue()-used))
+	} else {
+		listStyle.Height = Flex(1)
+	}
+	return Scrollable{
+		ID:    p.scrollID(),
+		State: p.State.scroll,
+		Style: listStyle,
+		Child: listChild,
+	}
+}
+
+func (p FilePicker) renderRow(theme ThemeData, row filePickerRow, active bool) Widget {
+	labelColor, dirColor, mutedColor := theme.Text, theme.Accent, theme.TextMuted
+	if active {
+		labelColor, dirColor, mutedColor = theme.SelectionText, theme.SelectionText, theme.SelectionText
+	}
+	rowStyle := Style{ForegroundColor: labelColor, Padding: EdgeInsets{Left: 1, Right: 1}, Width: Flex(1)}
+	if active {
+		rowStyle.BackgroundColor = theme.ActiveCursor
+	}
+
+	var spans []Span
+	if p.MultiSelect {
+		marker := "  "
+		if row.kind == filePickerRowEntry && !row.entry.IsDir && p.State.isMarked(row.entry.Path) {
+			marker = "✓ "
+		}
+		spans = append(spans, ColorSpan(marker, dirColor))
+	}
+	switch {
+	case row.kind == filePickerRowCreate:
+		spans = append(spans, ColorSpan("+ ", dirColor), PlainSpan(row.entry.Name), ColorSpan("  new file", mutedColor))
+	case row.entry.IsDir:
+		name := []Span{ColorSpan(row.entry.Name, dirColor)}
+		if row.match.Matched && len(row.match.Ranges) > 0 {
+			name = HighlightSpans(row.entry.Name, row.match.Ranges, MatchHighlightStyle(theme))
+		}
+		spans = append(spans, name...)
+		spans = append(spans, ColorSpan(string(filepath.Separator), dirColor))
+	default:
+		name := []Span{PlainSpan(row.entry.Name)}
+		if row.match.Matched && len(row.match.Ranges) > 0 {
+			name = HighlightSpans(row.entry.Name, row.match.Ranges, MatchHighlightStyle(theme))
+		}
+		spans = append(spans, name...)
+	}
+	return Text{
+		Spans: spans,
+		Style: rowStyle,
+		Click: p.clickRow(row),
+	}
+}
+
+// clickRow returns a handler that moves the cursor to row and chooses it.
+func (p FilePicker) clickRow(row filePickerRow) func(MouseEvent) {
+	return func(MouseEvent) {
+		if i := slices.IndexFunc(p.State.list.GetItems(), func(r filePickerRow) bool {
+			return r.kind == row.kind && r.entry.Path == row.entry.Path
+		}); i >= 0 {
+			p.State.list.SelectIndex(i)
+			p.selectCurrent()
+		}
+	}
+}
+
+// queryChanged filters the list by the query, and opens the directory it
+// names when it ends with a separator.
+func (p FilePicker) queryChanged(query string) {
+	if name, ok := strings.CutSuffix(query, string(filepath.Separator)); ok && name != "" {
+		dir := filepath.Join(p.State.Dir(), name)
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			p.State.SetDir(dir)
+			return
+		}
+	}
+	p.State.refresh()
+}
+
+// backspace deletes before the cursor, or goes up to the parent directory
+// when the query is empty.
+func (p FilePicker) backspace() {
+	input := p.State.input
+	if input.GetText() == "" {
+		p.State.SetDir(filepath.Dir(p.State.Dir()))
+		return
+	}
+	if !input.DeleteSelection() {
+		input.DeleteBackward()
+	}
+	p.State.refresh()
+}
+
+// markCurrent marks or unmarks the file under the cursor and moves down.
+func (p FilePicker) markCurrent() {
+	row, ok := p.State.list.SelectedItem()
+	if !ok || row.kind != filePickerRowEntry || row.entry.IsDir {
+		return
+	}
+	p.State.ToggleMarked(row.entry.Path)
+	p.State.list.SelectNext()
+}
+
+// selectCurrent chooses the row under the cursor: a directory is opened,
+// and a file is picked along with the marked files. In multi-select mode
+// with files marked, the marked files are picked wherever the cursor is.
+func (p FilePicker) selectCurrent() {
+	row, ok := p.State.list.SelectedItem()
+	marked := p.State.Marked()
+	if ok && row.entry.IsDir {
+		p.State.SetDir(row.entry.Path)
+		return
+	}
+	var paths []string
+	switch {
+	case p.MultiSelect && len(marked) > 0:
+		paths = marked
+	case ok:
+		paths = []string{row.entry.Path}
+	default:
+		return
+	}
+	p.State.Close()
+	if p.OnSelect != nil {
+		p.OnSelect(paths)
+	}
+}
+
+func (p FilePicker) dismiss() {
+	p.State.Close()
+	if p.OnDismiss != nil {
+		p.OnDismiss()
+	}
+}
+
+func (p FilePicker) titleText() string {
+	switch {
+	case p.Title != "":
+		return p.Title
+	case p.NewFile:
+		return "Save"
+	}
+	return "Open"
+}
+
+func (p FilePicker) placeholderText() string {
+	if p.Placeholder == "" {
+		return defaultFilePickerPlaceholder
+	}
+	return p.Placeholder
+}
+
+func (p FilePicker) inputID() string {
+	if p.ID == "" {
+		return ""
+	}
+	return p.ID + "-input"
+}
+
+func (p FilePicker) listID() string {
+	if p.ID == "" {
+		return ""
+	}
+	return p.ID + "-list"
+}
+
+func (p FilePicker) scrollID() string {
+	if p.ID == "" {
+		return ""
+	}
+	return p.ID + "-scroll"
+}
@@ -0,0 +1,119 @@
+package terma
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// filePickerDir creates a directory of files for a FilePicker to list.
+func filePickerDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range []string{"notes.md", "main.go", "todo.txt", ".env", "src/app.go", "src/app_test.go"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+	}
+	return dir
+}
+
+func filePickerRowNames(state *FilePickerState) []string {
+	var names []string
+	for _, row := range state.list.GetItems() {
+		name := row.entry.Name
+		if row.kind == filePickerRowCreate {
+			name = "+" + name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestFilePicker_ListsFilteredDirectory(t *testing.T) {
+	dir := filePickerDir(t)
+	state := NewFilePickerState(dir)
+	picker := FilePicker{ID: "picker", State: state, Patterns: []string{"*.go", "*.md"}, Style: Style{Width: Cells(40)}}
+	state.Open()
+
+	buf := renderToBufferWithFocus(picker, 50, 12, "")
+	assert.Contains(t, bufferLine(buf, 3, 50), "Open  "+dir[:10], "the title and the directory head the picker")
+	assert.Equal(t, []string{"..", "src", "main.go", "notes.md"}, filePickerRowNames(state),
+		"directories come first, files not matching a pattern and hidden files are left out")
+
+	state.ToggleHidden()
+	assert.Equal(t, []string{"..", "src", "main.go", "notes.md"}, filePickerRowNames(state), ".env doesn't match a pattern")
+	picker.Patterns = nil
+	renderToBufferWithFocus(picker, 50, 12, "")
+	assert.Equal(t, []string{"..", "src", ".env", "main.go", "notes.md", "todo.txt"}, filePickerRowNames(state))
+
+	state.input.SetText("ain")
+	picker.queryChanged("ain")
+	assert.Equal(t, []string{"main.go"}, filePickerRowNames(state))
+}
+
+func TestFilePicker_NavigatesDirectories(t *testing.T) {
+	dir := filePickerDir(t)
+	state := NewFilePickerState(dir)
+	picker := FilePicker{State: state}
+	state.Open()
+
+	state.input.SetText("src/")
+	picker.queryChanged("src/")
+	assert.Equal(t, filepath.Join(dir, "src"), state.Dir(), "typing a directory and a separator opens it")
+	assert.Empty(t, state.input.GetText())
+	assert.Equal(t, []string{"..", "app.go", "app_test.go"}, filePickerRowNames(state))
+
+	picker.backspace()
+	assert.Equal(t, dir, state.Dir(), "backspace on an empty query goes up")
+
+	state.list.SelectIndex(1)
+	picker.selectCurrent()
+	assert.Equal(t, filepath.Join(dir, "src"), state.Dir(), "enter opens the directory under the cursor")
+	picker.selectCurrent()
+	assert.Equal(t, dir, state.Dir(), "enter on .. goes up")
+}
+
+func TestFilePicker_MultiSelectPicksMarkedFiles(t *testing.T) {
+	dir := filePickerDir(t)
+	state := NewFilePickerState(dir)
+	var picked []string
+	picker := FilePicker{State: state, MultiSelect: true, OnSelect: func(paths []string) { picked = paths }}
+	state.Open()
+
+	state.list.SelectIndex(2) // main.go
+	picker.markCurrent()
+	state.SetDir(filepath.Join(dir, "src"))
+	state.list.SelectIndex(2) // app_test.go
+	picker.markCurrent()
+	assert.Equal(t, []string{filepath.Join(dir, "main.go"), filepath.Join(dir, "src", "app_test.go")}, state.Marked())
+
+	state.list.SelectIndex(1)
+	picker.selectCurrent()
+	assert.Equal(t, []string{filepath.Join(dir, "main.go"), filepath.Join(dir, "src", "app_test.go")}, picked,
+		"marked files are picked across directories")
+	assert.False(t, state.Visible.Peek())
+	assert.Empty(t, state.Marked())
+}
+
+func TestFilePicker_NewFileOffersTypedName(t *testing.T) {
+	dir := filePickerDir(t)
+	state := NewFilePickerState(dir)
+	var picked []string
+	picker := FilePicker{State: state, NewFile: true, OnSelect: func(paths []string) { picked = paths }}
+	renderToBufferWithFocus(picker, 50, 12, "")
+	state.Open()
+
+	state.input.SetText("notes.md")
+	picker.queryChanged("notes.md")
+	assert.Equal(t, []string{"notes.md"}, filePickerRowNames(state), "an existing name isn't offered as new")
+
+	state.input.SetText("note")
+	picker.queryChanged("note")
+	assert.Equal(t, []string{"+note", "notes.md"}, filePickerRowNames(state))
+	picker.selectCurrent()
+	assert.Equal(t, []string{filepath.Join(dir, "note")}, picked)
+}
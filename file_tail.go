@@ -0,0 +1,261 @@
+package terma
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// TailOptions configures TailFile.
+type TailOptions struct {
+	MaxLines      int                  // Lines kept in FileTail.Lines; older lines are dropped (default 10000)
+	FromStart     bool                 // Read the file's existing content instead of starting at its end
+	PollInterval  time.Duration        // How often to check for new data and rotation at end of file (default 250ms)
+	FlushInterval time.Duration        // Minimum time between updates of Lines while data is arriving (default 50ms)
+	OnLines       func(lines []string) // Optional callback with each batch of new lines; runs off the UI loop
+}
+
+// FileTail follows a file like `tail -F`, publishing its lines to a
+// signal. Reading runs on a background goroutine; new lines are batched
+// and published at most once per FlushInterval, so a burst of output
+// causes a handful of renders rather than one per line. Memory stays
+// bounded: only the last MaxLines lines are kept, a batch is published as
+// soon as it reaches MaxLines, and OnLines runs on the reading goroutine,
+// so a slow consumer slows reading instead of letting lines pile up.
+//
+// Rotation is detected when the path starts pointing at a different file
+// (rename and recreate) or the file shrinks (truncate); the tail then
+// continues from the start of the new content. While the file is missing,
+// Err holds the error and the tail keeps polling.
+//
+// Example:
+//
+//	tail := t.TailFile("/var/log/app.log", t.TailOptions{MaxLines: 5000})
+//	defer tail.Stop()
+//
+//	// In Build:
+//	lines := tail.Lines.Get()
+type FileTail struct {
+	Lines AnySignal[[]string] // Most recent lines, oldest first
+	Err   AnySignal[error]    // Last error opening or reading the file, or nil
+
+	path    string
+	options TailOptions
+
+	mu      sync.Mutex
+	dropped int
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// TailFile starts tailing path and returns the running FileTail. Call Stop
+// to end it.
+func TailFile(path string, options TailOptions) *FileTail {
+	if options.MaxLines <= 0 {
+		options.MaxLines = 10000
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = 250 * time.Millisecond
+	}
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = 50 * time.Millisecond
+	}
+	t := &FileTail{
+		Lines:   NewAnySignal([]string(nil)),
+		Err:     NewAnySignal[error](nil),
+		path:    path,
+		options: options,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	// Open before returning so that "the end" is the end as of this call.
+	r := &tailReader{}
+	t.open(r, !options.FromStart)
+	Go(func() { t.run(r) })
+	return t
+}
+
+// Stop ends the tail, publishing any lines already read, and waits for the
+// background goroutine to exit. It is safe to call more than once.
+func (t *FileTail) Stop() {
+	t.stopOnce.Do(func() { close(t.stop) })
+	<-t.done
+}
+
+// Dropped returns how many lines have been dropped to stay within MaxLines.
+func (t *FileTail) Dropped() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.dropped
+}
+
+// tailReader is the state of the background reader.
+type tailReader struct {
+	file    *os.File
+	info    os.FileInfo
+	offset  int64
+	partial []byte
+	pending []string
+	flushed time.Time
+}
+
+// tailReadSize is the most read from the file between flush checks.
+const tailReadSize = 64 * 1024
+
+func (t *FileTail) run(r *tailReader) {
+	defer close(t.done)
+	defer func() {
+		if r.file != nil {
+			r.file.Close()
+		}
+	}()
+
+	buf := make([]byte, tailReadSize)
+	for {
+		select {
+		case <-t.stop:
+			t.flush(r)
+			return
+		default:
+		}
+
+		n := 0
+		if r.file != nil {
+			var err error
+			n, err = r.file.Read(buf)
+			if n > 0 {
+				r.offset += int64(n)
+				t.addData(r, buf[:n])
+			}
+			if err != nil && !errors.Is(err, io.EOF) {
+				t.Err.Set(err)
+			}
+		}
+		if n > 0 {
+			if len(r.pending) >= t.options.MaxLines || time.Since(r.flushed) >= t.options.FlushInterval {
+				t.flush(r)
+			}
+			continue
+		}
+
+		// Caught up: publish, then wait before checking for more data.
+		t.flush(r)
+		select {
+		case <-t.stop:
+			return
+		case <-time.After(t.options.PollInterval):
+		}
+		t.checkRotation(r)
+	}
+}
+
+// open opens the file, starting at its end when atEnd is set.
+func (t *FileTail) open(r *tailReader, atEnd bool) {
+	file, err := os.Open(t.path)
+	if err != nil {
+		t.Err.Set(err)
+		return
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		t.Err.Set(err)
+		return
+	}
+	r.file, r.info, r.offset = file, info, 0
+	if atEnd {
+		r.offset, _ = file.Seek(0, io.SeekEnd)
+	}
+	if t.Err.Peek() != nil {
+		t.Err.Set(nil)
+	}
+}
+
+// checkRotation reopens the file if the path now names a different file,
+// or rewinds if the file was truncated.
+func (t *FileTail) checkRotation(r *tailReader) {
+	info, err := os.Stat(t.path)
+	if err != nil {
+		if t.Err.Peek() == nil {
+			t.Err.Set(err)
+		}
+		return
+	}
+	switch {
+	case r.file == nil:
+		t.open(r, false)
+	case !os.SameFile(info, r.info):
+		// Finish the old file first; a writer may have added to it
+		// between our last read and the rename.
+		if rest, err := io.ReadAll(r.file); err == nil && len(rest) > 0 {
+			t.addData(r, rest)
+		}
+		t.endPartial(r)
+		r.file.Close()
+		r.file = nil
+		t.open(r, false)
+	case info.Size() < r.offset:
+		t.endPartial(r)
+		if _, err := r.file.Seek(0, io.SeekStart); err == nil {
+			r.offset = 0
+		}
+	}
+}
+
+// addData splits data into lines, keeping an unterminated last line until
+// the rest of it arrives.
+func (t *FileTail) addData(r *tailReader, data []byte) {
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			r.partial = append(r.partial, data...)
+			return
+		}
+		line := append(r.partial, data[:i]...)
+		r.partial = nil
+		r.pending = append(r.pending, string(bytes.TrimSuffix(line, []byte("\r"))))
+		data = data[i+1:]
+	}
+}
+
+// endPartial treats an unterminated last line as complete, for when the
+// file it came from has gone.
+func (t *FileTail) endPartial(r *tailReader) {
+	if len(r.partial) > 0 {
+		r.pending = append(r.pending, string(r.partial))
+		r.partial = nil
+	}
+}
+
+// flush publishes pending lines, dropping the oldest beyond MaxLines.
+func (t *FileTail) flush(r *tailReader) {
+	r.flushed = time.Now()
+	if len(r.pending) == 0 {
+		return
+	}
+	batch := r.pending
+	r.pending = nil
+
+	current := t.Lines.Peek()
+	excess := len(current) + len(batch) - t.options.MaxLines
+	if excess > 0 {
+		t.mu.Lock()
+		t.dropped += excess
+		t.mu.Unlock()
+	}
+	next := make([]string, 0, min(len(current)+len(batch), t.options.MaxLines))
+	if excess < len(current) {
+		next = append(next, current[max(excess, 0):]...)
+	}
+	next = append(next, batch[max(excess-len(current), 0):]...)
+	t.Lines.Set(next)
+
+	if t.options.OnLines != nil {
+		t.options.OnLines(batch)
+	}
+}
@@ -0,0 +1,99 @@
+package terma
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fastTail(path string, options TailOptions) *FileTail {
+	options.PollInterval = 5 * time.Millisecond
+	options.FlushInterval = time.Millisecond
+	return TailFile(path, options)
+}
+
+func appendFile(t *testing.T, path, text string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString(text)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func waitForLines(t *testing.T, tail *FileTail, want ...string) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		return strings.Join(tail.Lines.Peek(), "\n") == strings.Join(want, "\n")
+	}, 2*time.Second, 5*time.Millisecond, "lines: %q", tail.Lines.Peek())
+}
+
+func TestTailFile_FollowsAppendsAndPartialLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "old\n")
+
+	tail := fastTail(path, TailOptions{})
+	defer tail.Stop()
+	appendFile(t, path, "one\r\ntw")
+	waitForLines(t, tail, "one")
+	appendFile(t, path, "o\nthree\n")
+	waitForLines(t, tail, "one", "two", "three")
+}
+
+func TestTailFile_FromStartKeepsMaxLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "a\nb\nc\nd\n")
+
+	var mu sync.Mutex
+	var batches []string
+	tail := fastTail(path, TailOptions{FromStart: true, MaxLines: 2, OnLines: func(lines []string) {
+		mu.Lock()
+		batches = append(batches, lines...)
+		mu.Unlock()
+	}})
+	defer tail.Stop()
+	waitForLines(t, tail, "c", "d")
+	assert.Equal(t, 2, tail.Dropped())
+
+	appendFile(t, path, "e\n")
+	waitForLines(t, tail, "d", "e")
+	mu.Lock()
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, batches, "OnLines sees every line")
+	mu.Unlock()
+}
+
+func TestTailFile_DetectsRotationAndTruncation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendFile(t, path, "")
+
+	tail := fastTail(path, TailOptions{})
+	defer tail.Stop()
+	appendFile(t, path, "before\n")
+	waitForLines(t, tail, "before")
+
+	require.NoError(t, os.Rename(path, path+".1"))
+	assert.Eventually(t, func() bool { return tail.Err.Peek() != nil }, 2*time.Second, 5*time.Millisecond,
+		"a missing file is reported")
+	appendFile(t, path, "after\n")
+	waitForLines(t, tail, "before", "after")
+	assert.NoError(t, tail.Err.Peek())
+
+	require.NoError(t, os.Truncate(path, 0))
+	time.Sleep(20 * time.Millisecond)
+	appendFile(t, path, "x\n")
+	waitForLines(t, tail, "before", "after", "x")
+}
+
+func TestTailFile_StopIsIdempotent(t *testing.T) {
+	tail := fastTail(filepath.Join(t.TempDir(), "missing.log"), TailOptions{})
+	assert.Eventually(t, func() bool { return tail.Err.Peek() != nil }, time.Second, 5*time.Millisecond)
+	tail.Stop()
+	tail.Stop()
+}
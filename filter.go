@@ -15,19 +15,54 @@ const (
 	// FilterFuzzy matches characters in order (subsequence); ranked consumers
 	// prefer matches near the start of the string.
 	FilterFuzzy
+	// FilterScored matches characters in order like FilterFuzzy, but picks
+	// the best-scoring alignment (fzf-style: word starts, camelCase humps and
+	// consecutive runs score higher) and always ranks results by Score.
+	FilterScored
+	// FilterRegex matches the query as a regular expression (RE2 syntax),
+	// highlighting every match. An invalid expression matches as plain text
+	// until it is fixed; FilterState.Err reports the problem.
+	FilterRegex
+	// FilterQuery matches a small query language (see ParseQuery): every
+	// word, "quoted phrase" and field:value term must match. Table matches
+	// field terms against the column with that name; elsewhere the field
+	// name is ignored.
+	FilterQuery
 )
 
 // FilterOptions configures text matching behavior.
 type FilterOptions struct {
 	Mode          FilterMode
 	CaseSensitive bool
+	SmartCase     bool    // Ignore case unless the query has an uppercase letter
+	SortByScore   bool    // Order results by MatchResult.Score, best first
+	Matcher       Matcher // Optional custom algorithm (overrides Mode)
 }
 
 // FilterState holds reactive filter input and matching options.
+//
+// The List, Table or Tree using the state reports results back through
+// MatchCount and Pending, so a status line can show "42 matches" or a
+// spinner while a large list is filtered in the background.
 type FilterState struct {
 	Query         Signal[string]
 	Mode          Signal[FilterMode]
 	CaseSensitive Signal[bool]
+	SmartCase     Signal[bool] // Ignore case unless the query has an uppercase letter
+	SortByScore   Signal[bool] // Order results by match score instead of source order
+
+	// Matcher replaces the built-in matching modes when set. It should not
+	// change while the state is in use.
+	Matcher Matcher
+
+	MatchCount Signal[int]  // Items matching the current query, set by the filtered widget
+	Pending    Signal[bool] // True while a background filter pass is running
+
+	// AsyncThreshold is the item count at which List and Table filter off
+	// the UI loop, showing the previous results until the new ones are ready
+	// (0 = DefaultAsyncFilterThreshold, negative = always filter synchronously).
+	// Custom matchers must be safe to call from another goroutine.
+	AsyncThreshold int
 }
 
 // NewFilterState creates a FilterState with default options.
@@ -36,6 +71,10 @@ func NewFilterState() *FilterState {
 		Query:         NewSignal(""),
 		Mode:          NewSignal(FilterContains),
 		CaseSensitive: NewSignal(false),
+		SmartCase:     NewSignal(false),
+		SortByScore:   NewSignal(false),
+		MatchCount:    NewSignal(0),
+		Pending:       NewSignal(false),
 	}
 }
 
@@ -60,10 +99,18 @@ func (s *FilterState) Options() FilterOptions {
 	if s == nil {
 		return FilterOptions{}
 	}
-	return FilterOptions{
+	options := FilterOptions{
 		Mode:          s.Mode.Get(),
 		CaseSensitive: s.CaseSensitive.Get(),
+		Matcher:       s.Matcher,
+	}
+	if s.SmartCase.IsValid() {
+		options.SmartCase = s.SmartCase.Get()
+	}
+	if s.SortByScore.IsValid() {
+		options.SortByScore = s.SortByScore.Get()
 	}
+	return options
 }
 
 // PeekOptions returns the current filter options without subscribing.
@@ -71,10 +118,27 @@ func (s *FilterState) PeekOptions() FilterOptions {
 	if s == nil {
 		return FilterOptions{}
 	}
-	return FilterOptions{
+	options := FilterOptions{
 		Mode:          s.Mode.Peek(),
 		CaseSensitive: s.CaseSensitive.Peek(),
+		Matcher:       s.Matcher,
 	}
+	if s.SmartCase.IsValid() {
+		options.SmartCase = s.SmartCase.Peek()
+	}
+	if s.SortByScore.IsValid() {
+		options.SortByScore = s.SortByScore.Peek()
+	}
+	return options
+}
+
+// Err returns the syntax error in the current query for FilterRegex and
+// FilterQuery modes, or nil (subscribes to changes).
+func (s *FilterState) Err() error {
+	if s == nil {
+		return nil
+	}
+	return ValidateQuery(s.Query.Get(), s.Options())
 }
 
 func filterStateValues(filter *FilterState) (string, FilterOptions) {
@@ -101,6 +165,7 @@ type MatchRange struct {
 type MatchResult struct {
 	Matched bool
 	Ranges  []MatchRange
+	Score   int // Match quality, higher is better (see FilterOptions.SortByScore)
 }
 
 // FilteredView contains the filtered slice, source indices, and match data.
@@ -267,7 +332,32 @@ func sortFilteredViewByFuzzyRank[T any](view *FilteredView[T]) {
 	sort.SliceStable(order, func(i, j int) bool {
 		return fuzzyMatchRankLess(ranks[order[i]], ranks[order[j]])
 	})
+	reorderFilteredView(view, order)
+}
+
+// sortFilteredViewByScore orders view by descending match score, keeping
+// the existing order for ties.
+func sortFilteredViewByScore[T any](view *FilteredView[T]) {
+	if view == nil || len(view.Items) < 2 {
+		return
+	}
 
+	n := len(view.Items)
+	if len(view.Indices) != n || len(view.Matches) != n {
+		return
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sortByScore(order, func(i int) int { return view.Matches[i].Score })
+	reorderFilteredView(view, order)
+}
+
+// reorderFilteredView rearranges view so entry i is the former entry order[i].
+func reorderFilteredView[T any](view *FilteredView[T], order []int) {
+	n := len(order)
 	items := make([]T, n)
 	indices := make([]int, n)
 	matches := make([]MatchResult, n)
@@ -283,24 +373,46 @@ func sortFilteredViewByFuzzyRank[T any](view *FilteredView[T]) {
 }
 
 // MatchString matches query against text using the provided options.
+// Results carry a Score so any mode can be ranked.
 func MatchString(text string, query string, options FilterOptions) MatchResult {
 	if query == "" {
 		return MatchResult{Matched: true}
 	}
+	if options.Matcher != nil {
+		return options.Matcher.Match(text, query, options)
+	}
+	switch options.Mode {
+	case FilterScored:
+		return matchScored(text, query, options)
+	case FilterQuery:
+		return matchQueryTerms(text, parseFilterQuery(query).terms, options)
+	case FilterRegex:
+		result := matchRegex(text, query, options)
+		if result.Matched {
+			result.Score = scoreMatchRanges(text, result.Ranges, foldCase(query, options))
+		}
+		return result
+	}
 
+	fold := foldCase(query, options)
 	haystack := text
 	needle := query
-	if !options.CaseSensitive {
+	if fold {
 		haystack = strings.ToLower(haystack)
 		needle = strings.ToLower(needle)
 	}
 
+	var result MatchResult
 	switch options.Mode {
 	case FilterFuzzy:
-		return matchFuzzy(text, haystack, needle)
+		result = matchFuzzy(text, haystack, needle)
 	default:
-		return matchContains(haystack, needle)
+		result = matchContains(haystack, needle)
+	}
+	if result.Matched {
+		result.Score = scoreMatchRanges(text, result.Ranges, fold)
 	}
+	return result
 }
 
 func matchContains(haystack, needle string) MatchResult {
@@ -0,0 +1,293 @@
+package terma
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultAsyncFilterThreshold is the item count at which List and Table
+// filter off the UI loop when FilterState.AsyncThreshold is zero.
+const DefaultAsyncFilterThreshold = 20000
+
+// filterCacheSize is how many recent queries a filterEngine keeps results
+// for, so deleting characters from a query is instant.
+const filterCacheSize = 16
+
+// filterPass is the result of filtering the items for one query.
+type filterPass[R any] struct {
+	query   string
+	options FilterOptions
+	indices []int // Source indices of matching items, in display order
+	results []R   // Match data for each entry in indices
+}
+
+// filterMatcher matches one item against query. rank orders fuzzy matches;
+// score orders results when sorting by score.
+type filterMatcher[T any, R any] struct {
+	match func(index int, item T, query string, options FilterOptions) (R, bool)
+	rank  func(R) fuzzyMatchRank
+	score func(R) int
+}
+
+// filterEngine filters a slice incrementally. Results are cached per query;
+// a query that extends a cached one only rechecks the items that matched it;
+// and passes over many items can run in the background, tagged with a
+// generation so a stale pass never replaces a newer one.
+//
+// Incremental narrowing assumes an item that fails a query also fails every
+// extension of it, which holds for the built-in modes except FilterRegex and
+// FilterQuery (see narrowsOnExtend).
+type filterEngine[T any, R any] struct {
+	mu         sync.Mutex
+	itemsPtr   *T // Identity of the items the cache was built for
+	itemsLen   int
+	passes     []*filterPass[R] // Most recent first
+	generation uint64
+	pending    *filterPass[R] // Query and options of the running background pass
+	completed  Signal[int]    // Bumped when a background pass finishes
+}
+
+// newFilterEngine creates an empty engine.
+func newFilterEngine[T any, R any]() *filterEngine[T, R] {
+	return &filterEngine[T, R]{completed: NewSignal(0)}
+}
+
+// reset drops cached results and cancels any background pass.
+func (e *filterEngine[T, R]) reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.passes = nil
+	e.generation++
+	e.pending = nil
+}
+
+// isPending reports whether a background pass is running.
+func (e *filterEngine[T, R]) isPending() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.pending != nil
+}
+
+// filter returns the pass for query. If the work is moved to the background,
+// it returns the latest completed pass instead (check pass.query) and
+// rebuilds the calling widget when the new one is ready. asyncThreshold <= 0
+// keeps filtering synchronous.
+func (e *filterEngine[T, R]) filter(items []T, query string, options FilterOptions, asyncThreshold int, matcher filterMatcher[T, R]) *filterPass[R] {
+	// Subscribe the building widget to background completions.
+	e.completed.Get()
+
+	e.mu.Lock()
+	e.syncItems(items)
+	if pass := e.lookup(query, options); pass != nil {
+		e.mu.Unlock()
+		return pass
+	}
+	if e.pending != nil && e.pending.query == query && sameFilterOptions(e.pending.options, options) {
+		latest := e.passes[0]
+		e.mu.Unlock()
+		return latest
+	}
+
+	candidates := e.candidates(query, options)
+	count := len(items)
+	if candidates != nil {
+		count = len(candidates)
+	}
+	e.generation++
+	generation := e.generation
+
+	var latest *filterPass[R]
+	if len(e.passes) > 0 {
+		latest = e.passes[0]
+	}
+	if asyncThreshold <= 0 || count < asyncThreshold || latest == nil {
+		e.pending = nil
+		e.mu.Unlock()
+		pass := runFilterPass(items, candidates, query, options, matcher)
+		e.mu.Lock()
+		if generation == e.generation {
+			e.store(pass)
+		}
+		e.mu.Unlock()
+		return pass
+	}
+
+	e.pending = &filterPass[R]{query: query, options: options}
+	e.mu.Unlock()
+	// The pass reads its own copy, as ListState.InsertAt and RemoveAt
+	// change the items' backing array in place.
+	items = slices.Clone(items)
+	Go(func() {
+		pass := runFilterPass(items, candidates, query, options, matcher)
+		e.mu.Lock()
+		if generation != e.generation {
+			e.mu.Unlock()
+			return
+		}
+		e.store(pass)
+		e.pending = nil
+		e.mu.Unlock()
+		e.completed.Update(func(n int) int { return n + 1 })
+	})
+	return latest
+}
+
+// syncItems clears the cache if items is not the slice it was built for.
+// Must be called with e.mu held.
+func (e *filterEngine[T, R]) syncItems(items []T) {
+	var ptr *T
+	if len(items) > 0 {
+		ptr = &items[0]
+	}
+	if ptr == e.itemsPtr && len(items) == e.itemsLen {
+		return
+	}
+	e.itemsPtr = ptr
+	e.itemsLen = len(items)
+	e.passes = nil
+	e.generation++
+	e.pending = nil
+}
+
+// lookup returns the cached pass for query, moving it to the front.
+// Must be called with e.mu held.
+func (e *filterEngine[T, R]) lookup(query string, options FilterOptions) *filterPass[R] {
+	for i, pass := range e.passes {
+		if pass.query == query && sameFilterOptions(pass.options, options) {
+			copy(e.passes[1:i+1], e.passes[:i])
+			e.passes[0] = pass
+			return pass
+		}
+	}
+	return nil
+}
+
+// candidates returns the source indices worth checking for query: those
+// matched by the longest cached query it extends, in source order. Returns
+// nil when every item must be checked. Must be called with e.mu held.
+func (e *filterEngine[T, R]) candidates(query string, options FilterOptions) []int {
+	if !narrowsOnExtend(options) {
+		return nil
+	}
+	var base *filterPass[R]
+	for _, pass := range e.passes {
+		if !sameFilterOptions(pass.options, options) || pass.query == "" || !strings.HasPrefix(query, pass.query) {
+			continue
+		}
+		if base == nil || len(pass.query) > len(base.query) {
+			base = pass
+		}
+	}
+	if base == nil {
+		return nil
+	}
+	indices := append([]int(nil), base.indices...)
+	sort.Ints(indices)
+	return indices
+}
+
+// narrowsOnExtend reports whether extending a query can only remove
+// matches. It doesn't for regular expressions ("a" then "a|b") or field
+// terms ("st" then "st:x").
+func narrowsOnExtend(options FilterOptions) bool {
+	return options.Matcher != nil || (options.Mode != FilterRegex && options.Mode != FilterQuery)
+}
+
+// store caches pass as the most recent result. Must be called with e.mu held.
+func (e *filterEngine[T, R]) store(pass *filterPass[R]) {
+	e.passes = append([]*filterPass[R]{pass}, e.passes...)
+	if len(e.passes) > filterCacheSize {
+		e.passes = e.passes[:filterCacheSize]
+	}
+}
+
+// runFilterPass matches the candidate items (all items when candidates is
+// nil), ordering them by score or fuzzy rank as the options ask.
+func runFilterPass[T any, R any](items []T, candidates []int, query string, options FilterOptions, matcher filterMatcher[T, R]) *filterPass[R] {
+	size := len(items)
+	if candidates != nil {
+		size = len(candidates)
+	}
+	pass := &filterPass[R]{
+		query:   query,
+		options: options,
+		indices: make([]int, 0, size),
+		results: make([]R, 0, size),
+	}
+	check := func(index int) {
+		result, matched := matcher.match(index, items[index], query, options)
+		if matched {
+			pass.indices = append(pass.indices, index)
+			pass.results = append(pass.results, result)
+		}
+	}
+	if candidates == nil {
+		for i := range items {
+			check(i)
+		}
+	} else {
+		for _, index := range candidates {
+			check(index)
+		}
+	}
+
+	byScore := (options.SortByScore || options.Mode == FilterScored) && matcher.score != nil
+	byRank := options.Mode == FilterFuzzy && matcher.rank != nil
+	if query != "" && (byScore || byRank) && len(pass.indices) > 1 {
+		order := make([]int, len(pass.indices))
+		for i := range order {
+			order[i] = i
+		}
+		if byScore {
+			scores := make([]int, len(pass.indices))
+			for i := range scores {
+				scores[i] = matcher.score(pass.results[i])
+			}
+			sortByScore(order, func(i int) int { return scores[i] })
+		} else {
+			ranks := make([]fuzzyMatchRank, len(pass.indices))
+			for i := range ranks {
+				ranks[i] = matcher.rank(pass.results[i])
+			}
+			sort.SliceStable(order, func(i, j int) bool {
+				return fuzzyMatchRankLess(ranks[order[i]], ranks[order[j]])
+			})
+		}
+		indices := make([]int, len(order))
+		results := make([]R, len(order))
+		for i, from := range order {
+			indices[i] = pass.indices[from]
+			results[i] = pass.results[from]
+		}
+		pass.indices = indices
+		pass.results = results
+	}
+	return pass
+}
+
+// asyncFilterThreshold returns the item count at which filter moves off
+// the UI loop, or 0 if it never should.
+func asyncFilterThreshold(filter *FilterState) int {
+	if filter == nil || filter.AsyncThreshold == 0 {
+		return DefaultAsyncFilterThreshold
+	}
+	if filter.AsyncThreshold < 0 {
+		return 0
+	}
+	return filter.AsyncThreshold
+}
+
+// reportFilterResult publishes the match count and pending flag on filter.
+func reportFilterResult(filter *FilterState, matches int, pending bool) {
+	if filter == nil {
+		return
+	}
+	if filter.MatchCount.IsValid() {
+		filter.MatchCount.Set(matches)
+	}
+	if filter.Pending.IsValid() {
+		filter.Pending.Set(pending)
+	}
+}
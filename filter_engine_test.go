@@ -0,0 +1,197 @@
+package terma
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingMatcher matches strings by substring and counts the calls.
+func countingMatcher(calls *atomic.Int32) filterMatcher[string, MatchResult] {
+	return filterMatcher[string, MatchResult]{
+		match: func(_ int, item string, query string, options FilterOptions) (MatchResult, bool) {
+			calls.Add(1)
+			result := MatchString(item, query, options)
+			return result, result.Matched
+		},
+		rank: fuzzyMatchRankFromResult,
+	}
+}
+
+func TestFilterEngine_NarrowsExtendedQueries(t *testing.T) {
+	items := []string{"cat", "car", "dog", "cart", "cow"}
+	engine := newFilterEngine[string, MatchResult]()
+	var calls atomic.Int32
+	matcher := countingMatcher(&calls)
+
+	pass := engine.filter(items, "c", FilterOptions{}, 0, matcher)
+	assert.Equal(t, []int{0, 1, 3, 4}, pass.indices)
+	assert.Equal(t, int32(5), calls.Load())
+
+	calls.Store(0)
+	pass = engine.filter(items, "ca", FilterOptions{}, 0, matcher)
+	assert.Equal(t, []int{0, 1, 3}, pass.indices)
+	assert.Equal(t, int32(4), calls.Load(), "only items matching \"c\" are rechecked")
+
+	calls.Store(0)
+	pass = engine.filter(items, "c", FilterOptions{}, 0, matcher)
+	assert.Equal(t, []int{0, 1, 3, 4}, pass.indices)
+	assert.Equal(t, int32(0), calls.Load(), "backspacing reuses the cached pass")
+
+	calls.Store(0)
+	engine.filter(items, "ca", FilterOptions{CaseSensitive: true}, 0, matcher)
+	assert.Equal(t, int32(5), calls.Load(), "different options start from scratch")
+}
+
+func TestFilterEngine_NewItemsClearCache(t *testing.T) {
+	engine := newFilterEngine[string, MatchResult]()
+	var calls atomic.Int32
+	matcher := countingMatcher(&calls)
+
+	engine.filter([]string{"alpha", "beta"}, "a", FilterOptions{}, 0, matcher)
+	pass := engine.filter([]string{"gamma", "delta", "zeta"}, "a", FilterOptions{}, 0, matcher)
+	assert.Equal(t, []int{0, 1, 2}, pass.indices)
+	assert.Equal(t, int32(5), calls.Load())
+}
+
+func TestFilterEngine_FuzzyResultsRanked(t *testing.T) {
+	engine := newFilterEngine[string, MatchResult]()
+	var calls atomic.Int32
+	pass := engine.filter([]string{"xa---b", "ab---", "zab"}, "ab", FilterOptions{Mode: FilterFuzzy}, 0, countingMatcher(&calls))
+	assert.Equal(t, []int{1, 2, 0}, pass.indices)
+}
+
+func TestFilterEngine_AsyncPassKeepsPreviousResults(t *testing.T) {
+	items := []string{"apple", "apricot", "banana", "avocado"}
+	engine := newFilterEngine[string, MatchResult]()
+	release := make(chan struct{})
+	blocking := filterMatcher[string, MatchResult]{
+		match: func(_ int, item string, query string, options FilterOptions) (MatchResult, bool) {
+			if query == "ap" {
+				<-release
+			}
+			result := MatchString(item, query, options)
+			return result, result.Matched
+		},
+	}
+
+	first := engine.filter(items, "a", FilterOptions{}, 2, blocking)
+	require.Equal(t, "a", first.query, "nothing to show yet, so the first pass is synchronous")
+
+	stale := engine.filter(items, "ap", FilterOptions{}, 2, blocking)
+	assert.Equal(t, "a", stale.query)
+	assert.True(t, engine.isPending())
+	assert.Same(t, stale, engine.filter(items, "ap", FilterOptions{}, 2, blocking), "no second pass for the same query")
+
+	before := engine.completed.Peek()
+	close(release)
+	require.Eventually(t, func() bool { return !engine.isPending() }, time.Second, time.Millisecond)
+	assert.Greater(t, engine.completed.Peek(), before)
+
+	pass := engine.filter(items, "ap", FilterOptions{}, 2, blocking)
+	assert.Equal(t, "ap", pass.query)
+	assert.Equal(t, []int{0, 1}, pass.indices)
+}
+
+func TestFilterEngine_AsyncPassReadsItsOwnCopy(t *testing.T) {
+	items := []string{"apple", "apricot", "banana"}
+	engine := newFilterEngine[string, MatchResult]()
+	release := make(chan struct{})
+	blocking := filterMatcher[string, MatchResult]{
+		match: func(_ int, item string, query string, options FilterOptions) (MatchResult, bool) {
+			if query == "ap" {
+				<-release
+			}
+			result := MatchString(item, query, options)
+			return result, result.Matched
+		},
+	}
+
+	engine.filter(items, "a", FilterOptions{}, 2, blocking)
+	engine.filter(items, "ap", FilterOptions{}, 2, blocking)
+	items[0] = "cherry" // As ListState.RemoveAt does, in place
+	close(release)
+	require.Eventually(t, func() bool { return !engine.isPending() }, time.Second, time.Millisecond)
+
+	pass := engine.filter(items, "ap", FilterOptions{}, 2, blocking)
+	assert.Equal(t, []int{0, 1}, pass.indices, "the pass matched the items it started with")
+}
+
+func TestFilterEngine_StalePassDiscarded(t *testing.T) {
+	items := []string{"one", "two", "three"}
+	engine := newFilterEngine[string, MatchResult]()
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	blocking := filterMatcher[string, MatchResult]{
+		match: func(index int, item string, query string, options FilterOptions) (MatchResult, bool) {
+			if query == "tw" {
+				<-release
+				if index == len(items)-1 {
+					defer close(finished)
+				}
+			}
+			return MatchResult{Matched: strings.Contains(item, query)}, strings.Contains(item, query)
+		},
+	}
+
+	engine.filter(items, "t", FilterOptions{}, 1, blocking)
+	engine.filter(items, "tw", FilterOptions{}, 1, blocking)
+	engine.reset()
+	close(release)
+	<-finished
+
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+	assert.Empty(t, engine.passes, "a pass started before reset is never stored")
+}
+
+func TestList_ReportsMatchCount(t *testing.T) {
+	state := NewListState([]string{"apple", "apricot", "banana"})
+	filter := NewFilterState()
+	filter.Query.Set("ap")
+	list := List[string]{State: state, Filter: filter}
+
+	list.Build(newTestBuildContext())
+	assert.Equal(t, 2, filter.MatchCount.Peek())
+	assert.False(t, filter.Pending.Peek())
+
+	filter.Query.Set("apr")
+	list.Build(newTestBuildContext())
+	assert.Equal(t, 1, filter.MatchCount.Peek())
+	assert.Equal(t, []int{1}, state.viewIndices)
+}
+
+func TestTree_FilterMemoSkipsKnownMisses(t *testing.T) {
+	var calls int
+	state := NewTreeState([]TreeNode[string]{
+		{Data: "src", Children: []TreeNode[string]{{Data: "main.go"}, {Data: "util.go"}}},
+		{Data: "README.md"},
+	})
+	filter := NewFilterState()
+	tree := Tree[string]{
+		State:  state,
+		Filter: filter,
+		MatchNode: func(node string, query string, options FilterOptions) MatchResult {
+			calls++
+			return MatchString(node, query, options)
+		},
+	}
+
+	filter.Query.Set("m")
+	tree.Build(newTestBuildContext())
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, 2, filter.MatchCount.Peek())
+
+	calls = 0
+	tree.Build(newTestBuildContext())
+	assert.Equal(t, 0, calls, "same query reuses results")
+
+	filter.Query.Set("ma")
+	tree.Build(newTestBuildContext())
+	assert.Equal(t, 2, calls, "only main.go and README.md matched \"m\"")
+	assert.Equal(t, 1, filter.MatchCount.Peek())
+}
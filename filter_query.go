@@ -0,0 +1,253 @@
+package terma
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// QueryTerm is one term of a FilterQuery query.
+type QueryTerm struct {
+	Field string // Field or column name for field:value terms, "" to match anywhere
+	Text  string // Text to find, matched as a substring
+}
+
+// ParseQuery splits a FilterQuery query into terms. Words are separated by
+// spaces, "double quotes" keep a phrase together, and field:value or
+// field:"quoted value" targets a single field. Every term must match.
+//
+// On error the terms that could be parsed are still returned, with an
+// unterminated phrase running to the end of the query, so filtering keeps
+// working while the user is typing.
+//
+// Example:
+//
+//	terms, err := t.ParseQuery(`status:Warn owner:Ingest "disk full"`)
+func ParseQuery(query string) ([]QueryTerm, error) {
+	var (
+		terms    []QueryTerm
+		firstErr error
+	)
+	fail := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	i := 0
+	for i < len(query) {
+		r, size := utf8.DecodeRuneInString(query[i:])
+		if unicode.IsSpace(r) {
+			i += size
+			continue
+		}
+		if r == '"' {
+			text, next, ok := readQueryPhrase(query, i)
+			if !ok {
+				fail(fmt.Errorf("unterminated quote at offset %d", i))
+			}
+			if text != "" {
+				terms = append(terms, QueryTerm{Text: text})
+			}
+			i = next
+			continue
+		}
+
+		start := i
+		for i < len(query) {
+			r, size := utf8.DecodeRuneInString(query[i:])
+			if unicode.IsSpace(r) || r == ':' || r == '"' {
+				break
+			}
+			i += size
+		}
+		word := query[start:i]
+		if i < len(query) && query[i] == ':' && isQueryFieldName(word) {
+			i++
+			var value string
+			if i < len(query) && query[i] == '"' {
+				var ok bool
+				value, i, ok = readQueryPhrase(query, i)
+				if !ok {
+					fail(fmt.Errorf("unterminated quote at offset %d", start+len(word)+1))
+				}
+			} else {
+				valueStart := i
+				i = skipQueryWord(query, i)
+				value = query[valueStart:i]
+			}
+			if value == "" {
+				fail(fmt.Errorf("missing value for %s: at offset %d", word, start))
+				continue
+			}
+			terms = append(terms, QueryTerm{Field: word, Text: value})
+			continue
+		}
+		i = skipQueryWord(query, i)
+		terms = append(terms, QueryTerm{Text: query[start:i]})
+	}
+	return terms, firstErr
+}
+
+// readQueryPhrase reads the quoted phrase starting at the quote at start,
+// returning its text, the offset after it, and false if it is unterminated.
+func readQueryPhrase(query string, start int) (string, int, bool) {
+	end := strings.IndexByte(query[start+1:], '"')
+	if end < 0 {
+		return query[start+1:], len(query), false
+	}
+	return query[start+1 : start+1+end], start + end + 2, true
+}
+
+// skipQueryWord returns the offset of the next space at or after i.
+func skipQueryWord(query string, i int) int {
+	for i < len(query) {
+		r, size := utf8.DecodeRuneInString(query[i:])
+		if unicode.IsSpace(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// isQueryFieldName reports whether word can name a field, so that text such
+// as "12:30" is not mistaken for a field term.
+func isQueryFieldName(word string) bool {
+	if word == "" {
+		return false
+	}
+	for i, r := range word {
+		if unicode.IsLetter(r) || r == '_' || (i > 0 && (unicode.IsDigit(r) || r == '-')) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// ValidateQuery returns the syntax error in query for the mode in options:
+// the regexp error for FilterRegex, a parse error for FilterQuery, and nil
+// otherwise. Invalid queries still filter (see FilterRegex and FilterQuery),
+// so this is for showing the problem to the user.
+func ValidateQuery(query string, options FilterOptions) error {
+	if query == "" || options.Matcher != nil {
+		return nil
+	}
+	switch options.Mode {
+	case FilterRegex:
+		return compileFilterRegex(query, foldCase(query, options)).err
+	case FilterQuery:
+		return parseFilterQuery(query).err
+	}
+	return nil
+}
+
+// matchRegex matches the regular expression query against text, falling
+// back to a substring match while the expression is invalid.
+func matchRegex(text, query string, options FilterOptions) MatchResult {
+	fold := foldCase(query, options)
+	compiled := compileFilterRegex(query, fold)
+	if compiled.err != nil {
+		haystack, needle := text, query
+		if fold {
+			haystack, needle = strings.ToLower(text), strings.ToLower(query)
+		}
+		return matchContains(haystack, needle)
+	}
+
+	locations := compiled.re.FindAllStringIndex(text, -1)
+	if locations == nil {
+		return MatchResult{}
+	}
+	ranges := make([]MatchRange, 0, len(locations))
+	for _, loc := range locations {
+		if loc[1] > loc[0] {
+			ranges = append(ranges, MatchRange{Start: loc[0], End: loc[1]})
+		}
+	}
+	return MatchResult{Matched: true, Ranges: ranges}
+}
+
+// matchQueryTerms matches every term against text. Field names are ignored,
+// since a plain string has no fields.
+func matchQueryTerms(text string, terms []QueryTerm, options FilterOptions) MatchResult {
+	options.Mode = FilterContains
+	result := MatchResult{Matched: true}
+	for _, term := range terms {
+		match := MatchString(text, term.Text, options)
+		if !match.Matched {
+			return MatchResult{}
+		}
+		result.Ranges = append(result.Ranges, match.Ranges...)
+		result.Score += match.Score
+	}
+	result.Ranges = normalizeMatchRanges(result.Ranges, len(text))
+	return result
+}
+
+// compiledFilterRegex is a cached regexp compilation.
+type compiledFilterRegex struct {
+	re  *regexp.Regexp
+	err error
+}
+
+// parsedFilterQuery is a cached ParseQuery result.
+type parsedFilterQuery struct {
+	terms []QueryTerm
+	err   error
+}
+
+var (
+	filterRegexCache = filterCompileCache[compiledFilterRegex]{}
+	filterQueryCache = filterCompileCache[parsedFilterQuery]{}
+)
+
+// compileFilterRegex compiles query once per query, rather than once per
+// item matched against it.
+func compileFilterRegex(query string, fold bool) compiledFilterRegex {
+	pattern := query
+	if fold {
+		pattern = "(?i)" + query
+	}
+	return filterRegexCache.get(pattern, func() compiledFilterRegex {
+		re, err := regexp.Compile(pattern)
+		return compiledFilterRegex{re: re, err: err}
+	})
+}
+
+// parseFilterQuery parses query once per query.
+func parseFilterQuery(query string) parsedFilterQuery {
+	return filterQueryCache.get(query, func() parsedFilterQuery {
+		terms, err := ParseQuery(query)
+		return parsedFilterQuery{terms: terms, err: err}
+	})
+}
+
+// filterCompileCacheSize bounds each filterCompileCache; a user types far
+// fewer distinct queries than this between filter passes.
+const filterCompileCacheSize = 64
+
+// filterCompileCache memoizes work derived from a query string. It is safe
+// for concurrent use, since background filter passes share it.
+type filterCompileCache[V any] struct {
+	mu      sync.Mutex
+	entries map[string]V
+}
+
+func (c *filterCompileCache[V]) get(key string, build func() V) V {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if value, ok := c.entries[key]; ok {
+		return value
+	}
+	if c.entries == nil || len(c.entries) >= filterCompileCacheSize {
+		c.entries = make(map[string]V)
+	}
+	value := build()
+	c.entries[key] = value
+	return value
+}
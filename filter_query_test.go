@@ -0,0 +1,129 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	terms, err := ParseQuery(`status:Warn  owner:"Data Ingest" "disk full" 12:30 retry`)
+	require.NoError(t, err)
+	assert.Equal(t, []QueryTerm{
+		{Field: "status", Text: "Warn"},
+		{Field: "owner", Text: "Data Ingest"},
+		{Text: "disk full"},
+		{Text: "12:30"},
+		{Text: "retry"},
+	}, terms)
+}
+
+func TestParseQuery_ErrorsKeepPartialTerms(t *testing.T) {
+	terms, err := ParseQuery(`error "disk ful`)
+	assert.EqualError(t, err, "unterminated quote at offset 6")
+	assert.Equal(t, []QueryTerm{{Text: "error"}, {Text: "disk ful"}}, terms)
+
+	terms, err = ParseQuery("status: error")
+	assert.EqualError(t, err, "missing value for status: at offset 0")
+	assert.Equal(t, []QueryTerm{{Text: "error"}}, terms)
+}
+
+func TestMatchString_Regex(t *testing.T) {
+	options := FilterOptions{Mode: FilterRegex}
+	result := MatchString("GET /api/v2/users", `v\d+`, options)
+	require.True(t, result.Matched)
+	assert.Equal(t, []MatchRange{{Start: 9, End: 11}}, result.Ranges)
+	assert.Positive(t, result.Score)
+
+	assert.True(t, MatchString("Error: disk", "^error", options).Matched, "case-insensitive by default")
+	assert.False(t, MatchString("Error: disk", "^error", FilterOptions{Mode: FilterRegex, CaseSensitive: true}).Matched)
+
+	invalid := MatchString("call foo(bar)", "foo(", options)
+	assert.True(t, invalid.Matched, "an invalid expression matches literally")
+	assert.Equal(t, []MatchRange{{Start: 5, End: 9}}, invalid.Ranges)
+}
+
+func TestMatchString_Query(t *testing.T) {
+	options := FilterOptions{Mode: FilterQuery}
+	text := "disk full on ingest-3"
+	result := MatchString(text, `ingest "disk full"`, options)
+	require.True(t, result.Matched)
+	assert.Equal(t, []MatchRange{{Start: 0, End: 9}, {Start: 13, End: 19}}, result.Ranges)
+	assert.Equal(t, []Span{
+		{Text: "disk full", Style: SpanStyle{Bold: true}},
+		{Text: " on "},
+		{Text: "ingest", Style: SpanStyle{Bold: true}},
+		{Text: "-3"},
+	}, HighlightSpans(text, result.Ranges, SpanStyle{Bold: true}))
+
+	assert.False(t, MatchString(text, `ingest "full disk"`, options).Matched, "every term must match")
+}
+
+func TestFilterState_Err(t *testing.T) {
+	filter := NewFilterState()
+	filter.Query.Set("foo(")
+	assert.NoError(t, filter.Err(), "plain modes have no syntax")
+
+	filter.Mode.Set(FilterRegex)
+	assert.Error(t, filter.Err())
+
+	filter.Mode.Set(FilterQuery)
+	assert.NoError(t, filter.Err())
+	filter.Query.Set(`owner:"ingest`)
+	assert.EqualError(t, filter.Err(), "unterminated quote at offset 6")
+}
+
+type logRow struct {
+	Status string
+	Owner  string
+	Detail string
+}
+
+func TestTableFilteredRows_QueryTargetsColumns(t *testing.T) {
+	rows := []logRow{
+		{Status: "Warn", Owner: "Ingest", Detail: "lag"},
+		{Status: "Info", Owner: "Ingest", Detail: "Warn threshold set"},
+		{Status: "Warn", Owner: "Billing", Detail: "retry"},
+	}
+	table := Table[logRow]{
+		Columns: []TableColumn{
+			{Header: Text{Content: "Status"}},
+			{Name: "owner"},
+			{},
+		},
+		MatchCell: func(row logRow, _ int, colIndex int, query string, options FilterOptions) MatchResult {
+			return MatchString([]string{row.Status, row.Owner, row.Detail}[colIndex], query, options)
+		},
+	}
+
+	_, indices, matches := table.filteredRows(rows, 3, "status:warn owner:ingest", FilterOptions{Mode: FilterQuery})
+	require.Equal(t, []int{0}, indices)
+	assert.Equal(t, []MatchRange{{Start: 0, End: 4}}, matches[0][0].Ranges)
+	assert.Equal(t, []MatchRange{{Start: 0, End: 6}}, matches[0][1].Ranges)
+	assert.False(t, matches[0][2].Matched)
+
+	_, indices, _ = table.filteredRows(rows, 3, "warn", FilterOptions{Mode: FilterQuery})
+	assert.Equal(t, []int{0, 1, 2}, indices, "unfielded terms match any column")
+
+	_, indices, _ = table.filteredRows(rows, 3, "team:ingest", FilterOptions{Mode: FilterQuery})
+	assert.Empty(t, indices, "unknown fields match nothing")
+}
+
+func TestFilterEngine_RegexDoesNotNarrow(t *testing.T) {
+	items := []string{"apple", "banana"}
+	engine := newFilterEngine[string, MatchResult]()
+	matcher := filterMatcher[string, MatchResult]{
+		match: func(_ int, item string, query string, options FilterOptions) (MatchResult, bool) {
+			result := MatchString(item, query, options)
+			return result, result.Matched
+		},
+	}
+	options := FilterOptions{Mode: FilterRegex}
+
+	engine.filter(items, "a", options, 0, matcher)
+	pass := engine.filter(items, "ap", options, 0, matcher)
+	assert.Equal(t, []int{0}, pass.indices)
+	pass = engine.filter(items, "ap|ban", options, 0, matcher)
+	assert.Equal(t, []int{0, 1}, pass.indices, "banana is rechecked although it failed \"ap\"")
+}
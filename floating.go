@@ -76,6 +76,11 @@ type FloatConfig struct {
 	// Offset from the calculated position.
 	Offset Offset
 
+	// Flip places an anchored float on the opposite side of its anchor
+	// when it would run off the screen on its own side and fits on the
+	// other, such as a tooltip above a widget on the top row.
+	Flip bool
+
 	// Modal behavior - when true, traps focus and shows a backdrop.
 	Modal bool
 
@@ -180,6 +185,23 @@ type FloatEntry struct {
 	// Computed position after layout (set during render phase)
 	X, Y          int
 	Width, Height int
+
+	// portal marks entries registered by Portal, which have no dismissal
+	// or modal semantics and are ignored by TopFloat and HasFloats.
+	portal bool
+	// clamp keeps a portal on screen (floats are always clamped).
+	clamp bool
+	// ignorePointer excludes the entry from mouse hit testing.
+	ignorePointer bool
+	// spotlight makes a modal entry leave its anchor undimmed and outline it
+	// with ringColor (used by Tour).
+	spotlight bool
+	ringColor Color
+}
+
+// IsPortal returns true if the entry was registered by a Portal.
+func (e FloatEntry) IsPortal() bool {
+	return e.portal
 }
 
 // FloatCollector gathers Floating widgets during the build phase
@@ -213,6 +235,16 @@ func (c *FloatCollector) HasModal() bool {
 	return false
 }
 
+// HasFloats returns true if any non-portal float is registered.
+func (c *FloatCollector) HasFloats() bool {
+	for _, entry := range c.entries {
+		if !entry.portal {
+			return true
+		}
+	}
+	return false
+}
+
 // TopModal returns the topmost modal float entry, or nil if none.
 func (c *FloatCollector) TopModal() *FloatEntry {
 	for i := len(c.entries) - 1; i >= 0; i-- {
@@ -285,6 +317,68 @@ func calculateAnchorPosition(anchor *WidgetEntry, anchorPoint AnchorPoint, float
 	return x + offset.X, y + offset.Y
 }
 
+// flipAnchorPosition is calculateAnchorPosition for a float that may flip:
+// if the float would run off the screen on its anchor point's side and
+// fits on the opposite side, it goes there instead, with its offset
+// mirrored.
+func flipAnchorPosition(anchor *WidgetEntry, anchorPoint AnchorPoint, floatWidth, floatHeight int, offset Offset, screenWidth, screenHeight int) (x, y int) {
+	x, y = calculateAnchorPosition(anchor, anchorPoint, floatWidth, floatHeight, offset)
+	if anchor == nil || fitsBeside(anchorPoint, x, y, floatWidth, floatHeight, screenWidth, screenHeight) {
+		return x, y
+	}
+	flipped := oppositeAnchor(anchorPoint)
+	fx, fy := calculateAnchorPosition(anchor, flipped, floatWidth, floatHeight, Offset{X: -offset.X, Y: -offset.Y})
+	if fitsBeside(flipped, fx, fy, floatWidth, floatHeight, screenWidth, screenHeight) {
+		return fx, fy
+	}
+	return x, y
+}
+
+// fitsBeside reports whether a float at (x, y) stays on screen along the
+// axis it sits beside its anchor on: vertically for the top and bottom
+// anchor points, horizontally for left and right. The other axis is left
+// to clampToScreen.
+func fitsBeside(anchorPoint AnchorPoint, x, y, floatWidth, floatHeight, screenWidth, screenHeight int) bool {
+	switch anchorPoint {
+	case AnchorLeftTop, AnchorLeftCenter, AnchorLeftBottom, AnchorRightTop, AnchorRightCenter, AnchorRightBottom:
+		return x >= 0 && x+floatWidth <= screenWidth
+	default:
+		return y >= 0 && y+floatHeight <= screenHeight
+	}
+}
+
+// oppositeAnchor returns the anchor point on the other side of the anchor,
+// keeping the alignment: AnchorTopLeft becomes AnchorBottomLeft.
+func oppositeAnchor(anchorPoint AnchorPoint) AnchorPoint {
+	switch anchorPoint {
+	case AnchorUnset, AnchorTopLeft:
+		return AnchorBottomLeft
+	case AnchorTopCenter:
+		return AnchorBottomCenter
+	case AnchorTopRight:
+		return AnchorBottomRight
+	case AnchorBottomLeft:
+		return AnchorTopLeft
+	case AnchorBottomCenter:
+		return AnchorTopCenter
+	case AnchorBottomRight:
+		return AnchorTopRight
+	case AnchorLeftTop:
+		return AnchorRightTop
+	case AnchorLeftCenter:
+		return AnchorRightCenter
+	case AnchorLeftBottom:
+		return AnchorRightBottom
+	case AnchorRightTop:
+		return AnchorLeftTop
+	case AnchorRightCenter:
+		return AnchorLeftCenter
+	case AnchorRightBottom:
+		return AnchorLeftBottom
+	}
+	return anchorPoint
+}
+
 // calculateAbsolutePosition computes the position for an absolutely positioned float.
 func calculateAbsolutePosition(position FloatPosition, screenWidth, screenHeight, floatWidth, floatHeight int, offset Offset) (x, y int) {
 	switch position {
@@ -34,6 +34,14 @@ type MouseEvent struct {
 	Mod        uv.KeyMod
 	ClickCount int // 1=single, 2=double, 3=triple, etc
 	WidgetID   string
+	Link       string // Hyperlink URL of the cell under the pointer, if any
+
+	// Pixel position, on terminals that report it (SGR-pixel mouse mode).
+	// When HasPixels is false these are zero; use PreciseLocalX and
+	// PreciseLocalY to get sub-cell positions with a cell fallback.
+	HasPixels             bool
+	PixelX, PixelY        int // Absolute position in pixels
+	CellWidth, CellHeight int // Size of a cell in pixels
 }
 
 // HoverEventType identifies the hover transition kind.
@@ -152,6 +160,9 @@ type FocusableEntry struct {
 	// TrapID is the ID of the innermost FocusTrapper ancestor, or "" if none.
 	// Used to constrain Tab/Shift+Tab cycling within a trap scope.
 	TrapID string
+	// Path is the IDs (explicit or auto) of every widget enclosing this
+	// one, from root to its parent. Used for focus-within queries.
+	Path []string
 }
 
 // FocusManager tracks the currently focused widget and handles navigation.
@@ -186,14 +197,6 @@ func (fm *FocusManager) SetRootWidget(root Widget) {
 func (fm *FocusManager) SetFocusables(focusables []FocusableEntry) {
 	fm.focusables = focusables
 
-	// Log collected focusables
-	if len(focusables) > 0 {
-		Log("SetFocusables: %d focusable widgets collected", len(focusables))
-		for i, entry := range focusables {
-			Log("  [%d] ID=%q Type=%T", i, entry.ID, entry.Focusable)
-		}
-	}
-
 	// If we have a focused ID, verify it still exists
 	if fm.focusedID != "" {
 		found := false
@@ -231,6 +234,29 @@ func (fm *FocusManager) FocusedID() string {
 	return fm.focusedID
 }
 
+// FocusWithin returns true if the focused widget is the widget with the
+// given ID or one of its descendants.
+func (fm *FocusManager) FocusWithin(id string) bool {
+	if fm.focusedID == "" || id == "" {
+		return false
+	}
+	if fm.focusedID == id {
+		return true
+	}
+	for _, entry := range fm.focusables {
+		if entry.ID != fm.focusedID {
+			continue
+		}
+		for _, ancestorID := range entry.Path {
+			if ancestorID == id {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
 // ActiveKeybinds returns all declarative keybindings currently active
 // based on the focused widget and its ancestors, plus root widget keybinds.
 // Keybindings are returned in order from focused widget to root,
@@ -239,7 +265,22 @@ func (fm *FocusManager) FocusedID() string {
 // If the focused widget implements KeyCapturer, ancestor keybinds are filtered
 // to exclude keys that the focused widget captures (since those keys won't
 // bubble up to trigger the ancestor keybinds).
+//
+// While an input scope is pushed, its keybinds come first, and are the only
+// ones returned unless the scope passes keys through.
 func (fm *FocusManager) ActiveKeybinds() []Keybind {
+	if scope := activeInputScope(); scope != nil {
+		if !scope.PassThrough {
+			return scope.Keybinds
+		}
+		return append(append([]Keybind(nil), scope.Keybinds...), fm.focusedKeybinds()...)
+	}
+	return fm.focusedKeybinds()
+}
+
+// focusedKeybinds returns the keybinds of the focused widget, its ancestors
+// and the root widget.
+func (fm *FocusManager) focusedKeybinds() []Keybind {
 	// Find the focused entry
 	var focusedEntry *FocusableEntry
 	for i := range fm.focusables {
@@ -550,6 +591,8 @@ type FocusCollector struct {
 	// trapStack tracks the IDs of enclosing FocusTrapper widgets.
 	// The last element is the innermost active trap.
 	trapStack []string
+	// pathStack holds the IDs of the widgets enclosing the current position.
+	pathStack []string
 }
 
 // NewFocusCollector creates a new focus collector.
@@ -578,6 +621,19 @@ func (fc *FocusCollector) CurrentTrapID() string {
 	return ""
 }
 
+// pushPath enters the widget with the given ID, so focusables collected
+// before the matching popPath record it in their Path.
+func (fc *FocusCollector) pushPath(id string) {
+	fc.pathStack = append(fc.pathStack, id)
+}
+
+// popPath leaves the widget entered by the last pushPath.
+func (fc *FocusCollector) popPath() {
+	if len(fc.pathStack) > 0 {
+		fc.pathStack = fc.pathStack[:len(fc.pathStack)-1]
+	}
+}
+
 // PushAncestor adds a widget to the ancestor chain.
 // Called when entering a widget that implements KeyHandler or KeybindProvider.
 func (fc *FocusCollector) PushAncestor(widget Widget) {
@@ -633,6 +689,7 @@ func (fc *FocusCollector) Collect(widget Widget, autoID string, ctx BuildContext
 		Focusable: focusable,
 		Ancestors: ancestors,
 		TrapID:    fc.CurrentTrapID(),
+		Path:      append([]string(nil), fc.pathStack...),
 	})
 }
 
@@ -646,6 +703,7 @@ func (fc *FocusCollector) Reset() {
 	fc.focusables = fc.focusables[:0]
 	fc.ancestorStack = fc.ancestorStack[:0]
 	fc.trapStack = fc.trapStack[:0]
+	fc.pathStack = fc.pathStack[:0]
 }
 
 // Len returns the number of focusables collected so far.
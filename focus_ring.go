@@ -0,0 +1,137 @@
+package terma
+
+import "sync/atomic"
+
+// FocusRing selects the indicator the framework draws around the focused
+// widget, in the theme's "border.focus" token (its FocusRing color unless
+// overridden). Set it per widget with Style.FocusRing, or for the whole app
+// with SetDefaultFocusRing.
+type FocusRing int
+
+const (
+	// FocusRingDefault uses the app default set with SetDefaultFocusRing.
+	FocusRingDefault FocusRing = iota
+	// FocusRingNone draws nothing, for widgets that show focus themselves.
+	FocusRingNone
+	// FocusRingBorder recolors the widget's border, or, for a widget
+	// without one, draws a rounded outline around it. The outline goes in
+	// the space around the widget when there is room and over the widget's
+	// edge cells when there isn't.
+	FocusRingBorder
+	// FocusRingTint tints the widget's background.
+	FocusRingTint
+)
+
+// focusRingTintAlpha is how strongly FocusRingTint tints the background.
+const focusRingTintAlpha = 0.2
+
+// defaultFocusRing is the ring used for Style.FocusRing == FocusRingDefault.
+var defaultFocusRing atomic.Int32
+
+func init() {
+	defaultFocusRing.Store(int32(FocusRingNone))
+}
+
+// SetDefaultFocusRing sets the focus indicator for widgets that don't
+// choose one in their style. The default is FocusRingNone, leaving focus
+// styling to each widget; FocusRingBorder makes focus visible everywhere.
+//
+// Example:
+//
+//	t.SetDefaultFocusRing(t.FocusRingBorder)
+//	t.Run(app)
+func SetDefaultFocusRing(ring FocusRing) {
+	if ring == FocusRingDefault {
+		ring = FocusRingNone
+	}
+	defaultFocusRing.Store(int32(ring))
+}
+
+// resolveFocusRing returns the ring to draw for a style's FocusRing.
+func resolveFocusRing(ring FocusRing) FocusRing {
+	if ring == FocusRingDefault {
+		return FocusRing(defaultFocusRing.Load())
+	}
+	return ring
+}
+
+// pendingFocusRing is a focus indicator waiting to be drawn. Its position
+// is relative to ctx, the context the focused widget was rendered in.
+type pendingFocusRing struct {
+	ring                FocusRing
+	ctx                 *RenderContext
+	x, y, width, height int
+	border              Border
+}
+
+// drawFocusRing draws the queued focus indicator, if any.
+func (r *Renderer) drawFocusRing() {
+	pending := r.focusRing
+	r.focusRing = nil
+	if pending == nil || pending.width <= 0 || pending.height <= 0 {
+		return
+	}
+	color := pending.ctx.buildContext.Theme().Token(TokenBorderFocus)
+	inner := pending.ctx.SubContext(pending.x, pending.y, pending.width, pending.height)
+
+	if pending.ring == FocusRingTint {
+		tintFocusedWidget(inner, color)
+		return
+	}
+
+	if !pending.border.IsZero() && pending.border.Style != BorderNone {
+		border := pending.border
+		border.Color = color
+		inner.DrawBorder(0, 0, pending.width, pending.height, border)
+		return
+	}
+	outline := Border{Style: BorderRounded, Color: color}
+	outer := Rect{X: inner.X - 1, Y: inner.Y - 1, Width: pending.width + 2, Height: pending.height + 2}
+	room := pending.ctx.focusRingClip
+	if room.IsEmpty() {
+		room = pending.ctx.clip
+	}
+	switch {
+	case room.Intersect(outer) == outer:
+		outerCtx := pending.ctx.SubContext(pending.x-1, pending.y-1, outer.Width, outer.Height)
+		outerCtx.clip = outer
+		outerCtx.DrawBorder(0, 0, outer.Width, outer.Height, outline)
+	case pending.width >= 2 && pending.height >= 2:
+		inner.DrawBorder(0, 0, pending.width, pending.height, outline)
+	default:
+		// Too small for an outline; tint instead so focus stays visible.
+		tintFocusedWidget(inner, color)
+	}
+}
+
+// tintFocusedWidget blends color into the background of every cell in ctx.
+func tintFocusedWidget(ctx *RenderContext, color Color) {
+	tintBackground(ctx, color.WithAlpha(focusRingTintAlpha))
+}
+
+// tintBackground blends a translucent tint into the background of every
+// cell in ctx.
+func tintBackground(ctx *RenderContext, tint Color) {
+	for row := 0; row < ctx.Height; row++ {
+		for col := 0; col < ctx.Width; col++ {
+			x, y := ctx.X+col, ctx.Y+row
+			if !ctx.clip.Contains(x, y) {
+				continue
+			}
+			cell := ctx.terminal.CellAt(x, y)
+			if cell == nil {
+				continue
+			}
+			bg := FromANSI(cell.Style.Bg)
+			if !bg.IsSet() && ctx.inheritedBgAt != nil {
+				bg = ctx.inheritedBgAt(x, y)
+			}
+			if !bg.IsSet() {
+				bg = terminalBackground()
+			}
+			tinted := *cell
+			tinted.Style.Bg = tint.BlendOver(bg).toANSI()
+			ctx.terminal.SetCell(x, y, &tinted)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func useDefaultFocusRing(t *testing.T, ring FocusRing) {
+	t.Helper()
+	SetDefaultFocusRing(ring)
+	t.Cleanup(func() { SetDefaultFocusRing(FocusRingNone) })
+}
+
+func TestFocusRing_OutlineAroundFocusedWidget(t *testing.T) {
+	theme := newTestBuildContext().Theme()
+	widget := Column{
+		Style: Style{Padding: EdgeInsetsAll(1)},
+		Children: []Widget{
+			Button{ID: "ok", Label: "OK", Style: Style{FocusRing: FocusRingBorder}},
+		},
+	}
+
+	buf := renderToBufferWithFocus(widget, 10, 3, "ok")
+	assert.Equal(t, "╭", buf.CellAt(0, 0).Content)
+	assert.Equal(t, theme.FocusRing.toANSI(), buf.CellAt(0, 0).Style.Fg)
+	assert.Equal(t, "╯", buf.CellAt(5, 2).Content)
+
+	buf = renderToBufferWithFocus(widget, 10, 3, "")
+	assert.Equal(t, " ", buf.CellAt(0, 0).Content, "nothing drawn without focus")
+}
+
+func TestFocusRing_OutlineInsideWhenNoRoom(t *testing.T) {
+	theme := newTestBuildContext().Theme()
+	widget := Column{
+		Children: []Widget{
+			Button{ID: "ok", Label: "OK", Style: Style{FocusRing: FocusRingBorder, Height: Cells(3), Width: Cells(6)}},
+		},
+	}
+
+	buf := renderToBufferWithFocus(widget, 10, 3, "ok")
+	assert.Equal(t, "╭", buf.CellAt(0, 0).Content)
+	assert.Equal(t, "╯", buf.CellAt(5, 2).Content)
+	assert.Equal(t, theme.FocusRing.toANSI(), buf.CellAt(5, 2).Style.Fg)
+}
+
+func TestFocusRing_RecolorsOwnBorder(t *testing.T) {
+	theme := newTestBuildContext().Theme()
+	widget := Column{
+		Children: []Widget{
+			Button{ID: "ok", Label: "OK", Style: Style{
+				FocusRing: FocusRingBorder,
+				Border:    SquareBorder(theme.Border),
+			}},
+		},
+	}
+
+	buf := renderToBufferWithFocus(widget, 10, 3, "ok")
+	assert.Equal(t, "┌", buf.CellAt(0, 0).Content, "keeps the widget's border style")
+	assert.Equal(t, theme.FocusRing.toANSI(), buf.CellAt(0, 0).Style.Fg)
+}
+
+func TestFocusRing_Tint(t *testing.T) {
+	plain := Column{Children: []Widget{Button{ID: "ok", Label: "OK", Style: Style{FocusRing: FocusRingNone}}}}
+	tinted := Column{Children: []Widget{Button{ID: "ok", Label: "OK", Style: Style{FocusRing: FocusRingTint}}}}
+
+	before := renderToBufferWithFocus(plain, 10, 1, "ok")
+	after := renderToBufferWithFocus(tinted, 10, 1, "ok")
+	assert.Equal(t, before.CellAt(1, 0).Content, after.CellAt(1, 0).Content)
+	assert.NotEqual(t, before.CellAt(1, 0).Style.Bg, after.CellAt(1, 0).Style.Bg)
+	assert.Equal(t, before.CellAt(8, 0).Style.Bg, after.CellAt(8, 0).Style.Bg, "only the focused widget is tinted")
+}
+
+func TestFocusRing_DefaultFromApp(t *testing.T) {
+	widget := Column{
+		Style:    Style{Padding: EdgeInsetsAll(1)},
+		Children: []Widget{Button{ID: "ok", Label: "OK"}},
+	}
+
+	buf := renderToBufferWithFocus(widget, 10, 3, "ok")
+	assert.Equal(t, " ", buf.CellAt(0, 0).Content, "no ring by default")
+
+	useDefaultFocusRing(t, FocusRingBorder)
+	buf = renderToBufferWithFocus(widget, 10, 3, "ok")
+	assert.Equal(t, "╭", buf.CellAt(0, 0).Content)
+
+	opted := Column{
+		Style:    Style{Padding: EdgeInsetsAll(1)},
+		Children: []Widget{Button{ID: "ok", Label: "OK", Style: Style{FocusRing: FocusRingNone}}},
+	}
+	buf = renderToBufferWithFocus(opted, 10, 3, "ok")
+	assert.Equal(t, " ", buf.CellAt(0, 0).Content, "widgets can opt out")
+}
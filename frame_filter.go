@@ -0,0 +1,132 @@
+package terma
+
+import (
+	"sync"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// FrameFilter post-processes each rendered frame before it is shown, for
+// screen-wide effects such as dimming, grayscale or scanlines.
+type FrameFilter func(frame *Frame)
+
+// frameFilters holds the registered filters in the order they run.
+var frameFilters struct {
+	mu      sync.Mutex
+	nextID  int
+	filters []registeredFrameFilter
+}
+
+type registeredFrameFilter struct {
+	id     int
+	filter FrameFilter
+}
+
+// AddFrameFilter registers a filter that runs on every frame after the
+// widget tree (floats included) has been painted, in the order the filters
+// were added. Debug overlays are drawn after the filters, unaffected.
+// Returns a function that removes the filter. Both are safe to call from
+// any goroutine and schedule a new frame.
+//
+// Example (grayscale while disconnected):
+//
+//	removeGrayscale := t.AddFrameFilter(func(frame *t.Frame) {
+//	    frame.MapColors(func(x, y int, fg, bg t.Color) (t.Color, t.Color) {
+//	        return fg.Desaturate(1), bg.Desaturate(1)
+//	    })
+//	})
+func AddFrameFilter(filter FrameFilter) (remove func()) {
+	frameFilters.mu.Lock()
+	frameFilters.nextID++
+	id := frameFilters.nextID
+	frameFilters.filters = append(frameFilters.filters, registeredFrameFilter{id: id, filter: filter})
+	frameFilters.mu.Unlock()
+	scheduleRender()
+
+	return func() {
+		frameFilters.mu.Lock()
+		for i, registered := range frameFilters.filters {
+			if registered.id == id {
+				frameFilters.filters = append(frameFilters.filters[:i:i], frameFilters.filters[i+1:]...)
+				break
+			}
+		}
+		frameFilters.mu.Unlock()
+		scheduleRender()
+	}
+}
+
+// applyFrameFilters runs the registered filters over the frame.
+func applyFrameFilters(buffer CellBuffer, width, height, modalCount int) {
+	frameFilters.mu.Lock()
+	filters := make([]FrameFilter, len(frameFilters.filters))
+	for i, registered := range frameFilters.filters {
+		filters[i] = registered.filter
+	}
+	frameFilters.mu.Unlock()
+
+	if len(filters) == 0 || width <= 0 || height <= 0 {
+		return
+	}
+	frame := &Frame{Width: width, Height: height, ModalCount: modalCount, buffer: buffer}
+	for _, filter := range filters {
+		filter(frame)
+	}
+}
+
+// Frame is a rendered frame passed to a FrameFilter, giving access to its
+// cells.
+type Frame struct {
+	Width, Height int
+	ModalCount    int // Number of modal floats open in this frame
+	buffer        CellBuffer
+}
+
+// CellAt returns the cell at (x, y), or nil outside the frame.
+func (f *Frame) CellAt(x, y int) *uv.Cell {
+	if x < 0 || y < 0 || x >= f.Width || y >= f.Height {
+		return nil
+	}
+	return f.buffer.CellAt(x, y)
+}
+
+// SetCell replaces the cell at (x, y). Cells outside the frame are ignored.
+func (f *Frame) SetCell(x, y int, cell *uv.Cell) {
+	if x < 0 || y < 0 || x >= f.Width || y >= f.Height {
+		return
+	}
+	f.buffer.SetCell(x, y, cell)
+}
+
+// MapColors replaces the foreground and background color of every cell
+// with the colors fn returns for it. An unset background is passed as the
+// terminal's background; an unset foreground (the terminal's default text
+// color) is passed unset. Colors fn returns unchanged are left as they
+// were.
+func (f *Frame) MapColors(fn func(x, y int, fg, bg Color) (Color, Color)) {
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < f.Width; x++ {
+			cell := f.buffer.CellAt(x, y)
+			if cell == nil {
+				continue
+			}
+			fg := FromANSI(cell.Style.Fg)
+			bg := FromANSI(cell.Style.Bg)
+			if !bg.IsSet() {
+				bg = terminalBackground()
+			}
+			newFg, newBg := fn(x, y, fg, bg)
+			if newFg == fg && newBg == bg {
+				continue
+			}
+			mapped := *cell
+			if newFg != fg {
+				mapped.Style.Fg = newFg.toANSI()
+			}
+			if newBg != bg {
+				mapped.Style.Bg = newBg.toANSI()
+			}
+			f.buffer.SetCell(x, y, &mapped)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrameFilter_RunsInOrderUntilRemoved(t *testing.T) {
+	buf := uv.NewBuffer(2, 1)
+	var calls []string
+	removeFirst := AddFrameFilter(func(frame *Frame) { calls = append(calls, "first") })
+	removeSecond := AddFrameFilter(func(frame *Frame) {
+		calls = append(calls, "second")
+		assert.Equal(t, 2, frame.Width)
+		assert.Equal(t, 1, frame.ModalCount)
+	})
+	t.Cleanup(removeSecond)
+
+	applyFrameFilters(buf, 2, 1, 1)
+	removeFirst()
+	applyFrameFilters(buf, 2, 1, 1)
+
+	assert.Equal(t, []string{"first", "second", "second"}, calls)
+}
+
+func TestFrame_MapColors(t *testing.T) {
+	buf := uv.NewBuffer(2, 1)
+	ctx := NewRenderContext(buf, 2, 1, nil, nil, BuildContext{}, nil)
+	ctx.DrawStyledText(0, 0, "a", Style{ForegroundColor: RGB(200, 0, 0), BackgroundColor: RGB(0, 0, 200)})
+	ctx.DrawText(1, 0, "b")
+
+	frame := &Frame{Width: 2, Height: 1, buffer: buf}
+	frame.MapColors(func(x, y int, fg, bg Color) (Color, Color) {
+		if x == 1 {
+			return fg, bg // unchanged cells keep their unset colors
+		}
+		return fg.Desaturate(1), bg.Darken(0.5)
+	})
+
+	cell := frame.CellAt(0, 0)
+	assert.Equal(t, "a", cell.Content)
+	assert.Equal(t, RGB(200, 0, 0).Desaturate(1).toANSI(), cell.Style.Fg)
+	assert.Equal(t, RGB(0, 0, 200).Darken(0.5).toANSI(), cell.Style.Bg)
+	assert.Nil(t, frame.CellAt(1, 0).Style.Bg)
+	assert.Nil(t, frame.CellAt(2, 0), "outside the frame")
+}
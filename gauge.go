@@ -0,0 +1,249 @@
+package terma
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/darrenburns/terma/layout"
+)
+
+// GaugeShape is the arc a Gauge is drawn on.
+type GaugeShape int
+
+const (
+	// GaugeSemicircle draws a half circle, open at the bottom.
+	GaugeSemicircle GaugeShape = iota
+	// GaugeDial draws three quarters of a circle, like a speedometer.
+	GaugeDial
+)
+
+// Gauge shows a single bounded value as a dial: an arc that fills from
+// the minimum on the left towards the maximum on the right, with the value
+// in its center. Where ProgressBar shows how far along a task is, a Gauge
+// reads like an instrument, such as load or temperature.
+//
+// Thresholds divide the arc into color bands. The filled part takes the
+// color of each band it passes through and the rest of the track shows the
+// bands dimmed, so it is plain how close the value is to the next one.
+//
+// With an ID and Animation set, the arc and the value sweep to each new
+// Value over that duration instead of jumping.
+//
+// Example:
+//
+//	terma.Gauge{
+//	    ID:        "cpu",
+//	    Value:     a.cpu.Get(),
+//	    Label:     "CPU",
+//	    Animation: 300 * time.Millisecond,
+//	    Thresholds: []terma.Threshold{
+//	        {Value: 60, Color: theme.Warning},
+//	        {Value: 90, Color: theme.Error},
+//	    },
+//	}
+type Gauge struct {
+	ID         string                     // Optional unique identifier; required for Animation
+	Value      float64                    // The value shown, clamped to Min..Max
+	Min        float64                    // Start of the scale (default 0)
+	Max        float64                    // End of the scale (default 100)
+	Shape      GaugeShape                 // Arc to draw on (default: semicircle)
+	Label      string                     // Optional text below the value, such as "CPU"
+	Format     func(value float64) string // Formats the value in the center (default: 4 significant digits; return "" to hide)
+	Color      Color                      // Arc color below all thresholds (default: theme Primary)
+	Thresholds []Threshold                // Colors for the parts of the arc that reach them
+	Thickness  int                        // Width of the arc in braille dots (default: a quarter of its radius, at least 2)
+	Animation  time.Duration              // Sweep to new values over this duration (0 = jump)
+	Style      Style                      // Optional styling
+
+	shown float64 // Value drawn, part way to Value while animating
+}
+
+// gaugeValues holds the animated value of each Gauge with an ID.
+var gaugeValues struct {
+	sync.Mutex
+	byID map[string]*AnimatedValue[float64]
+}
+
+// Build returns the gauge as a leaf widget, with the value to draw while
+// an animation to Value runs.
+func (g Gauge) Build(ctx BuildContext) Widget {
+	g.shown = g.clamp(g.Value)
+	if g.Animation <= 0 || g.ID == "" || ReducedMotion() {
+		return g
+	}
+	gaugeValues.Lock()
+	if gaugeValues.byID == nil {
+		gaugeValues.byID = map[string]*AnimatedValue[float64]{}
+	}
+	value := gaugeValues.byID[g.ID]
+	if value == nil {
+		value = NewAnimatedValue(AnimatedValueConfig[float64]{Initial: g.shown, Duration: g.Animation})
+		gaugeValues.byID[g.ID] = value
+	}
+	gaugeValues.Unlock()
+	value.Set(g.shown)
+	g.shown = value.Get()
+	return g
+}
+
+// WidgetID returns the gauge's unique identifier.
+// Implements the Identifiable interface.
+func (g Gauge) WidgetID() string {
+	return g.ID
+}
+
+// GetContentDimensions returns the width and height dimension preferences.
+// Width defaults to Cells(24), Height defaults to Cells(6) for a semicircle
+// and Cells(12) for a dial, which draw round in most terminal fonts.
+func (g Gauge) GetContentDimensions() (width, height Dimension) {
+	dims := g.Style.GetDimensions()
+	width, height = dims.Width, dims.Height
+	if width.IsUnset() {
+		width = Cells(24)
+	}
+	if height.IsUnset() {
+		height = Cells(6)
+		if g.Shape == GaugeDial {
+			height = Cells(12)
+		}
+	}
+	return width, height
+}
+
+// GetStyle returns the style of the gauge.
+func (g Gauge) GetStyle() Style {
+	return g.Style
+}
+
+// BuildLayoutNode builds a layout node for this Gauge widget.
+func (g Gauge) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return monitorLayoutNode(g, g.Style)
+}
+
+// scale returns the ends of the scale.
+func (g Gauge) scale() (lo, hi float64) {
+	lo, hi = g.Min, g.Max
+	if hi == 0 && lo == 0 {
+		hi = 100
+	}
+	if hi <= lo {
+		hi = lo + 1
+	}
+	return lo, hi
+}
+
+// clamp returns value within the scale.
+func (g Gauge) clamp(value float64) float64 {
+	lo, hi := g.scale()
+	if math.IsNaN(value) {
+		return lo
+	}
+	return max(lo, min(value, hi))
+}
+
+// gaugeCell is what the arc drew in one cell.
+type gaugeCell struct {
+	filled, track           rune // Braille dots of each part
+	filledColor, trackColor Color
+}
+
+// Render draws the arc, the value and the label.
+func (g Gauge) Render(ctx *RenderContext) {
+	if ctx.Width <= 0 || ctx.Height <= 0 {
+		return
+	}
+	theme := ctx.buildContext.Theme()
+	lo, hi := g.scale()
+	base := g.Color
+	if !base.IsSet() {
+		base = theme.Primary
+	}
+
+	// The arc runs clockwise from start, in degrees counterclockwise from
+	// the right, through sweep degrees. Braille dots are about square, so
+	// the circle is measured in dots.
+	start, sweep := 180.0, 180.0
+	rows := ctx.Height // Rows the arc is drawn in
+	valueRow, labelRow := 0, 0
+	if g.Shape == GaugeDial {
+		start, sweep = 225, 270
+	} else if g.Label != "" && rows > 1 {
+		rows--
+	}
+	width, height := ctx.Width*2, rows*4
+	var cx, cy, radius float64
+	if g.Shape == GaugeDial {
+		radius = (math.Min(float64(width), float64(height)) - 1) / 2
+		cx, cy = float64(width-1)/2, float64(height-1)/2
+		valueRow = int(cy) / 4
+		labelRow = min(valueRow+1, ctx.Height-1)
+		if bottom := int(cy+radius*math.Sin(math.Pi/4)) / 4; bottom > labelRow && bottom < ctx.Height {
+			labelRow = bottom
+		}
+	} else {
+		radius = math.Min(float64(width-1)/2, float64(height-1))
+		cx, cy = float64(width-1)/2, float64(height-1)
+		valueRow = rows - 1
+		labelRow = rows // Below the arc, kept free for a label
+	}
+	thickness := float64(g.Thickness)
+	if thickness <= 0 {
+		thickness = math.Max(2, math.Round(radius/4))
+	}
+
+	filledTo := (g.shown - lo) / (hi - lo)
+	cells := make([][]gaugeCell, rows)
+	for y := range cells {
+		cells[y] = make([]gaugeCell, ctx.Width)
+	}
+	for dy := 0; dy < height; dy++ {
+		for dx := 0; dx < width; dx++ {
+			x, y := float64(dx)-cx, cy-float64(dy)
+			distance := math.Hypot(x, y)
+			if distance > radius+0.5 || distance <= radius+0.5-thickness {
+				continue
+			}
+			angle := math.Mod(start-math.Atan2(y, x)*180/math.Pi+720, 360)
+			if angle > sweep {
+				continue
+			}
+			along := angle / sweep
+			color := thresholdColor(g.Thresholds, lo+along*(hi-lo), base)
+			cell := &cells[dy/4][dx/2]
+			dot := brailleDots[dx%2][3-dy%4]
+			if along <= filledTo && g.shown > lo {
+				cell.filled |= dot
+				cell.filledColor = color
+			} else {
+				cell.track |= dot
+				cell.trackColor = color.Blend(theme.Background, 0.7)
+			}
+		}
+	}
+	for y, row := range cells {
+		for x, cell := range row {
+			switch {
+			case cell.filled != 0:
+				// A cell where the fill ends shows the rest of its dots
+				// filled too, rather than a notch in the arc.
+				ctx.DrawStyledText(x, y, string(0x2800+cell.filled|cell.track), Style{ForegroundColor: cell.filledColor})
+			case cell.track != 0:
+				ctx.DrawStyledText(x, y, string(0x2800+cell.track), Style{ForegroundColor: cell.trackColor})
+			}
+		}
+	}
+
+	if text := chartFormat(g.Format)(g.shown); text != "" && valueRow >= 0 {
+		text = ansi.Truncate(text, ctx.Width, "")
+		ctx.DrawStyledText((ctx.Width-ansi.StringWidth(text))/2, valueRow, text, Style{
+			ForegroundColor: thresholdColor(g.Thresholds, g.shown, base),
+			Bold:            true,
+		})
+	}
+	if g.Label != "" && labelRow < ctx.Height && labelRow != valueRow {
+		label := ansi.Truncate(g.Label, ctx.Width, "")
+		ctx.DrawStyledText((ctx.Width-ansi.StringWidth(label))/2, labelRow, label, Style{ForegroundColor: theme.TextMuted})
+	}
+}
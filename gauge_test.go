@@ -0,0 +1,95 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func renderGauge(gauge Gauge, width, height int) (*uv.Buffer, []string) {
+	renderer, buf := portalTestRenderer(width, height)
+	gauge.Style.Width = Cells(width)
+	gauge.Style.Height = Cells(height)
+	renderer.Render(gauge)
+	lines := make([]string, height)
+	for y := range lines {
+		lines[y] = strings.TrimRight(bufferLine(buf, y, width), " ")
+	}
+	return buf, lines
+}
+
+func TestGauge_DrawsArcValueAndLabel(t *testing.T) {
+	_, lines := renderGauge(Gauge{Value: 72, Label: "CPU"}, 12, 3)
+
+	assert.Equal(t, []string{
+		"   ⣴⠾⠛⠛⠷⣦",
+		"  ⣸⠇ 72 ⠸⣇",
+		"    CPU",
+	}, lines)
+}
+
+func TestGauge_ThresholdBands(t *testing.T) {
+	theme := getTheme()
+	low, high := RGB(0, 200, 0), RGB(200, 0, 0)
+	gauge := Gauge{Value: 40, Color: low, Thresholds: []Threshold{{Value: 60, Color: high}}}
+
+	buf, lines := renderGauge(gauge, 12, 3)
+	left := strings.IndexFunc(lines[2], func(r rune) bool { return r != ' ' })
+	right := len([]rune(lines[2])) - 1
+
+	assert.Equal(t, low.toANSI(), buf.CellAt(left, 2).Style.Fg, "the fill starts in the lowest band")
+	assert.Equal(t, high.Blend(theme.Background, 0.7).toANSI(), buf.CellAt(right, 2).Style.Fg, "unfilled bands are dimmed")
+	assert.Equal(t, low.toANSI(), buf.CellAt(5, 2).Style.Fg, "the value takes its band's color")
+
+	gauge.Value = 150
+	buf, _ = renderGauge(gauge, 12, 3)
+	assert.Equal(t, high.toANSI(), buf.CellAt(right, 2).Style.Fg, "values past Max fill the arc")
+}
+
+func TestGauge_AnimatesToNewValues(t *testing.T) {
+	clock := withManualClock(t)
+	controller := NewAnimationController(60)
+	previous := currentController
+	currentController = controller
+	t.Cleanup(func() {
+		controller.Stop()
+		currentController = previous
+		gaugeValues.Lock()
+		delete(gaugeValues.byID, "gauge-animates")
+		gaugeValues.Unlock()
+	})
+
+	gauge := Gauge{ID: "gauge-animates", Value: 0, Animation: 100 * time.Millisecond}
+	value := func() string {
+		return strings.Fields(strings.Split(screenText(gauge, 12, 3), "\n")[2])[1]
+	}
+	require.Equal(t, "0", value())
+
+	gauge.Value = 100
+	assert.Equal(t, "0", value(), "the sweep starts from the old value")
+	clock.Advance(50 * time.Millisecond)
+	controller.Update()
+	mid := value()
+	assert.NotEqual(t, "0", mid)
+	assert.NotEqual(t, "100", mid)
+
+	clock.Advance(100 * time.Millisecond)
+	controller.Update()
+	assert.Equal(t, "100", value())
+}
+
+func TestGauge_Snapshot(t *testing.T) {
+	AssertSnapshot(t, Gauge{
+		Value: 72,
+		Label: "CPU",
+		Shape: GaugeDial,
+		Thresholds: []Threshold{
+			{Value: 60, Color: RGB(230, 180, 40)},
+			{Value: 85, Color: RGB(220, 60, 60)},
+		},
+	}, 24, 12, "Dial at 72: filled in the primary color to 60 then amber; the red band past 85 is dimmed, and the value sits in the center with CPU in the gap at the bottom")
+}
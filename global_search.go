@@ -0,0 +1,660 @@
+package terma
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+const (
+	defaultGlobalSearchWidth       = 70
+	defaultGlobalSearchHeight      = 20
+	defaultGlobalSearchPlaceholder = "Search everything..."
+	defaultGlobalSearchEmptyLabel  = "No results"
+	defaultSearchProviderLimit     = 5
+)
+
+// SearchResult is a single result returned by a SearchProvider.
+type SearchResult struct {
+	Label       string // Primary display text
+	Description string // Optional muted text shown after the label (e.g., a path)
+	Hint        string // Optional right-aligned text
+	Action      func() // Called when the result is chosen (unless GlobalSearch.OnSelect is set)
+	Data        any    // User data for OnSelect
+}
+
+// SearchProvider is a source of results for a GlobalSearch, such as
+// commands, files or the app's own data. Search runs on a background
+// goroutine for every query; ctx is cancelled once the query changes or the
+// search closes, so a slow provider can stop early.
+type SearchProvider struct {
+	Name   string // Group heading; also identifies the provider
+	Limit  int    // Results shown until the group's "see all" is chosen (default: 5)
+	Search func(ctx context.Context, query string) ([]SearchResult, error)
+}
+
+// limit returns how many results the provider's group shows collapsed.
+func (p SearchProvider) limit() int {
+	if p.Limit <= 0 {
+		return defaultSearchProviderLimit
+	}
+	return p.Limit
+}
+
+// searchGroup holds one provider's results for the current query.
+type searchGroup struct {
+	results []SearchResult
+	loading bool
+	err     error
+}
+
+// searchRowKind identifies what a row in the result list shows.
+type searchRowKind int
+
+const (
+	searchRowHeader searchRowKind = iota
+	searchRowResult
+	searchRowMore
+)
+
+// searchRow is one row of the result list: a provider's heading, one of
+// its results, or its "see all" entry.
+type searchRow struct {
+	kind     searchRowKind
+	provider string
+	index    int // Result index within the provider's group
+	result   SearchResult
+	group    searchGroup // For headers and "see all"
+}
+
+// isSelectable reports whether the cursor can rest on the row.
+func (r searchRow) isSelectable() bool {
+	return r.kind != searchRowHeader
+}
+
+// key identifies the row across result updates, so the cursor stays on it
+// while other providers' results arrive.
+func (r searchRow) key() string {
+	switch r.kind {
+	case searchRowHeader:
+		return "header:" + r.provider
+	case searchRowMore:
+		return "more:" + r.provider
+	}
+	return fmt.Sprintf("result:%s:%d", r.provider, r.index)
+}
+
+// GlobalSearchState holds the providers, the query and the results of a
+// GlobalSearch.
+type GlobalSearchState struct {
+	Visible Signal[bool]
+
+	input  *TextInputState
+	list   *ListState[searchRow]
+	scroll *ScrollState
+
+	mu        sync.Mutex
+	providers []SearchProvider
+	groups    map[string]searchGroup
+	expanded  string // Provider whose "see all" was chosen, or ""
+	query     string
+	cancel    context.CancelFunc
+	moved     bool // The user moved the cursor, so arriving results leave it be
+
+	wasVisible  bool
+	lastFocusID string
+}
+
+// NewGlobalSearchState creates a search over the given providers. Their
+// groups are listed in this order.
+func NewGlobalSearchState(providers ...SearchProvider) *GlobalSearchState {
+	list := NewListState([]searchRow{})
+	list.KeyFor = searchRow.key
+	return &GlobalSearchState{
+		Visible:   NewSignal(false),
+		input:     NewTextInputState(""),
+		list:      list,
+		scroll:    NewScrollState(),
+		providers: slices.Clone(providers),
+		groups:    map[string]searchGroup{},
+	}
+}
+
+// Register adds a provider, or replaces the one with the same name, and
+// searches it for the current query if the search is open.
+func (s *GlobalSearchState) Register(provider SearchProvider) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if i := slices.IndexFunc(s.providers, func(p SearchProvider) bool { return p.Name == provider.Name }); i >= 0 {
+		s.providers[i] = provider
+	} else {
+		s.providers = append(s.providers, provider)
+	}
+	s.mu.Unlock()
+	if s.Visible.Peek() {
+		s.search(s.Query())
+	}
+}
+
+// Unregister removes the provider with the given name and its results.
+func (s *GlobalSearchState) Unregister(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.providers = slices.DeleteFunc(s.providers, func(p SearchProvider) bool { return p.Name == name })
+	delete(s.groups, name)
+	if s.expanded == name {
+		s.expanded = ""
+	}
+	s.mu.Unlock()
+	s.updateRows()
+}
+
+// Open shows the search and runs the providers for the current query.
+func (s *GlobalSearchState) Open() {
+	if s == nil {
+		return
+	}
+	s.Visible.Set(true)
+	s.search(s.Query())
+}
+
+// Close hides the search, cancels running searches and clears the query.
+func (s *GlobalSearchState) Close() {
+	if s == nil {
+		return
+	}
+	s.Visible.Set(false)
+	s.input.SetText("")
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+	s.query = ""
+	s.expanded = ""
+	s.groups = map[string]searchGroup{}
+	s.mu.Unlock()
+	s.updateRows()
+}
+
+// Query returns the text being searched for.
+func (s *GlobalSearchState) Query() string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.query
+}
+
+// SetQuery replaces the search text and searches for it.
+func (s *GlobalSearchState) SetQuery(query string) {
+	if s == nil {
+		return
+	}
+	s.input.SetText(query)
+	s.search(query)
+}
+
+// Expand shows all of a provider's results in place of the other groups,
+// as choosing its "see all" entry does.
+func (s *GlobalSearchState) Expand(provider string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.expanded = provider
+	s.moved = false
+	s.mu.Unlock()
+	s.updateRows()
+}
+
+// Collapse goes back from one provider's results to all the groups.
+// Returns false if no group was expanded.
+func (s *GlobalSearchState) Collapse() bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	provider := s.expanded
+	s.expanded = ""
+	s.moved = true
+	s.mu.Unlock()
+	if provider == "" {
+		return false
+	}
+	s.updateRows()
+	if i := slices.IndexFunc(s.list.GetItems(), func(r searchRow) bool {
+		return r.kind == searchRowMore && r.provider == provider
+	}); i >= 0 {
+		s.list.SelectIndex(i)
+	}
+	return true
+}
+
+// Expanded returns the provider whose results are all shown, or "".
+func (s *GlobalSearchState) Expanded() string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.expanded
+}
+
+// search cancels the running searches and starts every provider on query.
+func (s *GlobalSearchState) search(query string) {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.query = query
+	s.expanded = ""
+	s.moved = false
+	s.groups = make(map[string]searchGroup, len(s.providers))
+	providers := slices.Clone(s.providers)
+	for _, p := range providers {
+		s.groups[p.Name] = searchGroup{loading: true}
+	}
+	s.mu.Unlock()
+
+	s.updateRows()
+	s.scroll.SetOffset(0)
+
+	for _, p := range providers {
+		Go(func() {
+			results, err := p.Search(ctx, query)
+			if ctx.Err() != nil {
+				return
+			}
+			s.mu.Lock()
+			if ctx.Err() != nil {
+				s.mu.Unlock()
+				return
+			}
+			s.groups[p.Name] = searchGroup{results: results, err: err}
+			s.mu.Unlock()
+			s.updateRows()
+		})
+	}
+}
+
+// updateRows rebuilds the result list from the groups. Groups without
+// results are left out unless they are still loading or failed. Until the
+// user moves the cursor, it stays on the first result.
+func (s *GlobalSearchState) updateRows() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var rows []searchRow
+	for _, p := range s.providers {
+		group, ok := s.groups[p.Name]
+		if !ok || (s.expanded != "" && s.expanded != p.Name) {
+			continue
+		}
+		if len(group.results) == 0 && !group.loading && group.err == nil {
+			continue
+		}
+		rows = append(rows, searchRow{kind: searchRowHeader, provider: p.Name, group: group})
+		shown := len(group.results)
+		if s.expanded == "" {
+			shown = min(shown, p.limit())
+		}
+		for i, result := range group.results[:shown] {
+			rows = append(rows, searchRow{kind: searchRowResult, provider: p.Name, index: i, result: result})
+		}
+		if shown < len(group.results) {
+			rows = append(rows, searchRow{kind: searchRowMore, provider: p.Name, group: group})
+		}
+	}
+	s.list.SetItems(rows)
+	if !s.moved {
+		if first := slices.IndexFunc(rows, searchRow.isSelectable); first >= 0 {
+			s.list.SelectIndex(first)
+		}
+	}
+}
+
+// moveCursor moves the cursor by delta selectable rows. Returns false if
+// there is no row in that direction.
+func (s *GlobalSearchState) moveCursor(delta int) bool {
+	rows := s.list.GetItems()
+	for i := s.list.CursorIndex.Peek() + delta; i >= 0 && i < len(rows); i += delta {
+		if rows[i].isSelectable() {
+			s.mu.Lock()
+			s.moved = true
+			s.mu.Unlock()
+			s.list.SelectIndex(i)
+			return true
+		}
+	}
+	return false
+}
+
+// currentRow returns the row under the cursor, if it is selectable.
+func (s *GlobalSearchState) currentRow() (searchRow, bool) {
+	row, ok := s.list.SelectedItem()
+	if !ok || !row.isSelectable() {
+		return searchRow{}, false
+	}
+	return row, true
+}
+
+// Loading reports whether any provider is still searching.
+func (s *GlobalSearchState) Loading() bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, group := range s.groups {
+		if group.loading {
+			return true
+		}
+	}
+	return false
+}
+
+// GlobalSearch is a search overlay across several providers, such as
+// commands, files and the app's own data. Each keystroke runs every
+// provider in the background, and results are listed under a heading per
+// provider as they arrive. A group shows up to its provider's Limit
+// results, followed by a "see all" entry that lists the rest.
+//
+// Where a CommandPalette filters one list of items, GlobalSearch leaves
+// the matching to the providers, so each can search its own data however
+// suits it: a database query, a file index or a remote API.
+//
+// Example:
+//
+//	a.search = t.NewGlobalSearchState(
+//	    t.SearchProvider{Name: "Commands", Search: a.searchCommands},
+//	    t.SearchProvider{Name: "Files", Limit: 8, Search: a.searchFiles},
+//	)
+//	a.search.Register(t.SearchProvider{Name: "Tasks", Search: a.tasks.Search})
+//
+//	// In Build, with a keybind calling a.search.Open():
+//	t.GlobalSearch{ID: "search", State: a.search}
+type GlobalSearch struct {
+	ID            string
+	State         *GlobalSearchState
+	OnSelect      func(provider string, result SearchResult) // Custom selection handler (default: calls result.Action)
+	OnDismiss     func()                                     // Called when the search closes without a choice
+	Placeholder   string                                     // Default: "Search everything..."
+	BackdropColor Color                                      // Optional modal backdrop color override (default: theme.Overlay)
+	Style         Style                                      // Optional styling (default: Cells(70) wide, at most Cells(20) tall)
+}
+
+// Build renders the search as a floating modal.
+func (g GlobalSearch) Build(ctx BuildContext) Widget {
+	if g.State == nil {
+		return EmptyWidget{}
+	}
+
+	visible := g.State.Visible.Get()
+	if visible && !g.State.wasVisible {
+		if focused := ctx.Focused(); focused != nil {
+			if identifiable, ok := focused.(Identifiable); ok {
+				g.State.lastFocusID = identifiable.WidgetID()
+			}
+		}
+	}
+	if visible {
+		RequestFocus(g.inputID())
+	} else if g.State.wasVisible && g.State.lastFocusID != "" {
+		RequestFocus(g.State.lastFocusID)
+	}
+	g.State.wasVisible = visible
+
+	if !visible {
+		return EmptyWidget{}
+	}
+
+	theme := ctx.Theme()
+	backdropColor := theme.Overlay
+	if g.BackdropColor.IsSet() {
+		backdropColor = g.BackdropColor
+	}
+	float := Floating{
+		Visible: true,
+		Config: FloatConfig{
+			Position:              FloatPositionTopCenter,
+			Offset:                Offset{Y: defaultCommandPaletteTopOffsetY},
+			Modal:                 true,
+			DismissOnEsc:          BoolPtr(false),
+			DismissOnClickOutside: BoolPtr(true),
+			OnDismiss:             g.dismiss,
+			BackdropColor:         backdropColor,
+		},
+		Child: g.buildContent(ctx, theme),
+	}
+	return float.Build(ctx)
+}
+
+func (g GlobalSearch) buildContent(ctx BuildContext, theme ThemeData) Widget {
+	style := g.Style
+	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
+		style.BackgroundColor = theme.Token(TokenSurfaceFloating)
+	}
+	if style.Width.IsUnset() {
+		style.Width = Cells(defaultGlobalSearchWidth)
+	}
+	if style.Height.IsUnset() {
+		style.Height = Auto
+	}
+	if style.MaxHeight.IsUnset() {
+		style.MaxHeight = Cells(defaultGlobalSearchHeight)
+	}
+
+	return Column{
+		ID:         g.ID + "-content",
+		CrossAlign: CrossAxisStretch,
+		Style:      style,
+		Children:   []Widget{g.buildInput(theme), g.buildList(ctx, theme, style)},
+	}
+}
+
+func (g GlobalSearch) buildInput(theme ThemeData) Widget {
+	return TextInput{
+		ID:          g.inputID(),
+		State:       g.State.input,
+		Placeholder: g.placeholderText(),
+		Style: Style{
+			BackgroundColor: theme.Token(TokenSurfaceFloating),
+			ForegroundColor: theme.Text,
+			Padding:         commandPaletteInputPadding(),
+			Width:           Flex(1),
+		},
+		OnChange: g.State.search,
+		ExtraKeybinds: []Keybind{
+			{Key: "up", Action: func() { g.moveCursor(-1) }, Hidden: true},
+			{Key: "down", Action: func() { g.moveCursor(1) }, Hidden: true},
+			{Key: "ctrl+p", Action: func() { g.moveCursor(-1) }, Hidden: true},
+			{Key: "ctrl+n", Action: func() { g.moveCursor(1) }, Hidden: true},
+			{Key: "enter", Action: g.selectCurrent, Hidden: true},
+			{Key: "escape", Action: g.handleEscape, Hidden: true},
+		},
+	}
+}
+
+func (g GlobalSearch) buildList(ctx BuildContext, theme ThemeData, containerStyle Style) Widget {
+	rows := g.State.list.Items.Get()
+
+	var listChild Widget
+	if len(rows) == 0 {
+		listChild = Text{
+			Content:   defaultGlobalSearchEmptyLabel,
+			TextAlign: TextAlignCenter,
+			Style: Style{
+				ForegroundColor: theme.TextMuted,
+				Padding:         EdgeInsetsXY(1, 0),
+				Width:           Flex(1),
+			},
+		}
+	} else {
+		query := g.State.Query()
+		listChild = List[searchRow]{
+			ID:          g.listID(),
+			State:       g.State.list,
+			ScrollState: g.State.scroll,
+			RenderItem: func(row searchRow, active bool, selected bool) Widget {
+				return g.renderRow(theme, row, active && row.isSelectable(), query)
+			},
+			Style: Style{BackgroundColor: theme.Token(TokenSurfaceFloating)},
+		}
+	}
+
+	listStyle := Style{BackgroundColor: theme.Token(TokenSurfaceFloating)}
+	if maxHeight := containerStyle.MaxHeight; maxHeight.IsCells() {
+		inputHeight := 1 + commandPaletteInputPadding().Vertical()
+		listStyle.MaxHeight = Cells(max(0, maxHeight.CellsValue()-inputHeight))
+	} else {
+		listStyle.Height = Flex(1)
+	}
+	return Scrollable{
+		ID:    g.scrollID(),
+		State: g.State.scroll,
+		Style: listStyle,
+		Child: listChild,
+	}
+}
+
+func (g GlobalSearch) renderRow(theme ThemeData, row searchRow, active bool, query string) Widget {
+	switch row.kind {
+	case searchRowHeader:
+		spans := []Span{{Text: row.provider, Style: SpanStyle{Foreground: theme.TextMuted, Bold: true}}}
+		switch {
+		case row.group.err != nil:
+			spans = append(spans, ColorSpan("  "+row.group.err.Error(), theme.Error))
+		case row.group.loading:
+			spans = append(spans, ColorSpan("  searching…", theme.TextDisabled))
+		case g.State.Expanded() == row.provider:
+			spans = append(spans, ColorSpan(fmt.Sprintf("  %d results", len(row.group.results)), theme.TextDisabled))
+		}
+		return Text{Spans: spans, Style: Style{Padding: EdgeInsetsXY(1, 0), Width: Flex(1)}}
+
+	case searchRowMore:
+		style := Style{ForegroundColor: theme.Accent, Padding: EdgeInsets{Left: 3, Right: 1}, Width: Flex(1)}
+		if active {
+			style.BackgroundColor = theme.ActiveCursor
+			style.ForegroundColor = theme.SelectionText
+		}
+		return Text{
+			Content: fmt.Sprintf("See all %d results", len(row.group.results)),
+			Style:   style,
+			Click:   g.clickRow(row),
+		}
+	}
+
+	labelColor, mutedColor := theme.Text, theme.TextMuted
+	rowStyle := Style{Padding: EdgeInsets{Left: 3, Right: 1}, Width: Flex(1)}
+	if active {
+		rowStyle.BackgroundColor = theme.ActiveCursor
+		labelColor, mutedColor = theme.SelectionText, theme.SelectionText
+	}
+
+	label := []Span{PlainSpan(row.result.Label)}
+	if match := MatchString(row.result.Label, query, FilterOptions{}); match.Matched && len(match.Ranges) > 0 {
+		label = HighlightSpans(row.result.Label, match.Ranges, MatchHighlightStyle(theme))
+	}
+	if row.result.Description != "" {
+		label = append(label, ColorSpan("  "+row.result.Description, mutedColor))
+	}
+
+	children := []Widget{Text{Spans: label, Style: Style{ForegroundColor: labelColor, Width: Flex(1)}}}
+	if row.result.Hint != "" {
+		children = append(children, Text{Content: row.result.Hint, Style: Style{ForegroundColor: mutedColor, Padding: EdgeInsets{Left: 1}}})
+	}
+	return Row{
+		Style:    rowStyle,
+		Children: children,
+		Click:    g.clickRow(row),
+	}
+}
+
+// clickRow returns a handler that moves the cursor to row and chooses it.
+func (g GlobalSearch) clickRow(row searchRow) func(MouseEvent) {
+	return func(MouseEvent) {
+		key := row.key()
+		if i := slices.IndexFunc(g.State.list.GetItems(), func(r searchRow) bool { return r.key() == key }); i >= 0 {
+			g.State.list.SelectIndex(i)
+			g.selectCurrent()
+		}
+	}
+}
+
+func (g GlobalSearch) moveCursor(delta int) {
+	// Moving up from the first result reveals the first group's heading
+	if !g.State.moveCursor(delta) && delta < 0 {
+		g.State.scroll.SetOffset(g.State.scroll.GetOffset() - 1)
+	}
+}
+
+// selectCurrent chooses the row under the cursor: a result closes the
+// search and runs, and "see all" expands its group.
+func (g GlobalSearch) selectCurrent() {
+	row, ok := g.State.currentRow()
+	if !ok {
+		return
+	}
+	if row.kind == searchRowMore {
+		g.State.Expand(row.provider)
+		return
+	}
+	g.State.Close()
+	if g.OnSelect != nil {
+		g.OnSelect(row.provider, row.result)
+		return
+	}
+	if row.result.Action != nil {
+		row.result.Action()
+	}
+}
+
+func (g GlobalSearch) handleEscape() {
+	if g.State.Collapse() {
+		return
+	}
+	g.dismiss()
+}
+
+func (g GlobalSearch) dismiss() {
+	g.State.Close()
+	if g.OnDismiss != nil {
+		g.OnDismiss()
+	}
+}
+
+func (g GlobalSearch) placeholderText() string {
+	if g.Placeholder == "" {
+		return defaultGlobalSearchPlaceholder
+	}
+	return g.Placeholder
+}
+
+func (g GlobalSearch) inputID() string {
+	if g.ID == "" {
+		return ""
+	}
+	return g.ID + "-input"
+}
+
+func (g GlobalSearch) listID() string {
+	if g.ID == "" {
+		return ""
+	}
+	return g.ID + "-list"
+}
+
+func (g GlobalSearch) scrollID() string {
+	if g.ID == "" {
+		return ""
+	}
+	return g.ID + "-scroll"
+}
@@ -0,0 +1,193 @@
+package terma
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func staticSearch(results ...SearchResult) func(ctx context.Context, query string) ([]SearchResult, error) {
+	return func(ctx context.Context, query string) ([]SearchResult, error) {
+		return results, nil
+	}
+}
+
+func numberedResults(prefix string, n int) []SearchResult {
+	results := make([]SearchResult, n)
+	for i := range results {
+		results[i] = SearchResult{Label: fmt.Sprintf("%s%d.go", prefix, i), Description: "src/"}
+	}
+	return results
+}
+
+func waitForSearch(t *testing.T, state *GlobalSearchState) {
+	t.Helper()
+	require.Eventually(t, func() bool { return !state.Loading() }, time.Second, time.Millisecond)
+}
+
+func searchRowLabels(state *GlobalSearchState) []string {
+	var labels []string
+	for _, row := range state.list.GetItems() {
+		switch row.kind {
+		case searchRowHeader:
+			labels = append(labels, "# "+row.provider)
+		case searchRowMore:
+			labels = append(labels, "more "+row.provider)
+		default:
+			labels = append(labels, row.result.Label)
+		}
+	}
+	return labels
+}
+
+func TestGlobalSearch_GroupsResultsByProviderWithLimits(t *testing.T) {
+	state := NewGlobalSearchState(
+		SearchProvider{Name: "Commands", Search: staticSearch(SearchResult{Label: "Open File", Hint: "ctrl+o"}, SearchResult{Label: "Close"})},
+		SearchProvider{Name: "Empty", Search: staticSearch()},
+		SearchProvider{Name: "Files", Limit: 3, Search: staticSearch(numberedResults("file", 7)...)},
+	)
+	state.SetQuery("fi")
+	state.Open()
+	waitForSearch(t, state)
+
+	buf := renderToBufferWithFocus(GlobalSearch{ID: "search", State: state, Style: Style{Width: Cells(40)}}, 50, 14, "")
+	expected := []string{
+		"      fi                                          ",
+		"                                                  ",
+		"      Commands                                    ",
+		"        Open File                     ctrl+o      ",
+		"        Close                                     ",
+		"      Files                                       ",
+		"        file0.go  src/                            ",
+		"        file1.go  src/                            ",
+		"        file2.go  src/                            ",
+		"        See all 7 results                         ",
+	}
+	for i, line := range expected {
+		assert.Equal(t, line, bufferLine(buf, i+3, 50), "line %d", i+3)
+	}
+	row, ok := state.currentRow()
+	require.True(t, ok)
+	assert.Equal(t, "Open File", row.result.Label, "the cursor starts on the first result")
+}
+
+func TestGlobalSearch_SeeAllExpandsOneGroup(t *testing.T) {
+	state := NewGlobalSearchState(
+		SearchProvider{Name: "Commands", Search: staticSearch(SearchResult{Label: "Open File"})},
+		SearchProvider{Name: "Files", Limit: 2, Search: staticSearch(numberedResults("file", 4)...)},
+	)
+	search := GlobalSearch{State: state}
+	state.Open()
+	waitForSearch(t, state)
+
+	for range 4 {
+		search.moveCursor(1)
+	}
+	row, _ := state.currentRow()
+	assert.Equal(t, searchRowMore, row.kind, "moving down skips headings")
+
+	search.selectCurrent()
+	assert.Equal(t, "Files", state.Expanded())
+	assert.Equal(t, []string{"# Files", "file0.go", "file1.go", "file2.go", "file3.go"}, searchRowLabels(state))
+	row, _ = state.currentRow()
+	assert.Equal(t, "file0.go", row.result.Label)
+
+	search.handleEscape()
+	assert.Equal(t, "", state.Expanded())
+	assert.True(t, state.Visible.Peek(), "escape goes back to all groups before closing")
+	row, _ = state.currentRow()
+	assert.Equal(t, searchRowMore, row.kind, "the cursor returns to see all")
+
+	search.handleEscape()
+	assert.False(t, state.Visible.Peek())
+}
+
+func TestGlobalSearch_DiscardsResultsForAnOldQuery(t *testing.T) {
+	release := make(chan struct{})
+	cancelled := make(chan struct{})
+	state := NewGlobalSearchState(SearchProvider{Name: "Slow", Search: func(ctx context.Context, query string) ([]SearchResult, error) {
+		if query == "old" {
+			<-ctx.Done()
+			close(cancelled)
+			<-release
+		}
+		return []SearchResult{{Label: "result for " + query}}, nil
+	}})
+	state.Open()
+	state.SetQuery("old")
+	state.SetQuery("new")
+	<-cancelled
+	waitForSearch(t, state)
+	close(release)
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, []string{"# Slow", "result for new"}, searchRowLabels(state))
+}
+
+func TestGlobalSearch_ShowsLoadingAndErrorsInHeadings(t *testing.T) {
+	release := make(chan struct{})
+	state := NewGlobalSearchState(
+		SearchProvider{Name: "Broken", Search: func(ctx context.Context, query string) ([]SearchResult, error) {
+			return nil, errors.New("offline")
+		}},
+		SearchProvider{Name: "Slow", Search: func(ctx context.Context, query string) ([]SearchResult, error) {
+			<-release
+			return nil, nil
+		}},
+	)
+	state.Open()
+	require.Eventually(t, func() bool { return state.list.GetItems()[0].group.err != nil }, time.Second, time.Millisecond)
+
+	buf := renderToBufferWithFocus(GlobalSearch{State: state, Style: Style{Width: Cells(40)}}, 50, 10, "")
+	assert.Equal(t, "      Broken  offline                             ", bufferLine(buf, 5, 50))
+	assert.Equal(t, "      Slow  searching…                            ", bufferLine(buf, 6, 50))
+
+	close(release)
+	waitForSearch(t, state)
+	assert.Equal(t, []string{"# Broken"}, searchRowLabels(state), "finished groups without results are hidden")
+}
+
+func TestGlobalSearch_ChoosingAResultClosesAndRunsIt(t *testing.T) {
+	var ran []string
+	state := NewGlobalSearchState(SearchProvider{Name: "Commands", Search: staticSearch(
+		SearchResult{Label: "Open", Action: func() { ran = append(ran, "open") }},
+		SearchResult{Label: "Save", Action: func() { ran = append(ran, "save") }},
+	)})
+	search := GlobalSearch{State: state}
+	state.Open()
+	waitForSearch(t, state)
+
+	search.moveCursor(1)
+	search.selectCurrent()
+	assert.Equal(t, []string{"save"}, ran)
+	assert.False(t, state.Visible.Peek())
+	assert.Empty(t, state.list.GetItems())
+
+	var chosen string
+	search.OnSelect = func(provider string, result SearchResult) { chosen = provider + ": " + result.Label }
+	state.Open()
+	waitForSearch(t, state)
+	search.selectCurrent()
+	assert.Equal(t, "Commands: Open", chosen)
+	assert.Equal(t, []string{"save"}, ran, "OnSelect replaces the result's Action")
+}
+
+func TestGlobalSearchState_RegisterSearchesTheNewProvider(t *testing.T) {
+	state := NewGlobalSearchState(SearchProvider{Name: "Commands", Search: staticSearch(SearchResult{Label: "Open"})})
+	state.Open()
+	waitForSearch(t, state)
+
+	state.Register(SearchProvider{Name: "Tasks", Search: func(ctx context.Context, query string) ([]SearchResult, error) {
+		return []SearchResult{{Label: "task matching " + query}}, nil
+	}})
+	waitForSearch(t, state)
+	assert.Equal(t, []string{"# Commands", "Open", "# Tasks", "task matching "}, searchRowLabels(state))
+
+	state.Unregister("Commands")
+	assert.Equal(t, []string{"# Tasks", "task matching "}, searchRowLabels(state))
+}
@@ -0,0 +1,930 @@
+package terma
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/darrenburns/terma/layout"
+)
+
+// GraphNode is a node of a Graph.
+type GraphNode struct {
+	ID    string // Unique identifier, referenced by edges
+	Label string // Text in the node's box (default: ID)
+	Color Color  // Border color (default: the theme's border color)
+}
+
+// GraphEdge connects a parent node to a child node.
+type GraphEdge struct {
+	From string // ID of the parent node
+	To   string // ID of the child node
+}
+
+// GraphState holds the selection, collapsed nodes, scroll offset and zoom
+// of a Graph.
+type GraphState struct {
+	Selected  Signal[string]             // ID of the selected node ("" for none)
+	Collapsed AnySignal[map[string]bool] // Nodes whose children are hidden
+	OffsetX   Signal[int]                // Columns scrolled from the left of the graph
+	OffsetY   Signal[int]                // Rows scrolled from the top of the graph
+	Compact   Signal[bool]               // Zoomed out: nodes are drawn on one line, closer together
+
+	dragging    bool
+	dragX       int
+	dragY       int
+	dragOffsetX int
+	dragOffsetY int
+
+	viewWidth, viewHeight int // Size of the last render, for keeping the selection in view
+}
+
+// NewGraphState creates a GraphState with nothing selected or collapsed.
+func NewGraphState() *GraphState {
+	return &GraphState{
+		Selected:  NewSignal(""),
+		Collapsed: NewAnySignal(map[string]bool{}),
+		OffsetX:   NewSignal(0),
+		OffsetY:   NewSignal(0),
+		Compact:   NewSignal(false),
+	}
+}
+
+// IsCollapsed returns true if the node's children are hidden.
+func (s *GraphState) IsCollapsed(id string) bool {
+	if s == nil || !s.Collapsed.IsValid() {
+		return false
+	}
+	return s.Collapsed.Peek()[id]
+}
+
+// Toggle hides or shows the node's children.
+func (s *GraphState) Toggle(id string) {
+	if s.IsCollapsed(id) {
+		s.Expand(id)
+		return
+	}
+	s.Collapse(id)
+}
+
+// Expand shows the node's children.
+func (s *GraphState) Expand(id string) {
+	if s == nil || !s.Collapsed.IsValid() {
+		return
+	}
+	s.Collapsed.Update(func(collapsed map[string]bool) map[string]bool {
+		next := make(map[string]bool, len(collapsed))
+		for k, v := range collapsed {
+			next[k] = v
+		}
+		delete(next, id)
+		return next
+	})
+}
+
+// Collapse hides the node's children, and their descendants not reachable
+// another way.
+func (s *GraphState) Collapse(id string) {
+	if s == nil || !s.Collapsed.IsValid() {
+		return
+	}
+	s.Collapsed.Update(func(collapsed map[string]bool) map[string]bool {
+		next := make(map[string]bool, len(collapsed)+1)
+		for k, v := range collapsed {
+			next[k] = v
+		}
+		next[id] = true
+		return next
+	})
+}
+
+// Graph draws nodes as boxes joined by box-drawing connectors, laid out
+// automatically in layers: parents above their children, ordered to keep
+// connectors from crossing. It suits org charts, dependency graphs and
+// pipelines. Cycles are allowed; an edge that closes one is drawn upwards.
+//
+// With a State, arrow keys select nodes, space collapses or expands the
+// selected node's children, Enter calls OnSelect, + and - zoom between
+// boxes and a compact one-line view, and shift+arrows or dragging with the
+// mouse scroll a graph larger than the widget. Clicking a node selects it,
+// and double-clicking collapses or expands it.
+//
+// Width and height default to the size of the graph.
+//
+// Example:
+//
+//	terma.Graph{
+//	    State: a.graph,
+//	    Nodes: []terma.GraphNode{{ID: "build"}, {ID: "test"}, {ID: "deploy"}},
+//	    Edges: []terma.GraphEdge{{From: "build", To: "test"}, {From: "test", To: "deploy"}},
+//	    OnSelect: func(node terma.GraphNode) { a.showJob(node.ID) },
+//	}
+type Graph struct {
+	ID           string               // Optional unique identifier
+	DisableFocus bool                 // If true, prevent keyboard focus
+	State        *GraphState          // Optional; required for selection, collapsing, scrolling and zoom
+	Nodes        []GraphNode          // The nodes, in the order they're placed when nothing else decides
+	Edges        []GraphEdge          // Parent to child connections; unknown IDs are ignored
+	OnSelect     func(node GraphNode) // Called when Enter is pressed on the selected node
+	Style        Style                // Optional styling
+}
+
+// Build returns itself as Graph is a leaf widget, subscribing to its state.
+func (g Graph) Build(ctx BuildContext) Widget {
+	if g.State != nil {
+		g.State.Selected.Get()
+		g.State.Collapsed.Get()
+		g.State.OffsetX.Get()
+		g.State.OffsetY.Get()
+		g.State.Compact.Get()
+	}
+	return g
+}
+
+// WidgetID returns the graph's unique identifier.
+// Implements the Identifiable interface.
+func (g Graph) WidgetID() string {
+	return g.ID
+}
+
+// IsFocusable returns true when the graph has state to select nodes.
+func (g Graph) IsFocusable() bool {
+	return !g.DisableFocus && g.State != nil
+}
+
+// OnKey handles keys not covered by declarative keybindings.
+func (g Graph) OnKey(event KeyEvent) bool {
+	return false
+}
+
+// Keybinds returns the keys for selecting, collapsing, scrolling and
+// zooming.
+func (g Graph) Keybinds() []Keybind {
+	if g.State == nil {
+		return nil
+	}
+	return []Keybind{
+		{Key: "enter", Name: "Open", Action: g.selectNode, Hidden: g.OnSelect == nil},
+		{Key: "space", Name: "Collapse", Action: g.toggleSelected},
+		{Key: " ", Name: "Collapse", Action: g.toggleSelected, Hidden: true},
+		{Key: "+", Name: "Zoom in", Action: func() { g.Zoom(false) }},
+		{Key: "=", Name: "Zoom in", Action: func() { g.Zoom(false) }, Hidden: true},
+		{Key: "-", Name: "Zoom out", Action: func() { g.Zoom(true) }},
+		{Key: "up", Action: func() { g.moveSelection(0, -1) }, Hidden: true},
+		{Key: "k", Action: func() { g.moveSelection(0, -1) }, Hidden: true},
+		{Key: "down", Action: func() { g.moveSelection(0, 1) }, Hidden: true},
+		{Key: "j", Action: func() { g.moveSelection(0, 1) }, Hidden: true},
+		{Key: "left", Action: func() { g.moveSelection(-1, 0) }, Hidden: true},
+		{Key: "h", Action: func() { g.moveSelection(-1, 0) }, Hidden: true},
+		{Key: "right", Action: func() { g.moveSelection(1, 0) }, Hidden: true},
+		{Key: "l", Action: func() { g.moveSelection(1, 0) }, Hidden: true},
+		{Key: "shift+left", Action: func() { g.Scroll(-4, 0) }, Hidden: true},
+		{Key: "shift+right", Action: func() { g.Scroll(4, 0) }, Hidden: true},
+		{Key: "shift+up", Action: func() { g.Scroll(0, -2) }, Hidden: true},
+		{Key: "shift+down", Action: func() { g.Scroll(0, 2) }, Hidden: true},
+	}
+}
+
+func (g Graph) selectNode() {
+	if g.OnSelect == nil || g.State == nil {
+		return
+	}
+	id := g.State.Selected.Peek()
+	for _, node := range g.Nodes {
+		if node.ID == id {
+			g.OnSelect(node)
+			return
+		}
+	}
+}
+
+func (g Graph) toggleSelected() {
+	if g.State == nil || g.State.Selected.Peek() == "" {
+		return
+	}
+	g.State.Toggle(g.State.Selected.Peek())
+	g.scrollToSelected()
+}
+
+// Zoom switches between boxed nodes and the compact view, keeping the
+// selected node in view.
+func (g Graph) Zoom(compact bool) {
+	if g.State == nil {
+		return
+	}
+	g.State.Compact.Set(compact)
+	g.scrollToSelected()
+}
+
+// Scroll moves the view by columns and rows, staying within the graph.
+func (g Graph) Scroll(dx, dy int) {
+	if g.State == nil {
+		return
+	}
+	l := g.layout()
+	g.setOffset(l, g.State.OffsetX.Peek()+dx, g.State.OffsetY.Peek()+dy)
+}
+
+// setOffset scrolls to an offset, clamped to the graph.
+func (g Graph) setOffset(l graphLayout, x, y int) {
+	g.State.OffsetX.Set(max(0, min(x, l.width-g.State.viewWidth)))
+	g.State.OffsetY.Set(max(0, min(y, l.height-g.State.viewHeight)))
+}
+
+// moveSelection selects the nearest node in a direction: left and right
+// within the selected node's layer, up and down preferring nodes joined to
+// it. Without a selection, it selects the first node.
+func (g Graph) moveSelection(dx, dy int) {
+	if g.State == nil {
+		return
+	}
+	l := g.layout()
+	current, ok := l.byID[g.State.Selected.Peek()]
+	if !ok {
+		for _, box := range l.boxes {
+			if box.node >= 0 {
+				g.State.Selected.Set(g.Nodes[box.node].ID)
+				g.scrollToSelected()
+				return
+			}
+		}
+		return
+	}
+	from := l.boxes[current]
+	best, bestDistance := -1, math.MaxInt
+	consider := func(i int) {
+		box := l.boxes[i]
+		distance := box.center() - from.center()
+		if distance < 0 {
+			distance = -distance
+		}
+		if box.node >= 0 && i != current && distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+	switch {
+	case dx != 0:
+		layer := l.layers[from.layer]
+		at := slices.Index(layer, current)
+		for i := at + dx; i >= 0 && i < len(layer); i += dx {
+			if l.boxes[layer[i]].node >= 0 {
+				best = layer[i]
+				break
+			}
+		}
+	default:
+		for _, i := range l.joined[current] {
+			if (l.boxes[i].layer-from.layer)*dy > 0 {
+				consider(i)
+			}
+		}
+		for layer := from.layer + dy; best < 0 && layer >= 0 && layer < len(l.layers); layer += dy {
+			for _, i := range l.layers[layer] {
+				consider(i)
+			}
+		}
+	}
+	if best >= 0 {
+		g.State.Selected.Set(g.Nodes[l.boxes[best].node].ID)
+		g.scrollToSelected()
+	}
+}
+
+// scrollToSelected scrolls the least needed to show the selected node.
+func (g Graph) scrollToSelected() {
+	l := g.layout()
+	i, ok := l.byID[g.State.Selected.Peek()]
+	if !ok {
+		return
+	}
+	box := l.boxes[i]
+	x, y := g.State.OffsetX.Peek(), g.State.OffsetY.Peek()
+	viewWidth, viewHeight := g.State.viewWidth, g.State.viewHeight
+	if box.x < x {
+		x = box.x
+	} else if box.x+box.width > x+viewWidth {
+		x = box.x + box.width - viewWidth
+	}
+	if box.y < y {
+		y = box.y
+	} else if box.y+box.height > y+viewHeight {
+		y = box.y + box.height - viewHeight
+	}
+	g.setOffset(l, x, y)
+}
+
+// OnMouseDown selects the node under the pointer, collapsing or expanding
+// it on a double click, and starts scrolling by drag.
+func (g Graph) OnMouseDown(event MouseEvent) {
+	if g.State == nil {
+		return
+	}
+	l := g.layout()
+	x, y := g.contentCoords(event)
+	originX, originY := g.origin(l, g.State.viewWidth, g.State.viewHeight)
+	if i, ok := l.boxAt(x-originX, y-originY); ok {
+		id := g.Nodes[l.boxes[i].node].ID
+		g.State.Selected.Set(id)
+		if event.ClickCount == 2 {
+			g.State.Toggle(id)
+		}
+	}
+	g.State.dragging = true
+	g.State.dragX, g.State.dragY = x, y
+	g.State.dragOffsetX, g.State.dragOffsetY = g.State.OffsetX.Peek(), g.State.OffsetY.Peek()
+}
+
+// OnMouseMove scrolls with the pointer while dragging.
+func (g Graph) OnMouseMove(event MouseEvent) {
+	if g.State == nil || !g.State.dragging {
+		return
+	}
+	x, y := g.contentCoords(event)
+	g.setOffset(g.layout(), g.State.dragOffsetX-(x-g.State.dragX), g.State.dragOffsetY-(y-g.State.dragY))
+}
+
+// OnMouseUp ends a drag.
+func (g Graph) OnMouseUp(event MouseEvent) {
+	if g.State != nil {
+		g.State.dragging = false
+	}
+}
+
+// contentCoords converts a mouse event's border-box position to the
+// content box.
+func (g Graph) contentCoords(event MouseEvent) (int, int) {
+	insets := g.Style.Border.Insets()
+	return event.LocalX - insets.Left - g.Style.Padding.Left, event.LocalY - insets.Top - g.Style.Padding.Top
+}
+
+// origin returns where the graph's top left is drawn in a view: centered
+// when it's narrower than the view, else scrolled by the offset.
+func (g Graph) origin(l graphLayout, viewWidth, viewHeight int) (int, int) {
+	offsetX, offsetY := 0, 0
+	if g.State != nil {
+		offsetX, offsetY = g.State.OffsetX.Peek(), g.State.OffsetY.Peek()
+	}
+	x := -max(0, min(offsetX, l.width-viewWidth))
+	if l.width < viewWidth {
+		x = (viewWidth - l.width) / 2
+	}
+	return x, -max(0, min(offsetY, l.height-viewHeight))
+}
+
+// GetContentDimensions returns the width and height dimension preferences.
+// Both default to the size of the laid out graph.
+func (g Graph) GetContentDimensions() (width, height Dimension) {
+	dims := g.Style.GetDimensions()
+	width, height = dims.Width, dims.Height
+	if width.IsUnset() || height.IsUnset() {
+		l := g.layout()
+		if width.IsUnset() {
+			width = Cells(l.width)
+		}
+		if height.IsUnset() {
+			height = Cells(l.height)
+		}
+	}
+	return width, height
+}
+
+// GetStyle returns the style of the graph.
+func (g Graph) GetStyle() Style {
+	return g.Style
+}
+
+// BuildLayoutNode builds a layout node for this Graph widget.
+func (g Graph) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return monitorLayoutNode(g, g.Style)
+}
+
+// layout lays out the visible nodes for the state's collapsed nodes and
+// zoom.
+func (g Graph) layout() graphLayout {
+	compact := false
+	var collapsed map[string]bool
+	if g.State != nil {
+		compact = g.State.Compact.Peek()
+		collapsed = g.State.Collapsed.Peek()
+	}
+	return layoutGraph(g.Nodes, g.Edges, collapsed, compact)
+}
+
+// Render draws the connectors and then the nodes.
+func (g Graph) Render(ctx *RenderContext) {
+	if g.State != nil {
+		g.State.viewWidth, g.State.viewHeight = ctx.Width, ctx.Height
+	}
+	if ctx.Width <= 0 || ctx.Height <= 0 {
+		return
+	}
+	theme := ctx.buildContext.Theme()
+	focused := ctx.buildContext.IsFocused(g)
+	l := g.layout()
+	originX, originY := g.origin(l, ctx.Width, ctx.Height)
+	draw := func(x, y int, text string, style Style) {
+		x, y = x+originX, y+originY
+		if y < 0 || y >= ctx.Height || x >= ctx.Width {
+			return
+		}
+		if x < 0 {
+			text = ansi.Cut(text, -x, ansi.StringWidth(text))
+			x = 0
+		}
+		ctx.DrawStyledText(x, y, ansi.Truncate(text, ctx.Width-x, ""), style)
+	}
+
+	lineStyle := Style{ForegroundColor: theme.TextMuted}
+	for cell, bits := range l.connectors {
+		draw(cell.x, cell.y, graphConnectorChars[bits], lineStyle)
+	}
+
+	selected := ""
+	if g.State != nil {
+		selected = g.State.Selected.Peek()
+	}
+	for _, box := range l.boxes {
+		if box.node < 0 {
+			continue
+		}
+		node := g.Nodes[box.node]
+		borderStyle := Style{ForegroundColor: theme.Border}
+		if node.Color.IsSet() {
+			borderStyle.ForegroundColor = node.Color
+		}
+		labelStyle := Style{ForegroundColor: theme.Text}
+		if node.ID == selected {
+			borderStyle.ForegroundColor = theme.Primary
+			labelStyle.Bold = true
+			if focused {
+				labelStyle.ForegroundColor = theme.SelectionText
+				labelStyle.BackgroundColor = theme.ActiveCursor
+			}
+		}
+		label := box.label
+		if l.compact {
+			draw(box.x, box.y, "[", borderStyle)
+			draw(box.x+1, box.y, label, labelStyle)
+			draw(box.x+box.width-1, box.y, "]", borderStyle)
+			continue
+		}
+		center := box.width / 2
+		top := []rune("┌" + strings.Repeat("─", box.width-2) + "┐")
+		bottom := []rune("└" + strings.Repeat("─", box.width-2) + "┘")
+		if box.in {
+			top[center] = '┴'
+		}
+		if box.out {
+			bottom[center] = '┬'
+		}
+		draw(box.x, box.y, string(top), borderStyle)
+		draw(box.x, box.y+1, "│", borderStyle)
+		draw(box.x+1, box.y+1, " "+label+" ", labelStyle)
+		draw(box.x+box.width-1, box.y+1, "│", borderStyle)
+		draw(box.x, box.y+2, string(bottom), borderStyle)
+	}
+}
+
+// graphBox is a node placed by layoutGraph, or a dummy that carries an
+// edge through a layer it spans.
+type graphBox struct {
+	node          int    // Index in the graph's nodes, or -1 for a dummy
+	label         string // Text drawn in the box
+	layer         int
+	x, y          int
+	width, height int
+	in, out       bool // Whether connectors join the top and bottom
+}
+
+func (b graphBox) center() int {
+	return b.x + b.width/2
+}
+
+// graphCell is a cell of a laid out graph.
+type graphCell struct {
+	x, y int
+}
+
+// Connector bits of a cell: the directions its line leaves in.
+const (
+	graphUp uint8 = 1 << iota
+	graphDown
+	graphLeft
+	graphRight
+)
+
+// graphConnectorChars are the box-drawing characters for connector bits.
+var graphConnectorChars = [16]string{
+	graphUp:                                      "│",
+	graphDown:                                    "│",
+	graphUp | graphDown:                          "│",
+	graphLeft:                                    "─",
+	graphRight:                                   "─",
+	graphLeft | graphRight:                       "─",
+	graphDown | graphRight:                       "┌",
+	graphDown | graphLeft:                        "┐",
+	graphUp | graphRight:                         "└",
+	graphUp | graphLeft:                          "┘",
+	graphUp | graphDown | graphRight:             "├",
+	graphUp | graphDown | graphLeft:              "┤",
+	graphDown | graphLeft | graphRight:           "┬",
+	graphUp | graphLeft | graphRight:             "┴",
+	graphUp | graphDown | graphLeft | graphRight: "┼",
+}
+
+// graphLayout is where layoutGraph put the boxes and connectors.
+type graphLayout struct {
+	boxes         []graphBox
+	layers        [][]int        // Box indexes in each layer, left to right
+	byID          map[string]int // Box index of each visible node
+	joined        [][]int        // Boxes of the nodes joined to each node's box by an edge
+	connectors    map[graphCell]uint8
+	width, height int
+	compact       bool
+}
+
+// boxAt returns the node box covering a cell.
+func (l graphLayout) boxAt(x, y int) (int, bool) {
+	for i, box := range l.boxes {
+		if box.node >= 0 && x >= box.x && x < box.x+box.width && y >= box.y && y < box.y+box.height {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// layoutGraph places the nodes not hidden by collapsed ones in layers, in
+// the Sugiyama style: cycles are broken by reversing edges, nodes are
+// layered by their longest path from a root, edges spanning layers get a
+// dummy box in each, layers are reordered towards their neighbours'
+// average position, and boxes are centered over their neighbours.
+func layoutGraph(nodes []GraphNode, edges []GraphEdge, collapsed map[string]bool, compact bool) graphLayout {
+	index := make(map[string]int, len(nodes))
+	for i, node := range nodes {
+		if _, ok := index[node.ID]; !ok {
+			index[node.ID] = i
+		}
+	}
+	children := make([][]int, len(nodes))
+	hasParent := make([]bool, len(nodes))
+	for _, edge := range edges {
+		from, okFrom := index[edge.From]
+		to, okTo := index[edge.To]
+		if !okFrom || !okTo || from == to || slices.Contains(children[from], to) {
+			continue
+		}
+		children[from] = append(children[from], to)
+		hasParent[to] = true
+	}
+
+	// Roots are nodes without parents, then a node of each cycle not
+	// reachable from them
+	var roots []int
+	reached := make([]bool, len(nodes))
+	var reach func(i int)
+	reach = func(i int) {
+		if reached[i] {
+			return
+		}
+		reached[i] = true
+		for _, child := range children[i] {
+			reach(child)
+		}
+	}
+	for i := range nodes {
+		if !hasParent[i] && !reached[i] {
+			roots = append(roots, i)
+			reach(i)
+		}
+	}
+	for i := range nodes {
+		if !reached[i] {
+			roots = append(roots, i)
+			reach(i)
+		}
+	}
+
+	// Visit the visible nodes depth first, noting the order, the edges in
+	// it, and the edges that close cycles, which are reversed
+	visibleChildren := func(i int) []int {
+		if collapsed[nodes[i].ID] {
+			return nil
+		}
+		return children[i]
+	}
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(nodes))
+	var preorder, postorder []int
+	type dagEdge struct{ upper, lower int }
+	var dag []dagEdge
+	var visit func(i int)
+	visit = func(i int) {
+		state[i] = visiting
+		preorder = append(preorder, i)
+		for _, child := range visibleChildren(i) {
+			switch state[child] {
+			case unvisited:
+				dag = append(dag, dagEdge{i, child})
+				visit(child)
+			case visiting:
+				dag = append(dag, dagEdge{child, i})
+			default:
+				dag = append(dag, dagEdge{i, child})
+			}
+		}
+		state[i] = visited
+		postorder = append(postorder, i)
+	}
+	for _, root := range roots {
+		if state[root] == unvisited {
+			visit(root)
+		}
+	}
+
+	// Longest path layering, in topological order
+	layerOf := make([]int, len(nodes))
+	lowers := make([][]int, len(nodes))
+	for _, e := range dag {
+		lowers[e.upper] = append(lowers[e.upper], e.lower)
+	}
+	for i := len(postorder) - 1; i >= 0; i-- {
+		upper := postorder[i]
+		for _, lower := range lowers[upper] {
+			layerOf[lower] = max(layerOf[lower], layerOf[upper]+1)
+		}
+	}
+
+	l := graphLayout{byID: map[string]int{}, connectors: map[graphCell]uint8{}, compact: compact}
+	boxOf := make([]int, len(nodes))
+	order := map[int]float64{} // Box index to its position within its layer
+	for rank, i := range preorder {
+		label := nodes[i].Label
+		if label == "" {
+			label = nodes[i].ID
+		}
+		if collapsed[nodes[i].ID] && len(children[i]) > 0 {
+			label += " +"
+		}
+		boxOf[i] = len(l.boxes)
+		l.byID[nodes[i].ID] = len(l.boxes)
+		order[len(l.boxes)] = float64(rank)
+		l.boxes = append(l.boxes, graphBox{node: i, label: label, layer: layerOf[i]})
+	}
+
+	// Links join boxes in consecutive layers, through dummies
+	type graphLink struct{ upper, lower int }
+	var links []graphLink
+	l.joined = make([][]int, len(l.boxes))
+	for _, e := range dag {
+		upper, lower := boxOf[e.upper], boxOf[e.lower]
+		l.joined[upper] = append(l.joined[upper], lower)
+		l.joined[lower] = append(l.joined[lower], upper)
+		for layer := layerOf[e.upper] + 1; layer < layerOf[e.lower]; layer++ {
+			dummy := len(l.boxes)
+			order[dummy] = order[upper] + 0.5
+			l.boxes = append(l.boxes, graphBox{node: -1, layer: layer, width: 1})
+			links = append(links, graphLink{upper, dummy})
+			upper = dummy
+		}
+		links = append(links, graphLink{upper, lower})
+	}
+	if len(l.boxes) == 0 {
+		return l
+	}
+
+	for i := range l.boxes {
+		box := &l.boxes[i]
+		if len(l.layers) <= box.layer {
+			l.layers = append(l.layers, make([][]int, box.layer+1-len(l.layers))...)
+		}
+		l.layers[box.layer] = append(l.layers[box.layer], i)
+		if box.node < 0 {
+			continue
+		}
+		box.width = ansi.StringWidth(box.label) + 4
+		box.height = 3
+		if compact {
+			box.width -= 2
+			box.height = 1
+		}
+	}
+	uppers := make([][]int, len(l.boxes))
+	lowerBoxes := make([][]int, len(l.boxes))
+	for _, link := range links {
+		lowerBoxes[link.upper] = append(lowerBoxes[link.upper], link.lower)
+		uppers[link.lower] = append(uppers[link.lower], link.upper)
+		l.boxes[link.upper].out = true
+		l.boxes[link.lower].in = true
+	}
+
+	// Order each layer by its neighbours' average position, sweeping down
+	// and up
+	sortLayer := func(layer []int, neighbours [][]int) {
+		key := make(map[int]float64, len(layer))
+		for _, i := range layer {
+			key[i] = order[i]
+			if len(neighbours[i]) > 0 {
+				sum := 0.0
+				for _, n := range neighbours[i] {
+					sum += order[n]
+				}
+				key[i] = sum / float64(len(neighbours[i]))
+			}
+		}
+		slices.SortStableFunc(layer, func(a, b int) int {
+			return cmpFloat(key[a], key[b])
+		})
+		for rank, i := range layer {
+			order[i] = float64(rank)
+		}
+	}
+	for _, layer := range l.layers {
+		sortLayer(layer, make([][]int, len(l.boxes)))
+	}
+	for range 2 {
+		for i := 1; i < len(l.layers); i++ {
+			sortLayer(l.layers[i], uppers)
+		}
+		for i := len(l.layers) - 2; i >= 0; i-- {
+			sortLayer(l.layers[i], lowerBoxes)
+		}
+	}
+
+	// Place boxes over their neighbours, sweeping down and up and ending
+	// with parents centered over their children
+	gap := 2
+	if compact {
+		gap = 1
+	}
+	for _, layer := range l.layers {
+		x := 0
+		for _, i := range layer {
+			l.boxes[i].x = x
+			x += l.boxes[i].width + gap
+		}
+	}
+	for range 3 {
+		for i := 1; i < len(l.layers); i++ {
+			placeGraphLayer(l.boxes, l.layers[i], uppers, gap)
+		}
+		for i := len(l.layers) - 2; i >= 0; i-- {
+			placeGraphLayer(l.boxes, l.layers[i], lowerBoxes, gap)
+		}
+	}
+	minX := math.MaxInt
+	for _, box := range l.boxes {
+		minX = min(minX, box.x)
+	}
+	for i := range l.boxes {
+		l.boxes[i].x -= minX
+	}
+
+	// Stack the layers, with a row between each for every connector track
+	// that the links need so that buses from different parents don't
+	// overlap
+	y := 0
+	for layerIndex, layer := range l.layers {
+		height := 1
+		for _, i := range layer {
+			height = max(height, l.boxes[i].height)
+		}
+		for _, i := range layer {
+			box := &l.boxes[i]
+			box.y = y
+			if box.node < 0 {
+				box.height = height
+				for row := y; row < y+height; row++ {
+					l.connectors[graphCell{box.center(), row}] |= graphUp | graphDown
+				}
+			}
+		}
+		y += height
+		if layerIndex == len(l.layers)-1 {
+			break
+		}
+
+		type bus struct{ upper, start, end int }
+		var buses []bus
+		for _, i := range layer {
+			if len(lowerBoxes[i]) == 0 {
+				continue
+			}
+			b := bus{upper: i, start: l.boxes[i].center(), end: l.boxes[i].center()}
+			for _, lower := range lowerBoxes[i] {
+				b.start, b.end = min(b.start, l.boxes[lower].center()), max(b.end, l.boxes[lower].center())
+			}
+			buses = append(buses, b)
+		}
+		slices.SortStableFunc(buses, func(a, b bus) int { return a.start - b.start })
+		var trackEnds []int
+		tracks := make([]int, len(buses))
+		for bi, b := range buses {
+			track := slices.IndexFunc(trackEnds, func(end int) bool { return end < b.start })
+			if track < 0 {
+				track = len(trackEnds)
+				trackEnds = append(trackEnds, 0)
+			}
+			trackEnds[track] = b.end
+			tracks[bi] = track
+		}
+		rows := max(1, len(trackEnds))
+		for bi, b := range buses {
+			row := y + tracks[bi]
+			x := l.boxes[b.upper].center()
+			for r := y; r <= row; r++ {
+				bits := graphUp
+				if r < row {
+					bits |= graphDown
+				}
+				l.connectors[graphCell{x, r}] |= bits
+			}
+			for x := b.start; x <= b.end; x++ {
+				var bits uint8
+				if x > b.start {
+					bits |= graphLeft
+				}
+				if x < b.end {
+					bits |= graphRight
+				}
+				l.connectors[graphCell{x, row}] |= bits
+			}
+			for _, lower := range lowerBoxes[b.upper] {
+				x := l.boxes[lower].center()
+				l.connectors[graphCell{x, row}] |= graphDown
+				for r := row + 1; r < y+rows; r++ {
+					l.connectors[graphCell{x, r}] |= graphUp | graphDown
+				}
+			}
+		}
+		y += rows
+	}
+	l.height = y
+	for _, box := range l.boxes {
+		l.width = max(l.width, box.x+box.width)
+	}
+	return l
+}
+
+// placeGraphLayer moves a layer's boxes as close as it can to centered on
+// their neighbours, keeping their order and a gap between them. Boxes that
+// would overlap are merged into blocks placed at their members' average
+// wish.
+func placeGraphLayer(boxes []graphBox, layer []int, neighbours [][]int, gap int) {
+	type block struct {
+		first, last int     // Positions in the layer
+		wish        float64 // Sum of where each member wants the block to start
+		count       int
+		width       int
+	}
+	start := func(b block) float64 {
+		return b.wish / float64(b.count)
+	}
+	var blocks []block
+	for pos, i := range layer {
+		box := boxes[i]
+		wish := float64(box.x)
+		if len(neighbours[i]) > 0 {
+			sum := 0
+			for _, n := range neighbours[i] {
+				sum += boxes[n].center()
+			}
+			wish = float64(sum)/float64(len(neighbours[i])) - float64(box.width/2)
+		}
+		blocks = append(blocks, block{first: pos, last: pos, wish: wish, count: 1, width: box.width})
+		for len(blocks) > 1 {
+			prev, cur := blocks[len(blocks)-2], blocks[len(blocks)-1]
+			if start(prev)+float64(prev.width+gap) <= start(cur) {
+				break
+			}
+			shift := prev.width + gap
+			prev.wish += cur.wish - float64(shift*cur.count)
+			prev.count += cur.count
+			prev.last = cur.last
+			prev.width += gap + cur.width
+			blocks = blocks[:len(blocks)-1]
+			blocks[len(blocks)-1] = prev
+		}
+	}
+	for _, b := range blocks {
+		x := int(math.Round(start(b)))
+		for _, i := range layer[b.first : b.last+1] {
+			boxes[i].x = x
+			x += boxes[i].width + gap
+		}
+	}
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,180 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func renderGraph(t *testing.T, graph Graph, width, height int) []string {
+	t.Helper()
+	renderer, buf := portalTestRenderer(width, height)
+	graph.Style.Width = Cells(width)
+	graph.Style.Height = Cells(height)
+	renderer.Render(graph)
+	lines := make([]string, height)
+	for y := range lines {
+		lines[y] = bufferLine(buf, y, width)
+	}
+	return lines
+}
+
+func orgChart() Graph {
+	return Graph{
+		Nodes: []GraphNode{{ID: "ceo"}, {ID: "cto"}, {ID: "cfo"}, {ID: "dev"}, {ID: "ops"}},
+		Edges: []GraphEdge{
+			{From: "ceo", To: "cto"},
+			{From: "ceo", To: "cfo"},
+			{From: "cto", To: "dev"},
+			{From: "cto", To: "ops"},
+		},
+	}
+}
+
+func TestGraph_DrawsBoxesAndConnectors(t *testing.T) {
+	lines := renderGraph(t, orgChart(), 22, 11)
+
+	assert.Equal(t, []string{
+		"         ┌─────┐      ",
+		"         │ ceo │      ",
+		"         └──┬──┘      ",
+		"       ┌────┴────┐    ",
+		"    ┌──┴──┐   ┌──┴──┐ ",
+		"    │ cto │   │ cfo │ ",
+		"    └──┬──┘   └─────┘ ",
+		"   ┌───┴────┐         ",
+		"┌──┴──┐  ┌──┴──┐      ",
+		"│ dev │  │ ops │      ",
+		"└─────┘  └─────┘      ",
+	}, lines)
+}
+
+func TestGraph_CompactZoom(t *testing.T) {
+	state := NewGraphState()
+	graph := orgChart()
+	graph.State = state
+	graph.Zoom(true)
+
+	lines := renderGraph(t, graph, 17, 5)
+
+	assert.Equal(t, []string{
+		"       [ceo]     ",
+		"      ┌──┴──┐    ",
+		"    [cto] [cfo]  ",
+		"   ┌──┴──┐       ",
+		" [dev] [ops]     ",
+	}, lines)
+}
+
+func TestLayoutGraph_LayersByLongestPath(t *testing.T) {
+	l := layoutGraph(
+		[]GraphNode{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+		[]GraphEdge{{From: "a", To: "b"}, {From: "b", To: "c"}, {From: "a", To: "c"}},
+		nil, false,
+	)
+
+	assert.Equal(t, 0, l.boxes[l.byID["a"]].layer)
+	assert.Equal(t, 1, l.boxes[l.byID["b"]].layer)
+	assert.Equal(t, 2, l.boxes[l.byID["c"]].layer)
+	require.Len(t, l.layers, 3)
+	assert.Len(t, l.layers[1], 2, "a dummy carries a→c through b's layer")
+}
+
+func TestLayoutGraph_BreaksCycles(t *testing.T) {
+	l := layoutGraph(
+		[]GraphNode{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+		[]GraphEdge{{From: "a", To: "b"}, {From: "b", To: "c"}, {From: "c", To: "a"}},
+		nil, false,
+	)
+
+	assert.Equal(t, 0, l.boxes[l.byID["a"]].layer)
+	assert.Equal(t, 2, l.boxes[l.byID["c"]].layer)
+}
+
+func TestLayoutGraph_CollapsedNodesHideDescendants(t *testing.T) {
+	graph := orgChart()
+	l := layoutGraph(graph.Nodes, graph.Edges, map[string]bool{"cto": true}, false)
+
+	assert.NotContains(t, l.byID, "dev")
+	assert.NotContains(t, l.byID, "ops")
+	assert.Equal(t, "cto +", l.boxes[l.byID["cto"]].label)
+	assert.Equal(t, "cfo", l.boxes[l.byID["cfo"]].label, "leaves don't show the collapsed marker")
+}
+
+func TestGraph_KeyboardSelectionAndCollapse(t *testing.T) {
+	state := NewGraphState()
+	graph := orgChart()
+	graph.State = state
+	var opened string
+	graph.OnSelect = func(node GraphNode) { opened = node.ID }
+	renderGraph(t, graph, 30, 12)
+
+	keys := map[string]KeyEvent{
+		"up":    makeKeyEvent(uv.KeyUp, 0),
+		"down":  makeKeyEvent(uv.KeyDown, 0),
+		"left":  makeKeyEvent(uv.KeyLeft, 0),
+		"right": makeKeyEvent(uv.KeyRight, 0),
+		"space": makeKeyEvent(uv.KeySpace, 0),
+		"enter": makeKeyEvent(uv.KeyEnter, 0),
+	}
+	press := func(key string) {
+		t.Helper()
+		require.True(t, matchKeybind(keys[key], graph.Keybinds()), key)
+	}
+	press("down")
+	assert.Equal(t, "ceo", state.Selected.Peek(), "the first key selects the first node")
+	press("down")
+	assert.Equal(t, "cto", state.Selected.Peek())
+	press("right")
+	assert.Equal(t, "cfo", state.Selected.Peek())
+	press("up")
+	assert.Equal(t, "ceo", state.Selected.Peek())
+	press("down")
+	press("left")
+	press("down")
+	assert.Equal(t, "dev", state.Selected.Peek(), "down picks the nearest child")
+
+	press("up")
+	press("space")
+	assert.True(t, state.IsCollapsed("cto"))
+	press("enter")
+	assert.Equal(t, "cto", opened)
+}
+
+func TestGraph_ScrollAndDrag(t *testing.T) {
+	state := NewGraphState()
+	graph := orgChart()
+	graph.State = state
+	renderGraph(t, graph, 10, 5)
+
+	graph.Scroll(4, 2)
+	assert.Equal(t, 4, state.OffsetX.Peek())
+	assert.Equal(t, 2, state.OffsetY.Peek())
+
+	graph.Scroll(100, 100)
+	assert.Equal(t, 11, state.OffsetX.Peek(), "scrolling stops at the graph's edge")
+	assert.Equal(t, 6, state.OffsetY.Peek())
+
+	graph.OnMouseDown(MouseEvent{LocalX: 5, LocalY: 4})
+	graph.OnMouseMove(MouseEvent{LocalX: 8, LocalY: 3})
+	graph.OnMouseUp(MouseEvent{LocalX: 8, LocalY: 3})
+	assert.Equal(t, 8, state.OffsetX.Peek())
+	assert.Equal(t, 6, state.OffsetY.Peek())
+}
+
+func TestGraph_ClickSelectsAndDoubleClickCollapses(t *testing.T) {
+	state := NewGraphState()
+	graph := orgChart()
+	graph.State = state
+	renderGraph(t, graph, 22, 11)
+
+	graph.OnMouseDown(MouseEvent{LocalX: 6, LocalY: 5, ClickCount: 1})
+	graph.OnMouseUp(MouseEvent{LocalX: 6, LocalY: 5})
+	assert.Equal(t, "cto", state.Selected.Peek())
+	assert.False(t, state.IsCollapsed("cto"))
+
+	graph.OnMouseDown(MouseEvent{LocalX: 6, LocalY: 5, ClickCount: 2})
+	assert.True(t, state.IsCollapsed("cto"))
+}
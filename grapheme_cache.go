@@ -0,0 +1,97 @@
+package terma
+
+import "sync"
+
+// maxCachedSegments bounds the grapheme cache used by Text. When it fills
+// up it is emptied, and refills with the text still on screen.
+const maxCachedSegments = 4096
+
+// segmentedText is a string split into grapheme clusters, with the display
+// width of each.
+type segmentedText struct {
+	graphemes []string
+	widths    []int
+}
+
+// segmentCache memoizes segmentGraphemes by string.
+var segmentCache = struct {
+	sync.Mutex
+	entries map[string]segmentedText
+}{entries: map[string]segmentedText{}}
+
+// segmentGraphemes splits s into grapheme clusters and measures them,
+// remembering the result so text drawn on every frame is only segmented
+// the first time. The slices are shared, so don't modify them.
+func segmentGraphemes(s string) segmentedText {
+	if s == "" {
+		return segmentedText{}
+	}
+	segmentCache.Lock()
+	seg, ok := segmentCache.entries[s]
+	segmentCache.Unlock()
+	if ok {
+		return seg
+	}
+
+	seg.graphemes = splitGraphemes(s)
+	seg.widths = make([]int, len(seg.graphemes))
+	for i, g := range seg.graphemes {
+		seg.widths[i] = graphemeWidth(g)
+	}
+
+	segmentCache.Lock()
+	if len(segmentCache.entries) >= maxCachedSegments {
+		clear(segmentCache.entries)
+	}
+	segmentCache.entries[s] = seg
+	segmentCache.Unlock()
+	return seg
+}
+
+// contentSegments caches what TextInput and TextArea derive from their
+// grapheme Content: the width of each grapheme and the joined text passed
+// to a Highlighter. It is rebuilt only after Content changes, so a large
+// document isn't re-measured on every keystroke and frame.
+type contentSegments struct {
+	built    bool
+	revision uint64  // Content's revision when built
+	first    *string // &graphemes[0] when built, to tell slices apart
+	length   int
+	widths   []int
+	text     string
+	hasText  bool
+}
+
+// sync rebuilds the cache if graphemes isn't what it was built from.
+func (c *contentSegments) sync(content AnySignal[[]string], graphemes []string) {
+	var first *string
+	if len(graphemes) > 0 {
+		first = &graphemes[0]
+	}
+	revision := content.revision()
+	if c.built && c.revision == revision && c.first == first && c.length == len(graphemes) {
+		return
+	}
+	widths := make([]int, len(graphemes))
+	for i, g := range graphemes {
+		widths[i] = graphemeWidth(g)
+	}
+	*c = contentSegments{built: true, revision: revision, first: first, length: len(graphemes), widths: widths}
+}
+
+// widthsOf returns the display width of each of graphemes, the current
+// value of content.
+func (c *contentSegments) widthsOf(content AnySignal[[]string], graphemes []string) []int {
+	c.sync(content, graphemes)
+	return c.widths
+}
+
+// textOf returns graphemes, the current value of content, joined.
+func (c *contentSegments) textOf(content AnySignal[[]string], graphemes []string) string {
+	c.sync(content, graphemes)
+	if !c.hasText {
+		c.text = joinGraphemes(graphemes)
+		c.hasText = true
+	}
+	return c.text
+}
@@ -0,0 +1,74 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSegmentGraphemes(t *testing.T) {
+	seg := segmentGraphemes("a日👍🏽")
+	assert.Equal(t, []string{"a", "日", "👍🏽"}, seg.graphemes)
+	assert.Equal(t, []int{1, 2, 2}, seg.widths)
+
+	again := segmentGraphemes("a日👍🏽")
+	assert.Same(t, &seg.graphemes[0], &again.graphemes[0], "the same string is only segmented once")
+
+	assert.Empty(t, segmentGraphemes("").graphemes)
+}
+
+func TestContentSegments_RebuildsWhenContentChanges(t *testing.T) {
+	state := NewTextInputState("ab")
+	var cache contentSegments
+
+	assert.Equal(t, []int{1, 1}, cache.widthsOf(state.Content, state.Content.Peek()))
+	assert.Equal(t, "ab", cache.textOf(state.Content, state.Content.Peek()))
+
+	// Update changes the slice in place, keeping its length.
+	state.Content.Update(func(graphemes []string) []string {
+		graphemes[1] = "日"
+		return graphemes
+	})
+	assert.Equal(t, []int{1, 2}, cache.widthsOf(state.Content, state.Content.Peek()))
+	assert.Equal(t, "a日", cache.textOf(state.Content, state.Content.Peek()))
+
+	state.SetText("")
+	assert.Empty(t, cache.widthsOf(state.Content, state.Content.Peek()))
+	assert.Equal(t, "", cache.textOf(state.Content, state.Content.Peek()))
+}
+
+func TestTextInput_HighlighterSeesEditedText(t *testing.T) {
+	state := NewTextInputState("ab")
+	var seen string
+	input := TextInput{
+		ID:    "input",
+		State: state,
+		Highlighter: HighlighterFunc(func(text string, graphemes []string) []TextHighlight {
+			seen = text
+			return nil
+		}),
+	}
+
+	renderToBufferWithFocus(input, 10, 1, "input")
+	assert.Equal(t, "ab", seen)
+
+	state.Insert("c")
+	renderToBufferWithFocus(input, 10, 1, "input")
+	assert.Equal(t, "abc", seen)
+
+	state.DeleteBackward()
+	state.DeleteBackward()
+	renderToBufferWithFocus(input, 10, 1, "input")
+	assert.Equal(t, "a", seen)
+}
+
+func TestTextArea_WrapsWithEditedWidths(t *testing.T) {
+	state := NewTextAreaState("abcd")
+	area := TextArea{ID: "area", State: state, Style: Style{Width: Cells(5), Height: Cells(3)}}
+
+	renderToBufferWithFocus(area, 5, 3, "")
+	state.SetText("日日日")
+	buf := renderToBufferWithFocus(area, 5, 3, "")
+
+	assert.Equal(t, "日", string([]rune(bufferLine(buf, 1, 5))[:1]), "wide graphemes wrap by their width after the edit")
+}
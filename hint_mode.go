@@ -0,0 +1,177 @@
+package terma
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// hintAlphabet holds the letters hint codes are made of, home row first.
+const hintAlphabet = "asdfghjklqwertyuiopzxcvbnm"
+
+var hintModeRequested atomic.Bool
+
+// ShowHints enters hint mode on the next frame, as Ctrl+Shift+J does: a
+// short letter code appears over every widget that can take focus, and
+// typing a code focuses that widget. Buttons and checkboxes are pressed
+// too, like following a link. Bind it to a key of your own to offer hints
+// on terminals that don't report Ctrl+Shift chords.
+//
+// Example:
+//
+//	{Key: "f", Name: "Jump", Action: t.ShowHints}
+func ShowHints() {
+	hintModeRequested.Store(true)
+}
+
+// hintTarget is a widget with a hint code.
+type hintTarget struct {
+	code   string
+	id     string
+	widget Widget
+	bounds Rect
+}
+
+// hintMode overlays a letter code on every focusable widget on screen and
+// waits for one to be typed. While it is on it takes every key:
+//
+//   - letters narrow the hints to those starting with what was typed, and
+//     completing a code picks its widget and exits
+//   - backspace removes the last letter typed
+//   - escape, or Ctrl+Shift+J again, exits without picking
+//
+// Mouse presses exit it too. It is owned by the app's event loop.
+type hintMode struct {
+	active  bool
+	typed   string
+	targets []hintTarget
+}
+
+// enter starts hint mode with codes for the focusables in the active
+// focus trap, or all of them, that are on screen. It does nothing when
+// there are none.
+func (m *hintMode) enter(focusManager *FocusManager, registry *WidgetRegistry, width, height int) {
+	screen := Rect{Width: width, Height: height}
+	var targets []hintTarget
+	for _, focusable := range focusManager.focusablesInScope(focusManager.activeTrapID()) {
+		entry := registry.WidgetByID(focusable.ID)
+		if entry == nil {
+			continue
+		}
+		bounds := entry.Bounds.Intersect(screen)
+		if bounds.Width <= 0 || bounds.Height <= 0 {
+			continue
+		}
+		targets = append(targets, hintTarget{id: focusable.ID, widget: entry.EventWidget, bounds: bounds})
+	}
+	if len(targets) == 0 {
+		*m = hintMode{}
+		return
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		a, b := targets[i].bounds, targets[j].bounds
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.X < b.X
+	})
+	for i, code := range hintCodes(len(targets)) {
+		targets[i].code = code
+	}
+	*m = hintMode{active: true, targets: targets}
+}
+
+// exit leaves hint mode.
+func (m *hintMode) exit() {
+	*m = hintMode{}
+}
+
+// handleKey handles a key press while hint mode is on. It returns true
+// when hint mode took the key, which then doesn't reach widgets, and the
+// target whose code the key completed, if any.
+func (m *hintMode) handleKey(event KeyEvent) (*hintTarget, bool) {
+	if !m.active {
+		return nil, false
+	}
+	switch {
+	case event.MatchString("escape", "ctrl+shift+j"):
+		m.exit()
+	case event.MatchString("backspace"):
+		if m.typed != "" {
+			m.typed = m.typed[:len(m.typed)-1]
+		}
+	default:
+		text := strings.ToLower(event.Text())
+		if len(text) != 1 || !strings.Contains(hintAlphabet, text) {
+			break
+		}
+		typed := m.typed + text
+		for _, target := range m.targets {
+			if target.code == typed {
+				m.exit()
+				return &target, true
+			}
+			if strings.HasPrefix(target.code, typed) {
+				m.typed = typed
+			}
+		}
+	}
+	return nil, true
+}
+
+// draw paints the codes still matching what was typed over the top-left
+// corner of their widgets, with the letters typed so far dimmed.
+func (m *hintMode) draw(terminal CellBuffer, width, height int) {
+	if !m.active {
+		return
+	}
+	theme := getTheme()
+	ctx := NewRenderContext(terminal, width, height, nil, nil, BuildContext{}, nil)
+	typedStyle := Style{ForegroundColor: theme.TextOnPrimary.Blend(theme.Warning, 0.5), BackgroundColor: theme.Warning, Bold: true}
+	restStyle := Style{ForegroundColor: theme.TextOnPrimary, BackgroundColor: theme.Warning, Bold: true}
+	for _, target := range m.targets {
+		if !strings.HasPrefix(target.code, m.typed) {
+			continue
+		}
+		x, y := target.bounds.X, target.bounds.Y
+		ctx.DrawStyledText(x, y, m.typed, typedStyle)
+		ctx.DrawStyledText(x+len(m.typed), y, target.code[len(m.typed):], restStyle)
+	}
+}
+
+// hintCodes returns count codes of equal length, so that none is the
+// start of another, using as few letters as possible.
+func hintCodes(count int) []string {
+	length, capacity := 1, len(hintAlphabet)
+	for capacity < count {
+		length++
+		capacity *= len(hintAlphabet)
+	}
+	codes := make([]string, count)
+	for i := range codes {
+		code := make([]byte, length)
+		n := i
+		for j := length - 1; j >= 0; j-- {
+			code[j] = hintAlphabet[n%len(hintAlphabet)]
+			n /= len(hintAlphabet)
+		}
+		codes[i] = string(code)
+	}
+	return codes
+}
+
+// activateHint focuses a target picked in hint mode. Widgets that show
+// the pointing hand, as buttons and checkboxes do, are also pressed with
+// their Enter keybind.
+func activateHint(focusManager *FocusManager, target *hintTarget) {
+	focusManager.FocusByID(target.id)
+	provider, ok := target.widget.(CursorHintProvider)
+	if !ok || provider.CursorHint(MouseEvent{WidgetID: target.id}) != CursorPointer {
+		return
+	}
+	if keybinds, ok := target.widget.(KeybindProvider); ok {
+		matchKeybind(KeyEvent{event: uv.KeyPressEvent(uv.Key{Code: uv.KeyEnter})}, keybinds.Keybinds())
+	}
+}
@@ -0,0 +1,87 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHintCodes_ArePrefixFree(t *testing.T) {
+	assert.Equal(t, []string{"a", "s", "d"}, hintCodes(3))
+
+	codes := hintCodes(30)
+	assert.Equal(t, "aa", codes[0])
+	assert.Equal(t, "sd", codes[28])
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Len(t, code, 2)
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+}
+
+func TestHintMode_FocusesAndPressesTypedTarget(t *testing.T) {
+	saved := 0
+	name := NewTextInputState("")
+	widget := Column{Children: []Widget{
+		TextInput{ID: "name", State: name, Style: Style{Width: Cells(10)}},
+		Row{Spacing: 1, Children: []Widget{
+			Button{ID: "save", Label: "Save", OnPress: func() { saved++ }},
+			Button{ID: "quit", Label: "Quit"},
+		}},
+	}}
+	buf := uv.NewBuffer(20, 3)
+	focusManager := NewFocusManager()
+	renderer := NewRenderer(buf, 20, 3, focusManager, NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil))
+	focusManager.SetFocusables(renderer.Render(widget))
+
+	var hints hintMode
+	hints.enter(focusManager, renderer.widgetRegistry, 20, 3)
+	require.Len(t, hints.targets, 3)
+	hints.draw(buf, 20, 3)
+	assert.Equal(t, "a", buf.CellAt(0, 0).Content)
+	assert.True(t, strings.HasPrefix(bufferLine(buf, 1, 20), "s"), "codes go left to right, top to bottom")
+
+	_, took := hints.handleKey(makeCharEvent('x'))
+	assert.True(t, took, "hint mode takes every key")
+	assert.True(t, hints.active, "letters that start no code are ignored")
+
+	target, _ := hints.handleKey(makeCharEvent('s'))
+	require.NotNil(t, target)
+	activateHint(focusManager, target)
+	assert.Equal(t, "save", focusManager.FocusedID())
+	assert.Equal(t, 1, saved, "buttons are pressed")
+	assert.False(t, hints.active)
+
+	hints.enter(focusManager, renderer.widgetRegistry, 20, 3)
+	target, _ = hints.handleKey(makeCharEvent('a'))
+	activateHint(focusManager, target)
+	assert.Equal(t, "name", focusManager.FocusedID())
+	assert.Equal(t, "", name.GetText(), "text inputs are only focused")
+}
+
+func TestHintMode_NarrowsAsCodesAreTyped(t *testing.T) {
+	hints := hintMode{active: true, targets: []hintTarget{
+		{code: "aa", bounds: Rect{X: 0, Y: 0, Width: 5, Height: 1}},
+		{code: "as", bounds: Rect{X: 0, Y: 1, Width: 5, Height: 1}},
+		{code: "sa", bounds: Rect{X: 0, Y: 2, Width: 5, Height: 1}},
+	}}
+
+	target, _ := hints.handleKey(makeCharEvent('a'))
+	assert.Nil(t, target)
+	buf := uv.NewBuffer(5, 3)
+	hints.draw(buf, 5, 3)
+	assert.Equal(t, []string{"aa", "as", ""}, []string{
+		strings.TrimSpace(bufferLine(buf, 0, 5)),
+		strings.TrimSpace(bufferLine(buf, 1, 5)),
+		strings.TrimSpace(bufferLine(buf, 2, 5)),
+	})
+
+	hints.handleKey(makeKeyEvent(uv.KeyBackspace, 0))
+	assert.Equal(t, "", hints.typed)
+	hints.handleKey(makeKeyEvent(uv.KeyEscape, 0))
+	assert.False(t, hints.active)
+}
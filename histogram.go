@@ -0,0 +1,227 @@
+package terma
+
+import (
+	"math"
+	"slices"
+
+	"github.com/darrenburns/terma/layout"
+)
+
+// HistogramBinning is how a Histogram picks its number of bins when
+// neither Bins nor BinWidth is set.
+type HistogramBinning int
+
+const (
+	// BinSturges uses log2(n)+1 bins, which suits roughly normal data.
+	BinSturges HistogramBinning = iota
+	// BinSquareRoot uses √n bins.
+	BinSquareRoot
+	// BinFreedmanDiaconis sizes bins from the interquartile range, so
+	// outliers don't squash the rest of the data into a few bins.
+	BinFreedmanDiaconis
+)
+
+// HistogramSeries is one set of values counted by a Histogram.
+type HistogramSeries struct {
+	Name   string    // Shown in the legend
+	Values []float64 // The values to count; NaN and infinities are skipped
+	Color  Color     // Default: a theme color picked by the series' index
+}
+
+// Histogram counts values into equal-width bins and draws a bar for each,
+// with axes and a legend. Several series share the bins, side by side in
+// each bin. Bar heights use eighth blocks for sub-cell resolution.
+//
+// Example:
+//
+//	terma.Histogram{
+//	    Series:  []terma.HistogramSeries{{Name: "latency", Values: a.latencies}},
+//	    Binning: terma.BinFreedmanDiaconis,
+//	    XFormat: func(ms float64) string { return fmt.Sprintf("%.0fms", ms) },
+//	}
+type Histogram struct {
+	ID         string                     // Optional unique identifier
+	Series     []HistogramSeries          // The values to count
+	Bins       int                        // Number of bins (default: picked by Binning)
+	BinWidth   float64                    // Width of each bin from the range's start; used when Bins is unset
+	Binning    HistogramBinning           // How to pick the number of bins (default: BinSturges)
+	Min, Max   *float64                   // Optional fixed range; values outside it aren't counted (default: fit the data)
+	XFormat    func(value float64) string // Formats X axis labels (default: 4 significant digits)
+	YFormat    func(value float64) string // Formats count labels (default: 4 significant digits)
+	HideAxes   bool                       // Draw the bars only, without axes and labels
+	HideLegend bool                       // Hide the legend of named series
+	Style      Style                      // Optional styling
+}
+
+// Build returns itself as Histogram is a leaf widget.
+func (h Histogram) Build(ctx BuildContext) Widget {
+	return h
+}
+
+// WidgetID returns the histogram's unique identifier.
+// Implements the Identifiable interface.
+func (h Histogram) WidgetID() string {
+	return h.ID
+}
+
+// GetContentDimensions returns the width and height dimension preferences.
+// Width defaults to Flex(1), Height defaults to Cells(10).
+func (h Histogram) GetContentDimensions() (width, height Dimension) {
+	dims := h.Style.GetDimensions()
+	width, height = dims.Width, dims.Height
+	if width.IsUnset() {
+		width = Flex(1)
+	}
+	if height.IsUnset() {
+		height = Cells(10)
+	}
+	return width, height
+}
+
+// GetStyle returns the style of the histogram.
+func (h Histogram) GetStyle() Style {
+	return h.Style
+}
+
+// BuildLayoutNode builds a layout node for this Histogram widget.
+func (h Histogram) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return monitorLayoutNode(h, h.Style)
+}
+
+// histogramBin is the range of one bin and each series' count in it.
+type histogramBin struct {
+	start, end float64
+	counts     []int
+}
+
+// bins counts the series' values into bins.
+func (h Histogram) bins() []histogramBin {
+	var values []float64
+	for _, s := range h.Series {
+		for _, v := range s.Values {
+			if chartFinite(v) {
+				values = append(values, v)
+			}
+		}
+	}
+	slices.Sort(values)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	if len(values) > 0 {
+		lo, hi = values[0], values[len(values)-1]
+	}
+	if h.Min != nil {
+		lo = *h.Min
+	}
+	if h.Max != nil {
+		hi = *h.Max
+	}
+	if lo > hi {
+		return nil
+	}
+	if lo == hi {
+		view := widenChartView(PlotView{MinX: lo, MaxX: hi, MaxY: 1})
+		lo, hi = view.MinX, view.MaxX
+	}
+
+	count := h.Bins
+	if count <= 0 && h.BinWidth > 0 {
+		count = int(math.Ceil((hi - lo) / h.BinWidth))
+		hi = lo + float64(count)*h.BinWidth
+	}
+	if count <= 0 {
+		count = histogramBinCount(h.Binning, values, lo, hi)
+	}
+	count = max(1, count)
+
+	width := (hi - lo) / float64(count)
+	bins := make([]histogramBin, count)
+	for i := range bins {
+		bins[i] = histogramBin{start: lo + float64(i)*width, end: lo + float64(i+1)*width, counts: make([]int, len(h.Series))}
+	}
+	bins[count-1].end = hi
+	for series, s := range h.Series {
+		for _, v := range s.Values {
+			if !chartFinite(v) || v < lo || v > hi {
+				continue
+			}
+			bins[min(count-1, int((v-lo)/width))].counts[series]++
+		}
+	}
+	return bins
+}
+
+// histogramBinCount picks the number of bins for sorted values spanning
+// lo to hi.
+func histogramBinCount(binning HistogramBinning, sorted []float64, lo, hi float64) int {
+	n := float64(len(sorted))
+	if n == 0 {
+		return 1
+	}
+	switch binning {
+	case BinSquareRoot:
+		return int(math.Ceil(math.Sqrt(n)))
+	case BinFreedmanDiaconis:
+		iqr := chartQuantile(sorted, 0.75) - chartQuantile(sorted, 0.25)
+		if iqr > 0 {
+			return int(math.Ceil((hi - lo) / (2 * iqr / math.Cbrt(n))))
+		}
+	}
+	return int(math.Ceil(math.Log2(n))) + 1
+}
+
+func (h Histogram) seriesColor(theme ThemeData, index int) Color {
+	return chartColor(theme, h.Series[index].Color, index)
+}
+
+// Render draws the axes, the bars and the legend.
+func (h Histogram) Render(ctx *RenderContext) {
+	if ctx.Width <= 0 || ctx.Height <= 0 || len(h.Series) == 0 {
+		return
+	}
+	bins := h.bins()
+	if len(bins) == 0 {
+		return
+	}
+	theme := ctx.buildContext.Theme()
+	maxCount := 1
+	for _, bin := range bins {
+		for _, count := range bin.counts {
+			maxCount = max(maxCount, count)
+		}
+	}
+	view := PlotView{MinX: bins[0].start, MaxX: bins[len(bins)-1].end, MinY: 0, MaxY: float64(maxCount)}
+	area := chartArea{view: view, width: ctx.Width, height: ctx.Height}
+	if !h.HideAxes {
+		area = drawChartAxes(ctx, theme, view, h.YFormat)
+		drawChartXLabels(ctx, theme, area, h.XFormat)
+	}
+	if area.width <= 0 || area.height <= 0 {
+		return
+	}
+
+	// Each column shows the series at its position within its bin
+	for x := 0; x < area.width; x++ {
+		pos := (float64(x) + 0.5) / float64(area.width) * float64(len(bins))
+		bin := min(len(bins)-1, int(pos))
+		series := min(len(h.Series)-1, int((pos-float64(bin))*float64(len(h.Series))))
+		count := bins[bin].counts[series]
+		if count == 0 {
+			continue
+		}
+		eighths := max(1, int(math.Round(float64(count)/view.MaxY*float64(area.height*8))))
+		style := Style{ForegroundColor: h.seriesColor(theme, series)}
+		for row := area.height - 1; row >= 0 && eighths > 0; row-- {
+			cell := min(8, eighths)
+			ctx.DrawStyledText(area.left+x, area.top+row, sparklineBars[cell-1], style)
+			eighths -= cell
+		}
+	}
+
+	if !h.HideLegend {
+		entries := make([]chartLegendEntry, len(h.Series))
+		for i, s := range h.Series {
+			entries[i] = chartLegendEntry{name: s.Name, color: h.seriesColor(theme, i)}
+		}
+		drawChartLegend(ctx, theme, area, entries)
+	}
+}
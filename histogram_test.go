@@ -0,0 +1,104 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func renderHistogram(t *testing.T, histogram Histogram, width, height int) []string {
+	t.Helper()
+	renderer, buf := portalTestRenderer(width, height)
+	histogram.Style.Width = Cells(width)
+	histogram.Style.Height = Cells(height)
+	renderer.Render(histogram)
+	lines := make([]string, height)
+	for y := range lines {
+		lines[y] = bufferLine(buf, y, width)
+	}
+	return lines
+}
+
+func TestHistogram_CountsValuesIntoBins(t *testing.T) {
+	histogram := Histogram{Bins: 4, Series: []HistogramSeries{{Values: []float64{0, 1, 1, 2.5, 4, 4}}}}
+
+	bins := histogram.bins()
+
+	assert.Len(t, bins, 4)
+	assert.Equal(t, 0.0, bins[0].start)
+	assert.Equal(t, 4.0, bins[3].end)
+	counts := []int{bins[0].counts[0], bins[1].counts[0], bins[2].counts[0], bins[3].counts[0]}
+	assert.Equal(t, []int{1, 2, 1, 2}, counts, "the range's end falls in the last bin")
+}
+
+func TestHistogram_BinWidthAndFixedRange(t *testing.T) {
+	lo, hi := 0.0, 10.0
+	histogram := Histogram{BinWidth: 3, Min: &lo, Max: &hi, Series: []HistogramSeries{{Values: []float64{-1, 2, 9, 11}}}}
+
+	bins := histogram.bins()
+
+	assert.Len(t, bins, 4)
+	assert.Equal(t, 12.0, bins[3].end, "the last bin is a whole width")
+	total := 0
+	for _, bin := range bins {
+		total += bin.counts[0]
+	}
+	assert.Equal(t, 3, total, "values outside the range aren't counted")
+}
+
+func TestHistogramBinCount(t *testing.T) {
+	values := make([]float64, 100)
+	for i := range values {
+		values[i] = float64(i)
+	}
+
+	assert.Equal(t, 8, histogramBinCount(BinSturges, values, 0, 99))
+	assert.Equal(t, 10, histogramBinCount(BinSquareRoot, values, 0, 99))
+	// IQR 49.5 over 100 values gives bins about 21 wide
+	assert.Equal(t, 5, histogramBinCount(BinFreedmanDiaconis, values, 0, 99))
+	assert.Equal(t, 1, histogramBinCount(BinSturges, nil, 0, 1))
+}
+
+func TestHistogram_DrawsBarsWithEighthBlocks(t *testing.T) {
+	histogram := Histogram{
+		HideAxes: true,
+		Bins:     2,
+		Series:   []HistogramSeries{{Values: []float64{0, 0, 0, 0, 1, 1, 1}}},
+	}
+
+	lines := renderHistogram(t, histogram, 2, 2)
+
+	assert.Equal(t, "█▄", lines[0])
+	assert.Equal(t, "██", lines[1])
+}
+
+func TestHistogram_SeriesShareBinsSideBySide(t *testing.T) {
+	histogram := Histogram{
+		HideAxes: true,
+		Bins:     1,
+		Series: []HistogramSeries{
+			{Values: []float64{0, 1}},
+			{Values: []float64{0}},
+		},
+	}
+
+	lines := renderHistogram(t, histogram, 2, 1)
+
+	assert.Equal(t, "█▄", lines[0])
+}
+
+func TestHistogram_DrawsAxesAndLegend(t *testing.T) {
+	histogram := Histogram{
+		Bins:   2,
+		Series: []HistogramSeries{{Name: "ms", Values: []float64{0, 10, 10}}},
+	}
+
+	lines := renderHistogram(t, histogram, 20, 6)
+
+	assert.True(t, strings.HasPrefix(lines[0], "2│"), lines[0])
+	assert.True(t, strings.HasSuffix(lines[0], " ● ms "), lines[0])
+	assert.True(t, strings.HasPrefix(lines[3], "0│"), lines[3])
+	assert.Equal(t, " └──────────────────", lines[4])
+	assert.Equal(t, "  0        5      10", lines[5])
+}
@@ -0,0 +1,135 @@
+package terma
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// HotStateEnv names the environment variable pointing at the file used to
+// carry state across restarts. cmd/terma-dev sets it for the app it runs;
+// when it is unset, hot state is disabled and HotSignal behaves like NewSignal.
+const HotStateEnv = "TERMA_HOT_STATE"
+
+// hotFocusKey stores the focused widget ID alongside the app's hot signals.
+const hotFocusKey = "terma:focus"
+
+// hotState tracks the values restored at startup and the signals to save
+// at shutdown, keyed by the name given to HotSignal.
+var hotState = struct {
+	mu      sync.Mutex
+	loaded  bool
+	values  map[string]json.RawMessage
+	signals map[string]func() any
+}{}
+
+// loadHotState reads the state file once. Must be called with hotState.mu held.
+func loadHotState() {
+	if hotState.loaded {
+		return
+	}
+	hotState.loaded = true
+	hotState.values = map[string]json.RawMessage{}
+	hotState.signals = map[string]func() any{}
+
+	path := os.Getenv(HotStateEnv)
+	if path == "" {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			LogWarn("hot state not loaded", Field("path", path), Field("error", err))
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &hotState.values); err != nil {
+		LogWarn("hot state not loaded", Field("path", path), Field("error", err))
+	}
+}
+
+// restoreHotValue decodes the value saved under key into target.
+func restoreHotValue(key string, target any) bool {
+	raw, ok := hotState.values[key]
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(raw, target); err != nil {
+		LogWarn("hot state value ignored", Field("key", key), Field("error", err))
+		return false
+	}
+	return true
+}
+
+// HotSignal creates a signal whose value survives restarts when the app is
+// run under cmd/terma-dev. The key must be stable across builds and unique
+// in the app; the ID of the widget the state belongs to is a good choice.
+// T must round-trip through encoding/json. Create hot signals once, as
+// with NewSignal, not inside Build.
+//
+// Example:
+//
+//	app := &App{query: t.HotSignal("search-query", "")}
+func HotSignal[T comparable](key string, initial T) Signal[T] {
+	hotState.mu.Lock()
+	defer hotState.mu.Unlock()
+	loadHotState()
+
+	value := initial
+	if restored := initial; restoreHotValue(key, &restored) {
+		value = restored
+	}
+	signal := NewSignal(value)
+	hotState.signals[key] = func() any { return signal.Peek() }
+	return signal
+}
+
+// HotAnySignal is HotSignal for values that aren't comparable, such as slices.
+func HotAnySignal[T any](key string, initial T) AnySignal[T] {
+	hotState.mu.Lock()
+	defer hotState.mu.Unlock()
+	loadHotState()
+
+	value := initial
+	if restored := initial; restoreHotValue(key, &restored) {
+		value = restored
+	}
+	signal := NewAnySignal(value)
+	hotState.signals[key] = func() any { return signal.Peek() }
+	return signal
+}
+
+// restoredHotFocus returns the widget ID that had focus before the restart.
+func restoredHotFocus() string {
+	hotState.mu.Lock()
+	defer hotState.mu.Unlock()
+	loadHotState()
+
+	var focusedID string
+	restoreHotValue(hotFocusKey, &focusedID)
+	return focusedID
+}
+
+// saveHotState writes the current value of every hot signal, plus the
+// focused widget ID, to the state file. Does nothing outside terma-dev.
+func saveHotState(focusedID string) {
+	path := os.Getenv(HotStateEnv)
+	if path == "" {
+		return
+	}
+
+	hotState.mu.Lock()
+	values := map[string]any{hotFocusKey: focusedID}
+	for key, value := range hotState.signals {
+		values[key] = value()
+	}
+	hotState.mu.Unlock()
+
+	data, err := json.Marshal(values)
+	if err == nil {
+		err = os.WriteFile(path, data, 0644)
+	}
+	if err != nil {
+		LogError("hot state not saved", Field("path", path), Field("error", err))
+	}
+}
@@ -0,0 +1,72 @@
+package terma
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withHotState points the hot state at a file in a temp dir and resets the
+// loaded values so the next HotSignal reads it.
+func withHotState(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "state.json")
+	if contents != "" {
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+	}
+	t.Setenv(HotStateEnv, path)
+
+	resetHotState := func() {
+		hotState.mu.Lock()
+		hotState.loaded = false
+		hotState.mu.Unlock()
+	}
+	resetHotState()
+	t.Cleanup(resetHotState)
+	return path
+}
+
+func TestHotSignal_RestoresSavedValue(t *testing.T) {
+	withHotState(t, `{"query": "hello", "tags": ["a", "b"], "terma:focus": "search"}`)
+
+	assert.Equal(t, "hello", HotSignal("query", "").Peek())
+	assert.Equal(t, 3, HotSignal("count", 3).Peek())
+	assert.Equal(t, []string{"a", "b"}, HotAnySignal[[]string]("tags", nil).Peek())
+	assert.Equal(t, "search", restoredHotFocus())
+}
+
+func TestHotSignal_IgnoresMismatchedType(t *testing.T) {
+	withHotState(t, `{"count": "not a number"}`)
+
+	assert.Equal(t, 7, HotSignal("count", 7).Peek())
+}
+
+func TestSaveHotState_WritesSignalsAndFocus(t *testing.T) {
+	path := withHotState(t, "")
+
+	query := HotSignal("query", "")
+	query.Set("draft")
+	HotSignal("page", 2)
+	saveHotState("editor")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var saved map[string]any
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, map[string]any{"query": "draft", "page": float64(2), "terma:focus": "editor"}, saved)
+}
+
+func TestHotSignal_WithoutEnvBehavesLikeNewSignal(t *testing.T) {
+	withHotState(t, `{"query": "hello"}`)
+	t.Setenv(HotStateEnv, "")
+	hotState.mu.Lock()
+	hotState.loaded = false
+	hotState.mu.Unlock()
+
+	assert.Equal(t, "", HotSignal("query", "").Peek())
+	assert.Equal(t, "", restoredHotFocus())
+}
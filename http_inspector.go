@@ -0,0 +1,831 @@
+package terma
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/darrenburns/terma/layout"
+)
+
+// KeyValue is one row of a KeyValueEditor, such as an HTTP header or a
+// query parameter.
+type KeyValue struct {
+	Key      string
+	Value    string
+	Disabled bool // Kept in the editor but left out of EnabledRows
+}
+
+// KeyValueEditorState holds the rows and editor state for a KeyValueEditor.
+type KeyValueEditorState struct {
+	Rows AnySignal[[]KeyValue]
+
+	keys    []*TextInputState
+	values  []*TextInputState
+	enabled []*CheckboxState
+}
+
+// NewKeyValueEditorState creates a new KeyValueEditorState with the given rows.
+func NewKeyValueEditorState(rows []KeyValue) *KeyValueEditorState {
+	s := &KeyValueEditorState{Rows: NewAnySignal(rows)}
+	s.syncEditors()
+	return s
+}
+
+// KeyValuesFromHeader returns the header's values as rows, sorted by name.
+func KeyValuesFromHeader(header http.Header) []KeyValue {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var rows []KeyValue
+	for _, name := range names {
+		for _, value := range header[name] {
+			rows = append(rows, KeyValue{Key: name, Value: value})
+		}
+	}
+	return rows
+}
+
+// GetRows returns the current rows without subscribing to changes.
+func (s *KeyValueEditorState) GetRows() []KeyValue {
+	return s.Rows.Peek()
+}
+
+// SetRows replaces all rows.
+func (s *KeyValueEditorState) SetRows(rows []KeyValue) {
+	s.Rows.Set(rows)
+	s.syncEditors()
+}
+
+// EnabledRows returns the rows that are not disabled and have a key.
+func (s *KeyValueEditorState) EnabledRows() []KeyValue {
+	var rows []KeyValue
+	for _, row := range s.Rows.Peek() {
+		if !row.Disabled && strings.TrimSpace(row.Key) != "" {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// Header returns the enabled rows as an http.Header.
+func (s *KeyValueEditorState) Header() http.Header {
+	header := http.Header{}
+	for _, row := range s.EnabledRows() {
+		header.Add(strings.TrimSpace(row.Key), row.Value)
+	}
+	return header
+}
+
+// Add appends a row.
+func (s *KeyValueEditorState) Add(row KeyValue) {
+	s.Rows.Update(func(rows []KeyValue) []KeyValue {
+		return append(rows[:len(rows):len(rows)], row)
+	})
+	s.syncEditors()
+}
+
+// Remove deletes the row at index.
+func (s *KeyValueEditorState) Remove(index int) {
+	rows := s.Rows.Peek()
+	if index < 0 || index >= len(rows) {
+		return
+	}
+	next := make([]KeyValue, 0, len(rows)-1)
+	next = append(next, rows[:index]...)
+	next = append(next, rows[index+1:]...)
+	s.keys = append(s.keys[:index:index], s.keys[index+1:]...)
+	s.values = append(s.values[:index:index], s.values[index+1:]...)
+	s.enabled = append(s.enabled[:index:index], s.enabled[index+1:]...)
+	s.Rows.Set(next)
+}
+
+// SetRow replaces the row at index.
+func (s *KeyValueEditorState) SetRow(index int, row KeyValue) {
+	if !s.updateRow(index, row) {
+		return
+	}
+	s.keys[index].SetText(row.Key)
+	s.values[index].SetText(row.Value)
+	s.enabled[index].SetChecked(!row.Disabled)
+}
+
+// updateRow replaces the row at index without touching its editors, for
+// edits that come from the editors themselves.
+func (s *KeyValueEditorState) updateRow(index int, row KeyValue) bool {
+	rows := s.Rows.Peek()
+	if index < 0 || index >= len(rows) {
+		return false
+	}
+	next := make([]KeyValue, len(rows))
+	copy(next, rows)
+	next[index] = row
+	s.Rows.Set(next)
+	return true
+}
+
+// syncEditors makes the per-row editor states match the rows.
+func (s *KeyValueEditorState) syncEditors() {
+	rows := s.Rows.Peek()
+	for i, row := range rows {
+		if i < len(s.keys) {
+			s.keys[i].SetText(row.Key)
+			s.values[i].SetText(row.Value)
+			s.enabled[i].SetChecked(!row.Disabled)
+			continue
+		}
+		s.keys = append(s.keys, NewTextInputState(row.Key))
+		s.values = append(s.values, NewTextInputState(row.Value))
+		s.enabled = append(s.enabled, NewCheckboxState(!row.Disabled))
+	}
+	s.keys = s.keys[:len(rows)]
+	s.values = s.values[:len(rows)]
+	s.enabled = s.enabled[:len(rows)]
+}
+
+// KeyValueEditor is an editable two-column table of key/value rows, such
+// as request headers. Each row has a checkbox to disable it without
+// deleting it, key and value inputs, and a remove button; an add button
+// below the rows appends an empty row.
+//
+// Example:
+//
+//	headers := terma.NewKeyValueEditorState([]terma.KeyValue{
+//	    {Key: "Accept", Value: "application/json"},
+//	})
+//	terma.KeyValueEditor{ID: "headers", State: headers}
+type KeyValueEditor struct {
+	ID               string                // Optional unique identifier (prefix for child IDs, default "key-value")
+	State            *KeyValueEditorState  // Required - holds the rows
+	KeyHeader        string                // Key column heading (default "Key")
+	ValueHeader      string                // Value column heading (default "Value")
+	KeyPlaceholder   string                // Placeholder for empty keys (default "Key")
+	ValuePlaceholder string                // Placeholder for empty values (default "Value")
+	AddLabel         string                // Label of the add button (default "+ Add")
+	OnChange         func(rows []KeyValue) // Optional callback invoked after any edit
+	Style            Style                 // Optional styling
+}
+
+// WidgetID returns the editor's unique identifier.
+func (e KeyValueEditor) WidgetID() string {
+	return e.ID
+}
+
+// GetStyle returns the style.
+func (e KeyValueEditor) GetStyle() Style {
+	return e.Style
+}
+
+func (e KeyValueEditor) childID(parts ...string) string {
+	id := e.ID
+	if id == "" {
+		id = "key-value"
+	}
+	return id + "-" + strings.Join(parts, "-")
+}
+
+func (e KeyValueEditor) changed() {
+	if e.OnChange != nil {
+		e.OnChange(e.State.GetRows())
+	}
+}
+
+// Build returns the column headings, one row per entry and the add button.
+func (e KeyValueEditor) Build(ctx BuildContext) Widget {
+	if e.State == nil {
+		return EmptyWidget{}
+	}
+	theme := ctx.Theme()
+	state := e.State
+	rows := state.Rows.Get()
+
+	keyHeader := e.KeyHeader
+	if keyHeader == "" {
+		keyHeader = "Key"
+	}
+	valueHeader := e.ValueHeader
+	if valueHeader == "" {
+		valueHeader = "Value"
+	}
+	keyPlaceholder := e.KeyPlaceholder
+	if keyPlaceholder == "" {
+		keyPlaceholder = "Key"
+	}
+	valuePlaceholder := e.ValuePlaceholder
+	if valuePlaceholder == "" {
+		valuePlaceholder = "Value"
+	}
+	addLabel := e.AddLabel
+	if addLabel == "" {
+		addLabel = "+ Add"
+	}
+
+	children := []Widget{Row{
+		Style:   Style{Width: Flex(1)},
+		Spacing: 1,
+		Children: []Widget{
+			Text{Content: " ", Style: Style{Width: Cells(1)}},
+			Text{Content: keyHeader, Style: Style{Width: Flex(1), Bold: true, ForegroundColor: theme.TextMuted}},
+			Text{Content: valueHeader, Style: Style{Width: Flex(2), Bold: true, ForegroundColor: theme.TextMuted}},
+			Text{Content: " ", Style: Style{Width: Cells(3)}},
+		},
+	}}
+
+	for i := range rows {
+		index := i
+		rowID := fmt.Sprint(i)
+		children = append(children, Row{
+			Style:   Style{Width: Flex(1)},
+			Spacing: 1,
+			Children: []Widget{
+				&Checkbox{
+					ID:    e.childID(rowID, "enabled"),
+					State: state.enabled[i],
+					OnChange: func(checked bool) {
+						row := state.GetRows()[index]
+						row.Disabled = !checked
+						state.updateRow(index, row)
+						e.changed()
+					},
+				},
+				TextInput{
+					ID:          e.childID(rowID, "key"),
+					State:       state.keys[i],
+					Placeholder: keyPlaceholder,
+					OnChange: func(text string) {
+						row := state.GetRows()[index]
+						row.Key = text
+						state.updateRow(index, row)
+						e.changed()
+					},
+					Style: Style{Width: Flex(1), BackgroundColor: theme.Surface},
+				},
+				TextInput{
+					ID:          e.childID(rowID, "value"),
+					State:       state.values[i],
+					Placeholder: valuePlaceholder,
+					OnChange: func(text string) {
+						row := state.GetRows()[index]
+						row.Value = text
+						state.updateRow(index, row)
+						e.changed()
+					},
+					Style: Style{Width: Flex(2), BackgroundColor: theme.Surface},
+				},
+				Button{
+					ID:    e.childID(rowID, "remove"),
+					Label: "✕",
+					OnPress: func() {
+						state.Remove(index)
+						e.changed()
+					},
+				},
+			},
+		})
+	}
+	children = append(children, Button{
+		ID:    e.childID("add"),
+		Label: addLabel,
+		OnPress: func() {
+			state.Add(KeyValue{})
+			e.changed()
+		},
+	})
+
+	style := e.Style
+	if style.Width.IsUnset() {
+		style.Width = Flex(1)
+	}
+	return Column{Style: style, Children: children}
+}
+
+// QueryParamsState keeps a URL input and a KeyValueEditor of its query
+// parameters in sync: editing the URL re-parses the parameters, and
+// editing a parameter rewrites the URL's query string. Disabled
+// parameters stay in the editor but are left out of the URL.
+type QueryParamsState struct {
+	URL    *TextInputState
+	Params *KeyValueEditorState
+}
+
+// NewQueryParamsState creates a QueryParamsState for rawURL.
+func NewQueryParamsState(rawURL string) *QueryParamsState {
+	s := &QueryParamsState{
+		URL:    NewTextInputState(rawURL),
+		Params: NewKeyValueEditorState(nil),
+	}
+	s.SyncFromURL()
+	return s
+}
+
+// GetURL returns the current URL text.
+func (s *QueryParamsState) GetURL() string {
+	return s.URL.GetText()
+}
+
+// SetURL replaces the URL text and re-parses its parameters.
+func (s *QueryParamsState) SetURL(rawURL string) {
+	s.URL.SetText(rawURL)
+	s.SyncFromURL()
+}
+
+// SyncFromURL replaces the enabled parameters with those in the URL's
+// query string, in order. Disabled parameters are kept after them.
+func (s *QueryParamsState) SyncFromURL() {
+	_, query, _ := splitURLQuery(s.URL.GetText())
+	rows := parseQueryParams(query)
+	for _, row := range s.Params.GetRows() {
+		if row.Disabled {
+			rows = append(rows, row)
+		}
+	}
+	s.Params.SetRows(rows)
+}
+
+// SyncToURL rewrites the URL's query string from the enabled parameters.
+func (s *QueryParamsState) SyncToURL() {
+	base, _, fragment := splitURLQuery(s.URL.GetText())
+	var parts []string
+	for _, row := range s.Params.EnabledRows() {
+		part := url.QueryEscape(row.Key)
+		if row.Value != "" {
+			part += "=" + url.QueryEscape(row.Value)
+		}
+		parts = append(parts, part)
+	}
+	next := base
+	if len(parts) > 0 {
+		next += "?" + strings.Join(parts, "&")
+	}
+	if fragment != "" {
+		next += "#" + fragment
+	}
+	if next != s.URL.GetText() {
+		s.URL.SetText(next)
+	}
+}
+
+// splitURLQuery splits rawURL into the part before "?", the query string
+// and the fragment.
+func splitURLQuery(rawURL string) (base, query, fragment string) {
+	rest, fragment, _ := strings.Cut(rawURL, "#")
+	base, query, _ = strings.Cut(rest, "?")
+	return base, query, fragment
+}
+
+// parseQueryParams parses a query string into rows, keeping their order
+// and falling back to the raw text for malformed escapes.
+func parseQueryParams(query string) []KeyValue {
+	var rows []KeyValue
+	for _, part := range strings.Split(query, "&") {
+		if part == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+		rows = append(rows, KeyValue{Key: key, Value: value})
+	}
+	return rows
+}
+
+// URLInput is a TextInput for a QueryParamsState's URL that re-parses the
+// query parameters as the URL is edited.
+type URLInput struct {
+	ID          string              // Optional unique identifier
+	State       *QueryParamsState   // Required - holds the URL and parameters
+	Placeholder string              // Text shown when empty and unfocused
+	OnSubmit    func(rawURL string) // Optional callback invoked when Enter is pressed
+	Style       Style               // Optional styling
+}
+
+// WidgetID returns the input's unique identifier.
+func (u URLInput) WidgetID() string {
+	return u.ID
+}
+
+// Build returns a TextInput bound to the URL.
+func (u URLInput) Build(ctx BuildContext) Widget {
+	if u.State == nil {
+		return EmptyWidget{}
+	}
+	state := u.State
+	style := u.Style
+	if style.Width.IsUnset() {
+		style.Width = Flex(1)
+	}
+	return TextInput{
+		ID:          u.ID,
+		State:       state.URL,
+		Placeholder: u.Placeholder,
+		OnChange:    func(string) { state.SyncFromURL() },
+		OnSubmit:    u.OnSubmit,
+		Style:       style,
+	}
+}
+
+// QueryParamEditor is a KeyValueEditor for a QueryParamsState's
+// parameters that rewrites the URL as parameters are edited. Pair it with
+// URLInput for two-way sync.
+type QueryParamEditor struct {
+	ID       string              // Optional unique identifier (prefix for child IDs, default "query-params")
+	State    *QueryParamsState   // Required - holds the URL and parameters
+	OnChange func(rawURL string) // Optional callback invoked with the rewritten URL after an edit
+	Style    Style               // Optional styling
+}
+
+// WidgetID returns the editor's unique identifier.
+func (q QueryParamEditor) WidgetID() string {
+	return q.ID
+}
+
+// Build returns a KeyValueEditor bound to the parameters.
+func (q QueryParamEditor) Build(ctx BuildContext) Widget {
+	if q.State == nil {
+		return EmptyWidget{}
+	}
+	state := q.State
+	id := q.ID
+	if id == "" {
+		id = "query-params"
+	}
+	return KeyValueEditor{
+		ID:        id,
+		State:     state.Params,
+		KeyHeader: "Parameter",
+		AddLabel:  "+ Add parameter",
+		Style:     q.Style,
+		OnChange: func([]KeyValue) {
+			state.SyncToURL()
+			if q.OnChange != nil {
+				q.OnChange(state.GetURL())
+			}
+		},
+	}
+}
+
+// bodyKind classifies a body as "json", "markup" (XML or HTML) or "" from
+// its content type, sniffing JSON when the content type is missing.
+func bodyKind(contentType string, body []byte) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return "json"
+	case strings.HasSuffix(mediaType, "xml") || mediaType == "text/html":
+		return "markup"
+	case mediaType == "":
+		trimmed := bytes.TrimSpace(body)
+		if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+			return "json"
+		}
+	}
+	return ""
+}
+
+// FormatBody returns body as display text for its content type. JSON is
+// re-indented with two spaces; other bodies, and JSON that fails to
+// parse, are returned as received.
+func FormatBody(contentType string, body []byte) string {
+	if bodyKind(contentType, body) == "json" {
+		var out bytes.Buffer
+		if err := json.Indent(&out, bytes.TrimSpace(body), "", "  "); err == nil {
+			return out.String()
+		}
+	}
+	return string(body)
+}
+
+// BodyHighlighter returns a Highlighter for bodies of the given content
+// type, or nil when there is no highlighting for it. JSON keys, strings,
+// numbers and literals are colored; XML and HTML tags and attribute values
+// are colored.
+func BodyHighlighter(contentType string, theme ThemeData) Highlighter {
+	switch bodyKind(contentType, nil) {
+	case "json":
+		return HighlighterFunc(func(_ string, graphemes []string) []TextHighlight {
+			return highlightJSON(graphemes, theme)
+		})
+	case "markup":
+		return HighlighterFunc(func(_ string, graphemes []string) []TextHighlight {
+			return highlightMarkup(graphemes, theme)
+		})
+	}
+	return nil
+}
+
+// highlightJSON colors JSON tokens by grapheme index. It is tolerant of
+// invalid JSON, so partially typed bodies still highlight.
+func highlightJSON(graphemes []string, theme ThemeData) []TextHighlight {
+	var highlights []TextHighlight
+	for i := 0; i < len(graphemes); {
+		g := graphemes[i]
+		switch {
+		case g == `"`:
+			end := skipQuoted(graphemes, i)
+			style := SpanStyle{Foreground: theme.Success}
+			next := end
+			for next < len(graphemes) && strings.TrimSpace(graphemes[next]) == "" {
+				next++
+			}
+			if next < len(graphemes) && graphemes[next] == ":" {
+				style = SpanStyle{Foreground: theme.Primary}
+			}
+			highlights = append(highlights, TextHighlight{Start: i, End: end, Style: style})
+			i = end
+		case g == "-" || (g >= "0" && g <= "9" && len(g) == 1):
+			end := i + 1
+			for end < len(graphemes) && len(graphemes[end]) == 1 && strings.Contains("0123456789.eE+-", graphemes[end]) {
+				end++
+			}
+			highlights = append(highlights, TextHighlight{Start: i, End: end, Style: SpanStyle{Foreground: theme.Warning}})
+			i = end
+		case g >= "a" && g <= "z" && len(g) == 1:
+			end := i + 1
+			for end < len(graphemes) && len(graphemes[end]) == 1 && graphemes[end] >= "a" && graphemes[end] <= "z" {
+				end++
+			}
+			switch strings.Join(graphemes[i:end], "") {
+			case "true", "false", "null":
+				highlights = append(highlights, TextHighlight{Start: i, End: end, Style: SpanStyle{Foreground: theme.Accent}})
+			}
+			i = end
+		case strings.Contains("{}[],:", g):
+			highlights = append(highlights, TextHighlight{Start: i, End: i + 1, Style: SpanStyle{Foreground: theme.TextMuted}})
+			i++
+		default:
+			i++
+		}
+	}
+	return highlights
+}
+
+// highlightMarkup colors XML/HTML tags, their attribute values and comments.
+func highlightMarkup(graphemes []string, theme ThemeData) []TextHighlight {
+	var highlights []TextHighlight
+	for i := 0; i < len(graphemes); {
+		if graphemes[i] != "<" {
+			i++
+			continue
+		}
+		if strings.Join(graphemes[i:min(i+4, len(graphemes))], "") == "<!--" {
+			end := i + 4
+			for end < len(graphemes) && strings.Join(graphemes[end-2:end+1], "") != "-->" {
+				end++
+			}
+			end = min(end+1, len(graphemes))
+			highlights = append(highlights, TextHighlight{Start: i, End: end, Style: SpanStyle{Foreground: theme.TextMuted, Italic: true}})
+			i = end
+			continue
+		}
+		end := i + 1
+		var values []TextHighlight
+		for end < len(graphemes) && graphemes[end] != ">" {
+			if graphemes[end] == `"` || graphemes[end] == "'" {
+				close := skipQuoted(graphemes, end)
+				values = append(values, TextHighlight{Start: end, End: close, Style: SpanStyle{Foreground: theme.Success}})
+				end = close
+				continue
+			}
+			end++
+		}
+		end = min(end+1, len(graphemes))
+		highlights = append(highlights, TextHighlight{Start: i, End: end, Style: SpanStyle{Foreground: theme.Primary}})
+		highlights = append(highlights, values...)
+		i = end
+	}
+	return highlights
+}
+
+// skipQuoted returns the index just past the string starting with the
+// quote at start, honoring backslash escapes.
+func skipQuoted(graphemes []string, start int) int {
+	quote := graphemes[start]
+	for i := start + 1; i < len(graphemes); i++ {
+		switch graphemes[i] {
+		case `\`:
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(graphemes)
+}
+
+// ResponseBodyState holds the body shown by a ResponseBodyView.
+type ResponseBodyState struct {
+	ContentType Signal[string]
+	Text        *TextAreaState // Read-only; holds the formatted body
+}
+
+// NewResponseBodyState creates an empty ResponseBodyState.
+func NewResponseBodyState() *ResponseBodyState {
+	text := NewTextAreaState("")
+	text.ReadOnly.Set(true)
+	return &ResponseBodyState{
+		ContentType: NewSignal(""),
+		Text:        text,
+	}
+}
+
+// SetBody formats body for its content type (see FormatBody) and shows it.
+func (s *ResponseBodyState) SetBody(contentType string, body []byte) {
+	s.ContentType.Set(contentType)
+	s.Text.SetText(FormatBody(contentType, body))
+}
+
+// ResponseBodyView is a read-only, scrollable view of an HTTP body that
+// pretty-prints and highlights it according to its content type.
+//
+// Example:
+//
+//	body := terma.NewResponseBodyState()
+//	body.SetBody(resp.Header.Get("Content-Type"), data)
+//	terma.ResponseBodyView{ID: "response-body", State: body}
+type ResponseBodyView struct {
+	ID    string             // Optional unique identifier
+	State *ResponseBodyState // Required - holds the body
+	Style Style              // Optional styling
+}
+
+// WidgetID returns the view's unique identifier.
+func (r ResponseBodyView) WidgetID() string {
+	return r.ID
+}
+
+// GetStyle returns the style.
+func (r ResponseBodyView) GetStyle() Style {
+	return r.Style
+}
+
+// Build returns a read-only TextArea with a content-type highlighter.
+func (r ResponseBodyView) Build(ctx BuildContext) Widget {
+	if r.State == nil {
+		return EmptyWidget{}
+	}
+	style := r.Style
+	if style.Width.IsUnset() {
+		style.Width = Flex(1)
+	}
+	if style.Height.IsUnset() {
+		style.Height = Flex(1)
+	}
+	return TextArea{
+		ID:          r.ID,
+		State:       r.State.Text,
+		Highlighter: BodyHighlighter(r.State.ContentType.Get(), ctx.Theme()),
+		Style:       style,
+	}
+}
+
+// TimingPhase is one phase of a request, such as DNS lookup or
+// time to first byte.
+type TimingPhase struct {
+	Name     string
+	Duration time.Duration
+	Color    Color // Bar color (default: cycles through theme colors)
+}
+
+// TimingWaterfall shows request phases as a waterfall: one row per phase
+// with a bar that starts where the previous phase ended, followed by a
+// total row. Bars are scaled to the total duration.
+//
+// Example:
+//
+//	terma.TimingWaterfall{Phases: []terma.TimingPhase{
+//	    {Name: "DNS", Duration: 12 * time.Millisecond},
+//	    {Name: "Connect", Duration: 30 * time.Millisecond},
+//	    {Name: "TLS", Duration: 45 * time.Millisecond},
+//	    {Name: "Waiting", Duration: 120 * time.Millisecond},
+//	    {Name: "Download", Duration: 8 * time.Millisecond},
+//	}}
+type TimingWaterfall struct {
+	ID         string        // Optional unique identifier
+	Phases     []TimingPhase // Phases in the order they ran
+	LabelWidth int           // Width of the name column (default: longest name + 1)
+	Style      Style         // Optional styling
+}
+
+// WidgetID returns the waterfall's unique identifier.
+func (w TimingWaterfall) WidgetID() string {
+	return w.ID
+}
+
+// GetStyle returns the style.
+func (w TimingWaterfall) GetStyle() Style {
+	return w.Style
+}
+
+// Build returns one row per phase and a total row.
+func (w TimingWaterfall) Build(ctx BuildContext) Widget {
+	theme := ctx.Theme()
+	palette := []Color{theme.Info, theme.Primary, theme.Accent, theme.Warning, theme.Success}
+
+	var total time.Duration
+	labelWidth := w.LabelWidth
+	for _, phase := range w.Phases {
+		total += max(phase.Duration, 0)
+		if w.LabelWidth <= 0 {
+			labelWidth = max(labelWidth, len([]rune(phase.Name))+1)
+		}
+	}
+	labelWidth = max(labelWidth, len("Total")+1)
+
+	row := func(label string, bar Widget, d time.Duration, bold bool) Widget {
+		return Row{
+			Style: Style{Width: Flex(1)},
+			Children: []Widget{
+				Text{Content: label, Truncate: TruncateEnd, Style: Style{Width: Cells(labelWidth), Bold: bold}},
+				bar,
+				Text{Content: formatPhaseDuration(d), TextAlign: TextAlignRight, Style: Style{Width: Cells(8), Bold: bold}},
+			},
+		}
+	}
+
+	var rows []Widget
+	var elapsed time.Duration
+	for i, phase := range w.Phases {
+		d := max(phase.Duration, 0)
+		color := phase.Color
+		if !color.IsSet() {
+			color = palette[i%len(palette)]
+		}
+		bar := waterfallBar{Color: color, TrackColor: theme.Surface}
+		if total > 0 {
+			bar.Start = float64(elapsed) / float64(total)
+			bar.End = float64(elapsed+d) / float64(total)
+		}
+		rows = append(rows, row(phase.Name, bar, d, false))
+		elapsed += d
+	}
+	rows = append(rows, row("Total", Spacer{Width: Flex(1)}, total, true))
+
+	style := w.Style
+	if style.Width.IsUnset() {
+		style.Width = Flex(1)
+	}
+	return Column{Style: style, Children: rows}
+}
+
+// formatPhaseDuration formats d with a precision suited to request timings.
+func formatPhaseDuration(d time.Duration) string {
+	switch {
+	case d < time.Millisecond:
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	case d < 10*time.Millisecond:
+		return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	default:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	}
+}
+
+// waterfallBar is a one-row track with a bar between Start and End,
+// given as fractions of its width. The bar is always at least one cell
+// wide so that short phases stay visible.
+type waterfallBar struct {
+	Start, End float64
+	Color      Color
+	TrackColor Color
+}
+
+// Build returns itself as waterfallBar is a leaf widget.
+func (b waterfallBar) Build(ctx BuildContext) Widget {
+	return b
+}
+
+// GetContentDimensions returns Flex(1) width and a height of one cell.
+func (b waterfallBar) GetContentDimensions() (width, height Dimension) {
+	return Flex(1), Cells(1)
+}
+
+// BuildLayoutNode builds a layout node for the bar.
+func (b waterfallBar) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return &layout.BoxNode{MinHeight: 1, MaxHeight: 1, ExpandWidth: true}
+}
+
+// Render draws the track and the bar.
+func (b waterfallBar) Render(ctx *RenderContext) {
+	if ctx.Width <= 0 || ctx.Height <= 0 {
+		return
+	}
+	ctx.DrawStyledText(0, 0, strings.Repeat(" ", ctx.Width), Style{BackgroundColor: b.TrackColor})
+	start := min(int(b.Start*float64(ctx.Width)), ctx.Width-1)
+	end := min(max(int(b.End*float64(ctx.Width)+0.5), start+1), ctx.Width)
+	ctx.DrawStyledText(start, 0, strings.Repeat(" ", end-start), Style{BackgroundColor: b.Color})
+}
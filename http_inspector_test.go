@@ -0,0 +1,122 @@
+package terma
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyValueEditorState_AddRemoveAndHeader(t *testing.T) {
+	state := NewKeyValueEditorState(KeyValuesFromHeader(http.Header{
+		"Accept":        {"application/json"},
+		"Authorization": {"Bearer token"},
+	}))
+	state.Add(KeyValue{Key: "X-Trace", Value: "1", Disabled: true})
+	state.Add(KeyValue{})
+	require.Len(t, state.keys, 4)
+
+	state.SetRow(0, KeyValue{Key: "Accept", Value: "text/plain"})
+	assert.Equal(t, "text/plain", state.values[0].GetText(), "editors follow SetRow")
+	assert.Equal(t, http.Header{"Accept": {"text/plain"}, "Authorization": {"Bearer token"}}, state.Header(),
+		"disabled and empty rows are left out")
+
+	state.Remove(1)
+	assert.Equal(t, []KeyValue{
+		{Key: "Accept", Value: "text/plain"},
+		{Key: "X-Trace", Value: "1", Disabled: true},
+		{},
+	}, state.GetRows())
+	assert.Equal(t, "X-Trace", state.keys[1].GetText(), "editors move with their rows")
+	assert.False(t, state.enabled[1].IsChecked())
+}
+
+func TestQueryParamsState_SyncsBothWays(t *testing.T) {
+	state := NewQueryParamsState("https://api.test/users?page=2&q=a%20b#top")
+	assert.Equal(t, []KeyValue{{Key: "page", Value: "2"}, {Key: "q", Value: "a b"}}, state.Params.GetRows())
+
+	state.Params.SetRow(0, KeyValue{Key: "page", Value: "2", Disabled: true})
+	state.Params.Add(KeyValue{Key: "sort", Value: "name&id"})
+	state.SyncToURL()
+	assert.Equal(t, "https://api.test/users?q=a+b&sort=name%26id#top", state.GetURL())
+
+	state.SetURL("https://api.test/users?limit=5")
+	assert.Equal(t, []KeyValue{{Key: "limit", Value: "5"}, {Key: "page", Value: "2", Disabled: true}}, state.Params.GetRows(),
+		"disabled parameters survive URL edits")
+}
+
+func TestQueryParamEditor_EditRewritesURL(t *testing.T) {
+	state := NewQueryParamsState("/search?q=go")
+	editor := QueryParamEditor{State: state}.Build(newTestBuildContext()).(KeyValueEditor)
+	row := editor.Build(newTestBuildContext()).(Column).Children[1].(Row)
+
+	state.Params.values[0].SetText("rust")
+	row.Children[2].(TextInput).OnChange("rust")
+	assert.Equal(t, "/search?q=rust", state.GetURL())
+}
+
+func TestFormatBody(t *testing.T) {
+	assert.Equal(t, "{\n  \"a\": [\n    1,\n    true\n  ]\n}", FormatBody("application/json; charset=utf-8", []byte(`{"a":[1,true]}`)))
+	assert.Equal(t, "{\n  \"a\": 1\n}", FormatBody("", []byte(`{"a":1}`)), "JSON is sniffed without a content type")
+	assert.Equal(t, `{"a":`, FormatBody("application/json", []byte(`{"a":`)), "invalid JSON is shown as received")
+	assert.Equal(t, "<a>1</a>", FormatBody("text/html", []byte("<a>1</a>")))
+}
+
+func TestBodyHighlighter_JSON(t *testing.T) {
+	theme := newTestBuildContext().Theme()
+	text := `{"name": "x", "n": -1.5, "ok": null}`
+	graphemes := splitGraphemes(text)
+	highlights := BodyHighlighter("application/vnd.api+json", theme).Highlight(text, graphemes)
+
+	colored := map[string]Color{}
+	for _, h := range highlights {
+		colored[strings.Join(graphemes[h.Start:h.End], "")] = h.Style.Foreground
+	}
+	assert.Equal(t, theme.Primary, colored[`"name"`])
+	assert.Equal(t, theme.Success, colored[`"x"`])
+	assert.Equal(t, theme.Warning, colored["-1.5"])
+	assert.Equal(t, theme.Accent, colored["null"])
+	assert.Nil(t, BodyHighlighter("text/plain", theme))
+}
+
+func TestBodyHighlighter_Markup(t *testing.T) {
+	theme := newTestBuildContext().Theme()
+	text := `<!-- c --><a href="x">y</a>`
+	graphemes := splitGraphemes(text)
+	highlights := BodyHighlighter("application/xml", theme).Highlight(text, graphemes)
+
+	var tokens []string
+	for _, h := range highlights {
+		tokens = append(tokens, strings.Join(graphemes[h.Start:h.End], ""))
+	}
+	assert.Equal(t, []string{"<!-- c -->", `<a href="x">`, `"x"`, "</a>"}, tokens)
+}
+
+func TestTimingWaterfall_Render(t *testing.T) {
+	widget := TimingWaterfall{Phases: []TimingPhase{
+		{Name: "DNS", Duration: 25 * time.Millisecond},
+		{Name: "Wait", Duration: 75 * time.Millisecond},
+	}}
+	lines := strings.Split(screenText(widget, 30, 3), "\n")
+	assert.Equal(t, "DNS                       25ms", lines[0])
+	assert.Equal(t, "Wait                      75ms", lines[1])
+	assert.Equal(t, "Total                    100ms", lines[2])
+
+	buf := RenderToBuffer(widget, 30, 3)
+	theme := newTestBuildContext().Theme()
+	// The 16-cell track sits between the 6-cell labels and 8-cell durations.
+	assert.Equal(t, theme.Info.toANSI(), buf.CellAt(6, 0).Style.Bg)
+	assert.Equal(t, theme.Surface.toANSI(), buf.CellAt(10, 0).Style.Bg)
+	assert.Equal(t, theme.Surface.toANSI(), buf.CellAt(9, 1).Style.Bg)
+	assert.Equal(t, theme.Primary.toANSI(), buf.CellAt(10, 1).Style.Bg)
+}
+
+func TestFormatPhaseDuration(t *testing.T) {
+	assert.Equal(t, "850µs", formatPhaseDuration(850*time.Microsecond))
+	assert.Equal(t, "2.5ms", formatPhaseDuration(2500*time.Microsecond))
+	assert.Equal(t, "120ms", formatPhaseDuration(120*time.Millisecond))
+	assert.Equal(t, "1.25s", formatPhaseDuration(1250*time.Millisecond))
+}
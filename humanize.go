@@ -0,0 +1,246 @@
+package terma
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// builtinMessages holds terma's own translatable strings. Apps can override
+// any of them through SetMessageCatalog.
+var builtinMessages = MapCatalog{
+	"en": relativeTimeMessages("just now", "%s ago", "in %s", [7][2]string{
+		{"%d second", "%d seconds"},
+		{"%d minute", "%d minutes"},
+		{"%d hour", "%d hours"},
+		{"%d day", "%d days"},
+		{"%d week", "%d weeks"},
+		{"%d month", "%d months"},
+		{"%d year", "%d years"},
+	}),
+	"de": relativeTimeMessages("gerade eben", "vor %s", "in %s", [7][2]string{
+		{"%d Sekunde", "%d Sekunden"},
+		{"%d Minute", "%d Minuten"},
+		{"%d Stunde", "%d Stunden"},
+		{"%d Tag", "%d Tagen"},
+		{"%d Woche", "%d Wochen"},
+		{"%d Monat", "%d Monaten"},
+		{"%d Jahr", "%d Jahren"},
+	}),
+	"fr": relativeTimeMessages("à l'instant", "il y a %s", "dans %s", [7][2]string{
+		{"%d seconde", "%d secondes"},
+		{"%d minute", "%d minutes"},
+		{"%d heure", "%d heures"},
+		{"%d jour", "%d jours"},
+		{"%d semaine", "%d semaines"},
+		{"%d mois", "%d mois"},
+		{"%d an", "%d ans"},
+	}),
+	"es": relativeTimeMessages("ahora mismo", "hace %s", "en %s", [7][2]string{
+		{"%d segundo", "%d segundos"},
+		{"%d minuto", "%d minutos"},
+		{"%d hora", "%d horas"},
+		{"%d día", "%d días"},
+		{"%d semana", "%d semanas"},
+		{"%d mes", "%d meses"},
+		{"%d año", "%d años"},
+	}),
+}
+
+// relativeTimeUnits are the units used by FormatRelativeTime, smallest first.
+var relativeTimeUnits = [7]string{"seconds", "minutes", "hours", "days", "weeks", "months", "years"}
+
+// relativeTimeMessages builds the catalog entries for one language.
+// Keys have the form "relative.<unit>.ago.<category>" and "relative.<unit>.in.<category>".
+func relativeTimeMessages(now, ago, in string, units [7][2]string) map[string]string {
+	messages := map[string]string{"relative.now": now}
+	for i, unit := range relativeTimeUnits {
+		one, other := units[i][0], units[i][1]
+		messages["relative."+unit+".ago.one"] = strings.Replace(ago, "%s", one, 1)
+		messages["relative."+unit+".ago.other"] = strings.Replace(ago, "%s", other, 1)
+		messages["relative."+unit+".in.one"] = strings.Replace(in, "%s", one, 1)
+		messages["relative."+unit+".in.other"] = strings.Replace(in, "%s", other, 1)
+	}
+	return messages
+}
+
+// FormatRelativeTime describes t relative to now in the current locale,
+// e.g. "just now", "3 minutes ago" or "in 2 days".
+func FormatRelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	direction := "ago"
+	if d < 0 {
+		d = -d
+		direction = "in"
+	}
+	if d < 10*time.Second {
+		return Tr("relative.now")
+	}
+
+	const day = 24 * time.Hour
+	var unit string
+	var n int
+	switch {
+	case d < time.Minute:
+		unit, n = "seconds", int(d/time.Second)
+	case d < time.Hour:
+		unit, n = "minutes", int(d/time.Minute)
+	case d < day:
+		unit, n = "hours", int(d/time.Hour)
+	case d < 7*day:
+		unit, n = "days", int(d/day)
+	case d < 30*day:
+		unit, n = "weeks", int(d/(7*day))
+	case d < 365*day:
+		unit, n = "months", int(d/(30*day))
+	default:
+		unit, n = "years", int(d/(365*day))
+	}
+	return TrN("relative."+unit+"."+direction, n)
+}
+
+// FormatBytes formats a byte count with binary units, e.g. "512 B",
+// "1.5 KB" or "23 MB". Values under 10 keep one decimal.
+func FormatBytes(n int64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB", "PB", "EB"}
+	return humanizeScaled(n, 1024, units, " ", 10)
+}
+
+// FormatCompact formats a count in compact form, e.g. "950", "1.2k",
+// "45.3k" or "7M". Values under 100 keep one decimal.
+func FormatCompact(n int64) string {
+	units := []string{"", "k", "M", "B", "T", "Q", "Qi"}
+	return humanizeScaled(n, 1000, units, "", 100)
+}
+
+// humanizeScaled divides n by base until it fits below base, keeping one
+// decimal for scaled values below decimalBelow, and formats the number with
+// the current locale's separators.
+func humanizeScaled(n int64, base float64, units []string, sep string, decimalBelow float64) string {
+	value := float64(n)
+	negative := value < 0
+	value = math.Abs(value)
+
+	unit := 0
+	for value >= base && unit < len(units)-1 {
+		value /= base
+		unit++
+	}
+
+	decimals := func(v float64) int {
+		if unit > 0 && v < decimalBelow {
+			return 1
+		}
+		return 0
+	}
+	// Rounding can carry into the next unit (999.96k rounds to 1000k).
+	scale := math.Pow(10, float64(decimals(value)))
+	if math.Round(value*scale)/scale >= base && unit < len(units)-1 {
+		value /= base
+		unit++
+	}
+
+	locale := CurrentLocale()
+	formatted := locale.FormatNumber(value, decimals(value))
+	formatted = strings.TrimSuffix(formatted, locale.DecimalSeparator+"0")
+	if negative {
+		formatted = "-" + formatted
+	}
+	if units[unit] == "" {
+		return formatted
+	}
+	return formatted + sep + units[unit]
+}
+
+var (
+	clockMu sync.Mutex
+	clocks  = map[time.Duration]clockTicker{}
+)
+
+// clockTicker is a shared signal updated by a Clock.Every timer.
+type clockTicker struct {
+	signal Signal[int64]
+	timer  Timer
+}
+
+// clockSignal returns a signal that changes every interval. The timer
+// behind each interval is started on first use and shared by all callers.
+func clockSignal(interval time.Duration) Signal[int64] {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+
+	if ticker, ok := clocks[interval]; ok {
+		return ticker.signal
+	}
+	clock := currentClock()
+	signal := NewSignal(clock.Now().UnixNano())
+	timer := clock.Every(interval, func(tick time.Time) {
+		signal.Set(tick.UnixNano())
+	})
+	clocks[interval] = clockTicker{signal: signal, timer: timer}
+	return signal
+}
+
+// resetClockSignals stops the shared clock signals so they are recreated
+// from the newly installed Clock.
+func resetClockSignals() {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	for interval, ticker := range clocks {
+		ticker.timer.Stop()
+		delete(clocks, interval)
+	}
+}
+
+// RelativeTime displays how long ago (or how far ahead) Time is, such as
+// "3 minutes ago", and refreshes automatically as time passes. Text follows
+// the current locale.
+//
+// Example:
+//
+//	RelativeTime{Time: message.SentAt, Style: Style{ForegroundColor: theme.TextMuted}}
+type RelativeTime struct {
+	ID    string           // Optional unique identifier
+	Time  time.Time        // The moment to describe
+	Now   func() time.Time // Optional clock (default = terma.Now)
+	Style Style            // Optional styling
+}
+
+// WidgetID returns the widget's unique identifier.
+func (r RelativeTime) WidgetID() string {
+	return r.ID
+}
+
+// GetStyle returns the style.
+func (r RelativeTime) GetStyle() Style {
+	return r.Style
+}
+
+// Build returns a Text widget describing Time relative to now.
+func (r RelativeTime) Build(ctx BuildContext) Widget {
+	now := Now
+	if r.Now != nil {
+		now = r.Now
+	}
+	current := now()
+
+	// Subscribe to a clock that ticks often enough for the displayed unit.
+	age := current.Sub(r.Time)
+	if age < 0 {
+		age = -age
+	}
+	interval := time.Minute
+	switch {
+	case age < time.Minute:
+		interval = time.Second
+	case age < time.Hour:
+		interval = 10 * time.Second
+	}
+	clockSignal(interval).Get()
+
+	return Text{
+		Content: FormatRelativeTime(r.Time, current),
+		Style:   r.Style,
+	}
+}
@@ -0,0 +1,103 @@
+package terma
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatRelativeTime(t *testing.T) {
+	withLocale(t, "en", nil)
+	now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{3 * time.Second, "just now"},
+		{45 * time.Second, "45 seconds ago"},
+		{time.Minute, "1 minute ago"},
+		{3*time.Minute + 59*time.Second, "3 minutes ago"},
+		{5 * time.Hour, "5 hours ago"},
+		{2 * 24 * time.Hour, "2 days ago"},
+		{15 * 24 * time.Hour, "2 weeks ago"},
+		{95 * 24 * time.Hour, "3 months ago"},
+		{800 * 24 * time.Hour, "2 years ago"},
+		{-2 * time.Hour, "in 2 hours"},
+		{-24 * time.Hour, "in 1 day"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FormatRelativeTime(now.Add(-tt.ago), now), tt.ago.String())
+	}
+}
+
+func TestFormatRelativeTime_Localized(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	withLocale(t, "de", nil)
+	assert.Equal(t, "vor 3 Tagen", FormatRelativeTime(now.Add(-72*time.Hour), now))
+	assert.Equal(t, "in 1 Stunde", FormatRelativeTime(now.Add(time.Hour), now))
+
+	withLocale(t, "fr", nil)
+	assert.Equal(t, "il y a 1 minute", FormatRelativeTime(now.Add(-time.Minute), now))
+
+	withLocale(t, "en", MapCatalog{"en": {"relative.now": "now"}})
+	assert.Equal(t, "now", FormatRelativeTime(now, now), "apps can override built-in messages")
+}
+
+func TestFormatBytes(t *testing.T) {
+	withLocale(t, "en", nil)
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1023, "1,023 B"},
+		{1024, "1 KB"},
+		{1536, "1.5 KB"},
+		{20 * 1024 * 1024, "20 MB"},
+		{1024*1024 - 1, "1 MB"},
+		{5 * 1024 * 1024 * 1024 * 1024, "5 TB"},
+		{-2048, "-2 KB"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FormatBytes(tt.n))
+	}
+
+	withLocale(t, "de", nil)
+	assert.Equal(t, "1,5 KB", FormatBytes(1536))
+}
+
+func TestFormatCompact(t *testing.T) {
+	withLocale(t, "en", nil)
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{950, "950"},
+		{1000, "1k"},
+		{1234, "1.2k"},
+		{45_300, "45.3k"},
+		{123_456, "123k"},
+		{999_960, "1M"},
+		{7_000_000, "7M"},
+		{2_500_000_000, "2.5B"},
+		{-1500, "-1.5k"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FormatCompact(tt.n))
+	}
+}
+
+func TestRelativeTime_Renders(t *testing.T) {
+	withLocale(t, "en", nil)
+	now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	widget := RelativeTime{
+		Time: now.Add(-90 * time.Minute),
+		Now:  func() time.Time { return now },
+	}
+	buf := RenderToBuffer(widget, 12, 1)
+	assert.Equal(t, "1 hour ago  ", bufferLine(buf, 0, 12))
+}
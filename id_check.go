@@ -0,0 +1,88 @@
+package terma
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+var idCollisionCheckEnabled atomic.Bool
+
+// EnableIDCollisionCheck turns on detection of widgets that share an ID
+// within a frame. Duplicate IDs break focus, hover and float anchoring, so
+// each collision is logged once with the build sites of both widgets.
+// Apps enable this automatically when TERMA_DEBUG_IDS or
+// TERMA_DEBUG_OVERLAY is set.
+func EnableIDCollisionCheck() {
+	idCollisionCheckEnabled.Store(true)
+}
+
+// IDCollision describes two widgets that claimed the same ID in one frame.
+// First and Second describe where each widget sits in the tree,
+// e.g. "Column > Row > Button (path 0.1.2)".
+type IDCollision struct {
+	ID     string
+	First  string
+	Second string
+}
+
+// String formats the collision for logging.
+func (c IDCollision) String() string {
+	return fmt.Sprintf("duplicate widget ID %q\n  first:  %s\n  second: %s", c.ID, c.First, c.Second)
+}
+
+// idTracker records the explicit IDs claimed while building one frame.
+type idTracker struct {
+	sites      map[string]string
+	collisions []IDCollision
+	// ancestors holds the type names of the widgets currently being built.
+	ancestors []string
+}
+
+func newIDTracker() *idTracker {
+	return &idTracker{sites: map[string]string{}}
+}
+
+// claim records that widget uses id, noting a collision if the ID was
+// already taken earlier in the frame.
+func (t *idTracker) claim(id string, widget Widget, ctx BuildContext) {
+	site := t.site(widget, ctx)
+	if first, ok := t.sites[id]; ok {
+		// The same widget can be built more than once per frame (floats
+		// registered from a layout pass), which isn't a collision.
+		if first == site {
+			return
+		}
+		t.collisions = append(t.collisions, IDCollision{ID: id, First: first, Second: site})
+		return
+	}
+	t.sites[id] = site
+}
+
+// site describes where widget is being built.
+func (t *idTracker) site(widget Widget, ctx BuildContext) string {
+	chain := append(append([]string{}, t.ancestors...), widgetTypeName(widget))
+	return fmt.Sprintf("%s (path %s)", strings.Join(chain, " > "), ctx.pathString())
+}
+
+func (t *idTracker) push(widget Widget) {
+	t.ancestors = append(t.ancestors, widgetTypeName(widget))
+}
+
+func (t *idTracker) pop() {
+	t.ancestors = t.ancestors[:len(t.ancestors)-1]
+}
+
+// widgetTypeName returns the widget's type without its package,
+// e.g. "Button" or "List[string]".
+func widgetTypeName(widget Widget) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", widget), "*")
+	head, params, generic := strings.Cut(name, "[")
+	if i := strings.LastIndex(head, "."); i >= 0 {
+		head = head[i+1:]
+	}
+	if generic {
+		return head + "[" + params
+	}
+	return head
+}
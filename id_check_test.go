@@ -0,0 +1,94 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renderIDCollisions renders widget once with the ID check enabled and
+// returns the collisions found.
+func renderIDCollisions(t *testing.T, widget Widget) []IDCollision {
+	t.Helper()
+	idCollisionCheckEnabled.Store(true)
+	t.Cleanup(func() { idCollisionCheckEnabled.Store(false) })
+
+	renderer := NewRenderer(uv.NewBuffer(40, 10), 40, 10, NewFocusManager(), NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil))
+	renderer.Render(widget)
+	return renderer.IDCollisions()
+}
+
+func TestIDCollision_ReportsBothBuildSites(t *testing.T) {
+	collisions := renderIDCollisions(t, Column{Children: []Widget{
+		Text{ID: "title", Content: "one"},
+		Row{Children: []Widget{
+			Button{ID: "title", Label: "two"},
+		}},
+	}})
+
+	require.Len(t, collisions, 1)
+	assert.Equal(t, "title", collisions[0].ID)
+	assert.Equal(t, "Column > Text (path 0.0)", collisions[0].First)
+	assert.Equal(t, "Column > Row > Button (path 0.1.0)", collisions[0].Second)
+}
+
+func TestIDCollision_NoneForUniqueIDs(t *testing.T) {
+	collisions := renderIDCollisions(t, Column{Children: []Widget{
+		Text{ID: "a", Content: "one"},
+		Text{ID: "b", Content: "two"},
+		Text{Content: "no id"},
+		Text{Content: "no id"},
+	}})
+	assert.Empty(t, collisions)
+}
+
+func TestIDCollision_DisabledByDefault(t *testing.T) {
+	renderer := NewRenderer(uv.NewBuffer(10, 2), 10, 2, NewFocusManager(), NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil))
+	renderer.Render(Column{Children: []Widget{Text{ID: "x"}, Text{ID: "x"}}})
+	assert.Nil(t, renderer.IDCollisions())
+}
+
+// scopedRows builds a row of buttons whose IDs come from ScopedID.
+type scopedRows struct {
+	ID string
+}
+
+func (s scopedRows) WidgetID() string { return s.ID }
+
+func (s scopedRows) Build(ctx BuildContext) Widget {
+	var children []Widget
+	for i := range 2 {
+		children = append(children, Button{ID: ctx.ScopedID("row", i), Label: "x"})
+	}
+	return Column{Children: children}
+}
+
+func TestScopedID_UniqueAcrossInstances(t *testing.T) {
+	var ids []string
+	collect := func(widget Widget) {
+		renderer := NewRenderer(uv.NewBuffer(40, 10), 40, 10, NewFocusManager(), NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil))
+		renderer.Render(widget)
+		ids = ids[:0]
+		for _, entry := range renderer.widgetRegistry.Entries() {
+			if _, ok := entry.EventWidget.(Button); ok {
+				ids = append(ids, entry.ID)
+			}
+		}
+	}
+
+	collect(Row{Children: []Widget{scopedRows{ID: "left"}, scopedRows{ID: "right"}}})
+	assert.Equal(t, []string{"left-row-0", "left-row-1", "right-row-0", "right-row-1"}, ids)
+
+	collect(Row{Children: []Widget{scopedRows{}, scopedRows{}}})
+	assert.Equal(t, []string{"_auto:0.0-row-0", "_auto:0.0-row-1", "_auto:0.1-row-0", "_auto:0.1-row-1"}, ids)
+
+	assert.Empty(t, renderIDCollisions(t, Row{Children: []Widget{scopedRows{}, scopedRows{}}}))
+}
+
+func TestWidgetTypeName(t *testing.T) {
+	assert.Equal(t, "Button", widgetTypeName(Button{}))
+	assert.Equal(t, "Button", widgetTypeName(&Button{}))
+	assert.Equal(t, "List[string]", widgetTypeName(List[string]{}))
+}
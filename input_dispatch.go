@@ -0,0 +1,222 @@
+package terma
+
+import uv "github.com/charmbracelet/ultraviolet"
+
+// inputDispatcher routes key presses and mouse presses and releases to the
+// widgets on screen. Run's event loop and Replay share it, so replayed
+// input takes the same paths as live input. Keys and presses for the app
+// itself (quitting, suspending, copy mode and the ruler overlay) are taken
+// by Run, though hint mode and keyboard drags see keys first.
+type inputDispatcher struct {
+	renderer     *Renderer
+	focusManager *FocusManager
+	rootHandler  KeyHandler
+	rootKeybinds KeybindProvider
+
+	hints  *hintMode
+	drags  *dragTracker
+	clicks *mouseClickTracker
+	drag   *mouseDragState // Widget that took the last press, for motion
+	hover  *hoverTracker
+}
+
+// newInputDispatcher creates a dispatcher for root's widgets, as rendered
+// by renderer.
+func newInputDispatcher(renderer *Renderer, focusManager *FocusManager, root Widget) *inputDispatcher {
+	d := &inputDispatcher{
+		renderer:     renderer,
+		focusManager: focusManager,
+		hints:        &hintMode{},
+		drags:        &dragTracker{},
+		clicks:       &mouseClickTracker{},
+		drag:         &mouseDragState{},
+		hover:        &hoverTracker{},
+	}
+	// The root's key handling is used when nothing has focus
+	d.rootHandler, _ = root.(KeyHandler)
+	d.rootKeybinds, _ = root.(KeybindProvider)
+	return d
+}
+
+// captureKey handles a key press if hint mode or a keyboard drag takes it,
+// reporting whether one did. Run calls it ahead of its own keys, such as
+// the ruler toggle, and key after them.
+func (d *inputDispatcher) captureKey(event KeyEvent) bool {
+	// Hint mode takes every key while it is on
+	if target, ok := d.hints.handleKey(event); ok {
+		if target != nil {
+			activateHint(d.focusManager, target)
+		}
+		return true
+	}
+	if event.MatchString("ctrl+shift+j") {
+		ShowHints()
+		return true
+	}
+
+	// Escape cancels a drag; keyboard drags take every key
+	return d.drags.handleKey(event, d.renderer.widgetRegistry.Entries())
+}
+
+// key routes a key press that captureKey didn't take to the widgets.
+func (d *inputDispatcher) key(event KeyEvent) {
+	// An input scope takes keys before floats and focus
+	if dispatchInputScope(event) {
+		return
+	}
+
+	// Check for Escape to dismiss floats
+	if event.MatchString("escape") {
+		if topFloat := d.renderer.TopFloat(); topFloat != nil {
+			if topFloat.Config.shouldDismissOnEsc() && topFloat.Config.OnDismiss != nil {
+				topFloat.Config.OnDismiss()
+				return
+			}
+		}
+	}
+
+	// Enter on a text field opens the on-screen keyboard
+	// (InputProfileKiosk)
+	if openKioskKeyboard(d.focusManager, event) {
+		return
+	}
+
+	// Route the key through the focus manager (bubbles through widget tree)
+	handled := d.focusManager.HandleKey(event)
+
+	// If not handled, try root's keybindings and handler directly
+	// (handles case when there are no focusable widgets)
+	if !handled {
+		if d.rootKeybinds != nil {
+			handled = matchKeybind(event, d.rootKeybinds.Keybinds())
+		}
+		if !handled && d.rootHandler != nil {
+			handled = d.rootHandler.OnKey(event)
+		}
+	}
+
+	// Arrow keys nothing took move focus spatially
+	// (InputProfileKiosk)
+	if !handled {
+		handled = dispatchSpatialFocus(d.focusManager, d.renderer.widgetRegistry.Entries(), event)
+	}
+
+	// Scroll keys nothing took can scroll the region under the
+	// pointer (ScrollFocusFollowsMouse)
+	if !handled && d.hover.pointerKnown {
+		dispatchScrollKey(d.renderer, d.hover.pointerX, d.hover.pointerY, event)
+	}
+}
+
+// press routes a mouse press: it dismisses the top float when outside it,
+// focuses the focusable widget under the pointer, and calls OnMouseDown
+// and OnClick on the widget pressed.
+func (d *inputDispatcher) press(mouse uv.Mouse, pixels mousePixels) {
+	Log("MouseClickEvent at X=%d Y=%d Button=%v", mouse.X, mouse.Y, mouse.Button)
+	d.hints.exit()
+
+	entry, handled := mouseTarget(d.renderer, mouse.X, mouse.Y, true)
+	if handled {
+		Log("  Mouse click handled by float logic")
+		return
+	}
+	if mouse.Button == uv.MouseLeft {
+		d.drags.handlePress(d.renderer.widgetRegistry.Entries(), mouse.X, mouse.Y)
+	}
+	if entry == nil {
+		Log("  No widget found at position")
+		LogWidgetRegistry(d.renderer.widgetRegistry)
+		return
+	}
+
+	Log("  Found widget: ID=%q Type=%T", entry.ID, entry.EventWidget)
+	// The clicked widget (for OnClick) may be different from the focusable
+	// widget (e.g., clicking Text inside a List)
+	focusEntry := d.renderer.FocusableAt(mouse.X, mouse.Y)
+	if focusEntry != nil {
+		d.focusManager.FocusByID(focusEntry.ID)
+	}
+	clickCount := d.clicks.nextClick(entry.ID, mouse.Button, mouse.X, mouse.Y, Now())
+	mouseEvent := buildMouseEvent(mouse, entry, clickCount)
+	mouseEvent.Link = d.renderer.LinkAt(mouse.X, mouse.Y)
+	pixels.apply(&mouseEvent)
+
+	// Set drag state for mouse move tracking
+	d.drag.isDragging = true
+	d.drag.dragWidgetID = entry.ID
+	d.drag.pressedButton = mouse.Button
+
+	if downHandler, ok := entry.EventWidget.(MouseDownHandler); ok {
+		Log("  Widget has OnMouseDown")
+		downHandler.OnMouseDown(mouseEvent)
+	}
+
+	// Also notify the focused widget when a non-focusable child was clicked.
+	// This lets focusable widgets (Tree/TextInput/TextArea, etc.) handle cursor placement.
+	if focusEntry != nil && focusEntry != entry {
+		focusMouseEvent := buildMouseEvent(mouse, focusEntry, clickCount)
+		pixels.apply(&focusMouseEvent)
+		if downHandler, ok := focusEntry.EventWidget.(MouseDownHandler); ok {
+			Log("  Focused widget has OnMouseDown")
+			downHandler.OnMouseDown(focusMouseEvent)
+		}
+	}
+
+	if clickable, ok := entry.EventWidget.(Clickable); ok {
+		Log("  Widget is Clickable, calling OnClick")
+		clickable.OnClick(mouseEvent)
+	} else {
+		Log("  Widget is NOT Clickable")
+	}
+}
+
+// release routes a mouse release: it ends the press, drops a Draggable
+// being dragged, and calls OnMouseUp on the widget under the pointer.
+func (d *inputDispatcher) release(mouse uv.Mouse, pixels mousePixels) {
+	Log("MouseReleaseEvent at X=%d Y=%d Button=%v", mouse.X, mouse.Y, mouse.Button)
+
+	// Clear drag state
+	d.drag.isDragging = false
+	d.drag.dragWidgetID = ""
+	d.drag.pressedButton = uv.MouseNone
+
+	// Releasing a Draggable drops it instead
+	if d.drags.handleRelease(d.renderer.widgetRegistry.Entries(), mouse.X, mouse.Y) {
+		return
+	}
+
+	entry, handled := mouseTarget(d.renderer, mouse.X, mouse.Y, false)
+	if handled {
+		Log("  Mouse release blocked by float logic")
+		return
+	}
+	if entry == nil {
+		Log("  No widget found at position")
+		return
+	}
+
+	Log("  Found widget: ID=%q Type=%T", entry.ID, entry.EventWidget)
+	clickCount := d.clicks.releaseCount(entry.ID, mouse.Button)
+	mouseEvent := buildMouseEvent(mouse, entry, clickCount)
+	pixels.apply(&mouseEvent)
+
+	if upHandler, ok := entry.EventWidget.(MouseUpHandler); ok {
+		Log("  Widget has OnMouseUp")
+		upHandler.OnMouseUp(mouseEvent)
+	}
+}
+
+// wheel routes a mouse wheel event to the scrollable under the pointer.
+func (d *inputDispatcher) wheel(mouse uv.Mouse) {
+	dispatchMouseWheel(d.renderer, mouse.X, mouse.Y, mouse.Button)
+}
+
+// showRequestedHints enters hint mode if ShowHints was called since the
+// last frame.
+func (d *inputDispatcher) showRequestedHints(width, height int) bool {
+	if !hintModeRequested.Swap(false) {
+		return false
+	}
+	d.hints.enter(d.focusManager, d.renderer.widgetRegistry, width, height)
+	return true
+}
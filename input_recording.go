@@ -0,0 +1,207 @@
+package terma
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// InputRecord is one entry of an input recording: an input event, or a
+// signal change noted for context.
+type InputRecord struct {
+	At     time.Duration `json:"at"`               // Time since recording started
+	Kind   string        `json:"kind"`             // "key", "click", "release", "wheel", "resize" or "signal"
+	Key    string        `json:"key,omitempty"`    // Key as a keybind string, such as "ctrl+a", for reading
+	Code   rune          `json:"code,omitempty"`   // Key code
+	Mod    int           `json:"mod,omitempty"`    // Key or mouse modifiers
+	Text   string        `json:"text,omitempty"`   // Text a key types, or a signal's new value
+	X      int           `json:"x,omitempty"`      // Mouse position
+	Y      int           `json:"y,omitempty"`      // Mouse position
+	Button int           `json:"button,omitempty"` // Mouse button
+	Width  int           `json:"width,omitempty"`  // New terminal size
+	Height int           `json:"height,omitempty"` // New terminal size
+	Source string        `json:"source,omitempty"` // Where a signal was set, as file:line
+}
+
+// EnableInputRecording keeps the last size input events and signal changes
+// in memory, to reproduce bugs with: DumpInputRecording writes them on
+// demand, crash reports include them (see EnableCrashReports), and Replay
+// feeds them back into an app headlessly. Mouse motion isn't recorded.
+// Setting TERMA_INPUT_RECORDING to a size has the same effect. Pass 0 to
+// stop recording; Run still keeps the last few input events for crash
+// reports.
+func EnableInputRecording(size int) {
+	if size <= 0 {
+		inputEvents.Store(newInputHistory(crashReportInputEvents))
+		return
+	}
+	history := newInputHistory(size)
+	history.recording = true
+	inputEvents.Store(history)
+}
+
+// enableInputRecordingFromEnv starts recording when TERMA_INPUT_RECORDING
+// is set and recording isn't already on.
+func enableInputRecordingFromEnv() {
+	if history := inputEvents.Load(); history != nil && history.recording {
+		return
+	}
+	if size, err := strconv.Atoi(os.Getenv("TERMA_INPUT_RECORDING")); err == nil {
+		EnableInputRecording(size)
+	}
+}
+
+// InputRecording returns the records kept since EnableInputRecording,
+// oldest first, or nil when recording is off.
+func InputRecording() []InputRecord {
+	history := inputEvents.Load()
+	if history == nil || !history.recording {
+		return nil
+	}
+	return history.recent()
+}
+
+// DumpInputRecording writes the recording to w as JSON, one record per
+// line, for LoadInputRecording. Bind it to a key to save a recording when
+// something goes wrong.
+func DumpInputRecording(w io.Writer) error {
+	return writeInputRecords(w, InputRecording())
+}
+
+func writeInputRecords(w io.Writer, records []InputRecord) error {
+	encoder := json.NewEncoder(w)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadInputRecording reads a recording written by DumpInputRecording or a
+// crash report.
+func LoadInputRecording(r io.Reader) ([]InputRecord, error) {
+	var records []InputRecord
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record InputRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// recordInput adds a terminal event to the input history. Run calls it for
+// the events it passes on to widgets.
+func recordInput(event uv.Event) {
+	history := inputEvents.Load()
+	if history == nil {
+		return
+	}
+	switch ev := event.(type) {
+	case uv.KeyPressEvent:
+		history.record(InputRecord{Kind: "key", Key: ev.String(), Code: ev.Code, Mod: int(ev.Mod), Text: ev.Text})
+	case uv.MouseClickEvent:
+		history.record(InputRecord{Kind: "click", X: ev.X, Y: ev.Y, Button: int(ev.Button), Mod: int(ev.Mod)})
+	case uv.MouseReleaseEvent:
+		history.record(InputRecord{Kind: "release", X: ev.X, Y: ev.Y, Button: int(ev.Button), Mod: int(ev.Mod)})
+	case uv.MouseWheelEvent:
+		history.record(InputRecord{Kind: "wheel", X: ev.X, Y: ev.Y, Button: int(ev.Button), Mod: int(ev.Mod)})
+	case uv.WindowSizeEvent:
+		history.record(InputRecord{Kind: "resize", Width: ev.Width, Height: ev.Height})
+	}
+}
+
+// recordSignalChange adds a signal's new value to the recording, if it is
+// on, with the caller skip frames up as its source.
+func recordSignalChange(value any, skip int) {
+	history := inputEvents.Load()
+	if history == nil || !history.recording {
+		return
+	}
+	source := ""
+	if _, file, line, ok := runtime.Caller(skip + 1); ok {
+		source = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+	}
+	history.record(InputRecord{Kind: "signal", Text: fmt.Sprintf("%T %v", value, value), Source: source})
+}
+
+// Replay renders root headlessly at width×height, feeds it the input
+// events of a recording in order and returns the final frame. Use it in a
+// test to reproduce a recorded bug; a panic the recording led to happens
+// again inside Replay. Signal records are skipped, as the events cause
+// them again.
+//
+// Keys and mouse events are routed to widgets as they are in Run, with
+// hint mode, floats, input scopes and layers taking them first. Input Run
+// takes for the app itself, such as keys and presses in copy mode, isn't
+// recorded, and the app's own keys, such as Ctrl+C, aren't replayed.
+//
+// Example:
+//
+//	f, _ := os.Open("testdata/issue-42.jsonl")
+//	records, err := t.LoadInputRecording(f)
+//	require.NoError(tt, err)
+//	buf := t.Replay(NewApp(), records, 80, 24)
+func Replay(root Widget, records []InputRecord, width, height int) *uv.Buffer {
+	buf := uv.NewBuffer(width, height)
+	focusManager := NewFocusManager()
+	focusManager.SetRootWidget(root)
+	focusedSignal := NewAnySignal[Focusable](nil)
+	hoveredSignal := NewAnySignal[Widget](nil)
+	renderer := NewRenderer(buf, width, height, focusManager, focusedSignal, hoveredSignal)
+	input := newInputDispatcher(renderer, focusManager, root)
+
+	render := func() {
+		focusManager.SetFocusables(renderer.Render(root))
+		if pendingFocusID != "" {
+			focusManager.FocusByID(pendingFocusID)
+			pendingFocusID = ""
+		}
+		focusedSignal.Set(focusManager.Focused())
+		renderer.Render(root)
+		input.showRequestedHints(width, height)
+		input.hints.draw(buf, width, height)
+	}
+	render()
+
+	for _, record := range records {
+		mouse := uv.Mouse{X: record.X, Y: record.Y, Button: uv.MouseButton(record.Button), Mod: uv.KeyMod(record.Mod)}
+		switch record.Kind {
+		case "key":
+			event := KeyEvent{event: uv.KeyPressEvent(uv.Key{Code: record.Code, Mod: uv.KeyMod(record.Mod), Text: record.Text})}
+			if !input.captureKey(event) && !isAppKey(event) {
+				input.key(event)
+			}
+		case "click":
+			input.press(mouse, mousePixels{})
+		case "release":
+			input.release(mouse, mousePixels{})
+		case "wheel":
+			input.wheel(mouse)
+		case "resize":
+			width, height = record.Width, record.Height
+			buf.Resize(width, height)
+			renderer.Resize(width, height)
+			input.hints.exit()
+		default:
+			continue
+		}
+		render()
+	}
+	return buf
+}
@@ -0,0 +1,153 @@
+package terma
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputRecording_KeepsMostRecentAndRoundTrips(t *testing.T) {
+	EnableInputRecording(3)
+	t.Cleanup(func() { EnableInputRecording(0) })
+
+	recordInput(uv.KeyPressEvent{Code: 'a', Text: "a"})
+	recordInput(uv.MouseMotionEvent{X: 1, Y: 1})
+	recordInput(uv.KeyPressEvent{Code: 'b', Mod: uv.ModCtrl})
+	recordInput(uv.MouseClickEvent{X: 4, Y: 2, Button: uv.MouseLeft})
+	NewSignal(0).Set(7)
+
+	records := InputRecording()
+	require.Len(t, records, 3)
+	assert.Equal(t, "ctrl+b", records[0].Key)
+	assert.Equal(t, InputRecord{At: records[1].At, Kind: "click", X: 4, Y: 2, Button: int(uv.MouseLeft)}, records[1])
+	assert.Equal(t, "signal", records[2].Kind)
+	assert.Equal(t, "int 7", records[2].Text)
+	assert.Contains(t, records[2].Source, "input_recording_test.go:")
+
+	var buf bytes.Buffer
+	require.NoError(t, DumpInputRecording(&buf))
+	loaded, err := LoadInputRecording(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, records, loaded)
+
+	EnableInputRecording(0)
+	recordInput(uv.KeyPressEvent{Code: 'c', Text: "c"})
+	NewSignal(0).Set(8)
+	assert.Nil(t, InputRecording())
+	assert.Equal(t, []string{"c"}, recordedKeys(inputEvents.Load().recent()), "the crash report history keeps input but not signals")
+}
+
+func recordedKeys(records []InputRecord) []string {
+	var keys []string
+	for _, record := range records {
+		keys = append(keys, record.Key)
+	}
+	return keys
+}
+
+type replayTestRoot struct {
+	count   Signal[int]
+	pressed *[]string
+}
+
+func (r replayTestRoot) Keybinds() []Keybind {
+	return []Keybind{{Key: "+", Name: "Add", Action: func() { r.count.Update(func(n int) int { return n + 1 }) }}}
+}
+
+func (r replayTestRoot) Build(ctx BuildContext) Widget {
+	press := func(label string) func() {
+		return func() { *r.pressed = append(*r.pressed, label) }
+	}
+	return Column{Children: []Widget{
+		Button{ID: "a", Label: "A", OnPress: press("A")},
+		Button{ID: "b", Label: "B", OnPress: press("B")},
+		Text{Content: fmt.Sprintf("count %d", r.count.Get())},
+	}}
+}
+
+func TestReplay_FeedsRecordedInput(t *testing.T) {
+	EnableInputRecording(10)
+	t.Cleanup(func() { EnableInputRecording(0) })
+	recordInput(uv.MouseClickEvent{X: 1, Y: 1, Button: uv.MouseLeft})
+	recordInput(uv.MouseReleaseEvent{X: 1, Y: 1, Button: uv.MouseLeft})
+	recordInput(uv.KeyPressEvent{Code: uv.KeyEnter})
+	recordInput(uv.KeyPressEvent{Code: '+', Text: "+"})
+	recordInput(uv.KeyPressEvent{Code: '+', Text: "+"})
+	recordInput(uv.WindowSizeEvent{Width: 12, Height: 4})
+	records := InputRecording()
+	EnableInputRecording(0)
+
+	var pressed []string
+	buf := Replay(replayTestRoot{count: NewSignal(0), pressed: &pressed}, records, 20, 3)
+
+	assert.Equal(t, []string{"B"}, pressed, "the click focuses B, which enter presses")
+	assert.Equal(t, 12, buf.Width())
+	assert.Equal(t, "count 2     ", bufferLine(buf, 2, 12))
+}
+
+func TestReplay_RoutesInputLikeRun(t *testing.T) {
+	var pressed []string
+	root := replayTestRoot{count: NewSignal(0), pressed: &pressed}
+	records := []InputRecord{
+		{Kind: "key", Code: 'j', Mod: int(uv.ModCtrl | uv.ModShift)},
+		{Kind: "key", Code: 's', Text: "s"},
+	}
+
+	Replay(root, records, 20, 3)
+	assert.Equal(t, []string{"B"}, pressed, "hint mode picks B from its code")
+}
+
+type replayKeyRoot struct {
+	keys *[]string
+}
+
+func (r replayKeyRoot) OnKey(event KeyEvent) bool {
+	*r.keys = append(*r.keys, event.Key())
+	return true
+}
+
+func (r replayKeyRoot) Build(ctx BuildContext) Widget {
+	return Text{Content: "keys"}
+}
+
+func TestReplay_SkipsAppKeys(t *testing.T) {
+	var keys []string
+	records := []InputRecord{
+		{Kind: "key", Code: 'c', Mod: int(uv.ModCtrl)},
+		{Kind: "key", Code: 'y', Mod: int(uv.ModCtrl | uv.ModShift)},
+		{Kind: "key", Code: 'g', Mod: int(uv.ModCtrl | uv.ModShift)},
+		{Kind: "key", Code: 'z', Mod: int(uv.ModCtrl)},
+		{Kind: "key", Code: uv.KeyUp},
+	}
+
+	Replay(replayKeyRoot{keys: &keys}, records, 20, 3)
+	assert.Equal(t, []string{"up"}, keys, "only keys Run passes to widgets reach the root")
+}
+
+func TestWriteCrashReport_WritesInputRecording(t *testing.T) {
+	dir := t.TempDir()
+	report := crashReport{
+		Time:  time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+		Input: []InputRecord{{Kind: "key", Key: "j", Code: 'j', Text: "j"}},
+	}
+
+	path, err := writeCrashReport(dir, report)
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "input recording: terma-crash-20240301-123000-input.jsonl")
+
+	f, err := os.Open(filepath.Join(dir, "terma-crash-20240301-123000-input.jsonl"))
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := LoadInputRecording(f)
+	require.NoError(t, err)
+	assert.Equal(t, report.Input, records)
+}
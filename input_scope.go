@@ -0,0 +1,79 @@
+package terma
+
+import "sync"
+
+// InputScope takes every key press while it is the innermost scope pushed
+// with PushInputScope, before floats and the focused widget see it. Use it
+// for transient modes that shouldn't open an overlay, like a pending key
+// chord or a full-screen editor.
+//
+// Example - a "g" chord waiting for its second key:
+//
+//	var pop func()
+//	pop = PushInputScope(InputScope{
+//	    Keybinds: []Keybind{
+//	        {Key: "g", Name: "Top", Action: func() { pop(); scrollToTop() }},
+//	        {Key: "d", Name: "Definition", Action: func() { pop(); goToDefinition() }},
+//	    },
+//	    OnKey: func(KeyEvent) bool { pop(); return true }, // Any other key cancels
+//	})
+type InputScope struct {
+	Keybinds    []Keybind                 // Matched first; KeybindBar shows these while the scope is active
+	OnKey       func(event KeyEvent) bool // Optional: called with keys no keybind matched, returning whether it handled them
+	PassThrough bool                      // Let keys the scope doesn't handle reach the focused widget (default: they are dropped)
+}
+
+// inputScopes is the stack of pushed scopes, innermost last.
+var inputScopes struct {
+	mu    sync.Mutex
+	stack []*InputScope
+}
+
+// PushInputScope makes scope the innermost input scope and returns the
+// function that removes it. Scopes can be removed in any order; calling
+// the function again does nothing.
+func PushInputScope(scope InputScope) (pop func()) {
+	entry := &scope
+	inputScopes.mu.Lock()
+	inputScopes.stack = append(inputScopes.stack, entry)
+	inputScopes.mu.Unlock()
+	scheduleRender()
+
+	return func() {
+		inputScopes.mu.Lock()
+		defer inputScopes.mu.Unlock()
+		for i, pushed := range inputScopes.stack {
+			if pushed == entry {
+				inputScopes.stack = append(inputScopes.stack[:i], inputScopes.stack[i+1:]...)
+				scheduleRender()
+				return
+			}
+		}
+	}
+}
+
+// activeInputScope returns the innermost input scope, or nil.
+func activeInputScope() *InputScope {
+	inputScopes.mu.Lock()
+	defer inputScopes.mu.Unlock()
+	if len(inputScopes.stack) == 0 {
+		return nil
+	}
+	return inputScopes.stack[len(inputScopes.stack)-1]
+}
+
+// dispatchInputScope sends a key to the innermost input scope. It returns
+// true when the key should go no further.
+func dispatchInputScope(event KeyEvent) bool {
+	scope := activeInputScope()
+	if scope == nil {
+		return false
+	}
+	if matchKeybind(event, scope.Keybinds) {
+		return true
+	}
+	if scope.OnKey != nil && scope.OnKey(event) {
+		return true
+	}
+	return !scope.PassThrough
+}
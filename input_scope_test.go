@@ -0,0 +1,100 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+type scopeTestRoot struct{ Column }
+
+func (scopeTestRoot) Keybinds() []Keybind {
+	return []Keybind{{Key: "q", Name: "Quit", Action: func() {}}}
+}
+
+func TestInputScope_CapturesKeysUntilPopped(t *testing.T) {
+	assert.False(t, dispatchInputScope(makeCharEvent('x')))
+
+	var matched []string
+	pop := PushInputScope(InputScope{
+		Keybinds: []Keybind{{Key: "g", Name: "Top", Action: func() { matched = append(matched, "g") }}},
+	})
+
+	assert.True(t, dispatchInputScope(makeCharEvent('g')))
+	assert.True(t, dispatchInputScope(makeCharEvent('x')), "unmatched keys are dropped")
+	assert.True(t, dispatchInputScope(makeKeyEvent(uv.KeyEscape, 0)))
+	assert.Equal(t, []string{"g"}, matched)
+
+	pop()
+	pop()
+	assert.Nil(t, activeInputScope())
+	assert.False(t, dispatchInputScope(makeCharEvent('g')))
+}
+
+func TestInputScope_OnKeyAndPassThrough(t *testing.T) {
+	var seen []string
+	pop := PushInputScope(InputScope{
+		PassThrough: true,
+		OnKey: func(event KeyEvent) bool {
+			seen = append(seen, event.Text())
+			return event.MatchString("a")
+		},
+	})
+	defer pop()
+
+	assert.True(t, dispatchInputScope(makeCharEvent('a')))
+	assert.False(t, dispatchInputScope(makeCharEvent('b')), "unhandled keys pass through")
+	assert.Equal(t, []string{"a", "b"}, seen)
+}
+
+func TestInputScope_InnermostScopeWins(t *testing.T) {
+	var got []string
+	popOuter := PushInputScope(InputScope{
+		Keybinds: []Keybind{{Key: "a", Action: func() { got = append(got, "outer") }}},
+	})
+	defer popOuter()
+	popInner := PushInputScope(InputScope{
+		Keybinds: []Keybind{{Key: "a", Action: func() { got = append(got, "inner") }}},
+	})
+
+	dispatchInputScope(makeCharEvent('a'))
+	popInner()
+	dispatchInputScope(makeCharEvent('a'))
+
+	assert.Equal(t, []string{"inner", "outer"}, got)
+}
+
+func TestInputScope_OuterScopeCanBePoppedFirst(t *testing.T) {
+	popOuter := PushInputScope(InputScope{})
+	popInner := PushInputScope(InputScope{PassThrough: true})
+	popOuter()
+
+	assert.True(t, activeInputScope().PassThrough)
+	popInner()
+	assert.Nil(t, activeInputScope())
+}
+
+func TestInputScope_ReplacesActiveKeybinds(t *testing.T) {
+	fm := NewFocusManager()
+	fm.SetRootWidget(scopeTestRoot{})
+	assert.Equal(t, []string{"q"}, keybindKeys(fm.ActiveKeybinds()))
+
+	pop := PushInputScope(InputScope{Keybinds: []Keybind{{Key: "g", Name: "Top"}}})
+	assert.Equal(t, []string{"g"}, keybindKeys(fm.ActiveKeybinds()))
+	pop()
+
+	pop = PushInputScope(InputScope{PassThrough: true, Keybinds: []Keybind{{Key: "g", Name: "Top"}}})
+	assert.Equal(t, []string{"g", "q"}, keybindKeys(fm.ActiveKeybinds()))
+	pop()
+
+	assert.Equal(t, []string{"q"}, keybindKeys(fm.ActiveKeybinds()))
+}
+
+func keybindKeys(keybinds []Keybind) []string {
+	keys := make([]string, len(keybinds))
+	for i, kb := range keybinds {
+		keys[i] = kb.Key
+	}
+	return keys
+}
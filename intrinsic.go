@@ -0,0 +1,64 @@
+package terma
+
+import (
+	"math"
+
+	"github.com/darrenburns/terma/layout"
+)
+
+// IntrinsicSize returns the border-box size widget takes when it has as
+// much room as it wants: text is not wrapped and nothing is stretched.
+// Widgets that fill their parent (Flex or Percent sizes) report 0 on that
+// axis, since they have no size of their own.
+//
+// Use it to size things to their content before layout, such as a column
+// of labels as wide as the longest one (see MaxIntrinsicWidth).
+func IntrinsicSize(ctx BuildContext, widget Widget) Size {
+	computed := computeIntrinsicLayout(ctx, widget, layout.Loose(math.MaxInt32, math.MaxInt32))
+	return Size{
+		Width:  intrinsicExtent(computed.Box.BorderBoxWidth()),
+		Height: intrinsicExtent(computed.Box.BorderBoxHeight()),
+	}
+}
+
+// IntrinsicHeight returns the border-box height widget takes when it is
+// at most width cells wide, with text wrapped to fit.
+func IntrinsicHeight(ctx BuildContext, widget Widget, width int) int {
+	computed := computeIntrinsicLayout(ctx, widget, layout.Loose(max(0, width), math.MaxInt32))
+	return intrinsicExtent(computed.Box.BorderBoxHeight())
+}
+
+// MaxIntrinsicWidth returns the largest intrinsic width among widgets.
+//
+// Example:
+//
+//	labelWidth := t.MaxIntrinsicWidth(ctx, labels...)
+//	// give every label Style{Width: t.Cells(labelWidth)}
+func MaxIntrinsicWidth(ctx BuildContext, widgets ...Widget) int {
+	widest := 0
+	for _, widget := range widgets {
+		widest = max(widest, IntrinsicSize(ctx, widget).Width)
+	}
+	return widest
+}
+
+// computeIntrinsicLayout lays widget out on its own under constraints.
+func computeIntrinsicLayout(ctx BuildContext, widget Widget, constraints layout.Constraints) layout.ComputedLayout {
+	built := widget.Build(ctx)
+	var node layout.LayoutNode
+	if builder, ok := built.(LayoutNodeBuilder); ok {
+		node = builder.BuildLayoutNode(ctx)
+	} else {
+		node = buildFallbackLayoutNode(built, ctx)
+	}
+	return node.ComputeLayout(constraints)
+}
+
+// intrinsicExtent maps sizes that grew to fill the unbounded measuring
+// space to 0.
+func intrinsicExtent(size int) int {
+	if size > 100_000 {
+		return 0
+	}
+	return size
+}
@@ -0,0 +1,39 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntrinsicSize(t *testing.T) {
+	ctx := BuildContext{}
+	assert.Equal(t, Size{Width: 11, Height: 1}, IntrinsicSize(ctx, Text{Content: "Hello world"}))
+	assert.Equal(t, Size{Width: 9, Height: 3}, IntrinsicSize(ctx, Text{
+		Content: "Label",
+		Style:   Style{Border: SquareBorder(Red), Padding: EdgeInsetsXY(1, 0)},
+	}), "border-box size")
+	assert.Equal(t, 0, IntrinsicSize(ctx, Spacer{}).Width, "expanding widgets have no width of their own")
+	assert.Equal(t, 2, IntrinsicHeight(ctx, Text{Content: "Hello world", Wrap: WrapSoft}, 6), "wrapped to the width")
+}
+
+func TestMaxIntrinsicWidth(t *testing.T) {
+	ctx := BuildContext{}
+	labels := []Widget{Text{Content: "Name"}, Text{Content: "Description"}, Text{Content: "Age"}}
+	assert.Equal(t, 11, MaxIntrinsicWidth(ctx, labels...))
+	assert.Equal(t, 0, MaxIntrinsicWidth(ctx))
+}
+
+func TestRow_CrossAxisBaseline(t *testing.T) {
+	widget := Row{
+		CrossAlign: CrossAxisBaseline,
+		Children: []Widget{
+			Text{Content: "Name:"},
+			Text{Content: "Bob", Style: Style{Border: SquareBorder(Red)}},
+		},
+	}
+
+	buf := renderWithPointer(widget, 10, 3, "", "")
+	assert.Equal(t, "Name:", rowText(buf, 5, 1), "the label sits level with the bordered text")
+	assert.Equal(t, "B", buf.CellAt(6, 1).Content)
+}
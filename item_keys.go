@@ -0,0 +1,289 @@
+package terma
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ItemDiff describes how a keyed slice changed between two versions.
+type ItemDiff struct {
+	Moved    map[int]int // Old index -> new index, for items in both versions
+	Removed  []int       // Old indices of items that are gone, ascending
+	Inserted []int       // New indices of items that are new, ascending
+
+	// Updated holds the new indices of items in both versions whose value
+	// changed, ascending. Only ListState.ReplaceItemsDiff and
+	// TableState.ReplaceRowsDiff fill it in.
+	Updated []int
+}
+
+// Changed reports whether any item was added, removed, moved or updated.
+func (d ItemDiff) Changed() bool {
+	if len(d.Removed) > 0 || len(d.Inserted) > 0 || len(d.Updated) > 0 {
+		return true
+	}
+	for from, to := range d.Moved {
+		if from != to {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffItems compares two versions of a slice by item identity rather than
+// index. With duplicate keys, the first occurrence in each slice is paired
+// and later ones count as removed or inserted.
+//
+// Example:
+//
+//	diff := t.DiffItems(oldTasks, newTasks, func(task Task) string { return task.ID })
+//	for from, to := range diff.Moved { ... }
+func DiffItems[T any](old, new []T, key func(item T) string) ItemDiff {
+	diff := ItemDiff{Moved: make(map[int]int)}
+	newIndex := make(map[string]int, len(new))
+	for i, item := range new {
+		k := key(item)
+		if _, ok := newIndex[k]; !ok {
+			newIndex[k] = i
+		}
+	}
+	paired := make(map[int]bool, len(old))
+	seen := make(map[string]bool, len(old))
+	for i, item := range old {
+		k := key(item)
+		to, ok := newIndex[k]
+		if !ok || seen[k] {
+			diff.Removed = append(diff.Removed, i)
+			continue
+		}
+		seen[k] = true
+		diff.Moved[i] = to
+		paired[to] = true
+	}
+	for i := range new {
+		if !paired[i] {
+			diff.Inserted = append(diff.Inserted, i)
+		}
+	}
+	return diff
+}
+
+// keyTracker keeps index-based state (the cursor, the selection and its
+// anchor) on the same items when they move. It remembers the item key at
+// each tracked index; once the key at one of those indices changes, the
+// items have moved, and it moves the state to the indices the keys went to.
+type keyTracker[T any] struct {
+	selected  map[int]string // Item key at each selected index and the anchor
+	cursor    int            // Cursor index when last synced
+	cursorKey string
+	hasCursor bool
+}
+
+// sync follows moved items, then records the keys of the current state.
+// selection may be nil to leave the selection alone (see
+// TableState.KeyFor). It does nothing without a key function.
+func (t *keyTracker[T]) sync(items []T, key func(item T) string, cursor Signal[int], selection *SelectionModel[int]) {
+	if key == nil {
+		*t = keyTracker[T]{}
+		return
+	}
+	if t.moved(items, key) {
+		indexByKey := make(map[string]int, len(items))
+		for i := len(items) - 1; i >= 0; i-- {
+			indexByKey[key(items[i])] = i
+		}
+		if selection != nil {
+			selection.remap(func(idx int) (int, bool) {
+				k, tracked := t.selected[idx]
+				if !tracked {
+					return idx, idx >= 0 && idx < len(items)
+				}
+				next, ok := indexByKey[k]
+				return next, ok
+			})
+		}
+		// Leave a cursor that was moved since the last sync where it was put.
+		if t.hasCursor && cursor.IsValid() && cursor.Peek() == t.cursor {
+			if next, ok := indexByKey[t.cursorKey]; ok {
+				cursor.Set(next)
+			}
+		}
+	}
+	t.record(items, key, cursor, selection)
+}
+
+// moved reports whether the key at any tracked index changed.
+func (t *keyTracker[T]) moved(items []T, key func(item T) string) bool {
+	if t.hasCursor && (t.cursor >= len(items) || key(items[t.cursor]) != t.cursorKey) {
+		return true
+	}
+	for idx, k := range t.selected {
+		if idx >= len(items) || key(items[idx]) != k {
+			return true
+		}
+	}
+	return false
+}
+
+// record remembers the keys at the cursor, the selected indices and the
+// anchor.
+func (t *keyTracker[T]) record(items []T, key func(item T) string, cursor Signal[int], selection *SelectionModel[int]) {
+	if key == nil {
+		*t = keyTracker[T]{}
+		return
+	}
+	t.hasCursor = false
+	if cursor.IsValid() {
+		if idx := cursor.Peek(); idx >= 0 && idx < len(items) {
+			t.cursor, t.cursorKey, t.hasCursor = idx, key(items[idx]), true
+		}
+	}
+	t.selected = nil
+	if selection == nil {
+		return
+	}
+	selected := selection.Selection.Peek()
+	t.selected = make(map[int]string, len(selected)+1)
+	for idx := range selected {
+		if idx >= 0 && idx < len(items) {
+			t.selected[idx] = key(items[idx])
+		}
+	}
+	if anchor, ok := selection.Anchor(); ok && anchor >= 0 && anchor < len(items) {
+		t.selected[anchor] = key(items[anchor])
+	}
+}
+
+// scrollAnchor keeps the item at the top of a scrolled list in place when
+// items move, so reordering doesn't jump the viewport.
+type scrollAnchor struct {
+	index int    // Source index of the top item when last recorded
+	key   string // Its key
+	delta int    // Scroll offset minus the item's y
+	ok    bool
+}
+
+const (
+	// changeHighlightDuration is how long the tint over changed items takes
+	// to fade out after ReplaceItemsDiff.
+	changeHighlightDuration = time.Second
+	// changeHighlightAlpha is how strongly the tint colors a changed item
+	// at first.
+	changeHighlightAlpha = 0.3
+)
+
+// diffReplacement compares the items being replaced with their
+// replacement for ReplaceItemsDiff: by key when there is a key function,
+// and by index otherwise. Items in both versions for which equal returns
+// false are updated; a nil equal compares with reflect.DeepEqual.
+func diffReplacement[T any](old, new []T, key func(item T) string, equal func(a, b T) bool) ItemDiff {
+	if equal == nil {
+		equal = func(a, b T) bool { return reflect.DeepEqual(a, b) }
+	}
+	var diff ItemDiff
+	if key != nil {
+		diff = DiffItems(old, new, key)
+	} else {
+		diff = ItemDiff{Moved: make(map[int]int)}
+		for i := range old {
+			if i < len(new) {
+				diff.Moved[i] = i
+			} else {
+				diff.Removed = append(diff.Removed, i)
+			}
+		}
+		for i := len(old); i < len(new); i++ {
+			diff.Inserted = append(diff.Inserted, i)
+		}
+	}
+	for from, to := range diff.Moved {
+		if !equal(old[from], new[to]) {
+			diff.Updated = append(diff.Updated, to)
+		}
+	}
+	slices.Sort(diff.Updated)
+	return diff
+}
+
+// changeHighlight fades a tint out over the items ReplaceItemsDiff
+// inserted or updated. Items are remembered by key, or by index without a
+// key function, so the tint stays on an item that moves while it fades.
+type changeHighlight struct {
+	keys      map[string]struct{}
+	animation *Animation[float64] // Tint strength, from 1 down to 0
+}
+
+// start highlights the items with the given keys, replacing any running
+// highlight.
+func (h *changeHighlight) start(keys map[string]struct{}) {
+	if h.animation != nil {
+		h.animation.Stop()
+	}
+	h.keys, h.animation = nil, nil
+	if len(keys) == 0 {
+		return
+	}
+	var animation *Animation[float64]
+	animation = NewAnimation(AnimationConfig[float64]{
+		From:     1,
+		To:       0,
+		Duration: changeHighlightDuration,
+		Easing:   EaseInQuad,
+		OnComplete: func() {
+			if h.animation == animation {
+				h.keys, h.animation = nil, nil
+			}
+		},
+	})
+	h.keys, h.animation = keys, animation
+	animation.Start()
+}
+
+// tint returns the color to blend behind the item with the given key, if
+// it is highlighted. Calling it during Build subscribes to the fade.
+func (h *changeHighlight) tint(theme ThemeData, key string) (Color, bool) {
+	if h.animation == nil {
+		return Color{}, false
+	}
+	if _, ok := h.keys[key]; !ok {
+		return Color{}, false
+	}
+	strength := h.animation.Value().Get()
+	if strength <= 0 {
+		return Color{}, false
+	}
+	return theme.Accent.WithAlpha(changeHighlightAlpha * strength), true
+}
+
+// active reports whether any item is highlighted.
+func (h *changeHighlight) active() bool {
+	return h.animation != nil
+}
+
+// changedKeys returns the keys of the items diff inserted or updated in
+// items, to highlight. Replacing no items highlights nothing, so the first
+// load doesn't flash every item.
+func changedKeys[T any](items []T, hadItems bool, diff ItemDiff, key func(item T) string) map[string]struct{} {
+	if !hadItems {
+		return nil
+	}
+	keys := make(map[string]struct{}, len(diff.Inserted)+len(diff.Updated))
+	for _, idx := range diff.Inserted {
+		keys[itemKey(items, idx, key)] = struct{}{}
+	}
+	for _, idx := range diff.Updated {
+		keys[itemKey(items, idx, key)] = struct{}{}
+	}
+	return keys
+}
+
+// itemKey returns the key changeHighlight remembers an item by: its
+// KeyFor key, or its index without a key function.
+func itemKey[T any](items []T, index int, key func(item T) string) string {
+	if key == nil {
+		return strconv.Itoa(index)
+	}
+	return key(items[index])
+}
@@ -0,0 +1,162 @@
+package terma
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffItems(t *testing.T) {
+	key := func(s string) string { return s }
+	diff := DiffItems([]string{"a", "b", "c"}, []string{"c", "a", "d"}, key)
+	assert.Equal(t, map[int]int{0: 1, 2: 0}, diff.Moved)
+	assert.Equal(t, []int{1}, diff.Removed)
+	assert.Equal(t, []int{2}, diff.Inserted)
+	assert.True(t, diff.Changed())
+
+	assert.False(t, DiffItems([]string{"a", "b"}, []string{"a", "b"}, key).Changed())
+}
+
+func TestListState_CursorFollowsKeyFor(t *testing.T) {
+	state := NewListState([]string{"alpha", "beta", "gamma"})
+	state.KeyFor = func(item string) string { return item }
+	state.SelectIndex(1)
+	state.syncKeys()
+
+	state.SetItems([]string{"gamma", "delta", "alpha", "beta"})
+	assert.Equal(t, 3, state.CursorIndex.Peek())
+
+	state.SetItems([]string{"gamma", "alpha"})
+	assert.Equal(t, 1, state.CursorIndex.Peek(), "a removed cursor item leaves the cursor clamped in place")
+}
+
+func TestTableState_CursorFollowsKeyFor(t *testing.T) {
+	state := NewTableState([][]string{{"1"}, {"2"}, {"3"}})
+	state.KeyFor = func(row []string) string { return row[0] }
+	state.SelectIndex(0)
+	state.syncKeys()
+
+	state.SetRows([][]string{{"3"}, {"2"}, {"1"}})
+	assert.Equal(t, 2, state.CursorIndex.Peek())
+}
+
+func TestList_ScrollAnchorFollowsKeyFor(t *testing.T) {
+	items := make([]string, 20)
+	for i := range items {
+		items[i] = fmt.Sprintf("item %02d", i)
+	}
+	state := NewListState(items)
+	state.KeyFor = func(item string) string { return item }
+	scroll := NewScrollState()
+	widget := &scrollableListTestWidget{listState: state, scrollState: scroll}
+
+	state.SelectIndex(10)
+	screenText(widget, 30, 5)
+	scroll.SetOffset(8)
+	screenText(widget, 30, 5)
+	require.Equal(t, 8, scroll.GetOffset())
+
+	state.SetItems(append([]string{"new a", "new b", "new c"}, items...))
+	screen := screenText(widget, 30, 5)
+	assert.Equal(t, 11, scroll.GetOffset(), "item 08 moved down three rows and stays on top")
+	assert.True(t, strings.HasPrefix(strings.TrimSpace(strings.Split(screen, "\n")[0]), "item 08"), screen)
+	assert.Equal(t, 13, state.CursorIndex.Peek())
+}
+
+type keyedRow struct {
+	ID    string
+	Value int
+}
+
+func TestListState_ReplaceItemsDiff(t *testing.T) {
+	state := NewListState([]keyedRow{{"a", 1}, {"b", 2}, {"c", 3}})
+	state.KeyFor = func(row keyedRow) string { return row.ID }
+	state.SelectIndex(2)
+	state.syncKeys()
+
+	diff := state.ReplaceItemsDiff([]keyedRow{{"d", 4}, {"a", 1}, {"c", 30}}, nil)
+
+	assert.Equal(t, []int{1}, diff.Removed)
+	assert.Equal(t, []int{0}, diff.Inserted)
+	assert.Equal(t, []int{2}, diff.Updated)
+	assert.Equal(t, 2, state.CursorIndex.Peek(), "the cursor stays on c")
+	assert.Equal(t, map[string]struct{}{"d": {}, "c": {}}, state.changes.keys)
+
+	state.changes.animation.Advance(changeHighlightDuration)
+	assert.False(t, state.changes.active(), "the highlight clears once it fades out")
+
+	state.ReplaceItemsDiff([]keyedRow{{"d", 4}, {"a", 1}, {"c", 30}}, nil)
+	assert.False(t, state.changes.active(), "an unchanged refresh highlights nothing")
+}
+
+func TestListState_ReplaceItemsDiffWithoutKeyFor(t *testing.T) {
+	state := NewListState([]string{"a", "b"})
+	equal := func(a, b string) bool { return strings.EqualFold(a, b) }
+
+	diff := state.ReplaceItemsDiff([]string{"A", "c", "d"}, equal)
+
+	assert.Equal(t, []int{1}, diff.Updated, "items are paired by index")
+	assert.Equal(t, []int{2}, diff.Inserted)
+	assert.Equal(t, map[string]struct{}{"1": {}, "2": {}}, state.changes.keys)
+
+	empty := NewListState[string](nil)
+	empty.ReplaceItemsDiff([]string{"a"}, nil)
+	assert.False(t, empty.changes.active(), "the first load isn't highlighted")
+}
+
+type scrollableTableTestWidget struct {
+	tableState  *TableState[keyedRow]
+	scrollState *ScrollState
+}
+
+func (w *scrollableTableTestWidget) Build(ctx BuildContext) Widget {
+	return Scrollable{
+		State: w.scrollState,
+		Style: Style{Width: Cells(20), Height: Cells(5)},
+		Child: Table[keyedRow]{
+			ID:          "test-table",
+			State:       w.tableState,
+			ScrollState: w.scrollState,
+			Columns:     []TableColumn{{Width: Cells(10)}, {Width: Cells(5)}},
+			RenderCell: func(row keyedRow, rowIndex, colIndex int, active, selected bool) Widget {
+				if colIndex == 0 {
+					return Text{Content: row.ID}
+				}
+				return Text{Content: fmt.Sprint(row.Value)}
+			},
+		},
+	}
+}
+
+func TestTable_ReplaceRowsDiffKeepsScrollAndHighlights(t *testing.T) {
+	rows := make([]keyedRow, 20)
+	for i := range rows {
+		rows[i] = keyedRow{ID: fmt.Sprintf("row %02d", i), Value: i}
+	}
+	state := NewTableState(rows)
+	state.KeyFor = func(row keyedRow) string { return row.ID }
+	scroll := NewScrollState()
+	widget := &scrollableTableTestWidget{tableState: state, scrollState: scroll}
+
+	state.SelectIndex(10)
+	screenText(widget, 20, 5)
+	scroll.SetOffset(8)
+	before := RenderToBuffer(widget, 20, 5)
+	require.Equal(t, 8, scroll.GetOffset())
+
+	next := append([]keyedRow{{ID: "new a"}, {ID: "new b"}}, rows...)
+	next[11].Value = 99 // row 09
+	diff := state.ReplaceRowsDiff(next, nil)
+	after := RenderToBuffer(widget, 20, 5)
+
+	assert.Equal(t, []int{0, 1}, diff.Inserted)
+	assert.Equal(t, []int{11}, diff.Updated)
+	assert.Equal(t, 10, scroll.GetOffset(), "row 08 moved down two rows and stays on top")
+	assert.Equal(t, 12, state.CursorIndex.Peek())
+	assert.Equal(t, "row 09    99", bufferLine(after, 1, 12))
+	assert.NotEqual(t, before.CellAt(0, 1).Style.Bg, after.CellAt(0, 1).Style.Bg, "the updated row is highlighted")
+	assert.Equal(t, before.CellAt(0, 0).Style.Bg, after.CellAt(0, 0).Style.Bg, "unchanged rows aren't")
+}
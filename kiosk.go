@@ -0,0 +1,390 @@
+package terma
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// InputProfile adapts navigation to the keys a terminal has.
+type InputProfile int
+
+const (
+	// InputProfileDefault expects a full keyboard (default).
+	InputProfileDefault InputProfile = iota
+	// InputProfileKiosk runs the whole app from the arrow keys, Enter and
+	// Escape, for kiosk and appliance terminals without a full keyboard:
+	//
+	//   - arrow keys that the focused widget leaves unhandled move focus to
+	//     the nearest focusable widget in that direction
+	//   - Enter on a text field opens an OnScreenKeyboard to type with;
+	//     Escape or a long press of Enter closes it
+	//   - holding Enter down is a long press, sent to the focused widget's
+	//     LongPressHandler (or its ancestors') instead of a press
+	//
+	// Long presses are recognised from key release events when the
+	// terminal reports them (Kitty keyboard protocol), and from key repeats
+	// otherwise, in which case a short press of Enter arrives after the long
+	// press delay.
+	InputProfileKiosk
+)
+
+var inputProfile atomic.Int32
+
+// SetInputProfile sets how keys navigate the app.
+func SetInputProfile(profile InputProfile) {
+	inputProfile.Store(int32(profile))
+}
+
+func kioskProfile() bool {
+	return InputProfile(inputProfile.Load()) == InputProfileKiosk
+}
+
+// DefaultLongPressDelay is how long a key is held down before it is a long
+// press. It is longer than most terminals' key repeat delay, so held keys
+// have started repeating by then.
+const DefaultLongPressDelay = 700 * time.Millisecond
+
+var longPressDelay atomic.Int64
+
+// SetLongPressDelay sets how long a key is held down before it is a long
+// press under InputProfileKiosk. Zero restores DefaultLongPressDelay.
+func SetLongPressDelay(delay time.Duration) {
+	longPressDelay.Store(int64(delay))
+}
+
+func currentLongPressDelay() time.Duration {
+	if delay := time.Duration(longPressDelay.Load()); delay > 0 {
+		return delay
+	}
+	return DefaultLongPressDelay
+}
+
+// LongPressHandler is implemented by widgets that respond to Enter being
+// held down under InputProfileKiosk, like a secondary action or context
+// menu. Long presses bubble from the focused widget to the root like key
+// presses; one nothing handles arrives as a plain press.
+type LongPressHandler interface {
+	// OnLongPress is called with the held key. Return true if it was handled.
+	OnLongPress(event KeyEvent) bool
+}
+
+// longPressEvent is a key held down for the long press delay.
+type longPressEvent struct {
+	key uv.KeyPressEvent
+}
+
+// Key repeats closer together than this are one hold.
+const longPressRepeatGap = 150 * time.Millisecond
+
+// longPressFilter holds Enter back under InputProfileKiosk until it is
+// known whether the key is tapped or held.
+type longPressFilter struct {
+	pending  *uv.KeyPressEvent // Enter press waiting to be a press or a long press
+	start    time.Time         // When the pending press started
+	last     time.Time         // Latest press or repeat of the held key
+	repeated bool              // The pending press has repeated
+	holding  bool              // A long press fired and the key is still held
+	releases bool              // The terminal reports key releases
+}
+
+func isLongPressKey(key uv.Key) bool {
+	return key.MatchString("enter")
+}
+
+// handle filters one event, returning the events to deliver now.
+func (f *longPressFilter) handle(ev uv.Event, now time.Time) []uv.Event {
+	switch e := ev.(type) {
+	case uv.KeyReleaseEvent:
+		f.releases = true
+		if !isLongPressKey(uv.Key(e)) {
+			return []uv.Event{ev}
+		}
+		f.holding = false
+		return append(f.flush(), ev)
+	case uv.KeyPressEvent:
+		if !isLongPressKey(uv.Key(e)) || !kioskProfile() {
+			f.holding = false
+			return append(f.flush(), ev)
+		}
+		if f.holding && (f.releases || now.Sub(f.last) < longPressRepeatGap) {
+			f.last = now // Rest of a hold that was already a long press
+			return nil
+		}
+		f.holding = false
+		if f.pending != nil && (!f.releases || e.IsRepeat) {
+			f.repeated = true
+			f.last = now
+			if now.Sub(f.start) >= currentLongPressDelay() {
+				return f.fire(now)
+			}
+			return nil
+		}
+		events := f.flush()
+		f.pending = &e
+		f.start, f.last = now, now
+		f.repeated = false
+		return events
+	}
+	return []uv.Event{ev}
+}
+
+// deadline returns when expire should next be called.
+func (f *longPressFilter) deadline() (time.Time, bool) {
+	if f.pending == nil {
+		return time.Time{}, false
+	}
+	return f.start.Add(currentLongPressDelay()), true
+}
+
+// expire decides a pending press whose long press delay has passed: it is
+// a long press if the key is still down.
+func (f *longPressFilter) expire(now time.Time) []uv.Event {
+	if f.pending == nil || now.Sub(f.start) < currentLongPressDelay() {
+		return nil
+	}
+	if f.releases || (f.repeated && now.Sub(f.last) < longPressRepeatGap) {
+		return f.fire(now)
+	}
+	return f.flush()
+}
+
+// fire turns the pending press into a long press.
+func (f *longPressFilter) fire(now time.Time) []uv.Event {
+	event := longPressEvent{key: *f.pending}
+	f.pending = nil
+	f.holding = true
+	f.last = now
+	return []uv.Event{event}
+}
+
+// flush delivers the pending press as a plain press.
+func (f *longPressFilter) flush() []uv.Event {
+	if f.pending == nil {
+		return nil
+	}
+	event := *f.pending
+	f.pending = nil
+	return []uv.Event{event}
+}
+
+// filterLongPresses passes terminal events through a longPressFilter.
+func filterLongPresses(ctx context.Context, in <-chan uv.Event) <-chan uv.Event {
+	out := make(chan uv.Event)
+	go func() {
+		defer close(out)
+		var filter longPressFilter
+		send := func(events []uv.Event) bool {
+			for _, ev := range events {
+				select {
+				case out <- ev:
+				case <-ctx.Done():
+					return false
+				}
+			}
+			return true
+		}
+		for {
+			var expired <-chan time.Time
+			if deadline, ok := filter.deadline(); ok {
+				expired = time.After(time.Until(deadline))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-in:
+				if !ok || !send(filter.handle(ev, time.Now())) {
+					return
+				}
+			case now := <-expired:
+				if !send(filter.expire(now)) {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// dispatchLongPress sends a long press to the on-screen keyboard, which it
+// closes, or to the focused widget and its ancestors, then the root. It
+// returns false when nothing took it.
+func dispatchLongPress(fm *FocusManager, root Widget, event KeyEvent) bool {
+	if closeKioskKeyboard() {
+		return true
+	}
+	if activeInputScope() != nil {
+		return false
+	}
+	if fm.handleLongPress(event) {
+		return true
+	}
+	if handler, ok := root.(LongPressHandler); ok {
+		return handler.OnLongPress(event)
+	}
+	return false
+}
+
+// handleLongPress sends a long press to the focused widget, bubbling up
+// through its ancestors.
+func (fm *FocusManager) handleLongPress(event KeyEvent) bool {
+	for _, entry := range fm.focusables {
+		if entry.ID != fm.focusedID {
+			continue
+		}
+		if handler, ok := entry.Focusable.(LongPressHandler); ok && handler.OnLongPress(event) {
+			return true
+		}
+		for i := len(entry.Ancestors) - 1; i >= 0; i-- {
+			if handler, ok := entry.Ancestors[i].(LongPressHandler); ok && handler.OnLongPress(event) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// dispatchSpatialFocus moves focus in the direction of an arrow key nothing
+// else handled, under InputProfileKiosk.
+func dispatchSpatialFocus(fm *FocusManager, entries []WidgetEntry, event KeyEvent) bool {
+	if !kioskProfile() {
+		return false
+	}
+	switch {
+	case event.MatchString("up"):
+		return fm.focusInDirection(entries, 0, -1)
+	case event.MatchString("down"):
+		return fm.focusInDirection(entries, 0, 1)
+	case event.MatchString("left"):
+		return fm.focusInDirection(entries, -1, 0)
+	case event.MatchString("right"):
+		return fm.focusInDirection(entries, 1, 0)
+	}
+	return false
+}
+
+// focusInDirection focuses the nearest focusable widget beyond the focused
+// one's edge in direction (dx, dy), within the active focus trap. Widgets
+// out of line with the focused one count as further away. It returns false
+// when there is none.
+func (fm *FocusManager) focusInDirection(entries []WidgetEntry, dx, dy int) bool {
+	current := widgetEntryByID(entries, fm.focusedID)
+	if current == nil {
+		return false
+	}
+	from := current.Bounds
+
+	bestID, bestScore := "", 0
+	for _, candidate := range fm.focusablesInScope(fm.activeTrapID()) {
+		if candidate.ID == fm.focusedID || !candidate.Focusable.IsFocusable() {
+			continue
+		}
+		entry := widgetEntryByID(entries, candidate.ID)
+		if entry == nil {
+			continue
+		}
+		to := entry.Bounds
+		var gap, offset int
+		switch {
+		case dx > 0:
+			gap, offset = to.X-(from.X+from.Width), spanGap(from.Y, from.Height, to.Y, to.Height)
+		case dx < 0:
+			gap, offset = from.X-(to.X+to.Width), spanGap(from.Y, from.Height, to.Y, to.Height)
+		case dy > 0:
+			gap, offset = to.Y-(from.Y+from.Height), spanGap(from.X, from.Width, to.X, to.Width)
+		default:
+			gap, offset = from.Y-(to.Y+to.Height), spanGap(from.X, from.Width, to.X, to.Width)
+		}
+		if gap < 0 {
+			continue
+		}
+		if score := gap + 2*offset; bestID == "" || score < bestScore {
+			bestID, bestScore = candidate.ID, score
+		}
+	}
+	if bestID == "" {
+		return false
+	}
+	Log("focusInDirection: %q -> %q (dx=%d dy=%d)", fm.focusedID, bestID, dx, dy)
+	fm.FocusByID(bestID)
+	return true
+}
+
+// spanGap returns the distance between two spans on one axis, or 0 when
+// they overlap.
+func spanGap(start, size, otherStart, otherSize int) int {
+	return max(0, otherStart-(start+size), start-(otherStart+otherSize))
+}
+
+// kioskKeyboardSession is the on-screen keyboard open for a text field.
+type kioskKeyboardSession struct {
+	state  *OnScreenKeyboardState
+	layout *KeyboardLayout    // Set with SetOnScreenKeyboardLayout, or nil
+	press  func(key KeyEvent) // Types a key into the text field
+	pop    func()             // Removes the keyboard's input scope
+}
+
+// kioskKeyboard is the open on-screen keyboard, or nil.
+var kioskKeyboard = NewAnySignal[*kioskKeyboardSession](nil)
+
+// openKioskKeyboard opens the on-screen keyboard when Enter is pressed on
+// a text field under InputProfileKiosk. The keyboard takes every key
+// until Escape or a long press closes it, typing into the text field.
+func openKioskKeyboard(fm *FocusManager, event KeyEvent) bool {
+	if !kioskProfile() || kioskKeyboard.Peek() != nil || !event.MatchString("enter") {
+		return false
+	}
+	capturer, ok := fm.Focused().(KeyCapturer)
+	if !ok || !capturer.CapturesKey("a") {
+		return false
+	}
+
+	session := &kioskKeyboardSession{
+		state:  NewOnScreenKeyboardState(),
+		layout: kioskKeyboardLayout.Load(),
+		press:  func(key KeyEvent) { fm.HandleKey(key) },
+	}
+	keyboard := session.widget()
+	session.pop = PushInputScope(InputScope{
+		Keybinds: []Keybind{
+			{Key: "escape", Name: "Done", Action: func() { closeKioskKeyboard() }},
+			{Key: "enter", Name: "Press key", Action: keyboard.Press},
+		},
+		OnKey: keyboard.OnKey,
+	})
+	kioskKeyboard.Set(session)
+	return true
+}
+
+// closeKioskKeyboard closes the on-screen keyboard, returning false if it
+// wasn't open.
+func closeKioskKeyboard() bool {
+	session := kioskKeyboard.Peek()
+	if session == nil {
+		return false
+	}
+	session.pop()
+	kioskKeyboard.Set(nil)
+	return true
+}
+
+func (s *kioskKeyboardSession) widget() OnScreenKeyboard {
+	return OnScreenKeyboard{DisableFocus: true, State: s.state, Layout: s.layout, OnPress: s.press}
+}
+
+// buildKioskKeyboard adds the open on-screen keyboard to the overlay
+// layer, along the bottom of the screen.
+func buildKioskKeyboard(ctx BuildContext) {
+	session := kioskKeyboard.Peek()
+	if session == nil {
+		return
+	}
+	Floating{
+		Visible: true,
+		Config:  FloatConfig{Position: FloatPositionBottomCenter},
+		Child:   session.widget(),
+	}.Build(ctx)
+}
@@ -0,0 +1,231 @@
+package terma
+
+import (
+	"testing"
+	"time"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useKioskProfile(t *testing.T) {
+	t.Helper()
+	SetInputProfile(InputProfileKiosk)
+	t.Cleanup(func() {
+		closeKioskKeyboard()
+		SetInputProfile(InputProfileDefault)
+	})
+}
+
+func enterPress(repeat bool) uv.KeyPressEvent {
+	return uv.KeyPressEvent{Code: uv.KeyEnter, IsRepeat: repeat}
+}
+
+func TestLongPressFilter_PassesEverythingThroughByDefault(t *testing.T) {
+	var f longPressFilter
+	now := time.Now()
+
+	events := f.handle(enterPress(false), now)
+
+	assert.Equal(t, []uv.Event{enterPress(false)}, events)
+	_, pending := f.deadline()
+	assert.False(t, pending)
+}
+
+func TestLongPressFilter_TapIsAPressOnceTheDelayPasses(t *testing.T) {
+	useKioskProfile(t)
+	var f longPressFilter
+	start := time.Now()
+
+	assert.Empty(t, f.handle(enterPress(false), start))
+	deadline, pending := f.deadline()
+	require.True(t, pending)
+	assert.Equal(t, start.Add(DefaultLongPressDelay), deadline)
+
+	assert.Equal(t, []uv.Event{enterPress(false)}, f.expire(deadline))
+}
+
+func TestLongPressFilter_RepeatsUntilTheDelayAreALongPress(t *testing.T) {
+	useKioskProfile(t)
+	var f longPressFilter
+	start := time.Now()
+
+	f.handle(enterPress(false), start)
+	for at := 500 * time.Millisecond; at < DefaultLongPressDelay; at += 30 * time.Millisecond {
+		assert.Empty(t, f.handle(enterPress(false), start.Add(at)))
+	}
+	events := f.expire(start.Add(DefaultLongPressDelay))
+	assert.Equal(t, []uv.Event{longPressEvent{key: enterPress(false)}}, events)
+
+	// The rest of the hold is swallowed, then Enter works again
+	assert.Empty(t, f.handle(enterPress(false), start.Add(DefaultLongPressDelay+30*time.Millisecond)))
+	assert.Empty(t, f.handle(enterPress(false), start.Add(2*time.Second)))
+	_, pending := f.deadline()
+	assert.True(t, pending, "a press after the hold ended waits again")
+}
+
+func TestLongPressFilter_UsesKeyReleasesWhenReported(t *testing.T) {
+	useKioskProfile(t)
+	var f longPressFilter
+	start := time.Now()
+	release := uv.KeyReleaseEvent{Code: uv.KeyEnter}
+
+	f.handle(enterPress(false), start)
+	events := f.handle(release, start.Add(100*time.Millisecond))
+	assert.Equal(t, []uv.Event{enterPress(false), release}, events, "released before the delay")
+
+	f.handle(enterPress(false), start.Add(time.Second))
+	events = f.expire(start.Add(time.Second + DefaultLongPressDelay))
+	assert.Equal(t, []uv.Event{longPressEvent{key: enterPress(false)}}, events, "still down at the delay")
+	assert.Empty(t, f.handle(enterPress(true), start.Add(2*time.Second)))
+	f.handle(release, start.Add(2*time.Second))
+
+	f.handle(enterPress(false), start.Add(3*time.Second))
+	events = f.handle(enterPress(false), start.Add(3*time.Second+100*time.Millisecond))
+	assert.Equal(t, []uv.Event{enterPress(false)}, events, "a second tap isn't a repeat")
+}
+
+func TestLongPressFilter_OtherKeysDeliverThePendingPress(t *testing.T) {
+	useKioskProfile(t)
+	var f longPressFilter
+	start := time.Now()
+	down := uv.KeyPressEvent{Code: uv.KeyDown}
+
+	f.handle(enterPress(false), start)
+	events := f.handle(down, start.Add(50*time.Millisecond))
+
+	assert.Equal(t, []uv.Event{enterPress(false), down}, events)
+}
+
+type longPressButton struct {
+	Button
+	pressed *[]string
+}
+
+func (b longPressButton) OnLongPress(event KeyEvent) bool {
+	*b.pressed = append(*b.pressed, b.ID)
+	return true
+}
+
+func TestDispatchLongPress_BubblesFromTheFocusedWidget(t *testing.T) {
+	var pressed []string
+	fm := NewFocusManager()
+	fm.SetFocusables([]FocusableEntry{
+		{ID: "plain", Focusable: &Button{ID: "plain"}, Ancestors: []Widget{longPressButton{Button: Button{ID: "outer"}, pressed: &pressed}}},
+		{ID: "held", Focusable: longPressButton{Button: Button{ID: "held"}, pressed: &pressed}},
+	})
+	enter := KeyEvent{event: enterPress(false)}
+
+	assert.True(t, dispatchLongPress(fm, nil, enter))
+	fm.FocusByID("held")
+	assert.True(t, dispatchLongPress(fm, nil, enter))
+	assert.Equal(t, []string{"outer", "held"}, pressed)
+
+	fm.SetFocusables([]FocusableEntry{{ID: "plain", Focusable: &Button{ID: "plain"}}})
+	assert.False(t, dispatchLongPress(fm, nil, enter))
+}
+
+// spatialGrid is laid out as:
+//
+//	[a] [b]
+//	[c]          [d]
+func spatialGrid(t *testing.T) (*FocusManager, []WidgetEntry) {
+	t.Helper()
+	fm := NewFocusManager()
+	renderer := NewRenderer(uv.NewBuffer(30, 4), 30, 4, fm, NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil))
+	focusables := renderer.Render(Column{Children: []Widget{
+		Row{Spacing: 1, Children: []Widget{
+			&Button{ID: "a", Label: "a"},
+			&Button{ID: "b", Label: "b"},
+		}},
+		Row{Spacing: 1, Children: []Widget{
+			&Button{ID: "c", Label: "c"},
+			Spacer{Width: Cells(8)},
+			&Button{ID: "d", Label: "d"},
+		}},
+	}})
+	fm.SetFocusables(focusables)
+	return fm, renderer.widgetRegistry.Entries()
+}
+
+func TestDispatchSpatialFocus_MovesToTheNearestWidgetInDirection(t *testing.T) {
+	useKioskProfile(t)
+	fm, entries := spatialGrid(t)
+	require.Equal(t, "a", fm.FocusedID())
+
+	move := func(code rune) string {
+		dispatchSpatialFocus(fm, entries, makeKeyEvent(code, 0))
+		return fm.FocusedID()
+	}
+	assert.Equal(t, "b", move(uv.KeyRight))
+	assert.Equal(t, "d", move(uv.KeyRight), "d is below b's line but beyond it")
+	assert.Equal(t, "b", move(uv.KeyUp), "b is nearer in line than a")
+	assert.Equal(t, "c", move(uv.KeyDown), "c is nearer in line than d")
+	assert.Equal(t, "a", move(uv.KeyUp))
+
+	assert.False(t, dispatchSpatialFocus(fm, entries, makeKeyEvent(uv.KeyUp, 0)), "nothing above")
+	assert.Equal(t, "a", fm.FocusedID())
+}
+
+func TestDispatchSpatialFocus_OnlyUnderKioskProfile(t *testing.T) {
+	fm, entries := spatialGrid(t)
+
+	assert.False(t, dispatchSpatialFocus(fm, entries, makeKeyEvent(uv.KeyRight, 0)))
+	assert.Equal(t, "a", fm.FocusedID())
+}
+
+func TestOpenKioskKeyboard_TypesIntoTheFocusedTextField(t *testing.T) {
+	useKioskProfile(t)
+	state := NewTextInputState("")
+	fm := NewFocusManager()
+	fm.SetFocusables([]FocusableEntry{
+		{ID: "button", Focusable: &Button{ID: "button"}},
+		{ID: "name", Focusable: TextInput{ID: "name", State: state}},
+	})
+	enter := KeyEvent{event: enterPress(false)}
+
+	assert.False(t, openKioskKeyboard(fm, enter), "not a text field")
+	fm.FocusByID("name")
+	require.True(t, openKioskKeyboard(fm, enter))
+	assert.Equal(t, []string{"escape", "enter"}, keybindKeys(fm.ActiveKeybinds()))
+
+	// "q" is one row down from "1"
+	assert.True(t, dispatchInputScope(makeKeyEvent(uv.KeyDown, 0)))
+	assert.True(t, dispatchInputScope(enter))
+	assert.True(t, dispatchInputScope(makeKeyEvent(uv.KeyRight, 0)))
+	assert.True(t, dispatchInputScope(enter))
+	assert.Equal(t, "qw", state.GetText())
+
+	assert.True(t, dispatchInputScope(makeKeyEvent(uv.KeyEscape, 0)))
+	assert.Nil(t, kioskKeyboard.Peek())
+	assert.Nil(t, activeInputScope())
+}
+
+func TestDispatchLongPress_ClosesTheKeyboard(t *testing.T) {
+	useKioskProfile(t)
+	fm := NewFocusManager()
+	fm.SetFocusables([]FocusableEntry{{ID: "name", Focusable: TextInput{ID: "name", State: NewTextInputState("")}}})
+	enter := KeyEvent{event: enterPress(false)}
+	require.True(t, openKioskKeyboard(fm, enter))
+
+	assert.True(t, dispatchLongPress(fm, nil, enter))
+	assert.Nil(t, kioskKeyboard.Peek())
+	assert.Nil(t, activeInputScope())
+}
+
+func TestOpenKioskKeyboard_UsesTheConfiguredLayout(t *testing.T) {
+	useKioskProfile(t)
+	SetOnScreenKeyboardLayout(&KeyboardLayout{Layers: []KeyboardLayer{{Rows: [][]KeyboardKey{KeyboardTextKeys("xyz")}}}})
+	t.Cleanup(func() { SetOnScreenKeyboardLayout(nil) })
+	state := NewTextInputState("")
+	fm := NewFocusManager()
+	fm.SetFocusables([]FocusableEntry{{ID: "name", Focusable: TextInput{ID: "name", State: state}}})
+	enter := KeyEvent{event: enterPress(false)}
+
+	require.True(t, openKioskKeyboard(fm, enter))
+	dispatchInputScope(makeKeyEvent(uv.KeyRight, 0))
+	dispatchInputScope(enter)
+
+	assert.Equal(t, "y", state.GetText())
+}
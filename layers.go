@@ -0,0 +1,109 @@
+package terma
+
+import (
+	"slices"
+	"sort"
+)
+
+// LayerInput is how a Layer takes input.
+type LayerInput int
+
+const (
+	// LayerPassive only displays: its widgets never take focus, and mouse
+	// events reach whatever is underneath (default). For HUDs, status
+	// readouts and notifications.
+	LayerPassive LayerInput = iota
+	// LayerInteractive widgets take focus and mouse presses like the app's
+	// own, with Tab moving between both. Presses outside the layer reach
+	// the app, so size it to its content.
+	LayerInteractive
+	// LayerModal takes all input while it is shown: everything below it is
+	// dimmed, focus stays within it and presses outside it are blocked.
+	LayerModal
+)
+
+// Layer is a widget tree drawn over the whole app, independent of the
+// app's own Build: a debug HUD, a notification stack or coach marks can be
+// added and removed without threading them through the root widget.
+//
+// Each layer's Widget is built every frame, subscribing to the signals it
+// reads like any widget, and placed like a Floating at Position.
+type Layer struct {
+	Name          string        // Identifies the layer; adding a layer with the same name replaces it
+	Widget        Widget        // Root of the layer's widget tree
+	Position      FloatPosition // Where the tree is placed (default: at Offset from the top-left corner)
+	Offset        Offset        // Offset from Position
+	Input         LayerInput    // How the layer takes input (default: LayerPassive)
+	Order         int           // Layers are drawn in ascending Order, later ones on top; equal Orders in the order added
+	BackdropColor Color         // Backdrop of a LayerModal (default: theme.Overlay)
+}
+
+// layers holds the layers added with AddLayer, sorted for drawing.
+var layers = NewAnySignal[[]Layer](nil)
+
+// AddLayer adds a layer drawn above the app, its floats and any layer
+// with a lower Order, or replaces the layer with the same name.
+//
+// Example:
+//
+//	t.AddLayer(t.Layer{
+//	    Name:     "fps",
+//	    Position: t.FloatPositionTopRight,
+//	    Widget:   StatsHUD{Stats: a.stats}, // Reads a.stats in its Build
+//	    Order:    10,
+//	})
+func AddLayer(layer Layer) {
+	layers.Update(func(current []Layer) []Layer {
+		next := slices.DeleteFunc(slices.Clone(current), func(l Layer) bool { return l.Name == layer.Name })
+		next = append(next, layer)
+		sort.SliceStable(next, func(i, j int) bool { return next[i].Order < next[j].Order })
+		return next
+	})
+}
+
+// RemoveLayer removes the layer with the given name.
+func RemoveLayer(name string) {
+	layers.Update(func(current []Layer) []Layer {
+		return slices.DeleteFunc(slices.Clone(current), func(l Layer) bool { return l.Name == name })
+	})
+}
+
+// Layers returns the names of the layers added, in drawing order.
+func Layers() []string {
+	var names []string
+	for _, layer := range layers.Peek() {
+		names = append(names, layer.Name)
+	}
+	return names
+}
+
+// buildLayers registers each layer with the float collector, so they are
+// painted after the app's floats.
+func buildLayers(ctx BuildContext) {
+	if ctx.floatCollector == nil {
+		return
+	}
+	for _, layer := range layers.Peek() {
+		if layer.Widget == nil {
+			continue
+		}
+		entry := FloatEntry{
+			Config: FloatConfig{Position: layer.Position, Offset: layer.Offset},
+			Child:  layer.Widget,
+			portal: true,
+			clamp:  true,
+		}
+		switch layer.Input {
+		case LayerPassive:
+			entry.Child = Inert(layer.Widget)
+			entry.ignorePointer = true
+		case LayerModal:
+			// A modal layer behaves as a modal float: it traps focus and
+			// blocks presses outside it.
+			entry.Config.Modal = true
+			entry.Config.BackdropColor = layer.BackdropColor
+			entry.portal = false
+		}
+		ctx.floatCollector.Add(entry)
+	}
+}
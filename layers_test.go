@@ -0,0 +1,60 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addTestLayer adds a layer for the length of the test.
+func addTestLayer(t *testing.T, layer Layer) {
+	t.Helper()
+	AddLayer(layer)
+	t.Cleanup(func() { RemoveLayer(layer.Name) })
+}
+
+func TestLayers_DrawnInOrderAboveTheApp(t *testing.T) {
+	addTestLayer(t, Layer{Name: "top", Widget: Text{Content: "TOP"}, Offset: Offset{X: 1}, Order: 2})
+	addTestLayer(t, Layer{Name: "bottom", Widget: Text{Content: "under"}, Order: 1})
+	addTestLayer(t, Layer{Name: "hud", Widget: Text{Content: "hud"}, Position: FloatPositionBottomRight})
+	assert.Equal(t, []string{"hud", "bottom", "top"}, Layers())
+
+	renderer, buf := portalTestRenderer(12, 3)
+	renderer.Render(Text{Content: "application"})
+	assert.Equal(t, "uTOPrcation ", bufferLine(buf, 0, 12))
+	assert.Equal(t, "         hud", bufferLine(buf, 2, 12))
+
+	RemoveLayer("top")
+	renderer.Render(Text{Content: "application"})
+	assert.Equal(t, "undercation ", bufferLine(buf, 0, 12))
+}
+
+func TestLayers_PassiveLayerTakesNoInput(t *testing.T) {
+	addTestLayer(t, Layer{Name: "hud", Widget: Button{ID: "hud-button", Label: "x"}})
+
+	renderer, _ := portalTestRenderer(20, 3)
+	focusables := renderer.Render(Button{ID: "app-button", Label: "app"})
+	require.Len(t, focusables, 1)
+	assert.Equal(t, "app-button", focusables[0].ID)
+	assert.Nil(t, renderer.FloatAt(0, 0))
+	assert.Equal(t, "app-button", renderer.WidgetAt(0, 0).ID, "presses reach the app under the layer")
+}
+
+func TestLayers_InteractiveAndModalLayers(t *testing.T) {
+	addTestLayer(t, Layer{Name: "coach", Widget: Button{ID: "got-it", Label: "Got it"}, Position: FloatPositionBottomLeft, Input: LayerInteractive})
+
+	renderer, _ := portalTestRenderer(20, 3)
+	var ids []string
+	for _, focusable := range renderer.Render(Button{ID: "app-button", Label: "app"}) {
+		ids = append(ids, focusable.ID)
+	}
+	assert.Equal(t, []string{"app-button", "got-it"}, ids, "Tab moves between the app and the layer")
+	assert.Equal(t, "got-it", renderer.WidgetAt(0, 2).ID)
+	assert.False(t, renderer.HasModalFloat())
+
+	addTestLayer(t, Layer{Name: "coach", Widget: Button{ID: "got-it", Label: "Got it"}, Position: FloatPositionCenter, Input: LayerModal})
+	renderer.Render(Button{ID: "app-button", Label: "app"})
+	assert.True(t, renderer.HasModalFloat(), "a modal layer blocks presses outside it")
+	assert.Equal(t, 1, renderer.ModalCount())
+}
@@ -29,6 +29,10 @@ const (
 	CrossAxisCenter
 	// CrossAxisEnd aligns children at the end of the cross axis.
 	CrossAxisEnd
+	// CrossAxisBaseline lines up the first line of text in each child of a
+	// Row, so a label sits level with the text inside a padded or bordered
+	// neighbour. In a Column it behaves like CrossAxisStart.
+	CrossAxisBaseline
 )
 
 // Row arranges its children horizontally.
@@ -111,24 +115,10 @@ func (r Row) Build(ctx BuildContext) Widget {
 // BuildLayoutNode builds a layout node for this Row widget.
 // Implements the LayoutNodeBuilder interface.
 func (r Row) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
-	children := make([]layout.LayoutNode, len(r.Children))
-	for i, child := range r.Children {
-		childCtx := ctx.PushChild(i)
-		built := child.Build(childCtx)
-
-		// Build the child's layout node
-		var childNode layout.LayoutNode
-		if builder, ok := built.(LayoutNodeBuilder); ok {
-			childNode = builder.BuildLayoutNode(childCtx)
-		} else {
-			// Fallback: create a BoxNode for widgets without LayoutNodeBuilder
-			childNode = buildFallbackLayoutNode(built, childCtx)
-		}
-
-		// Wrap in FlexNode or PercentNode if child has Flex/Percent width (Row's main axis is horizontal)
-		mainAxisDim := getChildMainAxisDimension(built, true)
-		childNode = wrapInPercentIfNeeded(childNode, mainAxisDim, layout.Horizontal)
-		children[i] = wrapInFlexIfNeeded(childNode, mainAxisDim)
+	widgetChildren := arrangeChildren(r.Children)
+	children := make([]layout.LayoutNode, len(widgetChildren))
+	for i, child := range widgetChildren {
+		children[i] = ChildLayoutNode(ctx.PushChild(i), child, layout.Horizontal)
 	}
 
 	padding := toLayoutEdgeInsets(r.Style.Padding)
@@ -259,24 +249,10 @@ func (c Column) Build(ctx BuildContext) Widget {
 
 // BuildLayoutNode creates a ColumnNode for the layout system.
 func (c Column) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
-	children := make([]layout.LayoutNode, len(c.Children))
-	for i, child := range c.Children {
-		childCtx := ctx.PushChild(i)
-		built := child.Build(childCtx)
-
-		// Build the child's layout node
-		var childNode layout.LayoutNode
-		if builder, ok := built.(LayoutNodeBuilder); ok {
-			childNode = builder.BuildLayoutNode(childCtx)
-		} else {
-			// Fallback: create a BoxNode for widgets without LayoutNodeBuilder
-			childNode = buildFallbackLayoutNode(built, childCtx)
-		}
-
-		// Wrap in FlexNode or PercentNode if child has Flex/Percent height (Column's main axis is vertical)
-		mainAxisDim := getChildMainAxisDimension(built, false)
-		childNode = wrapInPercentIfNeeded(childNode, mainAxisDim, layout.Vertical)
-		children[i] = wrapInFlexIfNeeded(childNode, mainAxisDim)
+	widgetChildren := arrangeChildren(c.Children)
+	children := make([]layout.LayoutNode, len(widgetChildren))
+	for i, child := range widgetChildren {
+		children[i] = ChildLayoutNode(ctx.PushChild(i), child, layout.Vertical)
 	}
 
 	padding := toLayoutEdgeInsets(c.Style.Padding)
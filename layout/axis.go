@@ -55,4 +55,9 @@ const (
 	// CrossAxisStretch stretches children to fill the cross axis.
 	// Children are re-laid out with tight cross-axis constraints.
 	CrossAxisStretch
+
+	// CrossAxisBaseline lines up the first line of text in each child of a
+	// horizontal container, however much padding or border sits above it.
+	// Vertical containers treat it as CrossAxisStart.
+	CrossAxisBaseline
 )
@@ -175,6 +175,18 @@ type ComputedLayout struct {
 	Children []PositionedChild
 }
 
+// Baseline returns the row of the first line of text, counted from the top
+// of the border box. For containers it is the first child's baseline; for
+// leaves it is the first content row.
+func (c ComputedLayout) Baseline() int {
+	top := c.Box.Border.Top + c.Box.Padding.Top
+	if len(c.Children) == 0 {
+		return top
+	}
+	first := c.Children[0]
+	return top + first.Y + first.Layout.Baseline()
+}
+
 // PositionedChild is a child with its computed position.
 //
 // Coordinate system: X and Y specify the child's border-box position
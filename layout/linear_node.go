@@ -83,6 +83,16 @@ func (l *LinearNode) ComputeLayout(constraints Constraints) ComputedLayout {
 		}
 	}
 
+	// Baseline alignment can push children apart, needing more cross space
+	if l.alignsBaselines() {
+		_, above := l.baselineOffsets(childLayouts)
+		below := 0
+		for _, layout := range childLayouts {
+			below = max(below, layout.Box.MarginBoxHeight()-marginBaseline(layout))
+		}
+		maxCross = max(maxCross, above+below)
+	}
+
 	// Step 6: Re-determine container cross size with flex children included
 	_, crossMax := l.crossConstraint(contentConstraints)
 	crossMin, _ := l.crossConstraint(contentConstraints)
@@ -445,6 +455,7 @@ func (l *LinearNode) positionChildren(
 	contentConstraints Constraints,
 ) []PositionedChild {
 	positioned := make([]PositionedChild, len(childLayouts))
+	baselines, maxBaseline := l.baselineOffsets(childLayouts)
 
 	for i, layout := range childLayouts {
 		childCross := l.crossSize(layout.Box.MarginBoxWidth(), layout.Box.MarginBoxHeight())
@@ -461,6 +472,11 @@ func (l *LinearNode) positionChildren(
 		case CrossAxisEnd:
 			crossPos = containerCross - childCross
 
+		case CrossAxisBaseline:
+			if l.alignsBaselines() {
+				crossPos = maxBaseline - baselines[i]
+			}
+
 		case CrossAxisStretch:
 			// Get the actual child (unwrap FlexNode/PercentNode if present)
 			actualChild := l.Children[i]
@@ -517,6 +533,34 @@ func (l *LinearNode) positionChildren(
 	return positioned
 }
 
+// alignsBaselines reports whether children are lined up by their baselines,
+// which only applies along a horizontal axis.
+func (l *LinearNode) alignsBaselines() bool {
+	return l.CrossAlign == CrossAxisBaseline && l.Axis == Horizontal
+}
+
+// baselineOffsets returns each child's baseline measured from the top of
+// its margin box, and the largest of them. Both are nil/zero unless
+// children are aligned by baseline.
+func (l *LinearNode) baselineOffsets(childLayouts []ComputedLayout) ([]int, int) {
+	if !l.alignsBaselines() {
+		return nil, 0
+	}
+	offsets := make([]int, len(childLayouts))
+	maxOffset := 0
+	for i, layout := range childLayouts {
+		offsets[i] = marginBaseline(layout)
+		maxOffset = max(maxOffset, offsets[i])
+	}
+	return offsets, maxOffset
+}
+
+// marginBaseline returns a layout's baseline measured from the top of its
+// margin box.
+func marginBaseline(layout ComputedLayout) int {
+	return layout.Box.Margin.Top + layout.Baseline()
+}
+
 // makeStretchConstraintsPreserveMain creates constraints for stretching a child on the cross axis
 // while preserving its main-axis size. This is critical for flex children whose main-axis
 // size was determined by flex distribution and must not revert to natural size.
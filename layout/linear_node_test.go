@@ -844,6 +844,38 @@ func TestLinearNode_ColumnCrossAxisAlignment(t *testing.T) {
 	})
 }
 
+func TestLinearNode_BaselineAlignment(t *testing.T) {
+	label := box(5, 1)
+	bordered := &BoxNode{Width: 5, Height: 4, Border: EdgeInsetsAll(1), Padding: EdgeInsets{Top: 1}}
+	nested := &ColumnNode{
+		Margin:   EdgeInsets{Top: 1},
+		Children: []LayoutNode{&BoxNode{Width: 3, Height: 2, Padding: EdgeInsets{Top: 1}}},
+	}
+
+	t.Run("Row", func(t *testing.T) {
+		row := &RowNode{CrossAlign: CrossAxisBaseline, Children: []LayoutNode{label, bordered, nested}}
+		result := row.ComputeLayout(Loose(100, 50))
+
+		// The bordered box's text is 2 rows down, so the others move down to match.
+		assert.Equal(t, 2, result.Children[0].Y)
+		assert.Equal(t, 0, result.Children[1].Y)
+		assert.Equal(t, 1, result.Children[2].Y, "margin and nested padding count towards the baseline")
+		for i, child := range result.Children {
+			assert.Equal(t, 2, child.Y+child.Layout.Baseline(), "child %d", i)
+		}
+		assert.Equal(t, 4, result.Box.Height, "tall enough for the shifted children")
+		assert.Equal(t, 2, result.Baseline())
+	})
+
+	t.Run("ColumnActsAsStart", func(t *testing.T) {
+		col := &ColumnNode{CrossAlign: CrossAxisBaseline, Children: []LayoutNode{label, bordered}}
+		result := col.ComputeLayout(Loose(100, 50))
+
+		assert.Equal(t, 0, result.Children[0].X)
+		assert.Equal(t, 0, result.Children[1].X)
+	})
+}
+
 func TestLinearNode_ContainerInsets(t *testing.T) {
 	t.Run("Padding", func(t *testing.T) {
 		row := &RowNode{
@@ -2,6 +2,7 @@ package layout
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/charmbracelet/x/ansi"
 )
@@ -36,7 +37,8 @@ func MeasureText(content string, wrap WrapMode, maxWidth int) (width, height int
 		return 0, 0
 	}
 
-	lines := wrapTextLines(content, wrap, maxWidth)
+	buf := linePool.Get().(*[]string)
+	lines := wrapTextLines((*buf)[:0], content, wrap, maxWidth)
 
 	height = len(lines)
 	for _, line := range lines {
@@ -46,42 +48,41 @@ func MeasureText(content string, wrap WrapMode, maxWidth int) (width, height int
 		}
 	}
 
+	clear(lines)
+	*buf = lines[:0]
+	linePool.Put(buf)
 	return width, height
 }
 
-// wrapTextLines splits and wraps text into lines based on wrap mode and max width.
-func wrapTextLines(content string, wrap WrapMode, maxWidth int) []string {
-	// Split by explicit newlines first
-	inputLines := strings.Split(content, "\n")
-
-	// If no wrapping or unbounded, just return input lines
-	if wrap == WrapNone || maxWidth <= 0 {
-		return inputLines
-	}
+// linePool holds the scratch slices MeasureText wraps lines into, so
+// measuring text on every frame doesn't allocate a slice of lines each time.
+var linePool = sync.Pool{New: func() any { return new([]string) }}
 
-	var result []string
-	for _, line := range inputLines {
-		// If line fits, add as-is
-		if ansi.StringWidth(line) <= maxWidth {
-			result = append(result, line)
-			continue
+// wrapTextLines splits and wraps text into lines based on wrap mode and max
+// width, appending them to dst.
+func wrapTextLines(dst []string, content string, wrap WrapMode, maxWidth int) []string {
+	// Split by explicit newlines first
+	for {
+		line, rest, more := strings.Cut(content, "\n")
+		switch {
+		case wrap == WrapNone || maxWidth <= 0 || ansi.StringWidth(line) <= maxWidth:
+			// No wrapping, unbounded, or the line fits: add as-is
+			dst = append(dst, line)
+		case wrap == WrapChar:
+			dst = wrapLineByChar(dst, line, maxWidth)
+		case wrap == WrapWord:
+			dst = wrapLineByWord(dst, line, maxWidth)
 		}
-
-		// Need to wrap this line
-		switch wrap {
-		case WrapChar:
-			result = append(result, wrapLineByChar(line, maxWidth)...)
-		case WrapWord:
-			result = append(result, wrapLineByWord(line, maxWidth)...)
+		if !more {
+			return dst
 		}
+		content = rest
 	}
-
-	return result
 }
 
-// wrapLineByChar wraps a single line at character boundaries.
-func wrapLineByChar(line string, maxWidth int) []string {
-	var result []string
+// wrapLineByChar wraps a single line at character boundaries, appending the
+// pieces to result.
+func wrapLineByChar(result []string, line string, maxWidth int) []string {
 	remaining := line
 
 	for len(remaining) > 0 {
@@ -99,22 +100,23 @@ func wrapLineByChar(line string, maxWidth int) []string {
 	return result
 }
 
-// wrapLineByWord wraps a single line at word boundaries.
-// Falls back to character breaks for words longer than maxWidth.
-func wrapLineByWord(line string, maxWidth int) []string {
+// wrapLineByWord wraps a single line at word boundaries, appending the
+// pieces to result. Falls back to character breaks for words longer than
+// maxWidth.
+func wrapLineByWord(result []string, line string, maxWidth int) []string {
 	// Use ansi.Wordwrap for word-boundary wrapping
 	wrapped := ansi.Wordwrap(line, maxWidth, "")
-	wrappedLines := strings.Split(wrapped, "\n")
-
-	var result []string
-	for _, wl := range wrappedLines {
+	for {
+		wl, rest, more := strings.Cut(wrapped, "\n")
 		// If a word is longer than maxWidth, we need to break it
 		if ansi.StringWidth(wl) > maxWidth {
-			result = append(result, wrapLineByChar(wl, maxWidth)...)
+			result = wrapLineByChar(result, wl, maxWidth)
 		} else {
 			result = append(result, wl)
 		}
+		if !more {
+			return result
+		}
+		wrapped = rest
 	}
-
-	return result
 }
@@ -23,6 +23,9 @@ type TextNode struct {
 	// Default is WrapNone (no wrapping).
 	Wrap WrapMode
 
+	// MaxLines caps the measured height in lines (0 = unlimited).
+	MaxLines int
+
 	// Insets (passed through to BoxNode)
 	Padding EdgeInsets
 	Border  EdgeInsets
@@ -48,7 +51,11 @@ func (t *TextNode) ComputeLayout(constraints Constraints) ComputedLayout {
 		MinHeight: t.MinHeight,
 		MaxHeight: t.MaxHeight,
 		MeasureFunc: func(c Constraints) (int, int) {
-			return MeasureText(t.Content, t.Wrap, c.MaxWidth)
+			width, height := MeasureText(t.Content, t.Wrap, c.MaxWidth)
+			if t.MaxLines > 0 && height > t.MaxLines {
+				height = t.MaxLines
+			}
+			return width, height
 		},
 	}
 	return box.ComputeLayout(constraints)
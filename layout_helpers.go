@@ -1,6 +1,8 @@
 package terma
 
 import (
+	"slices"
+
 	"github.com/darrenburns/terma/layout"
 )
 
@@ -16,8 +18,7 @@ func toLayoutEdgeInsets(e EdgeInsets) layout.EdgeInsets {
 
 // borderToEdgeInsets converts a Border to layout.EdgeInsets based on border width.
 func borderToEdgeInsets(b Border) layout.EdgeInsets {
-	w := b.Width()
-	return layout.EdgeInsetsAll(w)
+	return toLayoutEdgeInsets(b.Insets())
 }
 
 // toLayoutWrapMode converts terma.WrapMode to layout.WrapMode.
@@ -53,6 +54,8 @@ func toLayoutCrossAlign(a CrossAxisAlign) layout.CrossAxisAlignment {
 		return layout.CrossAxisCenter
 	case CrossAxisEnd:
 		return layout.CrossAxisEnd
+	case CrossAxisBaseline:
+		return layout.CrossAxisBaseline
 	default: // CrossAxisStretch
 		return layout.CrossAxisStretch
 	}
@@ -278,6 +281,12 @@ func wrapInPercentNodesForStack(node layout.LayoutNode, widget Widget) layout.La
 // by adding padding and border. This allows widgets to specify their content size without
 // worrying about the box model - the framework handles adding space for decoration.
 func buildFallbackLayoutNode(widget Widget, ctx BuildContext) layout.LayoutNode {
+	return leafLayoutNode(widget, nil)
+}
+
+// leafLayoutNode creates a BoxNode sized from widget's dimensions and
+// insets, measuring content with measure when it is non-nil.
+func leafLayoutNode(widget Widget, measure func(layout.Constraints) (int, int)) layout.LayoutNode {
 	dims := GetWidgetDimensionSet(widget)
 
 	// Extract style for insets first - we need these to compute border-box dimensions
@@ -301,6 +310,7 @@ func buildFallbackLayoutNode(widget Widget, ctx BuildContext) layout.LayoutNode
 		Margin:    margin,
 		ExpandWidth:  dims.Width.IsFlex() || dims.Width.IsPercent(),
 		ExpandHeight: dims.Height.IsFlex() || dims.Height.IsPercent(),
+		MeasureFunc:  measure,
 	})
 
 	if hasPercentMinMax(dims) {
@@ -317,3 +327,42 @@ func buildFallbackLayoutNode(widget Widget, ctx BuildContext) layout.LayoutNode
 
 	return node
 }
+
+// arrangeChildren returns the children of a Row or Column in layout order:
+// children whose Style.Visible is false are dropped and the rest are
+// stably sorted by Style.Order. The slice is returned unchanged when no
+// child uses either field.
+func arrangeChildren(children []Widget) []Widget {
+	arranged := false
+	for _, child := range children {
+		if style, ok := childStyle(child); ok && (style.Order != 0 || style.Visible != nil) {
+			arranged = true
+			break
+		}
+	}
+	if !arranged {
+		return children
+	}
+
+	visible := make([]Widget, 0, len(children))
+	for _, child := range children {
+		if style, ok := childStyle(child); ok && style.Visible != nil && !*style.Visible {
+			continue
+		}
+		visible = append(visible, child)
+	}
+	slices.SortStableFunc(visible, func(a, b Widget) int {
+		aStyle, _ := childStyle(a)
+		bStyle, _ := childStyle(b)
+		return aStyle.Order - bStyle.Order
+	})
+	return visible
+}
+
+// childStyle returns the style a child widget was declared with.
+func childStyle(child Widget) (Style, bool) {
+	if styled, ok := child.(Styled); ok {
+		return styled.GetStyle(), true
+	}
+	return Style{}, false
+}
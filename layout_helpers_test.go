@@ -0,0 +1,53 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+func rowText(buf *uv.Buffer, width, y int) string {
+	line := ""
+	for x := 0; x < width; x++ {
+		if cell := buf.CellAt(x, y); cell != nil && cell.Content != "" {
+			line += cell.Content
+		} else {
+			line += " "
+		}
+	}
+	return line
+}
+
+func TestRow_OrdersChildren(t *testing.T) {
+	widget := Row{Children: []Widget{
+		Text{Content: "A", Style: Style{Order: 2}},
+		Text{Content: "B"},
+		Text{Content: "C", Style: Style{Order: -1}},
+		Text{Content: "D"},
+	}}
+
+	buf := renderWithPointer(widget, 4, 1, "", "")
+	assert.Equal(t, "CBDA", rowText(buf, 4, 0), "equal orders keep their position")
+}
+
+func TestColumn_HidesInvisibleChildren(t *testing.T) {
+	widget := Column{Children: []Widget{
+		Text{Content: "A"},
+		Text{Content: "B", Style: Style{Visible: BoolPtr(false)}},
+		Text{Content: "C", Style: Style{Visible: BoolPtr(true)}},
+	}}
+
+	buf := renderWithPointer(widget, 1, 3, "", "")
+	assert.Equal(t, "A", rowText(buf, 1, 0))
+	assert.Equal(t, "C", rowText(buf, 1, 1), "the hidden child takes no space")
+	assert.Equal(t, " ", rowText(buf, 1, 2))
+}
+
+func TestArrangeChildren_UnchangedWithoutOrderOrVisible(t *testing.T) {
+	children := []Widget{Text{Content: "A"}, Spacer{}, Text{Content: "B"}}
+	assert.Equal(t, children, arrangeChildren(children))
+
+	arranged := arrangeChildren([]Widget{Spacer{}, Text{Content: "A", Style: Style{Order: -1}}})
+	assert.Equal(t, []Widget{Text{Content: "A", Style: Style{Order: -1}}, Spacer{}}, arranged, "widgets without a style sort as order 0")
+}
@@ -0,0 +1,346 @@
+package terma
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/darrenburns/terma/layout"
+)
+
+// LineChartMarker is how a LineChart draws its lines.
+type LineChartMarker int
+
+const (
+	// LineChartBraille draws with braille characters, 2×4 dots per cell.
+	LineChartBraille LineChartMarker = iota
+	// LineChartHalfBlock draws with half blocks, 1×2 per cell: coarser,
+	// but solid and easy to read at a glance.
+	LineChartHalfBlock
+)
+
+// LineChartSeries is one line on a LineChart: values at evenly spaced
+// positions along the X axis. NaN values leave a gap in the line.
+type LineChartSeries struct {
+	Name   string               // Shown in the legend
+	Values []float64            // The data
+	Data   AnySignal[[]float64] // Live data, used instead of Values when set; the chart redraws as it changes
+	Color  Color                // Default: a theme color picked by the series' index
+}
+
+// LineChart draws series of values as lines with sub-cell resolution, with
+// ticked axes and a legend. Where Sparkline shows a trend in one row,
+// LineChart shows its shape: braille gives each cell 2×4 dots, and half
+// blocks 1×2.
+//
+// The X axis is the position of each value. Set Window to show only the
+// latest values of live data, which then scrolls from the right as values
+// are appended.
+//
+// Example:
+//
+//	lo, hi := 0.0, 100.0
+//	terma.LineChart{
+//	    Series: []terma.LineChartSeries{
+//	        {Name: "cpu", Data: a.cpu},
+//	        {Name: "mem", Data: a.mem},
+//	    },
+//	    Window: 120,
+//	    MinY:   &lo,
+//	    MaxY:   &hi,
+//	    Style:  terma.Style{Height: terma.Cells(12)},
+//	}
+type LineChart struct {
+	ID         string                     // Optional unique identifier
+	Series     []LineChartSeries          // The lines to draw
+	Marker     LineChartMarker            // Characters to draw with (default: braille)
+	Window     int                        // Show the last Window values, aligned at the right (0 = all, aligned at the left)
+	MinY, MaxY *float64                   // Optional fixed Y range (default: fit the data)
+	XTicks     int                        // Number of X axis ticks (default: one per 12 columns, at least 2)
+	YTicks     int                        // Number of Y axis ticks (default: one per 4 rows, at least 2)
+	XFormat    func(value float64) string // Formats X tick labels, given the position of the value under the tick (default: the position)
+	YFormat    func(value float64) string // Formats Y tick labels (default: 4 significant digits)
+	HideAxes   bool                       // Draw the lines only, without axes and labels
+	HideLegend bool                       // Hide the legend of named series
+	Style      Style                      // Optional styling
+}
+
+// Build returns itself as LineChart is a leaf widget, subscribing to the
+// live data of its series.
+func (c LineChart) Build(ctx BuildContext) Widget {
+	for _, s := range c.Series {
+		if s.Data.IsValid() {
+			s.Data.Get()
+		}
+	}
+	return c
+}
+
+// WidgetID returns the chart's unique identifier.
+// Implements the Identifiable interface.
+func (c LineChart) WidgetID() string {
+	return c.ID
+}
+
+// GetContentDimensions returns the width and height dimension preferences.
+// Width defaults to Flex(1), Height defaults to Cells(10).
+func (c LineChart) GetContentDimensions() (width, height Dimension) {
+	dims := c.Style.GetDimensions()
+	width, height = dims.Width, dims.Height
+	if width.IsUnset() {
+		width = Flex(1)
+	}
+	if height.IsUnset() {
+		height = Cells(10)
+	}
+	return width, height
+}
+
+// GetStyle returns the style of the chart.
+func (c LineChart) GetStyle() Style {
+	return c.Style
+}
+
+// BuildLayoutNode builds a layout node for this LineChart widget.
+func (c LineChart) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return monitorLayoutNode(c, c.Style)
+}
+
+// values returns the values of each series shown, and the number of X
+// positions they span.
+func (c LineChart) values() ([][]float64, int) {
+	values := make([][]float64, len(c.Series))
+	count := 0
+	for i, s := range c.Series {
+		v := s.Values
+		if s.Data.IsValid() {
+			v = s.Data.Peek()
+		}
+		if c.Window > 0 && len(v) > c.Window {
+			v = v[len(v)-c.Window:]
+		}
+		values[i] = v
+		count = max(count, len(v))
+	}
+	if c.Window > 0 {
+		count = c.Window
+	}
+	return values, count
+}
+
+// view returns the range shown: every X position, and the fixed Y range
+// or the range of the values on any side without one.
+func (c LineChart) view(values [][]float64, count int) PlotView {
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		for _, y := range v {
+			if chartFinite(y) {
+				minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+			}
+		}
+	}
+	if c.MinY != nil {
+		minY = *c.MinY
+	}
+	if c.MaxY != nil {
+		maxY = *c.MaxY
+	}
+	if minY > maxY {
+		minY, maxY = 0, 1
+	}
+	return widenChartView(PlotView{MinX: 0, MaxX: float64(max(0, count-1)), MinY: minY, MaxY: maxY})
+}
+
+// lineChartCell is what the series drew in one cell.
+type lineChartCell struct {
+	bits   rune   // Braille dots
+	series int    // Series that drew a braille dot last
+	halves [2]int // Series drawing the upper and lower half block, plus one; 0 if none
+}
+
+// Render draws the axes, the lines and the legend.
+func (c LineChart) Render(ctx *RenderContext) {
+	if ctx.Width <= 0 || ctx.Height <= 0 {
+		return
+	}
+	theme := ctx.buildContext.Theme()
+	values, count := c.values()
+	view := c.view(values, count)
+	area := chartArea{view: view, width: ctx.Width, height: ctx.Height}
+	if !c.HideAxes {
+		area = c.drawAxes(ctx, theme, view)
+	}
+	if area.width <= 0 || area.height <= 0 {
+		return
+	}
+
+	dotsX, dotsY := 2, 4
+	if c.Marker == LineChartHalfBlock {
+		dotsX, dotsY = 1, 2
+	}
+	width, height := area.width*dotsX, area.height*dotsY
+	cells := make([][]lineChartCell, area.height)
+	for y := range cells {
+		cells[y] = make([]lineChartCell, area.width)
+	}
+	setDot := func(dx, dy, series int) {
+		if dx < 0 || dy < 0 || dx >= width || dy >= height {
+			return
+		}
+		cell := &cells[dy/dotsY][dx/dotsX]
+		if c.Marker == LineChartHalfBlock {
+			cell.halves[dy%2] = series + 1
+			return
+		}
+		cell.bits |= brailleDots[dx%2][3-dy%4]
+		cell.series = series
+	}
+	dot := func(x, y float64) (float64, float64) {
+		return (x - view.MinX) / view.width() * float64(width-1),
+			(view.MaxY - y) / view.height() * float64(height-1)
+	}
+
+	for i, v := range values {
+		offset := 0
+		if c.Window > 0 {
+			offset = count - len(v)
+		}
+		havePrev := false
+		var prevX, prevY float64
+		for j, y := range v {
+			if !chartFinite(y) {
+				havePrev = false
+				continue
+			}
+			dx, dy := dot(float64(offset+j), y)
+			if havePrev {
+				x0, y0, x1, y1, ok := clipPlotSegment(prevX, prevY, dx, dy, float64(width-1), float64(height-1))
+				if ok {
+					plotLine(int(math.Round(x0)), int(math.Round(y0)), int(math.Round(x1)), int(math.Round(y1)), func(x, y int) {
+						setDot(x, y, i)
+					})
+				}
+			} else {
+				setDot(int(math.Round(dx)), int(math.Round(dy)), i)
+			}
+			prevX, prevY, havePrev = dx, dy, true
+		}
+	}
+
+	color := func(series int) Color {
+		return chartColor(theme, c.Series[series].Color, series)
+	}
+	for y, row := range cells {
+		for x, cell := range row {
+			switch upper, lower := cell.halves[0], cell.halves[1]; {
+			case cell.bits != 0:
+				ctx.DrawStyledText(area.left+x, area.top+y, string(0x2800+cell.bits), Style{ForegroundColor: color(cell.series)})
+			case upper != 0 && lower != 0 && upper != lower:
+				ctx.DrawStyledText(area.left+x, area.top+y, "▀", Style{ForegroundColor: color(upper - 1), BackgroundColor: color(lower - 1)})
+			case upper != 0 && lower != 0:
+				ctx.DrawStyledText(area.left+x, area.top+y, "█", Style{ForegroundColor: color(upper - 1)})
+			case upper != 0:
+				ctx.DrawStyledText(area.left+x, area.top+y, "▀", Style{ForegroundColor: color(upper - 1)})
+			case lower != 0:
+				ctx.DrawStyledText(area.left+x, area.top+y, "▄", Style{ForegroundColor: color(lower - 1)})
+			}
+		}
+	}
+
+	if !c.HideLegend {
+		entries := make([]chartLegendEntry, len(c.Series))
+		for i, s := range c.Series {
+			entries[i] = chartLegendEntry{name: s.Name, color: color(i)}
+		}
+		drawChartLegend(ctx, theme, area, entries)
+	}
+}
+
+// drawAxes draws the Y axis with its ticks and labels on the left and the
+// X axis with its ticks and labels along the bottom, and returns the area
+// left for the lines.
+func (c LineChart) drawAxes(ctx *RenderContext, theme ThemeData, view PlotView) chartArea {
+	yFormat, xFormat := chartFormat(c.YFormat), chartFormat(c.XFormat)
+	area := chartArea{view: view, height: ctx.Height - 2}
+	if area.height <= 0 {
+		return chartArea{}
+	}
+
+	yLabels := map[int]string{}
+	for _, row := range lineChartTicks(c.YTicks, area.height, 4) {
+		fraction := 0.0
+		if area.height > 1 {
+			fraction = float64(row) / float64(area.height-1)
+		}
+		yLabels[row] = yFormat(view.MaxY - fraction*view.height())
+	}
+	labelWidth := 0
+	for _, label := range yLabels {
+		labelWidth = max(labelWidth, ansi.StringWidth(label))
+	}
+	area.left = labelWidth + 1
+	area.width = ctx.Width - area.left
+	if area.width <= 0 {
+		return chartArea{}
+	}
+
+	labelStyle := Style{ForegroundColor: theme.TextMuted}
+	axisStyle := Style{ForegroundColor: theme.Border}
+	for y := 0; y < area.height; y++ {
+		axis := "│"
+		if label, ok := yLabels[y]; ok {
+			ctx.DrawStyledText(labelWidth-ansi.StringWidth(label), y, label, labelStyle)
+			axis = "┤"
+		}
+		ctx.DrawStyledText(labelWidth, y, axis, axisStyle)
+	}
+
+	axis := []rune("└" + strings.Repeat("─", area.width))
+	labelY := area.height + 1
+	nextFree := area.left // First column a label may start at
+	for _, col := range lineChartTicks(c.XTicks, area.width, 12) {
+		// X values are positions, so ticks go on whole ones.
+		fraction := 0.0
+		if area.width > 1 {
+			fraction = float64(col) / float64(area.width-1)
+		}
+		value := math.Round(view.MinX + fraction*view.width())
+		col = int(math.Round((value - view.MinX) / view.width() * float64(area.width-1)))
+		if col < 0 || col >= area.width {
+			continue
+		}
+		axis[col+1] = '┬'
+		label := xFormat(value)
+		labelWidth := ansi.StringWidth(label)
+		x := area.left + col - labelWidth/2
+		x = max(area.left, min(x, area.left+area.width-labelWidth))
+		if x < nextFree {
+			continue
+		}
+		ctx.DrawStyledText(x, labelY, label, labelStyle)
+		nextFree = x + labelWidth + 1
+	}
+	ctx.DrawStyledText(labelWidth, area.height, string(axis), axisStyle)
+	return area
+}
+
+// lineChartTicks returns the positions of count ticks spread evenly over
+// size cells, the first at 0 and the last at size-1. A count of 0 picks
+// one tick per spacing cells.
+func lineChartTicks(count, size, spacing int) []int {
+	if size <= 0 {
+		return nil
+	}
+	if count <= 0 {
+		count = size/spacing + 1
+	}
+	count = max(2, min(count, size))
+	if size == 1 {
+		return []int{0}
+	}
+	ticks := make([]int, count)
+	for i := range ticks {
+		ticks[i] = int(math.Round(float64(i) * float64(size-1) / float64(count-1)))
+	}
+	return ticks
+}
@@ -0,0 +1,95 @@
+package terma
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func renderLineChart(t *testing.T, chart LineChart, width, height int) []string {
+	t.Helper()
+	renderer, buf := portalTestRenderer(width, height)
+	chart.Style.Width = Cells(width)
+	chart.Style.Height = Cells(height)
+	renderer.Render(chart)
+	lines := make([]string, height)
+	for y := range lines {
+		lines[y] = bufferLine(buf, y, width)
+	}
+	return lines
+}
+
+func TestLineChart_BrailleLine(t *testing.T) {
+	chart := LineChart{HideAxes: true, Series: []LineChartSeries{{Values: []float64{0, 1, 2, 3}}}}
+
+	lines := renderLineChart(t, chart, 2, 1)
+
+	assert.Equal(t, []string{"⡠⠊"}, lines, "a rising line across 4×4 dots")
+}
+
+func TestLineChart_HalfBlocks(t *testing.T) {
+	chart := LineChart{HideAxes: true, Marker: LineChartHalfBlock, Series: []LineChartSeries{
+		{Values: []float64{1, 1, 0, math.NaN()}},
+	}}
+
+	lines := renderLineChart(t, chart, 4, 1)
+
+	assert.Equal(t, []string{"▀▀▄ "}, lines, "NaN leaves a gap")
+}
+
+func TestLineChart_WindowFollowsLiveData(t *testing.T) {
+	data := NewAnySignal([]float64{5, 5, 5, 0})
+	chart := LineChart{HideAxes: true, Window: 2, Marker: LineChartHalfBlock, Series: []LineChartSeries{{Data: data}}}
+
+	assert.Equal(t, []string{"▀▄"}, renderLineChart(t, chart, 2, 1))
+
+	data.Update(func(values []float64) []float64 { return append(values, 5) })
+	assert.Equal(t, []string{"▄▀"}, renderLineChart(t, chart, 2, 1))
+
+	short := LineChart{HideAxes: true, Window: 4, Marker: LineChartHalfBlock, Series: []LineChartSeries{{Values: []float64{1}}}}
+	assert.Equal(t, []string{"   ▄"}, renderLineChart(t, short, 4, 1), "a series shorter than the window ends at the right")
+}
+
+func TestLineChart_AxisTicks(t *testing.T) {
+	chart := LineChart{
+		YTicks: 3,
+		XTicks: 3,
+		Series: []LineChartSeries{{Values: []float64{0, 10, 20, 30, 40}}},
+	}
+
+	lines := renderLineChart(t, chart, 14, 7)
+
+	assert.True(t, strings.HasPrefix(lines[0], "40┤"), lines[0])
+	assert.True(t, strings.HasPrefix(lines[2], "20┤"), lines[2])
+	assert.True(t, strings.HasPrefix(lines[4], " 0┤"), lines[4])
+	assert.True(t, strings.HasPrefix(lines[1], "  │"), lines[1])
+	assert.Equal(t, "  └┬────┬────┬", lines[5])
+	assert.Equal(t, "   0    2    4", strings.TrimRight(lines[6], " "))
+}
+
+func TestLineChart_LegendAndSeriesColors(t *testing.T) {
+	chart := LineChart{HideAxes: true, Series: []LineChartSeries{
+		{Name: "cpu", Values: []float64{1, 1}},
+		{Name: "mem", Values: []float64{0, 0}},
+	}}
+
+	lines := renderLineChart(t, chart, 12, 3)
+
+	assert.Contains(t, lines[0], "● cpu")
+	assert.Contains(t, lines[1], "● mem")
+}
+
+func TestLineChart_Snapshot(t *testing.T) {
+	values := make([]float64, 60)
+	for i := range values {
+		values[i] = math.Sin(float64(i) / 6)
+	}
+	cosine := make([]float64, 60)
+	for i := range cosine {
+		cosine[i] = math.Cos(float64(i) / 6)
+	}
+	chart := LineChart{Series: []LineChartSeries{{Name: "sin", Values: values}, {Name: "cos", Values: cosine}}}
+	AssertSnapshot(t, chart, 40, 12, "Sine and cosine as braille lines in two theme colors, with ticked axes and a legend")
+}
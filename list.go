@@ -1,6 +1,9 @@
 package terma
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+)
 
 // ListState holds the state for a List widget.
 // It is the source of truth for items and cursor position, and must be provided to List.
@@ -9,14 +12,35 @@ type ListState[T any] struct {
 	Items       AnySignal[[]T]              // Reactive list data
 	CursorIndex Signal[int]                 // Cursor position
 	Selection   AnySignal[map[int]struct{}] // Selected item indices (for multi-select)
+	Loading     Signal[bool]                // True while items are loading (set by BindList)
+	Err         AnySignal[error]            // Error loading items, or nil (set by BindList)
 
-	anchorIndex *int // Anchor point for shift-selection (nil = no anchor)
+	// KeyFor optionally returns a stable identity for an item. When set,
+	// the cursor, the selection and the item at the top of the viewport
+	// follow their items when SetItems (or any other change) moves them to
+	// new indices, and removed items are deselected.
+	KeyFor func(item T) string
+
+	// OnSelectionChange is called with the selected items after the
+	// selection changes.
+	OnSelectionChange func(selected []T)
+
+	selection *SelectionModel[int] // Created on first use
+	tracker   keyTracker[T]        // Follows the cursor and selection across moves (with KeyFor)
+	scrollTop scrollAnchor         // Item at the top of the viewport (with KeyFor)
+	changes   changeHighlight      // Items ReplaceItemsDiff changed, fading out
+	onScreen  onScreenItems        // Items in view in the last frame, for Refresher
 
 	itemLayouts       []listItemLayout // Cached layout metrics (per item)
 	viewIndices       []int            // View index -> source index for filtered views
 	viewIndexBySource map[int]int      // Source index -> view index for filtered views
 	cachedMatches     []MatchResult    // Cached match results from filtering
 	cachedFilterQuery string           // Query used for cached filter results
+	cachedFilterOpts  FilterOptions    // Options used for cached filter results
+
+	filter *filterEngine[T, MatchResult] // Incremental filtering (created on first use)
+
+	typeAhead *typeAheadBuffer // Text typed for type-ahead (created on first use)
 }
 
 // NewListState creates a new ListState with the given initial items.
@@ -28,6 +52,8 @@ func NewListState[T any](initialItems []T) *ListState[T] {
 		Items:       NewAnySignal(initialItems),
 		CursorIndex: NewSignal(0),
 		Selection:   NewAnySignal(make(map[int]struct{})),
+		Loading:     NewSignal(false),
+		Err:         NewAnySignal[error](nil),
 	}
 }
 
@@ -38,9 +64,33 @@ func (s *ListState[T]) SetItems(items []T) {
 	}
 	s.Items.Set(items)
 	s.resetFilterCache()
+	s.syncKeys()
 	s.clampCursor()
 }
 
+// ReplaceItemsDiff replaces all items like SetItems, for data that is
+// refreshed wholesale, such as by polling an API, and returns what changed.
+// Items are paired with their replacements by KeyFor, or by index without
+// it, and equal decides whether a paired item was updated (nil compares
+// with reflect.DeepEqual). The cursor, selection and scroll position stay
+// on their items, and the inserted and updated items are highlighted
+// briefly, so a refresh shows what changed instead of resetting the view.
+//
+// Example:
+//
+//	state.KeyFor = func(job Job) string { return job.ID }
+//	state.ReplaceItemsDiff(jobs, nil)
+func (s *ListState[T]) ReplaceItemsDiff(items []T, equal func(a, b T) bool) ItemDiff {
+	if items == nil {
+		items = []T{}
+	}
+	old := s.Items.Peek()
+	diff := diffReplacement(old, items, s.KeyFor, equal)
+	s.SetItems(items)
+	s.changes.start(changedKeys(items, len(old) > 0, diff, s.KeyFor))
+	return diff
+}
+
 // GetItems returns the current list data (without subscribing to changes).
 func (s *ListState[T]) GetItems() []T {
 	return s.Items.Peek()
@@ -69,6 +119,7 @@ func (s *ListState[T]) Prepend(item T) {
 	s.CursorIndex.Update(func(i int) int {
 		return i + 1
 	})
+	s.syncKeys()
 }
 
 // InsertAt inserts an item at the specified index.
@@ -93,6 +144,7 @@ func (s *ListState[T]) InsertAt(index int, item T) {
 	if index <= cursorIdx {
 		s.CursorIndex.Set(cursorIdx + 1)
 	}
+	s.syncKeys()
 }
 
 // RemoveAt removes the item at the specified index.
@@ -106,6 +158,7 @@ func (s *ListState[T]) RemoveAt(index int) bool {
 		return append(items[:index], items[index+1:]...)
 	})
 	s.resetFilterCache()
+	s.syncKeys()
 	s.clampCursor()
 	return true
 }
@@ -128,6 +181,7 @@ func (s *ListState[T]) RemoveWhere(predicate func(T) bool) int {
 	if removed > 0 {
 		s.resetFilterCache()
 	}
+	s.syncKeys()
 	s.clampCursor()
 	return removed
 }
@@ -137,6 +191,116 @@ func (s *ListState[T]) Clear() {
 	s.Items.Set([]T{})
 	s.resetFilterCache()
 	s.CursorIndex.Set(0)
+	s.syncKeys()
+}
+
+// WithItems replaces the items with what fn returns, given a copy of the
+// current items, in a single update. Use it to make several changes at
+// once, so the list rebuilds once rather than after each change. The
+// cursor is clamped to the new items, and with KeyFor set the cursor and
+// selection follow their items.
+//
+// Example:
+//
+//	state.WithItems(func(tasks []Task) []Task {
+//	    tasks = slices.DeleteFunc(tasks, Task.Done)
+//	    return append(tasks, newTasks...)
+//	})
+func (s *ListState[T]) WithItems(fn func(items []T) []T) {
+	s.SetItems(fn(slices.Clone(s.Items.Peek())))
+}
+
+// InsertAll inserts items at the specified index, keeping their order.
+// If index is out of bounds, it's clamped to valid range.
+func (s *ListState[T]) InsertAll(index int, items []T) {
+	if len(items) == 0 {
+		return
+	}
+	current := s.Items.Peek()
+	index = max(0, min(index, len(current)))
+	s.Items.Set(slices.Concat(current[:index], items, current[index:]))
+	s.resetFilterCache()
+	// Adjust cursor if insertion was at or before cursor
+	cursorIdx := s.CursorIndex.Peek()
+	if index <= cursorIdx {
+		s.CursorIndex.Set(cursorIdx + len(items))
+	}
+	s.syncKeys()
+}
+
+// RemoveIndices removes the items at the given indices, ignoring indices
+// that are out of bounds or repeated. Returns the number of items removed.
+func (s *ListState[T]) RemoveIndices(indices ...int) int {
+	items, removed := removeIndices(s.Items.Peek(), indices)
+	if removed == 0 {
+		return 0
+	}
+	s.SetItems(items)
+	return removed
+}
+
+// UpdateWhere replaces each item matching the predicate with the result
+// of transform. Returns the number of items updated.
+func (s *ListState[T]) UpdateWhere(predicate func(T) bool, transform func(T) T) int {
+	items, updated := updateWhere(s.Items.Peek(), predicate, transform)
+	if updated == 0 {
+		return 0
+	}
+	s.SetItems(items)
+	return updated
+}
+
+// Swap exchanges the items at indices i and j, such as to move an item up
+// or down. Returns false if either index is out of bounds. With KeyFor
+// set, the cursor and selection move with the swapped items.
+func (s *ListState[T]) Swap(i, j int) bool {
+	items := s.Items.Peek()
+	if i < 0 || i >= len(items) || j < 0 || j >= len(items) {
+		return false
+	}
+	items = slices.Clone(items)
+	items[i], items[j] = items[j], items[i]
+	s.SetItems(items)
+	return true
+}
+
+// removeIndices returns a copy of items without those at the given
+// indices, and how many were removed. Shared by ListState and TableState.
+func removeIndices[T any](items []T, indices []int) ([]T, int) {
+	remove := make(map[int]struct{}, len(indices))
+	for _, idx := range indices {
+		if idx >= 0 && idx < len(items) {
+			remove[idx] = struct{}{}
+		}
+	}
+	if len(remove) == 0 {
+		return items, 0
+	}
+	result := make([]T, 0, len(items)-len(remove))
+	for i, item := range items {
+		if _, ok := remove[i]; !ok {
+			result = append(result, item)
+		}
+	}
+	return result, len(remove)
+}
+
+// updateWhere returns a copy of items with those matching predicate
+// transformed, and how many were. Shared by ListState and TableState.
+func updateWhere[T any](items []T, predicate func(T) bool, transform func(T) T) ([]T, int) {
+	var result []T
+	updated := 0
+	for i, item := range items {
+		if !predicate(item) {
+			continue
+		}
+		if result == nil {
+			result = slices.Clone(items)
+		}
+		result[i] = transform(item)
+		updated++
+	}
+	return result, updated
 }
 
 // SelectedItem returns the currently selected item (if any).
@@ -219,6 +383,68 @@ func (s *ListState[T]) resetFilterCache() {
 	s.setViewIndices(nil)
 	s.cachedMatches = nil
 	s.cachedFilterQuery = ""
+	if s.filter != nil {
+		s.filter.reset()
+	}
+}
+
+func (s *ListState[T]) typeAheadBuffer() *typeAheadBuffer {
+	if s.typeAhead == nil {
+		s.typeAhead = newTypeAheadBuffer()
+	}
+	return s.typeAhead
+}
+
+// filterItems filters items through the state's filter engine and caches
+// the resulting view. While a large list is filtered in the background, the
+// previous results are returned and cached under their own query, so the
+// next Build picks up the new results once they land.
+func (s *ListState[T]) filterItems(items []T, filter *FilterState, matchItem func(item T, query string, options FilterOptions) MatchResult, async bool) FilteredView[T] {
+	query, options := filterStateValues(filter)
+	if matchItem == nil {
+		matchItem = defaultListMatchItem[T]
+	}
+
+	var view FilteredView[T]
+	pending := false
+	if query == "" {
+		view = ApplyFilter(items, "", func(item T, q string) MatchResult {
+			return matchItem(item, q, options)
+		})
+	} else {
+		if s.filter == nil {
+			s.filter = newFilterEngine[T, MatchResult]()
+		}
+		threshold := 0
+		if async {
+			threshold = asyncFilterThreshold(filter)
+		}
+		pass := s.filter.filter(items, query, options, threshold, filterMatcher[T, MatchResult]{
+			match: func(_ int, item T, q string, o FilterOptions) (MatchResult, bool) {
+				result := matchItem(item, q, o)
+				return result, result.Matched
+			},
+			rank:  fuzzyMatchRankFromResult,
+			score: func(result MatchResult) int { return result.Score },
+		})
+		query, options = pass.query, pass.options
+		pending = s.filter.isPending()
+		view = FilteredView[T]{
+			Items:   make([]T, len(pass.indices)),
+			Indices: pass.indices,
+			Matches: pass.results,
+		}
+		for i, idx := range pass.indices {
+			view.Items[i] = items[idx]
+		}
+	}
+
+	s.setViewIndices(view.Indices)
+	s.cachedMatches = view.Matches
+	s.cachedFilterQuery = query
+	s.cachedFilterOpts = options
+	reportFilterResult(filter, len(view.Indices), pending)
+	return view
 }
 
 func (s *ListState[T]) viewIndexForSource(sourceIdx int) (int, bool) {
@@ -236,67 +462,90 @@ func (s *ListState[T]) viewIndexForSource(sourceIdx int) (int, bool) {
 	return 0, false
 }
 
-// ToggleSelection toggles the selection state of the item at the given index.
-func (s *ListState[T]) ToggleSelection(index int) {
-	s.Selection.Update(func(sel map[int]struct{}) map[int]struct{} {
-		newSel := make(map[int]struct{}, len(sel))
-		for k := range sel {
-			newSel[k] = struct{}{}
+// SelectionModel returns the model behind Selection, for code shared with
+// other selectable widgets.
+func (s *ListState[T]) SelectionModel() *SelectionModel[int] {
+	if s.selection == nil {
+		if !s.Selection.IsValid() {
+			s.Selection = NewAnySignal(make(map[int]struct{}))
 		}
-		if _, exists := newSel[index]; exists {
-			delete(newSel, index)
-		} else {
-			newSel[index] = struct{}{}
+		s.selection = &SelectionModel[int]{Selection: s.Selection}
+		s.selection.OnSelectionChange = func(map[int]struct{}) {
+			s.tracker.record(s.Items.Peek(), s.KeyFor, s.CursorIndex, s.selection)
+			if s.OnSelectionChange != nil {
+				s.OnSelectionChange(s.SelectedItems())
+			}
 		}
-		return newSel
-	})
+	}
+	return s.selection
+}
+
+// syncKeys moves the cursor and selection to follow items that moved (see
+// KeyFor).
+func (s *ListState[T]) syncKeys() {
+	s.tracker.sync(s.Items.Peek(), s.KeyFor, s.CursorIndex, s.SelectionModel())
+}
+
+// ToggleSelection toggles the selection state of the item at the given index.
+func (s *ListState[T]) ToggleSelection(index int) {
+	s.SelectionModel().Toggle(index)
 }
 
 // Select adds the item at the given index to the selection.
 func (s *ListState[T]) Select(index int) {
-	s.Selection.Update(func(sel map[int]struct{}) map[int]struct{} {
-		newSel := make(map[int]struct{}, len(sel)+1)
-		for k := range sel {
-			newSel[k] = struct{}{}
-		}
-		newSel[index] = struct{}{}
-		return newSel
-	})
+	s.SelectionModel().Select(index)
 }
 
 // Deselect removes the item at the given index from the selection.
 func (s *ListState[T]) Deselect(index int) {
-	s.Selection.Update(func(sel map[int]struct{}) map[int]struct{} {
-		newSel := make(map[int]struct{}, len(sel))
-		for k := range sel {
-			if k != index {
-				newSel[k] = struct{}{}
-			}
-		}
-		return newSel
-	})
+	s.SelectionModel().Deselect(index)
 }
 
 // IsSelected returns true if the item at the given index is selected.
 func (s *ListState[T]) IsSelected(index int) bool {
-	sel := s.Selection.Peek()
-	_, exists := sel[index]
-	return exists
+	return s.SelectionModel().IsSelected(index)
 }
 
 // ClearSelection removes all items from the selection.
 func (s *ListState[T]) ClearSelection() {
-	s.Selection.Set(make(map[int]struct{}))
+	s.SelectionModel().Clear()
 }
 
 // SelectAll selects all items in the list.
 func (s *ListState[T]) SelectAll() {
-	items := s.Items.Peek()
-	sel := make(map[int]struct{}, len(items))
-	for i := range items {
-		sel[i] = struct{}{}
+	s.SelectionModel().SelectAll(indexRange(0, len(s.Items.Peek())))
+}
+
+// SelectAllVisible selects the items that match the current filter (all
+// items when unfiltered), replacing the selection.
+func (s *ListState[T]) SelectAllVisible() {
+	s.SelectionModel().SelectAll(s.visibleIndices())
+}
+
+// DeselectAllVisible removes the items that match the current filter (all
+// items when unfiltered) from the selection. Hidden items stay selected.
+func (s *ListState[T]) DeselectAllVisible() {
+	s.SelectionModel().Deselect(s.visibleIndices()...)
+}
+
+// SelectionCount returns the number of selected items. Reading it in Build
+// subscribes to selection changes, like Selection.Get.
+func (s *ListState[T]) SelectionCount() int {
+	return len(s.Selection.Get())
+}
+
+// InvertSelection flips the selection of the items that match the current
+// filter (all items when unfiltered). Hidden items keep their state.
+func (s *ListState[T]) InvertSelection() {
+	s.SelectionModel().Invert(s.visibleIndices())
+}
+
+// visibleIndices returns the source indices of the items in the current view.
+func (s *ListState[T]) visibleIndices() []int {
+	if s.viewIndices != nil {
+		return s.viewIndices
 	}
-	s.Selection.Set(sel)
+	return indexRange(0, len(s.Items.Peek()))
 }
 
 // SelectedItems returns all currently selected items.
@@ -314,43 +563,31 @@ func (s *ListState[T]) SelectedItems() []T {
 
 // SelectedIndices returns the indices of all selected items in ascending order.
 func (s *ListState[T]) SelectedIndices() []int {
-	sel := s.Selection.Peek()
-	result := make([]int, 0, len(sel))
-	for i := range sel {
-		result = append(result, i)
-	}
-	// Sort for consistent ordering
-	for i := 0; i < len(result)-1; i++ {
-		for j := i + 1; j < len(result); j++ {
-			if result[i] > result[j] {
-				result[i], result[j] = result[j], result[i]
-			}
-		}
-	}
-	return result
+	return sortedSelection(s.Selection.Peek())
 }
 
 // SetAnchor sets the anchor point for shift-selection.
 func (s *ListState[T]) SetAnchor(index int) {
-	s.anchorIndex = &index
+	s.SelectionModel().SetAnchor(index)
 }
 
 // ClearAnchor removes the anchor point.
 func (s *ListState[T]) ClearAnchor() {
-	s.anchorIndex = nil
+	s.SelectionModel().ClearAnchor()
 }
 
 // HasAnchor returns true if an anchor point is set.
 func (s *ListState[T]) HasAnchor() bool {
-	return s.anchorIndex != nil
+	_, ok := s.SelectionModel().Anchor()
+	return ok
 }
 
 // GetAnchor returns the anchor index, or -1 if no anchor is set.
 func (s *ListState[T]) GetAnchor() int {
-	if s.anchorIndex == nil {
-		return -1
+	if anchor, ok := s.SelectionModel().Anchor(); ok {
+		return anchor
 	}
-	return *s.anchorIndex
+	return -1
 }
 
 // SelectRange selects all items between from and to (inclusive).
@@ -359,17 +596,13 @@ func (s *ListState[T]) SelectRange(from, to int) {
 		from, to = to, from
 	}
 	items := s.Items.Peek()
-	if from < 0 {
-		from = 0
-	}
-	if to >= len(items) {
-		to = len(items) - 1
-	}
-	sel := make(map[int]struct{}, to-from+1)
-	for i := from; i <= to; i++ {
-		sel[i] = struct{}{}
+	from = max(from, 0)
+	to = min(to, len(items)-1)
+	if to < from {
+		s.ClearSelection()
+		return
 	}
-	s.Selection.Set(sel)
+	s.SelectionModel().SetSelected(indexRange(from, to+1))
 }
 
 // ApplyFilter applies a filter to the items and caches the results.
@@ -384,23 +617,7 @@ func (s *ListState[T]) ApplyFilter(filter *FilterState, matchItem func(item T, q
 		return 0
 	}
 
-	query, options := filterStateValues(filter)
-	if matchItem == nil {
-		matchItem = defaultListMatchItem[T]
-	}
-
-	filtered := ApplyFilter(items, query, func(item T, q string) MatchResult {
-		return matchItem(item, q, options)
-	})
-	if options.Mode == FilterFuzzy {
-		sortFilteredViewByFuzzyRank(&filtered)
-	}
-
-	s.setViewIndices(filtered.Indices)
-	s.cachedMatches = filtered.Matches
-	s.cachedFilterQuery = query
-
-	return len(filtered.Items)
+	return len(s.filterItems(items, filter, matchItem, false).Indices)
 }
 
 // FilteredCount returns the number of items after filtering.
@@ -412,6 +629,42 @@ func (s *ListState[T]) FilteredCount() int {
 	return len(s.Items.Peek())
 }
 
+// SelectionMarkers configures the marker column a multi-select List draws
+// before each item. Clicking a marker toggles the item's selection. Empty
+// fields use checkbox glyphs.
+type SelectionMarkers struct {
+	Selected   string        // Marker for selected items (default: "☑")
+	Unselected string        // Marker for unselected items (default: "☐")
+	Color      ColorProvider // Marker color (default: theme TextMuted, Accent when selected)
+}
+
+// marker returns the marker widget for an item.
+func (m SelectionMarkers) marker(theme ThemeData, selected bool, toggle func()) Widget {
+	glyph, color := m.Unselected, m.Color
+	if glyph == "" {
+		glyph = "☐"
+	}
+	if color == nil || !color.IsSet() {
+		color = theme.TextMuted
+	}
+	if selected {
+		glyph = m.Selected
+		if glyph == "" {
+			glyph = "☑"
+		}
+		if m.Color == nil || !m.Color.IsSet() {
+			color = theme.Accent
+		}
+	}
+	return Text{
+		Content: glyph + " ",
+		Style:   Style{ForegroundColor: color},
+		Click: func(MouseEvent) {
+			toggle()
+		},
+	}
+}
+
 // List is a generic focusable widget that displays a navigable list of items.
 // It builds a Column of widgets, with the active item (cursor position) highlighted.
 // Use with Scrollable and a shared ScrollState to enable scroll-into-view.
@@ -444,7 +697,12 @@ type List[T any] struct {
 	Filter              *FilterState                                                       // Optional filter state for matching items
 	MatchItem           func(item T, query string, options FilterOptions) MatchResult      // Optional matcher for filtering/highlighting
 	ItemHeight          int                                                                // Optional uniform item height override (default 0 = layout metrics / fallback 1)
-	MultiSelect         bool                                                               // Enable multi-select mode (space to toggle, shift+move to extend)
+	MultiSelect         bool                                                               // Enable multi-select mode (shift+move to extend, ctrl+a/alt+a/* to select all/none/invert)
+	Markers             *SelectionMarkers                                                  // Optional checkbox column drawn before each item in multi-select mode
+	TypeAhead           *TypeAhead[T]                                                      // Optional: typing jumps to the next item whose label starts with the typed text
+	EmptyState          Widget                                                             // Optional widget shown when there are no items (or none match the filter)
+	LoadingState        Widget                                                             // Optional widget shown instead of EmptyState while State.Loading is true
+	ErrorState          func(err error) Widget                                             // Optional widget shown instead of the items while State.Err is set
 	Width               Dimension                                                          // Deprecated: use Style.Width
 	Height              Dimension                                                          // Deprecated: use Style.Height
 	Style               Style                                                              // Optional styling
@@ -490,7 +748,11 @@ func (c listContainer[T]) OnLayout(ctx BuildContext, metrics LayoutMetrics) {
 	}
 
 	c.list.State.itemLayouts = layouts
+	c.list.keepScrollAnchor()
 	c.list.scrollCursorIntoView()
+	c.list.State.onScreen.record(ctx.frame, onScreenIndices(c.list.viewIndices(), count, func(viewIdx int) (int, int) {
+		return layouts[viewIdx].y, layouts[viewIdx].height
+	}, c.list.ScrollState))
 }
 
 func (c listContainer[T]) ChildWidgets() []Widget {
@@ -577,17 +839,25 @@ func (l List[T]) Build(ctx BuildContext) Widget {
 
 	// Get items (subscribes to changes via signal)
 	items := l.State.Items.Get()
+	l.State.syncKeys()
 	if len(items) == 0 {
 		l.State.itemLayouts = nil
 		l.State.setViewIndices(nil)
+		if slot := l.stateSlot(true); slot != nil {
+			return slot
+		}
 		return Column{}
 	}
 
+	if l.TypeAhead != nil && !l.TypeAhead.HideOverlay {
+		l.State.typeAheadBuffer().overlay(ctx, l.anchorID(ctx))
+	}
+
 	query, options := filterStateValues(l.Filter)
 
 	// Check if we have cached filter results for this query
 	var filtered FilteredView[T]
-	useCached := l.State.cachedFilterQuery == query && l.State.viewIndices != nil
+	useCached := l.State.cachedFilterQuery == query && sameFilterOptions(l.State.cachedFilterOpts, options) && l.State.viewIndices != nil
 	if useCached {
 		if len(l.State.cachedMatches) > 0 && len(l.State.cachedMatches) != len(l.State.viewIndices) {
 			useCached = false
@@ -612,22 +882,13 @@ func (l List[T]) Build(ctx BuildContext) Widget {
 			filtered.Items[i] = items[idx]
 		}
 	} else {
-		// Compute filter
-		matchItem := l.MatchItem
-		if matchItem == nil {
-			matchItem = defaultListMatchItem[T]
-		}
-		filtered = ApplyFilter(items, query, func(item T, q string) MatchResult {
-			return matchItem(item, q, options)
-		})
-		if options.Mode == FilterFuzzy {
-			sortFilteredViewByFuzzyRank(&filtered)
-		}
-		l.State.setViewIndices(filtered.Indices)
-		l.State.cachedMatches = filtered.Matches
-		l.State.cachedFilterQuery = query
+		filtered = l.State.filterItems(items, l.Filter, l.MatchItem, true)
 	}
 
+	if slot := l.stateSlot(len(filtered.Items) == 0); slot != nil {
+		l.State.itemLayouts = nil
+		return slot
+	}
 	if len(filtered.Items) == 0 {
 		l.State.itemLayouts = nil
 		return Column{}
@@ -673,21 +934,27 @@ func (l List[T]) Build(ctx BuildContext) Widget {
 		} else {
 			children[viewIdx] = renderItem(item, active, selected)
 		}
+		if l.MultiSelect && l.Markers != nil {
+			children[viewIdx] = Row{
+				Children: []Widget{
+					l.Markers.marker(ctx.Theme(), selected, func() { l.State.ToggleSelection(sourceIdx) }),
+					children[viewIdx],
+				},
+			}
+		}
+		if l.State.changes.active() {
+			if tint, ok := l.State.changes.tint(ctx.Theme(), itemKey(items, sourceIdx, l.State.KeyFor)); ok {
+				children[viewIdx] = Row{Style: Style{BackgroundColor: tint}, Children: []Widget{children[viewIdx]}}
+			}
+		}
 	}
 
 	// Ensure cursor item is visible whenever we rebuild
-	style := l.Style
-	if style.Width.IsUnset() {
-		style.Width = l.Width
-	}
-	if style.Height.IsUnset() {
-		style.Height = l.Height
-	}
 	return listContainer[T]{
 		Column: Column{
 			ID:         l.ID,
 			CrossAlign: CrossAxisStretch,
-			Style:      style,
+			Style:      l.containerStyle(),
 			Children:   children,
 			Click:      l.Click,
 			Hover:      l.Hover,
@@ -696,6 +963,34 @@ func (l List[T]) Build(ctx BuildContext) Widget {
 	}
 }
 
+// containerStyle returns Style with the deprecated Width and Height applied.
+func (l List[T]) containerStyle() Style {
+	style := l.Style
+	if style.Width.IsUnset() {
+		style.Width = l.Width
+	}
+	if style.Height.IsUnset() {
+		style.Height = l.Height
+	}
+	return style
+}
+
+// stateSlot returns the EmptyState, LoadingState or ErrorState widget to
+// show in place of the items, or nil to show the items.
+func (l List[T]) stateSlot(empty bool) Widget {
+	slot := stateSlot(l.State.Loading, l.State.Err, empty, l.EmptyState, l.LoadingState, l.ErrorState)
+	if slot == nil {
+		return nil
+	}
+	return Column{
+		ID:       l.ID,
+		Style:    l.containerStyle(),
+		Children: []Widget{slot},
+		Click:    l.Click,
+		Hover:    l.Hover,
+	}
+}
+
 // themedDefaultRenderItem returns a themed render function for list items.
 // Captures theme colors and widget focus state from the context for use in the render function.
 // Cursor highlighting is only shown when the widget has focus.
@@ -704,12 +999,18 @@ func (l List[T]) themedDefaultRenderItem(ctx BuildContext) func(item T, active b
 	widgetFocused := ctx.IsFocused(l)
 	cursorPrefix := l.CursorPrefix
 	selectedPrefix := l.SelectedPrefix
+	// Comfortable density gives each row a cell either side and a blank
+	// line below
+	var padding EdgeInsets
+	if ctx.Density() == DensityComfortable {
+		padding = EdgeInsetsTRBL(0, 1, 1, 1)
+	}
 
 	highlight := MatchHighlightStyle(theme)
 	return func(item T, active bool, selected bool, match MatchResult) Widget {
 		content := fmt.Sprintf("%v", item)
 		prefix := ""
-		style := Style{ForegroundColor: theme.Text}
+		style := Style{ForegroundColor: theme.Text, Padding: padding}
 
 		// Only show cursor highlight when widget has focus
 		showCursor := active && widgetFocused
@@ -755,7 +1056,41 @@ func defaultListMatchItem[T any](item T, query string, options FilterOptions) Ma
 // OnKey handles keys not covered by declarative keybindings.
 // Implements the Focusable interface.
 func (l List[T]) OnKey(event KeyEvent) bool {
-	return false
+	if l.TypeAhead == nil || l.State == nil {
+		return false
+	}
+	return l.State.typeAheadBuffer().handleKey(event, l.TypeAhead.timeout(), l.typeAheadJump)
+}
+
+// typeAheadJump moves the cursor to the item matching the typed text.
+func (l List[T]) typeAheadJump(query string) {
+	view, cursorViewIdx, ok := l.normalizeCursorForInteraction()
+	if !ok {
+		return
+	}
+	items := l.State.Items.Peek()
+	label := func(viewIdx int) string {
+		return l.TypeAhead.label(items[view[viewIdx]])
+	}
+	viewIdx, ok := typeAheadMatch(len(view), cursorViewIdx, label, query, l.TypeAhead.Fuzzy)
+	if !ok || viewIdx == cursorViewIdx {
+		return
+	}
+	if l.MultiSelect {
+		l.State.ClearSelection()
+		l.State.ClearAnchor()
+	}
+	l.setCursorToViewIndex(viewIdx)
+	l.scrollCursorIntoView()
+	l.notifyCursorChange()
+}
+
+// anchorID returns the ID the type-ahead overlay is positioned against.
+func (l List[T]) anchorID(ctx BuildContext) string {
+	if l.ID != "" {
+		return l.ID
+	}
+	return ctx.AutoID()
 }
 
 // Keybinds returns the declarative keybindings for this list.
@@ -786,8 +1121,14 @@ func (l List[T]) Keybinds() []Keybind {
 			Keybind{Key: "shift+j", Action: l.shiftCursorDown, Hidden: true},
 			Keybind{Key: "shift+home", Action: l.shiftCursorToFirst, Hidden: true},
 			Keybind{Key: "shift+end", Action: l.shiftCursorToLast, Hidden: true},
+			Keybind{Key: "ctrl+a", Name: "Select all", Action: l.State.SelectAllVisible, Hidden: true},
+			Keybind{Key: "alt+a", Name: "Select none", Action: l.State.DeselectAllVisible, Hidden: true},
+			Keybind{Key: "*", Name: "Invert selection", Action: l.State.InvertSelection, Hidden: true},
 		)
 	}
+	if l.TypeAhead != nil {
+		binds = withoutTypeAheadKeys(binds)
+	}
 	return binds
 }
 
@@ -1009,29 +1350,7 @@ func (l List[T]) selectViewRange(anchorSource, cursorSource int) {
 	if l.State == nil {
 		return
 	}
-	view := l.viewIndices()
-	if len(view) == 0 {
-		return
-	}
-
-	anchorView, ok := l.viewIndexForSource(anchorSource)
-	if !ok {
-		anchorView = 0
-	}
-	cursorView, ok := l.viewIndexForSource(cursorSource)
-	if !ok {
-		cursorView = anchorView
-	}
-
-	if anchorView > cursorView {
-		anchorView, cursorView = cursorView, anchorView
-	}
-
-	sel := make(map[int]struct{}, cursorView-anchorView+1)
-	for i := anchorView; i <= cursorView; i++ {
-		sel[view[i]] = struct{}{}
-	}
-	l.State.Selection.Set(sel)
+	l.State.SelectionModel().SelectRange(l.viewIndices(), anchorSource, cursorSource)
 }
 
 // scrollCursorIntoView uses the ScrollState to ensure
@@ -1061,6 +1380,37 @@ func (l List[T]) getItemHeight() int {
 	return 1
 }
 
+// keepScrollAnchor scrolls so the item that was at the top of the viewport
+// stays there after items move (see ListState.KeyFor), then records the
+// current top item.
+func (l List[T]) keepScrollAnchor() {
+	s := l.State
+	if l.ScrollState == nil || s.KeyFor == nil {
+		s.scrollTop = scrollAnchor{}
+		return
+	}
+	items := s.Items.Peek()
+	view := l.viewIndices()
+	if top := s.scrollTop; top.ok && (top.index >= len(items) || s.KeyFor(items[top.index]) != top.key) {
+		for viewIdx, idx := range view {
+			if viewIdx < len(s.itemLayouts) && s.KeyFor(items[idx]) == top.key {
+				l.ScrollState.SetOffset(s.itemLayouts[viewIdx].y + top.delta)
+				break
+			}
+		}
+	}
+
+	s.scrollTop = scrollAnchor{}
+	offset := l.ScrollState.GetOffset()
+	for viewIdx, layout := range s.itemLayouts {
+		if viewIdx < len(view) && layout.y+layout.height > offset {
+			idx := view[viewIdx]
+			s.scrollTop = scrollAnchor{index: idx, key: s.KeyFor(items[idx]), delta: offset - layout.y, ok: true}
+			break
+		}
+	}
+}
+
 // getItemLayout returns the cached item layout for the given index.
 func (l List[T]) getItemLayout(index int) (y, height int, ok bool) {
 	if l.State == nil {
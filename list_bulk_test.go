@@ -0,0 +1,74 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListState_BulkChangesSetItemsOnce(t *testing.T) {
+	state := NewListState([]string{"a", "b", "c", "d"})
+	state.SelectIndex(2)
+
+	before := state.Items.revision()
+	state.InsertAll(1, []string{"x", "y"})
+	assert.Equal(t, []string{"a", "x", "y", "b", "c", "d"}, state.GetItems())
+	assert.Equal(t, 4, state.CursorIndex.Peek(), "the cursor stays on c")
+	assert.Equal(t, before+1, state.Items.revision())
+
+	before = state.Items.revision()
+	assert.Equal(t, 2, state.RemoveIndices(1, 2, 2, 99))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, state.GetItems())
+	assert.Equal(t, before+1, state.Items.revision())
+
+	before = state.Items.revision()
+	assert.Equal(t, 2, state.UpdateWhere(func(s string) bool { return s < "c" }, strings.ToUpper))
+	assert.Equal(t, []string{"A", "B", "c", "d"}, state.GetItems())
+	assert.Equal(t, 0, state.UpdateWhere(func(s string) bool { return s == "z" }, strings.ToUpper))
+	assert.Equal(t, before+1, state.Items.revision(), "updating nothing doesn't set the items")
+
+	before = state.Items.revision()
+	assert.True(t, state.Swap(0, 3))
+	assert.False(t, state.Swap(0, 4))
+	assert.Equal(t, []string{"d", "B", "c", "A"}, state.GetItems())
+	assert.Equal(t, before+1, state.Items.revision())
+
+	before = state.Items.revision()
+	state.WithItems(func(items []string) []string {
+		items[0] = "D"
+		return append(items[:2], "e")
+	})
+	assert.Equal(t, []string{"D", "B", "e"}, state.GetItems())
+	assert.Equal(t, 2, state.CursorIndex.Peek(), "the cursor is clamped")
+	assert.Equal(t, before+1, state.Items.revision())
+}
+
+func TestListState_SwapMovesCursorWithKeyFor(t *testing.T) {
+	state := NewListState([]string{"a", "b", "c"})
+	state.KeyFor = func(item string) string { return item }
+	state.SelectIndex(1)
+	state.syncKeys()
+
+	state.Swap(1, 0)
+	assert.Equal(t, []string{"b", "a", "c"}, state.GetItems())
+	assert.Equal(t, 0, state.CursorIndex.Peek())
+}
+
+func TestTableState_BulkChangesSetRowsOnce(t *testing.T) {
+	state := NewTableState([][]string{{"1"}, {"2"}, {"3"}})
+
+	before := state.Rows.revision()
+	state.InsertAll(3, [][]string{{"4"}, {"5"}})
+	assert.Equal(t, before+1, state.Rows.revision())
+
+	before = state.Rows.revision()
+	assert.Equal(t, 2, state.RemoveIndices(0, 4))
+	assert.Equal(t, 1, state.UpdateWhere(func(row []string) bool { return row[0] == "2" }, func(row []string) []string {
+		return []string{"two"}
+	}))
+	assert.True(t, state.Swap(0, 2))
+	state.WithRows(func(rows [][]string) [][]string { return rows[1:] })
+	assert.Equal(t, [][]string{{"3"}, {"two"}}, state.GetRows())
+	assert.Equal(t, before+4, state.Rows.revision())
+}
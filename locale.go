@@ -0,0 +1,460 @@
+package terma
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PluralCategory is a CLDR plural category used to select message variants.
+type PluralCategory int
+
+const (
+	// PluralOther is the general plural form, used by every language.
+	PluralOther PluralCategory = iota
+	// PluralZero is used for zero in languages that distinguish it.
+	PluralZero
+	// PluralOne is the singular form.
+	PluralOne
+	// PluralTwo is the dual form.
+	PluralTwo
+	// PluralFew is used for small quantities (e.g. 2-4 in Russian).
+	PluralFew
+	// PluralMany is used for large quantities (e.g. 5-20 in Russian).
+	PluralMany
+)
+
+// String returns the CLDR name of the category ("one", "few", "other", ...).
+func (c PluralCategory) String() string {
+	switch c {
+	case PluralZero:
+		return "zero"
+	case PluralOne:
+		return "one"
+	case PluralTwo:
+		return "two"
+	case PluralFew:
+		return "few"
+	case PluralMany:
+		return "many"
+	default:
+		return "other"
+	}
+}
+
+// PluralRule selects the plural category for a count.
+type PluralRule func(n int) PluralCategory
+
+// OneOtherPlural is the plural rule for English, German, Spanish and most
+// other European languages: 1 is singular, everything else is plural.
+func OneOtherPlural(n int) PluralCategory {
+	if n == 1 || n == -1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// FrenchPlural treats 0 and 1 as singular.
+func FrenchPlural(n int) PluralCategory {
+	if n >= -1 && n <= 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// SlavicPlural is the one/few/many rule used by Russian and Ukrainian.
+func SlavicPlural(n int) PluralCategory {
+	if n < 0 {
+		n = -n
+	}
+	mod10, mod100 := n%10, n%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// NoPlural is the rule for languages without grammatical plurals (Japanese, Chinese).
+func NoPlural(int) PluralCategory {
+	return PluralOther
+}
+
+// Locale describes the language and formatting conventions of a region.
+type Locale struct {
+	Tag              string // BCP 47 tag, e.g. "en", "en-GB", "fr"
+	DecimalSeparator string
+	GroupSeparator   string
+	DateFormat       string // Go time layout for dates; January/Jan/Monday/Mon are localized
+	TimeFormat       string // Go time layout for times of day
+	MonthNames       [12]string
+	MonthAbbrevs     [12]string
+	WeekdayNames     [7]string // Sunday first, matching time.Weekday
+	WeekdayAbbrevs   [7]string
+	FirstWeekday     time.Weekday
+	Plural           PluralRule
+}
+
+// PluralCategory returns the plural category for n in this locale.
+func (l Locale) PluralCategory(n int) PluralCategory {
+	if l.Plural == nil {
+		return OneOtherPlural(n)
+	}
+	return l.Plural(n)
+}
+
+// MonthName returns the localized name of m.
+func (l Locale) MonthName(m time.Month) string {
+	return l.MonthNames[m-1]
+}
+
+// WeekdayName returns the localized name of d.
+func (l Locale) WeekdayName(d time.Weekday) string {
+	return l.WeekdayNames[d]
+}
+
+// FormatInt formats n with the locale's digit grouping.
+func (l Locale) FormatInt(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	return sign + groupDigits(digits, l.GroupSeparator)
+}
+
+// FormatNumber formats v with the given number of decimals using the
+// locale's decimal and group separators.
+func (l Locale) FormatNumber(v float64, decimals int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	formatted := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	intPart, fracPart, _ := strings.Cut(formatted, ".")
+
+	sign := ""
+	if v < 0 && strings.Trim(formatted, "0.") != "" {
+		sign = "-"
+	}
+	result := sign + groupDigits(intPart, l.GroupSeparator)
+	if fracPart != "" {
+		result += l.DecimalSeparator + fracPart
+	}
+	return result
+}
+
+// FormatDate formats t using DateFormat with localized month and weekday names.
+func (l Locale) FormatDate(t time.Time) string {
+	return l.formatTime(t, l.DateFormat)
+}
+
+// FormatTime formats the time of day of t using TimeFormat.
+func (l Locale) FormatTime(t time.Time) string {
+	return l.formatTime(t, l.TimeFormat)
+}
+
+// formatTime formats t with layout, substituting localized names for the
+// English month and weekday names produced by the time package.
+func (l Locale) formatTime(t time.Time, layout string) string {
+	// Replace name directives with placeholders that time.Format leaves
+	// untouched, so localized names can't be mistaken for layout elements.
+	names := []struct {
+		directive string
+		value     string
+	}{
+		{"January", l.MonthName(t.Month())},
+		{"Jan", l.MonthAbbrevs[t.Month()-1]},
+		{"Monday", l.WeekdayName(t.Weekday())},
+		{"Mon", l.WeekdayAbbrevs[t.Weekday()]},
+	}
+	for i, name := range names {
+		layout = strings.ReplaceAll(layout, name.directive, string(rune(0xE000+i)))
+	}
+	formatted := t.Format(layout)
+	for i, name := range names {
+		formatted = strings.ReplaceAll(formatted, string(rune(0xE000+i)), name.value)
+	}
+	return formatted
+}
+
+// groupDigits inserts sep between groups of three digits.
+func groupDigits(digits, sep string) string {
+	if len(digits) <= 3 || sep == "" {
+		return digits
+	}
+	var sb strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		sb.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if sb.Len() > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
+
+// MessageCatalog provides translated messages.
+type MessageCatalog interface {
+	// Message returns the message for key in the given locale and plural
+	// category, and false if the catalog has no such message.
+	Message(locale, key string, category PluralCategory) (string, bool)
+}
+
+// MapCatalog is a MessageCatalog backed by maps of locale tag to key to message.
+// Plural variants are stored under "key.<category>" (e.g. "files.one",
+// "files.other"); a bare "key" is used when no variant matches.
+//
+// Example:
+//
+//	MapCatalog{
+//	    "en": {"greeting": "Hello, %s!", "files.one": "%d file", "files.other": "%d files"},
+//	    "fr": {"greeting": "Bonjour, %s !", "files.one": "%d fichier", "files.other": "%d fichiers"},
+//	}
+type MapCatalog map[string]map[string]string
+
+// Message implements MessageCatalog.
+func (c MapCatalog) Message(locale, key string, category PluralCategory) (string, bool) {
+	messages, ok := c[locale]
+	if !ok {
+		return "", false
+	}
+	if msg, ok := messages[key+"."+category.String()]; ok {
+		return msg, true
+	}
+	if category != PluralOther {
+		if msg, ok := messages[key+"."+PluralOther.String()]; ok {
+			return msg, true
+		}
+	}
+	msg, ok := messages[key]
+	return msg, ok
+}
+
+// DefaultLocaleTag is the locale used at startup and as the final fallback.
+const DefaultLocaleTag = "en"
+
+var englishMonths = [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+var englishWeekdays = [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+var englishLocale = Locale{
+	Tag:              "en",
+	DecimalSeparator: ".",
+	GroupSeparator:   ",",
+	DateFormat:       "Jan 2, 2006",
+	TimeFormat:       "3:04 PM",
+	MonthNames:       englishMonths,
+	MonthAbbrevs:     [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	WeekdayNames:     englishWeekdays,
+	WeekdayAbbrevs:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	FirstWeekday:     time.Sunday,
+	Plural:           OneOtherPlural,
+}
+
+// localeRegistry maps locale tags to their formatting data.
+var localeRegistry = map[string]Locale{
+	"en": englishLocale,
+	"en-GB": func() Locale {
+		l := englishLocale
+		l.Tag = "en-GB"
+		l.DateFormat = "2 Jan 2006"
+		l.TimeFormat = "15:04"
+		l.FirstWeekday = time.Monday
+		return l
+	}(),
+	"de": {
+		Tag:              "de",
+		DecimalSeparator: ",",
+		GroupSeparator:   ".",
+		DateFormat:       "02.01.2006",
+		TimeFormat:       "15:04",
+		MonthNames:       [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		MonthAbbrevs:     [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		WeekdayNames:     [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		WeekdayAbbrevs:   [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		FirstWeekday:     time.Monday,
+		Plural:           OneOtherPlural,
+	},
+	"fr": {
+		Tag:              "fr",
+		DecimalSeparator: ",",
+		GroupSeparator:   "\u202f",
+		DateFormat:       "02/01/2006",
+		TimeFormat:       "15:04",
+		MonthNames:       [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		MonthAbbrevs:     [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		WeekdayNames:     [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		WeekdayAbbrevs:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		FirstWeekday:     time.Monday,
+		Plural:           FrenchPlural,
+	},
+	"es": {
+		Tag:              "es",
+		DecimalSeparator: ",",
+		GroupSeparator:   ".",
+		DateFormat:       "02/01/2006",
+		TimeFormat:       "15:04",
+		MonthNames:       [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		MonthAbbrevs:     [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		WeekdayNames:     [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		WeekdayAbbrevs:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		FirstWeekday:     time.Monday,
+		Plural:           OneOtherPlural,
+	},
+	"ru": {
+		Tag:              "ru",
+		DecimalSeparator: ",",
+		GroupSeparator:   "\u00a0",
+		DateFormat:       "02.01.2006",
+		TimeFormat:       "15:04",
+		MonthNames:       [12]string{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
+		MonthAbbrevs:     [12]string{"янв.", "февр.", "март", "апр.", "май", "июнь", "июль", "авг.", "сент.", "окт.", "нояб.", "дек."},
+		WeekdayNames:     [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		WeekdayAbbrevs:   [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+		FirstWeekday:     time.Monday,
+		Plural:           SlavicPlural,
+	},
+	"ja": {
+		Tag:              "ja",
+		DecimalSeparator: ".",
+		GroupSeparator:   ",",
+		DateFormat:       "2006/01/02",
+		TimeFormat:       "15:04",
+		MonthNames:       [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		MonthAbbrevs:     [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		WeekdayNames:     [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		WeekdayAbbrevs:   [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		FirstWeekday:     time.Sunday,
+		Plural:           NoPlural,
+	},
+	"zh": {
+		Tag:              "zh",
+		DecimalSeparator: ".",
+		GroupSeparator:   ",",
+		DateFormat:       "2006/01/02",
+		TimeFormat:       "15:04",
+		MonthNames:       [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		MonthAbbrevs:     [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		WeekdayNames:     [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		WeekdayAbbrevs:   [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		FirstWeekday:     time.Monday,
+		Plural:           NoPlural,
+	},
+}
+
+// activeLocale is the signal holding the current locale
+var activeLocale = NewAnySignal(englishLocale)
+
+// activeCatalog provides messages for Tr and TrN.
+var activeCatalog MessageCatalog
+
+// RegisterLocale registers formatting data for locale.Tag, replacing any
+// existing entry. If this is the active locale, the change takes effect immediately.
+func RegisterLocale(locale Locale) {
+	localeRegistry[locale.Tag] = locale
+	if activeLocale.Peek().Tag == locale.Tag {
+		activeLocale.Set(locale)
+	}
+}
+
+// SetLocale switches to the locale with the given tag (e.g. "fr" or "en-GB").
+// A regional tag without registered data falls back to its language ("fr-CA"
+// uses "fr" formatting but still looks up "fr-CA" messages first).
+// If neither is registered, this logs a warning and does nothing.
+func SetLocale(tag string) {
+	tag = strings.ReplaceAll(tag, "_", "-")
+	candidates := []string{tag}
+	if lang, _, ok := strings.Cut(tag, "-"); ok {
+		candidates = append(candidates, lang)
+	}
+	for _, candidate := range candidates {
+		if locale, ok := localeRegistry[candidate]; ok {
+			locale.Tag = tag
+			activeLocale.Set(locale)
+			return
+		}
+	}
+	Log("Locale not found: %s", tag)
+}
+
+// CurrentLocale returns the active locale. Reading it during Build
+// subscribes the widget to locale changes.
+func CurrentLocale() Locale {
+	return activeLocale.Get()
+}
+
+// SetMessageCatalog sets the catalog used by Tr and TrN. Messages missing
+// from it fall back to terma's built-in messages (such as relative times),
+// so apps can also use it to override those.
+func SetMessageCatalog(catalog MessageCatalog) {
+	activeCatalog = catalog
+}
+
+// Tr returns the translation of key in the current locale, formatted with
+// args as by fmt.Sprintf. Missing translations fall back to the language
+// ("fr-CA" → "fr"), then DefaultLocaleTag, then the key itself.
+//
+// Example:
+//
+//	Text{Content: Tr("greeting", user.Name)}
+func Tr(key string, args ...any) string {
+	return translate(CurrentLocale().Tag, key, PluralOther, args)
+}
+
+// TrN returns the plural variant of key for count n. If no args are given,
+// n is used as the only format argument.
+//
+// Example:
+//
+//	Text{Content: TrN("files", len(files))} // "1 file", "3 files"
+func TrN(key string, n int, args ...any) string {
+	locale := CurrentLocale()
+	if len(args) == 0 {
+		args = []any{n}
+	}
+	return translate(locale.Tag, key, locale.PluralCategory(n), args)
+}
+
+// Locale returns the active locale and subscribes the widget to locale changes.
+func (ctx BuildContext) Locale() Locale {
+	return CurrentLocale()
+}
+
+func translate(tag, key string, category PluralCategory, args []any) string {
+	msg := key
+lookup:
+	for _, candidate := range localeFallbacks(tag) {
+		for _, catalog := range []MessageCatalog{activeCatalog, builtinMessages} {
+			if catalog == nil {
+				continue
+			}
+			if m, ok := catalog.Message(candidate, key, category); ok {
+				msg = m
+				break lookup
+			}
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// localeFallbacks returns the lookup chain for tag, e.g. "fr-CA" → ["fr-CA", "fr", "en"].
+func localeFallbacks(tag string) []string {
+	chain := []string{tag}
+	if lang, _, ok := strings.Cut(tag, "-"); ok {
+		chain = append(chain, lang)
+	}
+	if chain[len(chain)-1] != DefaultLocaleTag {
+		chain = append(chain, DefaultLocaleTag)
+	}
+	return chain
+}
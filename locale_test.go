@@ -0,0 +1,124 @@
+package terma
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// withLocale switches to tag and catalog for the duration of a test.
+func withLocale(t *testing.T, tag string, catalog MessageCatalog) {
+	t.Helper()
+	prevLocale, prevCatalog := activeLocale.Peek(), activeCatalog
+	SetLocale(tag)
+	SetMessageCatalog(catalog)
+	t.Cleanup(func() {
+		activeLocale.Set(prevLocale)
+		activeCatalog = prevCatalog
+	})
+}
+
+var testCatalog = MapCatalog{
+	"en": {
+		"greeting":    "Hello, %s!",
+		"files.one":   "%d file",
+		"files.other": "%d files",
+		"only.en":     "English only",
+	},
+	"fr": {
+		"greeting":    "Bonjour, %s !",
+		"files.one":   "%d fichier",
+		"files.other": "%d fichiers",
+	},
+	"ru": {
+		"files.one":  "%d файл",
+		"files.few":  "%d файла",
+		"files.many": "%d файлов",
+	},
+}
+
+func TestTr_TranslatesWithFallbacks(t *testing.T) {
+	withLocale(t, "fr-CA", testCatalog)
+
+	assert.Equal(t, "fr-CA", CurrentLocale().Tag)
+	assert.Equal(t, "Bonjour, Ana !", Tr("greeting", "Ana"))
+	assert.Equal(t, "English only", Tr("only.en"), "falls back to the default locale")
+	assert.Equal(t, "missing.key", Tr("missing.key"), "falls back to the key")
+}
+
+func TestTrN_SelectsPluralForm(t *testing.T) {
+	withLocale(t, "en", testCatalog)
+	assert.Equal(t, "1 file", TrN("files", 1))
+	assert.Equal(t, "0 files", TrN("files", 0))
+
+	withLocale(t, "fr", testCatalog)
+	assert.Equal(t, "0 fichier", TrN("files", 0))
+	assert.Equal(t, "2 fichiers", TrN("files", 2))
+
+	withLocale(t, "ru", testCatalog)
+	assert.Equal(t, "21 файл", TrN("files", 21))
+	assert.Equal(t, "3 файла", TrN("files", 3))
+	assert.Equal(t, "12 файлов", TrN("files", 12))
+}
+
+func TestSetLocale_UnknownIsIgnored(t *testing.T) {
+	withLocale(t, "de", nil)
+	SetLocale("xx")
+	assert.Equal(t, "de", CurrentLocale().Tag)
+}
+
+func TestTr_FollowsLocaleChanges(t *testing.T) {
+	withLocale(t, "en", testCatalog)
+	assert.Equal(t, "Hello, Bo!", Tr("greeting", "Bo"))
+
+	SetLocale("fr")
+	assert.Equal(t, "Bonjour, Bo !", Tr("greeting", "Bo"))
+}
+
+func TestLocale_FormatNumber(t *testing.T) {
+	tests := []struct {
+		tag      string
+		value    float64
+		decimals int
+		want     string
+	}{
+		{"en", 1234567.891, 2, "1,234,567.89"},
+		{"en", -1234.6, 0, "-1,235"},
+		{"en", -0.001, 2, "0.00"},
+		{"en", 999, 0, "999"},
+		{"de", 1234567.891, 2, "1.234.567,89"},
+		{"fr", 1234.5, 1, "1 234,5"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, localeRegistry[tt.tag].FormatNumber(tt.value, tt.decimals), tt.tag)
+	}
+	assert.Equal(t, "-12,345", localeRegistry["en"].FormatInt(-12345))
+}
+
+func TestLocale_FormatDate(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, "Mar 5, 2024", localeRegistry["en"].FormatDate(date))
+	assert.Equal(t, "2:30 PM", localeRegistry["en"].FormatTime(date))
+	assert.Equal(t, "5 Mar 2024", localeRegistry["en-GB"].FormatDate(date))
+	assert.Equal(t, "05.03.2024", localeRegistry["de"].FormatDate(date))
+
+	german := localeRegistry["de"]
+	german.DateFormat = "Monday, 2. January 2006"
+	assert.Equal(t, "Dienstag, 5. März 2024", german.FormatDate(date))
+
+	french := localeRegistry["fr"]
+	french.DateFormat = "Mon 2 Jan"
+	assert.Equal(t, "mar. 5 mars", french.FormatDate(date))
+}
+
+func TestPluralRules(t *testing.T) {
+	assert.Equal(t, PluralOne, OneOtherPlural(1))
+	assert.Equal(t, PluralOther, OneOtherPlural(0))
+	assert.Equal(t, PluralOne, FrenchPlural(0))
+	assert.Equal(t, PluralMany, SlavicPlural(11))
+	assert.Equal(t, PluralFew, SlavicPlural(22))
+	assert.Equal(t, PluralMany, SlavicPlural(114))
+	assert.Equal(t, PluralOther, NoPlural(1))
+}
@@ -3,7 +3,9 @@ package terma
 import (
 	"fmt"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -17,6 +19,11 @@ type Logger struct {
 
 var globalLogger *Logger
 
+// debugLogging is whether debug entries are recorded at all. It is off
+// until InitLogger or SetDebugLogging(true), so the debug messages logged
+// throughout the framework cost nothing by default.
+var debugLogging atomic.Bool
+
 // InitLogger initializes the global logger.
 // Call this at the start of your application to enable logging.
 func InitLogger() error {
@@ -28,6 +35,7 @@ func InitLogger() error {
 		file:    f,
 		enabled: true,
 	}
+	debugLogging.Store(true)
 	Log("Logger initialized")
 	return nil
 }
@@ -38,11 +46,14 @@ func CloseLogger() {
 		globalLogger.file.Close()
 		globalLogger = nil
 	}
+	debugLogging.Store(false)
 }
 
 // SetDebugLogging enables or disables debug logging.
-// Logging is enabled by default when InitLogger is called.
+// Logging is enabled by default when InitLogger is called. Without
+// InitLogger, enabling it keeps debug entries for RecentLogs.
 func SetDebugLogging(enabled bool) {
+	debugLogging.Store(enabled)
 	if globalLogger != nil {
 		globalLogger.mu.Lock()
 		globalLogger.enabled = enabled
@@ -50,22 +61,184 @@ func SetDebugLogging(enabled bool) {
 	}
 }
 
-// Log writes a message to the log file.
-func Log(format string, args ...any) {
+// LogLevel is the severity of a log entry.
+type LogLevel int
+
+const (
+	// LevelDebug is for detailed diagnostics. Log writes at this level.
+	LevelDebug LogLevel = iota
+	// LevelInfo is for notable events.
+	LevelInfo
+	// LevelWarn is for unexpected but recoverable situations.
+	LevelWarn
+	// LevelError is for failures.
+	LevelError
+)
+
+// String returns the level name, e.g. "INFO".
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// LogField is a key/value pair attached to a log entry.
+type LogField struct {
+	Key   string
+	Value any
+}
+
+// Field creates a LogField.
+//
+// Example:
+//
+//	LogInfo("file opened", Field("path", path), Field("bytes", n))
+func Field(key string, value any) LogField {
+	return LogField{Key: key, Value: value}
+}
+
+// LogEntry is a single recorded log line.
+type LogEntry struct {
+	Time    time.Time
+	Level   LogLevel
+	Message string
+	Fields  []LogField
+}
+
+// FieldsString formats the entry's fields as "key=value" pairs.
+// Values containing spaces are quoted.
+func (e LogEntry) FieldsString() string {
+	var b strings.Builder
+	for i, field := range e.Fields {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		value := fmt.Sprint(field.Value)
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = fmt.Sprintf("%q", value)
+		}
+		b.WriteString(field.Key)
+		b.WriteByte('=')
+		b.WriteString(value)
+	}
+	return b.String()
+}
+
+// String formats the entry as one line, e.g.
+// "12:00:01.250 INFO  file opened path=notes.txt".
+func (e LogEntry) String() string {
+	line := fmt.Sprintf("%s %-5s %s", e.Time.Format("15:04:05.000"), e.Level, e.Message)
+	if len(e.Fields) > 0 {
+		line += " " + e.FieldsString()
+	}
+	return line
+}
+
+// DefaultLogBufferSize is how many entries RecentLogs keeps.
+const DefaultLogBufferSize = 1000
+
+// logBuffer is the in-memory ring of recent entries, kept whether or not
+// InitLogger was called. Debug entries are only kept while debug logging
+// is enabled.
+var logBuffer = struct {
+	mu      sync.Mutex
+	entries []LogEntry
+	next    int
+	size    int
+}{size: DefaultLogBufferSize}
+
+// SetLogBufferSize changes how many entries RecentLogs keeps.
+// Existing entries are discarded.
+func SetLogBufferSize(size int) {
+	logBuffer.mu.Lock()
+	defer logBuffer.mu.Unlock()
+	logBuffer.entries = nil
+	logBuffer.next = 0
+	logBuffer.size = max(size, 1)
+}
+
+// RecentLogs returns the buffered log entries at or above minLevel,
+// oldest first.
+func RecentLogs(minLevel LogLevel) []LogEntry {
+	logBuffer.mu.Lock()
+	defer logBuffer.mu.Unlock()
+
+	var entries []LogEntry
+	for i := range logBuffer.entries {
+		entry := logBuffer.entries[(logBuffer.next+i)%len(logBuffer.entries)]
+		if entry.Level >= minLevel {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// writeLog records an entry in the ring buffer and, when InitLogger has
+// been called, the log file.
+func writeLog(level LogLevel, message string, fields []LogField) {
+	entry := LogEntry{Time: time.Now(), Level: level, Message: message, Fields: fields}
+
+	logBuffer.mu.Lock()
+	if len(logBuffer.entries) < logBuffer.size {
+		logBuffer.entries = append(logBuffer.entries, entry)
+	} else {
+		logBuffer.entries[logBuffer.next] = entry
+		logBuffer.next = (logBuffer.next + 1) % len(logBuffer.entries)
+	}
+	logBuffer.mu.Unlock()
+
 	if globalLogger == nil || !globalLogger.enabled {
 		return
 	}
 	globalLogger.mu.Lock()
 	defer globalLogger.mu.Unlock()
+	fmt.Fprintf(globalLogger.file, "%s\n", entry)
+}
+
+// Log writes a formatted debug message to the log. It does nothing,
+// without formatting the message, while debug logging is disabled.
+func Log(format string, args ...any) {
+	if !debugLogging.Load() {
+		return
+	}
+	writeLog(LevelDebug, fmt.Sprintf(format, args...), nil)
+}
+
+// LogDebug records a debug message with optional fields, while debug
+// logging is enabled.
+func LogDebug(message string, fields ...LogField) {
+	if !debugLogging.Load() {
+		return
+	}
+	writeLog(LevelDebug, message, fields)
+}
 
-	timestamp := time.Now().Format("15:04:05.000")
-	msg := fmt.Sprintf(format, args...)
-	fmt.Fprintf(globalLogger.file, "[%s] %s\n", timestamp, msg)
+// LogInfo records an informational message with optional fields.
+func LogInfo(message string, fields ...LogField) {
+	writeLog(LevelInfo, message, fields)
+}
+
+// LogWarn records a warning with optional fields.
+func LogWarn(message string, fields ...LogField) {
+	writeLog(LevelWarn, message, fields)
+}
+
+// LogError records an error with optional fields.
+func LogError(message string, fields ...LogField) {
+	writeLog(LevelError, message, fields)
 }
 
 // LogWidgetRegistry logs all entries in a widget registry.
 func LogWidgetRegistry(registry *WidgetRegistry) {
-	if globalLogger == nil || !globalLogger.enabled {
+	if !debugLogging.Load() {
 		return
 	}
 	Log("=== Widget Registry (%d entries) ===", len(registry.entries))
@@ -76,4 +249,3 @@ func LogWidgetRegistry(registry *WidgetRegistry) {
 	}
 	Log("=== End Registry ===")
 }
-
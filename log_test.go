@@ -0,0 +1,114 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withLogBuffer gives the test an empty log buffer of the given size.
+func withLogBuffer(t *testing.T, size int) {
+	t.Helper()
+	SetLogBufferSize(size)
+	SetDebugLogging(true)
+	t.Cleanup(func() {
+		SetDebugLogging(false)
+		SetLogBufferSize(DefaultLogBufferSize)
+	})
+}
+
+func TestRecentLogs_LevelsAndFields(t *testing.T) {
+	withLogBuffer(t, 10)
+
+	Log("frame %d", 1)
+	LogInfo("file opened", Field("path", "notes.txt"), Field("bytes", 42))
+	LogWarn("slow frame", Field("took", "20 ms"))
+	LogError("save failed")
+
+	all := RecentLogs(LevelDebug)
+	require.Len(t, all, 4)
+	assert.Equal(t, LevelDebug, all[0].Level)
+	assert.Equal(t, "frame 1", all[0].Message)
+	assert.Equal(t, `path=notes.txt bytes=42`, all[1].FieldsString())
+	assert.Equal(t, `took="20 ms"`, all[2].FieldsString())
+
+	warnings := RecentLogs(LevelWarn)
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "slow frame", warnings[0].Message)
+	assert.Equal(t, "save failed", warnings[1].Message)
+}
+
+func TestRecentLogs_RingDropsOldest(t *testing.T) {
+	withLogBuffer(t, 3)
+	for _, message := range []string{"a", "b", "c", "d", "e"} {
+		LogInfo(message)
+	}
+
+	var messages []string
+	for _, entry := range RecentLogs(LevelDebug) {
+		messages = append(messages, entry.Message)
+	}
+	assert.Equal(t, []string{"c", "d", "e"}, messages)
+}
+
+func TestRecentLogs_DebugOnlyWhileEnabled(t *testing.T) {
+	withLogBuffer(t, 10)
+	SetDebugLogging(false)
+
+	Log("frame %d", 1)
+	LogDebug("detail")
+	LogInfo("kept")
+
+	entries := RecentLogs(LevelDebug)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "kept", entries[0].Message)
+}
+
+func TestLogEntry_String(t *testing.T) {
+	entry := LogEntry{
+		Time:    time.Date(2024, 1, 1, 12, 0, 1, 250_000_000, time.UTC),
+		Level:   LevelInfo,
+		Message: "file opened",
+		Fields:  []LogField{Field("path", "notes.txt")},
+	}
+	assert.Equal(t, "12:00:01.250 INFO  file opened path=notes.txt", entry.String())
+	assert.Equal(t, "LEVEL(9)", LogLevel(9).String())
+}
+
+func TestDebugLogPanelState_CycleLevel(t *testing.T) {
+	state := NewDebugLogPanelState()
+	var levels []LogLevel
+	for range 5 {
+		state.CycleLevel()
+		levels = append(levels, state.MinLevel.Peek())
+	}
+	assert.Equal(t, []LogLevel{LevelInfo, LevelWarn, LevelError, LevelDebug, LevelInfo}, levels)
+}
+
+func TestDebugLogPanel_ShowsFilteredEntries(t *testing.T) {
+	withLogBuffer(t, 10)
+	LogDebug("debug detail")
+	LogError("disk full", Field("free", 0))
+
+	state := NewDebugLogPanelState()
+	widget := DebugLogPanel{State: state, Child: Text{Content: "app"}}
+
+	hidden := screenText(widget, 50, 16)
+	assert.True(t, strings.HasPrefix(hidden, "app"))
+	assert.NotContains(t, hidden, "disk full")
+
+	state.Toggle()
+	screen := screenText(widget, 50, 16)
+	assert.True(t, strings.HasPrefix(screen, "app"))
+	assert.Contains(t, screen, "Logs  DEBUG   INFO   WARN   ERROR")
+	assert.Contains(t, screen, "debug detail")
+	assert.Contains(t, screen, "ERROR disk full free=0")
+
+	state.MinLevel.Set(LevelWarn)
+	screen = screenText(widget, 50, 16)
+	assert.NotContains(t, screen, "debug detail")
+	assert.Contains(t, screen, "disk full")
+}
@@ -1,13 +1,101 @@
 package terma
 
 import (
+	"fmt"
+	"math"
+	"slices"
 	"strings"
+	"sync"
 )
 
+// maxMarkupAliasDepth bounds alias expansion so self-referencing aliases terminate.
+const maxMarkupAliasDepth = 8
+
+// maxCachedMarkup bounds the ParseMarkup cache. When it fills up it is
+// emptied, and refills with the markup still in use.
+const maxCachedMarkup = 1024
+
+// markupStyles holds style aliases registered with RegisterMarkupStyle.
+// Aliases may be registered while the app renders, so access is locked.
+var markupStyles = struct {
+	sync.RWMutex
+	aliases map[string]string
+	// revision counts RegisterMarkupStyle calls, so precompiled markup
+	// knows when an alias it used may have changed.
+	revision uint64
+}{aliases: map[string]string{}}
+
+// markupStyle returns the definition of the alias name.
+func markupStyle(name string) (string, bool) {
+	markupStyles.RLock()
+	defer markupStyles.RUnlock()
+	definition, ok := markupStyles.aliases[name]
+	return definition, ok
+}
+
+// markupStylesRevision returns the number of RegisterMarkupStyle calls.
+func markupStylesRevision() uint64 {
+	markupStyles.RLock()
+	defer markupStyles.RUnlock()
+	return markupStyles.revision
+}
+
+// markupCacheKey identifies a parse: the markup, the theme colors it was
+// parsed against and the style aliases registered at the time.
+type markupCacheKey struct {
+	markup  string
+	palette markupPalette
+	styles  uint64
+}
+
+// markupPalette holds a theme's colors addressable in markup, in the order
+// of markupThemeColors. Two themes with the same palette parse markup the
+// same way.
+type markupPalette [len(markupThemeColors)]Color
+
+// paletteOf returns the colors of theme that markup can refer to.
+func paletteOf(theme ThemeData) markupPalette {
+	var palette markupPalette
+	for i, entry := range markupThemeColors {
+		palette[i] = entry.color(theme)
+	}
+	return palette
+}
+
+// markupCache memoizes ParseMarkup, so markup that is rebuilt on every
+// frame is only parsed the first time.
+var markupCache = struct {
+	sync.Mutex
+	spans map[markupCacheKey][]Span
+}{spans: map[markupCacheKey][]Span{}}
+
+// RegisterMarkupStyle registers a named style alias for use in markup.
+// The definition uses the same syntax as a tag, and may refer to other aliases.
+// Names are case-insensitive; registering an existing name replaces it.
+// Aliases can be registered at any time, including while the app runs.
+//
+// Example:
+//
+//	RegisterMarkupStyle("warning", "bold $Warning")
+//	ParseMarkup("[warning]Disk almost full[/]", theme)
+func RegisterMarkupStyle(name, definition string) {
+	markupStyles.Lock()
+	markupStyles.aliases[strings.ToLower(name)] = definition
+	markupStyles.revision++
+	markupStyles.Unlock()
+	markupCache.Lock()
+	clear(markupCache.spans)
+	markupCache.Unlock()
+}
+
 // ParseMarkup parses a markup string and returns a slice of Spans.
 // Supports styles like [bold], [italic], [underline] (or [b], [i], [u]),
-// theme colors like [$Primary], background colors like [on $Surface],
-// and literal hex colors like [#ff5500].
+// [dim], [strike], [reverse], [blink], [conceal], underline variants
+// ([uu], [curly], [dotted], [dashed]), [sup] and [sub], theme colors like
+// [$Primary], background colors like [on $Surface], literal hex colors like
+// [#ff5500], links like [link=https://example.com], and style aliases
+// registered with RegisterMarkupStyle. Prefix an attribute with "not" to
+// turn it off inside a nested tag: [not bold].
 //
 // Examples:
 //
@@ -16,9 +104,34 @@ import (
 //	ParseMarkup("[bold $Error on $Background]Warning![/]", theme)
 //
 // Style nesting is supported: [bold]Hello [italic]World[/][/]
-// Use [[ to insert a literal [ character.
+// Use [[ or \[ to insert a literal [ character (and ]] or \] for ]), and
+// \\ for a literal backslash.
+// EscapeMarkup escapes arbitrary text for embedding in markup.
 // Invalid markup is returned as literal text (graceful fallback).
+//
+// Results are cached by markup and theme colors, so parsing the same
+// markup again only copies the spans. Hold a PrecompiledMarkup to skip the
+// copy too.
 func ParseMarkup(markup string, theme ThemeData) []Span {
+	key := markupCacheKey{markup: markup, palette: paletteOf(theme), styles: markupStylesRevision()}
+	markupCache.Lock()
+	spans, ok := markupCache.spans[key]
+	markupCache.Unlock()
+	if !ok {
+		spans = parseMarkup(markup, theme)
+		markupCache.Lock()
+		if len(markupCache.spans) >= maxCachedMarkup {
+			clear(markupCache.spans)
+		}
+		markupCache.spans[key] = spans
+		markupCache.Unlock()
+	}
+	// The caller owns the result, so hand out a copy of the cached spans.
+	return slices.Clone(spans)
+}
+
+// parseMarkup parses markup without the cache.
+func parseMarkup(markup string, theme ThemeData) []Span {
 	p := &markupParser{
 		input:      markup,
 		theme:      theme,
@@ -37,6 +150,55 @@ func ParseMarkupToText(markup string, theme ThemeData) Text {
 	return Text{Spans: ParseMarkup(markup, theme)}
 }
 
+// PrecompiledMarkup is markup parsed once and kept, for static markup
+// shown on every frame. Spans returns the same slice each time and only
+// parses again when the theme changes or a style alias is registered.
+//
+// Example:
+//
+//	var helpLine = t.CompileMarkup("Press [b $Accent]?[/] for help")
+//
+//	func (a *App) Build(ctx t.BuildContext) t.Widget {
+//	    return helpLine.Text(ctx.Theme())
+//	}
+type PrecompiledMarkup struct {
+	markup  string
+	parsed  bool
+	palette markupPalette // Theme colors spans was parsed against
+	styles  uint64        // markupStylesRevision() when spans was parsed
+	spans   []Span
+}
+
+// CompileMarkup returns markup to be parsed on first use and kept.
+func CompileMarkup(markup string) *PrecompiledMarkup {
+	return &PrecompiledMarkup{markup: markup}
+}
+
+// Markup returns the markup source.
+func (m *PrecompiledMarkup) Markup() string {
+	return m.markup
+}
+
+// Spans returns the markup's spans in theme. The slice is shared between
+// calls, so don't modify it.
+func (m *PrecompiledMarkup) Spans(theme ThemeData) []Span {
+	palette := paletteOf(theme)
+	styles := markupStylesRevision()
+	if m.parsed && palette == m.palette && m.styles == styles {
+		return m.spans
+	}
+	m.spans = parseMarkup(m.markup, theme)
+	m.parsed = true
+	m.palette = palette
+	m.styles = styles
+	return m.spans
+}
+
+// Text returns a Text widget showing the markup in theme.
+func (m *PrecompiledMarkup) Text(theme ThemeData) Text {
+	return Text{Spans: m.Spans(theme)}
+}
+
 type markupParser struct {
 	input      string
 	pos        int
@@ -51,6 +213,17 @@ func (p *markupParser) parse() []Span {
 	for p.pos < len(p.input) {
 		ch := p.input[p.pos]
 
+		if ch == '\\' && p.pos+1 < len(p.input) {
+			// Backslash escapes: \[, \] and \\ are a literal bracket or
+			// backslash. Other backslashes are literal.
+			next := p.input[p.pos+1]
+			if next == '[' || next == ']' || next == '\\' {
+				textBuf.WriteByte(next)
+				p.pos += 2
+				continue
+			}
+		}
+
 		if ch == '[' {
 			// Check for escape sequence [[
 			if p.pos+1 < len(p.input) && p.input[p.pos+1] == '[' {
@@ -142,32 +315,39 @@ func (p *markupParser) parseTag() bool {
 func (p *markupParser) parseTagContent(content string) SpanStyle {
 	// Start with current style (for inheritance)
 	style := p.currentStyle()
+	p.applyTokens(&style, tokenizeTagContent(content), 0)
+	return style
+}
 
-	// Split by whitespace, but handle "on" specially
-	tokens := tokenizeTagContent(content)
-
+// applyTokens applies tag tokens to style. Style aliases are expanded
+// recursively, up to maxMarkupAliasDepth levels deep.
+func (p *markupParser) applyTokens(style *SpanStyle, tokens []string, depth int) {
 	expectingBackground := false
+	negate := false
 	for _, token := range tokens {
 		lower := strings.ToLower(token)
 
-		// Check for "on" keyword
-		if lower == "on" {
+		// Check for "on" and "not" keywords
+		switch lower {
+		case "on":
 			expectingBackground = true
 			continue
+		case "not":
+			negate = true
+			continue
 		}
 
-		// Check for style modifiers
-		switch lower {
-		case "bold", "b":
-			style.Bold = true
-			continue
-		case "italic", "i":
-			style.Italic = true
+		if strings.HasPrefix(lower, "link=") {
+			style.Link = token[len("link="):]
 			continue
-		case "underline", "u":
-			style.Underline = UnderlineSingle
+		}
+
+		// Check for style modifiers
+		if applyMarkupAttribute(style, lower, !negate) {
+			negate = false
 			continue
 		}
+		negate = false
 
 		// Try to parse as color
 		if color, ok := p.resolveColor(token); ok {
@@ -177,10 +357,65 @@ func (p *markupParser) parseTagContent(content string) SpanStyle {
 			} else {
 				style.Foreground = color
 			}
+			continue
+		}
+
+		// Finally, try a registered style alias
+		if alias, ok := markupStyle(lower); ok && depth < maxMarkupAliasDepth {
+			p.applyTokens(style, tokenizeTagContent(alias), depth+1)
 		}
 	}
+}
 
-	return style
+// applyMarkupAttribute sets or clears the attribute named by token.
+// Returns false if the token is not an attribute name.
+func applyMarkupAttribute(style *SpanStyle, token string, on bool) bool {
+	underline := func(u UnderlineStyle) UnderlineStyle {
+		if on {
+			return u
+		}
+		return UnderlineNone
+	}
+	align := func(a SpanVerticalAlign) SpanVerticalAlign {
+		if on {
+			return a
+		}
+		return SpanAlignBaseline
+	}
+
+	switch token {
+	case "bold", "b":
+		style.Bold = on
+	case "dim", "faint", "d":
+		style.Faint = on
+	case "italic", "i":
+		style.Italic = on
+	case "underline", "u":
+		style.Underline = underline(UnderlineSingle)
+	case "uu":
+		style.Underline = underline(UnderlineDouble)
+	case "curly":
+		style.Underline = underline(UnderlineCurly)
+	case "dotted":
+		style.Underline = underline(UnderlineDotted)
+	case "dashed":
+		style.Underline = underline(UnderlineDashed)
+	case "strike", "strikethrough", "s":
+		style.Strikethrough = on
+	case "reverse", "r":
+		style.Reverse = on
+	case "blink":
+		style.Blink = on
+	case "conceal":
+		style.Conceal = on
+	case "sup":
+		style.VerticalAlign = align(SpanAlignSuperscript)
+	case "sub":
+		style.VerticalAlign = align(SpanAlignSubscript)
+	default:
+		return false
+	}
+	return true
 }
 
 func tokenizeTagContent(content string) []string {
@@ -221,42 +456,139 @@ func (p *markupParser) resolveColor(token string) (Color, bool) {
 	return Color{}, false
 }
 
+// markupThemeColors lists the theme colors addressable as $Name in markup.
+var markupThemeColors = [...]struct {
+	name  string
+	color func(ThemeData) Color
+}{
+	{"Primary", func(t ThemeData) Color { return t.Primary }},
+	{"Secondary", func(t ThemeData) Color { return t.Secondary }},
+	{"Accent", func(t ThemeData) Color { return t.Accent }},
+	{"Background", func(t ThemeData) Color { return t.Background }},
+	{"Surface", func(t ThemeData) Color { return t.Surface }},
+	{"SurfaceHover", func(t ThemeData) Color { return t.SurfaceHover }},
+	{"Text", func(t ThemeData) Color { return t.Text }},
+	{"TextMuted", func(t ThemeData) Color { return t.TextMuted }},
+	{"TextOnPrimary", func(t ThemeData) Color { return t.TextOnPrimary }},
+	{"Border", func(t ThemeData) Color { return t.Border }},
+	{"FocusRing", func(t ThemeData) Color { return t.FocusRing }},
+	{"Error", func(t ThemeData) Color { return t.Error }},
+	{"Warning", func(t ThemeData) Color { return t.Warning }},
+	{"Success", func(t ThemeData) Color { return t.Success }},
+	{"Info", func(t ThemeData) Color { return t.Info }},
+}
+
 func (p *markupParser) resolveThemeColor(name string) (Color, bool) {
 	// Normalize: lowercase and remove underscores for comparison
 	normalized := strings.ToLower(strings.ReplaceAll(name, "_", ""))
 
-	switch normalized {
-	case "primary":
-		return p.theme.Primary, true
-	case "secondary":
-		return p.theme.Secondary, true
-	case "accent":
-		return p.theme.Accent, true
-	case "background":
-		return p.theme.Background, true
-	case "surface":
-		return p.theme.Surface, true
-	case "surfacehover":
-		return p.theme.SurfaceHover, true
-	case "text":
-		return p.theme.Text, true
-	case "textmuted":
-		return p.theme.TextMuted, true
-	case "textonprimary":
-		return p.theme.TextOnPrimary, true
-	case "border":
-		return p.theme.Border, true
-	case "focusring":
-		return p.theme.FocusRing, true
-	case "error":
-		return p.theme.Error, true
-	case "warning":
-		return p.theme.Warning, true
-	case "success":
-		return p.theme.Success, true
-	case "info":
-		return p.theme.Info, true
-	default:
-		return Color{}, false
+	for _, entry := range markupThemeColors {
+		if strings.ToLower(entry.name) == normalized {
+			return entry.color(p.theme), true
+		}
+	}
+	return Color{}, false
+}
+
+// markupEscaper doubles the characters markup gives meaning to.
+var markupEscaper = strings.NewReplacer(`\`, `\\`, "[", "[[", "]", "]]")
+
+// EscapeMarkup escapes square brackets and backslashes so text is rendered
+// literally by ParseMarkup, wherever it is placed in the markup.
+func EscapeMarkup(text string) string {
+	return markupEscaper.Replace(text)
+}
+
+// SpansToMarkup serializes spans into markup that ParseMarkup turns back
+// into the same spans. Colors equal to a theme color are written as $Name,
+// other colors as hex. Underline colors cannot be expressed in markup and
+// are dropped, as are link URLs containing spaces or brackets.
+//
+// Example:
+//
+//	SpansToMarkup([]Span{PlainSpan("Hi "), BoldSpan("there")}, theme) // "Hi [bold]there[/]"
+func SpansToMarkup(spans []Span, theme ThemeData) string {
+	var sb strings.Builder
+	for _, span := range spans {
+		if span.Text == "" {
+			continue
+		}
+		tag := spanStyleMarkupTag(span.Style, theme)
+		if tag == "" {
+			sb.WriteString(EscapeMarkup(span.Text))
+			continue
+		}
+		sb.WriteString("[" + tag + "]")
+		sb.WriteString(EscapeMarkup(span.Text))
+		sb.WriteString("[/]")
+	}
+	return sb.String()
+}
+
+// spanStyleMarkupTag returns the tag content describing style, or "" for an unstyled span.
+func spanStyleMarkupTag(style SpanStyle, theme ThemeData) string {
+	var tokens []string
+	flags := []struct {
+		on    bool
+		token string
+	}{
+		{style.Bold, "bold"},
+		{style.Faint, "dim"},
+		{style.Italic, "italic"},
+		{style.Strikethrough, "strike"},
+		{style.Reverse, "reverse"},
+		{style.Blink, "blink"},
+		{style.Conceal, "conceal"},
+	}
+	for _, flag := range flags {
+		if flag.on {
+			tokens = append(tokens, flag.token)
+		}
+	}
+
+	switch style.Underline {
+	case UnderlineSingle:
+		tokens = append(tokens, "underline")
+	case UnderlineDouble:
+		tokens = append(tokens, "uu")
+	case UnderlineCurly:
+		tokens = append(tokens, "curly")
+	case UnderlineDotted:
+		tokens = append(tokens, "dotted")
+	case UnderlineDashed:
+		tokens = append(tokens, "dashed")
+	}
+
+	switch style.VerticalAlign {
+	case SpanAlignSuperscript:
+		tokens = append(tokens, "sup")
+	case SpanAlignSubscript:
+		tokens = append(tokens, "sub")
+	}
+
+	if style.Foreground.IsSet() {
+		tokens = append(tokens, markupColorToken(style.Foreground, theme))
+	}
+	if style.Background.IsSet() {
+		tokens = append(tokens, "on", markupColorToken(style.Background, theme))
+	}
+	if style.Link != "" && !strings.ContainsAny(style.Link, " \t[]") {
+		tokens = append(tokens, "link="+style.Link)
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// markupColorToken returns $Name for theme colors and a hex literal otherwise.
+func markupColorToken(c Color, theme ThemeData) string {
+	for _, entry := range markupThemeColors {
+		if entry.color(theme) == c {
+			return "$" + entry.name
+		}
+	}
+	if !c.IsOpaque() {
+		r, g, b := c.RGB()
+		return fmt.Sprintf("#%02X%02X%02X%02X", r, g, b, uint8(math.Round(c.Alpha()*255)))
 	}
+	return c.Hex()
 }
@@ -1,6 +1,7 @@
 package terma
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -497,3 +498,306 @@ func TestParseMarkup_ComplexNesting(t *testing.T) {
 		}
 	}
 }
+
+func TestParseMarkup_Link(t *testing.T) {
+	spans := ParseMarkup("See [link=https://example.com u]the docs[/] now", testTheme)
+
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	if spans[1].Style.Link != "https://example.com" {
+		t.Errorf("expected link 'https://example.com', got '%s'", spans[1].Style.Link)
+	}
+	if spans[1].Style.Underline != UnderlineSingle {
+		t.Error("expected link span to be underlined")
+	}
+	if spans[2].Style.Link != "" {
+		t.Error("expected link to end with its tag")
+	}
+}
+
+func TestParseMarkup_LinkPreservesCase(t *testing.T) {
+	spans := ParseMarkup("[LINK=https://Example.com/Path]x[/]", testTheme)
+
+	if spans[0].Style.Link != "https://Example.com/Path" {
+		t.Errorf("expected URL case preserved, got '%s'", spans[0].Style.Link)
+	}
+}
+
+func TestParseMarkup_StyleAlias(t *testing.T) {
+	RegisterMarkupStyle("Caution", "bold $Warning")
+	RegisterMarkupStyle("shout", "caution underline")
+	defer delete(markupStyles.aliases, "caution")
+	defer delete(markupStyles.aliases, "shout")
+
+	spans := ParseMarkup("[caution]careful[/] [shout on $Surface]loud[/]", testTheme)
+
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	if !spans[0].Style.Bold || spans[0].Style.Foreground != testTheme.Warning {
+		t.Error("expected alias to apply bold $Warning")
+	}
+	if !spans[2].Style.Bold || spans[2].Style.Underline != UnderlineSingle {
+		t.Error("expected nested alias to expand")
+	}
+	if spans[2].Style.Background != testTheme.Surface {
+		t.Error("expected tag tokens to combine with alias")
+	}
+}
+
+func TestParseMarkup_SelfReferencingAliasTerminates(t *testing.T) {
+	RegisterMarkupStyle("loop", "loop bold")
+	defer delete(markupStyles.aliases, "loop")
+
+	spans := ParseMarkup("[loop]x[/]", testTheme)
+
+	if len(spans) != 1 || !spans[0].Style.Bold {
+		t.Error("expected self-referencing alias to still apply its attributes")
+	}
+}
+
+func TestParseMarkup_ExtendedAttributes(t *testing.T) {
+	spans := ParseMarkup("[dim strike reverse blink conceal curly sup]x[/]", testTheme)
+
+	style := spans[0].Style
+	if !style.Faint || !style.Strikethrough || !style.Reverse || !style.Blink || !style.Conceal {
+		t.Errorf("expected all attributes set, got %+v", style)
+	}
+	if style.Underline != UnderlineCurly {
+		t.Errorf("expected curly underline, got %v", style.Underline)
+	}
+	if style.VerticalAlign != SpanAlignSuperscript {
+		t.Error("expected superscript")
+	}
+}
+
+func TestParseMarkup_NotTurnsOffInheritedAttribute(t *testing.T) {
+	spans := ParseMarkup("[b i $Error]A[not b]B[/][/]", testTheme)
+
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[1].Style.Bold {
+		t.Error("expected bold turned off")
+	}
+	if !spans[1].Style.Italic || spans[1].Style.Foreground != testTheme.Error {
+		t.Error("expected other inherited styles to remain")
+	}
+}
+
+func TestParseMarkup_BackslashEscape(t *testing.T) {
+	spans := ParseMarkup(`\[b] and \]`, testTheme)
+
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Text != "[b] and ]" {
+		t.Errorf("expected '[b] and ]', got '%s'", spans[0].Text)
+	}
+
+	spans = ParseMarkup(`C:\\[b]dir[/] \n`, testTheme)
+	if len(spans) != 3 || spans[0].Text != `C:\` || !spans[1].Style.Bold || spans[2].Text != ` \n` {
+		t.Errorf("unexpected spans %+v", spans)
+	}
+
+	spans = ParseMarkup(`\\\\server\share`, testTheme)
+	if len(spans) != 1 || spans[0].Text != `\\server\share` {
+		t.Errorf("expected \\\\ to be a backslash, got %+v", spans)
+	}
+}
+
+func TestEscapeMarkup_RoundTrips(t *testing.T) {
+	for _, text := range []string{"arr[0] = [x]]", `C:\[dir]`, `a\]`, `x\\[y`, `\\server\share\`} {
+		spans := ParseMarkup(EscapeMarkup(text), testTheme)
+		if len(spans) != 1 || spans[0].Text != text {
+			t.Errorf("expected literal %q, got %+v", text, spans)
+		}
+	}
+}
+
+func TestSpansToMarkup_RoundTripsBackslashesAndBrackets(t *testing.T) {
+	spans := []Span{
+		PlainSpan(`C:\[dir]`),
+		BoldSpan(`ends in \`),
+		PlainSpan(`a\]`),
+		PlainSpan(`x\\[y`),
+		ItalicSpan(`[\]`),
+	}
+	parsed := ParseMarkup(SpansToMarkup(spans, testTheme), testTheme)
+
+	want := []string{`C:\[dir]`, `ends in \`, `a\]x\\[y`, `[\]`}
+	if len(parsed) != len(want) {
+		t.Fatalf("expected %d spans, got %+v", len(want), parsed)
+	}
+	for i, text := range want {
+		if parsed[i].Text != text {
+			t.Errorf("span %d: expected %q, got %q", i, text, parsed[i].Text)
+		}
+	}
+	if !parsed[1].Style.Bold || !parsed[3].Style.Italic {
+		t.Errorf("expected styles to round-trip, got %+v", parsed)
+	}
+}
+
+func TestSpansToMarkup(t *testing.T) {
+	spans := []Span{
+		PlainSpan("Press "),
+		{Text: "Enter", Style: SpanStyle{Bold: true, Foreground: testTheme.Accent}},
+		PlainSpan(" or [q]"),
+		{Text: "docs", Style: SpanStyle{Underline: UnderlineSingle, Link: "https://example.com", Background: Hex("#123456")}},
+	}
+
+	got := SpansToMarkup(spans, testTheme)
+	want := "Press [bold $Accent]Enter[/] or [[q]][underline on #123456 link=https://example.com]docs[/]"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestSpansToMarkup_RoundTrip(t *testing.T) {
+	spans := []Span{
+		{Text: "a", Style: SpanStyle{Faint: true, Italic: true, Strikethrough: true}},
+		{Text: "b", Style: SpanStyle{Underline: UnderlineDouble, VerticalAlign: SpanAlignSubscript}},
+		{Text: "c]", Style: SpanStyle{Foreground: RGBA(10, 20, 30, 0.5), Background: testTheme.Surface}},
+		PlainSpan("[d]"),
+	}
+
+	parsed := ParseMarkup(SpansToMarkup(spans, testTheme), testTheme)
+	if len(parsed) != len(spans) {
+		t.Fatalf("expected %d spans, got %d", len(spans), len(parsed))
+	}
+	for i := range spans {
+		if parsed[i].Text != spans[i].Text {
+			t.Errorf("span %d: expected text %q, got %q", i, spans[i].Text, parsed[i].Text)
+		}
+		if parsed[i].Style.Bold != spans[i].Style.Bold ||
+			parsed[i].Style.Faint != spans[i].Style.Faint ||
+			parsed[i].Style.Underline != spans[i].Style.Underline ||
+			parsed[i].Style.VerticalAlign != spans[i].Style.VerticalAlign ||
+			parsed[i].Style.Background != spans[i].Style.Background ||
+			parsed[i].Style.Foreground.Hex() != spans[i].Style.Foreground.Hex() {
+			t.Errorf("span %d: expected style %+v, got %+v", i, spans[i].Style, parsed[i].Style)
+		}
+	}
+}
+
+func TestParseMarkup_CachedResultIsCallersOwn(t *testing.T) {
+	theme, _ := GetTheme(ThemeNameDracula)
+
+	first := ParseMarkup("[b $Primary]cached[/] text", theme)
+	first[0].Text = "changed"
+
+	second := ParseMarkup("[b $Primary]cached[/] text", theme)
+	if len(second) != 2 || second[0].Text != "cached" {
+		t.Fatalf("expected changes to a result not to reach the cache, got %+v", second)
+	}
+	if second[0].Style.Foreground != theme.Primary {
+		t.Error("expected cached span to keep the theme color")
+	}
+}
+
+func TestParseMarkup_CacheFollowsTheme(t *testing.T) {
+	dracula, _ := GetTheme(ThemeNameDracula)
+	nord, _ := GetTheme(ThemeNameNord)
+
+	if got := ParseMarkup("[$Primary]x[/]", dracula)[0].Style.Foreground; got != dracula.Primary {
+		t.Errorf("expected dracula primary, got %v", got)
+	}
+	if got := ParseMarkup("[$Primary]x[/]", nord)[0].Style.Foreground; got != nord.Primary {
+		t.Errorf("expected nord primary, got %v", got)
+	}
+
+	extended := ExtendTheme(ThemeNameDracula, WithPrimary(Hex("#123456")))
+	if got := ParseMarkup("[$Primary]x[/]", extended)[0].Style.Foreground; got != Hex("#123456") {
+		t.Errorf("expected an unregistered theme to be parsed, not served from the cache, got %v", got)
+	}
+
+	modified := dracula
+	modified.Primary = Hex("#654321")
+	if got := ParseMarkup("[$Primary]x[/]", modified)[0].Style.Foreground; got != Hex("#654321") {
+		t.Errorf("expected a modified copy of a registered theme to use its own colors, got %v", got)
+	}
+}
+
+func TestParseMarkup_RegisterMarkupStyleClearsCache(t *testing.T) {
+	theme, _ := GetTheme(ThemeNameDracula)
+	defer delete(markupStyles.aliases, "cache-alias")
+
+	RegisterMarkupStyle("cache-alias", "bold")
+	if !ParseMarkup("[cache-alias]x[/]", theme)[0].Style.Bold {
+		t.Fatal("expected alias to apply bold")
+	}
+	RegisterMarkupStyle("cache-alias", "italic")
+	spans := ParseMarkup("[cache-alias]x[/]", theme)
+	if spans[0].Style.Bold || !spans[0].Style.Italic {
+		t.Error("expected the redefined alias to apply")
+	}
+}
+
+func TestRegisterMarkupStyle_WhileParsing(t *testing.T) {
+	theme, _ := GetTheme(ThemeNameDracula)
+	defer delete(markupStyles.aliases, "concurrent-alias")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 100 {
+			RegisterMarkupStyle("concurrent-alias", fmt.Sprintf("bold #%06x", i))
+		}
+	}()
+	for range 100 {
+		ParseMarkup("[concurrent-alias]x[/]", theme)
+	}
+	<-done
+
+	if got := ParseMarkup("[concurrent-alias]x[/]", theme)[0].Style.Foreground; got != Hex("#000063") {
+		t.Errorf("expected the last registered alias, got %v", got)
+	}
+}
+
+func TestPrecompiledMarkup_ReusesSpans(t *testing.T) {
+	theme, _ := GetTheme(ThemeNameDracula)
+	markup := CompileMarkup("Press [b]?[/] for help")
+
+	first := markup.Spans(theme)
+	second := markup.Spans(theme)
+	if len(first) != 3 || &first[0] != &second[0] {
+		t.Fatal("expected the same spans to be returned without parsing again")
+	}
+	if markup.Markup() != "Press [b]?[/] for help" {
+		t.Errorf("unexpected markup %q", markup.Markup())
+	}
+	if text := markup.Text(theme); len(text.Spans) != 3 || !text.Spans[1].Style.Bold {
+		t.Error("expected Text to show the parsed spans")
+	}
+}
+
+func TestPrecompiledMarkup_ReparsesWhenThemeOrStylesChange(t *testing.T) {
+	dracula, _ := GetTheme(ThemeNameDracula)
+	nord, _ := GetTheme(ThemeNameNord)
+	markup := CompileMarkup("[$Primary]x[/]")
+
+	if got := markup.Spans(dracula)[0].Style.Foreground; got != dracula.Primary {
+		t.Errorf("expected dracula primary, got %v", got)
+	}
+	if got := markup.Spans(nord)[0].Style.Foreground; got != nord.Primary {
+		t.Errorf("expected nord primary after the theme changed, got %v", got)
+	}
+	modified := nord
+	modified.Primary = Hex("#654321")
+	if got := markup.Spans(modified)[0].Style.Foreground; got != Hex("#654321") {
+		t.Errorf("expected the modified primary, got %v", got)
+	}
+
+	defer delete(markupStyles.aliases, "precompiled-alias")
+	aliased := CompileMarkup("[precompiled-alias]x[/]")
+	RegisterMarkupStyle("precompiled-alias", "bold")
+	if !aliased.Spans(nord)[0].Style.Bold {
+		t.Fatal("expected alias to apply bold")
+	}
+	RegisterMarkupStyle("precompiled-alias", "italic")
+	if !aliased.Spans(nord)[0].Style.Italic {
+		t.Error("expected the spans to be parsed again after the alias changed")
+	}
+}
@@ -0,0 +1,373 @@
+package terma
+
+import (
+	"reflect"
+	"sort"
+	"unicode"
+)
+
+// Matcher is a pluggable matching algorithm. Set it on FilterState (or
+// FilterOptions) to replace the built-in modes. Match should fill Ranges for
+// highlighting and Score for ranking (higher is better).
+//
+// Filtering narrows results as the query grows, so a Matcher must not match
+// an extension of a query that an item failed.
+type Matcher interface {
+	Match(text, query string, options FilterOptions) MatchResult
+}
+
+// MatcherFunc adapts a function to the Matcher interface.
+type MatcherFunc func(text, query string, options FilterOptions) MatchResult
+
+// Match calls f.
+func (f MatcherFunc) Match(text, query string, options FilterOptions) MatchResult {
+	return f(text, query, options)
+}
+
+// Scoring weights, modelled on fzf: every matched character earns
+// scoreMatch; characters at word starts earn a bonus (doubled for the first
+// query character); runs of consecutive matches earn at least
+// bonusConsecutive; gaps between matches cost a start penalty plus a
+// per-character extension.
+const (
+	scoreMatch        = 16
+	scoreGapStart     = -3
+	scoreGapExtension = -1
+	bonusBoundary     = 8
+	bonusCamel        = 7
+	bonusConsecutive  = 4
+	bonusFirstFactor  = 2
+)
+
+// scoredMatchLimit caps the text*query size the optimal alignment search is
+// run on; longer inputs fall back to the leftmost alignment.
+const scoredMatchLimit = 64 * 1024
+
+// MatchField is one searchable field of an item for MatchFields.
+type MatchField struct {
+	Text   string
+	Weight float64 // Multiplies the field's score (0 = 1)
+}
+
+// FieldsMatch is the result of MatchFields.
+type FieldsMatch struct {
+	Matched bool
+	Score   int           // Highest weighted field score
+	Fields  []MatchResult // One result per field, for highlighting
+}
+
+// MatchFields matches query against several fields of an item, such as a
+// name and a description, weighting each field's score. The item matches if
+// any field does.
+//
+// Example:
+//
+//	m := t.MatchFields(query, options,
+//	    t.MatchField{Text: cmd.Name, Weight: 2},
+//	    t.MatchField{Text: cmd.Description},
+//	)
+func MatchFields(query string, options FilterOptions, fields ...MatchField) FieldsMatch {
+	result := FieldsMatch{Fields: make([]MatchResult, len(fields))}
+	for i, field := range fields {
+		match := MatchString(field.Text, query, options)
+		result.Fields[i] = match
+		if !match.Matched {
+			continue
+		}
+		score := weightScore(match.Score, field.Weight)
+		if !result.Matched || score > result.Score {
+			result.Score = score
+		}
+		result.Matched = true
+	}
+	return result
+}
+
+// weightScore scales a score by weight, treating 0 as 1.
+func weightScore(score int, weight float64) int {
+	if weight == 0 {
+		return score
+	}
+	return int(float64(score) * weight)
+}
+
+// foldCase reports whether matching should ignore case for query.
+func foldCase(query string, options FilterOptions) bool {
+	if options.CaseSensitive {
+		return false
+	}
+	if options.SmartCase {
+		for _, r := range query {
+			if unicode.IsUpper(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// charClass groups runes for word-boundary bonuses.
+type charClass int
+
+const (
+	charOther charClass = iota
+	charLower
+	charUpper
+	charDigit
+)
+
+func classOf(r rune) charClass {
+	switch {
+	case unicode.IsLower(r):
+		return charLower
+	case unicode.IsUpper(r):
+		return charUpper
+	case unicode.IsDigit(r):
+		return charDigit
+	}
+	return charOther
+}
+
+// positionBonus returns the bonus for matching the rune of class current
+// that follows a rune of class previous.
+func positionBonus(previous, current charClass) int {
+	switch {
+	case current == charOther:
+		return 0
+	case previous == charOther:
+		return bonusBoundary
+	case previous == charLower && current == charUpper:
+		return bonusCamel
+	case previous != charDigit && current == charDigit:
+		return bonusCamel
+	}
+	return 0
+}
+
+// textRunes holds text decoded for scoring.
+type textRunes struct {
+	runes   []rune // Case-folded when matching ignores case
+	offsets []int  // Byte offset of each rune in the original text
+	bonus   []int  // positionBonus for each rune
+}
+
+func decodeText(text string, fold bool) textRunes {
+	decoded := textRunes{
+		runes:   make([]rune, 0, len(text)),
+		offsets: make([]int, 0, len(text)),
+		bonus:   make([]int, 0, len(text)),
+	}
+	previous := charOther
+	for offset, r := range text {
+		class := classOf(r)
+		decoded.bonus = append(decoded.bonus, positionBonus(previous, class))
+		previous = class
+		if fold {
+			r = unicode.ToLower(r)
+		}
+		decoded.runes = append(decoded.runes, r)
+		decoded.offsets = append(decoded.offsets, offset)
+	}
+	return decoded
+}
+
+// byteRange returns the byte range in the original text of runes [from, to).
+func (t textRunes) byteRange(text string, from, to int) MatchRange {
+	end := len(text)
+	if to < len(t.offsets) {
+		end = t.offsets[to]
+	}
+	return MatchRange{Start: t.offsets[from], End: end}
+}
+
+// scorePositions scores a match at the given rune positions.
+func scorePositions(text textRunes, positions []int) int {
+	score := 0
+	for i, pos := range positions {
+		bonus := text.bonus[pos]
+		switch {
+		case i == 0:
+			bonus *= bonusFirstFactor
+		case pos == positions[i-1]+1:
+			bonus = max(bonus, bonusConsecutive)
+		default:
+			score += scoreGapStart + scoreGapExtension*(pos-positions[i-1]-2)
+		}
+		score += scoreMatch + bonus
+	}
+	return score
+}
+
+// scoreMatchRanges scores a match given as byte ranges into text, so every
+// mode produces comparable scores.
+func scoreMatchRanges(text string, ranges []MatchRange, fold bool) int {
+	if len(ranges) == 0 {
+		return 0
+	}
+	decoded := decodeText(text, fold)
+	var positions []int
+	for i, offset := range decoded.offsets {
+		for _, r := range ranges {
+			if offset >= r.Start && offset < r.End {
+				positions = append(positions, i)
+				break
+			}
+		}
+	}
+	return scorePositions(decoded, positions)
+}
+
+// matchScored finds the highest-scoring in-order alignment of query in text
+// (Smith-Waterman style, as in fzf), returning its ranges and score.
+func matchScored(text, query string, options FilterOptions) MatchResult {
+	if query == "" {
+		return MatchResult{Matched: true}
+	}
+	fold := foldCase(query, options)
+	decoded := decodeText(text, fold)
+	needle := []rune(query)
+	if fold {
+		for i, r := range needle {
+			needle[i] = unicode.ToLower(r)
+		}
+	}
+	n, m := len(decoded.runes), len(needle)
+	if m > n {
+		return MatchResult{}
+	}
+	if n*m > scoredMatchLimit {
+		positions := leftmostPositions(decoded.runes, needle)
+		if positions == nil {
+			return MatchResult{}
+		}
+		return scoredResult(text, decoded, positions)
+	}
+
+	// score[i][j] is the best score for query[:i+1] with query[i] matched at
+	// text[j]; from[i][j] is where query[i-1] was matched in that alignment.
+	const none = -1 << 30
+	score := make([][]int, m)
+	from := make([][]int, m)
+	for i := range score {
+		score[i] = make([]int, n)
+		from[i] = make([]int, n)
+		for j := range score[i] {
+			score[i][j] = none
+		}
+	}
+	for j := 0; j < n; j++ {
+		if decoded.runes[j] == needle[0] {
+			score[0][j] = scoreMatch + decoded.bonus[j]*bonusFirstFactor
+		}
+	}
+	for i := 1; i < m; i++ {
+		// gapBest is the best score[i-1][k] + gap penalty for k <= j-2.
+		gapBest, gapFrom := none, -1
+		for j := i; j < n; j++ {
+			if j >= 2 {
+				gapBest += scoreGapExtension
+				if candidate := score[i-1][j-2] + scoreGapStart; candidate > gapBest {
+					gapBest, gapFrom = candidate, j-2
+				}
+			}
+			if decoded.runes[j] != needle[i] {
+				continue
+			}
+			best, bestFrom := none, -1
+			if prev := score[i-1][j-1]; prev > none {
+				best = prev + scoreMatch + max(decoded.bonus[j], bonusConsecutive)
+				bestFrom = j - 1
+			}
+			if gapBest > none/2 {
+				if candidate := gapBest + scoreMatch + decoded.bonus[j]; candidate > best {
+					best, bestFrom = candidate, gapFrom
+				}
+			}
+			if bestFrom >= 0 {
+				score[i][j] = best
+				from[i][j] = bestFrom
+			}
+		}
+	}
+
+	end, best := -1, none
+	for j := m - 1; j < n; j++ {
+		if score[m-1][j] > best {
+			end, best = j, score[m-1][j]
+		}
+	}
+	if end < 0 {
+		return MatchResult{}
+	}
+	positions := make([]int, m)
+	for i, j := m-1, end; i >= 0; i-- {
+		positions[i] = j
+		j = from[i][j]
+	}
+	result := scoredResult(text, decoded, positions)
+	result.Score = best
+	return result
+}
+
+// leftmostPositions greedily matches needle in order, or returns nil.
+func leftmostPositions(haystack, needle []rune) []int {
+	positions := make([]int, 0, len(needle))
+	for j, r := range haystack {
+		if len(positions) < len(needle) && r == needle[len(positions)] {
+			positions = append(positions, j)
+		}
+	}
+	if len(positions) < len(needle) {
+		return nil
+	}
+	return positions
+}
+
+// scoredResult converts rune positions to a MatchResult with merged ranges.
+func scoredResult(text string, decoded textRunes, positions []int) MatchResult {
+	ranges := make([]MatchRange, 0, len(positions))
+	for _, pos := range positions {
+		r := decoded.byteRange(text, pos, pos+1)
+		if n := len(ranges); n > 0 && ranges[n-1].End == r.Start {
+			ranges[n-1].End = r.End
+			continue
+		}
+		ranges = append(ranges, r)
+	}
+	return MatchResult{Matched: true, Ranges: ranges, Score: scorePositions(decoded, positions)}
+}
+
+// sortByScore orders results by descending score, keeping the existing
+// order for ties.
+func sortByScore(order []int, score func(i int) int) {
+	sort.SliceStable(order, func(a, b int) bool {
+		return score(order[a]) > score(order[b])
+	})
+}
+
+// sameFilterOptions reports whether two option sets filter identically.
+// Matchers are compared by identity.
+func sameFilterOptions(a, b FilterOptions) bool {
+	if a.Mode != b.Mode || a.CaseSensitive != b.CaseSensitive || a.SmartCase != b.SmartCase || a.SortByScore != b.SortByScore {
+		return false
+	}
+	return sameMatcher(a.Matcher, b.Matcher)
+}
+
+func sameMatcher(a, b Matcher) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	if ta != tb {
+		return false
+	}
+	if ta.Comparable() {
+		return a == b
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if ta.Kind() == reflect.Func {
+		return va.Pointer() == vb.Pointer()
+	}
+	return false
+}
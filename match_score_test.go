@@ -0,0 +1,123 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchScored_PrefersWordBoundaries(t *testing.T) {
+	result := MatchString("fob_bar", "fb", FilterOptions{Mode: FilterScored})
+	require.True(t, result.Matched)
+	assert.Equal(t, []MatchRange{{Start: 0, End: 1}, {Start: 4, End: 5}}, result.Ranges, "b after _ beats the b in fob")
+
+	camel := MatchString("getFooBar", "gfb", FilterOptions{Mode: FilterScored})
+	assert.Equal(t, []MatchRange{{Start: 0, End: 1}, {Start: 3, End: 4}, {Start: 6, End: 7}}, camel.Ranges)
+
+	assert.False(t, MatchString("foo", "oof", FilterOptions{Mode: FilterScored}).Matched)
+}
+
+func TestMatchScored_RanksTighterAndBoundaryMatchesHigher(t *testing.T) {
+	options := FilterOptions{Mode: FilterScored}
+	boundary := MatchString("open_file", "of", options).Score
+	scattered := MatchString("prooffile", "of", options).Score
+	consecutive := MatchString("ofx", "of", options).Score
+	gapped := MatchString("o---f", "of", options).Score
+
+	assert.Greater(t, boundary, scattered)
+	assert.Greater(t, consecutive, gapped)
+}
+
+func TestMatchString_SmartCase(t *testing.T) {
+	options := FilterOptions{SmartCase: true}
+	assert.True(t, MatchString("README", "read", options).Matched, "lowercase query ignores case")
+	assert.False(t, MatchString("readme", "Read", options).Matched, "uppercase query is case sensitive")
+	assert.True(t, MatchString("Readme", "Read", options).Matched)
+}
+
+func TestMatchString_ScoresEveryMode(t *testing.T) {
+	for _, mode := range []FilterMode{FilterContains, FilterFuzzy, FilterScored} {
+		result := MatchString("file_name", "name", FilterOptions{Mode: mode})
+		assert.Positive(t, result.Score, "mode %v", mode)
+	}
+}
+
+func TestMatchFields_WeightsFields(t *testing.T) {
+	options := FilterOptions{}
+	name := MatchFields("save", options,
+		MatchField{Text: "Save file", Weight: 2},
+		MatchField{Text: "Writes the buffer"},
+	)
+	description := MatchFields("save", options,
+		MatchField{Text: "Write file", Weight: 2},
+		MatchField{Text: "Save the buffer"},
+	)
+
+	require.True(t, name.Matched)
+	require.True(t, description.Matched)
+	assert.Equal(t, 2*description.Score, name.Score)
+	assert.True(t, name.Fields[0].Matched)
+	assert.False(t, name.Fields[1].Matched)
+	assert.False(t, MatchFields("zzz", options, MatchField{Text: "abc"}).Matched)
+}
+
+func TestList_SortByScore(t *testing.T) {
+	state := NewListState([]string{"profile", "pro_file", "proxfile", "file"})
+	filter := NewFilterState()
+	filter.SortByScore.Set(true)
+	filter.Query.Set("f")
+	list := List[string]{State: state, Filter: filter}
+
+	list.Build(newTestBuildContext())
+	assert.Equal(t, []int{1, 3, 0, 2}, state.viewIndices, "boundary matches first, ties keep source order")
+}
+
+func TestTableFilteredRows_ColumnWeights(t *testing.T) {
+	rows := [][]string{
+		{"misc", "deploy"},
+		{"deploy", "misc"},
+	}
+	table := Table[[]string]{Columns: []TableColumn{{FilterWeight: 3}, {}}}
+
+	_, indices, _ := table.filteredRows(rows, 2, "deploy", FilterOptions{SortByScore: true})
+	assert.Equal(t, []int{1, 0}, indices)
+}
+
+func TestCommandPaletteFilteredView_SortByScore(t *testing.T) {
+	items := []CommandPaletteItem{{Label: "Reopen File"}, {Label: "Open File"}}
+	filter := NewFilterState()
+	filter.Query.Set("of")
+	filter.Mode.Set(FilterScored)
+
+	view := commandPaletteFilteredView(items, filter)
+	assert.Equal(t, []int{1, 0}, view.Indices)
+}
+
+func TestFilterState_CustomMatcher(t *testing.T) {
+	prefix := MatcherFunc(func(text, query string, options FilterOptions) MatchResult {
+		if !strings.HasPrefix(text, query) {
+			return MatchResult{}
+		}
+		return MatchResult{Matched: true, Ranges: []MatchRange{{Start: 0, End: len(query)}}, Score: 1}
+	})
+	state := NewListState([]string{"beta", "alpha", "alphabet"})
+	filter := NewFilterState()
+	filter.Matcher = prefix
+	filter.Query.Set("al")
+	list := List[string]{State: state, Filter: filter}
+
+	list.Build(newTestBuildContext())
+	assert.Equal(t, []int{1, 2}, state.viewIndices)
+}
+
+func TestSameFilterOptions(t *testing.T) {
+	fn := MatcherFunc(matchScored)
+	other := MatcherFunc(func(text, query string, options FilterOptions) MatchResult { return MatchResult{} })
+
+	assert.True(t, sameFilterOptions(FilterOptions{Matcher: fn}, FilterOptions{Matcher: fn}))
+	assert.False(t, sameFilterOptions(FilterOptions{Matcher: fn}, FilterOptions{Matcher: other}))
+	assert.False(t, sameFilterOptions(FilterOptions{Matcher: fn}, FilterOptions{}))
+	assert.False(t, sameFilterOptions(FilterOptions{}, FilterOptions{SortByScore: true}))
+}
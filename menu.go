@@ -303,7 +303,7 @@ func (m Menu) menuStyle(ctx BuildContext) Style {
 	theme := ctx.Theme()
 
 	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
-		style.BackgroundColor = theme.Surface
+		style.BackgroundColor = theme.Token(TokenSurfaceFloating)
 	}
 
 	return style
@@ -314,7 +314,7 @@ func (m Menu) renderItem(ctx BuildContext, item MenuItem, index int, active bool
 
 	if item.IsDivider() {
 		prefix, line := layout.dividerParts(item.Divider)
-		lineColor := theme.TextMuted.Blend(theme.Surface, 0.7)
+		lineColor := theme.TextMuted.Blend(theme.Token(TokenSurfaceFloating), 0.7)
 		rowStyle := Style{
 			Padding: EdgeInsetsXY(layout.paddingX, 0),
 			Width:   itemWidth,
@@ -518,10 +518,8 @@ type menuItemLayout struct {
 	spacing      int
 }
 
-const menuItemPaddingX = 1
-
 func computeMenuItemLayout(items []MenuItem, width Dimension) menuItemLayout {
-	layout := menuItemLayout{paddingX: menuItemPaddingX}
+	layout := menuItemLayout{paddingX: getDensity().Pick(0, 1, 2)}
 	maxDividerPrefixWidth := 0
 	for _, item := range items {
 		if item.IsDivider() {
@@ -0,0 +1,432 @@
+package terma
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/darrenburns/terma/layout"
+)
+
+// Threshold colors values at or above Value.
+type Threshold struct {
+	Value float64
+	Color Color
+}
+
+// thresholdColor returns the color of the highest threshold value reaches,
+// or fallback if it reaches none.
+func thresholdColor(thresholds []Threshold, value float64, fallback Color) Color {
+	color := fallback
+	best := math.Inf(-1)
+	for _, t := range thresholds {
+		if value >= t.Value && t.Value >= best {
+			best = t.Value
+			color = t.Color
+		}
+	}
+	return color
+}
+
+// monitorLayoutNode builds the layout node for a leaf monitor widget.
+func monitorLayoutNode(widget Widget, style Style) layout.LayoutNode {
+	padding := toLayoutEdgeInsets(style.Padding)
+	border := borderToEdgeInsets(style.Border)
+	dims := GetWidgetDimensionSet(widget)
+	minWidth, maxWidth, minHeight, maxHeight := dimensionSetToMinMax(dims, padding, border)
+
+	node := layout.LayoutNode(&layout.BoxNode{
+		Padding:      padding,
+		Border:       border,
+		Margin:       toLayoutEdgeInsets(style.Margin),
+		MinWidth:     minWidth,
+		MaxWidth:     maxWidth,
+		MinHeight:    minHeight,
+		MaxHeight:    maxHeight,
+		ExpandWidth:  dims.Width.IsFlex() || dims.Width.IsPercent(),
+		ExpandHeight: dims.Height.IsFlex() || dims.Height.IsPercent(),
+	})
+
+	if hasPercentMinMax(dims) {
+		node = &percentConstraintWrapper{
+			child:     node,
+			minWidth:  dims.MinWidth,
+			maxWidth:  dims.MaxWidth,
+			minHeight: dims.MinHeight,
+			maxHeight: dims.MaxHeight,
+			padding:   padding,
+			border:    border,
+		}
+	}
+
+	return node
+}
+
+// MeterSegment is one stacked part of a Meter, such as user or system CPU time.
+type MeterSegment struct {
+	Value float64
+	Color Color // Optional; defaults to the threshold color for the first segment, then theme colors
+}
+
+// Meter is a one-line bar in the style of htop's CPU and memory meters:
+// a label, then stacked segments between brackets with the total printed
+// at the right end of the bar.
+//
+// Example:
+//
+//	terma.Meter{
+//	    Label: "CPU",
+//	    Value: cpuPercent.Get(),
+//	    Thresholds: []terma.Threshold{
+//	        {Value: 60, Color: theme.Warning},
+//	        {Value: 90, Color: theme.Error},
+//	    },
+//	}
+type Meter struct {
+	ID         string                          // Optional unique identifier
+	Label      string                          // Optional text before the bar, such as "CPU"
+	LabelWidth int                             // Width of the label column (default: label width + 1)
+	Value      float64                         // Value of a single-segment meter (ignored when Segments is set)
+	Segments   []MeterSegment                  // Stacked segments, drawn left to right
+	Max        float64                         // Full-scale value (default 100)
+	Thresholds []Threshold                     // Colors for segments without a Color, by the meter's total
+	Format     func(total, max float64) string // Text at the right of the bar (default: percentage; return "" to hide)
+	BarChar    string                          // Character used to fill the bar (default "|")
+	Style      Style                           // Optional styling
+}
+
+// Build returns itself as Meter is a leaf widget.
+func (m Meter) Build(ctx BuildContext) Widget {
+	return m
+}
+
+// WidgetID returns the meter's unique identifier.
+// Implements the Identifiable interface.
+func (m Meter) WidgetID() string {
+	return m.ID
+}
+
+// GetContentDimensions returns the width and height dimension preferences.
+// Width defaults to Flex(1), Height defaults to Cells(1).
+func (m Meter) GetContentDimensions() (width, height Dimension) {
+	dims := m.Style.GetDimensions()
+	width, height = dims.Width, dims.Height
+	if width.IsUnset() {
+		width = Flex(1)
+	}
+	if height.IsUnset() {
+		height = Cells(1)
+	}
+	return width, height
+}
+
+// GetStyle returns the style of the meter.
+func (m Meter) GetStyle() Style {
+	return m.Style
+}
+
+// BuildLayoutNode builds a layout node for this Meter widget.
+func (m Meter) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return monitorLayoutNode(m, m.Style)
+}
+
+// segments returns the meter's segments with colors resolved.
+func (m Meter) segments(theme ThemeData) []MeterSegment {
+	segments := m.Segments
+	if len(segments) == 0 {
+		segments = []MeterSegment{{Value: m.Value}}
+	}
+	total := 0.0
+	for _, segment := range segments {
+		total += max(segment.Value, 0)
+	}
+	palette := []Color{theme.Success, theme.Error, theme.Info, theme.Warning, theme.Accent}
+	resolved := make([]MeterSegment, len(segments))
+	for i, segment := range segments {
+		if !segment.Color.IsSet() {
+			if i == 0 {
+				segment.Color = thresholdColor(m.Thresholds, total, palette[0])
+			} else {
+				segment.Color = palette[i%len(palette)]
+			}
+		}
+		resolved[i] = segment
+	}
+	return resolved
+}
+
+// Render draws the meter to the render context.
+func (m Meter) Render(ctx *RenderContext) {
+	if ctx.Width <= 0 || ctx.Height <= 0 {
+		return
+	}
+	theme := ctx.buildContext.Theme()
+	maxValue := m.Max
+	if maxValue <= 0 {
+		maxValue = 100
+	}
+	barChar := m.BarChar
+	if barChar == "" {
+		barChar = "|"
+	}
+
+	x := 0
+	if m.Label != "" || m.LabelWidth > 0 {
+		labelWidth := m.LabelWidth
+		if labelWidth <= 0 {
+			labelWidth = ansi.StringWidth(m.Label) + 1
+		}
+		ctx.DrawStyledText(0, 0, ansi.Truncate(m.Label, labelWidth, ""), Style{ForegroundColor: theme.Primary, Bold: true})
+		x = labelWidth
+	}
+	bracket := Style{ForegroundColor: theme.TextMuted, Bold: true}
+	barWidth := ctx.Width - x - 2
+	if barWidth <= 0 {
+		return
+	}
+	ctx.DrawStyledText(x, 0, "[", bracket)
+	ctx.DrawStyledText(x+1+barWidth, 0, "]", bracket)
+
+	// Lay out segment cells, rounding cumulative boundaries so stacked
+	// segments always add up to the total.
+	segments := m.segments(theme)
+	colors := make([]Color, barWidth)
+	total, end := 0.0, 0
+	for _, segment := range segments {
+		total += max(segment.Value, 0)
+		next := min(int(math.Round(total/maxValue*float64(barWidth))), barWidth)
+		for i := end; i < next; i++ {
+			colors[i] = segment.Color
+		}
+		end = max(end, next)
+	}
+
+	text := fmt.Sprintf("%.1f%%", total/maxValue*100)
+	if m.Format != nil {
+		text = m.Format(total, maxValue)
+	}
+	text = ansi.Truncate(text, barWidth, "")
+	textStart := barWidth - ansi.StringWidth(text)
+
+	for i := 0; i < textStart; i++ {
+		if colors[i].IsSet() {
+			ctx.DrawStyledText(x+1+i, 0, barChar, Style{ForegroundColor: colors[i]})
+		}
+	}
+	for i, g := range splitGraphemes(text) {
+		color := theme.TextMuted
+		if cell := textStart + i; cell < barWidth && colors[cell].IsSet() {
+			color = colors[cell]
+		}
+		ctx.DrawStyledText(x+1+textStart+i, 0, g, Style{ForegroundColor: color})
+	}
+}
+
+// HistoryState holds the recent samples shown by a HistoryGraph, oldest
+// first. Once Capacity samples are held, each new sample drops the oldest.
+type HistoryState struct {
+	Values   AnySignal[[]float64]
+	Capacity int
+
+	mu sync.Mutex
+}
+
+// NewHistoryState creates an empty HistoryState that keeps up to capacity samples.
+func NewHistoryState(capacity int) *HistoryState {
+	return &HistoryState{
+		Values:   NewAnySignal([]float64(nil)),
+		Capacity: capacity,
+	}
+}
+
+// Push appends a sample. It is safe to call from any goroutine.
+func (s *HistoryState) Push(value float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := s.Values.Peek()
+	start := 0
+	if s.Capacity > 0 && len(values) >= s.Capacity {
+		start = len(values) - s.Capacity + 1
+	}
+	next := make([]float64, 0, len(values)-start+1)
+	next = append(next, values[start:]...)
+	s.Values.Set(append(next, value))
+}
+
+// Clear removes all samples.
+func (s *HistoryState) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Values.Set(nil)
+}
+
+// Latest returns the newest sample.
+func (s *HistoryState) Latest() (float64, bool) {
+	values := s.Values.Peek()
+	if len(values) == 0 {
+		return 0, false
+	}
+	return values[len(values)-1], true
+}
+
+// Sample pushes source's value every interval, so that the history
+// advances at a steady rate even while the value is unchanged. Call the
+// returned function to stop sampling.
+//
+// Like NewSignal, call it once and keep the state; don't call Sample in Build.
+//
+// Example:
+//
+//	cpu := t.NewSignal(0.0) // updated by a poller
+//	history := t.NewHistoryState(120)
+//	stop := history.Sample(cpu, time.Second)
+func (s *HistoryState) Sample(source Signal[float64], interval time.Duration) (stop func()) {
+	timer := currentClock().Every(interval, func(time.Time) {
+		s.Push(source.Peek())
+	})
+	return func() { timer.Stop() }
+}
+
+// brailleDots are the braille dot bits for the left and right dot columns
+// of a cell, from the bottom row up.
+var brailleDots = [2][4]rune{
+	{0x40, 0x04, 0x02, 0x01},
+	{0x80, 0x20, 0x10, 0x08},
+}
+
+// HistoryGraph is a scrolling area graph of a HistoryState drawn with
+// braille characters, which give each cell 2×4 dots. The newest sample is
+// at the right edge and each sample takes one dot column, so a graph N
+// cells wide shows the last 2N samples. Cell rows are colored by the
+// Thresholds their values reach.
+//
+// Example:
+//
+//	terma.HistoryGraph{
+//	    State:     history,
+//	    Label:     "CPU",
+//	    ShowValue: true,
+//	    Max:       100,
+//	    Style:     terma.Style{Height: terma.Cells(6)},
+//	}
+type HistoryGraph struct {
+	ID         string                     // Optional unique identifier
+	State      *HistoryState              // Required - holds the samples
+	Label      string                     // Optional label drawn at the top left
+	ShowValue  bool                       // Draw the latest sample at the top right
+	Format     func(value float64) string // Formats the latest sample (default "%.1f")
+	Min        float64                    // Bottom of the scale (default 0)
+	Max        float64                    // Top of the scale (default: largest visible sample)
+	Color      Color                      // Graph color below all thresholds (default: theme Primary)
+	Thresholds []Threshold                // Colors for cell rows whose values reach them
+	Style      Style                      // Optional styling
+}
+
+// Build returns itself as HistoryGraph is a leaf widget, subscribing to
+// new samples.
+func (g HistoryGraph) Build(ctx BuildContext) Widget {
+	if g.State != nil {
+		g.State.Values.Get()
+	}
+	return g
+}
+
+// WidgetID returns the graph's unique identifier.
+// Implements the Identifiable interface.
+func (g HistoryGraph) WidgetID() string {
+	return g.ID
+}
+
+// GetContentDimensions returns the width and height dimension preferences.
+// Width defaults to Flex(1), Height defaults to Cells(4).
+func (g HistoryGraph) GetContentDimensions() (width, height Dimension) {
+	dims := g.Style.GetDimensions()
+	width, height = dims.Width, dims.Height
+	if width.IsUnset() {
+		width = Flex(1)
+	}
+	if height.IsUnset() {
+		height = Cells(4)
+	}
+	return width, height
+}
+
+// GetStyle returns the style of the graph.
+func (g HistoryGraph) GetStyle() Style {
+	return g.Style
+}
+
+// BuildLayoutNode builds a layout node for this HistoryGraph widget.
+func (g HistoryGraph) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return monitorLayoutNode(g, g.Style)
+}
+
+// Render draws the graph and its labels to the render context.
+func (g HistoryGraph) Render(ctx *RenderContext) {
+	if ctx.Width <= 0 || ctx.Height <= 0 || g.State == nil {
+		return
+	}
+	theme := ctx.buildContext.Theme()
+	values := g.State.Values.Peek()
+	if columns := ctx.Width * 2; len(values) > columns {
+		values = values[len(values)-columns:]
+	}
+
+	minValue, maxValue := g.Min, g.Max
+	if maxValue <= minValue {
+		_, maxValue = sparklineMinMax(values)
+	}
+	color := g.Color
+	if !color.IsSet() {
+		color = theme.Primary
+	}
+
+	// Dot levels per dot column, right-aligned so the newest sample is at
+	// the right edge. Samples above the minimum always show one dot.
+	levels := make([]int, ctx.Width*2)
+	dotRows := ctx.Height * 4
+	offset := len(levels) - len(values)
+	for i, v := range values {
+		level := int(math.Round(sparklineNormalize(v, minValue, maxValue) * float64(dotRows)))
+		if level == 0 && v > minValue {
+			level = 1
+		}
+		levels[offset+i] = level
+	}
+
+	for row := 0; row < ctx.Height; row++ {
+		bottom := (ctx.Height - 1 - row) * 4
+		rowValue := minValue + float64(bottom+4)/float64(dotRows)*(maxValue-minValue)
+		style := Style{ForegroundColor: thresholdColor(g.Thresholds, rowValue, color)}
+		var line strings.Builder
+		for x := 0; x < ctx.Width; x++ {
+			var bits rune
+			for side := 0; side < 2; side++ {
+				filled := min(max(levels[x*2+side]-bottom, 0), 4)
+				for dot := 0; dot < filled; dot++ {
+					bits |= brailleDots[side][dot]
+				}
+			}
+			if bits == 0 {
+				line.WriteRune(' ')
+			} else {
+				line.WriteRune(0x2800 + bits)
+			}
+		}
+		ctx.DrawStyledText(0, row, line.String(), style)
+	}
+
+	if g.Label != "" {
+		ctx.DrawStyledText(0, 0, g.Label, Style{ForegroundColor: theme.Text, Bold: true})
+	}
+	if latest, ok := g.State.Latest(); ok && g.ShowValue {
+		text := fmt.Sprintf("%.1f", latest)
+		if g.Format != nil {
+			text = g.Format(latest)
+		}
+		ctx.DrawStyledText(max(ctx.Width-ansi.StringWidth(text), 0), 0, text, Style{ForegroundColor: theme.Text})
+	}
+}
@@ -0,0 +1,113 @@
+package terma
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeter_RendersSegmentsAndValue(t *testing.T) {
+	meter := Meter{
+		Label:    "CPU",
+		Segments: []MeterSegment{{Value: 30}, {Value: 20}},
+	}
+	assert.Equal(t, "CPU [|||||||||    50.0%]", screenText(meter, 24, 1))
+
+	buf := RenderToBuffer(meter, 24, 1)
+	theme := newTestBuildContext().Theme()
+	assert.Equal(t, theme.Success.toANSI(), buf.CellAt(5, 0).Style.Fg)
+	assert.Equal(t, theme.Error.toANSI(), buf.CellAt(11, 0).Style.Fg, "the second segment has its own color")
+}
+
+func TestMeter_ThresholdsAndFormat(t *testing.T) {
+	theme := newTestBuildContext().Theme()
+	thresholds := []Threshold{{Value: 90, Color: theme.Error}, {Value: 60, Color: theme.Warning}}
+	meter := Meter{Value: 75, Thresholds: thresholds}
+	assert.Equal(t, theme.Warning, meter.segments(theme)[0].Color)
+	meter.Value = 95
+	assert.Equal(t, theme.Error, meter.segments(theme)[0].Color)
+	meter.Value = 10
+	assert.Equal(t, theme.Success, meter.segments(theme)[0].Color)
+
+	memory := Meter{
+		Label:  "Mem",
+		Value:  3,
+		Max:    8,
+		Format: func(total, max float64) string { return fmt.Sprintf("%.0fG/%.0fG", total, max) },
+	}
+	assert.Equal(t, "Mem [||||||     3G/8G]", screenText(memory, 22, 1))
+}
+
+func TestHistoryState_PushDropsOldest(t *testing.T) {
+	state := NewHistoryState(3)
+	for i := 1; i <= 5; i++ {
+		state.Push(float64(i))
+	}
+	assert.Equal(t, []float64{3, 4, 5}, state.Values.Peek())
+	latest, ok := state.Latest()
+	assert.True(t, ok)
+	assert.Equal(t, 5.0, latest)
+
+	state.Clear()
+	_, ok = state.Latest()
+	assert.False(t, ok)
+}
+
+func TestHistoryState_SampleFollowsClock(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC))
+	SetClock(clock)
+	t.Cleanup(func() { SetClock(nil) })
+
+	cpu := NewSignal(10.0)
+	state := NewHistoryState(10)
+	stop := state.Sample(cpu, time.Second)
+
+	clock.Advance(2 * time.Second)
+	cpu.Set(40)
+	clock.Advance(time.Second)
+	stop()
+	clock.Advance(time.Second)
+	assert.Equal(t, []float64{10, 10, 40}, state.Values.Peek(), "unchanged values are sampled too")
+}
+
+func TestHistoryGraph_RendersBrailleArea(t *testing.T) {
+	state := NewHistoryState(0)
+	for _, v := range []float64{0, 25, 50, 100} {
+		state.Push(v)
+	}
+	graph := HistoryGraph{State: state, Max: 100, Style: Style{Width: Cells(3), Height: Cells(1)}}
+	// Four samples fill the two right-hand cells; levels 0, 1, 2 and 4 dots.
+	assert.Equal(t, " ⢀⣼", screenText(graph, 3, 1))
+
+	graph.Style.Height = Cells(2)
+	lines := strings.Split(screenText(graph, 3, 2), "\n")
+	assert.Equal(t, "  ⢸", lines[0])
+	assert.Equal(t, " ⢠⣿", lines[1])
+}
+
+func TestHistoryGraph_LabelsAndThresholdColors(t *testing.T) {
+	theme := newTestBuildContext().Theme()
+	state := NewHistoryState(0)
+	for i := 0; i < 20; i++ {
+		state.Push(100)
+	}
+	graph := HistoryGraph{
+		State:      state,
+		Label:      "CPU",
+		ShowValue:  true,
+		Format:     func(v float64) string { return fmt.Sprintf("%.0f%%", v) },
+		Max:        100,
+		Thresholds: []Threshold{{Value: 80, Color: theme.Error}},
+		Style:      Style{Height: Cells(2)},
+	}
+	lines := strings.Split(screenText(graph, 10, 2), "\n")
+	assert.Equal(t, "CPU⣿⣿⣿100%", lines[0])
+	assert.Equal(t, "⣿⣿⣿⣿⣿⣿⣿⣿⣿⣿", lines[1])
+
+	buf := RenderToBuffer(graph, 10, 2)
+	assert.Equal(t, theme.Error.toANSI(), buf.CellAt(4, 0).Style.Fg, "the top row reaches the threshold")
+	assert.Equal(t, theme.Primary.toANSI(), buf.CellAt(4, 1).Style.Fg)
+}
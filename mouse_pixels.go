@@ -0,0 +1,152 @@
+package terma
+
+import (
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// PreciseLocalX returns the pointer's X offset within the widget in cells,
+// including the fraction of a cell when the terminal reports pixel
+// positions (3.25 is a quarter of the way into the fourth cell). Without
+// pixel positions it returns LocalX.
+func (e MouseEvent) PreciseLocalX() float64 {
+	if !e.HasPixels || e.CellWidth <= 0 {
+		return float64(e.LocalX)
+	}
+	return float64(e.LocalX) + float64(e.PixelX%e.CellWidth)/float64(e.CellWidth)
+}
+
+// PreciseLocalY returns the pointer's Y offset within the widget in cells,
+// including the fraction of a cell when the terminal reports pixel
+// positions. Without pixel positions it returns LocalY.
+func (e MouseEvent) PreciseLocalY() float64 {
+	if !e.HasPixels || e.CellHeight <= 0 {
+		return float64(e.LocalY)
+	}
+	return float64(e.LocalY) + float64(e.PixelY%e.CellHeight)/float64(e.CellHeight)
+}
+
+// mousePixels is the pixel part of a mouse event.
+type mousePixels struct {
+	ok                    bool
+	x, y                  int
+	cellWidth, cellHeight int
+}
+
+// apply copies the pixel position into event.
+func (p mousePixels) apply(event *MouseEvent) {
+	if !p.ok {
+		return
+	}
+	event.HasPixels = true
+	event.PixelX, event.PixelY = p.x, p.y
+	event.CellWidth, event.CellHeight = p.cellWidth, p.cellHeight
+}
+
+// pixelMouse switches the terminal to SGR-pixel mouse reporting (mode 1016)
+// when it supports the mode and reports its size in pixels, and converts
+// the resulting pixel positions back to cells. Terminals that don't support
+// it, or don't report a pixel size, keep reporting cells.
+//
+// Support is found by asking the terminal for the mode's state (DECRQM),
+// so pixel reporting is only turned on once the terminal has answered.
+type pixelMouse struct {
+	disabled  bool // Opted out with TERMA_DISABLE_PIXEL_MOUSE
+	supported bool // Terminal recognizes mode 1016
+	enabled   bool // Mode 1016 is on
+	cols      int
+	rows      int
+	pixelSize uv.Size
+}
+
+func newPixelMouse(cols, rows int) *pixelMouse {
+	return &pixelMouse{
+		disabled: boolEnv("TERMA_DISABLE_PIXEL_MOUSE"),
+		cols:     cols,
+		rows:     rows,
+	}
+}
+
+// start asks the terminal whether it supports pixel mouse reporting.
+func (p *pixelMouse) start(writeString func(string) (int, error)) {
+	if !p.disabled {
+		_, _ = writeString(ansi.RequestModeMouseExtSgrPixel)
+	}
+}
+
+// resume turns pixel reporting back on after the terminal's input modes
+// were reset (for example after suspending).
+func (p *pixelMouse) resume(writeString func(string) (int, error)) {
+	if p.enabled {
+		_, _ = writeString(ansi.SetModeMouseExtSgrPixel)
+	}
+}
+
+// handle updates the terminal's size and support from ev, enabling pixel
+// reporting once both are known. It reports whether ev was consumed.
+func (p *pixelMouse) handle(ev uv.Event, writeString func(string) (int, error)) bool {
+	consumed := false
+	switch ev := ev.(type) {
+	case uv.WindowSizeEvent:
+		p.cols, p.rows = ev.Width, ev.Height
+	case uv.WindowPixelSizeEvent:
+		p.pixelSize = uv.Size(ev)
+	case uv.ModeReportEvent:
+		if ev.Mode != ansi.ModeMouseExtSgrPixel {
+			return false
+		}
+		p.supported = !ev.Value.IsNotRecognized() && !ev.Value.IsPermanentlyReset()
+		consumed = true
+	default:
+		return false
+	}
+	if !p.enabled && !p.disabled && p.supported {
+		if _, _, ok := p.cellSize(); ok {
+			p.enabled = true
+			_, _ = writeString(ansi.SetModeMouseExtSgrPixel)
+		}
+	}
+	return consumed
+}
+
+// cellSize returns the size of a cell in pixels.
+func (p *pixelMouse) cellSize() (width, height int, ok bool) {
+	if p.cols <= 0 || p.rows <= 0 || p.pixelSize.Width <= 0 || p.pixelSize.Height <= 0 {
+		return 0, 0, false
+	}
+	width, height = p.pixelSize.Width/p.cols, p.pixelSize.Height/p.rows
+	return width, height, width > 0 && height > 0
+}
+
+// translate converts a mouse event reported in pixels to cells, returning
+// the pixel position separately. Other events are returned unchanged.
+func (p *pixelMouse) translate(ev uv.Event) (uv.Event, mousePixels) {
+	if !p.enabled {
+		return ev, mousePixels{}
+	}
+	cellWidth, cellHeight, ok := p.cellSize()
+	if !ok {
+		return ev, mousePixels{}
+	}
+	toCells := func(m uv.Mouse) (uv.Mouse, mousePixels) {
+		px := mousePixels{ok: true, x: max(m.X, 0), y: max(m.Y, 0), cellWidth: cellWidth, cellHeight: cellHeight}
+		m.X = min(px.x/cellWidth, p.cols-1)
+		m.Y = min(px.y/cellHeight, p.rows-1)
+		return m, px
+	}
+	switch ev := ev.(type) {
+	case uv.MouseClickEvent:
+		m, px := toCells(uv.Mouse(ev))
+		return uv.MouseClickEvent(m), px
+	case uv.MouseReleaseEvent:
+		m, px := toCells(uv.Mouse(ev))
+		return uv.MouseReleaseEvent(m), px
+	case uv.MouseMotionEvent:
+		m, px := toCells(uv.Mouse(ev))
+		return uv.MouseMotionEvent(m), px
+	case uv.MouseWheelEvent:
+		m, px := toCells(uv.Mouse(ev))
+		return uv.MouseWheelEvent(m), px
+	}
+	return ev, mousePixels{}
+}
@@ -0,0 +1,80 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMouseEvent_PreciseLocalPosition(t *testing.T) {
+	event := MouseEvent{X: 12, Y: 3, LocalX: 2, LocalY: 1}
+	assert.Equal(t, 2.0, event.PreciseLocalX(), "falls back to cells")
+	assert.Equal(t, 1.0, event.PreciseLocalY())
+
+	event.HasPixels = true
+	event.PixelX, event.PixelY = 125, 75
+	event.CellWidth, event.CellHeight = 10, 20
+	assert.Equal(t, 2.5, event.PreciseLocalX())
+	assert.Equal(t, 1.75, event.PreciseLocalY())
+}
+
+func TestPixelMouse_EnablesOnceSupportedAndSized(t *testing.T) {
+	t.Setenv("TERMA_DISABLE_PIXEL_MOUSE", "")
+	var out strings.Builder
+	p := newPixelMouse(80, 24)
+	p.start(out.WriteString)
+	assert.Equal(t, ansi.RequestModeMouseExtSgrPixel, out.String())
+
+	out.Reset()
+	assert.True(t, p.handle(uv.ModeReportEvent{Mode: ansi.ModeMouseExtSgrPixel, Value: ansi.ModeReset}, out.WriteString))
+	assert.Empty(t, out.String(), "waits for the pixel size")
+	assert.False(t, p.handle(uv.WindowPixelSizeEvent{Width: 800, Height: 480}, out.WriteString))
+	assert.Equal(t, ansi.SetModeMouseExtSgrPixel, out.String())
+
+	ev, px := p.translate(uv.MouseClickEvent{X: 125, Y: 75, Button: uv.MouseLeft})
+	assert.Equal(t, uv.MouseClickEvent{X: 12, Y: 3, Button: uv.MouseLeft}, ev)
+	assert.Equal(t, mousePixels{ok: true, x: 125, y: 75, cellWidth: 10, cellHeight: 20}, px)
+
+	// The terminal may report positions just past the last cell.
+	ev, _ = p.translate(uv.MouseMotionEvent{X: 805, Y: 490})
+	assert.Equal(t, uv.MouseMotionEvent{X: 79, Y: 23}, ev)
+
+	// Resizing changes the cell size used for conversion.
+	p.handle(uv.WindowSizeEvent{Width: 40, Height: 24}, out.WriteString)
+	ev, _ = p.translate(uv.MouseReleaseEvent{X: 125, Y: 75})
+	assert.Equal(t, uv.MouseReleaseEvent{X: 6, Y: 3}, ev)
+
+	out.Reset()
+	p.resume(out.WriteString)
+	assert.Equal(t, ansi.SetModeMouseExtSgrPixel, out.String())
+}
+
+func TestPixelMouse_FallsBackToCells(t *testing.T) {
+	t.Setenv("TERMA_DISABLE_PIXEL_MOUSE", "")
+	var out strings.Builder
+	p := newPixelMouse(80, 24)
+	p.handle(uv.WindowPixelSizeEvent{Width: 800, Height: 480}, out.WriteString)
+	p.handle(uv.ModeReportEvent{Mode: ansi.ModeMouseExtSgrPixel, Value: ansi.ModeNotRecognized}, out.WriteString)
+	assert.Empty(t, out.String())
+
+	ev, px := p.translate(uv.MouseClickEvent{X: 12, Y: 3})
+	assert.Equal(t, uv.MouseClickEvent{X: 12, Y: 3}, ev)
+	assert.False(t, px.ok)
+
+	event := MouseEvent{}
+	px.apply(&event)
+	assert.False(t, event.HasPixels)
+}
+
+func TestPixelMouse_DisabledByEnv(t *testing.T) {
+	t.Setenv("TERMA_DISABLE_PIXEL_MOUSE", "1")
+	var out strings.Builder
+	p := newPixelMouse(80, 24)
+	p.start(out.WriteString)
+	p.handle(uv.WindowPixelSizeEvent{Width: 800, Height: 480}, out.WriteString)
+	p.handle(uv.ModeReportEvent{Mode: ansi.ModeMouseExtSgrPixel, Value: ansi.ModeSet}, out.WriteString)
+	assert.Empty(t, out.String())
+}
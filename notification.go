@@ -0,0 +1,267 @@
+package terma
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// NotificationProtocol selects the escape sequence Notify uses to show a
+// desktop notification.
+type NotificationProtocol int
+
+const (
+	// NotifyAuto picks a protocol from the environment (TERMA_NOTIFY,
+	// TERM_PROGRAM, TERM and terminal-specific variables), falling back to
+	// NotifyBell.
+	NotifyAuto NotificationProtocol = iota
+	// NotifyOSC9 is iTerm2's notification, also supported by WezTerm and
+	// Ghostty. It has no separate title.
+	NotifyOSC9
+	// NotifyOSC777 is the urxvt notify extension, supported by VTE-based
+	// terminals (GNOME Terminal, Tilix), foot, WezTerm and Ghostty.
+	NotifyOSC777
+	// NotifyOSC99 is kitty's desktop notification protocol.
+	NotifyOSC99
+	// NotifyBell rings the bell (see SetBellStyle) instead of showing a
+	// notification.
+	NotifyBell
+)
+
+// BellStyle controls what Bell does.
+type BellStyle int
+
+const (
+	// BellAudible sends BEL, which most terminals play as a sound and
+	// use to mark an unfocused window as urgent.
+	BellAudible BellStyle = iota
+	// BellVisual briefly reverses the screen's colors.
+	BellVisual
+	// BellNone disables the bell.
+	BellNone
+)
+
+// visualBellDuration is how long BellVisual reverses the screen.
+const visualBellDuration = 150 * time.Millisecond
+
+var (
+	notificationProtocol atomic.Int32
+	bellStyle            atomic.Int32
+	notificationID       atomic.Int64
+)
+
+// SetNotificationProtocol overrides how Notify reaches the desktop. Pass
+// NotifyAuto to restore detection.
+func SetNotificationProtocol(protocol NotificationProtocol) {
+	notificationProtocol.Store(int32(protocol))
+}
+
+// SetBellStyle sets what Bell, and Notify's bell fallback, do.
+func SetBellStyle(style BellStyle) {
+	bellStyle.Store(int32(style))
+}
+
+// Notify shows a desktop notification with the given title and body, so
+// long-running work can alert a user who has switched to another window.
+// Terminals without a supported protocol get the bell instead. Inside tmux
+// the notification is passed through to the outer terminal, which needs
+// tmux's allow-passthrough option. Safe to call from any goroutine.
+//
+// Example:
+//
+//	t.Go(func() {
+//		err := build()
+//		t.Notify("Build finished", resultText(err))
+//		t.RequestAttention()
+//	})
+func Notify(title, body string) {
+	protocol := NotificationProtocol(notificationProtocol.Load())
+	if protocol == NotifyAuto {
+		protocol = detectNotificationProtocol(os.Getenv)
+	}
+	if protocol == NotifyBell {
+		Bell()
+		return
+	}
+	seq := notificationSequence(protocol, title, body, notificationID.Add(1))
+	if os.Getenv("TMUX") != "" {
+		seq = ansi.TmuxPassthrough(seq)
+	}
+	writeTerminalOutput(seq)
+}
+
+// Bell rings the terminal bell in the style set with SetBellStyle. Safe to
+// call from any goroutine.
+func Bell() {
+	switch BellStyle(bellStyle.Load()) {
+	case BellAudible:
+		writeTerminalOutput(string(rune(ansi.BEL)))
+	case BellVisual:
+		// DECSCNM: reverse video for the whole screen.
+		writeTerminalOutput(ansi.SetMode(ansi.DECMode(5)))
+		currentClock().AfterFunc(visualBellDuration, func() {
+			writeTerminalOutput(ansi.ResetMode(ansi.DECMode(5)))
+		})
+	}
+}
+
+// RequestAttention asks the terminal to mark its window as needing
+// attention (the window manager's urgency hint), for example bouncing the
+// dock icon or highlighting the taskbar entry. iTerm2 has a dedicated
+// sequence; other terminals set the hint when the bell rings in an
+// unfocused window, so this sends BEL regardless of SetBellStyle. Safe to
+// call from any goroutine.
+func RequestAttention() {
+	if detectNotificationTerminal(os.Getenv) == "iterm" {
+		seq := "\x1b]1337;RequestAttention=yes\x07"
+		if os.Getenv("TMUX") != "" {
+			seq = ansi.TmuxPassthrough(seq)
+		}
+		writeTerminalOutput(seq)
+		return
+	}
+	writeTerminalOutput(string(rune(ansi.BEL)))
+}
+
+// notificationSequence builds the escape sequence for a notification.
+func notificationSequence(protocol NotificationProtocol, title, body string, id int64) string {
+	title, body = sanitizeNotificationText(title), sanitizeNotificationText(body)
+	switch protocol {
+	case NotifyOSC777:
+		// Parameters are separated by semicolons, so the title can't hold one.
+		return ansi.URxvtExt("notify", strings.ReplaceAll(title, ";", ","), body)
+	case NotifyOSC99:
+		// Sent in two parts so that title and body can hold any text.
+		idMeta := "i=" + strconv.FormatInt(id, 10)
+		if body == "" {
+			return ansi.DesktopNotification(title, idMeta, "p=title")
+		}
+		return ansi.DesktopNotification(title, idMeta, "d=0", "p=title") +
+			ansi.DesktopNotification(body, idMeta, "d=1", "p=body")
+	default:
+		switch {
+		case title == "":
+			return ansi.Notify(body)
+		case body == "":
+			return ansi.Notify(title)
+		}
+		return ansi.Notify(title + ": " + body)
+	}
+}
+
+// sanitizeNotificationText removes control characters, which could end the
+// escape sequence early and be interpreted by the terminal.
+func sanitizeNotificationText(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\t':
+			return ' '
+		case r < 0x20 || r == 0x7f || (r >= 0x80 && r < 0xa0):
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// detectNotificationProtocol picks a protocol for the terminal described by
+// the environment.
+func detectNotificationProtocol(getenv func(string) string) NotificationProtocol {
+	switch strings.ToLower(strings.TrimSpace(getenv("TERMA_NOTIFY"))) {
+	case "osc9":
+		return NotifyOSC9
+	case "osc777":
+		return NotifyOSC777
+	case "osc99", "kitty":
+		return NotifyOSC99
+	case "bell", "off", "none":
+		return NotifyBell
+	}
+	switch detectNotificationTerminal(getenv) {
+	case "iterm", "wezterm", "ghostty":
+		return NotifyOSC9
+	case "kitty":
+		return NotifyOSC99
+	case "vte", "rxvt", "foot":
+		return NotifyOSC777
+	}
+	return NotifyBell
+}
+
+// detectNotificationTerminal identifies terminals with notification
+// support. Terminal-specific variables are checked as well as TERM_PROGRAM
+// because they survive into tmux sessions.
+func detectNotificationTerminal(getenv func(string) string) string {
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app":
+		return "iterm"
+	case "WezTerm":
+		return "wezterm"
+	case "ghostty":
+		return "ghostty"
+	}
+	term := getenv("TERM")
+	switch {
+	case getenv("ITERM_SESSION_ID") != "":
+		return "iterm"
+	case getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty":
+		return "kitty"
+	case getenv("WEZTERM_EXECUTABLE") != "":
+		return "wezterm"
+	case term == "xterm-ghostty":
+		return "ghostty"
+	case strings.HasPrefix(term, "foot"):
+		return "foot"
+	case strings.Contains(term, "rxvt"):
+		return "rxvt"
+	case getenv("VTE_VERSION") != "":
+		return "vte"
+	}
+	return ""
+}
+
+// terminalOutput holds escape sequences waiting to be written by the
+// running app's render loop, which owns the terminal.
+var terminalOutput struct {
+	mu      sync.Mutex
+	pending []string
+}
+
+// terminalOutputFallback receives escape sequences when no app is running.
+var terminalOutputFallback io.Writer = os.Stdout
+
+// writeTerminalOutput sends an escape sequence to the terminal: queued for
+// the next frame while an app is running, written directly otherwise.
+func writeTerminalOutput(seq string) {
+	if queueTerminalOutput(seq) {
+		scheduleRender()
+	}
+}
+
+// queueTerminalOutput is writeTerminalOutput without scheduling a frame,
+// for use while rendering, which writes the queue at the end of the frame.
+// It reports whether seq was queued rather than written directly.
+func queueTerminalOutput(seq string) bool {
+	terminalOutput.mu.Lock()
+	defer terminalOutput.mu.Unlock()
+	if renderTrigger == nil {
+		_, _ = io.WriteString(terminalOutputFallback, seq)
+		return false
+	}
+	terminalOutput.pending = append(terminalOutput.pending, seq)
+	return true
+}
+
+// drainTerminalOutput returns and clears the queued escape sequences.
+func drainTerminalOutput() []string {
+	terminalOutput.mu.Lock()
+	defer terminalOutput.mu.Unlock()
+	pending := terminalOutput.pending
+	terminalOutput.pending = nil
+	return pending
+}
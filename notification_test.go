@@ -0,0 +1,114 @@
+package terma
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// captureTerminalOutput collects sequences written while no app is running.
+func captureTerminalOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := terminalOutputFallback
+	terminalOutputFallback = &buf
+	t.Setenv("TMUX", "")
+	t.Cleanup(func() {
+		terminalOutputFallback = previous
+		SetNotificationProtocol(NotifyAuto)
+		SetBellStyle(BellAudible)
+	})
+	return &buf
+}
+
+func TestNotificationSequence(t *testing.T) {
+	assert.Equal(t, "\x1b]9;Build: done\x07", notificationSequence(NotifyOSC9, "Build", "done", 1))
+	assert.Equal(t, "\x1b]9;done\x07", notificationSequence(NotifyOSC9, "", "done", 1))
+	assert.Equal(t, "\x1b]777;notify;a, b;done\x07", notificationSequence(NotifyOSC777, "a; b", "done", 1))
+	assert.Equal(t,
+		"\x1b]99;i=7:d=0:p=title;Build\x07\x1b]99;i=7:d=1:p=body;done\x07",
+		notificationSequence(NotifyOSC99, "Build", "done", 7))
+	assert.Equal(t, "\x1b]99;i=7:p=title;Build\x07", notificationSequence(NotifyOSC99, "Build", "", 7))
+}
+
+func TestNotificationSequence_StripsControlCharacters(t *testing.T) {
+	assert.Equal(t, "\x1b]9;evil]0;title: two lines\x07",
+		notificationSequence(NotifyOSC9, "evil\x07\x1b]0;title", "two\nlines", 1))
+}
+
+func TestDetectNotificationProtocol(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+	assert.Equal(t, NotifyOSC9, detectNotificationProtocol(env(map[string]string{"TERM_PROGRAM": "iTerm.app"})))
+	assert.Equal(t, NotifyOSC99, detectNotificationProtocol(env(map[string]string{"TERM": "xterm-kitty"})))
+	assert.Equal(t, NotifyOSC99, detectNotificationProtocol(env(map[string]string{"TERM_PROGRAM": "tmux", "KITTY_WINDOW_ID": "1"})))
+	assert.Equal(t, NotifyOSC777, detectNotificationProtocol(env(map[string]string{"TERM": "xterm-256color", "VTE_VERSION": "7600"})))
+	assert.Equal(t, NotifyOSC777, detectNotificationProtocol(env(map[string]string{"TERM": "foot"})))
+	assert.Equal(t, NotifyBell, detectNotificationProtocol(env(map[string]string{"TERM": "xterm-256color"})))
+	assert.Equal(t, NotifyOSC777, detectNotificationProtocol(env(map[string]string{"TERM_PROGRAM": "iTerm.app", "TERMA_NOTIFY": "osc777"})))
+}
+
+func TestNotify_UsesProtocolOrBell(t *testing.T) {
+	buf := captureTerminalOutput(t)
+
+	SetNotificationProtocol(NotifyOSC9)
+	Notify("Build", "done")
+	assert.Equal(t, "\x1b]9;Build: done\x07", buf.String())
+
+	buf.Reset()
+	SetNotificationProtocol(NotifyBell)
+	Notify("Build", "done")
+	assert.Equal(t, "\a", buf.String())
+
+	buf.Reset()
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	SetNotificationProtocol(NotifyOSC9)
+	Notify("", "x")
+	assert.Equal(t, "\x1bPtmux;\x1b\x1b]9;x\x07\x1b\\", buf.String())
+}
+
+func TestBell_VisualReversesScreenBriefly(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	SetClock(clock)
+	t.Cleanup(func() { SetClock(nil) })
+	buf := captureTerminalOutput(t)
+
+	SetBellStyle(BellVisual)
+	Bell()
+	assert.Equal(t, "\x1b[?5h", buf.String())
+	clock.Advance(visualBellDuration)
+	assert.Equal(t, "\x1b[?5h\x1b[?5l", buf.String())
+
+	buf.Reset()
+	SetBellStyle(BellNone)
+	Bell()
+	assert.Empty(t, buf.String())
+}
+
+func TestRequestAttention(t *testing.T) {
+	buf := captureTerminalOutput(t)
+	t.Setenv("TERM_PROGRAM", "iTerm.app")
+	RequestAttention()
+	assert.Equal(t, "\x1b]1337;RequestAttention=yes\x07", buf.String())
+
+	buf.Reset()
+	t.Setenv("TERM_PROGRAM", "")
+	t.Setenv("ITERM_SESSION_ID", "")
+	RequestAttention()
+	assert.Equal(t, "\a", buf.String())
+}
+
+func TestWriteTerminalOutput_QueuesWhileAppRuns(t *testing.T) {
+	buf := captureTerminalOutput(t)
+	renderTrigger = make(chan struct{}, 1)
+	t.Cleanup(func() { renderTrigger = nil })
+
+	Bell()
+	assert.Empty(t, buf.String())
+	assert.Len(t, renderTrigger, 1, "a frame is scheduled to write the bell")
+	assert.Equal(t, []string{"\a"}, drainTerminalOutput())
+	assert.Empty(t, drainTerminalOutput())
+}
@@ -0,0 +1,323 @@
+package terma
+
+import (
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// KeyboardKey is one key of an OnScreenKeyboard layout. A key types Text,
+// sends the key Code, toggles Shift, or switches to another Layer.
+type KeyboardKey struct {
+	Label string // Shown on the key (default: Text)
+	Text  string // Typed when pressed; single letters are upper case while shifted
+	Code  rune   // Key code sent when pressed, like uv.KeyBackspace (default: the typed character)
+	Shift bool   // Toggles shift for the next letter instead of typing
+	Layer string // Switches to the layer with this name instead of typing
+}
+
+// KeyboardTextKeys returns one key per character of chars, each typing
+// that character.
+func KeyboardTextKeys(chars string) []KeyboardKey {
+	keys := make([]KeyboardKey, 0, len(chars))
+	for _, r := range chars {
+		keys = append(keys, KeyboardKey{Text: string(r)})
+	}
+	return keys
+}
+
+// label returns the text shown on the key.
+func (k KeyboardKey) label(shifted bool) string {
+	label := k.Label
+	if label == "" {
+		label = k.Text
+	}
+	if shifted && k.shiftable() {
+		label = strings.ToUpper(label)
+	}
+	return label
+}
+
+// shiftable reports whether the key types a single letter, which shift
+// makes upper case.
+func (k KeyboardKey) shiftable() bool {
+	return utf8.RuneCountInString(k.Text) == 1 && strings.ToUpper(k.Text) != k.Text
+}
+
+// KeyboardLayer is one set of keys of a KeyboardLayout, like letters or
+// symbols.
+type KeyboardLayer struct {
+	Name string          // Referenced by keys that switch to this layer
+	Rows [][]KeyboardKey // Keys from the top row down
+}
+
+// KeyboardLayout is the keys of an OnScreenKeyboard, in layers that keys
+// switch between. The keyboard starts on the first layer.
+type KeyboardLayout struct {
+	Layers []KeyboardLayer
+}
+
+// layer returns the layer with the given name, or the first layer.
+func (l KeyboardLayout) layer(name string) KeyboardLayer {
+	for _, layer := range l.Layers {
+		if layer.Name == name {
+			return layer
+		}
+	}
+	if len(l.Layers) == 0 {
+		return KeyboardLayer{}
+	}
+	return l.Layers[0]
+}
+
+// DefaultKeyboardLayout returns the QWERTY layout OnScreenKeyboard uses by
+// default: a "letters" layer and a "symbols" layer, switched with the
+// bottom-left key.
+func DefaultKeyboardLayout() KeyboardLayout {
+	bottomRow := func(switchLabel, switchTo string) []KeyboardKey {
+		return []KeyboardKey{
+			{Label: switchLabel, Layer: switchTo},
+			{Label: "space", Text: " "},
+			{Label: "⌫", Code: uv.KeyBackspace},
+			{Label: "⏎", Code: uv.KeyEnter},
+		}
+	}
+	return KeyboardLayout{Layers: []KeyboardLayer{
+		{
+			Name: "letters",
+			Rows: [][]KeyboardKey{
+				KeyboardTextKeys("1234567890"),
+				KeyboardTextKeys("qwertyuiop"),
+				KeyboardTextKeys("asdfghjkl-"),
+				append([]KeyboardKey{{Label: "⇧", Shift: true}}, KeyboardTextKeys("zxcvbnm,.")...),
+				bottomRow("?123", "symbols"),
+			},
+		},
+		{
+			Name: "symbols",
+			Rows: [][]KeyboardKey{
+				KeyboardTextKeys("1234567890"),
+				KeyboardTextKeys("!@#$%^&*()"),
+				KeyboardTextKeys(`-_=+[]{}\|`),
+				KeyboardTextKeys(`<>;:'",./?`),
+				bottomRow("abc", "letters"),
+			},
+		},
+	}}
+}
+
+// OnScreenKeyboardState holds the state for an OnScreenKeyboard widget.
+type OnScreenKeyboardState struct {
+	Layer   Signal[string] // Name of the layer shown ("" for the first)
+	Row     Signal[int]    // Row of the selected key
+	Column  Signal[int]    // Column of the selected key
+	Shifted Signal[bool]   // Whether the next letter is typed in upper case
+}
+
+// NewOnScreenKeyboardState creates an OnScreenKeyboardState with the first
+// key of the first layer selected.
+func NewOnScreenKeyboardState() *OnScreenKeyboardState {
+	return &OnScreenKeyboardState{
+		Layer:   NewSignal(""),
+		Row:     NewSignal(0),
+		Column:  NewSignal(0),
+		Shifted: NewSignal(false),
+	}
+}
+
+// OnScreenKeyboard is a keyboard drawn on screen, for typing with only the
+// arrow keys and Enter, or by clicking keys: arrows select a key and Enter
+// presses it, sending the key to OnPress. Layout sets the keys, in layers
+// such as letters and symbols. The kiosk input profile opens one
+// automatically when Enter is pressed on a text field (see
+// SetInputProfile).
+//
+// Example - typing into a TextInput that doesn't need focus:
+//
+//	OnScreenKeyboard{
+//	    State:   a.keyboard,
+//	    OnPress: func(key KeyEvent) { a.search.OnKey(key) },
+//	}
+type OnScreenKeyboard struct {
+	ID           string                 // Optional unique identifier
+	DisableFocus bool                   // If true, prevent keyboard focus
+	State        *OnScreenKeyboardState // Required - holds the selected key
+	Layout       *KeyboardLayout        // Optional keys (default: DefaultKeyboardLayout)
+	OnPress      func(key KeyEvent)     // Called with the key each key press types
+	Style        Style                  // Optional styling
+}
+
+// WidgetID returns the keyboard's unique identifier.
+func (k OnScreenKeyboard) WidgetID() string {
+	return k.ID
+}
+
+// IsFocusable returns true unless focus is disabled.
+func (k OnScreenKeyboard) IsFocusable() bool {
+	return !k.DisableFocus && k.State != nil
+}
+
+// OnKey moves the selection with the arrow keys and presses the selected
+// key with Enter.
+func (k OnScreenKeyboard) OnKey(event KeyEvent) bool {
+	if k.State == nil {
+		return false
+	}
+	switch {
+	case event.MatchString("up"):
+		k.Move(0, -1)
+	case event.MatchString("down"):
+		k.Move(0, 1)
+	case event.MatchString("left"):
+		k.Move(-1, 0)
+	case event.MatchString("right"):
+		k.Move(1, 0)
+	case event.MatchString("enter"):
+		k.Press()
+	default:
+		return false
+	}
+	return true
+}
+
+func (k OnScreenKeyboard) layout() KeyboardLayout {
+	if k.Layout != nil {
+		return *k.Layout
+	}
+	return defaultKeyboardLayout
+}
+
+var defaultKeyboardLayout = DefaultKeyboardLayout()
+
+// rows returns the keys of the layer shown.
+func (k OnScreenKeyboard) rows() [][]KeyboardKey {
+	return k.layout().layer(k.State.Layer.Peek()).Rows
+}
+
+// Move moves the selection by (dx, dy) keys, staying on the keyboard.
+// Moving between rows of different lengths keeps the column where it can.
+func (k OnScreenKeyboard) Move(dx, dy int) {
+	if k.State == nil {
+		return
+	}
+	rows := k.rows()
+	if len(rows) == 0 {
+		return
+	}
+	row := max(0, min(len(rows)-1, k.State.Row.Peek()+dy))
+	col := max(0, min(len(rows[row])-1, k.State.Column.Peek()+dx))
+	k.State.Row.Set(row)
+	k.State.Column.Set(col)
+}
+
+// selected returns the selected key.
+func (k OnScreenKeyboard) selected() (KeyboardKey, bool) {
+	rows := k.rows()
+	row := k.State.Row.Peek()
+	if row < 0 || row >= len(rows) {
+		return KeyboardKey{}, false
+	}
+	col := k.State.Column.Peek()
+	if col < 0 || col >= len(rows[row]) {
+		return KeyboardKey{}, false
+	}
+	return rows[row][col], true
+}
+
+// Press presses the selected key.
+func (k OnScreenKeyboard) Press() {
+	if k.State == nil {
+		return
+	}
+	key, ok := k.selected()
+	if !ok {
+		return
+	}
+	switch {
+	case key.Shift:
+		k.State.Shifted.Update(func(shifted bool) bool { return !shifted })
+		return
+	case key.Layer != "":
+		k.State.Layer.Set(key.Layer)
+		k.State.Shifted.Set(false)
+		k.Move(0, 0) // Stay on the new layer's keys
+		return
+	}
+
+	pressed := uv.Key{Code: key.Code, Text: key.Text}
+	if pressed.Text != "" && k.State.Shifted.Peek() && key.shiftable() {
+		pressed.Text = strings.ToUpper(pressed.Text)
+		k.State.Shifted.Set(false)
+	}
+	if pressed.Code == 0 {
+		pressed.Code, _ = utf8.DecodeRuneInString(pressed.Text)
+	}
+	if k.OnPress != nil {
+		k.OnPress(KeyEvent{event: uv.KeyPressEvent(pressed)})
+	}
+}
+
+// pressAt selects the key at (row, col) and presses it.
+func (k OnScreenKeyboard) pressAt(row, col int) {
+	k.State.Row.Set(row)
+	k.State.Column.Set(col)
+	k.Press()
+}
+
+// Build lays the keys out in rows, highlighting the selected key.
+func (k OnScreenKeyboard) Build(ctx BuildContext) Widget {
+	if k.State == nil {
+		return EmptyWidget{}
+	}
+	theme := ctx.Theme()
+	k.State.Layer.Get()
+	selectedRow, selectedCol := k.State.Row.Get(), k.State.Column.Get()
+	shifted := k.State.Shifted.Get()
+
+	keyRows := k.rows()
+	rows := make([]Widget, len(keyRows))
+	for r, keys := range keyRows {
+		children := make([]Widget, len(keys))
+		for c, key := range keys {
+			style := Style{
+				ForegroundColor: theme.Text,
+				BackgroundColor: theme.Surface,
+				Padding:         EdgeInsetsXY(1, 0),
+			}
+			if key.Shift && shifted {
+				style.ForegroundColor = theme.Accent
+			}
+			if r == selectedRow && c == selectedCol {
+				style.ForegroundColor = theme.TextOnPrimary
+				style.BackgroundColor = theme.Primary
+			}
+			row, col := r, c
+			children[c] = Text{
+				Content: key.label(shifted),
+				Style:   style,
+				Click:   func(MouseEvent) { k.pressAt(row, col) },
+			}
+		}
+		rows[r] = Row{Spacing: 1, Children: children}
+	}
+
+	style := k.Style
+	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
+		style.BackgroundColor = theme.Background
+	}
+	if style.Padding == (EdgeInsets{}) {
+		style.Padding = EdgeInsetsXY(1, 0)
+	}
+	return Column{ID: k.ID, Style: style, CrossAlign: CrossAxisCenter, Children: rows}
+}
+
+var kioskKeyboardLayout atomic.Pointer[KeyboardLayout]
+
+// SetOnScreenKeyboardLayout sets the keys of the on-screen keyboard the
+// kiosk input profile opens for text fields. Nil restores
+// DefaultKeyboardLayout.
+func SetOnScreenKeyboardLayout(layout *KeyboardLayout) {
+	kioskKeyboardLayout.Store(layout)
+}
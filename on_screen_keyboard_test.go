@@ -0,0 +1,142 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordKeys returns an OnPress callback that records what each key press
+// types, or the key name for keys that don't type text.
+func recordKeys(typed *[]string) func(KeyEvent) {
+	return func(key KeyEvent) {
+		if key.Text() != "" {
+			*typed = append(*typed, key.Text())
+		} else {
+			*typed = append(*typed, key.Key())
+		}
+	}
+}
+
+func TestOnScreenKeyboard_MoveStaysOnTheKeyboard(t *testing.T) {
+	state := NewOnScreenKeyboardState()
+	keyboard := OnScreenKeyboard{State: state}
+
+	keyboard.Move(-1, -1)
+	assert.Equal(t, 0, state.Row.Peek())
+	assert.Equal(t, 0, state.Column.Peek())
+
+	keyboard.Move(9, 0)
+	keyboard.Move(0, 4)
+	assert.Equal(t, 4, state.Row.Peek())
+	assert.Equal(t, 3, state.Column.Peek(), "the bottom row has four keys")
+	key, ok := keyboard.selected()
+	require.True(t, ok)
+	assert.Equal(t, "⏎", key.label(false))
+}
+
+func TestOnScreenKeyboard_PressSendsTheSelectedKey(t *testing.T) {
+	state := NewOnScreenKeyboardState()
+	var typed []string
+	keyboard := OnScreenKeyboard{State: state, OnPress: recordKeys(&typed)}
+
+	// Shift types one upper case letter
+	state.Row.Set(3)
+	keyboard.Press()
+	assert.True(t, state.Shifted.Peek())
+	keyboard.pressAt(3, 1)
+	keyboard.Press()
+
+	for col := 1; col < 4; col++ {
+		keyboard.pressAt(4, col)
+	}
+
+	assert.Equal(t, []string{"Z", "z", " ", "backspace", "enter"}, typed)
+}
+
+func TestOnScreenKeyboard_LayerKeysSwitchLayers(t *testing.T) {
+	state := NewOnScreenKeyboardState()
+	var typed []string
+	keyboard := OnScreenKeyboard{State: state, OnPress: recordKeys(&typed)}
+
+	keyboard.pressAt(3, 0) // Shift
+	keyboard.pressAt(4, 0) // ?123
+	assert.Equal(t, "symbols", state.Layer.Peek())
+	assert.False(t, state.Shifted.Peek(), "switching layers drops shift")
+
+	keyboard.pressAt(1, 1)
+	keyboard.pressAt(4, 0) // abc
+	keyboard.pressAt(1, 1)
+
+	assert.Equal(t, []string{"@", "w"}, typed)
+	assert.Equal(t, "letters", state.Layer.Peek())
+}
+
+func TestOnScreenKeyboard_CustomLayout(t *testing.T) {
+	layout := KeyboardLayout{Layers: []KeyboardLayer{
+		{Name: "digits", Rows: [][]KeyboardKey{
+			KeyboardTextKeys("123"),
+			{{Label: "more", Layer: "extra"}, {Label: "del", Code: uv.KeyBackspace}},
+		}},
+		{Name: "extra", Rows: [][]KeyboardKey{
+			{{Label: "dot", Text: "."}},
+		}},
+	}}
+	state := NewOnScreenKeyboardState()
+	var typed []string
+	keyboard := OnScreenKeyboard{State: state, Layout: &layout, OnPress: recordKeys(&typed)}
+
+	keyboard.Move(5, 0)
+	keyboard.Press()
+	keyboard.pressAt(1, 1)
+	keyboard.pressAt(1, 0)
+	assert.Equal(t, 0, state.Row.Peek(), "the selection moved onto the smaller layer")
+	keyboard.Press()
+
+	assert.Equal(t, []string{"3", "backspace", "."}, typed)
+}
+
+func TestOnScreenKeyboard_OnKeyNavigatesAndPresses(t *testing.T) {
+	state := NewOnScreenKeyboardState()
+	var typed []string
+	keyboard := OnScreenKeyboard{State: state, OnPress: recordKeys(&typed)}
+
+	assert.True(t, keyboard.OnKey(makeKeyEvent(uv.KeyRight, 0)))
+	assert.True(t, keyboard.OnKey(makeKeyEvent(uv.KeyEnter, 0)))
+	assert.False(t, keyboard.OnKey(makeCharEvent('x')))
+
+	assert.Equal(t, []string{"2"}, typed)
+}
+
+func TestOnScreenKeyboard_RendersRowsOfKeys(t *testing.T) {
+	state := NewOnScreenKeyboardState()
+	state.Shifted.Set(true)
+	renderer, buf := portalTestRenderer(60, 5)
+
+	renderer.Render(OnScreenKeyboard{State: state})
+
+	assert.Contains(t, bufferLine(buf, 0, 60), " 1   2   3 ")
+	assert.Contains(t, bufferLine(buf, 1, 60), " Q   W   E ")
+	assert.Contains(t, strings.TrimSpace(bufferLine(buf, 4, 60)), "?123   space   ⌫   ⏎")
+}
+
+func TestOnScreenKeyboard_ClickingAKeyPressesIt(t *testing.T) {
+	state := NewOnScreenKeyboardState()
+	var typed []string
+	renderer, buf := portalTestRenderer(60, 5)
+	renderer.Render(OnScreenKeyboard{State: state, OnPress: recordKeys(&typed)})
+
+	x := strings.Index(bufferLine(buf, 2, 60), " s ") + 1
+	entry := renderer.WidgetAt(x, 2)
+	require.NotNil(t, entry)
+	clickable, ok := entry.EventWidget.(Clickable)
+	require.True(t, ok)
+	clickable.OnClick(MouseEvent{})
+
+	assert.Equal(t, []string{"s"}, typed)
+	assert.Equal(t, 2, state.Row.Peek())
+	assert.Equal(t, 1, state.Column.Peek())
+}
@@ -0,0 +1,145 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+// bufferLine returns the visible text of row y in the buffer.
+func bufferLine(buf *uv.Buffer, y, width int) string {
+	var sb strings.Builder
+	for x := 0; x < width; x++ {
+		cell := buf.CellAt(x, y)
+		if cell == nil || cell.Content == "" {
+			if cell == nil {
+				sb.WriteByte(' ')
+			}
+			continue
+		}
+		sb.WriteString(cell.Content)
+	}
+	return sb.String()
+}
+
+func overflowRow(overflow Overflow) Widget {
+	return Row{
+		Style: Style{Width: Cells(10), Overflow: overflow},
+		Children: []Widget{
+			Text{Content: "aaaaaa", Style: Style{Width: Cells(6)}},
+			Text{Content: "bbbbbbbb", Style: Style{Width: Cells(8)}},
+		},
+	}
+}
+
+func TestOverflow_ClipIsDefaultForContainers(t *testing.T) {
+	buf := RenderToBuffer(overflowRow(OverflowDefault), 20, 1)
+	assert.Equal(t, "aaaaaabbbb          ", bufferLine(buf, 0, 20))
+}
+
+func TestOverflow_VisibleLetsChildrenBleed(t *testing.T) {
+	buf := RenderToBuffer(overflowRow(OverflowVisible), 20, 1)
+	assert.Equal(t, "aaaaaabbbbbbbb      ", bufferLine(buf, 0, 20))
+}
+
+func TestOverflow_EllipsisMarksClippedRows(t *testing.T) {
+	buf := RenderToBuffer(overflowRow(OverflowEllipsis), 20, 1)
+	assert.Equal(t, "aaaaaabbb…          ", bufferLine(buf, 0, 20))
+}
+
+func TestOverflow_EllipsisCustomMarker(t *testing.T) {
+	row := overflowRow(OverflowEllipsis).(Row)
+	row.Style.Ellipsis = ">>"
+	buf := RenderToBuffer(row, 20, 1)
+	assert.Equal(t, "aaaaaabb>>          ", bufferLine(buf, 0, 20))
+}
+
+func TestOverflow_EllipsisVerticalMarksLastRow(t *testing.T) {
+	widget := Column{
+		Style: Style{Width: Cells(6), Height: Cells(2), Overflow: OverflowEllipsis},
+		Children: []Widget{
+			Text{Content: "one"},
+			Text{Content: "two"},
+			Text{Content: "three"},
+		},
+	}
+	buf := RenderToBuffer(widget, 6, 3)
+	assert.Equal(t, "one   ", bufferLine(buf, 0, 6))
+	assert.Equal(t, "two  …", bufferLine(buf, 1, 6))
+}
+
+func TestOverflow_StackClipOverridesDefault(t *testing.T) {
+	stack := func(overflow Overflow) Widget {
+		return Stack{
+			Style: Style{Width: Cells(4), Height: Cells(1), Overflow: overflow},
+			Children: []Widget{
+				Positioned{Top: IntPtr(0), Left: IntPtr(2), Child: Text{Content: "abcd"}},
+			},
+		}
+	}
+
+	buf := RenderToBuffer(stack(OverflowDefault), 8, 1)
+	assert.Equal(t, "  abcd  ", bufferLine(buf, 0, 8), "Stack children bleed by default")
+
+	buf = RenderToBuffer(stack(OverflowClip), 8, 1)
+	assert.Equal(t, "  ab    ", bufferLine(buf, 0, 8), "OverflowClip clips Stack children")
+}
+
+func TestOverflow_StackClipBehavior(t *testing.T) {
+	// A bordered 6x3 stack one cell in from the screen's edges, with a
+	// child starting one cell above and left of it.
+	stack := func(clip ClipBehavior) Widget {
+		return Column{
+			Style: Style{Padding: EdgeInsetsAll(1), Overflow: OverflowVisible},
+			Children: []Widget{Stack{
+				Clip:  clip,
+				Style: Style{Width: Cells(4), Height: Cells(1), Border: RoundedBorder(RGB(90, 90, 90)), Overflow: OverflowClip},
+				Children: []Widget{
+					Positioned{Top: IntPtr(-1), Left: IntPtr(-1), Child: Text{Content: "abcdef\nabcdef\nabcdef"}},
+				},
+			}},
+		}
+	}
+
+	buf := RenderToBuffer(stack(ClipNone), 8, 5)
+	assert.Equal(t, "abcdef  ", bufferLine(buf, 0, 8), "ClipNone overrides Style.Overflow")
+
+	buf = RenderToBuffer(stack(ClipToBounds), 8, 5)
+	assert.Equal(t, "        ", bufferLine(buf, 0, 8))
+	assert.Equal(t, " bcdef╮ ", bufferLine(buf, 1, 8), "children may paint over the border")
+
+	buf = RenderToBuffer(stack(ClipInsideBorder), 8, 5)
+	assert.Equal(t, " ╭────╮ ", bufferLine(buf, 1, 8), "rounded corners stay intact")
+	assert.Equal(t, " │cdef│ ", bufferLine(buf, 2, 8))
+}
+
+func TestStack_ZIndexSetsPaintOrder(t *testing.T) {
+	widget := Stack{
+		Children: []Widget{
+			Positioned{Top: IntPtr(0), Left: IntPtr(0), ZIndex: 1, Child: Text{Content: "top"}},
+			Text{Content: "base!"},
+			Positioned{Top: IntPtr(0), Left: IntPtr(1), Child: Text{Content: "mid"}},
+		},
+	}
+
+	buf := RenderToBuffer(widget, 5, 1)
+	assert.Equal(t, "topd!", bufferLine(buf, 0, 5), "higher ZIndex paints last; ties keep declaration order")
+}
+
+func TestOverflow_TextEllipsis(t *testing.T) {
+	plain := Text{
+		Content: "hello world",
+		Style:   Style{Width: Cells(8), Overflow: OverflowEllipsis},
+	}
+	buf := RenderToBuffer(plain, 8, 1)
+	assert.Equal(t, "hello w…", bufferLine(buf, 0, 8))
+
+	rich := Text{
+		Spans: []Span{BoldSpan("hello "), PlainSpan("world")},
+		Style: Style{Width: Cells(8), Overflow: OverflowEllipsis},
+	}
+	buf = RenderToBuffer(rich, 8, 1)
+	assert.Equal(t, "hello w…", bufferLine(buf, 0, 8))
+}
@@ -3,6 +3,7 @@ package terma
 import (
 	"fmt"
 	"os"
+	"runtime/debug"
 	"sync"
 )
 
@@ -33,6 +34,35 @@ func drainPanics() []Panic {
 	return panics
 }
 
+// recordedPanics returns a copy of the recorded panics without clearing them.
+func recordedPanics() []Panic {
+	panicStoreMu.Lock()
+	defer panicStoreMu.Unlock()
+	return append([]Panic(nil), panicStore...)
+}
+
+// Go runs fn in a new goroutine. If fn panics, the panic is recorded and the
+// running app quits, so the terminal is restored and the panic printed
+// instead of the process dying with the terminal left in raw mode.
+func Go(fn func()) {
+	go runGuarded(fn)
+}
+
+// runGuarded calls fn, recording a panic and quitting the app instead of
+// letting it crash the process. Used for code running off the event loop.
+func runGuarded(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			recordPanic(Panic{
+				Message:    fmt.Sprint(r),
+				StackTrace: string(debug.Stack()),
+			})
+			Quit()
+		}
+	}()
+	fn()
+}
+
 // renderPanics prints recorded panics to stderr. Called after the terminal
 // has been restored to normal mode so output is visible.
 func renderPanics() {
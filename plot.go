@@ -0,0 +1,569 @@
+package terma
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/darrenburns/terma/layout"
+)
+
+// PlotPoint is a point on a Plot, in data coordinates.
+type PlotPoint struct {
+	X, Y float64
+}
+
+// PlotSeries is one set of data drawn on a Plot.
+type PlotSeries struct {
+	Name   string                  // Shown in the legend and the crosshair readout
+	Points []PlotPoint             // Data points, in order for line series
+	Func   func(x float64) float64 // Plotted across the visible X range instead of Points
+	Line   bool                    // Connect consecutive points (default: scatter)
+	Color  Color                   // Default: a theme color picked by the series' index
+}
+
+// PlotView is the range of data a Plot shows.
+type PlotView struct {
+	MinX, MaxX float64
+	MinY, MaxY float64
+}
+
+func (v PlotView) width() float64  { return v.MaxX - v.MinX }
+func (v PlotView) height() float64 { return v.MaxY - v.MinY }
+
+// PlotState holds the view and crosshair of a Plot.
+type PlotState struct {
+	View      AnySignal[*PlotView]  // Zoomed or panned view, or nil to fit the data
+	Crosshair AnySignal[*PlotPoint] // Crosshair position, or nil when hidden
+
+	dragging bool
+	moved    bool
+	dragX    int
+	dragY    int
+	dragView PlotView
+
+	area chartArea // Where the last render put the data, for mouse handling
+}
+
+// NewPlotState creates a PlotState that fits the data, without a
+// crosshair.
+func NewPlotState() *PlotState {
+	return &PlotState{
+		View:      NewAnySignal[*PlotView](nil),
+		Crosshair: NewAnySignal[*PlotPoint](nil),
+	}
+}
+
+// ResetView fits the plot to its data again.
+func (s *PlotState) ResetView() {
+	s.View.Set(nil)
+}
+
+// Plot draws XY data as scatter points, lines or functions, using braille
+// characters for 2×4 dots per cell, with axes and a legend. The view fits
+// the data unless zoomed or panned: when focused, arrow keys pan, + and -
+// zoom, 0 resets, and dragging with the mouse pans too. c (or a click)
+// shows a crosshair, moved with shift+arrows, that reads out the nearest
+// point.
+//
+// Example:
+//
+//	terma.Plot{
+//	    State: a.plot,
+//	    Series: []terma.PlotSeries{
+//	        {Name: "samples", Points: a.samples},
+//	        {Name: "fit", Func: a.fit, Line: true},
+//	    },
+//	    Style: terma.Style{Height: terma.Cells(16)},
+//	}
+type Plot struct {
+	ID           string                     // Optional unique identifier
+	DisableFocus bool                       // If true, prevent keyboard focus
+	State        *PlotState                 // Optional; required for zoom, pan and the crosshair
+	Series       []PlotSeries               // The data to draw
+	MinX, MaxX   *float64                   // Optional fixed X range (default: fit the data)
+	MinY, MaxY   *float64                   // Optional fixed Y range (default: fit the data)
+	XFormat      func(value float64) string // Formats X axis labels (default: 4 significant digits)
+	YFormat      func(value float64) string // Formats Y axis labels (default: 4 significant digits)
+	HideAxes     bool                       // Draw the data only, without axes and labels
+	HideLegend   bool                       // Hide the legend of named series
+	Style        Style                      // Optional styling
+}
+
+// Build returns itself as Plot is a leaf widget, subscribing to its view.
+func (p Plot) Build(ctx BuildContext) Widget {
+	if p.State != nil {
+		p.State.View.Get()
+		p.State.Crosshair.Get()
+	}
+	return p
+}
+
+// WidgetID returns the plot's unique identifier.
+// Implements the Identifiable interface.
+func (p Plot) WidgetID() string {
+	return p.ID
+}
+
+// IsFocusable returns true when the plot has state to zoom and pan.
+func (p Plot) IsFocusable() bool {
+	return !p.DisableFocus && p.State != nil
+}
+
+// OnKey handles keys not covered by declarative keybindings.
+func (p Plot) OnKey(event KeyEvent) bool {
+	return false
+}
+
+// Keybinds returns the keys for zooming, panning and the crosshair.
+func (p Plot) Keybinds() []Keybind {
+	if p.State == nil {
+		return nil
+	}
+	return []Keybind{
+		{Key: "+", Name: "Zoom in", Action: func() { p.Zoom(2) }},
+		{Key: "=", Name: "Zoom in", Action: func() { p.Zoom(2) }, Hidden: true},
+		{Key: "-", Name: "Zoom out", Action: func() { p.Zoom(0.5) }},
+		{Key: "0", Name: "Reset view", Action: p.State.ResetView},
+		{Key: "c", Name: "Crosshair", Action: p.ToggleCrosshair},
+		{Key: "left", Name: "Pan", Action: func() { p.Pan(-0.1, 0) }, Hidden: true},
+		{Key: "right", Name: "Pan", Action: func() { p.Pan(0.1, 0) }, Hidden: true},
+		{Key: "up", Name: "Pan", Action: func() { p.Pan(0, 0.1) }, Hidden: true},
+		{Key: "down", Name: "Pan", Action: func() { p.Pan(0, -0.1) }, Hidden: true},
+		{Key: "shift+left", Name: "Move crosshair", Action: func() { p.moveCrosshair(-1, 0) }, Hidden: true},
+		{Key: "shift+right", Name: "Move crosshair", Action: func() { p.moveCrosshair(1, 0) }, Hidden: true},
+		{Key: "shift+up", Name: "Move crosshair", Action: func() { p.moveCrosshair(0, -1) }, Hidden: true},
+		{Key: "shift+down", Name: "Move crosshair", Action: func() { p.moveCrosshair(0, 1) }, Hidden: true},
+	}
+}
+
+// Zoom scales the view around its center: factors above 1 zoom in, below
+// 1 zoom out.
+func (p Plot) Zoom(factor float64) {
+	if p.State == nil || factor <= 0 {
+		return
+	}
+	view := p.view()
+	cx, cy := view.MinX+view.width()/2, view.MinY+view.height()/2
+	halfW, halfH := view.width()/2/factor, view.height()/2/factor
+	p.State.View.Set(&PlotView{MinX: cx - halfW, MaxX: cx + halfW, MinY: cy - halfH, MaxY: cy + halfH})
+}
+
+// Pan moves the view by fractions of its width and height; positive
+// values move right and up.
+func (p Plot) Pan(dx, dy float64) {
+	if p.State == nil {
+		return
+	}
+	view := p.view()
+	shiftX, shiftY := dx*view.width(), dy*view.height()
+	p.State.View.Set(&PlotView{
+		MinX: view.MinX + shiftX, MaxX: view.MaxX + shiftX,
+		MinY: view.MinY + shiftY, MaxY: view.MaxY + shiftY,
+	})
+}
+
+// ToggleCrosshair shows the crosshair at the center of the view, or hides
+// it.
+func (p Plot) ToggleCrosshair() {
+	if p.State == nil {
+		return
+	}
+	if p.State.Crosshair.Peek() != nil {
+		p.State.Crosshair.Set(nil)
+		return
+	}
+	view := p.view()
+	p.State.Crosshair.Set(&PlotPoint{X: view.MinX + view.width()/2, Y: view.MinY + view.height()/2})
+}
+
+// moveCrosshair moves the crosshair by whole cells.
+func (p Plot) moveCrosshair(dx, dy int) {
+	if p.State == nil {
+		return
+	}
+	area := p.State.area
+	crosshair := p.State.Crosshair.Peek()
+	if crosshair == nil || area.width <= 0 || area.height <= 0 {
+		return
+	}
+	x, y := area.cellOf(*crosshair)
+	point := area.pointAt(max(0, min(area.width-1, x+dx)), max(0, min(area.height-1, y+dy)))
+	p.State.Crosshair.Set(&point)
+}
+
+// OnMouseDown starts panning when the data area is pressed.
+func (p Plot) OnMouseDown(event MouseEvent) {
+	if p.State == nil {
+		return
+	}
+	x, y := p.contentCoords(event)
+	if !p.State.area.contains(x, y) {
+		return
+	}
+	p.State.dragging = true
+	p.State.moved = false
+	p.State.dragX, p.State.dragY = x, y
+	p.State.dragView = p.State.area.view
+}
+
+// OnMouseMove pans the view with the pointer while dragging.
+func (p Plot) OnMouseMove(event MouseEvent) {
+	if p.State == nil || !p.State.dragging {
+		return
+	}
+	x, y := p.contentCoords(event)
+	dx, dy := x-p.State.dragX, y-p.State.dragY
+	if dx == 0 && dy == 0 && !p.State.moved {
+		return
+	}
+	p.State.moved = true
+	area, view := p.State.area, p.State.dragView
+	shiftX := -float64(dx) * view.width() / float64(max(1, area.width))
+	shiftY := float64(dy) * view.height() / float64(max(1, area.height))
+	p.State.View.Set(&PlotView{
+		MinX: view.MinX + shiftX, MaxX: view.MaxX + shiftX,
+		MinY: view.MinY + shiftY, MaxY: view.MaxY + shiftY,
+	})
+}
+
+// OnMouseUp ends a drag. A press that didn't move places the crosshair.
+func (p Plot) OnMouseUp(event MouseEvent) {
+	if p.State == nil || !p.State.dragging {
+		return
+	}
+	p.State.dragging = false
+	if !p.State.moved {
+		point := p.State.area.pointAt(p.State.dragX-p.State.area.left, p.State.dragY-p.State.area.top)
+		p.State.Crosshair.Set(&point)
+	}
+}
+
+// contentCoords converts a mouse event's border-box position to the
+// content box.
+func (p Plot) contentCoords(event MouseEvent) (int, int) {
+	insets := p.Style.Border.Insets()
+	return event.LocalX - insets.Left - p.Style.Padding.Left, event.LocalY - insets.Top - p.Style.Padding.Top
+}
+
+// GetContentDimensions returns the width and height dimension preferences.
+// Width defaults to Flex(1), Height defaults to Cells(10).
+func (p Plot) GetContentDimensions() (width, height Dimension) {
+	dims := p.Style.GetDimensions()
+	width, height = dims.Width, dims.Height
+	if width.IsUnset() {
+		width = Flex(1)
+	}
+	if height.IsUnset() {
+		height = Cells(10)
+	}
+	return width, height
+}
+
+// GetStyle returns the style of the plot.
+func (p Plot) GetStyle() Style {
+	return p.Style
+}
+
+// BuildLayoutNode builds a layout node for this Plot widget.
+func (p Plot) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return monitorLayoutNode(p, p.Style)
+}
+
+// view returns the visible range: the zoomed or panned view, else the
+// fixed range, fitting the data on any axis without one.
+func (p Plot) view() PlotView {
+	if p.State != nil {
+		if view := p.State.View.Peek(); view != nil {
+			return *view
+		}
+	}
+	bounds := plotDataBounds(p.Series, p.MinX, p.MaxX)
+	overrides := []struct {
+		value  *float64
+		target *float64
+	}{{p.MinX, &bounds.MinX}, {p.MaxX, &bounds.MaxX}, {p.MinY, &bounds.MinY}, {p.MaxY, &bounds.MaxY}}
+	for _, o := range overrides {
+		if o.value != nil {
+			*o.target = *o.value
+		}
+	}
+	return widenChartView(bounds)
+}
+
+// plotDataBounds returns the range of the series' points, sampling
+// functions across the X range.
+func plotDataBounds(series []PlotSeries, fixedMinX, fixedMaxX *float64) PlotView {
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, s := range series {
+		for _, pt := range s.Points {
+			if s.Func != nil || !chartFinite(pt.X) || !chartFinite(pt.Y) {
+				continue
+			}
+			minX, maxX = math.Min(minX, pt.X), math.Max(maxX, pt.X)
+			minY, maxY = math.Min(minY, pt.Y), math.Max(maxY, pt.Y)
+		}
+	}
+	if fixedMinX != nil {
+		minX = *fixedMinX
+	}
+	if fixedMaxX != nil {
+		maxX = *fixedMaxX
+	}
+	if minX > maxX {
+		minX, maxX = 0, 1
+	}
+
+	const functionSamples = 200
+	for _, s := range series {
+		if s.Func == nil {
+			continue
+		}
+		for i := 0; i <= functionSamples; i++ {
+			y := s.Func(minX + (maxX-minX)*float64(i)/functionSamples)
+			if chartFinite(y) {
+				minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+			}
+		}
+	}
+	if minY > maxY {
+		minY, maxY = 0, 1
+	}
+	return PlotView{MinX: minX, MaxX: maxX, MinY: minY, MaxY: maxY}
+}
+
+func (p Plot) seriesColor(theme ThemeData, index int) Color {
+	return chartColor(theme, p.Series[index].Color, index)
+}
+
+// Render draws the axes, the series, the legend and the crosshair.
+func (p Plot) Render(ctx *RenderContext) {
+	if ctx.Width <= 0 || ctx.Height <= 0 {
+		return
+	}
+	theme := ctx.buildContext.Theme()
+	view := p.view()
+	area := chartArea{view: view, width: ctx.Width, height: ctx.Height}
+	if !p.HideAxes {
+		area = drawChartAxes(ctx, theme, view, p.YFormat)
+		drawChartXLabels(ctx, theme, area, p.XFormat)
+	}
+	if p.State != nil {
+		p.State.area = area
+	}
+	if area.width <= 0 || area.height <= 0 {
+		return
+	}
+
+	// Dots of each cell, and the series that drew last in it
+	type plotCell struct {
+		bits   rune
+		series int
+	}
+	cells := make([][]plotCell, area.height)
+	for y := range cells {
+		cells[y] = make([]plotCell, area.width)
+	}
+	setDot := func(dx, dy, series int) {
+		if dx < 0 || dy < 0 || dx >= area.width*2 || dy >= area.height*4 {
+			return
+		}
+		cell := &cells[dy/4][dx/2]
+		cell.bits |= brailleDots[dx%2][3-dy%4]
+		cell.series = series
+	}
+	line := func(x0, y0, x1, y1 float64, series int) {
+		x0, y0, x1, y1, ok := clipPlotSegment(x0, y0, x1, y1, float64(area.width*2-1), float64(area.height*4-1))
+		if ok {
+			plotLine(int(math.Round(x0)), int(math.Round(y0)), int(math.Round(x1)), int(math.Round(y1)), func(x, y int) {
+				setDot(x, y, series)
+			})
+		}
+	}
+
+	for i, s := range p.Series {
+		if s.Func != nil {
+			prevX, prevY, havePrev := 0.0, 0.0, false
+			for dx := 0; dx < area.width*2; dx++ {
+				x := view.MinX + float64(dx)/float64(max(1, area.width*2-1))*view.width()
+				y := s.Func(x)
+				if !chartFinite(y) {
+					havePrev = false
+					continue
+				}
+				_, dy := area.dot(PlotPoint{X: x, Y: y})
+				if havePrev {
+					line(prevX, prevY, float64(dx), dy, i)
+				} else {
+					line(float64(dx), dy, float64(dx), dy, i)
+				}
+				prevX, prevY, havePrev = float64(dx), dy, true
+			}
+			continue
+		}
+		havePrev := false
+		var prevX, prevY float64
+		for _, pt := range s.Points {
+			if !chartFinite(pt.X) || !chartFinite(pt.Y) {
+				havePrev = false
+				continue
+			}
+			dx, dy := area.dot(pt)
+			switch {
+			case s.Line && havePrev:
+				line(prevX, prevY, dx, dy, i)
+			default:
+				setDot(int(math.Round(dx)), int(math.Round(dy)), i)
+			}
+			prevX, prevY, havePrev = dx, dy, true
+		}
+	}
+
+	for y, row := range cells {
+		for x, cell := range row {
+			if cell.bits != 0 {
+				style := Style{ForegroundColor: p.seriesColor(theme, cell.series)}
+				ctx.DrawStyledText(area.left+x, area.top+y, string(0x2800+cell.bits), style)
+			}
+		}
+	}
+
+	if p.State != nil {
+		if crosshair := p.State.Crosshair.Peek(); crosshair != nil {
+			p.drawCrosshair(ctx, theme, area, *crosshair)
+		}
+	}
+	if !p.HideLegend {
+		entries := make([]chartLegendEntry, len(p.Series))
+		for i, s := range p.Series {
+			entries[i] = chartLegendEntry{name: s.Name, color: p.seriesColor(theme, i)}
+		}
+		drawChartLegend(ctx, theme, area, entries)
+	}
+}
+
+// drawCrosshair draws lines through the crosshair, marks the nearest
+// point and reads it out in the top left corner.
+func (p Plot) drawCrosshair(ctx *RenderContext, theme ThemeData, area chartArea, crosshair PlotPoint) {
+	cx, cy := area.cellOf(crosshair)
+	lineStyle := Style{ForegroundColor: theme.TextMuted}
+	if cx >= 0 && cx < area.width {
+		for y := 0; y < area.height; y++ {
+			ctx.DrawStyledText(area.left+cx, area.top+y, "│", lineStyle)
+		}
+	}
+	if cy >= 0 && cy < area.height {
+		ctx.DrawStyledText(area.left, area.top+cy, strings.Repeat("─", area.width), lineStyle)
+		if cx >= 0 && cx < area.width {
+			ctx.DrawStyledText(area.left+cx, area.top+cy, "┼", lineStyle)
+		}
+	}
+
+	nearest, series, ok := p.nearestPoint(area, crosshair)
+	if !ok {
+		return
+	}
+	if x, y := area.cellOf(nearest); area.contains(area.left+x, area.top+y) {
+		ctx.DrawStyledText(area.left+x, area.top+y, "●", Style{ForegroundColor: p.seriesColor(theme, series), Bold: true})
+	}
+	readout := fmt.Sprintf(" x=%s y=%s ", chartFormat(p.XFormat)(nearest.X), chartFormat(p.YFormat)(nearest.Y))
+	if name := p.Series[series].Name; name != "" {
+		readout = " " + name + ":" + readout
+	}
+	ctx.DrawStyledText(area.left, area.top, readout, Style{ForegroundColor: theme.Text, BackgroundColor: theme.Surface})
+}
+
+// nearestPoint returns the visible point closest to target on screen, and
+// its series. Functions count as the point at target's X.
+func (p Plot) nearestPoint(area chartArea, target PlotPoint) (PlotPoint, int, bool) {
+	tx, ty := area.dot(target)
+	// Dots are about twice as tall as they are wide on screen
+	distance := func(pt PlotPoint) float64 {
+		dx, dy := area.dot(pt)
+		return math.Hypot(dx-tx, (dy-ty)*2)
+	}
+	visible := func(pt PlotPoint) bool {
+		return pt.X >= area.view.MinX && pt.X <= area.view.MaxX && pt.Y >= area.view.MinY && pt.Y <= area.view.MaxY
+	}
+
+	var best PlotPoint
+	bestSeries, bestDistance := -1, math.Inf(1)
+	consider := func(pt PlotPoint, series int) {
+		if !chartFinite(pt.X) || !chartFinite(pt.Y) || !visible(pt) {
+			return
+		}
+		if d := distance(pt); d < bestDistance {
+			best, bestSeries, bestDistance = pt, series, d
+		}
+	}
+	for i, s := range p.Series {
+		if s.Func != nil {
+			consider(PlotPoint{X: target.X, Y: s.Func(target.X)}, i)
+			continue
+		}
+		for _, pt := range s.Points {
+			consider(pt, i)
+		}
+	}
+	return best, bestSeries, bestSeries >= 0
+}
+
+// clipPlotSegment clips a segment to [0, maxX]×[0, maxY] (Liang–Barsky).
+func clipPlotSegment(x0, y0, x1, y1, maxX, maxY float64) (float64, float64, float64, float64, bool) {
+	dx, dy := x1-x0, y1-y0
+	t0, t1 := 0.0, 1.0
+	clip := func(p, q float64) bool {
+		if p == 0 {
+			return q >= 0
+		}
+		r := q / p
+		if p < 0 {
+			if r > t1 {
+				return false
+			}
+			t0 = math.Max(t0, r)
+		} else {
+			if r < t0 {
+				return false
+			}
+			t1 = math.Min(t1, r)
+		}
+		return true
+	}
+	if !clip(-dx, x0) || !clip(dx, maxX-x0) || !clip(-dy, y0) || !clip(dy, maxY-y0) {
+		return 0, 0, 0, 0, false
+	}
+	return x0 + t0*dx, y0 + t0*dy, x0 + t1*dx, y0 + t1*dy, true
+}
+
+// plotLine calls set for each dot on the line from (x0, y0) to (x1, y1)
+// (Bresenham).
+func plotLine(x0, y0, x1, y1 int, set func(x, y int)) {
+	dx, dy := x1-x0, y1-y0
+	sx, sy := 1, 1
+	if dx < 0 {
+		dx, sx = -dx, -1
+	}
+	if dy > 0 {
+		dy = -dy
+	} else {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		set(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func renderPlot(t *testing.T, plot Plot, width, height int) []string {
+	t.Helper()
+	renderer, buf := portalTestRenderer(width, height)
+	plot.Style.Width = Cells(width)
+	plot.Style.Height = Cells(height)
+	renderer.Render(plot)
+	lines := make([]string, height)
+	for y := range lines {
+		lines[y] = bufferLine(buf, y, width)
+	}
+	return lines
+}
+
+func TestPlot_ScatterPointsUseBrailleDots(t *testing.T) {
+	plot := Plot{
+		HideAxes: true,
+		Series: []PlotSeries{{Points: []PlotPoint{
+			{X: 0, Y: 1}, // Top left dot
+			{X: 1, Y: 0}, // Bottom right dot
+		}}},
+	}
+
+	lines := renderPlot(t, plot, 2, 2)
+
+	assert.Equal(t, "⠁ ", lines[0])
+	assert.Equal(t, " ⢀", lines[1])
+}
+
+func TestPlot_LineSeriesConnectsPoints(t *testing.T) {
+	plot := Plot{
+		HideAxes: true,
+		Series: []PlotSeries{{Line: true, Points: []PlotPoint{
+			{X: 0, Y: 0},
+			{X: 1, Y: 0},
+			{X: 1, Y: 1},
+		}}},
+	}
+
+	lines := renderPlot(t, plot, 2, 1)
+
+	// Bottom row of dots across both cells, then up the right column
+	assert.Equal(t, "⣀⣸", lines[0])
+}
+
+func TestPlot_FunctionSeriesIsSampledAcrossTheView(t *testing.T) {
+	zero, one := 0.0, 1.0
+	plot := Plot{
+		HideAxes: true,
+		MinX:     &zero, MaxX: &one,
+		MinY: &zero, MaxY: &one,
+		Series: []PlotSeries{{Func: func(x float64) float64 { return 0 }}},
+	}
+
+	lines := renderPlot(t, plot, 3, 1)
+
+	assert.Equal(t, "⣀⣀⣀", lines[0])
+}
+
+func TestPlot_DrawsAxesWithLabels(t *testing.T) {
+	plot := Plot{Series: []PlotSeries{{Points: []PlotPoint{{X: 0, Y: 0}, {X: 10, Y: 100}}}}}
+
+	lines := renderPlot(t, plot, 20, 7)
+
+	assert.True(t, strings.HasPrefix(lines[0], "100│"), lines[0])
+	assert.True(t, strings.HasPrefix(lines[2], " 50│"), lines[2])
+	assert.True(t, strings.HasPrefix(lines[4], "  0│"), lines[4])
+	assert.Equal(t, "   └────────────────", lines[5])
+	assert.Equal(t, "    0       5     10", lines[6])
+}
+
+func TestPlot_LegendListsNamedSeries(t *testing.T) {
+	plot := Plot{
+		HideAxes: true,
+		Series: []PlotSeries{
+			{Name: "cpu", Points: []PlotPoint{{X: 0, Y: 0}}},
+			{Points: []PlotPoint{{X: 1, Y: 1}}},
+			{Name: "mem", Points: []PlotPoint{{X: 1, Y: 0}}},
+		},
+	}
+
+	lines := renderPlot(t, plot, 12, 3)
+
+	assert.True(t, strings.HasSuffix(lines[0], " ● cpu "), lines[0])
+	assert.True(t, strings.HasSuffix(lines[1], " ● mem "), lines[1])
+}
+
+func TestPlot_ZoomPanAndReset(t *testing.T) {
+	state := NewPlotState()
+	plot := Plot{State: state, Series: []PlotSeries{{Points: []PlotPoint{{X: 0, Y: 0}, {X: 10, Y: 20}}}}}
+
+	plot.Zoom(2)
+	assert.Equal(t, &PlotView{MinX: 2.5, MaxX: 7.5, MinY: 5, MaxY: 15}, state.View.Peek())
+
+	plot.Pan(0.1, -0.5)
+	assert.Equal(t, &PlotView{MinX: 3, MaxX: 8, MinY: 0, MaxY: 10}, state.View.Peek())
+
+	assert.True(t, matchKeybind(makeCharEvent('0'), plot.Keybinds()))
+	assert.Nil(t, state.View.Peek())
+	assert.True(t, matchKeybind(makeCharEvent('-'), plot.Keybinds()))
+	assert.Equal(t, &PlotView{MinX: -5, MaxX: 15, MinY: -10, MaxY: 30}, state.View.Peek())
+}
+
+func TestPlot_CrosshairReadsOutTheNearestPoint(t *testing.T) {
+	state := NewPlotState()
+	plot := Plot{
+		State:    state,
+		HideAxes: true,
+		Series: []PlotSeries{
+			{Name: "a", Points: []PlotPoint{{X: 0, Y: 0}, {X: 10, Y: 10}}},
+			{Name: "b", Points: []PlotPoint{{X: 4, Y: 6}}},
+		},
+		HideLegend: true,
+	}
+	state.Crosshair.Set(&PlotPoint{X: 5, Y: 5})
+
+	lines := renderPlot(t, plot, 30, 10)
+
+	assert.True(t, strings.HasPrefix(lines[0], " b: x=4 y=6 "), lines[0])
+	assert.Contains(t, strings.Join(lines, "\n"), "●")
+	assert.Contains(t, strings.Join(lines, "\n"), "┼")
+}
+
+func TestPlot_KeyboardCrosshair(t *testing.T) {
+	state := NewPlotState()
+	plot := Plot{State: state, HideAxes: true, Series: []PlotSeries{{Points: []PlotPoint{{X: 0, Y: 0}, {X: 10, Y: 10}}}}}
+	renderPlot(t, plot, 10, 5)
+
+	plot.ToggleCrosshair()
+	require.NotNil(t, state.Crosshair.Peek())
+	start := *state.Crosshair.Peek()
+	startX, _ := state.area.cellOf(start)
+
+	plot.moveCrosshair(1, 0)
+	x, _ := state.area.cellOf(*state.Crosshair.Peek())
+	assert.Equal(t, startX+1, x)
+
+	plot.ToggleCrosshair()
+	assert.Nil(t, state.Crosshair.Peek())
+}
+
+func TestPlot_DraggingPansAndClickingPlacesTheCrosshair(t *testing.T) {
+	state := NewPlotState()
+	plot := Plot{State: state, HideAxes: true, Series: []PlotSeries{{Points: []PlotPoint{{X: 0, Y: 0}, {X: 10, Y: 10}}}}}
+	renderPlot(t, plot, 10, 5)
+
+	plot.OnMouseDown(MouseEvent{LocalX: 5, LocalY: 2})
+	plot.OnMouseMove(MouseEvent{LocalX: 4, LocalY: 3})
+	plot.OnMouseUp(MouseEvent{LocalX: 4, LocalY: 3})
+	assert.Equal(t, &PlotView{MinX: 1, MaxX: 11, MinY: 2, MaxY: 12}, state.View.Peek())
+	assert.Nil(t, state.Crosshair.Peek(), "a drag doesn't place the crosshair")
+
+	plot.OnMouseDown(MouseEvent{LocalX: 0, LocalY: 0})
+	plot.OnMouseUp(MouseEvent{LocalX: 0, LocalY: 0})
+	require.NotNil(t, state.Crosshair.Peek())
+	x, y := state.area.cellOf(*state.Crosshair.Peek())
+	assert.Equal(t, 0, x)
+	assert.Equal(t, 0, y)
+}
+
+func TestClipPlotSegment(t *testing.T) {
+	x0, y0, x1, y1, ok := clipPlotSegment(-10, 5, 20, 5, 10, 10)
+	assert.True(t, ok)
+	assert.Equal(t, []float64{0, 5, 10, 5}, []float64{x0, y0, x1, y1})
+
+	_, _, _, _, ok = clipPlotSegment(-10, -5, 20, -5, 10, 10)
+	assert.False(t, ok)
+}
@@ -0,0 +1,154 @@
+package terma
+
+import uv "github.com/charmbracelet/ultraviolet"
+
+// pointerState is what the pointer is over and pressing, used to apply
+// Style.HoverStyle and Style.PressedStyle.
+type pointerState struct {
+	hoveredID string // Widget under the pointer ("" if none)
+	pressedID string // Widget a mouse button went down on, until released
+}
+
+// setPointerState records the hovered and pressed widgets for the next render.
+func (r *Renderer) setPointerState(hoveredID, pressedID string) {
+	r.pointer = pointerState{hoveredID: hoveredID, pressedID: pressedID}
+}
+
+// markPointerPaths finds the widgets in tree that enclose the hovered and
+// pressed widgets. Like CSS :hover and :active, a container counts as
+// hovered while the pointer is over any of its descendants.
+func (r *Renderer) markPointerPaths(tree RenderTree) {
+	r.hoverPath = idPath(tree, r.pointer.hoveredID)
+	r.pressPath = idPath(tree, r.pointer.pressedID)
+	r.scrollTarget = r.scrollTargetID(tree)
+}
+
+// idPath returns the EventIDs of the nodes from tree's root to the node
+// with the given ID, or nil if there is no such node.
+func idPath(tree RenderTree, id string) map[string]bool {
+	if id == "" {
+		return nil
+	}
+	var path []string
+	var find func(node RenderTree) bool
+	find = func(node RenderTree) bool {
+		path = append(path, node.EventID)
+		if node.EventID == id {
+			return true
+		}
+		for _, child := range node.Children {
+			if find(child) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	if !find(tree) {
+		return nil
+	}
+	ids := make(map[string]bool, len(path))
+	for _, eventID := range path {
+		ids[eventID] = true
+	}
+	return ids
+}
+
+// pointerStyle returns the HoverStyle or PressedStyle that applies to the
+// node with the given ID, PressedStyle winning while both apply.
+func (r *Renderer) pointerStyle(eventID string, style Style) (Style, bool) {
+	if style.PressedStyle != nil && r.pressPath[eventID] {
+		return *style.PressedStyle, true
+	}
+	if style.HoverStyle != nil && r.hoverPath[eventID] {
+		return *style.HoverStyle, true
+	}
+	return Style{}, false
+}
+
+// withPointerStyle returns style with overlay's background and border
+// applied. Only a widget that has a border gets overlay's border, and only
+// its look changes, so hovering never changes layout.
+func (s Style) withPointerStyle(overlay Style) Style {
+	if overlay.BackgroundColor != nil && overlay.BackgroundColor.IsSet() {
+		s.BackgroundColor = overlay.BackgroundColor
+	}
+	if !s.Border.IsZero() && !overlay.Border.IsZero() {
+		s.Border.Style = overlay.Border.Style
+		if overlay.Border.Color != nil {
+			s.Border.Color = overlay.Border.Color
+		}
+		if len(overlay.Border.Decorations) > 0 {
+			s.Border.Decorations = overlay.Border.Decorations
+		}
+	}
+	return s
+}
+
+// restylePointerContent applies overlay's colors and text attributes to
+// the content a widget has drawn in ctx. Colors are sampled across the
+// widget's border box, of the given size, starting at (originX, originY).
+func restylePointerContent(ctx *RenderContext, overlay Style, width, height, originX, originY int) {
+	hasFg := overlay.ForegroundColor != nil && overlay.ForegroundColor.IsSet()
+	hasBg := overlay.BackgroundColor != nil && overlay.BackgroundColor.IsSet()
+
+	var attrs uint8
+	if overlay.Bold {
+		attrs |= uv.AttrBold
+	}
+	if overlay.Faint {
+		attrs |= uv.AttrFaint
+	}
+	if overlay.Italic {
+		attrs |= uv.AttrItalic
+	}
+	if overlay.Blink {
+		attrs |= uv.AttrBlink
+	}
+	if overlay.Reverse {
+		attrs |= uv.AttrReverse
+	}
+	if overlay.Conceal {
+		attrs |= uv.AttrConceal
+	}
+	if overlay.Strikethrough {
+		attrs |= uv.AttrStrikethrough
+	}
+	if !hasFg && !hasBg && attrs == 0 && overlay.Underline == UnderlineNone {
+		return
+	}
+
+	for row := 0; row < ctx.Height; row++ {
+		for col := 0; col < ctx.Width; col++ {
+			x, y := ctx.X+col, ctx.Y+row
+			if !ctx.clip.Contains(x, y) {
+				continue
+			}
+			cell := ctx.terminal.CellAt(x, y)
+			if cell == nil {
+				continue
+			}
+			styled := *cell
+			relX, relY := x-originX, y-originY
+			// Translucent backgrounds were already blended into the fill
+			// behind the content; blending again would darken them twice.
+			if hasBg {
+				if bg := overlay.BackgroundColor.ColorAt(width, height, relX, relY); bg.IsOpaque() {
+					styled.Style.Bg = bg.toANSI()
+				}
+			}
+			if hasFg {
+				fg := overlay.ForegroundColor.ColorAt(width, height, relX, relY)
+				styled.Style.Fg = blendForeground(fg, FromANSI(styled.Style.Bg)).toANSI()
+			}
+			styled.Style.Attrs |= attrs
+			if overlay.Underline != UnderlineNone {
+				styled.Style.Underline = toUVUnderline(overlay.Underline)
+				if overlay.UnderlineColor.IsSet() {
+					styled.Style.UnderlineColor = overlay.UnderlineColor.toANSI()
+				}
+			}
+			ctx.terminal.SetCell(x, y, &styled)
+		}
+	}
+}
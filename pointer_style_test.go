@@ -0,0 +1,95 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+func renderWithPointer(widget Widget, width, height int, hoveredID, pressedID string) *uv.Buffer {
+	buf := uv.NewBuffer(width, height)
+	renderer := NewRenderer(buf, width, height, NewFocusManager(), NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil))
+	renderer.setPointerState(hoveredID, pressedID)
+	renderer.Render(widget)
+	return buf
+}
+
+func TestHoverStyle_AppliesToHoveredWidget(t *testing.T) {
+	hoverBg := RGB(200, 0, 0)
+	hoverFg := RGB(255, 255, 255)
+	widget := Row{Children: []Widget{
+		Text{ID: "a", Content: "AA", Style: Style{
+			BackgroundColor: RGB(0, 0, 100),
+			ForegroundColor: RGB(0, 100, 0),
+			HoverStyle:      &Style{BackgroundColor: hoverBg, ForegroundColor: hoverFg, Bold: true},
+		}},
+		Text{ID: "b", Content: "BB", Style: Style{
+			BackgroundColor: RGB(0, 0, 100),
+			HoverStyle:      &Style{BackgroundColor: hoverBg},
+		}},
+	}}
+
+	buf := renderWithPointer(widget, 4, 1, "a", "")
+	cell := buf.CellAt(0, 0)
+	assert.Equal(t, "A", cell.Content)
+	assert.Equal(t, hoverBg.toANSI(), cell.Style.Bg)
+	assert.Equal(t, hoverFg.toANSI(), cell.Style.Fg)
+	assert.NotZero(t, cell.Style.Attrs&uv.AttrBold)
+	assert.Equal(t, RGB(0, 0, 100).toANSI(), buf.CellAt(2, 0).Style.Bg, "other widgets keep their style")
+
+	buf = renderWithPointer(widget, 4, 1, "", "")
+	assert.Equal(t, RGB(0, 0, 100).toANSI(), buf.CellAt(0, 0).Style.Bg)
+	assert.Zero(t, buf.CellAt(0, 0).Style.Attrs&uv.AttrBold)
+}
+
+func TestHoverStyle_AppliesWhileDescendantHovered(t *testing.T) {
+	hoverBg := RGB(200, 0, 0)
+	widget := Row{
+		ID: "card",
+		Style: Style{
+			Padding:    EdgeInsetsXY(1, 0),
+			HoverStyle: &Style{BackgroundColor: hoverBg},
+		},
+		Children: []Widget{Text{ID: "label", Content: "Hi"}},
+	}
+
+	buf := renderWithPointer(widget, 4, 1, "label", "")
+	assert.Equal(t, hoverBg.toANSI(), buf.CellAt(0, 0).Style.Bg, "padding")
+	assert.Equal(t, hoverBg.toANSI(), buf.CellAt(1, 0).Style.Bg, "child inherits the background")
+}
+
+func TestPressedStyle_WinsOverHover(t *testing.T) {
+	hoverBg := RGB(200, 0, 0)
+	pressedBg := RGB(0, 200, 0)
+	widget := Text{ID: "a", Content: "A", Style: Style{
+		HoverStyle:   &Style{BackgroundColor: hoverBg},
+		PressedStyle: &Style{BackgroundColor: pressedBg},
+	}}
+
+	buf := renderWithPointer(widget, 1, 1, "a", "a")
+	assert.Equal(t, pressedBg.toANSI(), buf.CellAt(0, 0).Style.Bg)
+
+	buf = renderWithPointer(widget, 1, 1, "a", "")
+	assert.Equal(t, hoverBg.toANSI(), buf.CellAt(0, 0).Style.Bg)
+}
+
+func TestHoverStyle_RestylesBorderWithoutMovingContent(t *testing.T) {
+	hoverBorder := RGB(255, 0, 0)
+	widget := Text{ID: "a", Content: "A", Style: Style{
+		Border: SquareBorder(RGB(50, 50, 50)),
+		HoverStyle: &Style{
+			Border:  RoundedBorder(hoverBorder),
+			Padding: EdgeInsetsAll(1),
+		},
+	}}
+
+	buf := renderWithPointer(widget, 3, 3, "a", "")
+	assert.Equal(t, "╭", buf.CellAt(0, 0).Content)
+	assert.Equal(t, hoverBorder.toANSI(), buf.CellAt(0, 0).Style.Fg)
+	assert.Equal(t, "A", buf.CellAt(1, 1).Content, "padding in HoverStyle is ignored")
+
+	plain := Text{ID: "a", Content: "A", Style: Style{HoverStyle: &Style{Border: RoundedBorder(hoverBorder)}}}
+	buf = renderWithPointer(plain, 3, 1, "a", "")
+	assert.Equal(t, "A", buf.CellAt(0, 0).Content, "no border is added")
+}
@@ -0,0 +1,58 @@
+package terma
+
+import (
+	"strings"
+	"sync"
+)
+
+// maxPooledSlice is the largest capacity a pooled slice may keep when it is
+// returned. Bigger ones are dropped so one huge frame doesn't pin its
+// memory for the life of the app.
+const maxPooledSlice = 1 << 16
+
+// slicePool reuses scratch slices from frame to frame, so the hot paths of
+// layout and rendering stop allocating a fresh slice for every widget on
+// every frame. A slice taken with get must not be used after it is put back.
+//
+// Example:
+//
+//	buf := graphemePool.get()
+//	defer graphemePool.put(buf)
+//	*buf = appendSpanGraphemes(*buf, spans)
+type slicePool[T any] struct {
+	pool sync.Pool
+}
+
+// get returns an empty slice, reusing the storage of one put back earlier.
+func (p *slicePool[T]) get() *[]T {
+	if s, ok := p.pool.Get().(*[]T); ok {
+		return s
+	}
+	return new([]T)
+}
+
+// put empties s and returns it to the pool. The elements are zeroed first
+// so the pool doesn't keep what they point to alive.
+func (p *slicePool[T]) put(s *[]T) {
+	if cap(*s) > maxPooledSlice {
+		return
+	}
+	clear(*s)
+	*s = (*s)[:0]
+	p.pool.Put(s)
+}
+
+// blankRun is a run of spaces that blanks slices from.
+var blankRun = strings.Repeat(" ", 256)
+
+// blanks returns n spaces. Runs up to 256 wide are slices of one shared
+// string, so padding a line costs no allocation.
+func blanks(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if n <= len(blankRun) {
+		return blankRun[:n]
+	}
+	return strings.Repeat(" ", n)
+}
@@ -0,0 +1,49 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlanks(t *testing.T) {
+	assert.Equal(t, "", blanks(0))
+	assert.Equal(t, "", blanks(-3))
+	assert.Equal(t, "   ", blanks(3))
+	assert.Equal(t, strings.Repeat(" ", 300), blanks(300))
+}
+
+func TestSlicePool_PutEmptiesAndZeroes(t *testing.T) {
+	var pool slicePool[string]
+	buf := pool.get()
+	assert.Empty(t, *buf)
+
+	*buf = append(*buf, "a", "b")
+	backing := (*buf)[:2]
+	pool.put(buf)
+
+	assert.Empty(t, *buf)
+	assert.Equal(t, []string{"", ""}, backing, "put zeroes the elements so the pool doesn't keep them alive")
+}
+
+func TestSlicePool_DropsHugeSlices(t *testing.T) {
+	var pool slicePool[byte]
+	buf := pool.get()
+	*buf = make([]byte, 0, maxPooledSlice+1)
+	pool.put(buf)
+	assert.Equal(t, maxPooledSlice+1, cap(*buf), "a slice too big to pool is left as it was")
+}
+
+func TestSpanText_RendersSameAcrossFrames(t *testing.T) {
+	text := Text{
+		Spans: []Span{PlainSpan("reused "), BoldSpan("span buffers")},
+		Wrap:  WrapSoft,
+	}
+	first := screenText(text, 10, 3)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, first, screenText(text, 10, 3))
+	}
+	assert.Contains(t, first, "reused")
+	assert.Contains(t, first, "span")
+}
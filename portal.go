@@ -0,0 +1,76 @@
+package terma
+
+// Portal renders its child into the overlay layer at an arbitrary screen location.
+// Unlike Floating, a Portal has no modal, backdrop, or dismissal semantics: it never
+// traps focus, never reacts to Escape or clicks outside it, and is not clamped to the
+// screen unless ClampToScreen is set. This makes it suitable for custom badges,
+// connection lines between widgets, and drag ghosts that follow the mouse.
+//
+// Portals are painted in the same overlay pass as floats, in build order, so a Portal
+// built inside a Dialog appears above the dialog.
+//
+// Example - badge pinned to the top-right corner of a button:
+//
+//	Portal{
+//	    AnchorID: "inbox-btn",
+//	    Anchor:   AnchorTopRight,
+//	    Offset:   Offset{X: 1, Y: 1},
+//	    Child:    Text{Content: "3", Style: Style{BackgroundColor: theme.Error}},
+//	}
+//
+// Example - drag ghost at the current mouse position:
+//
+//	Portal{
+//	    Offset:        Offset{X: a.dragX.Get(), Y: a.dragY.Get()},
+//	    IgnorePointer: true,
+//	    Child:         Text{Content: a.dragLabel.Get()},
+//	}
+type Portal struct {
+	// Child is the widget rendered into the overlay layer.
+	Child Widget
+
+	// AnchorID is the ID of the widget to position relative to.
+	// If empty, Offset is interpreted as absolute screen coordinates.
+	AnchorID string
+
+	// Anchor specifies where on the anchor widget to attach the child.
+	// Only used when AnchorID is set.
+	Anchor AnchorPoint
+
+	// Offset is added to the computed position. When AnchorID is empty,
+	// this is the absolute screen position of the child's top-left corner.
+	Offset Offset
+
+	// ClampToScreen keeps the child fully on screen.
+	// By default portals may extend past the screen edges and are clipped.
+	ClampToScreen bool
+
+	// IgnorePointer makes the portal transparent to mouse hit testing, so
+	// events reach the widgets underneath (useful for drag ghosts).
+	IgnorePointer bool
+}
+
+// Build registers the portal with the float collector for deferred rendering.
+// Returns an empty widget since the child is painted in the overlay phase.
+func (p Portal) Build(ctx BuildContext) Widget {
+	if p.Child == nil {
+		return EmptyWidget{}
+	}
+
+	if ctx.floatCollector != nil {
+		ctx.floatCollector.Add(FloatEntry{
+			Config: FloatConfig{
+				AnchorID: p.AnchorID,
+				Anchor:   p.Anchor,
+				Position: FloatPositionAbsolute,
+				Offset:   p.Offset,
+			},
+			Child:         p.Child,
+			portal:        true,
+			clamp:         p.ClampToScreen,
+			ignorePointer: p.IgnorePointer,
+		})
+	}
+
+	return EmptyWidget{}
+}
@@ -0,0 +1,124 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+func portalTestRenderer(width, height int) (*Renderer, *uv.Buffer) {
+	buf := uv.NewBuffer(width, height)
+	renderer := NewRenderer(buf, width, height, NewFocusManager(), NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil))
+	return renderer, buf
+}
+
+func TestPortal_RendersAtAbsoluteOffset(t *testing.T) {
+	widget := Column{
+		Children: []Widget{
+			Text{Content: "base"},
+			Portal{
+				Offset: Offset{X: 5, Y: 2},
+				Child:  Text{Content: "ghost"},
+			},
+		},
+	}
+
+	renderer, buf := portalTestRenderer(20, 5)
+	renderer.Render(widget)
+
+	assert.Equal(t, "g", buf.CellAt(5, 2).Content)
+	assert.Equal(t, "t", buf.CellAt(9, 2).Content)
+	assert.False(t, renderer.HasFloats(), "portals should not count as dismissible floats")
+	assert.Nil(t, renderer.TopFloat())
+}
+
+func TestPortal_AnchorsToWidget(t *testing.T) {
+	widget := Column{
+		Children: []Widget{
+			Text{Content: "first"},
+			Text{ID: "target", Content: "target"},
+			Portal{
+				AnchorID: "target",
+				Anchor:   AnchorRightTop,
+				Offset:   Offset{X: 1},
+				Child:    Text{Content: "!"},
+			},
+		},
+	}
+
+	renderer, buf := portalTestRenderer(20, 5)
+	renderer.Render(widget)
+
+	assert.Equal(t, "!", buf.CellAt(7, 1).Content)
+}
+
+func TestPortal_NotClampedByDefault(t *testing.T) {
+	widget := Portal{
+		Offset: Offset{X: 8, Y: 0},
+		Child:  Text{Content: "overflow"},
+	}
+
+	renderer, buf := portalTestRenderer(10, 2)
+	renderer.Render(widget)
+
+	assert.Equal(t, "o", buf.CellAt(8, 0).Content)
+	assert.Equal(t, "v", buf.CellAt(9, 0).Content)
+
+	clamped := Portal{
+		Offset:        Offset{X: 8, Y: 0},
+		ClampToScreen: true,
+		Child:         Text{Content: "overflow"},
+	}
+	renderer, buf = portalTestRenderer(10, 2)
+	renderer.Render(clamped)
+
+	assert.Equal(t, "o", buf.CellAt(2, 0).Content)
+}
+
+func TestPortal_IgnorePointerFallsThrough(t *testing.T) {
+	widget := Column{
+		Children: []Widget{
+			Text{ID: "under", Content: "underneath"},
+			Portal{
+				Offset:        Offset{X: 0, Y: 0},
+				IgnorePointer: true,
+				Child:         Text{ID: "ghost", Content: "ghost"},
+			},
+		},
+	}
+
+	renderer, _ := portalTestRenderer(20, 3)
+	renderer.Render(widget)
+
+	assert.Nil(t, renderer.FloatAt(0, 0))
+	entry := renderer.WidgetAt(0, 0)
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, "under", entry.ID)
+	}
+	assert.Nil(t, renderer.WidgetByID("ghost"))
+}
+
+func TestPortal_DoesNotShadowFloatDismissal(t *testing.T) {
+	dismissed := false
+	widget := Column{
+		Children: []Widget{
+			Floating{
+				Visible: true,
+				Config:  FloatConfig{OnDismiss: func() { dismissed = true }},
+				Child:   Text{Content: "menu"},
+			},
+			Portal{Offset: Offset{X: 10, Y: 2}, Child: Text{Content: "badge"}},
+		},
+	}
+
+	renderer, _ := portalTestRenderer(20, 4)
+	renderer.Render(widget)
+
+	top := renderer.TopFloat()
+	if assert.NotNil(t, top) {
+		assert.False(t, top.IsPortal())
+		top.Config.OnDismiss()
+	}
+	assert.True(t, dismissed)
+}
@@ -8,6 +8,7 @@ import (
 
 	uv "github.com/charmbracelet/ultraviolet"
 	"github.com/charmbracelet/x/term"
+	"github.com/darrenburns/terma/layout"
 )
 
 // PrintOptions configures widget printing behavior.
@@ -134,6 +135,57 @@ func RenderToPlainString(widget Widget, width, height int) string {
 	return bufferToPlainText(buf, layoutWidth, layoutHeight)
 }
 
+// maxReportHeight bounds the height a report is laid out in. Widgets with
+// Flex heights expand to fill it, so reports should use Auto heights.
+const maxReportHeight = 10000
+
+// RenderReport renders a widget to an ANSI-styled string at the given
+// width, as tall as its layout needs, for printing a report, logging or
+// piping the output of a Table or Text outside the event loop. Unlike
+// RenderToString, nothing is focused, so lists and tables show no cursor.
+//
+// Example:
+//
+//	if *reportFlag {
+//	    fmt.Println(t.RenderReport(buildOrdersTable(orders), 100))
+//	    return
+//	}
+func RenderReport(widget Widget, width int) string {
+	buf, layoutWidth, layoutHeight := renderReport(widget, width)
+	return BufferToANSI(buf, layoutWidth, layoutHeight)
+}
+
+// RenderReportPlain renders a widget like RenderReport, as plain text (no
+// ANSI codes) with trailing spaces trimmed from each line.
+func RenderReportPlain(widget Widget, width int) string {
+	buf, layoutWidth, layoutHeight := renderReport(widget, width)
+	lines := strings.Split(bufferToPlainText(buf, layoutWidth, layoutHeight), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderReport lays the widget out at the given width to find its height,
+// then renders it into a buffer of exactly that size.
+func renderReport(widget Widget, width int) (buf *uv.Buffer, layoutWidth, layoutHeight int) {
+	if width <= 0 {
+		width = 80
+	}
+	focusManager := NewFocusManager()
+	focusedSignal := NewAnySignal[Focusable](nil)
+	hoveredSignal := NewAnySignal[Widget](nil)
+
+	buildCtx := NewBuildContext(focusManager, focusedSignal, hoveredSignal, NewFloatCollector())
+	tree := BuildRenderTree(widget, buildCtx, layout.Loose(width, maxReportHeight), NewFocusCollector())
+	height := max(tree.Layout.Box.BorderBoxHeight(), 1)
+
+	buf = uv.NewBuffer(width, height)
+	renderer := NewRenderer(buf, width, height, focusManager, focusedSignal, hoveredSignal)
+	layoutWidth, layoutHeight = renderer.RenderWithSize(widget)
+	return buf, layoutWidth, layoutHeight
+}
+
 // BufferToANSI converts a rendered buffer to an ANSI-styled string.
 func BufferToANSI(buf CellBuffer, width, height int) string {
 	var sb strings.Builder
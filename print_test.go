@@ -2,6 +2,7 @@ package terma
 
 import (
 	"bytes"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -321,3 +322,32 @@ func TestUvStylesEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderReportPlain_UsesLayoutHeight(t *testing.T) {
+	rows := make([][]string, 40)
+	for i := range rows {
+		rows[i] = []string{"row", strconv.Itoa(i)}
+	}
+	table := Table[[]string]{
+		State:         NewTableState(rows),
+		Columns:       []TableColumn{{Header: Text{Content: "Name"}}, {Header: Text{Content: "N"}}},
+		ColumnSpacing: 1,
+	}
+
+	lines := strings.Split(RenderReportPlain(table, 20), "\n")
+	require.Len(t, lines, 41, "every row is rendered, not just a screenful")
+	assert.Equal(t, []string{"row", "39"}, strings.Fields(lines[40]))
+	for _, line := range lines {
+		assert.Equal(t, strings.TrimRight(line, " "), line)
+	}
+}
+
+func TestRenderReport_NoCursorHighlight(t *testing.T) {
+	state := NewListState([]string{"first", "second"})
+	list := List[string]{State: state}
+
+	report := RenderReport(list, 10)
+	assert.Equal(t, "first\nsecond", RenderReportPlain(list, 10))
+	assert.NotContains(t, report, "\x1b[48;", "nothing is focused, so the cursor row has no background")
+	assert.True(t, strings.HasSuffix(report, "\x1b[0m"))
+}
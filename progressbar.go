@@ -46,6 +46,10 @@ type ProgressBar struct {
 	Style         Style // General styling (padding, margins, border)
 	FilledColor   Color // Color of filled portion (default: theme Primary)
 	UnfilledColor Color // Color of unfilled portion (default: theme Surface)
+
+	// TerminalProgress also shows Progress in the terminal's tab or taskbar
+	// (see SetTerminalProgress). Set it on at most one visible bar.
+	TerminalProgress bool
 }
 
 // Build returns itself as ProgressBar is a leaf widget.
@@ -133,6 +137,13 @@ func (p ProgressBar) Render(ctx *RenderContext) {
 		progress = 1
 	}
 
+	if p.TerminalProgress {
+		// Written at the end of this frame.
+		if seq, ok := terminalProgressUpdate(ProgressNormal, progress); ok {
+			queueTerminalOutput(seq)
+		}
+	}
+
 	// Determine colors (use theme defaults if not specified)
 	filledColor := p.FilledColor
 	if !filledColor.IsSet() {
@@ -0,0 +1,623 @@
+package terma
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// PropertyKind identifies the editor used for a Property.
+type PropertyKind int
+
+const (
+	// PropertyText is free text (string).
+	PropertyText PropertyKind = iota
+	// PropertyNumber is an integer or float; edits keep the value's Go type.
+	PropertyNumber
+	// PropertyBool is an on/off value (bool).
+	PropertyBool
+	// PropertySelect is one of a fixed set of Choices.
+	PropertySelect
+	// PropertyColor is a color, edited as a hex string (Color).
+	PropertyColor
+)
+
+// Property is one label/value row of a PropertyGrid.
+type Property struct {
+	Key      string       // Required - unique key used for lookup and change callbacks
+	Label    string       // Display name (default = Key)
+	Group    string       // Optional group heading; ungrouped rows come first
+	Kind     PropertyKind // Editor type (default = PropertyText)
+	Value    any          // Current value
+	Choices  []string     // Allowed values for PropertySelect
+	ReadOnly bool         // Display the value without an editor
+}
+
+// PropertiesOf builds properties from a struct, a pointer to a struct or a
+// map, choosing each editor from the value's type. Nested structs and maps
+// become groups with dotted keys ("Server.Port"). Values with no editor,
+// such as slices or nil pointers, are shown read-only.
+//
+// Struct fields can be tuned with a `prop` tag:
+//
+//	Name  string `prop:"Display name"`
+//	Level string `prop:",choices=debug|info|warn"`
+//	ID    int    `prop:",readonly,group=Meta"`
+//	Token string `prop:"-"`
+//
+// Map entries are listed in key order.
+func PropertiesOf(v any) []Property {
+	var props []Property
+	collectProperties(reflect.ValueOf(v), "", "", &props)
+	return props
+}
+
+func collectProperties(v reflect.Value, prefix, group string, props *[]Property) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			tag := field.Tag.Get("prop")
+			if tag == "-" {
+				continue
+			}
+			prop := Property{Key: prefix + field.Name, Label: field.Name, Group: group}
+			applyPropertyTag(&prop, tag)
+			addProperty(v.Field(i), prop, props)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			name := fmt.Sprint(key.Interface())
+			addProperty(v.MapIndex(key), Property{Key: prefix + name, Label: name, Group: group}, props)
+		}
+	}
+}
+
+// addProperty appends prop for value, or its children when value is a
+// nested struct or map.
+func addProperty(value reflect.Value, prop Property, props *[]Property) {
+	for value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
+	if isPropertyGroup(value) {
+		group := prop.Label
+		if prop.Group != "" {
+			group = prop.Group + " › " + prop.Label
+		}
+		collectProperties(value, prop.Key+".", group, props)
+		return
+	}
+	if !value.IsValid() {
+		prop.ReadOnly = true
+		*props = append(*props, prop)
+		return
+	}
+	prop.Value = value.Interface()
+	if len(prop.Choices) > 0 {
+		prop.Kind = PropertySelect
+		*props = append(*props, prop)
+		return
+	}
+	if _, ok := prop.Value.(Color); ok {
+		prop.Kind = PropertyColor
+		*props = append(*props, prop)
+		return
+	}
+	switch value.Kind() {
+	case reflect.String:
+		prop.Kind = PropertyText
+	case reflect.Bool:
+		prop.Kind = PropertyBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		prop.Kind = PropertyNumber
+	default:
+		prop.ReadOnly = true
+	}
+	*props = append(*props, prop)
+}
+
+// isPropertyGroup reports whether value is shown as a group of properties
+// rather than a single row.
+func isPropertyGroup(value reflect.Value) bool {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return false
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Struct:
+		// Structs with their own string form (time.Time, Color) are values.
+		if value.Type() == reflect.TypeOf(Color{}) {
+			return false
+		}
+		_, isStringer := value.Interface().(fmt.Stringer)
+		return !isStringer
+	}
+	return false
+}
+
+// applyPropertyTag applies a `prop:"label,readonly,group=...,choices=a|b"` tag.
+func applyPropertyTag(prop *Property, tag string) {
+	if tag == "" {
+		return
+	}
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		prop.Label = parts[0]
+	}
+	for _, part := range parts[1:] {
+		name, value, _ := strings.Cut(part, "=")
+		switch name {
+		case "readonly":
+			prop.ReadOnly = true
+		case "group":
+			prop.Group = value
+		case "choices":
+			prop.Choices = strings.Split(value, "|")
+		}
+	}
+}
+
+// PropertyGridState holds the properties and editor state for a
+// PropertyGrid widget.
+type PropertyGridState struct {
+	Properties AnySignal[[]Property]
+
+	errors AnySignal[map[string]string]
+	checks map[string]*CheckboxState
+	inputs map[string]*TextInputState
+	scroll *ScrollState
+}
+
+// NewPropertyGridState creates a new PropertyGridState with the given properties.
+func NewPropertyGridState(props []Property) *PropertyGridState {
+	s := &PropertyGridState{
+		errors: NewAnySignal(map[string]string{}),
+		checks: map[string]*CheckboxState{},
+		inputs: map[string]*TextInputState{},
+		scroll: NewScrollState(),
+	}
+	s.Properties = NewAnySignal(props)
+	s.syncEditors(props)
+	return s
+}
+
+// SetProperties replaces the properties, e.g. after re-inspecting a value.
+func (s *PropertyGridState) SetProperties(props []Property) {
+	s.Properties.Set(props)
+	s.errors.Set(map[string]string{})
+	s.syncEditors(props)
+}
+
+// Property returns the property with the given key.
+func (s *PropertyGridState) Property(key string) (Property, bool) {
+	for _, prop := range s.Properties.Peek() {
+		if prop.Key == key {
+			return prop, true
+		}
+	}
+	return Property{}, false
+}
+
+// Value returns the current value of key, or nil if there is no such property.
+func (s *PropertyGridState) Value(key string) any {
+	prop, _ := s.Property(key)
+	return prop.Value
+}
+
+// Error returns the last edit error for key, or "" if none.
+func (s *PropertyGridState) Error(key string) string {
+	return s.errors.Get()[key]
+}
+
+// Set validates and stores a new value for key. The value must have the
+// same Go type as the current one; read-only properties cannot be set.
+func (s *PropertyGridState) Set(key string, value any) error {
+	prop, ok := s.Property(key)
+	if !ok {
+		return fmt.Errorf("unknown property %q", key)
+	}
+	if prop.ReadOnly {
+		return fmt.Errorf("property %q is read-only", key)
+	}
+	if err := validatePropertyValue(prop, value); err != nil {
+		s.setError(key, err.Error())
+		return fmt.Errorf("property %q: %w", key, err)
+	}
+	s.setError(key, "")
+	s.Properties.Update(func(props []Property) []Property {
+		next := make([]Property, len(props))
+		copy(next, props)
+		for i := range next {
+			if next[i].Key == key {
+				next[i].Value = value
+			}
+		}
+		return next
+	})
+	prop.Value = value
+	s.syncEditors([]Property{prop})
+	return nil
+}
+
+func (s *PropertyGridState) setError(key, message string) {
+	s.errors.Update(func(errs map[string]string) map[string]string {
+		if errs[key] == message {
+			return errs
+		}
+		next := maps.Clone(errs)
+		if message == "" {
+			delete(next, key)
+		} else {
+			next[key] = message
+		}
+		return next
+	})
+}
+
+// syncEditors updates the widget state backing each property to match its value.
+func (s *PropertyGridState) syncEditors(props []Property) {
+	for _, prop := range props {
+		if prop.ReadOnly {
+			continue
+		}
+		switch prop.Kind {
+		case PropertyBool:
+			checked, _ := prop.Value.(bool)
+			if check, ok := s.checks[prop.Key]; ok {
+				check.SetChecked(checked)
+			} else {
+				s.checks[prop.Key] = NewCheckboxState(checked)
+			}
+		case PropertyText, PropertyNumber, PropertyColor:
+			text := formatPropertyValue(prop.Value)
+			if input, ok := s.inputs[prop.Key]; ok {
+				input.SetText(text)
+			} else {
+				s.inputs[prop.Key] = NewTextInputState(text)
+			}
+		}
+	}
+}
+
+// submitText parses text typed into a property's editor and applies it.
+// It returns the applied value and whether the edit succeeded.
+func (s *PropertyGridState) submitText(prop Property, text string) (any, bool) {
+	value, err := parsePropertyText(prop, text)
+	if err != nil {
+		s.setError(prop.Key, err.Error())
+		return nil, false
+	}
+	if reflect.DeepEqual(value, s.Value(prop.Key)) {
+		s.setError(prop.Key, "")
+		return nil, false
+	}
+	return value, s.Set(prop.Key, value) == nil
+}
+
+// cycleChoice advances a select property to its next choice.
+func (s *PropertyGridState) cycleChoice(prop Property) (any, bool) {
+	if len(prop.Choices) == 0 {
+		return nil, false
+	}
+	current := formatPropertyValue(s.Value(prop.Key))
+	next := prop.Choices[0]
+	for i, choice := range prop.Choices {
+		if choice == current {
+			next = prop.Choices[(i+1)%len(prop.Choices)]
+			break
+		}
+	}
+	value, err := parsePropertyText(prop, next)
+	if err != nil {
+		return nil, false
+	}
+	return value, s.Set(prop.Key, value) == nil
+}
+
+// validatePropertyValue checks that value fits prop's kind and Go type.
+func validatePropertyValue(prop Property, value any) error {
+	if prop.Value != nil && reflect.TypeOf(value) != reflect.TypeOf(prop.Value) {
+		return fmt.Errorf("expected %T", prop.Value)
+	}
+	switch prop.Kind {
+	case PropertyBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected true or false")
+		}
+	case PropertySelect:
+		text := formatPropertyValue(value)
+		for _, choice := range prop.Choices {
+			if choice == text {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected one of %s", strings.Join(prop.Choices, ", "))
+	case PropertyColor:
+		if color, ok := value.(Color); !ok || !color.IsSet() {
+			return fmt.Errorf("expected a hex color such as #ff8800")
+		}
+	}
+	return nil
+}
+
+// parsePropertyText converts editor text into a value of the same Go type
+// as prop's current value.
+func parsePropertyText(prop Property, text string) (any, error) {
+	if prop.Kind == PropertyColor {
+		return parseHexColor(strings.TrimSpace(text))
+	}
+	if prop.Value == nil {
+		if prop.Kind != PropertyNumber {
+			return text, nil
+		}
+		text = strings.TrimSpace(text)
+		if n, err := strconv.Atoi(text); err == nil {
+			return n, nil
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number")
+		}
+		return f, nil
+	}
+
+	current := reflect.ValueOf(prop.Value)
+	value := reflect.New(current.Type()).Elem()
+	switch current.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(text))
+		if err != nil {
+			return nil, fmt.Errorf("expected true or false")
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(text), 10, current.Type().Bits())
+		if err != nil {
+			return nil, fmt.Errorf("expected a whole number")
+		}
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(text), 10, current.Type().Bits())
+		if err != nil {
+			return nil, fmt.Errorf("expected a positive whole number")
+		}
+		value.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(text), current.Type().Bits())
+		if err != nil {
+			return nil, fmt.Errorf("expected a number")
+		}
+		value.SetFloat(f)
+	default:
+		return nil, fmt.Errorf("cannot edit %T", prop.Value)
+	}
+	return value.Interface(), nil
+}
+
+// formatPropertyValue returns the display and editor text for value.
+func formatPropertyValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "<nil>"
+	case Color:
+		return v.Hex()
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// PropertyGrid renders properties as aligned label/value rows with an
+// editor chosen by each property's kind: text and numbers are text inputs
+// applied on Enter or blur, bools are checkboxes, selects cycle through
+// their choices on press and colors show a swatch next to a hex input.
+// Read-only properties show their value as text.
+//
+// Example:
+//
+//	state := terma.NewPropertyGridState(terma.PropertiesOf(request))
+//	terma.PropertyGrid{
+//	    ID:    "inspector",
+//	    State: state,
+//	    OnChange: func(key string, value any) {
+//	        log.Printf("%s = %v", key, value)
+//	    },
+//	}
+type PropertyGrid struct {
+	ID         string                      // Optional unique identifier (prefix for child IDs, default "property-grid")
+	State      *PropertyGridState          // Required - holds the properties
+	OnChange   func(key string, value any) // Optional callback invoked after an edit is applied
+	LabelWidth int                         // Width of the label column (default: widest label + 2)
+	Style      Style                       // Optional styling
+}
+
+// WidgetID returns the property grid's unique identifier.
+func (g PropertyGrid) WidgetID() string {
+	return g.ID
+}
+
+// GetStyle returns the style.
+func (g PropertyGrid) GetStyle() Style {
+	return g.Style
+}
+
+func (g PropertyGrid) childID(parts ...string) string {
+	id := g.ID
+	if id == "" {
+		id = "property-grid"
+	}
+	return id + "-" + strings.Join(parts, "-")
+}
+
+// Build returns the grouped property rows in a scrollable column.
+func (g PropertyGrid) Build(ctx BuildContext) Widget {
+	if g.State == nil {
+		return EmptyWidget{}
+	}
+	theme := ctx.Theme()
+	props := g.State.Properties.Get()
+
+	labelWidth := g.LabelWidth
+	if labelWidth <= 0 {
+		for _, prop := range props {
+			labelWidth = max(labelWidth, ansi.StringWidth(propertyLabel(prop)))
+		}
+		labelWidth += 2
+	}
+
+	// Ungrouped rows first, then groups in order of first appearance.
+	var groups []string
+	byGroup := map[string][]Property{}
+	for _, prop := range props {
+		if _, seen := byGroup[prop.Group]; !seen && prop.Group != "" {
+			groups = append(groups, prop.Group)
+		}
+		byGroup[prop.Group] = append(byGroup[prop.Group], prop)
+	}
+
+	var rows []Widget
+	for _, prop := range byGroup[""] {
+		rows = append(rows, g.buildProperty(ctx, prop, labelWidth))
+	}
+	for _, group := range groups {
+		rows = append(rows, Text{
+			Content: group,
+			Style:   Style{Bold: true, ForegroundColor: theme.Primary},
+		})
+		for _, prop := range byGroup[group] {
+			rows = append(rows, g.buildProperty(ctx, prop, labelWidth))
+		}
+	}
+
+	style := g.Style
+	if style.Width.IsUnset() {
+		style.Width = Flex(1)
+	}
+	if style.Height.IsUnset() {
+		style.Height = Flex(1)
+	}
+
+	return Scrollable{
+		ID:    g.childID("scroll"),
+		State: g.State.scroll,
+		Style: style,
+		Child: Column{Style: Style{Width: Flex(1)}, Children: rows},
+	}
+}
+
+func propertyLabel(prop Property) string {
+	if prop.Label != "" {
+		return prop.Label
+	}
+	return prop.Key
+}
+
+// buildProperty returns the row for a single property.
+func (g PropertyGrid) buildProperty(ctx BuildContext, prop Property, labelWidth int) Widget {
+	theme := ctx.Theme()
+
+	value := g.buildEditor(ctx, prop)
+	if msg := g.State.Error(prop.Key); msg != "" {
+		value = Column{Children: []Widget{
+			value,
+			Text{Content: msg, Style: Style{ForegroundColor: theme.Error}},
+		}}
+	}
+
+	return Row{
+		Style: Style{Width: Flex(1), Padding: EdgeInsets{Left: 1, Right: 1}},
+		Children: []Widget{
+			Text{
+				Content:  propertyLabel(prop),
+				Truncate: TruncateEnd,
+				Style:    Style{Width: Cells(labelWidth), ForegroundColor: theme.TextMuted},
+			},
+			value,
+		},
+	}
+}
+
+// buildEditor returns the editor widget, or plain text for read-only values.
+func (g PropertyGrid) buildEditor(ctx BuildContext, prop Property) Widget {
+	theme := ctx.Theme()
+	state := g.State
+	id := g.childID(prop.Key)
+	applied := func(value any, ok bool) {
+		if ok && g.OnChange != nil {
+			g.OnChange(prop.Key, value)
+		}
+	}
+
+	if prop.ReadOnly {
+		text := Text{Content: formatPropertyValue(prop.Value), Style: Style{Width: Flex(1)}, Truncate: TruncateEnd}
+		if color, ok := prop.Value.(Color); ok {
+			return Row{Spacing: 1, Children: []Widget{
+				Text{Content: "  ", Style: Style{BackgroundColor: color}},
+				text,
+			}}
+		}
+		return text
+	}
+
+	switch prop.Kind {
+	case PropertyBool:
+		return &Checkbox{
+			ID:    id,
+			State: state.checks[prop.Key],
+			OnChange: func(checked bool) {
+				applied(checked, state.Set(prop.Key, checked) == nil)
+			},
+		}
+	case PropertySelect:
+		return Button{
+			ID:      id,
+			Label:   formatPropertyValue(prop.Value) + " ▾",
+			OnPress: func() { applied(state.cycleChoice(prop)) },
+		}
+	default:
+		input := TextInput{
+			ID:       id,
+			State:    state.inputs[prop.Key],
+			OnSubmit: func(text string) { applied(state.submitText(prop, text)) },
+			Blur:     func() { applied(state.submitText(prop, state.inputs[prop.Key].GetText())) },
+			Style:    Style{Width: Flex(1), BackgroundColor: theme.Surface},
+		}
+		if prop.Kind != PropertyColor {
+			return input
+		}
+		color, _ := prop.Value.(Color)
+		return Row{Style: Style{Width: Flex(1)}, Spacing: 1, Children: []Widget{
+			Text{Content: "  ", Style: Style{BackgroundColor: color}},
+			input,
+		}}
+	}
+}
@@ -0,0 +1,117 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEndpoint struct {
+	Name    string `prop:"Display name"`
+	Method  string `prop:",choices=GET|POST|PUT"`
+	Timeout float64
+	Retries uint8
+	Verbose bool
+	Accent  Color
+	Created time.Time `prop:",readonly"`
+	Tags    []string
+	Headers map[string]string
+	token   string
+	Secret  string `prop:"-"`
+}
+
+func TestPropertiesOf_Struct(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	props := PropertiesOf(&testEndpoint{
+		Name:    "users",
+		Method:  "GET",
+		Timeout: 2.5,
+		Retries: 3,
+		Accent:  Hex("#ff8800"),
+		Created: created,
+		Tags:    []string{"a"},
+		Headers: map[string]string{"b": "2", "a": "1"},
+	})
+
+	var keys []string
+	byKey := map[string]Property{}
+	for _, prop := range props {
+		keys = append(keys, prop.Key)
+		byKey[prop.Key] = prop
+	}
+	assert.Equal(t, []string{"Name", "Method", "Timeout", "Retries", "Verbose", "Accent", "Created", "Tags", "Headers.a", "Headers.b"}, keys)
+
+	assert.Equal(t, "Display name", byKey["Name"].Label)
+	assert.Equal(t, PropertyText, byKey["Name"].Kind)
+	assert.Equal(t, PropertySelect, byKey["Method"].Kind)
+	assert.Equal(t, []string{"GET", "POST", "PUT"}, byKey["Method"].Choices)
+	assert.Equal(t, PropertyNumber, byKey["Timeout"].Kind)
+	assert.Equal(t, PropertyNumber, byKey["Retries"].Kind)
+	assert.Equal(t, PropertyBool, byKey["Verbose"].Kind)
+	assert.Equal(t, PropertyColor, byKey["Accent"].Kind)
+	assert.True(t, byKey["Created"].ReadOnly)
+	assert.Equal(t, created, byKey["Created"].Value)
+	assert.True(t, byKey["Tags"].ReadOnly)
+	assert.Equal(t, "Headers", byKey["Headers.a"].Group)
+	assert.Equal(t, "1", byKey["Headers.a"].Value)
+}
+
+func TestPropertyGridState_SetKeepsTypes(t *testing.T) {
+	state := NewPropertyGridState(PropertiesOf(testEndpoint{Method: "GET", Retries: 3, Timeout: 1}))
+
+	prop, _ := state.Property("Retries")
+	value, ok := state.submitText(prop, " 7 ")
+	require.True(t, ok)
+	assert.Equal(t, uint8(7), value)
+	assert.Equal(t, uint8(7), state.Value("Retries"))
+
+	_, ok = state.submitText(prop, "-1")
+	assert.False(t, ok)
+	assert.Equal(t, "expected a positive whole number", state.Error("Retries"))
+	assert.Equal(t, uint8(7), state.Value("Retries"))
+
+	prop, _ = state.Property("Timeout")
+	_, ok = state.submitText(prop, "0.25")
+	require.True(t, ok)
+	assert.Equal(t, 0.25, state.Value("Timeout"))
+
+	prop, _ = state.Property("Method")
+	value, ok = state.cycleChoice(prop)
+	require.True(t, ok)
+	assert.Equal(t, "POST", value)
+
+	assert.Error(t, state.Set("Verbose", "yes"))
+	assert.Error(t, state.Set("Method", "DELETE"))
+	assert.Error(t, state.Set("Created", time.Now()), "read-only properties cannot be set")
+	require.NoError(t, state.Set("Verbose", true))
+	assert.True(t, state.checks["Verbose"].IsChecked(), "editors follow Set")
+}
+
+func TestPropertyGrid_RendersGroupsAndEditors(t *testing.T) {
+	var changed []string
+	state := NewPropertyGridState([]Property{
+		{Key: "host", Value: "localhost"},
+		{Key: "port", Label: "Port", Group: "Network", Kind: PropertyNumber, Value: 8080},
+		{Key: "tls", Label: "TLS", Group: "Network", Kind: PropertyBool, Value: true},
+		{Key: "id", Label: "ID", ReadOnly: true, Value: 42},
+	})
+	grid := PropertyGrid{
+		State:    state,
+		OnChange: func(key string, value any) { changed = append(changed, key) },
+	}
+
+	lines := strings.Split(screenText(grid, 30, 6), "\n")
+	assert.Regexp(t, `^ host  localhost`, lines[0])
+	assert.Regexp(t, `^ ID    42`, lines[1])
+	assert.Regexp(t, `^Network`, lines[2])
+	assert.Regexp(t, `^ Port  8080`, lines[3])
+	assert.Regexp(t, `^ TLS   ☑`, lines[4])
+
+	prop, _ := state.Property("port")
+	grid.buildEditor(newTestBuildContext(), prop).(TextInput).OnSubmit("9090")
+	assert.Equal(t, []string{"port"}, changed)
+	assert.Equal(t, 9090, state.Value("port"))
+}
@@ -0,0 +1,348 @@
+package terma
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// RefreshOptions configures RefreshList and RefreshTable.
+type RefreshOptions struct {
+	Interval    time.Duration // Time between refreshes of each item (default 5s)
+	Jitter      float64       // Fraction of each wait varied at random, so items don't refresh in lockstep (default 0.1; negative disables)
+	MaxBackoff  time.Duration // Longest wait after repeated failures, which double the wait each time (default 1m)
+	Concurrency int           // Fetches running at once (default 4)
+}
+
+// Refresher re-fetches the items of a ListState or TableState one at a
+// time, each on its own schedule, for dashboards whose rows each come from
+// their own request, such as a list of services and their health checks.
+//
+// Only items on screen are fetched: those inside the viewport of the
+// List's or Table's ScrollState, or all of them without one. An item that
+// comes into view after its refresh fell due is fetched straight away. A
+// List or Table that wasn't in the last frame, such as one on a hidden
+// tab, has no items on screen, so its refresher idles until it is shown
+// again.
+//
+// A failed fetch keeps the item as it was, and the item waits twice as
+// long before each further attempt, up to MaxBackoff. Err reports the
+// failure until a fetch succeeds.
+//
+// Example:
+//
+//	a.services.KeyFor = func(s Service) string { return s.Name }
+//	refresher := t.RefreshTable(ctx, a.services, func(ctx context.Context, s Service) (Service, error) {
+//		return client.Health(ctx, s.Name)
+//	}, t.RefreshOptions{Interval: 10 * time.Second})
+//	defer refresher.Stop()
+type Refresher[T any] struct {
+	fetch    func(ctx context.Context, item T) (T, error)
+	items    func() []T
+	replace  func(key string, item T)
+	keyFor   func(T) string
+	onScreen *onScreenItems
+	options  RefreshOptions
+
+	errs AnySignal[map[string]error] // Error of each item whose last fetch failed
+
+	mu       sync.Mutex
+	entries  map[string]*refreshEntry // Schedule of each item seen on screen
+	started  time.Time
+	paused   bool
+	inFlight int
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	ticker   Timer
+	fetches  sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// refreshEntry is the schedule of one item.
+type refreshEntry struct {
+	due      time.Time
+	failures int // Consecutive failed fetches
+	fetching bool
+}
+
+// RefreshList starts refreshing the items of state on screen with fetch,
+// until ctx is cancelled or Stop is called. Fetched items replace the
+// items with the same key, so state.KeyFor must be set.
+func RefreshList[T any](ctx context.Context, state *ListState[T], fetch func(ctx context.Context, item T) (T, error), options RefreshOptions) *Refresher[T] {
+	if state.KeyFor == nil {
+		panic("terma: RefreshList requires ListState.KeyFor")
+	}
+	return startRefresher(ctx, state.Items.Peek, func(key string, item T) {
+		state.Items.Update(func(items []T) []T {
+			return replaceKeyed(items, state.KeyFor, key, item)
+		})
+		state.resetFilterCache()
+	}, state.KeyFor, &state.onScreen, fetch, options)
+}
+
+// RefreshTable starts refreshing the rows of state on screen with fetch,
+// until ctx is cancelled or Stop is called. Fetched rows replace the rows
+// with the same key, so state.KeyFor must be set.
+func RefreshTable[T any](ctx context.Context, state *TableState[T], fetch func(ctx context.Context, row T) (T, error), options RefreshOptions) *Refresher[T] {
+	if state.KeyFor == nil {
+		panic("terma: RefreshTable requires TableState.KeyFor")
+	}
+	return startRefresher(ctx, state.Rows.Peek, func(key string, row T) {
+		state.Rows.Update(func(rows []T) []T {
+			return replaceKeyed(rows, state.KeyFor, key, row)
+		})
+	}, state.KeyFor, &state.onScreen, fetch, options)
+}
+
+func startRefresher[T any](ctx context.Context, items func() []T, replace func(key string, item T), keyFor func(T) string, onScreen *onScreenItems, fetch func(ctx context.Context, item T) (T, error), options RefreshOptions) *Refresher[T] {
+	if options.Interval <= 0 {
+		options.Interval = 5 * time.Second
+	}
+	if options.Jitter == 0 {
+		options.Jitter = 0.1
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = time.Minute
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = 4
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	r := &Refresher[T]{
+		fetch:    fetch,
+		items:    items,
+		replace:  replace,
+		keyFor:   keyFor,
+		onScreen: onScreen,
+		options:  options,
+		errs:     NewAnySignal(map[string]error{}),
+		entries:  map[string]*refreshEntry{},
+		started:  Now(),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	tick := min(max(options.Interval/4, 10*time.Millisecond), time.Second)
+	r.ticker = currentClock().Every(tick, r.tick)
+	Go(func() {
+		<-ctx.Done()
+		r.ticker.Stop()
+	})
+	return r
+}
+
+// Stop ends refreshing and waits for fetches in progress to return. It is
+// safe to call more than once.
+func (r *Refresher[T]) Stop() {
+	r.stopOnce.Do(func() {
+		r.cancel()
+		r.ticker.Stop()
+	})
+	r.fetches.Wait()
+}
+
+// Pause stops starting fetches until Resume is called, for example while
+// the user edits a row. Fetches in progress still finish.
+func (r *Refresher[T]) Pause() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paused = true
+}
+
+// Resume starts fetching again after Pause. Items that fell due while
+// paused are fetched straight away.
+func (r *Refresher[T]) Resume() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paused = false
+}
+
+// Paused reports whether Pause was called without Resume.
+func (r *Refresher[T]) Paused() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.paused
+}
+
+// Err returns the error of the last fetch of item, or nil if it succeeded
+// or hasn't run. Calling it during Build subscribes to changes, so a row
+// can show that its data is stale.
+func (r *Refresher[T]) Err(item T) error {
+	return r.errs.Get()[r.keyFor(item)]
+}
+
+// tick starts fetches for the items on screen that are due.
+func (r *Refresher[T]) tick(now time.Time) {
+	if r.ctx.Err() != nil {
+		return
+	}
+	items := r.items()
+	visible := r.onScreen.current()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prune(items)
+	if r.paused {
+		return
+	}
+	for _, idx := range visible {
+		if r.inFlight >= r.options.Concurrency {
+			return
+		}
+		if idx < 0 || idx >= len(items) {
+			continue
+		}
+		item := items[idx]
+		key := r.keyFor(item)
+		entry := r.entries[key]
+		if entry == nil {
+			entry = &refreshEntry{due: r.started.Add(r.wait(0))}
+			r.entries[key] = entry
+		}
+		if entry.fetching || now.Before(entry.due) {
+			continue
+		}
+		entry.fetching = true
+		r.inFlight++
+		r.fetches.Add(1)
+		Go(func() {
+			defer r.fetches.Done()
+			r.run(key, item)
+		})
+	}
+}
+
+// run fetches one item and schedules its next refresh.
+func (r *Refresher[T]) run(key string, item T) {
+	fetched, err := r.fetch(r.ctx, item)
+	if r.ctx.Err() != nil {
+		return
+	}
+	if err == nil {
+		// Widgets read the state's caches while building, so the state is
+		// only changed on the event loop.
+		runOnUI(func() { r.replace(key, fetched) })
+	}
+
+	r.mu.Lock()
+	r.inFlight--
+	failed := false
+	if entry := r.entries[key]; entry != nil {
+		entry.fetching = false
+		if err != nil {
+			entry.failures++
+		} else {
+			entry.failures = 0
+		}
+		entry.due = Now().Add(r.wait(entry.failures))
+		failed = entry.failures > 0
+	}
+	r.mu.Unlock()
+
+	if failed || r.errs.Peek()[key] != nil {
+		r.errs.Update(func(errs map[string]error) map[string]error {
+			next := make(map[string]error, len(errs)+1)
+			for k, e := range errs {
+				next[k] = e
+			}
+			if err != nil {
+				next[key] = err
+			} else {
+				delete(next, key)
+			}
+			return next
+		})
+	}
+}
+
+// wait returns how long an item waits before its next fetch after the
+// given number of consecutive failures, with jitter.
+func (r *Refresher[T]) wait(failures int) time.Duration {
+	wait := r.options.Interval
+	for i := 0; i < failures && wait < r.options.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if failures > 0 {
+		wait = min(wait, r.options.MaxBackoff)
+	}
+	if r.options.Jitter > 0 {
+		wait = time.Duration(float64(wait) * (1 + r.options.Jitter*(2*rand.Float64()-1)))
+	}
+	return wait
+}
+
+// prune forgets the schedules of items that are gone. Must be called with
+// r.mu held.
+func (r *Refresher[T]) prune(items []T) {
+	if len(r.entries) <= len(items) {
+		return
+	}
+	present := make(map[string]bool, len(items))
+	for _, item := range items {
+		present[r.keyFor(item)] = true
+	}
+	for key, entry := range r.entries {
+		if !present[key] && !entry.fetching {
+			delete(r.entries, key)
+		}
+	}
+}
+
+// replaceKeyed returns items with the item that has the given key
+// replaced, or items unchanged if there is none.
+func replaceKeyed[T any](items []T, keyFor func(T) string, key string, item T) []T {
+	for i, current := range items {
+		if keyFor(current) == key {
+			next := append([]T(nil), items...)
+			next[i] = item
+			return next
+		}
+	}
+	return items
+}
+
+// onScreenItems records which items a List or Table showed in the last
+// frame it was rendered in, for Refresher.
+type onScreenItems struct {
+	mu      sync.Mutex
+	frame   frameStamp
+	indices []int // Source indices
+}
+
+// record remembers the items shown in the frame being rendered.
+func (o *onScreenItems) record(frame frameStamp, indices []int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.frame = frame
+	o.indices = indices
+}
+
+// current returns the items shown, or nil if the widget wasn't in its
+// renderer's last frame.
+func (o *onScreenItems) current() []int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !o.frame.current() {
+		return nil
+	}
+	return o.indices
+}
+
+// onScreenIndices returns the source indices of the items in view whose
+// layouts overlap the scroll viewport, or of all of them without one.
+// layout returns the y and height of the item at a view index.
+func onScreenIndices(view []int, count int, layout func(viewIdx int) (y, height int), scroll *ScrollState) []int {
+	indices := make([]int, 0, min(count, len(view)))
+	for viewIdx := 0; viewIdx < count && viewIdx < len(view); viewIdx++ {
+		if scroll != nil && scroll.viewportHeight > 0 {
+			y, height := layout(viewIdx)
+			offset := scroll.GetOffset()
+			if y+height <= offset || y >= offset+scroll.viewportHeight {
+				continue
+			}
+		}
+		indices = append(indices, view[viewIdx])
+	}
+	return indices
+}
@@ -0,0 +1,125 @@
+package terma
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// refresherFixture is a scrolled table of ten services showing three at a
+// time, refreshed every second by a fetch that counts its calls.
+type refresherFixture struct {
+	clock     *ManualClock
+	state     *TableState[keyedRow]
+	widget    *scrollableTableTestWidget
+	refresher *Refresher[keyedRow]
+
+	mu      sync.Mutex
+	fetched []string
+	failing map[string]bool
+}
+
+func newRefresherFixture(t *testing.T) *refresherFixture {
+	t.Helper()
+	f := &refresherFixture{
+		clock:   NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		failing: map[string]bool{},
+	}
+	SetClock(f.clock)
+	t.Cleanup(func() { SetClock(nil) })
+
+	rows := make([]keyedRow, 10)
+	for i := range rows {
+		rows[i] = keyedRow{ID: fmt.Sprintf("svc %d", i)}
+	}
+	f.state = NewTableState(rows)
+	f.state.KeyFor = func(row keyedRow) string { return row.ID }
+	f.widget = &scrollableTableTestWidget{tableState: f.state, scrollState: NewScrollState()}
+	f.refresher = RefreshTable(context.Background(), f.state, func(ctx context.Context, row keyedRow) (keyedRow, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.fetched = append(f.fetched, row.ID)
+		if f.failing[row.ID] {
+			return row, errors.New("unreachable")
+		}
+		row.Value++
+		return row, nil
+	}, RefreshOptions{Interval: time.Second, Jitter: -1, Concurrency: 5})
+	t.Cleanup(f.refresher.Stop)
+	return f
+}
+
+// advance moves the clock and waits for the fetches it started.
+func (f *refresherFixture) advance(d time.Duration) []string {
+	f.clock.Advance(d)
+	f.refresher.fetches.Wait()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fetched := f.fetched
+	f.fetched = nil
+	return fetched
+}
+
+func TestRefresher_FetchesOnlyRowsOnScreen(t *testing.T) {
+	f := newRefresherFixture(t)
+	RenderToBuffer(f.widget, 20, 5)
+
+	assert.Empty(t, f.advance(500*time.Millisecond))
+	assert.ElementsMatch(t, []string{"svc 0", "svc 1", "svc 2", "svc 3", "svc 4"}, f.advance(500*time.Millisecond))
+	assert.Equal(t, 1, f.state.GetRows()[0].Value)
+	assert.Equal(t, 0, f.state.GetRows()[5].Value)
+
+	f.state.SelectIndex(9)
+	RenderToBuffer(f.widget, 20, 5)
+	require.Equal(t, 5, f.widget.scrollState.GetOffset())
+	assert.ElementsMatch(t, []string{"svc 5", "svc 6", "svc 7", "svc 8", "svc 9"}, f.advance(250*time.Millisecond),
+		"rows that fell due offscreen are fetched once they're shown")
+}
+
+func TestRefresher_BacksOffAfterErrors(t *testing.T) {
+	f := newRefresherFixture(t)
+	f.failing["svc 1"] = true
+	RenderToBuffer(f.widget, 20, 5)
+
+	assert.Contains(t, f.advance(time.Second), "svc 1")
+	assert.EqualError(t, f.refresher.Err(keyedRow{ID: "svc 1"}), "unreachable")
+	assert.NoError(t, f.refresher.Err(keyedRow{ID: "svc 0"}))
+	assert.Equal(t, 0, f.state.GetRows()[1].Value, "a failed fetch keeps the row")
+
+	assert.NotContains(t, f.advance(time.Second), "svc 1")
+	assert.Contains(t, f.advance(time.Second), "svc 1", "the retry waits twice the interval")
+
+	f.mu.Lock()
+	f.failing["svc 1"] = false
+	f.mu.Unlock()
+	assert.NotContains(t, f.advance(3*time.Second), "svc 1")
+	assert.Contains(t, f.advance(time.Second), "svc 1")
+	assert.NoError(t, f.refresher.Err(keyedRow{ID: "svc 1"}))
+	assert.Equal(t, 1, f.state.GetRows()[1].Value)
+}
+
+func TestRefresher_IdlesWhileHiddenOrPaused(t *testing.T) {
+	f := newRefresherFixture(t)
+	renderer, _ := portalTestRenderer(20, 5)
+	renderer.Render(f.widget)
+	renderer.Render(Text{Content: "another tab"})
+
+	assert.Empty(t, f.advance(2*time.Second), "the table isn't on screen")
+
+	renderer.Render(f.widget)
+	RenderToBuffer(Text{Content: "a report"}, 20, 5)
+	assert.Len(t, f.advance(250*time.Millisecond), 5, "rendering elsewhere doesn't hide the table")
+
+	f.refresher.Pause()
+	assert.Empty(t, f.advance(time.Second))
+	require.True(t, f.refresher.Paused())
+
+	f.refresher.Resume()
+	assert.Len(t, f.advance(250*time.Millisecond), 5, "rows that fell due while paused are fetched")
+}
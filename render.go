@@ -3,6 +3,7 @@ package terma
 import (
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"github.com/darrenburns/terma/layout"
 
@@ -13,6 +14,8 @@ import (
 // CellBuffer is the interface for cell-based rendering.
 // Both *uv.Terminal and *uv.Buffer satisfy this interface.
 type CellBuffer interface {
+	// SetCell copies *c into the buffer at (x, y). Callers reuse c for the
+	// next cell, so implementations must not keep the pointer.
 	SetCell(x, y int, c *uv.Cell)
 	CellAt(x, y int) *uv.Cell
 }
@@ -25,7 +28,7 @@ func blendForeground(fg, bg Color) Color {
 	}
 	blendTarget := bg
 	if !blendTarget.IsSet() {
-		blendTarget = Black
+		blendTarget = terminalBackground()
 	}
 	return fg.BlendOver(blendTarget)
 }
@@ -58,6 +61,8 @@ type RenderContext struct {
 	Width, Height int
 	// Clip rect in absolute screen coordinates - all drawing is clipped to this rect
 	clip Rect
+	// Clip rects saved by PushClip, restored by PopClip
+	clipStack []Rect
 	// Focus collector for gathering focusable widgets
 	focusCollector *FocusCollector
 	// Focus manager for checking focus state
@@ -74,6 +79,13 @@ type RenderContext struct {
 	// Takes both X and Y to support arbitrary-angle gradients.
 	// Nil means no inherited background (use terminal default).
 	inheritedBgAt func(absX, absY int) Color
+	// focusRingClip is where a focus ring around a child may be drawn: the
+	// parent's area inside its border, so the ring can use its padding.
+	// Empty means the clip rect.
+	focusRingClip Rect
+	// cell is the scratch cell every draw is written through, shared by a
+	// root context and all of its subcontexts. Nil until first used.
+	cell *uv.Cell
 }
 
 // NewRenderContext creates a root render context for the terminal.
@@ -89,9 +101,21 @@ func NewRenderContext(terminal CellBuffer, width, height int, fc *FocusCollector
 		focusManager:   fm,
 		buildContext:   bc,
 		widgetRegistry: wr,
+		cell:           new(uv.Cell),
 	}
 }
 
+// setCell writes cell at absolute (x, y) through the context's scratch
+// cell. CellBuffer copies what it is given, so one cell serves every write
+// rather than allocating a new one for each cell drawn.
+func (ctx *RenderContext) setCell(x, y int, cell uv.Cell) {
+	if ctx.cell == nil {
+		ctx.cell = new(uv.Cell)
+	}
+	*ctx.cell = cell
+	ctx.terminal.SetCell(x, y, ctx.cell)
+}
+
 // IsVisible returns whether a point is within the clip rect.
 func (ctx *RenderContext) IsVisible(absX, absY int) bool {
 	return ctx.clip.Contains(absX, absY)
@@ -102,6 +126,23 @@ func (ctx *RenderContext) ClipBounds() Rect {
 	return ctx.clip
 }
 
+// PushClip narrows drawing to the given rectangle, relative to this
+// context, until the matching PopClip. The new clip is the intersection
+// with the current one, so pushes nest.
+func (ctx *RenderContext) PushClip(x, y, width, height int) {
+	ctx.clipStack = append(ctx.clipStack, ctx.clip)
+	ctx.clip = ctx.clip.Intersect(Rect{X: ctx.X + x, Y: ctx.Y + y, Width: max(0, width), Height: max(0, height)})
+}
+
+// PopClip restores the clip rect in place before the last PushClip.
+func (ctx *RenderContext) PopClip() {
+	if len(ctx.clipStack) == 0 {
+		return
+	}
+	ctx.clip = ctx.clipStack[len(ctx.clipStack)-1]
+	ctx.clipStack = ctx.clipStack[:len(ctx.clipStack)-1]
+}
+
 // SubContext creates a child context offset from this one.
 // The child's clip rect is the intersection of the parent's clip rect and the child's bounds.
 func (ctx *RenderContext) SubContext(xOffset, yOffset, width, height int) *RenderContext {
@@ -136,6 +177,7 @@ func (ctx *RenderContext) SubContext(xOffset, yOffset, width, height int) *Rende
 		widgetRegistry: ctx.widgetRegistry,
 		inheritedBgAt:  ctx.inheritedBgAt,
 		currentEventID: ctx.currentEventID,
+		cell:           ctx.cell,
 	}
 }
 
@@ -170,6 +212,7 @@ func (ctx *RenderContext) OverflowSubContext(xOffset, yOffset, width, height int
 		widgetRegistry: ctx.widgetRegistry,
 		inheritedBgAt:  ctx.inheritedBgAt,
 		currentEventID: ctx.currentEventID,
+		cell:           ctx.cell,
 	}
 }
 
@@ -213,6 +256,7 @@ func (ctx *RenderContext) ScrolledSubContext(xOffset, yOffset, width, height, sc
 		widgetRegistry: ctx.widgetRegistry,
 		inheritedBgAt:  ctx.inheritedBgAt,
 		currentEventID: ctx.currentEventID,
+		cell:           ctx.cell,
 	}
 }
 
@@ -244,9 +288,10 @@ func (ctx *RenderContext) IsFocused(widget Widget) bool {
 }
 
 // FillRect fills a rectangular region with a background color.
-// If the color is semi-transparent, it blends with the inherited background.
-func (ctx *RenderContext) FillRect(x, y, width, height int, bgColor Color) {
-	if !bgColor.IsSet() {
+// Gradients are sampled across the rectangle. Semi-transparent colors
+// blend with the inherited background.
+func (ctx *RenderContext) FillRect(x, y, width, height int, bg ColorProvider) {
+	if bg == nil || !bg.IsSet() {
 		return
 	}
 
@@ -265,6 +310,7 @@ func (ctx *RenderContext) FillRect(x, y, width, height int, bgColor Color) {
 			}
 
 			// Determine effective background color for this cell
+			bgColor := bg.ColorAt(width, height, col, row)
 			effectiveBg := bgColor
 			if !bgColor.IsOpaque() {
 				// Semi-transparent: blend over inherited background
@@ -273,7 +319,7 @@ func (ctx *RenderContext) FillRect(x, y, width, height int, bgColor Color) {
 					inherited = ctx.inheritedBgAt(absX, absY)
 				}
 				if !inherited.IsSet() {
-					inherited = Black
+					inherited = terminalBackground()
 				}
 				effectiveBg = bgColor.BlendOver(inherited)
 			}
@@ -281,8 +327,7 @@ func (ctx *RenderContext) FillRect(x, y, width, height int, bgColor Color) {
 			cellStyle := uv.Style{
 				Bg: effectiveBg.toANSI(),
 			}
-			cell := &uv.Cell{Content: " ", Width: 1, Style: cellStyle}
-			ctx.terminal.SetCell(absX, absY, cell)
+			ctx.setCell(absX, absY, uv.Cell{Content: " ", Width: 1, Style: cellStyle})
 		}
 	}
 }
@@ -319,12 +364,11 @@ func (ctx *RenderContext) DrawBackdrop(x, y, width, height int, backdrop ColorPr
 			existingCell := ctx.terminal.CellAt(absX, absY)
 			if existingCell == nil {
 				// No existing cell, just fill with backdrop color
-				cell := &uv.Cell{
+				ctx.setCell(absX, absY, uv.Cell{
 					Content: " ",
 					Width:   1,
 					Style:   uv.Style{Bg: backdropColor.toANSI()},
-				}
-				ctx.terminal.SetCell(absX, absY, cell)
+				})
 				continue
 			}
 
@@ -337,7 +381,7 @@ func (ctx *RenderContext) DrawBackdrop(x, y, width, height int, backdrop ColorPr
 			blendedBg := backdropColor.BlendOver(existingBg)
 
 			// Re-write cell with same content but blended colors
-			newCell := &uv.Cell{
+			ctx.setCell(absX, absY, uv.Cell{
 				Content: existingCell.Content,
 				Width:   existingCell.Width,
 				Style: uv.Style{
@@ -346,29 +390,25 @@ func (ctx *RenderContext) DrawBackdrop(x, y, width, height int, backdrop ColorPr
 					Attrs:     existingCell.Style.Attrs,
 					Underline: existingCell.Style.Underline,
 				},
-			}
-			ctx.terminal.SetCell(absX, absY, newCell)
+			})
 		}
 	}
 }
 
 // DrawBorder draws a border around a rectangular region.
-// The border is drawn at the edges of the specified rectangle.
+// The border is drawn at the edges of the specified rectangle, on the sides
+// the border has (see Border.Sides).
 func (ctx *RenderContext) DrawBorder(x, y, width, height int, border Border) {
-	if border.Style == BorderNone || width < 2 || height < 2 {
+	insets := border.Insets()
+	if border.Style == BorderNone || width <= 0 || height <= 0 || width < insets.Horizontal() || height < insets.Vertical() {
 		return
 	}
 
 	// Border characters based on style
-	chars := GetBorderCharSet(border.Style)
+	chars := border.charSet()
 	if chars.TopLeft == "" {
 		return // BorderNone or unknown style
 	}
-	tl, tr, bl, br := chars.TopLeft, chars.TopRight, chars.BottomLeft, chars.BottomRight
-	h, v := chars.Top, chars.Left
-
-	// Check if border color is a ColorProvider (for gradient borders)
-	borderColorProvider := border.Color
 
 	// Helper to set a cell with a specific foreground color
 	// Reads existing cell to preserve background from underlying content (for transparency)
@@ -393,28 +433,37 @@ func (ctx *RenderContext) DrawBorder(x, y, width, height int, border Border) {
 			Fg: effectiveFg.toANSI(),
 			Bg: bg.toANSI(),
 		}
-		cell := &uv.Cell{Content: content, Width: 1, Style: style}
-		ctx.terminal.SetCell(absX, absY, cell)
+		ctx.setCell(absX, absY, uv.Cell{Content: content, Width: 1, Style: style})
 	}
 
-	// Helper to set a cell with border color (samples from ColorProvider at cell position)
-	setCell := func(cx, cy int, content string) {
+	// Helper to set a cell with a side's color (samples from ColorProvider at cell position)
+	setCell := func(cx, cy int, content string, color ColorProvider) {
 		var fgColor Color
-		if borderColorProvider != nil && borderColorProvider.IsSet() {
+		if color != nil && color.IsSet() {
 			// Sample border color at this cell's position within the border box
-			fgColor = borderColorProvider.ColorAt(width, height, cx-x, cy-y)
+			fgColor = color.ColorAt(width, height, cx-x, cy-y)
 		}
 		setCellStyled(cx, cy, content, fgColor)
 	}
 
-	// Draw corners
-	setCell(x, y, tl)
-	setCell(x+width-1, y, tr)
-	setCell(x, y+height-1, bl)
-	setCell(x+width-1, y+height-1, br)
+	// Draw corners where two drawn sides meet
+	if insets.Top > 0 && insets.Left > 0 {
+		setCell(x, y, chars.TopLeft, border.sideColor(BorderSideTop))
+	}
+	if insets.Top > 0 && insets.Right > 0 {
+		setCell(x+width-1, y, chars.TopRight, border.sideColor(BorderSideTop))
+	}
+	if insets.Bottom > 0 && insets.Left > 0 {
+		setCell(x, y+height-1, chars.BottomLeft, border.sideColor(BorderSideBottom))
+	}
+	if insets.Bottom > 0 && insets.Right > 0 {
+		setCell(x+width-1, y+height-1, chars.BottomRight, border.sideColor(BorderSideBottom))
+	}
 
-	// Available width for horizontal edges (excluding corners)
-	edgeWidth := width - 2
+	// Horizontal edges run between the corners, or to the rectangle's
+	// edge where a side is hidden
+	edgeX := x + insets.Left
+	edgeWidth := width - insets.Horizontal()
 
 	// Group decorations by edge (top or bottom)
 	var topDecorations, bottomDecorations []BorderDecoration
@@ -428,17 +477,18 @@ func (ctx *RenderContext) DrawBorder(x, y, width, height int, border Border) {
 	}
 
 	// Draw horizontal edge with decorations
-	drawHorizontalEdge := func(edgeY int, decorations []BorderDecoration) {
+	drawHorizontalEdge := func(edgeY int, decorations []BorderDecoration, h string, color ColorProvider) {
 		// Create a slice to track which positions are occupied by decoration text
 		// true = occupied by decoration, false = draw border character
 		occupied := make([]bool, edgeWidth)
 
 		// Calculate decoration positions and mark occupied cells
 		type placedDecoration struct {
-			text  string
-			spans []Span // Parsed markup spans (if Markup was set)
-			start int
-			color ColorProvider
+			text     string
+			spans    []Span // Parsed markup spans (if Markup was set)
+			start    int
+			color    ColorProvider
+			position DecorationPosition
 		}
 		var placed []placedDecoration
 
@@ -467,49 +517,59 @@ func (ctx *RenderContext) DrawBorder(x, y, width, height int, border Border) {
 				text = " " + dec.Text + " "
 			}
 
-			textLen := ansi.StringWidth(text)
-
-			if textLen > edgeWidth {
+			if ansi.StringWidth(text) > edgeWidth {
 				// Truncate if too long (using display width)
 				text = ansi.Truncate(text, edgeWidth, "")
-				textLen = edgeWidth
 			}
 
-			var startPos int
-			switch dec.Position {
-			case DecorationTopLeft, DecorationBottomLeft:
-				startPos = 0
-			case DecorationTopCenter, DecorationBottomCenter:
-				startPos = (edgeWidth - textLen) / 2
-			case DecorationTopRight, DecorationBottomRight:
-				startPos = edgeWidth - textLen
+			placed = append(placed, placedDecoration{
+				text:     text,
+				spans:    spans,
+				color:    dec.Color,
+				position: dec.Position,
+			})
+		}
+
+		// Decorations at the same position sit side by side in order: the
+		// left group from the left end, the right group against the right
+		// end and the center group centered.
+		groupWidth := map[DecorationPosition]int{}
+		for _, p := range placed {
+			groupWidth[p.position] += ansi.StringWidth(p.text)
+		}
+		next := map[DecorationPosition]int{}
+		for i := range placed {
+			p := &placed[i]
+			textLen := ansi.StringWidth(p.text)
+			if _, ok := next[p.position]; !ok {
+				switch p.position {
+				case DecorationTopCenter, DecorationBottomCenter:
+					next[p.position] = (edgeWidth - groupWidth[p.position]) / 2
+				case DecorationTopRight, DecorationBottomRight:
+					next[p.position] = edgeWidth - groupWidth[p.position]
+				}
 			}
+			p.start = next[p.position]
+			next[p.position] += textLen
 
 			// Clamp to valid range
-			if startPos < 0 {
-				startPos = 0
+			if p.start < 0 {
+				p.start = 0
 			}
-			if startPos+textLen > edgeWidth {
-				startPos = edgeWidth - textLen
+			if p.start+textLen > edgeWidth {
+				p.start = edgeWidth - textLen
 			}
 
 			// Mark cells as occupied
-			for i := 0; i < textLen && startPos+i < edgeWidth; i++ {
-				occupied[startPos+i] = true
+			for i := 0; i < textLen && p.start+i < edgeWidth; i++ {
+				occupied[p.start+i] = true
 			}
-
-			placed = append(placed, placedDecoration{
-				text:  text,
-				spans: spans,
-				start: startPos,
-				color: dec.Color,
-			})
 		}
 
 		// Draw border characters where not occupied
 		for col := 0; col < edgeWidth; col++ {
 			if !occupied[col] {
-				setCell(x+1+col, edgeY, h)
+				setCell(edgeX+col, edgeY, h, color)
 			}
 		}
 
@@ -521,7 +581,7 @@ func (ctx *RenderContext) DrawBorder(x, y, width, height int, border Border) {
 				for _, span := range p.spans {
 					for _, r := range span.Text {
 						if p.start+col < edgeWidth {
-							cellX := x + 1 + p.start + col
+							cellX := edgeX + p.start + col
 							absX := ctx.X + cellX
 							absY := ctx.Y + edgeY
 							// Skip if outside clip bounds
@@ -546,9 +606,9 @@ func (ctx *RenderContext) DrawBorder(x, y, width, height int, border Border) {
 								// Fall back to decoration color
 								textLen := ansi.StringWidth(p.text)
 								fgColor = p.color.ColorAt(textLen, 1, col, 0)
-							} else if borderColorProvider != nil && borderColorProvider.IsSet() {
+							} else if color != nil && color.IsSet() {
 								// Fall back to border color
-								fgColor = borderColorProvider.ColorAt(width, height, cellX-x, edgeY-y)
+								fgColor = color.ColorAt(width, height, cellX-x, edgeY-y)
 							}
 							fgColor = blendForeground(fgColor, bg)
 
@@ -595,8 +655,7 @@ func (ctx *RenderContext) DrawBorder(x, y, width, height int, border Border) {
 								cellStyle.UnderlineColor = span.Style.UnderlineColor.toANSI()
 							}
 
-							cell := &uv.Cell{Content: string(r), Width: 1, Style: cellStyle}
-							ctx.terminal.SetCell(absX, absY, cell)
+							ctx.setCell(absX, absY, uv.Cell{Content: string(r), Width: 1, Style: cellStyle})
 						}
 						col++
 					}
@@ -607,7 +666,7 @@ func (ctx *RenderContext) DrawBorder(x, y, width, height int, border Border) {
 				textLen := len(runes)
 				for i, r := range runes {
 					if p.start+i < edgeWidth {
-						cellX := x + 1 + p.start + i
+						cellX := edgeX + p.start + i
 						// Determine foreground color for this decoration character
 						var fgColor Color
 						if p.color != nil && p.color.IsSet() {
@@ -615,9 +674,9 @@ func (ctx *RenderContext) DrawBorder(x, y, width, height int, border Border) {
 							// For horizontal gradients use angle=90; vertical (angle=0) on
 							// single-line text falls back to midpoint color
 							fgColor = p.color.ColorAt(textLen, 1, i, 0)
-						} else if borderColorProvider != nil && borderColorProvider.IsSet() {
+						} else if color != nil && color.IsSet() {
 							// Fall back to border color gradient at this position
-							fgColor = borderColorProvider.ColorAt(width, height, cellX-x, edgeY-y)
+							fgColor = color.ColorAt(width, height, cellX-x, edgeY-y)
 						}
 						setCellStyled(cellX, edgeY, string(r), fgColor)
 					}
@@ -627,15 +686,24 @@ func (ctx *RenderContext) DrawBorder(x, y, width, height int, border Border) {
 	}
 
 	// Draw top edge with decorations
-	drawHorizontalEdge(y, topDecorations)
+	if insets.Top > 0 {
+		drawHorizontalEdge(y, topDecorations, chars.Top, border.sideColor(BorderSideTop))
+	}
 
 	// Draw bottom edge with decorations
-	drawHorizontalEdge(y+height-1, bottomDecorations)
+	if insets.Bottom > 0 {
+		drawHorizontalEdge(y+height-1, bottomDecorations, chars.Bottom, border.sideColor(BorderSideBottom))
+	}
 
 	// Draw left and right edges
-	for row := 1; row < height-1; row++ {
-		setCell(x, y+row, v)
-		setCell(x+width-1, y+row, v)
+	leftColor, rightColor := border.sideColor(BorderSideLeft), border.sideColor(BorderSideRight)
+	for row := insets.Top; row < height-insets.Bottom; row++ {
+		if insets.Left > 0 {
+			setCell(x, y+row, chars.Left, leftColor)
+		}
+		if insets.Right > 0 {
+			setCell(x+width-1, y+row, chars.Right, rightColor)
+		}
 	}
 }
 
@@ -713,7 +781,7 @@ func (ctx *RenderContext) DrawStyledText(x, y int, text string, style Style) {
 				bg = style.BackgroundColor.ColorAt(1, 1, 0, 0)
 				if !bg.IsOpaque() {
 					if !screenBg.IsSet() {
-						screenBg = Black
+						screenBg = terminalBackground()
 					}
 					bg = bg.BlendOver(screenBg)
 				}
@@ -740,8 +808,7 @@ func (ctx *RenderContext) DrawStyledText(x, y int, text string, style Style) {
 				cellStyle.UnderlineColor = style.UnderlineColor.toANSI()
 			}
 
-			cell := &uv.Cell{Content: grapheme, Width: width, Style: cellStyle}
-			ctx.terminal.SetCell(cellX, absY, cell)
+			ctx.setCell(cellX, absY, uv.Cell{Content: grapheme, Width: width, Style: cellStyle})
 		}
 		col += width
 		remaining = remaining[len(grapheme):]
@@ -824,7 +891,7 @@ func (ctx *RenderContext) DrawSpan(x, y int, span Span, baseStyle Style) int {
 				bg = explicitBg
 				if !bg.IsOpaque() {
 					if !screenBg.IsSet() {
-						screenBg = Black
+						screenBg = terminalBackground()
 					}
 					bg = bg.BlendOver(screenBg)
 				}
@@ -832,7 +899,7 @@ func (ctx *RenderContext) DrawSpan(x, y int, span Span, baseStyle Style) int {
 				bg = baseStyle.BackgroundColor.ColorAt(1, 1, 0, 0)
 				if !bg.IsOpaque() {
 					if !screenBg.IsSet() {
-						screenBg = Black
+						screenBg = terminalBackground()
 					}
 					bg = bg.BlendOver(screenBg)
 				}
@@ -862,8 +929,11 @@ func (ctx *RenderContext) DrawSpan(x, y int, span Span, baseStyle Style) int {
 				cellStyle.UnderlineColor = span.Style.UnderlineColor.toANSI()
 			}
 
-			cell := &uv.Cell{Content: grapheme, Width: width, Style: cellStyle}
-			ctx.terminal.SetCell(cellX, absY, cell)
+			cell := uv.Cell{Content: grapheme, Width: width, Style: cellStyle}
+			if span.Style.Link != "" {
+				cell.Link = uv.NewLink(span.Style.Link)
+			}
+			ctx.setCell(cellX, absY, cell)
 		}
 		col += width
 		remaining = remaining[len(grapheme):]
@@ -872,6 +942,35 @@ func (ctx *RenderContext) DrawSpan(x, y int, span Span, baseStyle Style) int {
 	return spanWidth
 }
 
+// DrawSpans draws a run of styled spans one after another, starting at the
+// given position relative to this context. The baseStyle provides default
+// colors for spans that don't specify them.
+// Returns the total width drawn.
+func (ctx *RenderContext) DrawSpans(x, y int, spans []Span, baseStyle Style) int {
+	col := x
+	for _, span := range spans {
+		col += ctx.DrawSpan(col, y, span, baseStyle)
+	}
+	return col - x
+}
+
+// DrawAlignedSpans draws a run of styled spans aligned within a line of the
+// given width starting at x, filling the rest of the line with the base
+// style's background. Spans wider than the line are cut off at its end.
+// Returns the column, relative to this context, where the spans start.
+func (ctx *RenderContext) DrawAlignedSpans(x, y, width int, spans []Span, baseStyle Style, align TextAlign) int {
+	lineWidth := 0
+	for _, span := range spans {
+		lineWidth += ansi.StringWidth(span.Text)
+	}
+	ctx.PushClip(x, y, width, 1)
+	defer ctx.PopClip()
+	ctx.FillRect(x, y, width, 1, baseStyle.BackgroundColor)
+	start := x + max(0, alignLine(lineWidth, width, align))
+	ctx.DrawSpans(start, y, spans, baseStyle)
+	return start
+}
+
 // Renderer handles the widget tree rendering pipeline.
 type Renderer struct {
 	terminal       CellBuffer
@@ -885,6 +984,46 @@ type Renderer struct {
 	floatCollector *FloatCollector
 	// modalCount tracks the number of modal floats rendered in the last pass.
 	modalCount int
+	// ids tracks widget IDs claimed in the last pass when the ID collision
+	// check is enabled; reportedIDs stops each collision being logged every frame.
+	ids         *idTracker
+	reportedIDs map[string]bool
+	// focusRing is the focus indicator to draw once the tree holding the
+	// focused widget has been painted.
+	focusRing *pendingFocusRing
+	// pointer is the hovered and pressed widgets; hoverPath and pressPath
+	// hold the IDs of the widgets enclosing them in the tree being painted.
+	pointer   pointerState
+	hoverPath map[string]bool
+	pressPath map[string]bool
+	// lastTree is the main tree painted in the last pass, which hover
+	// targets were resolved against; BuildContext.HoverWithin uses it.
+	lastTree RenderTree
+	// scrollTarget is the Scrollable active for scrolling under the
+	// pointer (see ScrollFocusFollowsMouse).
+	scrollTarget string
+	// frames counts this renderer's render passes.
+	frames frameCounter
+}
+
+// frameCounter counts one renderer's render passes, so state recorded
+// while building can tell whether its widget is still shown. Passes of
+// other renderers, such as RenderToBuffer's, don't count.
+type frameCounter struct {
+	started   atomic.Uint64
+	completed atomic.Uint64
+}
+
+// frameStamp identifies one render pass of one renderer.
+type frameStamp struct {
+	counter *frameCounter
+	frame   uint64
+}
+
+// current reports whether the pass is its renderer's latest, finished or
+// in progress. A widget missing from a finished pass is no longer shown.
+func (s frameStamp) current() bool {
+	return s.counter != nil && s.frame >= s.counter.completed.Load()
 }
 
 // NewRenderer creates a new renderer for the given terminal.
@@ -963,13 +1102,23 @@ func (r *Renderer) renderInternal(root Widget) (focusables []FocusableEntry, lay
 	r.widgetRegistry.Reset()
 	r.floatCollector.Reset()
 	r.modalCount = 0
+	frame := r.frames.started.Add(1)
+	defer r.frames.completed.Store(frame)
 
 	// Create build context
 	buildCtx := NewBuildContext(r.focusManager, r.focusedSignal, r.hoveredSignal, r.floatCollector)
+	buildCtx.frame = frameStamp{counter: &r.frames, frame: frame}
+	buildCtx.hoverPath = idPath(r.lastTree, r.pointer.hoveredID)
+	r.ids = nil
+	if idCollisionCheckEnabled.Load() {
+		r.ids = newIDTracker()
+		buildCtx.ids = r.ids
+	}
 
 	// Phase 1+2: Build complete render tree (layout + focus collection)
 	constraints := layout.Loose(r.width, r.height)
 	renderTree := BuildRenderTree(root, buildCtx, constraints, r.focusCollector)
+	r.lastTree = renderTree
 
 	// Extract computed border-box size from the root render tree
 	layoutWidth = renderTree.Layout.Box.BorderBoxWidth()
@@ -977,18 +1126,63 @@ func (r *Renderer) renderInternal(root Widget) (focusables []FocusableEntry, lay
 
 	// Phase 3: Render from the tree (pure painting - no layout or focus logic)
 	ctx := NewRenderContext(r.terminal, r.width, r.height, nil, r.focusManager, buildCtx, r.widgetRegistry)
+	r.markPointerPaths(renderTree)
 	r.renderTree(ctx, renderTree, 0, 0)
+	r.drawFocusRing()
 
-	// Handle floats
+	// Handle floats, then the layers added with AddLayer, with the ghost of
+	// any drag in progress and the kiosk on-screen keyboard on top
+	buildLayers(buildCtx)
+	buildDragGhost(buildCtx)
+	buildKioskKeyboard(buildCtx)
 	r.renderFloats(ctx, buildCtx)
+	r.drawFocusRing()
+
+	r.reportIDCollisions()
 
 	return r.focusCollector.Focusables(), layoutWidth, layoutHeight
 }
 
+// IDCollisions returns the duplicate widget IDs found in the last render.
+// Always empty unless EnableIDCollisionCheck has been called.
+func (r *Renderer) IDCollisions() []IDCollision {
+	if r.ids == nil {
+		return nil
+	}
+	return r.ids.collisions
+}
+
+// reportIDCollisions logs collisions from the last render that haven't
+// been logged before.
+func (r *Renderer) reportIDCollisions() {
+	for _, collision := range r.IDCollisions() {
+		if r.reportedIDs[collision.ID] {
+			continue
+		}
+		if r.reportedIDs == nil {
+			r.reportedIDs = map[string]bool{}
+		}
+		r.reportedIDs[collision.ID] = true
+		LogWarn("duplicate widget ID", Field("id", collision.ID), Field("first", collision.First), Field("second", collision.Second))
+	}
+}
+
 // renderTree paints a render tree to the terminal.
 // All positions come from BoxModel utilities - no manual offset calculations.
 // This is the new rendering path that uses computed layout geometry.
 func (r *Renderer) renderTree(ctx *RenderContext, tree RenderTree, screenX, screenY int) {
+	if tree.recoverRender != nil {
+		parentCtx, parentX, parentY := ctx, screenX, screenY
+		defer func() {
+			if value := recover(); value != nil {
+				r.renderTree(parentCtx, tree.recoverRender(value), parentX, parentY)
+			}
+		}()
+	}
+
+	// A shaking widget is drawn moved sideways; its layout doesn't change.
+	screenX += shakeOffset(tree.EventID)
+
 	// Bind current event ID to this render node so auto-ID focus works in Render().
 	selfCtx := *ctx
 	selfCtx.currentEventID = tree.EventID
@@ -1015,14 +1209,19 @@ func (r *Renderer) renderTree(ctx *RenderContext, tree RenderTree, screenX, scre
 	if styled, ok := tree.Widget.(Styled); ok {
 		style = styled.GetStyle()
 	}
+	pointerOverlay, hasPointerStyle := r.pointerStyle(tree.EventID, style)
+	if hasPointerStyle {
+		style = style.withPointerStyle(pointerOverlay)
+	}
 
 	// 1. Fill background (border-box area) using ColorProvider
 	if style.BackgroundColor != nil && style.BackgroundColor.IsSet() {
 		// Check if background is semi-transparent (needs backdrop blending)
 		// Sample at (0,0) to check - for gradients this may not be fully accurate
 		// but is a reasonable heuristic
+		// Transparent takes the opaque path, which clears the cells.
 		sampleColor := style.BackgroundColor.ColorAt(box.Width, box.Height, 0, 0)
-		useBackdrop := !sampleColor.IsOpaque()
+		useBackdrop := !sampleColor.IsOpaque() && !sampleColor.IsTransparent()
 
 		if useBackdrop {
 			// Use DrawBackdrop to preserve underlying content and blend colors
@@ -1043,11 +1242,10 @@ func (r *Renderer) renderTree(ctx *RenderContext, tree RenderTree, screenX, scre
 					}
 
 					// Sample color at this position (works for both solid colors and gradients)
-					cellColor := style.BackgroundColor.ColorAt(box.Width, box.Height, col, row)
+					cellColor := backgroundAt(style.BackgroundColor, box.Width, box.Height, col, row, absX, absY)
 
 					cellStyle := uv.Style{Bg: cellColor.toANSI()}
-					cell := &uv.Cell{Content: " ", Width: 1, Style: cellStyle}
-					ctx.terminal.SetCell(absX, absY, cell)
+					ctx.setCell(absX, absY, uv.Cell{Content: " ", Width: 1, Style: cellStyle})
 				}
 			}
 		}
@@ -1066,13 +1264,13 @@ func (r *Renderer) renderTree(ctx *RenderContext, tree RenderTree, screenX, scre
 			borderCtx.inheritedBgAt = func(absX, absY int) Color {
 				relX := absX - originX
 				relY := absY - originY
-				cellColor := bg.ColorAt(w, h, relX, relY)
+				cellColor := backgroundAt(bg, w, h, relX, relY, absX, absY)
 
 				// Blend with parent if not opaque
 				if !cellColor.IsOpaque() && parentCallback != nil {
 					inherited := parentCallback(absX, absY)
 					if !inherited.IsSet() {
-						inherited = Black
+						inherited = terminalBackground()
 					}
 					cellColor = cellColor.BlendOver(inherited)
 				}
@@ -1095,13 +1293,13 @@ func (r *Renderer) renderTree(ctx *RenderContext, tree RenderTree, screenX, scre
 			contentCtx.inheritedBgAt = func(absX, absY int) Color {
 				relX := absX - originX
 				relY := absY - originY
-				cellColor := bg.ColorAt(w, h, relX, relY)
+				cellColor := backgroundAt(bg, w, h, relX, relY, absX, absY)
 
 				// Blend with parent if not opaque
 				if !cellColor.IsOpaque() && parentCallback != nil {
 					inherited := parentCallback(absX, absY)
 					if !inherited.IsSet() {
-						inherited = Black
+						inherited = terminalBackground()
 					}
 					cellColor = cellColor.BlendOver(inherited)
 				}
@@ -1109,6 +1307,9 @@ func (r *Renderer) renderTree(ctx *RenderContext, tree RenderTree, screenX, scre
 			}
 		}
 		renderable.Render(contentCtx)
+		if hasPointerStyle {
+			restylePointerContent(contentCtx, pointerOverlay, box.Width, box.Height, trueAbsBorderX, trueAbsBorderY)
+		}
 	}
 
 	// 4. Register for hit testing before rendering children so children win in z-order.
@@ -1131,11 +1332,23 @@ func (r *Renderer) renderTree(ctx *RenderContext, tree RenderTree, screenX, scre
 		var childClipCtx *RenderContext
 
 		// Stack positions children relative to border-box, not content-box
-		// Stack allows children to overflow (e.g., badges with negative positioning)
-		_, isStack := tree.Widget.(Stack)
+		// Stack allows children to overflow by default (e.g., badges with negative positioning)
+		stack, isStack := tree.Widget.(Stack)
+		overflow := style.Overflow
+		if overflow == OverflowDefault && isStack {
+			overflow = OverflowVisible
+		}
 		if isStack {
-			// Stack: children positioned relative to border-box, can overflow
+			// Stack: children positioned relative to border-box, clipped per its ClipBehavior
 			childClipCtx = ctx.OverflowSubContext(absBorderX, absBorderY, box.Width, box.Height)
+			if clip, ok := stack.clipRect(box); ok {
+				childClipCtx.clip = ctx.clip.Intersect(Rect{
+					X:      childClipCtx.X + clip.X,
+					Y:      childClipCtx.Y + clip.Y,
+					Width:  max(0, clip.Width),
+					Height: max(0, clip.Height),
+				})
+			}
 		} else if box.IsScrollableX() || box.IsScrollableY() {
 			// Scrollable: apply scroll offsets so content is shifted within viewport.
 			usableBox := box.UsableContentBox()
@@ -1147,14 +1360,26 @@ func (r *Renderer) renderTree(ctx *RenderContext, tree RenderTree, screenX, scre
 				box.ScrollOffsetX,
 				box.ScrollOffsetY,
 			)
+		} else if overflow == OverflowVisible {
+			// Containers that opt in let children bleed past their content area
+			usableBox := box.UsableContentBox()
+			childClipCtx = ctx.OverflowSubContext(absContentX, absContentY, usableBox.Width, usableBox.Height)
 		} else {
 			// Standard containers: children positioned relative to content area
 			// Use SubContext which clips children to the container bounds
-			// Stack uses OverflowSubContext to allow positioned children to overflow
 			usableBox := box.UsableContentBox()
 			childClipCtx = ctx.SubContext(absContentX, absContentY, usableBox.Width, usableBox.Height)
 		}
 
+		// Focus rings around children may use this container's padding
+		paddingBox := box.PaddingBox()
+		childClipCtx.focusRingClip = ctx.clip.Intersect(Rect{
+			X:      ctx.X + screenX + paddingBox.X,
+			Y:      ctx.Y + screenY + paddingBox.Y,
+			Width:  paddingBox.Width,
+			Height: paddingBox.Height,
+		})
+
 		// Set inherited background for children using ColorProvider
 		if style.BackgroundColor != nil && style.BackgroundColor.IsSet() {
 			bg := style.BackgroundColor
@@ -1165,13 +1390,13 @@ func (r *Renderer) renderTree(ctx *RenderContext, tree RenderTree, screenX, scre
 			childClipCtx.inheritedBgAt = func(absX, absY int) Color {
 				relX := absX - originX
 				relY := absY - originY
-				cellColor := bg.ColorAt(w, h, relX, relY)
+				cellColor := backgroundAt(bg, w, h, relX, relY, absX, absY)
 
 				// Blend with parent if not opaque
 				if !cellColor.IsOpaque() && parentCallback != nil {
 					inherited := parentCallback(absX, absY)
 					if !inherited.IsSet() {
-						inherited = Black
+						inherited = terminalBackground()
 					}
 					cellColor = cellColor.BlendOver(inherited)
 				}
@@ -1187,6 +1412,10 @@ func (r *Renderer) renderTree(ctx *RenderContext, tree RenderTree, screenX, scre
 			// Pass relative positions - childClipCtx.X/Y already contains the origin offset
 			r.renderTree(childClipCtx, childTree, pos.X, pos.Y)
 		}
+
+		if overflow == OverflowEllipsis && !box.IsScrollableX() && !box.IsScrollableY() {
+			drawOverflowEllipsis(childClipCtx, tree.Layout, style)
+		}
 	}
 
 	// 5b. Render scrollbar and update ScrollState if widget is scrollable
@@ -1202,7 +1431,7 @@ func (r *Renderer) renderTree(ctx *RenderContext, tree RenderTree, screenX, scre
 		// Render scrollbar if scrolling is enabled and content overflows
 		if box.IsScrollableY() && !scrollable.DisableScroll {
 			// Get focus state
-			focused := ctx.focusManager != nil && ctx.IsFocused(tree.Widget)
+			focused := (ctx.focusManager != nil && ctx.IsFocused(tree.Widget)) || tree.EventID == r.scrollTarget
 
 			// Create context for scrollbar (at content area, not affected by scroll offset)
 			scrollbarCtx := ctx.SubContext(absContentX, absContentY, box.ContentWidth(), box.ContentHeight())
@@ -1213,12 +1442,88 @@ func (r *Renderer) renderTree(ctx *RenderContext, tree RenderTree, screenX, scre
 				scrollbarCtx.inheritedBgAt = func(absX, absY int) Color {
 					relX := absX - originX
 					relY := absY - originY
-					return bg.ColorAt(w, h, relX, relY)
+					return backgroundAt(bg, w, h, relX, relY, absX, absY)
 				}
 			}
 			scrollable.renderScrollbar(scrollbarCtx, box.ScrollOffsetY, focused)
 		}
 	}
+
+	// 6. Queue the focus indicator, drawn over the finished tree. A widget
+	// that builds into another with the same ID is the same focus target,
+	// so the innermost one's ring replaces the outer one's.
+	if ring := resolveFocusRing(style.FocusRing); ring != FocusRingNone && ctx.focusManager != nil && ctx.IsFocused(tree.Widget) {
+		r.focusRing = &pendingFocusRing{
+			ring:   ring,
+			ctx:    ctx,
+			x:      absBorderX,
+			y:      absBorderY,
+			width:  box.Width,
+			height: box.Height,
+			border: style.Border,
+		}
+	}
+
+	// 7. Tint a flashing widget over everything it drew
+	if tint, ok := flashTint(tree.EventID, ctx); ok {
+		tintBackground(ctx.SubContext(absBorderX, absBorderY, box.Width, box.Height), tint)
+	}
+}
+
+// drawOverflowEllipsis marks rows where children were cut off by the container's
+// bounds. Rows with horizontally overflowing children get the ellipsis at their
+// right edge; vertical overflow marks the last visible row.
+func drawOverflowEllipsis(ctx *RenderContext, computed layout.ComputedLayout, style Style) {
+	if ctx.Width <= 0 || ctx.Height <= 0 {
+		return
+	}
+	clipped := make([]bool, ctx.Height)
+	anyClipped := false
+	for _, child := range computed.Children {
+		childBox := child.Layout.Box
+		left := child.X + childBox.Margin.Left
+		top := child.Y + childBox.Margin.Top
+		right := left + childBox.BorderBoxWidth()
+		bottom := top + childBox.BorderBoxHeight()
+		if right > ctx.Width {
+			for row := max(0, top); row < min(bottom, ctx.Height); row++ {
+				clipped[row] = true
+				anyClipped = true
+			}
+		}
+		if bottom > ctx.Height && left < ctx.Width {
+			clipped[ctx.Height-1] = true
+			anyClipped = true
+		}
+	}
+	if !anyClipped {
+		return
+	}
+
+	marker := style.EllipsisMarker()
+	markerWidth := ansi.StringWidth(marker)
+	if markerWidth > ctx.Width {
+		marker = ansi.Truncate(marker, ctx.Width, "")
+		markerWidth = ansi.StringWidth(marker)
+	}
+	markerStyle := Style{ForegroundColor: style.ForegroundColor}
+	if markerStyle.ForegroundColor == nil || !markerStyle.ForegroundColor.IsSet() {
+		markerStyle.ForegroundColor = ctx.buildContext.Theme().TextMuted
+	}
+	for row, cut := range clipped {
+		if cut {
+			ctx.DrawStyledText(ctx.Width-markerWidth, row, marker, markerStyle)
+		}
+	}
+}
+
+// LinkAt returns the hyperlink URL drawn at the given terminal coordinates,
+// or "" if the cell has no link.
+func (r *Renderer) LinkAt(x, y int) string {
+	if cell := r.terminal.CellAt(x, y); cell != nil {
+		return cell.Link.URL
+	}
+	return ""
 }
 
 // WidgetAt returns the topmost widget at the given terminal coordinates.
@@ -1288,17 +1593,28 @@ func (r *Renderer) renderFloats(ctx *RenderContext, buildCtx BuildContext) {
 
 		// Calculate position based on config
 		var x, y int
+		var anchor *WidgetEntry
 		if entry.Config.AnchorID != "" {
+			anchor = r.widgetRegistry.WidgetByID(entry.Config.AnchorID)
+		}
+		if anchor == nil && entry.spotlight {
+			// A spotlight whose target isn't on screen falls back to the center
+			x, y = calculateAbsolutePosition(FloatPositionCenter, r.width, r.height, floatWidth, floatHeight, Offset{})
+		} else if entry.Config.AnchorID != "" && entry.Config.Flip {
+			// Anchor-based positioning, on the other side if it doesn't fit
+			x, y = flipAnchorPosition(anchor, entry.Config.Anchor, floatWidth, floatHeight, entry.Config.Offset, r.width, r.height)
+		} else if entry.Config.AnchorID != "" {
 			// Anchor-based positioning
-			anchor := r.widgetRegistry.WidgetByID(entry.Config.AnchorID)
 			x, y = calculateAnchorPosition(anchor, entry.Config.Anchor, floatWidth, floatHeight, entry.Config.Offset)
 		} else {
 			// Absolute positioning
 			x, y = calculateAbsolutePosition(entry.Config.Position, r.width, r.height, floatWidth, floatHeight, entry.Config.Offset)
 		}
 
-		// Clamp to screen bounds
-		x, y = clampToScreen(x, y, floatWidth, floatHeight, r.width, r.height)
+		// Clamp to screen bounds (portals opt in)
+		if !entry.portal || entry.clamp {
+			x, y = clampToScreen(x, y, floatWidth, floatHeight, r.width, r.height)
+		}
 
 		// Store computed position and size for hit testing
 		entry.X = x
@@ -1310,15 +1626,21 @@ func (r *Renderer) renderFloats(ctx *RenderContext, buildCtx BuildContext) {
 		// Render modal backdrop if needed
 		if entry.Config.Modal {
 			r.modalCount++
-			r.renderModalBackdrop(ctx, entry.Config.BackdropColor)
+			if entry.spotlight && anchor != nil {
+				r.renderBackdrop(ctx, entry.Config.BackdropColor, anchor.Bounds)
+				r.renderSpotlightRing(ctx, anchor.Bounds, entry.ringColor)
+			} else {
+				r.renderModalBackdrop(ctx, entry.Config.BackdropColor)
+			}
 
 			// Auto-focus the first focusable inside the modal if focus
 			// is not already within it. This ensures modals receive focus
 			// when they open without requiring an explicit RequestFocus call.
+			// A focus request made during Build that targets the modal wins.
 			focusedID := r.focusManager.FocusedID()
 			alreadyInside := false
 			for _, fe := range r.focusCollector.Focusables()[focusableCountBefore:] {
-				if fe.ID == focusedID {
+				if fe.ID == focusedID || (pendingFocusID != "" && fe.ID == pendingFocusID) {
 					alreadyInside = true
 					break
 				}
@@ -1330,19 +1652,40 @@ func (r *Renderer) renderFloats(ctx *RenderContext, buildCtx BuildContext) {
 			}
 		}
 
-		// Render the float at its computed position
-		r.renderTree(ctx, floatTree, x, y)
+		// Render the float at its computed position.
+		r.markPointerPaths(floatTree)
+		// Pointer-transparent entries record into a scratch registry so they
+		// never win hit testing against the widgets underneath.
+		if entry.ignorePointer {
+			registry := r.widgetRegistry
+			r.widgetRegistry = NewWidgetRegistry()
+			r.renderTree(ctx, floatTree, x, y)
+			r.widgetRegistry = registry
+		} else {
+			r.renderTree(ctx, floatTree, x, y)
+		}
 	}
 }
 
 // renderModalBackdrop renders a semi-transparent backdrop over the entire screen.
 func (r *Renderer) renderModalBackdrop(ctx *RenderContext, backdropColor Color) {
+	r.renderBackdrop(ctx, backdropColor, Rect{})
+}
+
+// renderBackdrop renders a semi-transparent backdrop over the screen,
+// leaving the cells inside hole untouched.
+func (r *Renderer) renderBackdrop(ctx *RenderContext, backdropColor Color, hole Rect) {
 	if !backdropColor.IsSet() {
 		backdropColor = getTheme().Overlay
 	}
 
 	for y := 0; y < r.height; y++ {
 		for x := 0; x < r.width; {
+			if hole.Contains(x, y) {
+				x++
+				continue
+			}
+
 			// Get existing cell to blend with
 			existing := ctx.terminal.CellAt(x, y)
 			var bgColor Color
@@ -1350,7 +1693,7 @@ func (r *Renderer) renderModalBackdrop(ctx *RenderContext, backdropColor Color)
 				bgColor = FromANSI(existing.Style.Bg)
 			}
 			if !bgColor.IsSet() {
-				bgColor = Black
+				bgColor = terminalBackground()
 			}
 
 			// Blend backdrop color over existing background
@@ -1378,8 +1721,7 @@ func (r *Renderer) renderModalBackdrop(ctx *RenderContext, backdropColor Color)
 				}
 			}
 
-			cell := &uv.Cell{Content: content, Width: width, Style: cellStyle}
-			ctx.terminal.SetCell(x, y, cell)
+			ctx.setCell(x, y, uv.Cell{Content: content, Width: width, Style: cellStyle})
 
 			// Advance by cell width to skip continuation cells for wide characters
 			x += width
@@ -1387,9 +1729,44 @@ func (r *Renderer) renderModalBackdrop(ctx *RenderContext, backdropColor Color)
 	}
 }
 
+// renderSpotlightRing draws a rounded outline just outside bounds,
+// keeping the background of the cells it covers.
+func (r *Renderer) renderSpotlightRing(ctx *RenderContext, bounds Rect, ringColor Color) {
+	if !ringColor.IsSet() {
+		ringColor = getTheme().Token(TokenBorderFocus)
+	}
+
+	left, top := bounds.X-1, bounds.Y-1
+	right, bottom := bounds.X+bounds.Width, bounds.Y+bounds.Height
+	set := func(x, y int, content string) {
+		if x < 0 || y < 0 || x >= r.width || y >= r.height {
+			return
+		}
+		style := uv.Style{Fg: ringColor.toANSI()}
+		if existing := ctx.terminal.CellAt(x, y); existing != nil {
+			style.Bg = existing.Style.Bg
+		}
+		ctx.setCell(x, y, uv.Cell{Content: content, Width: 1, Style: style})
+	}
+
+	for x := left + 1; x < right; x++ {
+		set(x, top, "─")
+		set(x, bottom, "─")
+	}
+	for y := top + 1; y < bottom; y++ {
+		set(left, y, "│")
+		set(right, y, "│")
+	}
+	set(left, top, "╭")
+	set(right, top, "╮")
+	set(left, bottom, "╰")
+	set(right, bottom, "╯")
+}
+
 // HasFloats returns true if there are any floating widgets.
+// Portals are not counted since they have no dismissal semantics.
 func (r *Renderer) HasFloats() bool {
-	return r.floatCollector.Len() > 0
+	return r.floatCollector.HasFloats()
 }
 
 // FloatAt returns the topmost float entry containing the point (x, y).
@@ -1398,6 +1775,9 @@ func (r *Renderer) FloatAt(x, y int) *FloatEntry {
 	// Search back-to-front (topmost floats are last)
 	for i := len(r.floatCollector.entries) - 1; i >= 0; i-- {
 		entry := &r.floatCollector.entries[i]
+		if entry.ignorePointer {
+			continue
+		}
 		if x >= entry.X && x < entry.X+entry.Width &&
 			y >= entry.Y && y < entry.Y+entry.Height {
 			return entry
@@ -1407,11 +1787,14 @@ func (r *Renderer) FloatAt(x, y int) *FloatEntry {
 }
 
 // TopFloat returns the topmost (last registered) float entry, or nil if none.
+// Portals are skipped.
 func (r *Renderer) TopFloat() *FloatEntry {
-	if r.floatCollector.Len() == 0 {
-		return nil
+	for i := len(r.floatCollector.entries) - 1; i >= 0; i-- {
+		if !r.floatCollector.entries[i].portal {
+			return &r.floatCollector.entries[i]
+		}
 	}
-	return &r.floatCollector.entries[len(r.floatCollector.entries)-1]
+	return nil
 }
 
 // HasModalFloat returns true if any float is modal.
@@ -0,0 +1,102 @@
+package terma
+
+import uv "github.com/charmbracelet/ultraviolet"
+
+// DrawLayer draws into an offscreen layer covering the given rectangle,
+// relative to this context, and composites the layer over what is already
+// on screen at the given opacity (0 to 1). The draw function receives a
+// context for the layer whose origin is the rectangle's top-left corner;
+// cells it doesn't draw leave the content below untouched.
+//
+// Blank cells drawn in the layer tint the content below with their
+// background, keeping its text visible. Cells with text replace the text
+// below, faded by the opacity.
+//
+// Example (a half-transparent highlight):
+//
+//	ctx.DrawLayer(0, 0, ctx.Width, 1, 0.5, func(layer *t.RenderContext) {
+//	    layer.FillRect(0, 0, layer.Width, 1, theme.Primary)
+//	})
+func (ctx *RenderContext) DrawLayer(x, y, width, height int, opacity float64, draw func(layer *RenderContext)) {
+	if width <= 0 || height <= 0 || opacity <= 0 {
+		return
+	}
+	opacity = min(opacity, 1)
+
+	layerCtx := ctx.SubContext(x, y, width, height)
+	buffer := &layerBuffer{below: ctx.terminal, cells: make(map[layerPos]*uv.Cell)}
+	layerCtx.terminal = buffer
+	draw(layerCtx)
+
+	for pos, cell := range buffer.cells {
+		if !ctx.clip.Contains(pos.x, pos.y) {
+			continue
+		}
+		ctx.terminal.SetCell(pos.x, pos.y, ctx.compositeCell(pos.x, pos.y, cell, opacity))
+	}
+}
+
+// compositeCell blends a layer cell over the cell on screen at (absX, absY).
+func (ctx *RenderContext) compositeCell(absX, absY int, cell *uv.Cell, opacity float64) *uv.Cell {
+	below := ctx.terminal.CellAt(absX, absY)
+	var belowBg Color
+	if below != nil {
+		belowBg = FromANSI(below.Style.Bg)
+	}
+	if !belowBg.IsSet() && ctx.inheritedBgAt != nil {
+		belowBg = ctx.inheritedBgAt(absX, absY)
+	}
+	if !belowBg.IsSet() {
+		belowBg = terminalBackground()
+	}
+
+	layerBg := FromANSI(cell.Style.Bg)
+	if !layerBg.IsSet() {
+		layerBg = belowBg
+	}
+	tint := layerBg.WithAlpha(layerBg.Alpha() * opacity)
+	bg := tint.BlendOver(belowBg)
+
+	if (cell.Content == " " || cell.Content == "") && below != nil {
+		// Blank layer cell: keep the text below, seen through the tint
+		result := *below
+		result.Style.Bg = bg.toANSI()
+		if fg := FromANSI(below.Style.Fg); fg.IsSet() {
+			result.Style.Fg = tint.BlendOver(fg).toANSI()
+		}
+		return &result
+	}
+
+	result := *cell
+	result.Style.Bg = bg.toANSI()
+	if fg := FromANSI(cell.Style.Fg); fg.IsSet() {
+		result.Style.Fg = fg.WithAlpha(fg.Alpha() * opacity).BlendOver(bg).toANSI()
+	}
+	return &result
+}
+
+type layerPos struct{ x, y int }
+
+// layerBuffer records the cells drawn into a layer. Reads of cells the
+// layer hasn't drawn fall through to the buffer below, so translucent
+// drawing inside the layer blends with what is on screen.
+type layerBuffer struct {
+	below CellBuffer
+	cells map[layerPos]*uv.Cell
+}
+
+func (b *layerBuffer) SetCell(x, y int, c *uv.Cell) {
+	if c == nil {
+		delete(b.cells, layerPos{x, y})
+		return
+	}
+	cell := *c
+	b.cells[layerPos{x, y}] = &cell
+}
+
+func (b *layerBuffer) CellAt(x, y int) *uv.Cell {
+	if cell, ok := b.cells[layerPos{x, y}]; ok {
+		return cell
+	}
+	return b.below.CellAt(x, y)
+}
@@ -0,0 +1,46 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderContext_DrawLayerComposites(t *testing.T) {
+	buf := uv.NewBuffer(4, 1)
+	ctx := NewRenderContext(buf, 4, 1, nil, nil, BuildContext{}, nil)
+	ctx.DrawStyledText(0, 0, "abcd", Style{ForegroundColor: RGB(200, 200, 200), BackgroundColor: RGB(0, 0, 0)})
+
+	ctx.DrawLayer(1, 0, 3, 1, 0.5, func(layer *RenderContext) {
+		assert.Equal(t, 3, layer.Width)
+		layer.FillRect(0, 0, 2, 1, RGB(0, 0, 200))
+		layer.DrawStyledText(1, 0, "X", Style{ForegroundColor: RGB(200, 0, 0)})
+	})
+
+	assert.Equal(t, "abXd", rowText(buf, 4, 0))
+	assert.Equal(t, RGB(0, 0, 0).toANSI(), buf.CellAt(0, 0).Style.Bg, "outside the layer")
+	assert.Equal(t, RGB(0, 0, 0).toANSI(), buf.CellAt(3, 0).Style.Bg, "cells the layer didn't draw are untouched")
+
+	// A blank layer cell tints the text below it.
+	assert.Equal(t, "b", buf.CellAt(1, 0).Content)
+	assert.Equal(t, RGB(0, 0, 100).toANSI(), buf.CellAt(1, 0).Style.Bg)
+	assert.Equal(t, RGB(100, 100, 200).toANSI(), buf.CellAt(1, 0).Style.Fg)
+
+	// Text drawn in the layer replaces the text below, faded.
+	assert.Equal(t, RGB(0, 0, 100).toANSI(), buf.CellAt(2, 0).Style.Bg)
+	assert.Equal(t, RGB(100, 0, 50).toANSI(), buf.CellAt(2, 0).Style.Fg)
+}
+
+func TestRenderContext_DrawLayerRespectsClip(t *testing.T) {
+	buf := uv.NewBuffer(4, 1)
+	ctx := NewRenderContext(buf, 4, 1, nil, nil, BuildContext{}, nil)
+
+	ctx.PushClip(0, 0, 2, 1)
+	ctx.DrawLayer(0, 0, 4, 1, 1, func(layer *RenderContext) {
+		layer.DrawText(0, 0, "wxyz")
+	})
+	ctx.PopClip()
+
+	assert.Equal(t, "wx  ", rowText(buf, 4, 0))
+}
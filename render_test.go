@@ -0,0 +1,54 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderContext_FillRectSamplesGradient(t *testing.T) {
+	buf := uv.NewBuffer(3, 1)
+	ctx := NewRenderContext(buf, 3, 1, nil, nil, BuildContext{}, nil)
+
+	ctx.FillRect(0, 0, 3, 1, NewGradient(RGB(0, 0, 0), RGB(200, 0, 0)).WithAngle(90))
+
+	assert.Equal(t, RGB(0, 0, 0).toANSI(), buf.CellAt(0, 0).Style.Bg)
+	assert.Equal(t, RGB(100, 0, 0).toANSI(), buf.CellAt(1, 0).Style.Bg)
+	assert.Equal(t, RGB(200, 0, 0).toANSI(), buf.CellAt(2, 0).Style.Bg)
+}
+
+func TestRenderContext_PushClipNestsAndPops(t *testing.T) {
+	buf := uv.NewBuffer(6, 1)
+	ctx := NewRenderContext(buf, 6, 1, nil, nil, BuildContext{}, nil)
+
+	ctx.PushClip(1, 0, 4, 1)
+	ctx.PushClip(3, 0, 5, 1)
+	assert.Equal(t, Rect{X: 3, Y: 0, Width: 2, Height: 1}, ctx.ClipBounds())
+	ctx.DrawText(0, 0, "abcdef")
+	ctx.PopClip()
+	ctx.DrawText(0, 0, "ABC")
+	ctx.PopClip()
+	ctx.PopClip() // unbalanced pops are ignored
+
+	assert.Equal(t, " BCde ", rowText(buf, 6, 0))
+	assert.Equal(t, Rect{Width: 6, Height: 1}, ctx.ClipBounds())
+}
+
+func TestRenderContext_DrawSpans(t *testing.T) {
+	buf := uv.NewBuffer(10, 2)
+	ctx := NewRenderContext(buf, 10, 2, nil, nil, BuildContext{}, nil)
+	spans := []Span{BoldSpan("ab"), PlainSpan("cd")}
+
+	assert.Equal(t, 4, ctx.DrawSpans(1, 0, spans, Style{}))
+	assert.Equal(t, " abcd", rowText(buf, 5, 0))
+	assert.True(t, buf.CellAt(1, 0).Style.Attrs&uv.AttrBold != 0)
+	assert.False(t, buf.CellAt(3, 0).Style.Attrs&uv.AttrBold != 0)
+
+	bg := RGB(0, 0, 90)
+	start := ctx.DrawAlignedSpans(2, 1, 6, spans, Style{BackgroundColor: bg}, TextAlignRight)
+	assert.Equal(t, 4, start)
+	assert.Equal(t, "    abcd  ", rowText(buf, 10, 1))
+	assert.Equal(t, bg.toANSI(), buf.CellAt(2, 1).Style.Bg, "the line is filled with the base background")
+	assert.Nil(t, buf.CellAt(8, 1).Style.Bg)
+}
@@ -26,6 +26,11 @@ type RenderTree struct {
 	// Children are the child render trees.
 	// Positions come from Layout.Children[i].X and Layout.Children[i].Y.
 	Children []RenderTree
+
+	// recoverRender is set on the root of an ErrorBoundary's child. It is
+	// called with a panic recovered while painting the subtree and returns
+	// the tree to paint in its place.
+	recoverRender func(value any) RenderTree
 }
 
 // BuildRenderTree constructs the complete render tree with all layout computed.
@@ -57,20 +62,54 @@ func BuildRenderTree(widget Widget, ctx BuildContext, constraints layout.Constra
 			fc.PushTrap(trapID)
 			defer fc.PopTrap()
 		}
+		if fc != nil {
+			pathID := ft.WidgetID()
+			if pathID == "" {
+				pathID = ctx.AutoID()
+			}
+			fc.pushPath(pathID)
+			defer fc.popPath()
+		}
 		if ft.Child == nil {
 			return BuildRenderTree(EmptyWidget{}, ctx, constraints, fc)
 		}
 		return BuildRenderTree(ft.Child, ctx, constraints, fc)
 	}
 
+	// Handle Shortcuts specially - recurse into child with the wrapper as an
+	// ancestor, so its bindings apply while focus is within the child.
+	if sc, ok := widget.(Shortcuts); ok {
+		if fc != nil {
+			fc.PushAncestor(sc)
+			defer fc.PopAncestor()
+			if sc.ID != "" {
+				fc.pushPath(sc.ID)
+				defer fc.popPath()
+			}
+		}
+		if sc.Child == nil {
+			return BuildRenderTree(EmptyWidget{}, ctx, constraints, fc)
+		}
+		return BuildRenderTree(sc.Child, ctx, constraints, fc)
+	}
+
+	// Handle ErrorBoundary specially - recover panics from the child's subtree
+	if eb, ok := widget.(ErrorBoundary); ok {
+		return buildErrorBoundary(eb, ctx, constraints, fc)
+	}
+
 	autoID := ctx.AutoID()
 
 	// Determine event ID (explicit ID or auto)
 	eventID := autoID
 	if identifiable, ok := widget.(Identifiable); ok && identifiable.WidgetID() != "" {
 		eventID = identifiable.WidgetID()
+		if ctx.ids != nil {
+			ctx.ids.claim(eventID, widget, ctx)
+		}
 	}
 
+	ctx.scope = eventID
 	built := widget.Build(ctx)
 
 	// Collect focusables during tree build (not during render)
@@ -84,6 +123,14 @@ func BuildRenderTree(widget Widget, ctx BuildContext, constraints layout.Constra
 			fc.PushAncestor(widget)
 			defer fc.PopAncestor()
 		}
+		fc.pushPath(eventID)
+		defer fc.popPath()
+		// A widget can build into a container with its own ID, which
+		// encloses the children just the same.
+		if identifiable, ok := built.(Identifiable); ok && identifiable.WidgetID() != "" && identifiable.WidgetID() != eventID {
+			fc.pushPath(identifiable.WidgetID())
+			defer fc.popPath()
+		}
 	}
 
 	// Build layout node and compute layout
@@ -103,6 +150,10 @@ func BuildRenderTree(widget Widget, ctx BuildContext, constraints layout.Constra
 	}
 
 	// Recursively build children
+	if ctx.ids != nil {
+		ctx.ids.push(widget)
+		defer ctx.ids.pop()
+	}
 	children := buildChildTrees(built, ctx, computed, fc)
 
 	return RenderTree{
@@ -151,9 +202,9 @@ func extractChildren(widget Widget) []Widget {
 	}
 	switch w := widget.(type) {
 	case Row:
-		return w.Children
+		return arrangeChildren(w.Children)
 	case Column:
-		return w.Children
+		return arrangeChildren(w.Children)
 	case Scrollable:
 		if w.Child != nil {
 			return []Widget{w.Child}
@@ -0,0 +1,111 @@
+package terma
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// ResizeStrategy selects what the app shows while the terminal is being
+// resized. Dragging a window edge sends a burst of sizes, and laying out
+// every one of them can flicker through broken intermediate layouts.
+type ResizeStrategy int
+
+const (
+	// ResizeImmediate lays out and draws every size as it arrives (default).
+	ResizeImmediate ResizeStrategy = iota
+	// ResizeFreeze keeps showing the last frame drawn before the resize,
+	// cropped or padded to the new size, until the size settles.
+	ResizeFreeze
+	// ResizePlaceholder shows only the new size in the middle of the
+	// screen until the size settles.
+	ResizePlaceholder
+)
+
+// DefaultResizeSettle is how long the terminal size must stay unchanged
+// before the app lays out again under ResizeFreeze and ResizePlaceholder.
+const DefaultResizeSettle = 150 * time.Millisecond
+
+var (
+	resizeStrategy atomic.Int32
+	resizeSettle   atomic.Int64
+)
+
+// SetResizeStrategy sets what the app shows while the terminal is being
+// resized. Under any strategy but ResizeImmediate, layout waits until the
+// size has stayed unchanged for the settle window (see SetResizeSettle).
+func SetResizeStrategy(strategy ResizeStrategy) {
+	resizeStrategy.Store(int32(strategy))
+}
+
+// SetResizeSettle sets how long the terminal size must stay unchanged
+// before the app lays out again after a resize. Zero restores
+// DefaultResizeSettle.
+func SetResizeSettle(settle time.Duration) {
+	resizeSettle.Store(int64(settle))
+}
+
+func currentResizeStrategy() ResizeStrategy {
+	return ResizeStrategy(resizeStrategy.Load())
+}
+
+func currentResizeSettle() time.Duration {
+	if settle := time.Duration(resizeSettle.Load()); settle > 0 {
+		return settle
+	}
+	return DefaultResizeSettle
+}
+
+// resizeHold is drawn in place of the app while a resize settles. It is
+// owned by the app's event loop.
+type resizeHold struct {
+	frame [][]uv.Cell // Last frame before the resize; nil for a placeholder
+}
+
+// newResizeHold starts holding for strategy, keeping a copy of what the
+// terminal shows for ResizeFreeze. It must be called before the terminal
+// is resized.
+func newResizeHold(strategy ResizeStrategy, terminal CellBuffer, width, height int) *resizeHold {
+	hold := &resizeHold{}
+	if strategy != ResizeFreeze {
+		return hold
+	}
+	hold.frame = make([][]uv.Cell, height)
+	for y := range hold.frame {
+		row := make([]uv.Cell, width)
+		for x := range row {
+			if cell := terminal.CellAt(x, y); cell != nil {
+				row[x] = *cell
+			} else {
+				row[x] = uv.EmptyCell
+			}
+		}
+		hold.frame[y] = row
+	}
+	return hold
+}
+
+// draw paints the held frame from the top-left corner, cut off where the
+// terminal is now smaller and blank where it is larger, or the placeholder.
+func (h *resizeHold) draw(terminal CellBuffer, width, height int) {
+	if h.frame != nil {
+		for y := 0; y < min(height, len(h.frame)); y++ {
+			row := h.frame[y]
+			for x := 0; x < min(width, len(row)); x++ {
+				cell := row[x]
+				if x+max(cell.Width, 1) > width {
+					break // A wide character no longer fits
+				}
+				terminal.SetCell(x, y, &cell)
+			}
+		}
+		return
+	}
+	theme := getTheme()
+	ctx := NewRenderContext(terminal, width, height, nil, nil, BuildContext{}, nil)
+	ctx.FillRect(0, 0, width, height, theme.Background)
+	text := fmt.Sprintf("%d×%d", width, height)
+	ctx.DrawStyledText((width-len([]rune(text)))/2, height/2, text, Style{ForegroundColor: theme.TextMuted})
+}
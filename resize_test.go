@@ -0,0 +1,45 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResizeHold_FreezeCropsAndPadsLastFrame(t *testing.T) {
+	before := uv.NewBuffer(6, 2)
+	ctx := NewRenderContext(before, 6, 2, nil, nil, BuildContext{}, nil)
+	ctx.DrawText(0, 0, "abcdef")
+	ctx.DrawText(0, 1, "ghijkl")
+	hold := newResizeHold(ResizeFreeze, before, 6, 2)
+	ctx.DrawText(0, 0, "later!")
+
+	smaller := uv.NewBuffer(4, 1)
+	hold.draw(smaller, 4, 1)
+	assert.Equal(t, "abcd", bufferLine(smaller, 0, 4))
+
+	larger := uv.NewBuffer(8, 3)
+	hold.draw(larger, 8, 3)
+	assert.Equal(t, "abcdef  ", bufferLine(larger, 0, 8))
+	assert.Equal(t, "ghijkl  ", bufferLine(larger, 1, 8))
+	assert.Equal(t, strings.Repeat(" ", 8), bufferLine(larger, 2, 8))
+}
+
+func TestResizeHold_PlaceholderShowsNewSize(t *testing.T) {
+	hold := newResizeHold(ResizePlaceholder, uv.NewBuffer(4, 1), 4, 1)
+	buf := uv.NewBuffer(20, 5)
+	hold.draw(buf, 20, 5)
+	assert.Equal(t, "        20×5        ", bufferLine(buf, 2, 20))
+	assert.Equal(t, strings.Repeat(" ", 20), bufferLine(buf, 0, 20))
+}
+
+func TestSetResizeSettle_ZeroRestoresDefault(t *testing.T) {
+	t.Cleanup(func() { SetResizeSettle(0) })
+	SetResizeSettle(40 * time.Millisecond)
+	assert.Equal(t, 40*time.Millisecond, currentResizeSettle())
+	SetResizeSettle(0)
+	assert.Equal(t, DefaultResizeSettle, currentResizeSettle())
+}
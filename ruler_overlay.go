@@ -0,0 +1,257 @@
+package terma
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+var rulerOverlayEnabled atomic.Bool
+
+// EnableRulerOverlay draws a cell grid, rulers along the top and left
+// edges, and guides over every frame, to check spacing in demos cell by
+// cell. Press on a ruler to pull out a guide, drag guides to move them and
+// drag them back onto their ruler to remove them. The readout in the
+// bottom-right corner shows the pointer position and its distance from
+// the nearest guides. Ctrl+Shift+G toggles the overlay while the app runs;
+// apps enable it at startup when TERMA_DEBUG_RULERS is set.
+func EnableRulerOverlay() {
+	rulerOverlayEnabled.Store(true)
+}
+
+// Alphas of the tints the overlay blends into cell backgrounds.
+const (
+	rulerGridAlpha  = 0.06
+	rulerGuideAlpha = 0.35
+)
+
+// rulerGuide is a guide line at a screen column (vertical) or row.
+type rulerGuide struct {
+	vertical bool
+	pos      int
+}
+
+// rulerOverlay holds the guides and pointer position of the ruler overlay.
+// It is owned by the app's event loop.
+type rulerOverlay struct {
+	guides       []rulerGuide
+	dragging     int // Index of the guide being dragged, or -1
+	pointerX     int
+	pointerY     int
+	pointerKnown bool
+}
+
+func newRulerOverlay() *rulerOverlay {
+	return &rulerOverlay{dragging: -1}
+}
+
+// toggle shows or hides the overlay.
+func (o *rulerOverlay) toggle() {
+	rulerOverlayEnabled.Store(!rulerOverlayEnabled.Load())
+	o.dragging = -1
+}
+
+// rulerWidth returns the width of the left ruler for a screen height.
+func rulerWidth(height int) int {
+	return len(strconv.Itoa(max(0, height-1)))
+}
+
+// handlePress starts dragging a guide: a new one when pressing on a ruler,
+// or an existing one under the pointer. It returns true when the overlay
+// took the press, which then doesn't reach the widgets below.
+func (o *rulerOverlay) handlePress(x, y, height int) bool {
+	if !rulerOverlayEnabled.Load() {
+		return false
+	}
+	o.trackPointer(x, y)
+	switch {
+	case y == 0:
+		o.guides = append(o.guides, rulerGuide{vertical: true, pos: x})
+		o.dragging = len(o.guides) - 1
+	case x < rulerWidth(height):
+		o.guides = append(o.guides, rulerGuide{pos: y})
+		o.dragging = len(o.guides) - 1
+	default:
+		o.dragging = o.guideAt(x, y)
+	}
+	return o.dragging >= 0
+}
+
+// handleMotion moves the guide being dragged. It returns true when the
+// overlay needs redrawing.
+func (o *rulerOverlay) handleMotion(x, y int) bool {
+	if !rulerOverlayEnabled.Load() {
+		return false
+	}
+	o.trackPointer(x, y)
+	if o.dragging >= 0 {
+		guide := &o.guides[o.dragging]
+		if guide.vertical {
+			guide.pos = x
+		} else {
+			guide.pos = y
+		}
+	}
+	return true
+}
+
+// handleRelease drops the guide being dragged, removing it when it was
+// dropped back on its ruler. It returns true when the overlay took the
+// release.
+func (o *rulerOverlay) handleRelease(x, y, height int) bool {
+	if !rulerOverlayEnabled.Load() || o.dragging < 0 {
+		return false
+	}
+	o.handleMotion(x, y)
+	guide := o.guides[o.dragging]
+	if (guide.vertical && y == 0) || (!guide.vertical && x < rulerWidth(height)) {
+		o.guides = append(o.guides[:o.dragging], o.guides[o.dragging+1:]...)
+	}
+	o.dragging = -1
+	return true
+}
+
+func (o *rulerOverlay) trackPointer(x, y int) {
+	o.pointerX, o.pointerY, o.pointerKnown = x, y, true
+}
+
+// guideAt returns the index of the topmost guide through (x, y), or -1.
+func (o *rulerOverlay) guideAt(x, y int) int {
+	for i := len(o.guides) - 1; i >= 0; i-- {
+		guide := o.guides[i]
+		if (guide.vertical && guide.pos == x) || (!guide.vertical && guide.pos == y) {
+			return i
+		}
+	}
+	return -1
+}
+
+// draw paints the grid, guides, rulers and readout on top of the frame.
+func (o *rulerOverlay) draw(terminal CellBuffer, width, height int) {
+	if !rulerOverlayEnabled.Load() || width <= 0 || height <= 0 {
+		return
+	}
+	theme := getTheme()
+
+	// Checkerboard grid, so individual cells can be counted.
+	grid := theme.Text.WithAlpha(rulerGridAlpha)
+	for y := 0; y < height; y++ {
+		for x := (y & 1); x < width; x += 2 {
+			tintCell(terminal, x, y, grid)
+		}
+	}
+
+	guideTint := theme.Accent.WithAlpha(rulerGuideAlpha)
+	for _, guide := range o.guides {
+		if guide.vertical {
+			for y := 0; y < height; y++ {
+				tintCell(terminal, guide.pos, y, guideTint)
+			}
+		} else {
+			for x := 0; x < width; x++ {
+				tintCell(terminal, x, guide.pos, guideTint)
+			}
+		}
+	}
+
+	ctx := NewRenderContext(terminal, width, height, nil, nil, BuildContext{}, nil)
+	rulerStyle := Style{ForegroundColor: theme.TextMuted, BackgroundColor: theme.Surface}
+	markStyle := Style{ForegroundColor: theme.Accent, BackgroundColor: theme.Surface, Bold: true}
+
+	// Top ruler: a tick every 5 columns, the column number every 10.
+	left := rulerWidth(height)
+	for x := left; x < width; x++ {
+		ctx.DrawStyledText(x, 0, rulerTick(x, 5), rulerStyle)
+	}
+	for x := (left + 9) / 10 * 10; x < width; x += 10 {
+		ctx.DrawStyledText(x, 0, strconv.Itoa(x), rulerStyle)
+	}
+	// Left ruler: the row number every 5 rows.
+	for y := 0; y < height; y++ {
+		label := fmt.Sprintf("%*s", left, rulerTick(y, 5))
+		if y%5 == 0 {
+			label = fmt.Sprintf("%*d", left, y)
+		}
+		ctx.DrawStyledText(0, y, label, rulerStyle)
+	}
+	for _, guide := range o.guides {
+		if guide.vertical {
+			ctx.DrawStyledText(guide.pos, 0, "▼", markStyle)
+		} else {
+			ctx.DrawStyledText(left-1, guide.pos, "▶", markStyle)
+		}
+	}
+
+	if o.pointerKnown {
+		readout := " " + o.readout() + " "
+		x := max(0, width-len([]rune(readout)))
+		ctx.DrawStyledText(x, height-1, readout, Style{
+			ForegroundColor: theme.TextOnPrimary,
+			BackgroundColor: theme.Primary,
+		})
+	}
+}
+
+// readout describes the pointer position and its offset from the nearest
+// vertical and horizontal guides.
+func (o *rulerOverlay) readout() string {
+	text := fmt.Sprintf("x %d y %d", o.pointerX, o.pointerY)
+	if guide, ok := o.nearestGuide(true); ok {
+		text += fmt.Sprintf(" │ x %+d from %d", o.pointerX-guide.pos, guide.pos)
+	}
+	if guide, ok := o.nearestGuide(false); ok {
+		text += fmt.Sprintf(" │ y %+d from %d", o.pointerY-guide.pos, guide.pos)
+	}
+	return text
+}
+
+// nearestGuide returns the vertical or horizontal guide closest to the
+// pointer.
+func (o *rulerOverlay) nearestGuide(vertical bool) (rulerGuide, bool) {
+	pointer := o.pointerY
+	if vertical {
+		pointer = o.pointerX
+	}
+	var nearest rulerGuide
+	found := false
+	for _, guide := range o.guides {
+		if guide.vertical != vertical {
+			continue
+		}
+		if !found || distance(pointer, guide.pos) < distance(pointer, nearest.pos) {
+			nearest, found = guide, true
+		}
+	}
+	return nearest, found
+}
+
+// distance returns how many cells apart a and b are.
+func distance(a, b int) int {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// rulerTick returns the ruler mark for position pos.
+func rulerTick(pos, every int) string {
+	if pos%every == 0 {
+		return "┊"
+	}
+	return "·"
+}
+
+// tintCell blends tint into the background of the cell at (x, y).
+func tintCell(terminal CellBuffer, x, y int, tint Color) {
+	cell := terminal.CellAt(x, y)
+	if cell == nil {
+		return
+	}
+	bg := FromANSI(cell.Style.Bg)
+	if !bg.IsSet() {
+		bg = terminalBackground()
+	}
+	tinted := *cell
+	tinted.Style.Bg = tint.BlendOver(bg).toANSI()
+	terminal.SetCell(x, y, &tinted)
+}
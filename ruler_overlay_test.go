@@ -0,0 +1,66 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+func enableRulersForTest(t *testing.T) *rulerOverlay {
+	EnableRulerOverlay()
+	t.Cleanup(func() { rulerOverlayEnabled.Store(false) })
+	return newRulerOverlay()
+}
+
+func TestRulerOverlay_DisabledIgnoresInput(t *testing.T) {
+	rulers := newRulerOverlay()
+	assert.False(t, rulers.handlePress(5, 0, 20))
+	assert.False(t, rulers.handleMotion(5, 5))
+	assert.Empty(t, rulers.guides)
+}
+
+func TestRulerOverlay_DragGuidesFromRulers(t *testing.T) {
+	rulers := enableRulersForTest(t)
+
+	// Pull a vertical guide out of the top ruler and drop it at column 12.
+	assert.True(t, rulers.handlePress(8, 0, 20))
+	rulers.handleMotion(12, 6)
+	assert.True(t, rulers.handleRelease(12, 6, 20))
+	// Pull a horizontal guide out of the left ruler and drop it at row 4.
+	assert.True(t, rulers.handlePress(0, 7, 20))
+	assert.True(t, rulers.handleRelease(15, 4, 20))
+	assert.Equal(t, []rulerGuide{{vertical: true, pos: 12}, {pos: 4}}, rulers.guides)
+
+	assert.False(t, rulers.handlePress(30, 10, 20), "presses away from rulers and guides reach widgets")
+
+	// Move the vertical guide, then drag the horizontal one back to remove it.
+	assert.True(t, rulers.handlePress(12, 10, 20))
+	rulers.handleRelease(14, 10, 20)
+	assert.True(t, rulers.handlePress(30, 4, 20))
+	rulers.handleRelease(1, 4, 20)
+	assert.Equal(t, []rulerGuide{{vertical: true, pos: 14}}, rulers.guides)
+
+	rulers.handleMotion(17, 9)
+	assert.Equal(t, "x 17 y 9 │ x +3 from 14", rulers.readout())
+}
+
+func TestRulerOverlay_Draw(t *testing.T) {
+	rulers := enableRulersForTest(t)
+	rulers.guides = []rulerGuide{{vertical: true, pos: 15}}
+	rulers.handleMotion(3, 3)
+
+	buf := uv.NewBuffer(30, 12)
+	rulers.draw(buf, 30, 12)
+
+	assert.Equal(t, "1", buf.CellAt(10, 0).Content, "column numbers every 10")
+	assert.Equal(t, "0", buf.CellAt(11, 0).Content)
+	assert.Equal(t, "▼", buf.CellAt(15, 0).Content, "guide marker")
+	assert.Equal(t, "┊", buf.CellAt(5, 0).Content)
+	assert.Equal(t, "1", buf.CellAt(0, 10).Content, "row numbers every 5")
+	assert.Equal(t, "0", buf.CellAt(1, 10).Content)
+	assert.NotEqual(t, buf.CellAt(14, 5).Style.Bg, buf.CellAt(15, 5).Style.Bg, "guide line is tinted")
+	assert.NotEqual(t, buf.CellAt(4, 5).Style.Bg, buf.CellAt(5, 5).Style.Bg, "checkerboard grid")
+	assert.Equal(t, "x 3 y 3 │ x -12 from 15", rulers.readout())
+	assert.Equal(t, "5", buf.CellAt(28, 11).Content, "readout in the bottom-right corner")
+}
@@ -0,0 +1,288 @@
+package terma
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultToastDuration      = 4 * time.Second
+	defaultScaffoldSidebarKey = "ctrl+b"
+	defaultScaffoldPaletteKey = "ctrl+p"
+	defaultScaffoldSidebar    = 30
+	maxScaffoldToasts         = 3
+)
+
+// Toast is a short message shown in the corner of a Scaffold, above the
+// status bar, that disappears on its own.
+type Toast struct {
+	Message  string        // The text shown
+	Color    Color         // Optional accent bar color (default: theme.Primary)
+	Duration time.Duration // How long it shows (default 4 seconds)
+}
+
+// scaffoldToast is a toast being shown.
+type scaffoldToast struct {
+	Toast
+	id int
+}
+
+// ScaffoldState holds the sidebar and toasts of a Scaffold.
+type ScaffoldState struct {
+	SidebarCollapsed Signal[bool]
+	toasts           AnySignal[[]scaffoldToast]
+
+	mu     sync.Mutex
+	nextID int
+}
+
+// NewScaffoldState creates a state with the sidebar expanded and no toasts.
+func NewScaffoldState() *ScaffoldState {
+	return &ScaffoldState{
+		SidebarCollapsed: NewSignal(false),
+		toasts:           NewAnySignal([]scaffoldToast(nil)),
+	}
+}
+
+// ToggleSidebar collapses or expands the sidebar.
+func (s *ScaffoldState) ToggleSidebar() {
+	s.SidebarCollapsed.Update(func(collapsed bool) bool { return !collapsed })
+}
+
+// Toast shows message in a toast for the default duration.
+//
+// Example:
+//
+//	a.shell.Toast("Saved " + name)
+func (s *ScaffoldState) Toast(message string) {
+	s.ShowToast(Toast{Message: message})
+}
+
+// ShowToast shows a toast, newest at the bottom. Only the three newest are
+// shown; older ones are dismissed early. Safe to call from any goroutine.
+func (s *ScaffoldState) ShowToast(toast Toast) {
+	if toast.Duration <= 0 {
+		toast.Duration = defaultToastDuration
+	}
+	s.mu.Lock()
+	s.nextID++
+	id := s.nextID
+	s.mu.Unlock()
+
+	s.toasts.Update(func(toasts []scaffoldToast) []scaffoldToast {
+		toasts = append(append([]scaffoldToast(nil), toasts...), scaffoldToast{Toast: toast, id: id})
+		if len(toasts) > maxScaffoldToasts {
+			toasts = toasts[len(toasts)-maxScaffoldToasts:]
+		}
+		return toasts
+	})
+	currentClock().AfterFunc(toast.Duration, func() { s.dismissToast(id) })
+}
+
+// Toasts returns the messages of the toasts being shown, oldest first.
+func (s *ScaffoldState) Toasts() []string {
+	toasts := s.toasts.Peek()
+	messages := make([]string, len(toasts))
+	for i, toast := range toasts {
+		messages[i] = toast.Message
+	}
+	return messages
+}
+
+// dismissToast removes the toast with the given id if it is still shown.
+func (s *ScaffoldState) dismissToast(id int) {
+	s.toasts.Update(func(toasts []scaffoldToast) []scaffoldToast {
+		kept := make([]scaffoldToast, 0, len(toasts))
+		for _, toast := range toasts {
+			if toast.id != id {
+				kept = append(kept, toast)
+			}
+		}
+		return kept
+	})
+}
+
+// Scaffold is an app shell: a title bar across the top, an optional
+// collapsible sidebar beside the body, and a status bar and KeybindBar
+// along the bottom. It also mounts a CommandPalette and shows toasts, so a
+// new app only has to fill in the slots.
+//
+// Without a TitleBar, one is made from Title, Subtitle and Actions, with a
+// menu trigger that toggles the sidebar. The sidebar and palette keys work
+// from anywhere inside the Scaffold.
+//
+// Example:
+//
+//	func (a *App) Build(ctx t.BuildContext) t.Widget {
+//	    return t.Scaffold{
+//	        State:     a.shell,
+//	        Title:     "Mail",
+//	        Subtitle:  a.folder.Get(),
+//	        Sidebar:   a.folders(),
+//	        Body:      a.messages(),
+//	        StatusBar: t.Text{Content: a.syncStatus.Get()},
+//	        Palette:   a.palette,
+//	    }
+//	}
+type Scaffold struct {
+	ID             string                        // Optional unique identifier
+	State          *ScaffoldState                // Optional - needed to collapse the sidebar and show toasts
+	Title          string                        // Title of the default title bar
+	Subtitle       string                        // Optional text shown muted after the title
+	Actions        []Widget                      // Optional widgets on the right of the default title bar
+	TitleBar       Widget                        // Optional - replaces the default title bar
+	Sidebar        Widget                        // Optional pane left of the body
+	SidebarWidth   Dimension                     // Sidebar width (default = Cells(30))
+	SidebarKey     string                        // Collapses/expands the sidebar (default = "ctrl+b")
+	Body           Widget                        // The app content, filling the remaining space
+	StatusBar      Widget                        // Optional line above the KeybindBar
+	HideKeybindBar bool                          // If true, don't show the KeybindBar
+	Palette        *CommandPaletteState          // Optional command palette to mount
+	PaletteKey     string                        // Opens/closes the palette (default = "ctrl+p")
+	OnCommand      func(item CommandPaletteItem) // Optional palette selection handler (default: the item's Action)
+	Style          Style                         // Optional styling
+}
+
+// WidgetID returns the widget's unique identifier.
+func (s Scaffold) WidgetID() string {
+	return s.ID
+}
+
+// Keybinds returns the sidebar and command palette bindings.
+func (s Scaffold) Keybinds() []Keybind {
+	var keybinds []Keybind
+	if s.Palette != nil {
+		keybinds = append(keybinds, Keybind{Key: s.paletteKey(), Name: "Commands", Action: s.togglePalette})
+	}
+	if s.Sidebar != nil && s.State != nil {
+		keybinds = append(keybinds, Keybind{Key: s.sidebarKey(), Name: "Sidebar", Action: s.State.ToggleSidebar})
+	}
+	return keybinds
+}
+
+// Build returns a Dock of the bars, sidebar and body, in a Stack with the
+// toasts and command palette above it.
+func (s Scaffold) Build(ctx BuildContext) Widget {
+	theme := ctx.Theme()
+
+	style := s.Style
+	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
+		style.BackgroundColor = theme.Background
+	}
+	if style.Width.IsUnset() {
+		style.Width = Flex(1)
+	}
+	if style.Height.IsUnset() {
+		style.Height = Flex(1)
+	}
+
+	body := s.Body
+	if body == nil {
+		body = EmptyWidget{}
+	}
+	dock := Dock{Body: body}
+	if titleBar := s.titleBar(); titleBar != nil {
+		dock.Top = []Widget{titleBar}
+	}
+	if !s.HideKeybindBar {
+		dock.Bottom = append(dock.Bottom, KeybindBar{Style: Style{BackgroundColor: theme.Surface, Padding: EdgeInsetsXY(1, 0), Width: Flex(1)}})
+	}
+	if s.StatusBar != nil {
+		dock.Bottom = append(dock.Bottom, Row{
+			Style:    Style{ForegroundColor: theme.TextMuted, Padding: EdgeInsetsXY(1, 0), Width: Flex(1)},
+			Children: []Widget{s.StatusBar},
+		})
+	}
+	if s.Sidebar != nil && (s.State == nil || !s.State.SidebarCollapsed.Get()) {
+		width := s.SidebarWidth
+		if width.IsUnset() {
+			width = Cells(defaultScaffoldSidebar)
+		}
+		dock.Left = []Widget{Column{
+			Style: Style{
+				Width:  width,
+				Height: Flex(1),
+				Border: Border{Style: BorderSquare, Sides: BorderSideRight, Color: theme.Border},
+			},
+			Children: []Widget{s.Sidebar},
+		}}
+	}
+
+	children := []Widget{dock}
+	if s.State != nil {
+		if toasts := s.State.toasts.Get(); len(toasts) > 0 {
+			children = append(children, Positioned{
+				Right:  IntPtr(1),
+				Bottom: IntPtr(len(dock.Bottom)),
+				ZIndex: 1,
+				Child:  s.buildToasts(theme, toasts),
+			})
+		}
+	}
+	if s.Palette != nil {
+		children = append(children, CommandPalette{State: s.Palette, OnSelect: s.OnCommand})
+	}
+	return Stack{ID: s.ID, Style: style, Children: children}
+}
+
+// titleBar returns the TitleBar slot, the default title bar, or nil if
+// there is no title.
+func (s Scaffold) titleBar() Widget {
+	if s.TitleBar != nil {
+		return s.TitleBar
+	}
+	if s.Title == "" && s.Subtitle == "" && len(s.Actions) == 0 {
+		return nil
+	}
+	bar := TitleBar{Title: s.Title, Subtitle: s.Subtitle, Actions: s.Actions}
+	if s.Sidebar != nil && s.State != nil {
+		bar.OnMenu = s.State.ToggleSidebar
+	}
+	return bar
+}
+
+// buildToasts stacks the toasts, each with an accent bar on its left.
+// Clicking a toast dismisses it.
+func (s Scaffold) buildToasts(theme ThemeData, toasts []scaffoldToast) Widget {
+	rows := make([]Widget, len(toasts))
+	for i, toast := range toasts {
+		accent := toast.Color
+		if !accent.IsSet() {
+			accent = theme.Primary
+		}
+		id := toast.id
+		rows[i] = Text{
+			Spans: []Span{ColorSpan("▌ ", accent), PlainSpan(toast.Message)},
+			Style: Style{
+				BackgroundColor: theme.Token(TokenSurfaceFloating),
+				ForegroundColor: theme.Text,
+				Padding:         EdgeInsets{Right: 2},
+				MaxWidth:        Cells(50),
+			},
+			Click: func(MouseEvent) { s.State.dismissToast(id) },
+		}
+	}
+	return Column{Spacing: 1, CrossAlign: CrossAxisEnd, Children: rows}
+}
+
+func (s Scaffold) togglePalette() {
+	if s.Palette.Visible.Peek() {
+		s.Palette.Close(false)
+		return
+	}
+	s.Palette.Open()
+}
+
+func (s Scaffold) sidebarKey() string {
+	if s.SidebarKey != "" {
+		return s.SidebarKey
+	}
+	return defaultScaffoldSidebarKey
+}
+
+func (s Scaffold) paletteKey() string {
+	if s.PaletteKey != "" {
+		return s.PaletteKey
+	}
+	return defaultScaffoldPaletteKey
+}
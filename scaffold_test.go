@@ -0,0 +1,75 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaffold_Layout(t *testing.T) {
+	state := NewScaffoldState()
+	scaffold := Scaffold{
+		State:        state,
+		Title:        "Mail",
+		Subtitle:     "Inbox",
+		Sidebar:      Text{Content: "Folders"},
+		SidebarWidth: Cells(10),
+		Body:         Text{Content: "body"},
+		StatusBar:    Text{Content: "synced"},
+	}
+
+	lines := strings.Split(screenText(scaffold, 30, 6), "\n")
+	assert.Equal(t, " ≡ Mail  Inbox", strings.TrimRight(lines[0], " "))
+	assert.Equal(t, "Folders   │body", strings.TrimRight(lines[1], " "))
+	assert.Equal(t, " synced", strings.TrimRight(lines[4], " "), "the status bar sits above the keybind bar")
+	assert.Equal(t, " ctrl+b Sidebar", strings.TrimRight(lines[5], " "))
+
+	state.ToggleSidebar()
+	lines = strings.Split(screenText(scaffold, 30, 6), "\n")
+	assert.Equal(t, "body", strings.TrimRight(lines[1], " "), "the body fills the collapsed sidebar's space")
+}
+
+func TestScaffold_Keybinds(t *testing.T) {
+	palette := NewCommandPaletteState("Commands", nil)
+	state := NewScaffoldState()
+	scaffold := Scaffold{State: state, Sidebar: Text{Content: "Folders"}, Palette: palette, PaletteKey: "f1"}
+
+	keybinds := scaffold.Keybinds()
+	assert.Equal(t, "f1", keybinds[0].Key)
+	assert.Equal(t, "ctrl+b", keybinds[1].Key)
+
+	keybinds[0].Action()
+	assert.True(t, palette.Visible.Peek())
+	keybinds[0].Action()
+	assert.False(t, palette.Visible.Peek())
+
+	keybinds[1].Action()
+	assert.True(t, state.SidebarCollapsed.Peek())
+
+	assert.Empty(t, Scaffold{Sidebar: Text{Content: "Folders"}}.Keybinds(), "the sidebar can't collapse without State")
+}
+
+func TestScaffold_Toasts(t *testing.T) {
+	clock := NewManualClock(time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC))
+	SetClock(clock)
+	t.Cleanup(func() { SetClock(nil) })
+
+	state := NewScaffoldState()
+	state.Toast("one")
+	state.ShowToast(Toast{Message: "two", Duration: 10 * time.Second})
+	assert.Equal(t, []string{"one", "two"}, state.Toasts())
+
+	lines := strings.Split(screenText(Scaffold{State: state, HideKeybindBar: true}, 20, 4), "\n")
+	assert.Equal(t, "            ▌ one   ", lines[1])
+	assert.Equal(t, "            ▌ two   ", lines[3])
+
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, []string{"two"}, state.Toasts(), "toasts disappear after their duration")
+
+	state.Toast("three")
+	state.Toast("four")
+	state.Toast("five")
+	assert.Equal(t, []string{"three", "four", "five"}, state.Toasts(), "only the newest toasts are kept")
+}
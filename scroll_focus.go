@@ -0,0 +1,71 @@
+package terma
+
+import "sync/atomic"
+
+// ScrollFocusPolicy controls which Scrollable the scroll keys reach. The
+// mouse wheel always scrolls the Scrollable under the pointer.
+type ScrollFocusPolicy int
+
+const (
+	// ScrollFocusKeyboard sends scroll keys only to the focused Scrollable
+	// (default).
+	ScrollFocusKeyboard ScrollFocusPolicy = iota
+	// ScrollFocusFollowsMouse makes the Scrollable under the pointer active
+	// for scrolling without taking keyboard focus: arrow, page, home and end
+	// keys that the focused widget leaves unhandled scroll it, and its
+	// scrollbar is drawn as focused. Scrollables no longer need focus (or
+	// DisableFocus workarounds) to be scrolled from the keyboard.
+	ScrollFocusFollowsMouse
+)
+
+var scrollFocusPolicy atomic.Int32
+
+// SetScrollFocusPolicy sets which Scrollable the scroll keys reach.
+func SetScrollFocusPolicy(policy ScrollFocusPolicy) {
+	scrollFocusPolicy.Store(int32(policy))
+}
+
+func scrollFollowsMouse() bool {
+	return ScrollFocusPolicy(scrollFocusPolicy.Load()) == ScrollFocusFollowsMouse
+}
+
+// dispatchScrollKey sends a key nothing else handled to the Scrollables
+// under the pointer, innermost first, under ScrollFocusFollowsMouse.
+// Keys that type text, like j and k, are left alone.
+func dispatchScrollKey(renderer *Renderer, x, y int, event KeyEvent) bool {
+	if renderer == nil || !scrollFollowsMouse() || event.Text() != "" {
+		return false
+	}
+	for _, scrollable := range renderer.ScrollablesAt(x, y) {
+		if !scrollable.DisableScroll && scrollable.OnKey(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// scrollTargetID returns the ID of the innermost Scrollable enclosing the
+// hovered widget, which is active for scrolling under
+// ScrollFocusFollowsMouse.
+func (r *Renderer) scrollTargetID(tree RenderTree) string {
+	if !scrollFollowsMouse() || r.pointer.hoveredID == "" {
+		return ""
+	}
+	var find func(node RenderTree, enclosing string) (string, bool)
+	find = func(node RenderTree, enclosing string) (string, bool) {
+		if scrollable, ok := node.Widget.(Scrollable); ok && !scrollable.DisableScroll {
+			enclosing = node.EventID
+		}
+		if node.EventID == r.pointer.hoveredID {
+			return enclosing, true
+		}
+		for _, child := range node.Children {
+			if id, found := find(child, enclosing); found {
+				return id, true
+			}
+		}
+		return "", false
+	}
+	id, _ := find(tree, "")
+	return id
+}
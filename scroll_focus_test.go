@@ -0,0 +1,70 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+func scrollFocusTestRenderer(t *testing.T, policy ScrollFocusPolicy) (*Renderer, *uv.Buffer, *ScrollState, Widget) {
+	SetScrollFocusPolicy(policy)
+	t.Cleanup(func() { SetScrollFocusPolicy(ScrollFocusKeyboard) })
+
+	state := NewScrollState()
+	widget := Row{
+		Children: []Widget{
+			Button{ID: "button", Label: "Go"},
+			Scrollable{
+				ID:     "log",
+				State:  state,
+				Height: Cells(3),
+				Width:  Cells(10),
+				Child:  Text{ID: "lines", Content: strings.Repeat("line\n", 9) + "line"},
+			},
+		},
+	}
+	renderer, buf := portalTestRenderer(20, 3)
+	renderer.Render(widget)
+	return renderer, buf, state, widget
+}
+
+func TestDispatchScrollKey_ScrollsUnderPointer(t *testing.T) {
+	renderer, _, state, _ := scrollFocusTestRenderer(t, ScrollFocusFollowsMouse)
+
+	assert.True(t, dispatchScrollKey(renderer, 12, 1, makeKeyEvent(uv.KeyPgDown, 0)))
+	assert.Equal(t, 3, state.GetOffset())
+	assert.True(t, dispatchScrollKey(renderer, 12, 1, makeKeyEvent(uv.KeyUp, 0)))
+	assert.Equal(t, 2, state.GetOffset())
+
+	assert.False(t, dispatchScrollKey(renderer, 12, 1, makeCharEvent('j')), "keys that type text are left alone")
+	assert.False(t, dispatchScrollKey(renderer, 0, 0, makeKeyEvent(uv.KeyDown, 0)), "no Scrollable under the pointer")
+	assert.Equal(t, 2, state.GetOffset())
+}
+
+func TestDispatchScrollKey_KeyboardPolicy(t *testing.T) {
+	renderer, _, state, _ := scrollFocusTestRenderer(t, ScrollFocusKeyboard)
+
+	assert.False(t, dispatchScrollKey(renderer, 12, 1, makeKeyEvent(uv.KeyPgDown, 0)))
+	assert.Equal(t, 0, state.GetOffset())
+}
+
+func TestScrollTarget_FollowsHoveredWidget(t *testing.T) {
+	renderer, buf, _, widget := scrollFocusTestRenderer(t, ScrollFocusFollowsMouse)
+	unfocusedThumb := buf.CellAt(13, 0).Style
+
+	renderer.setPointerState("lines", "")
+	renderer.Render(widget)
+	assert.Equal(t, "log", renderer.scrollTarget)
+	assert.NotEqual(t, unfocusedThumb, buf.CellAt(13, 0).Style, "the active Scrollable draws its scrollbar as focused")
+
+	renderer.setPointerState("button", "")
+	renderer.Render(widget)
+	assert.Empty(t, renderer.scrollTarget)
+
+	SetScrollFocusPolicy(ScrollFocusKeyboard)
+	renderer.setPointerState("lines", "")
+	renderer.Render(widget)
+	assert.Empty(t, renderer.scrollTarget)
+}
@@ -0,0 +1,618 @@
+package terma
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ListSection is a titled group of items in a SectionList.
+type ListSection[T any] struct {
+	Title string
+	Items []T
+}
+
+// SectionPosition identifies a row of a SectionList. Item is the index
+// within the section, or -1 for the section header.
+type SectionPosition struct {
+	Section int
+	Item    int
+}
+
+// SectionListState holds the state for a SectionList widget.
+// The cursor only rests on items, and on the headers of collapsed sections
+// so that they can be expanded again.
+type SectionListState[T any] struct {
+	Sections  AnySignal[[]ListSection[T]] // Reactive section data
+	Cursor    Signal[SectionPosition]     // Cursor position
+	Collapsed AnySignal[map[int]struct{}] // Indices of collapsed sections
+
+	rows        []SectionPosition // Row positions from the last layout
+	rowLayouts  []listItemLayout  // Cached layout metrics (per row)
+	jumpSection int               // Section to scroll to the top on the next layout (-1 = none)
+}
+
+// NewSectionListState creates a new SectionListState with the given sections.
+func NewSectionListState[T any](sections []ListSection[T]) *SectionListState[T] {
+	if sections == nil {
+		sections = []ListSection[T]{}
+	}
+	s := &SectionListState[T]{
+		Sections:    NewAnySignal(sections),
+		Cursor:      NewSignal(SectionPosition{}),
+		Collapsed:   NewAnySignal(make(map[int]struct{})),
+		jumpSection: -1,
+	}
+	s.clampCursor()
+	return s
+}
+
+// SetSections replaces all sections and moves the cursor to the nearest
+// remaining row.
+func (s *SectionListState[T]) SetSections(sections []ListSection[T]) {
+	if sections == nil {
+		sections = []ListSection[T]{}
+	}
+	s.Sections.Set(sections)
+	s.clampCursor()
+}
+
+// GetSections returns the current sections (without subscribing).
+func (s *SectionListState[T]) GetSections() []ListSection[T] {
+	return s.Sections.Peek()
+}
+
+// SelectedItem returns the item at the cursor. Returns false if the cursor
+// is on a header or the list is empty.
+func (s *SectionListState[T]) SelectedItem() (T, bool) {
+	var zero T
+	pos := s.Cursor.Peek()
+	sections := s.Sections.Peek()
+	if pos.Section < 0 || pos.Section >= len(sections) {
+		return zero, false
+	}
+	items := sections[pos.Section].Items
+	if pos.Item < 0 || pos.Item >= len(items) {
+		return zero, false
+	}
+	return items[pos.Item], true
+}
+
+// SelectNext moves the cursor to the next item, skipping headers.
+func (s *SectionListState[T]) SelectNext() {
+	s.moveCursor(1)
+}
+
+// SelectPrevious moves the cursor to the previous item, skipping headers.
+func (s *SectionListState[T]) SelectPrevious() {
+	s.moveCursor(-1)
+}
+
+// SelectFirst moves the cursor to the first row.
+func (s *SectionListState[T]) SelectFirst() {
+	if stops := s.stops(); len(stops) > 0 {
+		s.Cursor.Set(stops[0])
+	}
+}
+
+// SelectLast moves the cursor to the last row.
+func (s *SectionListState[T]) SelectLast() {
+	if stops := s.stops(); len(stops) > 0 {
+		s.Cursor.Set(stops[len(stops)-1])
+	}
+}
+
+// IsCollapsed reports whether the section at index is collapsed.
+func (s *SectionListState[T]) IsCollapsed(section int) bool {
+	_, ok := s.Collapsed.Peek()[section]
+	return ok
+}
+
+// Collapse hides the items of a section. A cursor inside it moves to its header.
+func (s *SectionListState[T]) Collapse(section int) {
+	if s.IsCollapsed(section) {
+		return
+	}
+	next := make(map[int]struct{}, len(s.Collapsed.Peek())+1)
+	for idx := range s.Collapsed.Peek() {
+		next[idx] = struct{}{}
+	}
+	next[section] = struct{}{}
+	s.Collapsed.Set(next)
+	if s.Cursor.Peek().Section == section {
+		s.Cursor.Set(SectionPosition{Section: section, Item: -1})
+	}
+}
+
+// Expand shows the items of a section. A cursor on its header moves to its
+// first item.
+func (s *SectionListState[T]) Expand(section int) {
+	if !s.IsCollapsed(section) {
+		return
+	}
+	next := make(map[int]struct{}, len(s.Collapsed.Peek()))
+	for idx := range s.Collapsed.Peek() {
+		if idx != section {
+			next[idx] = struct{}{}
+		}
+	}
+	s.Collapsed.Set(next)
+	if s.Cursor.Peek() == (SectionPosition{Section: section, Item: -1}) {
+		s.Cursor.Set(SectionPosition{Section: section, Item: 0})
+		s.clampCursor()
+	}
+}
+
+// ToggleSection collapses an expanded section or expands a collapsed one.
+func (s *SectionListState[T]) ToggleSection(section int) {
+	if s.IsCollapsed(section) {
+		s.Expand(section)
+	} else {
+		s.Collapse(section)
+	}
+}
+
+// JumpToSection moves the cursor to the first row of a section and, once
+// laid out, scrolls its header to the top of the viewport.
+func (s *SectionListState[T]) JumpToSection(section int) {
+	for _, stop := range s.stops() {
+		if stop.Section == section {
+			s.Cursor.Set(stop)
+			s.jumpSection = section
+			return
+		}
+	}
+}
+
+// stops returns the positions the cursor can rest on, in display order.
+func (s *SectionListState[T]) stops() []SectionPosition {
+	sections := s.Sections.Peek()
+	collapsed := s.Collapsed.Peek()
+	var stops []SectionPosition
+	for i, section := range sections {
+		if _, ok := collapsed[i]; ok {
+			stops = append(stops, SectionPosition{Section: i, Item: -1})
+			continue
+		}
+		for j := range section.Items {
+			stops = append(stops, SectionPosition{Section: i, Item: j})
+		}
+	}
+	return stops
+}
+
+// moveCursor moves the cursor delta stops, stopping at either end.
+func (s *SectionListState[T]) moveCursor(delta int) {
+	stops := s.stops()
+	if len(stops) == 0 {
+		return
+	}
+	current := stopIndex(stops, s.Cursor.Peek())
+	s.Cursor.Set(stops[clampInt(current+delta, 0, len(stops)-1)])
+}
+
+// clampCursor moves a cursor that isn't on a stop to the closest stop
+// before it, or the first stop if there is none.
+func (s *SectionListState[T]) clampCursor() {
+	stops := s.stops()
+	if len(stops) == 0 {
+		return
+	}
+	if next := stops[stopIndex(stops, s.Cursor.Peek())]; next != s.Cursor.Peek() {
+		s.Cursor.Set(next)
+	}
+}
+
+// stopIndex returns the index of the last stop at or before pos, or 0.
+func stopIndex(stops []SectionPosition, pos SectionPosition) int {
+	idx := 0
+	for i, stop := range stops {
+		if stop.Section > pos.Section || (stop.Section == pos.Section && stop.Item > pos.Item) {
+			break
+		}
+		idx = i
+	}
+	return idx
+}
+
+// SectionList displays items grouped into titled sections.
+// Headers can stay pinned to the top of the viewport while their section
+// scrolls past, sections collapse to their header, and an index down the
+// right-hand side jumps between sections.
+//
+// Like List, it should be wrapped in a Scrollable sharing its ScrollState;
+// StickyHeaders and ShowIndex need the ScrollState to track the viewport.
+//
+// Example:
+//
+//	state := terma.NewSectionListState([]terma.ListSection[string]{
+//	    {Title: "A", Items: []string{"Ada", "Alan"}},
+//	    {Title: "B", Items: []string{"Barbara"}},
+//	})
+//	scroll := terma.NewScrollState()
+//	terma.Scrollable{
+//	    State: scroll,
+//	    Child: terma.SectionList[string]{
+//	        State:         state,
+//	        ScrollState:   scroll,
+//	        StickyHeaders: true,
+//	        ShowIndex:     true,
+//	    },
+//	}
+//
+// Keys: up/down (j/k) move between items, left/right (h/l) collapse and
+// expand the cursor's section, [ and ] jump to the previous and next
+// section, and enter selects.
+type SectionList[T any] struct {
+	ID             string                                                           // Optional unique identifier
+	DisableFocus   bool                                                             // If true, prevent keyboard focus
+	CursorStyle                                                                     // Embedded - CursorPrefix field for the cursor indicator
+	State          *SectionListState[T]                                             // Required - holds sections and cursor position
+	ScrollState    *ScrollState                                                     // Optional state for scroll-into-view, sticky headers and the index
+	OnSelect       func(item T)                                                     // Callback invoked when Enter is pressed on an item
+	OnCursorChange func(item T)                                                     // Callback invoked when the cursor moves to a different item
+	RenderItem     func(item T, active bool) Widget                                 // Function to render each item (uses default if nil)
+	RenderHeader   func(section ListSection[T], collapsed bool, active bool) Widget // Function to render section headers (uses default if nil)
+	StickyHeaders  bool                                                             // Keep the current section's header at the top of the viewport
+	ShowIndex      bool                                                             // Show a clickable section index down the right-hand side
+	IndexLabel     func(section ListSection[T]) string                              // Index entry for a section (default: first character of the title)
+	Style          Style                                                            // Optional styling
+}
+
+type sectionListContainer[T any] struct {
+	Column
+	list SectionList[T]
+	rows []SectionPosition
+}
+
+func (c sectionListContainer[T]) Build(ctx BuildContext) Widget {
+	return c
+}
+
+func (c sectionListContainer[T]) OnLayout(ctx BuildContext, metrics LayoutMetrics) {
+	s := c.list.State
+	layouts := make([]listItemLayout, metrics.ChildCount())
+	for i := range layouts {
+		if bounds, ok := metrics.ChildBounds(i); ok {
+			layouts[i] = listItemLayout{y: bounds.Y, height: bounds.Height}
+		}
+	}
+	s.rows = c.rows
+	s.rowLayouts = layouts
+
+	if section := s.jumpSection; section >= 0 {
+		s.jumpSection = -1
+		if y, _, ok := c.list.rowLayout(SectionPosition{Section: section, Item: -1}); ok && c.list.ScrollState != nil {
+			c.list.ScrollState.SetOffset(y)
+			return
+		}
+	}
+	c.list.scrollCursorIntoView()
+}
+
+func (c sectionListContainer[T]) ChildWidgets() []Widget {
+	return c.Children
+}
+
+// WidgetID returns the widget's unique identifier.
+// Implements the Identifiable interface.
+func (l SectionList[T]) WidgetID() string {
+	return l.ID
+}
+
+// GetContentDimensions returns the width and height dimension preferences.
+// Implements the Dimensioned interface.
+func (l SectionList[T]) GetContentDimensions() (width, height Dimension) {
+	dims := l.Style.GetDimensions()
+	return dims.Width, dims.Height
+}
+
+// GetStyle returns the style of the section list.
+// Implements the Styled interface.
+func (l SectionList[T]) GetStyle() Style {
+	return l.Style
+}
+
+// IsFocusable returns true to allow keyboard navigation.
+// Implements the Focusable interface.
+func (l SectionList[T]) IsFocusable() bool {
+	return !l.DisableFocus
+}
+
+// OnKey handles keys not covered by declarative keybindings.
+// Implements the Focusable interface.
+func (l SectionList[T]) OnKey(event KeyEvent) bool {
+	return false
+}
+
+// Keybinds returns the declarative keybindings for this section list.
+func (l SectionList[T]) Keybinds() []Keybind {
+	if l.State == nil {
+		return nil
+	}
+	return []Keybind{
+		{Key: "enter", Action: l.selectItem, Hidden: true},
+		{Key: "up", Action: func() { l.moveCursor(-1) }, Hidden: true},
+		{Key: "k", Action: func() { l.moveCursor(-1) }, Hidden: true},
+		{Key: "down", Action: func() { l.moveCursor(1) }, Hidden: true},
+		{Key: "j", Action: func() { l.moveCursor(1) }, Hidden: true},
+		{Key: "pgup", Action: func() { l.moveCursor(-10) }, Hidden: true},
+		{Key: "pgdown", Action: func() { l.moveCursor(10) }, Hidden: true},
+		{Key: "home", Action: func() { l.moveCursor(-len(l.State.stops())) }, Hidden: true},
+		{Key: "g", Action: func() { l.moveCursor(-len(l.State.stops())) }, Hidden: true},
+		{Key: "end", Action: func() { l.moveCursor(len(l.State.stops())) }, Hidden: true},
+		{Key: "G", Action: func() { l.moveCursor(len(l.State.stops())) }, Hidden: true},
+		{Key: "left", Action: l.collapseCursorSection, Hidden: true},
+		{Key: "h", Action: l.collapseCursorSection, Hidden: true},
+		{Key: "right", Action: l.expandCursorSection, Hidden: true},
+		{Key: "l", Action: l.expandCursorSection, Hidden: true},
+		{Key: "[", Action: func() { l.jumpSection(-1) }, Hidden: true},
+		{Key: "]", Action: func() { l.jumpSection(1) }, Hidden: true},
+	}
+}
+
+// Build returns a Column of headers and items, overlaid with the sticky
+// header and the section index when they are enabled.
+func (l SectionList[T]) Build(ctx BuildContext) Widget {
+	if l.State == nil {
+		return Column{}
+	}
+
+	sections := l.State.Sections.Get()
+	collapsed := l.State.Collapsed.Get()
+	cursor := l.State.Cursor.Get()
+
+	renderItem := l.RenderItem
+	if renderItem == nil {
+		renderItem = l.themedDefaultRenderItem(ctx)
+	}
+	renderHeader := l.renderHeader(ctx)
+
+	var rows []SectionPosition
+	var children []Widget
+	for i, section := range sections {
+		_, isCollapsed := collapsed[i]
+		header := SectionPosition{Section: i, Item: -1}
+		rows = append(rows, header)
+		children = append(children, renderHeader(i, section, isCollapsed, cursor == header))
+		if isCollapsed {
+			continue
+		}
+		for j, item := range section.Items {
+			pos := SectionPosition{Section: i, Item: j}
+			rows = append(rows, pos)
+			children = append(children, renderItem(item, cursor == pos))
+		}
+	}
+
+	content := sectionListContainer[T]{
+		Column: Column{
+			ID:         l.ID,
+			CrossAlign: CrossAxisStretch,
+			Style:      l.Style,
+			Children:   children,
+		},
+		list: l,
+		rows: rows,
+	}
+	if l.ScrollState == nil || (!l.StickyHeaders && !l.ShowIndex) || len(sections) == 0 {
+		return content
+	}
+
+	// Overlays are positioned at the scroll offset, which puts them at the
+	// top of the enclosing Scrollable's viewport.
+	offset := l.ScrollState.Offset.Get()
+	content.ID = ""
+	content.Style = Style{Width: Flex(1)}
+	overlays := []Widget{content}
+	if l.StickyHeaders && offset > 0 {
+		if section, ok := l.sectionAt(offset); ok && section < len(sections) {
+			_, isCollapsed := collapsed[section]
+			header := SectionPosition{Section: section, Item: -1}
+			zero := 0
+			overlays = append(overlays, Positioned{
+				Top:   &offset,
+				Left:  &zero,
+				Right: &zero,
+				Child: renderHeader(section, sections[section], isCollapsed, cursor == header),
+			})
+		}
+	}
+	if l.ShowIndex {
+		index, width := l.buildIndex(ctx, sections, cursor.Section)
+		content.Style.Padding.Right = width
+		overlays[0] = content
+		zero := 0
+		overlays = append(overlays, Positioned{Top: &offset, Right: &zero, Child: index})
+	}
+
+	style := l.Style
+	if style.Width.IsUnset() {
+		style.Width = Flex(1)
+	}
+	return Stack{ID: l.ID, Style: style, Children: overlays}
+}
+
+// renderHeader returns the header render function, wrapping the default
+// so that clicking a header toggles its section.
+func (l SectionList[T]) renderHeader(ctx BuildContext) func(index int, section ListSection[T], collapsed bool, active bool) Widget {
+	if l.RenderHeader != nil {
+		return func(index int, section ListSection[T], collapsed bool, active bool) Widget {
+			return l.RenderHeader(section, collapsed, active)
+		}
+	}
+	theme := ctx.Theme()
+	widgetFocused := ctx.IsFocused(l)
+	return func(index int, section ListSection[T], collapsed bool, active bool) Widget {
+		indicator := "▼ "
+		if collapsed {
+			indicator = "▶ "
+		}
+		style := Style{
+			ForegroundColor: theme.Primary,
+			BackgroundColor: theme.Surface,
+			Bold:            true,
+			Width:           Flex(1),
+		}
+		if active && widgetFocused {
+			style.ForegroundColor = theme.SelectionText
+			style.BackgroundColor = theme.ActiveCursor
+		}
+		return Text{
+			Content: indicator + section.Title,
+			Style:   style,
+			Click:   func(MouseEvent) { l.State.ToggleSection(index) },
+		}
+	}
+}
+
+// themedDefaultRenderItem returns a themed render function for items.
+// Cursor highlighting is only shown when the widget has focus.
+func (l SectionList[T]) themedDefaultRenderItem(ctx BuildContext) func(item T, active bool) Widget {
+	theme := ctx.Theme()
+	widgetFocused := ctx.IsFocused(l)
+	cursorPrefix := l.CursorPrefix
+	return func(item T, active bool) Widget {
+		style := Style{ForegroundColor: theme.Text, Width: Flex(1)}
+		prefix := ""
+		if active && widgetFocused {
+			prefix = cursorPrefix
+			style.BackgroundColor = theme.ActiveCursor
+			style.ForegroundColor = theme.SelectionText
+		}
+		return Text{Content: prefix + fmt.Sprintf("%v", item), Style: style}
+	}
+}
+
+// buildIndex returns the section index column and its width.
+func (l SectionList[T]) buildIndex(ctx BuildContext, sections []ListSection[T], current int) (Widget, int) {
+	theme := ctx.Theme()
+	labels := make([]Widget, len(sections))
+	width := 0
+	for i, section := range sections {
+		label := l.indexLabel(section)
+		width = max(width, ansi.StringWidth(label))
+		style := Style{ForegroundColor: theme.TextMuted, Padding: EdgeInsets{Left: 1}}
+		if i == current {
+			style.ForegroundColor = theme.Primary
+			style.Bold = true
+		}
+		labels[i] = Text{
+			Content: label,
+			Style:   style,
+			Click:   func(MouseEvent) { l.State.JumpToSection(i) },
+		}
+	}
+	return Column{Children: labels}, width + 1
+}
+
+func (l SectionList[T]) indexLabel(section ListSection[T]) string {
+	if l.IndexLabel != nil {
+		return l.IndexLabel(section)
+	}
+	for _, r := range section.Title {
+		return string(r)
+	}
+	return "#"
+}
+
+// sectionAt returns the section of the first row visible at offset, using
+// the last layout.
+func (l SectionList[T]) sectionAt(offset int) (int, bool) {
+	s := l.State
+	for i, layout := range s.rowLayouts {
+		if i < len(s.rows) && layout.y+layout.height > offset {
+			return s.rows[i].Section, true
+		}
+	}
+	return 0, false
+}
+
+// rowLayout returns the cached layout of the row at pos.
+func (l SectionList[T]) rowLayout(pos SectionPosition) (y, height int, ok bool) {
+	s := l.State
+	for i, row := range s.rows {
+		if row == pos && i < len(s.rowLayouts) && s.rowLayouts[i].height > 0 {
+			return s.rowLayouts[i].y, s.rowLayouts[i].height, true
+		}
+	}
+	return 0, 0, false
+}
+
+// scrollCursorIntoView scrolls the cursor row into view. The first item of
+// a section brings its header along, and with sticky headers other items
+// stay clear of the pinned header.
+func (l SectionList[T]) scrollCursorIntoView() {
+	if l.ScrollState == nil || l.State == nil {
+		return
+	}
+	cursor := l.State.Cursor.Peek()
+	y, height, ok := l.rowLayout(cursor)
+	if !ok {
+		return
+	}
+	if cursor.Item >= 0 {
+		if headerY, headerHeight, ok := l.rowLayout(SectionPosition{Section: cursor.Section, Item: -1}); ok {
+			if cursor.Item == 0 {
+				height += y - headerY
+				y = headerY
+			} else if l.StickyHeaders {
+				y -= headerHeight
+				height += headerHeight
+			}
+		}
+	}
+	l.ScrollState.ScrollToView(y, height)
+}
+
+func (l SectionList[T]) moveCursor(delta int) {
+	before := l.State.Cursor.Peek()
+	l.State.moveCursor(delta)
+	l.afterCursorMove(before)
+}
+
+func (l SectionList[T]) jumpSection(delta int) {
+	before := l.State.Cursor.Peek()
+	sections := l.State.Sections.Peek()
+	for section := before.Section + delta; section >= 0 && section < len(sections); section += delta {
+		l.State.JumpToSection(section)
+		if l.State.Cursor.Peek().Section == section {
+			break
+		}
+	}
+	l.afterCursorMove(before)
+}
+
+func (l SectionList[T]) collapseCursorSection() {
+	before := l.State.Cursor.Peek()
+	l.State.Collapse(before.Section)
+	l.afterCursorMove(before)
+}
+
+func (l SectionList[T]) expandCursorSection() {
+	before := l.State.Cursor.Peek()
+	l.State.Expand(before.Section)
+	l.afterCursorMove(before)
+}
+
+func (l SectionList[T]) afterCursorMove(before SectionPosition) {
+	if l.State.Cursor.Peek() == before {
+		return
+	}
+	l.scrollCursorIntoView()
+	if l.OnCursorChange != nil {
+		if item, ok := l.State.SelectedItem(); ok {
+			l.OnCursorChange(item)
+		}
+	}
+}
+
+func (l SectionList[T]) selectItem() {
+	if item, ok := l.State.SelectedItem(); ok {
+		if l.OnSelect != nil {
+			l.OnSelect(item)
+		}
+		return
+	}
+	l.State.ToggleSection(l.State.Cursor.Peek().Section)
+}
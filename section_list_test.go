@@ -0,0 +1,93 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func contactSections() []ListSection[string] {
+	return []ListSection[string]{
+		{Title: "A", Items: []string{"Ada", "Alan", "Alonzo"}},
+		{Title: "B", Items: []string{"Barbara", "Bjarne"}},
+		{Title: "C", Items: []string{"Claude", "Corrado", "Cynthia"}},
+		{Title: "D", Items: []string{"Dennis", "Donald"}},
+	}
+}
+
+func TestSectionListState_NavigationSkipsHeaders(t *testing.T) {
+	state := NewSectionListState(contactSections())
+	assert.Equal(t, SectionPosition{Section: 0, Item: 0}, state.Cursor.Peek())
+
+	state.Cursor.Set(SectionPosition{Section: 0, Item: 2})
+	state.SelectNext()
+	assert.Equal(t, SectionPosition{Section: 1, Item: 0}, state.Cursor.Peek())
+
+	state.Collapse(1)
+	assert.Equal(t, SectionPosition{Section: 1, Item: -1}, state.Cursor.Peek(), "the cursor moves to the collapsed header")
+	_, ok := state.SelectedItem()
+	assert.False(t, ok)
+
+	state.SelectNext()
+	item, _ := state.SelectedItem()
+	assert.Equal(t, "Claude", item)
+	state.SelectPrevious()
+	state.SelectPrevious()
+	item, _ = state.SelectedItem()
+	assert.Equal(t, "Alonzo", item, "a collapsed header is a single stop")
+
+	state.Cursor.Set(SectionPosition{Section: 1, Item: -1})
+	state.Expand(1)
+	assert.Equal(t, SectionPosition{Section: 1, Item: 0}, state.Cursor.Peek())
+
+	state.JumpToSection(3)
+	item, _ = state.SelectedItem()
+	assert.Equal(t, "Dennis", item)
+
+	state.SetSections(contactSections()[:2])
+	item, _ = state.SelectedItem()
+	assert.Equal(t, "Bjarne", item, "a removed cursor row clamps to the closest earlier item")
+}
+
+type sectionListTestWidget struct {
+	list SectionList[string]
+}
+
+func (w *sectionListTestWidget) Build(ctx BuildContext) Widget {
+	return Scrollable{
+		State: w.list.ScrollState,
+		Style: Style{Height: Cells(4), Width: Cells(20)},
+		Child: w.list,
+	}
+}
+
+func TestSectionList_StickyHeaderAndIndex(t *testing.T) {
+	state := NewSectionListState(contactSections())
+	scroll := NewScrollState()
+	widget := &sectionListTestWidget{list: SectionList[string]{
+		State:         state,
+		ScrollState:   scroll,
+		StickyHeaders: true,
+		ShowIndex:     true,
+	}}
+
+	screen := screenText(widget, 20, 4)
+	lines := strings.Split(screen, "\n")
+	assert.True(t, strings.HasPrefix(lines[0], "▼ A"), screen)
+	assert.Regexp(t, `^Ada +B`, lines[1])
+
+	state.Cursor.Set(SectionPosition{Section: 2, Item: 1})
+	widget.list.moveCursor(1)
+	screen = screenText(widget, 20, 4)
+	lines = strings.Split(screen, "\n")
+	assert.True(t, strings.HasPrefix(lines[0], "▼ C"), screen)
+	assert.True(t, strings.HasPrefix(lines[3], "Cynthia"), "the cursor stays clear of the sticky header:\n%s", screen)
+
+	state.JumpToSection(1)
+	screenText(widget, 20, 4)
+	screen = screenText(widget, 20, 4)
+	lines = strings.Split(screen, "\n")
+	assert.True(t, strings.HasPrefix(lines[0], "▼ B"), "the jumped-to header is scrolled to the top:\n%s", screen)
+	assert.True(t, strings.HasPrefix(lines[1], "Barbara"), screen)
+}
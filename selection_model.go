@@ -0,0 +1,244 @@
+package terma
+
+import "sort"
+
+// SelectionModel is a reactive set of selected keys plus the anchor that
+// shift-selection extends from. ListState and TableState key it by item
+// index and TreeState by node identifier; custom widgets can use it
+// directly.
+//
+// Index keys break when items move, so ListState.KeyFor and
+// TableState.KeyFor let those states carry selections over to the items'
+// new indices after sorting, inserting or removing items.
+type SelectionModel[K comparable] struct {
+	Selection AnySignal[map[K]struct{}] // Selected keys (treat the map as read-only)
+
+	// OnSelectionChange is called after a change made through the model,
+	// with the new selection.
+	OnSelectionChange func(selected map[K]struct{})
+
+	anchor    K
+	hasAnchor bool
+}
+
+// NewSelectionModel creates an empty SelectionModel.
+func NewSelectionModel[K comparable]() *SelectionModel[K] {
+	return &SelectionModel[K]{Selection: NewAnySignal(make(map[K]struct{}))}
+}
+
+// IsSelected reports whether key is selected.
+func (m *SelectionModel[K]) IsSelected(key K) bool {
+	if m == nil || !m.Selection.IsValid() {
+		return false
+	}
+	_, ok := m.Selection.Peek()[key]
+	return ok
+}
+
+// Len returns the number of selected keys.
+func (m *SelectionModel[K]) Len() int {
+	if m == nil || !m.Selection.IsValid() {
+		return 0
+	}
+	return len(m.Selection.Peek())
+}
+
+// Select adds keys to the selection.
+func (m *SelectionModel[K]) Select(keys ...K) {
+	next := m.copySelection(len(keys))
+	for _, key := range keys {
+		next[key] = struct{}{}
+	}
+	m.set(next)
+}
+
+// Deselect removes keys from the selection.
+func (m *SelectionModel[K]) Deselect(keys ...K) {
+	next := m.copySelection(0)
+	for _, key := range keys {
+		delete(next, key)
+	}
+	m.set(next)
+}
+
+// Toggle selects key if it is unselected, and deselects it otherwise.
+func (m *SelectionModel[K]) Toggle(key K) {
+	next := m.copySelection(1)
+	if _, ok := next[key]; ok {
+		delete(next, key)
+	} else {
+		next[key] = struct{}{}
+	}
+	m.set(next)
+}
+
+// Clear deselects everything.
+func (m *SelectionModel[K]) Clear() {
+	m.set(make(map[K]struct{}))
+}
+
+// SetSelected replaces the selection with keys.
+func (m *SelectionModel[K]) SetSelected(keys []K) {
+	next := make(map[K]struct{}, len(keys))
+	for _, key := range keys {
+		next[key] = struct{}{}
+	}
+	m.set(next)
+}
+
+// SelectAll replaces the selection with keys. Pass only the visible keys to
+// select everything that matches a filter.
+func (m *SelectionModel[K]) SelectAll(keys []K) {
+	m.SetSelected(keys)
+}
+
+// Invert flips the selection of each of keys, leaving other keys as they
+// are. Pass only the visible keys to invert within a filter.
+func (m *SelectionModel[K]) Invert(keys []K) {
+	next := m.copySelection(len(keys))
+	for _, key := range keys {
+		if _, ok := next[key]; ok {
+			delete(next, key)
+		} else {
+			next[key] = struct{}{}
+		}
+	}
+	m.set(next)
+}
+
+// SelectRange replaces the selection with the keys of order between from and
+// to, inclusive. If from is not in order the range starts at the first key;
+// if to is not, the range is just from.
+func (m *SelectionModel[K]) SelectRange(order []K, from, to K) {
+	if len(order) == 0 {
+		return
+	}
+	start, end := -1, -1
+	for i, key := range order {
+		if key == from {
+			start = i
+		}
+		if key == to {
+			end = i
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end < 0 {
+		end = start
+	}
+	if start > end {
+		start, end = end, start
+	}
+	m.SetSelected(order[start : end+1])
+}
+
+// SetAnchor sets the key shift-selection extends from.
+func (m *SelectionModel[K]) SetAnchor(key K) {
+	m.anchor = key
+	m.hasAnchor = true
+}
+
+// ClearAnchor removes the anchor.
+func (m *SelectionModel[K]) ClearAnchor() {
+	var zero K
+	m.anchor = zero
+	m.hasAnchor = false
+}
+
+// Anchor returns the anchor, if one is set.
+func (m *SelectionModel[K]) Anchor() (K, bool) {
+	return m.anchor, m.hasAnchor
+}
+
+// copySelection returns a mutable copy of the selection with room for extra
+// more keys.
+func (m *SelectionModel[K]) copySelection(extra int) map[K]struct{} {
+	if !m.Selection.IsValid() {
+		return make(map[K]struct{}, extra)
+	}
+	current := m.Selection.Peek()
+	next := make(map[K]struct{}, len(current)+extra)
+	for key := range current {
+		next[key] = struct{}{}
+	}
+	return next
+}
+
+// set stores next and reports the change if there was one.
+func (m *SelectionModel[K]) set(next map[K]struct{}) {
+	if !m.Selection.IsValid() {
+		m.Selection = NewAnySignal(next)
+	} else {
+		previous := m.Selection.Peek()
+		m.Selection.Set(next)
+		if sameKeySet(previous, next) {
+			return
+		}
+	}
+	if m.OnSelectionChange != nil {
+		m.OnSelectionChange(next)
+	}
+}
+
+// remap moves each selected key to move(key), dropping it if move returns
+// false. It keeps the same items selected when their keys change, so it
+// doesn't call OnSelectionChange unless a key is dropped.
+func (m *SelectionModel[K]) remap(move func(key K) (K, bool)) {
+	if !m.Selection.IsValid() {
+		return
+	}
+	current := m.Selection.Peek()
+	next := make(map[K]struct{}, len(current))
+	for key := range current {
+		if moved, ok := move(key); ok {
+			next[moved] = struct{}{}
+		}
+	}
+	if m.hasAnchor {
+		if moved, ok := move(m.anchor); ok {
+			m.anchor = moved
+		} else {
+			m.ClearAnchor()
+		}
+	}
+	m.Selection.Set(next)
+	if len(next) != len(current) && m.OnSelectionChange != nil {
+		m.OnSelectionChange(next)
+	}
+}
+
+func sameKeySet[K comparable](a, b map[K]struct{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key := range a {
+		if _, ok := b[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// indexRange returns the indices from (inclusive) to to (exclusive).
+func indexRange(from, to int) []int {
+	if to <= from {
+		return nil
+	}
+	indices := make([]int, to-from)
+	for i := range indices {
+		indices[i] = from + i
+	}
+	return indices
+}
+
+// sortedSelection returns the selected indices in ascending order.
+func sortedSelection(selected map[int]struct{}) []int {
+	indices := make([]int, 0, len(selected))
+	for idx := range selected {
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+	return indices
+}
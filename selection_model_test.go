@@ -0,0 +1,163 @@
+package terma
+
+import (
+	"slices"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectionModel_Operations(t *testing.T) {
+	model := NewSelectionModel[string]()
+	var changes []int
+	model.OnSelectionChange = func(selected map[string]struct{}) { changes = append(changes, len(selected)) }
+
+	model.Select("a", "b")
+	model.Toggle("b")
+	model.Toggle("c")
+	assert.True(t, model.IsSelected("a"))
+	assert.False(t, model.IsSelected("b"))
+	assert.Equal(t, 2, model.Len())
+
+	model.Invert([]string{"a", "b"})
+	assert.Equal(t, map[string]struct{}{"b": {}, "c": {}}, model.Selection.Peek(), "keys outside the inverted set are untouched")
+
+	model.SelectRange([]string{"w", "x", "y", "z"}, "y", "x")
+	assert.Equal(t, map[string]struct{}{"x": {}, "y": {}}, model.Selection.Peek())
+
+	model.Select("x")
+	assert.Equal(t, []int{2, 1, 2, 2, 2}, changes, "no event when nothing changed")
+}
+
+func TestListState_SelectionFollowsKeyFor(t *testing.T) {
+	state := NewListState([]string{"alpha", "beta", "gamma"})
+	state.KeyFor = func(item string) string { return item }
+	var notified [][]string
+	state.OnSelectionChange = func(selected []string) { notified = append(notified, selected) }
+
+	state.Select(1)
+	state.SetItems([]string{"gamma", "beta", "alpha"})
+	state.Select(0)
+	assert.Equal(t, []string{"gamma", "beta"}, state.SelectedItems())
+
+	state.SetItems([]string{"delta", "beta", "alpha", "gamma"})
+	assert.Equal(t, []int{1, 3}, state.SelectedIndices())
+
+	state.RemoveAt(1)
+	assert.Equal(t, []string{"gamma"}, state.SelectedItems(), "removed items are deselected")
+	assert.Equal(t, [][]string{{"beta"}, {"gamma", "beta"}, {"gamma"}}, notified)
+}
+
+func TestListState_SelectionFollowsInPlaceSort(t *testing.T) {
+	state := NewListState([]string{"c", "a", "b"})
+	state.KeyFor = func(item string) string { return item }
+	state.Select(0)
+	list := List[string]{State: state, MultiSelect: true}
+
+	state.Items.Update(func(items []string) []string {
+		slices.Sort(items)
+		return items
+	})
+	list.Build(newTestBuildContext())
+	assert.Equal(t, []string{"c"}, state.SelectedItems())
+}
+
+func TestListState_SelectionWithoutKeyForStaysByIndex(t *testing.T) {
+	state := NewListState([]string{"a", "b"})
+	state.Select(0)
+	state.Prepend("z")
+	assert.Equal(t, []int{0}, state.SelectedIndices())
+}
+
+func TestListState_SelectAllAndInvertUnderFilter(t *testing.T) {
+	state := NewListState([]string{"apple", "banana", "apricot", "cherry"})
+	filter := NewFilterState()
+	filter.Query.Set("ap")
+	list := List[string]{State: state, Filter: filter, MultiSelect: true}
+	list.Build(newTestBuildContext())
+
+	state.Select(3)
+	state.InvertSelection()
+	assert.Equal(t, []int{0, 2, 3}, state.SelectedIndices(), "hidden cherry keeps its selection")
+
+	state.SelectAllVisible()
+	assert.Equal(t, []int{0, 2}, state.SelectedIndices())
+	assert.Equal(t, 2, state.SelectionCount())
+
+	state.Select(3)
+	state.DeselectAllVisible()
+	assert.Equal(t, []int{3}, state.SelectedIndices(), "select none keeps hidden items")
+
+	actions := map[string]func(){}
+	for _, bind := range list.Keybinds() {
+		actions[bind.Key] = bind.Action
+	}
+	actions["ctrl+a"]()
+	assert.Equal(t, []int{0, 2}, state.SelectedIndices())
+	actions["*"]()
+	assert.Empty(t, state.SelectedIndices())
+	actions["ctrl+a"]()
+	actions["alt+a"]()
+	assert.Empty(t, state.SelectedIndices())
+}
+
+func TestList_ClickingMarkerTogglesSelection(t *testing.T) {
+	state := NewListState([]string{"a", "b"})
+	list := List[string]{ID: "list", State: state, MultiSelect: true, Markers: &SelectionMarkers{Selected: "*", Unselected: "-"}}
+
+	buf := uv.NewBuffer(10, 2)
+	renderer := NewRenderer(buf, 10, 2, NewFocusManager(), NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil))
+	renderer.Render(list)
+	assert.Equal(t, "- b", bufferLine(buf, 1, 3))
+
+	entry := renderer.WidgetAt(0, 1)
+	require.NotNil(t, entry)
+	entry.EventWidget.(Clickable).OnClick(MouseEvent{ClickCount: 1})
+	assert.Equal(t, []int{1}, state.SelectedIndices())
+
+	renderer.Render(list)
+	assert.Equal(t, "* b", bufferLine(buf, 1, 3))
+}
+
+func TestTableState_SelectionFollowsKeyFor(t *testing.T) {
+	state := NewTableState([][]string{{"1", "web"}, {"2", "db"}})
+	state.KeyFor = func(row []string) string { return row[0] }
+	table := Table[[]string]{State: state, Columns: []TableColumn{{}, {}}, SelectionMode: TableSelectionRow, MultiSelect: true}
+	table.Build(newTestBuildContext())
+
+	state.Select(1)
+	state.SetRows([][]string{{"2", "db"}, {"3", "cache"}, {"1", "web"}})
+	assert.Equal(t, []int{0}, state.SelectedIndices())
+}
+
+func TestTableState_KeyForIgnoredForCellSelection(t *testing.T) {
+	state := NewTableState([][]string{{"1", "web"}, {"2", "db"}})
+	state.KeyFor = func(row []string) string { return row[0] }
+	table := Table[[]string]{State: state, Columns: []TableColumn{{}, {}}, MultiSelect: true}
+	table.Build(newTestBuildContext())
+
+	state.Select(3)
+	state.SetRows([][]string{{"2", "db"}, {"1", "web"}})
+	assert.Equal(t, []int{3}, state.SelectedIndices(), "cell indices are not row indices")
+}
+
+func TestTreeState_SelectAllVisibleAndOnSelectionChange(t *testing.T) {
+	state := NewTreeState([]TreeNode[string]{
+		{Data: "src", Children: []TreeNode[string]{{Data: "main.go"}, {Data: "util.go"}}},
+		{Data: "README.md"},
+	})
+	var notified []string
+	state.OnSelectionChange = func(selected []string) { notified = selected }
+	filter := NewFilterState()
+	filter.Query.Set("go")
+	tree := Tree[string]{State: state, Filter: filter, MultiSelect: true, NodeID: func(node string) string { return node }}
+	tree.Build(newTestBuildContext())
+
+	state.SelectAllVisible()
+	assert.Equal(t, []string{"src", "main.go", "util.go"}, notified)
+
+	state.InvertSelection()
+	assert.Nil(t, notified)
+}
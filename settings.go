@@ -0,0 +1,684 @@
+package terma
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SettingKind identifies the type of value a Setting holds.
+type SettingKind int
+
+const (
+	// SettingToggle is an on/off option (bool).
+	SettingToggle SettingKind = iota
+	// SettingSelect is one of a fixed set of Choices (string).
+	SettingSelect
+	// SettingNumber is an integer, optionally bounded by Min and Max (int).
+	SettingNumber
+	// SettingKeybind is a key combination such as "ctrl+s" (string).
+	SettingKeybind
+	// SettingColor is a color, edited and stored as a hex string (Color).
+	SettingColor
+)
+
+// Setting declares one option in a settings schema.
+type Setting struct {
+	Key         string                // Required - unique key used for lookup and storage
+	Label       string                // Display name
+	Description string                // Optional help text shown under the label
+	Kind        SettingKind           // Value type (default = SettingToggle)
+	Default     any                   // Default value: bool, string, int or Color depending on Kind
+	Choices     []string              // Allowed values for SettingSelect
+	Min, Max    int                   // Bounds for SettingNumber (ignored when Min == Max)
+	Validate    func(value any) error // Optional extra validation run after the built-in checks
+}
+
+// SettingsSection groups related settings under a heading.
+type SettingsSection struct {
+	Title    string
+	Settings []Setting
+}
+
+// SettingsStore persists settings values between runs.
+type SettingsStore interface {
+	// Load returns the stored values keyed by Setting.Key.
+	Load() (map[string]any, error)
+	// Save replaces the stored values.
+	Save(values map[string]any) error
+}
+
+// JSONFileStore is a SettingsStore that keeps values in a JSON file.
+// A missing file loads as empty; parent directories are created on save.
+type JSONFileStore struct {
+	Path string
+}
+
+// Load implements SettingsStore.
+func (s JSONFileStore) Load() (map[string]any, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]any{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load settings: %w", err)
+	}
+	values := map[string]any{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("load settings: %w", err)
+	}
+	return values, nil
+}
+
+// Save implements SettingsStore.
+func (s JSONFileStore) Save(values map[string]any) error {
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return fmt.Errorf("save settings: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o755); err != nil {
+		return fmt.Errorf("save settings: %w", err)
+	}
+	if err := os.WriteFile(s.Path, data, 0o644); err != nil {
+		return fmt.Errorf("save settings: %w", err)
+	}
+	return nil
+}
+
+// SettingsState holds the values and editing state for a Settings widget.
+// Only values that differ from their defaults are persisted, so changing a
+// default in the schema takes effect for users who never touched the option.
+type SettingsState struct {
+	Sections []SettingsSection
+
+	values AnySignal[map[string]any]
+	errors AnySignal[map[string]string]
+	store  SettingsStore
+
+	search *TextInputState
+	scroll *ScrollState
+	checks map[string]*CheckboxState
+	inputs map[string]*TextInputState
+}
+
+// NewSettingsState creates settings state for the schema, loading stored
+// values from store (which may be nil). Stored values that no longer pass
+// validation are ignored.
+func NewSettingsState(sections []SettingsSection, store SettingsStore) *SettingsState {
+	s := &SettingsState{
+		Sections: sections,
+		store:    store,
+		search:   NewTextInputState(""),
+		scroll:   NewScrollState(),
+		checks:   map[string]*CheckboxState{},
+		inputs:   map[string]*TextInputState{},
+	}
+
+	values := map[string]any{}
+	if store != nil {
+		stored, err := store.Load()
+		if err != nil {
+			Log("Settings: %v", err)
+		}
+		for _, setting := range s.settings() {
+			raw, ok := stored[setting.Key]
+			if !ok {
+				continue
+			}
+			value, err := decodeSettingValue(setting, raw)
+			if err == nil {
+				err = validateSetting(setting, value)
+			}
+			if err != nil {
+				Log("Settings: ignoring stored %s: %v", setting.Key, err)
+				continue
+			}
+			values[setting.Key] = value
+		}
+	}
+	s.values = NewAnySignal(values)
+	s.errors = NewAnySignal(map[string]string{})
+
+	for _, setting := range s.settings() {
+		switch setting.Kind {
+		case SettingToggle:
+			checked, _ := s.peek(setting.Key).(bool)
+			s.checks[setting.Key] = NewCheckboxState(checked)
+		case SettingNumber, SettingKeybind, SettingColor:
+			s.inputs[setting.Key] = NewTextInputState(formatSettingValue(setting, s.peek(setting.Key)))
+		}
+	}
+	return s
+}
+
+// settings returns every setting in schema order.
+func (s *SettingsState) settings() []Setting {
+	var all []Setting
+	for _, section := range s.Sections {
+		all = append(all, section.Settings...)
+	}
+	return all
+}
+
+// lookup returns the setting declared with key.
+func (s *SettingsState) lookup(key string) (Setting, bool) {
+	for _, setting := range s.settings() {
+		if setting.Key == key {
+			return setting, true
+		}
+	}
+	return Setting{}, false
+}
+
+func (s *SettingsState) peek(key string) any {
+	if value, ok := s.values.Peek()[key]; ok {
+		return value
+	}
+	setting, _ := s.lookup(key)
+	return setting.Default
+}
+
+// Value returns the current value of key, or its default if unchanged.
+// Calling it during Build subscribes the widget to settings changes.
+func (s *SettingsState) Value(key string) any {
+	if value, ok := s.values.Get()[key]; ok {
+		return value
+	}
+	setting, _ := s.lookup(key)
+	return setting.Default
+}
+
+// Bool returns the value of a toggle setting.
+func (s *SettingsState) Bool(key string) bool {
+	value, _ := s.Value(key).(bool)
+	return value
+}
+
+// String returns the value of a select or keybind setting.
+func (s *SettingsState) String(key string) string {
+	value, _ := s.Value(key).(string)
+	return value
+}
+
+// Int returns the value of a number setting.
+func (s *SettingsState) Int(key string) int {
+	value, _ := s.Value(key).(int)
+	return value
+}
+
+// Color returns the value of a color setting.
+func (s *SettingsState) Color(key string) Color {
+	value, _ := s.Value(key).(Color)
+	return value
+}
+
+// IsDefault reports whether key currently has its default value.
+func (s *SettingsState) IsDefault(key string) bool {
+	_, changed := s.values.Get()[key]
+	return !changed
+}
+
+// Error returns the last validation error for key, or "" if none.
+func (s *SettingsState) Error(key string) string {
+	return s.errors.Get()[key]
+}
+
+// Set validates and stores a new value for key, then persists all changed
+// values. Validation failures are also recorded for display by Settings.
+func (s *SettingsState) Set(key string, value any) error {
+	setting, ok := s.lookup(key)
+	if !ok {
+		return fmt.Errorf("unknown setting %q", key)
+	}
+	if err := validateSetting(setting, value); err != nil {
+		s.setError(key, err.Error())
+		return err
+	}
+	s.setError(key, "")
+
+	s.values.Update(func(values map[string]any) map[string]any {
+		next := maps.Clone(values)
+		if settingValuesEqual(value, setting.Default) {
+			delete(next, key)
+		} else {
+			next[key] = value
+		}
+		return next
+	})
+	s.syncEditor(setting)
+	return s.save()
+}
+
+// Reset restores key to its default value.
+func (s *SettingsState) Reset(key string) error {
+	setting, ok := s.lookup(key)
+	if !ok {
+		return fmt.Errorf("unknown setting %q", key)
+	}
+	return s.Set(key, setting.Default)
+}
+
+// ResetAll restores every setting to its default value.
+func (s *SettingsState) ResetAll() error {
+	s.values.Set(map[string]any{})
+	s.errors.Set(map[string]string{})
+	for _, setting := range s.settings() {
+		s.syncEditor(setting)
+	}
+	return s.save()
+}
+
+// Query returns the current search text.
+func (s *SettingsState) Query() string {
+	return joinGraphemes(s.search.Content.Get())
+}
+
+// SetQuery replaces the search text.
+func (s *SettingsState) SetQuery(query string) {
+	s.search.SetText(query)
+}
+
+func (s *SettingsState) setError(key, message string) {
+	s.errors.Update(func(errs map[string]string) map[string]string {
+		if errs[key] == message {
+			return errs
+		}
+		next := maps.Clone(errs)
+		if message == "" {
+			delete(next, key)
+		} else {
+			next[key] = message
+		}
+		return next
+	})
+}
+
+// syncEditor updates the widget state backing setting to match its value.
+func (s *SettingsState) syncEditor(setting Setting) {
+	value := s.peek(setting.Key)
+	if check, ok := s.checks[setting.Key]; ok {
+		checked, _ := value.(bool)
+		check.SetChecked(checked)
+	}
+	if input, ok := s.inputs[setting.Key]; ok {
+		input.SetText(formatSettingValue(setting, value))
+	}
+}
+
+// save persists the changed values. Stored keys that aren't settings, such
+// as a Tour's completion, are kept.
+func (s *SettingsState) save() error {
+	if s.store == nil {
+		return nil
+	}
+	stored, err := s.store.Load()
+	if err != nil {
+		Log("Settings: %v", err)
+	}
+	encoded := map[string]any{}
+	for key, value := range stored {
+		if _, ok := s.lookup(key); !ok {
+			encoded[key] = value
+		}
+	}
+	for key, value := range s.values.Peek() {
+		if color, ok := value.(Color); ok {
+			encoded[key] = color.Hex()
+			continue
+		}
+		encoded[key] = value
+	}
+	return s.store.Save(encoded)
+}
+
+// submitText parses text typed into a setting's editor and applies it.
+func (s *SettingsState) submitText(setting Setting, text string) {
+	value, err := parseSettingText(setting, text)
+	if err != nil {
+		s.setError(setting.Key, err.Error())
+		return
+	}
+	if err := s.Set(setting.Key, value); err != nil {
+		Log("Settings: %v", err)
+	}
+}
+
+// cycleChoice advances a select setting to its next choice.
+func (s *SettingsState) cycleChoice(setting Setting) {
+	if len(setting.Choices) == 0 {
+		return
+	}
+	current, _ := s.peek(setting.Key).(string)
+	next := setting.Choices[0]
+	for i, choice := range setting.Choices {
+		if choice == current {
+			next = setting.Choices[(i+1)%len(setting.Choices)]
+			break
+		}
+	}
+	if err := s.Set(setting.Key, next); err != nil {
+		Log("Settings: %v", err)
+	}
+}
+
+// validateSetting checks that value has the right type for setting and
+// satisfies its constraints.
+func validateSetting(setting Setting, value any) error {
+	switch setting.Kind {
+	case SettingToggle:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected on or off")
+		}
+	case SettingSelect:
+		choice, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected one of %s", strings.Join(setting.Choices, ", "))
+		}
+		valid := false
+		for _, c := range setting.Choices {
+			if c == choice {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("expected one of %s", strings.Join(setting.Choices, ", "))
+		}
+	case SettingNumber:
+		n, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("expected a whole number")
+		}
+		if setting.Min != setting.Max && (n < setting.Min || n > setting.Max) {
+			return fmt.Errorf("must be between %d and %d", setting.Min, setting.Max)
+		}
+	case SettingKeybind:
+		key, ok := value.(string)
+		if !ok || strings.TrimSpace(key) == "" || strings.HasSuffix(key, "+") {
+			return fmt.Errorf("expected a key such as ctrl+s")
+		}
+	case SettingColor:
+		color, ok := value.(Color)
+		if !ok || !color.IsSet() {
+			return fmt.Errorf("expected a hex color such as #ff8800")
+		}
+	}
+	if setting.Validate != nil {
+		return setting.Validate(value)
+	}
+	return nil
+}
+
+// parseSettingText converts editor text into a value for setting.
+func parseSettingText(setting Setting, text string) (any, error) {
+	text = strings.TrimSpace(text)
+	switch setting.Kind {
+	case SettingNumber:
+		n, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, fmt.Errorf("expected a whole number")
+		}
+		return n, nil
+	case SettingColor:
+		return parseHexColor(text)
+	default:
+		return text, nil
+	}
+}
+
+// decodeSettingValue converts a value loaded from a store into the Go type
+// used for setting's kind.
+func decodeSettingValue(setting Setting, raw any) (any, error) {
+	switch setting.Kind {
+	case SettingNumber:
+		switch n := raw.(type) {
+		case int:
+			return n, nil
+		case float64:
+			if n != float64(int(n)) {
+				return nil, fmt.Errorf("expected a whole number")
+			}
+			return int(n), nil
+		}
+		return nil, fmt.Errorf("expected a whole number")
+	case SettingColor:
+		switch c := raw.(type) {
+		case Color:
+			return c, nil
+		case string:
+			return parseHexColor(c)
+		}
+		return nil, fmt.Errorf("expected a hex color such as #ff8800")
+	default:
+		return raw, nil
+	}
+}
+
+// parseHexColor parses "#rgb", "#rrggbb" or "#rrggbbaa".
+func parseHexColor(text string) (Color, error) {
+	hex := strings.TrimPrefix(text, "#")
+	if len(hex) != 3 && len(hex) != 6 && len(hex) != 8 {
+		return Color{}, fmt.Errorf("expected a hex color such as #ff8800")
+	}
+	if _, err := strconv.ParseUint(hex, 16, 64); err != nil {
+		return Color{}, fmt.Errorf("expected a hex color such as #ff8800")
+	}
+	return Hex(hex), nil
+}
+
+// formatSettingValue returns the editor text for value.
+func formatSettingValue(setting Setting, value any) string {
+	switch v := value.(type) {
+	case Color:
+		return v.Hex()
+	case int:
+		return strconv.Itoa(v)
+	case string:
+		return v
+	case bool:
+		if v {
+			return "on"
+		}
+		return "off"
+	}
+	return ""
+}
+
+func settingValuesEqual(a, b any) bool {
+	if ca, ok := a.(Color); ok {
+		cb, ok := b.(Color)
+		return ok && ca == cb
+	}
+	return a == b
+}
+
+// Settings renders a searchable settings screen generated from a
+// SettingsState schema. Toggles are checkboxes, selects cycle through their
+// choices on press, and numbers, keybinds and colors are text inputs applied
+// on Enter. Changed settings show a reset button.
+//
+// Example:
+//
+//	state := NewSettingsState([]SettingsSection{{
+//	    Title: "Editor",
+//	    Settings: []Setting{
+//	        {Key: "wrap", Label: "Soft wrap", Kind: SettingToggle, Default: true},
+//	        {Key: "tab", Label: "Tab width", Kind: SettingNumber, Default: 4, Min: 1, Max: 16},
+//	    },
+//	}}, JSONFileStore{Path: configPath})
+//
+//	Settings{ID: "settings", State: state}
+type Settings struct {
+	ID    string         // Optional unique identifier (prefix for child IDs, default "settings")
+	State *SettingsState // Required - holds values and schema
+	Style Style          // Optional styling
+}
+
+// WidgetID returns the settings widget's unique identifier.
+func (s Settings) WidgetID() string {
+	return s.ID
+}
+
+// GetStyle returns the style.
+func (s Settings) GetStyle() Style {
+	return s.Style
+}
+
+func (s Settings) childID(parts ...string) string {
+	id := s.ID
+	if id == "" {
+		id = "settings"
+	}
+	return id + "-" + strings.Join(parts, "-")
+}
+
+// Build returns the search box and the filtered list of settings.
+func (s Settings) Build(ctx BuildContext) Widget {
+	if s.State == nil {
+		return EmptyWidget{}
+	}
+	theme := ctx.Theme()
+	query := s.State.Query()
+
+	var rows []Widget
+	for _, section := range s.State.Sections {
+		var matched []Widget
+		for _, setting := range section.Settings {
+			if !settingMatches(section, setting, query) {
+				continue
+			}
+			matched = append(matched, s.buildSetting(ctx, setting))
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		rows = append(rows, Text{
+			Content: section.Title,
+			Style:   Style{Bold: true, ForegroundColor: theme.Primary, Padding: EdgeInsets{Top: 1}},
+		})
+		rows = append(rows, matched...)
+	}
+	if len(rows) == 0 {
+		rows = append(rows, Text{
+			Content: "No settings match \"" + query + "\"",
+			Style:   Style{ForegroundColor: theme.TextMuted, Padding: EdgeInsets{Top: 1}},
+		})
+	}
+
+	style := s.Style
+	if style.Width.IsUnset() {
+		style.Width = Flex(1)
+	}
+	if style.Height.IsUnset() {
+		style.Height = Flex(1)
+	}
+
+	return Column{
+		Style: style,
+		Children: []Widget{
+			TextInput{
+				ID:          s.childID("search"),
+				State:       s.State.search,
+				Placeholder: "Search settings…",
+				Style:       Style{Width: Flex(1), BackgroundColor: theme.Surface, Padding: EdgeInsets{Left: 1, Right: 1}},
+			},
+			Scrollable{
+				ID:    s.childID("scroll"),
+				State: s.State.scroll,
+				Style: Style{Width: Flex(1), Height: Flex(1)},
+				Child: Column{Style: Style{Width: Flex(1)}, Children: rows},
+			},
+		},
+	}
+}
+
+// settingMatches reports whether query matches the setting or its section.
+func settingMatches(section SettingsSection, setting Setting, query string) bool {
+	if query == "" {
+		return true
+	}
+	text := strings.Join([]string{section.Title, setting.Label, setting.Description, setting.Key}, " ")
+	return MatchString(text, query, FilterOptions{}).Matched
+}
+
+// buildSetting returns the row for a single setting.
+func (s Settings) buildSetting(ctx BuildContext, setting Setting) Widget {
+	theme := ctx.Theme()
+	state := s.State
+
+	label := setting.Label
+	if label == "" {
+		label = setting.Key
+	}
+	details := []Widget{Text{Content: label}}
+	if setting.Description != "" {
+		details = append(details, Text{
+			Content: setting.Description,
+			Wrap:    WrapSoft,
+			Style:   Style{ForegroundColor: theme.TextMuted},
+		})
+	}
+	if msg := state.Error(setting.Key); msg != "" {
+		details = append(details, Text{Content: msg, Style: Style{ForegroundColor: theme.Error}})
+	}
+
+	controls := []Widget{s.buildControl(ctx, setting)}
+	if !state.IsDefault(setting.Key) {
+		controls = append(controls, Button{
+			ID:      s.childID(setting.Key, "reset"),
+			Label:   "Reset",
+			OnPress: func() { _ = state.Reset(setting.Key) },
+		})
+	}
+
+	return Row{
+		Style:   Style{Width: Flex(1), Padding: EdgeInsets{Left: 1, Right: 1}},
+		Spacing: 2,
+		Children: []Widget{
+			Column{Style: Style{Width: Flex(1)}, Children: details},
+			Row{Spacing: 1, Children: controls},
+		},
+	}
+}
+
+// buildControl returns the editor widget for a setting.
+func (s Settings) buildControl(ctx BuildContext, setting Setting) Widget {
+	theme := ctx.Theme()
+	state := s.State
+	id := s.childID(setting.Key)
+
+	switch setting.Kind {
+	case SettingToggle:
+		return &Checkbox{
+			ID:       id,
+			State:    state.checks[setting.Key],
+			OnChange: func(checked bool) { _ = state.Set(setting.Key, checked) },
+		}
+	case SettingSelect:
+		return Button{
+			ID:      id,
+			Label:   state.String(setting.Key) + " ▾",
+			OnPress: func() { state.cycleChoice(setting) },
+		}
+	default:
+		input := TextInput{
+			ID:       id,
+			State:    state.inputs[setting.Key],
+			OnSubmit: func(text string) { state.submitText(setting, text) },
+			Blur:     func() { state.submitText(setting, state.inputs[setting.Key].GetText()) },
+			Style:    Style{Width: Cells(12), BackgroundColor: theme.Surface},
+		}
+		if setting.Kind != SettingColor {
+			return input
+		}
+		return Row{Spacing: 1, Children: []Widget{
+			Text{Content: "  ", Style: Style{BackgroundColor: state.Color(setting.Key)}},
+			input,
+		}}
+	}
+}
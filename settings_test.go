@@ -0,0 +1,204 @@
+package terma
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySettingsStore records saved values for tests.
+type memorySettingsStore struct {
+	values map[string]any
+	saves  int
+}
+
+func (m *memorySettingsStore) Load() (map[string]any, error) {
+	return m.values, nil
+}
+
+func (m *memorySettingsStore) Save(values map[string]any) error {
+	m.values = values
+	m.saves++
+	return nil
+}
+
+func testSettingsSections() []SettingsSection {
+	return []SettingsSection{
+		{
+			Title: "Editor",
+			Settings: []Setting{
+				{Key: "wrap", Label: "Soft wrap", Description: "Wrap long lines", Kind: SettingToggle, Default: true},
+				{Key: "tab", Label: "Tab width", Kind: SettingNumber, Default: 4, Min: 1, Max: 16},
+				{Key: "mode", Label: "Cursor", Kind: SettingSelect, Choices: []string{"block", "bar", "underline"}, Default: "block"},
+			},
+		},
+		{
+			Title: "Appearance",
+			Settings: []Setting{
+				{Key: "accent", Label: "Accent color", Kind: SettingColor, Default: Hex("#ff8800")},
+				{Key: "save", Label: "Save shortcut", Kind: SettingKeybind, Default: "ctrl+s"},
+			},
+		},
+	}
+}
+
+func TestSettingsState_DefaultsAndSet(t *testing.T) {
+	store := &memorySettingsStore{}
+	state := NewSettingsState(testSettingsSections(), store)
+
+	assert.True(t, state.Bool("wrap"))
+	assert.Equal(t, 4, state.Int("tab"))
+	assert.Equal(t, "block", state.String("mode"))
+	assert.Equal(t, Hex("#ff8800"), state.Color("accent"))
+	assert.True(t, state.IsDefault("tab"))
+
+	require.NoError(t, state.Set("tab", 8))
+	assert.Equal(t, 8, state.Int("tab"))
+	assert.False(t, state.IsDefault("tab"))
+	assert.Equal(t, map[string]any{"tab": 8}, store.values, "only changed values are persisted")
+
+	require.NoError(t, state.Set("accent", Hex("#112233")))
+	assert.Equal(t, "#112233", store.values["accent"], "colors are stored as hex")
+}
+
+func TestSettingsState_Validation(t *testing.T) {
+	state := NewSettingsState(testSettingsSections(), nil)
+
+	assert.Error(t, state.Set("tab", 40))
+	assert.Equal(t, "must be between 1 and 16", state.Error("tab"))
+	assert.Equal(t, 4, state.Int("tab"))
+
+	assert.Error(t, state.Set("mode", "beam"))
+	assert.Error(t, state.Set("wrap", "yes"))
+	assert.Error(t, state.Set("save", "ctrl+"))
+	assert.Error(t, state.Set("missing", true))
+
+	require.NoError(t, state.Set("tab", 2))
+	assert.Equal(t, "", state.Error("tab"), "a valid value clears the error")
+
+	sections := testSettingsSections()
+	sections[0].Settings[1].Validate = func(value any) error {
+		if value.(int)%2 != 0 {
+			return assert.AnError
+		}
+		return nil
+	}
+	custom := NewSettingsState(sections, nil)
+	assert.ErrorIs(t, custom.Set("tab", 3), assert.AnError)
+}
+
+func TestSettingsState_ResetAndPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "settings.json")
+	state := NewSettingsState(testSettingsSections(), JSONFileStore{Path: path})
+
+	require.NoError(t, state.Set("wrap", false))
+	require.NoError(t, state.Set("tab", 2))
+	require.NoError(t, state.Set("accent", Hex("#abcdef")))
+
+	reloaded := NewSettingsState(testSettingsSections(), JSONFileStore{Path: path})
+	assert.False(t, reloaded.Bool("wrap"))
+	assert.Equal(t, 2, reloaded.Int("tab"))
+	assert.Equal(t, Hex("#abcdef"), reloaded.Color("accent"))
+	assert.False(t, reloaded.checks["wrap"].IsChecked(), "editor state reflects loaded values")
+	assert.Equal(t, "2", reloaded.inputs["tab"].GetText())
+
+	require.NoError(t, reloaded.Reset("tab"))
+	assert.True(t, reloaded.IsDefault("tab"))
+	assert.Equal(t, "4", reloaded.inputs["tab"].GetText())
+
+	require.NoError(t, reloaded.ResetAll())
+	assert.True(t, reloaded.Bool("wrap"))
+	assert.True(t, reloaded.checks["wrap"].IsChecked())
+
+	values, err := JSONFileStore{Path: path}.Load()
+	require.NoError(t, err)
+	assert.Empty(t, values)
+}
+
+func TestSettingsState_KeepsOtherStoredValues(t *testing.T) {
+	store := &memorySettingsStore{values: map[string]any{"tab": 2.0}}
+	state := NewSettingsState(testSettingsSections(), store)
+	tour := NewTourState("welcome", testTourSteps(), store)
+	tour.Start()
+	tour.Skip()
+
+	require.NoError(t, state.Set("wrap", false))
+	assert.Equal(t, map[string]any{"tab": 2, "wrap": false, "tour:welcome": true}, store.values)
+
+	require.NoError(t, state.ResetAll())
+	assert.Equal(t, map[string]any{"tour:welcome": true}, store.values, "resetting settings doesn't reset the tour")
+}
+
+func TestSettingsState_IgnoresInvalidStoredValues(t *testing.T) {
+	store := &memorySettingsStore{values: map[string]any{"tab": 99.0, "mode": "bar", "accent": "nope"}}
+	state := NewSettingsState(testSettingsSections(), store)
+
+	assert.Equal(t, 4, state.Int("tab"))
+	assert.Equal(t, "bar", state.String("mode"))
+	assert.Equal(t, Hex("#ff8800"), state.Color("accent"))
+}
+
+func TestSettingsState_EditorsApplyText(t *testing.T) {
+	state := NewSettingsState(testSettingsSections(), nil)
+
+	state.submitText(Setting{Key: "tab", Kind: SettingNumber, Default: 4, Min: 1, Max: 16}, "abc")
+	assert.Equal(t, "expected a whole number", state.Error("tab"))
+
+	setting, _ := state.lookup("tab")
+	state.submitText(setting, " 12 ")
+	assert.Equal(t, 12, state.Int("tab"))
+
+	setting, _ = state.lookup("mode")
+	state.cycleChoice(setting)
+	assert.Equal(t, "bar", state.String("mode"))
+	state.cycleChoice(setting)
+	state.cycleChoice(setting)
+	assert.Equal(t, "block", state.String("mode"))
+}
+
+func TestSettings_SearchFiltersRows(t *testing.T) {
+	state := NewSettingsState(testSettingsSections(), nil)
+	widget := Settings{State: state}
+
+	screen := func() string {
+		buf := RenderToBuffer(widget, 50, 16)
+		var lines []string
+		for y := 0; y < 16; y++ {
+			lines = append(lines, bufferLine(buf, y, 50))
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	all := screen()
+	assert.Contains(t, all, "Editor")
+	assert.Contains(t, all, "Appearance")
+	assert.Contains(t, all, "Wrap long lines")
+	assert.Contains(t, all, "block ▾")
+
+	state.SetQuery("shortcut")
+	filtered := screen()
+	assert.Contains(t, filtered, "Save shortcut")
+	assert.NotContains(t, filtered, "Editor")
+	assert.NotContains(t, filtered, "Tab width")
+
+	state.SetQuery("zzz")
+	assert.Contains(t, screen(), `No settings match "zzz"`)
+}
+
+func TestSettings_ShowsResetForChangedValues(t *testing.T) {
+	state := NewSettingsState(testSettingsSections(), nil)
+	require.NoError(t, state.Set("mode", "bar"))
+
+	buf := RenderToBuffer(Settings{State: state}, 50, 16)
+	var found bool
+	for y := 0; y < 16; y++ {
+		line := bufferLine(buf, y, 50)
+		if strings.Contains(line, "Cursor") {
+			found = strings.Contains(line, "Reset")
+		}
+	}
+	assert.True(t, found)
+}
@@ -0,0 +1,59 @@
+package terma
+
+// Shortcuts is a transparent wrapper widget that binds keys to actions for
+// its subtree. The bindings are active only while focus is within Child, so
+// a composite widget can ship its own shortcuts without the app declaring
+// them in Keybinds() and without them firing elsewhere. They appear in
+// KeybindBar while they are active.
+//
+// Keys bubble as usual: the focused widget and any enclosing widgets get
+// the key first, and Shortcuts' bindings are checked on the way out to
+// the app's own Keybinds().
+//
+// Shortcuts is transparent to layout — it delegates entirely to its Child.
+//
+// Example - a search panel with its own shortcuts:
+//
+//	Shortcuts{
+//	    Bindings: []Keybind{
+//	        {Key: "ctrl+r", Name: "Regex", Action: toggleRegex},
+//	        {Key: "ctrl+l", Name: "Clear", Action: clearQuery},
+//	    },
+//	    Child: Column{
+//	        Children: []Widget{
+//	            TextInput{ID: "query", State: queryState},
+//	            List[Result]{ID: "results", State: resultsState},
+//	        },
+//	    },
+//	}
+type Shortcuts struct {
+	// ID optionally identifies the subtree, for ctx.FocusWithin.
+	ID string
+
+	// Bindings are active while focus is within Child.
+	Bindings []Keybind
+
+	// Child is the widget subtree the bindings apply to.
+	Child Widget
+}
+
+// WidgetID returns the shortcuts scope's identifier.
+func (s Shortcuts) WidgetID() string {
+	return s.ID
+}
+
+// Keybinds returns the bindings.
+// Implements the KeybindProvider interface.
+func (s Shortcuts) Keybinds() []Keybind {
+	return s.Bindings
+}
+
+// Build returns the child widget directly.
+// Shortcuts is handled as a transparent wrapper by BuildRenderTree,
+// so this method is only called as a fallback.
+func (s Shortcuts) Build(ctx BuildContext) Widget {
+	if s.Child == nil {
+		return EmptyWidget{}
+	}
+	return s.Child.Build(ctx)
+}
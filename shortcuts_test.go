@@ -0,0 +1,51 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortcuts_ActiveOnlyWhileFocusWithin(t *testing.T) {
+	pressed := 0
+	widget := Row{Children: []Widget{
+		Shortcuts{
+			ID:       "panel",
+			Bindings: []Keybind{{Key: "x", Name: "Do", Action: func() { pressed++ }}},
+			Child:    Button{ID: "inside", Label: "In"},
+		},
+		Button{ID: "outside", Label: "Out"},
+	}}
+
+	fm := NewFocusManager()
+	buf := uv.NewBuffer(20, 1)
+	renderer := NewRenderer(buf, 20, 1, fm, NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil))
+	fm.SetFocusables(renderer.Render(widget))
+
+	fm.FocusByID("outside")
+	assert.False(t, fm.HandleKey(makeCharEvent('x')))
+	assert.NotContains(t, keybindNames(fm.ActiveKeybinds()), "Do")
+
+	fm.FocusByID("inside")
+	assert.True(t, fm.HandleKey(makeCharEvent('x')))
+	assert.Equal(t, 1, pressed)
+	assert.Contains(t, keybindNames(fm.ActiveKeybinds()), "Do", "shown in KeybindBar")
+	assert.True(t, fm.FocusWithin("panel"))
+}
+
+func TestShortcuts_TransparentToLayout(t *testing.T) {
+	buf := renderWithPointer(Shortcuts{Child: Text{Content: "Hi"}}, 2, 1, "", "")
+	assert.Equal(t, "Hi", rowText(buf, 2, 0))
+
+	buf = renderWithPointer(Shortcuts{}, 2, 1, "", "")
+	assert.Equal(t, "  ", rowText(buf, 2, 0), "no child renders nothing")
+}
+
+func keybindNames(keybinds []Keybind) []string {
+	names := make([]string, len(keybinds))
+	for i, kb := range keybinds {
+		names[i] = kb.Name
+	}
+	return names
+}
@@ -23,6 +23,12 @@ type signalCore[T comparable] struct {
 	mu        sync.Mutex
 	value     T
 	listeners map[*widgetNode]struct{}
+	watchers  map[*signalWatcher]struct{}
+}
+
+// signalWatcher is a callback run after a signal's value changes.
+type signalWatcher struct {
+	fn func()
 }
 
 // Signal holds reactive state that automatically tracks dependencies.
@@ -76,6 +82,7 @@ func (s Signal[T]) Set(value T) {
 	for listener := range s.core.listeners {
 		listeners = append(listeners, listener)
 	}
+	watchers := s.core.copyWatchers()
 	s.core.mu.Unlock()
 
 	for _, listener := range listeners {
@@ -83,6 +90,7 @@ func (s Signal[T]) Set(value T) {
 	}
 	recordRenderCause("Signal.Set", value, s.core, 2)
 	scheduleRender()
+	runWatchers(watchers)
 }
 
 // Peek returns the current value without subscribing.
@@ -111,6 +119,7 @@ func (s Signal[T]) Update(fn func(T) T) {
 	for listener := range s.core.listeners {
 		listeners = append(listeners, listener)
 	}
+	watchers := s.core.copyWatchers()
 	s.core.mu.Unlock()
 
 	for _, listener := range listeners {
@@ -118,6 +127,7 @@ func (s Signal[T]) Update(fn func(T) T) {
 	}
 	recordRenderCause("Signal.Update", newValue, s.core, 2)
 	scheduleRender()
+	runWatchers(watchers)
 }
 
 // unsubscribe removes a widget node from the listeners.
@@ -135,12 +145,50 @@ func (s Signal[T]) IsValid() bool {
 	return s.core != nil
 }
 
+// watch calls fn after every change to the value until stop is called.
+// fn runs on the goroutine that changed the value, without the lock held.
+func (s Signal[T]) watch(fn func()) (stop func()) {
+	watcher := &signalWatcher{fn: fn}
+	s.core.mu.Lock()
+	if s.core.watchers == nil {
+		s.core.watchers = make(map[*signalWatcher]struct{})
+	}
+	s.core.watchers[watcher] = struct{}{}
+	s.core.mu.Unlock()
+
+	return func() {
+		s.core.mu.Lock()
+		delete(s.core.watchers, watcher)
+		s.core.mu.Unlock()
+	}
+}
+
+// copyWatchers returns the current watchers. Must be called with mu held.
+func (c *signalCore[T]) copyWatchers() []*signalWatcher {
+	if len(c.watchers) == 0 {
+		return nil
+	}
+	watchers := make([]*signalWatcher, 0, len(c.watchers))
+	for watcher := range c.watchers {
+		watchers = append(watchers, watcher)
+	}
+	return watchers
+}
+
+// runWatchers calls each watcher's callback.
+func runWatchers(watchers []*signalWatcher) {
+	for _, watcher := range watchers {
+		watcher.fn()
+	}
+}
+
 // anySignalCore holds the internal state for AnySignal.
 // All fields are protected by mu for thread-safe access.
 type anySignalCore[T any] struct {
 	mu        sync.Mutex
 	value     T
 	listeners map[*widgetNode]struct{}
+	revision  uint64 // Bumped on every Set and Update
 }
 
 // AnySignal holds reactive state for non-comparable types (like interfaces).
@@ -183,6 +231,7 @@ func (s AnySignal[T]) Get() T {
 func (s AnySignal[T]) Set(value T) {
 	s.core.mu.Lock()
 	s.core.value = value
+	s.core.revision++
 
 	// Copy listeners to avoid holding lock during markDirty
 	listeners := make([]*widgetNode, 0, len(s.core.listeners))
@@ -211,7 +260,9 @@ func (s AnySignal[T]) Peek() T {
 // while holding the lock, so it should be fast and not call other Signal methods.
 func (s AnySignal[T]) Update(fn func(T) T) {
 	s.core.mu.Lock()
-	s.core.value = fn(s.core.value)
+	value := fn(s.core.value)
+	s.core.value = value
+	s.core.revision++
 
 	// Copy listeners to avoid holding lock during markDirty
 	listeners := make([]*widgetNode, 0, len(s.core.listeners))
@@ -223,10 +274,18 @@ func (s AnySignal[T]) Update(fn func(T) T) {
 	for _, listener := range listeners {
 		listener.markDirty()
 	}
-	recordRenderCause("AnySignal.Update", s.core.value, s.core, 2)
+	recordRenderCause("AnySignal.Update", value, s.core, 2)
 	scheduleRender()
 }
 
+// revision returns how many times the value has been set, so caches of
+// something derived from the value can tell when to rebuild.
+func (s AnySignal[T]) revision() uint64 {
+	s.core.mu.Lock()
+	defer s.core.mu.Unlock()
+	return s.core.revision
+}
+
 // IsValid returns true if the signal was properly initialized.
 // An uninitialized AnySignal (zero value) returns false.
 func (s AnySignal[T]) IsValid() bool {
@@ -251,6 +310,7 @@ func LastRenderCause() string {
 }
 
 func recordRenderCause(kind string, value any, core any, skip int) {
+	recordSignalChange(value, skip)
 	if !debugRenderCauseEnabled.Load() {
 		return
 	}
@@ -298,6 +298,19 @@ func TestSnapshot_List_MultiSelect(t *testing.T) {
 		"Multi-select list with items 0 and 2 selected. 'Option 1' and 'Option 3' shown as selected, 'Option 2' unselected.")
 }
 
+func TestSnapshot_List_SelectionMarkers(t *testing.T) {
+	state := NewListState([]string{"Option 1", "Option 2", "Option 3"})
+	state.Select(1)
+	widget := List[string]{
+		ID:          "list6",
+		State:       state,
+		MultiSelect: true,
+		Markers:     &SelectionMarkers{},
+	}
+	AssertSnapshot(t, widget, 30, 5,
+		"Multi-select list with a checkbox column: '☐ Option 1', '☑ Option 2' (accent checkbox, selected background), '☐ Option 3'.")
+}
+
 // =============================================================================
 // ProgressBar Widget Tests
 // =============================================================================
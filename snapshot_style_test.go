@@ -161,6 +161,70 @@ func TestSnapshot_Style_BorderMixedDecorations(t *testing.T) {
 		"30x5 column with square border. 'Styled' in italic at top-left, 'Plain' at top-right. 'Mixed decorations' inside.")
 }
 
+func TestSnapshot_Style_BorderThick(t *testing.T) {
+	widget := Column{
+		Width:  Cells(15),
+		Height: Cells(5),
+		Style: Style{
+			Border: ThickBorder(RGB(200, 200, 200)),
+		},
+		Children: []Widget{
+			Text{Content: "Thick"},
+		},
+	}
+	AssertSnapshot(t, widget, 15, 5,
+		"15x5 column with gray thick block border (█▀█ top, █ sides, █▄█ bottom). 'Thick' text inside.")
+}
+
+func TestSnapshot_Style_BorderPartialSides(t *testing.T) {
+	widget := Column{
+		Width:  Cells(20),
+		Height: Cells(5),
+		Style: Style{
+			Border: Border{
+				Style: BorderSquare,
+				Color: RGB(200, 200, 200),
+				Sides: BorderSideTop | BorderSideBottom,
+				SideColors: BorderSideColors{
+					Bottom: RGB(220, 80, 80),
+				},
+				Decorations: []BorderDecoration{BorderSubtitleCenter("total")},
+			},
+		},
+		Children: []Widget{
+			Text{Content: "No side borders"},
+		},
+	}
+	AssertSnapshot(t, widget, 20, 5,
+		"20x5 column with only top and bottom borders, full width and no corners. Gray top line, red bottom line with 'total' centered. 'No side borders' starts at column 0.")
+}
+
+func TestSnapshot_Style_BorderCustomCornersAndGroupedDecorations(t *testing.T) {
+	widget := Column{
+		Width:  Cells(30),
+		Height: Cells(5),
+		Style: Style{
+			Border: Border{
+				Style: BorderSquare,
+				Color: RGB(200, 200, 200),
+				Chars: BorderCharSet{TopLeft: "◆", TopRight: "◆", BottomLeft: "◆", BottomRight: "◆"},
+				Decorations: []BorderDecoration{
+					BorderTitle("one"),
+					BorderTitle("two"),
+					BorderTitleRight("a"),
+					BorderTitleRight("b"),
+					BorderSubtitleRight("end"),
+				},
+			},
+		},
+		Children: []Widget{
+			Text{Content: "Custom corners"},
+		},
+	}
+	AssertSnapshot(t, widget, 30, 5,
+		"30x5 column with square border and ◆ corners. ' one  two ' side by side at top-left, ' a  b ' at top-right, ' end ' at bottom-right. 'Custom corners' inside.")
+}
+
 func TestSnapshot_Style_BorderGradientWithMarkupTitle(t *testing.T) {
 	// Test that markup title text without explicit color samples from the gradient border
 	widget := Column{
@@ -19,7 +19,7 @@ type SplitPaneState struct {
 	DividerPosition Signal[float64] // 0.0-1.0
 
 	dragging   bool
-	dragOffset int
+	dragOffset float64 // Pointer position relative to the divider when the drag started
 
 	layoutCache splitPaneLayoutCache
 }
@@ -231,6 +231,22 @@ func (s SplitPane) OnClick(event MouseEvent) {
 	}
 }
 
+// CursorHint suggests a resize cursor over the divider and while it is
+// being dragged. Implements the CursorHintProvider interface.
+func (s SplitPane) CursorHint(event MouseEvent) CursorHint {
+	if s.State == nil {
+		return CursorDefault
+	}
+	cache := s.State.layoutCache
+	if !cache.valid || (!s.State.dragging && !s.isOnDivider(event, cache)) {
+		return CursorDefault
+	}
+	if cache.orientation == SplitVertical {
+		return CursorResizeVertical
+	}
+	return CursorResizeHorizontal
+}
+
 // OnMouseDown is called when the mouse is pressed on the widget.
 func (s SplitPane) OnMouseDown(event MouseEvent) {
 	if s.MouseDown != nil {
@@ -249,8 +265,7 @@ func (s SplitPane) OnMouseDown(event MouseEvent) {
 	}
 
 	s.State.dragging = true
-	coord := s.contentCoord(event, cache)
-	s.State.dragOffset = coord - cache.dividerPos
+	s.State.dragOffset = s.preciseContentCoord(event, cache) - float64(cache.dividerPos)
 }
 
 // OnMouseMove is called when the mouse is moved while dragging.
@@ -274,10 +289,11 @@ func (s SplitPane) OnMouseMove(event MouseEvent) {
 		return
 	}
 
-	coord := s.contentCoord(event, cache)
-	newOffset := coord - s.State.dragOffset
-	newOffset = clampInt(newOffset, 0, available)
-	newPos := float64(newOffset) / float64(available)
+	// Sub-cell pointer positions, where available, keep the divider
+	// exactly under the pointer instead of snapping between cells.
+	newOffset := s.preciseContentCoord(event, cache) - s.State.dragOffset
+	newOffset = clampFloat(newOffset, 0, float64(available))
+	newPos := newOffset / float64(available)
 	newPos = s.State.clampPosition(newPos)
 	s.State.DividerPosition.Set(newPos)
 }
@@ -531,6 +547,15 @@ func (s SplitPane) contentCoord(event MouseEvent, cache splitPaneLayoutCache) in
 	return event.LocalX - cache.contentOffsetX
 }
 
+// preciseContentCoord is contentCoord including the fraction of a cell,
+// when the terminal reports pixel positions.
+func (s SplitPane) preciseContentCoord(event MouseEvent, cache splitPaneLayoutCache) float64 {
+	if cache.orientation == SplitVertical {
+		return event.PreciseLocalY() - float64(cache.contentOffsetY)
+	}
+	return event.PreciseLocalX() - float64(cache.contentOffsetX)
+}
+
 func (s SplitPane) dividerProviders(dividerHighlighted bool) (ColorProvider, ColorProvider) {
 	var fg ColorProvider
 	var bg ColorProvider
@@ -1,6 +1,9 @@
 package terma
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestSplitPane_Horizontal(t *testing.T) {
 	state := NewSplitPaneState(0.5)
@@ -157,3 +160,58 @@ func splitPaneKeybindByKey(keybinds []Keybind, key string) (Keybind, bool) {
 	}
 	return Keybind{}, false
 }
+
+func TestSplitPane_DragUsesSubCellPointerPosition(t *testing.T) {
+	state := NewSplitPaneState(0.5)
+	widget := SplitPane{
+		State:        state,
+		DisableFocus: true,
+		First:        EmptyWidget{},
+		Second:       EmptyWidget{},
+		Orientation:  SplitHorizontal,
+		DividerSize:  1,
+	}
+	RenderToBuffer(widget, 21, 4)
+
+	// Cells are 10 pixels wide; grab the middle of the divider at x=10.
+	pointer := func(pixelX int) MouseEvent {
+		return MouseEvent{LocalX: pixelX / 10, HasPixels: true, PixelX: pixelX, CellWidth: 10, CellHeight: 20}
+	}
+	widget.OnMouseDown(pointer(105))
+	widget.OnMouseMove(pointer(127))
+	if got := state.GetPosition(); math.Abs(got-0.61) > 1e-9 {
+		t.Fatalf("expected divider at 0.61 after moving 2.2 cells, got %v", got)
+	}
+
+	// Without pixel positions, drags move in whole cells.
+	widget.OnMouseMove(MouseEvent{LocalX: 14})
+	if got := state.GetPosition(); math.Abs(got-0.675) > 1e-9 {
+		t.Fatalf("expected divider at 0.675, got %v", got)
+	}
+}
+
+func TestSplitPane_CursorHintOverDivider(t *testing.T) {
+	state := NewSplitPaneState(0.5)
+	widget := SplitPane{
+		State:        state,
+		DisableFocus: true,
+		First:        EmptyWidget{},
+		Second:       EmptyWidget{},
+		Orientation:  SplitHorizontal,
+		DividerSize:  1,
+	}
+	RenderToBuffer(widget, 21, 4)
+
+	if got := widget.CursorHint(MouseEvent{LocalX: 10}); got != CursorResizeHorizontal {
+		t.Fatalf("expected resize hint over the divider, got %v", got)
+	}
+	if got := widget.CursorHint(MouseEvent{LocalX: 3}); got != CursorDefault {
+		t.Fatalf("expected default hint over a pane, got %v", got)
+	}
+
+	// The hint stays while dragging, even once the pointer leaves the divider.
+	widget.OnMouseDown(MouseEvent{LocalX: 10})
+	if got := widget.CursorHint(MouseEvent{LocalX: 3}); got != CursorResizeHorizontal {
+		t.Fatalf("expected resize hint while dragging, got %v", got)
+	}
+}
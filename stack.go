@@ -1,6 +1,10 @@
 package terma
 
-import "github.com/darrenburns/terma/layout"
+import (
+	"slices"
+
+	"github.com/darrenburns/terma/layout"
+)
 
 // IntPtr returns a pointer to an int value.
 // This is a helper for creating Positioned widgets.
@@ -20,6 +24,7 @@ type Positioned struct {
 	Right  *int   // Offset from right edge (nil = not constrained)
 	Bottom *int   // Offset from bottom edge (nil = not constrained)
 	Left   *int   // Offset from left edge (nil = not constrained)
+	ZIndex int    // Paint order within the Stack: higher is drawn on top (default 0)
 	Child  Widget // The child widget to position
 }
 
@@ -39,8 +44,29 @@ func (p Positioned) Build(ctx BuildContext) Widget {
 	return p
 }
 
+// ClipBehavior controls whether a Stack's children may paint outside it.
+type ClipBehavior int
+
+// ClipBehavior constants.
+const (
+	// ClipDefault follows Style.Overflow, which lets children overflow
+	// unless set to OverflowClip.
+	ClipDefault ClipBehavior = iota
+	// ClipNone lets children paint outside the stack (e.g. badges).
+	ClipNone
+	// ClipToBounds clips children to the stack's border box; they may paint
+	// over its border.
+	ClipToBounds
+	// ClipInsideBorder clips children to the area inside the stack's border,
+	// so they never paint over it and rounded corners stay intact.
+	ClipInsideBorder
+)
+
 // Stack overlays children on top of each other in z-order.
-// First child is at the bottom, last child is on top.
+// First child is at the bottom, last child is on top, unless a Positioned
+// child sets a ZIndex: children are painted in ascending ZIndex, in
+// declaration order among equal ones, and those painted later receive
+// clicks first.
 //
 // Children can be:
 //   - Regular widgets: positioned using the Stack's Alignment
@@ -55,6 +81,7 @@ type Stack struct {
 	Width     Dimension        // Deprecated: use Style.Width
 	Height    Dimension        // Deprecated: use Style.Height
 	Style     Style            // Optional styling
+	Clip      ClipBehavior     // Whether children may paint outside the stack (default: Style.Overflow)
 	Click     func(MouseEvent) // Optional callback invoked when clicked
 	MouseDown func(MouseEvent) // Optional callback invoked when mouse is pressed
 	MouseUp   func(MouseEvent) // Optional callback invoked when mouse is released
@@ -121,9 +148,10 @@ func (s Stack) Build(ctx BuildContext) Widget {
 
 // BuildLayoutNode creates a StackNode for the layout system.
 func (s Stack) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
-	children := make([]layout.StackChild, len(s.Children))
+	ordered := s.paintOrder()
+	children := make([]layout.StackChild, len(ordered))
 
-	for i, child := range s.Children {
+	for i, child := range ordered {
 		childCtx := ctx.PushChild(i)
 		built := child.Build(childCtx)
 
@@ -211,11 +239,13 @@ func (s Stack) Render(ctx *RenderContext) {
 	// No-op: children are positioned by renderTree() using ComputedLayout.Children
 }
 
-// AllChildren returns all child widgets, unwrapping Positioned to get inner children.
+// AllChildren returns all child widgets in paint order, unwrapping Positioned
+// to get inner children.
 // This is used by the render tree to match widgets with their computed layouts.
 func (s Stack) AllChildren() []Widget {
-	children := make([]Widget, len(s.Children))
-	for i, child := range s.Children {
+	ordered := s.paintOrder()
+	children := make([]Widget, len(ordered))
+	for i, child := range ordered {
 		if positioned, ok := child.(Positioned); ok {
 			children[i] = positioned.Child
 		} else {
@@ -225,6 +255,46 @@ func (s Stack) AllChildren() []Widget {
 	return children
 }
 
+// paintOrder returns the children sorted by ZIndex, keeping declaration
+// order among equal ones.
+func (s Stack) paintOrder() []Widget {
+	ordered := slices.Clone(s.Children)
+	slices.SortStableFunc(ordered, func(a, b Widget) int {
+		return stackZIndex(a) - stackZIndex(b)
+	})
+	return ordered
+}
+
+// stackZIndex returns the ZIndex of a Positioned child, or 0.
+func stackZIndex(child Widget) int {
+	if positioned, ok := child.(Positioned); ok {
+		return positioned.ZIndex
+	}
+	return 0
+}
+
+// clipRect returns the rectangle, relative to the stack's border box, that
+// children are clipped to, or false when they may overflow.
+func (s Stack) clipRect(box layout.BoxModel) (Rect, bool) {
+	switch s.Clip {
+	case ClipNone:
+		return Rect{}, false
+	case ClipToBounds:
+		return Rect{Width: box.Width, Height: box.Height}, true
+	case ClipInsideBorder:
+		return Rect{
+			X:      box.Border.Left,
+			Y:      box.Border.Top,
+			Width:  box.Width - box.Border.Horizontal(),
+			Height: box.Height - box.Border.Vertical(),
+		}, true
+	}
+	if s.Style.Overflow == OverflowDefault || s.Style.Overflow == OverflowVisible {
+		return Rect{}, false
+	}
+	return Rect{Width: box.Width, Height: box.Height}, true
+}
+
 // toLayoutHAlign converts terma.HorizontalAlignment to layout.HorizontalAlignment.
 func toLayoutHAlign(a HorizontalAlignment) layout.HorizontalAlignment {
 	switch a {
@@ -0,0 +1,21 @@
+package terma
+
+// stateSlot picks the widget a List or Table shows instead of its items:
+// errorState when loadErr holds an error, loadingState while loading with
+// nothing to show yet, or emptyState when there is nothing to show.
+// Returns nil to show the items. Signals are only read (and subscribed to)
+// for the slots that are set.
+func stateSlot(loading Signal[bool], loadErr AnySignal[error], empty bool, emptyState, loadingState Widget, errorState func(err error) Widget) Widget {
+	if errorState != nil && loadErr.IsValid() {
+		if err := loadErr.Get(); err != nil {
+			return errorState(err)
+		}
+	}
+	if !empty {
+		return nil
+	}
+	if loadingState != nil && loading.IsValid() && loading.Get() {
+		return loadingState
+	}
+	return emptyState
+}
@@ -0,0 +1,65 @@
+package terma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList_StateSlots(t *testing.T) {
+	state := NewListState[string](nil)
+	list := List[string]{
+		State:        state,
+		EmptyState:   Text{Content: "nothing"},
+		LoadingState: Text{Content: "loading"},
+		ErrorState:   func(err error) Widget { return Text{Content: "error: " + err.Error()} },
+	}
+	render := func() string {
+		renderer, buf := portalTestRenderer(20, 3)
+		renderer.Render(list)
+		return bufferLine(buf, 0, 20)
+	}
+
+	assert.Equal(t, "nothing             ", render())
+
+	state.Loading.Set(true)
+	assert.Equal(t, "loading             ", render())
+
+	state.SetItems([]string{"apple"})
+	assert.Equal(t, "apple               ", render(), "items are shown while more load")
+
+	state.Err.Set(errors.New("offline"))
+	assert.Equal(t, "error: offline      ", render())
+}
+
+func TestList_EmptyStateWhenFilterMatchesNothing(t *testing.T) {
+	state := NewListState([]string{"apple", "banana"})
+	filter := NewFilterState()
+	filter.Query.Set("zzz")
+	list := List[string]{State: state, Filter: filter, EmptyState: Text{Content: "no matches"}}
+
+	renderer, buf := portalTestRenderer(20, 3)
+	renderer.Render(list)
+	assert.Equal(t, "no matches", bufferLine(buf, 0, 10))
+}
+
+func TestTable_StateSlots(t *testing.T) {
+	state := NewTableState[[]string](nil)
+	table := Table[[]string]{
+		State:      state,
+		Columns:    []TableColumn{{Header: Text{Content: "Name"}}},
+		EmptyState: Text{Content: "no rows"},
+		ErrorState: func(err error) Widget { return Text{Content: err.Error()} },
+	}
+
+	renderer, buf := portalTestRenderer(20, 3)
+	renderer.Render(table)
+	assert.Equal(t, "no rows", bufferLine(buf, 0, 7))
+
+	state.SetRows([][]string{{"Ada"}})
+	state.Err.Set(errors.New("timeout"))
+	renderer, buf = portalTestRenderer(20, 3)
+	renderer.Render(table)
+	assert.Equal(t, "timeout", bufferLine(buf, 0, 7))
+}
@@ -0,0 +1,518 @@
+package terma
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// StructField is one value shown by a StructView: a struct field, a map
+// entry, a slice element or a lone root value.
+type StructField struct {
+	Key   string // Field name, map key or "[i]" index ("" for a lone root value)
+	Path  string // Path from the root, such as "Servers[0].Port"; identifies the node
+	Value any    // The value, or nil for a nil pointer or interface
+
+	value reflect.Value // The field itself, settable when reached through a pointer
+	owner reflect.Value // The map holding the entry, for map entries
+	key   reflect.Value // The entry's key in owner
+}
+
+// Editable reports whether Set can change the field: its value is a
+// string, bool or number, and it was reached through a pointer or is a map
+// entry.
+func (f StructField) Editable() bool {
+	if _, ok := structEditKind(f.Value); !ok {
+		return false
+	}
+	return f.value.CanSet() || f.owner.IsValid()
+}
+
+// Set parses text as a value of the field's type and stores it in the
+// viewed value. Struct fields and slice elements can only be set when the
+// StructView was given a pointer.
+func (f StructField) Set(text string) error {
+	kind, ok := structEditKind(f.Value)
+	if !ok {
+		return fmt.Errorf("cannot edit %T", f.Value)
+	}
+	parsed, err := parsePropertyText(Property{Kind: kind, Value: f.Value}, text)
+	if err != nil {
+		return err
+	}
+	value := reflect.ValueOf(parsed)
+	switch {
+	case f.owner.IsValid():
+		f.owner.SetMapIndex(f.key, value.Convert(f.owner.Type().Elem()))
+	case f.value.CanSet():
+		f.value.Set(value.Convert(f.value.Type()))
+	default:
+		return errors.New("value is read-only; pass a pointer to edit it")
+	}
+	return nil
+}
+
+// structEditKind returns the property kind used to parse text for value.
+func structEditKind(value any) (PropertyKind, bool) {
+	if value == nil {
+		return 0, false
+	}
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.String:
+		return PropertyText, true
+	case reflect.Bool:
+		return PropertyBool, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return PropertyNumber, true
+	}
+	return 0, false
+}
+
+// StructViewState holds the value shown by a StructView, which nodes are
+// expanded and any edit in progress.
+type StructViewState struct {
+	Tree *TreeState[StructField] // Nodes, cursor and expansion
+
+	root    reflect.Value
+	editing AnySignal[string] // Path of the field being edited, or ""
+	input   *TextInputState
+	editErr Signal[string]
+}
+
+// NewStructViewState creates a state showing v, with every node collapsed.
+// Pass a pointer to let StructField.Set change struct fields and slice
+// elements.
+func NewStructViewState(v any) *StructViewState {
+	s := &StructViewState{
+		Tree:    NewTreeState[StructField](nil),
+		editing: NewAnySignal(""),
+		input:   NewTextInputState(""),
+		editErr: NewSignal(""),
+	}
+	s.Tree.nodeID = structFieldID
+	s.SetValue(v)
+	return s
+}
+
+// SetValue shows v in place of the current value. Nodes at the same paths
+// stay expanded.
+func (s *StructViewState) SetValue(v any) {
+	s.root = reflect.ValueOf(v)
+	s.Refresh()
+}
+
+// Refresh re-reads the value, after it has been changed in place.
+func (s *StructViewState) Refresh() {
+	s.Tree.Nodes.Set(structNodes(structRootFields(s.root), s.Tree.Nodes.Peek()))
+}
+
+// ExpandToDepth loads and expands the nodes above depth, so depth 1 shows
+// the children of the top-level nodes.
+func (s *StructViewState) ExpandToDepth(depth int) {
+	var expand func(nodes []TreeNode[StructField], level int) []TreeNode[StructField]
+	expand = func(nodes []TreeNode[StructField], level int) []TreeNode[StructField] {
+		next := make([]TreeNode[StructField], len(nodes))
+		for i, node := range nodes {
+			if level < depth && structHasChildren(node.Data) {
+				s.expandPath(node.Data.Path)
+				children := node.Children
+				if children == nil {
+					children = structNodes(structChildFields(node.Data), nil)
+				}
+				node.Children = expand(children, level+1)
+			}
+			next[i] = node
+		}
+		return next
+	}
+	s.Tree.Nodes.Set(expand(s.Tree.Nodes.Peek(), 0))
+}
+
+// expandPath removes a path from the collapsed set.
+func (s *StructViewState) expandPath(path string) {
+	if s.Tree.Collapsed.Peek()[path] {
+		s.Tree.Collapsed.Update(func(collapsed map[string]bool) map[string]bool {
+			next := make(map[string]bool, len(collapsed))
+			for k, v := range collapsed {
+				next[k] = v
+			}
+			delete(next, path)
+			return next
+		})
+	}
+}
+
+// Field returns the field the cursor is on.
+func (s *StructViewState) Field() (StructField, bool) {
+	return s.Tree.CursorNode()
+}
+
+func structFieldID(field StructField) string {
+	return field.Path
+}
+
+// structNodes makes tree nodes for fields. Fields whose node in previous
+// had its children loaded get theirs loaded again, so they stay expanded.
+func structNodes(fields []StructField, previous []TreeNode[StructField]) []TreeNode[StructField] {
+	loaded := map[string][]TreeNode[StructField]{}
+	for _, node := range previous {
+		if node.Children != nil {
+			loaded[node.Data.Path] = node.Children
+		}
+	}
+	nodes := make([]TreeNode[StructField], len(fields))
+	for i, field := range fields {
+		nodes[i] = TreeNode[StructField]{Data: field, Children: []TreeNode[StructField]{}}
+		if !structHasChildren(field) {
+			continue
+		}
+		nodes[i].Children = nil
+		if old, ok := loaded[field.Path]; ok {
+			nodes[i].Children = structNodes(structChildFields(field), old)
+		}
+	}
+	return nodes
+}
+
+// structRootFields returns the top-level fields of root: its fields,
+// entries or elements, or root itself when it holds none.
+func structRootFields(root reflect.Value) []StructField {
+	field := newStructField("", "", root)
+	if structHasChildren(field) {
+		return structChildFields(field)
+	}
+	return []StructField{field}
+}
+
+func newStructField(key, path string, value reflect.Value) StructField {
+	field := StructField{Key: key, Path: path, value: value}
+	if resolved := structResolve(value); resolved.IsValid() && resolved.CanInterface() {
+		field.Value = resolved.Interface()
+	}
+	return field
+}
+
+// structResolve follows pointers and interfaces to the value they hold, or
+// returns the zero Value for nil.
+func structResolve(value reflect.Value) reflect.Value {
+	for value.IsValid() && (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// structHasChildren reports whether a field holds fields, entries or
+// elements to expand into.
+func structHasChildren(field StructField) bool {
+	value := structResolve(field.value)
+	switch value.Kind() {
+	case reflect.Struct:
+		return isPropertyGroup(value) && len(structChildFields(field)) > 0
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return value.Len() > 0
+	}
+	return false
+}
+
+// structChildFields returns the exported fields of a struct, the entries
+// of a map in key order, or the elements of a slice or array.
+func structChildFields(parent StructField) []StructField {
+	value := structResolve(parent.value)
+	join := func(key string) string {
+		if parent.Path == "" {
+			return key
+		}
+		return parent.Path + "." + key
+	}
+
+	var fields []StructField
+	switch value.Kind() {
+	case reflect.Struct:
+		t := value.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.IsExported() && f.Tag.Get("prop") != "-" {
+				fields = append(fields, newStructField(f.Name, join(f.Name), value.Field(i)))
+			}
+		}
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			name := fmt.Sprint(key.Interface())
+			field := newStructField(name, join(name), value.MapIndex(key))
+			field.owner, field.key = value, key
+			fields = append(fields, field)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			index := "[" + strconv.Itoa(i) + "]"
+			fields = append(fields, newStructField(index, parent.Path+index, value.Index(i)))
+		}
+	}
+	return fields
+}
+
+// formatStructValue returns the text shown for a field's value and the kind
+// of token it is colored as. Containers show a count of what they hold.
+func formatStructValue(field StructField) (string, CodeTokenKind) {
+	value := structResolve(field.value)
+	if !value.IsValid() {
+		return "nil", CodeTokenKeyword
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return strconv.Quote(value.String()), CodeTokenString
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), CodeTokenKeyword
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if stringer, ok := field.Value.(fmt.Stringer); ok {
+			return stringer.String(), CodeTokenNumber
+		}
+		return formatPropertyValue(field.Value), CodeTokenNumber
+	case reflect.Map:
+		if value.IsNil() {
+			return "nil", CodeTokenKeyword
+		}
+		return "{" + structCount(value.Len(), "key") + "}", CodeTokenComment
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return "nil", CodeTokenKeyword
+		}
+		return "[" + structCount(value.Len(), "item") + "]", CodeTokenComment
+	case reflect.Struct:
+		if !isPropertyGroup(value) {
+			return fmt.Sprint(field.Value), CodeTokenPlain
+		}
+		return value.Type().Name() + "{…}", CodeTokenComment
+	}
+	if !value.CanInterface() {
+		return value.Type().String(), CodeTokenComment
+	}
+	return fmt.Sprint(field.Value), CodeTokenPlain
+}
+
+func structCount(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
+
+// structEditText returns the text an edit of value starts from.
+func structEditText(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return formatPropertyValue(value)
+}
+
+// StructView shows a value as a collapsible tree of its fields, for config
+// inspectors and data of unknown shape. It takes any Go value: structs
+// list their exported fields, maps their entries in key order and slices
+// their elements, each expanding into its own contents. Children are only
+// read when a node is first expanded, so large or deeply nested values
+// open quickly. Data decoded from JSON, YAML or TOML into maps and slices
+// shows the same way.
+//
+// Values are formatted by type: strings quoted, numbers, bools and nil in
+// their own colors, and containers as a count of what they hold.
+//
+// With OnEdit set, pressing e on a string, bool or number edits it in
+// place; Enter passes the text to OnEdit and Escape cancels. An error from
+// OnEdit is shown beside the editor. StructField.Set parses the text and
+// stores it, when the state was given a pointer.
+//
+// Example:
+//
+//	a.config = t.NewStructViewState(&cfg)
+//
+//	// In Build:
+//	t.StructView{
+//	    ID:    "config",
+//	    State: a.config,
+//	    OnEdit: func(field t.StructField, text string) error {
+//	        return field.Set(text)
+//	    },
+//	}
+type StructView struct {
+	ID       string                                     // Required for focus; the editor's ID is ID + "-edit"
+	State    *StructViewState                           // Required - holds the value and expansion
+	OnSelect func(field StructField)                    // Optional callback when Enter is pressed on a field
+	OnEdit   func(field StructField, text string) error // Optional: enables editing and applies an edit
+	Filter   *FilterState                               // Optional filter matching field keys of loaded nodes
+	Style    Style                                      // Optional styling
+}
+
+// WidgetID returns the struct view's unique identifier.
+func (v StructView) WidgetID() string {
+	return v.ID
+}
+
+// GetStyle returns the struct view's style.
+func (v StructView) GetStyle() Style {
+	return v.Style
+}
+
+// IsFocusable returns true to allow keyboard navigation.
+func (v StructView) IsFocusable() bool {
+	return v.State != nil
+}
+
+// OnKey handles keys not covered by declarative keybindings.
+func (v StructView) OnKey(event KeyEvent) bool {
+	return v.State != nil && v.tree().OnKey(event)
+}
+
+// OnMouseDown moves the cursor to the clicked field.
+func (v StructView) OnMouseDown(event MouseEvent) {
+	if v.State != nil {
+		v.tree().OnMouseDown(event)
+	}
+}
+
+// Keybinds returns the tree's navigation keys, plus e to edit when OnEdit
+// is set. While an edit is open only Escape is bound, so keys the editor
+// doesn't use can't move the cursor.
+func (v StructView) Keybinds() []Keybind {
+	if v.State == nil {
+		return nil
+	}
+	if v.State.editing.Peek() != "" {
+		return []Keybind{{Key: "escape", Name: "Cancel", Action: v.cancelEdit}}
+	}
+	keybinds := v.tree().Keybinds()
+	if v.OnEdit != nil {
+		keybinds = append(keybinds, Keybind{Key: "e", Name: "Edit", Action: v.startEdit})
+	}
+	return keybinds
+}
+
+func (v StructView) editID() string {
+	return v.ID + "-edit"
+}
+
+// startEdit opens the editor on the field under the cursor, if editable.
+func (v StructView) startEdit() {
+	field, ok := v.State.Field()
+	if !ok || !field.Editable() {
+		return
+	}
+	v.State.input.SetText(structEditText(field.Value))
+	v.State.input.CursorEnd()
+	v.State.editErr.Set("")
+	v.State.editing.Set(field.Path)
+	RequestFocus(v.editID())
+}
+
+func (v StructView) cancelEdit() {
+	v.State.editing.Set("")
+	v.State.editErr.Set("")
+	RequestFocus(v.ID)
+}
+
+// commitEdit passes the edited text to OnEdit, closing the editor and
+// re-reading the value if it succeeds.
+func (v StructView) commitEdit(field StructField, text string) {
+	if err := v.OnEdit(field, text); err != nil {
+		v.State.editErr.Set(err.Error())
+		return
+	}
+	v.cancelEdit()
+	v.State.Refresh()
+}
+
+// tree returns the Tree the fields are shown in, without its node renderer.
+func (v StructView) tree() Tree[StructField] {
+	tree := Tree[StructField]{
+		ID:          v.ID,
+		State:       v.State.Tree,
+		NodeID:      structFieldID,
+		HasChildren: structHasChildren,
+		OnExpand: func(field StructField, _ []int, setChildren func([]TreeNode[StructField])) {
+			setChildren(structNodes(structChildFields(field), nil))
+		},
+		Filter: v.Filter,
+		MatchNode: func(field StructField, query string, options FilterOptions) MatchResult {
+			return MatchString(field.Key, query, options)
+		},
+		Style: v.Style,
+	}
+	if v.OnSelect != nil {
+		tree.OnSelect = func(field StructField, _ []StructField) { v.OnSelect(field) }
+	}
+	return tree
+}
+
+// Build renders the fields as a Tree.
+func (v StructView) Build(ctx BuildContext) Widget {
+	if v.State == nil {
+		return EmptyWidget{}
+	}
+	tree := v.tree()
+	tree.RenderNodeWithMatch = v.renderField(ctx, tree)
+	return tree.Build(ctx)
+}
+
+// renderField returns the row for a field: its key, then its formatted
+// value or the editor.
+func (v StructView) renderField(ctx BuildContext, tree Tree[StructField]) func(StructField, TreeNodeContext, MatchResult) Widget {
+	theme := ctx.Theme()
+	focused := ctx.IsFocused(tree)
+	editing := v.State.editing.Get()
+	highlight := MatchHighlightStyle(theme)
+
+	return func(field StructField, nodeCtx TreeNodeContext, match MatchResult) Widget {
+		style := tree.styleForContext(ctx, nodeCtx, focused)
+		cursor := nodeCtx.Active && focused
+		keyStyle := SpanStyle{Foreground: theme.Primary}
+		if cursor {
+			keyStyle = SpanStyle{}
+		}
+
+		var spans []Span
+		if field.Key != "" {
+			if match.Matched && len(match.Ranges) > 0 {
+				spans = HighlightSpans(field.Key, match.Ranges, highlight)
+			} else {
+				spans = []Span{{Text: field.Key, Style: keyStyle}}
+			}
+			spans = append(spans, Span{Text: ": "})
+		}
+
+		if field.Path == editing {
+			children := []Widget{
+				Text{Spans: spans, Style: style},
+				TextInput{
+					ID:       v.editID(),
+					State:    v.State.input,
+					OnSubmit: func(text string) { v.commitEdit(field, text) },
+					Style:    Style{Width: Flex(1), BackgroundColor: theme.Surface},
+				},
+			}
+			if msg := v.State.editErr.Get(); msg != "" {
+				children = append(children, Text{Content: " " + msg, Style: Style{ForegroundColor: theme.Error}})
+			}
+			return Row{Style: Style{Width: Flex(1)}, Children: children}
+		}
+
+		text, kind := formatStructValue(field)
+		valueStyle := CodeTokenStyle(theme, kind)
+		if cursor {
+			valueStyle = SpanStyle{}
+		}
+		spans = append(spans, Span{Text: text, Style: valueStyle})
+		style.Width = Flex(1)
+		return Text{Spans: spans, Style: style, Truncate: TruncateEnd}
+	}
+}
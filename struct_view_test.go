@@ -0,0 +1,151 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type structViewServer struct {
+	Host    string
+	Port    int
+	Timeout time.Duration
+	Tags    []string
+	secret  string
+}
+
+type structViewConfig struct {
+	Name    string
+	Debug   bool
+	Servers []structViewServer
+	Extra   map[string]any
+	Owner   *structViewServer
+}
+
+func structViewSample() *structViewConfig {
+	return &structViewConfig{
+		Name:    "api",
+		Servers: []structViewServer{{Host: "db.local", Port: 5432, Timeout: 2 * time.Second, secret: "x"}},
+		Extra:   map[string]any{"zone": "eu", "replicas": 3},
+	}
+}
+
+func structViewLines(view StructView, height int) []string {
+	lines := strings.Split(screenText(view, 40, height), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	return lines
+}
+
+func TestStructView_FormatsValuesByType(t *testing.T) {
+	state := NewStructViewState(structViewSample())
+
+	lines := structViewLines(StructView{ID: "config", State: state}, 5)
+
+	assert.Equal(t, []string{
+		`  Name: "api"`,
+		`  Debug: false`,
+		`▶ Servers: [1 item]`,
+		`▶ Extra: {2 keys}`,
+		`  Owner: nil`,
+	}, lines)
+}
+
+func TestStructView_LoadsChildrenOnExpand(t *testing.T) {
+	state := NewStructViewState(structViewSample())
+	view := StructView{ID: "config", State: state}
+	tree := view.tree()
+
+	servers, ok := state.Tree.NodeAtPath([]int{2})
+	require.True(t, ok)
+	assert.Nil(t, servers.Children, "children aren't read until expanded")
+
+	tree.expandNode(servers, []int{2})
+	server, _ := state.Tree.NodeAtPath([]int{2, 0})
+	tree.expandNode(server, []int{2, 0})
+
+	fields, _ := state.Tree.NodeAtPath([]int{2, 0})
+	var keys []string
+	for _, child := range fields.Children {
+		keys = append(keys, child.Data.Path)
+	}
+	assert.Equal(t, []string{"Servers[0].Host", "Servers[0].Port", "Servers[0].Timeout", "Servers[0].Tags"}, keys, "unexported fields are skipped")
+	assert.Contains(t, structViewLines(view, 10), "  ├─  Timeout: 2s")
+}
+
+func TestStructViewState_RefreshKeepsExpansion(t *testing.T) {
+	config := structViewSample()
+	state := NewStructViewState(config)
+	state.ExpandToDepth(1)
+
+	config.Extra["zone"] = "us"
+	state.Refresh()
+
+	extra, _ := state.Tree.NodeAtPath([]int{3})
+	require.NotNil(t, extra.Children)
+	assert.Equal(t, "us", extra.Children[1].Data.Value)
+}
+
+func TestStructField_Set(t *testing.T) {
+	config := structViewSample()
+	state := NewStructViewState(config)
+	state.ExpandToDepth(2)
+
+	name, _ := state.Tree.NodeAtPath([]int{0})
+	require.NoError(t, name.Data.Set("web"))
+	port, _ := state.Tree.NodeAtPath([]int{2, 0, 1})
+	require.NoError(t, port.Data.Set("6543"))
+	replicas, _ := state.Tree.NodeAtPath([]int{3, 0})
+	require.NoError(t, replicas.Data.Set("5"))
+
+	assert.Equal(t, "web", config.Name)
+	assert.Equal(t, 6543, config.Servers[0].Port)
+	assert.Equal(t, 5, config.Extra["replicas"])
+	assert.EqualError(t, port.Data.Set("many"), "expected a whole number")
+
+	byValue := NewStructViewState(*config)
+	debug, _ := byValue.Tree.NodeAtPath([]int{1})
+	assert.False(t, debug.Data.Editable())
+	assert.Error(t, debug.Data.Set("true"))
+}
+
+func TestStructView_EditWithOnEdit(t *testing.T) {
+	config := structViewSample()
+	state := NewStructViewState(config)
+	state.Tree.CursorPath.Set([]int{1})
+	var edited []string
+	view := StructView{ID: "config", State: state, OnEdit: func(field StructField, text string) error {
+		edited = append(edited, field.Path+"="+text)
+		return field.Set(text)
+	}}
+
+	require.True(t, matchKeybind(makeCharEvent('e'), view.Keybinds()))
+	assert.Equal(t, "Debug", state.editing.Peek())
+	assert.Equal(t, "false", state.input.GetText())
+
+	field, _ := state.Field()
+	view.commitEdit(field, "maybe")
+	assert.Equal(t, "expected true or false", state.editErr.Peek())
+	view.commitEdit(field, "true")
+
+	assert.Equal(t, []string{"Debug=maybe", "Debug=true"}, edited)
+	assert.True(t, config.Debug)
+	assert.Equal(t, "", state.editing.Peek())
+	assert.Equal(t, "  Debug: true", structViewLines(view, 2)[1])
+
+	state.Tree.CursorPath.Set([]int{2})
+	matchKeybind(makeKeyEvent(uv.KeyEscape, 0), view.Keybinds())
+	matchKeybind(makeCharEvent('e'), view.Keybinds())
+	assert.Equal(t, "", state.editing.Peek(), "containers aren't editable")
+}
+
+func TestStructView_Snapshot(t *testing.T) {
+	state := NewStructViewState(structViewSample())
+	state.ExpandToDepth(2)
+	AssertSnapshot(t, StructView{ID: "config", State: state}, 40, 14, "Nested config: strings green, numbers and bools colored, containers show counts, two levels expanded")
+}
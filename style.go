@@ -42,6 +42,7 @@ const (
 	BorderHeavy
 	BorderDashed
 	BorderAscii
+	BorderThick
 )
 
 // BorderCharSet contains the characters used to render a border.
@@ -90,11 +91,57 @@ func GetBorderCharSet(style BorderStyle) BorderCharSet {
 			TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
 			Top: "-", Bottom: "-", Left: "|", Right: "|",
 		}
+	case BorderThick:
+		return BorderCharSet{
+			TopLeft: "█", TopRight: "█", BottomLeft: "█", BottomRight: "█",
+			Top: "▀", Bottom: "▄", Left: "█", Right: "█",
+		}
 	default:
 		return BorderCharSet{}
 	}
 }
 
+// withOverrides returns the character set with each non-empty field of
+// overrides replacing the matching character.
+func (c BorderCharSet) withOverrides(overrides BorderCharSet) BorderCharSet {
+	override := func(char *string, with string) {
+		if with != "" {
+			*char = with
+		}
+	}
+	override(&c.TopLeft, overrides.TopLeft)
+	override(&c.TopRight, overrides.TopRight)
+	override(&c.BottomLeft, overrides.BottomLeft)
+	override(&c.BottomRight, overrides.BottomRight)
+	override(&c.Top, overrides.Top)
+	override(&c.Bottom, overrides.Bottom)
+	override(&c.Left, overrides.Left)
+	override(&c.Right, overrides.Right)
+	return c
+}
+
+// BorderSides selects the sides of a border that are drawn.
+type BorderSides uint8
+
+// Border side constants, combined with |. The zero value draws all sides.
+const (
+	BorderSideTop BorderSides = 1 << iota
+	BorderSideRight
+	BorderSideBottom
+	BorderSideLeft
+
+	BorderSidesAll = BorderSideTop | BorderSideRight | BorderSideBottom | BorderSideLeft
+)
+
+// BorderSideColors overrides the border color of individual sides. Nil
+// sides use Border.Color. Corners take the color of the top or bottom side.
+type BorderSideColors struct {
+	Top    ColorProvider
+	Right  ColorProvider
+	Bottom ColorProvider
+	Left   ColorProvider
+}
+
 // DecorationPosition defines where a decoration appears on the border.
 type DecorationPosition int
 
@@ -108,7 +155,8 @@ const (
 	DecorationBottomRight
 )
 
-// BorderDecoration defines a text label on a border edge.
+// BorderDecoration defines a text label on a border edge. Several
+// decorations at the same position are drawn side by side in order.
 type BorderDecoration struct {
 	Text     string // Plain text (used if Markup is empty)
 	Markup   string // Markup string, parsed at render time for styled text
@@ -182,6 +230,9 @@ type Border struct {
 	Style       BorderStyle
 	Color       ColorProvider // Can be Color or Gradient
 	Decorations []BorderDecoration
+	Sides       BorderSides      // Sides to draw (default: all); hidden sides take no space
+	SideColors  BorderSideColors // Optional per-side colors overriding Color
+	Chars       BorderCharSet    // Optional characters overriding the style's (e.g. custom corners)
 }
 
 // SquareBorder creates a square border with the given color and optional decorations.
@@ -235,13 +286,22 @@ func AsciiBorder(color ColorProvider, decorations ...BorderDecoration) Border {
 	return Border{Style: BorderAscii, Color: color, Decorations: decorations}
 }
 
+// ThickBorder creates a thick block border with the given color and optional decorations.
+//
+//	█▀▀▀█
+//	█   █
+//	█▄▄▄█
+func ThickBorder(color ColorProvider, decorations ...BorderDecoration) Border {
+	return Border{Style: BorderThick, Color: color, Decorations: decorations}
+}
+
 // IsZero returns true if no border is set.
 func (b Border) IsZero() bool {
 	return b.Style == BorderNone
 }
 
 // Width returns the border width (1 if border is set, 0 otherwise).
-// Borders consume 1 cell on each side.
+// Borders consume 1 cell on each side that is drawn; see Insets.
 func (b Border) Width() int {
 	if b.Style == BorderNone {
 		return 0
@@ -249,6 +309,54 @@ func (b Border) Width() int {
 	return 1
 }
 
+// HasSide reports whether the given side of the border is drawn.
+func (b Border) HasSide(side BorderSides) bool {
+	if b.Style == BorderNone {
+		return false
+	}
+	return b.Sides == 0 || b.Sides&side != 0
+}
+
+// Insets returns the space the border takes on each side.
+func (b Border) Insets() EdgeInsets {
+	side := func(s BorderSides) int {
+		if b.HasSide(s) {
+			return 1
+		}
+		return 0
+	}
+	return EdgeInsets{
+		Top:    side(BorderSideTop),
+		Right:  side(BorderSideRight),
+		Bottom: side(BorderSideBottom),
+		Left:   side(BorderSideLeft),
+	}
+}
+
+// charSet returns the characters the border is drawn with.
+func (b Border) charSet() BorderCharSet {
+	return GetBorderCharSet(b.Style).withOverrides(b.Chars)
+}
+
+// sideColor returns the color of a side, falling back to Color.
+func (b Border) sideColor(side BorderSides) ColorProvider {
+	var color ColorProvider
+	switch side {
+	case BorderSideTop:
+		color = b.SideColors.Top
+	case BorderSideRight:
+		color = b.SideColors.Right
+	case BorderSideBottom:
+		color = b.SideColors.Bottom
+	case BorderSideLeft:
+		color = b.SideColors.Left
+	}
+	if color != nil && color.IsSet() {
+		return color
+	}
+	return b.Color
+}
+
 // UnderlineStyle defines the visual style of underlined text.
 type UnderlineStyle int
 
@@ -262,6 +370,25 @@ const (
 	UnderlineDashed
 )
 
+// Overflow controls what happens to content that exceeds a widget's bounds.
+type Overflow int
+
+// Overflow constants.
+const (
+	// OverflowDefault uses the widget's built-in behavior: Stack lets children
+	// bleed past its edges, all other widgets clip.
+	OverflowDefault Overflow = iota
+	// OverflowClip clips content to the widget's bounds.
+	OverflowClip
+	// OverflowVisible lets content render outside the widget's bounds (e.g. badges).
+	OverflowVisible
+	// OverflowEllipsis clips content and draws Style.Ellipsis where it was cut off.
+	OverflowEllipsis
+)
+
+// DefaultEllipsis is the marker drawn for OverflowEllipsis when Style.Ellipsis is empty.
+const DefaultEllipsis = "…"
+
 // Style defines the visual appearance of a widget.
 type Style struct {
 	ForegroundColor ColorProvider // Can be Color or Gradient
@@ -284,6 +411,22 @@ type Style struct {
 	Margin  EdgeInsets
 	Border  Border
 
+	// Overflow
+	Overflow Overflow // How content exceeding the widget's bounds is handled
+	Ellipsis string   // Marker for OverflowEllipsis (default: DefaultEllipsis)
+
+	// Focus
+	FocusRing FocusRing // Indicator drawn while the widget has focus (default: SetDefaultFocusRing)
+
+	// Pointer states. Colors, text attributes and the border's look apply;
+	// layout fields are ignored so the widget never moves.
+	HoverStyle   *Style // Applied while the pointer is over the widget or its descendants
+	PressedStyle *Style // Applied while a mouse button is held down on the widget
+
+	// Row and Column children
+	Order   int   // Children are laid out by ascending Order; equal orders keep their position
+	Visible *bool // When false, the child is left out of layout entirely (default: true)
+
 	// Dimensions (content-box)
 	Width     Dimension
 	Height    Dimension
@@ -312,6 +455,13 @@ func (s Style) IsZero() bool {
 		s.Padding == (EdgeInsets{}) &&
 		s.Margin == (EdgeInsets{}) &&
 		s.Border.IsZero() &&
+		s.Overflow == OverflowDefault &&
+		s.Ellipsis == "" &&
+		s.FocusRing == FocusRingDefault &&
+		s.HoverStyle == nil &&
+		s.PressedStyle == nil &&
+		s.Order == 0 &&
+		s.Visible == nil &&
 		s.Width.IsUnset() &&
 		s.Height.IsUnset() &&
 		s.MinWidth.IsUnset() &&
@@ -320,6 +470,14 @@ func (s Style) IsZero() bool {
 		s.MaxHeight.IsUnset()
 }
 
+// EllipsisMarker returns the marker drawn for OverflowEllipsis.
+func (s Style) EllipsisMarker() string {
+	if s.Ellipsis != "" {
+		return s.Ellipsis
+	}
+	return DefaultEllipsis
+}
+
 // GetDimensions returns the style's dimension fields.
 func (s Style) GetDimensions() DimensionSet {
 	return DimensionSet{
@@ -332,6 +490,19 @@ func (s Style) GetDimensions() DimensionSet {
 	}
 }
 
+// SpanVerticalAlign positions a span relative to the surrounding text's baseline.
+// Terminal cells cannot be offset vertically, so raised and lowered spans are
+// drawn with Unicode superscript and subscript characters where they exist;
+// other characters are drawn unchanged.
+type SpanVerticalAlign int
+
+// Span vertical alignment constants.
+const (
+	SpanAlignBaseline SpanVerticalAlign = iota
+	SpanAlignSuperscript
+	SpanAlignSubscript
+)
+
 // SpanStyle defines text attributes for a span (colors + formatting).
 type SpanStyle struct {
 	Foreground     Color
@@ -345,6 +516,8 @@ type SpanStyle struct {
 	Reverse        bool
 	Conceal        bool
 	Strikethrough  bool
+	VerticalAlign  SpanVerticalAlign
+	Link           string // Hyperlink URL, emitted as an OSC 8 link and reported on click
 }
 
 // Span represents a segment of text with its own styling.
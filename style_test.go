@@ -155,6 +155,22 @@ func TestBorder_Width_ReturnsOneForRoundedBorder(t *testing.T) {
 	}
 }
 
+func TestBorder_Insets_SkipHiddenSides(t *testing.T) {
+	border := SquareBorder(RGB(255, 255, 255))
+	if got := border.Insets(); got != EdgeInsetsAll(1) {
+		t.Errorf("expected all sides by default, got %+v", got)
+	}
+
+	border.Sides = BorderSideTop | BorderSideLeft
+	if got := border.Insets(); got != EdgeInsetsTRBL(1, 0, 0, 1) {
+		t.Errorf("expected top and left insets only, got %+v", got)
+	}
+
+	if got := (Border{Sides: BorderSidesAll}).Insets(); got != (EdgeInsets{}) {
+		t.Errorf("expected no insets without a border style, got %+v", got)
+	}
+}
+
 func TestBorder_IsZero_TrueForNoBorder(t *testing.T) {
 	var border Border
 
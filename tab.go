@@ -551,7 +551,7 @@ func (t TabBar) Build(ctx BuildContext) Widget {
 		// Add default padding if not set
 		if style.Padding.Top == 0 && style.Padding.Bottom == 0 &&
 			style.Padding.Left == 0 && style.Padding.Right == 0 {
-			style.Padding = EdgeInsetsXY(2, 0)
+			style.Padding = EdgeInsetsXY(ctx.Density().Pick(1, 2, 3), 0)
 		}
 
 		// Build tab content
@@ -3,7 +3,10 @@ package terma
 import (
 	"fmt"
 	"reflect"
-	"sort"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/darrenburns/terma/layout"
 )
@@ -16,8 +19,37 @@ type TableState[T any] struct {
 	CursorIndex  Signal[int]                 // Cursor position (row index)
 	CursorColumn Signal[int]                 // Cursor position (column index)
 	Selection    AnySignal[map[int]struct{}] // Selected indices (row/column/cell based on selection mode)
-
-	anchorIndex *int // Anchor point for shift-selection (nil = no anchor)
+	Loading      Signal[bool]                // True while rows are loading (set by BindTable)
+	Err          AnySignal[error]            // Error loading rows, or nil (set by BindTable)
+	Expanded     AnySignal[map[int]struct{}] // Indices of rows showing their RenderDetail widget
+
+	// VisibleColumns holds the columns shown, as indices into Table.Columns
+	// in display order; nil shows every column. Set it directly, through
+	// the column chooser (Table.ColumnChooser) or with ApplyView.
+	VisibleColumns AnySignal[[]int]
+
+	// ViewStore optionally persists the views saved with SaveView, under
+	// "table-views:<ViewStoreKey>". The same store can be shared with
+	// SettingsState; tables only touch their own key.
+	ViewStore    SettingsStore
+	ViewStoreKey string
+
+	// KeyFor optionally returns a stable identity for a row. When set, the
+	// cursor and the row at the top of the viewport follow their rows when
+	// SetRows (or any other change) moves them to new indices. So does the
+	// selection in TableSelectionRow mode, where it holds row indices;
+	// removed rows are deselected.
+	KeyFor func(row T) string
+
+	// OnSelectionChange is called with the selected indices after the
+	// selection changes.
+	OnSelectionChange func(selected map[int]struct{})
+
+	selection *SelectionModel[int] // Created on first use
+	tracker   keyTracker[T]        // Follows the cursor and selection across moves (with KeyFor)
+	scrollTop scrollAnchor         // Row at the top of the viewport (with KeyFor)
+	changes   changeHighlight      // Rows ReplaceRowsDiff changed, fading out
+	onScreen  onScreenItems        // Rows in view in the last frame, for Refresher
 
 	lastSelectionMode TableSelectionMode
 	hasSelectionMode  bool
@@ -25,6 +57,18 @@ type TableState[T any] struct {
 	rowLayouts        []tableRowLayout // Cached layout metrics (per row)
 	viewIndices       []int            // View index -> source index for filtered views
 	viewIndexBySource map[int]int      // Source index -> view index for filtered views
+
+	filter        *filterEngine[T, tableRowMatch] // Incremental filtering (created on first use)
+	filterColumns int                             // Column count the filter cache was built for
+
+	typeAhead *typeAheadBuffer // Text typed for type-ahead (created on first use)
+
+	detailReveal map[int]*AnimatedValue[float64] // Per-row detail open/close animations (with DetailAnimation)
+
+	columns *tableColumnView // Column chooser and saved views (created on first use)
+
+	fitRequested atomic.Bool // FitToContent was called; the next layout measures every row
+	fitWidths    []int       // Auto column content widths frozen by FitToContent
 }
 
 // NewTableState creates a new TableState with the given initial rows.
@@ -33,22 +77,76 @@ func NewTableState[T any](initialRows []T) *TableState[T] {
 		initialRows = []T{}
 	}
 	return &TableState[T]{
-		Rows:         NewAnySignal(initialRows),
-		CursorIndex:  NewSignal(0),
-		CursorColumn: NewSignal(0),
-		Selection:    NewAnySignal(make(map[int]struct{})),
+		Rows:           NewAnySignal(initialRows),
+		CursorIndex:    NewSignal(0),
+		CursorColumn:   NewSignal(0),
+		Selection:      NewAnySignal(make(map[int]struct{})),
+		Loading:        NewSignal(false),
+		Err:            NewAnySignal[error](nil),
+		Expanded:       NewAnySignal(make(map[int]struct{})),
+		VisibleColumns: NewAnySignal[[]int](nil),
 	}
 }
 
+func (s *TableState[T]) typeAheadBuffer() *typeAheadBuffer {
+	if s.typeAhead == nil {
+		s.typeAhead = newTypeAheadBuffer()
+	}
+	return s.typeAhead
+}
+
+// FitToContent sizes the table's Auto columns to the widest cell in each,
+// measuring every row on the next layout, and keeps those widths until it
+// is called again or ClearFit is called. Use it once data has loaded to
+// fit a large table exactly without measuring every row on each frame.
+// Safe to call from any goroutine.
+func (s *TableState[T]) FitToContent() {
+	s.fitRequested.Store(true)
+	scheduleRender()
+}
+
+// ClearFit goes back to measuring Auto columns on every layout after
+// FitToContent. Safe to call from any goroutine.
+func (s *TableState[T]) ClearFit() {
+	s.fitRequested.Store(false)
+	s.fitWidths = nil
+	scheduleRender()
+}
+
 // SetRows replaces all rows and clamps cursor to valid range.
 func (s *TableState[T]) SetRows(rows []T) {
 	if rows == nil {
 		rows = []T{}
 	}
 	s.Rows.Set(rows)
+	s.syncKeys()
 	s.clampCursor()
 }
 
+// ReplaceRowsDiff replaces all rows like SetRows, for data that is
+// refreshed wholesale, such as by polling an API, and returns what changed.
+// Rows are paired with their replacements by KeyFor, or by index without
+// it, and equal decides whether a paired row was updated (nil compares
+// with reflect.DeepEqual). The cursor, selection and scroll position stay
+// on their rows, and the inserted and updated rows are highlighted
+// briefly, so a refreshing dashboard doesn't reset.
+//
+// Example:
+//
+//	state.KeyFor = func(host Host) string { return host.Name }
+//	diff := state.ReplaceRowsDiff(hosts, nil)
+//	a.status.Set(fmt.Sprintf("%d new, %d gone", len(diff.Inserted), len(diff.Removed)))
+func (s *TableState[T]) ReplaceRowsDiff(rows []T, equal func(a, b T) bool) ItemDiff {
+	if rows == nil {
+		rows = []T{}
+	}
+	old := s.Rows.Peek()
+	diff := diffReplacement(old, rows, s.KeyFor, equal)
+	s.SetRows(rows)
+	s.changes.start(changedKeys(rows, len(old) > 0, diff, s.KeyFor))
+	return diff
+}
+
 // GetRows returns the current table rows (without subscribing to changes).
 func (s *TableState[T]) GetRows() []T {
 	return s.Rows.Peek()
@@ -75,6 +173,7 @@ func (s *TableState[T]) Prepend(row T) {
 	s.CursorIndex.Update(func(i int) int {
 		return i + 1
 	})
+	s.syncKeys()
 }
 
 // InsertAt inserts a row at the specified index.
@@ -97,6 +196,7 @@ func (s *TableState[T]) InsertAt(index int, row T) {
 	if index <= cursorIdx {
 		s.CursorIndex.Set(cursorIdx + 1)
 	}
+	s.syncKeys()
 }
 
 // RemoveAt removes the row at the specified index.
@@ -109,6 +209,7 @@ func (s *TableState[T]) RemoveAt(index int) bool {
 	s.Rows.Update(func(rows []T) []T {
 		return append(rows[:index], rows[index+1:]...)
 	})
+	s.syncKeys()
 	s.clampCursor()
 	return true
 }
@@ -128,6 +229,7 @@ func (s *TableState[T]) RemoveWhere(predicate func(T) bool) int {
 		}
 		return result
 	})
+	s.syncKeys()
 	s.clampCursor()
 	return removed
 }
@@ -137,6 +239,69 @@ func (s *TableState[T]) Clear() {
 	s.Rows.Set([]T{})
 	s.CursorIndex.Set(0)
 	s.CursorColumn.Set(0)
+	s.syncKeys()
+}
+
+// WithRows replaces the rows with what fn returns, given a copy of the
+// current rows, in a single update. Use it to make several changes at
+// once, so the table rebuilds once rather than after each change. The
+// cursor is clamped to the new rows, and with KeyFor set the cursor and
+// selection follow their rows.
+func (s *TableState[T]) WithRows(fn func(rows []T) []T) {
+	s.SetRows(fn(slices.Clone(s.Rows.Peek())))
+}
+
+// InsertAll inserts rows at the specified index, keeping their order.
+// If index is out of bounds, it's clamped to valid range.
+func (s *TableState[T]) InsertAll(index int, rows []T) {
+	if len(rows) == 0 {
+		return
+	}
+	current := s.Rows.Peek()
+	index = max(0, min(index, len(current)))
+	s.Rows.Set(slices.Concat(current[:index], rows, current[index:]))
+	// Adjust cursor if insertion was at or before cursor
+	cursorIdx := s.CursorIndex.Peek()
+	if index <= cursorIdx {
+		s.CursorIndex.Set(cursorIdx + len(rows))
+	}
+	s.syncKeys()
+}
+
+// RemoveIndices removes the rows at the given indices, ignoring indices
+// that are out of bounds or repeated. Returns the number of rows removed.
+func (s *TableState[T]) RemoveIndices(indices ...int) int {
+	rows, removed := removeIndices(s.Rows.Peek(), indices)
+	if removed == 0 {
+		return 0
+	}
+	s.SetRows(rows)
+	return removed
+}
+
+// UpdateWhere replaces each row matching the predicate with the result of
+// transform. Returns the number of rows updated.
+func (s *TableState[T]) UpdateWhere(predicate func(T) bool, transform func(T) T) int {
+	rows, updated := updateWhere(s.Rows.Peek(), predicate, transform)
+	if updated == 0 {
+		return 0
+	}
+	s.SetRows(rows)
+	return updated
+}
+
+// Swap exchanges the rows at indices i and j. Returns false if either
+// index is out of bounds. With KeyFor set, the cursor and selection move
+// with the swapped rows.
+func (s *TableState[T]) Swap(i, j int) bool {
+	rows := s.Rows.Peek()
+	if i < 0 || i >= len(rows) || j < 0 || j >= len(rows) {
+		return false
+	}
+	rows = slices.Clone(rows)
+	rows[i], rows[j] = rows[j], rows[i]
+	s.SetRows(rows)
+	return true
 }
 
 // SelectedRow returns the currently selected row (if any).
@@ -235,67 +400,89 @@ func (s *TableState[T]) viewIndexForSource(sourceIdx int) (int, bool) {
 	return 0, false
 }
 
-// ToggleSelection toggles the selection state of the row at the given index.
-func (s *TableState[T]) ToggleSelection(index int) {
-	s.Selection.Update(func(sel map[int]struct{}) map[int]struct{} {
-		newSel := make(map[int]struct{}, len(sel))
-		for k := range sel {
-			newSel[k] = struct{}{}
+// SelectionModel returns the model behind Selection, for code shared with
+// other selectable widgets.
+func (s *TableState[T]) SelectionModel() *SelectionModel[int] {
+	if s.selection == nil {
+		if !s.Selection.IsValid() {
+			s.Selection = NewAnySignal(make(map[int]struct{}))
 		}
-		if _, exists := newSel[index]; exists {
-			delete(newSel, index)
-		} else {
-			newSel[index] = struct{}{}
+		s.selection = &SelectionModel[int]{Selection: s.Selection}
+		s.selection.OnSelectionChange = func(map[int]struct{}) {
+			s.tracker.record(s.Rows.Peek(), s.KeyFor, s.CursorIndex, s.keyedSelection())
+			if s.OnSelectionChange != nil {
+				s.OnSelectionChange(s.Selection.Peek())
+			}
 		}
-		return newSel
-	})
+	}
+	return s.selection
+}
+
+// keyedSelection returns the selection model while it holds row indices,
+// or nil when KeyFor should leave it alone.
+func (s *TableState[T]) keyedSelection() *SelectionModel[int] {
+	if s.hasSelectionMode && s.lastSelectionMode != TableSelectionRow {
+		return nil
+	}
+	return s.SelectionModel()
+}
+
+// syncKeys moves the cursor and selection to follow rows that moved (see
+// KeyFor).
+func (s *TableState[T]) syncKeys() {
+	s.tracker.sync(s.Rows.Peek(), s.KeyFor, s.CursorIndex, s.keyedSelection())
+}
+
+// ToggleSelection toggles the selection state of the row at the given index.
+func (s *TableState[T]) ToggleSelection(index int) {
+	s.SelectionModel().Toggle(index)
 }
 
 // Select adds the row at the given index to the selection.
 func (s *TableState[T]) Select(index int) {
-	s.Selection.Update(func(sel map[int]struct{}) map[int]struct{} {
-		newSel := make(map[int]struct{}, len(sel)+1)
-		for k := range sel {
-			newSel[k] = struct{}{}
-		}
-		newSel[index] = struct{}{}
-		return newSel
-	})
+	s.SelectionModel().Select(index)
 }
 
 // Deselect removes the row at the given index from the selection.
 func (s *TableState[T]) Deselect(index int) {
-	s.Selection.Update(func(sel map[int]struct{}) map[int]struct{} {
-		newSel := make(map[int]struct{}, len(sel))
-		for k := range sel {
-			if k != index {
-				newSel[k] = struct{}{}
-			}
-		}
-		return newSel
-	})
+	s.SelectionModel().Deselect(index)
 }
 
 // IsSelected returns true if the row at the given index is selected.
 func (s *TableState[T]) IsSelected(index int) bool {
-	sel := s.Selection.Peek()
-	_, exists := sel[index]
-	return exists
+	return s.SelectionModel().IsSelected(index)
 }
 
 // ClearSelection removes all rows from the selection.
 func (s *TableState[T]) ClearSelection() {
-	s.Selection.Set(make(map[int]struct{}))
+	s.SelectionModel().Clear()
 }
 
 // SelectAll selects all rows in the table.
 func (s *TableState[T]) SelectAll() {
-	rows := s.Rows.Peek()
-	sel := make(map[int]struct{}, len(rows))
-	for i := range rows {
-		sel[i] = struct{}{}
+	s.SelectionModel().SelectAll(indexRange(0, len(s.Rows.Peek())))
+}
+
+// SelectAllVisible selects the rows that match the current filter (all rows
+// when unfiltered), replacing the selection.
+// Note: This assumes row-based selection.
+func (s *TableState[T]) SelectAllVisible() {
+	s.SelectionModel().SelectAll(s.visibleIndices())
+}
+
+// InvertSelection flips the selection of the rows that match the current
+// filter (all rows when unfiltered). Hidden rows keep their state.
+// Note: This assumes row-based selection.
+func (s *TableState[T]) InvertSelection() {
+	s.SelectionModel().Invert(s.visibleIndices())
+}
+
+// visibleIndices returns the source indices of the rows in the current view.
+func (s *TableState[T]) visibleIndices() []int {
+	if s.viewIndices != nil {
+		return s.viewIndices
 	}
-	s.Selection.Set(sel)
+	return indexRange(0, len(s.Rows.Peek()))
 }
 
 // SelectedRows returns all currently selected rows.
@@ -315,30 +502,17 @@ func (s *TableState[T]) SelectedRows() []T {
 // SelectedIndices returns the indices of all selected rows in ascending order.
 // Note: This assumes row-based selection.
 func (s *TableState[T]) SelectedIndices() []int {
-	sel := s.Selection.Peek()
-	result := make([]int, 0, len(sel))
-	for i := range sel {
-		result = append(result, i)
-	}
-	// Sort for consistent ordering
-	for i := 0; i < len(result)-1; i++ {
-		for j := i + 1; j < len(result); j++ {
-			if result[i] > result[j] {
-				result[i], result[j] = result[j], result[i]
-			}
-		}
-	}
-	return result
+	return sortedSelection(s.Selection.Peek())
 }
 
 // SetAnchor sets the anchor point for shift-selection.
 func (s *TableState[T]) SetAnchor(index int) {
-	s.anchorIndex = &index
+	s.SelectionModel().SetAnchor(index)
 }
 
 // ClearAnchor removes the anchor point.
 func (s *TableState[T]) ClearAnchor() {
-	s.anchorIndex = nil
+	s.SelectionModel().ClearAnchor()
 }
 
 func (s *TableState[T]) syncSelectionMode(mode TableSelectionMode) {
@@ -357,15 +531,16 @@ func (s *TableState[T]) syncSelectionMode(mode TableSelectionMode) {
 
 // HasAnchor returns true if an anchor point is set.
 func (s *TableState[T]) HasAnchor() bool {
-	return s.anchorIndex != nil
+	_, ok := s.SelectionModel().Anchor()
+	return ok
 }
 
 // GetAnchor returns the anchor index, or -1 if no anchor is set.
 func (s *TableState[T]) GetAnchor() int {
-	if s.anchorIndex == nil {
-		return -1
+	if anchor, ok := s.SelectionModel().Anchor(); ok {
+		return anchor
 	}
-	return *s.anchorIndex
+	return -1
 }
 
 // SelectRange selects all rows between from and to (inclusive).
@@ -374,23 +549,35 @@ func (s *TableState[T]) SelectRange(from, to int) {
 		from, to = to, from
 	}
 	rows := s.Rows.Peek()
-	if from < 0 {
-		from = 0
-	}
-	if to >= len(rows) {
-		to = len(rows) - 1
-	}
-	sel := make(map[int]struct{}, to-from+1)
-	for i := from; i <= to; i++ {
-		sel[i] = struct{}{}
+	from = max(from, 0)
+	to = min(to, len(rows)-1)
+	if to < from {
+		s.ClearSelection()
+		return
 	}
-	s.Selection.Set(sel)
+	s.SelectionModel().SetSelected(indexRange(from, to+1))
 }
 
 // TableColumn defines layout properties for a table column.
 type TableColumn struct {
-	Width  Dimension // Optional width (Cells, Percent, Flex, Auto)
-	Header Widget    // Optional header widget for this column
+	Width        Dimension         // Optional width (Cells, Percent, Flex, Auto)
+	MinWidth     int               // Minimum width of an Auto column (0 = none)
+	MaxWidth     int               // Maximum width of an Auto column, so one long cell can't widen it past this (0 = none)
+	Header       Widget            // Optional header widget for this column
+	FilterWeight float64           // Multiplies this column's match score when ranking rows (0 = 1)
+	Name         string            // Name for field:value terms in FilterQuery mode (default: Text header content)
+	Render       TableCellRenderer // Optional built-in renderer for this column's cells (DataBar, DeltaCell, SparklineCell); takes precedence over RenderCell
+}
+
+// filterName returns the name FilterQuery field terms use for the column.
+func (c TableColumn) filterName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	if header, ok := c.Header.(Text); ok {
+		return header.Content
+	}
+	return ""
 }
 
 // TableSelectionMode controls how cursor and selection highlights are applied.
@@ -417,6 +604,7 @@ type Table[T any] struct {
 	RenderCellWithMatch func(row T, rowIndex int, colIndex int, active bool, selected bool, match MatchResult) Widget // Optional cell renderer with match data
 	Filter              *FilterState                                                                                  // Optional filter state for matching rows
 	MatchCell           func(row T, rowIndex int, colIndex int, query string, options FilterOptions) MatchResult      // Optional matcher per cell
+	CopyCell            func(row T, rowIndex int, colIndex int) string                                                // Optional: text of a cell for CopyRows (default: slice element, or the row's %v in column 0)
 	RenderHeader        func(colIndex int) Widget                                                                     // Optional header renderer (takes precedence over column headers)
 	OnSelect            func(row T)                                                                                   // Callback invoked when Enter is pressed on a row
 	OnCursorChange      func(row T)                                                                                   // Callback invoked when cursor moves to a different row
@@ -425,7 +613,17 @@ type Table[T any] struct {
 	ColumnSpacing       int                                                                                           // Space between columns
 	RowSpacing          int                                                                                           // Space between rows
 	SelectionMode       TableSelectionMode                                                                            // Cursor/selection highlight mode (row/column/cursor)
+	AutoFitSample       int                                                                                           // Auto columns measure the first N rows and N random others instead of every row (0 = all rows)
 	MultiSelect         bool                                                                                          // Enable multi-select mode (shift+move to extend)
+	ColumnChooser       bool                                                                                          // ctrl+o opens a popup to show, hide and reorder columns (see TableState.VisibleColumns)
+	TypeAhead           *TypeAhead[T]                                                                                 // Optional: typing jumps to the next row whose label starts with the typed text
+	FormatRules         []TableFormatRule[T]                                                                          // Optional conditional formatting: styles applied to the cells each rule matches
+	EmptyState          Widget                                                                                        // Optional widget shown instead of the table when there are no rows (or none match the filter)
+	LoadingState        Widget                                                                                        // Optional widget shown instead of EmptyState while State.Loading is true
+	ErrorState          func(err error) Widget                                                                        // Optional widget shown instead of the table while State.Err is set
+	RenderDetail        func(row T, rowIndex int) Widget                                                              // Optional: space (and enter without OnSelect) expands the cursor row to show this widget beneath it, spanning all columns
+	ExpandPolicy        TableExpandPolicy                                                                             // Whether several rows can be expanded at once (default TableExpandMultiple)
+	DetailAnimation     time.Duration                                                                                 // Optional: grow details open and shrink them closed over this duration (0 = instant)
 	Width               Dimension                                                                                     // Deprecated: use Style.Width
 	Height              Dimension                                                                                     // Deprecated: use Style.Height
 	Style               Style                                                                                         // Optional styling
@@ -436,8 +634,9 @@ type Table[T any] struct {
 }
 
 type tableRowLayout struct {
-	y      int
-	height int
+	y            int
+	height       int
+	detailHeight int // Height of the row's detail beneath it
 }
 
 type tableContainer[T any] struct {
@@ -446,6 +645,7 @@ type tableContainer[T any] struct {
 	rowCount    int
 	columnCount int
 	headerRows  int
+	details     []tableDetail // Detail rows; their widgets follow the cells in children
 }
 
 func (c tableContainer[T]) Build(ctx BuildContext) Widget {
@@ -497,10 +697,24 @@ func (c tableContainer[T]) OnLayout(ctx BuildContext, metrics LayoutMetrics) {
 		rowLayouts[dataRow] = tableRowLayout{y: top, height: bottom - top}
 	}
 
+	cellCount := (c.rowCount + c.headerRows) * c.columnCount
+	for i, detail := range c.details {
+		bounds, ok := metrics.ChildBounds(cellCount + i)
+		dataRow := detail.Row - c.headerRows
+		if !ok || dataRow < 0 || dataRow >= c.rowCount {
+			continue
+		}
+		rowLayouts[dataRow].detailHeight += bounds.Height
+	}
+
 	c.State.rowLayouts = rowLayouts
+	c.keepScrollAnchor()
 	if c.selectionMode() != TableSelectionColumn {
 		c.scrollCursorIntoView()
 	}
+	c.State.onScreen.record(ctx.frame, onScreenIndices(c.viewIndices(), c.rowCount, func(viewIdx int) (int, int) {
+		return rowLayouts[viewIdx].y, rowLayouts[viewIdx].height + rowLayouts[viewIdx].detailHeight
+	}, c.ScrollState))
 }
 
 func (c tableContainer[T]) ChildWidgets() []Widget {
@@ -577,6 +791,16 @@ func (t Table[T]) Build(ctx BuildContext) Widget {
 		return Column{}
 	}
 
+	if t.ColumnChooser {
+		t.buildColumnChooser(ctx)
+	}
+	if visible := t.visibleColumns(t.State.VisibleColumns.Get()); visible != nil {
+		if t.RenderCell == nil && t.RenderCellWithMatch == nil {
+			t.RenderCellWithMatch = t.themedDefaultRenderCell(ctx)
+		}
+		t = t.withColumns(visible)
+	}
+
 	renderCell := t.RenderCell
 	renderCellWithMatch := t.RenderCellWithMatch
 	if renderCellWithMatch == nil && renderCell == nil {
@@ -586,6 +810,10 @@ func (t Table[T]) Build(ctx BuildContext) Widget {
 	rows := t.State.Rows.Get()
 	columnCount := len(t.Columns)
 	mode := t.selectionMode()
+	if t.TypeAhead != nil && !t.TypeAhead.HideOverlay && mode != TableSelectionColumn {
+		t.State.typeAheadBuffer().overlay(ctx, t.anchorID(ctx))
+	}
+	t.State.syncKeys()
 	query, options := filterStateValues(t.Filter)
 	viewRows, viewIndices, viewMatches := t.filteredRows(rows, columnCount, query, options)
 	t.State.setViewIndices(viewIndices)
@@ -611,6 +839,10 @@ func (t Table[T]) Build(ctx BuildContext) Widget {
 		}
 	}
 
+	if slot := t.stateSlot(len(viewRows) == 0); slot != nil {
+		t.State.rowLayouts = nil
+		return slot
+	}
 	if len(viewRows) == 0 && headerRows == 0 {
 		t.State.rowLayouts = nil
 		return Column{}
@@ -644,8 +876,18 @@ func (t Table[T]) Build(ctx BuildContext) Widget {
 		t.registerScrollCallbacks(mode, hasHeader)
 	}
 
+	columnCells := t.columnCells(ctx, rows)
+	formatRules := t.sortedFormatRules()
+	widgetFocused := ctx.IsFocused(t)
+	theme := ctx.Theme()
+
 	for viewRowIdx, row := range viewRows {
 		sourceRowIdx := viewIndices[viewRowIdx]
+		var changeTint Color
+		changed := false
+		if t.State.changes.active() {
+			changeTint, changed = t.State.changes.tint(theme, itemKey(rows, sourceRowIdx, t.State.KeyFor))
+		}
 		for colIdx := 0; colIdx < columnCount; colIdx++ {
 			active := tableCellActive(mode, sourceRowIdx, colIdx, cursorRow, cursorCol)
 			selected := false
@@ -657,24 +899,71 @@ func (t Table[T]) Build(ctx BuildContext) Widget {
 				match = viewMatches[viewRowIdx][colIdx]
 			}
 			var cell Widget
-			if renderCellWithMatch != nil {
-				cell = renderCellWithMatch(row, sourceRowIdx, colIdx, active, selected, match)
-			} else {
-				cell = renderCell(row, sourceRowIdx, colIdx, active, selected)
+			if render := columnCells[colIdx]; render != nil {
+				cell = render(row, tableDefaultCellStyle(theme, active, selected, widgetFocused))
+			}
+			if cell == nil {
+				if renderCellWithMatch != nil {
+					cell = renderCellWithMatch(row, sourceRowIdx, colIdx, active, selected, match)
+				} else {
+					cell = renderCell(row, sourceRowIdx, colIdx, active, selected)
+				}
 			}
 			if cell == nil {
 				cell = Text{}
 			}
+			if formatRules != nil {
+				highlighted := (active && widgetFocused) || selected
+				cell = formatCell(cell, formatRules, row, sourceRowIdx, colIdx, highlighted)
+			}
+			if changed {
+				cell = Row{Style: Style{BackgroundColor: changeTint, Width: Flex(1)}, Children: []Widget{cell}}
+			}
 			children = append(children, cell)
 		}
 	}
 
+	detailWidgets, details := t.buildDetails(viewRows, viewIndices, headerRows)
+	children = append(children, detailWidgets...)
+
 	return tableContainer[T]{
 		Table:       t,
 		children:    children,
 		rowCount:    len(viewRows),
 		columnCount: columnCount,
 		headerRows:  headerRows,
+		details:     details,
+	}
+}
+
+// columnCells returns the cell render function of each column with a
+// Render set, or nil for columns rendered by RenderCell.
+func (t Table[T]) columnCells(ctx BuildContext, rows []T) []func(row any, style Style) Widget {
+	cells := make([]func(row any, style Style) Widget, len(t.Columns))
+	for i, column := range t.Columns {
+		if column.Render != nil {
+			cells[i] = column.Render.columnCells(ctx, rows)
+		}
+	}
+	return cells
+}
+
+// stateSlot returns the EmptyState, LoadingState or ErrorState widget to
+// show in place of the table, or nil to show the table.
+func (t Table[T]) stateSlot(empty bool) Widget {
+	slot := stateSlot(t.State.Loading, t.State.Err, empty, t.EmptyState, t.LoadingState, t.ErrorState)
+	if slot == nil {
+		return nil
+	}
+	width, height := t.GetContentDimensions()
+	style := t.Style
+	style.Width, style.Height = width, height
+	return Column{
+		ID:       t.ID,
+		Style:    style,
+		Children: []Widget{slot},
+		Click:    t.Click,
+		Hover:    t.Hover,
 	}
 }
 
@@ -741,6 +1030,7 @@ func (t Table[T]) filteredRows(rows []T, columnCount int, query string, options
 		for i := range rows {
 			viewIndices[i] = i
 		}
+		reportFilterResult(t.Filter, len(rows), false)
 		return rows, viewIndices, nil
 	}
 
@@ -749,57 +1039,117 @@ func (t Table[T]) filteredRows(rows []T, columnCount int, query string, options
 		matchCell = defaultTableMatchCell[T]
 	}
 
-	viewRows := make([]T, 0, len(rows))
-	viewIndices := make([]int, 0, len(rows))
-	viewMatches := make([][]MatchResult, 0, len(rows))
-	rowRanks := make([]fuzzyMatchRank, 0, len(rows))
-
-	for rowIdx, row := range rows {
-		cellMatches := make([]MatchResult, columnCount)
-		rowMatched := false
-		bestRank := fuzzyWorstMatchRank()
-		for colIdx := 0; colIdx < columnCount; colIdx++ {
-			match := matchCell(row, rowIdx, colIdx, query, options)
-			cellMatches[colIdx] = match
-			if match.Matched {
-				rowMatched = true
-				rank := fuzzyMatchRankFromResult(match)
-				if fuzzyMatchRankLess(rank, bestRank) {
-					bestRank = rank
+	matcher := filterMatcher[T, tableRowMatch]{
+		match: func(rowIdx int, row T, q string, o FilterOptions) (tableRowMatch, bool) {
+			if o.Mode == FilterQuery && o.Matcher == nil {
+				return t.matchQueryRow(row, rowIdx, columnCount, parseFilterQuery(q).terms, o, matchCell)
+			}
+			result := tableRowMatch{cells: make([]MatchResult, columnCount), rank: fuzzyWorstMatchRank()}
+			rowMatched := false
+			for colIdx := 0; colIdx < columnCount; colIdx++ {
+				match := matchCell(row, rowIdx, colIdx, q, o)
+				result.cells[colIdx] = match
+				if match.Matched {
+					score := match.Score
+					if colIdx < len(t.Columns) {
+						score = weightScore(score, t.Columns[colIdx].FilterWeight)
+					}
+					if !rowMatched || score > result.score {
+						result.score = score
+					}
+					rowMatched = true
+					rank := fuzzyMatchRankFromResult(match)
+					if fuzzyMatchRankLess(rank, result.rank) {
+						result.rank = rank
+					}
 				}
 			}
+			return result, rowMatched
+		},
+		rank:  func(match tableRowMatch) fuzzyMatchRank { return match.rank },
+		score: func(match tableRowMatch) int { return match.score },
+	}
+
+	var pass *filterPass[tableRowMatch]
+	pending := false
+	if t.State == nil {
+		pass = runFilterPass(rows, nil, query, options, matcher)
+	} else {
+		if t.State.filter == nil {
+			t.State.filter = newFilterEngine[T, tableRowMatch]()
 		}
-		if rowMatched {
-			viewRows = append(viewRows, row)
-			viewIndices = append(viewIndices, rowIdx)
-			viewMatches = append(viewMatches, cellMatches)
-			rowRanks = append(rowRanks, bestRank)
+		if t.State.filterColumns != columnCount {
+			t.State.filter.reset()
+			t.State.filterColumns = columnCount
 		}
+		pass = t.State.filter.filter(rows, query, options, asyncFilterThreshold(t.Filter), matcher)
+		pending = t.State.filter.isPending()
+	}
+	reportFilterResult(t.Filter, len(pass.indices), pending)
+
+	viewRows := make([]T, len(pass.indices))
+	viewMatches := make([][]MatchResult, len(pass.indices))
+	for i, rowIdx := range pass.indices {
+		viewRows[i] = rows[rowIdx]
+		viewMatches[i] = pass.results[i].cells
 	}
+	return viewRows, pass.indices, viewMatches
+}
+
+// tableRowMatch is the filter result for one row: a match per cell and the
+// best rank and weighted score among them.
+type tableRowMatch struct {
+	cells []MatchResult
+	rank  fuzzyMatchRank
+	score int
+}
 
-	if options.Mode == FilterFuzzy && len(viewRows) > 1 {
-		order := make([]int, len(viewRows))
-		for i := range order {
-			order[i] = i
+// matchQueryRow matches FilterQuery terms against a row. Each term must
+// match some cell, restricted to the named column for field terms; a cell's
+// ranges combine those of every term it matched.
+func (t Table[T]) matchQueryRow(row T, rowIdx int, columnCount int, terms []QueryTerm, options FilterOptions, matchCell func(T, int, int, string, FilterOptions) MatchResult) (tableRowMatch, bool) {
+	options.Mode = FilterContains
+	result := tableRowMatch{cells: make([]MatchResult, columnCount), rank: fuzzyWorstMatchRank()}
+	for _, term := range terms {
+		termMatched := false
+		termScore := 0
+		for colIdx := 0; colIdx < columnCount; colIdx++ {
+			var column TableColumn
+			if colIdx < len(t.Columns) {
+				column = t.Columns[colIdx]
+			}
+			if term.Field != "" && !strings.EqualFold(term.Field, column.filterName()) {
+				continue
+			}
+			match := matchCell(row, rowIdx, colIdx, term.Text, options)
+			if !match.Matched {
+				continue
+			}
+			cell := &result.cells[colIdx]
+			cell.Matched = true
+			cell.Ranges = append(cell.Ranges, match.Ranges...)
+			cell.Score += match.Score
+			if score := weightScore(match.Score, column.FilterWeight); !termMatched || score > termScore {
+				termScore = score
+			}
+			termMatched = true
 		}
-		sort.SliceStable(order, func(i, j int) bool {
-			return fuzzyMatchRankLess(rowRanks[order[i]], rowRanks[order[j]])
-		})
-
-		sortedRows := make([]T, len(viewRows))
-		sortedIndices := make([]int, len(viewIndices))
-		sortedMatches := make([][]MatchResult, len(viewMatches))
-		for i, originalIdx := range order {
-			sortedRows[i] = viewRows[originalIdx]
-			sortedIndices[i] = viewIndices[originalIdx]
-			sortedMatches[i] = viewMatches[originalIdx]
+		if !termMatched {
+			return tableRowMatch{}, false
 		}
-		viewRows = sortedRows
-		viewIndices = sortedIndices
-		viewMatches = sortedMatches
+		result.score += termScore
 	}
-
-	return viewRows, viewIndices, viewMatches
+	for colIdx := range result.cells {
+		cell := &result.cells[colIdx]
+		if !cell.Matched {
+			continue
+		}
+		cell.Ranges = normalizeRankRanges(cell.Ranges)
+		if rank := fuzzyMatchRankFromResult(*cell); fuzzyMatchRankLess(rank, result.rank) {
+			result.rank = rank
+		}
+	}
+	return result, true
 }
 
 func defaultTableMatchCell[T any](row T, rowIndex int, colIndex int, query string, options FilterOptions) MatchResult {
@@ -815,7 +1165,41 @@ func defaultTableMatchCell[T any](row T, rowIndex int, colIndex int, query strin
 // OnKey handles keys not covered by declarative keybindings.
 // Implements the Focusable interface.
 func (t Table[T]) OnKey(event KeyEvent) bool {
-	return false
+	if t.TypeAhead == nil || t.State == nil || t.selectionMode() == TableSelectionColumn {
+		return false
+	}
+	return t.State.typeAheadBuffer().handleKey(event, t.TypeAhead.timeout(), t.typeAheadJump)
+}
+
+// typeAheadJump moves the cursor to the row matching the typed text.
+func (t Table[T]) typeAheadJump(query string) {
+	view, cursorViewIdx, ok := t.normalizeRowCursorForInteraction()
+	if !ok {
+		return
+	}
+	rows := t.State.Rows.Peek()
+	label := func(viewIdx int) string {
+		return t.TypeAhead.label(rows[view[viewIdx]])
+	}
+	viewIdx, ok := typeAheadMatch(len(view), cursorViewIdx, label, query, t.TypeAhead.Fuzzy)
+	if !ok || viewIdx == cursorViewIdx {
+		return
+	}
+	if t.MultiSelect {
+		t.State.ClearSelection()
+		t.State.ClearAnchor()
+	}
+	t.setCursorToViewIndex(viewIdx)
+	t.scrollCursorIntoView()
+	t.notifyCursorChange()
+}
+
+// anchorID returns the ID the type-ahead overlay is positioned against.
+func (t Table[T]) anchorID(ctx BuildContext) string {
+	if t.ID != "" {
+		return t.ID
+	}
+	return ctx.AutoID()
 }
 
 // Keybinds returns the declarative keybindings for this table.
@@ -823,10 +1207,19 @@ func (t Table[T]) Keybinds() []Keybind {
 	if t.State == nil {
 		return nil
 	}
+	openColumnChooser := t.openColumnChooser // Lists every column, not just the visible ones
+	copyRows := t.CopyRows                   // Picks the visible columns itself
+	if visible := t.visibleColumns(t.State.VisibleColumns.Peek()); visible != nil {
+		t = t.withColumns(visible)
+	}
 	mode := t.selectionMode()
 
+	enter := t.selectRow
+	if t.RenderDetail != nil && mode != TableSelectionColumn && t.OnSelect == nil {
+		enter = t.toggleDetail
+	}
 	binds := []Keybind{
-		{Key: "enter", Action: t.selectRow, Hidden: true},
+		{Key: "enter", Action: enter, Hidden: true},
 		{Key: "up", Action: t.keyCursorUp, Hidden: true},
 		{Key: "k", Action: t.keyCursorUp, Hidden: true},
 		{Key: "down", Action: t.keyCursorDown, Hidden: true},
@@ -839,6 +1232,7 @@ func (t Table[T]) Keybinds() []Keybind {
 		{Key: "ctrl+u", Action: t.pageUp, Hidden: true},
 		{Key: "pgdown", Action: t.pageDown, Hidden: true},
 		{Key: "ctrl+d", Action: t.pageDown, Hidden: true},
+		{Key: "ctrl+y", Action: copyRows, Hidden: true},
 	}
 
 	// Left/right only in Cursor mode (not Row, not Column)
@@ -862,6 +1256,7 @@ func (t Table[T]) Keybinds() []Keybind {
 				Keybind{Key: "shift+j", Action: t.shiftRowDown, Hidden: true},
 				Keybind{Key: "shift+home", Action: t.shiftRowToFirst, Hidden: true},
 				Keybind{Key: "shift+end", Action: t.shiftRowToLast, Hidden: true},
+				Keybind{Key: "ctrl+a", Action: t.State.SelectAllVisible, Hidden: true},
 			)
 		case TableSelectionColumn:
 			binds = append(binds,
@@ -888,6 +1283,20 @@ func (t Table[T]) Keybinds() []Keybind {
 		}
 	}
 
+	if t.ColumnChooser {
+		binds = append(binds, Keybind{Key: "ctrl+o", Name: "Columns", Action: openColumnChooser})
+	}
+
+	if t.RenderDetail != nil && mode != TableSelectionColumn {
+		binds = append(binds,
+			Keybind{Key: "space", Action: t.toggleDetail, Hidden: true},
+			Keybind{Key: " ", Action: t.toggleDetail, Hidden: true},
+		)
+	}
+
+	if t.TypeAhead != nil {
+		binds = withoutTypeAheadKeys(binds)
+	}
 	return binds
 }
 
@@ -1307,10 +1716,48 @@ func (t Table[T]) scrollCursorIntoView() {
 	if !ok {
 		rowHeight = t.getRowHeight()
 		rowY = viewIdx * rowHeight
+	} else if detailHeight := t.State.rowLayouts[viewIdx].detailHeight; detailHeight > 0 {
+		// Bring an expanded row's detail into view too, keeping the row
+		// itself visible when the detail is taller than the viewport.
+		rowHeight += detailHeight
+		if viewport := t.ScrollState.viewportHeight; viewport > 0 {
+			rowHeight = min(rowHeight, viewport)
+		}
 	}
 	t.ScrollState.ScrollToView(rowY, rowHeight)
 }
 
+// keepScrollAnchor scrolls so the row that was at the top of the viewport
+// stays there after rows move (see TableState.KeyFor), then records the
+// current top row.
+func (t Table[T]) keepScrollAnchor() {
+	s := t.State
+	if t.ScrollState == nil || s.KeyFor == nil {
+		s.scrollTop = scrollAnchor{}
+		return
+	}
+	rows := s.Rows.Peek()
+	view := t.viewIndices()
+	if top := s.scrollTop; top.ok && (top.index >= len(rows) || s.KeyFor(rows[top.index]) != top.key) {
+		for viewIdx, idx := range view {
+			if viewIdx < len(s.rowLayouts) && s.KeyFor(rows[idx]) == top.key {
+				t.ScrollState.SetOffset(s.rowLayouts[viewIdx].y + top.delta)
+				break
+			}
+		}
+	}
+
+	s.scrollTop = scrollAnchor{}
+	offset := t.ScrollState.GetOffset()
+	for viewIdx, layout := range s.rowLayouts {
+		if viewIdx < len(view) && layout.y+layout.height+layout.detailHeight > offset {
+			idx := view[viewIdx]
+			s.scrollTop = scrollAnchor{index: idx, key: s.KeyFor(rows[idx]), delta: offset - layout.y, ok: true}
+			break
+		}
+	}
+}
+
 // getRowHeight returns the fallback uniform height of table rows.
 func (t Table[T]) getRowHeight() int {
 	if t.RowHeight > 0 {
@@ -1543,14 +1990,20 @@ func (c tableContainer[T]) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
 	preserveHeight := dims.Height.IsAuto() && !dims.Height.IsUnset()
 
 	columnWidths := make([]Dimension, len(c.Columns))
+	columnMin := make([]int, len(c.Columns))
+	columnMax := make([]int, len(c.Columns))
 	for i, col := range c.Columns {
 		columnWidths[i] = col.Width
+		columnMin[i] = col.MinWidth
+		columnMax[i] = col.MaxWidth
 	}
 
-	node := layout.LayoutNode(&tableNode{
+	table := &tableNode{
 		Columns:        c.columnCount,
 		Rows:           c.rowCount + c.headerRows,
 		ColumnWidths:   columnWidths,
+		ColumnMin:      columnMin,
+		ColumnMax:      columnMax,
 		ColumnSpacing:  c.ColumnSpacing,
 		RowSpacing:     c.RowSpacing,
 		Children:       children,
@@ -1565,7 +2018,21 @@ func (c tableContainer[T]) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
 		ExpandHeight:   dims.Height.IsFlex(),
 		PreserveWidth:  preserveWidth,
 		PreserveHeight: preserveHeight,
-	})
+		HeaderRows:     c.headerRows,
+		SampleRows:     c.AutoFitSample,
+		Details:        c.details,
+	}
+	if state := c.State; state != nil {
+		if state.fitRequested.Load() {
+			table.OnMeasured = func(widths []int) {
+				state.fitWidths = widths
+				state.fitRequested.Store(false)
+			}
+		} else {
+			table.FittedWidths = state.fitWidths
+		}
+	}
+	node := layout.LayoutNode(table)
 
 	if hasPercentMinMax(dims) {
 		node = &percentConstraintWrapper{
@@ -1695,42 +2162,16 @@ func (t Table[T]) setSelectionRange(from, to, count int) {
 	if from > to {
 		from, to = to, from
 	}
-	if from < 0 {
-		from = 0
-	}
-	if to >= count {
-		to = count - 1
-	}
-	sel := make(map[int]struct{}, to-from+1)
-	for i := from; i <= to; i++ {
-		sel[i] = struct{}{}
-	}
-	t.State.Selection.Set(sel)
+	from = max(from, 0)
+	to = min(to, count-1)
+	t.State.SelectionModel().SetSelected(indexRange(from, to+1))
 }
 
 func (t Table[T]) setSelectionRangeFromView(viewIndices []int, anchorSource, cursorSource int) {
 	if t.State == nil || len(viewIndices) == 0 {
 		return
 	}
-
-	anchorView, ok := t.viewIndexForSource(anchorSource)
-	if !ok {
-		anchorView = 0
-	}
-	cursorView, ok := t.viewIndexForSource(cursorSource)
-	if !ok {
-		cursorView = anchorView
-	}
-
-	if anchorView > cursorView {
-		anchorView, cursorView = cursorView, anchorView
-	}
-
-	sel := make(map[int]struct{}, cursorView-anchorView+1)
-	for i := anchorView; i <= cursorView; i++ {
-		sel[viewIndices[i]] = struct{}{}
-	}
-	t.State.Selection.Set(sel)
+	t.State.SelectionModel().SelectRange(viewIndices, anchorSource, cursorSource)
 }
 
 func (t Table[T]) setSelectionBox(viewIndices []int, anchorRow, anchorCol, rowIdx, colIdx, columnCount int) {
@@ -1752,12 +2193,12 @@ func (t Table[T]) setSelectionBox(viewIndices []int, anchorRow, anchorCol, rowId
 		minCol, maxCol = maxCol, minCol
 	}
 
-	sel := make(map[int]struct{}, (maxRow-minRow+1)*(maxCol-minCol+1))
+	sel := make([]int, 0, (maxRow-minRow+1)*(maxCol-minCol+1))
 	for viewRow := minRow; viewRow <= maxRow; viewRow++ {
 		sourceRow := viewIndices[viewRow]
 		for col := minCol; col <= maxCol; col++ {
-			sel[cellIndex(sourceRow, col, columnCount)] = struct{}{}
+			sel = append(sel, cellIndex(sourceRow, col, columnCount))
 		}
 	}
-	t.State.Selection.Set(sel)
+	t.State.SelectionModel().SetSelected(sel)
 }
@@ -0,0 +1,85 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// renderTableLine renders the first line of a table. Tables share spare
+// width out between Auto columns, so tests end with a Flex column to take
+// it and keep the Auto columns at their content width.
+func renderTableLine(table Widget, width int) string {
+	renderer, buf := portalTestRenderer(width, 1)
+	renderer.Render(table)
+	return strings.TrimRight(bufferLine(buf, 0, width), " ")
+}
+
+func TestTable_ColumnMaxWidthCapsLongCells(t *testing.T) {
+	state := NewTableState([][]string{{"a much longer value", "x", ""}})
+	table := Table[[]string]{
+		State:         state,
+		Columns:       []TableColumn{{MaxWidth: 8}, {}, {Width: Flex(1)}},
+		ColumnSpacing: 1,
+	}
+
+	assert.Equal(t, "a much l x", renderTableLine(table, 30))
+}
+
+func TestTable_ColumnMinWidth(t *testing.T) {
+	state := NewTableState([][]string{{"a", "b", ""}})
+	table := Table[[]string]{
+		State:   state,
+		Columns: []TableColumn{{MinWidth: 4}, {}, {Width: Flex(1)}},
+	}
+
+	assert.Equal(t, "a   b", renderTableLine(table, 20))
+}
+
+func TestTableNode_SampledRows(t *testing.T) {
+	node := &tableNode{HeaderRows: 1, SampleRows: 3}
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, node.sampledRows(6), "small tables measure every row")
+
+	sampled := node.sampledRows(1000)
+	assert.Len(t, sampled, 7)
+	assert.Equal(t, []int{0, 1, 2, 3}, sampled[:4], "header and first rows are always measured")
+	seen := map[int]bool{}
+	for _, row := range sampled[4:] {
+		assert.GreaterOrEqual(t, row, 4)
+		assert.Less(t, row, 1000)
+		assert.False(t, seen[row], "sampled rows are distinct")
+		seen[row] = true
+	}
+	assert.Equal(t, sampled, node.sampledRows(1000), "sampling is stable between frames")
+}
+
+func TestTableState_FitToContentMeasuresEveryRowOnce(t *testing.T) {
+	rows := make([][]string, 50)
+	for i := range rows {
+		rows[i] = []string{"ab", "x", ""}
+	}
+	rows[49] = []string{"wide cell", "x", ""}
+	state := NewTableState(rows)
+	table := Table[[]string]{
+		State:         state,
+		Columns:       []TableColumn{{}, {}, {Width: Flex(1)}},
+		ColumnSpacing: 1,
+		AutoFitSample: 2,
+	}
+
+	// The random sample for 50 rows happens to miss the last one.
+	assert.Equal(t, "ab x", renderTableLine(table, 30))
+
+	state.FitToContent()
+	assert.Equal(t, "ab        x", renderTableLine(table, 30))
+	assert.Equal(t, []int{9, 1, 0}, state.fitWidths)
+	assert.False(t, state.fitRequested.Load())
+
+	state.SetRows(rows[:49])
+	assert.Equal(t, "ab        x", renderTableLine(table, 30), "fitted widths are kept")
+
+	state.ClearFit()
+	assert.Equal(t, "ab x", renderTableLine(table, 30))
+}
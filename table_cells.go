@@ -0,0 +1,188 @@
+package terma
+
+import (
+	"fmt"
+	"math"
+)
+
+// TableCellRenderer renders every cell of a TableColumn from its row data,
+// in place of Table.RenderCell. DataBar, DeltaCell and SparklineCell
+// implement it.
+//
+// Example:
+//
+//	Columns: []TableColumn{
+//	    {Header: Text{Content: "Service"}},
+//	    {Header: Text{Content: "Load"}, Width: Cells(12), Render: DataBar[Stat]{Value: func(s Stat) float64 { return s.Load }}},
+//	    {Header: Text{Content: "Change"}, Render: DeltaCell[Stat]{Value: func(s Stat) float64 { return s.Change }}},
+//	    {Header: Text{Content: "Trend"}, Render: SparklineCell[Stat]{Values: func(s Stat) []float64 { return s.History }}},
+//	}
+type TableCellRenderer interface {
+	// columnCells is called once per build with the table's rows ([]T) and
+	// returns the function that renders one cell. A nil widget falls back
+	// to the table's own cell renderer.
+	columnCells(ctx BuildContext, rows any) func(row any, style Style) Widget
+}
+
+// DataBar renders a column of numbers as inline bars, scaled so the
+// column's smallest value is an empty bar and its largest a full one.
+// The bar fills the column, so give the column a Cells or Flex width.
+type DataBar[T any] struct {
+	Value         func(row T) float64 // Required - the number to draw
+	MinValue      *float64            // Optional: value drawn as an empty bar (default: column minimum)
+	MaxValue      *float64            // Optional: value drawn as a full bar (default: column maximum)
+	ShowValue     bool                // Show the number after the bar
+	Format        string              // fmt verb for ShowValue (default "%g")
+	FilledColor   Color               // Color of the bar (default: theme Primary)
+	UnfilledColor Color               // Color of the rest of the cell (default: theme Surface)
+}
+
+func (b DataBar[T]) columnCells(ctx BuildContext, rows any) func(row any, style Style) Widget {
+	items, _ := rows.([]T)
+	if b.Value == nil {
+		return func(any, Style) Widget { return nil }
+	}
+	values := make([]float64, len(items))
+	for i, item := range items {
+		values[i] = b.Value(item)
+	}
+	minValue, maxValue := valueRange(values, b.MinValue, b.MaxValue)
+	format := b.Format
+	if format == "" {
+		format = "%g"
+	}
+
+	return func(row any, style Style) Widget {
+		item, ok := row.(T)
+		if !ok {
+			return nil
+		}
+		value := b.Value(item)
+		progress := 1.0
+		if maxValue > minValue {
+			progress = (value - minValue) / (maxValue - minValue)
+		}
+		bar := ProgressBar{
+			Progress:      clamp01(progress),
+			FilledColor:   b.FilledColor,
+			UnfilledColor: b.UnfilledColor,
+		}
+		if !b.ShowValue {
+			bar.Style = style
+			return bar
+		}
+		style.Width = Flex(1)
+		return Row{
+			Style:   style,
+			Spacing: 1,
+			Children: []Widget{
+				bar,
+				Text{Content: fmt.Sprintf(format, value)},
+			},
+		}
+	}
+}
+
+// DeltaCell renders a column of changes as an up or down arrow and the
+// size of the change: rises in the theme's Success color, falls in its
+// Error color, and no change muted.
+type DeltaCell[T any] struct {
+	Value  func(row T) float64 // Required - the change to show
+	Format string              // fmt verb for the size of the change (default "%g")
+	Invert bool                // Falling is good (e.g. latency): swaps the colors
+}
+
+func (d DeltaCell[T]) columnCells(ctx BuildContext, rows any) func(row any, style Style) Widget {
+	theme := ctx.Theme()
+	format := d.Format
+	if format == "" {
+		format = "%g"
+	}
+	up, down := theme.Success, theme.Error
+	if d.Invert {
+		up, down = down, up
+	}
+
+	return func(row any, style Style) Widget {
+		item, ok := row.(T)
+		if !ok || d.Value == nil {
+			return nil
+		}
+		value := d.Value(item)
+		arrow := "▬"
+		style.ForegroundColor = theme.TextMuted
+		switch {
+		case value > 0:
+			arrow = "▲"
+			style.ForegroundColor = up
+		case value < 0:
+			arrow = "▼"
+			style.ForegroundColor = down
+		}
+		return Text{
+			Content: arrow + " " + fmt.Sprintf(format, math.Abs(value)),
+			Style:   style,
+		}
+	}
+}
+
+// SparklineCell renders a slice of numbers from each row as a Sparkline.
+// Each row is scaled on its own unless SharedScale is set.
+type SparklineCell[T any] struct {
+	Values       func(row T) []float64 // Required - the series to draw
+	SharedScale  bool                  // Scale every row to the column's overall min/max so rows compare
+	ColorByValue bool                  // Vary bar colors by magnitude (see Sparkline.ColorByValue)
+}
+
+func (s SparklineCell[T]) columnCells(ctx BuildContext, rows any) func(row any, style Style) Widget {
+	if s.Values == nil {
+		return func(any, Style) Widget { return nil }
+	}
+	var minValue, maxValue *float64
+	if s.SharedScale {
+		items, _ := rows.([]T)
+		var all []float64
+		for _, item := range items {
+			all = append(all, s.Values(item)...)
+		}
+		if len(all) > 0 {
+			lo, hi := valueRange(all, nil, nil)
+			minValue, maxValue = &lo, &hi
+		}
+	}
+
+	return func(row any, style Style) Widget {
+		item, ok := row.(T)
+		if !ok {
+			return nil
+		}
+		return Sparkline{
+			Values:       s.Values(item),
+			Style:        style,
+			ColorByValue: s.ColorByValue,
+			MinValue:     minValue,
+			MaxValue:     maxValue,
+		}
+	}
+}
+
+// valueRange returns the min and max of values, with either bound replaced
+// by its override when set.
+func valueRange(values []float64, minOverride, maxOverride *float64) (float64, float64) {
+	minValue, maxValue := 0.0, 0.0
+	for i, value := range values {
+		if i == 0 || value < minValue {
+			minValue = value
+		}
+		if i == 0 || value > maxValue {
+			maxValue = value
+		}
+	}
+	if minOverride != nil {
+		minValue = *minOverride
+	}
+	if maxOverride != nil {
+		maxValue = *maxOverride
+	}
+	return minValue, maxValue
+}
@@ -0,0 +1,116 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tableCellStat struct {
+	Name    string
+	Load    float64
+	Change  float64
+	History []float64
+}
+
+func tableCellStats() []tableCellStat {
+	return []tableCellStat{
+		{Name: "api", Load: 10, Change: 1.5, History: []float64{1, 2, 3}},
+		{Name: "db", Load: 5, Change: -0.5, History: []float64{3, 2, 1}},
+		{Name: "cache", Load: 0, Change: 0, History: []float64{1, 1, 1}},
+	}
+}
+
+func renderTableCellsPlain(table Table[tableCellStat]) []string {
+	return strings.Split(RenderReportPlain(table, 30), "\n")
+}
+
+func TestDataBar_ScalesToColumnRange(t *testing.T) {
+	table := Table[tableCellStat]{
+		State: NewTableState(tableCellStats()),
+		Columns: []TableColumn{
+			{Width: Cells(4), Render: DataBar[tableCellStat]{Value: func(s tableCellStat) float64 { return s.Load }}},
+		},
+	}
+
+	lines := renderTableCellsPlain(table)
+	assert.Equal(t, "████", lines[0], "the column maximum is a full bar")
+	assert.Equal(t, "██", lines[1])
+	assert.Equal(t, "", lines[2], "the column minimum is an empty bar")
+}
+
+func TestDataBar_ShowValueAndOverrides(t *testing.T) {
+	maxValue := 20.0
+	table := Table[tableCellStat]{
+		State: NewTableState(tableCellStats()),
+		Columns: []TableColumn{
+			{Width: Cells(7), Render: DataBar[tableCellStat]{
+				Value:     func(s tableCellStat) float64 { return s.Load },
+				MaxValue:  &maxValue,
+				ShowValue: true,
+			}},
+		},
+	}
+
+	lines := renderTableCellsPlain(table)
+	assert.Equal(t, "██   10", lines[0])
+	assert.Equal(t, "█▎    5", lines[1], "bars use partial blocks")
+}
+
+func TestDeltaCell_ArrowsAndColors(t *testing.T) {
+	table := Table[tableCellStat]{
+		State: NewTableState(tableCellStats()),
+		Columns: []TableColumn{
+			{Render: DeltaCell[tableCellStat]{Value: func(s tableCellStat) float64 { return s.Change }, Format: "%.1f"}},
+			{Width: Flex(1)},
+		},
+	}
+
+	lines := renderTableCellsPlain(table)
+	assert.Equal(t, "▲ 1.5", strings.TrimSpace(lines[0]))
+	assert.Equal(t, "▼ 0.5", strings.TrimSpace(lines[1]))
+	assert.Equal(t, "▬ 0.0", strings.TrimSpace(lines[2]))
+
+	ctx := NewBuildContext(nil, AnySignal[Focusable]{}, AnySignal[Widget]{}, nil)
+	theme := ctx.Theme()
+	render := DeltaCell[tableCellStat]{Value: func(s tableCellStat) float64 { return s.Change }}.columnCells(ctx, tableCellStats())
+	assert.Equal(t, theme.Success, render(tableCellStats()[0], Style{}).(Text).Style.ForegroundColor)
+	assert.Equal(t, theme.Error, render(tableCellStats()[1], Style{}).(Text).Style.ForegroundColor)
+
+	inverted := DeltaCell[tableCellStat]{Value: func(s tableCellStat) float64 { return s.Change }, Invert: true}.columnCells(ctx, tableCellStats())
+	assert.Equal(t, theme.Error, inverted(tableCellStats()[0], Style{}).(Text).Style.ForegroundColor)
+}
+
+func TestSparklineCell_SharedScale(t *testing.T) {
+	rows := []tableCellStat{{History: []float64{1, 2}}, {History: []float64{10, 20}}}
+	ctx := NewBuildContext(nil, AnySignal[Focusable]{}, AnySignal[Widget]{}, nil)
+
+	own := SparklineCell[tableCellStat]{Values: func(s tableCellStat) []float64 { return s.History }}.columnCells(ctx, rows)
+	spark := own(rows[0], Style{}).(Sparkline)
+	assert.Equal(t, []float64{1, 2}, spark.Values)
+	assert.Nil(t, spark.MinValue)
+
+	shared := SparklineCell[tableCellStat]{Values: func(s tableCellStat) []float64 { return s.History }, SharedScale: true}.columnCells(ctx, rows)
+	spark = shared(rows[0], Style{}).(Sparkline)
+	assert.Equal(t, 1.0, *spark.MinValue)
+	assert.Equal(t, 20.0, *spark.MaxValue)
+}
+
+func TestTableColumnRender_OverridesRenderCell(t *testing.T) {
+	table := Table[tableCellStat]{
+		State: NewTableState(tableCellStats()),
+		Columns: []TableColumn{
+			{Width: Cells(6)},
+			{Render: DeltaCell[tableCellStat]{Value: func(s tableCellStat) float64 { return s.Change }}},
+			{Width: Flex(1)},
+		},
+		ColumnSpacing: 1,
+		RenderCell: func(row tableCellStat, rowIndex, colIndex int, active, selected bool) Widget {
+			return Text{Content: row.Name}
+		},
+	}
+
+	lines := renderTableCellsPlain(table)
+	assert.Equal(t, []string{"api", "▲", "1.5", "api"}, strings.Fields(lines[0]))
+}
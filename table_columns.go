@@ -0,0 +1,456 @@
+package terma
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// tableColumnEntry is one line of the column chooser.
+type tableColumnEntry struct {
+	Column  int // Index into Table.Columns
+	Visible bool
+}
+
+// tableColumnView holds the column chooser and named views of a TableState.
+type tableColumnView struct {
+	chooserOpen    Signal[bool]
+	chooserCursor  Signal[int]
+	chooserEntries AnySignal[[]tableColumnEntry]
+	chooserID      string // ID of the open chooser, set during Build
+	tableID        string // ID focus returns to when the chooser closes
+
+	views map[string][]int // Named views, loaded from ViewStore on first use
+}
+
+func (s *TableState[T]) columnView() *tableColumnView {
+	if s.columns == nil {
+		s.columns = &tableColumnView{
+			chooserOpen:    NewSignal(false),
+			chooserCursor:  NewSignal(0),
+			chooserEntries: NewAnySignal[[]tableColumnEntry](nil),
+		}
+	}
+	return s.columns
+}
+
+// SetVisibleColumns shows only the given columns, in the given order, as
+// indices into Table.Columns. nil shows every column in declared order.
+func (s *TableState[T]) SetVisibleColumns(columns []int) {
+	if columns != nil {
+		columns = append([]int(nil), columns...)
+	}
+	s.VisibleColumns.Set(columns)
+}
+
+// ShowAllColumns shows every column in declared order.
+func (s *TableState[T]) ShowAllColumns() {
+	s.VisibleColumns.Set(nil)
+}
+
+// SaveView stores the visible columns as a named view, persisted to
+// ViewStore when set.
+func (s *TableState[T]) SaveView(name string) {
+	views := s.loadViews()
+	views[name] = append([]int{}, s.VisibleColumns.Peek()...) // Empty for every column
+	s.saveViews()
+}
+
+// ApplyView shows the columns saved under name. Returns false if there is
+// no such view.
+func (s *TableState[T]) ApplyView(name string) bool {
+	columns, ok := s.loadViews()[name]
+	if !ok {
+		return false
+	}
+	if len(columns) == 0 {
+		columns = nil
+	}
+	s.SetVisibleColumns(columns)
+	return true
+}
+
+// DeleteView removes the view saved under name.
+func (s *TableState[T]) DeleteView(name string) {
+	views := s.loadViews()
+	if _, ok := views[name]; !ok {
+		return
+	}
+	delete(views, name)
+	s.saveViews()
+}
+
+// ViewNames returns the names of the saved views, sorted.
+func (s *TableState[T]) ViewNames() []string {
+	views := s.loadViews()
+	names := make([]string, 0, len(views))
+	for name := range views {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tableViewsStoreKey returns the key a table's views are stored under.
+func tableViewsStoreKey(name string) string {
+	return "table-views:" + name
+}
+
+func (s *TableState[T]) loadViews() map[string][]int {
+	view := s.columnView()
+	if view.views != nil {
+		return view.views
+	}
+	view.views = map[string][]int{}
+	if s.ViewStore == nil {
+		return view.views
+	}
+	stored, err := s.ViewStore.Load()
+	if err != nil {
+		Log("Table views: %v", err)
+	}
+	saved, _ := stored[tableViewsStoreKey(s.ViewStoreKey)].(map[string]any)
+	for name, value := range saved {
+		if columns, ok := storedInts(value); ok {
+			view.views[name] = columns
+		}
+	}
+	return view.views
+}
+
+// saveViews persists the views, keeping any other values in the store intact.
+func (s *TableState[T]) saveViews() {
+	if s.ViewStore == nil {
+		return
+	}
+	stored, err := s.ViewStore.Load()
+	if err != nil {
+		Log("Table views: %v", err)
+	}
+	values := map[string]any{}
+	for key, value := range stored {
+		values[key] = value
+	}
+	saved := map[string]any{}
+	for name, columns := range s.columnView().views {
+		saved[name] = columns
+	}
+	values[tableViewsStoreKey(s.ViewStoreKey)] = saved
+	if err := s.ViewStore.Save(values); err != nil {
+		Log("Table views: %v", err)
+	}
+}
+
+// storedInts converts a stored column list, which is []int when saved in
+// memory and []any of float64 once it has been through JSON.
+func storedInts(value any) ([]int, bool) {
+	switch v := value.(type) {
+	case nil:
+		return []int{}, true
+	case []int:
+		return append([]int(nil), v...), true
+	case []any:
+		ints := make([]int, 0, len(v))
+		for _, item := range v {
+			f, ok := item.(float64)
+			if !ok {
+				return nil, false
+			}
+			ints = append(ints, int(f))
+		}
+		return ints, true
+	default:
+		return nil, false
+	}
+}
+
+// visibleColumns returns the columns to show as indices into t.Columns, or
+// nil when every column shows in declared order.
+func (t Table[T]) visibleColumns(columns []int) []int {
+	if columns == nil {
+		return nil
+	}
+	seen := make(map[int]bool, len(columns))
+	visible := make([]int, 0, len(columns))
+	for _, col := range columns {
+		if col < 0 || col >= len(t.Columns) || seen[col] {
+			continue
+		}
+		seen[col] = true
+		visible = append(visible, col)
+	}
+	if len(visible) == 0 {
+		return nil
+	}
+	if len(visible) == len(t.Columns) {
+		inOrder := true
+		for i, col := range visible {
+			if col != i {
+				inOrder = false
+				break
+			}
+		}
+		if inOrder {
+			return nil
+		}
+	}
+	return visible
+}
+
+// withColumns returns the table showing only the given columns in order.
+// Callbacks still receive column indices into the original Columns.
+func (t Table[T]) withColumns(visible []int) Table[T] {
+	source := func(col int) int {
+		if col >= 0 && col < len(visible) {
+			return visible[col]
+		}
+		return col
+	}
+	display := make(map[int]int, len(visible))
+	columns := make([]TableColumn, len(visible))
+	for i, col := range visible {
+		columns[i] = t.Columns[col]
+		display[col] = i
+	}
+	t.Columns = columns
+
+	if render := t.RenderCell; render != nil {
+		t.RenderCell = func(row T, rowIndex, colIndex int, active, selected bool) Widget {
+			return render(row, rowIndex, source(colIndex), active, selected)
+		}
+	}
+	if render := t.RenderCellWithMatch; render != nil {
+		t.RenderCellWithMatch = func(row T, rowIndex, colIndex int, active, selected bool, match MatchResult) Widget {
+			return render(row, rowIndex, source(colIndex), active, selected, match)
+		}
+	}
+	if render := t.RenderHeader; render != nil {
+		t.RenderHeader = func(colIndex int) Widget {
+			return render(source(colIndex))
+		}
+	}
+	match := t.MatchCell
+	if match == nil {
+		match = defaultTableMatchCell[T]
+	}
+	t.MatchCell = func(row T, rowIndex, colIndex int, query string, options FilterOptions) MatchResult {
+		return match(row, rowIndex, source(colIndex), query, options)
+	}
+
+	if len(t.FormatRules) > 0 {
+		rules := make([]TableFormatRule[T], len(t.FormatRules))
+		for i, rule := range t.FormatRules {
+			if when := rule.When; when != nil {
+				rule.When = func(row T, rowIndex, colIndex int) bool {
+					return when(row, rowIndex, source(colIndex))
+				}
+			}
+			if rule.Columns != nil {
+				shown := []int{}
+				for _, col := range rule.Columns {
+					if idx, ok := display[col]; ok {
+						shown = append(shown, idx)
+					}
+				}
+				rule.Columns = shown
+			}
+			rules[i] = rule
+		}
+		t.FormatRules = rules
+	}
+	return t
+}
+
+// columnLabel returns the name the chooser shows for a column.
+func (t Table[T]) columnLabel(col int) string {
+	if name := t.Columns[col].filterName(); name != "" {
+		return name
+	}
+	return "Column " + strconv.Itoa(col+1)
+}
+
+// openColumnChooser shows the column chooser and moves focus into it.
+func (t Table[T]) openColumnChooser() {
+	view := t.State.columnView()
+	shown := t.visibleColumns(t.State.VisibleColumns.Peek())
+	if shown == nil {
+		shown = indexRange(0, len(t.Columns))
+	}
+	isShown := make(map[int]bool, len(shown))
+	entries := make([]tableColumnEntry, 0, len(t.Columns))
+	for _, col := range shown {
+		isShown[col] = true
+		entries = append(entries, tableColumnEntry{Column: col, Visible: true})
+	}
+	for col := range t.Columns {
+		if !isShown[col] {
+			entries = append(entries, tableColumnEntry{Column: col})
+		}
+	}
+	view.chooserEntries.Set(entries)
+	view.chooserCursor.Set(0)
+	view.chooserOpen.Set(true)
+	if view.chooserID != "" {
+		RequestFocus(view.chooserID)
+	}
+}
+
+// buildColumnChooser registers the open column chooser as a float below
+// the table's top-left corner.
+func (t Table[T]) buildColumnChooser(ctx BuildContext) {
+	view := t.State.columnView()
+	view.tableID = t.anchorID(ctx)
+	view.chooserID = view.tableID + "-columns"
+	if !view.chooserOpen.Get() {
+		return
+	}
+	Floating{
+		Visible: true,
+		Config: FloatConfig{
+			AnchorID:  view.tableID,
+			Anchor:    AnchorTopLeft,
+			Offset:    Offset{Y: 1},
+			OnDismiss: tableColumnChooser[T]{table: t}.close,
+		},
+		Child: tableColumnChooser[T]{table: t},
+	}.Build(ctx)
+}
+
+// tableColumnChooser lists a table's columns with checkboxes. Space shows
+// or hides the column under the cursor, alt+up/alt+down move it, and
+// Enter or Escape closes the chooser.
+type tableColumnChooser[T any] struct {
+	table Table[T]
+}
+
+// WidgetID returns the chooser's unique identifier.
+func (c tableColumnChooser[T]) WidgetID() string {
+	return c.table.State.columnView().chooserID
+}
+
+// IsFocusable returns true to allow keyboard navigation.
+func (c tableColumnChooser[T]) IsFocusable() bool {
+	return true
+}
+
+// OnKey handles keys not covered by declarative keybindings.
+func (c tableColumnChooser[T]) OnKey(event KeyEvent) bool {
+	return false
+}
+
+// Keybinds returns the declarative keybindings for the chooser.
+func (c tableColumnChooser[T]) Keybinds() []Keybind {
+	return []Keybind{
+		{Key: "up", Action: func() { c.moveCursor(-1) }, Hidden: true},
+		{Key: "k", Action: func() { c.moveCursor(-1) }, Hidden: true},
+		{Key: "down", Action: func() { c.moveCursor(1) }, Hidden: true},
+		{Key: "j", Action: func() { c.moveCursor(1) }, Hidden: true},
+		{Key: "space", Name: "Show/hide", Action: c.toggle},
+		{Key: " ", Action: c.toggle, Hidden: true},
+		{Key: "alt+up", Name: "Move up", Action: func() { c.moveColumn(-1) }},
+		{Key: "alt+down", Name: "Move down", Action: func() { c.moveColumn(1) }},
+		{Key: "enter", Name: "Done", Action: c.close},
+		{Key: "escape", Action: c.close, Hidden: true},
+	}
+}
+
+func (c tableColumnChooser[T]) moveCursor(delta int) {
+	view := c.table.State.columnView()
+	count := len(view.chooserEntries.Peek())
+	if count == 0 {
+		return
+	}
+	view.chooserCursor.Set(clampInt(view.chooserCursor.Peek()+delta, 0, count-1))
+}
+
+// toggle shows or hides the column under the cursor. The last visible
+// column can't be hidden.
+func (c tableColumnChooser[T]) toggle() {
+	view := c.table.State.columnView()
+	entries := append([]tableColumnEntry(nil), view.chooserEntries.Peek()...)
+	cursor := view.chooserCursor.Peek()
+	if cursor < 0 || cursor >= len(entries) {
+		return
+	}
+	if entries[cursor].Visible {
+		visible := 0
+		for _, entry := range entries {
+			if entry.Visible {
+				visible++
+			}
+		}
+		if visible <= 1 {
+			return
+		}
+	}
+	entries[cursor].Visible = !entries[cursor].Visible
+	c.apply(entries)
+}
+
+// moveColumn moves the column under the cursor up or down the order.
+func (c tableColumnChooser[T]) moveColumn(delta int) {
+	view := c.table.State.columnView()
+	entries := append([]tableColumnEntry(nil), view.chooserEntries.Peek()...)
+	cursor := view.chooserCursor.Peek()
+	target := cursor + delta
+	if cursor < 0 || cursor >= len(entries) || target < 0 || target >= len(entries) {
+		return
+	}
+	entries[cursor], entries[target] = entries[target], entries[cursor]
+	view.chooserCursor.Set(target)
+	c.apply(entries)
+}
+
+// apply stores the chooser's entries and shows their visible columns.
+func (c tableColumnChooser[T]) apply(entries []tableColumnEntry) {
+	c.table.State.columnView().chooserEntries.Set(entries)
+	visible := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Visible {
+			visible = append(visible, entry.Column)
+		}
+	}
+	c.table.State.SetVisibleColumns(visible)
+}
+
+func (c tableColumnChooser[T]) close() {
+	view := c.table.State.columnView()
+	view.chooserOpen.Set(false)
+	if view.tableID != "" {
+		RequestFocus(view.tableID)
+	}
+}
+
+// Build renders a checkbox line per column.
+func (c tableColumnChooser[T]) Build(ctx BuildContext) Widget {
+	theme := ctx.Theme()
+	view := c.table.State.columnView()
+	entries := view.chooserEntries.Get()
+	cursor := view.chooserCursor.Get()
+	focused := ctx.IsFocused(c)
+
+	children := make([]Widget, 0, len(entries))
+	for i, entry := range entries {
+		mark := "[ ]"
+		if entry.Visible {
+			mark = "[x]"
+		}
+		style := Style{ForegroundColor: theme.Text, Padding: EdgeInsetsXY(1, 0)}
+		if i == cursor && focused {
+			style.BackgroundColor = theme.ActiveCursor
+			style.ForegroundColor = theme.SelectionText
+		}
+		children = append(children, Text{
+			Content: fmt.Sprintf("%s %s", mark, c.table.columnLabel(entry.Column)),
+			Style:   style,
+		})
+	}
+	return Column{
+		CrossAlign: CrossAxisStretch,
+		Style: Style{
+			BackgroundColor: theme.Surface,
+			Border:          RoundedBorder(theme.Border, BorderTitle("Columns")),
+		},
+		Children: children,
+	}
+}
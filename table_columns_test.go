@@ -0,0 +1,171 @@
+package terma
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func columnsTable(state *TableState[[]string]) Table[[]string] {
+	return Table[[]string]{
+		ID:    "people",
+		State: state,
+		Columns: []TableColumn{
+			{Header: Text{Content: "Name"}},
+			{Header: Text{Content: "Born"}},
+			{Header: Text{Content: "Lang"}},
+		},
+		ColumnSpacing: 1,
+		ColumnChooser: true,
+	}
+}
+
+func columnsRows() [][]string {
+	return [][]string{{"Ada", "1815", "Note G"}, {"Grace", "1906", "COBOL"}}
+}
+
+func tableKeybind(binds []Keybind, key string) (Keybind, bool) {
+	for _, bind := range binds {
+		if bind.Key == key {
+			return bind, true
+		}
+	}
+	return Keybind{}, false
+}
+
+func TestTableVisibleColumns_HidesAndReorders(t *testing.T) {
+	state := NewTableState(columnsRows())
+	state.SetVisibleColumns([]int{2, 0})
+	var renderedCols []int
+	table := columnsTable(state)
+
+	lines := strings.Split(RenderReportPlain(table, 30), "\n")
+	assert.Equal(t, []string{"Lang", "Name"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"Note", "G", "Ada"}, strings.Fields(lines[1]))
+
+	table.RenderCell = func(row []string, rowIndex, colIndex int, active, selected bool) Widget {
+		renderedCols = append(renderedCols, colIndex)
+		return Text{Content: row[colIndex]}
+	}
+	RenderReportPlain(table, 30)
+	assert.Equal(t, []int{2, 0, 2, 0}, renderedCols[:4], "callbacks get indices into Columns")
+
+	state.ShowAllColumns()
+	lines = strings.Split(RenderReportPlain(table, 30), "\n")
+	assert.Equal(t, []string{"Name", "Born", "Lang"}, strings.Fields(lines[0]))
+}
+
+func TestTableVisibleColumns_FormatRulesFollowColumns(t *testing.T) {
+	state := NewTableState(columnsRows())
+	state.SetVisibleColumns([]int{2, 1})
+	table := columnsTable(state)
+	table.FormatRules = []TableFormatRule[[]string]{
+		{When: func([]string, int, int) bool { return true }, Columns: []int{1}, Style: Style{Bold: true}},
+	}
+
+	ctx := NewBuildContext(nil, AnySignal[Focusable]{}, AnySignal[Widget]{}, NewFloatCollector())
+	container := table.Build(ctx).(tableContainer[[]string])
+	cells := container.children[2:] // after the header
+	assert.False(t, cells[0].(Text).Style.Bold)
+	assert.True(t, cells[1].(Text).Style.Bold)
+}
+
+func TestTableColumnChooser_TogglesAndMovesColumns(t *testing.T) {
+	state := NewTableState(columnsRows())
+	table := columnsTable(state)
+	ctx := NewBuildContext(nil, AnySignal[Focusable]{}, AnySignal[Widget]{}, NewFloatCollector())
+	table.Build(ctx)
+
+	open, ok := tableKeybind(table.Keybinds(), "ctrl+o")
+	require.True(t, ok)
+	open.Action()
+	assert.True(t, state.columnView().chooserOpen.Peek())
+
+	chooser := tableColumnChooser[[]string]{table: table}
+	assert.Equal(t, "people-columns", chooser.WidgetID())
+	press := func(key string) {
+		bind, ok := tableKeybind(chooser.Keybinds(), key)
+		require.True(t, ok, key)
+		bind.Action()
+	}
+
+	press("down")
+	press("space")
+	assert.Equal(t, []int{0, 2}, state.VisibleColumns.Peek())
+
+	press("down")
+	press("alt+up")
+	press("alt+up")
+	assert.Equal(t, []int{2, 0}, state.VisibleColumns.Peek())
+
+	press("space")
+	press("down")
+	press("space")
+	assert.Equal(t, []int{0}, state.VisibleColumns.Peek(), "the last visible column can't be hidden")
+
+	press("enter")
+	assert.False(t, state.columnView().chooserOpen.Peek())
+}
+
+func TestTableColumnChooser_RendersFloat(t *testing.T) {
+	state := NewTableState(columnsRows())
+	table := columnsTable(state)
+	state.SetVisibleColumns([]int{1})
+	renderer, buf := portalTestRenderer(30, 10)
+	renderer.Render(table)
+	tableKeybindMust(t, table, "ctrl+o").Action()
+	renderer.Render(table)
+
+	var screen []string
+	for y := 0; y < 6; y++ {
+		screen = append(screen, bufferLine(buf, y, 30))
+	}
+	text := strings.Join(screen, "\n")
+	assert.Contains(t, text, "[x] Born")
+	assert.Contains(t, text, "[ ] Name")
+	assert.Contains(t, text, "[ ] Lang")
+}
+
+func tableKeybindMust(t *testing.T, table Table[[]string], key string) Keybind {
+	t.Helper()
+	bind, ok := tableKeybind(table.Keybinds(), key)
+	require.True(t, ok, key)
+	return bind
+}
+
+func TestTableViews_PersistToStore(t *testing.T) {
+	store := JSONFileStore{Path: filepath.Join(t.TempDir(), "settings.json")}
+	require.NoError(t, store.Save(map[string]any{"theme": "dark"}))
+
+	state := NewTableState(columnsRows())
+	state.ViewStore, state.ViewStoreKey = store, "people"
+	state.SetVisibleColumns([]int{0, 2})
+	state.SaveView("compact")
+	state.ShowAllColumns()
+	state.SaveView("all")
+
+	reloaded := NewTableState(columnsRows())
+	reloaded.ViewStore, reloaded.ViewStoreKey = store, "people"
+	assert.Equal(t, []string{"all", "compact"}, reloaded.ViewNames())
+	assert.True(t, reloaded.ApplyView("compact"))
+	assert.Equal(t, []int{0, 2}, reloaded.VisibleColumns.Peek())
+	assert.True(t, reloaded.ApplyView("all"))
+	assert.Nil(t, reloaded.VisibleColumns.Peek())
+	assert.False(t, reloaded.ApplyView("missing"))
+
+	reloaded.DeleteView("compact")
+	values, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "dark", values["theme"], "other settings are kept")
+	assert.Equal(t, []string{"all"}, viewsTableState(t, store).ViewNames())
+}
+
+func viewsTableState(t *testing.T, store SettingsStore) *TableState[[]string] {
+	t.Helper()
+	state := NewTableState(columnsRows())
+	state.ViewStore, state.ViewStoreKey = store, "people"
+	return state
+}
@@ -0,0 +1,134 @@
+package terma
+
+// TableExpandPolicy controls how many rows of a Table with RenderDetail can
+// be expanded at once.
+type TableExpandPolicy int
+
+const (
+	// TableExpandMultiple lets any number of rows be expanded (default).
+	TableExpandMultiple TableExpandPolicy = iota
+	// TableExpandSingle collapses the expanded row when another is expanded.
+	TableExpandSingle
+)
+
+// IsExpanded reports whether the row at index shows its detail.
+func (s *TableState[T]) IsExpanded(index int) bool {
+	_, ok := s.Expanded.Peek()[index]
+	return ok
+}
+
+// Expand shows the detail of the row at index.
+func (s *TableState[T]) Expand(index int) {
+	if s.IsExpanded(index) {
+		return
+	}
+	s.setExpanded(index, true)
+}
+
+// Collapse hides the detail of the row at index.
+func (s *TableState[T]) Collapse(index int) {
+	if !s.IsExpanded(index) {
+		return
+	}
+	s.setExpanded(index, false)
+}
+
+// ToggleExpanded shows or hides the detail of the row at index.
+func (s *TableState[T]) ToggleExpanded(index int) {
+	s.setExpanded(index, !s.IsExpanded(index))
+}
+
+// CollapseAll hides every row's detail.
+func (s *TableState[T]) CollapseAll() {
+	if len(s.Expanded.Peek()) == 0 {
+		return
+	}
+	s.Expanded.Set(map[int]struct{}{})
+}
+
+func (s *TableState[T]) setExpanded(index int, expanded bool) {
+	current := s.Expanded.Peek()
+	next := make(map[int]struct{}, len(current)+1)
+	for idx := range current {
+		next[idx] = struct{}{}
+	}
+	if expanded {
+		next[index] = struct{}{}
+	} else {
+		delete(next, index)
+	}
+	s.Expanded.Set(next)
+}
+
+// toggleDetail expands or collapses the cursor row, applying ExpandPolicy.
+func (t Table[T]) toggleDetail() {
+	if _, _, ok := t.normalizeRowCursorForInteraction(); !ok {
+		return
+	}
+	index := t.State.CursorIndex.Peek()
+	if t.ExpandPolicy == TableExpandSingle && !t.State.IsExpanded(index) {
+		t.State.CollapseAll()
+	}
+	t.State.ToggleExpanded(index)
+	t.scrollCursorIntoView()
+}
+
+// detailReveal returns how much of a row's detail to show, from 0 (none)
+// to 1 (all). With DetailAnimation set, the detail grows open and shrinks
+// closed over that duration instead of appearing at once.
+func (t Table[T]) detailReveal(index int, expanded bool) float64 {
+	target := 0.0
+	if expanded {
+		target = 1
+	}
+	if t.DetailAnimation <= 0 {
+		t.State.detailReveal = nil
+		return target
+	}
+
+	reveal := t.State.detailReveal[index]
+	if reveal == nil {
+		if !expanded {
+			return 0
+		}
+		if t.State.detailReveal == nil {
+			t.State.detailReveal = make(map[int]*AnimatedValue[float64])
+		}
+		reveal = NewAnimatedValue(AnimatedValueConfig[float64]{Duration: t.DetailAnimation})
+		t.State.detailReveal[index] = reveal
+	}
+	reveal.Set(target)
+	value := reveal.Get()
+	if !expanded && value <= 0 {
+		delete(t.State.detailReveal, index)
+	}
+	return value
+}
+
+// buildDetails returns the detail widgets to show beneath the view rows,
+// with where each goes.
+func (t Table[T]) buildDetails(viewRows []T, viewIndices []int, headerRows int) ([]Widget, []tableDetail) {
+	if t.RenderDetail == nil || t.selectionMode() == TableSelectionColumn {
+		t.State.detailReveal = nil
+		return nil, nil
+	}
+
+	expanded := t.State.Expanded.Get()
+	var widgets []Widget
+	var details []tableDetail
+	for viewRowIdx, row := range viewRows {
+		sourceRowIdx := viewIndices[viewRowIdx]
+		_, isExpanded := expanded[sourceRowIdx]
+		reveal := t.detailReveal(sourceRowIdx, isExpanded)
+		if reveal <= 0 {
+			continue
+		}
+		detail := t.RenderDetail(row, sourceRowIdx)
+		if detail == nil {
+			continue
+		}
+		widgets = append(widgets, detail)
+		details = append(details, tableDetail{Row: viewRowIdx + headerRows, Reveal: reveal})
+	}
+	return widgets, details
+}
@@ -0,0 +1,180 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func detailTable(state *TableState[[]string]) Table[[]string] {
+	return Table[[]string]{
+		State:         state,
+		Columns:       []TableColumn{{}, {Width: Flex(1)}},
+		ColumnSpacing: 1,
+		RenderDetail: func(row []string, rowIndex int) Widget {
+			return Column{Children: []Widget{
+				Text{Content: "  detail of " + row[0]},
+				Text{Content: "  second line"},
+			}}
+		},
+	}
+}
+
+func detailRows() [][]string {
+	return [][]string{{"ada", "1815"}, {"grace", "1906"}, {"linus", "1969"}}
+}
+
+// pressTableKey runs the table's binding for key, reporting whether it has one.
+func pressTableKey(table Table[[]string], key string) bool {
+	for _, bind := range table.Keybinds() {
+		if bind.Key == key {
+			bind.Action()
+			return true
+		}
+	}
+	return false
+}
+
+func trimmedLines(s string) []string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return lines
+}
+
+func TestTableDetail_RendersBeneathRow(t *testing.T) {
+	state := NewTableState(detailRows())
+	state.Expand(1)
+	table := detailTable(state)
+	table.Columns[0].Header = Text{Content: "Name"}
+
+	lines := trimmedLines(RenderReportPlain(table, 20))
+	assert.Equal(t, []string{
+		"Name",
+		"ada   1815",
+		"grace 1906",
+		"  detail of grace",
+		"  second line",
+		"linus 1969",
+	}, lines)
+}
+
+func TestTableDetail_FollowsFilteredRows(t *testing.T) {
+	state := NewTableState(detailRows())
+	state.Expand(2)
+	filter := NewFilterState()
+	filter.Query.Set("lin")
+	table := detailTable(state)
+	table.Filter = filter
+
+	lines := trimmedLines(RenderReportPlain(table, 20))
+	assert.Equal(t, []string{"linus 1969", "  detail of linus", "  second line"}, lines)
+}
+
+func TestTableDetail_KeysToggleCursorRow(t *testing.T) {
+	state := NewTableState(detailRows())
+	table := detailTable(state)
+
+	assert.True(t, pressTableKey(table, "space"))
+	assert.True(t, state.IsExpanded(0))
+	assert.True(t, pressTableKey(table, "enter"), "enter toggles without OnSelect")
+	assert.False(t, state.IsExpanded(0))
+
+	var selected []string
+	table.OnSelect = func(row []string) { selected = row }
+	pressTableKey(table, "enter")
+	assert.False(t, state.IsExpanded(0))
+	assert.Equal(t, []string{"ada", "1815"}, selected)
+}
+
+func TestTableDetail_ExpandPolicy(t *testing.T) {
+	state := NewTableState(detailRows())
+	table := detailTable(state)
+
+	pressTableKey(table, "space")
+	state.SelectIndex(1)
+	pressTableKey(table, "space")
+	assert.Len(t, state.Expanded.Peek(), 2, "multiple rows expand by default")
+
+	state.CollapseAll()
+	table.ExpandPolicy = TableExpandSingle
+	state.SelectIndex(0)
+	pressTableKey(table, "space")
+	state.SelectIndex(2)
+	pressTableKey(table, "space")
+	assert.Equal(t, map[int]struct{}{2: {}}, state.Expanded.Peek())
+}
+
+func TestTableDetail_NoKeysWithoutRenderDetail(t *testing.T) {
+	table := Table[[]string]{State: NewTableState(detailRows()), Columns: []TableColumn{{}}}
+	for _, bind := range table.Keybinds() {
+		assert.NotEqual(t, "space", bind.Key)
+	}
+}
+
+func TestTableDetail_ScrollsDetailIntoView(t *testing.T) {
+	state := NewTableState(detailRows())
+	state.SelectIndex(1)
+	scrollState := NewScrollState()
+	scrollState.updateLayout(3, 10)
+	state.rowLayouts = []tableRowLayout{
+		{y: 0, height: 1},
+		{y: 1, height: 1, detailHeight: 4},
+		{y: 6, height: 1},
+	}
+	table := detailTable(state)
+	table.ScrollState = scrollState
+
+	table.scrollCursorIntoView()
+	assert.Equal(t, 1, scrollState.GetOffset(), "a detail taller than the viewport keeps its row at the top")
+
+	state.rowLayouts[1].detailHeight = 1
+	scrollState.SetOffset(0)
+	table.scrollCursorIntoView()
+	assert.Equal(t, 0, scrollState.GetOffset())
+	state.rowLayouts[1].detailHeight = 2
+	table.scrollCursorIntoView()
+	assert.Equal(t, 1, scrollState.GetOffset())
+}
+
+func TestTableDetail_Animates(t *testing.T) {
+	clock := withManualClock(t)
+	controller := NewAnimationController(60)
+	previous := currentController
+	currentController = controller
+	t.Cleanup(func() {
+		controller.Stop()
+		currentController = previous
+	})
+
+	state := NewTableState(detailRows())
+	table := detailTable(state)
+	table.DetailAnimation = 100 * time.Millisecond
+	state.Expand(0)
+
+	render := func() []string {
+		return trimmedLines(RenderReportPlain(table, 20))
+	}
+	require.Len(t, render(), 3, "the detail starts closed")
+
+	clock.Advance(20 * time.Millisecond)
+	controller.Update()
+	lines := render()
+	assert.Len(t, lines, 4, "partly open")
+	assert.Equal(t, "  detail of ada", lines[1])
+
+	clock.Advance(100 * time.Millisecond)
+	controller.Update()
+	assert.Len(t, render(), 5)
+
+	state.Collapse(0)
+	assert.Len(t, render(), 5, "closing starts from fully open")
+	clock.Advance(100 * time.Millisecond)
+	controller.Update()
+	assert.Len(t, render(), 3)
+	assert.Empty(t, state.detailReveal)
+}
@@ -0,0 +1,109 @@
+package terma
+
+import "sort"
+
+// TableFormatRule styles the Table cells it matches, so conditional
+// formatting like "degraded services in red" is configured as data rather
+// than written into every RenderCell.
+//
+// Rules are applied on top of each cell as rendered: a Text cell takes the
+// rule's colors and text attributes; any other cell takes only its
+// background. Rule backgrounds don't replace the cursor and selection
+// highlights.
+//
+// Example:
+//
+//	FormatRules: []TableFormatRule[Service]{
+//	    {When: func(s Service, _, _ int) bool { return s.Status == "Degraded" }, Style: Style{ForegroundColor: theme.Error}},
+//	    {When: func(s Service, _, _ int) bool { return s.Latency > 500 }, Columns: []int{2}, Style: Style{Bold: true}},
+//	}
+type TableFormatRule[T any] struct {
+	When     func(row T, rowIndex int, colIndex int) bool // Required - whether the rule applies to a cell
+	Columns  []int                                        // Columns the rule applies to (nil = every column)
+	Style    Style                                        // Colors and text attributes to apply
+	Priority int                                          // Rules with a higher Priority win where rules set the same property; equal priorities: later rules win
+}
+
+func (r TableFormatRule[T]) matches(row T, rowIndex, colIndex int) bool {
+	if r.When == nil {
+		return false
+	}
+	if r.Columns != nil {
+		found := false
+		for _, col := range r.Columns {
+			if col == colIndex {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return r.When(row, rowIndex, colIndex)
+}
+
+// sortedFormatRules returns the rules from lowest to highest priority, so
+// applying them in order lets higher priorities win.
+func (t Table[T]) sortedFormatRules() []TableFormatRule[T] {
+	if len(t.FormatRules) == 0 {
+		return nil
+	}
+	rules := append([]TableFormatRule[T](nil), t.FormatRules...)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority < rules[j].Priority
+	})
+	return rules
+}
+
+// formatCell applies the matching rules to a rendered cell. highlighted
+// cells keep their cursor or selection background.
+func formatCell[T any](cell Widget, rules []TableFormatRule[T], row T, rowIndex, colIndex int, highlighted bool) Widget {
+	var format Style
+	for _, rule := range rules {
+		if rule.matches(row, rowIndex, colIndex) {
+			format = format.withFormatStyle(rule.Style)
+		}
+	}
+	if highlighted {
+		format.BackgroundColor = nil
+	}
+	if format.IsZero() {
+		return cell
+	}
+
+	if text, ok := cell.(Text); ok {
+		text.Style = text.Style.withFormatStyle(format)
+		return text
+	}
+	if format.BackgroundColor == nil || !format.BackgroundColor.IsSet() {
+		return cell
+	}
+	return Row{
+		Style:    Style{BackgroundColor: format.BackgroundColor, Width: Flex(1)},
+		Children: []Widget{cell},
+	}
+}
+
+// withFormatStyle returns style with overlay's colors and text attributes
+// applied. Layout fields are left alone.
+func (s Style) withFormatStyle(overlay Style) Style {
+	if overlay.ForegroundColor != nil && overlay.ForegroundColor.IsSet() {
+		s.ForegroundColor = overlay.ForegroundColor
+	}
+	if overlay.BackgroundColor != nil && overlay.BackgroundColor.IsSet() {
+		s.BackgroundColor = overlay.BackgroundColor
+	}
+	s.Bold = s.Bold || overlay.Bold
+	s.Faint = s.Faint || overlay.Faint
+	s.Italic = s.Italic || overlay.Italic
+	s.Reverse = s.Reverse || overlay.Reverse
+	s.Strikethrough = s.Strikethrough || overlay.Strikethrough
+	if overlay.Underline != UnderlineNone {
+		s.Underline = overlay.Underline
+	}
+	if overlay.UnderlineColor.IsSet() {
+		s.UnderlineColor = overlay.UnderlineColor
+	}
+	return s
+}
@@ -0,0 +1,89 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type formatService struct {
+	Name   string
+	Status string
+}
+
+func formatTableCells(t *testing.T, table Table[formatService]) []Widget {
+	t.Helper()
+	ctx := NewBuildContext(nil, AnySignal[Focusable]{}, AnySignal[Widget]{}, nil)
+	container, ok := table.Build(ctx).(tableContainer[formatService])
+	require.True(t, ok)
+	return container.children
+}
+
+func TestTableFormatRules_StyleMatchingRows(t *testing.T) {
+	red, blue := Hex("#ff0000"), Hex("#0000ff")
+	table := Table[formatService]{
+		State:   NewTableState([]formatService{{"api", "OK"}, {"db", "Degraded"}}),
+		Columns: []TableColumn{{}, {}},
+		FormatRules: []TableFormatRule[formatService]{
+			{When: func(s formatService, _, _ int) bool { return s.Status == "Degraded" }, Style: Style{ForegroundColor: red}},
+			{When: func(s formatService, _, _ int) bool { return true }, Columns: []int{1}, Style: Style{Bold: true}},
+		},
+	}
+
+	cells := formatTableCells(t, table)
+	require.Len(t, cells, 4)
+	assert.NotEqual(t, red, cells[0].(Text).Style.ForegroundColor)
+	assert.True(t, cells[1].(Text).Style.Bold, "Columns limits a rule to those columns")
+	assert.False(t, cells[2].(Text).Style.Bold)
+	assert.Equal(t, red, cells[2].(Text).Style.ForegroundColor)
+	assert.Equal(t, red, cells[3].(Text).Style.ForegroundColor)
+	assert.True(t, cells[3].(Text).Style.Bold)
+
+	table.FormatRules = append(table.FormatRules, TableFormatRule[formatService]{
+		When:     func(s formatService, _, col int) bool { return col == 0 },
+		Style:    Style{ForegroundColor: blue},
+		Priority: -1,
+	})
+	cells = formatTableCells(t, table)
+	assert.Equal(t, red, cells[2].(Text).Style.ForegroundColor, "higher priority wins regardless of order")
+	assert.Equal(t, blue, cells[0].(Text).Style.ForegroundColor)
+}
+
+func TestTableFormatRules_KeepHighlightBackground(t *testing.T) {
+	red := Hex("#ff0000")
+	state := NewTableState([]formatService{{"api", "OK"}, {"db", "OK"}})
+	state.Select(1)
+	table := Table[formatService]{
+		State:       state,
+		Columns:     []TableColumn{{}},
+		MultiSelect: true,
+		FormatRules: []TableFormatRule[formatService]{
+			{When: func(formatService, int, int) bool { return true }, Style: Style{BackgroundColor: red}},
+		},
+	}
+
+	cells := formatTableCells(t, table)
+	assert.Equal(t, red, cells[0].(Text).Style.BackgroundColor)
+	assert.NotEqual(t, red, cells[1].(Text).Style.BackgroundColor, "the selection highlight shows through")
+}
+
+func TestTableFormatRules_WrapNonTextCells(t *testing.T) {
+	red := Hex("#ff0000")
+	table := Table[formatService]{
+		State:   NewTableState([]formatService{{"api", "OK"}}),
+		Columns: []TableColumn{{}},
+		RenderCell: func(s formatService, _, _ int, _, _ bool) Widget {
+			return ProgressBar{Progress: 0.5}
+		},
+		FormatRules: []TableFormatRule[formatService]{
+			{When: func(formatService, int, int) bool { return true }, Style: Style{BackgroundColor: red, Bold: true}},
+		},
+	}
+
+	cells := formatTableCells(t, table)
+	row, ok := cells[0].(Row)
+	require.True(t, ok)
+	assert.Equal(t, red, row.Style.BackgroundColor)
+	assert.IsType(t, ProgressBar{}, row.Children[0])
+}
@@ -1,12 +1,20 @@
 package terma
 
-import "github.com/darrenburns/terma/layout"
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/darrenburns/terma/layout"
+)
 
 type tableNode struct {
 	Columns int
 	Rows    int
 
 	ColumnWidths  []Dimension
+	ColumnMin     []int // Per-column minimum width of Auto columns (0 = none)
+	ColumnMax     []int // Per-column maximum width of Auto columns (0 = none)
 	ColumnSpacing int
 	RowSpacing    int
 	Children      []layout.LayoutNode
@@ -25,6 +33,29 @@ type tableNode struct {
 
 	PreserveWidth  bool
 	PreserveHeight bool
+
+	// HeaderRows leading rows are always measured for Auto columns. Beyond
+	// them, with SampleRows > 0, only the next SampleRows rows and
+	// SampleRows others picked at random are measured.
+	HeaderRows int
+	SampleRows int
+
+	// FittedWidths, when set, are used as the content widths of Auto columns
+	// instead of measuring the cells.
+	FittedWidths []int
+	// OnMeasured, when set, receives the content widths measured for every
+	// row, with sampling turned off.
+	OnMeasured func(widths []int)
+
+	// Details are full-width rows shown beneath grid rows. Their nodes
+	// follow the Rows*Columns cells in Children, in the same order.
+	Details []tableDetail
+}
+
+// tableDetail places a full-width detail row beneath a grid row.
+type tableDetail struct {
+	Row    int     // Grid row (including header rows) the detail follows
+	Reveal float64 // Fraction of the detail's height shown, for animating it open (1 = all)
 }
 
 func (t *tableNode) ComputeLayout(constraints layout.Constraints) layout.ComputedLayout {
@@ -45,14 +76,20 @@ func (t *tableNode) ComputeLayout(constraints layout.Constraints) layout.Compute
 	}
 
 	columnWidths := t.computeColumnWidths(rows, cols, contentConstraints)
-	cellLayouts, rowHeights := t.layoutCells(rows, cols, columnWidths, contentConstraints)
+	cellBuf := cellLayoutPool.get()
+	defer cellLayoutPool.put(cellBuf)
+	var rowHeights []int
+	*cellBuf, rowHeights = t.layoutCells(*cellBuf, rows, cols, columnWidths, contentConstraints)
+	cellLayouts := *cellBuf
 
 	contentWidth := sumInts(columnWidths)
 	if cols > 1 {
 		contentWidth += t.ColumnSpacing * (cols - 1)
 	}
 
-	contentHeight := sumInts(rowHeights)
+	detailLayouts, detailHeights := t.layoutDetails(rows, contentWidth, contentConstraints)
+
+	contentHeight := sumInts(rowHeights) + sumInts(detailHeights)
 	if rows > 1 {
 		contentHeight += t.RowSpacing * (rows - 1)
 	}
@@ -61,6 +98,7 @@ func (t *tableNode) ComputeLayout(constraints layout.Constraints) layout.Compute
 	containerHeight := t.resolveContainerSize(contentConstraints.MinHeight, contentConstraints.MaxHeight, contentHeight, t.ExpandHeight)
 
 	positioned := t.positionCells(rows, cols, columnWidths, rowHeights, cellLayouts)
+	positioned = t.positionDetails(rows, cols, rowHeights, detailHeights, positioned, detailLayouts)
 
 	return t.buildResult(effective, containerWidth, containerHeight, positioned)
 }
@@ -108,7 +146,7 @@ func (t *tableNode) toContentConstraints(constraints layout.Constraints) layout.
 
 func (t *tableNode) computeColumnWidths(rows, cols int, contentConstraints layout.Constraints) []int {
 	widths := make([]int, cols)
-	intrinsic := t.measureIntrinsicWidths(rows, cols, contentConstraints)
+	intrinsic := t.intrinsicWidths(rows, cols, contentConstraints)
 
 	widthMax := contentConstraints.MaxWidth
 	widthBounded := widthMax < maxTableInt()
@@ -154,7 +192,7 @@ func (t *tableNode) computeColumnWidths(rows, cols int, contentConstraints layou
 			flexValues[i] = normalizeFlex(dim.FlexValue())
 			totalFlex += flexValues[i]
 		default:
-			widths[i] = intrinsic[i]
+			widths[i] = t.capColumnWidth(i, intrinsic[i])
 			fixedTotal += widths[i]
 			autoFlags[i] = true
 		}
@@ -184,10 +222,70 @@ func (t *tableNode) computeColumnWidths(rows, cols int, contentConstraints layou
 	}
 
 	distributeExtra(widths, autoFlags, extra)
+	for i := range widths {
+		if autoFlags[i] {
+			widths[i] = t.capColumnWidth(i, widths[i])
+		}
+	}
 	return widths
 }
 
-func (t *tableNode) measureIntrinsicWidths(rows, cols int, contentConstraints layout.Constraints) []int {
+// capColumnWidth clamps an Auto column's width to its Min/Max caps.
+func (t *tableNode) capColumnWidth(col, width int) int {
+	if col < len(t.ColumnMax) && t.ColumnMax[col] > 0 {
+		width = min(width, t.ColumnMax[col])
+	}
+	if col < len(t.ColumnMin) && t.ColumnMin[col] > 0 {
+		width = max(width, t.ColumnMin[col])
+	}
+	return width
+}
+
+// intrinsicWidths returns the content width of each column: the fitted
+// widths when set, otherwise the widest measured cell.
+func (t *tableNode) intrinsicWidths(rows, cols int, contentConstraints layout.Constraints) []int {
+	if len(t.FittedWidths) == cols {
+		return t.FittedWidths
+	}
+	if t.OnMeasured != nil {
+		intrinsic := t.measureIntrinsicWidths(allRows(rows), cols, contentConstraints)
+		t.OnMeasured(intrinsic)
+		return intrinsic
+	}
+	return t.measureIntrinsicWidths(t.sampledRows(rows), cols, contentConstraints)
+}
+
+// sampledRows returns the rows measured for Auto columns: every row, or
+// with SampleRows set, the header rows, the first SampleRows rows after
+// them and SampleRows more picked at random. The random picks depend only
+// on the row count, so widths don't change between frames.
+func (t *tableNode) sampledRows(rows int) []int {
+	first := t.HeaderRows + t.SampleRows
+	if t.SampleRows <= 0 || rows <= first+t.SampleRows {
+		return allRows(rows)
+	}
+	sampled := allRows(first)
+	rng := rand.New(rand.NewPCG(uint64(rows), uint64(t.SampleRows)))
+	picked := make(map[int]struct{}, t.SampleRows)
+	for len(picked) < t.SampleRows {
+		row := first + rng.IntN(rows-first)
+		if _, ok := picked[row]; !ok {
+			picked[row] = struct{}{}
+			sampled = append(sampled, row)
+		}
+	}
+	return sampled
+}
+
+func allRows(rows int) []int {
+	indices := make([]int, rows)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
+
+func (t *tableNode) measureIntrinsicWidths(rows []int, cols int, contentConstraints layout.Constraints) []int {
 	intrinsic := make([]int, cols)
 	maxWidth := contentConstraints.MaxWidth
 	if maxWidth <= 0 {
@@ -206,7 +304,7 @@ func (t *tableNode) measureIntrinsicWidths(rows, cols int, contentConstraints la
 
 	for col := 0; col < cols; col++ {
 		maxWidthForCol := 0
-		for row := 0; row < rows; row++ {
+		for _, row := range rows {
 			idx := row*cols + col
 			if idx < 0 || idx >= len(t.Children) {
 				continue
@@ -229,7 +327,14 @@ func (t *tableNode) measureIntrinsicWidths(rows, cols int, contentConstraints la
 	return intrinsic
 }
 
-func (t *tableNode) layoutCells(rows, cols int, columnWidths []int, contentConstraints layout.Constraints) ([]layout.ComputedLayout, []int) {
+// cellLayoutPool holds the cell layouts a table measures before placing
+// them. positionCells copies the layouts into the result, so the slice is
+// scratch and reused by the next layout.
+var cellLayoutPool slicePool[layout.ComputedLayout]
+
+// layoutCells lays out every cell at its column's width and its row's
+// height, reusing dst for the layouts. Returns the layouts and row heights.
+func (t *tableNode) layoutCells(dst []layout.ComputedLayout, rows, cols int, columnWidths []int, contentConstraints layout.Constraints) ([]layout.ComputedLayout, []int) {
 	rowHeights := make([]int, rows)
 
 	maxHeight := contentConstraints.MaxHeight
@@ -266,7 +371,7 @@ func (t *tableNode) layoutCells(rows, cols int, columnWidths []int, contentConst
 		rowHeights[row] = rowHeight
 	}
 
-	cellLayouts := make([]layout.ComputedLayout, rows*cols)
+	cellLayouts := slices.Grow(dst[:0], rows*cols)[:rows*cols]
 	for row := 0; row < rows; row++ {
 		rowHeight := rowHeights[row]
 		for col := 0; col < cols; col++ {
@@ -292,6 +397,80 @@ func (t *tableNode) layoutCells(rows, cols int, columnWidths []int, contentConst
 	return cellLayouts, rowHeights
 }
 
+// layoutDetails lays out each detail at the table's full content width.
+// Returns the detail layouts and the total detail height beneath each row.
+func (t *tableNode) layoutDetails(rows, contentWidth int, contentConstraints layout.Constraints) ([]layout.ComputedLayout, []int) {
+	detailHeights := make([]int, rows)
+	if len(t.Details) == 0 {
+		return nil, detailHeights
+	}
+
+	maxHeight := max(contentConstraints.MaxHeight, 0)
+	start := t.Rows * t.Columns
+	detailLayouts := make([]layout.ComputedLayout, len(t.Details))
+	for i, detail := range t.Details {
+		idx := start + i
+		if idx >= len(t.Children) || detail.Row < 0 || detail.Row >= rows {
+			continue
+		}
+		child := stripExpandHeight(t.Children[idx])
+		detailLayout := child.ComputeLayout(layout.Constraints{
+			MinWidth:  contentWidth,
+			MaxWidth:  contentWidth,
+			MinHeight: 0,
+			MaxHeight: maxHeight,
+		})
+		height := detailLayout.Box.BorderBoxHeight()
+		if detail.Reveal < 1 {
+			height = int(math.Ceil(float64(height) * max(detail.Reveal, 0)))
+			detailLayout = child.ComputeLayout(layout.Constraints{
+				MinWidth:  contentWidth,
+				MaxWidth:  contentWidth,
+				MinHeight: height,
+				MaxHeight: height,
+			})
+		}
+		detailLayouts[i] = detailLayout
+		detailHeights[detail.Row] += height
+	}
+	return detailLayouts, detailHeights
+}
+
+// positionDetails moves cells down to make room for the details beneath
+// earlier rows and appends the positioned details after the cells.
+func (t *tableNode) positionDetails(rows, cols int, rowHeights, detailHeights []int, positioned []layout.PositionedChild, detailLayouts []layout.ComputedLayout) []layout.PositionedChild {
+	if len(t.Details) == 0 {
+		return positioned
+	}
+
+	offsets := make([]int, rows)
+	offset := 0
+	for row := 0; row < rows; row++ {
+		offsets[row] = offset
+		offset += detailHeights[row]
+	}
+	for idx := range positioned {
+		positioned[idx].Y += offsets[idx/cols]
+	}
+
+	// Details beneath the same row stack in order.
+	below := make([]int, rows)
+	for i, detail := range t.Details {
+		if detail.Row < 0 || detail.Row >= rows {
+			positioned = append(positioned, layout.PositionedChild{})
+			continue
+		}
+		rowTop := positioned[detail.Row*cols].Y
+		positioned = append(positioned, layout.PositionedChild{
+			X:      0,
+			Y:      rowTop + rowHeights[detail.Row] + below[detail.Row],
+			Layout: detailLayouts[i],
+		})
+		below[detail.Row] += detailLayouts[i].Box.BorderBoxHeight()
+	}
+	return positioned
+}
+
 func (t *tableNode) positionCells(rows, cols int, columnWidths []int, rowHeights []int, cellLayouts []layout.ComputedLayout) []layout.PositionedChild {
 	positioned := make([]layout.PositionedChild, rows*cols)
 
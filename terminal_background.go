@@ -0,0 +1,40 @@
+package terma
+
+import (
+	"image/color"
+	"sync/atomic"
+)
+
+// detectedBackground is the background color the terminal reported in
+// reply to an OSC 11 query, or nil before it has answered.
+var detectedBackground atomic.Pointer[Color]
+
+// TerminalBackground returns the terminal's default background color and
+// whether it is known. Run asks the terminal for it at startup; terminals
+// that don't answer leave it unknown.
+func TerminalBackground() (Color, bool) {
+	if c := detectedBackground.Load(); c != nil {
+		return *c, true
+	}
+	return Color{}, false
+}
+
+// terminalBackground returns the color semi-transparent colors blend with
+// where nothing has been painted: the terminal's reported background, or
+// black when it is unknown.
+func terminalBackground() Color {
+	if c, ok := TerminalBackground(); ok {
+		return c
+	}
+	return Black
+}
+
+// setTerminalBackground records the background the terminal reported.
+func setTerminalBackground(c color.Color) {
+	if c == nil {
+		detectedBackground.Store(nil)
+		return
+	}
+	bg := FromANSI(c)
+	detectedBackground.Store(&bg)
+}
@@ -0,0 +1,62 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransparent_ClearsParentBackground(t *testing.T) {
+	widget := Row{
+		Style: Style{BackgroundColor: RGB(0, 0, 100)},
+		Children: []Widget{
+			Text{Content: "A", Style: Style{BackgroundColor: Transparent}},
+			Text{Content: "B"},
+		},
+	}
+
+	buf := renderWithPointer(widget, 2, 1, "", "")
+	assert.Equal(t, "A", buf.CellAt(0, 0).Content)
+	assert.Nil(t, buf.CellAt(0, 0).Style.Bg, "the terminal's background shows through")
+	assert.Equal(t, RGB(0, 0, 100).toANSI(), buf.CellAt(1, 0).Style.Bg)
+}
+
+func TestTransparent_SemiTransparentBlendsWithTerminalBackground(t *testing.T) {
+	t.Cleanup(func() { setTerminalBackground(nil) })
+	widget := Column{
+		Style: Style{BackgroundColor: Transparent},
+		Children: []Widget{
+			Text{Content: "A", Style: Style{BackgroundColor: RGBA(255, 255, 255, 0.5)}},
+			Text{Content: "B"},
+		},
+	}
+
+	buf := renderWithPointer(widget, 1, 2, "", "")
+	assert.Equal(t, RGB(128, 128, 128).toANSI(), buf.CellAt(0, 0).Style.Bg, "unknown terminal background blends as black")
+	assert.Nil(t, buf.CellAt(0, 1).Style.Bg)
+
+	setTerminalBackground(ansi.RGBColor{R: 0, G: 0, B: 200})
+	buf = renderWithPointer(widget, 1, 2, "", "")
+	assert.Equal(t, RGB(128, 128, 228).toANSI(), buf.CellAt(0, 0).Style.Bg)
+	assert.Nil(t, buf.CellAt(0, 1).Style.Bg)
+}
+
+func TestTransparent_Color(t *testing.T) {
+	t.Cleanup(func() { setTerminalBackground(nil) })
+	assert.True(t, Transparent.IsSet())
+	assert.True(t, Transparent.IsTransparent())
+	assert.False(t, Black.IsTransparent())
+	assert.Nil(t, Transparent.toANSI())
+	assert.Equal(t, "", Transparent.Hex())
+	assert.Equal(t, Transparent, Transparent.BlendOver(Black))
+
+	_, known := TerminalBackground()
+	assert.False(t, known)
+	setTerminalBackground(ansi.RGBColor{R: 10, G: 20, B: 30})
+	bg, known := TerminalBackground()
+	assert.True(t, known)
+	assert.Equal(t, RGB(10, 20, 30), bg)
+	assert.Equal(t, RGB(10, 20, 30), RGBA(0, 0, 0, 0).BlendOver(Transparent))
+	assert.Equal(t, RGB(10, 20, 30), Transparent.Blend(Red, 0))
+}
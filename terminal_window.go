@@ -0,0 +1,167 @@
+package terma
+
+import (
+	"math"
+	"sync"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TerminalProgressState is the state shown by the terminal's progress
+// indicator (see SetTerminalProgress).
+type TerminalProgressState int
+
+const (
+	// ProgressNormal shows progress normally.
+	ProgressNormal TerminalProgressState = iota
+	// ProgressError shows progress in the terminal's error color.
+	ProgressError
+	// ProgressWarning shows progress in the terminal's warning (paused) color.
+	ProgressWarning
+	// ProgressIndeterminate shows activity without a known amount of progress.
+	ProgressIndeterminate
+)
+
+// Saving and restoring the window title use the xterm title stack.
+var (
+	pushWindowTitle = ansi.WindowOp(22, 2)
+	popWindowTitle  = ansi.WindowOp(23, 2)
+)
+
+// terminalWindow tracks what has been changed about the terminal window,
+// so it can be restored when the app exits.
+var terminalWindow struct {
+	mu            sync.Mutex
+	titleSaved    bool
+	progressShown bool
+	lastProgress  string     // Last progress sequence sent, to skip repeats
+	pointerShape  CursorHint // Pointer shape last sent (see setPointerShape)
+}
+
+// SetWindowTitle sets the terminal window's title. The title it replaces
+// is saved the first time and restored when Run returns (or by
+// RestoreWindowTitle). Safe to call from any goroutine.
+func SetWindowTitle(title string) {
+	seq := ansi.SetWindowTitle(sanitizeNotificationText(title))
+	terminalWindow.mu.Lock()
+	if !terminalWindow.titleSaved {
+		terminalWindow.titleSaved = true
+		seq = pushWindowTitle + seq
+	}
+	terminalWindow.mu.Unlock()
+	writeTerminalOutput(seq)
+}
+
+// RestoreWindowTitle restores the title the window had before the first
+// SetWindowTitle. Run does this on exit; call it yourself when setting the
+// title outside Run.
+func RestoreWindowTitle() {
+	terminalWindow.mu.Lock()
+	saved := terminalWindow.titleSaved
+	terminalWindow.titleSaved = false
+	terminalWindow.mu.Unlock()
+	if saved {
+		writeTerminalOutput(popWindowTitle)
+	}
+}
+
+// SetTerminalProgress shows progress (0.0 to 1.0) in the terminal's tab or
+// taskbar using the ConEmu/Windows Terminal OSC 9;4 sequence, which is also
+// supported by Ghostty, WezTerm and recent VTE-based terminals; others
+// ignore it. The indicator is cleared when Run returns (or by
+// ClearTerminalProgress). Safe to call from any goroutine.
+//
+// Example:
+//
+//	for i, file := range files {
+//		install(file)
+//		t.SetTerminalProgress(t.ProgressNormal, float64(i+1)/float64(len(files)))
+//	}
+//	t.ClearTerminalProgress()
+func SetTerminalProgress(state TerminalProgressState, progress float64) {
+	if seq, ok := terminalProgressUpdate(state, progress); ok {
+		writeTerminalOutput(seq)
+	}
+}
+
+// ClearTerminalProgress hides the terminal's progress indicator.
+func ClearTerminalProgress() {
+	terminalWindow.mu.Lock()
+	shown := terminalWindow.progressShown
+	terminalWindow.progressShown = false
+	terminalWindow.lastProgress = ""
+	terminalWindow.mu.Unlock()
+	if shown {
+		writeTerminalOutput(ansi.ResetProgressBar)
+	}
+}
+
+// ReportProgress mirrors progress (0.0 to 1.0) to the terminal's progress
+// indicator whenever it changes, until stop is called, which also clears
+// the indicator.
+//
+// Example:
+//
+//	stop := t.ReportProgress(a.downloaded)
+//	defer stop()
+func ReportProgress(progress Signal[float64]) (stop func()) {
+	SetTerminalProgress(ProgressNormal, progress.Peek())
+	unwatch := progress.watch(func() {
+		SetTerminalProgress(ProgressNormal, progress.Peek())
+	})
+	return func() {
+		unwatch()
+		ClearTerminalProgress()
+	}
+}
+
+// terminalProgressUpdate returns the sequence for the given progress,
+// or false if it is what the terminal already shows.
+func terminalProgressUpdate(state TerminalProgressState, progress float64) (string, bool) {
+	if math.IsNaN(progress) {
+		progress = 0
+	}
+	percent := int(math.Round(min(max(progress, 0), 1) * 100))
+	var seq string
+	switch state {
+	case ProgressError:
+		seq = ansi.SetErrorProgressBar(percent)
+	case ProgressWarning:
+		seq = ansi.SetWarningProgressBar(percent)
+	case ProgressIndeterminate:
+		seq = ansi.SetIndeterminateProgressBar
+	default:
+		seq = ansi.SetProgressBar(percent)
+	}
+
+	terminalWindow.mu.Lock()
+	defer terminalWindow.mu.Unlock()
+	if seq == terminalWindow.lastProgress {
+		return "", false
+	}
+	terminalWindow.lastProgress = seq
+	terminalWindow.progressShown = true
+	return seq, true
+}
+
+// restoreTerminalWindow returns the sequences undoing SetWindowTitle,
+// SetTerminalProgress and pointer shape changes, and forgets them.
+func restoreTerminalWindow() []string {
+	terminalWindow.mu.Lock()
+	defer terminalWindow.mu.Unlock()
+	var seqs []string
+	if terminalWindow.progressShown {
+		seqs = append(seqs, ansi.ResetProgressBar)
+	}
+	if terminalWindow.titleSaved {
+		seqs = append(seqs, popWindowTitle)
+	}
+	if terminalWindow.pointerShape != CursorDefault {
+		seqs = append(seqs, ansi.SetPointerShape(CursorDefault.String()))
+	}
+	terminalWindow.titleSaved = false
+	terminalWindow.pointerShape = CursorDefault
+	terminalWindow.progressShown = false
+	terminalWindow.lastProgress = ""
+	return seqs
+}
@@ -0,0 +1,76 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func resetTerminalWindow(t *testing.T) {
+	t.Helper()
+	restoreTerminalWindow()
+	t.Cleanup(func() { restoreTerminalWindow() })
+}
+
+func TestSetWindowTitle_SavesOnceAndRestores(t *testing.T) {
+	buf := captureTerminalOutput(t)
+	resetTerminalWindow(t)
+
+	SetWindowTitle("Installing")
+	SetWindowTitle("Done\x07")
+	assert.Equal(t, "\x1b[22;2t\x1b]2;Installing\x07\x1b]2;Done\x07", buf.String())
+
+	buf.Reset()
+	RestoreWindowTitle()
+	RestoreWindowTitle()
+	assert.Equal(t, "\x1b[23;2t", buf.String())
+}
+
+func TestSetTerminalProgress_SkipsRepeats(t *testing.T) {
+	buf := captureTerminalOutput(t)
+	resetTerminalWindow(t)
+
+	SetTerminalProgress(ProgressNormal, 0.5)
+	SetTerminalProgress(ProgressNormal, 0.501)
+	SetTerminalProgress(ProgressError, 2)
+	SetTerminalProgress(ProgressIndeterminate, 0)
+	assert.Equal(t, "\x1b]9;4;1;50\x07\x1b]9;4;2;100\x07\x1b]9;4;3\x07", buf.String())
+
+	buf.Reset()
+	ClearTerminalProgress()
+	ClearTerminalProgress()
+	assert.Equal(t, "\x1b]9;4;0\x07", buf.String())
+}
+
+func TestReportProgress_FollowsSignal(t *testing.T) {
+	buf := captureTerminalOutput(t)
+	resetTerminalWindow(t)
+
+	progress := NewSignal(0.25)
+	stop := ReportProgress(progress)
+	progress.Set(0.75)
+	stop()
+	progress.Set(1)
+	assert.Equal(t, "\x1b]9;4;1;25\x07\x1b]9;4;1;75\x07\x1b]9;4;0\x07", buf.String())
+}
+
+func TestRestoreTerminalWindow(t *testing.T) {
+	captureTerminalOutput(t)
+	resetTerminalWindow(t)
+
+	assert.Empty(t, restoreTerminalWindow())
+	SetWindowTitle("App")
+	SetTerminalProgress(ProgressNormal, 0.1)
+	assert.Equal(t, []string{"\x1b]9;4;0\x07", "\x1b[23;2t"}, restoreTerminalWindow())
+	assert.Empty(t, restoreTerminalWindow())
+}
+
+func TestProgressBar_TerminalProgress(t *testing.T) {
+	buf := captureTerminalOutput(t)
+	resetTerminalWindow(t)
+
+	RenderToBuffer(ProgressBar{Progress: 0.4, TerminalProgress: true, Width: Cells(10)}, 10, 1)
+	RenderToBuffer(ProgressBar{Progress: 0.4, TerminalProgress: true, Width: Cells(10)}, 10, 1)
+	RenderToBuffer(ProgressBar{Progress: 0.9, Width: Cells(10)}, 10, 1)
+	assert.Equal(t, "\x1b]9;4;1;40\x07", buf.String())
+}
@@ -29,6 +29,29 @@ const (
 	TextAlignCenter
 	// TextAlignRight aligns text to the right edge.
 	TextAlignRight
+	// TextAlignJustify stretches wrapped lines to the full width by widening
+	// the gaps between words. The last line of each paragraph is left-aligned.
+	TextAlignJustify
+)
+
+// TruncateMode controls where a line that is too wide is shortened.
+// The removed text is replaced by Style.Ellipsis (default "…").
+type TruncateMode int
+
+const (
+	// TruncateNone clips lines at the right edge without a marker (default).
+	// Style.Overflow = OverflowEllipsis behaves like TruncateEnd.
+	TruncateNone TruncateMode = iota
+	// TruncateEnd keeps the start of the line: "hello w…".
+	TruncateEnd
+	// TruncateStart keeps the end of the line: "…o world".
+	TruncateStart
+	// TruncateMiddle keeps both ends of the line: "hell…orld".
+	TruncateMiddle
+	// TruncatePath elides whole directories from the middle of a "/"-separated
+	// path, keeping the first and last segments: "/usr/…/src/main.go".
+	// Falls back to TruncateStart when even that does not fit.
+	TruncatePath
 )
 
 // Text is a leaf widget that displays text content.
@@ -38,10 +61,16 @@ type Text struct {
 	Spans     []Span           // Rich text segments (takes precedence if non-empty)
 	Wrap      WrapMode         // Wrapping mode (default = WrapNone)
 	TextAlign TextAlign        // Horizontal alignment (default = TextAlignLeft)
+	Truncate  TruncateMode     // How lines wider than the widget are shortened (default = TruncateNone)
+	MaxLines  int              // Maximum lines shown; the last gets an ellipsis if text is cut (0 = unlimited)
+	WordBreak WordBreak        // Where WrapSoft may break words (default = WordBreakNormal)
+	Hyphenate bool             // Append "-" where WrapSoft splits a word across lines
+	TabWidth  int              // Tab stop interval in cells (default = DefaultTabWidth)
 	Width     Dimension        // Deprecated: use Style.Width
 	Height    Dimension        // Deprecated: use Style.Height
 	Style     Style            // Optional styling (colors, inherited by spans)
 	Click     func(MouseEvent) // Optional callback invoked when clicked
+	LinkClick func(url string) // Optional callback invoked when a span with a Link is clicked
 	MouseDown func(MouseEvent) // Optional callback invoked when mouse is pressed
 	MouseUp   func(MouseEvent) // Optional callback invoked when mouse is released
 	Hover     func(HoverEvent) // Optional callback invoked when hover state changes
@@ -61,6 +90,9 @@ func (t Text) WidgetID() string {
 // OnClick is called when the widget is clicked.
 // Implements the Clickable interface.
 func (t Text) OnClick(event MouseEvent) {
+	if t.LinkClick != nil && event.Link != "" {
+		t.LinkClick(event.Link)
+	}
 	if t.Click != nil {
 		t.Click(event)
 	}
@@ -111,6 +143,8 @@ func (t Text) GetStyle() Style {
 // BuildLayoutNode builds a layout node for this Text widget.
 // Implements the LayoutNodeBuilder interface.
 func (t Text) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	t = t.withTabsExpanded()
+
 	// Get the text content (spans concatenated or plain content)
 	content := t.textContent()
 
@@ -119,17 +153,36 @@ func (t Text) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
 	dims := GetWidgetDimensionSet(t)
 	minWidth, maxWidth, minHeight, maxHeight := dimensionSetToMinMax(dims, padding, border)
 
-	node := layout.LayoutNode(&layout.TextNode{
-		Content:   content,
-		Wrap:      toLayoutWrapMode(t.Wrap),
-		Padding:   padding,
-		Border:    border,
-		Margin:    toLayoutEdgeInsets(t.Style.Margin),
-		MinWidth:  minWidth,
-		MaxWidth:  maxWidth,
-		MinHeight: minHeight,
-		MaxHeight: maxHeight,
-	})
+	var node layout.LayoutNode
+	if t.usesWordBreakPolicy() {
+		// The word-break policy changes line counts, so measure with the
+		// same wrapping routine the renderer uses.
+		node = &layout.BoxNode{
+			Padding:   padding,
+			Border:    border,
+			Margin:    toLayoutEdgeInsets(t.Style.Margin),
+			MinWidth:  minWidth,
+			MaxWidth:  maxWidth,
+			MinHeight: minHeight,
+			MaxHeight: maxHeight,
+			MeasureFunc: func(c layout.Constraints) (int, int) {
+				return t.measureWrapped(content, c.MaxWidth)
+			},
+		}
+	} else {
+		node = &layout.TextNode{
+			Content:   content,
+			Wrap:      toLayoutWrapMode(t.Wrap),
+			MaxLines:  t.MaxLines,
+			Padding:   padding,
+			Border:    border,
+			Margin:    toLayoutEdgeInsets(t.Style.Margin),
+			MinWidth:  minWidth,
+			MaxWidth:  maxWidth,
+			MinHeight: minHeight,
+			MaxHeight: maxHeight,
+		}
+	}
 
 	if hasPercentMinMax(dims) {
 		node = &percentConstraintWrapper{
@@ -224,13 +277,14 @@ func alignLine(lineWidth, availableWidth int, align TextAlign) int {
 		return (availableWidth - lineWidth) / 2
 	case TextAlignRight:
 		return availableWidth - lineWidth
-	default:
+	default: // TextAlignLeft, TextAlignJustify
 		return 0
 	}
 }
 
 // Render draws the text to the render context.
 func (t Text) Render(ctx *RenderContext) {
+	t = t.withTabsExpanded()
 	if len(t.Spans) > 0 {
 		t.renderSpans(ctx)
 	} else {
@@ -250,8 +304,9 @@ func (t Text) renderPlain(ctx *RenderContext) {
 		drawStyle.BackgroundColor = nil
 	}
 
-	// Get lines with wrapping applied
-	lines := wrapText(t.Content, ctx.Width, t.Wrap)
+	// Get lines with wrapping and truncation applied
+	wrapped, paragraphEnds := t.wrapPlain(t.Content, ctx.Width)
+	lines := t.fitPlainLines(wrapped, ctx.Width, ctx.Height)
 
 	// Check if we need to draw text and padding separately
 	// (when strikethrough/underline is set but FillLine is false)
@@ -269,6 +324,10 @@ func (t Text) renderPlain(ctx *RenderContext) {
 			line = ansi.Truncate(line, ctx.Width, "")
 			lineWidth = ctx.Width
 		}
+		if t.TextAlign == TextAlignJustify && i < len(paragraphEnds) && !paragraphEnds[i] {
+			line = graphemesText(justifyGraphemes(plainGraphemes(line), ctx.Width))
+			lineWidth = ansi.StringWidth(line)
+		}
 
 		// Calculate alignment offset
 		xOffset := alignLine(lineWidth, ctx.Width, t.TextAlign)
@@ -283,17 +342,17 @@ func (t Text) renderPlain(ctx *RenderContext) {
 
 			// Draw left padding
 			if leftPadding > 0 {
-				ctx.DrawStyledText(0, i, strings.Repeat(" ", leftPadding), paddingStyle)
+				ctx.DrawStyledText(0, i, blanks(leftPadding), paddingStyle)
 			}
 			// Draw text with full style (including strikethrough/underline)
 			ctx.DrawStyledText(xOffset, i, line, drawStyle)
 			// Draw right padding
 			if rightPadding > 0 {
-				ctx.DrawStyledText(xOffset+lineWidth, i, strings.Repeat(" ", rightPadding), paddingStyle)
+				ctx.DrawStyledText(xOffset+lineWidth, i, blanks(rightPadding), paddingStyle)
 			}
 		} else {
 			// Build aligned line with padding
-			alignedLine := strings.Repeat(" ", leftPadding) + line + strings.Repeat(" ", rightPadding)
+			alignedLine := blanks(leftPadding) + line + blanks(rightPadding)
 			ctx.DrawStyledText(0, i, alignedLine, drawStyle)
 		}
 	}
@@ -308,8 +367,9 @@ type spanSegment struct {
 
 // lineData holds all span segments for a single line.
 type lineData struct {
-	segments []spanSegment
-	width    int // total width of the line
+	segments     []spanSegment
+	width        int  // total width of the line
+	paragraphEnd bool // line ends at an explicit newline or the end of the text
 }
 
 type styledGrapheme struct {
@@ -318,24 +378,30 @@ type styledGrapheme struct {
 	width int
 }
 
-func collectSpanGraphemes(spans []Span) []styledGrapheme {
-	if len(spans) == 0 {
-		return nil
-	}
-	result := make([]styledGrapheme, 0, len(spans))
+// graphemePool holds the scratch grapheme slices that span text is split
+// into while it is wrapped. The lines built from them copy what they keep.
+var graphemePool slicePool[styledGrapheme]
+
+// appendSpanGraphemes splits spans into styled graphemes, appended to dst.
+func appendSpanGraphemes(dst []styledGrapheme, spans []Span) []styledGrapheme {
 	for _, span := range spans {
 		if span.Text == "" {
 			continue
 		}
-		for _, g := range splitGraphemes(span.Text) {
-			result = append(result, styledGrapheme{
+		text := span.Text
+		if span.Style.VerticalAlign != SpanAlignBaseline {
+			text = shiftBaseline(text, span.Style.VerticalAlign)
+		}
+		seg := segmentGraphemes(text)
+		for i, g := range seg.graphemes {
+			dst = append(dst, styledGrapheme{
 				text:  g,
 				style: span.Style,
-				width: graphemeWidth(g),
+				width: seg.widths[i],
 			})
 		}
 	}
-	return result
+	return dst
 }
 
 func appendStyledGrapheme(line *lineData, g styledGrapheme, x *int) {
@@ -359,6 +425,261 @@ func appendStyledGrapheme(line *lineData, g styledGrapheme, x *int) {
 	*x += g.width
 }
 
+// truncateMode returns the effective truncation mode, treating
+// Style.Overflow = OverflowEllipsis as TruncateEnd.
+func (t Text) truncateMode() TruncateMode {
+	if t.Truncate == TruncateNone && t.Style.Overflow == OverflowEllipsis {
+		return TruncateEnd
+	}
+	return t.Truncate
+}
+
+// lineLimit returns the number of lines that can be shown in the given height.
+func (t Text) lineLimit(height int) int {
+	if t.MaxLines > 0 && t.MaxLines < height {
+		return t.MaxLines
+	}
+	return height
+}
+
+// marksCutLines reports whether the last visible line should get an
+// ellipsis when lines beyond the limit are dropped.
+func (t Text) marksCutLines() bool {
+	return t.MaxLines > 0 || t.truncateMode() != TruncateNone
+}
+
+// fitPlainLines applies per-line truncation and the line limit to wrapped plain lines.
+func (t Text) fitPlainLines(lines []string, width, height int) []string {
+	mode := t.truncateMode()
+	marker := t.Style.EllipsisMarker()
+	limit := t.lineLimit(height)
+
+	cut := len(lines) > limit
+	if cut {
+		lines = lines[:limit]
+	}
+	if mode != TruncateNone {
+		for i, line := range lines {
+			if ansi.StringWidth(line) > width {
+				lines[i] = graphemesText(truncateGraphemes(plainGraphemes(line), width, mode, marker))
+			}
+		}
+	}
+	if cut && limit > 0 && t.marksCutLines() {
+		last := limit - 1
+		lines[last] = graphemesText(appendEllipsisGraphemes(plainGraphemes(lines[last]), width, marker))
+	}
+	return lines
+}
+
+// fitSpanLines collects span lines and applies per-line truncation and the line limit.
+func (t Text) fitSpanLines(width, height int) []lineData {
+	mode := t.truncateMode()
+	marker := t.Style.EllipsisMarker()
+	limit := t.lineLimit(height)
+
+	var lines []lineData
+	if mode != TruncateNone && (width <= 0 || t.Wrap == WrapNone) {
+		// Collect full-width lines so truncation can keep either end.
+		graphemes := graphemePool.get()
+		*graphemes = appendSpanGraphemes(*graphemes, t.Spans)
+		lines = collectSpanLinesNoWrap(*graphemes, 0, limit+1)
+		graphemePool.put(graphemes)
+	} else {
+		lines = t.collectSpanLines(width, limit+1)
+	}
+
+	cut := len(lines) > limit
+	if cut {
+		lines = lines[:limit]
+	}
+	if mode != TruncateNone {
+		for i, line := range lines {
+			if line.width > width {
+				lines[i] = graphemesLine(truncateGraphemes(lineGraphemes(line), width, mode, marker))
+			}
+		}
+	}
+	if cut && limit > 0 && t.marksCutLines() {
+		last := limit - 1
+		lines[last] = graphemesLine(appendEllipsisGraphemes(lineGraphemes(lines[last]), width, marker))
+	}
+	return lines
+}
+
+// plainGraphemes splits unstyled text into graphemes.
+func plainGraphemes(text string) []styledGrapheme {
+	seg := segmentGraphemes(text)
+	result := make([]styledGrapheme, len(seg.graphemes))
+	for i, g := range seg.graphemes {
+		result[i] = styledGrapheme{text: g, width: seg.widths[i]}
+	}
+	return result
+}
+
+// graphemesText joins grapheme text back into a string.
+func graphemesText(graphemes []styledGrapheme) string {
+	var sb strings.Builder
+	for _, g := range graphemes {
+		sb.WriteString(g.text)
+	}
+	return sb.String()
+}
+
+// lineGraphemes flattens a span line into styled graphemes.
+func lineGraphemes(line lineData) []styledGrapheme {
+	var result []styledGrapheme
+	for _, seg := range line.segments {
+		text := segmentGraphemes(seg.span.Text)
+		for i, g := range text.graphemes {
+			result = append(result, styledGrapheme{text: g, style: seg.span.Style, width: text.widths[i]})
+		}
+	}
+	return result
+}
+
+// graphemesLine rebuilds a span line from styled graphemes.
+func graphemesLine(graphemes []styledGrapheme) lineData {
+	var line lineData
+	x := 0
+	for _, g := range graphemes {
+		appendStyledGrapheme(&line, g, &x)
+	}
+	line.width = x
+	return line
+}
+
+// graphemesWidth returns the total display width of the graphemes.
+func graphemesWidth(graphemes []styledGrapheme) int {
+	total := 0
+	for _, g := range graphemes {
+		total += g.width
+	}
+	return total
+}
+
+// takeGraphemesWidth returns the longest prefix of graphemes fitting in width.
+func takeGraphemesWidth(graphemes []styledGrapheme, width int) []styledGrapheme {
+	used := 0
+	for i, g := range graphemes {
+		if used+g.width > width {
+			return graphemes[:i]
+		}
+		used += g.width
+	}
+	return graphemes
+}
+
+// takeGraphemesWidthFromEnd returns the longest suffix of graphemes fitting in width.
+func takeGraphemesWidthFromEnd(graphemes []styledGrapheme, width int) []styledGrapheme {
+	used := 0
+	for i := len(graphemes) - 1; i >= 0; i-- {
+		if used+graphemes[i].width > width {
+			return graphemes[i+1:]
+		}
+		used += graphemes[i].width
+	}
+	return graphemes
+}
+
+// joinWithMarker concatenates head, marker and tail. The marker takes the
+// style of the grapheme it replaces the text next to.
+func joinWithMarker(head, tail []styledGrapheme, marker string, markerWidth int) []styledGrapheme {
+	var markerStyle SpanStyle
+	if len(head) > 0 {
+		markerStyle = head[len(head)-1].style
+	} else if len(tail) > 0 {
+		markerStyle = tail[0].style
+	}
+	result := make([]styledGrapheme, 0, len(head)+len(tail)+1)
+	result = append(result, head...)
+	result = append(result, styledGrapheme{text: marker, style: markerStyle, width: markerWidth})
+	return append(result, tail...)
+}
+
+// truncateGraphemes shortens graphemes to fit width using the given mode.
+func truncateGraphemes(graphemes []styledGrapheme, width int, mode TruncateMode, marker string) []styledGrapheme {
+	if graphemesWidth(graphemes) <= width {
+		return graphemes
+	}
+	if width <= 0 {
+		return nil
+	}
+	markerWidth := ansi.StringWidth(marker)
+	if markerWidth > width {
+		return takeGraphemesWidth(plainGraphemes(marker), width)
+	}
+	keep := width - markerWidth
+
+	switch mode {
+	case TruncateStart:
+		return joinWithMarker(nil, takeGraphemesWidthFromEnd(graphemes, keep), marker, markerWidth)
+	case TruncateMiddle:
+		head := takeGraphemesWidth(graphemes, (keep+1)/2)
+		tail := takeGraphemesWidthFromEnd(graphemes, keep-graphemesWidth(head))
+		return joinWithMarker(head, tail, marker, markerWidth)
+	case TruncatePath:
+		return truncatePathGraphemes(graphemes, width, marker, markerWidth)
+	default:
+		return joinWithMarker(takeGraphemesWidth(graphemes, keep), nil, marker, markerWidth)
+	}
+}
+
+// truncatePathGraphemes elides whole middle segments of a "/"-separated path,
+// preferring "first/…/rest" and then "…/last", before falling back to TruncateStart.
+func truncatePathGraphemes(graphemes []styledGrapheme, width int, marker string, markerWidth int) []styledGrapheme {
+	var seps []int
+	for i, g := range graphemes {
+		if g.text == "/" {
+			seps = append(seps, i)
+		}
+	}
+
+	// The head runs through the first separator that follows a non-empty
+	// segment ("/usr/" in "/usr/local/bin", "src/" in "src/a/b").
+	headEnd := -1
+	for _, i := range seps {
+		if i > 0 {
+			headEnd = i
+			break
+		}
+	}
+
+	if headEnd >= 0 {
+		head := graphemes[:headEnd+1]
+		headWidth := graphemesWidth(head)
+		for _, j := range seps {
+			if j <= headEnd {
+				continue
+			}
+			tail := graphemes[j:]
+			if headWidth+markerWidth+graphemesWidth(tail) <= width {
+				return joinWithMarker(head, tail, marker, markerWidth)
+			}
+		}
+	}
+
+	if len(seps) > 0 {
+		tail := graphemes[seps[len(seps)-1]:]
+		if markerWidth+graphemesWidth(tail) <= width {
+			return joinWithMarker(nil, tail, marker, markerWidth)
+		}
+	}
+
+	return truncateGraphemes(graphemes, width, TruncateStart, marker)
+}
+
+// appendEllipsisGraphemes marks a line as continued by appending the marker,
+// dropping graphemes from the end if needed to make room.
+func appendEllipsisGraphemes(graphemes []styledGrapheme, width int, marker string) []styledGrapheme {
+	markerWidth := ansi.StringWidth(marker)
+	if markerWidth > width {
+		return takeGraphemesWidth(plainGraphemes(marker), max(0, width))
+	}
+	head := takeGraphemesWidth(graphemes, width-markerWidth)
+	return joinWithMarker(head, nil, marker, markerWidth)
+}
+
 // renderSpans renders rich text with multiple styled spans.
 func (t Text) renderSpans(ctx *RenderContext) {
 	// Start with the full style, then ensure foreground color has a default
@@ -371,8 +692,8 @@ func (t Text) renderSpans(ctx *RenderContext) {
 		drawBaseStyle.BackgroundColor = nil
 	}
 
-	// First pass: collect all spans per line
-	lines := t.collectSpanLines(ctx.Width, ctx.Height)
+	// First pass: collect all spans per line, then apply truncation
+	lines := t.fitSpanLines(ctx.Width, ctx.Height)
 
 	// Second pass: render each line with alignment
 	for y, line := range lines {
@@ -380,12 +701,16 @@ func (t Text) renderSpans(ctx *RenderContext) {
 			break
 		}
 
+		if t.TextAlign == TextAlignJustify && !line.paragraphEnd && line.width < ctx.Width {
+			line = graphemesLine(justifyGraphemes(lineGraphemes(line), ctx.Width))
+		}
+
 		// Calculate alignment offset for this line
 		xOffset := alignLine(line.width, ctx.Width, t.TextAlign)
 
 		// Draw left padding if needed
 		if xOffset > 0 {
-			ctx.DrawStyledText(0, y, strings.Repeat(" ", xOffset), drawBaseStyle)
+			ctx.DrawStyledText(0, y, blanks(xOffset), drawBaseStyle)
 		}
 
 		// Draw all spans in the line
@@ -396,13 +721,13 @@ func (t Text) renderSpans(ctx *RenderContext) {
 		// Draw right padding to fill remaining width
 		rightPadding := ctx.Width - xOffset - line.width
 		if rightPadding > 0 {
-			ctx.DrawStyledText(xOffset+line.width, y, strings.Repeat(" ", rightPadding), drawBaseStyle)
+			ctx.DrawStyledText(xOffset+line.width, y, blanks(rightPadding), drawBaseStyle)
 		}
 	}
 
 	// Fill any remaining lines with empty space
 	for y := len(lines); y < ctx.Height; y++ {
-		ctx.DrawStyledText(0, y, strings.Repeat(" ", ctx.Width), drawBaseStyle)
+		ctx.DrawStyledText(0, y, blanks(ctx.Width), drawBaseStyle)
 	}
 }
 
@@ -411,7 +736,10 @@ func (t Text) collectSpanLines(width, height int) []lineData {
 	if height == 0 {
 		return nil
 	}
-	graphemes := collectSpanGraphemes(t.Spans)
+	buf := graphemePool.get()
+	defer graphemePool.put(buf)
+	*buf = appendSpanGraphemes(*buf, t.Spans)
+	graphemes := *buf
 	if len(graphemes) == 0 {
 		return []lineData{{}}
 	}
@@ -424,8 +752,12 @@ func (t Text) collectSpanLines(width, height int) []lineData {
 	case WrapHard:
 		return collectSpanLinesHard(graphemes, width, height)
 	default:
-		lines := collectSpanLinesSoft(graphemes, width, height)
-		return hardWrapSpanLines(lines, width, height)
+		keepWords := t.WordBreak == WordBreakKeepAll
+		lines := collectSpanLinesSoft(graphemes, width, height, keepWords)
+		if keepWords {
+			return lines
+		}
+		return hardWrapSpanLines(lines, width, height, t.Hyphenate)
 	}
 }
 
@@ -436,6 +768,7 @@ func collectSpanLinesNoWrap(graphemes []styledGrapheme, width, height int) []lin
 
 	flushLine := func() bool {
 		currentLine.width = x
+		currentLine.paragraphEnd = true
 		lines = append(lines, currentLine)
 		currentLine = lineData{}
 		x = 0
@@ -481,6 +814,7 @@ func collectSpanLinesHard(graphemes []styledGrapheme, width, height int) []lineD
 
 	for _, g := range graphemes {
 		if g.text == "\n" {
+			currentLine.paragraphEnd = true
 			if flushLine() {
 				return lines
 			}
@@ -496,6 +830,7 @@ func collectSpanLinesHard(graphemes []styledGrapheme, width, height int) []lineD
 		appendStyledGrapheme(&currentLine, g, &x)
 	}
 
+	currentLine.paragraphEnd = true
 	flushLine()
 	if height > 0 && len(lines) > height {
 		return lines[:height]
@@ -503,7 +838,10 @@ func collectSpanLinesHard(graphemes []styledGrapheme, width, height int) []lineD
 	return lines
 }
 
-func collectSpanLinesSoft(graphemes []styledGrapheme, width, height int) []lineData {
+// collectSpanLinesSoft wraps graphemes at spaces. Words longer than the width
+// are left intact for hardWrapSpanLines to split, unless keepWords is set, in
+// which case they are moved onto their own line and clipped when rendered.
+func collectSpanLinesSoft(graphemes []styledGrapheme, width, height int, keepWords bool) []lineData {
 	if width <= 0 {
 		return collectSpanLinesNoWrap(graphemes, width, height)
 	}
@@ -545,6 +883,7 @@ func collectSpanLinesSoft(graphemes []styledGrapheme, width, height int) []lineD
 
 	for _, g := range graphemes {
 		if g.text == "\n" {
+			currentLine.paragraphEnd = true
 			flushWord()
 			space = nil
 			spaceWidth = 0
@@ -564,7 +903,7 @@ func collectSpanLinesSoft(graphemes []styledGrapheme, width, height int) []lineD
 		word = append(word, g)
 		wordWidth += g.width
 
-		if x+spaceWidth+wordWidth > width && wordWidth < width {
+		if x+spaceWidth+wordWidth > width && (wordWidth < width || keepWords) {
 			if x > 0 {
 				if flushLine() {
 					return lines
@@ -576,6 +915,7 @@ func collectSpanLinesSoft(graphemes []styledGrapheme, width, height int) []lineD
 	}
 
 	flushWord()
+	currentLine.paragraphEnd = true
 	flushLine()
 	if height > 0 && len(lines) > height {
 		return lines[:height]
@@ -583,7 +923,9 @@ func collectSpanLinesSoft(graphemes []styledGrapheme, width, height int) []lineD
 	return lines
 }
 
-func hardWrapSpanLines(lines []lineData, width, height int) []lineData {
+// hardWrapSpanLines splits lines that are still wider than width at grapheme
+// boundaries. With hyphenate, a "-" is appended where a word is split.
+func hardWrapSpanLines(lines []lineData, width, height int, hyphenate bool) []lineData {
 	if width <= 0 {
 		return lines
 	}
@@ -603,26 +945,29 @@ func hardWrapSpanLines(lines []lineData, width, height int) []lineData {
 			continue
 		}
 
+		graphemes := lineGraphemes(line)
+		limit := width
+		if hyphenate && width > 1 {
+			limit = width - 1
+		}
 		var currentLine lineData
 		x := 0
-		for _, seg := range line.segments {
-			for _, g := range splitGraphemes(seg.span.Text) {
-				gWidth := graphemeWidth(g)
-				if x > 0 && x+gWidth > width {
-					currentLine.width = x
-					if appendLine(currentLine) {
-						return wrapped
-					}
-					currentLine = lineData{}
-					x = 0
+		for i, g := range graphemes {
+			if x > 0 && x+g.width > limit && (x+g.width > width || i < len(graphemes)-1) {
+				prev := graphemes[i-1]
+				if hyphenate && width > 1 && prev.text != " " && g.text != " " {
+					appendStyledGrapheme(&currentLine, styledGrapheme{text: "-", style: prev.style, width: 1}, &x)
+				}
+				currentLine.width = x
+				if appendLine(currentLine) {
+					return wrapped
 				}
-				appendStyledGrapheme(&currentLine, styledGrapheme{
-					text:  g,
-					style: seg.span.Style,
-					width: gWidth,
-				}, &x)
+				currentLine = lineData{}
+				x = 0
 			}
+			appendStyledGrapheme(&currentLine, g, &x)
 		}
+		currentLine.paragraphEnd = line.paragraphEnd
 		currentLine.width = x
 		if appendLine(currentLine) {
 			return wrapped
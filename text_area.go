@@ -19,6 +19,7 @@ type TextAreaState struct {
 	WrapMode        Signal[WrapMode]    // WrapNone or WrapSoft/WrapHard
 	SelectionAnchor Signal[int]         // -1 = no selection, else anchor grapheme index
 	ReadOnly        Signal[bool]        // When true, content cannot be edited but cursor can move
+	MaxLength       Signal[int]         // Most graphemes Content may hold (0 = no limit)
 
 	scrollOffsetX int
 	scrollOffsetY int
@@ -27,6 +28,16 @@ type TextAreaState struct {
 	lastFocused   bool
 
 	preferredColumn int
+
+	// segments caches the widths and joined text of Content.
+	segments contentSegments
+
+	// A buffer-backed state edits a window of the buffer's lines loaded
+	// into Content. See NewTextAreaStateFromBuffer.
+	buffer         *TextBuffer
+	windowStart    int    // First buffer line in Content
+	windowLines    int    // Buffer lines in Content
+	windowRevision uint64 // Content's revision when last loaded or written back
 }
 
 // NewTextAreaState creates a new TextAreaState with optional initial text.
@@ -39,29 +50,53 @@ func NewTextAreaState(initial string) *TextAreaState {
 		WrapMode:        NewSignal(WrapSoft),
 		SelectionAnchor: NewSignal(-1),
 		ReadOnly:        NewSignal(false),
+		MaxLength:       NewSignal(0),
 		preferredColumn: -1,
 	}
 }
 
-// GetText returns the content as a string.
+// GetText returns the content as a string. With a buffer-backed state it
+// is the whole buffer, not just the lines loaded into Content.
 func (s *TextAreaState) GetText() string {
+	if s.buffer != nil {
+		s.syncBuffer()
+		return s.buffer.String()
+	}
 	return joinGraphemes(s.Content.Peek())
 }
 
-// SetText replaces the content and clamps the cursor.
+// SetText replaces the content, cut to MaxLength, and clamps the cursor.
 func (s *TextAreaState) SetText(text string) {
-	graphemes := splitGraphemes(text)
+	if s.buffer != nil {
+		s.buffer.Replace(0, s.buffer.Len(), text)
+		s.windowRevision = s.Content.revision()
+		s.loadWindow(s.windowStart, textAreaWindowLines)
+		s.clampCursor()
+		s.resetPreferredColumn()
+		return
+	}
+	graphemes, _ := limitGraphemes(splitGraphemes(text), 0, s.MaxLength.Peek())
 	s.Content.Set(graphemes)
 	s.clampCursor()
 	s.resetPreferredColumn()
 }
 
 // Insert inserts text at the cursor position and advances the cursor.
+// Text beyond MaxLength is dropped.
 func (s *TextAreaState) Insert(text string) {
+	s.insert(text)
+}
+
+// insert inserts text like Insert, and reports whether MaxLength cut it
+// short.
+func (s *TextAreaState) insert(text string) bool {
 	if text == "" {
-		return
+		return false
+	}
+	newGraphemes, cut := limitGraphemes(splitGraphemes(text), len(s.Content.Peek()), s.MaxLength.Peek())
+	if len(newGraphemes) == 0 {
+		return cut
 	}
-	newGraphemes := splitGraphemes(text)
 	s.Content.Update(func(graphemes []string) []string {
 		cursor := s.CursorIndex.Peek()
 		result := make([]string, 0, len(graphemes)+len(newGraphemes))
@@ -76,6 +111,7 @@ func (s *TextAreaState) Insert(text string) {
 	// Clear selection anchor to prevent unwanted selection after typing
 	s.SelectionAnchor.Set(-1)
 	s.updatePreferredColumn()
+	return cut
 }
 
 // InsertNewline inserts a newline at the cursor position.
@@ -322,8 +358,12 @@ func (s *TextAreaState) SetSelectionAnchor(index int) {
 	s.SelectionAnchor.Set(index)
 }
 
-// SelectAll selects all text (anchor=0, cursor=len).
+// SelectAll selects all text (anchor=0, cursor=len). A buffer-backed state
+// loads the whole buffer first.
 func (s *TextAreaState) SelectAll() {
+	if s.buffer != nil {
+		s.loadWindow(0, s.buffer.LineCount())
+	}
 	graphemes := s.Content.Peek()
 	s.SelectionAnchor.Set(0)
 	s.CursorIndex.Set(len(graphemes))
@@ -401,17 +441,24 @@ func (s *TextAreaState) DeleteSelection() bool {
 
 // ReplaceSelection deletes any selected text and inserts the given text.
 func (s *TextAreaState) ReplaceSelection(text string) {
+	s.replaceSelection(text)
+}
+
+// replaceSelection replaces the selection like ReplaceSelection, and
+// reports whether MaxLength cut the text short.
+func (s *TextAreaState) replaceSelection(text string) bool {
 	s.DeleteSelection()
-	s.Insert(text)
+	return s.insert(text)
 }
 
 func (s *TextAreaState) cursorVerticalMove(delta int) {
-	graphemes := s.Content.Peek()
+	s.followCursor()
+	graphemes, widths := s.contentWidths()
 	if len(graphemes) == 0 {
 		return
 	}
 	contentWidth := reservedContentWidth(s.lastWidth)
-	layout := buildTextAreaLayout(graphemes, s.WrapMode.Peek(), contentWidth, s.CursorIndex.Peek())
+	layout := buildTextAreaLayout(graphemes, widths, s.WrapMode.Peek(), contentWidth, s.CursorIndex.Peek())
 	if len(layout.lines) == 0 {
 		return
 	}
@@ -420,15 +467,15 @@ func (s *TextAreaState) cursorVerticalMove(delta int) {
 	if targetCol < 0 {
 		targetCol = layout.cursorCol
 	}
-	newCursor := cursorIndexForLineColumn(layout.lines, graphemes, targetLine, targetCol)
+	newCursor := cursorIndexForLineColumn(layout.lines, widths, targetLine, targetCol)
 	s.CursorIndex.Set(newCursor)
 	s.preferredColumn = targetCol
 }
 
 func (s *TextAreaState) updatePreferredColumn() {
-	graphemes := s.Content.Peek()
+	graphemes, widths := s.contentWidths()
 	contentWidth := reservedContentWidth(s.lastWidth)
-	layout := buildTextAreaLayout(graphemes, s.WrapMode.Peek(), contentWidth, s.CursorIndex.Peek())
+	layout := buildTextAreaLayout(graphemes, widths, s.WrapMode.Peek(), contentWidth, s.CursorIndex.Peek())
 	s.preferredColumn = layout.cursorCol
 }
 
@@ -443,14 +490,20 @@ func (s *TextAreaState) SetCursorFromLocalPosition(localX, localY int, contentWi
 	displayLine := localY + s.scrollOffsetY
 	displayCol := localX + s.scrollOffsetX
 
-	graphemes := s.Content.Peek()
+	graphemes, widths := s.contentWidths()
 	wrapMode := s.WrapMode.Peek()
-	layout := buildTextAreaLayout(graphemes, wrapMode, contentWidth, s.CursorIndex.Peek())
-	newIdx := cursorIndexForLineColumn(layout.lines, graphemes, displayLine, displayCol)
+	layout := buildTextAreaLayout(graphemes, widths, wrapMode, contentWidth, s.CursorIndex.Peek())
+	newIdx := cursorIndexForLineColumn(layout.lines, widths, displayLine, displayCol)
 	s.CursorIndex.Set(newIdx)
 	s.updatePreferredColumn()
 }
 
+// contentWidths returns Content and the display width of each grapheme.
+func (s *TextAreaState) contentWidths() ([]string, []int) {
+	graphemes := s.Content.Peek()
+	return graphemes, s.segments.widthsOf(s.Content, graphemes)
+}
+
 func (s *TextAreaState) clampCursor() {
 	graphemes := s.Content.Peek()
 	cursor := s.CursorIndex.Peek()
@@ -466,8 +519,8 @@ func (s *TextAreaState) clampCursor() {
 // the cursor location.
 func (s *TextAreaState) CursorScreenPosition(widgetX, widgetY int) (screenX, screenY int) {
 	contentWidth := reservedContentWidth(s.lastWidth)
-	graphemes := s.Content.Peek()
-	layout := buildTextAreaLayout(graphemes, s.WrapMode.Peek(), contentWidth, s.CursorIndex.Peek())
+	graphemes, widths := s.contentWidths()
+	layout := buildTextAreaLayout(graphemes, widths, s.WrapMode.Peek(), contentWidth, s.CursorIndex.Peek())
 	return widgetX + layout.cursorCol - s.scrollOffsetX, widgetY + layout.cursorLine - s.scrollOffsetY
 }
 
@@ -484,7 +537,7 @@ type textAreaLayout struct {
 	maxWidth   int
 }
 
-func buildTextAreaLayout(graphemes []string, wrap WrapMode, maxWidth, cursorIdx int) textAreaLayout {
+func buildTextAreaLayout(graphemes []string, widths []int, wrap WrapMode, maxWidth, cursorIdx int) textAreaLayout {
 	if maxWidth <= 0 || wrap == WrapNone {
 		wrap = WrapNone
 	}
@@ -530,7 +583,7 @@ func buildTextAreaLayout(graphemes []string, wrap WrapMode, maxWidth, cursorIdx
 			lastSpaceWidth = lineWidth
 		}
 
-		gWidth := graphemeWidth(g)
+		gWidth := widths[i]
 		if wrap != WrapNone && lineWidth+gWidth > maxWidth && lineWidth > 0 {
 			// For soft wrap, try to break at the last space
 			if wrap == WrapSoft && lastSpaceIdx > lineStart {
@@ -548,7 +601,7 @@ func buildTextAreaLayout(graphemes []string, wrap WrapMode, maxWidth, cursorIdx
 				lineStart = breakAt
 				lineWidth = 0
 				for j := breakAt; j < i; j++ {
-					lineWidth += graphemeWidth(graphemes[j])
+					lineWidth += widths[j]
 				}
 				lineIndex++
 
@@ -557,7 +610,7 @@ func buildTextAreaLayout(graphemes []string, wrap WrapMode, maxWidth, cursorIdx
 					cursorLine = lineIndex
 					cursorCol = 0
 					for j := breakAt; j < cursorIdx; j++ {
-						cursorCol += graphemeWidth(graphemes[j])
+						cursorCol += widths[j]
 					}
 				}
 
@@ -604,7 +657,7 @@ func buildTextAreaLayout(graphemes []string, wrap WrapMode, maxWidth, cursorIdx
 	}
 }
 
-func cursorIndexForLineColumn(lines []textAreaLine, graphemes []string, lineIdx, column int) int {
+func cursorIndexForLineColumn(lines []textAreaLine, widths []int, lineIdx, column int) int {
 	if len(lines) == 0 {
 		return 0
 	}
@@ -619,7 +672,7 @@ func cursorIndexForLineColumn(lines []textAreaLine, graphemes []string, lineIdx,
 	}
 	displayX := 0
 	for i := line.start; i < line.end; i++ {
-		gWidth := graphemeWidth(graphemes[i])
+		gWidth := widths[i]
 		if displayX+gWidth > column {
 			return i
 		}
@@ -646,16 +699,16 @@ func lineBoundsForIndex(graphemes []string, cursorIdx int) (start, end int) {
 	return start, end
 }
 
-func maxLineWidth(graphemes []string) int {
+func maxLineWidth(graphemes []string, widths []int) int {
 	maxWidth := 0
 	current := 0
-	for _, g := range graphemes {
+	for i, g := range graphemes {
 		if g == "\n" {
 			maxWidth = max(maxWidth, current)
 			current = 0
 			continue
 		}
-		current += graphemeWidth(g)
+		current += widths[i]
 	}
 	maxWidth = max(maxWidth, current)
 	return maxWidth
@@ -703,6 +756,8 @@ type TextArea struct {
 	ScrollState       *ScrollState      // Optional state for scroll-into-view
 	OnChange          func(text string) // Callback when text changes
 	OnSubmit          func(text string) // Callback when submit key is pressed
+	OnLimit           func()            // Callback when State.MaxLength cuts typed or pasted text short
+	ShowCount         bool              // Show a "length/MaxLength" counter on a row below the text
 	Click             func(MouseEvent)  // Optional click callback
 	MouseDown         func(MouseEvent)  // Optional mouse down callback
 	MouseUp           func(MouseEvent)  // Optional mouse up callback
@@ -833,11 +888,19 @@ func (t TextArea) exitInsertMode() {
 
 func (t TextArea) insertNewline() {
 	if t.State != nil {
-		t.State.ReplaceSelection("\n")
-		t.notifyChange()
+		t.replaceSelection("\n")
 	}
 }
 
+// replaceSelection types text over the selection, calling OnLimit if
+// MaxLength cut it short.
+func (t TextArea) replaceSelection(text string) {
+	if t.State.replaceSelection(text) && t.OnLimit != nil {
+		t.OnLimit()
+	}
+	t.notifyChange()
+}
+
 func (t TextArea) cursorLeft() {
 	if t.State != nil {
 		t.State.ClearSelection()
@@ -1087,8 +1150,7 @@ func (t TextArea) OnKey(event KeyEvent) bool {
 
 	text := event.Text()
 	if text != "" {
-		t.State.ReplaceSelection(text)
-		t.notifyChange()
+		t.replaceSelection(text)
 		return true
 	}
 	return false
@@ -1188,7 +1250,7 @@ func (t TextArea) Layout(ctx BuildContext, constraints Constraints) Size {
 	default:
 		contentWidth := 1
 		if t.State != nil {
-			contentWidth = maxLineWidth(t.State.Content.Peek())
+			contentWidth = maxLineWidth(t.State.contentWidths())
 		}
 		placeholderWidth := maxLineWidthString(t.Placeholder)
 		width = max(contentWidth, placeholderWidth, 1)
@@ -1210,23 +1272,55 @@ func (t TextArea) Layout(ctx BuildContext, constraints Constraints) Size {
 		if t.State != nil {
 			wrapMode = t.State.WrapMode.Peek()
 			contentWidth := reservedContentWidth(width)
-			layout := buildTextAreaLayout(t.State.Content.Peek(), wrapMode, contentWidth, t.State.CursorIndex.Peek())
+			graphemes, widths := t.State.contentWidths()
+			layout := buildTextAreaLayout(graphemes, widths, wrapMode, contentWidth, t.State.CursorIndex.Peek())
 			contentLines = max(1, len(layout.lines))
 		}
 		placeholderLines := wrapLineCount(t.Placeholder, reservedContentWidth(width), wrapMode)
 		height = max(contentLines, placeholderLines, 1)
+		if t.ShowCount {
+			height++
+		}
 	}
 	height = clampInt(height, constraints.MinHeight, constraints.MaxHeight)
 
 	return Size{Width: width, Height: height}
 }
 
-// Render draws the text area with cursor.
+// baseStyle returns the style text is drawn in: Style with the theme's
+// colors filled in, and muted while the text area is read-only.
+func (t TextArea) baseStyle(theme ThemeData) Style {
+	style := t.Style
+	if style.ForegroundColor == nil || !style.ForegroundColor.IsSet() {
+		style.ForegroundColor = theme.Text
+		if t.State.ReadOnly.Get() {
+			style.ForegroundColor = theme.TextMuted
+		}
+	}
+	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
+		style.BackgroundColor = theme.Surface
+	}
+	return style
+}
+
+// Render draws the text area with cursor, and the length counter on the
+// bottom row if ShowCount is set.
 func (t TextArea) Render(ctx *RenderContext) {
 	if t.State == nil {
 		return
 	}
+	if !t.ShowCount || ctx.Height < 2 {
+		t.renderText(ctx)
+		return
+	}
+	theme := ctx.buildContext.Theme()
+	t.renderText(ctx.SubContext(0, 0, ctx.Width, ctx.Height-1))
+	length, maxLength := len(t.State.Content.Get()), t.State.MaxLength.Get()
+	drawLengthCounter(ctx.SubContext(0, ctx.Height-1, ctx.Width, 1), theme, t.baseStyle(theme), length, maxLength)
+}
 
+// renderText draws the text, or the placeholder, with the cursor.
+func (t TextArea) renderText(ctx *RenderContext) {
 	focused := ctx.IsFocused(t)
 	if t.RequireInsertMode {
 		if focused && !t.State.lastFocused {
@@ -1236,6 +1330,7 @@ func (t TextArea) Render(ctx *RenderContext) {
 	t.State.lastFocused = focused
 	t.State.lastWidth = ctx.Width
 	t.State.lastHeight = ctx.Height
+	t.State.followCursor()
 
 	theme := ctx.buildContext.Theme()
 	graphemes := t.State.Content.Get()
@@ -1243,13 +1338,7 @@ func (t TextArea) Render(ctx *RenderContext) {
 	wrapMode := t.State.WrapMode.Get()
 	contentWidth := reservedContentWidth(ctx.Width)
 
-	baseStyle := t.Style
-	if baseStyle.ForegroundColor == nil || !baseStyle.ForegroundColor.IsSet() {
-		baseStyle.ForegroundColor = theme.Text
-	}
-	if baseStyle.BackgroundColor == nil || !baseStyle.BackgroundColor.IsSet() {
-		baseStyle.BackgroundColor = theme.Surface
-	}
+	baseStyle := t.baseStyle(theme)
 
 	bgColor := baseStyle.BackgroundColor.ColorAt(ctx.Width, ctx.Height, 0, 0)
 	ctx.FillRect(0, 0, ctx.Width, ctx.Height, bgColor)
@@ -1268,21 +1357,22 @@ func (t TextArea) Render(ctx *RenderContext) {
 		return
 	}
 
-	layout := buildTextAreaLayout(graphemes, wrapMode, contentWidth, cursorIdx)
+	widths := t.State.segments.widthsOf(t.State.Content, graphemes)
+	layout := buildTextAreaLayout(graphemes, widths, wrapMode, contentWidth, cursorIdx)
 	t.updateScrollOffsets(layout, contentWidth, ctx.Height)
 	t.scrollCursorIntoViewWithLayout(layout)
 
 	// Build highlight maps
 	var highlightMap map[int]SpanStyle
 	if t.Highlighter != nil && len(graphemes) > 0 {
-		text := joinGraphemes(graphemes)
+		text := t.State.segments.textOf(t.State.Content, graphemes)
 		highlights := t.Highlighter.Highlight(text, graphemes)
 		highlightMap = buildHighlightMap(highlights)
 	}
 	lineHighlightMap := buildLineHighlightMap(t.LineHighlights, len(layout.lines))
 
 	selStart, selEnd := t.State.GetSelectionBounds()
-	t.renderContent(ctx, graphemes, layout, cursorIdx, focused, baseStyle, contentWidth, selStart, selEnd, theme, highlightMap, lineHighlightMap)
+	t.renderContent(ctx, graphemes, widths, layout, cursorIdx, focused, baseStyle, contentWidth, selStart, selEnd, theme, highlightMap, lineHighlightMap)
 }
 
 func (t TextArea) updateScrollOffsets(layout textAreaLayout, contentWidth, viewportHeight int) {
@@ -1312,7 +1402,7 @@ func (t TextArea) updateScrollOffsets(layout textAreaLayout, contentWidth, viewp
 	}
 }
 
-func (t TextArea) renderContent(ctx *RenderContext, graphemes []string, layout textAreaLayout, cursorIdx int, focused bool, baseStyle Style, contentWidth int, selStart, selEnd int, theme ThemeData, highlightMap map[int]SpanStyle, lineHighlightMap map[int]Style) {
+func (t TextArea) renderContent(ctx *RenderContext, graphemes []string, widths []int, layout textAreaLayout, cursorIdx int, focused bool, baseStyle Style, contentWidth int, selStart, selEnd int, theme ThemeData, highlightMap map[int]SpanStyle, lineHighlightMap map[int]Style) {
 	scrollY := t.State.scrollOffsetY
 	scrollX := t.State.scrollOffsetX
 	hasSelection := selStart >= 0
@@ -1336,7 +1426,7 @@ func (t TextArea) renderContent(ctx *RenderContext, graphemes []string, layout t
 		displayX := 0
 		for i := line.start; i < line.end; i++ {
 			grapheme := graphemes[i]
-			gWidth := graphemeWidth(grapheme)
+			gWidth := widths[i]
 
 			if t.State.WrapMode.Peek() == WrapNone {
 				if displayX+gWidth <= scrollX {
@@ -1395,7 +1485,8 @@ func (t TextArea) scrollCursorIntoView() {
 		return
 	}
 	contentWidth := reservedContentWidth(t.State.lastWidth)
-	layout := buildTextAreaLayout(t.State.Content.Peek(), t.State.WrapMode.Peek(), contentWidth, t.State.CursorIndex.Peek())
+	graphemes, widths := t.State.contentWidths()
+	layout := buildTextAreaLayout(graphemes, widths, t.State.WrapMode.Peek(), contentWidth, t.State.CursorIndex.Peek())
 	t.scrollCursorIntoViewWithLayout(layout)
 }
 
@@ -1435,6 +1526,12 @@ func (t TextArea) OnClick(event MouseEvent) {
 	}
 }
 
+// CursorHint suggests the text cursor over the text area.
+// Implements the CursorHintProvider interface.
+func (t TextArea) CursorHint(event MouseEvent) CursorHint {
+	return CursorText
+}
+
 // OnMouseDown is called when the mouse is pressed on the widget.
 // Implements the MouseDownHandler interface.
 func (t TextArea) OnMouseDown(event MouseEvent) {
@@ -1449,8 +1546,8 @@ func (t TextArea) OnMouseDown(event MouseEvent) {
 
 	// Adjust local coordinates for border and padding
 	// LocalX/LocalY are relative to border-box, but content is inside padding/border
-	localX := event.LocalX - t.Style.Border.Width() - t.Style.Padding.Left
-	localY := event.LocalY - t.Style.Border.Width() - t.Style.Padding.Top
+	localX := event.LocalX - t.Style.Border.Insets().Left - t.Style.Padding.Left
+	localY := event.LocalY - t.Style.Border.Insets().Top - t.Style.Padding.Top
 
 	// Shift+click: extend selection from current position
 	if event.Mod.Contains(uv.ModShift) {
@@ -1495,8 +1592,8 @@ func (t TextArea) OnMouseMove(event MouseEvent) {
 	}
 
 	// Adjust local coordinates for border and padding
-	localX := event.LocalX - t.Style.Border.Width() - t.Style.Padding.Left
-	localY := event.LocalY - t.Style.Border.Width() - t.Style.Padding.Top
+	localX := event.LocalX - t.Style.Border.Insets().Left - t.Style.Padding.Left
+	localY := event.LocalY - t.Style.Border.Insets().Top - t.Style.Padding.Top
 
 	// Update cursor position; selection extends from anchor
 	contentWidth := reservedContentWidth(t.State.lastWidth)
@@ -0,0 +1,164 @@
+package terma
+
+import "strings"
+
+// textAreaWindowLines is how many lines of a TextBuffer a buffer-backed
+// TextAreaState loads into Content at a time.
+const textAreaWindowLines = 1000
+
+// NewTextAreaStateFromBuffer creates a TextAreaState that edits buffer, for
+// files too large to keep as one slice of graphemes. Only a window of about
+// a thousand lines around the cursor is loaded into Content, and the window
+// follows the cursor, writing edits back to the buffer as it moves. Lines
+// outside the window are never wrapped, measured or drawn, so opening,
+// scrolling and typing cost the same whatever the size of the file.
+//
+// Content, CursorIndex, SelectionAnchor, MaxLength, a Highlighter and
+// LineHighlights address the loaded window rather than the whole buffer,
+// and a selection can't reach beyond it, except that SelectAll loads
+// everything.
+// GetText and Buffer write pending edits back first; OnChange is passed
+// the whole text after every edit, so leave it nil for large files. Change
+// the buffer only through the state, or replace its text with SetText.
+//
+// Example:
+//
+//	data, err := os.ReadFile("server.log")
+//	if err != nil {
+//	    return err
+//	}
+//	state := terma.NewTextAreaStateFromBuffer(terma.NewTextBuffer(string(data)))
+//	state.GoToLine(state.Buffer().LineCount() - 1)
+func NewTextAreaStateFromBuffer(buffer *TextBuffer) *TextAreaState {
+	s := NewTextAreaState("")
+	s.buffer = buffer
+	s.loadWindow(0, textAreaWindowLines)
+	s.CursorIndex.Set(0)
+	return s
+}
+
+// Buffer returns the buffer a state created by NewTextAreaStateFromBuffer
+// edits, with any pending edits written back, or nil for other states.
+func (s *TextAreaState) Buffer() *TextBuffer {
+	s.syncBuffer()
+	return s.buffer
+}
+
+// GoToLine moves the cursor to the start of a line (0-based) and clears the
+// selection. A buffer-backed state counts lines through the whole buffer
+// and loads the window around the line.
+func (s *TextAreaState) GoToLine(line int) {
+	s.SelectionAnchor.Set(-1)
+	if s.buffer != nil {
+		line = clampInt(line, 0, s.buffer.LineCount()-1)
+		s.loadWindow(line-textAreaWindowLines/2, textAreaWindowLines)
+		line -= s.windowStart
+	}
+	s.CursorIndex.Set(graphemeIndexAt(s.Content.Peek(), line, 0))
+	s.resetPreferredColumn()
+}
+
+// syncBuffer writes edits made to the loaded window back to the buffer.
+func (s *TextAreaState) syncBuffer() {
+	if s.buffer == nil || s.Content.revision() == s.windowRevision {
+		return
+	}
+	start, end := s.windowBounds()
+	text := s.segments.textOf(s.Content, s.Content.Peek())
+	s.buffer.Replace(start, end-start, text)
+	s.windowLines = strings.Count(text, "\n") + 1
+	s.windowRevision = s.Content.revision()
+}
+
+// windowBounds returns the byte range of the buffer loaded into Content.
+func (s *TextAreaState) windowBounds() (start, end int) {
+	return s.buffer.LineStart(s.windowStart), s.buffer.LineEnd(s.windowStart + s.windowLines - 1)
+}
+
+// loadWindow writes back any edits, then loads up to lines buffer lines
+// from first into Content. The cursor and selection are left for the
+// caller to move.
+func (s *TextAreaState) loadWindow(first, lines int) {
+	s.syncBuffer()
+	count := s.buffer.LineCount()
+	s.windowStart = clampInt(first, 0, count-1)
+	s.windowLines = clampInt(lines, 1, count-s.windowStart)
+	start, end := s.windowBounds()
+	s.Content.Set(splitGraphemes(s.buffer.Slice(start, end)))
+	s.windowRevision = s.Content.revision()
+}
+
+// followCursor moves the window when the cursor nears one of its edges, so
+// the lines around the cursor are always loaded, and shrinks a window that
+// SelectAll grew once the selection is gone. The cursor keeps its line,
+// column and row on screen.
+func (s *TextAreaState) followCursor() {
+	if s.buffer == nil {
+		return
+	}
+	graphemes, widths := s.contentWidths()
+	cursor := clampInt(s.CursorIndex.Peek(), 0, len(graphemes))
+	line, col := graphemeLineColumn(graphemes, cursor)
+	// Unsaved edits may have added or removed lines since the window was
+	// loaded; windowLines still counts those it covers in the buffer.
+	lastLine, _ := graphemeLineColumn(graphemes, len(graphemes))
+
+	margin := textAreaWindowLines / 4
+	nearTop := line < margin && s.windowStart > 0
+	nearBottom := line > lastLine-margin && s.windowStart+s.windowLines < s.buffer.LineCount()
+	oversized := lastLine >= 2*textAreaWindowLines && !s.HasSelection()
+	if !nearTop && !nearBottom && !oversized {
+		return
+	}
+
+	contentWidth := reservedContentWidth(s.lastWidth)
+	wrap := s.WrapMode.Peek()
+	row := buildTextAreaLayout(graphemes, widths, wrap, contentWidth, cursor).cursorLine - s.scrollOffsetY
+	anchorLine, anchorCol := -1, 0
+	if anchor := s.SelectionAnchor.Peek(); anchor >= 0 {
+		anchorLine, anchorCol = graphemeLineColumn(graphemes, min(anchor, len(graphemes)))
+	}
+
+	previousStart := s.windowStart
+	s.loadWindow(s.windowStart+line-textAreaWindowLines/2, textAreaWindowLines)
+	shift := s.windowStart - previousStart
+
+	graphemes, widths = s.contentWidths()
+	cursor = graphemeIndexAt(graphemes, line-shift, col)
+	s.CursorIndex.Set(cursor)
+	if anchorLine >= 0 {
+		s.SelectionAnchor.Set(graphemeIndexAt(graphemes, anchorLine-shift, anchorCol))
+	}
+	s.scrollOffsetY = max(0, buildTextAreaLayout(graphemes, widths, wrap, contentWidth, cursor).cursorLine-row)
+}
+
+// graphemeLineColumn returns the line of graphemes index is on and how many
+// graphemes into the line it is.
+func graphemeLineColumn(graphemes []string, index int) (line, col int) {
+	start := 0
+	for i, g := range graphemes[:index] {
+		if g == "\n" {
+			line++
+			start = i + 1
+		}
+	}
+	return line, index - start
+}
+
+// graphemeIndexAt returns the index col graphemes into a line of graphemes,
+// clamping the line to those there are and col to the line's length.
+func graphemeIndexAt(graphemes []string, line, col int) int {
+	index := 0
+	for ; line > 0 && index < len(graphemes); index++ {
+		if graphemes[index] == "\n" {
+			line--
+		}
+	}
+	if line > 0 {
+		index, _ = lineBoundsForIndex(graphemes, len(graphemes))
+	}
+	for ; col > 0 && index < len(graphemes) && graphemes[index] != "\n"; col-- {
+		index++
+	}
+	return index
+}
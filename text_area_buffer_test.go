@@ -0,0 +1,99 @@
+package terma
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func numberedLines(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestTextAreaStateFromBuffer_LoadsOnlyAWindow(t *testing.T) {
+	state := NewTextAreaStateFromBuffer(NewTextBuffer(numberedLines(50_000)))
+
+	assert.Equal(t, textAreaWindowLines-1, strings.Count(joinGraphemes(state.Content.Peek()), "\n"))
+	assert.Equal(t, 0, state.CursorIndex.Peek())
+
+	screen := screenText(TextArea{State: state, Style: Style{Width: Cells(20), Height: Cells(3)}}, 20, 3)
+	assert.Contains(t, screen, "line 0")
+	assert.Contains(t, screen, "line 2")
+}
+
+func TestTextAreaStateFromBuffer_WindowFollowsCursor(t *testing.T) {
+	state := NewTextAreaStateFromBuffer(NewTextBuffer(numberedLines(5_000)))
+	area := TextArea{State: state, Style: Style{Width: Cells(20), Height: Cells(5)}}
+
+	for i := 0; i < 30; i++ {
+		screenText(area, 20, 5)
+		state.CursorDownBy(100)
+	}
+	screen := screenText(area, 20, 5)
+
+	require.Greater(t, state.windowStart, 0)
+	graphemes := state.Content.Peek()
+	assert.LessOrEqual(t, strings.Count(joinGraphemes(graphemes), "\n")+1, textAreaWindowLines)
+	line, _ := graphemeLineColumn(graphemes, state.CursorIndex.Peek())
+	assert.Equal(t, 3000, state.windowStart+line)
+	assert.Contains(t, screen, "line 3000")
+}
+
+func TestTextAreaStateFromBuffer_EditsReachTheBuffer(t *testing.T) {
+	buffer := NewTextBuffer(numberedLines(5_000))
+	state := NewTextAreaStateFromBuffer(buffer)
+	area := TextArea{State: state, Style: Style{Width: Cells(20), Height: Cells(5)}}
+
+	state.GoToLine(4000)
+	state.Insert("edited ")
+	state.GoToLine(10)
+	state.Insert("also ")
+	screenText(area, 20, 5)
+
+	assert.Equal(t, "edited line 4000", state.Buffer().Line(4000))
+	assert.Equal(t, "also line 10", buffer.Line(10))
+	assert.Equal(t, 5_000, buffer.LineCount())
+	assert.True(t, strings.HasSuffix(state.GetText(), "line 4999"))
+}
+
+func TestTextAreaStateFromBuffer_NewlinesShiftLaterLines(t *testing.T) {
+	buffer := NewTextBuffer(numberedLines(3_000))
+	state := NewTextAreaStateFromBuffer(buffer)
+
+	state.GoToLine(1500)
+	state.Insert("a\nb\n")
+	state.GoToLine(2800)
+
+	assert.Equal(t, 3_002, buffer.LineCount())
+	assert.Equal(t, "b", buffer.Line(1501))
+	assert.Equal(t, "line 2798", buffer.Line(2800))
+	graphemes := state.Content.Peek()
+	start, end := lineBoundsForIndex(graphemes, state.CursorIndex.Peek())
+	assert.Equal(t, "line 2798", joinGraphemes(graphemes[start:end]))
+}
+
+func TestTextAreaStateFromBuffer_SelectAllLoadsEverything(t *testing.T) {
+	state := NewTextAreaStateFromBuffer(NewTextBuffer(numberedLines(3_000)))
+
+	state.SelectAll()
+
+	assert.Equal(t, numberedLines(3_000), state.GetSelectedText())
+}
+
+func TestTextAreaStateFromBuffer_SetText(t *testing.T) {
+	state := NewTextAreaStateFromBuffer(NewTextBuffer(numberedLines(3_000)))
+	state.GoToLine(2000)
+	state.Insert("discarded")
+
+	state.SetText("fresh")
+
+	assert.Equal(t, "fresh", state.GetText())
+	assert.Equal(t, []string{"f", "r", "e", "s", "h"}, state.Content.Peek())
+}
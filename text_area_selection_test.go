@@ -415,7 +415,7 @@ func TestSnapshot_TextArea_ReadOnly(t *testing.T) {
 	}
 
 	AssertSnapshot(t, widget, 15, 4,
-		"TextArea in read-only mode with cursor on line 2. Cursor should be visible but editing is disabled.")
+		"TextArea in read-only mode with cursor on line 2. Text is muted; cursor should be visible but editing is disabled.")
 }
 
 // --- Bug Fix Tests ---
@@ -0,0 +1,255 @@
+package terma
+
+import (
+	"sort"
+	"strings"
+)
+
+// textSource says which of a TextBuffer's sources a piece's bytes are in.
+type textSource uint8
+
+const (
+	textSourceOriginal textSource = iota // The text the buffer was created with
+	textSourceAdded                      // Text inserted since, appended in order
+)
+
+// textPiece is a run of bytes from one of a TextBuffer's sources.
+type textPiece struct {
+	source   textSource
+	start    int // Byte offset into the source
+	length   int // Length in bytes
+	newlines int // Newlines within the run
+}
+
+// TextBuffer is a piece table: a text made of runs ("pieces") of the
+// original text and of an append-only buffer of inserted text. Inserting
+// and deleting split and trim pieces instead of copying the text, and the
+// offsets of every newline in both sources are indexed, so finding a line
+// is a binary search rather than a scan. That keeps edits and line lookups
+// fast in files of many megabytes.
+//
+// Offsets are in bytes, and lines are separated by "\n" and counted from
+// 0. A TextBuffer is not safe for concurrent use.
+//
+// Example:
+//
+//	buf := terma.NewTextBuffer("first\nsecond\n")
+//	buf.Insert(buf.LineStart(1), "inserted\n")
+//	buf.Line(1) // "inserted"
+type TextBuffer struct {
+	sources   [2][]byte // Indexed by textSource
+	newlines  [2][]int  // Offsets of the newlines in each source, ascending
+	pieces    []textPiece
+	length    int
+	lineCount int
+}
+
+// NewTextBuffer creates a TextBuffer holding text.
+func NewTextBuffer(text string) *TextBuffer {
+	b := &TextBuffer{lineCount: 1}
+	b.sources[textSourceOriginal] = []byte(text)
+	b.newlines[textSourceOriginal] = appendNewlineOffsets(nil, text, 0)
+	if text != "" {
+		b.pieces = []textPiece{b.piece(textSourceOriginal, 0, len(text))}
+	}
+	b.length = len(text)
+	b.lineCount += len(b.newlines[textSourceOriginal])
+	return b
+}
+
+// appendNewlineOffsets appends the offsets of the newlines in text, plus
+// base, to offsets.
+func appendNewlineOffsets(offsets []int, text string, base int) []int {
+	for i := 0; ; {
+		j := strings.IndexByte(text[i:], '\n')
+		if j < 0 {
+			return offsets
+		}
+		offsets = append(offsets, base+i+j)
+		i += j + 1
+	}
+}
+
+// piece returns the piece covering length bytes of source from start.
+func (b *TextBuffer) piece(source textSource, start, length int) textPiece {
+	return textPiece{source: source, start: start, length: length, newlines: b.countNewlines(source, start, start+length)}
+}
+
+// countNewlines returns how many newlines source has in [start, end).
+func (b *TextBuffer) countNewlines(source textSource, start, end int) int {
+	offsets := b.newlines[source]
+	return sort.SearchInts(offsets, end) - sort.SearchInts(offsets, start)
+}
+
+// Len returns the length of the text in bytes.
+func (b *TextBuffer) Len() int {
+	return b.length
+}
+
+// LineCount returns the number of lines. An empty text has one line, and
+// so does a text ending in "\n" after its last full line.
+func (b *TextBuffer) LineCount() int {
+	return b.lineCount
+}
+
+// LineStart returns the offset of the first byte of a line, clamped to the
+// lines there are.
+func (b *TextBuffer) LineStart(line int) int {
+	line = clampInt(line, 0, b.lineCount-1)
+	if line == 0 {
+		return 0
+	}
+	// The line starts after the line'th newline.
+	remaining, pos := line, 0
+	for _, p := range b.pieces {
+		if remaining <= p.newlines {
+			offsets := b.newlines[p.source]
+			newline := offsets[sort.SearchInts(offsets, p.start)+remaining-1]
+			return pos + newline - p.start + 1
+		}
+		remaining -= p.newlines
+		pos += p.length
+	}
+	return b.length
+}
+
+// LineEnd returns the offset just past the last byte of a line, before its
+// newline.
+func (b *TextBuffer) LineEnd(line int) int {
+	line = clampInt(line, 0, b.lineCount-1)
+	if line == b.lineCount-1 {
+		return b.length
+	}
+	return b.LineStart(line+1) - 1
+}
+
+// LineOf returns the line containing the byte at offset.
+func (b *TextBuffer) LineOf(offset int) int {
+	offset = clampInt(offset, 0, b.length)
+	line, pos := 0, 0
+	for _, p := range b.pieces {
+		if offset < pos+p.length {
+			return line + b.countNewlines(p.source, p.start, p.start+offset-pos)
+		}
+		line += p.newlines
+		pos += p.length
+	}
+	return line
+}
+
+// Line returns a line without its newline.
+func (b *TextBuffer) Line(line int) string {
+	return b.Slice(b.LineStart(line), b.LineEnd(line))
+}
+
+// Slice returns the text between the offsets start and end.
+func (b *TextBuffer) Slice(start, end int) string {
+	start = clampInt(start, 0, b.length)
+	end = clampInt(end, start, b.length)
+	var sb strings.Builder
+	sb.Grow(end - start)
+	pos := 0
+	for _, p := range b.pieces {
+		if pos >= end {
+			break
+		}
+		from, to := max(start, pos), min(end, pos+p.length)
+		if from < to {
+			sb.Write(b.sources[p.source][p.start+from-pos : p.start+to-pos])
+		}
+		pos += p.length
+	}
+	return sb.String()
+}
+
+// String returns the whole text.
+func (b *TextBuffer) String() string {
+	return b.Slice(0, b.length)
+}
+
+// Insert inserts text at offset, clamped to the text.
+func (b *TextBuffer) Insert(offset int, text string) {
+	if text == "" {
+		return
+	}
+	offset = clampInt(offset, 0, b.length)
+	added := b.sources[textSourceAdded]
+	start := len(added)
+	b.sources[textSourceAdded] = append(added, text...)
+	b.newlines[textSourceAdded] = appendNewlineOffsets(b.newlines[textSourceAdded], text, start)
+	inserted := b.piece(textSourceAdded, start, len(text))
+	b.length += len(text)
+	b.lineCount += inserted.newlines
+
+	i, within := b.locate(offset)
+	// Typing appends to the piece the last insert made, so extend it
+	// rather than adding a piece per keystroke.
+	if within == 0 && i > 0 {
+		prev := &b.pieces[i-1]
+		if prev.source == textSourceAdded && prev.start+prev.length == start {
+			prev.length += inserted.length
+			prev.newlines += inserted.newlines
+			return
+		}
+	}
+	if within > 0 {
+		b.split(i, within)
+		i++
+	}
+	b.pieces = append(b.pieces, textPiece{})
+	copy(b.pieces[i+1:], b.pieces[i:])
+	b.pieces[i] = inserted
+}
+
+// Delete removes length bytes from offset, clamped to the text.
+func (b *TextBuffer) Delete(offset, length int) {
+	offset = clampInt(offset, 0, b.length)
+	end := clampInt(offset+length, offset, b.length)
+	if offset == end {
+		return
+	}
+	first, within := b.locate(offset)
+	if within > 0 {
+		b.split(first, within)
+		first++
+	}
+	last, within := b.locate(end)
+	if within > 0 {
+		b.split(last, within)
+		last++
+	}
+	for _, p := range b.pieces[first:last] {
+		b.lineCount -= p.newlines
+	}
+	b.pieces = append(b.pieces[:first], b.pieces[last:]...)
+	b.length -= end - offset
+}
+
+// Replace replaces length bytes from offset with text.
+func (b *TextBuffer) Replace(offset, length int, text string) {
+	b.Delete(offset, length)
+	b.Insert(offset, text)
+}
+
+// locate returns the index of the piece holding offset and how far into it
+// offset is. The offset of the end of the text is one past the last piece.
+func (b *TextBuffer) locate(offset int) (index, within int) {
+	pos := 0
+	for i, p := range b.pieces {
+		if offset < pos+p.length {
+			return i, offset - pos
+		}
+		pos += p.length
+	}
+	return len(b.pieces), 0
+}
+
+// split cuts piece i in two, at within bytes into it.
+func (b *TextBuffer) split(i, within int) {
+	p := b.pieces[i]
+	left := b.piece(p.source, p.start, within)
+	right := textPiece{source: p.source, start: p.start + within, length: p.length - within, newlines: p.newlines - left.newlines}
+	b.pieces = append(b.pieces, textPiece{})
+	copy(b.pieces[i+2:], b.pieces[i+1:])
+	b.pieces[i], b.pieces[i+1] = left, right
+}
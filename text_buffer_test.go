@@ -0,0 +1,90 @@
+package terma
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextBuffer_Lines(t *testing.T) {
+	buf := NewTextBuffer("alpha\nbeta\n\ngamma")
+
+	assert.Equal(t, 4, buf.LineCount())
+	assert.Equal(t, []string{"alpha", "beta", "", "gamma"}, bufferLines(buf))
+	assert.Equal(t, 6, buf.LineStart(1))
+	assert.Equal(t, 10, buf.LineEnd(1))
+	assert.Equal(t, 1, buf.LineOf(8))
+	assert.Equal(t, 3, buf.LineOf(buf.Len()))
+}
+
+func TestTextBuffer_EmptyAndTrailingNewline(t *testing.T) {
+	assert.Equal(t, 1, NewTextBuffer("").LineCount())
+	assert.Equal(t, "", NewTextBuffer("").Line(0))
+
+	buf := NewTextBuffer("one\n")
+	assert.Equal(t, 2, buf.LineCount())
+	assert.Equal(t, []string{"one", ""}, bufferLines(buf))
+}
+
+func TestTextBuffer_InsertAndDeleteAcrossPieces(t *testing.T) {
+	buf := NewTextBuffer("hello world")
+	buf.Insert(5, ",\nbig")
+	buf.Insert(buf.Len(), "!")
+	buf.Delete(3, 4)
+
+	assert.Equal(t, "helbig world!", buf.String())
+	assert.Equal(t, 1, buf.LineCount())
+
+	buf.Replace(0, 3, "a\nb\nc")
+	assert.Equal(t, "a\nb\ncbig world!", buf.String())
+	assert.Equal(t, []string{"a", "b", "cbig world!"}, bufferLines(buf))
+}
+
+func TestTextBuffer_TypingExtendsOnePiece(t *testing.T) {
+	buf := NewTextBuffer("log\n")
+	for _, r := range "typed text" {
+		buf.Insert(buf.Len(), string(r))
+	}
+
+	assert.Equal(t, "log\ntyped text", buf.String())
+	assert.Len(t, buf.pieces, 2)
+}
+
+func TestTextBuffer_MatchesStringEdits(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	inserts := []string{"x", "\n", "ab\ncd", "é", "\n\n", "line\n"}
+	want := "first\nsecond\nthird"
+	buf := NewTextBuffer(want)
+
+	for i := 0; i < 2000; i++ {
+		offset := rng.Intn(len(want) + 1)
+		if rng.Intn(3) == 0 {
+			length := rng.Intn(8)
+			end := min(offset+length, len(want))
+			buf.Delete(offset, length)
+			want = want[:offset] + want[end:]
+		} else {
+			text := inserts[rng.Intn(len(inserts))]
+			buf.Insert(offset, text)
+			want = want[:offset] + text + want[offset:]
+		}
+
+		require.Equal(t, want, buf.String(), "after edit %d", i)
+		require.Equal(t, strings.Count(want, "\n")+1, buf.LineCount(), "after edit %d", i)
+	}
+	assert.Equal(t, strings.Split(want, "\n"), bufferLines(buf))
+	for offset := 0; offset <= len(want); offset++ {
+		require.Equal(t, strings.Count(want[:offset], "\n"), buf.LineOf(offset), "offset %d", offset)
+	}
+}
+
+func bufferLines(buf *TextBuffer) []string {
+	lines := make([]string, buf.LineCount())
+	for i := range lines {
+		lines[i] = buf.Line(i)
+	}
+	return lines
+}
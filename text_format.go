@@ -0,0 +1,216 @@
+package terma
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// DefaultTabWidth is the tab stop interval used by Text when TabWidth is unset.
+const DefaultTabWidth = 4
+
+// WordBreak controls where WrapSoft is allowed to break a line.
+type WordBreak int
+
+const (
+	// WordBreakNormal breaks at spaces and splits words that are longer than
+	// the available width (default).
+	WordBreakNormal WordBreak = iota
+	// WordBreakKeepAll never splits a word, including runs of CJK characters.
+	// Words longer than the available width get their own line and are clipped.
+	WordBreakKeepAll
+)
+
+// usesWordBreakPolicy reports whether wrapping deviates from the default
+// soft-wrap behavior shared with the layout package.
+func (t Text) usesWordBreakPolicy() bool {
+	return t.Wrap == WrapSoft && (t.WordBreak == WordBreakKeepAll || t.Hyphenate)
+}
+
+// wrapPlain wraps plain content paragraph by paragraph, returning the lines
+// and whether each line ends a paragraph (used for justification).
+func (t Text) wrapPlain(content string, width int) (lines []string, paragraphEnds []bool) {
+	for _, paragraph := range strings.Split(content, "\n") {
+		var wrapped []string
+		if t.usesWordBreakPolicy() && width > 0 {
+			wrapped = wrapSoftWithPolicy(paragraph, width, t.WordBreak == WordBreakKeepAll, t.Hyphenate)
+		} else {
+			wrapped = wrapText(paragraph, width, t.Wrap)
+		}
+		for i, line := range wrapped {
+			lines = append(lines, line)
+			paragraphEnds = append(paragraphEnds, i == len(wrapped)-1)
+		}
+	}
+	return lines, paragraphEnds
+}
+
+// measureWrapped measures content using the same wrapping as wrapPlain.
+func (t Text) measureWrapped(content string, maxWidth int) (width, height int) {
+	if content == "" {
+		return 0, 0
+	}
+	lines, _ := t.wrapPlain(content, maxWidth)
+	for _, line := range lines {
+		width = max(width, ansi.StringWidth(line))
+	}
+	height = len(lines)
+	if t.MaxLines > 0 && height > t.MaxLines {
+		height = t.MaxLines
+	}
+	return width, height
+}
+
+// wrapSoftWithPolicy soft-wraps a single line, either keeping long words
+// intact or splitting them (with an optional hyphen).
+func wrapSoftWithPolicy(line string, width int, keepWords, hyphenate bool) []string {
+	if ansi.StringWidth(line) <= width {
+		return []string{line}
+	}
+	var result []string
+	for _, wl := range strings.Split(ansi.Wordwrap(line, width, ""), "\n") {
+		if keepWords || ansi.StringWidth(wl) <= width {
+			result = append(result, wl)
+			continue
+		}
+		split := hardWrapSpanLines([]lineData{graphemesLine(plainGraphemes(wl))}, width, 0, hyphenate)
+		for _, piece := range split {
+			result = append(result, graphemesText(lineGraphemes(piece)))
+		}
+	}
+	return result
+}
+
+// justifyGraphemes widens the gaps between words so the line fills width.
+// Leading indentation is preserved and trailing spaces are dropped.
+func justifyGraphemes(graphemes []styledGrapheme, width int) []styledGrapheme {
+	end := len(graphemes)
+	for end > 0 && graphemes[end-1].text == " " {
+		end--
+	}
+	graphemes = graphemes[:end]
+
+	extra := width - graphemesWidth(graphemes)
+	if extra <= 0 {
+		return graphemes
+	}
+
+	var gaps []int
+	seenWord := false
+	for i, g := range graphemes {
+		if g.text != " " {
+			seenWord = true
+			continue
+		}
+		if seenWord && graphemes[i-1].text != " " {
+			gaps = append(gaps, i)
+		}
+	}
+	if len(gaps) == 0 {
+		return graphemes
+	}
+
+	per, remainder := extra/len(gaps), extra%len(gaps)
+	result := make([]styledGrapheme, 0, len(graphemes)+extra)
+	gap := 0
+	for i, g := range graphemes {
+		if gap < len(gaps) && gaps[gap] == i {
+			n := per
+			if gap < remainder {
+				n++
+			}
+			for range n {
+				result = append(result, styledGrapheme{text: " ", style: g.style, width: 1})
+			}
+			gap++
+		}
+		result = append(result, g)
+	}
+	return result
+}
+
+// withTabsExpanded returns a copy of the text with tab characters replaced
+// by spaces up to the next tab stop. Columns are tracked across spans.
+func (t Text) withTabsExpanded() Text {
+	tabWidth := t.TabWidth
+	if tabWidth <= 0 {
+		tabWidth = DefaultTabWidth
+	}
+
+	if len(t.Spans) > 0 {
+		hasTab := false
+		for _, span := range t.Spans {
+			if strings.Contains(span.Text, "\t") {
+				hasTab = true
+				break
+			}
+		}
+		if !hasTab {
+			return t
+		}
+		spans := make([]Span, len(t.Spans))
+		col := 0
+		for i, span := range t.Spans {
+			spans[i] = span
+			spans[i].Text = expandTabs(span.Text, tabWidth, &col)
+		}
+		t.Spans = spans
+		return t
+	}
+
+	if strings.Contains(t.Content, "\t") {
+		col := 0
+		t.Content = expandTabs(t.Content, tabWidth, &col)
+	}
+	return t
+}
+
+// expandTabs replaces tabs with spaces, continuing from column *col.
+func expandTabs(text string, tabWidth int, col *int) string {
+	var sb strings.Builder
+	for _, g := range splitGraphemes(text) {
+		switch g {
+		case "\t":
+			n := tabWidth - *col%tabWidth
+			sb.WriteString(strings.Repeat(" ", n))
+			*col += n
+		case "\n":
+			sb.WriteString(g)
+			*col = 0
+		default:
+			sb.WriteString(g)
+			*col += graphemeWidth(g)
+		}
+	}
+	return sb.String()
+}
+
+var superscriptRunes = map[rune]rune{
+	'0': '⁰', '1': '¹', '2': '²', '3': '³', '4': '⁴', '5': '⁵', '6': '⁶', '7': '⁷', '8': '⁸', '9': '⁹',
+	'+': '⁺', '-': '⁻', '=': '⁼', '(': '⁽', ')': '⁾',
+	'a': 'ᵃ', 'b': 'ᵇ', 'c': 'ᶜ', 'd': 'ᵈ', 'e': 'ᵉ', 'f': 'ᶠ', 'g': 'ᵍ', 'h': 'ʰ', 'i': 'ⁱ',
+	'j': 'ʲ', 'k': 'ᵏ', 'l': 'ˡ', 'm': 'ᵐ', 'n': 'ⁿ', 'o': 'ᵒ', 'p': 'ᵖ', 'r': 'ʳ', 's': 'ˢ',
+	't': 'ᵗ', 'u': 'ᵘ', 'v': 'ᵛ', 'w': 'ʷ', 'x': 'ˣ', 'y': 'ʸ', 'z': 'ᶻ',
+}
+
+var subscriptRunes = map[rune]rune{
+	'0': '₀', '1': '₁', '2': '₂', '3': '₃', '4': '₄', '5': '₅', '6': '₆', '7': '₇', '8': '₈', '9': '₉',
+	'+': '₊', '-': '₋', '=': '₌', '(': '₍', ')': '₎',
+	'a': 'ₐ', 'e': 'ₑ', 'h': 'ₕ', 'i': 'ᵢ', 'j': 'ⱼ', 'k': 'ₖ', 'l': 'ₗ', 'm': 'ₘ', 'n': 'ₙ',
+	'o': 'ₒ', 'p': 'ₚ', 'r': 'ᵣ', 's': 'ₛ', 't': 'ₜ', 'u': 'ᵤ', 'v': 'ᵥ', 'x': 'ₓ',
+}
+
+// shiftBaseline maps text to superscript or subscript characters.
+// Characters without an equivalent are returned unchanged.
+func shiftBaseline(text string, align SpanVerticalAlign) string {
+	table := superscriptRunes
+	if align == SpanAlignSubscript {
+		table = subscriptRunes
+	}
+	return strings.Map(func(r rune) rune {
+		if mapped, ok := table[r]; ok {
+			return mapped
+		}
+		return r
+	}, text)
+}
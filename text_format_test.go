@@ -0,0 +1,138 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestText_JustifyPlain(t *testing.T) {
+	widget := Text{
+		Content:   "aa bb cc dd ee\nlast line",
+		Wrap:      WrapSoft,
+		TextAlign: TextAlignJustify,
+		Style:     Style{Width: Cells(10)},
+	}
+	buf := RenderToBuffer(widget, 10, 3)
+	assert.Equal(t, "aa  bb  cc", bufferLine(buf, 0, 10))
+	assert.Equal(t, "dd ee     ", bufferLine(buf, 1, 10), "paragraph ends are not justified")
+	assert.Equal(t, "last line ", bufferLine(buf, 2, 10))
+}
+
+func TestText_JustifySpans(t *testing.T) {
+	widget := Text{
+		Spans:     []Span{BoldSpan("aa bb "), PlainSpan("cc dd ee")},
+		Wrap:      WrapSoft,
+		TextAlign: TextAlignJustify,
+		Style:     Style{Width: Cells(10)},
+	}
+	buf := RenderToBuffer(widget, 10, 2)
+	assert.Equal(t, "aa  bb  cc", bufferLine(buf, 0, 10))
+	assert.Equal(t, "dd ee     ", bufferLine(buf, 1, 10))
+}
+
+func TestJustifyGraphemes_DistributesLeftmostFirst(t *testing.T) {
+	got := graphemesText(justifyGraphemes(plainGraphemes("a b c d"), 10))
+	assert.Equal(t, "a  b  c  d", got)
+
+	got = graphemesText(justifyGraphemes(plainGraphemes("a b c"), 8))
+	assert.Equal(t, "a   b  c", got)
+
+	got = graphemesText(justifyGraphemes(plainGraphemes("single"), 10))
+	assert.Equal(t, "single", got, "a single word cannot be justified")
+}
+
+func TestText_WordBreakKeepAll(t *testing.T) {
+	widget := Text{
+		Content:   "ab abcdefgh cd",
+		Wrap:      WrapSoft,
+		WordBreak: WordBreakKeepAll,
+		Style:     Style{Width: Cells(5)},
+	}
+	buf, _, height := RenderToBufferWithSize(widget, 5, 4)
+	assert.Equal(t, 3, height)
+	assert.Equal(t, "ab   ", bufferLine(buf, 0, 5))
+	assert.Equal(t, "abcde", bufferLine(buf, 1, 5))
+	assert.Equal(t, "cd   ", bufferLine(buf, 2, 5))
+
+	rich := widget
+	rich.Content = ""
+	rich.Spans = []Span{PlainSpan("ab "), BoldSpan("abcdefgh"), PlainSpan(" cd")}
+	buf = RenderToBuffer(rich, 5, 4)
+	assert.Equal(t, "abcde", bufferLine(buf, 1, 5))
+	assert.Equal(t, "cd   ", bufferLine(buf, 2, 5))
+}
+
+func TestText_Hyphenate(t *testing.T) {
+	widget := Text{
+		Content:   "abcdefgh",
+		Wrap:      WrapSoft,
+		Hyphenate: true,
+		Style:     Style{Width: Cells(5)},
+	}
+	buf, _, height := RenderToBufferWithSize(widget, 5, 3)
+	assert.Equal(t, 2, height)
+	assert.Equal(t, "abcd-", bufferLine(buf, 0, 5))
+	assert.Equal(t, "efgh ", bufferLine(buf, 1, 5))
+
+	rich := Text{
+		Spans:     []Span{BoldSpan("abcdefgh")},
+		Wrap:      WrapSoft,
+		Hyphenate: true,
+		Style:     Style{Width: Cells(5)},
+	}
+	buf = RenderToBuffer(rich, 5, 3)
+	assert.Equal(t, "abcd-", bufferLine(buf, 0, 5))
+	assert.Equal(t, "efgh ", bufferLine(buf, 1, 5))
+}
+
+func TestText_TabWidth(t *testing.T) {
+	buf := RenderToBuffer(Text{Content: "a\tb\nab\tc"}, 10, 2)
+	assert.Equal(t, "a   b     ", bufferLine(buf, 0, 10))
+	assert.Equal(t, "ab  c     ", bufferLine(buf, 1, 10))
+
+	buf = RenderToBuffer(Text{Content: "a\tb", TabWidth: 8}, 10, 1)
+	assert.Equal(t, "a       b ", bufferLine(buf, 0, 10))
+
+	rich := Text{Spans: []Span{PlainSpan("ab"), BoldSpan("\tc")}, TabWidth: 4}
+	buf = RenderToBuffer(rich, 10, 1)
+	assert.Equal(t, "ab  c     ", bufferLine(buf, 0, 10), "tab stops continue across spans")
+}
+
+func TestText_SpanVerticalAlign(t *testing.T) {
+	widget := Text{Spans: []Span{
+		PlainSpan("x"),
+		{Text: "2", Style: SpanStyle{VerticalAlign: SpanAlignSuperscript}},
+		PlainSpan(" H"),
+		{Text: "2", Style: SpanStyle{VerticalAlign: SpanAlignSubscript}},
+		PlainSpan("O"),
+	}}
+	buf := RenderToBuffer(widget, 8, 1)
+	assert.Equal(t, "x² H₂O  ", bufferLine(buf, 0, 8))
+}
+
+func TestShiftBaseline_LeavesUnmappedCharacters(t *testing.T) {
+	assert.Equal(t, "ⁿ⁺¹Q", shiftBaseline("n+1Q", SpanAlignSuperscript))
+	assert.Equal(t, "ᵢ₋₁Q", shiftBaseline("i-1Q", SpanAlignSubscript))
+}
+
+func TestText_LinkSpansCarryHyperlink(t *testing.T) {
+	widget := Text{Spans: []Span{PlainSpan("go "), {Text: "here", Style: SpanStyle{Link: "https://example.com"}}}}
+	buf := RenderToBuffer(widget, 10, 1)
+	assert.Equal(t, "", buf.CellAt(0, 0).Link.URL)
+	assert.Equal(t, "https://example.com", buf.CellAt(3, 0).Link.URL)
+}
+
+func TestText_LinkClick(t *testing.T) {
+	var clicked string
+	plainClicks := 0
+	widget := Text{
+		LinkClick: func(url string) { clicked = url },
+		Click:     func(MouseEvent) { plainClicks++ },
+	}
+	widget.OnClick(MouseEvent{})
+	assert.Equal(t, "", clicked)
+	widget.OnClick(MouseEvent{Link: "#intro"})
+	assert.Equal(t, "#intro", clicked)
+	assert.Equal(t, 2, plainClicks)
+}
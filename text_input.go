@@ -2,6 +2,7 @@ package terma
 
 import (
 	"strings"
+	"time"
 	"unicode"
 
 	uv "github.com/charmbracelet/ultraviolet"
@@ -60,10 +61,27 @@ type TextInputState struct {
 	CursorIndex     Signal[int]         // Grapheme index (0 = before first char)
 	SelectionAnchor Signal[int]         // -1 = no selection, else anchor grapheme index
 	ReadOnly        Signal[bool]        // When true, content cannot be edited but cursor can move
+	MaxLength       Signal[int]         // Most graphemes Content may hold (0 = no limit)
+
+	// History, when set, records submitted text for recall with up and
+	// down, and for searching with ctrl+r.
+	History *InputHistory
 
 	// scrollOffset is calculated during render to keep cursor visible.
 	// Not a signal because it's derived state, not source of truth.
 	scrollOffset int
+
+	// changeDebounce delays OnChangeDebounced until typing pauses.
+	changeDebounce debouncer[string]
+
+	// segments caches the widths and joined text of Content.
+	segments contentSegments
+
+	// recall tracks up/down steps through History.
+	recall historyRecall
+
+	// search is the open ctrl+r search through History, or nil.
+	search AnySignal[*historySearch]
 }
 
 // NewTextInputState creates a new TextInputState with optional initial text.
@@ -74,6 +92,8 @@ func NewTextInputState(initial string) *TextInputState {
 		CursorIndex:     NewSignal(len(graphemes)), // Cursor at end
 		SelectionAnchor: NewSignal(-1),
 		ReadOnly:        NewSignal(false),
+		MaxLength:       NewSignal(0),
+		search:          NewAnySignal[*historySearch](nil),
 	}
 }
 
@@ -82,19 +102,31 @@ func (s *TextInputState) GetText() string {
 	return joinGraphemes(s.Content.Peek())
 }
 
-// SetText replaces the content and clamps the cursor.
+// SetText replaces the content, cut to MaxLength, and clamps the cursor.
+// A pending OnChangeDebounced call for earlier typing is discarded.
 func (s *TextInputState) SetText(text string) {
-	graphemes := splitGraphemes(text)
+	s.changeDebounce.cancel()
+	graphemes, _ := limitGraphemes(splitGraphemes(text), 0, s.MaxLength.Peek())
 	s.Content.Set(graphemes)
 	s.clampCursor()
 }
 
 // Insert inserts text at the cursor position and advances the cursor.
+// Text beyond MaxLength is dropped.
 func (s *TextInputState) Insert(text string) {
+	s.insert(text)
+}
+
+// insert inserts text like Insert, and reports whether MaxLength cut it
+// short.
+func (s *TextInputState) insert(text string) bool {
 	if text == "" {
-		return
+		return false
+	}
+	newGraphemes, cut := limitGraphemes(splitGraphemes(text), len(s.Content.Peek()), s.MaxLength.Peek())
+	if len(newGraphemes) == 0 {
+		return cut
 	}
-	newGraphemes := splitGraphemes(text)
 	s.Content.Update(func(graphemes []string) []string {
 		cursor := s.CursorIndex.Peek()
 		// Insert new graphemes at cursor position
@@ -109,6 +141,7 @@ func (s *TextInputState) Insert(text string) {
 	})
 	// Clear selection anchor to prevent unwanted selection after typing
 	s.SelectionAnchor.Set(-1)
+	return cut
 }
 
 // DeleteBackward deletes the grapheme before the cursor.
@@ -259,20 +292,20 @@ func (s *TextInputState) CursorWordRight() {
 // cursorDisplayX returns the cursor position in display cells.
 func (s *TextInputState) cursorDisplayX() int {
 	graphemes := s.Content.Peek()
+	widths := s.segments.widthsOf(s.Content, graphemes)
 	cursor := s.CursorIndex.Peek()
 	x := 0
 	for i := 0; i < cursor && i < len(graphemes); i++ {
-		x += graphemeWidth(graphemes[i])
+		x += widths[i]
 	}
 	return x
 }
 
 // contentWidth returns the total display width of the content.
 func (s *TextInputState) contentWidth() int {
-	graphemes := s.Content.Peek()
 	width := 0
-	for _, g := range graphemes {
-		width += graphemeWidth(g)
+	for _, w := range s.segments.widthsOf(s.Content, s.Content.Peek()) {
+		width += w
 	}
 	return width
 }
@@ -409,8 +442,14 @@ func (s *TextInputState) DeleteSelection() bool {
 
 // ReplaceSelection deletes any selected text and inserts the given text.
 func (s *TextInputState) ReplaceSelection(text string) {
+	s.replaceSelection(text)
+}
+
+// replaceSelection replaces the selection like ReplaceSelection, and
+// reports whether MaxLength cut the text short.
+func (s *TextInputState) replaceSelection(text string) bool {
 	s.DeleteSelection()
-	s.Insert(text)
+	return s.insert(text)
 }
 
 // SetCursorFromLocalPosition moves the cursor to the given local X position.
@@ -428,8 +467,7 @@ func (s *TextInputState) SetCursorFromLocalPosition(localX int) {
 	}
 
 	x := 0
-	for i, grapheme := range graphemes {
-		gWidth := graphemeWidth(grapheme)
+	for i, gWidth := range s.segments.widthsOf(s.Content, graphemes) {
 		// Click in first half of grapheme -> position before it
 		if displayX < x+gWidth/2+1 {
 			s.CursorIndex.Set(i)
@@ -443,26 +481,33 @@ func (s *TextInputState) SetCursorFromLocalPosition(localX int) {
 
 // --- TextInput Widget ---
 
+// defaultTextInputDebounce is the pause before OnChangeDebounced fires.
+const defaultTextInputDebounce = 200 * time.Millisecond
+
 // TextInput is a single-line focusable text entry widget.
 // Content height is always 1 cell (single line). Use Style.Padding to add
 // visual space around the text - the framework automatically accounts for padding.
 type TextInput struct {
-	ID            string            // Optional unique identifier
-	DisableFocus  bool              // If true, prevent keyboard focus
-	State         *TextInputState   // Required - holds text and cursor position
-	Placeholder   string            // Text shown when empty and unfocused
-	Highlighter   Highlighter       // Optional: dynamic text highlighting
-	Width         Dimension         // Deprecated: use Style.Width
-	Height        Dimension         // Deprecated: use Style.Height (ignored; content height is always 1)
-	Style         Style             // Optional styling (padding adds to outer size automatically)
-	OnChange      func(text string) // Callback when text changes
-	OnSubmit      func(text string) // Callback when Enter pressed
-	Click         func(MouseEvent)  // Optional click callback
-	MouseDown     func(MouseEvent)  // Optional mouse down callback
-	MouseUp       func(MouseEvent)  // Optional mouse up callback
-	Hover         func(HoverEvent)  // Optional hover callback
-	Blur          func()            // Optional blur callback
-	ExtraKeybinds []Keybind         // Optional additional keybinds (checked before defaults)
+	ID                string            // Optional unique identifier
+	DisableFocus      bool              // If true, prevent keyboard focus
+	State             *TextInputState   // Required - holds text and cursor position
+	Placeholder       string            // Text shown when empty and unfocused
+	Highlighter       Highlighter       // Optional: dynamic text highlighting
+	Width             Dimension         // Deprecated: use Style.Width
+	Height            Dimension         // Deprecated: use Style.Height (ignored; content height is always 1)
+	Style             Style             // Optional styling (padding adds to outer size automatically)
+	OnChange          func(text string) // Callback when text changes
+	OnSubmit          func(text string) // Callback when Enter pressed
+	OnLimit           func()            // Callback when State.MaxLength cuts typed or pasted text short
+	ShowCount         bool              // Show a "length/MaxLength" counter at the right
+	OnChangeDebounced func(text string) // Callback with the latest text once typing pauses (runs off the UI goroutine)
+	DebounceDelay     time.Duration     // Pause before OnChangeDebounced (default = 200ms)
+	Click             func(MouseEvent)  // Optional click callback
+	MouseDown         func(MouseEvent)  // Optional mouse down callback
+	MouseUp           func(MouseEvent)  // Optional mouse up callback
+	Hover             func(HoverEvent)  // Optional hover callback
+	Blur              func()            // Optional blur callback
+	ExtraKeybinds     []Keybind         // Optional additional keybinds (checked before defaults)
 }
 
 // WidgetID returns the text input's unique identifier.
@@ -502,6 +547,10 @@ func (t TextInput) CapturesKey(key string) bool {
 // Keybinds returns the declarative keybindings for this text input.
 // ExtraKeybinds are checked first, allowing custom behavior to override defaults.
 func (t TextInput) Keybinds() []Keybind {
+	if t.canEdit() && t.State.search.Peek() != nil {
+		return t.historySearchKeybinds()
+	}
+
 	keybinds := []Keybind{
 		// Cursor movement (clears selection) - always allowed
 		{Key: "left", Action: t.cursorLeft, Hidden: true},
@@ -536,6 +585,15 @@ func (t TextInput) Keybinds() []Keybind {
 			Keybind{Key: "ctrl+w", Action: t.deleteWordBackward, Hidden: true},
 			Keybind{Key: "alt+backspace", Action: t.deleteWordBackward, Hidden: true},
 		)
+		// History recall takes up and down only when there is a history,
+		// so otherwise they still reach ancestors
+		if t.State.History != nil {
+			keybinds = append(keybinds,
+				Keybind{Key: "up", Action: t.historyPrevious, Hidden: true},
+				Keybind{Key: "down", Action: t.historyNext, Hidden: true},
+				Keybind{Key: "ctrl+r", Name: "Search history", Action: t.openHistorySearch, Hidden: true},
+			)
+		}
 	}
 
 	// Prepend extra keybinds so they're checked first
@@ -556,6 +614,13 @@ func (t TextInput) canEdit() bool {
 // Keybind action methods
 
 func (t TextInput) submit() {
+	if t.State != nil {
+		t.State.changeDebounce.flush()
+		if t.State.History != nil {
+			t.State.History.Add(t.State.GetText())
+			t.State.recall = historyRecall{}
+		}
+	}
 	if t.OnSubmit != nil && t.State != nil {
 		t.OnSubmit(t.State.GetText())
 	}
@@ -654,6 +719,18 @@ func (t TextInput) selectWordRight() {
 	}
 }
 
+func (t TextInput) historyPrevious() {
+	if t.State != nil && t.State.HistoryPrevious() {
+		t.notifyChange()
+	}
+}
+
+func (t TextInput) historyNext() {
+	if t.State != nil && t.State.HistoryNext() {
+		t.notifyChange()
+	}
+}
+
 func (t TextInput) selectAll() {
 	if t.State != nil {
 		t.State.SelectAll()
@@ -706,9 +783,19 @@ func (t TextInput) deleteWordBackward() {
 }
 
 func (t TextInput) notifyChange() {
-	if t.OnChange != nil && t.State != nil {
+	if t.State == nil {
+		return
+	}
+	if t.OnChange != nil {
 		t.OnChange(t.State.GetText())
 	}
+	if t.OnChangeDebounced != nil {
+		delay := t.DebounceDelay
+		if delay <= 0 {
+			delay = defaultTextInputDebounce
+		}
+		t.State.changeDebounce.call(delay, t.State.GetText(), t.OnChangeDebounced)
+	}
 }
 
 // OnKey handles printable character input not covered by Keybinds().
@@ -720,17 +807,31 @@ func (t TextInput) OnKey(event KeyEvent) bool {
 	// Use Text() to get the actual typed characters (including space as " ")
 	// Text() returns empty string for non-text keys like arrows, function keys, etc.
 	text := event.Text()
+	if text != "" && t.State.search.Peek() != nil {
+		t.setSearchQuery(t.State.search.Peek().query + text)
+		return true
+	}
 	if text != "" {
-		t.State.ReplaceSelection(text)
-		t.notifyChange()
+		t.replaceSelection(text)
 		return true
 	}
 
 	return false
 }
 
+// replaceSelection types text over the selection, calling OnLimit if
+// MaxLength cut it short.
+func (t TextInput) replaceSelection(text string) {
+	if t.State.replaceSelection(text) && t.OnLimit != nil {
+		t.OnLimit()
+	}
+	t.notifyChange()
+}
+
 // Build returns self since TextInput is a leaf widget with custom rendering.
+// An open history search registers its popup.
 func (t TextInput) Build(ctx BuildContext) Widget {
+	t.buildHistorySearch(ctx)
 	return t
 }
 
@@ -820,7 +921,7 @@ func (t TextInput) Layout(ctx BuildContext, constraints Constraints) Size {
 			contentWidth = t.State.contentWidth()
 		}
 		placeholderWidth := ansi.StringWidth(t.Placeholder)
-		width = max(contentWidth, placeholderWidth)
+		width = max(contentWidth, placeholderWidth) + t.counterWidth()
 	}
 
 	// Clamp to constraints
@@ -829,12 +930,55 @@ func (t TextInput) Layout(ctx BuildContext, constraints Constraints) Size {
 	return Size{Width: width, Height: height}
 }
 
-// Render draws the text input with cursor.
+// counterWidth returns the width the length counter takes, with the space
+// before it, or 0 without ShowCount. With a MaxLength it is sized for a
+// full input so the width doesn't change while typing.
+func (t TextInput) counterWidth() int {
+	if !t.ShowCount || t.State == nil {
+		return 0
+	}
+	length, maxLength := len(t.State.Content.Peek()), t.State.MaxLength.Peek()
+	if maxLength > 0 {
+		length = maxLength
+	}
+	return len(lengthCounter(length, maxLength)) + 1
+}
+
+// baseStyle returns the style text is drawn in: Style with the theme's
+// colors filled in, and muted while the input is read-only.
+func (t TextInput) baseStyle(theme ThemeData) Style {
+	style := t.Style
+	if style.ForegroundColor == nil || !style.ForegroundColor.IsSet() {
+		style.ForegroundColor = theme.Text
+		if t.State.ReadOnly.Get() {
+			style.ForegroundColor = theme.TextMuted
+		}
+	}
+	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
+		style.BackgroundColor = theme.Surface
+	}
+	return style
+}
+
+// Render draws the text input with cursor, and the length counter at the
+// right if ShowCount is set.
 func (t TextInput) Render(ctx *RenderContext) {
 	if t.State == nil {
 		return
 	}
+	if !t.ShowCount {
+		t.renderText(ctx)
+		return
+	}
+	theme := ctx.buildContext.Theme()
+	length, maxLength := len(t.State.Content.Get()), t.State.MaxLength.Get()
+	counterWidth := min(len(lengthCounter(length, maxLength))+1, ctx.Width)
+	t.renderText(ctx.SubContext(0, 0, ctx.Width-counterWidth, ctx.Height))
+	drawLengthCounter(ctx.SubContext(ctx.Width-counterWidth, 0, counterWidth, 1), theme, t.baseStyle(theme), length, maxLength)
+}
 
+// renderText draws the text, or the placeholder, with the cursor.
+func (t TextInput) renderText(ctx *RenderContext) {
 	focused := ctx.IsFocused(t)
 	theme := ctx.buildContext.Theme()
 
@@ -846,13 +990,7 @@ func (t TextInput) Render(ctx *RenderContext) {
 	viewportWidth := ctx.Width
 
 	// Determine base style
-	baseStyle := t.Style
-	if baseStyle.ForegroundColor == nil || !baseStyle.ForegroundColor.IsSet() {
-		baseStyle.ForegroundColor = theme.Text
-	}
-	if baseStyle.BackgroundColor == nil || !baseStyle.BackgroundColor.IsSet() {
-		baseStyle.BackgroundColor = theme.Surface
-	}
+	baseStyle := t.baseStyle(theme)
 
 	// Fill background - sample from ColorProvider
 	bgColor := baseStyle.BackgroundColor.ColorAt(viewportWidth, 1, 0, 0)
@@ -918,15 +1056,16 @@ func (t TextInput) renderContent(ctx *RenderContext, graphemes []string, cursorI
 	// Build highlight map from grapheme index -> SpanStyle
 	var highlightMap map[int]SpanStyle
 	if t.Highlighter != nil && len(graphemes) > 0 {
-		text := joinGraphemes(graphemes)
+		text := t.State.segments.textOf(t.State.Content, graphemes)
 		highlights := t.Highlighter.Highlight(text, graphemes)
 		highlightMap = buildHighlightMap(highlights)
 	}
+	widths := t.State.segments.widthsOf(t.State.Content, graphemes)
 	displayX := 0 // Position in content (display cells)
 	hasSelection := selStart >= 0
 
 	for i, grapheme := range graphemes {
-		gWidth := graphemeWidth(grapheme)
+		gWidth := widths[i]
 
 		// Skip graphemes before scroll offset
 		if displayX+gWidth <= scrollOffset {
@@ -993,6 +1132,12 @@ func (t TextInput) OnClick(event MouseEvent) {
 	}
 }
 
+// CursorHint suggests the text cursor over the input.
+// Implements the CursorHintProvider interface.
+func (t TextInput) CursorHint(event MouseEvent) CursorHint {
+	return CursorText
+}
+
 // OnMouseDown is called when the mouse is pressed on the widget.
 // Implements the MouseDownHandler interface.
 func (t TextInput) OnMouseDown(event MouseEvent) {
@@ -1005,7 +1150,7 @@ func (t TextInput) OnMouseDown(event MouseEvent) {
 
 	// Adjust local X coordinate for border and padding
 	// LocalX is relative to border-box, but content is inside padding/border
-	localX := event.LocalX - t.Style.Border.Width() - t.Style.Padding.Left
+	localX := event.LocalX - t.Style.Border.Insets().Left - t.Style.Padding.Left
 
 	// Shift+click: extend selection from current position
 	if event.Mod.Contains(uv.ModShift) {
@@ -1047,7 +1192,7 @@ func (t TextInput) OnMouseMove(event MouseEvent) {
 	}
 
 	// Adjust local X coordinate for border and padding
-	localX := event.LocalX - t.Style.Border.Width() - t.Style.Padding.Left
+	localX := event.LocalX - t.Style.Border.Insets().Left - t.Style.Padding.Left
 
 	// Update cursor position; selection extends from anchor
 	t.State.SetCursorFromLocalPosition(localX)
@@ -1070,6 +1215,7 @@ func (t TextInput) OnHover(event HoverEvent) {
 
 // OnBlur is called when the widget loses focus.
 func (t TextInput) OnBlur() {
+	t.closeHistorySearch()
 	if t.Blur != nil {
 		t.Blur()
 	}
@@ -0,0 +1,323 @@
+package terma
+
+import "strings"
+
+// defaultInputHistoryLimit is how many entries an InputHistory keeps unless
+// Limit says otherwise.
+const defaultInputHistoryLimit = 500
+
+// InputHistory records the text submitted from a TextInput, for shell-style
+// recall: up and down step through earlier entries, and ctrl+r searches
+// them. Set it as TextInputState.History; inputs may share one.
+//
+// Example:
+//
+//	history := &t.InputHistory{
+//	    Store:    t.JSONFileStore{Path: filepath.Join(configDir, "history.json")},
+//	    StoreKey: "repl",
+//	}
+//	state := t.NewTextInputState("")
+//	state.History = history
+type InputHistory struct {
+	Limit    int           // Most entries kept, dropping the oldest (0 = 500)
+	Store    SettingsStore // Optional: persists entries between runs
+	StoreKey string        // Key the entries are stored under in Store
+
+	entries []string // Oldest first, loaded from Store on first use
+	loaded  bool
+}
+
+// Add records a submitted entry as the newest, persisting the history to
+// Store when set. Blank entries and repeats of the newest entry are skipped.
+func (h *InputHistory) Add(entry string) {
+	entries := h.load()
+	if strings.TrimSpace(entry) == "" || (len(entries) > 0 && entries[len(entries)-1] == entry) {
+		return
+	}
+	entries = append(entries, entry)
+	limit := h.Limit
+	if limit <= 0 {
+		limit = defaultInputHistoryLimit
+	}
+	if len(entries) > limit {
+		entries = append([]string(nil), entries[len(entries)-limit:]...)
+	}
+	h.entries = entries
+	h.save()
+}
+
+// Entries returns the recorded entries, oldest first.
+func (h *InputHistory) Entries() []string {
+	return append([]string(nil), h.load()...)
+}
+
+// Clear removes every entry, from Store too.
+func (h *InputHistory) Clear() {
+	h.load()
+	h.entries = nil
+	h.save()
+}
+
+// inputHistoryStoreKey returns the key a history's entries are stored under.
+func inputHistoryStoreKey(name string) string {
+	return "input-history:" + name
+}
+
+func (h *InputHistory) load() []string {
+	if h.loaded {
+		return h.entries
+	}
+	h.loaded = true
+	if h.Store == nil {
+		return h.entries
+	}
+	stored, err := h.Store.Load()
+	if err != nil {
+		Log("Input history: %v", err)
+	}
+	if entries, ok := storedStrings(stored[inputHistoryStoreKey(h.StoreKey)]); ok {
+		h.entries = append(entries, h.entries...)
+	}
+	return h.entries
+}
+
+// save persists the entries, keeping any other values in the store intact.
+func (h *InputHistory) save() {
+	if h.Store == nil {
+		return
+	}
+	stored, err := h.Store.Load()
+	if err != nil {
+		Log("Input history: %v", err)
+	}
+	values := map[string]any{}
+	for key, value := range stored {
+		values[key] = value
+	}
+	values[inputHistoryStoreKey(h.StoreKey)] = append([]string{}, h.entries...)
+	if err := h.Store.Save(values); err != nil {
+		Log("Input history: %v", err)
+	}
+}
+
+// storedStrings converts a stored string list, which is []string when saved
+// in memory and []any once it has been through JSON.
+func storedStrings(value any) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return append([]string(nil), v...), true
+	case []any:
+		strs := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			strs = append(strs, s)
+		}
+		return strs, true
+	default:
+		return nil, false
+	}
+}
+
+// historyRecall tracks stepping through a TextInputState's History with up
+// and down.
+type historyRecall struct {
+	active   bool
+	index    int    // Entry shown, len(entries) before the first step
+	prefix   string // Text when recall started; only entries starting with it are shown
+	draft    string // Text restored on stepping down past the newest entry
+	revision uint64 // Content revision after the last step; any other edit ends recall
+}
+
+// HistoryPrevious replaces the text with the next older History entry that
+// starts with the text typed before recall began, as shells do, and moves
+// the cursor to the end. Returns false if there is no History or no older
+// match. Editing the recalled text starts a fresh recall.
+func (s *TextInputState) HistoryPrevious() bool {
+	if s.History == nil {
+		return false
+	}
+	entries := s.History.Entries()
+	if !s.recalling() {
+		text := s.GetText()
+		s.recall = historyRecall{active: true, index: len(entries), prefix: text, draft: text}
+	}
+	current := s.GetText()
+	for i := min(s.recall.index, len(entries)) - 1; i >= 0; i-- {
+		if strings.HasPrefix(entries[i], s.recall.prefix) && entries[i] != current {
+			s.recall.index = i
+			s.showRecalled(entries[i])
+			return true
+		}
+	}
+	return false
+}
+
+// HistoryNext steps back toward newer History entries matching the recall
+// prefix, restoring the text that was being typed after the newest. Returns
+// false if no recall is in progress.
+func (s *TextInputState) HistoryNext() bool {
+	if s.History == nil || !s.recalling() {
+		return false
+	}
+	entries := s.History.Entries()
+	current := s.GetText()
+	for i := s.recall.index + 1; i < len(entries); i++ {
+		if strings.HasPrefix(entries[i], s.recall.prefix) && entries[i] != current {
+			s.recall.index = i
+			s.showRecalled(entries[i])
+			return true
+		}
+	}
+	draft := s.recall.draft
+	s.recall = historyRecall{}
+	s.showRecalled(draft)
+	return true
+}
+
+// recalling reports whether up/down recall is in progress, which it stops
+// being once the text is edited.
+func (s *TextInputState) recalling() bool {
+	return s.recall.active && s.Content.revision() == s.recall.revision
+}
+
+// showRecalled puts recalled text in the input with the cursor at its end.
+func (s *TextInputState) showRecalled(text string) {
+	s.SetText(text)
+	s.SelectionAnchor.Set(-1)
+	s.CursorIndex.Set(len(s.Content.Peek()))
+	s.recall.revision = s.Content.revision()
+}
+
+// historySearch is an open ctrl+r search through a TextInputState's History.
+type historySearch struct {
+	query string
+	match int // Index of the entry found, or -1
+}
+
+// findInHistory returns the index of the newest entry before before that
+// contains query, or -1.
+func findInHistory(entries []string, query string, before int) int {
+	if query == "" {
+		return -1
+	}
+	for i := min(before, len(entries)) - 1; i >= 0; i-- {
+		if strings.Contains(entries[i], query) {
+			return i
+		}
+	}
+	return -1
+}
+
+// historySearchKeybinds replaces the input's keybinds while a search is
+// open. Typed text goes to the query through OnKey.
+func (t TextInput) historySearchKeybinds() []Keybind {
+	return []Keybind{
+		{Key: "ctrl+r", Name: "Older", Action: t.searchOlder},
+		{Key: "enter", Name: "Accept", Action: t.acceptHistorySearch},
+		{Key: "escape", Name: "Cancel", Action: t.closeHistorySearch},
+		{Key: "ctrl+g", Action: t.closeHistorySearch, Hidden: true},
+		{Key: "backspace", Action: t.searchBackspace, Hidden: true},
+	}
+}
+
+// openHistorySearch opens the ctrl+r search popup.
+func (t TextInput) openHistorySearch() {
+	if t.State != nil && t.State.History != nil {
+		t.State.search.Set(&historySearch{match: -1})
+	}
+}
+
+// closeHistorySearch closes the search popup, leaving the text as it was.
+func (t TextInput) closeHistorySearch() {
+	if t.State != nil && t.State.search.Peek() != nil {
+		t.State.search.Set(nil)
+	}
+}
+
+// setSearchQuery searches for query from the newest entry.
+func (t TextInput) setSearchQuery(query string) {
+	entries := t.State.History.Entries()
+	t.State.search.Set(&historySearch{query: query, match: findInHistory(entries, query, len(entries))})
+}
+
+func (t TextInput) searchBackspace() {
+	search := t.State.search.Peek()
+	graphemes := splitGraphemes(search.query)
+	if len(graphemes) > 0 {
+		t.setSearchQuery(joinGraphemes(graphemes[:len(graphemes)-1]))
+	}
+}
+
+// searchOlder moves to the next older entry matching the query.
+func (t TextInput) searchOlder() {
+	search := t.State.search.Peek()
+	if search.match < 0 {
+		return
+	}
+	if older := findInHistory(t.State.History.Entries(), search.query, search.match); older >= 0 {
+		t.State.search.Set(&historySearch{query: search.query, match: older})
+	}
+}
+
+// acceptHistorySearch puts the entry found in the input and closes the popup.
+func (t TextInput) acceptHistorySearch() {
+	search := t.State.search.Peek()
+	t.State.search.Set(nil)
+	entries := t.State.History.Entries()
+	if search.match < 0 || search.match >= len(entries) {
+		return
+	}
+	t.State.recall = historyRecall{}
+	t.State.showRecalled(entries[search.match])
+	t.notifyChange()
+}
+
+// buildHistorySearch registers the search popup below the input, or above
+// it when there is no room below.
+func (t TextInput) buildHistorySearch(ctx BuildContext) {
+	if t.State == nil || t.State.History == nil {
+		return
+	}
+	search := t.State.search.Get()
+	if search == nil {
+		return
+	}
+	theme := ctx.Theme()
+	muted := SpanStyle{Foreground: theme.TextMuted}
+
+	var found Widget = Text{Spans: []Span{{Text: "no match", Style: muted}}}
+	if entries := t.State.History.Entries(); search.match >= 0 && search.match < len(entries) {
+		entry := entries[search.match]
+		start := strings.Index(entry, search.query)
+		found = Text{Spans: HighlightSpans(entry, []MatchRange{{Start: start, End: start + len(search.query)}}, MatchHighlightStyle(theme))}
+	} else if search.query == "" {
+		found = Text{Spans: []Span{{Text: "type to search history", Style: muted}}}
+	}
+
+	id := t.ID
+	if id == "" {
+		id = ctx.AutoID()
+	}
+	Floating{
+		Visible: true,
+		Config: FloatConfig{
+			AnchorID: id,
+			Anchor:   AnchorBottomLeft,
+			Flip:     true,
+		},
+		Child: Column{
+			Style: Style{
+				BackgroundColor: theme.Token(TokenSurfaceFloating),
+				ForegroundColor: theme.Text,
+				Padding:         EdgeInsetsXY(1, 0),
+			},
+			Children: []Widget{
+				Text{Spans: []Span{{Text: "search: ", Style: muted}, {Text: search.query}}},
+				found,
+			},
+		},
+	}.Build(ctx)
+}
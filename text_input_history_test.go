@@ -0,0 +1,166 @@
+package terma
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func historyOf(entries ...string) *InputHistory {
+	h := &InputHistory{}
+	for _, entry := range entries {
+		h.Add(entry)
+	}
+	return h
+}
+
+func pressKey(input TextInput, event KeyEvent) bool {
+	return matchKeybind(event, input.Keybinds()) || input.OnKey(event)
+}
+
+func TestInputHistory_AddSkipsBlanksAndRepeats(t *testing.T) {
+	h := &InputHistory{Limit: 3}
+
+	for _, entry := range []string{"ls", "ls", "  ", "pwd", "cd /", "ls", "echo"} {
+		h.Add(entry)
+	}
+
+	assert.Equal(t, []string{"cd /", "ls", "echo"}, h.Entries())
+}
+
+func TestInputHistory_PersistsToStore(t *testing.T) {
+	store := JSONFileStore{Path: filepath.Join(t.TempDir(), "history.json")}
+	require.NoError(t, store.Save(map[string]any{"theme": "dark"}))
+
+	h := &InputHistory{Store: store, StoreKey: "repl"}
+	h.Add("1 + 1")
+	h.Add("print(x)")
+
+	reloaded := &InputHistory{Store: store, StoreKey: "repl"}
+	assert.Equal(t, []string{"1 + 1", "print(x)"}, reloaded.Entries())
+	other := &InputHistory{Store: store, StoreKey: "shell"}
+	assert.Empty(t, other.Entries())
+	values, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "dark", values["theme"])
+}
+
+func TestTextInputState_HistoryRecallMatchesPrefix(t *testing.T) {
+	state := NewTextInputState("git")
+	state.History = historyOf("git status", "ls", "git log", "git log", "make")
+
+	require.True(t, state.HistoryPrevious())
+	assert.Equal(t, "git log", state.GetText())
+	assert.Equal(t, 7, state.CursorIndex.Peek())
+	require.True(t, state.HistoryPrevious())
+	assert.Equal(t, "git status", state.GetText())
+	assert.False(t, state.HistoryPrevious())
+
+	require.True(t, state.HistoryNext())
+	assert.Equal(t, "git log", state.GetText())
+	require.True(t, state.HistoryNext())
+	assert.Equal(t, "git", state.GetText(), "stepping past the newest restores the draft")
+	assert.False(t, state.HistoryNext())
+}
+
+func TestTextInputState_EditingEndsRecall(t *testing.T) {
+	state := NewTextInputState("")
+	state.History = historyOf("make test", "ls", "make build")
+
+	state.HistoryPrevious()
+	state.HistoryPrevious()
+	assert.Equal(t, "ls", state.GetText())
+
+	state.DeleteBackward()
+	state.DeleteBackward()
+	state.Insert("make")
+	require.True(t, state.HistoryPrevious())
+	assert.Equal(t, "make build", state.GetText())
+}
+
+func TestTextInput_SubmitRecordsHistory(t *testing.T) {
+	state := NewTextInputState("")
+	state.History = &InputHistory{}
+	var submitted []string
+	input := TextInput{State: state, OnSubmit: func(text string) {
+		submitted = append(submitted, text)
+		state.SetText("")
+	}}
+
+	state.SetText("first")
+	pressKey(input, makeKeyEvent(uv.KeyEnter, 0))
+	state.SetText("second")
+	pressKey(input, makeKeyEvent(uv.KeyEnter, 0))
+	pressKey(input, makeKeyEvent(uv.KeyUp, 0))
+	pressKey(input, makeKeyEvent(uv.KeyUp, 0))
+
+	assert.Equal(t, []string{"first", "second"}, submitted)
+	assert.Equal(t, []string{"first", "second"}, state.History.Entries())
+	assert.Equal(t, "first", state.GetText())
+}
+
+func TestTextInput_UpAndDownBubbleWithoutHistory(t *testing.T) {
+	input := TextInput{State: NewTextInputState("text")}
+
+	assert.False(t, pressKey(input, makeKeyEvent(uv.KeyUp, 0)))
+	assert.False(t, pressKey(input, makeKeyEvent('r', uv.ModCtrl)))
+}
+
+func TestTextInput_ReverseSearch(t *testing.T) {
+	state := NewTextInputState("draft")
+	state.History = historyOf("go test ./...", "ls", "go vet ./...", "cat go.mod")
+	var changes []string
+	input := TextInput{State: state, OnChange: func(text string) { changes = append(changes, text) }}
+
+	require.True(t, pressKey(input, makeKeyEvent('r', uv.ModCtrl)))
+	for _, r := range "go " {
+		pressKey(input, makeCharEvent(r))
+	}
+	assert.Equal(t, "go ", state.search.Peek().query)
+	assert.Equal(t, 2, state.search.Peek().match)
+	assert.Equal(t, "draft", state.GetText(), "typing goes to the query")
+
+	pressKey(input, makeKeyEvent('r', uv.ModCtrl))
+	assert.Equal(t, 0, state.search.Peek().match)
+	pressKey(input, makeKeyEvent('r', uv.ModCtrl))
+	assert.Equal(t, 0, state.search.Peek().match, "no older match keeps the last")
+
+	pressKey(input, makeKeyEvent(uv.KeyEnter, 0))
+	assert.Nil(t, state.search.Peek())
+	assert.Equal(t, "go test ./...", state.GetText())
+	assert.Equal(t, []string{"go test ./..."}, changes)
+	assert.Len(t, state.History.Entries(), 4, "accepting doesn't submit")
+}
+
+func TestTextInput_ReverseSearchEscapeKeepsText(t *testing.T) {
+	state := NewTextInputState("draft")
+	state.History = historyOf("ls")
+	input := TextInput{State: state}
+
+	pressKey(input, makeKeyEvent('r', uv.ModCtrl))
+	pressKey(input, makeCharEvent('l'))
+	pressKey(input, makeKeyEvent(uv.KeyBackspace, 0))
+	assert.Equal(t, "", state.search.Peek().query)
+	pressKey(input, makeKeyEvent(uv.KeyEscape, 0))
+
+	assert.Nil(t, state.search.Peek())
+	assert.Equal(t, "draft", state.GetText())
+}
+
+func TestTextInput_ReverseSearchPopup(t *testing.T) {
+	state := NewTextInputState("")
+	state.History = historyOf("select * from users", "\\dt")
+	input := TextInput{ID: "sql", State: state}
+	input.openHistorySearch()
+	input.setSearchQuery("from")
+
+	screen := screenText(Column{Children: []Widget{input}}, 30, 4)
+
+	lines := strings.Split(screen, "\n")
+	assert.Equal(t, "search: from", strings.TrimSpace(lines[1]))
+	assert.Equal(t, "select * from users", strings.TrimSpace(lines[2]))
+}
@@ -773,7 +773,7 @@ func TestSnapshot_TextInput_ReadOnly(t *testing.T) {
 	}
 
 	AssertSnapshot(t, widget, 20, 1,
-		"TextInput in read-only mode with cursor in middle. Cursor should be visible but editing is disabled.")
+		"TextInput in read-only mode with cursor in middle. Text is muted; cursor should be visible but editing is disabled.")
 }
 
 func TestTextInputState_GetSelectionBounds_ClampsToContentLength(t *testing.T) {
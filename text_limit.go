@@ -0,0 +1,40 @@
+package terma
+
+import "strconv"
+
+// limitGraphemes cuts inserted down to the room maxLength leaves in a text
+// of length graphemes, and reports whether any were cut. A maxLength of 0
+// means no limit.
+func limitGraphemes(inserted []string, length, maxLength int) ([]string, bool) {
+	if maxLength <= 0 {
+		return inserted, false
+	}
+	room := max(0, maxLength-length)
+	if len(inserted) <= room {
+		return inserted, false
+	}
+	return inserted[:room], true
+}
+
+// lengthCounter returns the text of a length counter: "120/140", or just
+// "120" without a limit.
+func lengthCounter(length, maxLength int) string {
+	if maxLength <= 0 {
+		return strconv.Itoa(length)
+	}
+	return strconv.Itoa(length) + "/" + strconv.Itoa(maxLength)
+}
+
+// drawLengthCounter fills the one-row ctx with the background of base and
+// draws the counter at its right, muted, or in the warning color once the
+// text is as long as it may be.
+func drawLengthCounter(ctx *RenderContext, theme ThemeData, base Style, length, maxLength int) {
+	ctx.FillRect(0, 0, ctx.Width, 1, base.BackgroundColor.ColorAt(ctx.Width, 1, 0, 0))
+	style := base
+	style.ForegroundColor = theme.TextMuted
+	if maxLength > 0 && length >= maxLength {
+		style.ForegroundColor = theme.Warning
+	}
+	counter := lengthCounter(length, maxLength)
+	ctx.DrawStyledText(max(0, ctx.Width-len(counter)), 0, counter, style)
+}
@@ -0,0 +1,112 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextInputState_MaxLengthCutsInsertedText(t *testing.T) {
+	state := NewTextInputState("")
+	state.MaxLength.Set(5)
+
+	state.Insert("héllo wörld")
+	assert.Equal(t, "héllo", state.GetText())
+	assert.Equal(t, 5, state.CursorIndex.Peek())
+
+	state.Insert("!")
+	assert.Equal(t, "héllo", state.GetText())
+
+	state.SetSelectionAnchor(0)
+	state.CursorIndex.Set(2)
+	state.ReplaceSelection("abc")
+	assert.Equal(t, "abllo", state.GetText())
+}
+
+func TestTextInputState_SetTextCutsToMaxLength(t *testing.T) {
+	state := NewTextInputState("")
+	state.MaxLength.Set(3)
+
+	state.SetText("👍🏽👍🏽👍🏽👍🏽")
+
+	assert.Equal(t, "👍🏽👍🏽👍🏽", state.GetText())
+}
+
+func TestTextInput_OnLimitWhenTypingIsCut(t *testing.T) {
+	state := NewTextInputState("ab")
+	state.MaxLength.Set(3)
+	limits := 0
+	var changes []string
+	input := TextInput{
+		State:    state,
+		OnLimit:  func() { limits++ },
+		OnChange: func(text string) { changes = append(changes, text) },
+	}
+
+	input.OnKey(makeCharEvent('c'))
+	assert.Equal(t, 0, limits)
+	input.OnKey(makeCharEvent('d'))
+
+	assert.Equal(t, 1, limits)
+	assert.Equal(t, "abc", state.GetText())
+	assert.Equal(t, []string{"abc", "abc"}, changes)
+}
+
+func TestTextInput_ShowCount(t *testing.T) {
+	state := NewTextInputState("hello")
+	state.MaxLength.Set(140)
+
+	screen := screenText(TextInput{State: state, ShowCount: true, Style: Style{Width: Cells(20)}}, 20, 1)
+
+	assert.Equal(t, "hello          5/140", strings.TrimRight(screen, " "))
+}
+
+func TestTextInput_ShowCountWidensAutoWidth(t *testing.T) {
+	state := NewTextInputState("hi")
+	state.MaxLength.Set(10)
+
+	size := TextInput{State: state, ShowCount: true}.Layout(newTestBuildContext(), Constraints{MaxWidth: 80, MaxHeight: 1})
+
+	assert.Equal(t, 2+len(" 10/10"), size.Width)
+}
+
+func TestTextArea_MaxLengthCountsNewlines(t *testing.T) {
+	state := NewTextAreaState("ab")
+	state.MaxLength.Set(3)
+	limits := 0
+	area := TextArea{State: state, OnLimit: func() { limits++ }}
+
+	area.insertNewline()
+	area.insertNewline()
+
+	assert.Equal(t, "ab\n", state.GetText())
+	assert.Equal(t, 1, limits)
+}
+
+func TestTextArea_ShowCountOnBottomRow(t *testing.T) {
+	state := NewTextAreaState("one\ntwo")
+	state.MaxLength.Set(7)
+
+	screen := screenText(TextArea{State: state, ShowCount: true, Style: Style{Width: Cells(12), Height: Cells(3)}}, 12, 3)
+
+	lines := strings.Split(screen, "\n")
+	assert.Equal(t, "one", strings.TrimRight(lines[0], " "))
+	assert.Equal(t, "two", strings.TrimRight(lines[1], " "))
+	assert.Equal(t, "         7/7", lines[2])
+}
+
+func TestSnapshot_TextInput_ShowCount(t *testing.T) {
+	state := NewTextInputState("almost full")
+	state.MaxLength.Set(12)
+
+	widget := TextInput{
+		ID:        "textinput-count",
+		State:     state,
+		ShowCount: true,
+		Style:     Style{Width: Cells(24)},
+	}
+
+	AssertSnapshot(t, widget, 24, 1,
+		"TextInput with a 12-grapheme limit holding 11. The text is followed by a muted '11/12' counter at the right edge.")
+}
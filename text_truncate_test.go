@@ -0,0 +1,90 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateGraphemes_Modes(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		mode  TruncateMode
+		want  string
+	}{
+		{"fits unchanged", "hello", 8, TruncateEnd, "hello"},
+		{"end", "hello world", 8, TruncateEnd, "hello w…"},
+		{"start", "hello world", 8, TruncateStart, "…o world"},
+		{"middle", "hello world", 8, TruncateMiddle, "hell…rld"},
+		{"path keeps first and last segments", "/usr/local/lib/go/src/main.go", 20, TruncatePath, "/usr/…/src/main.go"},
+		{"path drops head when needed", "/usr/local/lib/go/src/main.go", 10, TruncatePath, "…/main.go"},
+		{"path falls back to start", "/usr/local/lib/go/src/main.go", 6, TruncatePath, "…in.go"},
+		{"relative path", "src/components/widgets/button.go", 22, TruncatePath, "src/…/button.go"},
+		{"wide characters", "日本語のテキスト", 7, TruncateEnd, "日本語…"},
+		{"marker wider than width", "hello", 0, TruncateEnd, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := graphemesText(truncateGraphemes(plainGraphemes(tt.text), tt.width, tt.mode, "…"))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestText_TruncateModesRender(t *testing.T) {
+	for _, tt := range []struct {
+		mode TruncateMode
+		want string
+	}{
+		{TruncateNone, "hello wo"},
+		{TruncateEnd, "hello w…"},
+		{TruncateStart, "…o world"},
+		{TruncateMiddle, "hell…rld"},
+	} {
+		plain := Text{Content: "hello world", Truncate: tt.mode, Style: Style{Width: Cells(8)}}
+		assert.Equal(t, tt.want, bufferLine(RenderToBuffer(plain, 8, 1), 0, 8))
+
+		rich := Text{Spans: []Span{BoldSpan("hello"), PlainSpan(" world")}, Truncate: tt.mode, Style: Style{Width: Cells(8)}}
+		assert.Equal(t, tt.want, bufferLine(RenderToBuffer(rich, 8, 1), 0, 8))
+	}
+}
+
+func TestText_TruncateCustomMarker(t *testing.T) {
+	widget := Text{
+		Content:  "hello world",
+		Truncate: TruncateMiddle,
+		Style:    Style{Width: Cells(9), Ellipsis: "..."},
+	}
+	assert.Equal(t, "hel...rld", bufferLine(RenderToBuffer(widget, 9, 1), 0, 9))
+}
+
+func TestText_MaxLinesWithSoftWrap(t *testing.T) {
+	widget := Text{
+		Content:  "the quick brown fox jumps over the lazy dog",
+		Wrap:     WrapSoft,
+		MaxLines: 2,
+		Style:    Style{Width: Cells(10)},
+	}
+	buf, _, height := RenderToBufferWithSize(widget, 10, 6)
+	assert.Equal(t, 2, height, "MaxLines caps the measured height")
+	assert.Equal(t, "the quick ", bufferLine(buf, 0, 10))
+	assert.Equal(t, "brown fox…", bufferLine(buf, 1, 10))
+	assert.Equal(t, "          ", bufferLine(buf, 2, 10))
+
+	rich := Text{
+		Spans:    []Span{PlainSpan("the quick brown "), BoldSpan("fox jumps over")},
+		Wrap:     WrapSoft,
+		MaxLines: 2,
+		Style:    Style{Width: Cells(10)},
+	}
+	buf = RenderToBuffer(rich, 10, 6)
+	assert.Equal(t, "brown fox…", bufferLine(buf, 1, 10))
+}
+
+func TestText_MaxLinesNotMarkedWhenEverythingFits(t *testing.T) {
+	widget := Text{Content: "short", Wrap: WrapSoft, MaxLines: 2, Style: Style{Width: Cells(10)}}
+	assert.Equal(t, "short     ", bufferLine(RenderToBuffer(widget, 10, 2), 0, 10))
+}
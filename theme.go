@@ -120,6 +120,10 @@ type ThemeData struct {
 	ErrorBg     Color
 	WarningBg   Color
 	InfoBg      Color
+
+	// Tokens overrides semantic tokens; see ThemeToken. Tokens that aren't
+	// set resolve to the roles above.
+	Tokens map[ThemeToken]Color
 }
 
 // computeLabelColors fills in derived label colors from base variant colors.
@@ -0,0 +1,133 @@
+package terma
+
+// PaletteOptions configures GenerateTheme.
+type PaletteOptions struct {
+	// Secondary is an optional second seed color. When unset it is derived
+	// from the primary seed by rotating its hue.
+	Secondary Color
+	// Light generates a light theme instead of a dark one.
+	Light bool
+}
+
+// Minimum contrast ratios GenerateTheme keeps against the background
+// (or, for TextOnX colors, against X). 4.5 and 3 are the WCAG AA levels
+// for text and for UI components.
+const (
+	paletteTextContrast     = 7.0
+	paletteMutedContrast    = 4.5
+	paletteDisabledContrast = 2.5
+	paletteOnColorContrast  = 4.5
+	paletteUIContrast       = 3.0
+)
+
+// GenerateTheme derives a complete theme from a seed color, for settings
+// such as "custom accent color". Surfaces are tinted with the seed's hue,
+// feedback colors keep their usual hues, and every text color is adjusted
+// until it is readable on the color behind it, so any seed gives a usable
+// theme. Register the result with RegisterTheme.
+//
+// Example:
+//
+//	data := t.GenerateTheme(t.Hex("#e0457b"), t.PaletteOptions{})
+//	t.RegisterTheme("custom", data)
+//	t.SetTheme("custom")
+func GenerateTheme(seed Color, opts PaletteOptions) ThemeData {
+	if !seed.IsSet() {
+		seed = Hex("#7aa2f7")
+	}
+	seed = RGB(seed.RGB())
+	hue, sat, _ := seed.HSL()
+	tint := min(sat, 0.25)
+
+	// Surfaces step away from the background as they nest.
+	shade := func(lightness float64) Color {
+		if opts.Light {
+			return HSL(hue, tint, 1-lightness)
+		}
+		return HSL(hue, tint, lightness)
+	}
+	background := shade(0.09)
+	data := ThemeData{
+		IsLight:      opts.Light,
+		Background:   background,
+		Surface:      shade(0.13),
+		Surface2:     shade(0.16),
+		SurfaceHover: shade(0.19),
+		Surface3:     shade(0.21),
+		Border:       shade(0.28),
+	}
+
+	secondary := opts.Secondary
+	if !secondary.IsSet() {
+		secondary = seed.Rotate(40)
+	}
+	data.Primary = ensureContrast(seed, background, paletteUIContrast)
+	data.Secondary = ensureContrast(RGB(secondary.RGB()), background, paletteUIContrast)
+	data.Accent = ensureContrast(seed.Rotate(180), background, paletteUIContrast)
+
+	// Text carries a hint of the seed's hue.
+	data.Text = ensureContrast(shade(0.90).Desaturate(0.1), background, paletteTextContrast)
+	data.TextMuted = ensureContrast(shade(0.62), background, paletteMutedContrast)
+	data.TextDisabled = ensureContrast(shade(0.42), background, paletteDisabledContrast)
+
+	// Feedback colors keep conventional hues so they read as status.
+	feedback := func(h float64) Color {
+		lightness := 0.62
+		if opts.Light {
+			lightness = 0.42
+		}
+		return ensureContrast(HSL(h, 0.7, lightness), background, paletteUIContrast)
+	}
+	data.Error = feedback(355)
+	data.Warning = feedback(38)
+	data.Success = feedback(140)
+	data.Info = feedback(205)
+
+	data.TextOnPrimary = textOn(data.Primary)
+	data.TextOnSecondary = textOn(data.Secondary)
+	data.TextOnAccent = textOn(data.Accent)
+	data.TextOnError = textOn(data.Error)
+	data.TextOnWarning = textOn(data.Warning)
+	data.TextOnSuccess = textOn(data.Success)
+	data.TextOnInfo = textOn(data.Info)
+
+	data.FocusRing = data.Primary
+	data.ActiveCursor = data.Accent
+	data.Selection = data.Accent.WithAlpha(DefaultSelectionAlpha)
+	data.SelectionText = data.TextOnAccent
+	data.ScrollbarTrack = data.SurfaceHover
+	data.ScrollbarThumb = data.TextDisabled
+	data.Overlay = background.WithAlpha(0.8)
+	data.Placeholder = data.TextDisabled
+	data.Cursor = data.Text
+	data.Link = ensureContrast(data.Primary, background, paletteMutedContrast)
+
+	computeLabelColors(&data)
+	return data
+}
+
+// ensureContrast returns c, lightened or darkened as little as needed to
+// reach the given contrast ratio against bg. If no lightness reaches it,
+// the readable text color for bg is returned.
+func ensureContrast(c, bg Color, ratio float64) Color {
+	if c.ContrastRatio(bg) >= ratio {
+		return c
+	}
+	h, s, l := c.HSL()
+	step := 0.02
+	if bg.Luminance() >= 0.18 {
+		step = -step
+	}
+	for l = l + step; l >= 0 && l <= 1; l += step {
+		if candidate := HSL(h, s, l); candidate.ContrastRatio(bg) >= ratio {
+			return candidate
+		}
+	}
+	return bg.AutoText()
+}
+
+// textOn returns a readable text color for the background c, keeping a
+// little of c's character.
+func textOn(c Color) Color {
+	return ensureContrast(c.AutoText(), c, paletteOnColorContrast)
+}
@@ -0,0 +1,67 @@
+package terma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateTheme_ContrastForAnySeed(t *testing.T) {
+	seeds := []Color{
+		Hex("#e0457b"), Hex("#ffff00"), Hex("#000033"), Hex("#808080"),
+		Hex("#ffffff"), Hex("#000000"), Hex("#00ff88"), Color{},
+	}
+	for _, light := range []bool{false, true} {
+		for _, seed := range seeds {
+			data := GenerateTheme(seed, PaletteOptions{Light: light})
+			name := seed.Hex()
+			if light {
+				name += " light"
+			}
+			bg := data.Background
+
+			assert.Equal(t, light, data.IsLight, name)
+			assert.Equal(t, light, bg.IsLight(), name)
+			assert.GreaterOrEqual(t, data.Text.ContrastRatio(bg), paletteTextContrast, name+" Text")
+			assert.GreaterOrEqual(t, data.TextMuted.ContrastRatio(bg), paletteMutedContrast, name+" TextMuted")
+			assert.GreaterOrEqual(t, data.Link.ContrastRatio(bg), paletteMutedContrast, name+" Link")
+			for label, pair := range map[string][2]Color{
+				"Primary":   {data.Primary, data.TextOnPrimary},
+				"Secondary": {data.Secondary, data.TextOnSecondary},
+				"Accent":    {data.Accent, data.TextOnAccent},
+				"Error":     {data.Error, data.TextOnError},
+				"Warning":   {data.Warning, data.TextOnWarning},
+				"Success":   {data.Success, data.TextOnSuccess},
+				"Info":      {data.Info, data.TextOnInfo},
+			} {
+				assert.GreaterOrEqual(t, pair[0].ContrastRatio(bg), paletteUIContrast, name+" "+label)
+				assert.GreaterOrEqual(t, pair[1].ContrastRatio(pair[0]), paletteOnColorContrast, name+" TextOn"+label)
+			}
+			assert.True(t, data.PrimaryBg.IsSet(), "label colors are computed")
+		}
+	}
+}
+
+func TestGenerateTheme_UsesSeeds(t *testing.T) {
+	seed := Hex("#e0457b")
+	data := GenerateTheme(seed, PaletteOptions{Secondary: Hex("#45e0a8")})
+	assert.Equal(t, seed, data.Primary, "a seed with enough contrast is kept")
+	assert.Equal(t, Hex("#45e0a8"), data.Secondary)
+	assert.Equal(t, data.Primary, data.FocusRing)
+
+	seedHue, _, _ := seed.HSL()
+	surfaceHue, _, _ := data.Surface.HSL()
+	assert.InDelta(t, seedHue, surfaceHue, 2, "surfaces are tinted with the seed")
+}
+
+func TestEnsureContrast(t *testing.T) {
+	bg := Hex("#101010")
+	dim := Hex("#303030")
+	adjusted := ensureContrast(dim, bg, 4.5)
+	assert.GreaterOrEqual(t, adjusted.ContrastRatio(bg), 4.5)
+	assert.True(t, adjusted.Luminance() > dim.Luminance(), "lightened on a dark background")
+
+	assert.Equal(t, Hex("#ffffff"), ensureContrast(Hex("#ffffff"), bg, 4.5))
+	grey := Hex("#808080")
+	assert.Equal(t, grey.AutoText(), ensureContrast(grey, grey, 21), "unreachable ratios fall back to AutoText")
+}
@@ -0,0 +1,150 @@
+package terma
+
+import (
+	"maps"
+	"strings"
+)
+
+// ThemeToken names a theme color by what it is for rather than where it
+// sits in the palette, such as "surface.raised" or "border.focus". Widgets
+// that use tokens follow a theme's intent, and theme authors can restyle
+// one purpose without touching the others: overriding "surface.floating"
+// changes menus, tooltips and dialogs but not panes that share Surface.
+//
+// Tokens are dotted paths. ThemeData.Token resolves a token from the
+// theme's Tokens overrides first, then from the built-in role it maps to,
+// then from its parent path, so "surface.raised.hover" is "surface.raised"
+// until a theme sets it. Apps can add their own tokens the same way.
+type ThemeToken string
+
+// Built-in tokens, with the ThemeData role each resolves to by default.
+const (
+	TokenSurfaceBase     ThemeToken = "surface.base"     // Background
+	TokenSurface         ThemeToken = "surface"          // Surface
+	TokenSurfaceHover    ThemeToken = "surface.hover"    // SurfaceHover
+	TokenSurfaceRaised   ThemeToken = "surface.raised"   // Surface2
+	TokenSurfaceHighest  ThemeToken = "surface.highest"  // Surface3
+	TokenSurfaceFloating ThemeToken = "surface.floating" // Surface: menus, tooltips, dialogs and palettes
+	TokenSurfaceBackdrop ThemeToken = "surface.backdrop" // Overlay: behind modals
+
+	TokenText            ThemeToken = "text"             // Text
+	TokenTextSecondary   ThemeToken = "text.secondary"   // TextMuted
+	TokenTextDisabled    ThemeToken = "text.disabled"    // TextDisabled
+	TokenTextPlaceholder ThemeToken = "text.placeholder" // Placeholder
+	TokenTextLink        ThemeToken = "text.link"        // Link
+	TokenTextSelection   ThemeToken = "text.selection"   // SelectionText
+
+	TokenBorder      ThemeToken = "border"       // Border
+	TokenBorderFocus ThemeToken = "border.focus" // FocusRing
+
+	TokenSelection         ThemeToken = "selection"          // ActiveCursor
+	TokenSelectionInactive ThemeToken = "selection.inactive" // Selection
+
+	TokenScrollbarTrack ThemeToken = "scrollbar.track" // ScrollbarTrack
+	TokenScrollbarThumb ThemeToken = "scrollbar.thumb" // ScrollbarThumb
+
+	TokenCursor ThemeToken = "cursor" // Cursor
+
+	TokenBrandPrimary   ThemeToken = "brand.primary"   // Primary
+	TokenBrandSecondary ThemeToken = "brand.secondary" // Secondary
+	TokenBrandAccent    ThemeToken = "brand.accent"    // Accent
+
+	TokenStatusError   ThemeToken = "status.error"   // Error
+	TokenStatusWarning ThemeToken = "status.warning" // Warning
+	TokenStatusSuccess ThemeToken = "status.success" // Success
+	TokenStatusInfo    ThemeToken = "status.info"    // Info
+)
+
+// tokenRoles maps the built-in tokens to the ThemeData roles they resolve
+// to when a theme doesn't override them.
+var tokenRoles = map[ThemeToken]func(t ThemeData) Color{
+	TokenSurfaceBase:     func(t ThemeData) Color { return t.Background },
+	TokenSurface:         func(t ThemeData) Color { return t.Surface },
+	TokenSurfaceHover:    func(t ThemeData) Color { return t.SurfaceHover },
+	TokenSurfaceRaised:   func(t ThemeData) Color { return t.Surface2 },
+	TokenSurfaceHighest:  func(t ThemeData) Color { return t.Surface3 },
+	TokenSurfaceFloating: func(t ThemeData) Color { return t.Surface },
+	TokenSurfaceBackdrop: func(t ThemeData) Color { return t.Overlay },
+
+	TokenText:            func(t ThemeData) Color { return t.Text },
+	TokenTextSecondary:   func(t ThemeData) Color { return t.TextMuted },
+	TokenTextDisabled:    func(t ThemeData) Color { return t.TextDisabled },
+	TokenTextPlaceholder: func(t ThemeData) Color { return t.Placeholder },
+	TokenTextLink:        func(t ThemeData) Color { return t.Link },
+	TokenTextSelection:   func(t ThemeData) Color { return t.SelectionText },
+
+	TokenBorder:      func(t ThemeData) Color { return t.Border },
+	TokenBorderFocus: func(t ThemeData) Color { return t.FocusRing },
+
+	TokenSelection:         func(t ThemeData) Color { return t.ActiveCursor },
+	TokenSelectionInactive: func(t ThemeData) Color { return t.Selection },
+
+	TokenScrollbarTrack: func(t ThemeData) Color { return t.ScrollbarTrack },
+	TokenScrollbarThumb: func(t ThemeData) Color { return t.ScrollbarThumb },
+
+	TokenCursor: func(t ThemeData) Color { return t.Cursor },
+
+	TokenBrandPrimary:   func(t ThemeData) Color { return t.Primary },
+	TokenBrandSecondary: func(t ThemeData) Color { return t.Secondary },
+	TokenBrandAccent:    func(t ThemeData) Color { return t.Accent },
+
+	TokenStatusError:   func(t ThemeData) Color { return t.Error },
+	TokenStatusWarning: func(t ThemeData) Color { return t.Warning },
+	TokenStatusSuccess: func(t ThemeData) Color { return t.Success },
+	TokenStatusInfo:    func(t ThemeData) Color { return t.Info },
+}
+
+// Token resolves a semantic token to a color. Each step of the token's
+// path, from the full token up to its first segment, is looked up in the
+// theme's Tokens overrides and then the built-in roles; the first match
+// wins. Returns an unset Color if nothing matches.
+//
+// Example:
+//
+//	theme := ctx.Theme()
+//	style := t.Style{
+//	    BackgroundColor: theme.Token(t.TokenSurfaceRaised),
+//	    ForegroundColor: theme.Token("text.secondary"),
+//	}
+func (t ThemeData) Token(token ThemeToken) Color {
+	for path := token; path != ""; path = path.parent() {
+		if c, ok := t.Tokens[path]; ok {
+			return c
+		}
+		if role, ok := tokenRoles[path]; ok {
+			return role(t)
+		}
+	}
+	return Color{}
+}
+
+// parent returns the token with its last segment removed, or "" for a
+// token with one segment.
+func (token ThemeToken) parent() ThemeToken {
+	i := strings.LastIndexByte(string(token), '.')
+	if i < 0 {
+		return ""
+	}
+	return token[:i]
+}
+
+// WithToken overrides a semantic token, leaving the role it maps to, and
+// any other widgets using that role, unchanged.
+//
+// Example:
+//
+//	t.ExtendAndRegisterTheme("my-theme", t.ThemeNameRosePine,
+//	    t.WithToken(t.TokenSurfaceFloating, t.Hex("#2a273f")),
+//	    t.WithToken("editor.gutter", t.Hex("#1f1d2e")),
+//	)
+func WithToken(token ThemeToken, c Color) ThemeOption {
+	return func(t *ThemeData) {
+		// Copy so the base theme's overrides are left alone
+		tokens := maps.Clone(t.Tokens)
+		if tokens == nil {
+			tokens = map[ThemeToken]Color{}
+		}
+		tokens[token] = c
+		t.Tokens = tokens
+	}
+}
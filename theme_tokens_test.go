@@ -0,0 +1,95 @@
+package terma
+
+import (
+	"testing"
+)
+
+func TestThemeToken_DefaultsToRoles(t *testing.T) {
+	theme, _ := GetTheme(ThemeNameDracula)
+
+	tests := []struct {
+		token ThemeToken
+		want  Color
+	}{
+		{TokenSurfaceBase, theme.Background},
+		{TokenSurfaceRaised, theme.Surface2},
+		{TokenSurfaceHighest, theme.Surface3},
+		{TokenSurfaceFloating, theme.Surface},
+		{TokenTextSecondary, theme.TextMuted},
+		{TokenBorderFocus, theme.FocusRing},
+		{TokenSelection, theme.ActiveCursor},
+		{TokenStatusError, theme.Error},
+	}
+	for _, tt := range tests {
+		if got := theme.Token(tt.token); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.token, got, tt.want)
+		}
+	}
+	for token, role := range tokenRoles {
+		if !role(theme).IsSet() {
+			t.Errorf("%s resolves to an unset color", token)
+		}
+	}
+}
+
+func TestThemeToken_FallsBackToParentPath(t *testing.T) {
+	theme, _ := GetTheme(ThemeNameDracula)
+
+	if got := theme.Token("surface.raised.hover"); got != theme.Surface2 {
+		t.Errorf("surface.raised.hover: got %v, want surface.raised %v", got, theme.Surface2)
+	}
+	if got := theme.Token("text.heading"); got != theme.Text {
+		t.Errorf("text.heading: got %v, want text %v", got, theme.Text)
+	}
+	if got := theme.Token("editor.gutter"); got.IsSet() {
+		t.Errorf("editor.gutter: got %v, want unset", got)
+	}
+}
+
+func TestThemeToken_Overrides(t *testing.T) {
+	raised := Hex("#123456")
+	gutter := Hex("#654321")
+	theme := ExtendTheme(ThemeNameDracula,
+		WithToken(TokenSurfaceRaised, raised),
+		WithToken("editor", gutter),
+	)
+	dracula, _ := GetTheme(ThemeNameDracula)
+
+	if got := theme.Token(TokenSurfaceRaised); got != raised {
+		t.Errorf("surface.raised: got %v, want override %v", got, raised)
+	}
+	if got := theme.Token("surface.raised.hover"); got != raised {
+		t.Errorf("surface.raised.hover: got %v, want the parent's override %v", got, raised)
+	}
+	if theme.Surface2 != dracula.Surface2 {
+		t.Errorf("overriding a token should leave its role alone: got %v, want %v", theme.Surface2, dracula.Surface2)
+	}
+	if got := theme.Token("editor.gutter"); got != gutter {
+		t.Errorf("editor.gutter: got %v, want app token %v", got, gutter)
+	}
+	if len(dracula.Tokens) != 0 {
+		t.Errorf("WithToken should not modify the base theme, got %v", dracula.Tokens)
+	}
+}
+
+func TestThemeToken_FloatingSurfaceStylesOverlays(t *testing.T) {
+	floating := Hex("#223344")
+	ExtendAndRegisterTheme("test-floating-token", ThemeNameDracula, WithToken(TokenSurfaceFloating, floating))
+	previous := CurrentThemeName()
+	SetTheme("test-floating-token")
+	t.Cleanup(func() {
+		SetTheme(previous)
+		delete(themeRegistry, "test-floating-token")
+	})
+
+	ctx := newTestBuildContext()
+	if got := (Tooltip{}).tooltipStyle(ctx).BackgroundColor; got != floating {
+		t.Errorf("Tooltip background: got %v, want %v", got, floating)
+	}
+	if got := (Menu{}).menuStyle(ctx).BackgroundColor; got != floating {
+		t.Errorf("Menu background: got %v, want %v", got, floating)
+	}
+	if got := (TitleBar{}).Build(ctx).(Row).Style.BackgroundColor; got == floating {
+		t.Errorf("TitleBar isn't floating and should keep Surface, got %v", got)
+	}
+}
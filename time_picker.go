@@ -0,0 +1,367 @@
+package terma
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// timeSegment is one editable part of a TimePicker.
+type timeSegment int
+
+const (
+	timeSegmentHour timeSegment = iota
+	timeSegmentMinute
+	timeSegmentSecond
+	timeSegmentPeriod // AM/PM in 12-hour mode
+)
+
+// TimePickerState holds the state for a TimePicker widget.
+type TimePickerState struct {
+	Time AnySignal[time.Time] // The time of day; the date part is kept as given
+
+	// Digits typed into a segment so far, so that typing "1" then "5"
+	// enters 15 rather than 5.
+	typed        string
+	typedSegment timeSegment
+}
+
+// NewTimePickerState creates a new TimePickerState holding t, with seconds
+// and below truncated away. A zero t starts at midnight.
+func NewTimePickerState(t time.Time) *TimePickerState {
+	return &TimePickerState{Time: NewAnySignal(t.Truncate(time.Second))}
+}
+
+// GetTime returns the current time (without subscribing).
+func (s *TimePickerState) GetTime() time.Time {
+	return s.Time.Peek()
+}
+
+// SetTime sets the time.
+func (s *TimePickerState) SetTime(t time.Time) {
+	s.typed = ""
+	s.Time.Set(t.Truncate(time.Second))
+}
+
+// TimePicker edits a time of day as separate hour, minute and optional
+// second segments, plus an AM/PM segment in 12-hour mode, such as
+// "09:30 PM". Each segment is focusable on its own, so tab moves through
+// them like any other fields.
+//
+// In a focused segment, up/down (k/j) step the value, wrapping around, and
+// typing digits enters it directly: after two digits, or one that can't
+// start a larger value, focus moves to the next segment. Left/right (h/l)
+// move between segments. In the AM/PM segment, a and p choose the period.
+// OnChange is called after every change.
+//
+// Example:
+//
+//	state := terma.NewTimePickerState(time.Date(0, 1, 1, 9, 30, 0, 0, time.Local))
+//	terma.TimePicker{
+//	    ID:        "alarm",
+//	    State:     state,
+//	    Use12Hour: true,
+//	    OnChange:  func(t time.Time) { a.setAlarm(t) },
+//	}
+type TimePicker struct {
+	ID           string            // Optional unique identifier; segment IDs are derived from it
+	DisableFocus bool              // If true, prevent keyboard focus
+	State        *TimePickerState  // Required - holds the time
+	Use12Hour    bool              // Show hours 1-12 with an AM/PM segment
+	ShowSeconds  bool              // Add a seconds segment
+	MinuteStep   int               // Minutes moved by up/down (default 1)
+	OnChange     func(t time.Time) // Called after the time changes
+	Style        Style             // Optional styling
+}
+
+// WidgetID returns the widget's unique identifier.
+// Implements the Identifiable interface.
+func (p TimePicker) WidgetID() string {
+	return p.ID
+}
+
+// Build returns a Row of the segments separated by colons.
+func (p TimePicker) Build(ctx BuildContext) Widget {
+	if p.State == nil {
+		return Row{}
+	}
+	theme := ctx.Theme()
+	value := p.State.Time.Get()
+
+	segments := p.segments()
+	ids := make([]string, len(segments))
+	for i, segment := range segments {
+		ids[i] = ctx.ScopedID(segment.name())
+	}
+
+	style := p.Style
+	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
+		style.BackgroundColor = theme.Surface
+	}
+
+	var children []Widget
+	for i, segment := range segments {
+		switch {
+		case segment == timeSegmentPeriod:
+			children = append(children, Text{Content: " ", Style: Style{BackgroundColor: style.BackgroundColor}})
+		case i > 0:
+			children = append(children, Text{Content: ":", Style: Style{ForegroundColor: theme.TextMuted, BackgroundColor: style.BackgroundColor}})
+		}
+		children = append(children, timePickerSegment{
+			id:      ids[i],
+			picker:  p,
+			segment: segment,
+			label:   p.format(segment, value),
+			prevID:  neighbourID(ids, i-1),
+			nextID:  neighbourID(ids, i+1),
+		})
+	}
+	return Row{ID: p.ID, Style: style, Children: children}
+}
+
+// neighbourID returns ids[i], or "" outside the slice.
+func neighbourID(ids []string, i int) string {
+	if i < 0 || i >= len(ids) {
+		return ""
+	}
+	return ids[i]
+}
+
+// segments returns the segments shown, in order.
+func (p TimePicker) segments() []timeSegment {
+	segments := []timeSegment{timeSegmentHour, timeSegmentMinute}
+	if p.ShowSeconds {
+		segments = append(segments, timeSegmentSecond)
+	}
+	if p.Use12Hour {
+		segments = append(segments, timeSegmentPeriod)
+	}
+	return segments
+}
+
+// name returns the segment's name, used in its ID.
+func (s timeSegment) name() string {
+	switch s {
+	case timeSegmentMinute:
+		return "minute"
+	case timeSegmentSecond:
+		return "second"
+	case timeSegmentPeriod:
+		return "period"
+	default:
+		return "hour"
+	}
+}
+
+// format returns the text shown for a segment of t.
+func (p TimePicker) format(segment timeSegment, t time.Time) string {
+	switch segment {
+	case timeSegmentMinute:
+		return fmt.Sprintf("%02d", t.Minute())
+	case timeSegmentSecond:
+		return fmt.Sprintf("%02d", t.Second())
+	case timeSegmentPeriod:
+		if t.Hour() >= 12 {
+			return "PM"
+		}
+		return "AM"
+	default:
+		return fmt.Sprintf("%02d", p.displayHour(t.Hour()))
+	}
+}
+
+// displayHour converts a 24-hour hour to the one shown.
+func (p TimePicker) displayHour(hour int) int {
+	if !p.Use12Hour {
+		return hour
+	}
+	if hour%12 == 0 {
+		return 12
+	}
+	return hour % 12
+}
+
+// bounds returns the smallest and largest values a segment shows.
+func (p TimePicker) bounds(segment timeSegment) (low, high int) {
+	switch segment {
+	case timeSegmentHour:
+		if p.Use12Hour {
+			return 1, 12
+		}
+		return 0, 23
+	case timeSegmentPeriod:
+		return 0, 1
+	default:
+		return 0, 59
+	}
+}
+
+// value returns the value a segment of t shows: the display hour, the
+// minute or second, or 0 for AM and 1 for PM.
+func (p TimePicker) value(segment timeSegment, t time.Time) int {
+	switch segment {
+	case timeSegmentMinute:
+		return t.Minute()
+	case timeSegmentSecond:
+		return t.Second()
+	case timeSegmentPeriod:
+		return t.Hour() / 12
+	default:
+		return p.displayHour(t.Hour())
+	}
+}
+
+// set changes a segment of the time to v, a value as returned by value,
+// and calls OnChange.
+func (p TimePicker) set(segment timeSegment, v int) {
+	t := p.State.Time.Peek()
+	hour, minute, second := t.Hour(), t.Minute(), t.Second()
+	switch segment {
+	case timeSegmentHour:
+		hour = v
+		if p.Use12Hour {
+			hour = v%12 + 12*(t.Hour()/12)
+		}
+	case timeSegmentMinute:
+		minute = v
+	case timeSegmentSecond:
+		second = v
+	case timeSegmentPeriod:
+		hour = t.Hour()%12 + 12*v
+	}
+	next := time.Date(t.Year(), t.Month(), t.Day(), hour, minute, second, 0, t.Location())
+	if next.Equal(t) {
+		return
+	}
+	p.State.Time.Set(next)
+	if p.OnChange != nil {
+		p.OnChange(next)
+	}
+}
+
+// step moves a segment by delta steps, wrapping within its bounds. The
+// hour doesn't carry into the period, nor minutes into the hour.
+func (p TimePicker) step(segment timeSegment, delta int) {
+	p.State.typed = ""
+	if segment == timeSegmentMinute && p.MinuteStep > 1 {
+		delta *= p.MinuteStep
+	}
+	low, high := p.bounds(segment)
+	size := high - low + 1
+	v := p.value(segment, p.State.Time.Peek())
+	p.set(segment, low+((v-low+delta)%size+size)%size)
+}
+
+// typeDigit enters a typed digit into a segment. It reports whether the
+// segment is complete and focus should move on.
+func (p TimePicker) typeDigit(segment timeSegment, digit int) bool {
+	low, high := p.bounds(segment)
+	if p.State.typed != "" && p.State.typedSegment == segment {
+		first, _ := strconv.Atoi(p.State.typed)
+		if v := first*10 + digit; v >= low && v <= high {
+			p.State.typed = ""
+			p.set(segment, v)
+			return true
+		}
+	}
+
+	p.State.typed, p.State.typedSegment = strconv.Itoa(digit), segment
+	if digit >= low {
+		p.set(segment, digit)
+	}
+	if digit*10 > high {
+		p.State.typed = ""
+		return true
+	}
+	return false
+}
+
+// timePickerSegment is one focusable segment of a TimePicker.
+type timePickerSegment struct {
+	id      string
+	picker  TimePicker
+	segment timeSegment
+	label   string
+	prevID  string // Segment left of this one ("" = none)
+	nextID  string // Segment right of this one ("" = none)
+}
+
+// WidgetID returns the segment's unique identifier.
+// Implements the Identifiable interface.
+func (s timePickerSegment) WidgetID() string {
+	return s.id
+}
+
+// IsFocusable returns true to allow keyboard editing.
+// Implements the Focusable interface.
+func (s timePickerSegment) IsFocusable() bool {
+	return !s.picker.DisableFocus
+}
+
+// Keybinds returns the declarative keybindings for this segment.
+func (s timePickerSegment) Keybinds() []Keybind {
+	keybinds := []Keybind{
+		{Key: "up", Name: "Increase", Action: func() { s.picker.step(s.segment, 1) }},
+		{Key: "k", Action: func() { s.picker.step(s.segment, 1) }, Hidden: true},
+		{Key: "down", Name: "Decrease", Action: func() { s.picker.step(s.segment, -1) }},
+		{Key: "j", Action: func() { s.picker.step(s.segment, -1) }, Hidden: true},
+		{Key: "left", Action: func() { s.focus(s.prevID) }, Hidden: true},
+		{Key: "h", Action: func() { s.focus(s.prevID) }, Hidden: true},
+		{Key: "right", Action: func() { s.focus(s.nextID) }, Hidden: true},
+		{Key: "l", Action: func() { s.focus(s.nextID) }, Hidden: true},
+	}
+	if s.segment == timeSegmentPeriod {
+		keybinds = append(keybinds,
+			Keybind{Key: "a", Name: "AM", Action: func() { s.picker.set(timeSegmentPeriod, 0) }},
+			Keybind{Key: "p", Name: "PM", Action: func() { s.picker.set(timeSegmentPeriod, 1) }},
+		)
+	}
+	return keybinds
+}
+
+// OnKey enters typed digits.
+// Implements the Focusable interface.
+func (s timePickerSegment) OnKey(event KeyEvent) bool {
+	text := event.Text()
+	if s.segment == timeSegmentPeriod || len(text) != 1 || text[0] < '0' || text[0] > '9' {
+		return false
+	}
+	if s.picker.typeDigit(s.segment, int(text[0]-'0')) {
+		s.focus(s.nextID)
+	}
+	return true
+}
+
+// CapturesKey keeps typed digits from triggering keybinds of ancestors.
+// Implements the KeyCapturer interface.
+func (s timePickerSegment) CapturesKey(key string) bool {
+	return s.segment != timeSegmentPeriod && len(key) == 1 && key[0] >= '0' && key[0] <= '9'
+}
+
+// OnBlur drops any half-typed value.
+// Implements the Blurrable interface.
+func (s timePickerSegment) OnBlur() {
+	s.picker.State.typed = ""
+}
+
+// focus moves focus to another segment, dropping any half-typed value.
+func (s timePickerSegment) focus(id string) {
+	if id == "" {
+		return
+	}
+	s.picker.State.typed = ""
+	RequestFocus(id)
+}
+
+// Build returns the segment's text, highlighted while focused.
+func (s timePickerSegment) Build(ctx BuildContext) Widget {
+	theme := ctx.Theme()
+	style := Style{ForegroundColor: theme.Text}
+	if ctx.IsDisabled() {
+		style.ForegroundColor = theme.TextDisabled
+	} else if ctx.IsFocused(s) {
+		style.BackgroundColor = theme.ActiveCursor
+		style.ForegroundColor = theme.SelectionText
+	}
+	return Text{Content: s.label, Style: style}
+}
@@ -0,0 +1,81 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func timePickerTestTime(hour, minute, second int) time.Time {
+	return time.Date(2026, 3, 4, hour, minute, second, 0, time.UTC)
+}
+
+func TestTimePicker_Renders(t *testing.T) {
+	state := NewTimePickerState(timePickerTestTime(21, 5, 9))
+
+	assert.Equal(t, "21:05", strings.TrimRight(screenText(TimePicker{ID: "alarm", State: state}, 12, 1), " "))
+	assert.Equal(t, "09:05:09 PM", strings.TrimRight(screenText(TimePicker{ID: "alarm", State: state, Use12Hour: true, ShowSeconds: true}, 12, 1), " "))
+}
+
+func TestTimePicker_StepWraps(t *testing.T) {
+	state := NewTimePickerState(timePickerTestTime(23, 55, 0))
+	var changes []string
+	picker := TimePicker{
+		State:      state,
+		MinuteStep: 5,
+		OnChange:   func(t time.Time) { changes = append(changes, t.Format("15:04")) },
+	}
+
+	picker.step(timeSegmentMinute, 1)
+	picker.step(timeSegmentHour, 1)
+	picker.step(timeSegmentHour, -1)
+
+	assert.Equal(t, []string{"23:00", "00:00", "23:00"}, changes, "segments wrap without carrying into the next")
+	assert.Equal(t, timePickerTestTime(23, 0, 0), state.GetTime(), "the date is kept")
+}
+
+func TestTimePicker_TwelveHour(t *testing.T) {
+	state := NewTimePickerState(timePickerTestTime(0, 30, 0))
+	picker := TimePicker{State: state, Use12Hour: true}
+
+	assert.Equal(t, 12, picker.value(timeSegmentHour, state.GetTime()), "midnight is 12 AM")
+	picker.step(timeSegmentHour, 1)
+	assert.Equal(t, 1, state.GetTime().Hour())
+	picker.set(timeSegmentPeriod, 1)
+	assert.Equal(t, 13, state.GetTime().Hour())
+	picker.set(timeSegmentHour, 12)
+	assert.Equal(t, 12, state.GetTime().Hour(), "12 PM is noon")
+}
+
+func TestTimePicker_TypedDigits(t *testing.T) {
+	state := NewTimePickerState(timePickerTestTime(9, 30, 0))
+	picker := TimePicker{State: state}
+
+	assert.False(t, picker.typeDigit(timeSegmentHour, 1), "1 could start 10 to 19")
+	assert.Equal(t, 1, state.GetTime().Hour())
+	assert.True(t, picker.typeDigit(timeSegmentHour, 5))
+	assert.Equal(t, 15, state.GetTime().Hour())
+
+	assert.True(t, picker.typeDigit(timeSegmentHour, 7), "no hour starts with 7")
+	assert.Equal(t, 7, state.GetTime().Hour())
+
+	assert.False(t, picker.typeDigit(timeSegmentMinute, 4))
+	assert.True(t, picker.typeDigit(timeSegmentMinute, 5))
+	assert.Equal(t, 45, state.GetTime().Minute())
+
+	picker.typeDigit(timeSegmentHour, 2)
+	assert.True(t, picker.typeDigit(timeSegmentHour, 9), "29 isn't an hour, so 9 is taken on its own")
+	assert.Equal(t, 9, state.GetTime().Hour())
+}
+
+func TestTimePicker_SegmentsAreFocusable(t *testing.T) {
+	state := NewTimePickerState(timePickerTestTime(9, 30, 0))
+	buf := renderToBufferWithFocus(TimePicker{ID: "alarm", State: state}, 12, 1, "alarm-minute")
+
+	assert.Equal(t, "09:30", strings.TrimRight(bufferLine(buf, 0, 12), " "))
+	theme := getTheme()
+	assert.Equal(t, theme.ActiveCursor.toANSI(), buf.CellAt(3, 0).Style.Bg, "the focused segment is highlighted")
+	assert.NotEqual(t, theme.ActiveCursor.toANSI(), buf.CellAt(0, 0).Style.Bg)
+}
@@ -0,0 +1,115 @@
+package terma
+
+// TitleBar is a one-line header for full-screen apps: an optional menu
+// trigger, the app title, right-aligned action widgets and window controls.
+// Double-clicking an empty part of the bar calls OnZoom, like a desktop
+// window's title bar.
+//
+// Example (zooming the left pane of a SplitPane):
+//
+//	TitleBar{
+//	    Title:    "Mail",
+//	    Subtitle: "Inbox",
+//	    OnMenu:   func() { menuOpen.Set(true) },
+//	    Actions:  []Widget{Button{Label: "New", OnPress: compose}},
+//	    OnZoom: func() {
+//	        if split.GetPosition() < 1 {
+//	            split.SetPosition(1)
+//	        } else {
+//	            split.SetPosition(0.3)
+//	        }
+//	    },
+//	    OnClose: Quit,
+//	}
+type TitleBar struct {
+	ID       string   // Optional unique identifier for the widget
+	Title    string   // App or screen title, shown in bold
+	Subtitle string   // Optional text shown muted after the title
+	OnMenu   func()   // Optional: shows a menu trigger (≡) at the left, called when clicked
+	Actions  []Widget // Optional widgets aligned to the right, before the window controls
+	OnZoom   func()   // Optional: shows a zoom control (□), also called on double-click
+	OnClose  func()   // Optional: shows a close control (✕)
+	Style    Style    // Optional styling (defaults to the theme's surface colors)
+}
+
+// WidgetID returns the title bar's unique identifier.
+// Implements the Identifiable interface.
+func (b TitleBar) WidgetID() string {
+	return b.ID
+}
+
+// Build composes the title bar from a Row of text and the action widgets.
+func (b TitleBar) Build(ctx BuildContext) Widget {
+	theme := ctx.Theme()
+
+	style := b.Style
+	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
+		style.BackgroundColor = theme.Surface
+	}
+	if style.ForegroundColor == nil || !style.ForegroundColor.IsSet() {
+		style.ForegroundColor = theme.Text
+	}
+	if style.Width.IsUnset() {
+		style.Width = Flex(1)
+	}
+	if style.Padding == (EdgeInsets{}) {
+		style.Padding = EdgeInsetsXY(1, 0)
+	}
+
+	var children []Widget
+	if b.OnMenu != nil {
+		children = append(children, b.control("menu", "≡", b.OnMenu))
+	}
+
+	spans := []Span{BoldSpan(b.Title)}
+	if b.Subtitle != "" {
+		spans = append(spans, ColorSpan("  "+b.Subtitle, theme.TextMuted))
+	}
+	children = append(children, Text{
+		Spans: spans,
+		Style: Style{Width: Flex(1)},
+		Click: b.OnClick,
+	})
+
+	children = append(children, b.Actions...)
+	if b.OnZoom != nil {
+		children = append(children, b.control("zoom", "□", b.OnZoom))
+	}
+	if b.OnClose != nil {
+		children = append(children, b.control("close", "✕", b.OnClose))
+	}
+
+	return Row{
+		ID:         b.ID,
+		Style:      style,
+		Spacing:    1,
+		CrossAlign: CrossAxisCenter,
+		Children:   children,
+	}
+}
+
+// OnClick calls OnZoom when the bar is double-clicked.
+// Implements the Clickable interface.
+func (b TitleBar) OnClick(event MouseEvent) {
+	if event.ClickCount == 2 && b.OnZoom != nil {
+		b.OnZoom()
+	}
+}
+
+// control returns a clickable glyph for a window control.
+func (b TitleBar) control(name, glyph string, onClick func()) Widget {
+	id := ""
+	if b.ID != "" {
+		id = b.ID + "-" + name
+	}
+	return Text{
+		ID:      id,
+		Content: glyph,
+		Style: Style{
+			HoverStyle: &Style{Reverse: true},
+		},
+		Click: func(MouseEvent) {
+			onClick()
+		},
+	}
+}
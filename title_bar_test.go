@@ -0,0 +1,57 @@
+package terma
+
+import (
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_TitleBar(t *testing.T) {
+	widget := TitleBar{
+		Title:    "Mail",
+		Subtitle: "Inbox",
+		OnMenu:   func() {},
+		Actions:  []Widget{Text{Content: "3 unread"}},
+		OnZoom:   func() {},
+		OnClose:  func() {},
+	}
+	AssertSnapshot(t, widget, 40, 1,
+		"One-line title bar on the surface color: ≡ menu trigger, bold 'Mail' then muted 'Inbox', with '3 unread', □ and ✕ aligned to the right.")
+}
+
+func TestTitleBar_Controls(t *testing.T) {
+	var menus, zooms, closes int
+	widget := TitleBar{
+		ID:      "bar",
+		Title:   "App",
+		OnMenu:  func() { menus++ },
+		OnZoom:  func() { zooms++ },
+		OnClose: func() { closes++ },
+	}
+
+	buf := uv.NewBuffer(20, 1)
+	renderer := NewRenderer(buf, 20, 1, NewFocusManager(), NewAnySignal[Focusable](nil), NewAnySignal[Widget](nil))
+	renderer.Render(widget)
+	assert.Equal(t, " ≡ App          □ ✕ ", bufferLine(buf, 0, 20))
+
+	click := func(x, clickCount int) {
+		entry := renderer.WidgetAt(x, 0)
+		require.NotNil(t, entry)
+		clickable, ok := entry.EventWidget.(Clickable)
+		require.True(t, ok)
+		clickable.OnClick(MouseEvent{X: x, ClickCount: clickCount})
+	}
+
+	click(1, 1)
+	click(16, 1)
+	click(17, 1)
+	click(10, 1)
+	assert.Equal(t, []int{1, 1, 0}, []int{menus, zooms, closes}, "single clicks on the title do nothing")
+
+	click(18, 1)
+	click(10, 2)
+	click(15, 2)
+	assert.Equal(t, []int{1, 3, 1}, []int{menus, zooms, closes}, "double-clicking the title or the gaps between controls zooms")
+}
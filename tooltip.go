@@ -1,6 +1,11 @@
 package terma
 
-import "github.com/darrenburns/terma/layout"
+import (
+	"sync"
+	"time"
+
+	"github.com/darrenburns/terma/layout"
+)
 
 // TooltipPosition specifies where the tooltip appears relative to the child.
 type TooltipPosition int
@@ -16,6 +21,23 @@ const (
 	TooltipRight
 )
 
+// TooltipTrigger specifies what shows a tooltip.
+type TooltipTrigger int
+
+const (
+	// TooltipOnFocusOrHover shows the tooltip while the child is focused or
+	// after the pointer has rested on it for HoverDelay (default).
+	TooltipOnFocusOrHover TooltipTrigger = iota
+	// TooltipOnFocus shows the tooltip only while the child is focused.
+	TooltipOnFocus
+	// TooltipOnHover shows the tooltip only after the pointer has rested on
+	// the child for HoverDelay.
+	TooltipOnHover
+)
+
+// defaultTooltipHoverDelay is how long the pointer rests on a child before
+// its tooltip shows, unless HoverDelay says otherwise.
+const defaultTooltipHoverDelay = 500 * time.Millisecond
 
 // Tooltip displays contextual help when hovering over or focusing on an element.
 // It wraps a child widget and shows a floating tooltip based on the configured trigger.
@@ -34,6 +56,7 @@ const (
 //	    ID:       "password-tooltip",
 //	    Content:  "3-20 characters",
 //	    Position: TooltipRight,
+//	    Trigger:  TooltipOnFocus,
 //	    Child:    TextInput{ID: "password", State: passState},
 //	}
 //
@@ -71,6 +94,15 @@ type Tooltip struct {
 
 	// Style overrides the default tooltip styling.
 	Style Style
+
+	// Trigger specifies what shows the tooltip.
+	// Default: TooltipOnFocusOrHover
+	Trigger TooltipTrigger
+
+	// HoverDelay is how long the pointer must rest on the child before the
+	// tooltip shows. A negative delay shows it as soon as the pointer arrives.
+	// Default: 500ms
+	HoverDelay time.Duration
 }
 
 // WidgetID returns the tooltip's ID for anchor positioning.
@@ -97,15 +129,20 @@ func (t Tooltip) Build(ctx BuildContext) Widget {
 	if visible {
 		// Get the anchor ID (explicit or auto-generated)
 		anchorID := t.anchorID(ctx)
-		Floating{
-			Visible: true,
-			Config: FloatConfig{
-				AnchorID: anchorID,
-				Anchor:   t.anchorPoint(),
-				Offset:   t.offsetValue(),
-			},
-			Child: t.buildContent(ctx),
-		}.Build(ctx)
+		if ctx.floatCollector != nil {
+			// Registered directly rather than through Floating so the tooltip
+			// is drawn but never hovered itself, which would hide it.
+			ctx.floatCollector.Add(FloatEntry{
+				Config: FloatConfig{
+					AnchorID: anchorID,
+					Anchor:   t.anchorPoint(),
+					Offset:   t.offsetValue(),
+					Flip:     true,
+				},
+				Child:         t.buildContent(ctx),
+				ignorePointer: true,
+			})
+		}
 	}
 
 	// Return self - Tooltip acts as the anchor widget
@@ -122,33 +159,73 @@ func (t Tooltip) ChildWidgets() []Widget {
 
 // BuildLayoutNode wraps the child in a container for layout.
 func (t Tooltip) BuildLayoutNode(ctx BuildContext) layout.LayoutNode {
+	return wrapChildLayoutNode(ctx, t.Child)
+}
+
+// isVisible determines if the tooltip should be shown: when the child is
+// focused, or once the pointer has rested on it, depending on Trigger.
+func (t Tooltip) isVisible(ctx BuildContext) bool {
 	if t.Child == nil {
-		return &layout.BoxNode{}
+		return false
 	}
-
-	childCtx := ctx.PushChild(0)
-
-	// Build the child to get its layout node
-	built := t.Child.Build(childCtx)
-	var childNode layout.LayoutNode
-	if builder, ok := built.(LayoutNodeBuilder); ok {
-		childNode = builder.BuildLayoutNode(childCtx)
-	} else {
-		childNode = &layout.BoxNode{}
+	focused := t.Trigger != TooltipOnHover && ctx.IsFocused(t.Child)
+	hovered := false
+	if t.Trigger != TooltipOnFocus {
+		hovered = t.hoverShown(ctx)
 	}
+	return focused || hovered
+}
 
-	// Wrap in ColumnNode so ComputedLayout.Children is populated
-	return &layout.ColumnNode{
-		Children: []layout.LayoutNode{childNode},
-	}
+// tooltipHover is the hover state of one tooltip's child.
+type tooltipHover struct {
+	shown Signal[bool]
+	timer Timer
 }
 
-// isVisible determines if the tooltip should be shown (when child is focused).
-func (t Tooltip) isVisible(ctx BuildContext) bool {
-	if t.Child == nil {
+// tooltipHovers holds the hover state of tooltips whose child the pointer
+// is on, by anchor ID.
+var tooltipHovers struct {
+	sync.Mutex
+	byID map[string]*tooltipHover
+}
+
+// hoverShown reports whether the pointer has rested on the child long
+// enough to show the tooltip. The first build after the pointer arrives
+// starts the delay; a rebuild after it has passed shows the tooltip.
+func (t Tooltip) hoverShown(ctx BuildContext) bool {
+	id := t.anchorID(ctx)
+	tooltipHovers.Lock()
+	defer tooltipHovers.Unlock()
+
+	if !ctx.HoverWithin(id) {
+		if hover := tooltipHovers.byID[id]; hover != nil {
+			if hover.timer != nil {
+				hover.timer.Stop()
+			}
+			delete(tooltipHovers.byID, id)
+		}
 		return false
 	}
-	return ctx.IsFocused(t.Child)
+
+	hover := tooltipHovers.byID[id]
+	if hover == nil {
+		if tooltipHovers.byID == nil {
+			tooltipHovers.byID = map[string]*tooltipHover{}
+		}
+		hover = &tooltipHover{shown: NewSignal(false)}
+		tooltipHovers.byID[id] = hover
+		delay := t.HoverDelay
+		if delay == 0 {
+			delay = defaultTooltipHoverDelay
+		}
+		if delay < 0 {
+			hover.shown.Set(true)
+		} else {
+			shown := hover.shown
+			hover.timer = currentClock().AfterFunc(delay, func() { shown.Set(true) })
+		}
+	}
+	return hover.shown.Get()
 }
 
 // anchorPoint maps TooltipPosition to AnchorPoint.
@@ -200,7 +277,7 @@ func (t Tooltip) tooltipStyle(ctx BuildContext) Style {
 	theme := ctx.Theme()
 
 	if style.BackgroundColor == nil || !style.BackgroundColor.IsSet() {
-		style.BackgroundColor = theme.Surface
+		style.BackgroundColor = theme.Token(TokenSurfaceFloating)
 	}
 	if style.ForegroundColor == nil || !style.ForegroundColor.IsSet() {
 		style.ForegroundColor = theme.Text
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	uv "github.com/charmbracelet/ultraviolet"
 	"github.com/stretchr/testify/assert"
@@ -444,3 +445,92 @@ func TestTooltip_AnchorPoint(t *testing.T) {
 		assert.Equal(t, tt.expected, tooltip.anchorPoint())
 	}
 }
+
+// hoverRenderer renders a widget with the pointer resting on the widget with
+// the given ID, as the app does after a mouse motion event.
+func hoverRenderer(width, height int, hovered Widget, hoveredID string) (*Renderer, *uv.Buffer) {
+	buf := uv.NewBuffer(width, height)
+	hoveredSignal := NewAnySignal[Widget](hovered)
+	renderer := NewRenderer(buf, width, height, NewFocusManager(), NewAnySignal[Focusable](nil), hoveredSignal)
+	renderer.setPointerState(hoveredID, "")
+	return renderer, buf
+}
+
+func TestTooltip_VisibleAfterHoverDelay(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	SetClock(clock)
+	defer SetClock(nil)
+
+	button := Button{ID: "hover-btn", Label: "Hover me"}
+	widget := Column{Children: []Widget{
+		Text{ID: "header", Content: "header"},
+		Tooltip{ID: "hover-tip", Content: "Hover tooltip!", Child: button},
+	}}
+	renderer, buf := hoverRenderer(30, 6, button, "hover-btn")
+
+	renderer.Render(widget)
+	renderer.Render(widget)
+	assert.NotContains(t, renderer.ScreenText(), "Hover tooltip!")
+
+	clock.Advance(400 * time.Millisecond)
+	renderer.Render(widget)
+	assert.NotContains(t, renderer.ScreenText(), "Hover tooltip!")
+
+	clock.Advance(100 * time.Millisecond)
+	renderer.Render(widget)
+	assert.Equal(t, " Hover tooltip! ", bufferLine(buf, 0, 30)[:16])
+	assert.Equal(t, "header", renderer.WidgetAt(2, 0).ID, "the tooltip shouldn't take the hover")
+
+	renderer.setPointerState("", "")
+	renderer.hoveredSignal.Set(nil)
+	renderer.Render(widget)
+	assert.NotContains(t, renderer.ScreenText(), "Hover tooltip!")
+}
+
+func TestTooltip_OnFocusIgnoresHover(t *testing.T) {
+	button := Button{ID: "focus-only-btn", Label: "Hover me"}
+	widget := Tooltip{ID: "focus-only-tip", Content: "Focus only", Trigger: TooltipOnFocus, HoverDelay: -1, Child: button}
+	renderer, _ := hoverRenderer(30, 6, button, "focus-only-btn")
+
+	renderer.Render(widget)
+	renderer.Render(widget)
+
+	assert.NotContains(t, renderer.ScreenText(), "Focus only")
+}
+
+func TestTooltip_FlipsAwayFromScreenEdge(t *testing.T) {
+	widget := Tooltip{
+		Content: "Below",
+		Child:   Button{ID: "edge-btn", Label: "Edge"},
+	}
+
+	buf := renderToBufferWithFocus(widget, 20, 4, "edge-btn")
+
+	assert.Contains(t, bufferLine(buf, 0, 20), "Edge")
+	assert.Contains(t, bufferLine(buf, 1, 20), "Below", "a top tooltip on the top row should flip below its child")
+}
+
+func TestFlipAnchorPosition(t *testing.T) {
+	anchor := &WidgetEntry{Bounds: Rect{X: 10, Y: 1, Width: 6, Height: 1}}
+
+	x, y := flipAnchorPosition(anchor, AnchorTopLeft, 4, 3, Offset{Y: -1}, 40, 20)
+	assert.Equal(t, 10, x)
+	assert.Equal(t, 3, y, "no room above, so below with the gap mirrored")
+
+	x, _ = flipAnchorPosition(anchor, AnchorRightCenter, 28, 1, Offset{X: 1}, 40, 20)
+	assert.Equal(t, 17, x, "fits on neither side, so it stays put for clamping")
+
+	x, _ = flipAnchorPosition(anchor, AnchorRightCenter, 20, 1, Offset{}, 40, 20)
+	assert.Equal(t, 16, x)
+	x, _ = flipAnchorPosition(anchor, AnchorLeftCenter, 8, 1, Offset{}, 40, 20)
+	assert.Equal(t, 2, x)
+	x, _ = flipAnchorPosition(anchor, AnchorLeftCenter, 12, 1, Offset{}, 40, 20)
+	assert.Equal(t, 16, x)
+}
+
+func TestOppositeAnchor(t *testing.T) {
+	assert.Equal(t, AnchorBottomCenter, oppositeAnchor(AnchorTopCenter))
+	assert.Equal(t, AnchorTopRight, oppositeAnchor(AnchorBottomRight))
+	assert.Equal(t, AnchorRightBottom, oppositeAnchor(AnchorLeftBottom))
+	assert.Equal(t, AnchorLeftCenter, oppositeAnchor(AnchorRightCenter))
+}
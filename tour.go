@@ -0,0 +1,284 @@
+package terma
+
+import "fmt"
+
+// TourStep is one coach mark in a Tour.
+type TourStep struct {
+	TargetID string      // ID of the widget to highlight
+	Title    string      // Short heading shown in the popover
+	Body     string      // Explanatory text shown in the popover
+	Anchor   AnchorPoint // Where the popover attaches to the target (default = AnchorBottomLeft)
+}
+
+// TourState holds the progress of a Tour. Completion is persisted to the
+// store under "tour:<name>", so a finished or skipped tour is not shown again.
+type TourState struct {
+	Name  string
+	Steps []TourStep
+
+	index       Signal[int] // -1 when the tour isn't running
+	completed   Signal[bool]
+	store       SettingsStore
+	focusedStep int
+}
+
+// NewTourState creates a tour with the given steps. The store may be nil,
+// in which case completion only lasts for the lifetime of the state.
+// The same store can be shared with SettingsState; tours only touch their own key.
+func NewTourState(name string, steps []TourStep, store SettingsStore) *TourState {
+	completed := false
+	if store != nil {
+		stored, err := store.Load()
+		if err != nil {
+			Log("Tour: %v", err)
+		}
+		completed, _ = stored[tourStoreKey(name)].(bool)
+	}
+	return &TourState{
+		Name:        name,
+		Steps:       steps,
+		index:       NewSignal(-1),
+		completed:   NewSignal(completed),
+		store:       store,
+		focusedStep: -1,
+	}
+}
+
+// tourStoreKey returns the key a tour's completion is stored under.
+func tourStoreKey(name string) string {
+	return "tour:" + name
+}
+
+// Start shows the first step, unless the tour has already been completed.
+func (s *TourState) Start() {
+	if s.completed.Peek() || len(s.Steps) == 0 {
+		return
+	}
+	s.index.Set(0)
+}
+
+// Restart clears the completed flag and shows the first step again.
+func (s *TourState) Restart() {
+	s.setCompleted(false)
+	s.Start()
+}
+
+// Next advances to the next step. On the last step it finishes the tour.
+func (s *TourState) Next() {
+	i := s.index.Peek()
+	if i < 0 {
+		return
+	}
+	if i >= len(s.Steps)-1 {
+		s.finish()
+		return
+	}
+	s.index.Set(i + 1)
+}
+
+// Back returns to the previous step.
+func (s *TourState) Back() {
+	if i := s.index.Peek(); i > 0 {
+		s.index.Set(i - 1)
+	}
+}
+
+// Skip ends the tour early. A skipped tour counts as completed.
+func (s *TourState) Skip() {
+	if s.index.Peek() >= 0 {
+		s.finish()
+	}
+}
+
+// IsActive reports whether a step is currently shown.
+// Subscribes to changes when called during Build.
+func (s *TourState) IsActive() bool {
+	return s.index.Get() >= 0
+}
+
+// IsCompleted reports whether the tour has been finished or skipped.
+// Subscribes to changes when called during Build.
+func (s *TourState) IsCompleted() bool {
+	return s.completed.Get()
+}
+
+// CurrentStep returns the index of the shown step, or -1 when inactive.
+// Subscribes to changes when called during Build.
+func (s *TourState) CurrentStep() int {
+	return s.index.Get()
+}
+
+// finish hides the tour and records its completion.
+func (s *TourState) finish() {
+	s.index.Set(-1)
+	s.setCompleted(true)
+}
+
+// setCompleted updates the completed flag and persists it, keeping any
+// other values in the store intact.
+func (s *TourState) setCompleted(completed bool) {
+	s.completed.Set(completed)
+	if s.store == nil {
+		return
+	}
+	stored, err := s.store.Load()
+	if err != nil {
+		Log("Tour: %v", err)
+	}
+	values := map[string]any{}
+	for key, value := range stored {
+		values[key] = value
+	}
+	if completed {
+		values[tourStoreKey(s.Name)] = true
+	} else {
+		delete(values, tourStoreKey(s.Name))
+	}
+	if err := s.store.Save(values); err != nil {
+		Log("Tour: %v", err)
+	}
+}
+
+// Tour walks the user through an interface one widget at a time. While a
+// step is shown, everything except the target widget is dimmed, the target
+// is outlined with a ring, and a popover next to it explains what it does.
+// Escape skips the tour.
+//
+// Example:
+//
+//	tour := t.NewTourState("welcome", []t.TourStep{
+//	    {TargetID: "search", Title: "Search", Body: "Find files by name."},
+//	    {TargetID: "results", Title: "Results", Body: "Press Enter to open one."},
+//	}, store)
+//	tour.Start()
+//
+//	// In Build, alongside the rest of the UI:
+//	t.Tour{State: tour}
+type Tour struct {
+	ID        string     // Optional unique identifier
+	State     *TourState // Required
+	RingColor Color      // Ring around the target (default = theme FocusRing)
+	Width     int        // Popover width in cells (default = 40)
+}
+
+// WidgetID returns the widget's unique identifier.
+func (t Tour) WidgetID() string {
+	return t.ID
+}
+
+// Build registers the current step's popover as a float and returns an
+// empty widget. Nothing is shown when the tour isn't running.
+func (t Tour) Build(ctx BuildContext) Widget {
+	if t.State == nil {
+		return EmptyWidget{}
+	}
+	index := t.State.index.Get()
+	if index < 0 || index >= len(t.State.Steps) {
+		t.State.focusedStep = -1
+		return EmptyWidget{}
+	}
+	step := t.State.Steps[index]
+
+	tourID := t.ID
+	if tourID == "" {
+		tourID = ctx.AutoID()
+	}
+	nextID := tourID + "-next"
+	if t.State.focusedStep != index {
+		t.State.focusedStep = index
+		ctx.RequestFocus(nextID)
+	}
+
+	anchor := step.Anchor
+	if anchor == AnchorUnset {
+		anchor = AnchorBottomLeft
+	}
+
+	if ctx.floatCollector != nil {
+		ctx.floatCollector.Add(FloatEntry{
+			Config: FloatConfig{
+				AnchorID:  step.TargetID,
+				Anchor:    anchor,
+				Offset:    tourPopoverOffset(anchor),
+				Modal:     true,
+				OnDismiss: t.State.Skip,
+			},
+			Child:     t.popover(ctx, index, nextID),
+			spotlight: true,
+			ringColor: t.RingColor,
+		})
+	}
+	return EmptyWidget{}
+}
+
+// popover builds the explanatory card for the step at index.
+func (t Tour) popover(ctx BuildContext, index int, nextID string) Widget {
+	theme := ctx.Theme()
+	state := t.State
+	step := state.Steps[index]
+
+	width := t.Width
+	if width <= 0 {
+		width = 40
+	}
+
+	nextLabel := "Next"
+	if index == len(state.Steps)-1 {
+		nextLabel = "Done"
+	}
+	buttons := []Widget{
+		Button{ID: nextID + "-skip", Label: "Skip", OnPress: state.Skip},
+	}
+	if index > 0 {
+		buttons = append(buttons, Button{ID: nextID + "-back", Label: "Back", OnPress: state.Back})
+	}
+	buttons = append(buttons, Button{ID: nextID, Label: nextLabel, OnPress: state.Next})
+
+	var children []Widget
+	if step.Title != "" {
+		children = append(children, Text{Content: step.Title, Style: Style{Bold: true}})
+	}
+	if step.Body != "" {
+		children = append(children, Text{Content: step.Body, Wrap: WrapSoft})
+	}
+	children = append(children, Row{
+		Spacing:    2,
+		CrossAlign: CrossAxisCenter,
+		Children: []Widget{
+			Text{
+				Content: fmt.Sprintf("Step %d of %d", index+1, len(state.Steps)),
+				Style:   Style{ForegroundColor: theme.TextMuted},
+			},
+			Spacer{Width: Flex(1)},
+			Row{Spacing: 1, Children: buttons},
+		},
+	})
+
+	return Column{
+		Spacing: 1,
+		Style: Style{
+			Width:           Cells(width),
+			BackgroundColor: theme.Surface,
+			ForegroundColor: theme.Text,
+			Padding:         EdgeInsetsXY(2, 1),
+			Border:          RoundedBorder(theme.Border),
+		},
+		Children: children,
+	}
+}
+
+// tourPopoverOffset moves the popover one cell further out from the
+// target so it doesn't cover the spotlight ring.
+func tourPopoverOffset(anchor AnchorPoint) Offset {
+	switch anchor {
+	case AnchorTopLeft, AnchorTopCenter, AnchorTopRight:
+		return Offset{Y: -1}
+	case AnchorBottomLeft, AnchorBottomCenter, AnchorBottomRight:
+		return Offset{Y: 1}
+	case AnchorLeftTop, AnchorLeftCenter, AnchorLeftBottom:
+		return Offset{X: -1}
+	case AnchorRightTop, AnchorRightCenter, AnchorRightBottom:
+		return Offset{X: 1}
+	}
+	return Offset{}
+}
@@ -0,0 +1,119 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTourSteps() []TourStep {
+	return []TourStep{
+		{TargetID: "first", Title: "First", Body: "The first widget."},
+		{TargetID: "second", Title: "Second", Body: "The second widget."},
+	}
+}
+
+func TestTourState_StepsThroughAndCompletes(t *testing.T) {
+	store := &memorySettingsStore{values: map[string]any{"theme": "dark"}}
+	tour := NewTourState("welcome", testTourSteps(), store)
+
+	assert.False(t, tour.IsActive())
+	tour.Start()
+	assert.Equal(t, 0, tour.CurrentStep())
+
+	tour.Back()
+	assert.Equal(t, 0, tour.CurrentStep(), "Back on the first step is a no-op")
+
+	tour.Next()
+	assert.Equal(t, 1, tour.CurrentStep())
+	tour.Back()
+	assert.Equal(t, 0, tour.CurrentStep())
+	tour.Next()
+	tour.Next()
+
+	assert.False(t, tour.IsActive())
+	assert.True(t, tour.IsCompleted())
+	assert.Equal(t, true, store.values["tour:welcome"])
+	assert.Equal(t, "dark", store.values["theme"], "other stored values are kept")
+}
+
+func TestTourState_SkipCountsAsCompleted(t *testing.T) {
+	store := &memorySettingsStore{}
+	tour := NewTourState("welcome", testTourSteps(), store)
+
+	tour.Skip()
+	assert.Equal(t, 0, store.saves, "skipping an inactive tour does nothing")
+
+	tour.Start()
+	tour.Skip()
+	assert.False(t, tour.IsActive())
+	assert.True(t, tour.IsCompleted())
+}
+
+func TestTourState_CompletedTourDoesNotStartAgain(t *testing.T) {
+	store := &memorySettingsStore{values: map[string]any{"tour:welcome": true}}
+	tour := NewTourState("welcome", testTourSteps(), store)
+
+	assert.True(t, tour.IsCompleted())
+	tour.Start()
+	assert.False(t, tour.IsActive())
+
+	tour.Restart()
+	assert.True(t, tour.IsActive())
+	assert.False(t, tour.IsCompleted())
+	_, stored := store.values["tour:welcome"]
+	assert.False(t, stored)
+}
+
+func TestTour_SpotlightsTarget(t *testing.T) {
+	tour := NewTourState("welcome", testTourSteps(), nil)
+	layout := func(children ...Widget) Widget {
+		return Column{
+			Style: Style{Padding: EdgeInsets{Top: 1}},
+			Children: append([]Widget{
+				Text{Content: "header"},
+				Row{Children: []Widget{
+					Text{Content: "  "},
+					Text{ID: "first", Content: "target"},
+				}},
+			}, children...),
+		}
+	}
+	plain := RenderToBuffer(layout(), 60, 16)
+
+	tour.Start()
+	buf := RenderToBuffer(layout(Tour{ID: "tour", State: tour}), 60, 16)
+	assert.Equal(t, "╭", buf.CellAt(1, 1).Content, "ring top-left corner")
+	assert.Equal(t, "╮", buf.CellAt(8, 1).Content, "ring top-right corner")
+	assert.Equal(t, "│", buf.CellAt(1, 2).Content)
+	assert.Equal(t, "╰", buf.CellAt(1, 3).Content)
+	assert.True(t, strings.HasPrefix(bufferLine(buf, 2, 60), " │target│"))
+
+	// The target keeps its colours while the rest of the screen is dimmed.
+	assert.Equal(t, plain.CellAt(2, 2).Style, buf.CellAt(2, 2).Style)
+	assert.NotEqual(t, plain.CellAt(0, 0).Style, buf.CellAt(0, 0).Style)
+
+	// The popover sits below the ring.
+	var popover string
+	for y := 4; y < 16; y++ {
+		popover += bufferLine(buf, y, 60) + "\n"
+	}
+	assert.Contains(t, popover, "First")
+	assert.Contains(t, popover, "Step 1 of 2")
+	assert.Contains(t, popover, "Next")
+	assert.NotContains(t, popover, "Back")
+}
+
+func TestTour_HiddenWhenInactive(t *testing.T) {
+	tour := NewTourState("welcome", testTourSteps(), nil)
+	widget := Column{Children: []Widget{
+		Text{ID: "first", Content: "target"},
+		Tour{State: tour},
+	}}
+
+	buf := RenderToBuffer(widget, 20, 4)
+	require.Equal(t, "target", strings.TrimSpace(bufferLine(buf, 0, 20)))
+	assert.Equal(t, "", strings.TrimSpace(bufferLine(buf, 1, 20)))
+}
@@ -24,6 +24,12 @@ type TreeState[T any] struct {
 	Collapsed  AnySignal[map[string]bool]     // Collapsed node identifiers
 	Selection  AnySignal[map[string]struct{}] // Selected node identifiers
 
+	// OnSelectionChange is called with the selected nodes after the
+	// selection changes. Selection follows nodes that move when Tree.NodeID
+	// is set; otherwise nodes are identified by path.
+	OnSelectionChange func(selected []T)
+
+	selection       *SelectionModel[string] // Created on first use
 	anchorPath      []int
 	viewPaths       [][]int
 	viewIndexByPath map[string]int
@@ -31,6 +37,8 @@ type TreeState[T any] struct {
 	indicatorLayout []treeIndicatorLayout
 	nodeID          func(T) string
 	eagerLoadOnce   sync.Once
+	filterMemo      *treeFilterMemo[T] // Node match results for the current query
+	typeAhead       *typeAheadBuffer   // Text typed for type-ahead (created on first use)
 }
 
 // NewTreeState creates a new TreeState with the given root nodes.
@@ -50,6 +58,13 @@ func NewTreeState[T any](roots []TreeNode[T]) *TreeState[T] {
 	}
 }
 
+func (s *TreeState[T]) typeAheadBuffer() *typeAheadBuffer {
+	if s.typeAhead == nil {
+		s.typeAhead = newTypeAheadBuffer()
+	}
+	return s.typeAhead
+}
+
 // CursorUp moves the cursor to the previous visible node.
 func (s *TreeState[T]) CursorUp() {
 	s.moveCursor(-1)
@@ -205,27 +220,31 @@ func (s *TreeState[T]) SetChildren(path []int, children []TreeNode[T]) {
 	})
 }
 
+// SelectionModel returns the model behind Selection, for code shared with
+// other selectable widgets. Keys are node identifiers (see Tree.NodeID).
+func (s *TreeState[T]) SelectionModel() *SelectionModel[string] {
+	if s.selection == nil {
+		if !s.Selection.IsValid() {
+			s.Selection = NewAnySignal(make(map[string]struct{}))
+		}
+		s.selection = &SelectionModel[string]{Selection: s.Selection}
+		s.selection.OnSelectionChange = func(map[string]struct{}) {
+			if s.OnSelectionChange != nil {
+				s.OnSelectionChange(s.selectedNodes())
+			}
+		}
+	}
+	return s.selection
+}
+
 // ToggleSelection toggles selection for the node at the given path.
 func (s *TreeState[T]) ToggleSelection(path []int) {
 	if s == nil || !s.Selection.IsValid() {
 		return
 	}
-	id := s.idForPath(path)
-	if id == "" {
-		return
+	if id := s.idForPath(path); id != "" {
+		s.SelectionModel().Toggle(id)
 	}
-	s.Selection.Update(func(sel map[string]struct{}) map[string]struct{} {
-		next := make(map[string]struct{}, len(sel))
-		for k := range sel {
-			next[k] = struct{}{}
-		}
-		if _, exists := next[id]; exists {
-			delete(next, id)
-		} else {
-			next[id] = struct{}{}
-		}
-		return next
-	})
 }
 
 // Select adds the node at the given path to the selection.
@@ -233,18 +252,9 @@ func (s *TreeState[T]) Select(path []int) {
 	if s == nil || !s.Selection.IsValid() {
 		return
 	}
-	id := s.idForPath(path)
-	if id == "" {
-		return
+	if id := s.idForPath(path); id != "" {
+		s.SelectionModel().Select(id)
 	}
-	s.Selection.Update(func(sel map[string]struct{}) map[string]struct{} {
-		next := make(map[string]struct{}, len(sel)+1)
-		for k := range sel {
-			next[k] = struct{}{}
-		}
-		next[id] = struct{}{}
-		return next
-	})
 }
 
 // Deselect removes the node at the given path from the selection.
@@ -252,18 +262,9 @@ func (s *TreeState[T]) Deselect(path []int) {
 	if s == nil || !s.Selection.IsValid() {
 		return
 	}
-	id := s.idForPath(path)
-	if id == "" {
-		return
+	if id := s.idForPath(path); id != "" {
+		s.SelectionModel().Deselect(id)
 	}
-	s.Selection.Update(func(sel map[string]struct{}) map[string]struct{} {
-		next := make(map[string]struct{}, len(sel))
-		for k := range sel {
-			next[k] = struct{}{}
-		}
-		delete(next, id)
-		return next
-	})
 }
 
 // ClearSelection clears all selected nodes.
@@ -271,7 +272,52 @@ func (s *TreeState[T]) ClearSelection() {
 	if s == nil || !s.Selection.IsValid() {
 		return
 	}
-	s.Selection.Set(make(map[string]struct{}))
+	s.SelectionModel().Clear()
+}
+
+// SelectAllVisible selects every node currently shown (those matching the
+// filter and their ancestors, with collapsed subtrees hidden), replacing the
+// selection.
+func (s *TreeState[T]) SelectAllVisible() {
+	if s == nil || !s.Selection.IsValid() {
+		return
+	}
+	s.SelectionModel().SelectAll(s.visibleIDs())
+}
+
+// InvertSelection flips the selection of every node currently shown.
+// Hidden nodes keep their state.
+func (s *TreeState[T]) InvertSelection() {
+	if s == nil || !s.Selection.IsValid() {
+		return
+	}
+	s.SelectionModel().Invert(s.visibleIDs())
+}
+
+// visibleIDs returns the identifiers of the nodes in the last built view.
+func (s *TreeState[T]) visibleIDs() []string {
+	ids := make([]string, 0, len(s.viewPaths))
+	for _, path := range s.viewPaths {
+		if id := s.idForPath(path); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// selectedNodes returns the data of the selected nodes in pre-order.
+func (s *TreeState[T]) selectedNodes() []T {
+	paths := s.SelectedPaths()
+	if len(paths) == 0 {
+		return nil
+	}
+	nodes := make([]T, 0, len(paths))
+	for _, path := range paths {
+		if node, ok := s.NodeAtPath(path); ok {
+			nodes = append(nodes, node.Data)
+		}
+	}
+	return nodes
 }
 
 // IsSelected returns true if the node at the given path is selected.
@@ -494,6 +540,7 @@ type Tree[T any] struct {
 	Height              Dimension // Deprecated: use Style.Height
 	Style               Style
 	MultiSelect         bool
+	TypeAhead           *TypeAhead[T]
 	CursorStyle         // Embedded - CursorPrefix/SelectedPrefix for optional indicators
 	Indent              int
 	ShowGuideLines      *bool
@@ -592,8 +639,8 @@ func (t Tree[T]) OnMouseDown(event MouseEvent) {
 		return
 	}
 
-	localX := event.LocalX - t.Style.Border.Width() - t.Style.Padding.Left
-	localY := event.LocalY - t.Style.Border.Width() - t.Style.Padding.Top
+	localX := event.LocalX - t.Style.Border.Insets().Left - t.Style.Padding.Left
+	localY := event.LocalY - t.Style.Border.Insets().Top - t.Style.Padding.Top
 	viewIdx, ok := t.viewIndexFromMouseY(localY)
 	if !ok {
 		return
@@ -722,6 +769,9 @@ func (t Tree[T]) Build(ctx BuildContext) Widget {
 	}
 
 	t.State.nodeID = t.NodeID
+	if t.TypeAhead != nil && !t.TypeAhead.HideOverlay {
+		t.State.typeAheadBuffer().overlay(ctx, t.anchorID(ctx))
+	}
 
 	nodes := t.State.Nodes.Get()
 	query, options := filterStateValues(t.Filter)
@@ -863,7 +913,44 @@ func (t Tree[T]) Build(ctx BuildContext) Widget {
 
 // OnKey handles keys not covered by declarative keybindings.
 func (t Tree[T]) OnKey(event KeyEvent) bool {
-	return false
+	if t.TypeAhead == nil || t.State == nil {
+		return false
+	}
+	return t.State.typeAheadBuffer().handleKey(event, t.TypeAhead.timeout(), t.typeAheadJump)
+}
+
+// typeAheadJump moves the cursor to the visible node matching the typed text.
+func (t Tree[T]) typeAheadJump(query string) {
+	view := t.viewPaths()
+	if len(view) == 0 {
+		return
+	}
+	cursor := t.ensureCursor(view, t.State.CursorPath.Peek())
+	cursorViewIdx, _ := t.viewIndexForPath(cursor)
+	nodes := t.State.Nodes.Peek()
+	label := func(viewIdx int) string {
+		node, _ := nodeAtPath(nodes, view[viewIdx])
+		return t.TypeAhead.label(node.Data)
+	}
+	viewIdx, ok := typeAheadMatch(len(view), cursorViewIdx, label, query, t.TypeAhead.Fuzzy)
+	if !ok || viewIdx == cursorViewIdx {
+		return
+	}
+	if t.MultiSelect {
+		t.State.ClearSelection()
+		t.State.clearAnchor()
+	}
+	t.setCursorToViewIndex(viewIdx)
+	t.scrollCursorIntoView()
+	t.notifyCursorChange()
+}
+
+// anchorID returns the ID the type-ahead overlay is positioned against.
+func (t Tree[T]) anchorID(ctx BuildContext) string {
+	if t.ID != "" {
+		return t.ID
+	}
+	return ctx.AutoID()
 }
 
 // Keybinds returns the declarative keybindings for this tree.
@@ -896,8 +983,12 @@ func (t Tree[T]) Keybinds() []Keybind {
 			Keybind{Key: "shift+j", Action: t.shiftCursorDown, Hidden: true},
 			Keybind{Key: "shift+home", Action: t.shiftCursorToFirst, Hidden: true},
 			Keybind{Key: "shift+end", Action: t.shiftCursorToLast, Hidden: true},
+			Keybind{Key: "ctrl+a", Action: t.State.SelectAllVisible, Hidden: true},
 		)
 	}
+	if t.TypeAhead != nil {
+		binds = withoutTypeAheadKeys(binds)
+	}
 	return binds
 }
 
@@ -1204,10 +1295,78 @@ func (t Tree[T]) buildViewEntries(nodes []TreeNode[T], query string, options Fil
 	if query == "" {
 		return t.flattenVisible(nodes, nil, 0)
 	}
-	entries, _ := t.filterVisible(nodes, nil, 0, query, options, matchNode)
+	var memo *treeFilterMemo[T]
+	if t.State != nil {
+		if t.State.filterMemo == nil {
+			t.State.filterMemo = &treeFilterMemo[T]{}
+		}
+		memo = t.State.filterMemo
+		memo.begin(nodes, query, options)
+	}
+	entries, _ := t.filterVisible(nodes, nil, 0, query, options, matchNode, memo)
+	matches := 0
+	for _, entry := range entries {
+		if entry.match.Matched {
+			matches++
+		}
+	}
+	reportFilterResult(t.Filter, matches, false)
 	return entries
 }
 
+// treeFilterMemo caches node match results by path for the current query.
+// When the query extends the previous one, nodes that failed the previous
+// query are skipped without calling the matcher.
+type treeFilterMemo[T any] struct {
+	rootsPtr *TreeNode[T] // Identity of the roots the results belong to
+	rootsLen int
+	query    string
+	options  FilterOptions
+	results  map[string]MatchResult
+	misses   map[string]bool // Paths that failed the previous query
+}
+
+// begin prepares the memo for query, keeping what can be reused.
+func (m *treeFilterMemo[T]) begin(roots []TreeNode[T], query string, options FilterOptions) {
+	var ptr *TreeNode[T]
+	if len(roots) > 0 {
+		ptr = &roots[0]
+	}
+	sameRoots := ptr == m.rootsPtr && len(roots) == m.rootsLen
+	if sameRoots && query == m.query && sameFilterOptions(options, m.options) && m.results != nil {
+		return
+	}
+
+	m.misses = nil
+	if sameRoots && sameFilterOptions(options, m.options) && narrowsOnExtend(options) && m.query != "" && strings.HasPrefix(query, m.query) {
+		m.misses = make(map[string]bool)
+		for key, result := range m.results {
+			if !result.Matched {
+				m.misses[key] = true
+			}
+		}
+	}
+	m.rootsPtr = ptr
+	m.rootsLen = len(roots)
+	m.query = query
+	m.options = options
+	m.results = make(map[string]MatchResult)
+}
+
+// match returns the memoized result for the node at path.
+func (m *treeFilterMemo[T]) match(node T, path []int, matchNode func(node T, query string, options FilterOptions) MatchResult) MatchResult {
+	key := pathKey(path)
+	if result, ok := m.results[key]; ok {
+		return result
+	}
+	var result MatchResult
+	if !m.misses[key] {
+		result = matchNode(node, m.query, m.options)
+	}
+	m.results[key] = result
+	return result
+}
+
 func (t Tree[T]) flattenVisible(nodes []TreeNode[T], path []int, depth int) []treeViewEntry[T] {
 	entries := make([]treeViewEntry[T], 0)
 	for i, node := range nodes {
@@ -1229,13 +1388,18 @@ func (t Tree[T]) flattenVisible(nodes []TreeNode[T], path []int, depth int) []tr
 	return entries
 }
 
-func (t Tree[T]) filterVisible(nodes []TreeNode[T], path []int, depth int, query string, options FilterOptions, matchNode func(node T, query string, options FilterOptions) MatchResult) ([]treeViewEntry[T], bool) {
+func (t Tree[T]) filterVisible(nodes []TreeNode[T], path []int, depth int, query string, options FilterOptions, matchNode func(node T, query string, options FilterOptions) MatchResult, memo *treeFilterMemo[T]) ([]treeViewEntry[T], bool) {
 	entries := make([]treeViewEntry[T], 0)
 	hasMatch := false
 	for i, node := range nodes {
 		nextPath := appendPath(path, i)
-		match := matchNode(node.Data, query, options)
-		childEntries, childHasMatch := t.filterVisible(node.Children, nextPath, depth+1, query, options, matchNode)
+		var match MatchResult
+		if memo != nil {
+			match = memo.match(node.Data, nextPath, matchNode)
+		} else {
+			match = matchNode(node.Data, query, options)
+		}
+		childEntries, childHasMatch := t.filterVisible(node.Children, nextPath, depth+1, query, options, matchNode, memo)
 		if match.Matched || childHasMatch {
 			expandable := t.nodeExpandable(node)
 			entries = append(entries, treeViewEntry[T]{
@@ -1422,14 +1586,14 @@ func (t Tree[T]) selectViewRange(anchor, cursor []int) {
 	if anchorIdx > cursorIdx {
 		anchorIdx, cursorIdx = cursorIdx, anchorIdx
 	}
-	sel := make(map[string]struct{}, cursorIdx-anchorIdx+1)
+	sel := make([]string, 0, cursorIdx-anchorIdx+1)
 	for i := anchorIdx; i <= cursorIdx; i++ {
 		id := t.State.idForPath(view[i])
 		if id != "" {
-			sel[id] = struct{}{}
+			sel = append(sel, id)
 		}
 	}
-	t.State.Selection.Set(sel)
+	t.State.SelectionModel().SetSelected(sel)
 }
 
 func (t Tree[T]) scrollCursorIntoView() {
@@ -1533,17 +1697,7 @@ func (t Tree[T]) getSelectedNodes() []T {
 	if t.State == nil {
 		return nil
 	}
-	paths := t.State.SelectedPaths()
-	if len(paths) == 0 {
-		return nil
-	}
-	nodes := make([]T, 0, len(paths))
-	for _, path := range paths {
-		if node, ok := t.State.NodeAtPath(path); ok {
-			nodes = append(nodes, node.Data)
-		}
-	}
-	return nodes
+	return t.State.selectedNodes()
 }
 
 func defaultTreeMatchNode[T any](node T, query string, options FilterOptions) MatchResult {
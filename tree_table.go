@@ -0,0 +1,364 @@
+package terma
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// TreeTableRow is a visible row of a TreeTable: a node's data and where
+// it sits in the tree. For parents, Data is the aggregated value when
+// TreeTable.Aggregate is set.
+type TreeTableRow[T any] struct {
+	Data       T
+	Path       []int // Path to the node, as used by TreeState
+	Depth      int   // Nesting level (0 = root)
+	Expandable bool  // Has, or may load, children
+	Expanded   bool  // Children are shown below this row
+
+	id string // Node identifier (see TreeTable.NodeID)
+}
+
+// TreeTableState holds the state for a TreeTable widget. Tree holds the
+// nodes and which of them are collapsed; Table holds the visible rows with
+// the cursor and selection, which follow their nodes as rows above them
+// expand and collapse.
+type TreeTableState[T any] struct {
+	Tree  *TreeState[T]
+	Table *TableState[TreeTableRow[T]]
+
+	builtNodes     []TreeNode[T]   // Nodes the rows were last built from
+	builtCollapsed map[string]bool // Collapsed set the rows were last built from
+	built          bool
+}
+
+// NewTreeTableState creates a new TreeTableState with the given root nodes.
+func NewTreeTableState[T any](roots []TreeNode[T]) *TreeTableState[T] {
+	table := NewTableState[TreeTableRow[T]](nil)
+	table.KeyFor = func(row TreeTableRow[T]) string { return row.id }
+	return &TreeTableState[T]{
+		Tree:  NewTreeState(roots),
+		Table: table,
+	}
+}
+
+// CursorRow returns the row under the cursor.
+func (s *TreeTableState[T]) CursorRow() (TreeTableRow[T], bool) {
+	return s.Table.SelectedRow()
+}
+
+// SelectedRows returns the selected rows in display order.
+func (s *TreeTableState[T]) SelectedRows() []TreeTableRow[T] {
+	return s.Table.SelectedRows()
+}
+
+// TreeTable is a Table whose rows form a tree. The first column is
+// indented by depth and shows an expand/collapse toggle; children can be
+// loaded lazily when a node is first expanded, and parent rows can show
+// values aggregated from their children, such as directory sizes or span
+// durations.
+//
+// Example:
+//
+//	state := terma.NewTreeTableState(nodes)
+//	terma.TreeTable[Entry]{
+//	    State:   state,
+//	    Columns: []terma.TableColumn{{Header: terma.Text{Content: "Name"}, Width: terma.Flex(1)}, {Header: terma.Text{Content: "Size"}}},
+//	    Aggregate: func(dir Entry, children []Entry) Entry {
+//	        for _, child := range children {
+//	            dir.Size += child.Size
+//	        }
+//	        return dir
+//	    },
+//	    RenderCell: func(row terma.TreeTableRow[Entry], col int, active, selected bool) terma.Widget { ... },
+//	}
+//
+// Keys are the Table's, plus space to toggle the cursor row and, outside
+// TableSelectionCursor mode, left/right (h/l) to collapse or move to the
+// parent and to expand or move to the first child.
+type TreeTable[T any] struct {
+	ID                string                                                                     // Optional unique identifier
+	DisableFocus      bool                                                                       // If true, prevent keyboard focus
+	State             *TreeTableState[T]                                                         // Required - holds the tree and the table cursor
+	Columns           []TableColumn                                                              // Required - defines column count and widths
+	RenderCell        func(row TreeTableRow[T], colIndex int, active bool, selected bool) Widget // Cell renderer (default uses fmt); column 0 is indented after rendering
+	NodeID            func(data T) string                                                        // Optional stable node identity (default: path)
+	HasChildren       func(data T) bool                                                          // Reports whether an unloaded node (nil Children) has children
+	OnExpand          func(data T, path []int, setChildren func([]TreeNode[T]))                  // Loads children the first time a node is expanded
+	Aggregate         func(data T, children []T) T                                               // Optional value for parent rows from their loaded children's (aggregated) values
+	OnSelect          func(row TreeTableRow[T])                                                  // Callback invoked when Enter is pressed on a row
+	OnCursorChange    func(row TreeTableRow[T])                                                  // Callback invoked when the cursor moves to a different row
+	ScrollState       *ScrollState                                                               // Optional state for scroll-into-view
+	SelectionMode     TableSelectionMode                                                         // Cursor/selection highlight mode (row/column/cursor)
+	MultiSelect       bool                                                                       // Enable multi-select mode (shift+move to extend)
+	ColumnSpacing     int                                                                        // Space between columns
+	Indent            int                                                                        // Cells per depth level (default 2)
+	ExpandIndicator   string                                                                     // Indicator for expanded rows (default "▼ ")
+	CollapseIndicator string                                                                     // Indicator for collapsed rows (default "▶ ")
+	LeafIndicator     string                                                                     // Indicator for leaf rows (default "  ")
+	Style             Style                                                                      // Optional styling
+}
+
+// WidgetID returns the widget's unique identifier.
+// Implements the Identifiable interface.
+func (t TreeTable[T]) WidgetID() string {
+	return t.ID
+}
+
+// GetContentDimensions returns the width and height dimension preferences.
+// Implements the Dimensioned interface.
+func (t TreeTable[T]) GetContentDimensions() (width, height Dimension) {
+	dims := t.Style.GetDimensions()
+	return dims.Width, dims.Height
+}
+
+// GetStyle returns the style of the tree table.
+// Implements the Styled interface.
+func (t TreeTable[T]) GetStyle() Style {
+	return t.Style
+}
+
+// IsFocusable returns true to allow keyboard navigation.
+// Implements the Focusable interface.
+func (t TreeTable[T]) IsFocusable() bool {
+	return !t.DisableFocus
+}
+
+// OnKey handles keys not covered by declarative keybindings.
+// Implements the Focusable interface.
+func (t TreeTable[T]) OnKey(event KeyEvent) bool {
+	return false
+}
+
+// Keybinds returns the table's keybindings plus expand/collapse keys.
+func (t TreeTable[T]) Keybinds() []Keybind {
+	if t.State == nil {
+		return nil
+	}
+	binds := t.table(nil).Keybinds()
+	binds = append(binds,
+		Keybind{Key: "space", Action: t.toggleCursorRow, Hidden: true},
+		Keybind{Key: " ", Action: t.toggleCursorRow, Hidden: true},
+	)
+	if t.SelectionMode != TableSelectionCursor {
+		binds = append(binds,
+			Keybind{Key: "left", Action: t.collapseOrMoveToParent, Hidden: true},
+			Keybind{Key: "h", Action: t.collapseOrMoveToParent, Hidden: true},
+			Keybind{Key: "right", Action: t.expandOrMoveToChild, Hidden: true},
+			Keybind{Key: "l", Action: t.expandOrMoveToChild, Hidden: true},
+		)
+	}
+	return binds
+}
+
+// Build flattens the expanded part of the tree into rows and renders them
+// with Table.
+func (t TreeTable[T]) Build(ctx BuildContext) Widget {
+	if t.State == nil || t.State.Tree == nil || t.State.Table == nil {
+		return Column{}
+	}
+	s := t.State
+	nodes := s.Tree.Nodes.Get()
+	collapsed := s.Tree.Collapsed.Get()
+	s.Tree.nodeID = t.NodeID
+
+	// Rows only change when the tree does; rebuilding them every frame
+	// would set Table.Rows, and so schedule another frame, forever.
+	if !s.built || !sameNodeSlice(nodes, s.builtNodes) || !maps.Equal(collapsed, s.builtCollapsed) {
+		var rows []TreeTableRow[T]
+		t.flatten(nodes, nil, 0, &rows)
+		s.Table.SetRows(rows)
+		s.builtNodes, s.builtCollapsed, s.built = nodes, collapsed, true
+	}
+
+	return t.table(t.renderCell(ctx)).Build(ctx)
+}
+
+// table returns the Table that renders and navigates the rows.
+func (t TreeTable[T]) table(renderCell func(row TreeTableRow[T], rowIndex int, colIndex int, active bool, selected bool) Widget) Table[TreeTableRow[T]] {
+	table := Table[TreeTableRow[T]]{
+		ID:            t.ID,
+		DisableFocus:  t.DisableFocus,
+		State:         t.State.Table,
+		Columns:       t.Columns,
+		RenderCell:    renderCell,
+		ScrollState:   t.ScrollState,
+		SelectionMode: t.SelectionMode,
+		MultiSelect:   t.MultiSelect,
+		ColumnSpacing: t.ColumnSpacing,
+		Style:         t.Style,
+	}
+	if t.OnSelect != nil {
+		table.OnSelect = func(row TreeTableRow[T]) { t.OnSelect(row) }
+	}
+	if t.OnCursorChange != nil {
+		table.OnCursorChange = func(row TreeTableRow[T]) { t.OnCursorChange(row) }
+	}
+	return table
+}
+
+// tree returns a Tree sharing this widget's expansion settings.
+func (t TreeTable[T]) tree() Tree[T] {
+	return Tree[T]{State: t.State.Tree, NodeID: t.NodeID, HasChildren: t.HasChildren, OnExpand: t.OnExpand}
+}
+
+// flatten appends the rows for nodes and their expanded descendants to rows,
+// and returns the nodes' values after aggregation.
+func (t TreeTable[T]) flatten(nodes []TreeNode[T], parent []int, depth int, rows *[]TreeTableRow[T]) []T {
+	tree := t.tree()
+	values := make([]T, len(nodes))
+	for i, node := range nodes {
+		path := appendPath(parent, i)
+		expanded := tree.nodeExpanded(node, path)
+		rowIdx := len(*rows)
+		*rows = append(*rows, TreeTableRow[T]{
+			Data:       node.Data,
+			Path:       path,
+			Depth:      depth,
+			Expandable: tree.nodeExpandable(node),
+			Expanded:   expanded,
+			id:         t.State.Tree.idForNode(path, node),
+		})
+
+		values[i] = node.Data
+		if len(node.Children) == 0 {
+			continue
+		}
+		// Collapsed children still count towards the aggregate; their rows
+		// are dropped afterwards.
+		childRows := rows
+		if !expanded {
+			var hidden []TreeTableRow[T]
+			childRows = &hidden
+		}
+		children := t.flatten(node.Children, path, depth+1, childRows)
+		if t.Aggregate != nil {
+			values[i] = t.Aggregate(node.Data, children)
+			(*rows)[rowIdx].Data = values[i]
+		}
+	}
+	return values
+}
+
+// renderCell renders cells with the user's renderer (or the default) and
+// prefixes column 0 with the indentation and toggle.
+func (t TreeTable[T]) renderCell(ctx BuildContext) func(row TreeTableRow[T], rowIndex int, colIndex int, active bool, selected bool) Widget {
+	theme := ctx.Theme()
+	widgetFocused := ctx.IsFocused(t)
+	indent := t.Indent
+	if indent <= 0 {
+		indent = 2
+	}
+	expandIndicator := t.ExpandIndicator
+	if expandIndicator == "" {
+		expandIndicator = "▼ "
+	}
+	collapseIndicator := t.CollapseIndicator
+	if collapseIndicator == "" {
+		collapseIndicator = "▶ "
+	}
+	leafIndicator := t.LeafIndicator
+	if leafIndicator == "" {
+		leafIndicator = "  "
+	}
+
+	return func(row TreeTableRow[T], rowIndex int, colIndex int, active bool, selected bool) Widget {
+		style := tableDefaultCellStyle(theme, active, selected, widgetFocused)
+		var cell Widget
+		if t.RenderCell != nil {
+			cell = t.RenderCell(row, colIndex, active, selected)
+		} else {
+			content, ok := tableDefaultCellContent(row.Data, colIndex)
+			if !ok && colIndex == 0 {
+				content = fmt.Sprintf("%v", row.Data)
+			}
+			cell = Text{Content: content, Style: style}
+		}
+		if colIndex != 0 {
+			return cell
+		}
+
+		indicator := leafIndicator
+		if row.Expandable {
+			indicator = collapseIndicator
+			if row.Expanded {
+				indicator = expandIndicator
+			}
+		}
+		path := row.Path
+		return Row{Children: []Widget{
+			Text{
+				Content: strings.Repeat(" ", indent*row.Depth) + indicator,
+				Style:   style,
+				Click:   func(MouseEvent) { t.togglePath(path) },
+			},
+			cell,
+		}}
+	}
+}
+
+func (t TreeTable[T]) togglePath(path []int) {
+	tree := t.tree()
+	node, ok := t.State.Tree.NodeAtPath(path)
+	if !ok || !tree.nodeExpandable(node) {
+		return
+	}
+	if tree.nodeExpanded(node, path) {
+		t.State.Tree.Collapse(path)
+	} else {
+		tree.expandNode(node, path)
+	}
+}
+
+func (t TreeTable[T]) toggleCursorRow() {
+	if row, ok := t.State.CursorRow(); ok {
+		t.togglePath(row.Path)
+	}
+}
+
+func (t TreeTable[T]) collapseOrMoveToParent() {
+	row, ok := t.State.CursorRow()
+	if !ok {
+		return
+	}
+	if row.Expanded {
+		t.State.Tree.Collapse(row.Path)
+		return
+	}
+	if len(row.Path) > 1 {
+		t.moveCursorToPath(row.Path[:len(row.Path)-1])
+	}
+}
+
+func (t TreeTable[T]) expandOrMoveToChild() {
+	row, ok := t.State.CursorRow()
+	if !ok || !row.Expandable {
+		return
+	}
+	if !row.Expanded {
+		t.togglePath(row.Path)
+		return
+	}
+	t.moveCursorToPath(appendPath(row.Path, 0))
+}
+
+func (t TreeTable[T]) moveCursorToPath(path []int) {
+	for idx, row := range t.State.Table.GetRows() {
+		if pathsEqual(row.Path, path) {
+			table := t.table(nil)
+			t.State.Table.SelectIndex(idx)
+			table.scrollCursorIntoView()
+			table.notifyCursorChange()
+			return
+		}
+	}
+}
+
+// sameNodeSlice reports whether a and b are the same slice. TreeState
+// replaces the root slice whenever a node changes.
+func sameNodeSlice[T any](a, b []TreeNode[T]) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return len(a) == 0 || &a[0] == &b[0]
+}
@@ -0,0 +1,91 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sizeEntry struct {
+	Name string
+	Size int
+}
+
+func sizeTreeTable() TreeTable[sizeEntry] {
+	state := NewTreeTableState([]TreeNode[sizeEntry]{
+		{Data: sizeEntry{Name: "src"}, Children: []TreeNode[sizeEntry]{
+			{Data: sizeEntry{Name: "main.go", Size: 300}, Children: []TreeNode[sizeEntry]{}},
+			{Data: sizeEntry{Name: "util.go", Size: 200}, Children: []TreeNode[sizeEntry]{}},
+		}},
+		{Data: sizeEntry{Name: "vendor"}},
+		{Data: sizeEntry{Name: "go.mod", Size: 50}, Children: []TreeNode[sizeEntry]{}},
+	})
+	return TreeTable[sizeEntry]{
+		State:         state,
+		Columns:       []TableColumn{{Width: Cells(14)}, {}},
+		SelectionMode: TableSelectionRow,
+		HasChildren:   func(entry sizeEntry) bool { return entry.Name == "vendor" },
+		OnExpand: func(_ sizeEntry, _ []int, setChildren func([]TreeNode[sizeEntry])) {
+			setChildren([]TreeNode[sizeEntry]{{Data: sizeEntry{Name: "lib.go", Size: 1000}, Children: []TreeNode[sizeEntry]{}}})
+		},
+		Aggregate: func(dir sizeEntry, children []sizeEntry) sizeEntry {
+			for _, child := range children {
+				dir.Size += child.Size
+			}
+			return dir
+		},
+		RenderCell: func(row TreeTableRow[sizeEntry], col int, _, _ bool) Widget {
+			if col == 0 {
+				return Text{Content: row.Data.Name}
+			}
+			return Text{Content: FormatBytes(int64(row.Data.Size))}
+		},
+	}
+}
+
+func treeTableNames(state *TreeTableState[sizeEntry]) []string {
+	var names []string
+	for _, row := range state.Table.GetRows() {
+		names = append(names, row.Data.Name)
+	}
+	return names
+}
+
+func TestTreeTable_RendersIndentedRowsWithAggregates(t *testing.T) {
+	table := sizeTreeTable()
+	lines := strings.Split(screenText(table, 30, 6), "\n")
+	assert.Regexp(t, `^▼ src +500 B`, lines[0])
+	assert.Regexp(t, `^    main\.go +300 B`, lines[1])
+	assert.Regexp(t, `^▶ vendor +0 B`, lines[3])
+	assert.Regexp(t, `^  go\.mod +50 B`, lines[4])
+}
+
+func TestTreeTable_ExpandLoadsChildrenAndCursorFollowsNode(t *testing.T) {
+	table := sizeTreeTable()
+	state := table.State
+	table.Build(newTestBuildContext())
+
+	state.Table.SelectIndex(3)
+	table.expandOrMoveToChild()
+	table.Build(newTestBuildContext())
+	assert.Equal(t, []string{"src", "main.go", "util.go", "vendor", "lib.go", "go.mod"}, treeTableNames(state))
+	row, ok := state.CursorRow()
+	require.True(t, ok)
+	assert.Equal(t, sizeEntry{Name: "vendor", Size: 1000}, row.Data, "the parent row aggregates lazily loaded children")
+
+	state.Tree.Collapse([]int{0})
+	table.Build(newTestBuildContext())
+	assert.Equal(t, []string{"src", "vendor", "lib.go", "go.mod"}, treeTableNames(state))
+	row, _ = state.CursorRow()
+	assert.Equal(t, "vendor", row.Data.Name, "the cursor stays on its node as rows above collapse")
+	assert.Equal(t, 500, state.Table.GetRows()[0].Data.Size, "collapsed children still count")
+
+	table.expandOrMoveToChild()
+	row, _ = state.CursorRow()
+	assert.Equal(t, "lib.go", row.Data.Name)
+	table.collapseOrMoveToParent()
+	row, _ = state.CursorRow()
+	assert.Equal(t, "vendor", row.Data.Name)
+}
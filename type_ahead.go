@@ -0,0 +1,208 @@
+package terma
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultTypeAheadTimeout is how long typed text is kept between keystrokes.
+const defaultTypeAheadTimeout = time.Second
+
+// TypeAhead enables type-ahead navigation in a List, Table or Tree: typing
+// while the widget is focused jumps the cursor to the next item whose label
+// starts with the typed text. Typing the same letter repeatedly cycles
+// through the items starting with it. The typed text is shown in a small
+// overlay at the widget's top-right corner and resets after a pause.
+//
+// With type-ahead on, letter and digit keys are no longer bound to the
+// vi-style navigation keys (j/k, g/G, h/l); arrows, Home/End and the page
+// keys still work. Backspace removes the last typed character and Escape
+// clears the typed text.
+//
+// Example:
+//
+//	List[File]{
+//	    State:     files,
+//	    TypeAhead: &TypeAhead[File]{Label: func(f File) string { return f.Name }},
+//	}
+type TypeAhead[T any] struct {
+	Label       func(item T) string // Text matched against the typed text (default: fmt's %v)
+	Fuzzy       bool                // Fall back to a fuzzy match when no label starts with the typed text
+	Timeout     time.Duration       // Pause after which the typed text resets (default 1s)
+	HideOverlay bool                // Don't show the typed text
+}
+
+func (t *TypeAhead[T]) label(item T) string {
+	if t.Label != nil {
+		return t.Label(item)
+	}
+	return fmt.Sprintf("%v", item)
+}
+
+func (t *TypeAhead[T]) timeout() time.Duration {
+	if t.Timeout > 0 {
+		return t.Timeout
+	}
+	return defaultTypeAheadTimeout
+}
+
+// typeAheadBuffer holds the text typed so far. It lives in the widget's
+// state so it survives rebuilds.
+type typeAheadBuffer struct {
+	query    Signal[string] // Typed text, cleared when the timeout elapses
+	lastType time.Time
+	timer    Timer
+}
+
+func newTypeAheadBuffer() *typeAheadBuffer {
+	return &typeAheadBuffer{query: NewSignal("")}
+}
+
+// handleKey updates the typed text for a key event and calls jump with the
+// new text. Returns false for keys type-ahead doesn't use.
+func (b *typeAheadBuffer) handleKey(event KeyEvent, timeout time.Duration, jump func(query string)) bool {
+	now := Now()
+	query := b.query.Peek()
+	if query != "" && now.Sub(b.lastType) > timeout {
+		query = ""
+	}
+
+	switch {
+	case event.MatchString("backspace"):
+		if query == "" {
+			return false
+		}
+		_, size := utf8.DecodeLastRuneInString(query)
+		query = query[:len(query)-size]
+	case event.MatchString("escape"):
+		if query == "" {
+			return false
+		}
+		b.clear()
+		return true
+	default:
+		text := event.Text()
+		r, size := utf8.DecodeRuneInString(text)
+		if size == 0 || size != len(text) || !unicode.IsPrint(r) {
+			return false
+		}
+		if r == ' ' && query == "" {
+			return false
+		}
+		query += text
+	}
+
+	b.lastType = now
+	b.query.Set(query)
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	b.timer = currentClock().AfterFunc(timeout, func() {
+		b.query.Set("")
+	})
+	if query != "" {
+		jump(query)
+	}
+	return true
+}
+
+func (b *typeAheadBuffer) clear() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.query.Set("")
+}
+
+// overlay registers the typed text as a portal at the top-right corner of
+// the widget with the given ID, while there is typed text.
+func (b *typeAheadBuffer) overlay(ctx BuildContext, anchorID string) {
+	query := b.query.Get()
+	if query == "" {
+		return
+	}
+	theme := ctx.Theme()
+	Portal{
+		AnchorID:      anchorID,
+		Anchor:        AnchorTopRight,
+		Offset:        Offset{Y: 1},
+		ClampToScreen: true,
+		IgnorePointer: true,
+		Child: Text{
+			Content: query,
+			Style: Style{
+				BackgroundColor: theme.Accent,
+				ForegroundColor: theme.TextOnAccent,
+				Padding:         EdgeInsetsXY(1, 0),
+			},
+		},
+	}.Build(ctx)
+}
+
+// typeAheadMatch returns the view index of the item to move the cursor to
+// for the typed query, searching from the cursor and wrapping around.
+// A longer query keeps the cursor on the current item if it still matches;
+// a single character, or the same character typed repeatedly, moves on to
+// the next matching item.
+func typeAheadMatch(count, cursor int, label func(viewIdx int) string, query string, fuzzy bool) (int, bool) {
+	if count == 0 || query == "" {
+		return 0, false
+	}
+	cursor = clampInt(cursor, 0, count-1)
+
+	start := cursor
+	if utf8.RuneCountInString(query) == 1 {
+		start = cursor + 1
+	}
+	hasPrefix := func(query string) func(string) bool {
+		lower := strings.ToLower(query)
+		return func(label string) bool {
+			return strings.HasPrefix(strings.ToLower(label), lower)
+		}
+	}
+
+	if idx, ok := typeAheadFind(count, start, label, hasPrefix(query)); ok {
+		return idx, true
+	}
+	// "aaa" with no label starting with it cycles through labels starting with "a"
+	if first, _ := utf8.DecodeRuneInString(query); strings.Trim(query, string(first)) == "" {
+		if idx, ok := typeAheadFind(count, cursor+1, label, hasPrefix(string(first))); ok {
+			return idx, true
+		}
+	}
+	if fuzzy {
+		options := FilterOptions{Mode: FilterFuzzy}
+		return typeAheadFind(count, start, label, func(label string) bool {
+			return MatchString(label, query, options).Matched
+		})
+	}
+	return 0, false
+}
+
+func typeAheadFind(count, start int, label func(viewIdx int) string, match func(string) bool) (int, bool) {
+	for i := 0; i < count; i++ {
+		idx := (start + i) % count
+		if match(label(idx)) {
+			return idx, true
+		}
+	}
+	return 0, false
+}
+
+// withoutTypeAheadKeys drops bindings for letter and digit keys (including
+// shifted letters), which type-ahead takes over.
+func withoutTypeAheadKeys(binds []Keybind) []Keybind {
+	kept := binds[:0:0]
+	for _, bind := range binds {
+		key := strings.TrimPrefix(bind.Key, "shift+")
+		r, size := utf8.DecodeRuneInString(key)
+		if size == len(key) && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			continue
+		}
+		kept = append(kept, bind)
+	}
+	return kept
+}
@@ -0,0 +1,147 @@
+package terma
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeAheadMatch(t *testing.T) {
+	labels := []string{"Apple", "banana", "Blueberry", "cherry", "bagel"}
+	label := func(i int) string { return labels[i] }
+
+	idx, ok := typeAheadMatch(len(labels), 0, label, "b", false)
+	assert.True(t, ok)
+	assert.Equal(t, 1, idx, "single character moves to the next match")
+
+	idx, ok = typeAheadMatch(len(labels), 1, label, "bl", false)
+	assert.True(t, ok)
+	assert.Equal(t, 2, idx, "matching is case-insensitive")
+
+	idx, ok = typeAheadMatch(len(labels), 1, label, "ba", false)
+	assert.True(t, ok)
+	assert.Equal(t, 1, idx, "a longer query keeps a cursor that still matches")
+
+	idx, ok = typeAheadMatch(len(labels), 4, label, "bb", false)
+	assert.True(t, ok)
+	assert.Equal(t, 1, idx, "a repeated character cycles and wraps around")
+
+	_, ok = typeAheadMatch(len(labels), 0, label, "cy", false)
+	assert.False(t, ok)
+
+	idx, ok = typeAheadMatch(len(labels), 0, label, "cy", true)
+	assert.True(t, ok)
+	assert.Equal(t, 3, idx, "fuzzy falls back to a subsequence match")
+}
+
+func TestList_TypeAheadMovesCursor(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	SetClock(clock)
+	t.Cleanup(func() { SetClock(nil) })
+
+	state := NewListState([]string{"apple", "banana", "blueberry", "cherry"})
+	list := List[string]{State: state, TypeAhead: &TypeAhead[string]{}}
+
+	assert.True(t, list.OnKey(makeCharEvent('b')))
+	assert.Equal(t, 1, state.CursorIndex.Peek())
+	assert.True(t, list.OnKey(makeCharEvent('l')))
+	assert.Equal(t, 2, state.CursorIndex.Peek())
+	assert.Equal(t, "bl", state.typeAheadBuffer().query.Peek())
+
+	assert.True(t, list.OnKey(makeKeyEvent(uv.KeyBackspace, 0)))
+	assert.Equal(t, "b", state.typeAheadBuffer().query.Peek())
+	assert.True(t, list.OnKey(makeKeyEvent(uv.KeyEscape, 0)))
+	assert.Equal(t, "", state.typeAheadBuffer().query.Peek())
+	assert.False(t, list.OnKey(makeKeyEvent(uv.KeyEscape, 0)), "escape is passed on with nothing typed")
+
+	assert.True(t, list.OnKey(makeCharEvent('c')))
+	assert.Equal(t, 3, state.CursorIndex.Peek())
+	clock.Advance(time.Second)
+	assert.Equal(t, "", state.typeAheadBuffer().query.Peek(), "typed text resets after the timeout")
+
+	assert.True(t, list.OnKey(makeCharEvent('a')))
+	assert.Equal(t, 0, state.CursorIndex.Peek())
+}
+
+func TestList_TypeAheadUsesLabel(t *testing.T) {
+	type file struct{ name string }
+	state := NewListState([]file{{"main.go"}, {"go.mod"}, {"README.md"}})
+	list := List[file]{
+		State:     state,
+		TypeAhead: &TypeAhead[file]{Label: func(f file) string { return f.name }},
+	}
+
+	list.OnKey(makeCharEvent('r'))
+	assert.Equal(t, 2, state.CursorIndex.Peek())
+}
+
+func TestTypeAhead_ReplacesLetterKeybinds(t *testing.T) {
+	state := NewListState([]string{"a", "b"})
+	hasKey := func(binds []Keybind, key string) bool {
+		for _, bind := range binds {
+			if bind.Key == key {
+				return true
+			}
+		}
+		return false
+	}
+
+	binds := List[string]{State: state}.Keybinds()
+	assert.True(t, hasKey(binds, "j"))
+
+	binds = List[string]{State: state, MultiSelect: true, TypeAhead: &TypeAhead[string]{}}.Keybinds()
+	assert.False(t, hasKey(binds, "j"))
+	assert.False(t, hasKey(binds, "G"))
+	assert.False(t, hasKey(binds, "shift+k"))
+	assert.True(t, hasKey(binds, "down"))
+	assert.True(t, hasKey(binds, "ctrl+a"))
+	assert.True(t, hasKey(binds, "*"))
+}
+
+func TestTree_TypeAheadSearchesVisibleNodes(t *testing.T) {
+	state := NewTreeState([]TreeNode[string]{
+		{Data: "src", Children: []TreeNode[string]{{Data: "main.go"}, {Data: "tree.go"}}},
+		{Data: "test"},
+	})
+	tree := Tree[string]{State: state, TypeAhead: &TypeAhead[string]{}}
+
+	tree.OnKey(makeCharEvent('t'))
+	assert.Equal(t, []int{0, 1}, state.CursorPath.Peek())
+
+	state.Collapse([]int{0})
+	tree.OnKey(makeCharEvent('t'))
+	assert.Equal(t, []int{1}, state.CursorPath.Peek())
+}
+
+func TestTable_TypeAheadMovesRowCursor(t *testing.T) {
+	state := NewTableState([][]string{{"Ada", "1815"}, {"Grace", "1906"}, {"Linus", "1969"}})
+	table := Table[[]string]{
+		State:     state,
+		Columns:   []TableColumn{{}, {}},
+		TypeAhead: &TypeAhead[[]string]{Label: func(row []string) string { return row[0] }},
+	}
+
+	assert.True(t, table.OnKey(makeCharEvent('l')))
+	assert.Equal(t, 2, state.CursorIndex.Peek())
+}
+
+func TestList_TypeAheadOverlayShowsTypedText(t *testing.T) {
+	state := NewListState([]string{"apple", "banana", "cherry"})
+	list := List[string]{State: state, TypeAhead: &TypeAhead[string]{}, Style: Style{Width: Cells(12)}}
+	list.OnKey(makeCharEvent('b'))
+	list.OnKey(makeCharEvent('a'))
+
+	renderer, buf := portalTestRenderer(20, 5)
+	renderer.Render(Column{Children: []Widget{list}})
+
+	assert.Equal(t, "banana", bufferLine(buf, 1, 6))
+	assert.Equal(t, " ba ", bufferLine(buf, 0, 12)[8:])
+
+	state.typeAheadBuffer().clear()
+	renderer.Render(Column{Children: []Widget{list}})
+	assert.Equal(t, "apple", bufferLine(buf, 0, 5))
+	assert.Equal(t, "", strings.TrimSpace(bufferLine(buf, 0, 12)[5:]))
+}